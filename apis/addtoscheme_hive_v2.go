@@ -0,0 +1,10 @@
+package apis
+
+import (
+	hivev2 "github.com/openshift/hive/apis/hive/v2"
+)
+
+func init() {
+	// Register the types with the Scheme so the components can map objects to GroupVersionKinds and back
+	AddToSchemes = append(AddToSchemes, hivev2.SchemeBuilder.AddToScheme)
+}
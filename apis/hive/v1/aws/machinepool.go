@@ -4,6 +4,8 @@ package aws
 // installed on AWS.
 type MachinePoolPlatform struct {
 	// Zones is list of availability zones that can be used.
+	// Local Zones may be used by listing them here together with subnets of the Local Zones in Subnets or
+	// ZoneSubnets. Local Zones are never used by default.
 	Zones []string `json:"zones,omitempty"`
 
 	// Subnets is the list of subnets to which to attach the machines.
@@ -11,6 +13,13 @@ type MachinePoolPlatform struct {
 	// If public subnets are specified, there must be exactly one private and one public subnet specified for each availability zone.
 	Subnets []string `json:"subnets,omitempty"`
 
+	// ZoneSubnets explicitly maps availability zones to the IDs of the subnets in which the machines for each zone
+	// are created, rather than looking up the subnet of each zone from Subnets or from the subnets created by the
+	// installer. When Zones is empty, a MachineSet is created for each zone in the mapping. Cannot be used with
+	// Subnets.
+	// +optional
+	ZoneSubnets []ZoneSubnet `json:"zoneSubnets,omitempty"`
+
 	// InstanceType defines the ec2 instance type.
 	// eg. m4-large
 	InstanceType string `json:"type"`
@@ -18,9 +27,39 @@ type MachinePoolPlatform struct {
 	// EC2RootVolume defines the storage for ec2 instance.
 	EC2RootVolume `json:"rootVolume"`
 
+	// OutpostARN is the ARN of the AWS Outpost on which the machines are created. The subnets of the pool must be
+	// set in Subnets or ZoneSubnets and must all be subnets of the Outpost. Outposts only support gp2 root volumes
+	// and do not support spot instances.
+	// +optional
+	OutpostARN string `json:"outpostARN,omitempty"`
+
 	// SpotMarketOptions allows users to configure instances to be run using AWS Spot instances.
 	// +optional
 	SpotMarketOptions *SpotMarketOptions `json:"spotMarketOptions,omitempty"`
+
+	// GPU declares the GPUs of the instance type of the pool, which must be a GPU instance type such as g4dn.xlarge.
+	// AWS attaches the GPUs of the instance type, so GPU marks the pool as a GPU pool.
+	// +optional
+	GPU *GPU `json:"gpu,omitempty"`
+}
+
+// ZoneSubnet maps an availability zone to a subnet.
+type ZoneSubnet struct {
+	// Zone is the availability zone.
+	Zone string `json:"zone"`
+	// Subnet is the ID of the subnet in the availability zone.
+	Subnet string `json:"subnet"`
+}
+
+// GPU describes the GPUs attached to each instance.
+type GPU struct {
+	// Type is the model of the GPUs, such as nvidia-tesla-t4.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Count is the number of GPUs attached to each instance.
+	// +kubebuilder:validation:Minimum=1
+	Count int32 `json:"count"`
 }
 
 // SpotMarketOptions defines the options available to a user when configuring
@@ -17,6 +17,11 @@ type Platform struct {
 	// +optional
 	CredentialsAssumeRole *AssumeRole `json:"credentialsAssumeRole,omitempty"`
 
+	// CredentialsSource mints short-lived AWS account access credentials for the cluster operations with the
+	// credentials broker of Hive, instead of reading long-lived credentials from a secret.
+	// +optional
+	CredentialsSource *CredentialsSource `json:"credentialsSource,omitempty"`
+
 	// Region specifies the AWS region where the cluster will be created.
 	Region string `json:"region"`
 
@@ -29,16 +34,172 @@ type Platform struct {
 	// Endpoint accross AWS accounts and allows clients to connect to services using AWS's
 	// internal networking instead of the Internet.
 	PrivateLink *PrivateLinkAccess `json:"privateLink,omitempty"`
+
+	// SharedVPC configures the installation of the cluster into subnets shared with the cluster account from
+	// another AWS account using AWS Resource Access Manager.
+	// +optional
+	SharedVPC *SharedVPC `json:"sharedVPC,omitempty"`
+
+	// ServiceEndpoints overrides the endpoints of AWS services used by Hive for the cluster, such as the endpoints
+	// of a C2S or SC2S region that are not known to the AWS SDK. The endpoints of services not listed are resolved
+	// in the partition of the region, such as aws-us-gov for GovCloud.
+	// +optional
+	ServiceEndpoints []ServiceEndpoint `json:"serviceEndpoints,omitempty"`
+}
+
+// ServiceEndpoint overrides the endpoint of an AWS service.
+type ServiceEndpoint struct {
+	// Name is the endpoints ID of the AWS service, such as ec2, route53, sts or tagging.
+	Name string `json:"name"`
+
+	// URL is the URL of the endpoint of the service, such as https://ec2.us-iso-east-1.c2s.ic.gov.
+	URL string `json:"url"`
+}
+
+// SharedVPC configures the installation of a cluster into subnets of a VPC owned by another AWS account, the network
+// account. As only the owner of a subnet can tag it, and a private hosted zone can only be associated with a VPC of
+// another account with the consent of both accounts, Hive uses credentials for the network account to tag the shared
+// subnets for the cluster and to associate the private hosted zone of the cluster with the shared VPC.
+type SharedVPC struct {
+	// Subnets are the IDs of the shared subnets into which the cluster is installed. They must be the subnets set
+	// in the install config.
+	Subnets []string `json:"subnets"`
+
+	// CredentialsSecretRef refers to a secret that contains the access credentials of the network account.
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+
+	// CredentialsAssumeRole refers to the IAM role of the network account that must be assumed to obtain access
+	// to the network account.
+	// +optional
+	CredentialsAssumeRole *AssumeRole `json:"credentialsAssumeRole,omitempty"`
 }
 
 // PlatformStatus contains the observed state on AWS platform.
 type PlatformStatus struct {
 	PrivateLink *PrivateLinkAccessStatus `json:"privateLink,omitempty"`
+
+	// DNSRouting contains the observed state of the Route53 records with a routing policy of the cluster.
+	// +optional
+	DNSRouting *DNSRoutingStatus `json:"dnsRouting,omitempty"`
+}
+
+// DNSRoutingPolicy is the Route53 routing policy of the records of a cluster.
+// +kubebuilder:validation:Enum=Weighted;Latency;Failover
+type DNSRoutingPolicy string
+
+const (
+	// WeightedDNSRoutingPolicy routes traffic to the clusters in proportion to their weights.
+	WeightedDNSRoutingPolicy DNSRoutingPolicy = "Weighted"
+	// LatencyDNSRoutingPolicy routes traffic to the cluster in the region with the lowest latency.
+	LatencyDNSRoutingPolicy DNSRoutingPolicy = "Latency"
+	// FailoverDNSRoutingPolicy routes traffic to the primary cluster while it is healthy, and to the secondary
+	// cluster otherwise.
+	FailoverDNSRoutingPolicy DNSRoutingPolicy = "Failover"
+)
+
+// DNSFailoverRole is the role of the records of a cluster with the Failover routing policy.
+// +kubebuilder:validation:Enum=Primary;Secondary
+type DNSFailoverRole string
+
+const (
+	// PrimaryDNSFailoverRole is the role of the records of the active cluster.
+	PrimaryDNSFailoverRole DNSFailoverRole = "Primary"
+	// SecondaryDNSFailoverRole is the role of the records of the passive cluster.
+	SecondaryDNSFailoverRole DNSFailoverRole = "Secondary"
+)
+
+// DNSRouting configures Route53 records with a routing policy for the API and ingress of a cluster, in a hosted zone
+// shared with the other clusters serving the same endpoints. The records are api.<domain> and *.apps.<domain>, and
+// are maintained with the credentials of the managed DNS zone of the cluster. The infra ID of the cluster is the set
+// identifier that distinguishes the records of the cluster from the records of the other clusters.
+type DNSRouting struct {
+	// HostedZoneID is the ID of the shared hosted zone. It must be listed in the routingHostedZoneIDs of the AWS
+	// configuration of the managed domain of the cluster in HiveConfig.
+	HostedZoneID string `json:"hostedZoneID"`
+
+	// Domain is the domain of the shared endpoints, in the shared hosted zone.
+	Domain string `json:"domain"`
+
+	// Policy is the routing policy of the records. The Latency policy routes by the region of the cluster.
+	Policy DNSRoutingPolicy `json:"policy"`
+
+	// Weight is the weight of the records of the cluster, from 0 to 255, with the Weighted policy.
+	// +optional
+	Weight *int64 `json:"weight,omitempty"`
+
+	// Failover is the role of the records of the cluster with the Failover policy.
+	// +optional
+	Failover DNSFailoverRole `json:"failover,omitempty"`
+
+	// HealthCheck configures a Route53 health check of the API of the cluster. Route53 stops routing traffic to
+	// the records of the cluster while the health check fails.
+	// +optional
+	HealthCheck *DNSHealthCheck `json:"healthCheck,omitempty"`
+}
+
+// DNSHealthCheckType is the protocol of a Route53 health check.
+// +kubebuilder:validation:Enum=HTTPS;HTTP;TCP
+type DNSHealthCheckType string
+
+const (
+	HTTPSDNSHealthCheckType DNSHealthCheckType = "HTTPS"
+	HTTPDNSHealthCheckType  DNSHealthCheckType = "HTTP"
+	TCPDNSHealthCheckType   DNSHealthCheckType = "TCP"
+)
+
+// DNSHealthCheck configures a Route53 health check of the API load balancer of a cluster.
+type DNSHealthCheck struct {
+	// Type is the protocol of the health check. Defaults to HTTPS.
+	// +optional
+	Type DNSHealthCheckType `json:"type,omitempty"`
+
+	// Port is the port of the health check. Defaults to 6443.
+	// +optional
+	Port *int64 `json:"port,omitempty"`
+
+	// Path is the path requested by HTTP and HTTPS health checks. Defaults to /readyz.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// FailureThreshold is the number of consecutive failed checks after which the API is considered unhealthy,
+	// from 1 to 10. Defaults to 3.
+	// +optional
+	FailureThreshold *int64 `json:"failureThreshold,omitempty"`
+}
+
+// DNSRoutingStatus contains the observed state of the Route53 records with a routing policy of a cluster, so that
+// they can be removed when the configuration changes or the cluster is deleted.
+type DNSRoutingStatus struct {
+	// HostedZoneID is the ID of the hosted zone of the records.
+	HostedZoneID string `json:"hostedZoneID"`
+
+	// RecordNames are the names of the records.
+	RecordNames []string `json:"recordNames,omitempty"`
+
+	// SetIdentifier is the set identifier of the records.
+	SetIdentifier string `json:"setIdentifier"`
+
+	// HealthCheckID is the ID of the health check of the API of the cluster.
+	// +optional
+	HealthCheckID string `json:"healthCheckID,omitempty"`
 }
 
 // PrivateLinkAccess configures access to the cluster API using AWS PrivateLink
 type PrivateLinkAccess struct {
 	Enabled bool `json:"enabled"`
+
+	// AdditionalAllowedPrincipals is a list of IAM principal ARNs that are allowed to create
+	// VPC Endpoints for the cluster's VPC Endpoint Service, in addition to the identity used by
+	// the hub.
+	// +optional
+	AdditionalAllowedPrincipals []string `json:"additionalAllowedPrincipals,omitempty"`
+
+	// VPCEndpointID is the ID of a pre-created VPC Endpoint in the hub account that is connected
+	// to the cluster's VPC Endpoint Service. When set, the controller uses this VPC Endpoint instead
+	// of creating one from the endpoint VPC inventory, and leaves it in place during cleanup.
+	// +optional
+	VPCEndpointID string `json:"vpcEndpointID,omitempty"`
 }
 
 // PrivateLinkAccessStatus contains the observed state for PrivateLinkAccess resources.
@@ -67,3 +228,34 @@ type AssumeRole struct {
 	// +optional
 	ExternalID string `json:"externalID,omitempty"`
 }
+
+// CredentialsSource is a source of short-lived AWS credentials minted by the credentials broker of Hive. Exactly one
+// of WebIdentity and Vault must be set.
+type CredentialsSource struct {
+	// WebIdentity assumes an IAM role with AWS STS AssumeRoleWithWebIdentity, using the service account token of
+	// the Hive controllers.
+	// +optional
+	WebIdentity *WebIdentityCredentialsSource `json:"webIdentity,omitempty"`
+
+	// Vault reads credentials from a role of the AWS secrets engine of the Vault server configured in the
+	// CredentialsBroker of HiveConfig.
+	// +optional
+	Vault *VaultCredentialsSource `json:"vault,omitempty"`
+}
+
+// WebIdentityCredentialsSource is an IAM role assumed with the service account token of Hive.
+type WebIdentityCredentialsSource struct {
+	// RoleARN is the ARN of the IAM role to assume. The trust policy of the role must allow the IAM OIDC provider
+	// of the cluster running Hive for the service account of the Hive controllers.
+	RoleARN string `json:"roleARN"`
+}
+
+// VaultCredentialsSource is a role of the AWS secrets engine of Vault.
+type VaultCredentialsSource struct {
+	// MountPath is the path where the AWS secrets engine is mounted. Defaults to "aws".
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+
+	// Role is the role of the AWS secrets engine to read credentials for.
+	Role string `json:"role"`
+}
@@ -4,6 +4,10 @@
 
 package aws
 
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AssumeRole) DeepCopyInto(out *AssumeRole) {
 	*out = *in
@@ -20,6 +24,105 @@ func (in *AssumeRole) DeepCopy() *AssumeRole {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsSource) DeepCopyInto(out *CredentialsSource) {
+	*out = *in
+	if in.WebIdentity != nil {
+		in, out := &in.WebIdentity, &out.WebIdentity
+		*out = new(WebIdentityCredentialsSource)
+		**out = **in
+	}
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultCredentialsSource)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsSource.
+func (in *CredentialsSource) DeepCopy() *CredentialsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSHealthCheck) DeepCopyInto(out *DNSHealthCheck) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHealthCheck.
+func (in *DNSHealthCheck) DeepCopy() *DNSHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSRouting) DeepCopyInto(out *DNSRouting) {
+	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(DNSHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRouting.
+func (in *DNSRouting) DeepCopy() *DNSRouting {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRouting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSRoutingStatus) DeepCopyInto(out *DNSRoutingStatus) {
+	*out = *in
+	if in.RecordNames != nil {
+		in, out := &in.RecordNames, &out.RecordNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRoutingStatus.
+func (in *DNSRoutingStatus) DeepCopy() *DNSRoutingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRoutingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EC2RootVolume) DeepCopyInto(out *EC2RootVolume) {
 	*out = *in
@@ -36,6 +139,22 @@ func (in *EC2RootVolume) DeepCopy() *EC2RootVolume {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPU) DeepCopyInto(out *GPU) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPU.
+func (in *GPU) DeepCopy() *GPU {
+	if in == nil {
+		return nil
+	}
+	out := new(GPU)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachinePoolPlatform) DeepCopyInto(out *MachinePoolPlatform) {
 	*out = *in
@@ -49,12 +168,22 @@ func (in *MachinePoolPlatform) DeepCopyInto(out *MachinePoolPlatform) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ZoneSubnets != nil {
+		in, out := &in.ZoneSubnets, &out.ZoneSubnets
+		*out = make([]ZoneSubnet, len(*in))
+		copy(*out, *in)
+	}
 	out.EC2RootVolume = in.EC2RootVolume
 	if in.SpotMarketOptions != nil {
 		in, out := &in.SpotMarketOptions, &out.SpotMarketOptions
 		*out = new(SpotMarketOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GPU != nil {
+		in, out := &in.GPU, &out.GPU
+		*out = new(GPU)
+		**out = **in
+	}
 	return
 }
 
@@ -77,6 +206,11 @@ func (in *Platform) DeepCopyInto(out *Platform) {
 		*out = new(AssumeRole)
 		**out = **in
 	}
+	if in.CredentialsSource != nil {
+		in, out := &in.CredentialsSource, &out.CredentialsSource
+		*out = new(CredentialsSource)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.UserTags != nil {
 		in, out := &in.UserTags, &out.UserTags
 		*out = make(map[string]string, len(*in))
@@ -87,7 +221,17 @@ func (in *Platform) DeepCopyInto(out *Platform) {
 	if in.PrivateLink != nil {
 		in, out := &in.PrivateLink, &out.PrivateLink
 		*out = new(PrivateLinkAccess)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SharedVPC != nil {
+		in, out := &in.SharedVPC, &out.SharedVPC
+		*out = new(SharedVPC)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceEndpoints != nil {
+		in, out := &in.ServiceEndpoints, &out.ServiceEndpoints
+		*out = make([]ServiceEndpoint, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
@@ -110,6 +254,11 @@ func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
 		*out = new(PrivateLinkAccessStatus)
 		**out = **in
 	}
+	if in.DNSRouting != nil {
+		in, out := &in.DNSRouting, &out.DNSRouting
+		*out = new(DNSRoutingStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -126,6 +275,11 @@ func (in *PlatformStatus) DeepCopy() *PlatformStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateLinkAccess) DeepCopyInto(out *PrivateLinkAccess) {
 	*out = *in
+	if in.AdditionalAllowedPrincipals != nil {
+		in, out := &in.AdditionalAllowedPrincipals, &out.AdditionalAllowedPrincipals
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -156,6 +310,53 @@ func (in *PrivateLinkAccessStatus) DeepCopy() *PrivateLinkAccessStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceEndpoint) DeepCopyInto(out *ServiceEndpoint) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceEndpoint.
+func (in *ServiceEndpoint) DeepCopy() *ServiceEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SharedVPC) DeepCopyInto(out *SharedVPC) {
+	*out = *in
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.CredentialsAssumeRole != nil {
+		in, out := &in.CredentialsAssumeRole, &out.CredentialsAssumeRole
+		*out = new(AssumeRole)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedVPC.
+func (in *SharedVPC) DeepCopy() *SharedVPC {
+	if in == nil {
+		return nil
+	}
+	out := new(SharedVPC)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpotMarketOptions) DeepCopyInto(out *SpotMarketOptions) {
 	*out = *in
@@ -192,3 +393,51 @@ func (in *VPCEndpointService) DeepCopy() *VPCEndpointService {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultCredentialsSource) DeepCopyInto(out *VaultCredentialsSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultCredentialsSource.
+func (in *VaultCredentialsSource) DeepCopy() *VaultCredentialsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultCredentialsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebIdentityCredentialsSource) DeepCopyInto(out *WebIdentityCredentialsSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebIdentityCredentialsSource.
+func (in *WebIdentityCredentialsSource) DeepCopy() *WebIdentityCredentialsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(WebIdentityCredentialsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneSubnet) DeepCopyInto(out *ZoneSubnet) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneSubnet.
+func (in *ZoneSubnet) DeepCopy() *ZoneSubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneSubnet)
+	in.DeepCopyInto(out)
+	return out
+}
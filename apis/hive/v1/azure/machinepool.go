@@ -1,5 +1,7 @@
 package azure
 
+import "fmt"
+
 // MachinePool stores the configuration for a machine pool installed
 // on Azure.
 type MachinePool struct {
@@ -7,18 +9,81 @@ type MachinePool struct {
 	// eg. ["1", "2", "3"]
 	Zones []string `json:"zones,omitempty"`
 
+	// ZoneSubnets explicitly maps availability zones to the names of the subnets, in the virtual network of the
+	// cluster, in which the machines for each zone are created, rather than using the compute subnet of the cluster.
+	// When Zones is empty, a MachineSet is created for each zone in the mapping.
+	// +optional
+	ZoneSubnets []ZoneSubnet `json:"zoneSubnets,omitempty"`
+
 	// InstanceType defines the azure instance type.
 	// eg. Standard_DS_V2
 	InstanceType string `json:"type"`
 
 	// OSDisk defines the storage for instance.
 	OSDisk `json:"osDisk"`
+
+	// EncryptionAtHost enables encryption at the VM host, so that the temporary disks and the caches of the disks of
+	// the machines are also encrypted.
+	// +optional
+	EncryptionAtHost bool `json:"encryptionAtHost,omitempty"`
+
+	// GPU declares the GPUs of the VM size of the pool, which must be a GPU VM size such as Standard_NC6s_v3. Azure
+	// attaches the GPUs of the VM size, so GPU marks the pool as a GPU pool.
+	// +optional
+	GPU *GPU `json:"gpu,omitempty"`
+}
+
+// GPU describes the GPUs attached to each instance.
+type GPU struct {
+	// Type is the model of the GPUs, such as nvidia-tesla-v100.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Count is the number of GPUs attached to each instance.
+	// +kubebuilder:validation:Minimum=1
+	Count int32 `json:"count"`
+}
+
+// ZoneSubnet maps an availability zone to a subnet.
+type ZoneSubnet struct {
+	// Zone is the availability zone.
+	Zone string `json:"zone"`
+	// Subnet is the name of the subnet.
+	Subnet string `json:"subnet"`
 }
 
 // OSDisk defines the disk for machines on Azure.
 type OSDisk struct {
 	// DiskSizeGB defines the size of disk in GB.
 	DiskSizeGB int32 `json:"diskSizeGB"`
+
+	// DiskType defines the type of managed disk.
+	// Defaulted internally to Premium_LRS.
+	// +kubebuilder:validation:Enum=Standard_LRS;Premium_LRS;StandardSSD_LRS;PremiumV2_LRS;UltraSSD_LRS
+	// +optional
+	DiskType string `json:"diskType,omitempty"`
+
+	// DiskEncryptionSet is the disk encryption set used to encrypt the disk with customer managed keys.
+	// +optional
+	DiskEncryptionSet *DiskEncryptionSet `json:"diskEncryptionSet,omitempty"`
+}
+
+// DiskEncryptionSet defines an Azure disk encryption set.
+type DiskEncryptionSet struct {
+	// SubscriptionID is the ID of the subscription of the disk encryption set.
+	SubscriptionID string `json:"subscriptionId"`
+
+	// ResourceGroup is the name of the resource group of the disk encryption set.
+	ResourceGroup string `json:"resourceGroup"`
+
+	// Name is the name of the disk encryption set.
+	Name string `json:"name"`
+}
+
+// ID returns the Azure resource ID of the disk encryption set.
+func (d *DiskEncryptionSet) ID() string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/diskEncryptionSets/%s",
+		d.SubscriptionID, d.ResourceGroup, d.Name)
 }
 
 // Set sets the values from `required` to `a`.
@@ -31,6 +96,10 @@ func (a *MachinePool) Set(required *MachinePool) {
 		a.Zones = required.Zones
 	}
 
+	if len(required.ZoneSubnets) > 0 {
+		a.ZoneSubnets = required.ZoneSubnets
+	}
+
 	if required.InstanceType != "" {
 		a.InstanceType = required.InstanceType
 	}
@@ -38,4 +107,16 @@ func (a *MachinePool) Set(required *MachinePool) {
 	if required.OSDisk.DiskSizeGB != 0 {
 		a.OSDisk.DiskSizeGB = required.OSDisk.DiskSizeGB
 	}
+
+	if required.OSDisk.DiskType != "" {
+		a.OSDisk.DiskType = required.OSDisk.DiskType
+	}
+
+	if required.OSDisk.DiskEncryptionSet != nil {
+		a.OSDisk.DiskEncryptionSet = required.OSDisk.DiskEncryptionSet
+	}
+
+	if required.EncryptionAtHost {
+		a.EncryptionAtHost = true
+	}
 }
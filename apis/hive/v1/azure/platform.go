@@ -18,6 +18,58 @@ type Platform struct {
 
 	// BaseDomainResourceGroupName specifies the resource group where the azure DNS zone for the base domain is found
 	BaseDomainResourceGroupName string `json:"baseDomainResourceGroupName,omitempty"`
+
+	// ResourceGroupName is the name of an existing, empty resource group into which the cluster is installed. When
+	// the cluster is deprovisioned, only the resources created for the cluster are deleted from the resource group,
+	// and the resource group itself is kept.
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// NetworkResourceGroupName is the name of the resource group of the existing VNet into which the cluster is
+	// installed. Required when VirtualNetwork is set.
+	// +optional
+	NetworkResourceGroupName string `json:"networkResourceGroupName,omitempty"`
+
+	// VirtualNetwork is the name of an existing VNet into which the cluster is installed. The VNet and its subnets
+	// are never deleted by Hive.
+	// +optional
+	VirtualNetwork string `json:"virtualNetwork,omitempty"`
+
+	// ControlPlaneSubnet is the name of the existing subnet of VirtualNetwork used by the control plane machines.
+	// Required when VirtualNetwork is set.
+	// +optional
+	ControlPlaneSubnet string `json:"controlPlaneSubnet,omitempty"`
+
+	// ComputeSubnet is the name of the existing subnet of VirtualNetwork used by the compute machines. Required when
+	// VirtualNetwork is set.
+	// +optional
+	ComputeSubnet string `json:"computeSubnet,omitempty"`
+}
+
+// CloudEnvironment is the name of an Azure cloud environment.
+// +kubebuilder:validation:Enum="";AzurePublicCloud;AzureUSGovernmentCloud;AzureChinaCloud;AzureGermanCloud
+type CloudEnvironment string
+
+const (
+	// PublicCloud is the general-purpose, public Azure cloud environment.
+	PublicCloud CloudEnvironment = "AzurePublicCloud"
+
+	// USGovernmentCloud is the Azure cloud environment for the US government.
+	USGovernmentCloud CloudEnvironment = "AzureUSGovernmentCloud"
+
+	// ChinaCloud is the Azure cloud environment used in China.
+	ChinaCloud CloudEnvironment = "AzureChinaCloud"
+
+	// GermanCloud is the Azure cloud environment used in Germany.
+	GermanCloud CloudEnvironment = "AzureGermanCloud"
+)
+
+// Name returns the name that the Azure SDK uses for the cloud environment, defaulting to the public cloud.
+func (e CloudEnvironment) Name() string {
+	if e == "" {
+		return string(PublicCloud)
+	}
+	return string(e)
 }
 
 //SetBaseDomain parses the baseDomainID and sets the related fields on azure.Platform
@@ -4,6 +4,38 @@
 
 package azure
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskEncryptionSet) DeepCopyInto(out *DiskEncryptionSet) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskEncryptionSet.
+func (in *DiskEncryptionSet) DeepCopy() *DiskEncryptionSet {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskEncryptionSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPU) DeepCopyInto(out *GPU) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPU.
+func (in *GPU) DeepCopy() *GPU {
+	if in == nil {
+		return nil
+	}
+	out := new(GPU)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachinePool) DeepCopyInto(out *MachinePool) {
 	*out = *in
@@ -12,7 +44,17 @@ func (in *MachinePool) DeepCopyInto(out *MachinePool) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	out.OSDisk = in.OSDisk
+	if in.ZoneSubnets != nil {
+		in, out := &in.ZoneSubnets, &out.ZoneSubnets
+		*out = make([]ZoneSubnet, len(*in))
+		copy(*out, *in)
+	}
+	in.OSDisk.DeepCopyInto(&out.OSDisk)
+	if in.GPU != nil {
+		in, out := &in.GPU, &out.GPU
+		*out = new(GPU)
+		**out = **in
+	}
 	return
 }
 
@@ -45,6 +87,11 @@ func (in *Metadata) DeepCopy() *Metadata {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OSDisk) DeepCopyInto(out *OSDisk) {
 	*out = *in
+	if in.DiskEncryptionSet != nil {
+		in, out := &in.DiskEncryptionSet, &out.DiskEncryptionSet
+		*out = new(DiskEncryptionSet)
+		**out = **in
+	}
 	return
 }
 
@@ -74,3 +121,19 @@ func (in *Platform) DeepCopy() *Platform {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneSubnet) DeepCopyInto(out *ZoneSubnet) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneSubnet.
+func (in *ZoneSubnet) DeepCopy() *ZoneSubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneSubnet)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -25,6 +25,13 @@ type ClusterClaimSpec struct {
 	// when the lifetime has elapsed, the claim will be deleted by Hive.
 	// +optional
 	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+
+	// PowerState is the power state to which the claimed cluster is set when it is assigned to the claim. Defaults
+	// to Running. Set to Hibernating to claim a cluster ahead of time without resuming it; the cluster can then be
+	// resumed by setting the powerState of its ClusterDeployment to Running.
+	// +kubebuilder:validation:Enum="";Running;Hibernating;WorkersStopped
+	// +optional
+	PowerState ClusterPowerState `json:"powerState,omitempty"`
 }
 
 // ClusterClaimStatus defines the observed state of ClusterClaim.
@@ -37,6 +44,11 @@ type ClusterClaimStatus struct {
 	// when the lifetime has elapsed, the claim will be deleted by Hive.
 	// +optional
 	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+
+	// Architecture is the architecture of the cluster assigned to the claim, as set on the ClusterPool when the
+	// cluster was created.
+	// +optional
+	Architecture PoolArchitecture `json:"architecture,omitempty"`
 }
 
 // ClusterClaimCondition contains details for the current condition of a cluster claim.
@@ -82,6 +94,7 @@ const (
 // +kubebuilder:printcolumn:name="Pending",type="string",JSONPath=".status.conditions[?(@.type=='Pending')].reason"
 // +kubebuilder:printcolumn:name="ClusterNamespace",type="string",JSONPath=".spec.namespace"
 // +kubebuilder:printcolumn:name="ClusterRunning",type="string",JSONPath=".status.conditions[?(@.type=='ClusterRunning')].reason"
+// +kubebuilder:printcolumn:name="Architecture",type="string",JSONPath=".status.architecture",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type ClusterClaim struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -2,15 +2,20 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	configv1 "github.com/openshift/api/config/v1"
+
 	"github.com/openshift/hive/apis/hive/v1/agent"
 	"github.com/openshift/hive/apis/hive/v1/aws"
 	"github.com/openshift/hive/apis/hive/v1/azure"
 	"github.com/openshift/hive/apis/hive/v1/baremetal"
 	"github.com/openshift/hive/apis/hive/v1/gcp"
+	"github.com/openshift/hive/apis/hive/v1/nutanix"
 	"github.com/openshift/hive/apis/hive/v1/openstack"
 	"github.com/openshift/hive/apis/hive/v1/ovirt"
+	"github.com/openshift/hive/apis/hive/v1/powervs"
 	"github.com/openshift/hive/apis/hive/v1/vsphere"
 )
 
@@ -53,7 +58,7 @@ const (
 
 // ClusterPowerState is used to indicate whether a cluster is running or in a
 // hibernating state.
-// +kubebuilder:validation:Enum="";Running;Hibernating
+// +kubebuilder:validation:Enum="";Running;Hibernating;WorkersStopped
 type ClusterPowerState string
 
 const (
@@ -64,6 +69,11 @@ const (
 	// HibernatingClusterPowerState is used to stop the machines belonging to a cluster
 	// and move it to a hibernating state.
 	HibernatingClusterPowerState ClusterPowerState = "Hibernating"
+
+	// WorkersStoppedClusterPowerState is used to scale the worker MachineSets of a cluster
+	// to zero while the control plane keeps running. Unlike hibernation, it does not depend
+	// on the cloud provider supporting stopping instances.
+	WorkersStoppedClusterPowerState ClusterPowerState = "WorkersStopped"
 )
 
 // ClusterDeploymentSpec defines the desired state of ClusterDeployment
@@ -108,6 +118,11 @@ type ClusterDeploymentSpec struct {
 	// +optional
 	ManageDNS bool `json:"manageDNS,omitempty"`
 
+	// DNSRouting configures records with a routing policy for the API and ingress of an adopted cluster with managed
+	// DNS in a zone shared with other clusters, such as the active and passive clusters serving the same endpoints.
+	// +optional
+	DNSRouting *DNSRouting `json:"dnsRouting,omitempty"`
+
 	// ClusterMetadata contains metadata information about the installed cluster.
 	ClusterMetadata *ClusterMetadata `json:"clusterMetadata,omitempty"`
 
@@ -126,12 +141,19 @@ type ClusterDeploymentSpec struct {
 	// +optional
 	ClusterInstallRef *ClusterInstallLocalReference `json:"clusterInstallRef,omitempty"`
 
+	// Adoption requests the adoption of an existing cluster that was not installed by Hive. The clusteradoption
+	// controller connects to the cluster with the admin kubeconfig, verifies its infra ID, populates ClusterMetadata
+	// and marks the cluster installed without running an install. This cannot be set when Provisioning or
+	// ClusterInstallRef is also set.
+	// +optional
+	Adoption *ClusterAdoption `json:"adoption,omitempty"`
+
 	// ClusterPoolRef is a reference to the ClusterPool that this ClusterDeployment originated from.
 	// +optional
 	ClusterPoolRef *ClusterPoolReference `json:"clusterPoolRef,omitempty"`
 
-	// PowerState indicates whether a cluster should be running or hibernating. When omitted,
-	// PowerState defaults to the Running state.
+	// PowerState indicates whether a cluster should be running, hibernating, or running with its
+	// workers stopped. When omitted, PowerState defaults to the Running state.
 	// +optional
 	PowerState ClusterPowerState `json:"powerState,omitempty"`
 
@@ -156,6 +178,94 @@ type ClusterDeploymentSpec struct {
 	// provision AWS clusters to use Amazon's Security Token Service.
 	// +optional
 	BoundServiceAccountSignkingKeySecretRef *corev1.LocalObjectReference `json:"boundServiceAccountSigningKeySecretRef,omitempty"`
+
+	// ViewerKubeconfig configures a kubeconfig with restricted permissions on the cluster. When set, Hive creates a
+	// ServiceAccount on the cluster bound to the configured RBAC and stores a kubeconfig for it in a secret in the
+	// namespace of the ClusterDeployment. The secret is referenced by ClusterMetadata.ViewerKubeconfigSecretRef.
+	// +optional
+	ViewerKubeconfig *ViewerKubeconfig `json:"viewerKubeconfig,omitempty"`
+
+	// AdditionalTrustBundle refers to a ConfigMap with additional certificate authorities that are trusted by the
+	// install pod and added to the additionalTrustBundle of the install-config of the cluster. This is meant for
+	// environments with internal certificate authorities or proxies that intercept TLS.
+	// +optional
+	AdditionalTrustBundle *AdditionalTrustBundle `json:"additionalTrustBundle,omitempty"`
+
+	// CloudClientProxySecretRef refers to a secret in the namespace of the ClusterDeployment with the proxy configuration, and the
+	// additional trusted certificate authorities, of the clients with which Hive calls the cloud APIs for the cluster. The
+	// secret may have the httpProxy, httpsProxy and noProxy keys, and a PEM-encoded certificate bundle under the ca.crt
+	// key. It takes precedence over the CloudClientProxy of HiveConfig.
+	// Calls made with credentials of Hive, such as its service provider credentials or those minted by the credentials
+	// broker, do not use it.
+	// +optional
+	CloudClientProxySecretRef *corev1.LocalObjectReference `json:"cloudClientProxySecretRef,omitempty"`
+
+	// SSHKeyRotation requests the rotation of the SSH key of the cluster. Hive generates a new key pair, rolls the
+	// public key out to the machines of the cluster, and once the rollout is complete stores the private key in the
+	// ${CLUSTER_NAME}-rotated-ssh-key secret and points Provisioning.SSHPrivateKeySecretRef at it.
+	// +optional
+	SSHKeyRotation *SSHKeyRotation `json:"sshKeyRotation,omitempty"`
+
+	// Paused stops Hive from reconciling the ClusterDeployment and the resources of the cluster: provisioning,
+	// syncing of SyncSets, hibernation, DNS and the other controllers acting on the cluster leave it untouched
+	// until it is unpaused. Deprovisioning a deleted ClusterDeployment also waits until it is unpaused.
+	// Paused replaces the hive.openshift.io/syncset-pause annotation, which only pauses the syncing of SyncSets.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// SyncSetApplyWindows restricts the rollout of changes to the SyncSets and SelectorSyncSets of the cluster to
+	// recurring windows of time. New SyncSets, SyncSets whose spec changed and the deletion of resources of removed
+	// SyncSets wait for the next window, and are listed as pending in the ClusterSync of the cluster. Changes are
+	// applied immediately when no windows are set, and during the initial sync of the cluster.
+	// +optional
+	SyncSetApplyWindows []SyncSetApplyWindow `json:"syncSetApplyWindows,omitempty"`
+}
+
+// ClusterAdoption contains the details of an existing cluster to adopt.
+type ClusterAdoption struct {
+	// InfraID is the infra ID of the cluster, used for tagging/naming resources in cloud providers. It must match the
+	// infrastructure name reported by the cluster.
+	InfraID string `json:"infraID"`
+
+	// AdminKubeconfigSecretRef references the secret containing the admin kubeconfig of the cluster, under the
+	// kubeconfig key.
+	AdminKubeconfigSecretRef corev1.LocalObjectReference `json:"adminKubeconfigSecretRef"`
+
+	// AdminPasswordSecretRef references the secret containing the admin username/password of the cluster.
+	// +optional
+	AdminPasswordSecretRef *corev1.LocalObjectReference `json:"adminPasswordSecretRef,omitempty"`
+}
+
+// SSHKeyRotation requests the rotation of the SSH key of a cluster.
+type SSHKeyRotation struct {
+	// RotationID identifies the requested rotation. Setting it to a value other than Status.SSHKeyRotation.RotationID
+	// starts a new rotation.
+	RotationID string `json:"rotationID"`
+}
+
+// AdditionalTrustBundle specifies additional certificate authorities for a cluster.
+type AdditionalTrustBundle struct {
+	// ConfigMapRef refers to a ConfigMap in the ClusterDeployment's namespace that contains a PEM-encoded X.509
+	// certificate bundle under the "ca-bundle.crt" key.
+	ConfigMapRef corev1.LocalObjectReference `json:"configMapRef"`
+
+	// SyncToCluster keeps the trusted CA bundle of the proxy configuration of the cluster in sync with the ConfigMap
+	// after the cluster is installed.
+	// +optional
+	SyncToCluster bool `json:"syncToCluster,omitempty"`
+}
+
+// ViewerKubeconfig contains the permissions granted by the viewer kubeconfig of the cluster.
+type ViewerKubeconfig struct {
+	// ClusterRoleName is the name of the ClusterRole on the cluster that is granted to the viewer. This is ignored
+	// when Rules is set. Defaults to "cluster-reader".
+	// +optional
+	ClusterRoleName string `json:"clusterRoleName,omitempty"`
+
+	// Rules is the list of policy rules granted to the viewer. When set, Hive manages a ClusterRole with these rules
+	// on the cluster.
+	// +optional
+	Rules []rbacv1.PolicyRule `json:"rules,omitempty"`
 }
 
 // ClusterInstallLocalReference provides reference to an object that implements
@@ -205,6 +315,11 @@ type Provisioning struct {
 	// that will take precedence over the one from the ClusterImageSet.
 	ImageSetRef *ClusterImageSetReference `json:"imageSetRef,omitempty"`
 
+	// InstallerImageOverride is an installer image to use instead of the installer image from the release image.
+	// This may be used to test a patched openshift-install on a single cluster while keeping the release image.
+	// +optional
+	InstallerImageOverride string `json:"installerImageOverride,omitempty"`
+
 	// ManifestsConfigMapRef is a reference to user-provided manifests to
 	// add to or replace manifests that are generated by the installer.
 	ManifestsConfigMapRef *corev1.LocalObjectReference `json:"manifestsConfigMapRef,omitempty"`
@@ -222,10 +337,131 @@ type Provisioning struct {
 	// provisioning scenarios), so this setting is often not needed.
 	SSHKnownHosts []string `json:"sshKnownHosts,omitempty"`
 
+	// ResumableInstall saves a checkpoint of the installer state to a Secret once the bootstrap of the cluster has
+	// completed. If the install pod is lost after that point, the next install attempt restores the checkpoint and
+	// waits for the install to complete rather than destroying and re-creating the cloud infrastructure.
+	// Resuming is not supported for clusters using AWS PrivateLink or GCP Private Service Connect.
+	// +optional
+	ResumableInstall bool `json:"resumableInstall,omitempty"`
+
 	// InstallerEnv are extra environment variables to pass through to the installer. This may be used to enable
-	// additional features of the installer.
+	// additional features of the installer. Only the variables allowed by HiveConfig spec.allowedInstallerEnv, by
+	// default OPENSHIFT_INSTALL_*, may be set.
 	// +optional
 	InstallerEnv []corev1.EnvVar `json:"installerEnv,omitempty"`
+
+	// ManualCredentials installs the cluster with the cloud credential operator in Manual mode, so that no root
+	// cloud credentials are stored in the cluster. Hive sets credentialsMode: Manual in the InstallConfig, extracts
+	// the CredentialsRequests of the release being installed, and injects a credentials Secret for each of them
+	// before provisioning. The install fails early if a CredentialsRequest is not satisfied.
+	// +optional
+	ManualCredentials *ManualCredentials `json:"manualCredentials,omitempty"`
+}
+
+// ManualCredentials contains the credentials to inject for the CredentialsRequests of a cluster installed with the
+// cloud credential operator in Manual mode. Exactly one of ManifestsSecretRef, AWSSTS, GCPWorkloadIdentity or
+// AzureWorkloadIdentity must be set.
+type ManualCredentials struct {
+	// ManifestsSecretRef is a reference to a Secret whose keys are pre-created credentials manifests, usually the
+	// Secrets satisfying the CredentialsRequests of the release, to add to the manifests generated by the installer.
+	// +optional
+	ManifestsSecretRef *corev1.LocalObjectReference `json:"manifestsSecretRef,omitempty"`
+
+	// AWSSTS generates the credentials Secrets for the CredentialsRequests of the release so that the components of
+	// the cluster assume IAM roles using the bound service account tokens of the cluster. Requires
+	// BoundServiceAccountSigningKeySecretRef to be set.
+	// +optional
+	AWSSTS *ManualCredentialsAWSSTS `json:"awsSTS,omitempty"`
+
+	// GCPWorkloadIdentity generates the credentials Secrets for the CredentialsRequests of the release so that the
+	// components of the cluster impersonate GCP service accounts through a workload identity pool trusting the bound
+	// service account tokens of the cluster. Requires BoundServiceAccountSigningKeySecretRef to be set.
+	// +optional
+	GCPWorkloadIdentity *ManualCredentialsGCPWorkloadIdentity `json:"gcpWorkloadIdentity,omitempty"`
+
+	// AzureWorkloadIdentity generates the credentials Secrets for the CredentialsRequests of the release so that the
+	// components of the cluster authenticate as Azure managed identities with federated credentials trusting the
+	// bound service account tokens of the cluster. Requires BoundServiceAccountSigningKeySecretRef to be set.
+	// +optional
+	AzureWorkloadIdentity *ManualCredentialsAzureWorkloadIdentity `json:"azureWorkloadIdentity,omitempty"`
+
+	// ServiceAccountIssuer is the URL of the OIDC issuer of the bound service account tokens of the cluster, where
+	// the cloud provider finds the public key matching BoundServiceAccountSigningKeySecretRef. When set, Hive
+	// configures the issuer in the Authentication config of the cluster.
+	// +optional
+	ServiceAccountIssuer string `json:"serviceAccountIssuer,omitempty"`
+}
+
+// ManualCredentialsMode is the source of the credentials of a cluster installed with manual credentials.
+// +kubebuilder:validation:Enum=Manifests;AWSSTS;GCPWorkloadIdentity;AzureWorkloadIdentity
+type ManualCredentialsMode string
+
+const (
+	// ManualCredentialsModeManifests is used when the credentials Secrets are provided in ManifestsSecretRef.
+	ManualCredentialsModeManifests ManualCredentialsMode = "Manifests"
+	// ManualCredentialsModeAWSSTS is used when the credentials Secrets are generated for AWS STS.
+	ManualCredentialsModeAWSSTS ManualCredentialsMode = "AWSSTS"
+	// ManualCredentialsModeGCPWorkloadIdentity is used when the credentials Secrets are generated for GCP
+	// Workload Identity Federation.
+	ManualCredentialsModeGCPWorkloadIdentity ManualCredentialsMode = "GCPWorkloadIdentity"
+	// ManualCredentialsModeAzureWorkloadIdentity is used when the credentials Secrets are generated for Azure
+	// federated credentials.
+	ManualCredentialsModeAzureWorkloadIdentity ManualCredentialsMode = "AzureWorkloadIdentity"
+)
+
+// ManualCredentialsAWSSTS configures the credentials Secrets generated for a cluster using AWS Security Token Service.
+type ManualCredentialsAWSSTS struct {
+	// RoleARNPrefix is the ARN prefix of the IAM roles created for the CredentialsRequests of the release, for
+	// example arn:aws:iam::123456789012:role/mycluster. The role assumed for a CredentialsRequest is named
+	// <prefix>-<secret namespace>-<secret name>, truncated to 64 characters, matching the roles created by
+	// ccoctl aws create-iam-roles.
+	RoleARNPrefix string `json:"roleARNPrefix"`
+}
+
+// ManualCredentialsGCPWorkloadIdentity configures the credentials Secrets generated for a cluster using GCP Workload
+// Identity Federation.
+type ManualCredentialsGCPWorkloadIdentity struct {
+	// ProjectNumber is the number of the GCP project containing the workload identity pool.
+	ProjectNumber string `json:"projectNumber"`
+
+	// PoolID is the ID of the workload identity pool trusting the service account issuer of the cluster.
+	PoolID string `json:"poolID"`
+
+	// ProviderID is the ID of the OIDC provider of the workload identity pool.
+	ProviderID string `json:"providerID"`
+
+	// ServiceAccounts maps the Secrets requested by the CredentialsRequests of the release to the email of the GCP
+	// service account impersonated by each component.
+	ServiceAccounts []WorkloadIdentityServiceAccount `json:"serviceAccounts"`
+}
+
+// ManualCredentialsAzureWorkloadIdentity configures the credentials Secrets generated for a cluster using Azure
+// federated credentials.
+type ManualCredentialsAzureWorkloadIdentity struct {
+	// SubscriptionID is the ID of the Azure subscription of the managed identities.
+	SubscriptionID string `json:"subscriptionID"`
+
+	// TenantID is the ID of the Azure tenant of the managed identities.
+	TenantID string `json:"tenantID"`
+
+	// ServiceAccounts maps the Secrets requested by the CredentialsRequests of the release to the client ID of the
+	// managed identity, with a federated credential for the service account of the component, used by each
+	// component.
+	ServiceAccounts []WorkloadIdentityServiceAccount `json:"serviceAccounts"`
+}
+
+// WorkloadIdentityServiceAccount maps the Secret requested by a CredentialsRequest to the cloud identity used by the
+// component.
+type WorkloadIdentityServiceAccount struct {
+	// SecretNamespace is the namespace of the Secret requested by the CredentialsRequest.
+	SecretNamespace string `json:"secretNamespace"`
+
+	// SecretName is the name of the Secret requested by the CredentialsRequest.
+	SecretName string `json:"secretName"`
+
+	// Identity is the email of the GCP service account, or the client ID of the Azure managed identity, used by the
+	// component.
+	Identity string `json:"identity"`
 }
 
 // ClusterImageSetReference is a reference to a ClusterImageSet
@@ -259,6 +495,11 @@ type ClusterMetadata struct {
 
 	// AdminPasswordSecretRef references the secret containing the admin username/password which can be used to login to this cluster.
 	AdminPasswordSecretRef corev1.LocalObjectReference `json:"adminPasswordSecretRef"`
+
+	// ViewerKubeconfigSecretRef references the secret containing the viewer kubeconfig for this cluster. This is set
+	// by Hive when ViewerKubeconfig is configured.
+	// +optional
+	ViewerKubeconfigSecretRef *corev1.LocalObjectReference `json:"viewerKubeconfigSecretRef,omitempty"`
 }
 
 // ClusterDeploymentStatus defines the observed state of ClusterDeployment
@@ -286,6 +527,13 @@ type ClusterDeploymentStatus struct {
 	// +optional
 	CLIImage *string `json:"cliImage,omitempty"`
 
+	// VerifiedReleaseImage is the release image of the cluster pinned to the digest whose signature and
+	// architectures were verified before the cluster was provisioned. The imageset job and the install pods use it
+	// in place of the release image of the spec. It is only set when release image verification is enabled in
+	// HiveConfig.
+	// +optional
+	VerifiedReleaseImage string `json:"verifiedReleaseImage,omitempty"`
+
 	// Conditions includes more detailed status for the cluster deployment
 	// +optional
 	Conditions []ClusterDeploymentCondition `json:"conditions,omitempty"`
@@ -311,6 +559,85 @@ type ClusterDeploymentStatus struct {
 	// perform the installation.
 	// +optional
 	Platform *PlatformStatus `json:"platformStatus,omitempty"`
+
+	// ManualCredentialsMode is the source of the cloud credentials of the cluster when it was provisioned with the
+	// cloud credential operator in Manual mode.
+	// +optional
+	ManualCredentialsMode ManualCredentialsMode `json:"manualCredentialsMode,omitempty"`
+
+	// SSHKeyRotation is the status of the last completed rotation of the SSH key of the cluster.
+	// +optional
+	SSHKeyRotation *SSHKeyRotationStatus `json:"sshKeyRotation,omitempty"`
+
+	// APIURLOverrideHealthyProbes is the number of consecutive successful probes of the API URL override while
+	// Hive is failing back to it.
+	// +optional
+	APIURLOverrideHealthyProbes int32 `json:"apiURLOverrideHealthyProbes,omitempty"`
+
+	// PowerStateHistory is the history of the power state transitions of the cluster, oldest first. Only the most
+	// recent transitions are kept.
+	// +optional
+	PowerStateHistory []PowerStateTransition `json:"powerStateHistory,omitempty"`
+
+	// ClusterVersion is the version status of the cluster, synced from the ClusterVersion of the cluster.
+	// +optional
+	ClusterVersion *ClusterVersionStatus `json:"clusterVersion,omitempty"`
+}
+
+// ClusterVersionStatus is the version status of a cluster, as reported by the ClusterVersion of the cluster.
+type ClusterVersionStatus struct {
+	// DesiredVersion is the version the cluster is reconciling to.
+	// +optional
+	DesiredVersion string `json:"desiredVersion,omitempty"`
+
+	// History is the list of updates applied to the cluster, most recent first.
+	// +optional
+	History []configv1.UpdateHistory `json:"history,omitempty"`
+
+	// AvailableUpdates is the list of updates recommended for the cluster by its update service.
+	// +optional
+	AvailableUpdates []configv1.Update `json:"availableUpdates,omitempty"`
+}
+
+// PowerStateTransition records a transition of the power state of a cluster.
+type PowerStateTransition struct {
+	// RequestedState is the power state requested for the cluster.
+	RequestedState ClusterPowerState `json:"requestedState"`
+
+	// State is the reason of the Hibernating condition of the cluster when the transition was last updated, for
+	// example Stopping while the cluster is being hibernated and Hibernating once it has stopped.
+	State string `json:"state"`
+
+	// Initiator is what requested the transition, taken from the hive.openshift.io/power-state-initiator annotation
+	// of the ClusterDeployment when the transition started. Hive sets the annotation to HibernateAfter, ClusterPool
+	// or ClusterClaim when it changes the power state of a cluster.
+	// +optional
+	Initiator string `json:"initiator,omitempty"`
+
+	// StartTime is the time when the transition started.
+	StartTime metav1.Time `json:"startTime"`
+
+	// CompletionTime is the time when the cluster reached the requested power state.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// FailureReason is the last error that prevented the transition from progressing.
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+}
+
+// SSHKeyRotationStatus contains the status of the last completed rotation of the SSH key of a cluster.
+type SSHKeyRotationStatus struct {
+	// RotationID is the ID of the last completed rotation.
+	RotationID string `json:"rotationID"`
+
+	// PublicKeyFingerprint is the SHA256 fingerprint of the public key configured on the cluster.
+	// +optional
+	PublicKeyFingerprint string `json:"publicKeyFingerprint,omitempty"`
+
+	// LastRotationTime is the time when the last rotation completed.
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
 }
 
 // ClusterDeploymentCondition contains details for the current condition of a cluster deployment
@@ -376,6 +703,9 @@ const (
 	// SyncSetFailedCondition indicates if any syncset for a cluster deployment failed
 	SyncSetFailedCondition ClusterDeploymentConditionType = "SyncSetFailed"
 
+	// PullSecretSyncFailedCondition indicates if pushing an updated pull secret to an installed cluster failed
+	PullSecretSyncFailedCondition ClusterDeploymentConditionType = "PullSecretSyncFailed"
+
 	// RelocationFailedCondition indicates if a relocation to another Hive instance has failed
 	RelocationFailedCondition ClusterDeploymentConditionType = "RelocationFailed"
 
@@ -395,6 +725,19 @@ const (
 	// AuthenticationFailureCondition is true when platform credentials cannot be used because of authentication failure
 	AuthenticationFailureClusterDeploymentCondition ClusterDeploymentConditionType = "AuthenticationFailure"
 
+	// InsufficientPermissionsClusterDeploymentCondition is true when the platform credentials are missing
+	// permissions needed to provision or operate the cluster. The message lists the missing permissions.
+	InsufficientPermissionsClusterDeploymentCondition ClusterDeploymentConditionType = "InsufficientPermissions"
+
+	// FIPSPreconditionFailedClusterDeploymentCondition is true when a cluster requested to be installed in FIPS mode
+	// cannot be, because the release image does not support FIPS or the hub is not running in FIPS mode.
+	FIPSPreconditionFailedClusterDeploymentCondition ClusterDeploymentConditionType = "FIPSPreconditionFailed"
+
+	// ReleaseImageVerificationFailedClusterDeploymentCondition is true when the release image of a cluster could not
+	// be verified before provisioning, because its signature could not be verified or its architectures do not match
+	// the machine pools of the InstallConfig.
+	ReleaseImageVerificationFailedClusterDeploymentCondition ClusterDeploymentConditionType = "ReleaseImageVerificationFailed"
+
 	// AWSPrivateLinkReadyClusterDeploymentCondition is true when private link access has been
 	// setup for the cluster.
 	AWSPrivateLinkReadyClusterDeploymentCondition ClusterDeploymentConditionType = "AWSPrivateLinkReady"
@@ -403,6 +746,43 @@ const (
 	// for the cluster.
 	AWSPrivateLinkFailedClusterDeploymentCondition ClusterDeploymentConditionType = "AWSPrivateLinkFailed"
 
+	// AWSSharedVPCReadyClusterDeploymentCondition is true when the shared subnets of a cluster installed into a
+	// shared VPC have been tagged for the cluster and the private hosted zone of the cluster has been associated
+	// with the shared VPC.
+	AWSSharedVPCReadyClusterDeploymentCondition ClusterDeploymentConditionType = "AWSSharedVPCReady"
+
+	// GCPPrivateServiceConnectReadyClusterDeploymentCondition is true when private service connect access has been
+	// setup for the cluster.
+	GCPPrivateServiceConnectReadyClusterDeploymentCondition ClusterDeploymentConditionType = "GCPPrivateServiceConnectReady"
+
+	// GCPPrivateServiceConnectFailedClusterDeploymentCondition is true controller fails to setup private service connect
+	// access for the cluster.
+	GCPPrivateServiceConnectFailedClusterDeploymentCondition ClusterDeploymentConditionType = "GCPPrivateServiceConnectFailed"
+
+	// ManagedDNSRecordsReadyClusterDeploymentCondition is true when the records of an adopted cluster with managed
+	// DNS are maintained in its managed DNS zone.
+	ManagedDNSRecordsReadyClusterDeploymentCondition ClusterDeploymentConditionType = "ManagedDNSRecordsReady"
+
+	// SSHKeyRotationInProgressClusterDeploymentCondition is true while a new SSH key is being rolled out to the
+	// machines of the cluster.
+	SSHKeyRotationInProgressClusterDeploymentCondition ClusterDeploymentConditionType = "SSHKeyRotationInProgress"
+
+	// PausedClusterDeploymentCondition is true when the reconciliation of the ClusterDeployment is paused by
+	// Spec.Paused.
+	PausedClusterDeploymentCondition ClusterDeploymentConditionType = "Paused"
+
+	// CredentialsExpiringSoonClusterDeploymentCondition is true when the client certificate of the admin kubeconfig
+	// or the serving certificate of the API of the cluster expires within the warning period, or has expired.
+	CredentialsExpiringSoonClusterDeploymentCondition ClusterDeploymentConditionType = "CredentialsExpiringSoon"
+
+	// EndpointsUnhealthyClusterDeploymentCondition is true when the API, console or an ingress endpoint of the cluster
+	// probed by the endpointhealth controller is unhealthy.
+	EndpointsUnhealthyClusterDeploymentCondition ClusterDeploymentConditionType = "EndpointsUnhealthy"
+
+	// AdoptionFailedClusterDeploymentCondition is true when the cluster requested to be adopted by Spec.Adoption
+	// could not be reached or does not match the adoption details.
+	AdoptionFailedClusterDeploymentCondition ClusterDeploymentConditionType = "AdoptionFailed"
+
 	// These are conditions that are copied from ClusterInstall on to the ClusterDeployment object.
 	ClusterInstallFailedClusterDeploymentCondition          ClusterDeploymentConditionType = "ClusterInstallFailed"
 	ClusterInstallCompletedClusterDeploymentCondition       ClusterDeploymentConditionType = "ClusterInstallCompleted"
@@ -422,11 +802,23 @@ var AllClusterDeploymentConditions = []ClusterDeploymentConditionType{
 	DNSNotReadyCondition,
 	ProvisionFailedCondition,
 	SyncSetFailedCondition,
+	PullSecretSyncFailedCondition,
 	RelocationFailedCondition,
 	ClusterHibernatingCondition,
 	InstallLaunchErrorCondition,
 	AWSPrivateLinkReadyClusterDeploymentCondition,
 	AWSPrivateLinkFailedClusterDeploymentCondition,
+	GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+	GCPPrivateServiceConnectFailedClusterDeploymentCondition,
+	ManagedDNSRecordsReadyClusterDeploymentCondition,
+	InsufficientPermissionsClusterDeploymentCondition,
+	FIPSPreconditionFailedClusterDeploymentCondition,
+	ReleaseImageVerificationFailedClusterDeploymentCondition,
+	SSHKeyRotationInProgressClusterDeploymentCondition,
+	PausedClusterDeploymentCondition,
+	CredentialsExpiringSoonClusterDeploymentCondition,
+	EndpointsUnhealthyClusterDeploymentCondition,
+	AdoptionFailedClusterDeploymentCondition,
 }
 
 // Cluster hibernating reasons
@@ -454,11 +846,59 @@ const (
 	// FailedToStartHibernationReason is used when there was an error starting machines
 	// to leave hibernation
 	FailedToStartHibernationReason = "FailedToStart"
+	// ResumeTimedOutHibernationReason is used as the reason when the cluster has not resumed within the resume
+	// timeout of the hibernation defaults in the HiveConfig.
+	ResumeTimedOutHibernationReason = "ResumeTimedOut"
+	// StoppingWorkersHibernationReason is used as the reason when the worker MachineSets of the
+	// cluster are being scaled to zero for the WorkersStopped power state.
+	StoppingWorkersHibernationReason = "StoppingWorkers"
+	// WorkersStoppedHibernationReason is used as the reason when the worker MachineSets of the
+	// cluster have been scaled to zero for the WorkersStopped power state.
+	WorkersStoppedHibernationReason = "WorkersStopped"
+	// ResumingWorkersHibernationReason is used as the reason when the worker MachineSets of the
+	// cluster are being scaled back up after leaving the WorkersStopped power state.
+	ResumingWorkersHibernationReason = "ResumingWorkers"
 	// SyncSetsNotAppliedReason is used as the reason when SyncSets have not yet been applied
 	// for the cluster based on ClusterSync.Status.FirstSucessTime
 	SyncSetsNotAppliedReason = "SyncSetsNotApplied"
 )
 
+// SSH key rotation reasons
+const (
+	// SSHKeyRolloutInProgressReason is used when the new SSH key is being rolled out to the machines of the cluster.
+	SSHKeyRolloutInProgressReason = "RolloutInProgress"
+	// SSHKeyRotationCompleteReason is used when the new SSH key has been rolled out and stored in the hub.
+	SSHKeyRotationCompleteReason = "RotationComplete"
+	// SSHKeyRotationFailedReason is used when the SSH key rotation encountered an error.
+	SSHKeyRotationFailedReason = "RotationFailed"
+)
+
+// Paused reasons
+const (
+	// PausedReason is used when the reconciliation of the ClusterDeployment is paused by Spec.Paused.
+	PausedReason = "Paused"
+	// NotPausedReason is used when the reconciliation of the ClusterDeployment is not paused.
+	NotPausedReason = "NotPaused"
+)
+
+// Credentials expiry reasons
+const (
+	// CredentialsExpiringSoonReason is used when a certificate of the cluster expires within the warning period.
+	CredentialsExpiringSoonReason = "ExpiringSoon"
+	// CredentialsExpiredReason is used when a certificate of the cluster has expired.
+	CredentialsExpiredReason = "Expired"
+	// CredentialsValidReason is used when no certificate of the cluster expires within the warning period.
+	CredentialsValidReason = "CredentialsValid"
+)
+
+// Endpoint health reasons
+const (
+	// EndpointsHealthyReason is used when all of the probed endpoints of the cluster are healthy.
+	EndpointsHealthyReason = "EndpointsHealthy"
+	// EndpointsUnhealthyReason is used when a probed endpoint of the cluster is unhealthy.
+	EndpointsUnhealthyReason = "EndpointsUnhealthy"
+)
+
 // InitializedConditionReason is used when a condition is initialized for the first time, and the status of the
 // condition is still Unknown
 const InitializedConditionReason = "Initialized"
@@ -478,6 +918,7 @@ const InitializedConditionReason = "Initialized"
 // +kubebuilder:printcolumn:name="PowerState",type="string",JSONPath=".status.conditions[?(@.type=='Hibernating')].reason"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:resource:path=clusterdeployments,shortName=cd,scope=Namespaced
+// +kubebuilder:storageversion
 type ClusterDeployment struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -521,6 +962,12 @@ type Platform struct {
 	// Ovirt is the configuration used when installing on oVirt
 	Ovirt *ovirt.Platform `json:"ovirt,omitempty"`
 
+	// Nutanix is the configuration used when installing on Nutanix
+	Nutanix *nutanix.Platform `json:"nutanix,omitempty"`
+
+	// PowerVS is the configuration used when installing on IBM Power Virtual Server
+	PowerVS *powervs.Platform `json:"powervs,omitempty"`
+
 	// AgentBareMetal is the configuration used when performing an Assisted Agent based installation
 	// to bare metal.
 	AgentBareMetal *agent.BareMetalPlatform `json:"agentBareMetal,omitempty"`
@@ -531,6 +978,8 @@ type Platform struct {
 type PlatformStatus struct {
 	// AWS is the observed state on AWS.
 	AWS *aws.PlatformStatus `json:"aws,omitempty"`
+	// GCP is the observed state on GCP.
+	GCP *gcp.PlatformStatus `json:"gcp,omitempty"`
 }
 
 // ClusterIngress contains the configurable pieces for any ClusterIngress objects
@@ -558,6 +1007,61 @@ type ClusterIngress struct {
 	// should be used for this Ingress
 	// +optional
 	ServingCertificate string `json:"servingCertificate,omitempty"`
+
+	// Replicas is the desired number of ingress controller replicas. When omitted, the ingress operator of the
+	// cluster chooses the number of replicas.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// NodePlacement controls the scheduling of the ingress controller pods.
+	// +optional
+	NodePlacement *IngressNodePlacement `json:"nodePlacement,omitempty"`
+
+	// LoadBalancer configures the load balancer through which the ingress controller is published. When set, the
+	// ingress controller is published with a LoadBalancer service.
+	// +optional
+	LoadBalancer *IngressLoadBalancer `json:"loadBalancer,omitempty"`
+}
+
+// IngressNodePlacement describes the node scheduling of the pods of an ingress controller.
+type IngressNodePlacement struct {
+	// NodeSelector is the node selector applied to the ingress controller pods.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// Tolerations are the tolerations applied to the ingress controller pods.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// IngressLoadBalancerScope is the scope at which the load balancer of an ingress controller is exposed.
+type IngressLoadBalancerScope string
+
+const (
+	// ExternalIngressLoadBalancerScope exposes the load balancer publicly.
+	ExternalIngressLoadBalancerScope IngressLoadBalancerScope = "External"
+
+	// InternalIngressLoadBalancerScope exposes the load balancer only within the network of the cluster.
+	InternalIngressLoadBalancerScope IngressLoadBalancerScope = "Internal"
+)
+
+// IngressLoadBalancer configures the load balancer of an ingress controller.
+type IngressLoadBalancer struct {
+	// Scope is the scope at which the load balancer is exposed.
+	// +kubebuilder:validation:Enum=External;Internal
+	Scope IngressLoadBalancerScope `json:"scope"`
+
+	// Annotations are added to the LoadBalancer service of the ingress controller, for example to configure
+	// cloud provider specific load balancer settings.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// DNSRouting configures the records with a routing policy of a cluster on the platform of its managed DNS zone.
+type DNSRouting struct {
+	// AWS configures Route53 records with a routing policy and a health check.
+	// +optional
+	AWS *aws.DNSRouting `json:"aws,omitempty"`
 }
 
 // ControlPlaneConfigSpec contains additional configuration settings for a target
@@ -574,6 +1078,106 @@ type ControlPlaneConfigSpec struct {
 	// active, Hive will use the override URL for further communications with the API server of the remote cluster.
 	// +optional
 	APIURLOverride string `json:"apiURLOverride,omitempty"`
+
+	// APIURLOverrideFailback configures how Hive fails back to the API URL override after falling back to the
+	// initial API URL because the override was unreachable. When omitted, Hive probes the override with an
+	// exponential backoff and fails back to it after the first successful probe.
+	// +optional
+	APIURLOverrideFailback *APIURLOverrideFailback `json:"apiURLOverrideFailback,omitempty"`
+
+	// SSHBastion configures Hive to tunnel the communication with the API server of the remote cluster through an
+	// SSH bastion host. This is meant for environments where the API server can be reached neither over a public
+	// endpoint nor over a private endpoint such as AWS PrivateLink.
+	// +optional
+	SSHBastion *SSHBastion `json:"sshBastion,omitempty"`
+
+	// AuditLog configures the audit policy of the API servers of the target cluster and the forwarding of its
+	// audit logs.
+	// +optional
+	AuditLog *AuditLogConfig `json:"auditLog,omitempty"`
+}
+
+// AuditLogConfig specifies the audit policy of the API servers of a target cluster and where its audit logs are
+// forwarded.
+type AuditLogConfig struct {
+	// Profile is the audit policy profile that is set on the APIServer configuration of the cluster. When omitted,
+	// the audit policy of the cluster is left unchanged.
+	// +kubebuilder:validation:Enum=Default;WriteRequestBodies;AllRequestBodies
+	// +optional
+	Profile AuditProfileType `json:"profile,omitempty"`
+
+	// Outputs are the external log stores to which the audit logs of the cluster are forwarded. Forwarding requires
+	// the Red Hat OpenShift Logging operator to be installed on the cluster, and replaces the ClusterLogForwarder
+	// named "instance" in the openshift-logging namespace of the cluster.
+	// +optional
+	Outputs []AuditLogOutput `json:"outputs,omitempty"`
+}
+
+// AuditProfileType is a profile of the audit policy of the API servers of a cluster.
+type AuditProfileType string
+
+const (
+	// DefaultAuditProfileType logs only metadata for read and write requests.
+	DefaultAuditProfileType AuditProfileType = "Default"
+
+	// WriteRequestBodiesAuditProfileType additionally logs the request bodies of write requests.
+	WriteRequestBodiesAuditProfileType AuditProfileType = "WriteRequestBodies"
+
+	// AllRequestBodiesAuditProfileType additionally logs the request bodies of read and write requests.
+	AllRequestBodiesAuditProfileType AuditProfileType = "AllRequestBodies"
+)
+
+// AuditLogOutput is an external log store to which audit logs are forwarded.
+type AuditLogOutput struct {
+	// Name is the name of the output in the ClusterLogForwarder of the cluster.
+	Name string `json:"name"`
+
+	// Type is the type of the log store.
+	// +kubebuilder:validation:Enum=elasticsearch;fluentdForward;syslog;kafka;loki;cloudwatch
+	Type string `json:"type"`
+
+	// URL is the URL of the log store.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// SecretRef is a reference to a secret in the ClusterDeployment's namespace with the credentials or
+	// certificates used to connect to the log store. The secret is copied to the openshift-logging namespace of the
+	// cluster.
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+}
+
+// APIURLOverrideFailback configures the failback to the API URL override.
+type APIURLOverrideFailback struct {
+	// ProbeInterval is the interval at which Hive probes the API URL override while communicating with the remote
+	// cluster via the initial API URL. When omitted, the probes back off exponentially.
+	// +optional
+	ProbeInterval *metav1.Duration `json:"probeInterval,omitempty"`
+
+	// HealthyProbes is the number of consecutive successful probes of the API URL override after which Hive fails
+	// back to it. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	HealthyProbes int32 `json:"healthyProbes,omitempty"`
+}
+
+// SSHBastion specifies an SSH bastion host through which Hive reaches the API server of the remote cluster.
+type SSHBastion struct {
+	// Host is the address of the bastion host, in the form host or host:port. The port defaults to 22.
+	Host string `json:"host"`
+
+	// User is the user to authenticate as on the bastion host.
+	User string `json:"user"`
+
+	// KeySecretRef is a reference to a secret in the ClusterDeployment's namespace that contains the private key
+	// used to authenticate with the bastion host under the "ssh-privatekey" key. The secret may also contain the
+	// public keys of the bastion host, in known_hosts format, under the "ssh-knownhosts" key.
+	KeySecretRef corev1.LocalObjectReference `json:"keySecretRef"`
+
+	// InsecureIgnoreHostKey allows connecting to the bastion host without verifying its host key when the key
+	// secret does not contain the known hosts.
+	// +optional
+	InsecureIgnoreHostKey bool `json:"insecureIgnoreHostKey,omitempty"`
 }
 
 // ControlPlaneServingCertificateSpec specifies serving certificate settings for
@@ -0,0 +1,75 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ClusterDeploymentSummaryName is the name of the one and only ClusterDeploymentSummary, which is maintained by
+	// Hive.
+	ClusterDeploymentSummaryName = "cluster"
+)
+
+// ClusterDeploymentSummarySpec defines the desired state of ClusterDeploymentSummary
+type ClusterDeploymentSummarySpec struct {
+}
+
+// ClusterDeploymentSummaryStatus defines the observed state of ClusterDeploymentSummary
+type ClusterDeploymentSummaryStatus struct {
+	// LastUpdated is the last time that the summary was updated.
+	// +optional
+	LastUpdated *metav1.Time `json:"lastUpdated,omitempty"`
+
+	// Total is the number of ClusterDeployments.
+	Total int32 `json:"total"`
+
+	// Health is the number of ClusterDeployments in each of the following states: Provisioning, ProvisionFailed,
+	// Healthy, Unreachable and Deprovisioning.
+	// +optional
+	Health map[string]int32 `json:"health,omitempty"`
+
+	// PowerStates is the number of installed ClusterDeployments in each power state, as set in their spec.
+	// +optional
+	PowerStates map[string]int32 `json:"powerStates,omitempty"`
+
+	// Versions is the number of installed ClusterDeployments running each version of OpenShift.
+	// +optional
+	Versions map[string]int32 `json:"versions,omitempty"`
+
+	// Platforms is the number of ClusterDeployments on each platform.
+	// +optional
+	Platforms map[string]int32 `json:"platforms,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterDeploymentSummary aggregates counts of all of the ClusterDeployments on the hub, so that an overview of the
+// fleet can be shown without listing every ClusterDeployment. There is a single ClusterDeploymentSummary named
+// cluster, which is maintained by Hive.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Total",type="integer",JSONPath=".status.total"
+// +kubebuilder:printcolumn:name="LastUpdated",type="date",JSONPath=".status.lastUpdated"
+// +kubebuilder:resource:path=clusterdeploymentsummaries,scope=Cluster
+type ClusterDeploymentSummary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterDeploymentSummarySpec   `json:"spec,omitempty"`
+	Status ClusterDeploymentSummaryStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterDeploymentSummaryList contains a list of ClusterDeploymentSummary
+type ClusterDeploymentSummaryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterDeploymentSummary `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterDeploymentSummary{}, &ClusterDeploymentSummaryList{})
+}
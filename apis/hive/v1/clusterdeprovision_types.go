@@ -2,6 +2,7 @@ package v1
 
 import (
 	"github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/apis/hive/v1/nutanix"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -43,6 +44,10 @@ type ClusterDeprovisionPlatform struct {
 	VSphere *VSphereClusterDeprovision `json:"vsphere,omitempty"`
 	// Ovirt contains oVirt-specific deprovision settings
 	Ovirt *OvirtClusterDeprovision `json:"ovirt,omitempty"`
+	// Nutanix contains Nutanix-specific deprovision settings
+	Nutanix *NutanixClusterDeprovision `json:"nutanix,omitempty"`
+	// PowerVS contains IBM Power Virtual Server-specific deprovision settings
+	PowerVS *PowerVSClusterDeprovision `json:"powervs,omitempty"`
 }
 
 // AWSClusterDeprovision contains AWS-specific configuration for a ClusterDeprovision
@@ -58,12 +63,25 @@ type AWSClusterDeprovision struct {
 	// AWS account access for deprovisioning the cluster.
 	// +optional
 	CredentialsAssumeRole *aws.AssumeRole `json:"credentialsAssumeRole,omitempty"`
+
+	// CredentialsSource mints short-lived AWS account access credentials for deprovisioning the cluster with the
+	// credentials broker of Hive.
+	// +optional
+	CredentialsSource *aws.CredentialsSource `json:"credentialsSource,omitempty"`
+
+	// ServiceEndpoints overrides the endpoints of the AWS services used for deprovisioning the cluster.
+	// +optional
+	ServiceEndpoints []aws.ServiceEndpoint `json:"serviceEndpoints,omitempty"`
 }
 
 // AzureClusterDeprovision contains Azure-specific configuration for a ClusterDeprovision
 type AzureClusterDeprovision struct {
 	// CredentialsSecretRef is the Azure account credentials to use for deprovisioning the cluster
 	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+	// ResourceGroupName is the existing resource group into which the cluster was installed. When set, only the
+	// resources owned by the cluster are deleted from the resource group, rather than the whole resource group.
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
 }
 
 // GCPClusterDeprovision contains GCP-specific configuration for a ClusterDeprovision
@@ -72,6 +90,10 @@ type GCPClusterDeprovision struct {
 	Region string `json:"region"`
 	// CredentialsSecretRef is the GCP account credentials to use for deprovisioning the cluster
 	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+	// NetworkProjectID is the host project of the Shared VPC of the cluster, in which the firewall rules and private
+	// DNS zone of the cluster are also deleted.
+	// +optional
+	NetworkProjectID string `json:"networkProjectID,omitempty"`
 }
 
 // OpenStackClusterDeprovision contains OpenStack-specific configuration for a ClusterDeprovision
@@ -87,6 +109,17 @@ type OpenStackClusterDeprovision struct {
 	CertificatesSecretRef *corev1.LocalObjectReference `json:"certificatesSecretRef,omitempty"`
 }
 
+// PowerVSClusterDeprovision contains IBM Power Virtual Server-specific configuration for a ClusterDeprovision
+type PowerVSClusterDeprovision struct {
+	// Region is the IBM Cloud region of the cluster
+	Region string `json:"region"`
+	// ServiceInstanceID is the GUID of the Power Virtual Server workspace of the cluster
+	ServiceInstanceID string `json:"serviceInstanceID"`
+	// CredentialsSecretRef is the IBM Cloud credentials to use for deprovisioning the cluster
+	// secret fields: ibmcloud_api_key
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+}
+
 // VSphereClusterDeprovision contains VMware vSphere-specific configuration for a ClusterDeprovision
 type VSphereClusterDeprovision struct {
 	// CredentialsSecretRef is the vSphere account credentials to use for deprovisioning the cluster
@@ -110,6 +143,19 @@ type OvirtClusterDeprovision struct {
 	CertificatesSecretRef corev1.LocalObjectReference `json:"certificatesSecretRef"`
 }
 
+// NutanixClusterDeprovision contains Nutanix-specific configuration for a ClusterDeprovision
+type NutanixClusterDeprovision struct {
+	// PrismCentral is the endpoint of the Prism Central managing the virtual machines of the cluster.
+	PrismCentral nutanix.PrismEndpoint `json:"prismCentral"`
+	// CredentialsSecretRef is the Prism Central account credentials to use for deprovisioning the cluster
+	// secret fields: username, password
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+	// CertificatesSecretRef refers to a secret that contains the CA certificates
+	// necessary for communicating with Prism Central.
+	// +optional
+	CertificatesSecretRef *corev1.LocalObjectReference `json:"certificatesSecretRef,omitempty"`
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -12,7 +13,48 @@ type ClusterImageSetSpec struct {
 }
 
 // ClusterImageSetStatus defines the observed state of ClusterImageSet
-type ClusterImageSetStatus struct{}
+type ClusterImageSetStatus struct {
+	// ObservedReleaseImage is the release image that was last validated.
+	// +optional
+	ObservedReleaseImage string `json:"observedReleaseImage,omitempty"`
+
+	// Architectures are the architectures of the release image.
+	// +optional
+	Architectures []string `json:"architectures,omitempty"`
+
+	// Conditions includes more detailed status for the cluster image set.
+	// +optional
+	Conditions []ClusterImageSetCondition `json:"conditions,omitempty"`
+}
+
+// ClusterImageSetCondition contains details for the current condition of a cluster image set.
+type ClusterImageSetCondition struct {
+	// Type is the type of the condition.
+	Type ClusterImageSetConditionType `json:"type"`
+	// Status is the status of the condition.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastProbeTime is the last time we probed the condition.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterImageSetConditionType is a valid value for ClusterImageSetCondition.Type
+type ClusterImageSetConditionType string
+
+const (
+	// ValidClusterImageSetCondition is true when the release image exists, can be pulled with the global pull
+	// secret, and is of an allowed architecture.
+	ValidClusterImageSetCondition ClusterImageSetConditionType = "Valid"
+)
 
 // +genclient:nonNamespaced
 // +genclient
@@ -23,6 +65,7 @@ type ClusterImageSetStatus struct{}
 // +kubebuilder:resource:scope=Cluster
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Release",type="string",JSONPath=".spec.releaseImage"
+// +kubebuilder:printcolumn:name="Valid",type="string",JSONPath=".status.conditions[?(@.type=='Valid')].status"
 // +kubebuilder:resource:path=clusterimagesets,shortName=imgset,scope=Cluster
 type ClusterImageSet struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -71,8 +71,51 @@ type ClusterPoolSpec struct {
 	// ClaimLifetime defines the lifetimes for claims for the cluster pool.
 	// +optional
 	ClaimLifetime *ClusterPoolClaimLifetime `json:"claimLifetime,omitempty"`
+
+	// StaleClusterPolicy controls what happens to unclaimed clusters that were created from an earlier version of the
+	// pool spec. With Replace, stale clusters are deleted one at a time, and replaced with clusters created from the
+	// current pool spec, once the pool is otherwise at its desired size. With Keep, stale clusters are left alone and
+	// changes to the pool spec only affect newly provisioned clusters.
+	// Only changes to platform, imageSetRef and installConfigSecretTemplateRef make clusters stale.
+	// Defaults to Keep.
+	// +optional
+	StaleClusterPolicy StaleClusterPolicy `json:"staleClusterPolicy,omitempty"`
+
+	// Architecture is the architecture of the clusters created for the pool. The ClusterImageSet of the pool must
+	// support the architecture, as recorded in its status by release image validation, and the instance types of
+	// the InstallConfigSecretTemplateRef must match it. With amd64 or arm64, the control plane and compute machines
+	// of the clusters use the architecture. With multi, the ClusterImageSet must be a multi-architecture release and
+	// the architecture of the machines is taken from the InstallConfigSecretTemplateRef.
+	// When omitted, the architecture is not checked.
+	// +optional
+	Architecture PoolArchitecture `json:"architecture,omitempty"`
 }
 
+// PoolArchitecture is the architecture of the clusters of a ClusterPool.
+// +kubebuilder:validation:Enum=amd64;arm64;multi
+type PoolArchitecture string
+
+const (
+	// AMD64PoolArchitecture is used for pools of clusters running on amd64 (x86_64) machines.
+	AMD64PoolArchitecture PoolArchitecture = "amd64"
+	// ARM64PoolArchitecture is used for pools of clusters running on arm64 (aarch64) machines.
+	ARM64PoolArchitecture PoolArchitecture = "arm64"
+	// MultiPoolArchitecture is used for pools of clusters installed from multi-architecture releases.
+	MultiPoolArchitecture PoolArchitecture = "multi"
+)
+
+// StaleClusterPolicy is a policy for handling unclaimed clusters that were created from an earlier version of the
+// pool spec.
+// +kubebuilder:validation:Enum=Replace;Keep
+type StaleClusterPolicy string
+
+const (
+	// ReplaceStaleClusterPolicy replaces stale unclaimed clusters with clusters created from the current pool spec.
+	ReplaceStaleClusterPolicy StaleClusterPolicy = "Replace"
+	// KeepStaleClusterPolicy keeps stale unclaimed clusters in the pool until they are claimed.
+	KeepStaleClusterPolicy StaleClusterPolicy = "Keep"
+)
+
 // ClusterPoolClaimLifetime defines the lifetimes for claims for the cluster pool.
 type ClusterPoolClaimLifetime struct {
 	// Default is the default lifetime of the claim when no lifetime is set on the claim itself.
@@ -129,6 +172,9 @@ const (
 	// ClusterPoolCapacityAvailableCondition is set to provide information on whether the cluster pool has capacity
 	// available to create more clusters for the pool.
 	ClusterPoolCapacityAvailableCondition ClusterPoolConditionType = "CapacityAvailable"
+	// ClusterPoolAllClustersCurrentCondition is set to provide information on whether all of the unclaimed clusters
+	// in the pool were created from the current version of the pool spec.
+	ClusterPoolAllClustersCurrentCondition ClusterPoolConditionType = "AllClustersCurrent"
 )
 
 // +genclient
@@ -143,7 +189,9 @@ const (
 // +kubebuilder:printcolumn:name="Size",type="string",JSONPath=".spec.size"
 // +kubebuilder:printcolumn:name="BaseDomain",type="string",JSONPath=".spec.baseDomain"
 // +kubebuilder:printcolumn:name="ImageSet",type="string",JSONPath=".spec.imageSetRef.name"
+// +kubebuilder:printcolumn:name="Architecture",type="string",JSONPath=".spec.architecture",priority=1
 // +kubebuilder:resource:path=clusterpools,shortName=cp
+// +kubebuilder:storageversion
 type ClusterPool struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -44,6 +44,11 @@ type ClusterProvisionSpec struct {
 
 	// PrevInfraID is the infra ID of the previous failed provision attempt.
 	PrevInfraID *string `json:"prevInfraID,omitempty"`
+
+	// InstallerAssetsRef references the ConfigMap containing the install-config and the manifests used by the
+	// installer, with any credentials removed. It is kept for debugging failed installs.
+	// +optional
+	InstallerAssetsRef *corev1.LocalObjectReference `json:"installerAssetsRef,omitempty"`
 }
 
 // ClusterProvisionStatus defines the observed state of ClusterProvision.
@@ -54,6 +59,38 @@ type ClusterProvisionStatus struct {
 	// Conditions includes more detailed status for the cluster provision
 	// +optional
 	Conditions []ClusterProvisionCondition `json:"conditions,omitempty"`
+
+	// InstallPhases are the timings of the phases of the install, parsed from the install log once the install job
+	// has finished. Only the phases that completed are listed.
+	// +optional
+	InstallPhases []InstallPhase `json:"installPhases,omitempty"`
+}
+
+// InstallPhaseName is the name of a phase of the install.
+// +kubebuilder:validation:Enum=Infrastructure;Bootstrap;ClusterOperators
+type InstallPhaseName string
+
+const (
+	// InfrastructureInstallPhase is the creation of the cloud infrastructure of the cluster, up to the bootstrap
+	// machine serving the Kubernetes API.
+	InfrastructureInstallPhase InstallPhaseName = "Infrastructure"
+	// BootstrapInstallPhase is the bootstrapping of the control plane, from the Kubernetes API being served until
+	// the bootstrap resources can be removed.
+	BootstrapInstallPhase InstallPhaseName = "Bootstrap"
+	// ClusterOperatorsInstallPhase is the initialization of the cluster, until all cluster operators are stable.
+	ClusterOperatorsInstallPhase InstallPhaseName = "ClusterOperators"
+)
+
+// InstallPhase is the timing of a phase of the install.
+type InstallPhase struct {
+	// Name is the name of the phase.
+	Name InstallPhaseName `json:"name"`
+	// StartTime is when the phase started.
+	StartTime metav1.Time `json:"startTime"`
+	// CompletionTime is when the phase completed.
+	CompletionTime metav1.Time `json:"completionTime"`
+	// Duration is how long the phase took.
+	Duration metav1.Duration `json:"duration"`
 }
 
 // ClusterProvisionStage is the stage of provisioning.
@@ -12,6 +12,36 @@ type ClusterRelocateSpec struct {
 
 	// ClusterDeploymentSelector is a LabelSelector indicating which clusters will be relocated.
 	ClusterDeploymentSelector metav1.LabelSelector `json:"clusterDeploymentSelector"`
+
+	// NamespaceSelector is a LabelSelector limiting the clusters relocated to those in namespaces with matching
+	// labels. When not set, clusters in all namespaces are relocated.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Batching relocates the matching clusters in batches rather than all at once. When not set, all matching
+	// clusters are relocated at once.
+	// +optional
+	Batching *ClusterRelocateBatching `json:"batching,omitempty"`
+}
+
+// ClusterRelocateBatching defines how the clusters matching a ClusterRelocate are relocated in batches. A batch
+// starts once the previous batch has finished, which is when all of its clusters have been relocated or no longer
+// match the ClusterRelocate.
+type ClusterRelocateBatching struct {
+	// Size is the maximum number of clusters relocated in a batch.
+	// +kubebuilder:validation:Minimum=1
+	Size int `json:"size"`
+
+	// Interval is the minimum time to wait after a batch has finished before starting the next batch.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// ApprovedBatches is the number of batches approved to start. When set, no batch is started once this number of
+	// batches have been started, until it is raised. This allows each batch to be confirmed before it starts. When
+	// not set, batches are started without approval.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ApprovedBatches *int `json:"approvedBatches,omitempty"`
 }
 
 // KubeconfigSecretReference is a reference to a secret containing the kubeconfig for a remote cluster.
@@ -23,7 +53,37 @@ type KubeconfigSecretReference struct {
 }
 
 // ClusterRelocateStatus defines the observed state of ClusterRelocate.
-type ClusterRelocateStatus struct{}
+type ClusterRelocateStatus struct {
+	// Batch is the number of the current batch of a batched relocation, starting at 1.
+	// +optional
+	Batch int `json:"batch,omitempty"`
+
+	// BatchPhase is the phase of the current batch of a batched relocation.
+	// +optional
+	BatchPhase ClusterRelocateBatchPhase `json:"batchPhase,omitempty"`
+
+	// BatchClusters are the clusters of the current batch of a batched relocation, as namespace/name.
+	// +optional
+	BatchClusters []string `json:"batchClusters,omitempty"`
+
+	// BatchCompletionTime is when the current batch of a batched relocation finished.
+	// +optional
+	BatchCompletionTime *metav1.Time `json:"batchCompletionTime,omitempty"`
+}
+
+// ClusterRelocateBatchPhase is the phase of the current batch of a batched relocation.
+type ClusterRelocateBatchPhase string
+
+const (
+	// RelocatingClusterRelocateBatchPhase is the phase of a batch whose clusters are being relocated.
+	RelocatingClusterRelocateBatchPhase ClusterRelocateBatchPhase = "Relocating"
+	// PausedClusterRelocateBatchPhase is the phase of a finished batch while waiting for the batching interval to
+	// elapse before the next batch.
+	PausedClusterRelocateBatchPhase ClusterRelocateBatchPhase = "Paused"
+	// AwaitingApprovalClusterRelocateBatchPhase is the phase of a finished batch while waiting for the next batch to
+	// be approved.
+	AwaitingApprovalClusterRelocateBatchPhase ClusterRelocateBatchPhase = "AwaitingApproval"
+)
 
 // +genclient:nonNamespaced
 // +genclient
@@ -33,6 +93,8 @@ type ClusterRelocateStatus struct{}
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Selector",type="string",JSONPath=".spec.clusterDeploymentSelector"
+// +kubebuilder:printcolumn:name="Batch",type="integer",JSONPath=".status.batch"
+// +kubebuilder:printcolumn:name="BatchPhase",type="string",JSONPath=".status.batchPhase"
 // +kubebuilder:resource:path=clusterrelocates
 type ClusterRelocate struct {
 	metav1.TypeMeta   `json:",inline"`
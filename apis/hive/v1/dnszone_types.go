@@ -2,6 +2,7 @@ package v1
 
 import (
 	"github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/apis/hive/v1/azure"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -27,6 +28,41 @@ type DNSZoneSpec struct {
 	// +optional
 	LinkToParentDomain bool `json:"linkToParentDomain,omitempty"`
 
+	// PreserveOnDelete specifies whether the zone should be left in the DNS provider when the DNSZone is deleted. Set it
+	// for zones that Hive adopted but does not own, so that deleting the DNSZone only disconnects the zone from Hive.
+	// +optional
+	PreserveOnDelete bool `json:"preserveOnDelete,omitempty"`
+
+	// RecordCleanupPolicy specifies what happens to the records of the zone when the DNSZone is deleted. With
+	// Delete, the records are deleted along with the zone. With Fail, the zone is not deleted while it has records
+	// other than the NS and SOA records created with it and the records matching PreservedRecordNames; the
+	// ZoneDeletionBlocked condition lists them instead, so that they can be reviewed and removed.
+	// Defaults to Delete.
+	// +kubebuilder:validation:Enum=Delete;Fail
+	// +optional
+	RecordCleanupPolicy DNSZoneRecordCleanupPolicy `json:"recordCleanupPolicy,omitempty"`
+
+	// PreservedRecordNames are glob patterns, such as "_acme-challenge.*", of the fully qualified names of records
+	// that Hive never deletes, without the trailing dot. As a zone cannot be deleted while it has records, deletion
+	// of the DNSZone is blocked until the preserved records are removed from the zone.
+	// +optional
+	PreservedRecordNames []string `json:"preservedRecordNames,omitempty"`
+
+	// PublishRecordSetSummary specifies whether the record sets of the zone should be enumerated on each sync, and
+	// summarized in Status.RecordSetSummary. An event is emitted when record sets are added to or removed from the
+	// zone, to detect drift or stray records that would block the deletion of the zone.
+	// +optional
+	PublishRecordSetSummary bool `json:"publishRecordSetSummary,omitempty"`
+
+	// CloudClientProxySecretRef refers to a secret in the namespace of the DNSZone with the proxy configuration, and the
+	// additional trusted certificate authorities, of the clients with which Hive calls the cloud APIs for the zone. The
+	// secret may have the httpProxy, httpsProxy and noProxy keys, and a PEM-encoded certificate bundle under the ca.crt
+	// key. It takes precedence over the CloudClientProxy of HiveConfig.
+	// Calls made with credentials of Hive, such as its service provider credentials or those minted by the credentials
+	// broker, do not use it.
+	// +optional
+	CloudClientProxySecretRef *corev1.LocalObjectReference `json:"cloudClientProxySecretRef,omitempty"`
+
 	// AWS specifies AWS-specific cloud configuration
 	// +optional
 	AWS *AWSDNSZoneSpec `json:"aws,omitempty"`
@@ -38,8 +74,28 @@ type DNSZoneSpec struct {
 	// Azure specifes Azure-specific cloud configuration
 	// +optional
 	Azure *AzureDNSZoneSpec `json:"azure,omitempty"`
+
+	// IBMCloud specifies IBM Cloud-specific cloud configuration
+	// +optional
+	IBMCloud *IBMCloudDNSZoneSpec `json:"ibmcloud,omitempty"`
+
+	// Webhook specifies an external DNS webhook that manages the zone in a DNS provider that Hive does not support
+	// natively
+	// +optional
+	Webhook *WebhookDNSZoneSpec `json:"webhook,omitempty"`
 }
 
+// DNSZoneRecordCleanupPolicy is the policy for the records of a zone when its DNSZone is deleted.
+type DNSZoneRecordCleanupPolicy string
+
+const (
+	// DeleteDNSZoneRecordCleanupPolicy deletes the records of the zone along with the zone.
+	DeleteDNSZoneRecordCleanupPolicy DNSZoneRecordCleanupPolicy = "Delete"
+
+	// FailDNSZoneRecordCleanupPolicy blocks the deletion of the zone while it has records that Hive would delete.
+	FailDNSZoneRecordCleanupPolicy DNSZoneRecordCleanupPolicy = "Fail"
+)
+
 // AWSDNSZoneSpec contains AWS-specific DNSZone specifications
 type AWSDNSZoneSpec struct {
 	// CredentialsSecretRef contains a reference to a secret that contains AWS credentials
@@ -52,6 +108,23 @@ type AWSDNSZoneSpec struct {
 	// +optional
 	CredentialsAssumeRole *aws.AssumeRole `json:"credentialsAssumeRole,omitempty"`
 
+	// CredentialsSource mints short-lived AWS credentials for the DNS CRUD operations with the credentials broker
+	// of Hive. It takes precedence over CredentialsSecretRef and CredentialsAssumeRole.
+	// +optional
+	CredentialsSource *aws.CredentialsSource `json:"credentialsSource,omitempty"`
+
+	// ServiceEndpoints overrides the endpoints of the AWS services used for the DNS CRUD operations, such as
+	// route53 and tagging.
+	// +optional
+	ServiceEndpoints []aws.ServiceEndpoint `json:"serviceEndpoints,omitempty"`
+
+	// AssumeRole is an IAM role, usually in another AWS account, that is assumed using the credentials of
+	// CredentialsSecretRef, CredentialsAssumeRole or CredentialsSource. All Route53 and tagging calls for the zone are made as the
+	// assumed role. Use it to manage hosted zones kept in an account separate from the cluster account, such as a
+	// central networking account.
+	// +optional
+	AssumeRole *aws.AssumeRole `json:"assumeRole,omitempty"`
+
 	// AdditionalTags is a set of additional tags to set on the DNS hosted zone. In addition
 	// to these tags,the DNS Zone controller will set a hive.openhsift.io/hostedzone tag
 	// identifying the HostedZone record that it belongs to.
@@ -59,9 +132,73 @@ type AWSDNSZoneSpec struct {
 
 	// Region is the AWS region to use for route53 operations.
 	// This defaults to us-east-1.
-	// For AWS China, use cn-northwest-1.
+	// For AWS China, use cn-northwest-1. For AWS GovCloud, use us-gov-west-1. For C2S, use us-iso-east-1, and for
+	// SC2S, us-isob-east-1.
 	// +optional
 	Region string `json:"region,omitempty"`
+
+	// ZoneType is the type of the hosted zone. A Public zone is resolvable from the internet. A Private zone is
+	// resolvable only from the VPCs associated with it.
+	// Defaults to Public.
+	// +kubebuilder:validation:Enum=Public;Private
+	// +optional
+	ZoneType AWSDNSZoneType `json:"zoneType,omitempty"`
+
+	// VPCs are the VPCs associated with a Private zone. A Private zone must be associated with at least one VPC.
+	// VPCs associated with the zone that are removed from the list are disassociated.
+	// +optional
+	VPCs []AWSDNSZoneVPC `json:"vpcs,omitempty"`
+
+	// EnableDNSSEC enables DNSSEC signing of the hosted zone. Hive creates a key-signing key backed by the KMS key
+	// of DNSSECKMSKeyARN and enables signing once the key is active. The DS record to add to the parent zone is
+	// reported in the status. Unsetting it disables signing and deletes the key-signing key created by Hive.
+	// +optional
+	EnableDNSSEC bool `json:"enableDNSSEC,omitempty"`
+
+	// DNSSECKMSKeyARN is the ARN of the customer managed KMS key backing the key-signing key of the zone. The key
+	// must be in us-east-1, have the ECC_NIST_P256 key spec and the SIGN_VERIFY key usage, and allow the
+	// dnssec-route53.amazonaws.com service to use it. Required when EnableDNSSEC is set.
+	// +optional
+	DNSSECKMSKeyARN string `json:"dnssecKMSKeyARN,omitempty"`
+
+	// QueryLogging enables Route53 query logging of the public hosted zone to a CloudWatch Logs log group. Removing
+	// it deletes the query logging configuration created by Hive.
+	// +optional
+	QueryLogging *AWSDNSZoneQueryLogging `json:"queryLogging,omitempty"`
+
+	// ZoneID is the ID of an existing hosted zone to adopt instead of creating a new one, for hosted zones created
+	// outside of Hive. The name of the hosted zone must match Zone. Once adopted, the hosted zone is tagged and
+	// managed like the hosted zones created by Hive; set PreserveOnDelete to keep it when the DNSZone is deleted.
+	// +optional
+	ZoneID string `json:"zoneID,omitempty"`
+}
+
+// AWSDNSZoneType is the type of an AWS Route53 hosted zone.
+type AWSDNSZoneType string
+
+const (
+	// AWSPublicDNSZoneType is the type of Route53 hosted zones resolvable from the internet.
+	AWSPublicDNSZoneType AWSDNSZoneType = "Public"
+
+	// AWSPrivateDNSZoneType is the type of Route53 private hosted zones, resolvable only from associated VPCs.
+	AWSPrivateDNSZoneType AWSDNSZoneType = "Private"
+)
+
+// AWSDNSZoneQueryLogging configures Route53 query logging of a hosted zone.
+type AWSDNSZoneQueryLogging struct {
+	// CloudWatchLogsLogGroupARN is the ARN of the CloudWatch Logs log group receiving the DNS query logs. The log
+	// group must be in us-east-1, and its resource policy must allow the route53.amazonaws.com service to create log
+	// streams and put log events in it.
+	CloudWatchLogsLogGroupARN string `json:"cloudWatchLogsLogGroupARN"`
+}
+
+// AWSDNSZoneVPC is a VPC associated with a Route53 private hosted zone.
+type AWSDNSZoneVPC struct {
+	// VPCID is the ID of the VPC.
+	VPCID string `json:"vpcID"`
+
+	// Region is the region of the VPC.
+	Region string `json:"region"`
 }
 
 // AWSResourceTag represents a tag that is applied to an AWS cloud resource
@@ -79,6 +216,55 @@ type GCPDNSZoneSpec struct {
 	// Secret should have a key named 'osServiceAccount.json'.
 	// The credentials must specify the project to use.
 	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// ZoneName is the name of an existing managed zone to adopt instead of creating a new one, for managed zones
+	// created outside of Hive. The DNS name of the managed zone must match Zone. Set PreserveOnDelete to keep the
+	// managed zone when the DNSZone is deleted.
+	// +optional
+	ZoneName string `json:"zoneName,omitempty"`
+
+	// ZoneType is the type of the managed zone. A Public zone is resolvable from the internet. A Private zone has
+	// private visibility, and is resolvable only from the VPC networks attached to it.
+	// Defaults to Public.
+	// +kubebuilder:validation:Enum=Public;Private
+	// +optional
+	ZoneType GCPDNSZoneType `json:"zoneType,omitempty"`
+
+	// Networks are the VPC networks attached to a Private zone. A Private zone must be attached to at least one
+	// network. Networks removed from the list are detached from the zone.
+	// +optional
+	Networks []GCPDNSZoneNetwork `json:"networks,omitempty"`
+
+	// Peering makes a Private zone a DNS peering zone, whose names are resolved by the DNS of the target network
+	// rather than by records of the zone itself. It cannot be changed after the DNSZone is created.
+	// +optional
+	Peering *GCPDNSZonePeering `json:"peering,omitempty"`
+}
+
+// GCPDNSZoneType is the type of a GCP Cloud DNS managed zone.
+type GCPDNSZoneType string
+
+const (
+	// GCPPublicDNSZoneType is the type of Cloud DNS managed zones resolvable from the internet.
+	GCPPublicDNSZoneType GCPDNSZoneType = "Public"
+
+	// GCPPrivateDNSZoneType is the type of Cloud DNS managed zones with private visibility, resolvable only from
+	// attached networks.
+	GCPPrivateDNSZoneType GCPDNSZoneType = "Private"
+)
+
+// GCPDNSZoneNetwork is a VPC network attached to a Cloud DNS private managed zone.
+type GCPDNSZoneNetwork struct {
+	// NetworkURL is the URL of the network, for example
+	// https://www.googleapis.com/compute/v1/projects/<project>/global/networks/<network>.
+	NetworkURL string `json:"networkURL"`
+}
+
+// GCPDNSZonePeering is the DNS peering configuration of a Cloud DNS private managed zone.
+type GCPDNSZonePeering struct {
+	// TargetNetworkURL is the URL of the network whose DNS resolves the names of the zone, for example
+	// https://www.googleapis.com/compute/v1/projects/<project>/global/networks/<network>.
+	TargetNetworkURL string `json:"targetNetworkURL"`
 }
 
 // AzureDNSZoneSpec contains Azure-specific DNSZone specifications
@@ -91,6 +277,78 @@ type AzureDNSZoneSpec struct {
 
 	// ResourceGroupName specifies the Azure resource group in which the Hosted Zone should be created.
 	ResourceGroupName string `json:"resourceGroupName"`
+
+	// CloudName is the name of the Azure cloud environment of the zone, which sets the Azure API endpoints used to
+	// manage it. Defaults to AzurePublicCloud.
+	// +optional
+	CloudName azure.CloudEnvironment `json:"cloudName,omitempty"`
+
+	// ZoneType is the type of the zone. A Public zone is an Azure DNS zone resolvable from the internet. A Private
+	// zone is an Azure Private DNS zone resolvable only from the virtual networks linked to it.
+	// Defaults to Public.
+	// +kubebuilder:validation:Enum=Public;Private
+	// +optional
+	ZoneType AzureDNSZoneType `json:"zoneType,omitempty"`
+
+	// VirtualNetworkLinks are the virtual networks linked to a Private zone. Links created by Hive that are removed
+	// from the list are deleted.
+	// +optional
+	VirtualNetworkLinks []AzureVirtualNetworkLink `json:"virtualNetworkLinks,omitempty"`
+}
+
+// AzureDNSZoneType is the type of an Azure DNS zone.
+type AzureDNSZoneType string
+
+const (
+	// AzurePublicDNSZoneType is the type of Azure DNS zones resolvable from the internet.
+	AzurePublicDNSZoneType AzureDNSZoneType = "Public"
+
+	// AzurePrivateDNSZoneType is the type of Azure Private DNS zones, resolvable only from linked virtual networks.
+	AzurePrivateDNSZoneType AzureDNSZoneType = "Private"
+)
+
+// AzureVirtualNetworkLink is a link of an Azure Private DNS zone to a virtual network.
+type AzureVirtualNetworkLink struct {
+	// Name is the name of the link.
+	Name string `json:"name"`
+
+	// VirtualNetworkID is the resource ID of the virtual network, for example
+	// /subscriptions/<subscription>/resourceGroups/<group>/providers/Microsoft.Network/virtualNetworks/<name>.
+	VirtualNetworkID string `json:"virtualNetworkID"`
+
+	// RegistrationEnabled enables the automatic registration of the records of the virtual machines of the virtual
+	// network in the zone.
+	// +optional
+	RegistrationEnabled bool `json:"registrationEnabled,omitempty"`
+}
+
+// IBMCloudDNSZoneSpec contains IBM Cloud-specific DNSZone specifications
+type IBMCloudDNSZoneSpec struct {
+	// CredentialsSecretRef references a secret that will be used to authenticate with
+	// IBM Cloud Internet Services. It will need permission to create and manage CIS domains.
+	// Secret should have a key named 'ibmcloud_api_key'.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// CISInstanceCRN is the CRN of the IBM Cloud Internet Services instance in which the zone should be created.
+	CISInstanceCRN string `json:"cisInstanceCRN"`
+}
+
+// WebhookDNSZoneSpec contains the specification of the external DNS webhook that manages a DNSZone. The webhook
+// implements the DNSZone webhook protocol described in the Hive documentation.
+type WebhookDNSZoneSpec struct {
+	// URL is the base URL of the webhook. Must be an https URL.
+	URL string `json:"url"`
+
+	// CASecretRef references a secret with a key named 'ca.crt' containing the PEM bundle of the certificate
+	// authorities used to verify the serving certificate of the webhook, in addition to the system certificate
+	// authorities.
+	// +optional
+	CASecretRef *corev1.LocalObjectReference `json:"caSecretRef,omitempty"`
+
+	// AuthSecretRef references a secret with a key named 'token' containing the bearer token sent to the webhook in
+	// the Authorization header of each request.
+	// +optional
+	AuthSecretRef *corev1.LocalObjectReference `json:"authSecretRef,omitempty"`
 }
 
 // DNSZoneStatus defines the observed state of DNSZone
@@ -108,6 +366,10 @@ type DNSZoneStatus struct {
 	// +optional
 	NameServers []string `json:"nameServers,omitempty"`
 
+	// RecordSetSummary summarizes the record sets of the zone, when Spec.PublishRecordSetSummary is set.
+	// +optional
+	RecordSetSummary *DNSZoneRecordSetSummary `json:"recordSetSummary,omitempty"`
+
 	// AWSDNSZoneStatus contains status information specific to AWS
 	// +optional
 	AWS *AWSDNSZoneStatus `json:"aws,omitempty"`
@@ -119,6 +381,10 @@ type DNSZoneStatus struct {
 	// AzureDNSZoneStatus contains status information specific to Azure
 	Azure *AzureDNSZoneStatus `json:"azure,omitempty"`
 
+	// IBMCloudDNSZoneStatus contains status information specific to IBM Cloud
+	// +optional
+	IBMCloud *IBMCloudDNSZoneStatus `json:"ibmcloud,omitempty"`
+
 	// Conditions includes more detailed status for the DNSZone
 	// +optional
 	Conditions []DNSZoneCondition `json:"conditions,omitempty"`
@@ -129,10 +395,62 @@ type AWSDNSZoneStatus struct {
 	// ZoneID is the ID of the zone in AWS
 	// +optional
 	ZoneID *string `json:"zoneID,omitempty"`
+
+	// DNSSEC is the DNSSEC status of the zone, when DNSSEC is enabled.
+	// +optional
+	DNSSEC *AWSDNSSECStatus `json:"dnssec,omitempty"`
+
+	// QueryLoggingConfigID is the ID of the query logging configuration of the zone, when query logging is enabled.
+	// +optional
+	QueryLoggingConfigID string `json:"queryLoggingConfigID,omitempty"`
+}
+
+// AWSDNSSECStatus contains the DNSSEC status of a Route53 hosted zone.
+type AWSDNSSECStatus struct {
+	// ServeSignature is the DNSSEC signing status of the zone, for example SIGNING or NOT_SIGNING.
+	// +optional
+	ServeSignature string `json:"serveSignature,omitempty"`
+
+	// KeySigningKeyStatus is the status of the key-signing key created by Hive, for example ACTIVE or
+	// ACTION_NEEDED.
+	// +optional
+	KeySigningKeyStatus string `json:"keySigningKeyStatus,omitempty"`
+
+	// StatusMessage is the message explaining the status of the zone or of the key-signing key, when there is one.
+	// +optional
+	StatusMessage string `json:"statusMessage,omitempty"`
+
+	// DSRecord is the DS record to add to the parent zone to establish the chain of trust.
+	// +optional
+	DSRecord string `json:"dsRecord,omitempty"`
 }
 
 // AzureDNSZoneStatus contains status information specific to Azure DNS zones
 type AzureDNSZoneStatus struct {
+	// VirtualNetworkLinks is the status of the virtual network links of a Private zone.
+	// +optional
+	VirtualNetworkLinks []AzureVirtualNetworkLinkStatus `json:"virtualNetworkLinks,omitempty"`
+}
+
+// AzureVirtualNetworkLinkStatus is the status of a link of an Azure Private DNS zone to a virtual network.
+type AzureVirtualNetworkLinkStatus struct {
+	// Name is the name of the link.
+	Name string `json:"name"`
+
+	// VirtualNetworkID is the resource ID of the virtual network.
+	VirtualNetworkID string `json:"virtualNetworkID"`
+
+	// State is the state of the link, InProgress while the virtual network is being linked, and Completed once
+	// it resolves the records of the zone.
+	// +optional
+	State string `json:"state,omitempty"`
+}
+
+// IBMCloudDNSZoneStatus contains status information specific to IBM Cloud Internet Services zones
+type IBMCloudDNSZoneStatus struct {
+	// ZoneID is the ID of the zone in IBM Cloud Internet Services
+	// +optional
+	ZoneID *string `json:"zoneID,omitempty"`
 }
 
 // GCPDNSZoneStatus contains status information specific to GCP Cloud DNS zones
@@ -142,6 +460,21 @@ type GCPDNSZoneStatus struct {
 	ZoneName *string `json:"zoneName,omitempty"`
 }
 
+// DNSZoneRecordSetSummary summarizes the record sets of a DNS zone.
+type DNSZoneRecordSetSummary struct {
+	// Count is the number of record sets in the zone, including the record sets managed by the DNS provider, such as
+	// the NS and SOA record sets of the zone.
+	Count int `json:"count"`
+
+	// Hash is a hash of the names and types of the record sets in the zone. It changes when record sets are added to
+	// or removed from the zone, but not when the records of a record set are changed.
+	Hash string `json:"hash"`
+
+	// LastChangeTime is the last time the record sets of the zone were observed to change.
+	// +optional
+	LastChangeTime *metav1.Time `json:"lastChangeTime,omitempty"`
+}
+
 // DNSZoneCondition contains details for the current condition of a DNSZone
 type DNSZoneCondition struct {
 	// Type is the type of the condition.
@@ -170,6 +503,13 @@ const (
 	ZoneAvailableDNSZoneCondition DNSZoneConditionType = "ZoneAvailable"
 	// ParentLinkCreatedCondition is true if the parent link has been created
 	ParentLinkCreatedCondition DNSZoneConditionType = "ParentLinkCreated"
+	// ParentLinkFailedCondition is true if the delegation from the parent domain no longer resolves to the name
+	// servers of an available zone, for instance because of a change at the registrar of the parent domain.
+	ParentLinkFailedCondition DNSZoneConditionType = "ParentLinkFailed"
+	// ParentLinkVerifiedCondition is true if the delegation from the parent domain, as resolved by the parent link
+	// resolvers, points to the name servers of the zone. It is checked as soon as the parent link is created, so that a
+	// broken delegation is reported before the installs of the clusters using the zone fail on it.
+	ParentLinkVerifiedCondition DNSZoneConditionType = "ParentLinkVerified"
 	// DomainNotManaged is true if we try to reconcile a DNSZone and the HiveConfig
 	// does not contain a ManagedDNS entry for the domain in the DNSZone
 	DomainNotManaged DNSZoneConditionType = "DomainNotManaged"
@@ -179,6 +519,9 @@ const (
 	// AuthenticationFailureCondition is true when credentials cannot be used to create a
 	// DNS zone because they fail authentication
 	AuthenticationFailureCondition DNSZoneConditionType = "AuthenticationFailure"
+	// ZoneDeletionBlockedCondition is true when a DNSZone with the Fail record cleanup policy is deleted and its
+	// zone has records that would be deleted with it
+	ZoneDeletionBlockedCondition DNSZoneConditionType = "ZoneDeletionBlocked"
 )
 
 // +genclient
@@ -1,20 +1,101 @@
 package gcp
 
+import "fmt"
+
 // MachinePool stores the configuration for a machine pool installed on GCP.
 type MachinePool struct {
 	// Zones is list of availability zones that can be used.
 	Zones []string `json:"zones,omitempty"`
 
+	// ZoneSubnets explicitly maps zones to the names of the subnetworks, in the network of the cluster, in which the
+	// machines for each zone are created, rather than using the subnetwork of the existing workers of the cluster.
+	// When Zones is empty, a MachineSet is created for each zone in the mapping.
+	// +optional
+	ZoneSubnets []ZoneSubnet `json:"zoneSubnets,omitempty"`
+
 	// InstanceType defines the GCP instance type.
 	// eg. n1-standard-4
+	// When CustomCPUs and CustomMemoryMB are set, InstanceType is the machine series of the custom machine type.
+	// eg. n1, n2, e2
 	InstanceType string `json:"type"`
 
+	// CustomCPUs is the number of vCPUs of a custom machine type. Must be set with CustomMemoryMB.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CustomCPUs int32 `json:"customCPUs,omitempty"`
+
+	// CustomMemoryMB is the memory, in MB, of a custom machine type. Must be set with CustomCPUs.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CustomMemoryMB int32 `json:"customMemoryMB,omitempty"`
+
+	// LocalSSDs attaches local SSDs to the instances.
+	// +optional
+	LocalSSDs *LocalSSDs `json:"localSSDs,omitempty"`
+
+	// OnHostMaintenance determines the behavior of the instances when the host undergoes maintenance.
+	// Instances with GPUs or local SSDs must use Terminate.
+	// +kubebuilder:validation:Enum=Migrate;Terminate
+	// +optional
+	OnHostMaintenance string `json:"onHostMaintenance,omitempty"`
+
+	// GPU attaches GPUs to the instances. Instances with GPUs cannot be migrated, so OnHostMaintenance defaults to
+	// Terminate when GPU is set.
+	// +optional
+	GPU *GPU `json:"gpu,omitempty"`
+
 	// OSDisk defines the storage for instances.
 	//
 	// +optional
 	OSDisk OSDisk `json:"osDisk"`
 }
 
+// ZoneSubnet maps a zone to a subnetwork.
+type ZoneSubnet struct {
+	// Zone is the zone.
+	Zone string `json:"zone"`
+	// Subnet is the name of the subnetwork.
+	Subnet string `json:"subnet"`
+}
+
+// GPU defines the GPUs attached to instances on GCP.
+type GPU struct {
+	// Type is the accelerator type of the GPUs, such as nvidia-tesla-t4. The accelerator type must be available in
+	// the zones of the pool.
+	Type string `json:"type"`
+
+	// Count is the number of GPUs attached to each instance.
+	// +kubebuilder:validation:Minimum=1
+	Count int32 `json:"count"`
+}
+
+// LocalSSDs defines the local SSDs attached to instances on GCP.
+type LocalSSDs struct {
+	// Count is the number of 375 GB local SSDs attached to each instance.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=24
+	Count int32 `json:"count"`
+
+	// Interface is the interface used to attach the local SSDs.
+	// Defaulted internally to SCSI.
+	// +kubebuilder:validation:Enum=SCSI;NVME
+	// +optional
+	Interface string `json:"interface,omitempty"`
+}
+
+// MachineType returns the GCP machine type of the machine pool, which is the custom machine type when CustomCPUs and
+// CustomMemoryMB are set.
+func (m *MachinePool) MachineType() string {
+	if m.CustomCPUs == 0 || m.CustomMemoryMB == 0 {
+		return m.InstanceType
+	}
+	// N1 custom machine types have no series prefix.
+	if m.InstanceType == "" || m.InstanceType == "n1" {
+		return fmt.Sprintf("custom-%d-%d", m.CustomCPUs, m.CustomMemoryMB)
+	}
+	return fmt.Sprintf("%s-custom-%d-%d", m.InstanceType, m.CustomCPUs, m.CustomMemoryMB)
+}
+
 // OSDisk defines the disk for machines on GCP.
 type OSDisk struct {
 	// DiskType defines the type of disk.
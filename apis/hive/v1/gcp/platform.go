@@ -13,4 +13,65 @@ type Platform struct {
 
 	// Region specifies the GCP region where the cluster will be created.
 	Region string `json:"region"`
+
+	// PrivateServiceConnect allows users to enable access to the cluster's API server using GCP
+	// Private Service Connect. It includes a Service Attachment for the cluster's internal API
+	// load balancer and an endpoint in the hub's network, allowing clients to connect to the
+	// cluster using Google's internal networking instead of the Internet.
+	// +optional
+	PrivateServiceConnect *PrivateServiceConnectAccess `json:"privateServiceConnect,omitempty"`
+
+	// NetworkProjectID is the ID of the host project of a Shared VPC (XPN) into which the cluster is installed. When
+	// set, Network, ControlPlaneSubnet and ComputeSubnet must be set to the Shared VPC network and subnets of the host
+	// project, which must be shared with the project of the cluster. The service account of the cluster must be
+	// allowed to use the subnets and to manage firewall rules in the host project.
+	// +optional
+	NetworkProjectID string `json:"networkProjectID,omitempty"`
+
+	// Network is the name of an existing VPC network into which the cluster is installed.
+	// +optional
+	Network string `json:"network,omitempty"`
+
+	// ControlPlaneSubnet is the name of an existing subnet of Network for the control plane machines.
+	// +optional
+	ControlPlaneSubnet string `json:"controlPlaneSubnet,omitempty"`
+
+	// ComputeSubnet is the name of an existing subnet of Network for the compute machines.
+	// +optional
+	ComputeSubnet string `json:"computeSubnet,omitempty"`
+}
+
+// PlatformStatus contains the observed state on GCP platform.
+type PlatformStatus struct {
+	PrivateServiceConnect *PrivateServiceConnectAccessStatus `json:"privateServiceConnect,omitempty"`
+}
+
+// PrivateServiceConnectAccess configures access to the cluster API using GCP Private Service Connect.
+type PrivateServiceConnectAccess struct {
+	Enabled bool `json:"enabled"`
+
+	// ServiceAttachmentSubnetCIDR is the CIDR of the subnet created in the cluster's network for the
+	// NAT of the Service Attachment. It must not overlap with any other subnet in the network.
+	// Defaults to 172.16.0.0/29 when not provided.
+	// +optional
+	ServiceAttachmentSubnetCIDR string `json:"serviceAttachmentSubnetCIDR,omitempty"`
+}
+
+// PrivateServiceConnectAccessStatus contains the observed state for PrivateServiceConnectAccess resources.
+type PrivateServiceConnectAccessStatus struct {
+	// ServiceAttachmentSubnet is the URL of the NAT subnet of the Service Attachment.
+	// +optional
+	ServiceAttachmentSubnet string `json:"serviceAttachmentSubnet,omitempty"`
+	// ServiceAttachment is the URL of the Service Attachment for the cluster's internal API load balancer.
+	// +optional
+	ServiceAttachment string `json:"serviceAttachment,omitempty"`
+	// Endpoint is the URL of the Private Service Connect endpoint in the hub project.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// EndpointAddress is the IP address of the Private Service Connect endpoint.
+	// +optional
+	EndpointAddress string `json:"endpointAddress,omitempty"`
+	// DNSZone is the name of the private managed zone for the cluster's API in the hub project.
+	// +optional
+	DNSZone string `json:"dnsZone,omitempty"`
 }
@@ -25,6 +25,22 @@ func (in *EncryptionKeyReference) DeepCopy() *EncryptionKeyReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPU) DeepCopyInto(out *GPU) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPU.
+func (in *GPU) DeepCopy() *GPU {
+	if in == nil {
+		return nil
+	}
+	out := new(GPU)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KMSKeyReference) DeepCopyInto(out *KMSKeyReference) {
 	*out = *in
@@ -41,6 +57,22 @@ func (in *KMSKeyReference) DeepCopy() *KMSKeyReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalSSDs) DeepCopyInto(out *LocalSSDs) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalSSDs.
+func (in *LocalSSDs) DeepCopy() *LocalSSDs {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalSSDs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachinePool) DeepCopyInto(out *MachinePool) {
 	*out = *in
@@ -49,6 +81,21 @@ func (in *MachinePool) DeepCopyInto(out *MachinePool) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ZoneSubnets != nil {
+		in, out := &in.ZoneSubnets, &out.ZoneSubnets
+		*out = make([]ZoneSubnet, len(*in))
+		copy(*out, *in)
+	}
+	if in.LocalSSDs != nil {
+		in, out := &in.LocalSSDs, &out.LocalSSDs
+		*out = new(LocalSSDs)
+		**out = **in
+	}
+	if in.GPU != nil {
+		in, out := &in.GPU, &out.GPU
+		*out = new(GPU)
+		**out = **in
+	}
 	in.OSDisk.DeepCopyInto(&out.OSDisk)
 	return
 }
@@ -104,6 +151,11 @@ func (in *OSDisk) DeepCopy() *OSDisk {
 func (in *Platform) DeepCopyInto(out *Platform) {
 	*out = *in
 	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.PrivateServiceConnect != nil {
+		in, out := &in.PrivateServiceConnect, &out.PrivateServiceConnect
+		*out = new(PrivateServiceConnectAccess)
+		**out = **in
+	}
 	return
 }
 
@@ -116,3 +168,72 @@ func (in *Platform) DeepCopy() *Platform {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
+	*out = *in
+	if in.PrivateServiceConnect != nil {
+		in, out := &in.PrivateServiceConnect, &out.PrivateServiceConnect
+		*out = new(PrivateServiceConnectAccessStatus)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformStatus.
+func (in *PlatformStatus) DeepCopy() *PlatformStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateServiceConnectAccess) DeepCopyInto(out *PrivateServiceConnectAccess) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateServiceConnectAccess.
+func (in *PrivateServiceConnectAccess) DeepCopy() *PrivateServiceConnectAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateServiceConnectAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateServiceConnectAccessStatus) DeepCopyInto(out *PrivateServiceConnectAccessStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateServiceConnectAccessStatus.
+func (in *PrivateServiceConnectAccessStatus) DeepCopy() *PrivateServiceConnectAccessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateServiceConnectAccessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneSubnet) DeepCopyInto(out *ZoneSubnet) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneSubnet.
+func (in *ZoneSubnet) DeepCopy() *ZoneSubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneSubnet)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -58,17 +58,81 @@ type HiveConfigSpec struct {
 	// +optional
 	ServiceProviderCredentialsConfig ServiceProviderCredentials `json:"serviceProviderCredentialsConfig,omitempty"`
 
+	// CredentialsBroker configures the credentials broker minting short-lived cloud credentials for the resources
+	// with a credentials source, instead of reading long-lived credentials from secrets.
+	// +optional
+	CredentialsBroker *CredentialsBrokerConfig `json:"credentialsBroker,omitempty"`
+
 	// LogLevel is the level of logging to use for the Hive controllers.
 	// Acceptable levels, from coarsest to finest, are panic, fatal, error, warn, info, debug, and trace.
 	// The default level is info.
 	// +optional
 	LogLevel string `json:"logLevel,omitempty"`
 
+	// LogFormat is the format of the logs of the Hive controllers. The default format is text.
+	// +kubebuilder:validation:Enum=text;json
+	// +optional
+	LogFormat LogFormat `json:"logFormat,omitempty"`
+
 	// SyncSetReapplyInterval is a string duration indicating how much time must pass before SyncSet resources
 	// will be reapplied.
 	// The default reapply interval is two hours.
 	SyncSetReapplyInterval string `json:"syncSetReapplyInterval,omitempty"`
 
+	// Tracing enables the export of traces of the reconciles of the clusterdeployment, clusterprovision, clustersync
+	// and dnszone controllers to an OpenTelemetry collector. Reconciles are not traced unless it is set.
+	// +optional
+	Tracing *TracingConfig `json:"tracing,omitempty"`
+
+	// CredentialsExpiryWarningPeriod is a string duration indicating how long before the expiry of the client
+	// certificate of the admin kubeconfig or of the serving certificate of the API of a cluster the
+	// CredentialsExpiringSoon condition of the ClusterDeployment is set.
+	// The default warning period is 30 days.
+	// +optional
+	CredentialsExpiryWarningPeriod string `json:"credentialsExpiryWarningPeriod,omitempty"`
+
+	// ParentLinkCheckInterval is a string duration indicating how often the delegation from the parent domain of a
+	// DNSZone linked to its parent domain is re-verified once the zone is available, setting the ParentLinkFailed
+	// condition of the DNSZone when the delegation no longer resolves to the name servers of the zone.
+	// The default check interval is one hour. A zero duration disables the check, as well as the verification of the
+	// delegation before the zone is available.
+	// +optional
+	ParentLinkCheckInterval string `json:"parentLinkCheckInterval,omitempty"`
+
+	// ParentLinkResolvers are the addresses, as host or host:port, of the DNS resolvers queried to verify the
+	// delegation from the parent domain of DNSZones. The delegation is verified when all of them resolve it to the
+	// name servers of the zone. The default resolvers are the public resolvers of Google and Cloudflare, which
+	// resolve the delegation as clients outside of the hub do.
+	// +optional
+	ParentLinkResolvers []string `json:"parentLinkResolvers,omitempty"`
+
+	// UnreachableProbeBackoff configures how the probes of clusters that have been unreachable for a long time, such as
+	// clusters decommissioned without deleting their ClusterDeployment, are backed off. Reachable clusters, and
+	// clusters unreachable for less than the backoff threshold, are probed as usual.
+	// +optional
+	UnreachableProbeBackoff *UnreachableProbeBackoffConfig `json:"unreachableProbeBackoff,omitempty"`
+
+	// HibernationDefaults configures hub-wide defaults and restrictions for the hibernation of ClusterDeployments.
+	// +optional
+	HibernationDefaults *HibernationDefaultsConfig `json:"hibernationDefaults,omitempty"`
+
+	// EndpointHealth enables the periodic probing of the API, console and ingress endpoints of installed clusters over
+	// HTTPS, reported in the EndpointsUnhealthy condition of the ClusterDeployments and in metrics. Endpoints are not
+	// probed unless it is set.
+	// +optional
+	EndpointHealth *EndpointHealthConfig `json:"endpointHealth,omitempty"`
+
+	// AWSRoute53RateLimit configures the client-side rate limiting and the retries of the Route53 API calls of the Hive
+	// controllers. Route53 throttles the requests of an AWS account above five requests per second.
+	// +optional
+	AWSRoute53RateLimit *AWSRoute53RateLimitConfig `json:"awsRoute53RateLimit,omitempty"`
+
+	// AWSClientCache enables caching the responses of read-only AWS API calls of the Hive controllers, such as
+	// GetHostedZone, ListHostedZonesByName and DescribeInstances, for a short time. Responses are not cached unless it
+	// is set.
+	// +optional
+	AWSClientCache *AWSClientCacheConfig `json:"awsClientCache,omitempty"`
+
 	// MaintenanceMode can be set to true to disable the hive controllers in situations where we need to ensure
 	// nothing is running that will add or act upon finalizers on Hive types. This should rarely be needed.
 	// Sets replicas to 0 for the hive-controllers deployment to accomplish this.
@@ -103,9 +167,166 @@ type HiveConfigSpec struct {
 	// 3. A list of VPCs that should be able to resolve the DNS addresses setup for Private Link.
 	AWSPrivateLink *AWSPrivateLinkConfig `json:"awsPrivateLink,omitempty"`
 
+	// GCPPrivateServiceConnect defines the configuration for the gcp-private-service-connect controller.
+	// It provides the credentials used to create the endpoints in the hub project, the networks and
+	// subnets that can be used to create those endpoints, and the networks that should be able to
+	// resolve the DNS addresses setup for Private Service Connect.
+	// +optional
+	GCPPrivateServiceConnect *GCPPrivateServiceConnectConfig `json:"gcpPrivateServiceConnect,omitempty"`
+
+	// ClusterImageSetDiscovery defines the configuration for the clusterimagesetdiscovery controller, which
+	// creates ClusterImageSets for the releases found in channels of an OpenShift update graph.
+	// +optional
+	ClusterImageSetDiscovery *ClusterImageSetDiscoveryConfig `json:"clusterImageSetDiscovery,omitempty"`
+
+	// ReleaseImageValidation enables the validation of the release images of ClusterImageSets. When set, Hive
+	// checks that the release image of each ClusterImageSet exists and can be pulled with the global pull secret,
+	// and records the result in the Valid condition of the ClusterImageSet.
+	// +optional
+	ReleaseImageValidation *ReleaseImageValidationConfig `json:"releaseImageValidation,omitempty"`
+
+	// ReleaseImageVerification enables the verification of the release image of each cluster before it is
+	// provisioned. When set, Hive checks that the release image is signed by one of the configured public keys and
+	// that its architectures match the machine pools of the InstallConfig, and does not provision the cluster
+	// otherwise.
+	// +optional
+	ReleaseImageVerification *ReleaseImageVerificationConfig `json:"releaseImageVerification,omitempty"`
+
+	// AllowedInstallerEnv is the list of environment variable names that ClusterDeployments may pass through to the
+	// installer in spec.provisioning.installerEnv. A name ending in * allows all names with that prefix. When empty,
+	// only OPENSHIFT_INSTALL_* variables are allowed. Variables that Hive sets for the installer itself are never
+	// allowed.
+	// +optional
+	AllowedInstallerEnv []string `json:"allowedInstallerEnv,omitempty"`
+
+	// AdmissionRules sets the mode of selected validations of the Hive admission webhooks. A rule in Warn mode
+	// returns a warning to the client instead of rejecting the request, so that a new validation can be observed
+	// against existing automation before it is enforced. Rules that are not listed are enforced.
+	// +optional
+	AdmissionRules []AdmissionRuleConfig `json:"admissionRules,omitempty"`
+
+	// NamespaceQuotas limits the number of clusters and machines in specific namespaces, for hubs shared by tenants
+	// with budget caps. Quotas are enforced when ClusterDeployments and MachinePools are created or updated, and
+	// ClusterPools do not assign clusters to ClusterClaims in namespaces that are at their cluster quota.
+	// +optional
+	NamespaceQuotas []NamespaceQuota `json:"namespaceQuotas,omitempty"`
+
+	// WorkloadScheduling is the scheduling configuration applied to the pods of the jobs created by Hive, such as the
+	// install, uninstall and imageset jobs, so that they can be placed on dedicated nodes of the hub.
+	// +optional
+	WorkloadScheduling *WorkloadScheduling `json:"workloadScheduling,omitempty"`
+
+	// JobProxy is the proxy configuration of the pods of the jobs created by Hive, such as the install, uninstall and
+	// imageset jobs. The proxy settings take precedence over the proxy environment variables of the Hive operator.
+	// +optional
+	JobProxy *JobProxyConfig `json:"jobProxy,omitempty"`
+
+	// CloudClientProxy is the proxy configuration, and the additional trusted certificate authorities, of the clients
+	// with which the Hive controllers call the APIs of AWS, Azure and GCP. This is meant for controllers running
+	// behind an egress proxy. When unset, the clients use the proxy environment variables of the controllers.
+	// The CloudClientProxySecretRef of a ClusterDeployment or DNSZone takes precedence.
+	// +optional
+	CloudClientProxy *JobProxyConfig `json:"cloudClientProxy,omitempty"`
+
+	// Canary rolls out a change to the configuration of the Hive controllers to the namespaces selected by its
+	// namespace selector first. The change is promoted to all namespaces, or rolled back, based on the error rate of
+	// the reconciles of the canary controllers.
+	// +optional
+	Canary *CanaryConfig `json:"canary,omitempty"`
+
 	FeatureGates *FeatureGateSelection `json:"featureGates,omitempty"`
 }
 
+// CanaryConfig is a change to the configuration of the Hive controllers that is rolled out to a subset of the
+// namespaces first.
+type CanaryConfig struct {
+	// NamespaceSelector selects the namespaces whose objects are reconciled by the canary controllers while the
+	// canary is progressing. Objects in the other namespaces, and cluster-scoped objects, are reconciled by the
+	// stable controllers.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+
+	// Image is the Hive image run by the canary controllers. Defaults to the image of the stable controllers.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// ControllersConfig is the configuration of the canary controllers, in place of spec.controllersConfig.
+	// Defaults to spec.controllersConfig.
+	// +optional
+	ControllersConfig *ControllersConfig `json:"controllersConfig,omitempty"`
+
+	// AnalysisDuration is a string duration indicating how long the canary controllers must run without exceeding
+	// the maximum error rate before the canary is promoted.
+	// The default analysis duration is one hour.
+	// +optional
+	AnalysisDuration string `json:"analysisDuration,omitempty"`
+
+	// MaxErrorRatePercent is the percentage of the reconciles of the canary controllers that may fail before the
+	// canary is rolled back. The default is 5.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxErrorRatePercent *int32 `json:"maxErrorRatePercent,omitempty"`
+}
+
+// NamespaceQuota limits the number of clusters and machines in a namespace.
+type NamespaceQuota struct {
+	// Namespace is the namespace to which the quota applies.
+	Namespace string `json:"namespace"`
+
+	// MaxClusters is the maximum number of clusters in the namespace. Both the ClusterDeployments in the namespace
+	// and the ClusterClaims in the namespace that have been assigned a cluster count against this limit.
+	// ClusterDeployments that are being deleted do not.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxClusters *int32 `json:"maxClusters,omitempty"`
+
+	// MaxMachines is the maximum total number of machines of the MachinePools in the namespace. A MachinePool with
+	// autoscaling counts its maximum number of replicas.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxMachines *int32 `json:"maxMachines,omitempty"`
+}
+
+// WorkloadScheduling is the scheduling configuration of the pods created by Hive. Settings already present in the
+// spec of a pod take precedence.
+type WorkloadScheduling struct {
+	// NodeSelector is merged into the node selector of the pods.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations are added to the tolerations of the pods.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity is the affinity of the pods that do not have one.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// PriorityClassName is the priority class of the pods that do not have one.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// JobProxyConfig is the proxy configuration of the pods created by Hive.
+type JobProxyConfig struct {
+	// HTTPProxy is the URL of the proxy for HTTP requests.
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy for HTTPS requests.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is a comma-separated list of hostnames and CIDRs for which the proxy is not used.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+
+	// TrustedCASecretRefs is a list of references to secrets in the TargetNamespace that contain an additional
+	// certificate authority, under the ca.crt key, trusted by the pods. This is typically the CA of the proxy.
+	// +optional
+	TrustedCASecretRefs []corev1.LocalObjectReference `json:"trustedCASecretRefs,omitempty"`
+}
+
 // AWSPrivateLinkConfig defines the configuration for the aws-private-link controller.
 type AWSPrivateLinkConfig struct {
 	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
@@ -132,6 +353,12 @@ type AWSPrivateLinkConfig struct {
 type AWSPrivateLinkInventory struct {
 	AWSPrivateLinkVPC `json:",inline"`
 	Subnets           []AWSPrivateLinkSubnet `json:"subnets"`
+
+	// ServedRegions is a list of regions, other than the region of the VPC, whose clusters may
+	// use this VPC for their VPC Endpoints using cross-region PrivateLink. VPCs in the same region
+	// as the cluster are always preferred.
+	// +optional
+	ServedRegions []string `json:"servedRegions,omitempty"`
 }
 
 // AWSAssociatedVPC defines a VPC that should be able to resolve the DNS addresses
@@ -158,6 +385,282 @@ type AWSPrivateLinkSubnet struct {
 	AvailabilityZone string `json:"availabilityZone"`
 }
 
+// GCPPrivateServiceConnectConfig defines the configuration for the gcp-private-service-connect controller.
+type GCPPrivateServiceConnectConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// GCP for creating the resources for GCP Private Service Connect in the hub project.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// EndpointInventory is a list of subnets in various GCP regions that the controller uses to
+	// reserve addresses for Private Service Connect endpoints. Since the endpoints must be in the
+	// same region as the ClusterDeployment, there must be a subnet in that region to be able to
+	// setup Private Service Connect.
+	EndpointInventory []GCPPrivateServiceConnectInventory `json:"endpointInventory,omitempty"`
+
+	// AssociatedNetworks is the list of network URLs, in addition to the networks of the endpoint
+	// inventory, that should be able to resolve the DNS addresses setup for Private Service Connect.
+	//
+	// This list should at minimum include the network where the current Hive controller is running.
+	// +optional
+	AssociatedNetworks []string `json:"associatedNetworks,omitempty"`
+}
+
+// GCPPrivateServiceConnectInventory is a subnet in a GCP region that can be used to reserve
+// addresses for Private Service Connect endpoints.
+type GCPPrivateServiceConnectInventory struct {
+	// Network is the URL of the network of the subnet.
+	Network string `json:"network"`
+	// Subnet is the URL of the subnet.
+	Subnet string `json:"subnet"`
+	// Region is the region of the subnet.
+	Region string `json:"region"`
+}
+
+// ClusterImageSetDiscoveryConfig defines the configuration for the clusterimagesetdiscovery controller.
+type ClusterImageSetDiscoveryConfig struct {
+	// UpstreamURL is the URL of the update graph (Cincinnati) endpoint from which releases are discovered.
+	// Defaults to https://api.openshift.com/api/upgrades_info/v1/graph.
+	// +optional
+	UpstreamURL string `json:"upstreamURL,omitempty"`
+
+	// Channels are the channels of the update graph from which to discover releases.
+	Channels []ClusterImageSetDiscoveryChannel `json:"channels"`
+
+	// RefreshInterval is how often the update graph is queried for new releases. Defaults to 1h.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+
+	// Prune deletes discovered ClusterImageSets for releases that are no longer among the latest releases of
+	// any configured channel, unless they are referenced by a ClusterPool.
+	// +optional
+	Prune bool `json:"prune,omitempty"`
+}
+
+// TracingConfig configures the export of traces of reconciles to an OpenTelemetry collector. A trace covers the
+// requests to the hub and spoke API servers, the applies of SyncSet resources and the DNS provider calls made by a
+// reconcile.
+type TracingConfig struct {
+	// Endpoint is the URL of the OTLP/HTTP receiver of the collector, such as
+	// http://otel-collector.observability.svc:4318. Traces are sent to its /v1/traces path in the JSON encoding.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Endpoint string `json:"endpoint"`
+
+	// SamplingRatio is the fraction of the reconciles that are traced, between "0" and "1". The default is "0.1".
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	SamplingRatio string `json:"samplingRatio,omitempty"`
+}
+
+// ReleaseImageValidationConfig defines the configuration for the validation of the release images of
+// ClusterImageSets.
+type ReleaseImageValidationConfig struct {
+	// AllowedArchitectures is the list of architectures, for example amd64 or arm64, allowed for release images.
+	// A release image must support at least one of the architectures. When empty, all architectures are allowed.
+	// +optional
+	AllowedArchitectures []string `json:"allowedArchitectures,omitempty"`
+}
+
+// ReleaseImageVerificationConfig defines the configuration for the verification of the release images of clusters
+// before they are provisioned.
+type ReleaseImageVerificationConfig struct {
+	// SignatureStores are the base URLs of the signature stores from which the signatures of release images are
+	// fetched, for example https://mirror.openshift.com/pub/openshift-v4/signatures/openshift/release. The signatures
+	// of a release image with digest sha256:<hex> are read from <store>/sha256=<hex>/signature-<n>.
+	// +kubebuilder:validation:MinItems=1
+	SignatureStores []string `json:"signatureStores"`
+
+	// PublicKeysSecretRef references a secret in the TargetNamespace whose values are the ASCII-armored GPG public
+	// keys trusted to sign release images.
+	PublicKeysSecretRef corev1.LocalObjectReference `json:"publicKeysSecretRef"`
+}
+
+// HibernationDefaultsConfig contains the hub-wide defaults and restrictions for the hibernation of ClusterDeployments.
+type HibernationDefaultsConfig struct {
+	// HibernateAfter is the hibernateAfter set on new ClusterDeployments that do not set it, are not part of a
+	// ClusterPool and are allowed to be hibernated.
+	// +optional
+	HibernateAfter *metav1.Duration `json:"hibernateAfter,omitempty"`
+
+	// ResumeTimeout is how long a cluster may take to resume before the resume is reported as timed out in the
+	// Hibernating condition of the ClusterDeployment. Hive keeps trying to resume the cluster after the timeout.
+	// There is no timeout by default.
+	// +optional
+	ResumeTimeout *metav1.Duration `json:"resumeTimeout,omitempty"`
+
+	// PowerStatePolicies restrict the power states of the ClusterDeployments matching their selectors. A
+	// ClusterDeployment matching several policies may only use the power states allowed by all of them. The Running
+	// power state is always allowed.
+	// +optional
+	PowerStatePolicies []PowerStatePolicy `json:"powerStatePolicies,omitempty"`
+}
+
+// PowerStatePolicy restricts the power states of the ClusterDeployments matching its selector.
+type PowerStatePolicy struct {
+	// Selector selects the ClusterDeployments the policy applies to by their labels.
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// AllowedPowerStates are the power states, besides Running, that the selected ClusterDeployments may use. An
+	// empty list keeps the selected clusters running.
+	// +optional
+	AllowedPowerStates []ClusterPowerState `json:"allowedPowerStates,omitempty"`
+}
+
+// UnreachableProbeBackoffConfig is the backoff of the probes of clusters that have been unreachable for a long time.
+// Once a cluster has been unreachable for the threshold, it is probed at the initial interval. The interval is then
+// multiplied by the multiplier each time the time the cluster has been unreachable is multiplied by the multiplier, up
+// to the maximum interval. With the defaults, a cluster unreachable for a day is probed every hour, for two days every
+// two hours, for four days every four hours, and so on up to once a day.
+type UnreachableProbeBackoffConfig struct {
+	// Threshold is a string duration indicating how long a cluster must have been unreachable before its probes are
+	// backed off. The default threshold is one day. A zero duration disables the backoff.
+	// +optional
+	Threshold string `json:"threshold,omitempty"`
+
+	// InitialInterval is a string duration indicating the interval between probes once the backoff starts.
+	// The default initial interval is one hour.
+	// +optional
+	InitialInterval string `json:"initialInterval,omitempty"`
+
+	// Multiplier is the factor by which the interval grows. The default multiplier is 2.
+	// +kubebuilder:validation:Minimum=2
+	// +optional
+	Multiplier *int32 `json:"multiplier,omitempty"`
+
+	// MaxInterval is a string duration indicating the maximum interval between probes. The default maximum interval
+	// is one day.
+	// +optional
+	MaxInterval string `json:"maxInterval,omitempty"`
+}
+
+// EndpointHealthConfig configures the probing of the endpoints of clusters. The API is probed at its /readyz path and
+// the console at its URL. An endpoint is healthy when it responds with an expected status code and serves a
+// certificate that is valid for its host and has not expired. Redirects are not followed.
+type EndpointHealthConfig struct {
+	// Interval is a string duration indicating how often the endpoints of a cluster are probed. The default interval
+	// is five minutes.
+	// +optional
+	Interval string `json:"interval,omitempty"`
+
+	// Timeout is a string duration indicating how long to wait for the response of an endpoint. The default timeout
+	// is ten seconds.
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+
+	// IngressEndpoints are endpoints of the default ingress controller of the clusters that are probed in addition to
+	// the API and console.
+	// +optional
+	IngressEndpoints []EndpointHealthIngressEndpoint `json:"ingressEndpoints,omitempty"`
+}
+
+// AWSRoute53RateLimitConfig configures the rate limiting of the Route53 API calls of the Hive controllers. The rate is
+// limited separately for each set of AWS credentials, and throttled requests are retried with exponential backoff.
+type AWSRoute53RateLimitConfig struct {
+	// QPS is the number of Route53 requests per second allowed for each set of AWS credentials. The default is 5.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	QPS *int32 `json:"qps,omitempty"`
+
+	// Burst is the number of Route53 requests allowed in a burst for each set of AWS credentials. The default is 5.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst *int32 `json:"burst,omitempty"`
+
+	// MaxRetries is the number of times a failed Route53 request is retried. Throttled requests are retried after a
+	// delay growing exponentially from half a second to 30 seconds. The default is 8.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+}
+
+// AWSClientCacheConfig configures the caching of the responses of read-only AWS API calls. Responses are cached
+// separately for each set of AWS credentials and region, so that they are only shared between callers with the same
+// permissions.
+type AWSClientCacheConfig struct {
+	// TTL is how long a response is cached. Changes made to the AWS resources, including by the Hive controllers, may
+	// not be seen until the cached response expires. The default is 30 seconds.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// MaxEntries is the number of responses held in the cache, above which the responses closest to expiring are
+	// evicted. The default is 10000.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxEntries *int32 `json:"maxEntries,omitempty"`
+}
+
+// EndpointHealthIngressEndpoint is an endpoint of the default ingress controller of clusters.
+type EndpointHealthIngressEndpoint struct {
+	// Name identifies the endpoint in the condition and metrics of the probes.
+	Name string `json:"name"`
+
+	// Host is the host name of the endpoint relative to the ingress domain of the cluster, such as oauth-openshift
+	// for https://oauth-openshift.apps.<cluster name>.<base domain>.
+	Host string `json:"host"`
+
+	// Path is the path of the endpoint that is probed. The default path is /.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// ExpectedStatusCodes are the HTTP status codes of the responses of a healthy endpoint. By default, any 2xx or
+	// 3xx status code is expected.
+	// +optional
+	ExpectedStatusCodes []int32 `json:"expectedStatusCodes,omitempty"`
+}
+
+// AdmissionRule is the name of a validation of the Hive admission webhooks whose mode can be configured.
+// +kubebuilder:validation:Enum=InstallerEnv;SyncSetPauseAnnotation;SSHBastion;ManualCredentials;NamespaceQuota
+type AdmissionRule string
+
+const (
+	// InstallerEnvAdmissionRule validates the environment variables passed through to the installer by
+	// ClusterDeployments.
+	InstallerEnvAdmissionRule AdmissionRule = "InstallerEnv"
+	// SyncSetPauseAnnotationAdmissionRule validates the deprecated syncset-pause annotation of ClusterDeployments.
+	SyncSetPauseAnnotationAdmissionRule AdmissionRule = "SyncSetPauseAnnotation"
+	// SSHBastionAdmissionRule validates the SSH bastion of the control plane config of ClusterDeployments.
+	SSHBastionAdmissionRule AdmissionRule = "SSHBastion"
+	// ManualCredentialsAdmissionRule validates the manual credentials of ClusterDeployments.
+	ManualCredentialsAdmissionRule AdmissionRule = "ManualCredentials"
+	// NamespaceQuotaAdmissionRule enforces the namespace quotas on ClusterDeployments and MachinePools.
+	NamespaceQuotaAdmissionRule AdmissionRule = "NamespaceQuota"
+)
+
+// AdmissionRuleMode is the mode of a validation of the Hive admission webhooks.
+// +kubebuilder:validation:Enum=Enforce;Warn
+type AdmissionRuleMode string
+
+const (
+	// EnforceAdmissionRuleMode rejects requests that fail the validation.
+	EnforceAdmissionRuleMode AdmissionRuleMode = "Enforce"
+	// WarnAdmissionRuleMode allows requests that fail the validation, returning the failures as admission warnings.
+	WarnAdmissionRuleMode AdmissionRuleMode = "Warn"
+)
+
+// AdmissionRuleConfig sets the mode of a validation of the Hive admission webhooks.
+type AdmissionRuleConfig struct {
+	// Name is the name of the validation.
+	Name AdmissionRule `json:"name"`
+
+	// Mode is the mode of the validation.
+	Mode AdmissionRuleMode `json:"mode"`
+}
+
+// ClusterImageSetDiscoveryChannel is a channel of the update graph from which to discover releases.
+type ClusterImageSetDiscoveryChannel struct {
+	// Name is the name of the channel, for example stable-4.15.
+	Name string `json:"name"`
+
+	// Arch is the architecture of the releases to discover. Defaults to amd64.
+	// +optional
+	Arch string `json:"arch,omitempty"`
+
+	// Latest is the number of the newest releases in the channel for which ClusterImageSets are maintained.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Latest int `json:"latest,omitempty"`
+}
+
 // ServiceProviderCredentials is used to configure credentials related to being a service provider on
 // various cloud platforms.
 type ServiceProviderCredentials struct {
@@ -176,6 +679,50 @@ type AWSServiceProviderCredentials struct {
 	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
 }
 
+// CredentialsBrokerConfig configures the credentials broker of Hive. The web identity credentials sources need no
+// configuration, as they use the service account token of the Hive controllers.
+type CredentialsBrokerConfig struct {
+	// Vault configures the Vault server of the Vault credentials sources.
+	// +optional
+	Vault *VaultCredentialsBrokerConfig `json:"vault,omitempty"`
+
+	// Bindings are the credentials sources the resources of each namespace may use. Hive mints the credentials with
+	// its own identity, so a credentials source not allowed by a binding of the namespace of the resource is
+	// rejected. No credentials source is allowed when there are no bindings.
+	// +optional
+	Bindings []CredentialsBrokerBinding `json:"bindings,omitempty"`
+}
+
+// CredentialsBrokerBinding allows the resources of a namespace to use some credentials sources.
+type CredentialsBrokerBinding struct {
+	// Namespace is the namespace of the ClusterDeployments, ClusterDeprovisions and DNSZones the binding applies to.
+	Namespace string `json:"namespace"`
+
+	// RoleARNs are the ARNs of the IAM roles the web identity credentials sources may assume.
+	// +optional
+	RoleARNs []string `json:"roleARNs,omitempty"`
+
+	// VaultRoles are the roles of the AWS secrets engine of Vault the Vault credentials sources may read
+	// credentials for, in the form <mountPath>/<role>, such as aws/installer.
+	// +optional
+	VaultRoles []string `json:"vaultRoles,omitempty"`
+}
+
+// VaultCredentialsBrokerConfig configures the Vault server minting credentials for the Vault credentials sources.
+// Hive logs in to Vault with the Kubernetes auth method, using the service account token of the Hive controllers.
+type VaultCredentialsBrokerConfig struct {
+	// Address is the URL of the Vault server, such as https://vault.example.com:8200.
+	Address string `json:"address"`
+
+	// AuthMountPath is the path where the Kubernetes auth method is mounted. Defaults to "kubernetes".
+	// +optional
+	AuthMountPath string `json:"authMountPath,omitempty"`
+
+	// AuthRole is the role of the Kubernetes auth method to log in with. The role should only be bound to the
+	// service account of the Hive controllers, which mint the credentials of the install and uninstall pods.
+	AuthRole string `json:"authRole"`
+}
+
 // FeatureSet defines the set of feature gates that should be used.
 // +kubebuilder:validation:Enum="";Custom
 type FeatureSet string
@@ -239,6 +786,50 @@ type HiveConfigStatus struct {
 	// Conditions includes more detailed status for the HiveConfig
 	// +optional
 	Conditions []HiveConfigCondition `json:"conditions,omitempty"`
+
+	// Canary is the status of the rollout of spec.canary.
+	// +optional
+	Canary *CanaryStatus `json:"canary,omitempty"`
+}
+
+// CanaryPhase is the phase of the rollout of a canary.
+type CanaryPhase string
+
+const (
+	// CanaryPhaseProgressing means that the canary controllers reconcile the selected namespaces and their error
+	// rate is being analyzed.
+	CanaryPhaseProgressing CanaryPhase = "Progressing"
+	// CanaryPhasePromoted means that the canary did not exceed the maximum error rate during the analysis, and its
+	// configuration is used by the controllers of all namespaces.
+	CanaryPhasePromoted CanaryPhase = "Promoted"
+	// CanaryPhaseRolledBack means that the canary exceeded the maximum error rate, and the controllers of all
+	// namespaces use the configuration of the stable controllers.
+	CanaryPhaseRolledBack CanaryPhase = "RolledBack"
+)
+
+// CanaryStatus is the status of the rollout of a canary.
+type CanaryStatus struct {
+	// ConfigHash is the hash of the canary configuration that the status is for. A change to the canary
+	// configuration starts a new rollout.
+	ConfigHash string `json:"configHash"`
+
+	// Phase is the phase of the rollout.
+	Phase CanaryPhase `json:"phase"`
+
+	// StartTime is the time at which the rollout started.
+	StartTime metav1.Time `json:"startTime"`
+
+	// Reconciles is the number of reconciles of the canary controllers seen by the last analysis.
+	// +optional
+	Reconciles int64 `json:"reconciles,omitempty"`
+
+	// ReconcileErrors is the number of the reconciles of the canary controllers that failed.
+	// +optional
+	ReconcileErrors int64 `json:"reconcileErrors,omitempty"`
+
+	// Message is a human-readable message about the last analysis.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // HiveConfigCondition contains details for the current condition of a HiveConfig
@@ -281,6 +872,56 @@ type BackupConfig struct {
 	// backup happening once the interval has been completed.
 	// +optional
 	MinBackupPeriodSeconds *int `json:"minBackupPeriodSeconds,omitempty"`
+
+	// Export specifies configuration for the periodic export of Hive resources to object storage, independent of
+	// the Velero backup integration.
+	// +optional
+	Export *BackupExportConfig `json:"export,omitempty"`
+}
+
+// BackupExportConfig contains settings for the periodic export of Hive resources to object storage.
+type BackupExportConfig struct {
+	// Interval is the interval between exports.
+	// The default interval is 24 hours.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Retention is the number of exports kept in the bucket. Older exports are deleted.
+	// The default retention is 7 exports.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Retention *int32 `json:"retention,omitempty"`
+
+	// S3 is the S3, or S3-compatible, bucket to which the resources are exported.
+	S3 BackupExportS3Config `json:"s3"`
+
+	// SecretsEncryptionKeySecretRef references a secret in the TargetNamespace holding a 32 byte AES key in its "key"
+	// data entry. When set, the secrets in the namespaces of the exported ClusterDeployments and ClusterPools are
+	// exported as well, encrypted with the key.
+	// +optional
+	SecretsEncryptionKeySecretRef *corev1.LocalObjectReference `json:"secretsEncryptionKeySecretRef,omitempty"`
+}
+
+// BackupExportS3Config contains the settings of the bucket to which Hive resources are exported.
+type BackupExportS3Config struct {
+	// Bucket is the name of the bucket.
+	Bucket string `json:"bucket"`
+
+	// Region is the region of the bucket.
+	Region string `json:"region"`
+
+	// Prefix is the prefix of the keys of the exports in the bucket.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Endpoint overrides the endpoint of S3, for S3-compatible object storage like the interoperability endpoint of
+	// Google Cloud Storage, https://storage.googleapis.com.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// CredentialsSecretRef references a secret in the TargetNamespace holding the aws_access_key_id and
+	// aws_secret_access_key used to access the bucket.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
 }
 
 // VeleroBackupConfig contains settings for the Velero backup integration.
@@ -304,6 +945,19 @@ type FailedProvisionConfig struct {
 	// DEPRECATED: This flag is no longer respected and will be removed in the future.
 	SkipGatherLogs bool                      `json:"skipGatherLogs,omitempty"`
 	AWS            *FailedProvisionAWSConfig `json:"aws,omitempty"`
+
+	// AdditionalInstallLogRegexesConfigMapRef is a reference to a ConfigMap in the hive namespace with additional
+	// regexes used to classify install failures. The ConfigMap must have a "regexes" data entry with the same format
+	// as the install-log-regexes ConfigMap. The regexes are matched after Hive's own regexes, and changes to the
+	// ConfigMap take effect on the next failed install without restarting the controllers.
+	// +optional
+	AdditionalInstallLogRegexesConfigMapRef *corev1.LocalObjectReference `json:"additionalInstallLogRegexesConfigMapRef,omitempty"`
+
+	// RetryReasons is a list of install failure reasons for which a failed install is retried. When set, an install
+	// that fails for any other reason is not retried, and the ProvisionStopped condition of the ClusterDeployment is
+	// set. When unset, all failed installs are retried up to the InstallAttemptsLimit of the ClusterDeployment.
+	// +optional
+	RetryReasons *[]string `json:"retryReasons,omitempty"`
 }
 
 // ManageDNSConfig contains the domain being managed, and the cloud-specific
@@ -367,6 +1021,13 @@ type ManageDNSAWSConfig struct {
 	// For AWS China, use cn-northwest-1.
 	// +optional
 	Region string `json:"region,omitempty"`
+
+	// RoutingHostedZoneIDs is the list of IDs of the shared hosted zones where ClusterDeployments with a base domain
+	// under the managed domains may maintain records with a routing policy, as configured in their dnsRouting. The
+	// records are maintained with the credentials above, so only hosted zones shared by the clusters of these
+	// domains should be listed.
+	// +optional
+	RoutingHostedZoneIDs []string `json:"routingHostedZoneIDs,omitempty"`
 }
 
 // ManageDNSGCPConfig contains GCP-specific info to manage a given domain.
@@ -404,6 +1065,13 @@ type ControllerConfig struct {
 	// ConcurrentReconciles specifies number of concurrent reconciles for a controller
 	// +optional
 	ConcurrentReconciles *int32 `json:"concurrentReconciles,omitempty"`
+	// ConcurrentReconcilesPerCredentials caps the number of concurrent reconciles of objects using the same cloud
+	// credentials secret, so that many objects sharing a cloud account do not exhaust the API rate limits of the
+	// account. Objects over the cap are requeued. Unset or 0 means no cap.
+	// This is ONLY honored by the dnszone controller.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ConcurrentReconcilesPerCredentials *int32 `json:"concurrentReconcilesPerCredentials,omitempty"`
 	// ClientQPS specifies client rate limiter QPS for a controller
 	// +optional
 	ClientQPS *int32 `json:"clientQPS,omitempty"`
@@ -420,9 +1088,25 @@ type ControllerConfig struct {
 	// This is ONLY for controllers that have been split out into their own pods.
 	// This is ignored for all others.
 	Replicas *int32 `json:"replicas,omitempty"`
+	// LogLevel overrides spec.logLevel for the controller specified by Name. Changes to the log level are applied
+	// without restarting the controller. This is ignored in the default configuration.
+	// Acceptable levels, from coarsest to finest, are panic, fatal, error, warn, info, debug, and trace.
+	// +optional
+	LogLevel string `json:"logLevel,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=clusterDeployment;clusterrelocate;clusterstate;clusterversion;controlPlaneCerts;dnsendpoint;dnszone;remoteingress;remotemachineset;syncidentityprovider;unreachable;velerobackup;clusterprovision;clusterDeprovision;clusterpool;clusterpoolnamespace;hibernation;clusterclaim;metrics;clustersync
+// LogFormat is the format of the logs of the Hive controllers.
+type LogFormat string
+
+const (
+	// TextLogFormat logs in logfmt-like key=value text.
+	TextLogFormat LogFormat = "text"
+
+	// JSONLogFormat logs one JSON object per line.
+	JSONLogFormat LogFormat = "json"
+)
+
+// +kubebuilder:validation:Enum=clusterDeployment;clusterrelocate;clusterstate;clusterversion;controlPlaneCerts;dnsendpoint;dnszone;remoteingress;remotemachineset;syncidentityprovider;unreachable;velerobackup;clusterprovision;clusterDeprovision;clusterpool;clusterpoolnamespace;hibernation;clusterclaim;metrics;clustersync;viewerkubeconfig;clusterimagesetdiscovery;clusterimageset;clusterdnsrecords;auditlog;additionaltrustbundle;clusterdeploymentsummary;sshkeyrotation;credentialsexpiry;backupexport;endpointhealth;clusteradoption;gcpprivateserviceconnect
 type ControllerName string
 
 func (controllerName ControllerName) String() string {
@@ -445,30 +1129,43 @@ func (c ControllerNames) Contains(controllerName ControllerName) bool {
 
 // WARNING: All the controller names below should also be added to the kubebuilder validation of the type ControllerName
 const (
-	ClusterClaimControllerName         ControllerName = "clusterclaim"
-	ClusterDeploymentControllerName    ControllerName = "clusterDeployment"
-	ClusterDeprovisionControllerName   ControllerName = "clusterDeprovision"
-	ClusterpoolControllerName          ControllerName = "clusterpool"
-	ClusterpoolNamespaceControllerName ControllerName = "clusterpoolnamespace"
-	ClusterProvisionControllerName     ControllerName = "clusterProvision"
-	ClusterRelocateControllerName      ControllerName = "clusterRelocate"
-	ClusterStateControllerName         ControllerName = "clusterState"
-	ClusterVersionControllerName       ControllerName = "clusterversion"
-	ControlPlaneCertsControllerName    ControllerName = "controlPlaneCerts"
-	DNSEndpointControllerName          ControllerName = "dnsendpoint"
-	DNSZoneControllerName              ControllerName = "dnszone"
-	FakeClusterInstallControllerName   ControllerName = "fakeclusterinstall"
-	HibernationControllerName          ControllerName = "hibernation"
-	RemoteIngressControllerName        ControllerName = "remoteingress"
-	RemoteMachinesetControllerName     ControllerName = "remotemachineset"
-	SyncIdentityProviderControllerName ControllerName = "syncidentityprovider"
-	UnreachableControllerName          ControllerName = "unreachable"
-	VeleroBackupControllerName         ControllerName = "velerobackup"
-	MetricsControllerName              ControllerName = "metrics"
-	ClustersyncControllerName          ControllerName = "clustersync"
-	MachineManagementControllerName    ControllerName = "machineManagement"
-	AWSPrivateLinkControllerName       ControllerName = "awsprivatelink"
-	HiveControllerName                 ControllerName = "hive"
+	ClusterClaimControllerName             ControllerName = "clusterclaim"
+	ClusterDeploymentControllerName        ControllerName = "clusterDeployment"
+	ClusterDeprovisionControllerName       ControllerName = "clusterDeprovision"
+	ClusterpoolControllerName              ControllerName = "clusterpool"
+	ClusterpoolNamespaceControllerName     ControllerName = "clusterpoolnamespace"
+	ClusterProvisionControllerName         ControllerName = "clusterProvision"
+	ClusterRelocateControllerName          ControllerName = "clusterRelocate"
+	ClusterStateControllerName             ControllerName = "clusterState"
+	ClusterVersionControllerName           ControllerName = "clusterversion"
+	ControlPlaneCertsControllerName        ControllerName = "controlPlaneCerts"
+	DNSEndpointControllerName              ControllerName = "dnsendpoint"
+	DNSZoneControllerName                  ControllerName = "dnszone"
+	FakeClusterInstallControllerName       ControllerName = "fakeclusterinstall"
+	HibernationControllerName              ControllerName = "hibernation"
+	RemoteIngressControllerName            ControllerName = "remoteingress"
+	RemoteMachinesetControllerName         ControllerName = "remotemachineset"
+	SyncIdentityProviderControllerName     ControllerName = "syncidentityprovider"
+	UnreachableControllerName              ControllerName = "unreachable"
+	VeleroBackupControllerName             ControllerName = "velerobackup"
+	MetricsControllerName                  ControllerName = "metrics"
+	ClustersyncControllerName              ControllerName = "clustersync"
+	MachineManagementControllerName        ControllerName = "machineManagement"
+	AWSPrivateLinkControllerName           ControllerName = "awsprivatelink"
+	GCPPrivateServiceConnectControllerName ControllerName = "gcpprivateserviceconnect"
+	ViewerKubeconfigControllerName         ControllerName = "viewerkubeconfig"
+	ClusterImageSetDiscoveryControllerName ControllerName = "clusterimagesetdiscovery"
+	ClusterImageSetControllerName          ControllerName = "clusterimageset"
+	ClusterDNSRecordsControllerName        ControllerName = "clusterdnsrecords"
+	AuditLogControllerName                 ControllerName = "auditlog"
+	AdditionalTrustBundleControllerName    ControllerName = "additionaltrustbundle"
+	ClusterDeploymentSummaryControllerName ControllerName = "clusterdeploymentsummary"
+	SSHKeyRotationControllerName           ControllerName = "sshkeyrotation"
+	CredentialsExpiryControllerName        ControllerName = "credentialsexpiry"
+	BackupExportControllerName             ControllerName = "backupexport"
+	EndpointHealthControllerName           ControllerName = "endpointhealth"
+	ClusterAdoptionControllerName          ControllerName = "clusteradoption"
+	HiveControllerName                     ControllerName = "hive"
 )
 
 // SpecificControllerConfig contains the configuration for a specific controller
@@ -0,0 +1,3 @@
+// Package nutanix contains API Schema definitions for Nutanix clusters.
+// +k8s:deepcopy-gen=package,register
+package nutanix
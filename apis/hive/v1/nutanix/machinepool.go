@@ -0,0 +1,25 @@
+package nutanix
+
+// MachinePool stores the configuration for a machine pool installed
+// on Nutanix.
+type MachinePool struct {
+	// NumCPUs is the total number of virtual processor cores to assign a vm.
+	NumCPUs int64 `json:"cpus"`
+
+	// NumCoresPerSocket is the number of cores per socket in a vm. The number
+	// of sockets of the vm will be NumCPUs/NumCoresPerSocket. The default is 1.
+	// +optional
+	NumCoresPerSocket int64 `json:"coresPerSocket,omitempty"`
+
+	// MemoryMiB is the size of a VM's memory in MiB.
+	MemoryMiB int64 `json:"memoryMiB"`
+
+	// OSDisk defines the storage for instance.
+	OSDisk `json:"osDisk"`
+}
+
+// OSDisk defines the disk for a virtual machine.
+type OSDisk struct {
+	// DiskSizeGiB defines the size of disk in GiB.
+	DiskSizeGiB int64 `json:"diskSizeGiB"`
+}
@@ -0,0 +1,54 @@
+package nutanix
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Platform stores any global configuration used for Nutanix platforms.
+type Platform struct {
+	// PrismCentral is the endpoint of the Prism Central managing the Prism Elements in which the virtual machines
+	// are created.
+	PrismCentral PrismEndpoint `json:"prismCentral"`
+
+	// PrismElements are the Prism Elements, the Nutanix clusters, in which the virtual machines are created.
+	// +kubebuilder:validation:MinItems=1
+	PrismElements []PrismElement `json:"prismElements"`
+
+	// SubnetUUIDs are the UUIDs of the subnets to which the virtual machines are attached.
+	// +kubebuilder:validation:MinItems=1
+	SubnetUUIDs []string `json:"subnetUUIDs"`
+
+	// CredentialsSecretRef refers to a secret that contains the Prism Central account access
+	// credentials: username, password fields.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// CertificatesSecretRef refers to a secret that contains the CA certificates necessary for
+	// communicating with Prism Central, when its certificate is not signed by a public authority.
+	// +optional
+	CertificatesSecretRef *corev1.LocalObjectReference `json:"certificatesSecretRef,omitempty"`
+}
+
+// PrismEndpoint is the endpoint of a Prism Central or Prism Element.
+type PrismEndpoint struct {
+	// Address is the domain name or IP address of the endpoint.
+	Address string `json:"address"`
+
+	// Port is the port of the endpoint. The default is 9440.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
+}
+
+// PrismElement is a Prism Element, a Nutanix cluster, in which virtual machines are created.
+type PrismElement struct {
+	// UUID is the UUID of the Prism Element.
+	UUID string `json:"uuid"`
+
+	// Endpoint is the endpoint of the Prism Element.
+	Endpoint PrismEndpoint `json:"endpoint"`
+
+	// Name is the name of the Prism Element.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
@@ -0,0 +1,3 @@
+// Package powervs contains API Schema definitions for IBM Power Virtual Server clusters.
+// +k8s:deepcopy-gen=package,register
+package powervs
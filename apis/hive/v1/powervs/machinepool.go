@@ -0,0 +1,22 @@
+package powervs
+
+// MachinePool stores the configuration for a machine pool installed
+// on IBM Power Virtual Server.
+type MachinePool struct {
+	// ProcType defines the processor sharing model for the instance.
+	// +kubebuilder:validation:Enum=Dedicated;Shared;Capped
+	// +optional
+	ProcType string `json:"procType,omitempty"`
+
+	// Processors defines the processing units for the instance, such as "0.5" or "4".
+	// +optional
+	Processors string `json:"processors,omitempty"`
+
+	// MemoryGiB defines the memory, in GiB, for the instance.
+	// +optional
+	MemoryGiB int32 `json:"memoryGiB,omitempty"`
+
+	// SysType defines the system type for the instance, such as "s922" or "e980".
+	// +optional
+	SysType string `json:"sysType,omitempty"`
+}
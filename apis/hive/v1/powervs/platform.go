@@ -0,0 +1,21 @@
+package powervs
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Platform stores all the global configuration that all machinesets use.
+type Platform struct {
+	// CredentialsSecretRef refers to a secret that contains IBM Cloud account access
+	// credentials.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// Region specifies the IBM Cloud region where the cluster will be created.
+	Region string `json:"region"`
+
+	// Zone specifies the IBM Cloud zone, within the region, where the cluster will be created.
+	Zone string `json:"zone"`
+
+	// ServiceInstanceID is the GUID of the Power Virtual Server workspace in which the cluster will be created.
+	ServiceInstanceID string `json:"serviceInstanceID"`
+}
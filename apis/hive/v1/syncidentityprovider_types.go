@@ -11,6 +11,12 @@ type SyncIdentityProviderCommonSpec struct {
 	//IdentityProviders is an ordered list of ways for a user to identify themselves
 	// +required
 	IdentityProviders []openshiftapiv1.IdentityProvider `json:"identityProviders"`
+
+	// SyncReferencedResources indicates whether the secrets and config maps referenced by the identity providers
+	// are synced to the openshift-config namespace of the clusters. The secrets and config maps are taken from the
+	// namespace of each ClusterDeployment.
+	// +optional
+	SyncReferencedResources bool `json:"syncReferencedResources,omitempty"`
 }
 
 // SelectorSyncIdentityProviderSpec defines the SyncIdentityProviderCommonSpec to sync to
@@ -37,16 +43,92 @@ type SyncIdentityProviderSpec struct {
 	ClusterDeploymentRefs []corev1.LocalObjectReference `json:"clusterDeploymentRefs"`
 }
 
-// IdentityProviderStatus defines the observed state of SyncSet
+// IdentityProviderStatus defines the observed state of SyncIdentityProvider and SelectorSyncIdentityProvider
 type IdentityProviderStatus struct {
+	// Conditions includes more detailed status for the identity providers.
+	// +optional
+	Conditions []IdentityProviderCondition `json:"conditions,omitempty"`
+
+	// ClusterDeployments is the result of applying the identity providers to each of the clusters they apply to.
+	// +optional
+	ClusterDeployments []IdentityProviderClusterStatus `json:"clusterDeployments,omitempty"`
+}
+
+// IdentityProviderClusterStatus is the result of applying the identity providers to a cluster.
+type IdentityProviderClusterStatus struct {
+	// Namespace is the namespace of the ClusterDeployment.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the ClusterDeployment.
+	Name string `json:"name"`
+
+	// Result is the result of the last attempt to apply the identity providers to the cluster.
+	Result IdentityProviderApplyResult `json:"result"`
+
+	// FailureMessage is a message describing why the identity providers could not be applied. This is only set
+	// when Result is Failure.
+	// +optional
+	FailureMessage string `json:"failureMessage,omitempty"`
+
+	// LastTransitionTime is the time when this status last changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// IdentityProviderApplyResult is the result of applying the identity providers to a cluster.
+// +kubebuilder:validation:Enum=Pending;Success;Failure
+type IdentityProviderApplyResult string
+
+const (
+	// PendingIdentityProviderApplyResult is the result when the identity providers have not yet been applied to the
+	// cluster.
+	PendingIdentityProviderApplyResult IdentityProviderApplyResult = "Pending"
+
+	// SuccessIdentityProviderApplyResult is the result when the identity providers were applied successfully to the
+	// cluster.
+	SuccessIdentityProviderApplyResult IdentityProviderApplyResult = "Success"
+
+	// FailureIdentityProviderApplyResult is the result when there was an error when attempting to apply the identity
+	// providers to the cluster.
+	FailureIdentityProviderApplyResult IdentityProviderApplyResult = "Failure"
+)
+
+// IdentityProviderCondition contains details for the current condition of a SyncIdentityProvider or
+// SelectorSyncIdentityProvider
+type IdentityProviderCondition struct {
+	// Type is the type of the condition.
+	Type IdentityProviderConditionType `json:"type"`
+	// Status is the status of the condition.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastProbeTime is the last time we probed the condition.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
+// IdentityProviderConditionType is a valid value for IdentityProviderCondition.Type
+type IdentityProviderConditionType string
+
+const (
+	// ApplyFailedIdentityProviderCondition is true when the identity providers could not be applied to one or more
+	// of the clusters.
+	ApplyFailedIdentityProviderCondition IdentityProviderConditionType = "ApplyFailed"
+)
+
 // +genclient
 // +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // SelectorSyncIdentityProvider is the Schema for the SelectorSyncSet API
 // +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
 type SelectorSyncIdentityProvider struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -61,6 +143,7 @@ type SelectorSyncIdentityProvider struct {
 
 // SyncIdentityProvider is the Schema for the SyncIdentityProvider API
 // +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Namespaced
 type SyncIdentityProvider struct {
 	metav1.TypeMeta   `json:",inline"`
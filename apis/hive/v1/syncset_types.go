@@ -101,6 +101,41 @@ type SecretMapping struct {
 
 	// TargetRef specifies the target name and namespace of the secret on the target cluster
 	TargetRef SecretReference `json:"targetRef"`
+
+	// Keys limits the keys of the source secret that are synced to the target secret, optionally renaming them.
+	// When empty, all of the keys of the source secret are synced, unless Templates is set.
+	// +optional
+	Keys []SecretKeyMapping `json:"keys,omitempty"`
+
+	// Templates are keys of the target secret rendered from the data of the source secret with Go templates. The
+	// data of the source secret is available to the templates as a map of keys to decoded string values, along with
+	// the b64enc and b64dec functions. Templated keys take precedence over keys synced with Keys.
+	// +optional
+	Templates []SecretKeyTemplate `json:"templates,omitempty"`
+
+	// Type is the type of the target secret. Defaults to the type of the source secret.
+	// +optional
+	Type corev1.SecretType `json:"type,omitempty"`
+}
+
+// SecretKeyMapping maps a key of a source secret to a key of a target secret.
+type SecretKeyMapping struct {
+	// SourceKey is the key in the source secret.
+	SourceKey string `json:"sourceKey"`
+
+	// TargetKey is the key in the target secret. Defaults to SourceKey.
+	// +optional
+	TargetKey string `json:"targetKey,omitempty"`
+}
+
+// SecretKeyTemplate defines a key of a target secret rendered from a Go template.
+type SecretKeyTemplate struct {
+	// Key is the key in the target secret.
+	Key string `json:"key"`
+
+	// Template is the Go template rendered into the value of the key.
+	// eg. {"auths":{"{{ .registry }}":{"auth":"{{ printf "%s:%s" .username .password | b64enc }}"}}}
+	Template string `json:"template"`
 }
 
 // SyncConditionType is a valid value for SyncCondition.Type
@@ -206,6 +241,7 @@ type SyncStatus struct {
 type SyncSetCommonSpec struct {
 	// Resources is the list of objects to sync from RawExtension definitions.
 	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
 	Resources []runtime.RawExtension `json:"resources,omitempty"`
 
 	// ResourceApplyMode indicates if the Resource apply mode is "Upsert" (default) or "Sync".
@@ -233,8 +269,33 @@ type SyncSetCommonSpec struct {
 	// labels, and other map entries in general.
 	// +optional
 	ApplyBehavior SyncSetApplyBehavior `json:"applyBehavior,omitempty"`
+
+	// ApplyAfterClaim indicates that the syncset only applies to clusters from a ClusterPool once they have been
+	// claimed, so that claimant-specific configuration is not applied to unclaimed clusters of the pool. Clusters that
+	// are not from a ClusterPool are not affected. When the ClusterDeployment is no longer claimed, resources applied
+	// with the "Sync" ResourceApplyMode are deleted.
+	// +optional
+	ApplyAfterClaim bool `json:"applyAfterClaim,omitempty"`
+}
+
+// SyncSetApplyWindow is a recurring window of time during which changes to SyncSets are applied to a cluster.
+type SyncSetApplyWindow struct {
+	// Days are the days of the week on which the window opens. The window opens every day when empty.
+	// +optional
+	Days []SyncSetApplyWindowDay `json:"days,omitempty"`
+
+	// Start is the time of day, in UTC and "HH:MM" format, at which the window opens.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// Duration is how long the window stays open. It must be greater than zero.
+	Duration metav1.Duration `json:"duration"`
 }
 
+// SyncSetApplyWindowDay is a day of the week on which a SyncSetApplyWindow opens.
+// +kubebuilder:validation:Enum=Sunday;Monday;Tuesday;Wednesday;Thursday;Friday;Saturday
+type SyncSetApplyWindowDay string
+
 // SelectorSyncSetSpec defines the SyncSetCommonSpec resources and patches to sync along
 // with a ClusterDeploymentSelector indicating which clusters the SelectorSyncSet applies
 // to in any namespace.
@@ -290,6 +351,7 @@ type SelectorSyncSet struct {
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=syncsets,shortName=ss,scope=Namespaced
+// +kubebuilder:storageversion
 type SyncSet struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -11,8 +11,10 @@ import (
 	azure "github.com/openshift/hive/apis/hive/v1/azure"
 	baremetal "github.com/openshift/hive/apis/hive/v1/baremetal"
 	gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	nutanix "github.com/openshift/hive/apis/hive/v1/nutanix"
 	openstack "github.com/openshift/hive/apis/hive/v1/openstack"
 	ovirt "github.com/openshift/hive/apis/hive/v1/ovirt"
+	powervs "github.com/openshift/hive/apis/hive/v1/powervs"
 	vsphere "github.com/openshift/hive/apis/hive/v1/vsphere"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -20,6 +22,27 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIURLOverrideFailback) DeepCopyInto(out *APIURLOverrideFailback) {
+	*out = *in
+	if in.ProbeInterval != nil {
+		in, out := &in.ProbeInterval, &out.ProbeInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIURLOverrideFailback.
+func (in *APIURLOverrideFailback) DeepCopy() *APIURLOverrideFailback {
+	if in == nil {
+		return nil
+	}
+	out := new(APIURLOverrideFailback)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSAssociatedVPC) DeepCopyInto(out *AWSAssociatedVPC) {
 	*out = *in
@@ -42,6 +65,32 @@ func (in *AWSAssociatedVPC) DeepCopy() *AWSAssociatedVPC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSClientCacheConfig) DeepCopyInto(out *AWSClientCacheConfig) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxEntries != nil {
+		in, out := &in.MaxEntries, &out.MaxEntries
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSClientCacheConfig.
+func (in *AWSClientCacheConfig) DeepCopy() *AWSClientCacheConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSClientCacheConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSClusterDeprovision) DeepCopyInto(out *AWSClusterDeprovision) {
 	*out = *in
@@ -55,6 +104,16 @@ func (in *AWSClusterDeprovision) DeepCopyInto(out *AWSClusterDeprovision) {
 		*out = new(aws.AssumeRole)
 		**out = **in
 	}
+	if in.CredentialsSource != nil {
+		in, out := &in.CredentialsSource, &out.CredentialsSource
+		*out = new(aws.CredentialsSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceEndpoints != nil {
+		in, out := &in.ServiceEndpoints, &out.ServiceEndpoints
+		*out = make([]aws.ServiceEndpoint, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -68,6 +127,38 @@ func (in *AWSClusterDeprovision) DeepCopy() *AWSClusterDeprovision {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSDNSSECStatus) DeepCopyInto(out *AWSDNSSECStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSDNSSECStatus.
+func (in *AWSDNSSECStatus) DeepCopy() *AWSDNSSECStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSDNSSECStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSDNSZoneQueryLogging) DeepCopyInto(out *AWSDNSZoneQueryLogging) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSDNSZoneQueryLogging.
+func (in *AWSDNSZoneQueryLogging) DeepCopy() *AWSDNSZoneQueryLogging {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSDNSZoneQueryLogging)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSDNSZoneSpec) DeepCopyInto(out *AWSDNSZoneSpec) {
 	*out = *in
@@ -77,11 +168,36 @@ func (in *AWSDNSZoneSpec) DeepCopyInto(out *AWSDNSZoneSpec) {
 		*out = new(aws.AssumeRole)
 		**out = **in
 	}
+	if in.CredentialsSource != nil {
+		in, out := &in.CredentialsSource, &out.CredentialsSource
+		*out = new(aws.CredentialsSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceEndpoints != nil {
+		in, out := &in.ServiceEndpoints, &out.ServiceEndpoints
+		*out = make([]aws.ServiceEndpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.AssumeRole != nil {
+		in, out := &in.AssumeRole, &out.AssumeRole
+		*out = new(aws.AssumeRole)
+		**out = **in
+	}
 	if in.AdditionalTags != nil {
 		in, out := &in.AdditionalTags, &out.AdditionalTags
 		*out = make([]AWSResourceTag, len(*in))
 		copy(*out, *in)
 	}
+	if in.VPCs != nil {
+		in, out := &in.VPCs, &out.VPCs
+		*out = make([]AWSDNSZoneVPC, len(*in))
+		copy(*out, *in)
+	}
+	if in.QueryLogging != nil {
+		in, out := &in.QueryLogging, &out.QueryLogging
+		*out = new(AWSDNSZoneQueryLogging)
+		**out = **in
+	}
 	return
 }
 
@@ -103,6 +219,11 @@ func (in *AWSDNSZoneStatus) DeepCopyInto(out *AWSDNSZoneStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.DNSSEC != nil {
+		in, out := &in.DNSSEC, &out.DNSSEC
+		*out = new(AWSDNSSECStatus)
+		**out = **in
+	}
 	return
 }
 
@@ -116,6 +237,22 @@ func (in *AWSDNSZoneStatus) DeepCopy() *AWSDNSZoneStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSDNSZoneVPC) DeepCopyInto(out *AWSDNSZoneVPC) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSDNSZoneVPC.
+func (in *AWSDNSZoneVPC) DeepCopy() *AWSDNSZoneVPC {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSDNSZoneVPC)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSPrivateLinkConfig) DeepCopyInto(out *AWSPrivateLinkConfig) {
 	*out = *in
@@ -156,6 +293,11 @@ func (in *AWSPrivateLinkInventory) DeepCopyInto(out *AWSPrivateLinkInventory) {
 		*out = make([]AWSPrivateLinkSubnet, len(*in))
 		copy(*out, *in)
 	}
+	if in.ServedRegions != nil {
+		in, out := &in.ServedRegions, &out.ServedRegions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -217,6 +359,37 @@ func (in *AWSResourceTag) DeepCopy() *AWSResourceTag {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSRoute53RateLimitConfig) DeepCopyInto(out *AWSRoute53RateLimitConfig) {
+	*out = *in
+	if in.QPS != nil {
+		in, out := &in.QPS, &out.QPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSRoute53RateLimitConfig.
+func (in *AWSRoute53RateLimitConfig) DeepCopy() *AWSRoute53RateLimitConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSRoute53RateLimitConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSServiceProviderCredentials) DeepCopyInto(out *AWSServiceProviderCredentials) {
 	*out = *in
@@ -235,254 +408,493 @@ func (in *AWSServiceProviderCredentials) DeepCopy() *AWSServiceProviderCredentia
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AzureClusterDeprovision) DeepCopyInto(out *AzureClusterDeprovision) {
+func (in *AdditionalTrustBundle) DeepCopyInto(out *AdditionalTrustBundle) {
 	*out = *in
-	if in.CredentialsSecretRef != nil {
-		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
+	out.ConfigMapRef = in.ConfigMapRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureClusterDeprovision.
-func (in *AzureClusterDeprovision) DeepCopy() *AzureClusterDeprovision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalTrustBundle.
+func (in *AdditionalTrustBundle) DeepCopy() *AdditionalTrustBundle {
 	if in == nil {
 		return nil
 	}
-	out := new(AzureClusterDeprovision)
+	out := new(AdditionalTrustBundle)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AzureDNSZoneSpec) DeepCopyInto(out *AzureDNSZoneSpec) {
+func (in *AdmissionRuleConfig) DeepCopyInto(out *AdmissionRuleConfig) {
 	*out = *in
-	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureDNSZoneSpec.
-func (in *AzureDNSZoneSpec) DeepCopy() *AzureDNSZoneSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionRuleConfig.
+func (in *AdmissionRuleConfig) DeepCopy() *AdmissionRuleConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(AzureDNSZoneSpec)
+	out := new(AdmissionRuleConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AzureDNSZoneStatus) DeepCopyInto(out *AzureDNSZoneStatus) {
+func (in *AuditLogConfig) DeepCopyInto(out *AuditLogConfig) {
 	*out = *in
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]AuditLogOutput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureDNSZoneStatus.
-func (in *AzureDNSZoneStatus) DeepCopy() *AzureDNSZoneStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogConfig.
+func (in *AuditLogConfig) DeepCopy() *AuditLogConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(AzureDNSZoneStatus)
+	out := new(AuditLogConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BackupConfig) DeepCopyInto(out *BackupConfig) {
+func (in *AuditLogOutput) DeepCopyInto(out *AuditLogOutput) {
 	*out = *in
-	out.Velero = in.Velero
-	if in.MinBackupPeriodSeconds != nil {
-		in, out := &in.MinBackupPeriodSeconds, &out.MinBackupPeriodSeconds
-		*out = new(int)
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfig.
-func (in *BackupConfig) DeepCopy() *BackupConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogOutput.
+func (in *AuditLogOutput) DeepCopy() *AuditLogOutput {
 	if in == nil {
 		return nil
 	}
-	out := new(BackupConfig)
+	out := new(AuditLogOutput)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BackupReference) DeepCopyInto(out *BackupReference) {
+func (in *AzureClusterDeprovision) DeepCopyInto(out *AzureClusterDeprovision) {
 	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupReference.
-func (in *BackupReference) DeepCopy() *BackupReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureClusterDeprovision.
+func (in *AzureClusterDeprovision) DeepCopy() *AzureClusterDeprovision {
 	if in == nil {
 		return nil
 	}
-	out := new(BackupReference)
+	out := new(AzureClusterDeprovision)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CentralMachineManagement) DeepCopyInto(out *CentralMachineManagement) {
+func (in *AzureDNSZoneSpec) DeepCopyInto(out *AzureDNSZoneSpec) {
 	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.VirtualNetworkLinks != nil {
+		in, out := &in.VirtualNetworkLinks, &out.VirtualNetworkLinks
+		*out = make([]AzureVirtualNetworkLink, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CentralMachineManagement.
-func (in *CentralMachineManagement) DeepCopy() *CentralMachineManagement {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureDNSZoneSpec.
+func (in *AzureDNSZoneSpec) DeepCopy() *AzureDNSZoneSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CentralMachineManagement)
+	out := new(AzureDNSZoneSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificateBundleSpec) DeepCopyInto(out *CertificateBundleSpec) {
+func (in *AzureDNSZoneStatus) DeepCopyInto(out *AzureDNSZoneStatus) {
 	*out = *in
-	out.CertificateSecretRef = in.CertificateSecretRef
+	if in.VirtualNetworkLinks != nil {
+		in, out := &in.VirtualNetworkLinks, &out.VirtualNetworkLinks
+		*out = make([]AzureVirtualNetworkLinkStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateBundleSpec.
-func (in *CertificateBundleSpec) DeepCopy() *CertificateBundleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureDNSZoneStatus.
+func (in *AzureDNSZoneStatus) DeepCopy() *AzureDNSZoneStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CertificateBundleSpec)
+	out := new(AzureDNSZoneStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificateBundleStatus) DeepCopyInto(out *CertificateBundleStatus) {
+func (in *AzureVirtualNetworkLink) DeepCopyInto(out *AzureVirtualNetworkLink) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateBundleStatus.
-func (in *CertificateBundleStatus) DeepCopy() *CertificateBundleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureVirtualNetworkLink.
+func (in *AzureVirtualNetworkLink) DeepCopy() *AzureVirtualNetworkLink {
 	if in == nil {
 		return nil
 	}
-	out := new(CertificateBundleStatus)
+	out := new(AzureVirtualNetworkLink)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Checkpoint) DeepCopyInto(out *Checkpoint) {
+func (in *AzureVirtualNetworkLinkStatus) DeepCopyInto(out *AzureVirtualNetworkLinkStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Checkpoint.
-func (in *Checkpoint) DeepCopy() *Checkpoint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureVirtualNetworkLinkStatus.
+func (in *AzureVirtualNetworkLinkStatus) DeepCopy() *AzureVirtualNetworkLinkStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Checkpoint)
+	out := new(AzureVirtualNetworkLinkStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Checkpoint) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CheckpointList) DeepCopyInto(out *CheckpointList) {
+func (in *BackupConfig) DeepCopyInto(out *BackupConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Checkpoint, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out.Velero = in.Velero
+	if in.MinBackupPeriodSeconds != nil {
+		in, out := &in.MinBackupPeriodSeconds, &out.MinBackupPeriodSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.Export != nil {
+		in, out := &in.Export, &out.Export
+		*out = new(BackupExportConfig)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointList.
-func (in *CheckpointList) DeepCopy() *CheckpointList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfig.
+func (in *BackupConfig) DeepCopy() *BackupConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(CheckpointList)
+	out := new(BackupConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CheckpointList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CheckpointSpec) DeepCopyInto(out *CheckpointSpec) {
+func (in *BackupExportConfig) DeepCopyInto(out *BackupExportConfig) {
 	*out = *in
-	in.LastBackupTime.DeepCopyInto(&out.LastBackupTime)
-	out.LastBackupRef = in.LastBackupRef
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(int32)
+		**out = **in
+	}
+	out.S3 = in.S3
+	if in.SecretsEncryptionKeySecretRef != nil {
+		in, out := &in.SecretsEncryptionKeySecretRef, &out.SecretsEncryptionKeySecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointSpec.
-func (in *CheckpointSpec) DeepCopy() *CheckpointSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupExportConfig.
+func (in *BackupExportConfig) DeepCopy() *BackupExportConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(CheckpointSpec)
+	out := new(BackupExportConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CheckpointStatus) DeepCopyInto(out *CheckpointStatus) {
+func (in *BackupExportS3Config) DeepCopyInto(out *BackupExportS3Config) {
 	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointStatus.
-func (in *CheckpointStatus) DeepCopy() *CheckpointStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupExportS3Config.
+func (in *BackupExportS3Config) DeepCopy() *BackupExportS3Config {
 	if in == nil {
 		return nil
 	}
-	out := new(CheckpointStatus)
+	out := new(BackupExportS3Config)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterClaim) DeepCopyInto(out *ClusterClaim) {
+func (in *BackupReference) DeepCopyInto(out *BackupReference) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaim.
-func (in *ClusterClaim) DeepCopy() *ClusterClaim {
-	if in == nil {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupReference.
+func (in *BackupReference) DeepCopy() *BackupReference {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryConfig) DeepCopyInto(out *CanaryConfig) {
+	*out = *in
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	if in.ControllersConfig != nil {
+		in, out := &in.ControllersConfig, &out.ControllersConfig
+		*out = new(ControllersConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxErrorRatePercent != nil {
+		in, out := &in.MaxErrorRatePercent, &out.MaxErrorRatePercent
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryConfig.
+func (in *CanaryConfig) DeepCopy() *CanaryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryStatus) DeepCopyInto(out *CanaryStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStatus.
+func (in *CanaryStatus) DeepCopy() *CanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CentralMachineManagement) DeepCopyInto(out *CentralMachineManagement) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CentralMachineManagement.
+func (in *CentralMachineManagement) DeepCopy() *CentralMachineManagement {
+	if in == nil {
+		return nil
+	}
+	out := new(CentralMachineManagement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateBundleSpec) DeepCopyInto(out *CertificateBundleSpec) {
+	*out = *in
+	out.CertificateSecretRef = in.CertificateSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateBundleSpec.
+func (in *CertificateBundleSpec) DeepCopy() *CertificateBundleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateBundleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateBundleStatus) DeepCopyInto(out *CertificateBundleStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateBundleStatus.
+func (in *CertificateBundleStatus) DeepCopy() *CertificateBundleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateBundleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Checkpoint) DeepCopyInto(out *Checkpoint) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Checkpoint.
+func (in *Checkpoint) DeepCopy() *Checkpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(Checkpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Checkpoint) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckpointList) DeepCopyInto(out *CheckpointList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Checkpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointList.
+func (in *CheckpointList) DeepCopy() *CheckpointList {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckpointList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CheckpointList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckpointSpec) DeepCopyInto(out *CheckpointSpec) {
+	*out = *in
+	in.LastBackupTime.DeepCopyInto(&out.LastBackupTime)
+	out.LastBackupRef = in.LastBackupRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointSpec.
+func (in *CheckpointSpec) DeepCopy() *CheckpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckpointStatus) DeepCopyInto(out *CheckpointStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointStatus.
+func (in *CheckpointStatus) DeepCopy() *CheckpointStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckpointStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAdoption) DeepCopyInto(out *ClusterAdoption) {
+	*out = *in
+	out.AdminKubeconfigSecretRef = in.AdminKubeconfigSecretRef
+	if in.AdminPasswordSecretRef != nil {
+		in, out := &in.AdminPasswordSecretRef, &out.AdminPasswordSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAdoption.
+func (in *ClusterAdoption) DeepCopy() *ClusterAdoption {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAdoption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClaim) DeepCopyInto(out *ClusterClaim) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaim.
+func (in *ClusterClaim) DeepCopy() *ClusterClaim {
+	if in == nil {
 		return nil
 	}
 	out := new(ClusterClaim)
@@ -704,10 +1116,15 @@ func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
 		*out = make([]CertificateBundleSpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.DNSRouting != nil {
+		in, out := &in.DNSRouting, &out.DNSRouting
+		*out = new(DNSRouting)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ClusterMetadata != nil {
 		in, out := &in.ClusterMetadata, &out.ClusterMetadata
 		*out = new(ClusterMetadata)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Provisioning != nil {
 		in, out := &in.Provisioning, &out.Provisioning
@@ -719,6 +1136,11 @@ func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
 		*out = new(ClusterInstallLocalReference)
 		**out = **in
 	}
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(ClusterAdoption)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ClusterPoolRef != nil {
 		in, out := &in.ClusterPoolRef, &out.ClusterPoolRef
 		*out = new(ClusterPoolReference)
@@ -744,6 +1166,33 @@ func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.ViewerKubeconfig != nil {
+		in, out := &in.ViewerKubeconfig, &out.ViewerKubeconfig
+		*out = new(ViewerKubeconfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalTrustBundle != nil {
+		in, out := &in.AdditionalTrustBundle, &out.AdditionalTrustBundle
+		*out = new(AdditionalTrustBundle)
+		**out = **in
+	}
+	if in.CloudClientProxySecretRef != nil {
+		in, out := &in.CloudClientProxySecretRef, &out.CloudClientProxySecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.SSHKeyRotation != nil {
+		in, out := &in.SSHKeyRotation, &out.SSHKeyRotation
+		*out = new(SSHKeyRotation)
+		**out = **in
+	}
+	if in.SyncSetApplyWindows != nil {
+		in, out := &in.SyncSetApplyWindows, &out.SyncSetApplyWindows
+		*out = make([]SyncSetApplyWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -805,6 +1254,23 @@ func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
 		*out = new(PlatformStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SSHKeyRotation != nil {
+		in, out := &in.SSHKeyRotation, &out.SSHKeyRotation
+		*out = new(SSHKeyRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PowerStateHistory != nil {
+		in, out := &in.PowerStateHistory, &out.PowerStateHistory
+		*out = make([]PowerStateTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClusterVersion != nil {
+		in, out := &in.ClusterVersion, &out.ClusterVersion
+		*out = new(ClusterVersionStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -819,27 +1285,27 @@ func (in *ClusterDeploymentStatus) DeepCopy() *ClusterDeploymentStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeprovision) DeepCopyInto(out *ClusterDeprovision) {
+func (in *ClusterDeploymentSummary) DeepCopyInto(out *ClusterDeploymentSummary) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovision.
-func (in *ClusterDeprovision) DeepCopy() *ClusterDeprovision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentSummary.
+func (in *ClusterDeploymentSummary) DeepCopy() *ClusterDeploymentSummary {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeprovision)
+	out := new(ClusterDeploymentSummary)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterDeprovision) DeepCopyObject() runtime.Object {
+func (in *ClusterDeploymentSummary) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -847,12 +1313,137 @@ func (in *ClusterDeprovision) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeprovisionCondition) DeepCopyInto(out *ClusterDeprovisionCondition) {
+func (in *ClusterDeploymentSummaryList) DeepCopyInto(out *ClusterDeploymentSummaryList) {
 	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
-	return
-}
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterDeploymentSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentSummaryList.
+func (in *ClusterDeploymentSummaryList) DeepCopy() *ClusterDeploymentSummaryList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentSummaryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDeploymentSummaryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentSummarySpec) DeepCopyInto(out *ClusterDeploymentSummarySpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentSummarySpec.
+func (in *ClusterDeploymentSummarySpec) DeepCopy() *ClusterDeploymentSummarySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentSummarySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentSummaryStatus) DeepCopyInto(out *ClusterDeploymentSummaryStatus) {
+	*out = *in
+	if in.LastUpdated != nil {
+		in, out := &in.LastUpdated, &out.LastUpdated
+		*out = (*in).DeepCopy()
+	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PowerStates != nil {
+		in, out := &in.PowerStates, &out.PowerStates
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentSummaryStatus.
+func (in *ClusterDeploymentSummaryStatus) DeepCopy() *ClusterDeploymentSummaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentSummaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeprovision) DeepCopyInto(out *ClusterDeprovision) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovision.
+func (in *ClusterDeprovision) DeepCopy() *ClusterDeprovision {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeprovision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDeprovision) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeprovisionCondition) DeepCopyInto(out *ClusterDeprovisionCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionCondition.
 func (in *ClusterDeprovisionCondition) DeepCopy() *ClusterDeprovisionCondition {
@@ -930,6 +1521,16 @@ func (in *ClusterDeprovisionPlatform) DeepCopyInto(out *ClusterDeprovisionPlatfo
 		*out = new(OvirtClusterDeprovision)
 		**out = **in
 	}
+	if in.Nutanix != nil {
+		in, out := &in.Nutanix, &out.Nutanix
+		*out = new(NutanixClusterDeprovision)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PowerVS != nil {
+		in, out := &in.PowerVS, &out.PowerVS
+		*out = new(PowerVSClusterDeprovision)
+		**out = **in
+	}
 	return
 }
 
@@ -989,7 +1590,7 @@ func (in *ClusterImageSet) DeepCopyInto(out *ClusterImageSet) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -1011,6 +1612,66 @@ func (in *ClusterImageSet) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSetCondition) DeepCopyInto(out *ClusterImageSetCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetCondition.
+func (in *ClusterImageSetCondition) DeepCopy() *ClusterImageSetCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSetCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSetDiscoveryChannel) DeepCopyInto(out *ClusterImageSetDiscoveryChannel) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetDiscoveryChannel.
+func (in *ClusterImageSetDiscoveryChannel) DeepCopy() *ClusterImageSetDiscoveryChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSetDiscoveryChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSetDiscoveryConfig) DeepCopyInto(out *ClusterImageSetDiscoveryConfig) {
+	*out = *in
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]ClusterImageSetDiscoveryChannel, len(*in))
+		copy(*out, *in)
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetDiscoveryConfig.
+func (in *ClusterImageSetDiscoveryConfig) DeepCopy() *ClusterImageSetDiscoveryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSetDiscoveryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterImageSetList) DeepCopyInto(out *ClusterImageSetList) {
 	*out = *in
@@ -1079,6 +1740,18 @@ func (in *ClusterImageSetSpec) DeepCopy() *ClusterImageSetSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterImageSetStatus) DeepCopyInto(out *ClusterImageSetStatus) {
 	*out = *in
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterImageSetCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1105,6 +1778,21 @@ func (in *ClusterIngress) DeepCopyInto(out *ClusterIngress) {
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NodePlacement != nil {
+		in, out := &in.NodePlacement, &out.NodePlacement
+		*out = new(IngressNodePlacement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LoadBalancer != nil {
+		in, out := &in.LoadBalancer, &out.LoadBalancer
+		*out = new(IngressLoadBalancer)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1157,6 +1845,11 @@ func (in *ClusterMetadata) DeepCopyInto(out *ClusterMetadata) {
 	*out = *in
 	out.AdminKubeconfigSecretRef = in.AdminKubeconfigSecretRef
 	out.AdminPasswordSecretRef = in.AdminPasswordSecretRef
+	if in.ViewerKubeconfigSecretRef != nil {
+		in, out := &in.ViewerKubeconfigSecretRef, &out.ViewerKubeconfigSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	return
 }
 
@@ -1523,6 +2216,11 @@ func (in *ClusterProvisionSpec) DeepCopyInto(out *ClusterProvisionSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.InstallerAssetsRef != nil {
+		in, out := &in.InstallerAssetsRef, &out.InstallerAssetsRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	return
 }
 
@@ -1551,6 +2249,13 @@ func (in *ClusterProvisionStatus) DeepCopyInto(out *ClusterProvisionStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.InstallPhases != nil {
+		in, out := &in.InstallPhases, &out.InstallPhases
+		*out = make([]InstallPhase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1570,7 +2275,7 @@ func (in *ClusterRelocate) DeepCopyInto(out *ClusterRelocate) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -1592,6 +2297,32 @@ func (in *ClusterRelocate) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRelocateBatching) DeepCopyInto(out *ClusterRelocateBatching) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ApprovedBatches != nil {
+		in, out := &in.ApprovedBatches, &out.ApprovedBatches
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateBatching.
+func (in *ClusterRelocateBatching) DeepCopy() *ClusterRelocateBatching {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRelocateBatching)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterRelocateList) DeepCopyInto(out *ClusterRelocateList) {
 	*out = *in
@@ -1630,6 +2361,16 @@ func (in *ClusterRelocateSpec) DeepCopyInto(out *ClusterRelocateSpec) {
 	*out = *in
 	out.KubeconfigSecretRef = in.KubeconfigSecretRef
 	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Batching != nil {
+		in, out := &in.Batching, &out.Batching
+		*out = new(ClusterRelocateBatching)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1646,6 +2387,15 @@ func (in *ClusterRelocateSpec) DeepCopy() *ClusterRelocateSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterRelocateStatus) DeepCopyInto(out *ClusterRelocateStatus) {
 	*out = *in
+	if in.BatchClusters != nil {
+		in, out := &in.BatchClusters, &out.BatchClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BatchCompletionTime != nil {
+		in, out := &in.BatchCompletionTime, &out.BatchCompletionTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -1763,6 +2513,34 @@ func (in *ClusterStateStatus) DeepCopy() *ClusterStateStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterVersionStatus) DeepCopyInto(out *ClusterVersionStatus) {
+	*out = *in
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]configv1.UpdateHistory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AvailableUpdates != nil {
+		in, out := &in.AvailableUpdates, &out.AvailableUpdates
+		*out = make([]configv1.Update, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVersionStatus.
+func (in *ClusterVersionStatus) DeepCopy() *ClusterVersionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterVersionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControlPlaneAdditionalCertificate) DeepCopyInto(out *ControlPlaneAdditionalCertificate) {
 	*out = *in
@@ -1783,6 +2561,21 @@ func (in *ControlPlaneAdditionalCertificate) DeepCopy() *ControlPlaneAdditionalC
 func (in *ControlPlaneConfigSpec) DeepCopyInto(out *ControlPlaneConfigSpec) {
 	*out = *in
 	in.ServingCertificates.DeepCopyInto(&out.ServingCertificates)
+	if in.APIURLOverrideFailback != nil {
+		in, out := &in.APIURLOverrideFailback, &out.APIURLOverrideFailback
+		*out = new(APIURLOverrideFailback)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SSHBastion != nil {
+		in, out := &in.SSHBastion, &out.SSHBastion
+		*out = new(SSHBastion)
+		**out = **in
+	}
+	if in.AuditLog != nil {
+		in, out := &in.AuditLog, &out.AuditLog
+		*out = new(AuditLogConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1825,6 +2618,11 @@ func (in *ControllerConfig) DeepCopyInto(out *ControllerConfig) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ConcurrentReconcilesPerCredentials != nil {
+		in, out := &in.ConcurrentReconcilesPerCredentials, &out.ConcurrentReconcilesPerCredentials
+		*out = new(int32)
+		**out = **in
+	}
 	if in.ClientQPS != nil {
 		in, out := &in.ClientQPS, &out.ClientQPS
 		*out = new(int32)
@@ -1912,27 +2710,102 @@ func (in *ControllersConfig) DeepCopy() *ControllersConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZone) DeepCopyInto(out *DNSZone) {
+func (in *CredentialsBrokerBinding) DeepCopyInto(out *CredentialsBrokerBinding) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.RoleARNs != nil {
+		in, out := &in.RoleARNs, &out.RoleARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VaultRoles != nil {
+		in, out := &in.VaultRoles, &out.VaultRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZone.
-func (in *DNSZone) DeepCopy() *DNSZone {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsBrokerBinding.
+func (in *CredentialsBrokerBinding) DeepCopy() *CredentialsBrokerBinding {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZone)
+	out := new(CredentialsBrokerBinding)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSZone) DeepCopyObject() runtime.Object {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsBrokerConfig) DeepCopyInto(out *CredentialsBrokerConfig) {
+	*out = *in
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultCredentialsBrokerConfig)
+		**out = **in
+	}
+	if in.Bindings != nil {
+		in, out := &in.Bindings, &out.Bindings
+		*out = make([]CredentialsBrokerBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsBrokerConfig.
+func (in *CredentialsBrokerConfig) DeepCopy() *CredentialsBrokerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsBrokerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSRouting) DeepCopyInto(out *DNSRouting) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(aws.DNSRouting)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRouting.
+func (in *DNSRouting) DeepCopy() *DNSRouting {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRouting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZone) DeepCopyInto(out *DNSZone) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZone.
+func (in *DNSZone) DeepCopy() *DNSZone {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSZone) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1990,9 +2863,39 @@ func (in *DNSZoneList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneRecordSetSummary) DeepCopyInto(out *DNSZoneRecordSetSummary) {
+	*out = *in
+	if in.LastChangeTime != nil {
+		in, out := &in.LastChangeTime, &out.LastChangeTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneRecordSetSummary.
+func (in *DNSZoneRecordSetSummary) DeepCopy() *DNSZoneRecordSetSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneRecordSetSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSZoneSpec) DeepCopyInto(out *DNSZoneSpec) {
 	*out = *in
+	if in.PreservedRecordNames != nil {
+		in, out := &in.PreservedRecordNames, &out.PreservedRecordNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CloudClientProxySecretRef != nil {
+		in, out := &in.CloudClientProxySecretRef, &out.CloudClientProxySecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	if in.AWS != nil {
 		in, out := &in.AWS, &out.AWS
 		*out = new(AWSDNSZoneSpec)
@@ -2001,13 +2904,23 @@ func (in *DNSZoneSpec) DeepCopyInto(out *DNSZoneSpec) {
 	if in.GCP != nil {
 		in, out := &in.GCP, &out.GCP
 		*out = new(GCPDNSZoneSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Azure != nil {
 		in, out := &in.Azure, &out.Azure
 		*out = new(AzureDNSZoneSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IBMCloud != nil {
+		in, out := &in.IBMCloud, &out.IBMCloud
+		*out = new(IBMCloudDNSZoneSpec)
 		**out = **in
 	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookDNSZoneSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2033,6 +2946,11 @@ func (in *DNSZoneStatus) DeepCopyInto(out *DNSZoneStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RecordSetSummary != nil {
+		in, out := &in.RecordSetSummary, &out.RecordSetSummary
+		*out = new(DNSZoneRecordSetSummary)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AWS != nil {
 		in, out := &in.AWS, &out.AWS
 		*out = new(AWSDNSZoneStatus)
@@ -2046,7 +2964,12 @@ func (in *DNSZoneStatus) DeepCopyInto(out *DNSZoneStatus) {
 	if in.Azure != nil {
 		in, out := &in.Azure, &out.Azure
 		*out = new(AzureDNSZoneStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IBMCloud != nil {
+		in, out := &in.IBMCloud, &out.IBMCloud
+		*out = new(IBMCloudDNSZoneStatus)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -2068,6 +2991,50 @@ func (in *DNSZoneStatus) DeepCopy() *DNSZoneStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointHealthConfig) DeepCopyInto(out *EndpointHealthConfig) {
+	*out = *in
+	if in.IngressEndpoints != nil {
+		in, out := &in.IngressEndpoints, &out.IngressEndpoints
+		*out = make([]EndpointHealthIngressEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointHealthConfig.
+func (in *EndpointHealthConfig) DeepCopy() *EndpointHealthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointHealthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointHealthIngressEndpoint) DeepCopyInto(out *EndpointHealthIngressEndpoint) {
+	*out = *in
+	if in.ExpectedStatusCodes != nil {
+		in, out := &in.ExpectedStatusCodes, &out.ExpectedStatusCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointHealthIngressEndpoint.
+func (in *EndpointHealthIngressEndpoint) DeepCopy() *EndpointHealthIngressEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointHealthIngressEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FailedProvisionAWSConfig) DeepCopyInto(out *FailedProvisionAWSConfig) {
 	*out = *in
@@ -2093,6 +3060,20 @@ func (in *FailedProvisionConfig) DeepCopyInto(out *FailedProvisionConfig) {
 		*out = new(FailedProvisionAWSConfig)
 		**out = **in
 	}
+	if in.AdditionalInstallLogRegexesConfigMapRef != nil {
+		in, out := &in.AdditionalInstallLogRegexesConfigMapRef, &out.AdditionalInstallLogRegexesConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.RetryReasons != nil {
+		in, out := &in.RetryReasons, &out.RetryReasons
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
 	return
 }
 
@@ -2169,10 +3150,52 @@ func (in *GCPClusterDeprovision) DeepCopy() *GCPClusterDeprovision {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPDNSZoneNetwork) DeepCopyInto(out *GCPDNSZoneNetwork) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPDNSZoneNetwork.
+func (in *GCPDNSZoneNetwork) DeepCopy() *GCPDNSZoneNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPDNSZoneNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPDNSZonePeering) DeepCopyInto(out *GCPDNSZonePeering) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPDNSZonePeering.
+func (in *GCPDNSZonePeering) DeepCopy() *GCPDNSZonePeering {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPDNSZonePeering)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCPDNSZoneSpec) DeepCopyInto(out *GCPDNSZoneSpec) {
 	*out = *in
 	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.Networks != nil {
+		in, out := &in.Networks, &out.Networks
+		*out = make([]GCPDNSZoneNetwork, len(*in))
+		copy(*out, *in)
+	}
+	if in.Peering != nil {
+		in, out := &in.Peering, &out.Peering
+		*out = new(GCPDNSZonePeering)
+		**out = **in
+	}
 	return
 }
 
@@ -2207,6 +3230,82 @@ func (in *GCPDNSZoneStatus) DeepCopy() *GCPDNSZoneStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPPrivateServiceConnectConfig) DeepCopyInto(out *GCPPrivateServiceConnectConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.EndpointInventory != nil {
+		in, out := &in.EndpointInventory, &out.EndpointInventory
+		*out = make([]GCPPrivateServiceConnectInventory, len(*in))
+		copy(*out, *in)
+	}
+	if in.AssociatedNetworks != nil {
+		in, out := &in.AssociatedNetworks, &out.AssociatedNetworks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPPrivateServiceConnectConfig.
+func (in *GCPPrivateServiceConnectConfig) DeepCopy() *GCPPrivateServiceConnectConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPPrivateServiceConnectConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPPrivateServiceConnectInventory) DeepCopyInto(out *GCPPrivateServiceConnectInventory) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPPrivateServiceConnectInventory.
+func (in *GCPPrivateServiceConnectInventory) DeepCopy() *GCPPrivateServiceConnectInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPPrivateServiceConnectInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationDefaultsConfig) DeepCopyInto(out *HibernationDefaultsConfig) {
+	*out = *in
+	if in.HibernateAfter != nil {
+		in, out := &in.HibernateAfter, &out.HibernateAfter
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ResumeTimeout != nil {
+		in, out := &in.ResumeTimeout, &out.ResumeTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.PowerStatePolicies != nil {
+		in, out := &in.PowerStatePolicies, &out.PowerStatePolicies
+		*out = make([]PowerStatePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationDefaultsConfig.
+func (in *HibernationDefaultsConfig) DeepCopy() *HibernationDefaultsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationDefaultsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HiveConfig) DeepCopyInto(out *HiveConfig) {
 	*out = *in
@@ -2309,6 +3408,46 @@ func (in *HiveConfigSpec) DeepCopyInto(out *HiveConfigSpec) {
 	in.Backup.DeepCopyInto(&out.Backup)
 	in.FailedProvisionConfig.DeepCopyInto(&out.FailedProvisionConfig)
 	in.ServiceProviderCredentialsConfig.DeepCopyInto(&out.ServiceProviderCredentialsConfig)
+	if in.CredentialsBroker != nil {
+		in, out := &in.CredentialsBroker, &out.CredentialsBroker
+		*out = new(CredentialsBrokerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(TracingConfig)
+		**out = **in
+	}
+	if in.ParentLinkResolvers != nil {
+		in, out := &in.ParentLinkResolvers, &out.ParentLinkResolvers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnreachableProbeBackoff != nil {
+		in, out := &in.UnreachableProbeBackoff, &out.UnreachableProbeBackoff
+		*out = new(UnreachableProbeBackoffConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HibernationDefaults != nil {
+		in, out := &in.HibernationDefaults, &out.HibernationDefaults
+		*out = new(HibernationDefaultsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EndpointHealth != nil {
+		in, out := &in.EndpointHealth, &out.EndpointHealth
+		*out = new(EndpointHealthConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AWSRoute53RateLimit != nil {
+		in, out := &in.AWSRoute53RateLimit, &out.AWSRoute53RateLimit
+		*out = new(AWSRoute53RateLimitConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AWSClientCache != nil {
+		in, out := &in.AWSClientCache, &out.AWSClientCache
+		*out = new(AWSClientCacheConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MaintenanceMode != nil {
 		in, out := &in.MaintenanceMode, &out.MaintenanceMode
 		*out = new(bool)
@@ -2334,6 +3473,63 @@ func (in *HiveConfigSpec) DeepCopyInto(out *HiveConfigSpec) {
 		*out = new(AWSPrivateLinkConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GCPPrivateServiceConnect != nil {
+		in, out := &in.GCPPrivateServiceConnect, &out.GCPPrivateServiceConnect
+		*out = new(GCPPrivateServiceConnectConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterImageSetDiscovery != nil {
+		in, out := &in.ClusterImageSetDiscovery, &out.ClusterImageSetDiscovery
+		*out = new(ClusterImageSetDiscoveryConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReleaseImageValidation != nil {
+		in, out := &in.ReleaseImageValidation, &out.ReleaseImageValidation
+		*out = new(ReleaseImageValidationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReleaseImageVerification != nil {
+		in, out := &in.ReleaseImageVerification, &out.ReleaseImageVerification
+		*out = new(ReleaseImageVerificationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedInstallerEnv != nil {
+		in, out := &in.AllowedInstallerEnv, &out.AllowedInstallerEnv
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdmissionRules != nil {
+		in, out := &in.AdmissionRules, &out.AdmissionRules
+		*out = make([]AdmissionRuleConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceQuotas != nil {
+		in, out := &in.NamespaceQuotas, &out.NamespaceQuotas
+		*out = make([]NamespaceQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WorkloadScheduling != nil {
+		in, out := &in.WorkloadScheduling, &out.WorkloadScheduling
+		*out = new(WorkloadScheduling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JobProxy != nil {
+		in, out := &in.JobProxy, &out.JobProxy
+		*out = new(JobProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudClientProxy != nil {
+		in, out := &in.CloudClientProxy, &out.CloudClientProxy
+		*out = new(JobProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.FeatureGates != nil {
 		in, out := &in.FeatureGates, &out.FeatureGates
 		*out = new(FeatureGateSelection)
@@ -2362,6 +3558,11 @@ func (in *HiveConfigStatus) DeepCopyInto(out *HiveConfigStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2376,25 +3577,203 @@ func (in *HiveConfigStatus) DeepCopy() *HiveConfigStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IdentityProviderStatus) DeepCopyInto(out *IdentityProviderStatus) {
+func (in *IBMCloudDNSZoneSpec) DeepCopyInto(out *IBMCloudDNSZoneSpec) {
 	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityProviderStatus.
-func (in *IdentityProviderStatus) DeepCopy() *IdentityProviderStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMCloudDNSZoneSpec.
+func (in *IBMCloudDNSZoneSpec) DeepCopy() *IBMCloudDNSZoneSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IdentityProviderStatus)
+	out := new(IBMCloudDNSZoneSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeconfigSecretReference) DeepCopyInto(out *KubeconfigSecretReference) {
+func (in *IBMCloudDNSZoneStatus) DeepCopyInto(out *IBMCloudDNSZoneStatus) {
 	*out = *in
-	return
+	if in.ZoneID != nil {
+		in, out := &in.ZoneID, &out.ZoneID
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMCloudDNSZoneStatus.
+func (in *IBMCloudDNSZoneStatus) DeepCopy() *IBMCloudDNSZoneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMCloudDNSZoneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdentityProviderClusterStatus) DeepCopyInto(out *IdentityProviderClusterStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityProviderClusterStatus.
+func (in *IdentityProviderClusterStatus) DeepCopy() *IdentityProviderClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IdentityProviderClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdentityProviderCondition) DeepCopyInto(out *IdentityProviderCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityProviderCondition.
+func (in *IdentityProviderCondition) DeepCopy() *IdentityProviderCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(IdentityProviderCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdentityProviderStatus) DeepCopyInto(out *IdentityProviderStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]IdentityProviderCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClusterDeployments != nil {
+		in, out := &in.ClusterDeployments, &out.ClusterDeployments
+		*out = make([]IdentityProviderClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityProviderStatus.
+func (in *IdentityProviderStatus) DeepCopy() *IdentityProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IdentityProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressLoadBalancer) DeepCopyInto(out *IngressLoadBalancer) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressLoadBalancer.
+func (in *IngressLoadBalancer) DeepCopy() *IngressLoadBalancer {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressLoadBalancer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressNodePlacement) DeepCopyInto(out *IngressNodePlacement) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressNodePlacement.
+func (in *IngressNodePlacement) DeepCopy() *IngressNodePlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressNodePlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallPhase) DeepCopyInto(out *InstallPhase) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallPhase.
+func (in *InstallPhase) DeepCopy() *InstallPhase {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallPhase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobProxyConfig) DeepCopyInto(out *JobProxyConfig) {
+	*out = *in
+	if in.TrustedCASecretRefs != nil {
+		in, out := &in.TrustedCASecretRefs, &out.TrustedCASecretRefs
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobProxyConfig.
+func (in *JobProxyConfig) DeepCopy() *JobProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JobProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigSecretReference) DeepCopyInto(out *KubeconfigSecretReference) {
+	*out = *in
+	return
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigSecretReference.
@@ -2649,6 +4028,16 @@ func (in *MachinePoolPlatform) DeepCopyInto(out *MachinePoolPlatform) {
 		*out = new(ovirt.MachinePool)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Nutanix != nil {
+		in, out := &in.Nutanix, &out.Nutanix
+		*out = new(nutanix.MachinePool)
+		**out = **in
+	}
+	if in.PowerVS != nil {
+		in, out := &in.PowerVS, &out.PowerVS
+		*out = new(powervs.MachinePool)
+		**out = **in
+	}
 	return
 }
 
@@ -2764,6 +4153,11 @@ func (in *MachineSetStatus) DeepCopy() *MachineSetStatus {
 func (in *ManageDNSAWSConfig) DeepCopyInto(out *ManageDNSAWSConfig) {
 	*out = *in
 	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.RoutingHostedZoneIDs != nil {
+		in, out := &in.RoutingHostedZoneIDs, &out.RoutingHostedZoneIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2805,7 +4199,7 @@ func (in *ManageDNSConfig) DeepCopyInto(out *ManageDNSConfig) {
 	if in.AWS != nil {
 		in, out := &in.AWS, &out.AWS
 		*out = new(ManageDNSAWSConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.GCP != nil {
 		in, out := &in.GCP, &out.GCP
@@ -2820,198 +4214,549 @@ func (in *ManageDNSConfig) DeepCopyInto(out *ManageDNSConfig) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSConfig.
-func (in *ManageDNSConfig) DeepCopy() *ManageDNSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSConfig.
+func (in *ManageDNSConfig) DeepCopy() *ManageDNSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ManageDNSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManageDNSGCPConfig) DeepCopyInto(out *ManageDNSGCPConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSGCPConfig.
+func (in *ManageDNSGCPConfig) DeepCopy() *ManageDNSGCPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ManageDNSGCPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManualCredentials) DeepCopyInto(out *ManualCredentials) {
+	*out = *in
+	if in.ManifestsSecretRef != nil {
+		in, out := &in.ManifestsSecretRef, &out.ManifestsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.AWSSTS != nil {
+		in, out := &in.AWSSTS, &out.AWSSTS
+		*out = new(ManualCredentialsAWSSTS)
+		**out = **in
+	}
+	if in.GCPWorkloadIdentity != nil {
+		in, out := &in.GCPWorkloadIdentity, &out.GCPWorkloadIdentity
+		*out = new(ManualCredentialsGCPWorkloadIdentity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AzureWorkloadIdentity != nil {
+		in, out := &in.AzureWorkloadIdentity, &out.AzureWorkloadIdentity
+		*out = new(ManualCredentialsAzureWorkloadIdentity)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManualCredentials.
+func (in *ManualCredentials) DeepCopy() *ManualCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(ManualCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManualCredentialsAWSSTS) DeepCopyInto(out *ManualCredentialsAWSSTS) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManualCredentialsAWSSTS.
+func (in *ManualCredentialsAWSSTS) DeepCopy() *ManualCredentialsAWSSTS {
+	if in == nil {
+		return nil
+	}
+	out := new(ManualCredentialsAWSSTS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManualCredentialsAzureWorkloadIdentity) DeepCopyInto(out *ManualCredentialsAzureWorkloadIdentity) {
+	*out = *in
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]WorkloadIdentityServiceAccount, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManualCredentialsAzureWorkloadIdentity.
+func (in *ManualCredentialsAzureWorkloadIdentity) DeepCopy() *ManualCredentialsAzureWorkloadIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(ManualCredentialsAzureWorkloadIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManualCredentialsGCPWorkloadIdentity) DeepCopyInto(out *ManualCredentialsGCPWorkloadIdentity) {
+	*out = *in
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]WorkloadIdentityServiceAccount, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManualCredentialsGCPWorkloadIdentity.
+func (in *ManualCredentialsGCPWorkloadIdentity) DeepCopy() *ManualCredentialsGCPWorkloadIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(ManualCredentialsGCPWorkloadIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceQuota) DeepCopyInto(out *NamespaceQuota) {
+	*out = *in
+	if in.MaxClusters != nil {
+		in, out := &in.MaxClusters, &out.MaxClusters
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxMachines != nil {
+		in, out := &in.MaxMachines, &out.MaxMachines
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceQuota.
+func (in *NamespaceQuota) DeepCopy() *NamespaceQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NutanixClusterDeprovision) DeepCopyInto(out *NutanixClusterDeprovision) {
+	*out = *in
+	out.PrismCentral = in.PrismCentral
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.CertificatesSecretRef != nil {
+		in, out := &in.CertificatesSecretRef, &out.CertificatesSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NutanixClusterDeprovision.
+func (in *NutanixClusterDeprovision) DeepCopy() *NutanixClusterDeprovision {
+	if in == nil {
+		return nil
+	}
+	out := new(NutanixClusterDeprovision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenStackClusterDeprovision) DeepCopyInto(out *OpenStackClusterDeprovision) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.CertificatesSecretRef != nil {
+		in, out := &in.CertificatesSecretRef, &out.CertificatesSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackClusterDeprovision.
+func (in *OpenStackClusterDeprovision) DeepCopy() *OpenStackClusterDeprovision {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenStackClusterDeprovision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OvirtClusterDeprovision) DeepCopyInto(out *OvirtClusterDeprovision) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	out.CertificatesSecretRef = in.CertificatesSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvirtClusterDeprovision.
+func (in *OvirtClusterDeprovision) DeepCopy() *OvirtClusterDeprovision {
+	if in == nil {
+		return nil
+	}
+	out := new(OvirtClusterDeprovision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Platform) DeepCopyInto(out *Platform) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(aws.Platform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(azure.Platform)
+		**out = **in
+	}
+	if in.BareMetal != nil {
+		in, out := &in.BareMetal, &out.BareMetal
+		*out = new(baremetal.Platform)
+		**out = **in
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(gcp.Platform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenStack != nil {
+		in, out := &in.OpenStack, &out.OpenStack
+		*out = new(openstack.Platform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VSphere != nil {
+		in, out := &in.VSphere, &out.VSphere
+		*out = new(vsphere.Platform)
+		**out = **in
+	}
+	if in.Ovirt != nil {
+		in, out := &in.Ovirt, &out.Ovirt
+		*out = new(ovirt.Platform)
+		**out = **in
+	}
+	if in.Nutanix != nil {
+		in, out := &in.Nutanix, &out.Nutanix
+		*out = new(nutanix.Platform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PowerVS != nil {
+		in, out := &in.PowerVS, &out.PowerVS
+		*out = new(powervs.Platform)
+		**out = **in
+	}
+	if in.AgentBareMetal != nil {
+		in, out := &in.AgentBareMetal, &out.AgentBareMetal
+		*out = new(agent.BareMetalPlatform)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Platform.
+func (in *Platform) DeepCopy() *Platform {
+	if in == nil {
+		return nil
+	}
+	out := new(Platform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(aws.PlatformStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(gcp.PlatformStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformStatus.
+func (in *PlatformStatus) DeepCopy() *PlatformStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerStatePolicy) DeepCopyInto(out *PowerStatePolicy) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.AllowedPowerStates != nil {
+		in, out := &in.AllowedPowerStates, &out.AllowedPowerStates
+		*out = make([]ClusterPowerState, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerStatePolicy.
+func (in *PowerStatePolicy) DeepCopy() *PowerStatePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerStatePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerStateTransition) DeepCopyInto(out *PowerStateTransition) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerStateTransition.
+func (in *PowerStateTransition) DeepCopy() *PowerStateTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerStateTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerVSClusterDeprovision) DeepCopyInto(out *PowerVSClusterDeprovision) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerVSClusterDeprovision.
+func (in *PowerVSClusterDeprovision) DeepCopy() *PowerVSClusterDeprovision {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerVSClusterDeprovision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Provisioning) DeepCopyInto(out *Provisioning) {
+	*out = *in
+	if in.InstallConfigSecretRef != nil {
+		in, out := &in.InstallConfigSecretRef, &out.InstallConfigSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ImageSetRef != nil {
+		in, out := &in.ImageSetRef, &out.ImageSetRef
+		*out = new(ClusterImageSetReference)
+		**out = **in
+	}
+	if in.ManifestsConfigMapRef != nil {
+		in, out := &in.ManifestsConfigMapRef, &out.ManifestsConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.SSHPrivateKeySecretRef != nil {
+		in, out := &in.SSHPrivateKeySecretRef, &out.SSHPrivateKeySecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.SSHKnownHosts != nil {
+		in, out := &in.SSHKnownHosts, &out.SSHKnownHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstallerEnv != nil {
+		in, out := &in.InstallerEnv, &out.InstallerEnv
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ManualCredentials != nil {
+		in, out := &in.ManualCredentials, &out.ManualCredentials
+		*out = new(ManualCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Provisioning.
+func (in *Provisioning) DeepCopy() *Provisioning {
+	if in == nil {
+		return nil
+	}
+	out := new(Provisioning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReleaseImageValidationConfig) DeepCopyInto(out *ReleaseImageValidationConfig) {
+	*out = *in
+	if in.AllowedArchitectures != nil {
+		in, out := &in.AllowedArchitectures, &out.AllowedArchitectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReleaseImageValidationConfig.
+func (in *ReleaseImageValidationConfig) DeepCopy() *ReleaseImageValidationConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ManageDNSConfig)
+	out := new(ReleaseImageValidationConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManageDNSGCPConfig) DeepCopyInto(out *ManageDNSGCPConfig) {
+func (in *ReleaseImageVerificationConfig) DeepCopyInto(out *ReleaseImageVerificationConfig) {
 	*out = *in
-	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.SignatureStores != nil {
+		in, out := &in.SignatureStores, &out.SignatureStores
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.PublicKeysSecretRef = in.PublicKeysSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSGCPConfig.
-func (in *ManageDNSGCPConfig) DeepCopy() *ManageDNSGCPConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReleaseImageVerificationConfig.
+func (in *ReleaseImageVerificationConfig) DeepCopy() *ReleaseImageVerificationConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ManageDNSGCPConfig)
+	out := new(ReleaseImageVerificationConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenStackClusterDeprovision) DeepCopyInto(out *OpenStackClusterDeprovision) {
+func (in *SSHBastion) DeepCopyInto(out *SSHBastion) {
 	*out = *in
-	if in.CredentialsSecretRef != nil {
-		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	if in.CertificatesSecretRef != nil {
-		in, out := &in.CertificatesSecretRef, &out.CertificatesSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
+	out.KeySecretRef = in.KeySecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackClusterDeprovision.
-func (in *OpenStackClusterDeprovision) DeepCopy() *OpenStackClusterDeprovision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHBastion.
+func (in *SSHBastion) DeepCopy() *SSHBastion {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenStackClusterDeprovision)
+	out := new(SSHBastion)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvirtClusterDeprovision) DeepCopyInto(out *OvirtClusterDeprovision) {
+func (in *SSHKeyRotation) DeepCopyInto(out *SSHKeyRotation) {
 	*out = *in
-	out.CredentialsSecretRef = in.CredentialsSecretRef
-	out.CertificatesSecretRef = in.CertificatesSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvirtClusterDeprovision.
-func (in *OvirtClusterDeprovision) DeepCopy() *OvirtClusterDeprovision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHKeyRotation.
+func (in *SSHKeyRotation) DeepCopy() *SSHKeyRotation {
 	if in == nil {
 		return nil
 	}
-	out := new(OvirtClusterDeprovision)
+	out := new(SSHKeyRotation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Platform) DeepCopyInto(out *Platform) {
+func (in *SSHKeyRotationStatus) DeepCopyInto(out *SSHKeyRotationStatus) {
 	*out = *in
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(aws.Platform)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(azure.Platform)
-		**out = **in
-	}
-	if in.BareMetal != nil {
-		in, out := &in.BareMetal, &out.BareMetal
-		*out = new(baremetal.Platform)
-		**out = **in
-	}
-	if in.GCP != nil {
-		in, out := &in.GCP, &out.GCP
-		*out = new(gcp.Platform)
-		**out = **in
-	}
-	if in.OpenStack != nil {
-		in, out := &in.OpenStack, &out.OpenStack
-		*out = new(openstack.Platform)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.VSphere != nil {
-		in, out := &in.VSphere, &out.VSphere
-		*out = new(vsphere.Platform)
-		**out = **in
-	}
-	if in.Ovirt != nil {
-		in, out := &in.Ovirt, &out.Ovirt
-		*out = new(ovirt.Platform)
-		**out = **in
-	}
-	if in.AgentBareMetal != nil {
-		in, out := &in.AgentBareMetal, &out.AgentBareMetal
-		*out = new(agent.BareMetalPlatform)
-		(*in).DeepCopyInto(*out)
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Platform.
-func (in *Platform) DeepCopy() *Platform {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHKeyRotationStatus.
+func (in *SSHKeyRotationStatus) DeepCopy() *SSHKeyRotationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Platform)
+	out := new(SSHKeyRotationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
+func (in *SecretKeyMapping) DeepCopyInto(out *SecretKeyMapping) {
 	*out = *in
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(aws.PlatformStatus)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformStatus.
-func (in *PlatformStatus) DeepCopy() *PlatformStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyMapping.
+func (in *SecretKeyMapping) DeepCopy() *SecretKeyMapping {
 	if in == nil {
 		return nil
 	}
-	out := new(PlatformStatus)
+	out := new(SecretKeyMapping)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Provisioning) DeepCopyInto(out *Provisioning) {
+func (in *SecretKeyTemplate) DeepCopyInto(out *SecretKeyTemplate) {
 	*out = *in
-	if in.InstallConfigSecretRef != nil {
-		in, out := &in.InstallConfigSecretRef, &out.InstallConfigSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	if in.ImageSetRef != nil {
-		in, out := &in.ImageSetRef, &out.ImageSetRef
-		*out = new(ClusterImageSetReference)
-		**out = **in
-	}
-	if in.ManifestsConfigMapRef != nil {
-		in, out := &in.ManifestsConfigMapRef, &out.ManifestsConfigMapRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	if in.SSHPrivateKeySecretRef != nil {
-		in, out := &in.SSHPrivateKeySecretRef, &out.SSHPrivateKeySecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	if in.SSHKnownHosts != nil {
-		in, out := &in.SSHKnownHosts, &out.SSHKnownHosts
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.InstallerEnv != nil {
-		in, out := &in.InstallerEnv, &out.InstallerEnv
-		*out = make([]corev1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Provisioning.
-func (in *Provisioning) DeepCopy() *Provisioning {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyTemplate.
+func (in *SecretKeyTemplate) DeepCopy() *SecretKeyTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(Provisioning)
+	out := new(SecretKeyTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -3021,6 +4766,16 @@ func (in *SecretMapping) DeepCopyInto(out *SecretMapping) {
 	*out = *in
 	out.SourceRef = in.SourceRef
 	out.TargetRef = in.TargetRef
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]SecretKeyMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]SecretKeyTemplate, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -3056,7 +4811,7 @@ func (in *SelectorSyncIdentityProvider) DeepCopyInto(out *SelectorSyncIdentityPr
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -3286,7 +5041,7 @@ func (in *SyncIdentityProvider) DeepCopyInto(out *SyncIdentityProvider) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -3430,6 +5185,28 @@ func (in *SyncSet) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncSetApplyWindow) DeepCopyInto(out *SyncSetApplyWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]SyncSetApplyWindowDay, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncSetApplyWindow.
+func (in *SyncSetApplyWindow) DeepCopy() *SyncSetApplyWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncSetApplyWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncSetCommonSpec) DeepCopyInto(out *SyncSetCommonSpec) {
 	*out = *in
@@ -3448,7 +5225,9 @@ func (in *SyncSetCommonSpec) DeepCopyInto(out *SyncSetCommonSpec) {
 	if in.Secrets != nil {
 		in, out := &in.Secrets, &out.Secrets
 		*out = make([]SecretMapping, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -3601,6 +5380,43 @@ func (in *SyncStatus) DeepCopy() *SyncStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingConfig) DeepCopyInto(out *TracingConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingConfig.
+func (in *TracingConfig) DeepCopy() *TracingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnreachableProbeBackoffConfig) DeepCopyInto(out *UnreachableProbeBackoffConfig) {
+	*out = *in
+	if in.Multiplier != nil {
+		in, out := &in.Multiplier, &out.Multiplier
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnreachableProbeBackoffConfig.
+func (in *UnreachableProbeBackoffConfig) DeepCopy() *UnreachableProbeBackoffConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UnreachableProbeBackoffConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereClusterDeprovision) DeepCopyInto(out *VSphereClusterDeprovision) {
 	*out = *in
@@ -3619,6 +5435,22 @@ func (in *VSphereClusterDeprovision) DeepCopy() *VSphereClusterDeprovision {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultCredentialsBrokerConfig) DeepCopyInto(out *VaultCredentialsBrokerConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultCredentialsBrokerConfig.
+func (in *VaultCredentialsBrokerConfig) DeepCopy() *VaultCredentialsBrokerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultCredentialsBrokerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VeleroBackupConfig) DeepCopyInto(out *VeleroBackupConfig) {
 	*out = *in
@@ -3634,3 +5466,103 @@ func (in *VeleroBackupConfig) DeepCopy() *VeleroBackupConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ViewerKubeconfig) DeepCopyInto(out *ViewerKubeconfig) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]rbacv1.PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ViewerKubeconfig.
+func (in *ViewerKubeconfig) DeepCopy() *ViewerKubeconfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ViewerKubeconfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookDNSZoneSpec) DeepCopyInto(out *WebhookDNSZoneSpec) {
+	*out = *in
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.AuthSecretRef != nil {
+		in, out := &in.AuthSecretRef, &out.AuthSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookDNSZoneSpec.
+func (in *WebhookDNSZoneSpec) DeepCopy() *WebhookDNSZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookDNSZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityServiceAccount) DeepCopyInto(out *WorkloadIdentityServiceAccount) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityServiceAccount.
+func (in *WorkloadIdentityServiceAccount) DeepCopy() *WorkloadIdentityServiceAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityServiceAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadScheduling) DeepCopyInto(out *WorkloadScheduling) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadScheduling.
+func (in *WorkloadScheduling) DeepCopy() *WorkloadScheduling {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadScheduling)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,366 @@
+package v2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/apis/hive/v1/agent"
+	"github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/apis/hive/v1/azure"
+	"github.com/openshift/hive/apis/hive/v1/baremetal"
+	"github.com/openshift/hive/apis/hive/v1/gcp"
+	"github.com/openshift/hive/apis/hive/v1/nutanix"
+	"github.com/openshift/hive/apis/hive/v1/openstack"
+	"github.com/openshift/hive/apis/hive/v1/ovirt"
+	"github.com/openshift/hive/apis/hive/v1/powervs"
+	"github.com/openshift/hive/apis/hive/v1/vsphere"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+// Important: Run "make" to regenerate code after modifying this file
+
+// The v2 API reuses the v1 types that did not change between the versions. v1 remains the storage version, and
+// objects are converted between the versions by the conversion webhook served by hiveadmission.
+
+// ClusterDeploymentSpec defines the desired state of ClusterDeployment
+type ClusterDeploymentSpec struct {
+
+	// ClusterName is the friendly name of the cluster. It is used for subdomains,
+	// some resource tagging, and other instances where a friendly name for the
+	// cluster is useful.
+	// +required
+	ClusterName string `json:"clusterName"`
+
+	// BaseDomain is the base domain to which the cluster should belong.
+	// +required
+	BaseDomain string `json:"baseDomain"`
+
+	// Platform is the configuration for the specific platform upon which to
+	// perform the installation.
+	// +required
+	Platform Platform `json:"platform"`
+
+	// PullSecretRef is the reference to the secret to use when pulling images.
+	// +optional
+	PullSecretRef *corev1.LocalObjectReference `json:"pullSecretRef,omitempty"`
+
+	// PreserveOnDelete allows the user to disconnect a cluster from Hive without deprovisioning it
+	// +optional
+	PreserveOnDelete bool `json:"preserveOnDelete,omitempty"`
+
+	// ControlPlaneConfig contains additional configuration for the target cluster's control plane
+	// +optional
+	ControlPlaneConfig hivev1.ControlPlaneConfigSpec `json:"controlPlaneConfig,omitempty"`
+
+	// Ingress allows defining desired clusteringress/shards to be configured on the cluster.
+	// +optional
+	Ingress []hivev1.ClusterIngress `json:"ingress,omitempty"`
+
+	// CertificateBundles is a list of certificate bundles associated with this cluster
+	// +optional
+	CertificateBundles []hivev1.CertificateBundleSpec `json:"certificateBundles,omitempty"`
+
+	// ManageDNS specifies whether a DNSZone should be created and managed automatically
+	// for this ClusterDeployment
+	// +optional
+	ManageDNS bool `json:"manageDNS,omitempty"`
+
+	// DNSRouting configures records with a routing policy for the API and ingress of an adopted cluster with managed
+	// DNS in a zone shared with other clusters, such as the active and passive clusters serving the same endpoints.
+	// +optional
+	DNSRouting *hivev1.DNSRouting `json:"dnsRouting,omitempty"`
+
+	// ClusterMetadata contains metadata information about the installed cluster.
+	// +optional
+	ClusterMetadata *hivev1.ClusterMetadata `json:"clusterMetadata,omitempty"`
+
+	// Installed is true if the cluster has been installed
+	// +optional
+	Installed bool `json:"installed"`
+
+	// Provisioning contains settings used only for initial cluster provisioning.
+	// May be unset in the case of adopted clusters.
+	// +optional
+	Provisioning *hivev1.Provisioning `json:"provisioning,omitempty"`
+
+	// ClusterInstallLocalReference provides reference to an object that implements
+	// the hivecontract ClusterInstall. The namespace of the object is same as the
+	// ClusterDeployment.
+	// This cannot be set when Provisioning is also set.
+	// +optional
+	ClusterInstallRef *hivev1.ClusterInstallLocalReference `json:"clusterInstallRef,omitempty"`
+
+	// Adoption requests the adoption of an existing cluster that was not installed by Hive.
+	// +optional
+	Adoption *hivev1.ClusterAdoption `json:"adoption,omitempty"`
+
+	// ClusterPoolRef is a reference to the ClusterPool that this ClusterDeployment originated from.
+	// +optional
+	ClusterPoolRef *hivev1.ClusterPoolReference `json:"clusterPoolRef,omitempty"`
+
+	// PowerState indicates whether a cluster should be running, hibernating, or running with its
+	// workers stopped. When omitted, PowerState defaults to the Running state.
+	// +optional
+	PowerState hivev1.ClusterPowerState `json:"powerState,omitempty"`
+
+	// HibernateAfter will transition a cluster to hibernating power state after it has been running for the
+	// given duration. The time that a cluster has been running is the time since the cluster was installed or the
+	// time since the cluster last came out of hibernation.
+	// +optional
+	HibernateAfter *metav1.Duration `json:"hibernateAfter,omitempty"`
+
+	// InstallAttemptsLimit is the maximum number of times Hive will attempt to install the cluster.
+	// +optional
+	InstallAttemptsLimit *int32 `json:"installAttemptsLimit,omitempty"`
+
+	// MachineManagement contains machine management settings including the strategy that will be used when
+	// provisioning worker machines.
+	// +optional
+	MachineManagement *hivev1.MachineManagement `json:"machineManagement,omitempty"`
+
+	// BoundServiceAccountSigningKeySecretRef refers to a Secret that contains a
+	// 'bound-service-account-signing-key.key' data key pointing to the private
+	// key that will be used to sign ServiceAccount objects. Primarily used to
+	// provision AWS clusters to use Amazon's Security Token Service.
+	// +optional
+	BoundServiceAccountSigningKeySecretRef *corev1.LocalObjectReference `json:"boundServiceAccountSigningKeySecretRef,omitempty"`
+
+	// ViewerKubeconfig configures a kubeconfig with restricted permissions on the cluster. When set, Hive creates a
+	// ServiceAccount on the cluster bound to the configured RBAC and stores a kubeconfig for it in a secret in the
+	// namespace of the ClusterDeployment. The secret is referenced by ClusterMetadata.ViewerKubeconfigSecretRef.
+	// +optional
+	ViewerKubeconfig *hivev1.ViewerKubeconfig `json:"viewerKubeconfig,omitempty"`
+
+	// AdditionalTrustBundle refers to a ConfigMap with additional certificate authorities that are trusted by the
+	// install pod and added to the additionalTrustBundle of the install-config of the cluster. This is meant for
+	// environments with internal certificate authorities or proxies that intercept TLS.
+	// +optional
+	AdditionalTrustBundle *hivev1.AdditionalTrustBundle `json:"additionalTrustBundle,omitempty"`
+
+	// CloudClientProxySecretRef refers to a secret in the namespace of the ClusterDeployment with the proxy configuration, and the
+	// additional trusted certificate authorities, of the clients with which Hive calls the cloud APIs for the cluster. The
+	// secret may have the httpProxy, httpsProxy and noProxy keys, and a PEM-encoded certificate bundle under the ca.crt
+	// key. It takes precedence over the CloudClientProxy of HiveConfig.
+	// Calls made with credentials of Hive, such as its service provider credentials or those minted by the credentials
+	// broker, do not use it.
+	// +optional
+	CloudClientProxySecretRef *corev1.LocalObjectReference `json:"cloudClientProxySecretRef,omitempty"`
+
+	// SSHKeyRotation requests the rotation of the SSH key of the cluster. Hive generates a new key pair, rolls the
+	// public key out to the machines of the cluster, and once the rollout is complete stores the private key in the
+	// ${CLUSTER_NAME}-rotated-ssh-key secret and points Provisioning.SSHPrivateKeySecretRef at it.
+	// +optional
+	SSHKeyRotation *hivev1.SSHKeyRotation `json:"sshKeyRotation,omitempty"`
+
+	// Paused stops Hive from reconciling the ClusterDeployment and the resources of the cluster: provisioning,
+	// syncing of SyncSets, hibernation, DNS and the other controllers acting on the cluster leave it untouched
+	// until it is unpaused. Deprovisioning a deleted ClusterDeployment also waits until it is unpaused.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// SyncSetApplyWindows restricts the rollout of changes to the SyncSets and SelectorSyncSets of the cluster to
+	// recurring windows of time. Changes are applied immediately when no windows are set.
+	// +optional
+	SyncSetApplyWindows []hivev1.SyncSetApplyWindow `json:"syncSetApplyWindows,omitempty"`
+}
+
+// ClusterDeploymentStatus defines the observed state of ClusterDeployment
+type ClusterDeploymentStatus struct {
+
+	// InstallRestarts is the total count of container restarts on the clusters install job.
+	// +optional
+	InstallRestarts int32 `json:"installRestarts,omitempty"`
+
+	// APIURL is the URL where the cluster's API can be accessed.
+	// +optional
+	APIURL string `json:"apiURL,omitempty"`
+
+	// WebConsoleURL is the URL for the cluster's web console UI.
+	// +optional
+	WebConsoleURL string `json:"webConsoleURL,omitempty"`
+
+	// InstallerImage is the name of the installer image to use when installing the target cluster
+	// +optional
+	InstallerImage *string `json:"installerImage,omitempty"`
+
+	// InstallVersion is the version of OpenShift as reported by the release image
+	// resolved for the installation.
+	// +optional
+	InstallVersion *string `json:"installVersion,omitempty"`
+
+	// CLIImage is the name of the oc cli image to use when installing the target cluster
+	// +optional
+	CLIImage *string `json:"cliImage,omitempty"`
+
+	// VerifiedReleaseImage is the release image of the cluster pinned to the digest whose signature and
+	// architectures were verified before the cluster was provisioned. The imageset job and the install pods use it
+	// in place of the release image of the spec. It is only set when release image verification is enabled in
+	// HiveConfig.
+	// +optional
+	VerifiedReleaseImage string `json:"verifiedReleaseImage,omitempty"`
+
+	// Conditions includes more detailed status for the cluster deployment
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// CertificateBundles contains of the status of the certificate bundles associated with this cluster deployment.
+	// +optional
+	CertificateBundles []hivev1.CertificateBundleStatus `json:"certificateBundles,omitempty"`
+
+	// InstallStartedTime is the time when all pre-requisites were met and cluster installation was launched.
+	// +optional
+	InstallStartedTime *metav1.Time `json:"installStartedTime,omitempty"`
+
+	// InstalledTime is the time we first detected that the cluster has been successfully installed.
+	// +optional
+	InstalledTime *metav1.Time `json:"installedTime,omitempty"`
+
+	// ProvisionRef is a reference to the last ClusterProvision created for the deployment
+	// +optional
+	ProvisionRef *corev1.LocalObjectReference `json:"provisionRef,omitempty"`
+
+	// Platform contains the observed state for the specific platform upon which to
+	// perform the installation.
+	// +optional
+	Platform *hivev1.PlatformStatus `json:"platformStatus,omitempty"`
+
+	// ManualCredentialsMode is the source of the cloud credentials of the cluster when it was provisioned with the
+	// cloud credential operator in Manual mode.
+	// +optional
+	ManualCredentialsMode hivev1.ManualCredentialsMode `json:"manualCredentialsMode,omitempty"`
+
+	// SSHKeyRotation is the status of the last completed rotation of the SSH key of the cluster.
+	// +optional
+	SSHKeyRotation *hivev1.SSHKeyRotationStatus `json:"sshKeyRotation,omitempty"`
+
+	// APIURLOverrideHealthyProbes is the number of consecutive successful probes of the API URL override while
+	// Hive is failing back to it.
+	// +optional
+	APIURLOverrideHealthyProbes int32 `json:"apiURLOverrideHealthyProbes,omitempty"`
+
+	// PowerStateHistory is the history of the power state transitions of the cluster, oldest first. Only the most
+	// recent transitions are kept.
+	// +optional
+	PowerStateHistory []hivev1.PowerStateTransition `json:"powerStateHistory,omitempty"`
+
+	// ClusterVersion is the version status of the cluster, synced from the ClusterVersion of the cluster.
+	// +optional
+	ClusterVersion *hivev1.ClusterVersionStatus `json:"clusterVersion,omitempty"`
+}
+
+// PlatformType is the type of the platform upon which a cluster is installed.
+// +kubebuilder:validation:Enum=AWS;Azure;BareMetal;GCP;OpenStack;VSphere;Ovirt;Nutanix;PowerVS;AgentBareMetal
+type PlatformType string
+
+const (
+	// AWSPlatformType is used for clusters installed on AWS.
+	AWSPlatformType PlatformType = "AWS"
+	// AzurePlatformType is used for clusters installed on Azure.
+	AzurePlatformType PlatformType = "Azure"
+	// BareMetalPlatformType is used for clusters installed on bare metal.
+	BareMetalPlatformType PlatformType = "BareMetal"
+	// GCPPlatformType is used for clusters installed on Google Cloud Platform.
+	GCPPlatformType PlatformType = "GCP"
+	// OpenStackPlatformType is used for clusters installed on OpenStack.
+	OpenStackPlatformType PlatformType = "OpenStack"
+	// VSpherePlatformType is used for clusters installed on vSphere.
+	VSpherePlatformType PlatformType = "VSphere"
+	// OvirtPlatformType is used for clusters installed on oVirt.
+	OvirtPlatformType PlatformType = "Ovirt"
+	// NutanixPlatformType is used for clusters installed on Nutanix.
+	NutanixPlatformType PlatformType = "Nutanix"
+	// PowerVSPlatformType is used for clusters installed on IBM Power Virtual Server.
+	PowerVSPlatformType PlatformType = "PowerVS"
+	// AgentBareMetalPlatformType is used for clusters installed on bare metal by the Assisted Agent.
+	AgentBareMetalPlatformType PlatformType = "AgentBareMetal"
+)
+
+// Platform is the configuration for the specific platform upon which to perform
+// the installation. Type selects the platform, and only the configuration of that
+// platform may be set.
+// +union
+type Platform struct {
+	// Type is the type of the platform upon which to perform the installation.
+	// +unionDiscriminator
+	// +required
+	Type PlatformType `json:"type"`
+
+	// AWS is the configuration used when installing on AWS.
+	// +optional
+	AWS *aws.Platform `json:"aws,omitempty"`
+
+	// Azure is the configuration used when installing on Azure.
+	// +optional
+	Azure *azure.Platform `json:"azure,omitempty"`
+
+	// BareMetal is the configuration used when installing on bare metal.
+	// +optional
+	BareMetal *baremetal.Platform `json:"baremetal,omitempty"`
+
+	// GCP is the configuration used when installing on Google Cloud Platform.
+	// +optional
+	GCP *gcp.Platform `json:"gcp,omitempty"`
+
+	// OpenStack is the configuration used when installing on OpenStack
+	// +optional
+	OpenStack *openstack.Platform `json:"openstack,omitempty"`
+
+	// VSphere is the configuration used when installing on vSphere
+	// +optional
+	VSphere *vsphere.Platform `json:"vsphere,omitempty"`
+
+	// Ovirt is the configuration used when installing on oVirt
+	// +optional
+	Ovirt *ovirt.Platform `json:"ovirt,omitempty"`
+
+	// Nutanix is the configuration used when installing on Nutanix
+	// +optional
+	Nutanix *nutanix.Platform `json:"nutanix,omitempty"`
+
+	// PowerVS is the configuration used when installing on IBM Power Virtual Server
+	// +optional
+	PowerVS *powervs.Platform `json:"powervs,omitempty"`
+
+	// AgentBareMetal is the configuration used when performing an Assisted Agent based installation
+	// to bare metal.
+	// +optional
+	AgentBareMetal *agent.BareMetalPlatform `json:"agentBareMetal,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterDeployment is the Schema for the clusterdeployments API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Platform",type="string",JSONPath=".spec.platform.type"
+// +kubebuilder:printcolumn:name="Region",type="string",JSONPath=".metadata.labels.hive\\.openshift\\.io/cluster-region"
+// +kubebuilder:printcolumn:name="ClusterType",type="string",JSONPath=".metadata.labels.hive\\.openshift\\.io/cluster-type"
+// +kubebuilder:printcolumn:name="Installed",type="boolean",JSONPath=".spec.installed"
+// +kubebuilder:printcolumn:name="InfraID",type="string",JSONPath=".spec.clusterMetadata.infraID"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".metadata.labels.hive\\.openshift\\.io/version-major-minor-patch"
+// +kubebuilder:printcolumn:name="PowerState",type="string",JSONPath=".status.conditions[?(@.type=='Hibernating')].reason"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=clusterdeployments,shortName=cd,scope=Namespaced
+type ClusterDeployment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterDeploymentSpec   `json:"spec,omitempty"`
+	Status ClusterDeploymentStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterDeploymentList contains a list of ClusterDeployment
+type ClusterDeploymentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterDeployment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterDeployment{}, &ClusterDeploymentList{})
+}
@@ -0,0 +1,140 @@
+package v2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// ClusterPoolSpec defines the desired state of the ClusterPool.
+type ClusterPoolSpec struct {
+
+	// Platform encompasses the desired platform for the cluster.
+	// +required
+	Platform Platform `json:"platform"`
+
+	// PullSecretRef is the reference to the secret to use when pulling images.
+	// +optional
+	PullSecretRef *corev1.LocalObjectReference `json:"pullSecretRef,omitempty"`
+
+	// Size is the default number of clusters that we should keep provisioned and waiting for use.
+	// +kubebuilder:validation:Minimum=0
+	// +required
+	Size int32 `json:"size"`
+
+	// MaxSize is the maximum number of clusters that will be provisioned including clusters that have been claimed
+	// and ones waiting to be used.
+	// By default there is no limit.
+	// +optional
+	MaxSize *int32 `json:"maxSize,omitempty"`
+
+	// MaxConcurrent is the maximum number of clusters that will be provisioned or deprovisioned at an time. This includes the
+	// claimed clusters being deprovisioned.
+	// By default there is no limit.
+	// +optional
+	MaxConcurrent *int32 `json:"maxConcurrent,omitempty"`
+
+	// BaseDomain is the base domain to use for all clusters created in this pool.
+	// +required
+	BaseDomain string `json:"baseDomain"`
+
+	// ImageSetRef is a reference to a ClusterImageSet. The release image specified in the ClusterImageSet will be used
+	// by clusters created for this cluster pool.
+	ImageSetRef hivev1.ClusterImageSetReference `json:"imageSetRef"`
+
+	// Labels to be applied to new ClusterDeployments created for the pool. ClusterDeployments that have already been
+	// claimed will not be affected when this value is modified.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations to be applied to new ClusterDeployments created for the pool. ClusterDeployments that have already been
+	// claimed will not be affected when this value is modified.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// InstallConfigSecretTemplateRef is a secret with the key install-config.yaml consisting of the content of the install-config.yaml
+	// to be used as a template for all clusters in this pool.
+	// Cluster specific settings (name, basedomain) will be injected dynamically when the ClusterDeployment install-config Secret is generated.
+	// +optional
+	InstallConfigSecretTemplateRef *corev1.LocalObjectReference `json:"installConfigSecretTemplateRef,omitempty"`
+
+	// HibernateAfter will be applied to new ClusterDeployments created for the pool. HibernateAfter will transition
+	// clusters in the clusterpool to hibernating power state after it has been running for the given duration. The time
+	// that a cluster has been running is the time since the cluster was installed or the time since the cluster last came
+	// out of hibernation.
+	// +optional
+	HibernateAfter *metav1.Duration `json:"hibernateAfter,omitempty"`
+
+	// SkipMachinePools allows creating clusterpools where the machinepools are not managed by hive after cluster creation
+	// +optional
+	SkipMachinePools bool `json:"skipMachinePools,omitempty"`
+
+	// ClaimLifetime defines the lifetimes for claims for the cluster pool.
+	// +optional
+	ClaimLifetime *hivev1.ClusterPoolClaimLifetime `json:"claimLifetime,omitempty"`
+
+	// StaleClusterPolicy controls what happens to unclaimed clusters that were created from an earlier version of the
+	// pool spec. With Replace, stale clusters are deleted one at a time, and replaced with clusters created from the
+	// current pool spec, once the pool is otherwise at its desired size. With Keep, stale clusters are left alone and
+	// changes to the pool spec only affect newly provisioned clusters.
+	// Only changes to platform, imageSetRef and installConfigSecretTemplateRef make clusters stale.
+	// Defaults to Keep.
+	// +optional
+	StaleClusterPolicy hivev1.StaleClusterPolicy `json:"staleClusterPolicy,omitempty"`
+
+	// Architecture is the architecture of the clusters created for the pool. The ClusterImageSet of the pool must
+	// support the architecture, and the instance types of the InstallConfigSecretTemplateRef must match it.
+	// When omitted, the architecture is not checked.
+	// +optional
+	Architecture hivev1.PoolArchitecture `json:"architecture,omitempty"`
+}
+
+// ClusterPoolStatus defines the observed state of ClusterPool
+type ClusterPoolStatus struct {
+	// Size is the number of unclaimed clusters that have been created for the pool.
+	Size int32 `json:"size"`
+
+	// Ready is the number of unclaimed clusters that have been installed and are ready to be claimed.
+	Ready int32 `json:"ready"`
+
+	// Conditions includes more detailed status for the cluster pool
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterPool represents a pool of clusters that should be kept ready to be given out to users. Clusters are removed
+// from the pool once claimed and then automatically replaced with a new one.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.size,statuspath=.status.size
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready"
+// +kubebuilder:printcolumn:name="Size",type="string",JSONPath=".spec.size"
+// +kubebuilder:printcolumn:name="BaseDomain",type="string",JSONPath=".spec.baseDomain"
+// +kubebuilder:printcolumn:name="ImageSet",type="string",JSONPath=".spec.imageSetRef.name"
+// +kubebuilder:printcolumn:name="Architecture",type="string",JSONPath=".spec.architecture",priority=1
+// +kubebuilder:resource:path=clusterpools,shortName=cp
+type ClusterPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterPoolSpec   `json:"spec"`
+	Status ClusterPoolStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterPoolList contains a list of ClusterPools
+type ClusterPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterPool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterPool{}, &ClusterPoolList{})
+}
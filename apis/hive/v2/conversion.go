@@ -0,0 +1,383 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// lastProbeTimesAnnotation is set on v2 objects to the last probe times of the v1 conditions that differ from their
+// last transition times, so that converting an object to v2 and back to v1 does not lose them.
+const lastProbeTimesAnnotation = "hive.openshift.io/v1-condition-last-probe-times"
+
+// Convert_v1_ClusterDeployment_To_v2_ClusterDeployment converts a v1 ClusterDeployment to v2.
+func Convert_v1_ClusterDeployment_To_v2_ClusterDeployment(in *hivev1.ClusterDeployment, out *ClusterDeployment) error {
+	in = in.DeepCopy()
+	out.TypeMeta = metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: "ClusterDeployment"}
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = ClusterDeploymentSpec{
+		ClusterName:                            in.Spec.ClusterName,
+		BaseDomain:                             in.Spec.BaseDomain,
+		Platform:                               convertPlatformFromV1(in.Spec.Platform),
+		PullSecretRef:                          in.Spec.PullSecretRef,
+		PreserveOnDelete:                       in.Spec.PreserveOnDelete,
+		ControlPlaneConfig:                     in.Spec.ControlPlaneConfig,
+		Ingress:                                in.Spec.Ingress,
+		CertificateBundles:                     in.Spec.CertificateBundles,
+		ManageDNS:                              in.Spec.ManageDNS,
+		DNSRouting:                             in.Spec.DNSRouting,
+		ClusterMetadata:                        in.Spec.ClusterMetadata,
+		Installed:                              in.Spec.Installed,
+		Provisioning:                           in.Spec.Provisioning,
+		ClusterInstallRef:                      in.Spec.ClusterInstallRef,
+		Adoption:                               in.Spec.Adoption,
+		ClusterPoolRef:                         in.Spec.ClusterPoolRef,
+		PowerState:                             in.Spec.PowerState,
+		HibernateAfter:                         in.Spec.HibernateAfter,
+		InstallAttemptsLimit:                   in.Spec.InstallAttemptsLimit,
+		MachineManagement:                      in.Spec.MachineManagement,
+		BoundServiceAccountSigningKeySecretRef: in.Spec.BoundServiceAccountSignkingKeySecretRef,
+		ViewerKubeconfig:                       in.Spec.ViewerKubeconfig,
+		AdditionalTrustBundle:                  in.Spec.AdditionalTrustBundle,
+		CloudClientProxySecretRef:              in.Spec.CloudClientProxySecretRef,
+		SSHKeyRotation:                         in.Spec.SSHKeyRotation,
+		Paused:                                 in.Spec.Paused,
+		SyncSetApplyWindows:                    in.Spec.SyncSetApplyWindows,
+	}
+	out.Status = ClusterDeploymentStatus{
+		InstallRestarts:             int32(in.Status.InstallRestarts),
+		APIURL:                      in.Status.APIURL,
+		WebConsoleURL:               in.Status.WebConsoleURL,
+		InstallerImage:              in.Status.InstallerImage,
+		InstallVersion:              in.Status.InstallVersion,
+		CLIImage:                    in.Status.CLIImage,
+		VerifiedReleaseImage:        in.Status.VerifiedReleaseImage,
+		CertificateBundles:          in.Status.CertificateBundles,
+		InstallStartedTime:          in.Status.InstallStartedTimestamp,
+		InstalledTime:               in.Status.InstalledTimestamp,
+		ProvisionRef:                in.Status.ProvisionRef,
+		Platform:                    in.Status.Platform,
+		ManualCredentialsMode:       in.Status.ManualCredentialsMode,
+		SSHKeyRotation:              in.Status.SSHKeyRotation,
+		APIURLOverrideHealthyProbes: in.Status.APIURLOverrideHealthyProbes,
+		PowerStateHistory:           in.Status.PowerStateHistory,
+		ClusterVersion:              in.Status.ClusterVersion,
+	}
+	lastProbeTimes := map[string]metav1.Time{}
+	for _, c := range in.Status.Conditions {
+		out.Status.Conditions = append(out.Status.Conditions,
+			convertConditionFromV1(string(c.Type), c.Status, c.LastTransitionTime, c.Reason, c.Message))
+		if !c.LastProbeTime.Equal(&c.LastTransitionTime) {
+			lastProbeTimes[string(c.Type)] = c.LastProbeTime
+		}
+	}
+	return setLastProbeTimes(&out.ObjectMeta, lastProbeTimes)
+}
+
+// Convert_v2_ClusterDeployment_To_v1_ClusterDeployment converts a v2 ClusterDeployment to v1.
+func Convert_v2_ClusterDeployment_To_v1_ClusterDeployment(in *ClusterDeployment, out *hivev1.ClusterDeployment) error {
+	in = in.DeepCopy()
+	platform, err := convertPlatformToV1(in.Spec.Platform)
+	if err != nil {
+		return err
+	}
+	out.TypeMeta = metav1.TypeMeta{APIVersion: hivev1.SchemeGroupVersion.String(), Kind: "ClusterDeployment"}
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = hivev1.ClusterDeploymentSpec{
+		ClusterName:                             in.Spec.ClusterName,
+		BaseDomain:                              in.Spec.BaseDomain,
+		Platform:                                platform,
+		PullSecretRef:                           in.Spec.PullSecretRef,
+		PreserveOnDelete:                        in.Spec.PreserveOnDelete,
+		ControlPlaneConfig:                      in.Spec.ControlPlaneConfig,
+		Ingress:                                 in.Spec.Ingress,
+		CertificateBundles:                      in.Spec.CertificateBundles,
+		ManageDNS:                               in.Spec.ManageDNS,
+		DNSRouting:                              in.Spec.DNSRouting,
+		ClusterMetadata:                         in.Spec.ClusterMetadata,
+		Installed:                               in.Spec.Installed,
+		Provisioning:                            in.Spec.Provisioning,
+		ClusterInstallRef:                       in.Spec.ClusterInstallRef,
+		Adoption:                                in.Spec.Adoption,
+		ClusterPoolRef:                          in.Spec.ClusterPoolRef,
+		PowerState:                              in.Spec.PowerState,
+		HibernateAfter:                          in.Spec.HibernateAfter,
+		InstallAttemptsLimit:                    in.Spec.InstallAttemptsLimit,
+		MachineManagement:                       in.Spec.MachineManagement,
+		BoundServiceAccountSignkingKeySecretRef: in.Spec.BoundServiceAccountSigningKeySecretRef,
+		ViewerKubeconfig:                        in.Spec.ViewerKubeconfig,
+		AdditionalTrustBundle:                   in.Spec.AdditionalTrustBundle,
+		CloudClientProxySecretRef:               in.Spec.CloudClientProxySecretRef,
+		SSHKeyRotation:                          in.Spec.SSHKeyRotation,
+		Paused:                                  in.Spec.Paused,
+		SyncSetApplyWindows:                     in.Spec.SyncSetApplyWindows,
+	}
+	out.Status = hivev1.ClusterDeploymentStatus{
+		InstallRestarts:             int(in.Status.InstallRestarts),
+		APIURL:                      in.Status.APIURL,
+		WebConsoleURL:               in.Status.WebConsoleURL,
+		InstallerImage:              in.Status.InstallerImage,
+		InstallVersion:              in.Status.InstallVersion,
+		CLIImage:                    in.Status.CLIImage,
+		VerifiedReleaseImage:        in.Status.VerifiedReleaseImage,
+		CertificateBundles:          in.Status.CertificateBundles,
+		InstallStartedTimestamp:     in.Status.InstallStartedTime,
+		InstalledTimestamp:          in.Status.InstalledTime,
+		ProvisionRef:                in.Status.ProvisionRef,
+		Platform:                    in.Status.Platform,
+		ManualCredentialsMode:       in.Status.ManualCredentialsMode,
+		SSHKeyRotation:              in.Status.SSHKeyRotation,
+		APIURLOverrideHealthyProbes: in.Status.APIURLOverrideHealthyProbes,
+		PowerStateHistory:           in.Status.PowerStateHistory,
+		ClusterVersion:              in.Status.ClusterVersion,
+	}
+	lastProbeTimes, err := popLastProbeTimes(&out.ObjectMeta)
+	if err != nil {
+		return err
+	}
+	for _, c := range in.Status.Conditions {
+		out.Status.Conditions = append(out.Status.Conditions, hivev1.ClusterDeploymentCondition{
+			Type:               hivev1.ClusterDeploymentConditionType(c.Type),
+			Status:             corev1.ConditionStatus(c.Status),
+			LastProbeTime:      lastProbeTime(lastProbeTimes, c),
+			LastTransitionTime: c.LastTransitionTime,
+			Reason:             c.Reason,
+			Message:            c.Message,
+		})
+	}
+	return nil
+}
+
+// Convert_v1_ClusterPool_To_v2_ClusterPool converts a v1 ClusterPool to v2.
+func Convert_v1_ClusterPool_To_v2_ClusterPool(in *hivev1.ClusterPool, out *ClusterPool) error {
+	in = in.DeepCopy()
+	out.TypeMeta = metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: "ClusterPool"}
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = ClusterPoolSpec{
+		Platform:                       convertPlatformFromV1(in.Spec.Platform),
+		PullSecretRef:                  in.Spec.PullSecretRef,
+		Size:                           in.Spec.Size,
+		MaxSize:                        in.Spec.MaxSize,
+		MaxConcurrent:                  in.Spec.MaxConcurrent,
+		BaseDomain:                     in.Spec.BaseDomain,
+		ImageSetRef:                    in.Spec.ImageSetRef,
+		Labels:                         in.Spec.Labels,
+		Annotations:                    in.Spec.Annotations,
+		InstallConfigSecretTemplateRef: in.Spec.InstallConfigSecretTemplateRef,
+		HibernateAfter:                 in.Spec.HibernateAfter,
+		SkipMachinePools:               in.Spec.SkipMachinePools,
+		ClaimLifetime:                  in.Spec.ClaimLifetime,
+		StaleClusterPolicy:             in.Spec.StaleClusterPolicy,
+		Architecture:                   in.Spec.Architecture,
+	}
+	out.Status = ClusterPoolStatus{
+		Size:  in.Status.Size,
+		Ready: in.Status.Ready,
+	}
+	lastProbeTimes := map[string]metav1.Time{}
+	for _, c := range in.Status.Conditions {
+		out.Status.Conditions = append(out.Status.Conditions,
+			convertConditionFromV1(string(c.Type), c.Status, c.LastTransitionTime, c.Reason, c.Message))
+		if !c.LastProbeTime.Equal(&c.LastTransitionTime) {
+			lastProbeTimes[string(c.Type)] = c.LastProbeTime
+		}
+	}
+	return setLastProbeTimes(&out.ObjectMeta, lastProbeTimes)
+}
+
+// Convert_v2_ClusterPool_To_v1_ClusterPool converts a v2 ClusterPool to v1.
+func Convert_v2_ClusterPool_To_v1_ClusterPool(in *ClusterPool, out *hivev1.ClusterPool) error {
+	in = in.DeepCopy()
+	platform, err := convertPlatformToV1(in.Spec.Platform)
+	if err != nil {
+		return err
+	}
+	out.TypeMeta = metav1.TypeMeta{APIVersion: hivev1.SchemeGroupVersion.String(), Kind: "ClusterPool"}
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = hivev1.ClusterPoolSpec{
+		Platform:                       platform,
+		PullSecretRef:                  in.Spec.PullSecretRef,
+		Size:                           in.Spec.Size,
+		MaxSize:                        in.Spec.MaxSize,
+		MaxConcurrent:                  in.Spec.MaxConcurrent,
+		BaseDomain:                     in.Spec.BaseDomain,
+		ImageSetRef:                    in.Spec.ImageSetRef,
+		Labels:                         in.Spec.Labels,
+		Annotations:                    in.Spec.Annotations,
+		InstallConfigSecretTemplateRef: in.Spec.InstallConfigSecretTemplateRef,
+		HibernateAfter:                 in.Spec.HibernateAfter,
+		SkipMachinePools:               in.Spec.SkipMachinePools,
+		ClaimLifetime:                  in.Spec.ClaimLifetime,
+		StaleClusterPolicy:             in.Spec.StaleClusterPolicy,
+		Architecture:                   in.Spec.Architecture,
+	}
+	out.Status = hivev1.ClusterPoolStatus{
+		Size:  in.Status.Size,
+		Ready: in.Status.Ready,
+	}
+	lastProbeTimes, err := popLastProbeTimes(&out.ObjectMeta)
+	if err != nil {
+		return err
+	}
+	for _, c := range in.Status.Conditions {
+		out.Status.Conditions = append(out.Status.Conditions, hivev1.ClusterPoolCondition{
+			Type:               hivev1.ClusterPoolConditionType(c.Type),
+			Status:             corev1.ConditionStatus(c.Status),
+			LastProbeTime:      lastProbeTime(lastProbeTimes, c),
+			LastTransitionTime: c.LastTransitionTime,
+			Reason:             c.Reason,
+			Message:            c.Message,
+		})
+	}
+	return nil
+}
+
+// Convert_v1_SyncSet_To_v2_SyncSet converts a v1 SyncSet to v2.
+func Convert_v1_SyncSet_To_v2_SyncSet(in *hivev1.SyncSet, out *SyncSet) error {
+	in = in.DeepCopy()
+	out.TypeMeta = metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: "SyncSet"}
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = in.Spec
+	return nil
+}
+
+// Convert_v2_SyncSet_To_v1_SyncSet converts a v2 SyncSet to v1.
+func Convert_v2_SyncSet_To_v1_SyncSet(in *SyncSet, out *hivev1.SyncSet) error {
+	in = in.DeepCopy()
+	out.TypeMeta = metav1.TypeMeta{APIVersion: hivev1.SchemeGroupVersion.String(), Kind: "SyncSet"}
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = in.Spec
+	return nil
+}
+
+// convertConditionFromV1 converts the fields of a v1 condition to a metav1.Condition. The last probe time of v1
+// conditions has no equivalent, and is kept in the lastProbeTimesAnnotation when it differs from the last transition
+// time.
+func convertConditionFromV1(conditionType string, status corev1.ConditionStatus, lastTransitionTime metav1.Time, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionStatus(status),
+		LastTransitionTime: lastTransitionTime,
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+// setLastProbeTimes sets the lastProbeTimesAnnotation of a v2 object to the last probe times of its v1 conditions, by
+// condition type. The annotation is not set when there are none.
+func setLastProbeTimes(meta *metav1.ObjectMeta, lastProbeTimes map[string]metav1.Time) error {
+	if len(lastProbeTimes) == 0 {
+		return nil
+	}
+	value, err := json.Marshal(lastProbeTimes)
+	if err != nil {
+		return err
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[lastProbeTimesAnnotation] = string(value)
+	return nil
+}
+
+// popLastProbeTimes removes the lastProbeTimesAnnotation from an object converted to v1 and returns the last probe
+// times it holds.
+func popLastProbeTimes(meta *metav1.ObjectMeta) (map[string]metav1.Time, error) {
+	value, ok := meta.Annotations[lastProbeTimesAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	delete(meta.Annotations, lastProbeTimesAnnotation)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+	lastProbeTimes := map[string]metav1.Time{}
+	if err := json.Unmarshal([]byte(value), &lastProbeTimes); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", lastProbeTimesAnnotation, err)
+	}
+	return lastProbeTimes, nil
+}
+
+// lastProbeTime returns the last probe time of a condition converted to v1, which defaults to its last transition
+// time.
+func lastProbeTime(lastProbeTimes map[string]metav1.Time, c metav1.Condition) metav1.Time {
+	if t, ok := lastProbeTimes[c.Type]; ok {
+		return t
+	}
+	return c.LastTransitionTime
+}
+
+// convertPlatformFromV1 converts a v1 Platform to v2, setting the type of the platform from the platform
+// configuration that is set.
+func convertPlatformFromV1(in hivev1.Platform) Platform {
+	out := Platform{
+		AWS:            in.AWS,
+		Azure:          in.Azure,
+		BareMetal:      in.BareMetal,
+		GCP:            in.GCP,
+		OpenStack:      in.OpenStack,
+		VSphere:        in.VSphere,
+		Ovirt:          in.Ovirt,
+		Nutanix:        in.Nutanix,
+		PowerVS:        in.PowerVS,
+		AgentBareMetal: in.AgentBareMetal,
+	}
+	for _, p := range platformTypes(out) {
+		if p.set {
+			out.Type = p.platformType
+			break
+		}
+	}
+	return out
+}
+
+// convertPlatformToV1 converts a v2 Platform to v1. It returns an error when the configuration of the platform of
+// the type is not set, or when the configuration of another platform is set.
+func convertPlatformToV1(in Platform) (hivev1.Platform, error) {
+	for _, p := range platformTypes(in) {
+		switch {
+		case p.platformType == in.Type && !p.set:
+			return hivev1.Platform{}, fmt.Errorf("platform type is %s but the %s platform configuration is not set", in.Type, p.platformType)
+		case p.platformType != in.Type && p.set:
+			return hivev1.Platform{}, fmt.Errorf("platform type is %s but the %s platform configuration is set", in.Type, p.platformType)
+		}
+	}
+	return hivev1.Platform{
+		AWS:            in.AWS,
+		Azure:          in.Azure,
+		BareMetal:      in.BareMetal,
+		GCP:            in.GCP,
+		OpenStack:      in.OpenStack,
+		VSphere:        in.VSphere,
+		Ovirt:          in.Ovirt,
+		Nutanix:        in.Nutanix,
+		PowerVS:        in.PowerVS,
+		AgentBareMetal: in.AgentBareMetal,
+	}, nil
+}
+
+type platformTypeConfig struct {
+	platformType PlatformType
+	set          bool
+}
+
+// platformTypes returns the platform types, in the order of the fields of Platform, and whether their
+// configuration is set.
+func platformTypes(p Platform) []platformTypeConfig {
+	return []platformTypeConfig{
+		{platformType: AWSPlatformType, set: p.AWS != nil},
+		{platformType: AzurePlatformType, set: p.Azure != nil},
+		{platformType: BareMetalPlatformType, set: p.BareMetal != nil},
+		{platformType: GCPPlatformType, set: p.GCP != nil},
+		{platformType: OpenStackPlatformType, set: p.OpenStack != nil},
+		{platformType: VSpherePlatformType, set: p.VSphere != nil},
+		{platformType: OvirtPlatformType, set: p.Ovirt != nil},
+		{platformType: NutanixPlatformType, set: p.Nutanix != nil},
+		{platformType: PowerVSPlatformType, set: p.PowerVS != nil},
+		{platformType: AgentBareMetalPlatformType, set: p.AgentBareMetal != nil},
+	}
+}
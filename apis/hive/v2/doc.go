@@ -0,0 +1,6 @@
+// Package v2 contains API Schema definitions for the hive v2 API group
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=package,register
+// +k8s:defaulter-gen=TypeMeta
+// +groupName=hive.openshift.io
+package v2
@@ -0,0 +1,39 @@
+package v2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// SyncSetStatus defines the observed state of a SyncSet
+type SyncSetStatus struct {
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SyncSet is the Schema for the SyncSet API. The spec of a SyncSet is unchanged from v1; the status of the
+// syncing of a SyncSet is recorded in the ClusterSync of the cluster.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=syncsets,shortName=ss,scope=Namespaced
+type SyncSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   hivev1.SyncSetSpec `json:"spec,omitempty"`
+	Status SyncSetStatus      `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SyncSetList contains a list of SyncSets
+type SyncSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SyncSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SyncSet{}, &SyncSetList{})
+}
@@ -40,6 +40,20 @@ type ClusterSyncStatus struct {
 	// FirstSuccessTime is the time we first successfully applied all (selector)syncsets to a cluster.
 	// +optional
 	FirstSuccessTime *metav1.Time `json:"firstSuccessTime,omitempty"`
+
+	// PendingSyncSets are the names of the SyncSets with changes waiting for the next apply window of the cluster.
+	// +optional
+	PendingSyncSets []string `json:"pendingSyncSets,omitempty"`
+
+	// PendingSelectorSyncSets are the names of the SelectorSyncSets with changes waiting for the next apply window of
+	// the cluster.
+	// +optional
+	PendingSelectorSyncSets []string `json:"pendingSelectorSyncSets,omitempty"`
+
+	// NextApplyWindowTime is the time at which the next apply window of the cluster opens, set while changes are
+	// pending.
+	// +optional
+	NextApplyWindowTime *metav1.Time `json:"nextApplyWindowTime,omitempty"`
 }
 
 // SyncStatus is the status of applying a specific SyncSet or SelectorSyncSet to the cluster.
@@ -55,6 +69,22 @@ type SyncStatus struct {
 	// +optional
 	ResourcesToDelete []SyncResourceReference `json:"resourcesToDelete,omitempty"`
 
+	// PruneProtectedResources is the list of resources to delete that are prune-protected. They are left in the
+	// cluster when they are removed from the SyncSet or SelectorSyncSet.
+	// +optional
+	PruneProtectedResources []SyncResourceReference `json:"pruneProtectedResources,omitempty"`
+
+	// SkippedDeletions is the list of prune-protected resources that were removed from the SyncSet or SelectorSyncSet
+	// and were left in the cluster instead of being deleted.
+	// +optional
+	SkippedDeletions []SyncResourceReference `json:"skippedDeletions,omitempty"`
+
+	// AppliedContentHashes are the hashes of the resources, secret mappings and patches of the SyncSet or
+	// SelectorSyncSet that were last applied. While changes to the SyncSet or SelectorSyncSet wait for the next apply
+	// window of the cluster, the parts that did not change are still re-applied to correct drift.
+	// +optional
+	AppliedContentHashes []string `json:"appliedContentHashes,omitempty"`
+
 	// Result is the result of the last attempt to apply the SyncSet or SelectorSyncSet to the cluster.
 	Result SyncSetResult `json:"result"`
 
@@ -209,6 +209,20 @@ func (in *ClusterSyncStatus) DeepCopyInto(out *ClusterSyncStatus) {
 		in, out := &in.FirstSuccessTime, &out.FirstSuccessTime
 		*out = (*in).DeepCopy()
 	}
+	if in.PendingSyncSets != nil {
+		in, out := &in.PendingSyncSets, &out.PendingSyncSets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingSelectorSyncSets != nil {
+		in, out := &in.PendingSelectorSyncSets, &out.PendingSelectorSyncSets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NextApplyWindowTime != nil {
+		in, out := &in.NextApplyWindowTime, &out.NextApplyWindowTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -291,7 +305,7 @@ func (in *FakeClusterInstallSpec) DeepCopyInto(out *FakeClusterInstallSpec) {
 	if in.ClusterMetadata != nil {
 		in, out := &in.ClusterMetadata, &out.ClusterMetadata
 		*out = new(v1.ClusterMetadata)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -353,6 +367,21 @@ func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 		*out = make([]SyncResourceReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.PruneProtectedResources != nil {
+		in, out := &in.PruneProtectedResources, &out.PruneProtectedResources
+		*out = make([]SyncResourceReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.SkippedDeletions != nil {
+		in, out := &in.SkippedDeletions, &out.SkippedDeletions
+		*out = make([]SyncResourceReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.AppliedContentHashes != nil {
+		in, out := &in.AppliedContentHashes, &out.AppliedContentHashes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	if in.FirstSuccessTime != nil {
 		in, out := &in.FirstSuccessTime, &out.FirstSuccessTime
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	"github.com/openshift/hive/apis"
+	"github.com/openshift/hive/pkg/gateway"
+	"github.com/openshift/hive/pkg/version"
+)
+
+const (
+	defaultLogLevel   = "info"
+	defaultListenAddr = ":8443"
+)
+
+type gatewayOptions struct {
+	LogLevel    string
+	ListenAddr  string
+	TLSCertFile string
+	TLSKeyFile  string
+}
+
+func newRootCommand() *cobra.Command {
+	opts := &gatewayOptions{}
+	cmd := &cobra.Command{
+		Use:   "hive-gateway",
+		Short: "OpenShift Hive REST gateway for provisioning clusters",
+		Run: func(cmd *cobra.Command, args []string) {
+			level, err := log.ParseLevel(opts.LogLevel)
+			if err != nil {
+				log.WithError(err).Fatal("Cannot parse log level")
+			}
+			log.SetLevel(level)
+			log.Infof("Version: %s", version.String())
+
+			cfg, err := config.GetConfig()
+			if err != nil {
+				log.WithError(err).Fatal("could not get config to talk to the apiserver")
+			}
+
+			scheme := runtime.NewScheme()
+			if err := clientgoscheme.AddToScheme(scheme); err != nil {
+				log.WithError(err).Fatal("could not add client-go types to scheme")
+			}
+			if err := apis.AddToScheme(scheme); err != nil {
+				log.WithError(err).Fatal("could not add hive types to scheme")
+			}
+
+			clientFor, err := gateway.NewClientFactory(cfg, scheme)
+			if err != nil {
+				log.WithError(err).Fatal("could not create client factory")
+			}
+
+			mux := http.NewServeMux()
+			mux.Handle("/v1/", gateway.NewServer(clientFor, log.WithField("component", "gateway")))
+			mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			log.WithField("address", opts.ListenAddr).Info("starting gateway")
+			if opts.TLSCertFile != "" || opts.TLSKeyFile != "" {
+				err = http.ListenAndServeTLS(opts.ListenAddr, opts.TLSCertFile, opts.TLSKeyFile, mux)
+			} else {
+				log.Warn("serving without TLS, bearer tokens will be sent in the clear")
+				err = http.ListenAndServe(opts.ListenAddr, mux)
+			}
+			log.WithError(err).Fatal("gateway stopped")
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&opts.LogLevel, "log-level", defaultLogLevel, "Log level (debug,info,warn,error,fatal)")
+	cmd.PersistentFlags().StringVar(&opts.ListenAddr, "listen", defaultListenAddr, "Address on which to serve the gateway")
+	cmd.PersistentFlags().StringVar(&opts.TLSCertFile, "tls-cert-file", "", "File containing the TLS certificate for serving")
+	cmd.PersistentFlags().StringVar(&opts.TLSKeyFile, "tls-key-file", "", "File containing the TLS private key for serving")
+	return cmd
+}
+
+func main() {
+	cmd := newRootCommand()
+	if err := cmd.Execute(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"net/http"
+
 	admissionCmd "github.com/openshift/generic-admission-server/pkg/cmd"
 	log "github.com/sirupsen/logrus"
 
@@ -8,10 +10,21 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	conversionwebhooks "github.com/openshift/hive/pkg/conversion-webhooks"
 	hivevalidatingwebhooks "github.com/openshift/hive/pkg/validating-webhooks/hive/v1"
 	"github.com/openshift/hive/pkg/version"
 )
 
+const (
+	// conversionWebhookAddress is the address of the CRD conversion webhook. CRD conversion webhooks cannot be
+	// served through the aggregated API of the admission webhooks, so they are served on their own port.
+	conversionWebhookAddress = ":9444"
+	conversionWebhookPath    = "/convert"
+
+	servingCertFile = "/var/serving-cert/tls.crt"
+	servingKeyFile  = "/var/serving-cert/tls.key"
+)
+
 func main() {
 	log.Infof("Version: %s", version.String())
 	log.Info("Starting CRD Validation Webhooks.")
@@ -21,10 +34,15 @@ func main() {
 
 	decoder := createDecoder()
 
+	go serveConversionWebhook()
+
 	admissionCmd.RunAdmissionServer(
 		hivevalidatingwebhooks.NewDNSZoneValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterDeploymentValidatingAdmissionHook(decoder),
+		hivevalidatingwebhooks.NewClusterDeploymentMutatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterPoolValidatingAdmissionHook(decoder),
+		hivevalidatingwebhooks.NewClusterClaimValidatingAdmissionHook(decoder),
+		hivevalidatingwebhooks.NewClusterClaimMutatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterImageSetValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterProvisionValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewMachinePoolValidatingAdmissionHook(decoder),
@@ -33,6 +51,15 @@ func main() {
 	)
 }
 
+func serveConversionWebhook() {
+	mux := http.NewServeMux()
+	mux.Handle(conversionWebhookPath, conversionwebhooks.NewConversionWebhook())
+	log.WithField("address", conversionWebhookAddress).Info("Starting CRD conversion webhook.")
+	if err := http.ListenAndServeTLS(conversionWebhookAddress, servingCertFile, servingKeyFile, mux); err != nil {
+		log.WithError(err).Fatal("error serving CRD conversion webhook")
+	}
+}
+
 func createDecoder() *admission.Decoder {
 	scheme := runtime.NewScheme()
 	hivev1.AddToScheme(scheme)
@@ -17,6 +17,7 @@ import (
 
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
@@ -35,10 +36,18 @@ import (
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/additionaltrustbundle"
+	"github.com/openshift/hive/pkg/controller/auditlog"
 	"github.com/openshift/hive/pkg/controller/awsprivatelink"
+	"github.com/openshift/hive/pkg/controller/backupexport"
+	"github.com/openshift/hive/pkg/controller/clusteradoption"
 	"github.com/openshift/hive/pkg/controller/clusterclaim"
 	"github.com/openshift/hive/pkg/controller/clusterdeployment"
+	"github.com/openshift/hive/pkg/controller/clusterdeploymentsummary"
 	"github.com/openshift/hive/pkg/controller/clusterdeprovision"
+	"github.com/openshift/hive/pkg/controller/clusterdnsrecords"
+	"github.com/openshift/hive/pkg/controller/clusterimageset"
+	"github.com/openshift/hive/pkg/controller/clusterimagesetdiscovery"
 	"github.com/openshift/hive/pkg/controller/clusterpool"
 	"github.com/openshift/hive/pkg/controller/clusterpoolnamespace"
 	"github.com/openshift/hive/pkg/controller/clusterprovision"
@@ -47,60 +56,86 @@ import (
 	"github.com/openshift/hive/pkg/controller/clustersync"
 	"github.com/openshift/hive/pkg/controller/clusterversion"
 	"github.com/openshift/hive/pkg/controller/controlplanecerts"
+	"github.com/openshift/hive/pkg/controller/credentialsexpiry"
 	"github.com/openshift/hive/pkg/controller/dnsendpoint"
 	"github.com/openshift/hive/pkg/controller/dnszone"
+	"github.com/openshift/hive/pkg/controller/endpointhealth"
 	"github.com/openshift/hive/pkg/controller/fakeclusterinstall"
+	"github.com/openshift/hive/pkg/controller/gcpprivateserviceconnect"
 	"github.com/openshift/hive/pkg/controller/hibernation"
 	"github.com/openshift/hive/pkg/controller/machinemanagement"
 	"github.com/openshift/hive/pkg/controller/metrics"
 	"github.com/openshift/hive/pkg/controller/remoteingress"
 	"github.com/openshift/hive/pkg/controller/remotemachineset"
+	"github.com/openshift/hive/pkg/controller/sshkeyrotation"
 	"github.com/openshift/hive/pkg/controller/syncidentityprovider"
 	"github.com/openshift/hive/pkg/controller/unreachable"
 	"github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/controller/velerobackup"
+	"github.com/openshift/hive/pkg/controller/viewerkubeconfig"
+	"github.com/openshift/hive/pkg/tracing"
 	utillogrus "github.com/openshift/hive/pkg/util/logrus"
 	"github.com/openshift/hive/pkg/version"
 )
 
 const (
 	defaultLogLevel             = "info"
+	logTimestampFormat          = "2006-01-02T15:04:05.999Z07:00"
 	leaderElectionConfigMap     = "hive-controllers-leader"
 	leaderElectionLeaseDuration = "360s"
 	leaderElectionRenewDeadline = "270s"
 	leaderElectionRetryPeriod   = "90s"
+	tracingShutdownTimeout      = 10 * time.Second
 )
 
+// canaryLeaderElectionConfigMap is the leader election config map of the canary controllers, which run alongside the
+// stable controllers during a canary rollout.
+const canaryLeaderElectionConfigMap = "hive-controllers-canary-leader"
+
 type controllerSetupFunc func(manager.Manager) error
 
 var controllerFuncs = map[hivev1.ControllerName]controllerSetupFunc{
-	clusterclaim.ControllerName:         clusterclaim.Add,
-	clusterdeployment.ControllerName:    clusterdeployment.Add,
-	clusterdeprovision.ControllerName:   clusterdeprovision.Add,
-	clusterpoolnamespace.ControllerName: clusterpoolnamespace.Add,
-	clusterprovision.ControllerName:     clusterprovision.Add,
-	clusterrelocate.ControllerName:      clusterrelocate.Add,
-	clusterstate.ControllerName:         clusterstate.Add,
-	clustersync.ControllerName:          clustersync.Add,
-	clusterversion.ControllerName:       clusterversion.Add,
-	controlplanecerts.ControllerName:    controlplanecerts.Add,
-	dnsendpoint.ControllerName:          dnsendpoint.Add,
-	dnszone.ControllerName:              dnszone.Add,
-	fakeclusterinstall.ControllerName:   fakeclusterinstall.Add,
-	metrics.ControllerName:              metrics.Add,
-	remoteingress.ControllerName:        remoteingress.Add,
-	remotemachineset.ControllerName:     remotemachineset.Add,
-	syncidentityprovider.ControllerName: syncidentityprovider.Add,
-	unreachable.ControllerName:          unreachable.Add,
-	velerobackup.ControllerName:         velerobackup.Add,
-	clusterpool.ControllerName:          clusterpool.Add,
-	hibernation.ControllerName:          hibernation.Add,
-	machinemanagement.ControllerName:    machinemanagement.Add,
-	awsprivatelink.ControllerName:       awsprivatelink.Add,
+	clusterclaim.ControllerName:             clusterclaim.Add,
+	clusterdeployment.ControllerName:        clusterdeployment.Add,
+	clusterdeprovision.ControllerName:       clusterdeprovision.Add,
+	clusterpoolnamespace.ControllerName:     clusterpoolnamespace.Add,
+	clusterprovision.ControllerName:         clusterprovision.Add,
+	clusterrelocate.ControllerName:          clusterrelocate.Add,
+	clusterstate.ControllerName:             clusterstate.Add,
+	clustersync.ControllerName:              clustersync.Add,
+	clusterversion.ControllerName:           clusterversion.Add,
+	controlplanecerts.ControllerName:        controlplanecerts.Add,
+	dnsendpoint.ControllerName:              dnsendpoint.Add,
+	dnszone.ControllerName:                  dnszone.Add,
+	fakeclusterinstall.ControllerName:       fakeclusterinstall.Add,
+	metrics.ControllerName:                  metrics.Add,
+	remoteingress.ControllerName:            remoteingress.Add,
+	remotemachineset.ControllerName:         remotemachineset.Add,
+	syncidentityprovider.ControllerName:     syncidentityprovider.Add,
+	unreachable.ControllerName:              unreachable.Add,
+	velerobackup.ControllerName:             velerobackup.Add,
+	clusterpool.ControllerName:              clusterpool.Add,
+	hibernation.ControllerName:              hibernation.Add,
+	machinemanagement.ControllerName:        machinemanagement.Add,
+	awsprivatelink.ControllerName:           awsprivatelink.Add,
+	gcpprivateserviceconnect.ControllerName: gcpprivateserviceconnect.Add,
+	viewerkubeconfig.ControllerName:         viewerkubeconfig.Add,
+	clusterimagesetdiscovery.ControllerName: clusterimagesetdiscovery.Add,
+	clusterimageset.ControllerName:          clusterimageset.Add,
+	clusterdnsrecords.ControllerName:        clusterdnsrecords.Add,
+	auditlog.ControllerName:                 auditlog.Add,
+	additionaltrustbundle.ControllerName:    additionaltrustbundle.Add,
+	clusterdeploymentsummary.ControllerName: clusterdeploymentsummary.Add,
+	sshkeyrotation.ControllerName:           sshkeyrotation.Add,
+	credentialsexpiry.ControllerName:        credentialsexpiry.Add,
+	backupexport.ControllerName:             backupexport.Add,
+	endpointhealth.ControllerName:           endpointhealth.Add,
+	clusteradoption.ControllerName:          clusteradoption.Add,
 }
 
 type controllerManagerOptions struct {
 	LogLevel            string
+	LogFormat           string
 	Controllers         []string
 	DisabledControllers []string
 }
@@ -119,10 +154,17 @@ func newRootCommand() *cobra.Command {
 			log.SetLevel(level)
 
 			// Add some millisecond precision to log timestamps, useful for debugging performance.
-			formatter := new(log.TextFormatter)
-			formatter.TimestampFormat = "2006-01-02T15:04:05.999Z07:00"
-			formatter.FullTimestamp = true
-			log.SetFormatter(formatter)
+			switch hivev1.LogFormat(opts.LogFormat) {
+			case hivev1.JSONLogFormat:
+				log.SetFormatter(&log.JSONFormatter{TimestampFormat: logTimestampFormat})
+			case hivev1.TextLogFormat:
+				formatter := new(log.TextFormatter)
+				formatter.TimestampFormat = logTimestampFormat
+				formatter.FullTimestamp = true
+				log.SetFormatter(formatter)
+			default:
+				log.WithField("logFormat", opts.LogFormat).Fatal("Unknown log format")
+			}
 
 			log.Infof("Version: %s", version.String())
 			log.Debug("debug logging enabled")
@@ -154,6 +196,11 @@ func newRootCommand() *cobra.Command {
 			hiveNSName := utils.GetHiveNamespace()
 			log.Infof("hive namespace: %s", hiveNSName)
 
+			shutdownTracing, err := tracing.Configure("hive-controllers", log.StandardLogger())
+			if err != nil {
+				log.WithError(err).Fatal("Cannot configure tracing")
+			}
+
 			// Create and start liveness and readiness probe endpoints
 			http.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
 				w.WriteHeader(http.StatusOK)
@@ -164,6 +211,8 @@ func newRootCommand() *cobra.Command {
 			log.Info("Starting /healthz and /readyz endpoints")
 			go http.ListenAndServe(":8080", nil)
 
+			canary := os.Getenv(constants.CanaryEnvVar) == "true"
+
 			// use a Go context so we can tell the leaderelection code when we want to step down
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
@@ -178,8 +227,22 @@ func newRootCommand() *cobra.Command {
 					log.Fatal(err)
 				}
 
+				if canarySelector := os.Getenv(constants.CanaryNamespaceSelectorEnvVar); canarySelector != "" {
+					selector, err := labels.Parse(canarySelector)
+					if err != nil {
+						log.WithError(err).Fatal("Cannot parse canary namespace selector")
+					}
+					log.WithField("selector", canarySelector).WithField("canary", canary).Info("partitioning namespaces for canary rollout")
+					if mgr, err = utils.NewCanaryManager(mgr, selector, canary); err != nil {
+						log.Fatal(err)
+					}
+				}
+
 				log.Info("Registering Components.")
 
+				// Apply the controller log levels set in HiveConfig as they change
+				utils.WatchControllerLogLevels(ctx.Done())
+
 				if err := utils.SetupAdditionalCA(); err != nil {
 					log.Fatal(err)
 				}
@@ -228,6 +291,12 @@ func newRootCommand() *cobra.Command {
 				if err != nil {
 					log.WithError(err).Error("error running manager")
 				}
+				// Export the spans of the last reconciles before the process exits
+				tracingCtx, tracingCancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+				if err := shutdownTracing(tracingCtx); err != nil {
+					log.WithError(err).Error("error shutting down tracing")
+				}
+				tracingCancel()
 				// Canceling the leader election context
 				cancel()
 			}
@@ -239,6 +308,11 @@ func newRootCommand() *cobra.Command {
 			if os.Getenv("HIVE_SKIP_LEADER_ELECTION") != "" {
 				run(ctx)
 			} else {
+				leaderElectionName := leaderElectionConfigMap
+				if canary {
+					leaderElectionName = canaryLeaderElectionConfigMap
+				}
+
 				id := uuid.New().String()
 				leLog := log.WithField("id", id)
 				leLog.Info("generated leader election ID")
@@ -246,7 +320,7 @@ func newRootCommand() *cobra.Command {
 				lock := &resourcelock.ConfigMapLock{
 					ConfigMapMeta: metav1.ObjectMeta{
 						Namespace: hiveNSName,
-						Name:      leaderElectionConfigMap,
+						Name:      leaderElectionName,
 					},
 					Client: kubernetes.NewForConfigOrDie(cfg).CoreV1(),
 					LockConfig: resourcelock.ResourceLockConfig{
@@ -285,6 +359,7 @@ func newRootCommand() *cobra.Command {
 	}
 
 	cmd.PersistentFlags().StringVar(&opts.LogLevel, "log-level", defaultLogLevel, "Log level (debug,info,warn,error,fatal)")
+	cmd.PersistentFlags().StringVar(&opts.LogFormat, "log-format", string(hivev1.TextLogFormat), "Log format (text,json)")
 	cmd.PersistentFlags().AddGoFlagSet(flag.CommandLine)
 	cmd.PersistentFlags().StringSliceVar(&opts.Controllers, "controllers", opts.Controllers, "Comma-separated list of controllers to run")
 	cmd.PersistentFlags().StringSliceVar(&opts.DisabledControllers, "disabled-controllers", []string{},
@@ -0,0 +1,44 @@
+package awsprivatelink
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const hiveConfigName = "hive"
+
+// NewAWSPrivateLinkCommand is the entrypoint to create the 'awsprivatelink' subcommand
+func NewAWSPrivateLinkCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "awsprivatelink",
+		Short: "Utility to manage and debug AWS PrivateLink",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Usage()
+		},
+	}
+	cmd.AddCommand(NewListCommand())
+	cmd.AddCommand(NewEndpointVPCCommand())
+	cmd.AddCommand(NewVerifyCommand())
+	return cmd
+}
+
+// getHiveConfig returns the HiveConfig and the namespace in which hive is running.
+func getHiveConfig(c client.Client) (*hivev1.HiveConfig, string, error) {
+	hc := &hivev1.HiveConfig{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: hiveConfigName}, hc); err != nil {
+		return nil, "", errors.Wrapf(err, "error looking up HiveConfig %q", hiveConfigName)
+	}
+	hiveNSName := hc.Spec.TargetNamespace
+	if hiveNSName == "" {
+		hiveNSName = constants.DefaultHiveNamespace
+	}
+	return hc, hiveNSName, nil
+}
@@ -0,0 +1,189 @@
+package awsprivatelink
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/awsclient"
+)
+
+// EndpointVPCOptions is the set of options to add a VPC to the endpoint VPC inventory.
+type EndpointVPCOptions struct {
+	VPCID         string
+	Region        string
+	Subnets       []string
+	ServedRegions []string
+
+	log log.FieldLogger
+}
+
+// NewEndpointVPCCommand creates a command to manage the endpoint VPC inventory in HiveConfig.
+func NewEndpointVPCCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "endpointvpc",
+		Short: "Manage the VPCs used for the VPC Endpoints of AWS PrivateLink",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Usage()
+		},
+	}
+	cmd.AddCommand(NewAddEndpointVPCCommand())
+	cmd.AddCommand(NewRemoveEndpointVPCCommand())
+	return cmd
+}
+
+// NewAddEndpointVPCCommand creates a command that adds a VPC to the endpoint VPC inventory in HiveConfig.
+func NewAddEndpointVPCCommand() *cobra.Command {
+	opt := &EndpointVPCOptions{log: log.WithField("command", "awsprivatelink endpointvpc add")}
+
+	cmd := &cobra.Command{
+		Use:   "add VPC_ID",
+		Short: "Add a VPC to the endpoint VPC inventory",
+		Long: `Add a VPC to the endpoint VPC inventory in HiveConfig, replacing any existing entry for the VPC.
+When no subnets are given, the subnets of the VPC are discovered using the AWS PrivateLink credentials from HiveConfig.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			opt.VPCID = args[0]
+			if err := opt.runAdd(context.Background()); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opt.Region, "region", "", "Region of the VPC")
+	flags.StringSliceVar(&opt.Subnets, "subnet", nil, "Subnet of the VPC to use for VPC Endpoints, as SUBNET_ID:AVAILABILITY_ZONE. May be repeated.")
+	flags.StringSliceVar(&opt.ServedRegions, "served-region", nil, "Region other than the region of the VPC whose clusters may use the VPC. May be repeated.")
+	cmd.MarkFlagRequired("region")
+	return cmd
+}
+
+// NewRemoveEndpointVPCCommand creates a command that removes a VPC from the endpoint VPC inventory in HiveConfig.
+func NewRemoveEndpointVPCCommand() *cobra.Command {
+	opt := &EndpointVPCOptions{log: log.WithField("command", "awsprivatelink endpointvpc remove")}
+
+	cmd := &cobra.Command{
+		Use:   "remove VPC_ID",
+		Short: "Remove a VPC from the endpoint VPC inventory",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			opt.VPCID = args[0]
+			if err := opt.runRemove(); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+	return cmd
+}
+
+func (o *EndpointVPCOptions) runAdd(ctx context.Context) error {
+	c, err := contributils.GetClient()
+	if err != nil {
+		return err
+	}
+	hc, hiveNSName, err := getHiveConfig(c)
+	if err != nil {
+		return err
+	}
+	if hc.Spec.AWSPrivateLink == nil {
+		return errors.New("AWS PrivateLink is not configured in HiveConfig")
+	}
+
+	item := hivev1.AWSPrivateLinkInventory{
+		AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{VPCID: o.VPCID, Region: o.Region},
+		ServedRegions:     o.ServedRegions,
+	}
+	if len(o.Subnets) > 0 {
+		for _, s := range o.Subnets {
+			parts := strings.Split(s, ":")
+			if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				return errors.Errorf("subnet %q must be of the form SUBNET_ID:AVAILABILITY_ZONE", s)
+			}
+			item.Subnets = append(item.Subnets, hivev1.AWSPrivateLinkSubnet{SubnetID: parts[0], AvailabilityZone: parts[1]})
+		}
+	} else {
+		item.Subnets, err = o.discoverSubnets(ctx, c, hc.Spec.AWSPrivateLink.CredentialsSecretRef.Name, hiveNSName)
+		if err != nil {
+			return err
+		}
+	}
+
+	inventory := []hivev1.AWSPrivateLinkInventory{}
+	for _, existing := range hc.Spec.AWSPrivateLink.EndpointVPCInventory {
+		if existing.VPCID != o.VPCID {
+			inventory = append(inventory, existing)
+		}
+	}
+	hc.Spec.AWSPrivateLink.EndpointVPCInventory = append(inventory, item)
+	if err := c.Update(context.Background(), hc); err != nil {
+		return errors.Wrap(err, "error updating HiveConfig")
+	}
+	o.log.WithField("vpcID", o.VPCID).WithField("subnets", len(item.Subnets)).Info("added VPC to the endpoint VPC inventory")
+	return nil
+}
+
+func (o *EndpointVPCOptions) discoverSubnets(ctx context.Context, c client.Client, credentialsSecretName, hiveNSName string) ([]hivev1.AWSPrivateLinkSubnet, error) {
+	awsClient, err := awsclient.NewClient(c, credentialsSecretName, hiveNSName, o.Region)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating AWS client")
+	}
+	resp, err := awsClient.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+		Filters: []*ec2.Filter{{Name: aws.String("vpc-id"), Values: aws.StringSlice([]string{o.VPCID})}},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error describing subnets of the VPC")
+	}
+	if len(resp.Subnets) == 0 {
+		return nil, errors.Errorf("no subnets found for VPC %s", o.VPCID)
+	}
+	subnets := make([]hivev1.AWSPrivateLinkSubnet, len(resp.Subnets))
+	for i, subnet := range resp.Subnets {
+		subnets[i] = hivev1.AWSPrivateLinkSubnet{
+			SubnetID:         aws.StringValue(subnet.SubnetId),
+			AvailabilityZone: aws.StringValue(subnet.AvailabilityZone),
+		}
+		o.log.WithField("subnetID", subnets[i].SubnetID).WithField("availabilityZone", subnets[i].AvailabilityZone).Info("discovered subnet")
+	}
+	return subnets, nil
+}
+
+func (o *EndpointVPCOptions) runRemove() error {
+	c, err := contributils.GetClient()
+	if err != nil {
+		return err
+	}
+	hc, _, err := getHiveConfig(c)
+	if err != nil {
+		return err
+	}
+	if hc.Spec.AWSPrivateLink == nil {
+		return errors.New("AWS PrivateLink is not configured in HiveConfig")
+	}
+
+	inventory := []hivev1.AWSPrivateLinkInventory{}
+	for _, existing := range hc.Spec.AWSPrivateLink.EndpointVPCInventory {
+		if existing.VPCID != o.VPCID {
+			inventory = append(inventory, existing)
+		}
+	}
+	if len(inventory) == len(hc.Spec.AWSPrivateLink.EndpointVPCInventory) {
+		return errors.Errorf("VPC %s is not in the endpoint VPC inventory", o.VPCID)
+	}
+	hc.Spec.AWSPrivateLink.EndpointVPCInventory = inventory
+	if err := c.Update(context.Background(), hc); err != nil {
+		return errors.Wrap(err, "error updating HiveConfig")
+	}
+	o.log.WithField("vpcID", o.VPCID).Info("removed VPC from the endpoint VPC inventory")
+	return nil
+}
@@ -0,0 +1,72 @@
+package awsprivatelink
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+)
+
+// NewListCommand creates a command that prints the endpoint VPC inventory and the associated VPCs
+// configured for AWS PrivateLink in HiveConfig.
+func NewListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the endpoint VPCs and associated VPCs configured for AWS PrivateLink",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := runList(); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+	return cmd
+}
+
+func runList() error {
+	c, err := contributils.GetClient()
+	if err != nil {
+		return err
+	}
+	hc, _, err := getHiveConfig(c)
+	if err != nil {
+		return err
+	}
+	config := hc.Spec.AWSPrivateLink
+	if config == nil {
+		fmt.Println("AWS PrivateLink is not configured in HiveConfig")
+		return nil
+	}
+
+	fmt.Printf("Credentials secret: %s\n\n", config.CredentialsSecretRef.Name)
+
+	fmt.Println("Endpoint VPCs:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VPC ID\tREGION\tSUBNETS\tSERVED REGIONS")
+	for _, item := range config.EndpointVPCInventory {
+		subnets := make([]string, len(item.Subnets))
+		for i, subnet := range item.Subnets {
+			subnets[i] = fmt.Sprintf("%s (%s)", subnet.SubnetID, subnet.AvailabilityZone)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", item.VPCID, item.Region, strings.Join(subnets, ", "), strings.Join(item.ServedRegions, ", "))
+	}
+	w.Flush()
+
+	fmt.Println("\nAssociated VPCs:")
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VPC ID\tREGION\tCREDENTIALS SECRET")
+	for _, item := range config.AssociatedVPCs {
+		credentials := config.CredentialsSecretRef.Name
+		if item.CredentialsSecretRef != nil {
+			credentials = item.CredentialsSecretRef.Name
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", item.VPCID, item.Region, credentials)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,240 @@
+package awsprivatelink
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/controller/awsprivatelink"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// VerifyOptions is the set of options to verify the AWS PrivateLink of a ClusterDeployment.
+type VerifyOptions struct {
+	Name      string
+	Namespace string
+
+	failures int
+}
+
+// NewVerifyCommand creates a command that verifies the AWS PrivateLink resources and DNS wiring of a
+// ClusterDeployment.
+func NewVerifyCommand() *cobra.Command {
+	opt := &VerifyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "verify CLUSTER_DEPLOYMENT_NAME",
+		Short: "Verify the AWS PrivateLink of a ClusterDeployment",
+		Long: `Verify that the VPC Endpoint Service, the VPC Endpoint and the Private Hosted Zone of a ClusterDeployment using
+AWS PrivateLink exist, that the Hosted Zone is associated with the endpoint VPC and the associated VPCs from HiveConfig,
+and that the API record of the cluster points at the VPC Endpoint.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			opt.Name = args[0]
+			if err := opt.run(context.Background()); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace of the ClusterDeployment")
+	return cmd
+}
+
+func (o *VerifyOptions) run(ctx context.Context) error {
+	c, err := contributils.GetClient()
+	if err != nil {
+		return err
+	}
+	if o.Namespace == "" {
+		o.Namespace, err = contributils.DefaultNamespace()
+		if err != nil {
+			return errors.Wrap(err, "cannot determine default namespace")
+		}
+	}
+	hc, hiveNSName, err := getHiveConfig(c)
+	if err != nil {
+		return err
+	}
+	if hc.Spec.AWSPrivateLink == nil {
+		return errors.New("AWS PrivateLink is not configured in HiveConfig")
+	}
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: o.Namespace, Name: o.Name}, cd); err != nil {
+		return errors.Wrap(err, "error getting ClusterDeployment")
+	}
+	if cd.Spec.Platform.AWS == nil || cd.Spec.Platform.AWS.PrivateLink == nil || !cd.Spec.Platform.AWS.PrivateLink.Enabled {
+		return errors.New("ClusterDeployment does not have AWS PrivateLink enabled")
+	}
+
+	for _, condType := range []hivev1.ClusterDeploymentConditionType{
+		hivev1.AWSPrivateLinkReadyClusterDeploymentCondition,
+		hivev1.AWSPrivateLinkFailedClusterDeploymentCondition,
+	} {
+		if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, condType); cond != nil {
+			fmt.Printf("Condition %s: %s (%s) %s\n", condType, cond.Status, cond.Reason, cond.Message)
+		}
+	}
+
+	if cd.Status.Platform == nil || cd.Status.Platform.AWS == nil || cd.Status.Platform.AWS.PrivateLink == nil {
+		return errors.New("ClusterDeployment has no AWS PrivateLink status")
+	}
+	plStatus := cd.Status.Platform.AWS.PrivateLink
+
+	endpointRegion, ok := awsprivatelink.EndpointRegion(hc.Spec.AWSPrivateLink, cd)
+	if !ok {
+		o.fail("no VPC in the endpoint VPC inventory serves region %s", cd.Spec.Platform.AWS.Region)
+		return o.result()
+	}
+	o.pass("VPC Endpoints for region %s are created in region %s", cd.Spec.Platform.AWS.Region, endpointRegion)
+
+	if name := cd.Spec.Platform.AWS.CredentialsSecretRef.Name; name != "" {
+		userClient, err := awsclient.NewClient(c, name, cd.Namespace, cd.Spec.Platform.AWS.Region)
+		if err != nil {
+			return errors.Wrap(err, "error creating AWS client for the cluster account")
+		}
+		o.verifyVPCEndpointService(ctx, userClient, plStatus.VPCEndpointService.ID)
+	}
+
+	hubClient, err := awsclient.NewClient(c, hc.Spec.AWSPrivateLink.CredentialsSecretRef.Name, hiveNSName, endpointRegion)
+	if err != nil {
+		return errors.Wrap(err, "error creating AWS client for the hub account")
+	}
+	endpoint := o.verifyVPCEndpoint(ctx, hubClient, plStatus.VPCEndpointID)
+	o.verifyHostedZone(ctx, hubClient, plStatus.HostedZoneID, endpoint, endpointRegion, hc.Spec.AWSPrivateLink.AssociatedVPCs)
+	o.verifyAPIRecord(ctx, hubClient, plStatus.HostedZoneID, fmt.Sprintf("api.%s.%s", cd.Spec.ClusterName, cd.Spec.BaseDomain), endpoint)
+
+	return o.result()
+}
+
+func (o *VerifyOptions) verifyVPCEndpointService(ctx context.Context, awsClient awsclient.Client, serviceID string) {
+	if serviceID == "" {
+		o.fail("no VPC Endpoint Service is recorded in the ClusterDeployment status")
+		return
+	}
+	resp, err := awsClient.DescribeVpcEndpointServiceConfigurations(ctx, &ec2.DescribeVpcEndpointServiceConfigurationsInput{
+		ServiceIds: aws.StringSlice([]string{serviceID}),
+	})
+	if err != nil || len(resp.ServiceConfigurations) == 0 {
+		o.fail("VPC Endpoint Service %s could not be found: %v", serviceID, err)
+		return
+	}
+	if state := aws.StringValue(resp.ServiceConfigurations[0].ServiceState); state != ec2.ServiceStateAvailable {
+		o.fail("VPC Endpoint Service %s is in state %s", serviceID, state)
+		return
+	}
+	o.pass("VPC Endpoint Service %s is available", serviceID)
+}
+
+func (o *VerifyOptions) verifyVPCEndpoint(ctx context.Context, awsClient awsclient.Client, endpointID string) *ec2.VpcEndpoint {
+	if endpointID == "" {
+		o.fail("no VPC Endpoint is recorded in the ClusterDeployment status")
+		return nil
+	}
+	resp, err := awsClient.DescribeVpcEndpoints(ctx, &ec2.DescribeVpcEndpointsInput{
+		VpcEndpointIds: aws.StringSlice([]string{endpointID}),
+	})
+	if err != nil || len(resp.VpcEndpoints) == 0 {
+		o.fail("VPC Endpoint %s could not be found: %v", endpointID, err)
+		return nil
+	}
+	endpoint := resp.VpcEndpoints[0]
+	if state := aws.StringValue(endpoint.State); !strings.EqualFold(state, ec2.StateAvailable) {
+		o.fail("VPC Endpoint %s in VPC %s is in state %s", endpointID, aws.StringValue(endpoint.VpcId), state)
+		return endpoint
+	}
+	o.pass("VPC Endpoint %s in VPC %s is available", endpointID, aws.StringValue(endpoint.VpcId))
+	return endpoint
+}
+
+func (o *VerifyOptions) verifyHostedZone(ctx context.Context, awsClient awsclient.Client, hostedZoneID string, endpoint *ec2.VpcEndpoint, endpointRegion string, associatedVPCs []hivev1.AWSAssociatedVPC) {
+	if hostedZoneID == "" {
+		o.fail("no Hosted Zone is recorded in the ClusterDeployment status")
+		return
+	}
+	resp, err := awsClient.GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: aws.String(hostedZoneID)})
+	if err != nil {
+		o.fail("Hosted Zone %s could not be found: %v", hostedZoneID, err)
+		return
+	}
+	o.pass("Hosted Zone %s exists for %s", hostedZoneID, aws.StringValue(resp.HostedZone.Name))
+
+	associated := map[string]bool{}
+	for _, vpc := range resp.VPCs {
+		associated[aws.StringValue(vpc.VPCId)] = true
+	}
+	expected := []hivev1.AWSPrivateLinkVPC{}
+	if endpoint != nil {
+		expected = append(expected, hivev1.AWSPrivateLinkVPC{VPCID: aws.StringValue(endpoint.VpcId), Region: endpointRegion})
+	}
+	for _, vpc := range associatedVPCs {
+		expected = append(expected, vpc.AWSPrivateLinkVPC)
+	}
+	for _, vpc := range expected {
+		if associated[vpc.VPCID] {
+			o.pass("Hosted Zone is associated with VPC %s in region %s", vpc.VPCID, vpc.Region)
+		} else {
+			o.fail("Hosted Zone is not associated with VPC %s in region %s", vpc.VPCID, vpc.Region)
+		}
+	}
+}
+
+func (o *VerifyOptions) verifyAPIRecord(ctx context.Context, awsClient awsclient.Client, hostedZoneID, apiDomain string, endpoint *ec2.VpcEndpoint) {
+	if hostedZoneID == "" {
+		return
+	}
+	resp, err := awsClient.ListResourceRecordSets(ctx, &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(hostedZoneID),
+		StartRecordName: aws.String(apiDomain),
+		StartRecordType: aws.String(route53.RRTypeA),
+		MaxItems:        aws.String("1"),
+	})
+	if err != nil {
+		o.fail("could not list the records of Hosted Zone %s: %v", hostedZoneID, err)
+		return
+	}
+	if len(resp.ResourceRecordSets) == 0 ||
+		!strings.EqualFold(strings.TrimSuffix(aws.StringValue(resp.ResourceRecordSets[0].Name), "."), apiDomain) ||
+		resp.ResourceRecordSets[0].AliasTarget == nil {
+		o.fail("Hosted Zone has no alias record for %s", apiDomain)
+		return
+	}
+	target := strings.TrimSuffix(aws.StringValue(resp.ResourceRecordSets[0].AliasTarget.DNSName), ".")
+	if endpoint != nil && len(endpoint.DnsEntries) > 0 &&
+		!strings.EqualFold(target, strings.TrimSuffix(aws.StringValue(endpoint.DnsEntries[0].DnsName), ".")) {
+		o.fail("record for %s points at %s instead of the VPC Endpoint %s", apiDomain, target, aws.StringValue(endpoint.DnsEntries[0].DnsName))
+		return
+	}
+	o.pass("record for %s points at %s", apiDomain, target)
+}
+
+func (o *VerifyOptions) pass(format string, args ...interface{}) {
+	fmt.Printf("[PASS] "+format+"\n", args...)
+}
+
+func (o *VerifyOptions) fail(format string, args ...interface{}) {
+	o.failures++
+	fmt.Printf("[FAIL] "+format+"\n", args...)
+}
+
+func (o *VerifyOptions) result() error {
+	if o.failures > 0 {
+		return errors.Errorf("%d checks failed", o.failures)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package certificate
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -52,7 +53,7 @@ func NewCreateCertifcateCommand() *cobra.Command {
 				return
 			}
 			log.SetLevel(log.InfoLevel)
-			err := opt.Run()
+			err := opt.Run(context.Background())
 			if err != nil {
 				log.WithError(err).Error("Error")
 			}
@@ -74,12 +75,12 @@ func (o *Options) Complete(cmd *cobra.Command, args []string) error {
 }
 
 // Run executes the command
-func (o *Options) Run() error {
+func (o *Options) Run(ctx context.Context) error {
 	certbotPath, err := exec.LookPath("certbot")
 	if err != nil {
 		return errors.New("certbot is required to create a certificate, install it by following instructions here: https://certbot.eff.org")
 	}
-	baseDomainID, err := o.getBaseDomainID()
+	baseDomainID, err := o.getBaseDomainID(ctx)
 	if err != nil {
 		return err
 	}
@@ -138,12 +139,12 @@ func (o *Options) Run() error {
 	return nil
 }
 
-func (o *Options) getBaseDomainID() (string, error) {
+func (o *Options) getBaseDomainID(ctx context.Context) (string, error) {
 	client, err := awsclient.NewClient(nil, "", "", o.Region)
 	if err != nil {
 		return "", errors.Wrap(err, "cannot create AWS client; make sure your environment is setup to communicate with AWS")
 	}
-	result, err := client.ListHostedZonesByName(&route53.ListHostedZonesByNameInput{
+	result, err := client.ListHostedZonesByName(ctx, &route53.ListHostedZonesByNameInput{
 		DNSName: aws.String(o.BaseDomain),
 	})
 	if err != nil {
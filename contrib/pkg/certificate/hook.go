@@ -1,6 +1,7 @@
 package certificate
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"time"
@@ -37,7 +38,7 @@ func NewAuthHookCommand() *cobra.Command {
 				return
 			}
 			log.SetLevel(log.InfoLevel)
-			err := opt.Create()
+			err := opt.Create(context.Background())
 			if err != nil {
 				log.WithError(err).Error("Error")
 				os.Exit(1)
@@ -61,7 +62,7 @@ func NewCleanupHookCommand() *cobra.Command {
 				return
 			}
 			log.SetLevel(log.InfoLevel)
-			err := opt.Delete()
+			err := opt.Delete(context.Background())
 			if err != nil {
 				log.WithError(err).Error("Error")
 				os.Exit(1)
@@ -87,8 +88,8 @@ func (o *HookOptions) Complete(cmd *cobra.Command, args []string) error {
 }
 
 // Create creates an authentication DNS record in the specified zone
-func (o *HookOptions) Create() error {
-	err := o.modifyDNSRecord(false)
+func (o *HookOptions) Create(ctx context.Context) error {
+	err := o.modifyDNSRecord(ctx, false)
 	if err != nil {
 		return err
 	}
@@ -97,11 +98,11 @@ func (o *HookOptions) Create() error {
 }
 
 // Delete removes the authentication DNS record from the specified zone
-func (o *HookOptions) Delete() error {
-	return o.modifyDNSRecord(true)
+func (o *HookOptions) Delete(ctx context.Context) error {
+	return o.modifyDNSRecord(ctx, true)
 }
 
-func (o *HookOptions) modifyDNSRecord(remove bool) error {
+func (o *HookOptions) modifyDNSRecord(ctx context.Context, remove bool) error {
 	client, err := awsclient.NewClient(nil, "", "", o.Region)
 	if err != nil {
 		return errors.Wrap(err, "cannot create AWS client")
@@ -123,7 +124,7 @@ func (o *HookOptions) modifyDNSRecord(remove bool) error {
 			TTL:  aws.Int64(30),
 		},
 	}
-	_, err = client.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+	_, err = client.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
 		HostedZoneId: aws.String(o.HostedZoneID),
 		ChangeBatch: &route53.ChangeBatch{
 			Changes: []*route53.Change{change},
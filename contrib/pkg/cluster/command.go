@@ -0,0 +1,18 @@
+package cluster
+
+import "github.com/spf13/cobra"
+
+// NewClusterCommand is the entrypoint to create the 'cluster' subcommand
+func NewClusterCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Utility to manage ClusterDeployments",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Usage()
+		},
+	}
+	cmd.AddCommand(NewHibernateCommand())
+	cmd.AddCommand(NewResumeCommand())
+	cmd.AddCommand(NewStatusCommand())
+	return cmd
+}
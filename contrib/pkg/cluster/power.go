@@ -0,0 +1,213 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const pollInterval = 10 * time.Second
+
+// PowerStateOptions is the set of options to change the power state of a ClusterDeployment.
+type PowerStateOptions struct {
+	Name        string
+	Namespace   string
+	WorkersOnly bool
+	Wait        bool
+	Timeout     time.Duration
+
+	log log.FieldLogger
+}
+
+// NewHibernateCommand creates a command that hibernates a ClusterDeployment.
+func NewHibernateCommand() *cobra.Command {
+	opt := &PowerStateOptions{log: log.WithField("command", "cluster hibernate")}
+
+	cmd := &cobra.Command{
+		Use:   "hibernate CLUSTER_DEPLOYMENT_NAME",
+		Short: "Hibernate a cluster",
+		Long:  "Sets the power state of the ClusterDeployment to Hibernating, or to WorkersStopped with --workers-only, and waits for the cluster to reach that state.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			opt.Name = args[0]
+			powerState, reason := hivev1.HibernatingClusterPowerState, hivev1.HibernatingHibernationReason
+			if opt.WorkersOnly {
+				powerState, reason = hivev1.WorkersStoppedClusterPowerState, hivev1.WorkersStoppedHibernationReason
+			}
+			if err := opt.run(powerState, reason); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+	opt.addFlags(cmd)
+	cmd.Flags().BoolVar(&opt.WorkersOnly, "workers-only", false, "Stop only the workers of the cluster, leaving the control plane running")
+	return cmd
+}
+
+// NewResumeCommand creates a command that resumes a hibernating ClusterDeployment.
+func NewResumeCommand() *cobra.Command {
+	opt := &PowerStateOptions{log: log.WithField("command", "cluster resume")}
+
+	cmd := &cobra.Command{
+		Use:   "resume CLUSTER_DEPLOYMENT_NAME",
+		Short: "Resume a hibernating cluster",
+		Long:  "Sets the power state of the ClusterDeployment to Running and waits for the cluster to be running.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			opt.Name = args[0]
+			if err := opt.run(hivev1.RunningClusterPowerState, hivev1.RunningHibernationReason); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+	opt.addFlags(cmd)
+	return cmd
+}
+
+// NewStatusCommand creates a command that prints the power state of a ClusterDeployment.
+func NewStatusCommand() *cobra.Command {
+	opt := &PowerStateOptions{log: log.WithField("command", "cluster status")}
+
+	cmd := &cobra.Command{
+		Use:   "status CLUSTER_DEPLOYMENT_NAME",
+		Short: "Print the power state of a cluster",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			opt.Name = args[0]
+			if err := opt.status(); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+	cmd.Flags().StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace of the ClusterDeployment")
+	return cmd
+}
+
+func (o *PowerStateOptions) addFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVarP(&o.Namespace, "namespace", "n", "", "Namespace of the ClusterDeployment")
+	flags.BoolVar(&o.Wait, "wait", true, "Wait for the cluster to reach the requested power state")
+	flags.DurationVar(&o.Timeout, "timeout", 30*time.Minute, "How long to wait for the cluster to reach the requested power state")
+}
+
+func (o *PowerStateOptions) complete() (client.Client, error) {
+	c, err := contributils.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	if o.Namespace == "" {
+		o.Namespace, err = contributils.DefaultNamespace()
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot determine default namespace")
+		}
+	}
+	return c, nil
+}
+
+func (o *PowerStateOptions) getClusterDeployment(c client.Client) (*hivev1.ClusterDeployment, error) {
+	cd := &hivev1.ClusterDeployment{}
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: o.Namespace, Name: o.Name}, cd); err != nil {
+		return nil, errors.Wrap(err, "error getting ClusterDeployment")
+	}
+	return cd, nil
+}
+
+func (o *PowerStateOptions) run(powerState hivev1.ClusterPowerState, reason string) error {
+	c, err := o.complete()
+	if err != nil {
+		return err
+	}
+	cd, err := o.getClusterDeployment(c)
+	if err != nil {
+		return err
+	}
+	if !cd.Spec.Installed {
+		return errors.New("cluster is not installed")
+	}
+
+	if cd.Spec.PowerState != powerState {
+		patch := client.MergeFrom(cd.DeepCopy())
+		cd.Spec.PowerState = powerState
+		if err := c.Patch(context.Background(), cd, patch); err != nil {
+			return errors.Wrap(err, "error setting power state")
+		}
+		o.log.WithField("powerState", powerState).Info("set power state")
+	}
+	if !o.Wait {
+		return nil
+	}
+
+	var lastMessage string
+	err = wait.PollImmediate(pollInterval, o.Timeout, func() (bool, error) {
+		cd, err := o.getClusterDeployment(c)
+		if err != nil {
+			return false, err
+		}
+		cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
+		if cond == nil {
+			return false, nil
+		}
+		if message := fmt.Sprintf("%s: %s", cond.Reason, cond.Message); message != lastMessage {
+			o.log.WithField("reason", cond.Reason).Info(cond.Message)
+			lastMessage = message
+		}
+		switch cond.Reason {
+		case reason:
+			return true, nil
+		case hivev1.UnsupportedHibernationReason:
+			return false, errors.Errorf("cluster does not support hibernation: %s", cond.Message)
+		}
+		return false, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return errors.Errorf("timed out waiting for the cluster to reach power state %s", powerState)
+	}
+	if err != nil {
+		return err
+	}
+	o.log.WithField("powerState", powerState).Info("cluster reached power state")
+	return nil
+}
+
+func (o *PowerStateOptions) status() error {
+	c, err := o.complete()
+	if err != nil {
+		return err
+	}
+	cd, err := o.getClusterDeployment(c)
+	if err != nil {
+		return err
+	}
+	powerState := cd.Spec.PowerState
+	if powerState == "" {
+		powerState = hivev1.RunningClusterPowerState
+	}
+	fmt.Printf("Requested power state: %s\n", powerState)
+	cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
+	if cond == nil {
+		fmt.Println("Current power state: Unknown")
+		return nil
+	}
+	fmt.Printf("Current power state: %s\n", cond.Reason)
+	fmt.Printf("Message: %s\n", cond.Message)
+	fmt.Printf("Last transition: %s\n", cond.LastTransitionTime.Time.Format(time.RFC3339))
+	if cd.Spec.HibernateAfter != nil {
+		fmt.Printf("Hibernate after: %s\n", cd.Spec.HibernateAfter.Duration)
+	}
+	return nil
+}
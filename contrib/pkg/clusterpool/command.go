@@ -14,6 +14,8 @@ func NewClusterPoolCommand() *cobra.Command {
 	}
 	cmd.AddCommand(NewCreateClusterPoolCommand())
 	cmd.AddCommand(NewClaimClusterPoolCommand())
+	cmd.AddCommand(NewClusterPoolStatusCommand())
+	cmd.AddCommand(NewClusterPoolScaleCommand())
 	return cmd
 
 }
@@ -0,0 +1,124 @@
+package clusterpool
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/hive/contrib/pkg/utils"
+)
+
+// ClusterPoolScaleOptions is the set of options to resize a ClusterPool.
+type ClusterPoolScaleOptions struct {
+	Name      string
+	Namespace string
+	Size      int32
+	Yes       bool
+
+	log log.FieldLogger
+}
+
+// NewClusterPoolScaleCommand creates a command that changes the size of a ClusterPool.
+func NewClusterPoolScaleCommand() *cobra.Command {
+	opt := &ClusterPoolScaleOptions{log: log.WithField("command", "clusterpool scale")}
+
+	cmd := &cobra.Command{
+		Use:   "scale CLUSTER_POOL_NAME SIZE",
+		Short: "Changes the size of a ClusterPool",
+		Long: `Changes the size of a ClusterPool after printing the clusters that will be created or deleted and asking for
+confirmation. The new size cannot exceed the maxSize of the pool.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			opt.Name = args[0]
+			size, err := strconv.ParseInt(args[1], 10, 32)
+			if err != nil || size < 0 {
+				opt.log.Fatalf("invalid size %q", args[1])
+			}
+			opt.Size = int32(size)
+			if err := opt.run(); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace of the cluster pool")
+	flags.BoolVarP(&opt.Yes, "yes", "y", false, "Resize the pool without asking for confirmation")
+	return cmd
+}
+
+func (o *ClusterPoolScaleOptions) run() error {
+	c, err := utils.GetClient()
+	if err != nil {
+		return err
+	}
+	if len(o.Namespace) == 0 {
+		o.Namespace, err = utils.DefaultNamespace()
+		if err != nil {
+			return errors.Wrap(err, "cannot determine default namespace")
+		}
+	}
+	pool, err := getClusterPool(c, o.Namespace, o.Name)
+	if err != nil {
+		return err
+	}
+	if pool.Spec.MaxSize != nil && o.Size > *pool.Spec.MaxSize {
+		return errors.Errorf("size %d exceeds the max size %d of the pool", o.Size, *pool.Spec.MaxSize)
+	}
+	if pool.Spec.Size == o.Size {
+		fmt.Printf("ClusterPool %s/%s already has size %d\n", pool.Namespace, pool.Name, o.Size)
+		return nil
+	}
+	clusters, err := getPoolClusters(c, pool)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Scaling ClusterPool %s/%s from %d to %d\n", pool.Namespace, pool.Name, pool.Spec.Size, o.Size)
+	unclaimed := int32(len(clusters.ready) + len(clusters.provisioning) + len(clusters.broken))
+	switch {
+	case o.Size > unclaimed:
+		toCreate := o.Size - unclaimed
+		fmt.Printf("%d clusters will be created\n", toCreate)
+		if maxConcurrent := pool.Spec.MaxConcurrent; maxConcurrent != nil && toCreate > *maxConcurrent {
+			fmt.Printf("The pool installs at most %d clusters at a time, so the new clusters will be created in %d batches\n",
+				*maxConcurrent, (toCreate+*maxConcurrent-1) / *maxConcurrent)
+		}
+	case o.Size < unclaimed:
+		fmt.Printf("%d unclaimed clusters will be deleted, starting with the clusters that are still provisioning\n", unclaimed-o.Size)
+	}
+
+	if !o.Yes && !confirm("Proceed?") {
+		fmt.Println("Not scaling ClusterPool")
+		return nil
+	}
+
+	patch := client.MergeFrom(pool.DeepCopy())
+	pool.Spec.Size = o.Size
+	if err := c.Patch(context.Background(), pool, patch); err != nil {
+		return errors.Wrap(err, "error scaling ClusterPool")
+	}
+	o.log.WithField("size", o.Size).Info("scaled ClusterPool")
+	return nil
+}
+
+func confirm(prompt string) bool {
+	fmt.Printf("%s [y/N]: ", prompt)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
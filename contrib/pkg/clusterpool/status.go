@@ -0,0 +1,211 @@
+package clusterpool
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/contrib/pkg/utils"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// ClusterPoolStatusOptions is the set of options to print the status of a ClusterPool.
+type ClusterPoolStatusOptions struct {
+	Name      string
+	Namespace string
+
+	log log.FieldLogger
+}
+
+// poolClusters is the breakdown of the ClusterDeployments of a pool.
+type poolClusters struct {
+	ready        []*hivev1.ClusterDeployment
+	provisioning []*hivev1.ClusterDeployment
+	broken       []*hivev1.ClusterDeployment
+	claimed      []*hivev1.ClusterDeployment
+	deleting     []*hivev1.ClusterDeployment
+}
+
+// NewClusterPoolStatusCommand creates a command that prints the status of a ClusterPool.
+func NewClusterPoolStatusCommand() *cobra.Command {
+	opt := &ClusterPoolStatusOptions{log: log.WithField("command", "clusterpool status")}
+
+	cmd := &cobra.Command{
+		Use:   "status CLUSTER_POOL_NAME",
+		Short: "Prints the status of a ClusterPool",
+		Long:  "Prints the clusters of a ClusterPool by state, the pending claims and the broken clusters",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			opt.Name = args[0]
+			if err := opt.run(); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace of the cluster pool")
+	return cmd
+}
+
+func (o *ClusterPoolStatusOptions) run() error {
+	c, err := utils.GetClient()
+	if err != nil {
+		return err
+	}
+	if len(o.Namespace) == 0 {
+		o.Namespace, err = utils.DefaultNamespace()
+		if err != nil {
+			return errors.Wrap(err, "cannot determine default namespace")
+		}
+	}
+	pool, err := getClusterPool(c, o.Namespace, o.Name)
+	if err != nil {
+		return err
+	}
+	clusters, err := getPoolClusters(c, pool)
+	if err != nil {
+		return err
+	}
+	claims, err := getPendingClaims(c, pool)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Pool: %s/%s\n", pool.Namespace, pool.Name)
+	fmt.Printf("Size: %d\n", pool.Spec.Size)
+	if pool.Spec.MaxSize != nil {
+		fmt.Printf("Max size: %d\n", *pool.Spec.MaxSize)
+	}
+	if pool.Spec.MaxConcurrent != nil {
+		fmt.Printf("Max concurrent: %d\n", *pool.Spec.MaxConcurrent)
+	}
+	fmt.Printf("Image set: %s\n\n", pool.Spec.ImageSetRef.Name)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Ready:\t%d\n", len(clusters.ready))
+	fmt.Fprintf(w, "Provisioning:\t%d\n", len(clusters.provisioning))
+	fmt.Fprintf(w, "Broken:\t%d\n", len(clusters.broken))
+	fmt.Fprintf(w, "Claimed:\t%d\n", len(clusters.claimed))
+	fmt.Fprintf(w, "Deleting:\t%d\n", len(clusters.deleting))
+	fmt.Fprintf(w, "Pending claims:\t%d\n", len(claims))
+	w.Flush()
+
+	// Only the conditions that show a problem with the pool are printed.
+	healthyStatus := map[hivev1.ClusterPoolConditionType]corev1.ConditionStatus{
+		hivev1.ClusterPoolMissingDependenciesCondition: corev1.ConditionFalse,
+		hivev1.ClusterPoolCapacityAvailableCondition:   corev1.ConditionTrue,
+		hivev1.ClusterPoolAllClustersCurrentCondition:  corev1.ConditionTrue,
+	}
+	for _, cond := range pool.Status.Conditions {
+		if healthy, ok := healthyStatus[cond.Type]; ok && cond.Status == healthy {
+			continue
+		}
+		fmt.Printf("\nCondition %s: %s (%s) %s\n", cond.Type, cond.Status, cond.Reason, cond.Message)
+	}
+
+	if len(claims) > 0 {
+		fmt.Println("\nPending claims:")
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tAGE")
+		for _, claim := range claims {
+			fmt.Fprintf(w, "%s\t%s\n", claim.Name, duration.HumanDuration(time.Since(claim.CreationTimestamp.Time)))
+		}
+		w.Flush()
+	}
+
+	if len(clusters.broken) > 0 {
+		fmt.Println("\nBroken clusters:")
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAMESPACE\tNAME\tREASON\tMESSAGE")
+		for _, cd := range clusters.broken {
+			cond := brokenCondition(cd)
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", cd.Namespace, cd.Name, cond.Reason, cond.Message)
+		}
+		w.Flush()
+	}
+	return nil
+}
+
+func getClusterPool(c client.Client, namespace, name string) (*hivev1.ClusterPool, error) {
+	pool := &hivev1.ClusterPool{}
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, pool); err != nil {
+		return nil, errors.Wrap(err, "error getting ClusterPool")
+	}
+	return pool, nil
+}
+
+func getPoolClusters(c client.Client, pool *hivev1.ClusterPool) (*poolClusters, error) {
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := c.List(context.Background(), cdList); err != nil {
+		return nil, errors.Wrap(err, "error listing ClusterDeployments")
+	}
+	clusters := &poolClusters{}
+	for i := range cdList.Items {
+		cd := &cdList.Items[i]
+		ref := cd.Spec.ClusterPoolRef
+		if ref == nil || ref.Namespace != pool.Namespace || ref.PoolName != pool.Name {
+			continue
+		}
+		switch {
+		case cd.DeletionTimestamp != nil:
+			clusters.deleting = append(clusters.deleting, cd)
+		case ref.ClaimName != "":
+			clusters.claimed = append(clusters.claimed, cd)
+		case brokenCondition(cd) != nil:
+			clusters.broken = append(clusters.broken, cd)
+		case !cd.Spec.Installed:
+			clusters.provisioning = append(clusters.provisioning, cd)
+		default:
+			clusters.ready = append(clusters.ready, cd)
+		}
+	}
+	return clusters, nil
+}
+
+// brokenCondition returns the condition that shows that an unclaimed cluster of a pool will not become usable
+// without intervention, or nil if the cluster is not broken.
+func brokenCondition(cd *hivev1.ClusterDeployment) *hivev1.ClusterDeploymentCondition {
+	for _, condType := range []hivev1.ClusterDeploymentConditionType{
+		hivev1.ProvisionStoppedCondition,
+		hivev1.UnreachableCondition,
+	} {
+		cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, condType)
+		if cond != nil && cond.Status == corev1.ConditionTrue {
+			return cond
+		}
+	}
+	return nil
+}
+
+func getPendingClaims(c client.Client, pool *hivev1.ClusterPool) ([]*hivev1.ClusterClaim, error) {
+	claimList := &hivev1.ClusterClaimList{}
+	if err := c.List(context.Background(), claimList, client.InNamespace(pool.Namespace)); err != nil {
+		return nil, errors.Wrap(err, "error listing ClusterClaims")
+	}
+	var claims []*hivev1.ClusterClaim
+	for i, claim := range claimList.Items {
+		if claim.Spec.ClusterPoolName == pool.Name && claim.Spec.Namespace == "" && claim.DeletionTimestamp == nil {
+			claims = append(claims, &claimList.Items[i])
+		}
+	}
+	sort.Slice(claims, func(i, j int) bool {
+		return claims[i].CreationTimestamp.Before(&claims[j].CreationTimestamp)
+	})
+	return claims, nil
+}
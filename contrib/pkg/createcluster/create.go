@@ -31,6 +31,7 @@ import (
 	"github.com/openshift/hive/pkg/clusterresource"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/gcpclient"
+	installertypes "github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/validate"
 )
 
@@ -51,6 +52,15 @@ cluster deployment. If you don't wish to use a ClusterImageSet, specify
 --use-image-set=false. This will result in images only specified on the
 cluster itself.
 
+INSTALL CONFIG
+An existing install-config.yaml can be passed through to the installer as is
+with --install-config, to use installer features that the flags of this command
+do not cover. The cloud, region, base domain and platform settings of the
+ClusterDeployment are derived from the install-config, and its metadata.name
+must match CLUSTER_DEPLOYMENT_NAME. Flags that would set fields of the
+install-config cannot be used with it. No worker MachinePool is generated;
+create MachinePools matching the compute pools of the install-config to manage
+them with Hive.
 
 ENVIRONMENT VARIABLES
 The command will use the following environment variables for its output:
@@ -158,13 +168,22 @@ type Options struct {
 	AdditionalTrustBundle             string
 	CentralMachineManagement          bool
 	Internal                          bool
+	InstallConfigFile                 string
 
 	// AWS
 	AWSUserTags    []string
 	AWSPrivateLink bool
 
+	// GCP
+	GCPPrivateServiceConnect bool
+
 	// Azure
 	AzureBaseDomainResourceGroupName string
+	AzureResourceGroupName           string
+	AzureNetworkResourceGroupName    string
+	AzureVirtualNetwork              string
+	AzureControlPlaneSubnet          string
+	AzureComputeSubnet               string
 
 	// OpenStack
 	OpenStackCloud             string
@@ -193,6 +212,9 @@ type Options struct {
 	OvirtIngressVIP      string
 	OvirtCACerts         string
 
+	// installConfig is the contents of InstallConfigFile
+	installConfig string
+
 	homeDir string
 	log     log.FieldLogger
 }
@@ -218,6 +240,7 @@ func NewCreateClusterCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use: `create-cluster CLUSTER_DEPLOYMENT_NAME
 create-cluster CLUSTER_DEPLOYMENT_NAME --cloud=aws
+create-cluster CLUSTER_DEPLOYMENT_NAME --install-config=install-config.yaml
 create-cluster CLUSTER_DEPLOYMENT_NAME --cloud=azure --azure-base-domain-resource-group-name=RESOURCE_GROUP_NAME
 create-cluster CLUSTER_DEPLOYMENT_NAME --cloud=gcp
 create-cluster CLUSTER_DEPLOYMENT_NAME --cloud=openstack --openstack-api-floating-ip=192.168.1.2 --openstack-cloud=mycloud
@@ -280,6 +303,8 @@ create-cluster CLUSTER_DEPLOYMENT_NAME --cloud=ovirt --ovirt-api-vip 192.168.1.2
 	flags.BoolVar(&opt.Internal, "internal", false, `When set, it configures the install-config.yaml's publish field to Internal.
 OpenShift Installer publishes all the services of the cluster like API server and ingress to internal network and not the Internet.`)
 
+	flags.StringVar(&opt.InstallConfigFile, "install-config", "", "Path to an install-config.yaml passed through to the installer as is. The cloud, region, base domain and platform of the cluster are derived from it.")
+
 	// Flags related to adoption.
 	flags.BoolVar(&opt.Adopt, "adopt", false, "Enable adoption mode for importing a pre-existing cluster into Hive. Will require additional flags for adoption info.")
 	flags.StringVar(&opt.AdoptAdminKubeConfig, "adopt-admin-kubeconfig", "", "Path to a cluster admin kubeconfig file for a cluster being adopted. (required if using --adopt)")
@@ -292,8 +317,16 @@ OpenShift Installer publishes all the services of the cluster like API server an
 	flags.StringSliceVar(&opt.AWSUserTags, "aws-user-tags", nil, "Additional tags to add to resources. Must be in the form \"key=value\"")
 	flags.BoolVar(&opt.AWSPrivateLink, "aws-private-link", false, "Enables access to cluster using AWS PrivateLink")
 
+	// GCP flags
+	flags.BoolVar(&opt.GCPPrivateServiceConnect, "gcp-private-service-connect", false, "Enables access to cluster using GCP Private Service Connect")
+
 	// Azure flags
 	flags.StringVar(&opt.AzureBaseDomainResourceGroupName, "azure-base-domain-resource-group-name", "os4-common", "Resource group where the azure DNS zone for the base domain is found")
+	flags.StringVar(&opt.AzureResourceGroupName, "azure-resource-group-name", "", "Existing, empty resource group into which to install the cluster")
+	flags.StringVar(&opt.AzureNetworkResourceGroupName, "azure-network-resource-group-name", "", "Resource group of the existing VNet into which to install the cluster")
+	flags.StringVar(&opt.AzureVirtualNetwork, "azure-virtual-network", "", "Existing VNet into which to install the cluster")
+	flags.StringVar(&opt.AzureControlPlaneSubnet, "azure-control-plane-subnet", "", "Existing subnet of the VNet for the control plane machines")
+	flags.StringVar(&opt.AzureComputeSubnet, "azure-compute-subnet", "", "Existing subnet of the VNet for the compute machines")
 
 	// OpenStack flags
 	flags.StringVar(&opt.OpenStackCloud, "openstack-cloud", "openstack", "Section of clouds.yaml to use for API/auth")
@@ -332,6 +365,12 @@ OpenShift Installer publishes all the services of the cluster like API server an
 func (o *Options) Complete(cmd *cobra.Command, args []string) error {
 	o.Name = args[0]
 
+	if o.InstallConfigFile != "" {
+		if err := o.completeFromInstallConfig(cmd); err != nil {
+			return err
+		}
+	}
+
 	if o.Region == "" {
 		switch o.Cloud {
 		case cloudAWS:
@@ -354,6 +393,114 @@ func (o *Options) Complete(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// installConfigFlags are the flags setting fields of the install-config, which cannot be used with --install-config.
+var installConfigFlags = []string{
+	"cloud",
+	"base-domain",
+	"region",
+	"workers",
+	"machine-network",
+	"internal",
+	"ssh-public-key",
+	"ssh-public-key-file",
+	"additional-trust-bundle",
+	"credentials-mode-manual",
+	"aws-user-tags",
+	"azure-base-domain-resource-group-name",
+	"azure-resource-group-name",
+	"azure-network-resource-group-name",
+	"azure-virtual-network",
+	"azure-control-plane-subnet",
+	"azure-compute-subnet",
+	"openstack-cloud",
+	"openstack-external-network",
+	"openstack-master-flavor",
+	"openstack-compute-flavor",
+	"openstack-api-floating-ip",
+	"openstack-ingress-floating-ip",
+	"vsphere-vcenter",
+	"vsphere-datacenter",
+	"vsphere-default-datastore",
+	"vsphere-folder",
+	"vsphere-cluster",
+	"vsphere-api-vip",
+	"vsphere-ingress-vip",
+	"vsphere-network",
+	"ovirt-cluster-id",
+	"ovirt-storage-domain-id",
+	"ovirt-network-name",
+	"ovirt-api-vip",
+	"ovirt-ingress-vip",
+}
+
+// completeFromInstallConfig reads and validates the install-config file and derives the options of the
+// ClusterDeployment from it.
+func (o *Options) completeFromInstallConfig(cmd *cobra.Command) error {
+	for _, flag := range installConfigFlags {
+		if cmd.Flags().Changed(flag) {
+			return fmt.Errorf("--%s cannot be used with --install-config, set it in the install-config instead", flag)
+		}
+	}
+	data, err := ioutil.ReadFile(o.InstallConfigFile)
+	if err != nil {
+		return errors.Wrap(err, "could not read install-config")
+	}
+	ic, err := clusterresource.ParseInstallConfig(data)
+	if err != nil {
+		return err
+	}
+	if ic.ObjectMeta.Name != o.Name {
+		return fmt.Errorf("install-config name %q does not match cluster deployment name %q", ic.ObjectMeta.Name, o.Name)
+	}
+	o.installConfig = string(data)
+	o.Cloud = ic.Platform.Name()
+	o.BaseDomain = ic.BaseDomain
+	o.Internal = ic.Publish == installertypes.InternalPublishingStrategy
+	switch p := ic.Platform; o.Cloud {
+	case cloudAWS:
+		o.Region = p.AWS.Region
+		o.AWSUserTags = nil
+		for k, v := range p.AWS.UserTags {
+			o.AWSUserTags = append(o.AWSUserTags, k+"="+v)
+		}
+	case cloudAzure:
+		o.Region = p.Azure.Region
+		o.AzureBaseDomainResourceGroupName = p.Azure.BaseDomainResourceGroupName
+		o.AzureResourceGroupName = p.Azure.ResourceGroupName
+		o.AzureNetworkResourceGroupName = p.Azure.NetworkResourceGroupName
+		o.AzureVirtualNetwork = p.Azure.VirtualNetwork
+		o.AzureControlPlaneSubnet = p.Azure.ControlPlaneSubnet
+		o.AzureComputeSubnet = p.Azure.ComputeSubnet
+	case cloudGCP:
+		o.Region = p.GCP.Region
+	case cloudOpenStack:
+		o.OpenStackCloud = p.OpenStack.Cloud
+		o.OpenStackExternalNetwork = p.OpenStack.ExternalNetwork
+		o.OpenStackAPIFloatingIP = p.OpenStack.APIFloatingIP
+		o.OpenStackIngressFloatingIP = p.OpenStack.IngressFloatingIP
+	case cloudVSphere:
+		o.VSphereVCenter = p.VSphere.VCenter
+		o.VSphereDatacenter = p.VSphere.Datacenter
+		o.VSphereDefaultDataStore = p.VSphere.DefaultDatastore
+		o.VSphereFolder = p.VSphere.Folder
+		o.VSphereCluster = p.VSphere.Cluster
+		o.VSphereNetwork = p.VSphere.Network
+		o.VSphereAPIVIP = p.VSphere.APIVIP
+		o.VSphereIngressVIP = p.VSphere.IngressVIP
+	case cloudOVirt:
+		o.OvirtClusterID = p.Ovirt.ClusterID
+		o.OvirtStorageDomainID = p.Ovirt.StorageDomainID
+		o.OvirtNetworkName = p.Ovirt.NetworkName
+		o.OvirtAPIVIP = p.Ovirt.APIVIP
+		o.OvirtIngressVIP = p.Ovirt.IngressVIP
+	}
+	// The worker MachinePool generated by default would not match the compute pools of the install-config, and
+	// would replace the workers of the cluster once installed.
+	o.SkipMachinePools = true
+	o.log.WithField("cloud", o.Cloud).Info("using install-config, no worker MachinePool is generated")
+	return nil
+}
+
 // Validate ensures that option values make sense
 func (o *Options) Validate(cmd *cobra.Command) error {
 	if len(o.Output) > 0 && o.Output != "yaml" && o.Output != "json" {
@@ -397,6 +544,10 @@ func (o *Options) Validate(cmd *cobra.Command) error {
 		return fmt.Errorf("--aws-private-link can only be enabled for AWS cloud platform")
 	}
 
+	if o.GCPPrivateServiceConnect && o.Cloud != cloudGCP {
+		return fmt.Errorf("--gcp-private-service-connect can only be enabled for GCP cloud platform")
+	}
+
 	if o.Adopt {
 		if o.AdoptAdminKubeConfig == "" || o.AdoptInfraID == "" || o.AdoptClusterID == "" {
 			return fmt.Errorf("must specify the following options when using --adopt: --adopt-admin-kube-config, --adopt-infra-id, --adopt-cluster-id")
@@ -498,14 +649,18 @@ func (o *Options) GenerateObjects() ([]runtime.Object, error) {
 		return nil, err
 	}
 
-	sshPublicKey, err := o.getSSHPublicKey()
-	if err != nil {
-		return nil, err
-	}
+	// The SSH public key and additional trust bundle are only used to generate the install-config.
+	var sshPublicKey, additionalTrustBundle string
+	if o.installConfig == "" {
+		sshPublicKey, err = o.getSSHPublicKey()
+		if err != nil {
+			return nil, err
+		}
 
-	additionalTrustBundle, err := o.getAdditionalTrustBundle()
-	if err != nil {
-		return nil, err
+		additionalTrustBundle, err = o.getAdditionalTrustBundle()
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Load installer manifest files:
@@ -547,6 +702,7 @@ func (o *Options) GenerateObjects() ([]runtime.Object, error) {
 		SkipMachinePools:         o.SkipMachinePools,
 		AdditionalTrustBundle:    additionalTrustBundle,
 		CentralMachineManagement: o.CentralMachineManagement,
+		InstallConfig:            o.installConfig,
 	}
 	if o.Adopt {
 		kubeconfigBytes, err := ioutil.ReadFile(o.AdoptAdminKubeConfig)
@@ -605,6 +761,11 @@ func (o *Options) GenerateObjects() ([]runtime.Object, error) {
 			ServicePrincipal:            creds,
 			BaseDomainResourceGroupName: o.AzureBaseDomainResourceGroupName,
 			Region:                      o.Region,
+			ResourceGroupName:           o.AzureResourceGroupName,
+			NetworkResourceGroupName:    o.AzureNetworkResourceGroupName,
+			VirtualNetwork:              o.AzureVirtualNetwork,
+			ControlPlaneSubnet:          o.AzureControlPlaneSubnet,
+			ComputeSubnet:               o.AzureComputeSubnet,
 		}
 		builder.CloudBuilder = azureProvider
 	case cloudGCP:
@@ -618,9 +779,10 @@ func (o *Options) GenerateObjects() ([]runtime.Object, error) {
 		}
 
 		gcpProvider := &clusterresource.GCPCloudBuilder{
-			ProjectID:      projectID,
-			ServiceAccount: creds,
-			Region:         o.Region,
+			ProjectID:             projectID,
+			ServiceAccount:        creds,
+			Region:                o.Region,
+			PrivateServiceConnect: o.GCPPrivateServiceConnect,
 		}
 		builder.CloudBuilder = gcpProvider
 	case cloudOpenStack:
@@ -17,7 +17,7 @@ import (
 
 // NewDeprovisionAzureCommand is the entrypoint to create the azure deprovision subcommand
 func NewDeprovisionAzureCommand() *cobra.Command {
-	var logLevel string
+	var logLevel, resourceGroup string
 	cmd := &cobra.Command{
 		Use:   "azure INFRAID",
 		Short: "Deprovision Azure assets (as created by openshift-installer)",
@@ -26,7 +26,7 @@ func NewDeprovisionAzureCommand() *cobra.Command {
 			if err := validate(); err != nil {
 				log.WithError(err).Fatal("Failed validating Azure credentials")
 			}
-			uninstaller, err := completeAzureUninstaller(logLevel, args)
+			uninstaller, err := completeAzureUninstaller(logLevel, resourceGroup, args)
 			if err != nil {
 				log.WithError(err).Error("Cannot complete command")
 				return
@@ -38,6 +38,7 @@ func NewDeprovisionAzureCommand() *cobra.Command {
 	}
 	flags := cmd.Flags()
 	flags.StringVar(&logLevel, "loglevel", "info", "log level, one of: debug, info, warn, error, fatal, panic")
+	flags.StringVar(&resourceGroup, "resource-group", "", "existing resource group the cluster was installed into. Only the resources owned by the cluster are deleted from it")
 	return cmd
 }
 
@@ -50,7 +51,7 @@ func validate() error {
 	return nil
 }
 
-func completeAzureUninstaller(logLevel string, args []string) (providers.Destroyer, error) {
+func completeAzureUninstaller(logLevel, resourceGroup string, args []string) (providers.Destroyer, error) {
 
 	// Set log level
 	level, err := log.ParseLevel(logLevel)
@@ -68,6 +69,15 @@ func completeAzureUninstaller(logLevel string, args []string) (providers.Destroy
 		Level: level,
 	})
 
+	if resourceGroup != "" {
+		return &azureutils.ExistingResourceGroupUninstaller{
+			InfraID:           args[0],
+			ResourceGroupName: resourceGroup,
+			CloudName:         installertypesazure.PublicCloud,
+			Logger:            logger,
+		}, nil
+	}
+
 	metadata := &types.ClusterMetadata{
 		InfraID: args[0],
 		ClusterPlatformMetadata: types.ClusterPlatformMetadata{
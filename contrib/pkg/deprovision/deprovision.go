@@ -36,6 +36,8 @@ func NewDeprovisionCommand() *cobra.Command {
 	cmd.AddCommand(NewDeprovisionOpenStackCommand())
 	cmd.AddCommand(NewDeprovisionvSphereCommand())
 	cmd.AddCommand(NewDeprovisionOvirtCommand())
+	cmd.AddCommand(NewDeprovisionNutanixCommand())
+	cmd.AddCommand(NewDeprovisionPowerVSCommand())
 	return cmd
 }
 
@@ -22,6 +22,8 @@ type gcpOptions struct {
 	infraID   string
 	region    string
 	projectID string
+	// networkProjectID is the host project of the Shared VPC of the cluster
+	networkProjectID string
 }
 
 // NewDeprovisionGCPCommand is the entrypoint to create the GCP deprovision subcommand
@@ -46,6 +48,7 @@ func NewDeprovisionGCPCommand() *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&opt.logLevel, "loglevel", "info", "log level, one of: debug, info, warn, error, fatal, panic")
 	flags.StringVar(&opt.region, "region", "", "GCP region where the cluster is installed")
+	flags.StringVar(&opt.networkProjectID, "network-project-id", "", "host project of the Shared VPC the cluster is installed into")
 	return cmd
 }
 
@@ -107,6 +110,18 @@ func (o *gcpOptions) Run() error {
 	if err != nil {
 		return err
 	}
+	if err := destroyer.Run(); err != nil {
+		return err
+	}
 
-	return destroyer.Run()
+	if o.networkProjectID == "" || o.networkProjectID == o.projectID {
+		return nil
+	}
+	// The installer uninstaller only deletes resources in the project of the cluster.
+	uninstaller := &gcputils.SharedVPCUninstaller{
+		InfraID:          o.infraID,
+		NetworkProjectID: o.networkProjectID,
+		Logger:           logger,
+	}
+	return uninstaller.Run()
 }
@@ -0,0 +1,104 @@
+package deprovision
+
+import (
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	hivev1nutanix "github.com/openshift/hive/apis/hive/v1/nutanix"
+	nutanixutils "github.com/openshift/hive/contrib/pkg/utils/nutanix"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/nutanixclient"
+)
+
+// nutanixOptions is the set of options to deprovision a Nutanix cluster
+type nutanixOptions struct {
+	logLevel     string
+	infraID      string
+	prismCentral hivev1nutanix.PrismEndpoint
+	username     string
+	password     string
+}
+
+// NewDeprovisionNutanixCommand is the entrypoint to create the Nutanix deprovision subcommand
+func NewDeprovisionNutanixCommand() *cobra.Command {
+	opt := &nutanixOptions{}
+	cmd := &cobra.Command{
+		Use:   "nutanix INFRAID --prism-central=ADDRESS",
+		Short: "Deprovision Nutanix assets (as created by openshift-installer)",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opt.Complete(cmd, args); err != nil {
+				log.WithError(err).Fatal("failed to complete options")
+			}
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Fatal("validation failed")
+			}
+			if err := opt.Run(); err != nil {
+				log.WithError(err).Fatal("Runtime error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&opt.logLevel, "loglevel", "info", "log level, one of: debug, info, warn, error, fatal, panic")
+	flags.StringVar(&opt.prismCentral.Address, "prism-central", "", "Domain name or IP address of Prism Central")
+	flags.Int32Var(&opt.prismCentral.Port, "prism-central-port", nutanixclient.DefaultPrismPort, "Port of Prism Central")
+	return cmd
+}
+
+// Complete finishes parsing arguments for the command
+func (o *nutanixOptions) Complete(cmd *cobra.Command, args []string) error {
+	o.infraID = args[0]
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *nutanixOptions) Validate(cmd *cobra.Command) error {
+	if o.prismCentral.Address == "" {
+		cmd.Usage()
+		return fmt.Errorf("must provide --prism-central")
+	}
+	o.username = os.Getenv(constants.NutanixUsernameEnvVar)
+	if o.username == "" {
+		return fmt.Errorf("No %s env var set, cannot proceed", constants.NutanixUsernameEnvVar)
+	}
+	o.password = os.Getenv(constants.NutanixPasswordEnvVar)
+	if o.password == "" {
+		return fmt.Errorf("No %s env var set, cannot proceed", constants.NutanixPasswordEnvVar)
+	}
+	return nil
+}
+
+// Run executes the command
+func (o *nutanixOptions) Run() error {
+	// Set log level
+	level, err := log.ParseLevel(o.logLevel)
+	if err != nil {
+		log.WithError(err).Error("cannot parse log level")
+		return err
+	}
+
+	logger := log.NewEntry(&log.Logger{
+		Out: os.Stdout,
+		Formatter: &log.TextFormatter{
+			FullTimestamp: true,
+		},
+		Hooks: make(log.LevelHooks),
+		Level: level,
+	})
+
+	// The CA certificates of Prism Central, if any, are added to the system trust by the deprovision job.
+	client, err := nutanixclient.NewClient(o.prismCentral, o.username, o.password, nil)
+	if err != nil {
+		return err
+	}
+
+	uninstaller := &nutanixutils.ClusterUninstaller{
+		InfraID: o.infraID,
+		Client:  client,
+		Logger:  logger,
+	}
+	return uninstaller.Run()
+}
@@ -0,0 +1,97 @@
+package deprovision
+
+import (
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	powervsutils "github.com/openshift/hive/contrib/pkg/utils/powervs"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/ibmclient"
+)
+
+// powerVSOptions is the set of options to deprovision an IBM Power Virtual Server cluster
+type powerVSOptions struct {
+	logLevel          string
+	infraID           string
+	region            string
+	serviceInstanceID string
+	apiKey            string
+}
+
+// NewDeprovisionPowerVSCommand is the entrypoint to create the IBM Power Virtual Server deprovision subcommand
+func NewDeprovisionPowerVSCommand() *cobra.Command {
+	opt := &powerVSOptions{}
+	cmd := &cobra.Command{
+		Use:   "powervs INFRAID --region=REGION --service-instance-id=GUID",
+		Short: "Deprovision IBM Power Virtual Server assets (as created by openshift-installer)",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opt.Complete(cmd, args); err != nil {
+				log.WithError(err).Fatal("failed to complete options")
+			}
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Fatal("validation failed")
+			}
+			if err := opt.Run(); err != nil {
+				log.WithError(err).Fatal("Runtime error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&opt.logLevel, "loglevel", "info", "log level, one of: debug, info, warn, error, fatal, panic")
+	flags.StringVar(&opt.region, "region", "", "IBM Cloud region of the cluster")
+	flags.StringVar(&opt.serviceInstanceID, "service-instance-id", "", "GUID of the Power Virtual Server workspace of the cluster")
+	return cmd
+}
+
+// Complete finishes parsing arguments for the command
+func (o *powerVSOptions) Complete(cmd *cobra.Command, args []string) error {
+	o.infraID = args[0]
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *powerVSOptions) Validate(cmd *cobra.Command) error {
+	if o.region == "" {
+		cmd.Usage()
+		return fmt.Errorf("must provide --region")
+	}
+	if o.serviceInstanceID == "" {
+		cmd.Usage()
+		return fmt.Errorf("must provide --service-instance-id")
+	}
+	o.apiKey = os.Getenv(constants.PowerVSAPIKeyEnvVar)
+	if o.apiKey == "" {
+		return fmt.Errorf("No %s env var set, cannot proceed", constants.PowerVSAPIKeyEnvVar)
+	}
+	return nil
+}
+
+// Run executes the command
+func (o *powerVSOptions) Run() error {
+	// Set log level
+	level, err := log.ParseLevel(o.logLevel)
+	if err != nil {
+		log.WithError(err).Error("cannot parse log level")
+		return err
+	}
+
+	logger := log.NewEntry(&log.Logger{
+		Out: os.Stdout,
+		Formatter: &log.TextFormatter{
+			FullTimestamp: true,
+		},
+		Hooks: make(log.LevelHooks),
+		Level: level,
+	})
+
+	uninstaller := &powervsutils.ClusterUninstaller{
+		InfraID: o.infraID,
+		Client:  ibmclient.NewPowerVSClient(o.apiKey, o.region, o.serviceInstanceID),
+		Logger:  logger,
+	}
+	return uninstaller.Run()
+}
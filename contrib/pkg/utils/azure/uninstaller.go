@@ -0,0 +1,188 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	azuresession "github.com/openshift/installer/pkg/asset/installconfig/azure"
+	installertypesazure "github.com/openshift/installer/pkg/types/azure"
+)
+
+const (
+	// ownedTagValue is the value of the kubernetes.io_cluster.<infraID> tag on the resources the installer creates for
+	// the cluster.
+	ownedTagValue = "owned"
+
+	uninstallTimeout = 2 * time.Hour
+)
+
+// ExistingResourceGroupUninstaller deletes a cluster installed into an existing resource group. Unlike the installer
+// uninstaller, which deletes the whole resource group of the cluster, it only deletes the resources in the resource
+// group that are tagged as owned by the cluster, keeping the resource group and any other resources in it.
+type ExistingResourceGroupUninstaller struct {
+	InfraID           string
+	ResourceGroupName string
+	CloudName         installertypesazure.CloudEnvironment
+	Logger            log.FieldLogger
+
+	resourcesClient resources.Client
+	providersClient resources.ProvidersClient
+	// apiVersions caches the API version to use for each resource type
+	apiVersions map[string]string
+}
+
+// Run deletes the resources owned by the cluster, retrying until none are left as resources may only be deleted once
+// the resources depending on them are gone.
+func (o *ExistingResourceGroupUninstaller) Run() error {
+	cloudName := o.CloudName
+	if cloudName == "" {
+		cloudName = installertypesazure.PublicCloud
+	}
+	session, err := azuresession.GetSession(cloudName)
+	if err != nil {
+		return errors.Wrap(err, "could not get Azure session")
+	}
+	o.resourcesClient = resources.NewClientWithBaseURI(session.Environment.ResourceManagerEndpoint, session.Credentials.SubscriptionID)
+	o.resourcesClient.Authorizer = session.Authorizer
+	o.providersClient = resources.NewProvidersClientWithBaseURI(session.Environment.ResourceManagerEndpoint, session.Credentials.SubscriptionID)
+	o.providersClient.Authorizer = session.Authorizer
+	o.apiVersions = map[string]string{}
+
+	logger := o.Logger.WithField("resourceGroup", o.ResourceGroupName)
+	ctx, cancel := context.WithTimeout(context.Background(), uninstallTimeout)
+	defer cancel()
+	return wait.PollImmediateUntil(10*time.Second, func() (bool, error) {
+		remaining, err := o.deleteOwnedResources(ctx, logger)
+		if err != nil {
+			logger.WithError(err).Warn("failed to delete owned resources, will retry")
+			return false, nil
+		}
+		if remaining > 0 {
+			logger.WithField("remaining", remaining).Info("owned resources remain, will retry")
+			return false, nil
+		}
+		logger.Info("all owned resources deleted")
+		return true, nil
+	}, ctx.Done())
+}
+
+// deleteOwnedResources tries to delete every resource owned by the cluster and returns the number of resources which
+// could not be deleted.
+func (o *ExistingResourceGroupUninstaller) deleteOwnedResources(ctx context.Context, logger log.FieldLogger) (int, error) {
+	filter := fmt.Sprintf("tagName eq '%s' and tagValue eq '%s'", ownedTagName(o.InfraID), ownedTagValue)
+	owned := []resources.GenericResourceExpanded{}
+	page, err := o.resourcesClient.ListByResourceGroup(ctx, o.ResourceGroupName, filter, "", nil)
+	for ; err == nil && page.NotDone(); err = page.NextWithContext(ctx) {
+		owned = append(owned, page.Values()...)
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "could not list resources")
+	}
+	sortForDeletion(owned)
+
+	remaining := 0
+	for _, resource := range owned {
+		resourceLogger := logger.WithField("resource", to.String(resource.ID))
+		if err := o.deleteResource(ctx, resource); err != nil {
+			resourceLogger.WithError(err).Debug("could not delete resource")
+			remaining++
+			continue
+		}
+		resourceLogger.Info("deleted resource")
+	}
+	return remaining, nil
+}
+
+func (o *ExistingResourceGroupUninstaller) deleteResource(ctx context.Context, resource resources.GenericResourceExpanded) error {
+	apiVersion, err := o.apiVersion(ctx, to.String(resource.Type))
+	if err != nil {
+		return err
+	}
+	future, err := o.resourcesClient.DeleteByID(ctx, to.String(resource.ID), apiVersion)
+	if err != nil {
+		return err
+	}
+	return future.WaitForCompletionRef(ctx, o.resourcesClient.Client)
+}
+
+// apiVersion returns the newest stable API version of the resource provider for the resource type.
+func (o *ExistingResourceGroupUninstaller) apiVersion(ctx context.Context, resourceType string) (string, error) {
+	if version, ok := o.apiVersions[resourceType]; ok {
+		return version, nil
+	}
+	parts := strings.SplitN(resourceType, "/", 2)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("unexpected resource type %q", resourceType)
+	}
+	provider, err := o.providersClient.Get(ctx, parts[0], "")
+	if err != nil {
+		return "", errors.Wrapf(err, "could not get resource provider %s", parts[0])
+	}
+	if provider.ResourceTypes != nil {
+		for _, rt := range *provider.ResourceTypes {
+			if !strings.EqualFold(to.String(rt.ResourceType), parts[1]) || rt.APIVersions == nil {
+				continue
+			}
+			if version := newestStableAPIVersion(*rt.APIVersions); version != "" {
+				o.apiVersions[resourceType] = version
+				return version, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no API version found for resource type %s", resourceType)
+}
+
+// ownedTagName returns the name of the tag marking the Azure resources owned by the cluster with the infra ID.
+func ownedTagName(infraID string) string {
+	return fmt.Sprintf("kubernetes.io_cluster.%s", infraID)
+}
+
+// newestStableAPIVersion returns the newest API version which is not a preview version. API versions are dates, so
+// they sort lexically.
+func newestStableAPIVersion(versions []string) string {
+	newest := ""
+	for _, version := range versions {
+		if strings.Contains(version, "preview") {
+			continue
+		}
+		if version > newest {
+			newest = version
+		}
+	}
+	return newest
+}
+
+// deletionOrder lists the resource types which must be deleted before the resources they depend on. Other resource
+// types are deleted after them.
+var deletionOrder = []string{
+	"Microsoft.Compute/virtualMachines",
+	"Microsoft.Network/networkInterfaces",
+	"Microsoft.Network/loadBalancers",
+	"Microsoft.Network/privateDnsZones/virtualNetworkLinks",
+}
+
+// sortForDeletion sorts the resources so that dependent resources are deleted first, reducing the number of retries
+// needed.
+func sortForDeletion(owned []resources.GenericResourceExpanded) {
+	rank := func(resourceType string) int {
+		for i, t := range deletionOrder {
+			if strings.EqualFold(t, resourceType) {
+				return i
+			}
+		}
+		return len(deletionOrder)
+	}
+	sort.SliceStable(owned, func(i, j int) bool {
+		return rank(to.String(owned[i].Type)) < rank(to.String(owned[j].Type))
+	})
+}
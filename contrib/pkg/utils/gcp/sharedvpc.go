@@ -0,0 +1,146 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/oauth2/google"
+	compute "google.golang.org/api/compute/v1"
+	dns "google.golang.org/api/dns/v1"
+	"google.golang.org/api/option"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const sharedVPCUninstallTimeout = time.Hour
+
+// SharedVPCUninstaller deletes the resources of a cluster installed into a Shared VPC (XPN) that are created in the
+// host project of the Shared VPC: the firewall rules of the cluster network, and the private DNS zone of the cluster
+// when it is created in the host project. The installer uninstaller only deletes resources in the project of the
+// cluster, so it is run in addition to it.
+type SharedVPCUninstaller struct {
+	InfraID          string
+	NetworkProjectID string
+	Logger           log.FieldLogger
+
+	computeSvc *compute.Service
+	dnsSvc     *dns.Service
+}
+
+// Run deletes the resources of the cluster in the host project, retrying until none are left.
+func (o *SharedVPCUninstaller) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), sharedVPCUninstallTimeout)
+	defer cancel()
+
+	authJSON, err := GetCreds("")
+	if err != nil {
+		return errors.Wrap(err, "failed to get GCP credentials")
+	}
+	creds, err := google.CredentialsFromJSON(ctx, authJSON, compute.CloudPlatformScope)
+	if err != nil {
+		return errors.Wrap(err, "could not parse GCP credentials")
+	}
+	if o.computeSvc, err = compute.NewService(ctx, option.WithCredentials(creds)); err != nil {
+		return errors.Wrap(err, "could not create compute service")
+	}
+	if o.dnsSvc, err = dns.NewService(ctx, option.WithCredentials(creds)); err != nil {
+		return errors.Wrap(err, "could not create dns service")
+	}
+
+	logger := o.Logger.WithField("networkProject", o.NetworkProjectID)
+	return wait.PollImmediateUntil(10*time.Second, func() (bool, error) {
+		remaining, err := o.deleteResources(ctx, logger)
+		if err != nil {
+			logger.WithError(err).Warn("failed to delete cluster resources in the host project, will retry")
+			return false, nil
+		}
+		if remaining > 0 {
+			logger.WithField("remaining", remaining).Info("cluster resources remain in the host project, will retry")
+			return false, nil
+		}
+		logger.Info("all cluster resources deleted from the host project")
+		return true, nil
+	}, ctx.Done())
+}
+
+// deleteResources tries to delete every resource of the cluster in the host project and returns the number of
+// resources which could not be deleted.
+func (o *SharedVPCUninstaller) deleteResources(ctx context.Context, logger log.FieldLogger) (int, error) {
+	remaining := 0
+
+	var firewalls []string
+	err := o.computeSvc.Firewalls.List(o.NetworkProjectID).
+		Filter(fmt.Sprintf("name eq \"%s-.*\"", o.InfraID)).
+		Fields("items(name),nextPageToken").
+		Pages(ctx, func(list *compute.FirewallList) error {
+			for _, firewall := range list.Items {
+				firewalls = append(firewalls, firewall.Name)
+			}
+			return nil
+		})
+	if err != nil {
+		return 0, errors.Wrap(err, "could not list firewall rules")
+	}
+	for _, name := range firewalls {
+		firewallLogger := logger.WithField("firewall", name)
+		if _, err := o.computeSvc.Firewalls.Delete(o.NetworkProjectID, name).Context(ctx).Do(); err != nil {
+			firewallLogger.WithError(err).Debug("could not delete firewall rule")
+			remaining++
+			continue
+		}
+		firewallLogger.Info("deleted firewall rule")
+	}
+
+	var zones []*dns.ManagedZone
+	err = o.dnsSvc.ManagedZones.List(o.NetworkProjectID).
+		Fields("managedZones(name,dnsName,visibility),nextPageToken").
+		Pages(ctx, func(list *dns.ManagedZonesListResponse) error {
+			for _, zone := range list.ManagedZones {
+				if zone.Visibility == "private" && strings.HasPrefix(zone.Name, o.InfraID+"-") {
+					zones = append(zones, zone)
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return 0, errors.Wrap(err, "could not list DNS zones")
+	}
+	for _, zone := range zones {
+		zoneLogger := logger.WithField("zone", zone.Name)
+		if err := o.deleteDNSZone(ctx, zone); err != nil {
+			zoneLogger.WithError(err).Debug("could not delete DNS zone")
+			remaining++
+			continue
+		}
+		zoneLogger.Info("deleted DNS zone")
+	}
+	return remaining, nil
+}
+
+// deleteDNSZone deletes the record sets of the zone, which must be deleted before the zone, and then the zone.
+func (o *SharedVPCUninstaller) deleteDNSZone(ctx context.Context, zone *dns.ManagedZone) error {
+	change := &dns.Change{}
+	err := o.dnsSvc.ResourceRecordSets.List(o.NetworkProjectID, zone.Name).Pages(ctx, func(list *dns.ResourceRecordSetsListResponse) error {
+		for _, rrs := range list.Rrsets {
+			// The NS and SOA records of the zone itself are deleted with the zone.
+			if (rrs.Type == "NS" || rrs.Type == "SOA") && rrs.Name == zone.DnsName {
+				continue
+			}
+			change.Deletions = append(change.Deletions, rrs)
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not list record sets")
+	}
+	if len(change.Deletions) > 0 {
+		if _, err := o.dnsSvc.Changes.Create(o.NetworkProjectID, zone.Name, change).Context(ctx).Do(); err != nil {
+			return errors.Wrap(err, "could not delete record sets")
+		}
+	}
+	return o.dnsSvc.ManagedZones.Delete(o.NetworkProjectID, zone.Name).Context(ctx).Do()
+}
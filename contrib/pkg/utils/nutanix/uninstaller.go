@@ -0,0 +1,121 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openshift/hive/pkg/nutanixclient"
+)
+
+const (
+	// ownedCategoryValue is the value of the kubernetes-io-cluster-<infraID> category the installer assigns to the
+	// virtual machines and images it creates for the cluster.
+	ownedCategoryValue = "owned"
+
+	uninstallTimeout = 2 * time.Hour
+)
+
+// pollInterval is the interval between attempts to delete the resources of the cluster.
+var pollInterval = 10 * time.Second
+
+// ClusterUninstaller deletes the virtual machines and images of a cluster installed on Nutanix, and the category
+// marking them as owned by the cluster. The vendored installer has no Nutanix destroyer, so this mirrors what the
+// installer creates.
+type ClusterUninstaller struct {
+	InfraID string
+	Client  nutanixclient.Client
+	Logger  log.FieldLogger
+}
+
+// Run deletes the resources owned by the cluster, retrying until none are left. Virtual machines are deleted
+// asynchronously, so the images and the category are only deleted once the virtual machines are gone.
+func (o *ClusterUninstaller) Run() error {
+	logger := o.Logger.WithField("infraID", o.InfraID)
+	ctx, cancel := context.WithTimeout(context.Background(), uninstallTimeout)
+	defer cancel()
+	return wait.PollImmediateUntil(pollInterval, func() (bool, error) {
+		remaining, err := o.deleteOwnedResources(ctx, logger)
+		if err != nil {
+			logger.WithError(err).Warn("failed to delete owned resources, will retry")
+			return false, nil
+		}
+		if remaining > 0 {
+			logger.WithField("remaining", remaining).Info("owned resources remain, will retry")
+			return false, nil
+		}
+		logger.Info("all owned resources deleted")
+		return true, nil
+	}, ctx.Done())
+}
+
+// deleteOwnedResources deletes the resources owned by the cluster and returns the number of resources which were
+// still present.
+func (o *ClusterUninstaller) deleteOwnedResources(ctx context.Context, logger log.FieldLogger) (int, error) {
+	vms, err := o.Client.ListVMs(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not list virtual machines")
+	}
+	remaining := 0
+	for _, vm := range vms {
+		if !o.isOwned(vm) {
+			continue
+		}
+		remaining++
+		vmLogger := logger.WithField("vm", vm.Name)
+		if err := o.Client.DeleteVM(ctx, vm.UUID); err != nil && !nutanixclient.IsNotFound(err) {
+			vmLogger.WithError(err).Debug("could not delete virtual machine")
+			continue
+		}
+		vmLogger.Info("deleting virtual machine")
+	}
+	if remaining > 0 {
+		return remaining, nil
+	}
+
+	images, err := o.Client.ListImages(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not list images")
+	}
+	for _, image := range images {
+		if !o.isOwned(image) && !strings.HasPrefix(image.Name, o.InfraID+"-") {
+			continue
+		}
+		remaining++
+		imageLogger := logger.WithField("image", image.Name)
+		if err := o.Client.DeleteImage(ctx, image.UUID); err != nil && !nutanixclient.IsNotFound(err) {
+			imageLogger.WithError(err).Debug("could not delete image")
+			continue
+		}
+		imageLogger.Info("deleting image")
+	}
+	if remaining > 0 {
+		return remaining, nil
+	}
+
+	category := ownedCategoryName(o.InfraID)
+	if err := o.Client.DeleteCategoryValue(ctx, category, ownedCategoryValue); err != nil && !nutanixclient.IsNotFound(err) {
+		return 0, errors.Wrapf(err, "could not delete category value %s:%s", category, ownedCategoryValue)
+	}
+	if err := o.Client.DeleteCategory(ctx, category); err != nil && !nutanixclient.IsNotFound(err) {
+		return 0, errors.Wrapf(err, "could not delete category %s", category)
+	}
+	logger.WithField("category", category).Info("deleted category")
+	return 0, nil
+}
+
+func (o *ClusterUninstaller) isOwned(e nutanixclient.Entity) bool {
+	return e.Categories[ownedCategoryName(o.InfraID)] == ownedCategoryValue
+}
+
+// ownedCategoryName returns the name of the category marking the Nutanix resources owned by the cluster with the
+// infra ID.
+func ownedCategoryName(infraID string) string {
+	return fmt.Sprintf("kubernetes-io-cluster-%s", infraID)
+}
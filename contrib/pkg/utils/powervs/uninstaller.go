@@ -0,0 +1,113 @@
+package powervs
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openshift/hive/pkg/ibmclient"
+)
+
+const uninstallTimeout = 2 * time.Hour
+
+// pollInterval is the interval between attempts to delete the resources of the cluster.
+var pollInterval = 10 * time.Second
+
+// ClusterUninstaller deletes the instances, images and networks of a cluster installed in an IBM Power Virtual Server
+// workspace, and the Cloud Object Storage instance the installer creates for it. The vendored installer has no
+// PowerVS destroyer, so this mirrors what the installer creates, which is named after the infra ID.
+type ClusterUninstaller struct {
+	InfraID string
+	Client  ibmclient.PowerVSClient
+	Logger  log.FieldLogger
+}
+
+// Run deletes the resources of the cluster, retrying until none are left. Instances are deleted asynchronously, so
+// the images and networks, which cannot be deleted while in use, are only deleted once the instances are gone.
+func (o *ClusterUninstaller) Run() error {
+	logger := o.Logger.WithField("infraID", o.InfraID)
+	ctx, cancel := context.WithTimeout(context.Background(), uninstallTimeout)
+	defer cancel()
+	return wait.PollImmediateUntil(pollInterval, func() (bool, error) {
+		remaining, err := o.deleteClusterResources(ctx, logger)
+		if err != nil {
+			logger.WithError(err).Warn("failed to delete cluster resources, will retry")
+			return false, nil
+		}
+		if remaining > 0 {
+			logger.WithField("remaining", remaining).Info("cluster resources remain, will retry")
+			return false, nil
+		}
+		logger.Info("all cluster resources deleted")
+		return true, nil
+	}, ctx.Done())
+}
+
+// deleteClusterResources deletes the resources of the cluster and returns the number of resources which were still
+// present.
+func (o *ClusterUninstaller) deleteClusterResources(ctx context.Context, logger log.FieldLogger) (int, error) {
+	instances, err := o.Client.ListInstances(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not list instances")
+	}
+	remaining := o.deleteResources(ctx, logger.WithField("kind", "instance"), instances, o.Client.DeleteInstance)
+	if remaining > 0 {
+		return remaining, nil
+	}
+
+	images, err := o.Client.ListImages(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not list images")
+	}
+	remaining += o.deleteResources(ctx, logger.WithField("kind", "image"), images, o.Client.DeleteImage)
+	networks, err := o.Client.ListNetworks(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not list networks")
+	}
+	remaining += o.deleteResources(ctx, logger.WithField("kind", "network"), networks, o.Client.DeleteNetwork)
+	if remaining > 0 {
+		return remaining, nil
+	}
+
+	cosInstances, err := o.Client.ListResourceInstances(ctx, o.InfraID+"-cos")
+	if err != nil {
+		return 0, errors.Wrap(err, "could not list Cloud Object Storage instances")
+	}
+	for _, instance := range cosInstances {
+		if instance.State == "removed" || instance.State == "pending_reclamation" {
+			continue
+		}
+		remaining++
+		instanceLogger := logger.WithField("cos", instance.Name)
+		if err := o.Client.DeleteResourceInstance(ctx, instance.GUID); err != nil && !ibmclient.IsNotFound(err) {
+			instanceLogger.WithError(err).Debug("could not delete Cloud Object Storage instance")
+			continue
+		}
+		instanceLogger.Info("deleting Cloud Object Storage instance")
+	}
+	return remaining, nil
+}
+
+// deleteResources deletes the resources of the cluster among those listed and returns the number of resources of the
+// cluster.
+func (o *ClusterUninstaller) deleteResources(ctx context.Context, logger log.FieldLogger, resources []ibmclient.PowerVSResource, deleteFn func(context.Context, string) error) int {
+	remaining := 0
+	for _, r := range resources {
+		if !strings.Contains(r.Name, o.InfraID) {
+			continue
+		}
+		remaining++
+		resourceLogger := logger.WithField("name", r.Name)
+		if err := deleteFn(ctx, r.ID); err != nil && !ibmclient.IsNotFound(err) {
+			resourceLogger.WithError(err).Debug("could not delete resource")
+			continue
+		}
+		resourceLogger.Info("deleting resource")
+	}
+	return remaining
+}
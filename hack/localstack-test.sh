@@ -0,0 +1,47 @@
+#!/bin/bash
+
+# Runs the integration tests of the AWS code of Hive against LocalStack. When LOCALSTACK_ENDPOINT is not set, a
+# LocalStack container is started with podman or docker for the duration of the tests.
+
+set -e
+
+LOCALSTACK_IMAGE="${LOCALSTACK_IMAGE:-docker.io/localstack/localstack:latest}"
+LOCALSTACK_PORT="${LOCALSTACK_PORT:-4566}"
+LOCALSTACK_PACKAGES="${LOCALSTACK_PACKAGES:-./pkg/awsclient/... ./pkg/controller/dnszone/... ./pkg/controller/hibernation/...}"
+
+if [ -z "${LOCALSTACK_ENDPOINT}" ]; then
+  if which podman > /dev/null 2>&1; then
+    container_runtime=podman
+  elif which docker > /dev/null 2>&1; then
+    container_runtime=docker
+  else
+    echo "LOCALSTACK_ENDPOINT is not set and neither podman nor docker is available" >&2
+    exit 1
+  fi
+
+  container_name="hive-localstack-$$"
+  echo "Starting LocalStack container ${container_name}"
+  ${container_runtime} run -d --rm --name "${container_name}" \
+    -p "${LOCALSTACK_PORT}:4566" \
+    -e SERVICES=ec2,route53,sts,iam,s3,resourcegroupstaggingapi \
+    "${LOCALSTACK_IMAGE}" > /dev/null
+  trap '${container_runtime} stop "${container_name}" > /dev/null' EXIT
+
+  export LOCALSTACK_ENDPOINT="http://localhost:${LOCALSTACK_PORT}"
+  echo "Waiting for LocalStack to be ready"
+  for i in $(seq 1 60); do
+    if curl -sf "${LOCALSTACK_ENDPOINT}/_localstack/health" > /dev/null 2>&1 || \
+       curl -sf "${LOCALSTACK_ENDPOINT}/health" > /dev/null 2>&1; then
+      break
+    fi
+    if [ "$i" -eq 60 ]; then
+      echo "Timed out waiting for LocalStack" >&2
+      exit 1
+    fi
+    sleep 2
+  done
+fi
+
+echo "Running LocalStack tests against ${LOCALSTACK_ENDPOINT}"
+# shellcheck disable=SC2086
+go test ${GO_MOD_FLAGS} -tags localstack -count=1 -v -run LocalStack ${LOCALSTACK_PACKAGES}
@@ -12,13 +12,14 @@ GOFLAGS="" bash ${CODEGEN_PKG}/generate-groups.sh "all" \
   --go-header-file ${SCRIPT_ROOT}/hack/boilerplate.go.txt \
   ${verify}
 
-# Generate deepcopy for platform-specific types.
+# Generate deepcopy for platform-specific types and the v2 API, which has no generated clients.
 GOFLAGS="" bash ${CODEGEN_PKG}/generate-groups.sh "deepcopy" \
   github.com/openshift/hive/pkg/client \
   github.com/openshift/hive/apis \
-  "hive:v1/agent hive:v1/aws hive:v1/azure hive:v1/baremetal hive:v1/gcp hive:v1/openstack hive:v1/ovirt hive:v1/vsphere hivecontracts:v1alpha1" \
+  "hive:v2 hive:v1/agent hive:v1/aws hive:v1/azure hive:v1/baremetal hive:v1/gcp hive:v1/nutanix hive:v1/openstack hive:v1/ovirt hive:v1/powervs hive:v1/vsphere hivecontracts:v1alpha1" \
   --go-header-file ${SCRIPT_ROOT}/hack/boilerplate.go.txt \
   ${verify}
 
-# deepcopy generators place the generated files in vendor directory, so move them back
+# generators place the generated files of submodules in vendor directory, so move them back
 (cd ./vendor/github.com/openshift/hive/apis/; find . -name 'zz_generated.deepcopy.go' -exec cp --parents {} ../../../../../apis/ \;)
+(cd ./vendor/github.com/openshift/hive/pkg/client/; find . -name '*.go' -exec cp --parents {} ../../../../../../pkg/client/ \;)
@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"net/url"
 	"os"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -17,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/request"
@@ -27,6 +30,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/route53/route53iface"
@@ -36,9 +41,11 @@ import (
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 var (
@@ -60,230 +67,510 @@ func init() {
 // Client is a wrapper object for actual AWS SDK clients to allow for easier testing.
 type Client interface {
 	// EC2
-	DescribeAvailabilityZones(*ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error)
-	DescribeSubnets(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
-	DescribeRouteTables(*ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error)
-	DescribeInstances(*ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error)
-	StopInstances(*ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error)
-	StartInstances(*ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error)
-	CreateVpcEndpointServiceConfiguration(*ec2.CreateVpcEndpointServiceConfigurationInput) (*ec2.CreateVpcEndpointServiceConfigurationOutput, error)
-	DescribeVpcEndpointServiceConfigurations(*ec2.DescribeVpcEndpointServiceConfigurationsInput) (*ec2.DescribeVpcEndpointServiceConfigurationsOutput, error)
-	ModifyVpcEndpointServiceConfiguration(*ec2.ModifyVpcEndpointServiceConfigurationInput) (*ec2.ModifyVpcEndpointServiceConfigurationOutput, error)
-	DeleteVpcEndpointServiceConfigurations(*ec2.DeleteVpcEndpointServiceConfigurationsInput) (*ec2.DeleteVpcEndpointServiceConfigurationsOutput, error)
-	DescribeVpcEndpointServicePermissions(*ec2.DescribeVpcEndpointServicePermissionsInput) (*ec2.DescribeVpcEndpointServicePermissionsOutput, error)
-	ModifyVpcEndpointServicePermissions(*ec2.ModifyVpcEndpointServicePermissionsInput) (*ec2.ModifyVpcEndpointServicePermissionsOutput, error)
-	DescribeVpcEndpointServices(*ec2.DescribeVpcEndpointServicesInput) (*ec2.DescribeVpcEndpointServicesOutput, error)
-	DescribeVpcEndpoints(*ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error)
-	CreateVpcEndpoint(*ec2.CreateVpcEndpointInput) (*ec2.CreateVpcEndpointOutput, error)
-	DeleteVpcEndpoints(*ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error)
+	DescribeAvailabilityZones(context.Context, *ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error)
+	DescribeSubnets(context.Context, *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
+	DescribeInstanceTypeOfferings(context.Context, *ec2.DescribeInstanceTypeOfferingsInput) (*ec2.DescribeInstanceTypeOfferingsOutput, error)
+	DescribeRouteTables(context.Context, *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error)
+	DescribeInstances(context.Context, *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error)
+	CreateTags(context.Context, *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error)
+	DeleteTags(context.Context, *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error)
+	StopInstances(context.Context, *ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error)
+	StartInstances(context.Context, *ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error)
+	CreateVpcEndpointServiceConfiguration(context.Context, *ec2.CreateVpcEndpointServiceConfigurationInput) (*ec2.CreateVpcEndpointServiceConfigurationOutput, error)
+	DescribeVpcEndpointServiceConfigurations(context.Context, *ec2.DescribeVpcEndpointServiceConfigurationsInput) (*ec2.DescribeVpcEndpointServiceConfigurationsOutput, error)
+	ModifyVpcEndpointServiceConfiguration(context.Context, *ec2.ModifyVpcEndpointServiceConfigurationInput) (*ec2.ModifyVpcEndpointServiceConfigurationOutput, error)
+	DeleteVpcEndpointServiceConfigurations(context.Context, *ec2.DeleteVpcEndpointServiceConfigurationsInput) (*ec2.DeleteVpcEndpointServiceConfigurationsOutput, error)
+	RejectVpcEndpointConnections(context.Context, *ec2.RejectVpcEndpointConnectionsInput) (*ec2.RejectVpcEndpointConnectionsOutput, error)
+	DescribeVpcEndpointServicePermissions(context.Context, *ec2.DescribeVpcEndpointServicePermissionsInput) (*ec2.DescribeVpcEndpointServicePermissionsOutput, error)
+	ModifyVpcEndpointServicePermissions(context.Context, *ec2.ModifyVpcEndpointServicePermissionsInput) (*ec2.ModifyVpcEndpointServicePermissionsOutput, error)
+	DescribeVpcEndpointServices(context.Context, *ec2.DescribeVpcEndpointServicesInput) (*ec2.DescribeVpcEndpointServicesOutput, error)
+	DescribeVpcEndpoints(context.Context, *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error)
+	CreateVpcEndpoint(context.Context, *ec2.CreateVpcEndpointInput) (*ec2.CreateVpcEndpointOutput, error)
+	DeleteVpcEndpoints(context.Context, *ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error)
+	AddVpcEndpointServiceSupportedRegions(ctx context.Context, serviceID string, regions []string) error
+	CreateVpcEndpointForServiceRegion(ctx context.Context, input *ec2.CreateVpcEndpointInput, serviceRegion string) (*ec2.CreateVpcEndpointOutput, error)
 
 	// ELBV2
-	DescribeLoadBalancers(*elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error)
+	DescribeLoadBalancers(context.Context, *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error)
 
 	// S3 Manager
-	Upload(*s3manager.UploadInput) (*s3manager.UploadOutput, error)
+	Upload(context.Context, *s3manager.UploadInput) (*s3manager.UploadOutput, error)
 
 	// Custom
 	GetS3API() s3iface.S3API
 
 	// Route53
-	CreateHostedZone(input *route53.CreateHostedZoneInput) (*route53.CreateHostedZoneOutput, error)
-	GetHostedZone(*route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error)
-	ListTagsForResource(*route53.ListTagsForResourceInput) (*route53.ListTagsForResourceOutput, error)
-	ChangeTagsForResource(input *route53.ChangeTagsForResourceInput) (*route53.ChangeTagsForResourceOutput, error)
-	DeleteHostedZone(input *route53.DeleteHostedZoneInput) (*route53.DeleteHostedZoneOutput, error)
-	ListResourceRecordSets(input *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error)
-	ListHostedZonesByName(input *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error)
-	ListHostedZonesByVPC(input *route53.ListHostedZonesByVPCInput) (*route53.ListHostedZonesByVPCOutput, error)
-	ChangeResourceRecordSets(*route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error)
-	CreateVPCAssociationAuthorization(*route53.CreateVPCAssociationAuthorizationInput) (*route53.CreateVPCAssociationAuthorizationOutput, error)
-	DeleteVPCAssociationAuthorization(*route53.DeleteVPCAssociationAuthorizationInput) (*route53.DeleteVPCAssociationAuthorizationOutput, error)
-	AssociateVPCWithHostedZone(*route53.AssociateVPCWithHostedZoneInput) (*route53.AssociateVPCWithHostedZoneOutput, error)
-	DisassociateVPCFromHostedZone(input *route53.DisassociateVPCFromHostedZoneInput) (*route53.DisassociateVPCFromHostedZoneOutput, error)
+	CreateHostedZone(ctx context.Context, input *route53.CreateHostedZoneInput) (*route53.CreateHostedZoneOutput, error)
+	GetHostedZone(context.Context, *route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error)
+	ListTagsForResource(context.Context, *route53.ListTagsForResourceInput) (*route53.ListTagsForResourceOutput, error)
+	ChangeTagsForResource(ctx context.Context, input *route53.ChangeTagsForResourceInput) (*route53.ChangeTagsForResourceOutput, error)
+	DeleteHostedZone(ctx context.Context, input *route53.DeleteHostedZoneInput) (*route53.DeleteHostedZoneOutput, error)
+	ListResourceRecordSets(ctx context.Context, input *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error)
+	ListHostedZonesByName(ctx context.Context, input *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error)
+	ListHostedZonesByVPC(ctx context.Context, input *route53.ListHostedZonesByVPCInput) (*route53.ListHostedZonesByVPCOutput, error)
+	ChangeResourceRecordSets(context.Context, *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error)
+	CreateVPCAssociationAuthorization(context.Context, *route53.CreateVPCAssociationAuthorizationInput) (*route53.CreateVPCAssociationAuthorizationOutput, error)
+	DeleteVPCAssociationAuthorization(context.Context, *route53.DeleteVPCAssociationAuthorizationInput) (*route53.DeleteVPCAssociationAuthorizationOutput, error)
+	AssociateVPCWithHostedZone(context.Context, *route53.AssociateVPCWithHostedZoneInput) (*route53.AssociateVPCWithHostedZoneOutput, error)
+	DisassociateVPCFromHostedZone(ctx context.Context, input *route53.DisassociateVPCFromHostedZoneInput) (*route53.DisassociateVPCFromHostedZoneOutput, error)
+	GetDNSSEC(context.Context, *route53.GetDNSSECInput) (*route53.GetDNSSECOutput, error)
+	CreateKeySigningKey(context.Context, *route53.CreateKeySigningKeyInput) (*route53.CreateKeySigningKeyOutput, error)
+	DeactivateKeySigningKey(context.Context, *route53.DeactivateKeySigningKeyInput) (*route53.DeactivateKeySigningKeyOutput, error)
+	DeleteKeySigningKey(context.Context, *route53.DeleteKeySigningKeyInput) (*route53.DeleteKeySigningKeyOutput, error)
+	EnableHostedZoneDNSSEC(context.Context, *route53.EnableHostedZoneDNSSECInput) (*route53.EnableHostedZoneDNSSECOutput, error)
+	DisableHostedZoneDNSSEC(context.Context, *route53.DisableHostedZoneDNSSECInput) (*route53.DisableHostedZoneDNSSECOutput, error)
+	CreateHealthCheck(context.Context, *route53.CreateHealthCheckInput) (*route53.CreateHealthCheckOutput, error)
+	GetHealthCheck(context.Context, *route53.GetHealthCheckInput) (*route53.GetHealthCheckOutput, error)
+	UpdateHealthCheck(context.Context, *route53.UpdateHealthCheckInput) (*route53.UpdateHealthCheckOutput, error)
+	DeleteHealthCheck(context.Context, *route53.DeleteHealthCheckInput) (*route53.DeleteHealthCheckOutput, error)
+	ListQueryLoggingConfigs(context.Context, *route53.ListQueryLoggingConfigsInput) (*route53.ListQueryLoggingConfigsOutput, error)
+	CreateQueryLoggingConfig(context.Context, *route53.CreateQueryLoggingConfigInput) (*route53.CreateQueryLoggingConfigOutput, error)
+	DeleteQueryLoggingConfig(context.Context, *route53.DeleteQueryLoggingConfigInput) (*route53.DeleteQueryLoggingConfigOutput, error)
 	// ResourceTagging
-	GetResourcesPages(input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error
+	GetResourcesPages(ctx context.Context, input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error
 
 	// STS
-	GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error)
+	GetCallerIdentity(ctx context.Context, input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error)
+
+	// IAM
+	SimulatePrincipalPolicy(ctx context.Context, input *iam.SimulatePrincipalPolicyInput) (*iam.SimulatePolicyResponse, error)
 }
 
 type awsClient struct {
 	ec2Client     ec2iface.EC2API
 	elbClient     elbiface.ELBAPI
 	elbv2Client   elbv2iface.ELBV2API
+	iamClient     iamiface.IAMAPI
 	route53Client route53iface.Route53API
 	s3Client      s3iface.S3API
 	s3Uploader    *s3manager.Uploader
 	stsClient     stsiface.STSAPI
 	tagClient     *resourcegroupstaggingapi.ResourceGroupsTaggingAPI
+
+	// credentials and region identify the responses of the client in the responseCache, which is nil when read-only
+	// calls are not cached.
+	credentials   *credentials.Credentials
+	region        string
+	responseCache *controllerutils.ExpiringCache
 }
 
-func (c *awsClient) DescribeAvailabilityZones(input *ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
+const (
+	// defaultCallTimeout is the timeout of each API call, so that a stuck call does not block the caller until the
+	// context of the caller is done.
+	defaultCallTimeout = 2 * time.Minute
+)
+
+// contextWithTimeout returns the context of an API call made with the context of the caller.
+func contextWithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, defaultCallTimeout)
+}
+
+func (c *awsClient) DescribeAvailabilityZones(ctx context.Context, input *ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeAvailabilityZones").Inc()
-	return c.ec2Client.DescribeAvailabilityZones(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.ec2Client.DescribeAvailabilityZonesWithContext(ctx, input)
 }
 
-func (c *awsClient) DescribeSubnets(input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+func (c *awsClient) DescribeSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeSubnets").Inc()
-	return c.ec2Client.DescribeSubnets(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.ec2Client.DescribeSubnetsWithContext(ctx, input)
+}
+
+func (c *awsClient) CreateTags(ctx context.Context, input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("CreateTags").Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.ec2Client.CreateTagsWithContext(ctx, input)
 }
 
-func (c *awsClient) DescribeRouteTables(input *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
+func (c *awsClient) DeleteTags(ctx context.Context, input *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DeleteTags").Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.ec2Client.DeleteTagsWithContext(ctx, input)
+}
+
+func (c *awsClient) DescribeInstanceTypeOfferings(ctx context.Context, input *ec2.DescribeInstanceTypeOfferingsInput) (*ec2.DescribeInstanceTypeOfferingsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DescribeInstanceTypeOfferings").Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.ec2Client.DescribeInstanceTypeOfferingsWithContext(ctx, input)
+}
+
+func (c *awsClient) DescribeRouteTables(ctx context.Context, input *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeRouteTables").Inc()
-	return c.ec2Client.DescribeRouteTables(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.ec2Client.DescribeRouteTablesWithContext(ctx, input)
 }
 
-func (c *awsClient) DescribeInstances(input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
-	metricAWSAPICalls.WithLabelValues("DescribeInstances").Inc()
-	return c.ec2Client.DescribeInstances(input)
+func (c *awsClient) DescribeInstances(ctx context.Context, input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	output, err := c.cachedCall("DescribeInstances", input, func() (interface{}, error) {
+		metricAWSAPICalls.WithLabelValues("DescribeInstances").Inc()
+		ctx, cancel := contextWithTimeout(ctx)
+		defer cancel()
+		return c.ec2Client.DescribeInstancesWithContext(ctx, input)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.(*ec2.DescribeInstancesOutput), nil
 }
 
-func (c *awsClient) StopInstances(input *ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error) {
+func (c *awsClient) StopInstances(ctx context.Context, input *ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error) {
 	metricAWSAPICalls.WithLabelValues("StopInstances").Inc()
-	return c.ec2Client.StopInstances(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.ec2Client.StopInstancesWithContext(ctx, input)
 }
 
-func (c *awsClient) StartInstances(input *ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error) {
+func (c *awsClient) StartInstances(ctx context.Context, input *ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error) {
 	metricAWSAPICalls.WithLabelValues("StartInstances").Inc()
-	return c.ec2Client.StartInstances(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.ec2Client.StartInstancesWithContext(ctx, input)
 }
 
-func (c *awsClient) CreateVpcEndpointServiceConfiguration(input *ec2.CreateVpcEndpointServiceConfigurationInput) (*ec2.CreateVpcEndpointServiceConfigurationOutput, error) {
+func (c *awsClient) CreateVpcEndpointServiceConfiguration(ctx context.Context, input *ec2.CreateVpcEndpointServiceConfigurationInput) (*ec2.CreateVpcEndpointServiceConfigurationOutput, error) {
 	metricAWSAPICalls.WithLabelValues("CreateVpcEndpointServiceConfiguration").Inc()
-	return c.ec2Client.CreateVpcEndpointServiceConfiguration(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.ec2Client.CreateVpcEndpointServiceConfigurationWithContext(ctx, input)
 }
 
-func (c *awsClient) DescribeVpcEndpointServiceConfigurations(input *ec2.DescribeVpcEndpointServiceConfigurationsInput) (*ec2.DescribeVpcEndpointServiceConfigurationsOutput, error) {
+func (c *awsClient) DescribeVpcEndpointServiceConfigurations(ctx context.Context, input *ec2.DescribeVpcEndpointServiceConfigurationsInput) (*ec2.DescribeVpcEndpointServiceConfigurationsOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeVpcEndpointServiceConfigurations").Inc()
-	return c.ec2Client.DescribeVpcEndpointServiceConfigurations(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.ec2Client.DescribeVpcEndpointServiceConfigurationsWithContext(ctx, input)
 }
 
-func (c *awsClient) ModifyVpcEndpointServiceConfiguration(input *ec2.ModifyVpcEndpointServiceConfigurationInput) (*ec2.ModifyVpcEndpointServiceConfigurationOutput, error) {
+func (c *awsClient) ModifyVpcEndpointServiceConfiguration(ctx context.Context, input *ec2.ModifyVpcEndpointServiceConfigurationInput) (*ec2.ModifyVpcEndpointServiceConfigurationOutput, error) {
 	metricAWSAPICalls.WithLabelValues("ModifyVpcEndpointServiceConfiguration").Inc()
-	return c.ec2Client.ModifyVpcEndpointServiceConfiguration(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.ec2Client.ModifyVpcEndpointServiceConfigurationWithContext(ctx, input)
 }
 
-func (c *awsClient) DeleteVpcEndpointServiceConfigurations(input *ec2.DeleteVpcEndpointServiceConfigurationsInput) (*ec2.DeleteVpcEndpointServiceConfigurationsOutput, error) {
+func (c *awsClient) DeleteVpcEndpointServiceConfigurations(ctx context.Context, input *ec2.DeleteVpcEndpointServiceConfigurationsInput) (*ec2.DeleteVpcEndpointServiceConfigurationsOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DeleteVpcEndpointServiceConfigurations").Inc()
-	return c.ec2Client.DeleteVpcEndpointServiceConfigurations(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.ec2Client.DeleteVpcEndpointServiceConfigurationsWithContext(ctx, input)
+}
+
+func (c *awsClient) RejectVpcEndpointConnections(ctx context.Context, input *ec2.RejectVpcEndpointConnectionsInput) (*ec2.RejectVpcEndpointConnectionsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("RejectVpcEndpointConnections").Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.ec2Client.RejectVpcEndpointConnectionsWithContext(ctx, input)
 }
 
-func (c *awsClient) DescribeVpcEndpointServicePermissions(input *ec2.DescribeVpcEndpointServicePermissionsInput) (*ec2.DescribeVpcEndpointServicePermissionsOutput, error) {
+func (c *awsClient) DescribeVpcEndpointServicePermissions(ctx context.Context, input *ec2.DescribeVpcEndpointServicePermissionsInput) (*ec2.DescribeVpcEndpointServicePermissionsOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeVpcEndpointServicePermissions").Inc()
-	return c.ec2Client.DescribeVpcEndpointServicePermissions(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.ec2Client.DescribeVpcEndpointServicePermissionsWithContext(ctx, input)
 }
 
-func (c *awsClient) ModifyVpcEndpointServicePermissions(input *ec2.ModifyVpcEndpointServicePermissionsInput) (*ec2.ModifyVpcEndpointServicePermissionsOutput, error) {
+func (c *awsClient) ModifyVpcEndpointServicePermissions(ctx context.Context, input *ec2.ModifyVpcEndpointServicePermissionsInput) (*ec2.ModifyVpcEndpointServicePermissionsOutput, error) {
 	metricAWSAPICalls.WithLabelValues("ModifyVpcEndpointServicePermissions").Inc()
-	return c.ec2Client.ModifyVpcEndpointServicePermissions(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.ec2Client.ModifyVpcEndpointServicePermissionsWithContext(ctx, input)
 }
 
-func (c *awsClient) DescribeVpcEndpointServices(input *ec2.DescribeVpcEndpointServicesInput) (*ec2.DescribeVpcEndpointServicesOutput, error) {
+func (c *awsClient) DescribeVpcEndpointServices(ctx context.Context, input *ec2.DescribeVpcEndpointServicesInput) (*ec2.DescribeVpcEndpointServicesOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeVpcEndpointServices").Inc()
-	return c.ec2Client.DescribeVpcEndpointServices(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.ec2Client.DescribeVpcEndpointServicesWithContext(ctx, input)
 }
 
-func (c *awsClient) DescribeVpcEndpoints(input *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error) {
+func (c *awsClient) DescribeVpcEndpoints(ctx context.Context, input *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeVpcEndpoints").Inc()
-	return c.ec2Client.DescribeVpcEndpoints(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.ec2Client.DescribeVpcEndpointsWithContext(ctx, input)
 }
 
-func (c *awsClient) CreateVpcEndpoint(input *ec2.CreateVpcEndpointInput) (*ec2.CreateVpcEndpointOutput, error) {
+func (c *awsClient) CreateVpcEndpoint(ctx context.Context, input *ec2.CreateVpcEndpointInput) (*ec2.CreateVpcEndpointOutput, error) {
 	metricAWSAPICalls.WithLabelValues("CreateVpcEndpoint").Inc()
-	return c.ec2Client.CreateVpcEndpoint(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.ec2Client.CreateVpcEndpointWithContext(ctx, input)
 }
 
-func (c *awsClient) DeleteVpcEndpoints(input *ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error) {
+func (c *awsClient) DeleteVpcEndpoints(ctx context.Context, input *ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DeleteVpcEndpoints").Inc()
-	return c.ec2Client.DeleteVpcEndpoints(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.ec2Client.DeleteVpcEndpointsWithContext(ctx, input)
 }
 
-func (c *awsClient) DescribeLoadBalancers(input *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
+// AddVpcEndpointServiceSupportedRegions allows VPC Endpoints in the given regions to connect to the
+// VPC Endpoint Service using cross-region PrivateLink.
+func (c *awsClient) AddVpcEndpointServiceSupportedRegions(ctx context.Context, serviceID string, regions []string) error {
+	metricAWSAPICalls.WithLabelValues("ModifyVpcEndpointServiceConfiguration").Inc()
+	params := url.Values{}
+	for i, region := range regions {
+		params.Set(fmt.Sprintf("AddSupportedRegion.%d", i+1), region)
+	}
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	_, err := c.ec2Client.ModifyVpcEndpointServiceConfigurationWithContext(
+		ctx,
+		&ec2.ModifyVpcEndpointServiceConfigurationInput{ServiceId: aws.String(serviceID)},
+		withEC2QueryParams(params),
+	)
+	return err
+}
+
+// CreateVpcEndpointForServiceRegion creates a VPC Endpoint for a VPC Endpoint Service that is hosted
+// in serviceRegion, which may differ from the region of the client.
+func (c *awsClient) CreateVpcEndpointForServiceRegion(ctx context.Context, input *ec2.CreateVpcEndpointInput, serviceRegion string) (*ec2.CreateVpcEndpointOutput, error) {
+	metricAWSAPICalls.WithLabelValues("CreateVpcEndpoint").Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.ec2Client.CreateVpcEndpointWithContext(
+		ctx,
+		input,
+		withEC2QueryParams(url.Values{"ServiceRegion": []string{serviceRegion}}),
+	)
+}
+
+func (c *awsClient) DescribeLoadBalancers(ctx context.Context, input *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeLoadBalancers").Inc()
-	return c.elbv2Client.DescribeLoadBalancers(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.elbv2Client.DescribeLoadBalancersWithContext(ctx, input)
 }
 
 func (c *awsClient) GetS3API() s3iface.S3API {
 	return c.s3Client
 }
 
-func (c *awsClient) Upload(input *s3manager.UploadInput) (*s3manager.UploadOutput, error) {
-	return c.s3Uploader.Upload(input)
+func (c *awsClient) Upload(ctx context.Context, input *s3manager.UploadInput) (*s3manager.UploadOutput, error) {
+	// Uploads may take longer than the timeout of API calls, so they are only cancelled with the context of the caller.
+	return c.s3Uploader.UploadWithContext(ctx, input)
 }
 
-func (c *awsClient) ListHostedZonesByName(input *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
-	metricAWSAPICalls.WithLabelValues("ListHostedZonesByName").Inc()
-	return c.route53Client.ListHostedZonesByName(input)
+func (c *awsClient) ListHostedZonesByName(ctx context.Context, input *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
+	output, err := c.cachedCall("ListHostedZonesByName", input, func() (interface{}, error) {
+		metricAWSAPICalls.WithLabelValues("ListHostedZonesByName").Inc()
+		ctx, cancel := contextWithTimeout(ctx)
+		defer cancel()
+		return c.route53Client.ListHostedZonesByNameWithContext(ctx, input)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.(*route53.ListHostedZonesByNameOutput), nil
 }
 
-func (c *awsClient) ListHostedZonesByVPC(input *route53.ListHostedZonesByVPCInput) (*route53.ListHostedZonesByVPCOutput, error) {
+func (c *awsClient) ListHostedZonesByVPC(ctx context.Context, input *route53.ListHostedZonesByVPCInput) (*route53.ListHostedZonesByVPCOutput, error) {
 	metricAWSAPICalls.WithLabelValues("ListHostedZonesByVPC").Inc()
-	return c.route53Client.ListHostedZonesByVPC(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.ListHostedZonesByVPCWithContext(ctx, input)
 }
 
-func (c *awsClient) CreateHostedZone(input *route53.CreateHostedZoneInput) (*route53.CreateHostedZoneOutput, error) {
+func (c *awsClient) CreateHostedZone(ctx context.Context, input *route53.CreateHostedZoneInput) (*route53.CreateHostedZoneOutput, error) {
 	metricAWSAPICalls.WithLabelValues("CreateHostedZone").Inc()
-	return c.route53Client.CreateHostedZone(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.CreateHostedZoneWithContext(ctx, input)
 }
 
-func (c *awsClient) GetHostedZone(input *route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error) {
-	metricAWSAPICalls.WithLabelValues("GetHostedZone").Inc()
-	return c.route53Client.GetHostedZone(input)
+func (c *awsClient) GetHostedZone(ctx context.Context, input *route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error) {
+	output, err := c.cachedCall("GetHostedZone", input, func() (interface{}, error) {
+		metricAWSAPICalls.WithLabelValues("GetHostedZone").Inc()
+		ctx, cancel := contextWithTimeout(ctx)
+		defer cancel()
+		return c.route53Client.GetHostedZoneWithContext(ctx, input)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.(*route53.GetHostedZoneOutput), nil
 }
 
-func (c *awsClient) ListTagsForResource(input *route53.ListTagsForResourceInput) (*route53.ListTagsForResourceOutput, error) {
+func (c *awsClient) ListTagsForResource(ctx context.Context, input *route53.ListTagsForResourceInput) (*route53.ListTagsForResourceOutput, error) {
 	metricAWSAPICalls.WithLabelValues("ListTagsForResource").Inc()
-	return c.route53Client.ListTagsForResource(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.ListTagsForResourceWithContext(ctx, input)
 }
 
-func (c *awsClient) ChangeTagsForResource(input *route53.ChangeTagsForResourceInput) (*route53.ChangeTagsForResourceOutput, error) {
+func (c *awsClient) ChangeTagsForResource(ctx context.Context, input *route53.ChangeTagsForResourceInput) (*route53.ChangeTagsForResourceOutput, error) {
 	metricAWSAPICalls.WithLabelValues("ChangeTagsForResource").Inc()
-	return c.route53Client.ChangeTagsForResource(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.ChangeTagsForResourceWithContext(ctx, input)
 }
 
-func (c *awsClient) DeleteHostedZone(input *route53.DeleteHostedZoneInput) (*route53.DeleteHostedZoneOutput, error) {
+func (c *awsClient) DeleteHostedZone(ctx context.Context, input *route53.DeleteHostedZoneInput) (*route53.DeleteHostedZoneOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DeleteHostedZone").Inc()
-	return c.route53Client.DeleteHostedZone(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.DeleteHostedZoneWithContext(ctx, input)
 }
 
-func (c *awsClient) GetResourcesPages(input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
+func (c *awsClient) GetResourcesPages(ctx context.Context, input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
 	metricAWSAPICalls.WithLabelValues("GetResourcesPages").Inc()
-	return c.tagClient.GetResourcesPages(input, fn)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.tagClient.GetResourcesPagesWithContext(ctx, input, fn)
 }
 
-func (c *awsClient) ListResourceRecordSets(input *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error) {
+func (c *awsClient) ListResourceRecordSets(ctx context.Context, input *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error) {
 	metricAWSAPICalls.WithLabelValues("ListResourceRecordSets").Inc()
-	return c.route53Client.ListResourceRecordSets(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.ListResourceRecordSetsWithContext(ctx, input)
 }
 
-func (c *awsClient) ChangeResourceRecordSets(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+func (c *awsClient) ChangeResourceRecordSets(ctx context.Context, input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
 	metricAWSAPICalls.WithLabelValues("ChangeResourceRecordSets").Inc()
-	return c.route53Client.ChangeResourceRecordSets(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.ChangeResourceRecordSetsWithContext(ctx, input)
 }
 
-func (c *awsClient) AssociateVPCWithHostedZone(input *route53.AssociateVPCWithHostedZoneInput) (*route53.AssociateVPCWithHostedZoneOutput, error) {
+func (c *awsClient) AssociateVPCWithHostedZone(ctx context.Context, input *route53.AssociateVPCWithHostedZoneInput) (*route53.AssociateVPCWithHostedZoneOutput, error) {
 	metricAWSAPICalls.WithLabelValues("AssociateVPCWithHostedZone").Inc()
-	return c.route53Client.AssociateVPCWithHostedZone(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.AssociateVPCWithHostedZoneWithContext(ctx, input)
+}
+
+func (c *awsClient) GetDNSSEC(ctx context.Context, input *route53.GetDNSSECInput) (*route53.GetDNSSECOutput, error) {
+	metricAWSAPICalls.WithLabelValues("GetDNSSEC").Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.GetDNSSECWithContext(ctx, input)
+}
+
+func (c *awsClient) CreateKeySigningKey(ctx context.Context, input *route53.CreateKeySigningKeyInput) (*route53.CreateKeySigningKeyOutput, error) {
+	metricAWSAPICalls.WithLabelValues("CreateKeySigningKey").Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.CreateKeySigningKeyWithContext(ctx, input)
+}
+
+func (c *awsClient) DeactivateKeySigningKey(ctx context.Context, input *route53.DeactivateKeySigningKeyInput) (*route53.DeactivateKeySigningKeyOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DeactivateKeySigningKey").Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.DeactivateKeySigningKeyWithContext(ctx, input)
+}
+
+func (c *awsClient) DeleteKeySigningKey(ctx context.Context, input *route53.DeleteKeySigningKeyInput) (*route53.DeleteKeySigningKeyOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DeleteKeySigningKey").Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.DeleteKeySigningKeyWithContext(ctx, input)
+}
+
+func (c *awsClient) EnableHostedZoneDNSSEC(ctx context.Context, input *route53.EnableHostedZoneDNSSECInput) (*route53.EnableHostedZoneDNSSECOutput, error) {
+	metricAWSAPICalls.WithLabelValues("EnableHostedZoneDNSSEC").Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.EnableHostedZoneDNSSECWithContext(ctx, input)
+}
+
+func (c *awsClient) DisableHostedZoneDNSSEC(ctx context.Context, input *route53.DisableHostedZoneDNSSECInput) (*route53.DisableHostedZoneDNSSECOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DisableHostedZoneDNSSEC").Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.DisableHostedZoneDNSSECWithContext(ctx, input)
+}
+
+func (c *awsClient) CreateHealthCheck(ctx context.Context, input *route53.CreateHealthCheckInput) (*route53.CreateHealthCheckOutput, error) {
+	metricAWSAPICalls.WithLabelValues("CreateHealthCheck").Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.CreateHealthCheckWithContext(ctx, input)
+}
+
+func (c *awsClient) GetHealthCheck(ctx context.Context, input *route53.GetHealthCheckInput) (*route53.GetHealthCheckOutput, error) {
+	metricAWSAPICalls.WithLabelValues("GetHealthCheck").Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.GetHealthCheckWithContext(ctx, input)
 }
 
-func (c *awsClient) CreateVPCAssociationAuthorization(input *route53.CreateVPCAssociationAuthorizationInput) (*route53.CreateVPCAssociationAuthorizationOutput, error) {
+func (c *awsClient) UpdateHealthCheck(ctx context.Context, input *route53.UpdateHealthCheckInput) (*route53.UpdateHealthCheckOutput, error) {
+	metricAWSAPICalls.WithLabelValues("UpdateHealthCheck").Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.UpdateHealthCheckWithContext(ctx, input)
+}
+
+func (c *awsClient) DeleteHealthCheck(ctx context.Context, input *route53.DeleteHealthCheckInput) (*route53.DeleteHealthCheckOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DeleteHealthCheck").Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.DeleteHealthCheckWithContext(ctx, input)
+}
+
+func (c *awsClient) ListQueryLoggingConfigs(ctx context.Context, input *route53.ListQueryLoggingConfigsInput) (*route53.ListQueryLoggingConfigsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("ListQueryLoggingConfigs").Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.ListQueryLoggingConfigsWithContext(ctx, input)
+}
+
+func (c *awsClient) CreateQueryLoggingConfig(ctx context.Context, input *route53.CreateQueryLoggingConfigInput) (*route53.CreateQueryLoggingConfigOutput, error) {
+	metricAWSAPICalls.WithLabelValues("CreateQueryLoggingConfig").Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.CreateQueryLoggingConfigWithContext(ctx, input)
+}
+
+func (c *awsClient) DeleteQueryLoggingConfig(ctx context.Context, input *route53.DeleteQueryLoggingConfigInput) (*route53.DeleteQueryLoggingConfigOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DeleteQueryLoggingConfig").Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.DeleteQueryLoggingConfigWithContext(ctx, input)
+}
+
+func (c *awsClient) CreateVPCAssociationAuthorization(ctx context.Context, input *route53.CreateVPCAssociationAuthorizationInput) (*route53.CreateVPCAssociationAuthorizationOutput, error) {
 	metricAWSAPICalls.WithLabelValues("CreateVPCAssociationAuthorization").Inc()
-	return c.route53Client.CreateVPCAssociationAuthorization(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.CreateVPCAssociationAuthorizationWithContext(ctx, input)
 }
 
-func (c *awsClient) DeleteVPCAssociationAuthorization(input *route53.DeleteVPCAssociationAuthorizationInput) (*route53.DeleteVPCAssociationAuthorizationOutput, error) {
+func (c *awsClient) DeleteVPCAssociationAuthorization(ctx context.Context, input *route53.DeleteVPCAssociationAuthorizationInput) (*route53.DeleteVPCAssociationAuthorizationOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DeleteVPCAssociationAuthorization").Inc()
-	return c.route53Client.DeleteVPCAssociationAuthorization(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.DeleteVPCAssociationAuthorizationWithContext(ctx, input)
 }
 
-func (c *awsClient) DisassociateVPCFromHostedZone(input *route53.DisassociateVPCFromHostedZoneInput) (*route53.DisassociateVPCFromHostedZoneOutput, error) {
+func (c *awsClient) DisassociateVPCFromHostedZone(ctx context.Context, input *route53.DisassociateVPCFromHostedZoneInput) (*route53.DisassociateVPCFromHostedZoneOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DisassociateVPCFromHostedZone").Inc()
-	return c.route53Client.DisassociateVPCFromHostedZone(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.route53Client.DisassociateVPCFromHostedZoneWithContext(ctx, input)
 }
 
-func (c *awsClient) GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
+func (c *awsClient) GetCallerIdentity(ctx context.Context, input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
 	metricAWSAPICalls.WithLabelValues("GetCallerIdentity").Inc()
-	return c.stsClient.GetCallerIdentity(input)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.stsClient.GetCallerIdentityWithContext(ctx, input)
+}
+
+func (c *awsClient) SimulatePrincipalPolicy(ctx context.Context, input *iam.SimulatePrincipalPolicyInput) (*iam.SimulatePolicyResponse, error) {
+	metricAWSAPICalls.WithLabelValues("SimulatePrincipalPolicy").Inc()
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.iamClient.SimulatePrincipalPolicyWithContext(ctx, input)
 }
 
 // Options provides the means to control how a client is created and what
@@ -299,6 +586,31 @@ type Options struct {
 	// credentials are loaded from the environment.
 	// If multiple sources are configured, the first source is used.
 	CredentialsSource CredentialsSource
+
+	// Endpoint overrides the endpoint of all the AWS services used by the client. This is meant
+	// for running against an AWS emulator like LocalStack, or S3-compatible object storage.
+	Endpoint string
+
+	// ServiceEndpoints overrides the endpoints of some AWS services used by the client, such as the endpoints of a
+	// C2S region. The endpoints of the other services are resolved in the partition of the Region.
+	ServiceEndpoints []hivev1aws.ServiceEndpoint
+
+	// Controller is the name of the controller on behalf of which the client calls AWS, which labels the metrics of the
+	// calls.
+	Controller hivev1.ControllerName
+
+	// ProxySecret refers to a secret with the proxy configuration, and the additional trusted certificate
+	// authorities, of the client. It is only used when the credentials are loaded from the Secret of the
+	// CredentialsSource, so that calls made with the identity of Hive never go through it. Otherwise, or when it
+	// is not set, those of the CloudClientProxy of HiveConfig are used, if any.
+	ProxySecret *ProxySecretSource
+
+	// AssumeRole is a role assumed using the credentials loaded from the CredentialsSource. The client
+	// uses the credentials of the assumed role for all of its calls. This is meant for managing resources
+	// in an AWS account other than the one of the credentials, such as hosted zones kept in a central
+	// networking account.
+	// The role is assumed only when the RoleARN is not empty.
+	AssumeRole *hivev1aws.AssumeRole
 }
 
 // CredentialsSource defines how the credentials will be loaded.
@@ -307,6 +619,11 @@ type Options struct {
 // credentials are loaded from the environment.
 // If multiple sources are configured, the first source is used.
 type CredentialsSource struct {
+	// Broker credentials source mints short-lived credentials with the credentials
+	// broker configured in HiveConfig, from AWS STS web identity or Vault.
+	// This source is used only when it is not nil.
+	Broker *BrokerCredentialsSource
+
 	// Secret credentials source loads the credentials from a secret.
 	// It supports static credentials in the secret provided by aws_access_key_id,
 	// and aws_access_secret key. It also supports loading credentials from AWS
@@ -324,6 +641,41 @@ type CredentialsSource struct {
 	// when none set, use environment to load the credentials
 }
 
+// NewCredentialsSource returns the credentials source of a resource of the namespace with the credentials
+// secret, the IAM role assumed with the service provider credentials of Hive, and the credentials source of the
+// credentials broker of the resource, any of which may be unset.
+func NewCredentialsSource(namespace string, secretRef *corev1.LocalObjectReference, role *hivev1aws.AssumeRole, broker *hivev1aws.CredentialsSource) CredentialsSource {
+	source := CredentialsSource{
+		Secret: &SecretCredentialsSource{
+			Namespace: namespace,
+			Ref:       secretRef,
+		},
+		AssumeRole: &AssumeRoleCredentialsSource{
+			SecretRef: corev1.SecretReference{
+				Namespace: controllerutils.GetHiveNamespace(),
+				Name:      os.Getenv(constants.HiveAWSServiceProviderCredentialsSecretRefEnvVar),
+			},
+			Role: role,
+		},
+	}
+	if broker != nil {
+		source.Broker = &BrokerCredentialsSource{
+			Namespace: namespace,
+			Source:    broker,
+		}
+	}
+	return source
+}
+
+// BrokerCredentialsSource is the credentials source of the credentials broker of a resource.
+type BrokerCredentialsSource struct {
+	// Namespace is the namespace of the resource, which must be bound to the Source in the credentials broker.
+	Namespace string
+
+	// Source is the credentials source of the resource.
+	Source *hivev1aws.CredentialsSource
+}
+
 // Secret credentials source loads the credentials from a secret.
 // It supports static credentials in the secret provided by aws_access_key_id,
 // and aws_access_secret key. It also supports loading credentials from AWS
@@ -344,6 +696,13 @@ type AssumeRoleCredentialsSource struct {
 	Role      *hivev1aws.AssumeRole
 }
 
+// ProxySecretSource is a secret with the proxy configuration of the client, in the format read by
+// controllerutils.GetCloudClientProxy. It is used only when the Ref name is not empty.
+type ProxySecretSource struct {
+	Namespace string
+	Ref       *corev1.LocalObjectReference
+}
+
 // New creates an AWS client using the provided options. kubeClient is used whenever
 // a k8s resource like secret needs to be fetched. Look at doc for Options for various
 // configurations.
@@ -378,28 +737,95 @@ type AssumeRoleCredentialsSource struct {
 //    }
 //    client, err := New(kubeClient, options)
 //    ```
+// 3. Configure an AWS client acting in another account for DNSZone.
+//    ```go
+//    options := Options{
+//    	CredentialsSource: CredentialsSource{
+//    		Secret: &SecretCredentialsSource{
+//    			Namespace: dnsZone.Namespace,
+//    			Ref:       &dnsZone.Spec.AWS.CredentialsSecretRef,
+//    		},
+//    	},
+//    	AssumeRole: dnsZone.Spec.AWS.AssumeRole,
+//    }
+//    client, err := New(kubeClient, options)
+//    ```
 //
 func New(kubeClient client.Client, options Options) (Client, error) {
-	source := options.CredentialsSource
+	var cfgs []*aws.Config
+	if options.Endpoint != "" {
+		cfgs = append(cfgs, &aws.Config{
+			Endpoint:         aws.String(options.Endpoint),
+			S3ForcePathStyle: aws.Bool(true),
+		})
+	}
+	if len(options.ServiceEndpoints) > 0 {
+		cfgs = append(cfgs, &aws.Config{
+			EndpointResolver: serviceEndpointsResolver(options.ServiceEndpoints),
+		})
+	}
+	var secretCfgs []*aws.Config
+	if ps := options.ProxySecret; ps != nil && ps.Ref != nil && ps.Ref.Name != "" {
+		proxy, err := controllerutils.GetCloudClientProxy(kubeClient, ps.Namespace, ps.Ref)
+		if err != nil {
+			return nil, err
+		}
+		httpClient, err := proxy.HTTPClient()
+		if err != nil {
+			return nil, err
+		}
+		secretCfgs = append(secretCfgs, &aws.Config{HTTPClient: httpClient})
+	}
+
+	sess, err := newSessionFromCredentialsSource(kubeClient, options.CredentialsSource, options.Region, secretCfgs, cfgs...)
+	if err != nil {
+		return nil, err
+	}
+	addMetricsHandlers(sess, options.Controller)
+	if role := options.AssumeRole; role != nil && role.RoleARN != "" {
+		assumeRole(sess, role)
+	}
+	return newClientFromSession(sess)
+}
+
+// newSessionFromCredentialsSource creates a new AWS session with the credentials loaded from the first source
+// configured, or from the environment when none is. The secretCfgs are only applied to the session when its
+// credentials are loaded from the Secret source.
+func newSessionFromCredentialsSource(kubeClient client.Client, source CredentialsSource, region string, secretCfgs []*aws.Config, cfgs ...*aws.Config) (*session.Session, error) {
 	switch {
+	case source.Broker != nil:
+		return newSessionFromBroker(source.Broker.Namespace, source.Broker.Source, region, cfgs...)
 	case source.Secret != nil && source.Secret.Ref != nil && source.Secret.Ref.Name != "":
-		return NewClient(kubeClient, source.Secret.Ref.Name, source.Secret.Namespace, options.Region)
+		secret := &corev1.Secret{}
+		if err := kubeClient.Get(context.TODO(),
+			types.NamespacedName{
+				Name:      source.Secret.Ref.Name,
+				Namespace: source.Secret.Namespace,
+			},
+			secret); err != nil {
+			return nil, err
+		}
+		sess, err := newSessionFromSecret(secret, region, append(cfgs, secretCfgs...)...)
+		return sess, errors.Wrap(err, "failed to create AWS session")
 	case source.AssumeRole != nil && source.AssumeRole.Role != nil && source.AssumeRole.Role.RoleARN != "":
-		return newClientAssumeRole(kubeClient,
+		return newSessionAssumeRole(kubeClient,
 			source.AssumeRole.SecretRef.Name, source.AssumeRole.SecretRef.Namespace,
 			source.AssumeRole.Role,
-			options.Region,
+			region,
+			cfgs...,
 		)
 	}
 
-	return NewClientFromSecret(nil, options.Region)
+	sess, err := newSessionFromSecret(nil, region, cfgs...)
+	return sess, errors.Wrap(err, "failed to create AWS session")
 }
 
-func newClientAssumeRole(kubeClient client.Client,
+func newSessionAssumeRole(kubeClient client.Client,
 	serviceProviderSecretName, serviceProviderSecretNamespace string,
 	role *hivev1aws.AssumeRole,
 	region string,
-) (Client, error) {
+	cfgs ...*aws.Config,
+) (*session.Session, error) {
 	var secret *corev1.Secret
 	if serviceProviderSecretName != "" {
 		secret = &corev1.Secret{}
@@ -414,11 +840,18 @@ func newClientAssumeRole(kubeClient client.Client,
 		}
 	}
 
-	sess, err := NewSessionFromSecret(secret, region)
+	sess, err := newSessionFromSecret(secret, region, cfgs...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create AWS session")
 	}
 
+	assumeRole(sess, role)
+	return sess, nil
+}
+
+// assumeRole replaces the credentials of the session with the credentials of the role, assumed using the
+// current credentials of the session.
+func assumeRole(sess *session.Session, role *hivev1aws.AssumeRole) {
 	duration := stscreds.DefaultDuration
 	sess.Config.Credentials = stscreds.NewCredentials(sess, role.RoleARN, func(p *stscreds.AssumeRoleProvider) {
 		p.Duration = duration
@@ -426,8 +859,6 @@ func newClientAssumeRole(kubeClient client.Client,
 			p.ExternalID = &role.ExternalID
 		}
 	})
-
-	return newClientFromSession(sess)
 }
 
 // NewClient creates our client wrapper object for the actual AWS clients we use.
@@ -438,10 +869,14 @@ func newClientAssumeRole(kubeClient client.Client,
 // Pass a nil client, and empty secret name and namespace to load credentials from the standard
 // AWS environment variables.
 func NewClient(kubeClient client.Client, secretName, namespace, region string) (Client, error) {
+	return newClient(kubeClient, secretName, namespace, region)
+}
+
+func newClient(kubeClient client.Client, secretName, namespace, region string, cfgs ...*aws.Config) (Client, error) {
 
 	// Special case to not use a secret to gather credentials.
 	if secretName == "" {
-		return NewClientFromSecret(nil, region)
+		return newClientFromSecret(nil, region, cfgs...)
 	}
 
 	secret := &corev1.Secret{}
@@ -455,7 +890,7 @@ func NewClient(kubeClient client.Client, secretName, namespace, region string) (
 		return nil, err
 	}
 
-	return NewClientFromSecret(secret, region)
+	return newClientFromSecret(secret, region, cfgs...)
 }
 
 // NewClientFromSecret creates our client wrapper object for the actual AWS clients we use.
@@ -465,10 +900,15 @@ func NewClient(kubeClient client.Client, secretName, namespace, region string) (
 //
 // Pass a nil secret to load credentials from the standard AWS environment variables.
 func NewClientFromSecret(secret *corev1.Secret, region string) (Client, error) {
-	s, err := NewSessionFromSecret(secret, region)
+	return newClientFromSecret(secret, region)
+}
+
+func newClientFromSecret(secret *corev1.Secret, region string, cfgs ...*aws.Config) (Client, error) {
+	s, err := newSessionFromSecret(secret, region, cfgs...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create AWS session")
 	}
+	addMetricsHandlers(s, "")
 	return newClientFromSession(s)
 }
 
@@ -477,17 +917,27 @@ func newClientFromSession(s *session.Session, cfgs ...*aws.Config) (Client, erro
 		ec2Client:     ec2.New(s, cfgs...),
 		elbClient:     elb.New(s, cfgs...),
 		elbv2Client:   elbv2.New(s, cfgs...),
+		iamClient:     iam.New(s, cfgs...),
 		s3Client:      s3.New(s, cfgs...),
 		s3Uploader:    s3manager.NewUploader(s),
-		route53Client: route53.New(s, cfgs...),
+		route53Client: newRoute53Client(s, cfgs...),
 		stsClient:     sts.New(s, cfgs...),
 		tagClient:     resourcegroupstaggingapi.New(s, cfgs...),
+		credentials:   s.Config.Credentials,
+		region:        aws.StringValue(s.Config.Region),
+		responseCache: getResponseCache(),
 	}, nil
 }
 
 // NewSessionFromSecret creates a new AWS session using the configuration in the secret. If the secret
 // was nil, it initializes a new session using configuration of the envionment.
 func NewSessionFromSecret(secret *corev1.Secret, region string) (*session.Session, error) {
+	return newSessionFromSecret(secret, region)
+}
+
+// newSessionFromSecret creates a new AWS session like NewSessionFromSecret with the additional configuration
+// merged into the configuration of the session.
+func newSessionFromSecret(secret *corev1.Secret, region string, cfgs ...*aws.Config) (*session.Session, error) {
 	options := session.Options{
 		Config: aws.Config{
 			Region:           aws.String(region),
@@ -514,6 +964,20 @@ func NewSessionFromSecret(secret *corev1.Secret, region string) (*session.Sessio
 	}
 
 	// Otherwise default to relying on the environment where the actuator is running:
+	options.Config.MergeIn(cfgs...)
+
+	// The proxy of HiveConfig is used unless the client has its own.
+	if options.Config.HTTPClient == nil {
+		proxy, err := controllerutils.ReadCloudClientProxyFiles()
+		if err != nil {
+			return nil, err
+		}
+		httpClient, err := proxy.HTTPClient()
+		if err != nil {
+			return nil, err
+		}
+		options.Config.HTTPClient = httpClient
+	}
 	s, err := session.NewSessionWithOptions(options)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,115 @@
+// +build localstack
+
+package awsclient_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/test/localstack"
+)
+
+func TestListResourceRecordSetsPaginationLocalStack(t *testing.T) {
+	h := localstack.New(t)
+	c := h.Client(t)
+
+	zone := localstack.UniqueName("hive") + ".example.com"
+	zoneID := h.CreateHostedZone(t, zone)
+	h.CreateRecordSets(t, zoneID, zone, 250)
+
+	names := map[string]bool{}
+	pages := 0
+	input := &route53.ListResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+		MaxItems:     aws.String("100"),
+	}
+	for {
+		out, err := c.ListResourceRecordSets(context.TODO(), input)
+		require.NoError(t, err, "unexpected error listing record sets")
+		pages++
+		for _, rs := range out.ResourceRecordSets {
+			names[fmt.Sprintf("%s %s", aws.StringValue(rs.Name), aws.StringValue(rs.Type))] = true
+		}
+		if !aws.BoolValue(out.IsTruncated) {
+			break
+		}
+		input.StartRecordName = out.NextRecordName
+		input.StartRecordType = out.NextRecordType
+		input.StartRecordIdentifier = out.NextRecordIdentifier
+	}
+	assert.GreaterOrEqual(t, pages, 3, "expected the record sets to be paginated")
+	// The NS and SOA record sets are created with the zone.
+	assert.Len(t, names, 252, "unexpected number of record sets")
+}
+
+func TestErrorsLocalStack(t *testing.T) {
+	h := localstack.New(t)
+	c := h.Client(t)
+
+	_, err := c.GetHostedZone(context.TODO(), &route53.GetHostedZoneInput{Id: aws.String("ZDOESNOTEXIST")})
+	assertErrorCode(t, err, route53.ErrCodeNoSuchHostedZone)
+
+	_, err = c.DeleteHostedZone(context.TODO(), &route53.DeleteHostedZoneInput{Id: aws.String("ZDOESNOTEXIST")})
+	assertErrorCode(t, err, route53.ErrCodeNoSuchHostedZone)
+
+	zone := localstack.UniqueName("hive") + ".example.com"
+	zoneID := h.CreateHostedZone(t, zone)
+	h.CreateRecordSets(t, zoneID, zone, 1)
+	_, err = c.DeleteHostedZone(context.TODO(), &route53.DeleteHostedZoneInput{Id: aws.String(zoneID)})
+	assertErrorCode(t, err, route53.ErrCodeHostedZoneNotEmpty)
+
+	_, err = c.StopInstances(context.TODO(), &ec2.StopInstancesInput{InstanceIds: aws.StringSlice([]string{"i-0123456789abcdef0"})})
+	assertErrorCode(t, err, "InvalidInstanceID.NotFound")
+}
+
+func TestThrottlingLocalStack(t *testing.T) {
+	h := localstack.New(t)
+
+	tests := []struct {
+		name        string
+		failures    int32
+		expectError bool
+	}{
+		{
+			name:     "retried",
+			failures: 2,
+		},
+		{
+			name:        "retries exhausted",
+			failures:    100,
+			expectError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := h.Options()
+			options.Endpoint = h.ThrottlingEndpoint(t, test.failures)
+			c, err := awsclient.New(h.KubeClient, options)
+			require.NoError(t, err, "unexpected error creating AWS client")
+			_, err = c.ListHostedZonesByName(context.TODO(), &route53.ListHostedZonesByNameInput{})
+			if test.expectError {
+				assertErrorCode(t, err, "Throttling")
+			} else {
+				assert.NoError(t, err, "expected throttled requests to be retried")
+			}
+		})
+	}
+}
+
+func assertErrorCode(t *testing.T, err error, code string) {
+	if assert.Error(t, err, "expected %s error", code) {
+		awsErr, ok := err.(awserr.Error)
+		if assert.True(t, ok, "expected an AWS error, got %v", err) {
+			assert.Equal(t, code, awsErr.Code(), "unexpected error code")
+		}
+	}
+}
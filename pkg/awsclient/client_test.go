@@ -0,0 +1,117 @@
+package awsclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestCallsCancelledWithContext(t *testing.T) {
+	// The server does not respond until the end of the test, like an API endpoint that is stuck.
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	c, err := newClientFromSecret(nil, "us-east-1", &aws.Config{
+		Endpoint:    aws.String(server.URL),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+	})
+	require.NoError(t, err, "unexpected error creating client")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	start := time.Now()
+	_, err = c.DescribeInstances(ctx, &ec2.DescribeInstancesInput{})
+	if assert.Error(t, err, "expected the call to be cancelled") {
+		aerr, ok := err.(awserr.Error)
+		if assert.True(t, ok, "expected an AWS error") {
+			assert.Equal(t, request.CanceledErrorCode, aerr.Code(), "unexpected error code")
+		}
+	}
+	assert.Less(t, int64(time.Since(start)), int64(defaultCallTimeout), "expected the call to be cancelled before it timed out")
+}
+
+func TestNewSessionFromCredentialsSourceSecretConfigs(t *testing.T) {
+	c := fake.NewFakeClientWithScheme(scheme.Scheme, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-creds"},
+		Data: map[string][]byte{
+			"aws_access_key_id":     []byte("id"),
+			"aws_secret_access_key": []byte("secret"),
+		},
+	})
+	proxyClient := &http.Client{}
+	secretCfgs := []*aws.Config{{HTTPClient: proxyClient}}
+	os.Setenv(constants.CredentialsBrokerEnvVar, `{"bindings":[{"namespace":"test-namespace","roleARNs":["arn:aws:iam::123456789012:role/test-role"]}]}`)
+	defer os.Unsetenv(constants.CredentialsBrokerEnvVar)
+
+	cases := []struct {
+		name              string
+		source            CredentialsSource
+		expectProxyClient bool
+	}{
+		{
+			name: "secret",
+			source: CredentialsSource{
+				Secret: &SecretCredentialsSource{Namespace: "test-namespace", Ref: &corev1.LocalObjectReference{Name: "test-creds"}},
+			},
+			expectProxyClient: true,
+		},
+		{
+			name: "credentials broker",
+			source: CredentialsSource{
+				Broker: &BrokerCredentialsSource{
+					Namespace: "test-namespace",
+					Source: &hivev1aws.CredentialsSource{
+						WebIdentity: &hivev1aws.WebIdentityCredentialsSource{RoleARN: "arn:aws:iam::123456789012:role/test-role"},
+					},
+				},
+				Secret: &SecretCredentialsSource{Namespace: "test-namespace", Ref: &corev1.LocalObjectReference{Name: "test-creds"}},
+			},
+		},
+		{
+			name: "assume role with the service provider credentials",
+			source: CredentialsSource{
+				AssumeRole: &AssumeRoleCredentialsSource{
+					Role: &hivev1aws.AssumeRole{RoleARN: "arn:aws:iam::123456789012:role/test-role"},
+				},
+			},
+		},
+		{
+			name: "environment",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sess, err := newSessionFromCredentialsSource(c, tc.source, "us-east-1", secretCfgs)
+			require.NoError(t, err, "unexpected error creating session")
+			if tc.expectProxyClient {
+				assert.Same(t, proxyClient, sess.Config.HTTPClient, "expected the session to use the proxy client")
+			} else {
+				assert.NotSame(t, proxyClient, sess.Config.HTTPClient, "expected the session not to use the proxy client")
+			}
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package awsclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// serviceAccountTokenFile is the token of the service account of the Hive controllers, with which the credentials
+	// broker authenticates to AWS STS and Vault.
+	serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+	// brokerRoleSessionNamePrefix prefixes the namespace of the resource in the session name of the credentials minted
+	// for it, so that the sessions of each namespace can be told apart in AWS CloudTrail and in the trust policies.
+	brokerRoleSessionNamePrefix     = "hive-"
+	brokerRoleSessionNameHashLength = 16
+	maxRoleSessionNameLength        = 64
+
+	defaultVaultMountPath     = "aws"
+	defaultVaultAuthMountPath = "kubernetes"
+
+	// vaultExpiryWindow is how long before the expiry of their lease the credentials read from Vault are renewed.
+	vaultExpiryWindow = time.Minute
+	vaultTimeout      = 30 * time.Second
+
+	// VaultProviderName is the name of the provider of the credentials read from Vault.
+	VaultProviderName = "VaultProvider"
+)
+
+var (
+	metricCredentialsBrokerRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hive_credentials_broker_requests_total",
+			Help: "Number of short-lived credentials minted by the credentials broker, partitioned by source and result.",
+		},
+		[]string{"source", "result"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricCredentialsBrokerRequests)
+}
+
+// ReadCredentialsBrokerConfig returns the configuration of the credentials broker read from the
+// HIVE_CREDENTIALS_BROKER environment variable, or an empty configuration when it is not set.
+func ReadCredentialsBrokerConfig() (*hivev1.CredentialsBrokerConfig, error) {
+	config := &hivev1.CredentialsBrokerConfig{}
+	if value := os.Getenv(constants.CredentialsBrokerEnvVar); value != "" {
+		if err := json.Unmarshal([]byte(value), config); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s", constants.CredentialsBrokerEnvVar)
+		}
+	}
+	return config, nil
+}
+
+// NewBrokerCredentials returns the short-lived credentials minted by the credentials broker for the credentials
+// source of a resource of the namespace. The credentials are renewed when they expire. The calls to AWS STS are made
+// with the session. An error is returned when no binding of the configuration allows the namespace to use the
+// credentials source.
+func NewBrokerCredentials(sess *session.Session, namespace string, source *hivev1aws.CredentialsSource, config *hivev1.CredentialsBrokerConfig) (*credentials.Credentials, error) {
+	if err := CheckCredentialsSourceBinding(config, namespace, source); err != nil {
+		return nil, err
+	}
+	switch {
+	case source.WebIdentity != nil:
+		provider := stscreds.NewWebIdentityRoleProvider(sts.New(sess), source.WebIdentity.RoleARN, brokerRoleSessionName(namespace), serviceAccountTokenFile)
+		return credentials.NewCredentials(&brokerProvider{Provider: provider, source: "WebIdentity"}), nil
+	case source.Vault != nil:
+		if config == nil || config.Vault == nil || config.Vault.Address == "" {
+			return nil, errors.New("no Vault server is configured in the credentials broker of HiveConfig")
+		}
+		provider := &vaultProvider{
+			httpClient:  &http.Client{Timeout: vaultTimeout},
+			server:      config.Vault,
+			source:      source.Vault,
+			sessionName: brokerRoleSessionName(namespace),
+			tokenFile:   serviceAccountTokenFile,
+		}
+		return credentials.NewCredentials(&brokerProvider{Provider: provider, source: "Vault"}), nil
+	}
+	return nil, errors.New("the credentials source has neither web identity nor Vault set")
+}
+
+// CheckCredentialsSourceBinding returns an error unless a binding of the configuration allows the resources of the
+// namespace to use the credentials source.
+func CheckCredentialsSourceBinding(config *hivev1.CredentialsBrokerConfig, namespace string, source *hivev1aws.CredentialsSource) error {
+	var bindings []hivev1.CredentialsBrokerBinding
+	if config != nil {
+		bindings = config.Bindings
+	}
+	for _, b := range bindings {
+		if b.Namespace != namespace {
+			continue
+		}
+		switch {
+		case source.WebIdentity != nil && sets.NewString(b.RoleARNs...).Has(source.WebIdentity.RoleARN):
+			return nil
+		case source.Vault != nil && sets.NewString(b.VaultRoles...).Has(vaultRoleKey(source.Vault)):
+			return nil
+		}
+	}
+	switch {
+	case source.WebIdentity != nil:
+		return fmt.Errorf("the IAM role %s is not bound to namespace %s in the credentials broker of HiveConfig", source.WebIdentity.RoleARN, namespace)
+	case source.Vault != nil:
+		return fmt.Errorf("the Vault role %s is not bound to namespace %s in the credentials broker of HiveConfig", vaultRoleKey(source.Vault), namespace)
+	}
+	return errors.New("the credentials source has neither web identity nor Vault set")
+}
+
+// vaultRoleKey returns the role of the Vault credentials source in the form <mountPath>/<role> of the bindings.
+func vaultRoleKey(source *hivev1aws.VaultCredentialsSource) string {
+	mountPath := source.MountPath
+	if mountPath == "" {
+		mountPath = defaultVaultMountPath
+	}
+	return path.Join(mountPath, source.Role)
+}
+
+// brokerRoleSessionName returns the session name of the credentials minted for the resources of the namespace.
+// Names that would not fit in a session name are shortened to a prefix of the namespace followed by a hash of the
+// whole namespace, so that they remain unique. Shortened names are the only ones of the maximum length.
+func brokerRoleSessionName(namespace string) string {
+	name := brokerRoleSessionNamePrefix + namespace
+	if len(name) < maxRoleSessionNameLength {
+		return name
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(namespace)))[:brokerRoleSessionNameHashLength]
+	return name[:maxRoleSessionNameLength-len(hash)-1] + "-" + hash
+}
+
+// MintBrokerCredentials mints short-lived credentials with the credentials broker configured in the
+// HIVE_CREDENTIALS_BROKER environment variable for the credentials source of a resource of the namespace, calling
+// AWS STS in the region, and returns them with their expiry. The credentials are minted with the identity of the
+// calling pod, so this is only meant to be called by the Hive controllers.
+func MintBrokerCredentials(namespace string, source *hivev1aws.CredentialsSource, region string) (credentials.Value, time.Time, error) {
+	sess, err := newSessionFromBroker(namespace, source, region)
+	if err != nil {
+		return credentials.Value{}, time.Time{}, err
+	}
+	v, err := sess.Config.Credentials.Get()
+	if err != nil {
+		return credentials.Value{}, time.Time{}, errors.Wrap(err, "failed to mint credentials with the credentials broker")
+	}
+	expiry, err := sess.Config.Credentials.ExpiresAt()
+	if err != nil {
+		return credentials.Value{}, time.Time{}, errors.Wrap(err, "failed to get the expiry of the credentials")
+	}
+	return v, expiry, nil
+}
+
+// newSessionFromBroker creates a new AWS session with the short-lived credentials minted by the credentials broker
+// for the credentials source of a resource of the namespace.
+func newSessionFromBroker(namespace string, source *hivev1aws.CredentialsSource, region string, cfgs ...*aws.Config) (*session.Session, error) {
+	config, err := ReadCredentialsBrokerConfig()
+	if err != nil {
+		return nil, err
+	}
+	sess, err := newSessionFromSecret(nil, region, cfgs...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create AWS session")
+	}
+	creds, err := NewBrokerCredentials(sess, namespace, source, config)
+	if err != nil {
+		return nil, err
+	}
+	sess.Config.Credentials = creds
+	return sess, nil
+}
+
+// brokerProvider counts the credentials minted by the provider of a credentials source.
+type brokerProvider struct {
+	credentials.Provider
+	source string
+}
+
+func (p *brokerProvider) Retrieve() (credentials.Value, error) {
+	v, err := p.Provider.Retrieve()
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	metricCredentialsBrokerRequests.WithLabelValues(p.source, result).Inc()
+	return v, err
+}
+
+// ExpiresAt returns the expiry of the credentials, which both the web identity and the Vault providers track.
+func (p *brokerProvider) ExpiresAt() time.Time {
+	if e, ok := p.Provider.(credentials.Expirer); ok {
+		return e.ExpiresAt()
+	}
+	return time.Time{}
+}
+
+// vaultProvider reads credentials from a role of the AWS secrets engine of Vault, logging in with the Kubernetes
+// auth method.
+type vaultProvider struct {
+	credentials.Expiry
+
+	httpClient  *http.Client
+	server      *hivev1.VaultCredentialsBrokerConfig
+	source      *hivev1aws.VaultCredentialsSource
+	sessionName string
+	tokenFile   string
+}
+
+type vaultResponse struct {
+	LeaseDuration int `json:"lease_duration"`
+	Auth          *struct {
+		ClientToken string `json:"client_token"`
+	} `json:"auth"`
+	Data struct {
+		AccessKey     string `json:"access_key"`
+		SecretKey     string `json:"secret_key"`
+		SecurityToken string `json:"security_token"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+func (p *vaultProvider) Retrieve() (credentials.Value, error) {
+	jwt, err := ioutil.ReadFile(p.tokenFile)
+	if err != nil {
+		return credentials.Value{}, errors.Wrap(err, "failed to read the service account token")
+	}
+
+	authMountPath := p.server.AuthMountPath
+	if authMountPath == "" {
+		authMountPath = defaultVaultAuthMountPath
+	}
+	login := &vaultResponse{}
+	if err := p.call(http.MethodPost, path.Join("auth", authMountPath, "login"), "", map[string]string{
+		"role": p.server.AuthRole,
+		"jwt":  strings.TrimSpace(string(jwt)),
+	}, login); err != nil {
+		return credentials.Value{}, errors.Wrap(err, "failed to log in to Vault")
+	}
+	if login.Auth == nil || login.Auth.ClientToken == "" {
+		return credentials.Value{}, errors.New("failed to log in to Vault: no client token returned")
+	}
+
+	mountPath := p.source.MountPath
+	if mountPath == "" {
+		mountPath = defaultVaultMountPath
+	}
+	// The session name is used by the assumed_role credential type of the role, and ignored by the others.
+	credsPath := path.Join(mountPath, "creds", p.source.Role) + "?" + url.Values{"role_session_name": {p.sessionName}}.Encode()
+	creds := &vaultResponse{}
+	if err := p.call(http.MethodGet, credsPath, login.Auth.ClientToken, nil, creds); err != nil {
+		return credentials.Value{}, errors.Wrap(err, "failed to read AWS credentials from Vault")
+	}
+	if creds.Data.AccessKey == "" || creds.Data.SecretKey == "" {
+		return credentials.Value{}, errors.New("failed to read AWS credentials from Vault: no access key returned")
+	}
+	if creds.LeaseDuration > 0 {
+		p.SetExpiration(time.Now().Add(time.Duration(creds.LeaseDuration)*time.Second), vaultExpiryWindow)
+	}
+
+	return credentials.Value{
+		AccessKeyID:     creds.Data.AccessKey,
+		SecretAccessKey: creds.Data.SecretKey,
+		SessionToken:    creds.Data.SecurityToken,
+		ProviderName:    VaultProviderName,
+	}, nil
+}
+
+func (p *vaultProvider) call(method, apiPath, token string, body interface{}, out *vaultResponse) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, strings.TrimSuffix(p.server.Address, "/")+"/v1/"+apiPath, reader)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Errors are reported in the body, which may be empty.
+	decodeErr := json.NewDecoder(resp.Body).Decode(out)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Vault returned %s: %s", resp.Status, strings.Join(out.Errors, "; "))
+	}
+	return decodeErr
+}
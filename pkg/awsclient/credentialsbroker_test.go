@@ -0,0 +1,238 @@
+package awsclient
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+)
+
+func TestVaultProviderRetrieve(t *testing.T) {
+	cases := []struct {
+		name          string
+		source        *hivev1aws.VaultCredentialsSource
+		loginStatus   int
+		leaseDuration int
+		expectedPath  string
+		expectErr     bool
+	}{
+		{
+			name:          "credentials from default mount path",
+			source:        &hivev1aws.VaultCredentialsSource{Role: "installer"},
+			loginStatus:   http.StatusOK,
+			leaseDuration: 3600,
+			expectedPath:  "/v1/aws/creds/installer",
+		},
+		{
+			name:          "credentials from custom mount path",
+			source:        &hivev1aws.VaultCredentialsSource{Role: "installer", MountPath: "aws-prod"},
+			loginStatus:   http.StatusOK,
+			leaseDuration: 3600,
+			expectedPath:  "/v1/aws-prod/creds/installer",
+		},
+		{
+			name:        "login denied",
+			source:      &hivev1aws.VaultCredentialsSource{Role: "installer"},
+			loginStatus: http.StatusForbidden,
+			expectErr:   true,
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			var credsPath string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/v1/auth/kubernetes/login" {
+					login := map[string]string{}
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&login))
+					assert.Equal(t, "hive", login["role"], "unexpected auth role")
+					assert.Equal(t, "service-account-token", login["jwt"], "unexpected service account token")
+					w.WriteHeader(test.loginStatus)
+					if test.loginStatus != http.StatusOK {
+						w.Write([]byte(`{"errors":["permission denied"]}`))
+						return
+					}
+					w.Write([]byte(`{"auth":{"client_token":"vault-token"}}`))
+					return
+				}
+				credsPath = r.URL.Path
+				assert.Equal(t, "hive-cluster-namespace", r.URL.Query().Get("role_session_name"), "unexpected role session name")
+				assert.Equal(t, "vault-token", r.Header.Get("X-Vault-Token"), "unexpected Vault token")
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"lease_duration": test.leaseDuration,
+					"data": map[string]string{
+						"access_key":     "access-key",
+						"secret_key":     "secret-key",
+						"security_token": "security-token",
+					},
+				})
+			}))
+			defer server.Close()
+
+			dir, err := ioutil.TempDir("", "credentialsbroker")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+			tokenFile := filepath.Join(dir, "token")
+			require.NoError(t, ioutil.WriteFile(tokenFile, []byte("service-account-token\n"), 0600))
+
+			provider := &vaultProvider{
+				httpClient:  server.Client(),
+				server:      &hivev1.VaultCredentialsBrokerConfig{Address: server.URL, AuthRole: "hive"},
+				source:      test.source,
+				sessionName: brokerRoleSessionName("cluster-namespace"),
+				tokenFile:   tokenFile,
+			}
+			v, err := provider.Retrieve()
+			if test.expectErr {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedPath, credsPath, "unexpected credentials path")
+			assert.Equal(t, "access-key", v.AccessKeyID, "unexpected access key")
+			assert.Equal(t, "secret-key", v.SecretAccessKey, "unexpected secret key")
+			assert.Equal(t, "security-token", v.SessionToken, "unexpected session token")
+			assert.False(t, provider.IsExpired(), "credentials should not be expired")
+			assert.WithinDuration(t, time.Now().Add(time.Duration(test.leaseDuration)*time.Second-vaultExpiryWindow), provider.ExpiresAt(), time.Minute, "unexpected expiry")
+		})
+	}
+}
+
+func TestNewBrokerCredentialsWithoutVaultServer(t *testing.T) {
+	_, err := NewBrokerCredentials(nil, "cluster-namespace", &hivev1aws.CredentialsSource{
+		Vault: &hivev1aws.VaultCredentialsSource{Role: "installer"},
+	}, &hivev1.CredentialsBrokerConfig{
+		Bindings: []hivev1.CredentialsBrokerBinding{{
+			Namespace:  "cluster-namespace",
+			VaultRoles: []string{"aws/installer"},
+		}},
+	})
+	assert.Error(t, err, "expected error when no Vault server is configured")
+}
+
+func TestCheckCredentialsSourceBinding(t *testing.T) {
+	config := &hivev1.CredentialsBrokerConfig{
+		Bindings: []hivev1.CredentialsBrokerBinding{{
+			Namespace:  "team-a",
+			RoleARNs:   []string{"arn:aws:iam::111111111111:role/installer"},
+			VaultRoles: []string{"aws/installer", "aws-prod/installer"},
+		}, {
+			Namespace: "team-b",
+			RoleARNs:  []string{"arn:aws:iam::222222222222:role/installer"},
+		}},
+	}
+	cases := []struct {
+		name      string
+		config    *hivev1.CredentialsBrokerConfig
+		namespace string
+		source    *hivev1aws.CredentialsSource
+		expectErr bool
+	}{
+		{
+			name:      "role bound to namespace",
+			config:    config,
+			namespace: "team-a",
+			source: &hivev1aws.CredentialsSource{
+				WebIdentity: &hivev1aws.WebIdentityCredentialsSource{RoleARN: "arn:aws:iam::111111111111:role/installer"},
+			},
+		},
+		{
+			name:      "role bound to another namespace",
+			config:    config,
+			namespace: "team-a",
+			source: &hivev1aws.CredentialsSource{
+				WebIdentity: &hivev1aws.WebIdentityCredentialsSource{RoleARN: "arn:aws:iam::222222222222:role/installer"},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "namespace without binding",
+			config:    config,
+			namespace: "team-c",
+			source: &hivev1aws.CredentialsSource{
+				WebIdentity: &hivev1aws.WebIdentityCredentialsSource{RoleARN: "arn:aws:iam::111111111111:role/installer"},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "no bindings",
+			config:    &hivev1.CredentialsBrokerConfig{},
+			namespace: "team-a",
+			source: &hivev1aws.CredentialsSource{
+				WebIdentity: &hivev1aws.WebIdentityCredentialsSource{RoleARN: "arn:aws:iam::111111111111:role/installer"},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "no configuration",
+			namespace: "team-a",
+			source: &hivev1aws.CredentialsSource{
+				WebIdentity: &hivev1aws.WebIdentityCredentialsSource{RoleARN: "arn:aws:iam::111111111111:role/installer"},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "Vault role from default mount path",
+			config:    config,
+			namespace: "team-a",
+			source: &hivev1aws.CredentialsSource{
+				Vault: &hivev1aws.VaultCredentialsSource{Role: "installer"},
+			},
+		},
+		{
+			name:      "Vault role from custom mount path",
+			config:    config,
+			namespace: "team-a",
+			source: &hivev1aws.CredentialsSource{
+				Vault: &hivev1aws.VaultCredentialsSource{Role: "installer", MountPath: "aws-prod"},
+			},
+		},
+		{
+			name:      "Vault role from unbound mount path",
+			config:    config,
+			namespace: "team-a",
+			source: &hivev1aws.CredentialsSource{
+				Vault: &hivev1aws.VaultCredentialsSource{Role: "installer", MountPath: "aws-other"},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "Vault role not bound to namespace",
+			config:    config,
+			namespace: "team-b",
+			source: &hivev1aws.CredentialsSource{
+				Vault: &hivev1aws.VaultCredentialsSource{Role: "installer"},
+			},
+			expectErr: true,
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckCredentialsSourceBinding(test.config, test.namespace, test.source)
+			if test.expectErr {
+				assert.Error(t, err, "expected error")
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+		})
+	}
+}
+
+func TestBrokerRoleSessionName(t *testing.T) {
+	assert.Equal(t, "hive-team-a", brokerRoleSessionName("team-a"), "unexpected session name")
+	long := brokerRoleSessionName("a-namespace-with-a-name-that-is-as-long-as-namespaces-may-be-xyz")
+	assert.Len(t, long, maxRoleSessionNameLength, "session name should be shortened")
+	other := brokerRoleSessionName("a-namespace-with-a-name-that-is-as-long-as-namespaces-may-be-abc")
+	assert.NotEqual(t, long, other, "session names of namespaces with a common prefix should differ")
+	assert.Equal(t, "hive-a-namespace-with-a-name-as-long-as-session-names-may-be-x", brokerRoleSessionName("a-namespace-with-a-name-as-long-as-session-names-may-be-x"),
+		"session names that fit should not be shortened")
+}
@@ -0,0 +1,50 @@
+package awsclient
+
+import (
+	"io/ioutil"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// ec2QueryParamsHandlerName is the name of the build handler added by withEC2QueryParams.
+const ec2QueryParamsHandlerName = "hive.EC2QueryParamsHandler"
+
+// withEC2QueryParams adds parameters to an EC2 query request after it has been built. It is used for
+// request parameters that are not yet modelled by the vendored AWS SDK, such as the supported regions of
+// a VPC Endpoint Service and the service region of a VPC Endpoint used for cross-region PrivateLink. It
+// can be dropped once the SDK models them.
+func withEC2QueryParams(params url.Values) request.Option {
+	return func(req *request.Request) {
+		req.Handlers.Build.PushBackNamed(request.NamedHandler{
+			Name: ec2QueryParamsHandlerName,
+			Fn:   addEC2QueryParams(params),
+		})
+	}
+}
+
+// addEC2QueryParams returns a build handler setting the parameters in the form-encoded body of an EC2 query
+// request. Parameters already set by the SDK are replaced.
+func addEC2QueryParams(params url.Values) func(*request.Request) {
+	return func(r *request.Request) {
+		if r.Error != nil {
+			return
+		}
+		body, err := ioutil.ReadAll(r.GetBody())
+		if err != nil {
+			r.Error = errors.Wrap(err, "failed to read EC2 query request body")
+			return
+		}
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			r.Error = errors.Wrap(err, "failed to parse EC2 query request body")
+			return
+		}
+		for key, value := range params {
+			values[key] = value
+		}
+		r.SetBufferBody([]byte(values.Encode()))
+	}
+}
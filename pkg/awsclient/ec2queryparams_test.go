@@ -0,0 +1,87 @@
+package awsclient
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEC2QueryParams(t *testing.T) {
+	cases := []struct {
+		name           string
+		response       string
+		call           func(c Client) error
+		expectedParams url.Values
+	}{
+		{
+			name:     "supported regions",
+			response: `<ModifyVpcEndpointServiceConfigurationResponse><return>true</return></ModifyVpcEndpointServiceConfigurationResponse>`,
+			call: func(c Client) error {
+				return c.AddVpcEndpointServiceSupportedRegions(context.Background(), "vpce-svc-1", []string{"us-west-2", "eu-west-1"})
+			},
+			expectedParams: url.Values{
+				"Action":               {"ModifyVpcEndpointServiceConfiguration"},
+				"Version":              {"2016-11-15"},
+				"ServiceId":            {"vpce-svc-1"},
+				"AddSupportedRegion.1": {"us-west-2"},
+				"AddSupportedRegion.2": {"eu-west-1"},
+			},
+		},
+		{
+			name:     "service region",
+			response: `<CreateVpcEndpointResponse><vpcEndpoint><vpcEndpointId>vpce-1</vpcEndpointId></vpcEndpoint></CreateVpcEndpointResponse>`,
+			call: func(c Client) error {
+				resp, err := c.CreateVpcEndpointForServiceRegion(context.Background(), &ec2.CreateVpcEndpointInput{
+					ServiceName:     aws.String("com.amazonaws.vpce.us-east-1.vpce-svc-1"),
+					VpcEndpointType: aws.String(ec2.VpcEndpointTypeInterface),
+					VpcId:           aws.String("vpc-1"),
+				}, "us-east-1")
+				if err == nil {
+					assert.Equal(t, "vpce-1", aws.StringValue(resp.VpcEndpoint.VpcEndpointId), "unexpected response")
+				}
+				return err
+			},
+			expectedParams: url.Values{
+				"Action":          {"CreateVpcEndpoint"},
+				"Version":         {"2016-11-15"},
+				"ServiceName":     {"com.amazonaws.vpce.us-east-1.vpce-svc-1"},
+				"VpcEndpointType": {"Interface"},
+				"VpcId":           {"vpc-1"},
+				"ServiceRegion":   {"us-east-1"},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var params url.Values
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err, "unexpected error reading request")
+				assert.Equal(t, "application/x-www-form-urlencoded; charset=utf-8", r.Header.Get("Content-Type"), "unexpected content type")
+				params, err = url.ParseQuery(string(body))
+				assert.NoError(t, err, "unexpected error parsing request")
+				w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			c, err := newClientFromSecret(nil, "us-west-2", &aws.Config{
+				Endpoint:    aws.String(server.URL),
+				Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+				MaxRetries:  aws.Int(0),
+			})
+			require.NoError(t, err, "unexpected error creating client")
+
+			require.NoError(t, tc.call(c), "unexpected error calling EC2")
+			assert.Equal(t, tc.expectedParams, params, "unexpected encoded request")
+		})
+	}
+}
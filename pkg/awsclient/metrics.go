@@ -0,0 +1,50 @@
+package awsclient
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// cloudAPISpanKey is the key of the context value holding the span of an API call.
+type cloudAPISpanKey struct{}
+
+// addMetricsHandlers adds handlers to the session recording the duration of the API calls of the clients created from
+// it, and counting the failed calls by error code, labeled by the controller on behalf of which they are made. Calls
+// made with the context of a traced reconcile are traced as part of it.
+func addMetricsHandlers(s *session.Session, controller hivev1.ControllerName) {
+	s.Handlers.Build.PushFrontNamed(request.NamedHandler{
+		Name: "openshift.io/hive/cloudAPITracing",
+		Fn: func(r *request.Request) {
+			ctx, span := controllerutils.StartCloudAPISpan(r.Context(), controllerutils.CloudAWS, r.ClientInfo.ServiceName, r.Operation.Name)
+			if span != nil {
+				r.SetContext(context.WithValue(ctx, cloudAPISpanKey{}, span))
+			}
+		},
+	})
+	s.Handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: "openshift.io/hive/cloudAPIMetrics",
+		Fn: func(r *request.Request) {
+			code := ""
+			if r.Error != nil {
+				code = "error"
+				if awsErr, ok := r.Error.(awserr.Error); ok && awsErr.Code() != "" {
+					code = awsErr.Code()
+				}
+			}
+			// The time of the request is when it was built, so that the duration includes the retries.
+			controllerutils.ObserveCloudAPICall(controller, controllerutils.CloudAWS, r.ClientInfo.ServiceName, r.Operation.Name, time.Since(r.Time), code)
+			if span, ok := r.Context().Value(cloudAPISpanKey{}).(trace.Span); ok {
+				controllerutils.EndCloudAPISpan(span, code)
+			}
+		},
+	})
+}
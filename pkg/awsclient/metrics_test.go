@@ -0,0 +1,70 @@
+package awsclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+func TestMetricsHandlers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `<ErrorResponse><Error><Type>Sender</Type><Code>NoSuchHostedZone</Code><Message>not found</Message></Error><RequestId>1</RequestId></ErrorResponse>`)
+	}))
+	defer server.Close()
+
+	s, err := newSessionFromSecret(nil, "us-east-1", &aws.Config{
+		Endpoint:    aws.String(server.URL),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+	})
+	require.NoError(t, err, "unexpected error creating session")
+	addMetricsHandlers(s, hivev1.DNSZoneControllerName)
+	c, err := newClientFromSession(s)
+	require.NoError(t, err, "unexpected error creating client")
+
+	labels := map[string]string{
+		"controller": "dnszone",
+		"cloud":      controllerutils.CloudAWS,
+		"service":    "route53",
+		"operation":  "GetHostedZone",
+		"code":       "NoSuchHostedZone",
+	}
+	failed := cloudAPIRequestErrors(t, labels)
+	_, err = c.GetHostedZone(context.TODO(), &route53.GetHostedZoneInput{Id: aws.String("missing")})
+	assert.Error(t, err, "expected error for a missing zone")
+	assert.Equal(t, failed+1, cloudAPIRequestErrors(t, labels), "expected the failed call to be counted by error code")
+}
+
+// cloudAPIRequestErrors returns the number of failed cloud API calls with the labels.
+func cloudAPIRequestErrors(t *testing.T, labels map[string]string) float64 {
+	families, err := metrics.Registry.Gather()
+	require.NoError(t, err, "unexpected error gathering metrics")
+	for _, family := range families {
+		if family.GetName() != "hive_cloud_api_request_errors_total" {
+			continue
+		}
+	metrics:
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if labels[label.GetName()] != label.GetValue() {
+					continue metrics
+				}
+			}
+			return m.GetCounter().GetValue()
+		}
+	}
+	return 0
+}
@@ -5,8 +5,10 @@
 package mock
 
 import (
+	context "context"
 	ec2 "github.com/aws/aws-sdk-go/service/ec2"
 	elbv2 "github.com/aws/aws-sdk-go/service/elbv2"
+	iam "github.com/aws/aws-sdk-go/service/iam"
 	resourcegroupstaggingapi "github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	route53 "github.com/aws/aws-sdk-go/service/route53"
 	s3iface "github.com/aws/aws-sdk-go/service/s3/s3iface"
@@ -40,273 +42,362 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 }
 
 // DescribeAvailabilityZones mocks base method
-func (m *MockClient) DescribeAvailabilityZones(arg0 *ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
+func (m *MockClient) DescribeAvailabilityZones(arg0 context.Context, arg1 *ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DescribeAvailabilityZones", arg0)
+	ret := m.ctrl.Call(m, "DescribeAvailabilityZones", arg0, arg1)
 	ret0, _ := ret[0].(*ec2.DescribeAvailabilityZonesOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DescribeAvailabilityZones indicates an expected call of DescribeAvailabilityZones
-func (mr *MockClientMockRecorder) DescribeAvailabilityZones(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DescribeAvailabilityZones(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAvailabilityZones", reflect.TypeOf((*MockClient)(nil).DescribeAvailabilityZones), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAvailabilityZones", reflect.TypeOf((*MockClient)(nil).DescribeAvailabilityZones), arg0, arg1)
 }
 
 // DescribeSubnets mocks base method
-func (m *MockClient) DescribeSubnets(arg0 *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+func (m *MockClient) DescribeSubnets(arg0 context.Context, arg1 *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DescribeSubnets", arg0)
+	ret := m.ctrl.Call(m, "DescribeSubnets", arg0, arg1)
 	ret0, _ := ret[0].(*ec2.DescribeSubnetsOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DescribeSubnets indicates an expected call of DescribeSubnets
-func (mr *MockClientMockRecorder) DescribeSubnets(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DescribeSubnets(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeSubnets", reflect.TypeOf((*MockClient)(nil).DescribeSubnets), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeSubnets", reflect.TypeOf((*MockClient)(nil).DescribeSubnets), arg0, arg1)
+}
+
+// DescribeInstanceTypeOfferings mocks base method
+func (m *MockClient) DescribeInstanceTypeOfferings(arg0 context.Context, arg1 *ec2.DescribeInstanceTypeOfferingsInput) (*ec2.DescribeInstanceTypeOfferingsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeInstanceTypeOfferings", arg0, arg1)
+	ret0, _ := ret[0].(*ec2.DescribeInstanceTypeOfferingsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeInstanceTypeOfferings indicates an expected call of DescribeInstanceTypeOfferings
+func (mr *MockClientMockRecorder) DescribeInstanceTypeOfferings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeInstanceTypeOfferings", reflect.TypeOf((*MockClient)(nil).DescribeInstanceTypeOfferings), arg0, arg1)
 }
 
 // DescribeRouteTables mocks base method
-func (m *MockClient) DescribeRouteTables(arg0 *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
+func (m *MockClient) DescribeRouteTables(arg0 context.Context, arg1 *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DescribeRouteTables", arg0)
+	ret := m.ctrl.Call(m, "DescribeRouteTables", arg0, arg1)
 	ret0, _ := ret[0].(*ec2.DescribeRouteTablesOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DescribeRouteTables indicates an expected call of DescribeRouteTables
-func (mr *MockClientMockRecorder) DescribeRouteTables(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DescribeRouteTables(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeRouteTables", reflect.TypeOf((*MockClient)(nil).DescribeRouteTables), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeRouteTables", reflect.TypeOf((*MockClient)(nil).DescribeRouteTables), arg0, arg1)
 }
 
 // DescribeInstances mocks base method
-func (m *MockClient) DescribeInstances(arg0 *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+func (m *MockClient) DescribeInstances(arg0 context.Context, arg1 *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DescribeInstances", arg0)
+	ret := m.ctrl.Call(m, "DescribeInstances", arg0, arg1)
 	ret0, _ := ret[0].(*ec2.DescribeInstancesOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DescribeInstances indicates an expected call of DescribeInstances
-func (mr *MockClientMockRecorder) DescribeInstances(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DescribeInstances(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeInstances", reflect.TypeOf((*MockClient)(nil).DescribeInstances), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeInstances", reflect.TypeOf((*MockClient)(nil).DescribeInstances), arg0, arg1)
+}
+
+// CreateTags mocks base method
+func (m *MockClient) CreateTags(arg0 context.Context, arg1 *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTags", arg0, arg1)
+	ret0, _ := ret[0].(*ec2.CreateTagsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTags indicates an expected call of CreateTags
+func (mr *MockClientMockRecorder) CreateTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTags", reflect.TypeOf((*MockClient)(nil).CreateTags), arg0, arg1)
+}
+
+// DeleteTags mocks base method
+func (m *MockClient) DeleteTags(arg0 context.Context, arg1 *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTags", arg0, arg1)
+	ret0, _ := ret[0].(*ec2.DeleteTagsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTags indicates an expected call of DeleteTags
+func (mr *MockClientMockRecorder) DeleteTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTags", reflect.TypeOf((*MockClient)(nil).DeleteTags), arg0, arg1)
 }
 
 // StopInstances mocks base method
-func (m *MockClient) StopInstances(arg0 *ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error) {
+func (m *MockClient) StopInstances(arg0 context.Context, arg1 *ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StopInstances", arg0)
+	ret := m.ctrl.Call(m, "StopInstances", arg0, arg1)
 	ret0, _ := ret[0].(*ec2.StopInstancesOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // StopInstances indicates an expected call of StopInstances
-func (mr *MockClientMockRecorder) StopInstances(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) StopInstances(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopInstances", reflect.TypeOf((*MockClient)(nil).StopInstances), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopInstances", reflect.TypeOf((*MockClient)(nil).StopInstances), arg0, arg1)
 }
 
 // StartInstances mocks base method
-func (m *MockClient) StartInstances(arg0 *ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error) {
+func (m *MockClient) StartInstances(arg0 context.Context, arg1 *ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StartInstances", arg0)
+	ret := m.ctrl.Call(m, "StartInstances", arg0, arg1)
 	ret0, _ := ret[0].(*ec2.StartInstancesOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // StartInstances indicates an expected call of StartInstances
-func (mr *MockClientMockRecorder) StartInstances(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) StartInstances(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartInstances", reflect.TypeOf((*MockClient)(nil).StartInstances), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartInstances", reflect.TypeOf((*MockClient)(nil).StartInstances), arg0, arg1)
 }
 
 // CreateVpcEndpointServiceConfiguration mocks base method
-func (m *MockClient) CreateVpcEndpointServiceConfiguration(arg0 *ec2.CreateVpcEndpointServiceConfigurationInput) (*ec2.CreateVpcEndpointServiceConfigurationOutput, error) {
+func (m *MockClient) CreateVpcEndpointServiceConfiguration(arg0 context.Context, arg1 *ec2.CreateVpcEndpointServiceConfigurationInput) (*ec2.CreateVpcEndpointServiceConfigurationOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateVpcEndpointServiceConfiguration", arg0)
+	ret := m.ctrl.Call(m, "CreateVpcEndpointServiceConfiguration", arg0, arg1)
 	ret0, _ := ret[0].(*ec2.CreateVpcEndpointServiceConfigurationOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateVpcEndpointServiceConfiguration indicates an expected call of CreateVpcEndpointServiceConfiguration
-func (mr *MockClientMockRecorder) CreateVpcEndpointServiceConfiguration(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) CreateVpcEndpointServiceConfiguration(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVpcEndpointServiceConfiguration", reflect.TypeOf((*MockClient)(nil).CreateVpcEndpointServiceConfiguration), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVpcEndpointServiceConfiguration", reflect.TypeOf((*MockClient)(nil).CreateVpcEndpointServiceConfiguration), arg0, arg1)
 }
 
 // DescribeVpcEndpointServiceConfigurations mocks base method
-func (m *MockClient) DescribeVpcEndpointServiceConfigurations(arg0 *ec2.DescribeVpcEndpointServiceConfigurationsInput) (*ec2.DescribeVpcEndpointServiceConfigurationsOutput, error) {
+func (m *MockClient) DescribeVpcEndpointServiceConfigurations(arg0 context.Context, arg1 *ec2.DescribeVpcEndpointServiceConfigurationsInput) (*ec2.DescribeVpcEndpointServiceConfigurationsOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DescribeVpcEndpointServiceConfigurations", arg0)
+	ret := m.ctrl.Call(m, "DescribeVpcEndpointServiceConfigurations", arg0, arg1)
 	ret0, _ := ret[0].(*ec2.DescribeVpcEndpointServiceConfigurationsOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DescribeVpcEndpointServiceConfigurations indicates an expected call of DescribeVpcEndpointServiceConfigurations
-func (mr *MockClientMockRecorder) DescribeVpcEndpointServiceConfigurations(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DescribeVpcEndpointServiceConfigurations(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeVpcEndpointServiceConfigurations", reflect.TypeOf((*MockClient)(nil).DescribeVpcEndpointServiceConfigurations), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeVpcEndpointServiceConfigurations", reflect.TypeOf((*MockClient)(nil).DescribeVpcEndpointServiceConfigurations), arg0, arg1)
 }
 
 // ModifyVpcEndpointServiceConfiguration mocks base method
-func (m *MockClient) ModifyVpcEndpointServiceConfiguration(arg0 *ec2.ModifyVpcEndpointServiceConfigurationInput) (*ec2.ModifyVpcEndpointServiceConfigurationOutput, error) {
+func (m *MockClient) ModifyVpcEndpointServiceConfiguration(arg0 context.Context, arg1 *ec2.ModifyVpcEndpointServiceConfigurationInput) (*ec2.ModifyVpcEndpointServiceConfigurationOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ModifyVpcEndpointServiceConfiguration", arg0)
+	ret := m.ctrl.Call(m, "ModifyVpcEndpointServiceConfiguration", arg0, arg1)
 	ret0, _ := ret[0].(*ec2.ModifyVpcEndpointServiceConfigurationOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ModifyVpcEndpointServiceConfiguration indicates an expected call of ModifyVpcEndpointServiceConfiguration
-func (mr *MockClientMockRecorder) ModifyVpcEndpointServiceConfiguration(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) ModifyVpcEndpointServiceConfiguration(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyVpcEndpointServiceConfiguration", reflect.TypeOf((*MockClient)(nil).ModifyVpcEndpointServiceConfiguration), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyVpcEndpointServiceConfiguration", reflect.TypeOf((*MockClient)(nil).ModifyVpcEndpointServiceConfiguration), arg0, arg1)
 }
 
 // DeleteVpcEndpointServiceConfigurations mocks base method
-func (m *MockClient) DeleteVpcEndpointServiceConfigurations(arg0 *ec2.DeleteVpcEndpointServiceConfigurationsInput) (*ec2.DeleteVpcEndpointServiceConfigurationsOutput, error) {
+func (m *MockClient) DeleteVpcEndpointServiceConfigurations(arg0 context.Context, arg1 *ec2.DeleteVpcEndpointServiceConfigurationsInput) (*ec2.DeleteVpcEndpointServiceConfigurationsOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteVpcEndpointServiceConfigurations", arg0)
+	ret := m.ctrl.Call(m, "DeleteVpcEndpointServiceConfigurations", arg0, arg1)
 	ret0, _ := ret[0].(*ec2.DeleteVpcEndpointServiceConfigurationsOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteVpcEndpointServiceConfigurations indicates an expected call of DeleteVpcEndpointServiceConfigurations
-func (mr *MockClientMockRecorder) DeleteVpcEndpointServiceConfigurations(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DeleteVpcEndpointServiceConfigurations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVpcEndpointServiceConfigurations", reflect.TypeOf((*MockClient)(nil).DeleteVpcEndpointServiceConfigurations), arg0, arg1)
+}
+
+// RejectVpcEndpointConnections mocks base method
+func (m *MockClient) RejectVpcEndpointConnections(arg0 context.Context, arg1 *ec2.RejectVpcEndpointConnectionsInput) (*ec2.RejectVpcEndpointConnectionsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectVpcEndpointConnections", arg0, arg1)
+	ret0, _ := ret[0].(*ec2.RejectVpcEndpointConnectionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectVpcEndpointConnections indicates an expected call of RejectVpcEndpointConnections
+func (mr *MockClientMockRecorder) RejectVpcEndpointConnections(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVpcEndpointServiceConfigurations", reflect.TypeOf((*MockClient)(nil).DeleteVpcEndpointServiceConfigurations), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectVpcEndpointConnections", reflect.TypeOf((*MockClient)(nil).RejectVpcEndpointConnections), arg0, arg1)
 }
 
 // DescribeVpcEndpointServicePermissions mocks base method
-func (m *MockClient) DescribeVpcEndpointServicePermissions(arg0 *ec2.DescribeVpcEndpointServicePermissionsInput) (*ec2.DescribeVpcEndpointServicePermissionsOutput, error) {
+func (m *MockClient) DescribeVpcEndpointServicePermissions(arg0 context.Context, arg1 *ec2.DescribeVpcEndpointServicePermissionsInput) (*ec2.DescribeVpcEndpointServicePermissionsOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DescribeVpcEndpointServicePermissions", arg0)
+	ret := m.ctrl.Call(m, "DescribeVpcEndpointServicePermissions", arg0, arg1)
 	ret0, _ := ret[0].(*ec2.DescribeVpcEndpointServicePermissionsOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DescribeVpcEndpointServicePermissions indicates an expected call of DescribeVpcEndpointServicePermissions
-func (mr *MockClientMockRecorder) DescribeVpcEndpointServicePermissions(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DescribeVpcEndpointServicePermissions(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeVpcEndpointServicePermissions", reflect.TypeOf((*MockClient)(nil).DescribeVpcEndpointServicePermissions), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeVpcEndpointServicePermissions", reflect.TypeOf((*MockClient)(nil).DescribeVpcEndpointServicePermissions), arg0, arg1)
 }
 
 // ModifyVpcEndpointServicePermissions mocks base method
-func (m *MockClient) ModifyVpcEndpointServicePermissions(arg0 *ec2.ModifyVpcEndpointServicePermissionsInput) (*ec2.ModifyVpcEndpointServicePermissionsOutput, error) {
+func (m *MockClient) ModifyVpcEndpointServicePermissions(arg0 context.Context, arg1 *ec2.ModifyVpcEndpointServicePermissionsInput) (*ec2.ModifyVpcEndpointServicePermissionsOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ModifyVpcEndpointServicePermissions", arg0)
+	ret := m.ctrl.Call(m, "ModifyVpcEndpointServicePermissions", arg0, arg1)
 	ret0, _ := ret[0].(*ec2.ModifyVpcEndpointServicePermissionsOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ModifyVpcEndpointServicePermissions indicates an expected call of ModifyVpcEndpointServicePermissions
-func (mr *MockClientMockRecorder) ModifyVpcEndpointServicePermissions(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) ModifyVpcEndpointServicePermissions(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyVpcEndpointServicePermissions", reflect.TypeOf((*MockClient)(nil).ModifyVpcEndpointServicePermissions), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyVpcEndpointServicePermissions", reflect.TypeOf((*MockClient)(nil).ModifyVpcEndpointServicePermissions), arg0, arg1)
 }
 
 // DescribeVpcEndpointServices mocks base method
-func (m *MockClient) DescribeVpcEndpointServices(arg0 *ec2.DescribeVpcEndpointServicesInput) (*ec2.DescribeVpcEndpointServicesOutput, error) {
+func (m *MockClient) DescribeVpcEndpointServices(arg0 context.Context, arg1 *ec2.DescribeVpcEndpointServicesInput) (*ec2.DescribeVpcEndpointServicesOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DescribeVpcEndpointServices", arg0)
+	ret := m.ctrl.Call(m, "DescribeVpcEndpointServices", arg0, arg1)
 	ret0, _ := ret[0].(*ec2.DescribeVpcEndpointServicesOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DescribeVpcEndpointServices indicates an expected call of DescribeVpcEndpointServices
-func (mr *MockClientMockRecorder) DescribeVpcEndpointServices(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DescribeVpcEndpointServices(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeVpcEndpointServices", reflect.TypeOf((*MockClient)(nil).DescribeVpcEndpointServices), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeVpcEndpointServices", reflect.TypeOf((*MockClient)(nil).DescribeVpcEndpointServices), arg0, arg1)
 }
 
 // DescribeVpcEndpoints mocks base method
-func (m *MockClient) DescribeVpcEndpoints(arg0 *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error) {
+func (m *MockClient) DescribeVpcEndpoints(arg0 context.Context, arg1 *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DescribeVpcEndpoints", arg0)
+	ret := m.ctrl.Call(m, "DescribeVpcEndpoints", arg0, arg1)
 	ret0, _ := ret[0].(*ec2.DescribeVpcEndpointsOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DescribeVpcEndpoints indicates an expected call of DescribeVpcEndpoints
-func (mr *MockClientMockRecorder) DescribeVpcEndpoints(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DescribeVpcEndpoints(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeVpcEndpoints", reflect.TypeOf((*MockClient)(nil).DescribeVpcEndpoints), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeVpcEndpoints", reflect.TypeOf((*MockClient)(nil).DescribeVpcEndpoints), arg0, arg1)
 }
 
 // CreateVpcEndpoint mocks base method
-func (m *MockClient) CreateVpcEndpoint(arg0 *ec2.CreateVpcEndpointInput) (*ec2.CreateVpcEndpointOutput, error) {
+func (m *MockClient) CreateVpcEndpoint(arg0 context.Context, arg1 *ec2.CreateVpcEndpointInput) (*ec2.CreateVpcEndpointOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateVpcEndpoint", arg0)
+	ret := m.ctrl.Call(m, "CreateVpcEndpoint", arg0, arg1)
 	ret0, _ := ret[0].(*ec2.CreateVpcEndpointOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateVpcEndpoint indicates an expected call of CreateVpcEndpoint
-func (mr *MockClientMockRecorder) CreateVpcEndpoint(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) CreateVpcEndpoint(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVpcEndpoint", reflect.TypeOf((*MockClient)(nil).CreateVpcEndpoint), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVpcEndpoint", reflect.TypeOf((*MockClient)(nil).CreateVpcEndpoint), arg0, arg1)
 }
 
 // DeleteVpcEndpoints mocks base method
-func (m *MockClient) DeleteVpcEndpoints(arg0 *ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error) {
+func (m *MockClient) DeleteVpcEndpoints(arg0 context.Context, arg1 *ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteVpcEndpoints", arg0)
+	ret := m.ctrl.Call(m, "DeleteVpcEndpoints", arg0, arg1)
 	ret0, _ := ret[0].(*ec2.DeleteVpcEndpointsOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteVpcEndpoints indicates an expected call of DeleteVpcEndpoints
-func (mr *MockClientMockRecorder) DeleteVpcEndpoints(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DeleteVpcEndpoints(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVpcEndpoints", reflect.TypeOf((*MockClient)(nil).DeleteVpcEndpoints), arg0, arg1)
+}
+
+// AddVpcEndpointServiceSupportedRegions mocks base method
+func (m *MockClient) AddVpcEndpointServiceSupportedRegions(ctx context.Context, serviceID string, regions []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddVpcEndpointServiceSupportedRegions", ctx, serviceID, regions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddVpcEndpointServiceSupportedRegions indicates an expected call of AddVpcEndpointServiceSupportedRegions
+func (mr *MockClientMockRecorder) AddVpcEndpointServiceSupportedRegions(ctx, serviceID, regions interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVpcEndpoints", reflect.TypeOf((*MockClient)(nil).DeleteVpcEndpoints), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVpcEndpointServiceSupportedRegions", reflect.TypeOf((*MockClient)(nil).AddVpcEndpointServiceSupportedRegions), ctx, serviceID, regions)
+}
+
+// CreateVpcEndpointForServiceRegion mocks base method
+func (m *MockClient) CreateVpcEndpointForServiceRegion(ctx context.Context, input *ec2.CreateVpcEndpointInput, serviceRegion string) (*ec2.CreateVpcEndpointOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVpcEndpointForServiceRegion", ctx, input, serviceRegion)
+	ret0, _ := ret[0].(*ec2.CreateVpcEndpointOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVpcEndpointForServiceRegion indicates an expected call of CreateVpcEndpointForServiceRegion
+func (mr *MockClientMockRecorder) CreateVpcEndpointForServiceRegion(ctx, input, serviceRegion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVpcEndpointForServiceRegion", reflect.TypeOf((*MockClient)(nil).CreateVpcEndpointForServiceRegion), ctx, input, serviceRegion)
 }
 
 // DescribeLoadBalancers mocks base method
-func (m *MockClient) DescribeLoadBalancers(arg0 *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
+func (m *MockClient) DescribeLoadBalancers(arg0 context.Context, arg1 *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DescribeLoadBalancers", arg0)
+	ret := m.ctrl.Call(m, "DescribeLoadBalancers", arg0, arg1)
 	ret0, _ := ret[0].(*elbv2.DescribeLoadBalancersOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DescribeLoadBalancers indicates an expected call of DescribeLoadBalancers
-func (mr *MockClientMockRecorder) DescribeLoadBalancers(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DescribeLoadBalancers(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeLoadBalancers", reflect.TypeOf((*MockClient)(nil).DescribeLoadBalancers), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeLoadBalancers", reflect.TypeOf((*MockClient)(nil).DescribeLoadBalancers), arg0, arg1)
 }
 
 // Upload mocks base method
-func (m *MockClient) Upload(arg0 *s3manager.UploadInput) (*s3manager.UploadOutput, error) {
+func (m *MockClient) Upload(arg0 context.Context, arg1 *s3manager.UploadInput) (*s3manager.UploadOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Upload", arg0)
+	ret := m.ctrl.Call(m, "Upload", arg0, arg1)
 	ret0, _ := ret[0].(*s3manager.UploadOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Upload indicates an expected call of Upload
-func (mr *MockClientMockRecorder) Upload(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) Upload(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockClient)(nil).Upload), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockClient)(nil).Upload), arg0, arg1)
 }
 
 // GetS3API mocks base method
@@ -324,225 +415,435 @@ func (mr *MockClientMockRecorder) GetS3API() *gomock.Call {
 }
 
 // CreateHostedZone mocks base method
-func (m *MockClient) CreateHostedZone(input *route53.CreateHostedZoneInput) (*route53.CreateHostedZoneOutput, error) {
+func (m *MockClient) CreateHostedZone(ctx context.Context, input *route53.CreateHostedZoneInput) (*route53.CreateHostedZoneOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateHostedZone", input)
+	ret := m.ctrl.Call(m, "CreateHostedZone", ctx, input)
 	ret0, _ := ret[0].(*route53.CreateHostedZoneOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateHostedZone indicates an expected call of CreateHostedZone
-func (mr *MockClientMockRecorder) CreateHostedZone(input interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) CreateHostedZone(ctx, input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHostedZone", reflect.TypeOf((*MockClient)(nil).CreateHostedZone), input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHostedZone", reflect.TypeOf((*MockClient)(nil).CreateHostedZone), ctx, input)
 }
 
 // GetHostedZone mocks base method
-func (m *MockClient) GetHostedZone(arg0 *route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error) {
+func (m *MockClient) GetHostedZone(arg0 context.Context, arg1 *route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetHostedZone", arg0)
+	ret := m.ctrl.Call(m, "GetHostedZone", arg0, arg1)
 	ret0, _ := ret[0].(*route53.GetHostedZoneOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetHostedZone indicates an expected call of GetHostedZone
-func (mr *MockClientMockRecorder) GetHostedZone(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) GetHostedZone(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostedZone", reflect.TypeOf((*MockClient)(nil).GetHostedZone), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostedZone", reflect.TypeOf((*MockClient)(nil).GetHostedZone), arg0, arg1)
 }
 
 // ListTagsForResource mocks base method
-func (m *MockClient) ListTagsForResource(arg0 *route53.ListTagsForResourceInput) (*route53.ListTagsForResourceOutput, error) {
+func (m *MockClient) ListTagsForResource(arg0 context.Context, arg1 *route53.ListTagsForResourceInput) (*route53.ListTagsForResourceOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListTagsForResource", arg0)
+	ret := m.ctrl.Call(m, "ListTagsForResource", arg0, arg1)
 	ret0, _ := ret[0].(*route53.ListTagsForResourceOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListTagsForResource indicates an expected call of ListTagsForResource
-func (mr *MockClientMockRecorder) ListTagsForResource(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) ListTagsForResource(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForResource", reflect.TypeOf((*MockClient)(nil).ListTagsForResource), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForResource", reflect.TypeOf((*MockClient)(nil).ListTagsForResource), arg0, arg1)
 }
 
 // ChangeTagsForResource mocks base method
-func (m *MockClient) ChangeTagsForResource(input *route53.ChangeTagsForResourceInput) (*route53.ChangeTagsForResourceOutput, error) {
+func (m *MockClient) ChangeTagsForResource(ctx context.Context, input *route53.ChangeTagsForResourceInput) (*route53.ChangeTagsForResourceOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ChangeTagsForResource", input)
+	ret := m.ctrl.Call(m, "ChangeTagsForResource", ctx, input)
 	ret0, _ := ret[0].(*route53.ChangeTagsForResourceOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ChangeTagsForResource indicates an expected call of ChangeTagsForResource
-func (mr *MockClientMockRecorder) ChangeTagsForResource(input interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) ChangeTagsForResource(ctx, input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeTagsForResource", reflect.TypeOf((*MockClient)(nil).ChangeTagsForResource), input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeTagsForResource", reflect.TypeOf((*MockClient)(nil).ChangeTagsForResource), ctx, input)
 }
 
 // DeleteHostedZone mocks base method
-func (m *MockClient) DeleteHostedZone(input *route53.DeleteHostedZoneInput) (*route53.DeleteHostedZoneOutput, error) {
+func (m *MockClient) DeleteHostedZone(ctx context.Context, input *route53.DeleteHostedZoneInput) (*route53.DeleteHostedZoneOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteHostedZone", input)
+	ret := m.ctrl.Call(m, "DeleteHostedZone", ctx, input)
 	ret0, _ := ret[0].(*route53.DeleteHostedZoneOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteHostedZone indicates an expected call of DeleteHostedZone
-func (mr *MockClientMockRecorder) DeleteHostedZone(input interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DeleteHostedZone(ctx, input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHostedZone", reflect.TypeOf((*MockClient)(nil).DeleteHostedZone), input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHostedZone", reflect.TypeOf((*MockClient)(nil).DeleteHostedZone), ctx, input)
 }
 
 // ListResourceRecordSets mocks base method
-func (m *MockClient) ListResourceRecordSets(input *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error) {
+func (m *MockClient) ListResourceRecordSets(ctx context.Context, input *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListResourceRecordSets", input)
+	ret := m.ctrl.Call(m, "ListResourceRecordSets", ctx, input)
 	ret0, _ := ret[0].(*route53.ListResourceRecordSetsOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListResourceRecordSets indicates an expected call of ListResourceRecordSets
-func (mr *MockClientMockRecorder) ListResourceRecordSets(input interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) ListResourceRecordSets(ctx, input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourceRecordSets", reflect.TypeOf((*MockClient)(nil).ListResourceRecordSets), input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourceRecordSets", reflect.TypeOf((*MockClient)(nil).ListResourceRecordSets), ctx, input)
 }
 
 // ListHostedZonesByName mocks base method
-func (m *MockClient) ListHostedZonesByName(input *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
+func (m *MockClient) ListHostedZonesByName(ctx context.Context, input *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListHostedZonesByName", input)
+	ret := m.ctrl.Call(m, "ListHostedZonesByName", ctx, input)
 	ret0, _ := ret[0].(*route53.ListHostedZonesByNameOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListHostedZonesByName indicates an expected call of ListHostedZonesByName
-func (mr *MockClientMockRecorder) ListHostedZonesByName(input interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) ListHostedZonesByName(ctx, input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHostedZonesByName", reflect.TypeOf((*MockClient)(nil).ListHostedZonesByName), input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHostedZonesByName", reflect.TypeOf((*MockClient)(nil).ListHostedZonesByName), ctx, input)
 }
 
 // ListHostedZonesByVPC mocks base method
-func (m *MockClient) ListHostedZonesByVPC(input *route53.ListHostedZonesByVPCInput) (*route53.ListHostedZonesByVPCOutput, error) {
+func (m *MockClient) ListHostedZonesByVPC(ctx context.Context, input *route53.ListHostedZonesByVPCInput) (*route53.ListHostedZonesByVPCOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListHostedZonesByVPC", input)
+	ret := m.ctrl.Call(m, "ListHostedZonesByVPC", ctx, input)
 	ret0, _ := ret[0].(*route53.ListHostedZonesByVPCOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListHostedZonesByVPC indicates an expected call of ListHostedZonesByVPC
-func (mr *MockClientMockRecorder) ListHostedZonesByVPC(input interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) ListHostedZonesByVPC(ctx, input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHostedZonesByVPC", reflect.TypeOf((*MockClient)(nil).ListHostedZonesByVPC), input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHostedZonesByVPC", reflect.TypeOf((*MockClient)(nil).ListHostedZonesByVPC), ctx, input)
 }
 
 // ChangeResourceRecordSets mocks base method
-func (m *MockClient) ChangeResourceRecordSets(arg0 *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+func (m *MockClient) ChangeResourceRecordSets(arg0 context.Context, arg1 *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ChangeResourceRecordSets", arg0)
+	ret := m.ctrl.Call(m, "ChangeResourceRecordSets", arg0, arg1)
 	ret0, _ := ret[0].(*route53.ChangeResourceRecordSetsOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ChangeResourceRecordSets indicates an expected call of ChangeResourceRecordSets
-func (mr *MockClientMockRecorder) ChangeResourceRecordSets(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) ChangeResourceRecordSets(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeResourceRecordSets", reflect.TypeOf((*MockClient)(nil).ChangeResourceRecordSets), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeResourceRecordSets", reflect.TypeOf((*MockClient)(nil).ChangeResourceRecordSets), arg0, arg1)
 }
 
 // CreateVPCAssociationAuthorization mocks base method
-func (m *MockClient) CreateVPCAssociationAuthorization(arg0 *route53.CreateVPCAssociationAuthorizationInput) (*route53.CreateVPCAssociationAuthorizationOutput, error) {
+func (m *MockClient) CreateVPCAssociationAuthorization(arg0 context.Context, arg1 *route53.CreateVPCAssociationAuthorizationInput) (*route53.CreateVPCAssociationAuthorizationOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateVPCAssociationAuthorization", arg0)
+	ret := m.ctrl.Call(m, "CreateVPCAssociationAuthorization", arg0, arg1)
 	ret0, _ := ret[0].(*route53.CreateVPCAssociationAuthorizationOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateVPCAssociationAuthorization indicates an expected call of CreateVPCAssociationAuthorization
-func (mr *MockClientMockRecorder) CreateVPCAssociationAuthorization(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) CreateVPCAssociationAuthorization(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVPCAssociationAuthorization", reflect.TypeOf((*MockClient)(nil).CreateVPCAssociationAuthorization), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVPCAssociationAuthorization", reflect.TypeOf((*MockClient)(nil).CreateVPCAssociationAuthorization), arg0, arg1)
 }
 
 // DeleteVPCAssociationAuthorization mocks base method
-func (m *MockClient) DeleteVPCAssociationAuthorization(arg0 *route53.DeleteVPCAssociationAuthorizationInput) (*route53.DeleteVPCAssociationAuthorizationOutput, error) {
+func (m *MockClient) DeleteVPCAssociationAuthorization(arg0 context.Context, arg1 *route53.DeleteVPCAssociationAuthorizationInput) (*route53.DeleteVPCAssociationAuthorizationOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteVPCAssociationAuthorization", arg0)
+	ret := m.ctrl.Call(m, "DeleteVPCAssociationAuthorization", arg0, arg1)
 	ret0, _ := ret[0].(*route53.DeleteVPCAssociationAuthorizationOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteVPCAssociationAuthorization indicates an expected call of DeleteVPCAssociationAuthorization
-func (mr *MockClientMockRecorder) DeleteVPCAssociationAuthorization(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DeleteVPCAssociationAuthorization(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVPCAssociationAuthorization", reflect.TypeOf((*MockClient)(nil).DeleteVPCAssociationAuthorization), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVPCAssociationAuthorization", reflect.TypeOf((*MockClient)(nil).DeleteVPCAssociationAuthorization), arg0, arg1)
 }
 
 // AssociateVPCWithHostedZone mocks base method
-func (m *MockClient) AssociateVPCWithHostedZone(arg0 *route53.AssociateVPCWithHostedZoneInput) (*route53.AssociateVPCWithHostedZoneOutput, error) {
+func (m *MockClient) AssociateVPCWithHostedZone(arg0 context.Context, arg1 *route53.AssociateVPCWithHostedZoneInput) (*route53.AssociateVPCWithHostedZoneOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AssociateVPCWithHostedZone", arg0)
+	ret := m.ctrl.Call(m, "AssociateVPCWithHostedZone", arg0, arg1)
 	ret0, _ := ret[0].(*route53.AssociateVPCWithHostedZoneOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AssociateVPCWithHostedZone indicates an expected call of AssociateVPCWithHostedZone
-func (mr *MockClientMockRecorder) AssociateVPCWithHostedZone(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) AssociateVPCWithHostedZone(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssociateVPCWithHostedZone", reflect.TypeOf((*MockClient)(nil).AssociateVPCWithHostedZone), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssociateVPCWithHostedZone", reflect.TypeOf((*MockClient)(nil).AssociateVPCWithHostedZone), arg0, arg1)
 }
 
 // DisassociateVPCFromHostedZone mocks base method
-func (m *MockClient) DisassociateVPCFromHostedZone(input *route53.DisassociateVPCFromHostedZoneInput) (*route53.DisassociateVPCFromHostedZoneOutput, error) {
+func (m *MockClient) DisassociateVPCFromHostedZone(ctx context.Context, input *route53.DisassociateVPCFromHostedZoneInput) (*route53.DisassociateVPCFromHostedZoneOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DisassociateVPCFromHostedZone", input)
+	ret := m.ctrl.Call(m, "DisassociateVPCFromHostedZone", ctx, input)
 	ret0, _ := ret[0].(*route53.DisassociateVPCFromHostedZoneOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DisassociateVPCFromHostedZone indicates an expected call of DisassociateVPCFromHostedZone
-func (mr *MockClientMockRecorder) DisassociateVPCFromHostedZone(input interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DisassociateVPCFromHostedZone(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateVPCFromHostedZone", reflect.TypeOf((*MockClient)(nil).DisassociateVPCFromHostedZone), ctx, input)
+}
+
+// GetDNSSEC mocks base method
+func (m *MockClient) GetDNSSEC(arg0 context.Context, arg1 *route53.GetDNSSECInput) (*route53.GetDNSSECOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDNSSEC", arg0, arg1)
+	ret0, _ := ret[0].(*route53.GetDNSSECOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDNSSEC indicates an expected call of GetDNSSEC
+func (mr *MockClientMockRecorder) GetDNSSEC(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDNSSEC", reflect.TypeOf((*MockClient)(nil).GetDNSSEC), arg0, arg1)
+}
+
+// CreateKeySigningKey mocks base method
+func (m *MockClient) CreateKeySigningKey(arg0 context.Context, arg1 *route53.CreateKeySigningKeyInput) (*route53.CreateKeySigningKeyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKeySigningKey", arg0, arg1)
+	ret0, _ := ret[0].(*route53.CreateKeySigningKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKeySigningKey indicates an expected call of CreateKeySigningKey
+func (mr *MockClientMockRecorder) CreateKeySigningKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKeySigningKey", reflect.TypeOf((*MockClient)(nil).CreateKeySigningKey), arg0, arg1)
+}
+
+// DeactivateKeySigningKey mocks base method
+func (m *MockClient) DeactivateKeySigningKey(arg0 context.Context, arg1 *route53.DeactivateKeySigningKeyInput) (*route53.DeactivateKeySigningKeyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateKeySigningKey", arg0, arg1)
+	ret0, _ := ret[0].(*route53.DeactivateKeySigningKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateKeySigningKey indicates an expected call of DeactivateKeySigningKey
+func (mr *MockClientMockRecorder) DeactivateKeySigningKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateKeySigningKey", reflect.TypeOf((*MockClient)(nil).DeactivateKeySigningKey), arg0, arg1)
+}
+
+// DeleteKeySigningKey mocks base method
+func (m *MockClient) DeleteKeySigningKey(arg0 context.Context, arg1 *route53.DeleteKeySigningKeyInput) (*route53.DeleteKeySigningKeyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKeySigningKey", arg0, arg1)
+	ret0, _ := ret[0].(*route53.DeleteKeySigningKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteKeySigningKey indicates an expected call of DeleteKeySigningKey
+func (mr *MockClientMockRecorder) DeleteKeySigningKey(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateVPCFromHostedZone", reflect.TypeOf((*MockClient)(nil).DisassociateVPCFromHostedZone), input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKeySigningKey", reflect.TypeOf((*MockClient)(nil).DeleteKeySigningKey), arg0, arg1)
+}
+
+// EnableHostedZoneDNSSEC mocks base method
+func (m *MockClient) EnableHostedZoneDNSSEC(arg0 context.Context, arg1 *route53.EnableHostedZoneDNSSECInput) (*route53.EnableHostedZoneDNSSECOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableHostedZoneDNSSEC", arg0, arg1)
+	ret0, _ := ret[0].(*route53.EnableHostedZoneDNSSECOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableHostedZoneDNSSEC indicates an expected call of EnableHostedZoneDNSSEC
+func (mr *MockClientMockRecorder) EnableHostedZoneDNSSEC(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableHostedZoneDNSSEC", reflect.TypeOf((*MockClient)(nil).EnableHostedZoneDNSSEC), arg0, arg1)
+}
+
+// DisableHostedZoneDNSSEC mocks base method
+func (m *MockClient) DisableHostedZoneDNSSEC(arg0 context.Context, arg1 *route53.DisableHostedZoneDNSSECInput) (*route53.DisableHostedZoneDNSSECOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableHostedZoneDNSSEC", arg0, arg1)
+	ret0, _ := ret[0].(*route53.DisableHostedZoneDNSSECOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableHostedZoneDNSSEC indicates an expected call of DisableHostedZoneDNSSEC
+func (mr *MockClientMockRecorder) DisableHostedZoneDNSSEC(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableHostedZoneDNSSEC", reflect.TypeOf((*MockClient)(nil).DisableHostedZoneDNSSEC), arg0, arg1)
+}
+
+// CreateHealthCheck mocks base method
+func (m *MockClient) CreateHealthCheck(arg0 context.Context, arg1 *route53.CreateHealthCheckInput) (*route53.CreateHealthCheckOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHealthCheck", arg0, arg1)
+	ret0, _ := ret[0].(*route53.CreateHealthCheckOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHealthCheck indicates an expected call of CreateHealthCheck
+func (mr *MockClientMockRecorder) CreateHealthCheck(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHealthCheck", reflect.TypeOf((*MockClient)(nil).CreateHealthCheck), arg0, arg1)
+}
+
+// GetHealthCheck mocks base method
+func (m *MockClient) GetHealthCheck(arg0 context.Context, arg1 *route53.GetHealthCheckInput) (*route53.GetHealthCheckOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHealthCheck", arg0, arg1)
+	ret0, _ := ret[0].(*route53.GetHealthCheckOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHealthCheck indicates an expected call of GetHealthCheck
+func (mr *MockClientMockRecorder) GetHealthCheck(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthCheck", reflect.TypeOf((*MockClient)(nil).GetHealthCheck), arg0, arg1)
+}
+
+// UpdateHealthCheck mocks base method
+func (m *MockClient) UpdateHealthCheck(arg0 context.Context, arg1 *route53.UpdateHealthCheckInput) (*route53.UpdateHealthCheckOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHealthCheck", arg0, arg1)
+	ret0, _ := ret[0].(*route53.UpdateHealthCheckOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateHealthCheck indicates an expected call of UpdateHealthCheck
+func (mr *MockClientMockRecorder) UpdateHealthCheck(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHealthCheck", reflect.TypeOf((*MockClient)(nil).UpdateHealthCheck), arg0, arg1)
+}
+
+// DeleteHealthCheck mocks base method
+func (m *MockClient) DeleteHealthCheck(arg0 context.Context, arg1 *route53.DeleteHealthCheckInput) (*route53.DeleteHealthCheckOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHealthCheck", arg0, arg1)
+	ret0, _ := ret[0].(*route53.DeleteHealthCheckOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteHealthCheck indicates an expected call of DeleteHealthCheck
+func (mr *MockClientMockRecorder) DeleteHealthCheck(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHealthCheck", reflect.TypeOf((*MockClient)(nil).DeleteHealthCheck), arg0, arg1)
+}
+
+// ListQueryLoggingConfigs mocks base method
+func (m *MockClient) ListQueryLoggingConfigs(arg0 context.Context, arg1 *route53.ListQueryLoggingConfigsInput) (*route53.ListQueryLoggingConfigsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQueryLoggingConfigs", arg0, arg1)
+	ret0, _ := ret[0].(*route53.ListQueryLoggingConfigsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQueryLoggingConfigs indicates an expected call of ListQueryLoggingConfigs
+func (mr *MockClientMockRecorder) ListQueryLoggingConfigs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQueryLoggingConfigs", reflect.TypeOf((*MockClient)(nil).ListQueryLoggingConfigs), arg0, arg1)
+}
+
+// CreateQueryLoggingConfig mocks base method
+func (m *MockClient) CreateQueryLoggingConfig(arg0 context.Context, arg1 *route53.CreateQueryLoggingConfigInput) (*route53.CreateQueryLoggingConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQueryLoggingConfig", arg0, arg1)
+	ret0, _ := ret[0].(*route53.CreateQueryLoggingConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateQueryLoggingConfig indicates an expected call of CreateQueryLoggingConfig
+func (mr *MockClientMockRecorder) CreateQueryLoggingConfig(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQueryLoggingConfig", reflect.TypeOf((*MockClient)(nil).CreateQueryLoggingConfig), arg0, arg1)
+}
+
+// DeleteQueryLoggingConfig mocks base method
+func (m *MockClient) DeleteQueryLoggingConfig(arg0 context.Context, arg1 *route53.DeleteQueryLoggingConfigInput) (*route53.DeleteQueryLoggingConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteQueryLoggingConfig", arg0, arg1)
+	ret0, _ := ret[0].(*route53.DeleteQueryLoggingConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteQueryLoggingConfig indicates an expected call of DeleteQueryLoggingConfig
+func (mr *MockClientMockRecorder) DeleteQueryLoggingConfig(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQueryLoggingConfig", reflect.TypeOf((*MockClient)(nil).DeleteQueryLoggingConfig), arg0, arg1)
 }
 
 // GetResourcesPages mocks base method
-func (m *MockClient) GetResourcesPages(input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
+func (m *MockClient) GetResourcesPages(ctx context.Context, input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetResourcesPages", input, fn)
+	ret := m.ctrl.Call(m, "GetResourcesPages", ctx, input, fn)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // GetResourcesPages indicates an expected call of GetResourcesPages
-func (mr *MockClientMockRecorder) GetResourcesPages(input, fn interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) GetResourcesPages(ctx, input, fn interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourcesPages", reflect.TypeOf((*MockClient)(nil).GetResourcesPages), input, fn)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourcesPages", reflect.TypeOf((*MockClient)(nil).GetResourcesPages), ctx, input, fn)
 }
 
 // GetCallerIdentity mocks base method
-func (m *MockClient) GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
+func (m *MockClient) GetCallerIdentity(ctx context.Context, input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCallerIdentity", input)
+	ret := m.ctrl.Call(m, "GetCallerIdentity", ctx, input)
 	ret0, _ := ret[0].(*sts.GetCallerIdentityOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCallerIdentity indicates an expected call of GetCallerIdentity
-func (mr *MockClientMockRecorder) GetCallerIdentity(input interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) GetCallerIdentity(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCallerIdentity", reflect.TypeOf((*MockClient)(nil).GetCallerIdentity), ctx, input)
+}
+
+// SimulatePrincipalPolicy mocks base method
+func (m *MockClient) SimulatePrincipalPolicy(ctx context.Context, input *iam.SimulatePrincipalPolicyInput) (*iam.SimulatePolicyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimulatePrincipalPolicy", ctx, input)
+	ret0, _ := ret[0].(*iam.SimulatePolicyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimulatePrincipalPolicy indicates an expected call of SimulatePrincipalPolicy
+func (mr *MockClientMockRecorder) SimulatePrincipalPolicy(ctx, input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCallerIdentity", reflect.TypeOf((*MockClient)(nil).GetCallerIdentity), input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulatePrincipalPolicy", reflect.TypeOf((*MockClient)(nil).SimulatePrincipalPolicy), ctx, input)
 }
@@ -0,0 +1,52 @@
+package awsclient
+
+import (
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// route53Regions are the regions to use for route53 operations in each partition. Route53 is a global service, whose
+// endpoint in a partition is resolved from any region of the partition.
+var route53Regions = map[string]string{
+	endpoints.AwsPartitionID:      constants.AWSRoute53Region,
+	endpoints.AwsCnPartitionID:    constants.AWSChinaRoute53Region,
+	endpoints.AwsUsGovPartitionID: constants.AWSGovCloudRoute53Region,
+	endpoints.AwsIsoPartitionID:   constants.AWSC2SRoute53Region,
+	endpoints.AwsIsoBPartitionID:  constants.AWSSC2SRoute53Region,
+}
+
+// Partition returns the ID of the AWS partition of the region, such as aws-us-gov for us-gov-west-1. Regions not
+// known to the AWS SDK are considered to be in the aws partition.
+func Partition(region string) string {
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); ok {
+		return p.ID()
+	}
+	return endpoints.AwsPartitionID
+}
+
+// Route53Region returns the region to use for route53 operations for resources in the region.
+func Route53Region(region string) string {
+	if r, ok := route53Regions[Partition(region)]; ok {
+		return r
+	}
+	return constants.AWSRoute53Region
+}
+
+// serviceEndpointsResolver returns an endpoint resolver using the service endpoints for the services they override,
+// and resolving the endpoints of the other services in the partition of the region.
+func serviceEndpointsResolver(serviceEndpoints []hivev1aws.ServiceEndpoint) endpoints.ResolverFunc {
+	return func(service, region string, optFns ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
+		for _, e := range serviceEndpoints {
+			if e.Name == service {
+				return endpoints.ResolvedEndpoint{
+					URL:           e.URL,
+					PartitionID:   Partition(region),
+					SigningRegion: region,
+				}, nil
+			}
+		}
+		return awsChinaEndpointResolver(service, region, optFns...)
+	}
+}
@@ -0,0 +1,84 @@
+package awsclient
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+)
+
+func TestRoute53Region(t *testing.T) {
+	cases := []struct {
+		region            string
+		expectedPartition string
+		expectedRegion    string
+	}{
+		{region: "us-east-2", expectedPartition: "aws", expectedRegion: "us-east-1"},
+		{region: "cn-north-1", expectedPartition: "aws-cn", expectedRegion: "cn-northwest-1"},
+		{region: "us-gov-east-1", expectedPartition: "aws-us-gov", expectedRegion: "us-gov-west-1"},
+		{region: "us-iso-west-1", expectedPartition: "aws-iso", expectedRegion: "us-iso-east-1"},
+		{region: "us-isob-east-1", expectedPartition: "aws-iso-b", expectedRegion: "us-isob-east-1"},
+		{region: "", expectedPartition: "aws", expectedRegion: "us-east-1"},
+	}
+	for _, test := range cases {
+		t.Run(test.region, func(t *testing.T) {
+			assert.Equal(t, test.expectedPartition, Partition(test.region), "unexpected partition")
+			assert.Equal(t, test.expectedRegion, Route53Region(test.region), "unexpected route53 region")
+		})
+	}
+}
+
+func TestServiceEndpointsResolver(t *testing.T) {
+	resolver := serviceEndpointsResolver([]hivev1aws.ServiceEndpoint{
+		{Name: ec2.EndpointsID, URL: "https://ec2.us-iso-east-1.c2s.ic.gov"},
+	})
+	cases := []struct {
+		name                  string
+		service               string
+		region                string
+		expectedURL           string
+		expectedSigningRegion string
+	}{
+		{
+			name:                  "overridden service",
+			service:               ec2.EndpointsID,
+			region:                "us-iso-east-1",
+			expectedURL:           "https://ec2.us-iso-east-1.c2s.ic.gov",
+			expectedSigningRegion: "us-iso-east-1",
+		},
+		{
+			name:                  "regional service in GovCloud",
+			service:               sts.EndpointsID,
+			region:                "us-gov-east-1",
+			expectedURL:           "https://sts.us-gov-east-1.amazonaws.com",
+			expectedSigningRegion: "us-gov-east-1",
+		},
+		{
+			name:                  "route53 in GovCloud",
+			service:               route53.EndpointsID,
+			region:                "us-gov-west-1",
+			expectedURL:           "https://route53.us-gov.amazonaws.com",
+			expectedSigningRegion: "us-gov-west-1",
+		},
+		{
+			name:                  "route53 in AWS China",
+			service:               route53.EndpointsID,
+			region:                "cn-northwest-1",
+			expectedURL:           "https://route53.amazonaws.com.cn",
+			expectedSigningRegion: "",
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			e, err := resolver.EndpointFor(test.service, test.region)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedURL, e.URL, "unexpected endpoint URL")
+			assert.Equal(t, test.expectedSigningRegion, e.SigningRegion, "unexpected signing region")
+		})
+	}
+}
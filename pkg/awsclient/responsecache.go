@@ -0,0 +1,122 @@
+package awsclient
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	defaultResponseCacheTTL        = 30 * time.Second
+	defaultResponseCacheMaxEntries = 10000
+)
+
+var (
+	metricAWSResponseCacheRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hive_aws_response_cache_requests_total",
+			Help: "Number of read-only AWS API calls looked up in the response cache, partitioned by function and by whether the response was cached.",
+		},
+		[]string{"function", "result"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricAWSResponseCacheRequests)
+}
+
+var (
+	responseCacheOnce sync.Once
+
+	// responseCache holds the responses of read-only API calls, keyed by access key ID, region, function and input.
+	// It is nil when the cache is not enabled in HiveConfig.
+	responseCache *controllerutils.ExpiringCache
+)
+
+// getResponseCache returns the cache of the responses of read-only API calls configured by the HIVE_AWS_CLIENT_CACHE
+// environment variable, or nil if the cache is not enabled.
+func getResponseCache() *controllerutils.ExpiringCache {
+	responseCacheOnce.Do(func() {
+		value := os.Getenv(constants.AWSClientCacheEnvVar)
+		if value == "" {
+			return
+		}
+		config := hivev1.AWSClientCacheConfig{}
+		if err := json.Unmarshal([]byte(value), &config); err != nil {
+			log.WithError(err).WithField("config", value).Errorf("unable to parse %s, using defaults", constants.AWSClientCacheEnvVar)
+			config = hivev1.AWSClientCacheConfig{}
+		}
+		responseCache = newResponseCache(config)
+	})
+	return responseCache
+}
+
+func newResponseCache(config hivev1.AWSClientCacheConfig) *controllerutils.ExpiringCache {
+	ttl := defaultResponseCacheTTL
+	if config.TTL != nil && config.TTL.Duration > 0 {
+		ttl = config.TTL.Duration
+	}
+	maxEntries := defaultResponseCacheMaxEntries
+	if config.MaxEntries != nil && *config.MaxEntries > 0 {
+		maxEntries = int(*config.MaxEntries)
+	}
+	return controllerutils.NewExpiringCache("aws_responses", ttl, maxEntries)
+}
+
+// cachedCall returns a copy of the cached response of the read-only function for the input, or calls it and caches a
+// copy of its response. The function is always called when the cache is not enabled, or when the access key of the
+// client cannot be determined.
+func (c *awsClient) cachedCall(function string, input interface{}, call func() (interface{}, error)) (interface{}, error) {
+	key, ok := c.responseCacheKey(function, input)
+	if !ok {
+		return call()
+	}
+	loaded := false
+	output, err := c.responseCache.GetOrLoad(key, func() (interface{}, error) {
+		loaded = true
+		output, err := call()
+		if err != nil {
+			return nil, err
+		}
+		return awsutil.CopyOf(output), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := "hit"
+	if loaded {
+		result = "miss"
+	}
+	metricAWSResponseCacheRequests.WithLabelValues(function, result).Inc()
+	// Callers may modify the response, so they each get their own copy.
+	return awsutil.CopyOf(output), nil
+}
+
+// responseCacheKey returns the key of the cached response of the function for the input.
+func (c *awsClient) responseCacheKey(function string, input interface{}) (string, bool) {
+	if c.responseCache == nil || c.credentials == nil {
+		return "", false
+	}
+	// The credentials are cached by the session, so this only calls AWS when they have expired.
+	creds, err := c.credentials.Get()
+	if err != nil || creds.AccessKeyID == "" {
+		return "", false
+	}
+	inputJSON, err := json.Marshal(input)
+	if err != nil {
+		return "", false
+	}
+	return creds.AccessKeyID + "/" + c.region + "/" + function + "/" + string(inputJSON), true
+}
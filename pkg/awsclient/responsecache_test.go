@@ -0,0 +1,87 @@
+package awsclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestResponseCache(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path == "/2013-04-01/hostedzone/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<ErrorResponse><Error><Type>Sender</Type><Code>NoSuchHostedZone</Code><Message>not found</Message></Error><RequestId>1</RequestId></ErrorResponse>`)
+			return
+		}
+		fmt.Fprint(w, `<GetHostedZoneResponse><HostedZone><Id>/hostedzone/1234</Id><Name>example.com.</Name><CallerReference>ref</CallerReference></HostedZone></GetHostedZoneResponse>`)
+	}))
+	defer server.Close()
+
+	cache := newResponseCache(hivev1.AWSClientCacheConfig{})
+	newCachingClient := func(accessKeyID string) Client {
+		c, err := newClientFromSecret(nil, "us-east-1", &aws.Config{
+			Endpoint:    aws.String(server.URL),
+			Credentials: credentials.NewStaticCredentials(accessKeyID, "secret", ""),
+		})
+		require.NoError(t, err, "unexpected error creating client")
+		c.(*awsClient).responseCache = cache
+		return c
+	}
+	c := newCachingClient("id")
+	hits := testutil.ToFloat64(metricAWSResponseCacheRequests.WithLabelValues("GetHostedZone", "hit"))
+
+	out, err := c.GetHostedZone(context.TODO(), &route53.GetHostedZoneInput{Id: aws.String("1234")})
+	require.NoError(t, err)
+	assert.Equal(t, "/hostedzone/1234", aws.StringValue(out.HostedZone.Id))
+	out.HostedZone.Id = aws.String("modified")
+
+	out, err = c.GetHostedZone(context.TODO(), &route53.GetHostedZoneInput{Id: aws.String("1234")})
+	require.NoError(t, err)
+	assert.Equal(t, "/hostedzone/1234", aws.StringValue(out.HostedZone.Id), "cached response modified by the caller")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests), "expected the second call to be cached")
+	assert.Equal(t, hits+1, testutil.ToFloat64(metricAWSResponseCacheRequests.WithLabelValues("GetHostedZone", "hit")), "expected a cache hit")
+
+	_, err = newCachingClient("other-id").GetHostedZone(context.TODO(), &route53.GetHostedZoneInput{Id: aws.String("1234")})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests), "expected responses not to be shared between credentials")
+
+	for i := 0; i < 2; i++ {
+		_, err = c.GetHostedZone(context.TODO(), &route53.GetHostedZoneInput{Id: aws.String("missing")})
+		assert.Error(t, err, "expected error for a missing zone")
+	}
+	assert.Equal(t, int32(4), atomic.LoadInt32(&requests), "expected errors not to be cached")
+}
+
+func TestResponseCacheDisabled(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprint(w, `<GetHostedZoneResponse><HostedZone><Id>/hostedzone/1234</Id><Name>example.com.</Name><CallerReference>ref</CallerReference></HostedZone></GetHostedZoneResponse>`)
+	}))
+	defer server.Close()
+
+	c, err := newClientFromSecret(nil, "us-east-1", &aws.Config{
+		Endpoint:    aws.String(server.URL),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+	})
+	require.NoError(t, err, "unexpected error creating client")
+	for i := 0; i < 2; i++ {
+		_, err := c.GetHostedZone(context.TODO(), &route53.GetHostedZoneInput{Id: aws.String("1234")})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests), "expected no caching when the cache is not enabled")
+}
@@ -0,0 +1,146 @@
+package awsclient
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/route53"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// defaultRoute53QPS and defaultRoute53Burst match the rate at which Route53 throttles the requests of an account.
+	defaultRoute53QPS   = 5
+	defaultRoute53Burst = 5
+
+	defaultRoute53MaxRetries = 8
+
+	route53MinThrottleDelay = 500 * time.Millisecond
+	route53MaxThrottleDelay = 30 * time.Second
+)
+
+var (
+	metricAWSRoute53Throttled = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hive_aws_route53_throttled_requests_total",
+			Help: "Number of Route53 requests throttled by AWS, partitioned by operation.",
+		},
+		[]string{"operation"},
+	)
+	metricAWSRoute53RateLimitWait = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "hive_aws_route53_rate_limit_wait_seconds",
+			Help:    "Time Route53 requests waited for the client-side rate limiter of their credentials.",
+			Buckets: []float64{0.01, 0.1, 0.5, 1, 5, 10, 30, 60},
+		},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricAWSRoute53Throttled)
+	metrics.Registry.MustRegister(metricAWSRoute53RateLimitWait)
+}
+
+var (
+	route53RateLimitOnce   sync.Once
+	route53RateLimitConfig hivev1.AWSRoute53RateLimitConfig
+
+	// route53RateLimiters holds the rate limiter of each set of credentials, keyed by access key ID. The limiters of
+	// the temporary credentials of assumed roles are dropped once the credentials have expired.
+	route53RateLimiters = controllerutils.NewExpiringCache("aws_route53_rate_limiters", 2*time.Hour, 10000)
+)
+
+// getRoute53RateLimitConfig returns the Route53 rate limit configuration read from the HIVE_AWS_ROUTE53_RATE_LIMIT
+// environment variable, with the defaults set.
+func getRoute53RateLimitConfig() hivev1.AWSRoute53RateLimitConfig {
+	route53RateLimitOnce.Do(func() {
+		route53RateLimitConfig = readRoute53RateLimitConfig()
+	})
+	return route53RateLimitConfig
+}
+
+func readRoute53RateLimitConfig() hivev1.AWSRoute53RateLimitConfig {
+	config := hivev1.AWSRoute53RateLimitConfig{}
+	if value := os.Getenv(constants.AWSRoute53RateLimitEnvVar); value != "" {
+		if err := json.Unmarshal([]byte(value), &config); err != nil {
+			log.WithError(err).WithField("config", value).Errorf("unable to parse %s, using defaults", constants.AWSRoute53RateLimitEnvVar)
+			config = hivev1.AWSRoute53RateLimitConfig{}
+		}
+	}
+	if config.QPS == nil || *config.QPS < 1 {
+		config.QPS = aws.Int32(defaultRoute53QPS)
+	}
+	if config.Burst == nil || *config.Burst < 1 {
+		config.Burst = aws.Int32(defaultRoute53Burst)
+	}
+	if config.MaxRetries == nil || *config.MaxRetries < 0 {
+		config.MaxRetries = aws.Int32(defaultRoute53MaxRetries)
+	}
+	return config
+}
+
+// newRoute53Client creates a Route53 client whose requests are rate limited per set of credentials, and retried with
+// exponential backoff when throttled.
+func newRoute53Client(s *session.Session, cfgs ...*aws.Config) *route53.Route53 {
+	config := getRoute53RateLimitConfig()
+	retryer := client.DefaultRetryer{
+		NumMaxRetries:    int(*config.MaxRetries),
+		MinThrottleDelay: route53MinThrottleDelay,
+		MaxThrottleDelay: route53MaxThrottleDelay,
+	}
+	cfgs = append(cfgs, request.WithRetryer(aws.NewConfig(), retryer))
+	svc := route53.New(s, cfgs...)
+	// Send handlers run for each attempt, so that retries are rate limited too.
+	svc.Handlers.Send.PushFrontNamed(request.NamedHandler{
+		Name: "openshift.io/hive/route53RateLimit",
+		Fn:   waitForRoute53RateLimit,
+	})
+	svc.Handlers.Retry.PushFrontNamed(request.NamedHandler{
+		Name: "openshift.io/hive/route53Throttled",
+		Fn:   countRoute53Throttled,
+	})
+	return svc
+}
+
+// waitForRoute53RateLimit waits for the rate limiter of the credentials of the request.
+func waitForRoute53RateLimit(r *request.Request) {
+	key := ""
+	if r.Config.Credentials != nil {
+		// The credentials were already retrieved to sign the request, so this does not call AWS.
+		if creds, err := r.Config.Credentials.Get(); err == nil {
+			key = creds.AccessKeyID
+		}
+	}
+	limiter, _ := route53RateLimiters.GetOrLoad(key, func() (interface{}, error) {
+		config := getRoute53RateLimitConfig()
+		return flowcontrol.NewTokenBucketRateLimiter(float32(*config.QPS), int(*config.Burst)), nil
+	})
+	start := time.Now()
+	if err := limiter.(flowcontrol.RateLimiter).Wait(r.Context()); err != nil {
+		r.Error = err
+		return
+	}
+	metricAWSRoute53RateLimitWait.Observe(time.Since(start).Seconds())
+}
+
+// countRoute53Throttled counts the requests throttled by Route53.
+func countRoute53Throttled(r *request.Request) {
+	if request.IsErrorThrottle(r.Error) {
+		metricAWSRoute53Throttled.WithLabelValues(r.Operation.Name).Inc()
+	}
+}
@@ -0,0 +1,90 @@
+package awsclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestRoute53RetriesThrottledRequests(t *testing.T) {
+	// The first request is throttled.
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `<ErrorResponse><Error><Type>Sender</Type><Code>Throttling</Code><Message>Rate exceeded</Message></Error><RequestId>1</RequestId></ErrorResponse>`)
+			return
+		}
+		fmt.Fprint(w, `<GetHostedZoneResponse><HostedZone><Id>/hostedzone/1234</Id><Name>example.com.</Name><CallerReference>ref</CallerReference></HostedZone></GetHostedZoneResponse>`)
+	}))
+	defer server.Close()
+
+	c, err := newClientFromSecret(nil, "us-east-1", &aws.Config{
+		Endpoint:    aws.String(server.URL),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+	})
+	require.NoError(t, err, "unexpected error creating client")
+
+	throttled := testutil.ToFloat64(metricAWSRoute53Throttled.WithLabelValues("GetHostedZone"))
+	out, err := c.GetHostedZone(context.TODO(), &route53.GetHostedZoneInput{Id: aws.String("1234")})
+	require.NoError(t, err, "expected the throttled request to be retried")
+	assert.Equal(t, "/hostedzone/1234", aws.StringValue(out.HostedZone.Id))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests), "expected a single retry")
+	assert.Equal(t, throttled+1, testutil.ToFloat64(metricAWSRoute53Throttled.WithLabelValues("GetHostedZone")), "expected the throttled request to be counted")
+}
+
+func TestReadRoute53RateLimitConfig(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    string
+		expected hivev1.AWSRoute53RateLimitConfig
+	}{
+		{
+			name: "defaults",
+			expected: hivev1.AWSRoute53RateLimitConfig{
+				QPS:        aws.Int32(5),
+				Burst:      aws.Int32(5),
+				MaxRetries: aws.Int32(8),
+			},
+		},
+		{
+			name:  "configured",
+			value: `{"qps":2,"burst":4,"maxRetries":0}`,
+			expected: hivev1.AWSRoute53RateLimitConfig{
+				QPS:        aws.Int32(2),
+				Burst:      aws.Int32(4),
+				MaxRetries: aws.Int32(0),
+			},
+		},
+		{
+			name:  "invalid",
+			value: `{"qps":"fast"}`,
+			expected: hivev1.AWSRoute53RateLimitConfig{
+				QPS:        aws.Int32(5),
+				Burst:      aws.Int32(5),
+				MaxRetries: aws.Int32(8),
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Setenv(constants.AWSRoute53RateLimitEnvVar, tc.value)
+			defer os.Unsetenv(constants.AWSRoute53RateLimitEnvVar)
+			assert.Equal(t, tc.expected, readRoute53RateLimitConfig())
+		})
+	}
+}
@@ -4,16 +4,23 @@ import (
 	"context"
 	"encoding/json"
 	"io/ioutil"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 //go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
@@ -32,10 +39,27 @@ type Client interface {
 	CreateOrUpdateRecordSet(ctx context.Context, resourceGroupName string, zone string, recordSetName string, recordType dns.RecordType, recordSet dns.RecordSet) (dns.RecordSet, error)
 	DeleteRecordSet(ctx context.Context, resourceGroupName string, zone string, recordSetName string, recordType dns.RecordType) error
 
+	// Private Zones
+	CreateOrUpdatePrivateZone(ctx context.Context, resourceGroupName string, zone string) (privatedns.PrivateZone, error)
+	DeletePrivateZone(ctx context.Context, resourceGroupName string, zone string) error
+	GetPrivateZone(ctx context.Context, resourceGroupName string, zone string) (privatedns.PrivateZone, error)
+
+	// Private RecordSets
+	ListPrivateRecordSets(ctx context.Context, resourceGroupName string, zone string) (PrivateRecordSetPage, error)
+	DeletePrivateRecordSet(ctx context.Context, resourceGroupName string, zone string, recordSetName string, recordType privatedns.RecordType) error
+
+	// Virtual Network Links
+	ListVirtualNetworkLinks(ctx context.Context, resourceGroupName string, zone string) (VirtualNetworkLinkPage, error)
+	CreateOrUpdateVirtualNetworkLink(ctx context.Context, resourceGroupName string, zone string, linkName string, link privatedns.VirtualNetworkLink) (privatedns.VirtualNetworkLink, error)
+	DeleteVirtualNetworkLink(ctx context.Context, resourceGroupName string, zone string, linkName string) error
+
 	// Virtual Machines
 	ListAllVirtualMachines(ctx context.Context, statusOnly string) (compute.VirtualMachineListResultPage, error)
 	DeallocateVirtualMachine(ctx context.Context, resourceGroup, name string) (compute.VirtualMachinesDeallocateFuture, error)
 	StartVirtualMachine(ctx context.Context, resourceGroup, name string) (compute.VirtualMachinesStartFuture, error)
+
+	// Public IP Addresses
+	GetPublicIPAddress(ctx context.Context, resourceGroupName string, name string) (network.PublicIPAddress, error)
 }
 
 // ResourceSKUsPage is a page of results from listing resource SKUs.
@@ -52,19 +76,51 @@ type RecordSetPage interface {
 	Values() []dns.RecordSet
 }
 
+const (
+	// defaultCallTimeout is the timeout of each API call, so that a stuck call does not block the caller until its
+	// context is done.
+	defaultCallTimeout = 2 * time.Minute
+)
+
+func contextWithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, defaultCallTimeout)
+}
+
+// PrivateRecordSetPage is a page of results from listing the record sets of a private zone.
+type PrivateRecordSetPage interface {
+	NextWithContext(ctx context.Context) error
+	NotDone() bool
+	Values() []privatedns.RecordSet
+}
+
+// VirtualNetworkLinkPage is a page of results from listing the virtual network links of a private zone.
+type VirtualNetworkLinkPage interface {
+	NextWithContext(ctx context.Context) error
+	NotDone() bool
+	Values() []privatedns.VirtualNetworkLink
+}
+
 type azureClient struct {
-	resourceSKUsClient    *compute.ResourceSkusClient
-	recordSetsClient      *dns.RecordSetsClient
-	zonesClient           *dns.ZonesClient
-	virtualMachinesClient *compute.VirtualMachinesClient
+	resourceSKUsClient        *compute.ResourceSkusClient
+	recordSetsClient          *dns.RecordSetsClient
+	zonesClient               *dns.ZonesClient
+	virtualMachinesClient     *compute.VirtualMachinesClient
+	publicIPAddressesClient   *network.PublicIPAddressesClient
+	privateZonesClient        *privatedns.PrivateZonesClient
+	privateRecordSetsClient   *privatedns.RecordSetsClient
+	virtualNetworkLinksClient *privatedns.VirtualNetworkLinksClient
 }
 
 func (c *azureClient) ListResourceSKUs(ctx context.Context, filter string) (ResourceSKUsPage, error) {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
 	page, err := c.resourceSKUsClient.List(ctx, filter)
 	return &page, err
 }
 
 func (c *azureClient) CreateOrUpdateZone(ctx context.Context, resourceGroupName string, zone string) (dns.Zone, error) {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
 	return c.zonesClient.CreateOrUpdate(ctx, resourceGroupName, zone, dns.Zone{
 		Location: to.StringPtr("global"),
 		ZoneProperties: &dns.ZoneProperties{
@@ -74,62 +130,193 @@ func (c *azureClient) CreateOrUpdateZone(ctx context.Context, resourceGroupName
 }
 
 func (c *azureClient) DeleteZone(ctx context.Context, resourceGroupName string, zone string) error {
-	future, err := c.zonesClient.Delete(ctx, resourceGroupName, zone, "")
+	callCtx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	future, err := c.zonesClient.Delete(callCtx, resourceGroupName, zone, "")
 	if err != nil {
 		return err
 	}
 
+	// Waiting for the deletion polls the operation until the polling duration of the client, so it is only cancelled
+	// with the context of the caller.
 	return future.WaitForCompletionRef(ctx, c.zonesClient.Client)
 }
 
 func (c *azureClient) DeleteRecordSet(ctx context.Context, resourceGroupName string, zone string, recordSetName string, recordType dns.RecordType) error {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
 	_, err := c.recordSetsClient.Delete(ctx, resourceGroupName, zone, recordSetName, recordType, "")
 	return err
 }
 
 func (c *azureClient) ListRecordSetsByZone(ctx context.Context, resourceGroupName string, zone string, suffix string) (RecordSetPage, error) {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
 	page, err := c.recordSetsClient.ListByDNSZone(ctx, resourceGroupName, zone, nil, suffix)
 	return &page, err
 }
 
 func (c *azureClient) GetZone(ctx context.Context, resourceGroupName string, zone string) (dns.Zone, error) {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
 	return c.zonesClient.Get(ctx, resourceGroupName, zone)
 }
 
 func (c *azureClient) CreateOrUpdateRecordSet(ctx context.Context, resourceGroupName string, zone string, recordSetName string, recordType dns.RecordType, recordSet dns.RecordSet) (dns.RecordSet, error) {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
 	return c.recordSetsClient.CreateOrUpdate(ctx, resourceGroupName, zone, recordSetName, recordType, recordSet, "", "")
 }
 
 func (c *azureClient) ListAllVirtualMachines(ctx context.Context, statusOnly string) (compute.VirtualMachineListResultPage, error) {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
 	return c.virtualMachinesClient.ListAll(ctx, statusOnly)
 }
 
 func (c *azureClient) DeallocateVirtualMachine(ctx context.Context, resourceGroup, name string) (compute.VirtualMachinesDeallocateFuture, error) {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
 	return c.virtualMachinesClient.Deallocate(ctx, resourceGroup, name)
 }
 
 func (c *azureClient) StartVirtualMachine(ctx context.Context, resourceGroup, name string) (compute.VirtualMachinesStartFuture, error) {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
 	return c.virtualMachinesClient.Start(ctx, resourceGroup, name)
 }
 
+func (c *azureClient) GetPublicIPAddress(ctx context.Context, resourceGroupName string, name string) (network.PublicIPAddress, error) {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.publicIPAddressesClient.Get(ctx, resourceGroupName, name, "")
+}
+
+// CreateOrUpdatePrivateZone creates or updates the private zone and waits for the operation to complete.
+func (c *azureClient) CreateOrUpdatePrivateZone(ctx context.Context, resourceGroupName string, zone string) (privatedns.PrivateZone, error) {
+	callCtx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	future, err := c.privateZonesClient.CreateOrUpdate(callCtx, resourceGroupName, zone, privatedns.PrivateZone{
+		Location: to.StringPtr("global"),
+	}, "", "")
+	if err != nil {
+		return privatedns.PrivateZone{}, err
+	}
+	if err := future.WaitForCompletionRef(ctx, c.privateZonesClient.Client); err != nil {
+		return privatedns.PrivateZone{}, err
+	}
+	return future.Result(*c.privateZonesClient)
+}
+
+// DeletePrivateZone deletes the private zone and waits for the operation to complete. The virtual network links of
+// the zone must be deleted first.
+func (c *azureClient) DeletePrivateZone(ctx context.Context, resourceGroupName string, zone string) error {
+	callCtx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	future, err := c.privateZonesClient.Delete(callCtx, resourceGroupName, zone, "")
+	if err != nil {
+		return err
+	}
+	return future.WaitForCompletionRef(ctx, c.privateZonesClient.Client)
+}
+
+func (c *azureClient) GetPrivateZone(ctx context.Context, resourceGroupName string, zone string) (privatedns.PrivateZone, error) {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.privateZonesClient.Get(ctx, resourceGroupName, zone)
+}
+
+func (c *azureClient) ListPrivateRecordSets(ctx context.Context, resourceGroupName string, zone string) (PrivateRecordSetPage, error) {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	page, err := c.privateRecordSetsClient.List(ctx, resourceGroupName, zone, nil, "")
+	return &page, err
+}
+
+func (c *azureClient) DeletePrivateRecordSet(ctx context.Context, resourceGroupName string, zone string, recordSetName string, recordType privatedns.RecordType) error {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	_, err := c.privateRecordSetsClient.Delete(ctx, resourceGroupName, zone, recordType, recordSetName, "")
+	return err
+}
+
+func (c *azureClient) ListVirtualNetworkLinks(ctx context.Context, resourceGroupName string, zone string) (VirtualNetworkLinkPage, error) {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	page, err := c.virtualNetworkLinksClient.List(ctx, resourceGroupName, zone, nil)
+	return &page, err
+}
+
+// CreateOrUpdateVirtualNetworkLink creates or updates the virtual network link of the private zone and waits for the
+// operation to complete.
+func (c *azureClient) CreateOrUpdateVirtualNetworkLink(ctx context.Context, resourceGroupName string, zone string, linkName string, link privatedns.VirtualNetworkLink) (privatedns.VirtualNetworkLink, error) {
+	callCtx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	future, err := c.virtualNetworkLinksClient.CreateOrUpdate(callCtx, resourceGroupName, zone, linkName, link, "", "")
+	if err != nil {
+		return privatedns.VirtualNetworkLink{}, err
+	}
+	if err := future.WaitForCompletionRef(ctx, c.virtualNetworkLinksClient.Client); err != nil {
+		return privatedns.VirtualNetworkLink{}, err
+	}
+	return future.Result(*c.virtualNetworkLinksClient)
+}
+
+// DeleteVirtualNetworkLink deletes the virtual network link of the private zone and waits for the operation to
+// complete.
+func (c *azureClient) DeleteVirtualNetworkLink(ctx context.Context, resourceGroupName string, zone string, linkName string) error {
+	callCtx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	future, err := c.virtualNetworkLinksClient.Delete(callCtx, resourceGroupName, zone, linkName, "")
+	if err != nil {
+		return err
+	}
+	return future.WaitForCompletionRef(ctx, c.virtualNetworkLinksClient.Client)
+}
+
 // NewClientFromSecret creates our client wrapper object for interacting with Azure. The Azure creds are read from the
-// specified secret.
-func NewClientFromSecret(secret *corev1.Secret) (Client, error) {
-	return newClient(authJSONFromSecretSource(secret))
+// specified secret, and the Azure API endpoints are those of the given cloud environment.
+func NewClientFromSecret(secret *corev1.Secret, cloudName hivev1azure.CloudEnvironment) (Client, error) {
+	return newClient(authJSONFromSecretSource(secret), cloudName, Options{})
+}
+
+// Options are the options of the client created by NewClientFromSecretWithOptions.
+type Options struct {
+	// Proxy is the proxy through which the client calls Azure. When it is nil, the CloudClientProxy of HiveConfig is
+	// used, if any.
+	Proxy *controllerutils.CloudClientProxy
+
+	// Controller is the name of the controller on behalf of which the client calls Azure, which labels the metrics of
+	// the calls.
+	Controller hivev1.ControllerName
+}
+
+// NewClientFromSecretWithOptions creates our client wrapper object for interacting with Azure with the options. The
+// Azure creds are read from the specified secret, and the Azure API endpoints are those of the given cloud environment.
+func NewClientFromSecretWithOptions(secret *corev1.Secret, cloudName hivev1azure.CloudEnvironment, options Options) (Client, error) {
+	return newClient(authJSONFromSecretSource(secret), cloudName, options)
 }
 
 // NewClientFromFile creates our client wrapper object for interacting with Azure. The Azure creds are read from the
-// specified file.
-func NewClientFromFile(filename string) (Client, error) {
-	return newClient(authJSONFromFileSource(filename))
+// specified file, and the Azure API endpoints are those of the given cloud environment.
+func NewClientFromFile(filename string, cloudName hivev1azure.CloudEnvironment) (Client, error) {
+	return newClient(authJSONFromFileSource(filename), cloudName, Options{})
 }
 
-// NewClient creates our client wrapper object for interacting with Azure using the Azure creds provided.
-func NewClient(creds []byte) (Client, error) {
-	return newClient(authJSONFromBytes(creds))
+// NewClient creates our client wrapper object for interacting with Azure using the Azure creds provided, and the
+// Azure API endpoints of the given cloud environment.
+func NewClient(creds []byte, cloudName hivev1azure.CloudEnvironment) (Client, error) {
+	return newClient(authJSONFromBytes(creds), cloudName, Options{})
 }
 
-func newClient(authJSONSource func() ([]byte, error)) (*azureClient, error) {
+// newClient creates the client with the creds of the source. The calls go through the proxy of the options, or through
+// the CloudClientProxy of HiveConfig when the proxy is nil.
+func newClient(authJSONSource func() ([]byte, error), cloudName hivev1azure.CloudEnvironment, options Options) (*azureClient, error) {
+	env, err := azure.EnvironmentFromName(cloudName.Name())
+	if err != nil {
+		return nil, err
+	}
+
 	authJSON, err := authJSONSource()
 	if err != nil {
 		return nil, err
@@ -155,30 +342,65 @@ func newClient(authJSONSource func() ([]byte, error)) (*azureClient, error) {
 		return nil, errors.New("missing subscriptionId in auth")
 	}
 
+	proxy := options.Proxy
+	if proxy == nil {
+		if proxy, err = controllerutils.ReadCloudClientProxyFiles(); err != nil {
+			return nil, err
+		}
+	}
+	httpClient, err := controllerutils.NewCloudAPIHTTPClient(proxy, options.Controller, controllerutils.CloudAzure)
+	if err != nil {
+		return nil, err
+	}
+
 	config := auth.NewClientCredentialsConfig(clientID, clientSecret, tenantID)
+	config.AADEndpoint = env.ActiveDirectoryEndpoint
+	config.Resource = env.ResourceManagerEndpoint
 
-	authorizer, err := config.Authorizer()
+	spToken, err := config.ServicePrincipalToken()
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(err, "failed to get SPT from client credentials")
+	}
+	spToken.SetSender(httpClient)
+	authorizer := autorest.NewBearerAuthorizer(spToken)
+	configure := func(c *autorest.Client) {
+		c.Authorizer = authorizer
+		c.Sender = httpClient
 	}
 
-	resourceSKUsClient := compute.NewResourceSkusClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
-	resourceSKUsClient.Authorizer = authorizer
+	resourceSKUsClient := compute.NewResourceSkusClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	configure(&resourceSKUsClient.Client)
+
+	recordSetsClient := dns.NewRecordSetsClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	configure(&recordSetsClient.Client)
+
+	zonesClient := dns.NewZonesClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	configure(&zonesClient.Client)
+
+	virtualMachinesClient := compute.NewVirtualMachinesClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	configure(&virtualMachinesClient.Client)
+
+	publicIPAddressesClient := network.NewPublicIPAddressesClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	configure(&publicIPAddressesClient.Client)
 
-	recordSetsClient := dns.NewRecordSetsClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
-	recordSetsClient.Authorizer = authorizer
+	privateZonesClient := privatedns.NewPrivateZonesClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	configure(&privateZonesClient.Client)
 
-	zonesClient := dns.NewZonesClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
-	zonesClient.Authorizer = authorizer
+	privateRecordSetsClient := privatedns.NewRecordSetsClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	configure(&privateRecordSetsClient.Client)
 
-	virtualMachinesClient := compute.NewVirtualMachinesClientWithBaseURI(azure.PublicCloud.ResourceManagerEndpoint, subscriptionID)
-	virtualMachinesClient.Authorizer = authorizer
+	virtualNetworkLinksClient := privatedns.NewVirtualNetworkLinksClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	configure(&virtualNetworkLinksClient.Client)
 
 	return &azureClient{
-		resourceSKUsClient:    &resourceSKUsClient,
-		recordSetsClient:      &recordSetsClient,
-		zonesClient:           &zonesClient,
-		virtualMachinesClient: &virtualMachinesClient,
+		resourceSKUsClient:        &resourceSKUsClient,
+		recordSetsClient:          &recordSetsClient,
+		zonesClient:               &zonesClient,
+		virtualMachinesClient:     &virtualMachinesClient,
+		publicIPAddressesClient:   &publicIPAddressesClient,
+		privateZonesClient:        &privateZonesClient,
+		privateRecordSetsClient:   &privateRecordSetsClient,
+		virtualNetworkLinksClient: &virtualNetworkLinksClient,
 	}, nil
 }
 
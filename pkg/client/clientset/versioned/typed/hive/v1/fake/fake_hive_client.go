@@ -24,6 +24,10 @@ func (c *FakeHiveV1) ClusterDeployments(namespace string) v1.ClusterDeploymentIn
 	return &FakeClusterDeployments{c, namespace}
 }
 
+func (c *FakeHiveV1) ClusterDeploymentSummaries() v1.ClusterDeploymentSummaryInterface {
+	return &FakeClusterDeploymentSummaries{c}
+}
+
 func (c *FakeHiveV1) ClusterDeprovisions(namespace string) v1.ClusterDeprovisionInterface {
 	return &FakeClusterDeprovisions{c, namespace}
 }
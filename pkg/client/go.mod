@@ -0,0 +1,24 @@
+module github.com/openshift/hive/pkg/client
+
+go 1.15
+
+require (
+	github.com/hashicorp/golang-lru v0.5.4 // indirect
+	github.com/openshift/hive/apis v0.0.0
+	golang.org/x/crypto v0.0.0-20201221181555-eec23a3978ad // indirect
+	golang.org/x/oauth2 v0.0.0-20200902213428-5d25da1a8d43 // indirect
+	golang.org/x/sys v0.0.0-20201202213521-69691e467435 // indirect
+	k8s.io/apimachinery v0.20.0
+	k8s.io/client-go v0.20.0
+)
+
+replace github.com/openshift/hive/apis => ../../apis
+
+// Keep the dependencies shared with hive at the versions hive builds with.
+replace (
+	github.com/Azure/go-autorest/autorest/adal => github.com/Azure/go-autorest/autorest/adal v0.8.2
+	github.com/go-openapi/spec => github.com/go-openapi/spec v0.19.0
+	google.golang.org/api => google.golang.org/api v0.25.0
+	google.golang.org/genproto => google.golang.org/genproto v0.0.0-20200526211855-cb27e3aa2013
+	google.golang.org/grpc => google.golang.org/grpc v1.29.1
+)
@@ -14,6 +14,8 @@ type Interface interface {
 	ClusterClaims() ClusterClaimInformer
 	// ClusterDeployments returns a ClusterDeploymentInformer.
 	ClusterDeployments() ClusterDeploymentInformer
+	// ClusterDeploymentSummaries returns a ClusterDeploymentSummaryInformer.
+	ClusterDeploymentSummaries() ClusterDeploymentSummaryInformer
 	// ClusterDeprovisions returns a ClusterDeprovisionInformer.
 	ClusterDeprovisions() ClusterDeprovisionInformer
 	// ClusterImageSets returns a ClusterImageSetInformer.
@@ -70,6 +72,11 @@ func (v *version) ClusterDeployments() ClusterDeploymentInformer {
 	return &clusterDeploymentInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterDeploymentSummaries returns a ClusterDeploymentSummaryInformer.
+func (v *version) ClusterDeploymentSummaries() ClusterDeploymentSummaryInformer {
+	return &clusterDeploymentSummaryInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterDeprovisions returns a ClusterDeprovisionInformer.
 func (v *version) ClusterDeprovisions() ClusterDeprovisionInformer {
 	return &clusterDeprovisionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
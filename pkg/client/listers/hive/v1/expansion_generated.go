@@ -26,6 +26,10 @@ type ClusterDeploymentListerExpansion interface{}
 // ClusterDeploymentNamespaceLister.
 type ClusterDeploymentNamespaceListerExpansion interface{}
 
+// ClusterDeploymentSummaryListerExpansion allows custom methods to be added to
+// ClusterDeploymentSummaryLister.
+type ClusterDeploymentSummaryListerExpansion interface{}
+
 // ClusterDeprovisionListerExpansion allows custom methods to be added to
 // ClusterDeprovisionLister.
 type ClusterDeprovisionListerExpansion interface{}
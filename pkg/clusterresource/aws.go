@@ -16,10 +16,11 @@ import (
 )
 
 const (
-	awsInstanceType = "m4.xlarge"
-	volumeIOPS      = 100
-	volumeSize      = 22
-	volumeType      = "gp2"
+	awsInstanceType      = "m4.xlarge"
+	awsARM64InstanceType = "m6g.xlarge"
+	volumeIOPS           = 100
+	volumeSize           = 22
+	volumeType           = "gp2"
 )
 
 var _ CloudBuilder = (*AWSCloudBuilder)(nil)
@@ -97,7 +98,7 @@ func (p *AWSCloudBuilder) GenerateCloudObjects(o *Builder) []runtime.Object {
 
 func (p *AWSCloudBuilder) addMachinePoolPlatform(o *Builder, mp *hivev1.MachinePool) {
 	mp.Spec.Platform.AWS = &hivev1aws.MachinePoolPlatform{
-		InstanceType: awsInstanceType,
+		InstanceType: p.instanceType(o),
 		EC2RootVolume: hivev1aws.EC2RootVolume{
 			IOPS: volumeIOPS,
 			Size: volumeSize,
@@ -117,7 +118,7 @@ func (p *AWSCloudBuilder) addInstallConfigPlatform(o *Builder, ic *installertype
 
 	// Used for both control plane and workers.
 	mpp := &awsinstallertypes.MachinePool{
-		InstanceType: awsInstanceType,
+		InstanceType: p.instanceType(o),
 		EC2RootVolume: awsinstallertypes.EC2RootVolume{
 			IOPS: volumeIOPS,
 			Size: volumeSize,
@@ -133,6 +134,13 @@ func (p *AWSCloudBuilder) addInstallConfigPlatform(o *Builder, ic *installertype
 
 }
 
+func (p *AWSCloudBuilder) instanceType(o *Builder) string {
+	if o.Architecture == string(hivev1.ARM64PoolArchitecture) {
+		return awsARM64InstanceType
+	}
+	return awsInstanceType
+}
+
 func (p *AWSCloudBuilder) CredsSecretName(o *Builder) string {
 	return fmt.Sprintf("%s-aws-creds", o.Name)
 }
@@ -16,8 +16,9 @@ import (
 )
 
 const (
-	azureCredFile     = "osServicePrincipal.json"
-	azureInstanceType = "Standard_D2s_v3"
+	azureCredFile          = "osServicePrincipal.json"
+	azureInstanceType      = "Standard_D2s_v3"
+	azureARM64InstanceType = "Standard_D4ps_v5"
 )
 
 var _ CloudBuilder = (*AzureCloudBuilder)(nil)
@@ -32,6 +33,21 @@ type AzureCloudBuilder struct {
 
 	// Region is the Azure region to which to install the cluster.
 	Region string
+
+	// ResourceGroupName is an existing, empty resource group into which to install the cluster.
+	ResourceGroupName string
+
+	// NetworkResourceGroupName is the resource group of the existing VNet into which to install the cluster.
+	NetworkResourceGroupName string
+
+	// VirtualNetwork is the existing VNet into which to install the cluster.
+	VirtualNetwork string
+
+	// ControlPlaneSubnet is the existing subnet of the VNet for the control plane machines.
+	ControlPlaneSubnet string
+
+	// ComputeSubnet is the existing subnet of the VNet for the compute machines.
+	ComputeSubnet string
 }
 
 func NewAzureCloudBuilderFromSecret(credsSecret *corev1.Secret) *AzureCloudBuilder {
@@ -66,13 +82,18 @@ func (p *AzureCloudBuilder) GetCloudPlatform(o *Builder) hivev1.Platform {
 			},
 			Region:                      p.Region,
 			BaseDomainResourceGroupName: p.BaseDomainResourceGroupName,
+			ResourceGroupName:           p.ResourceGroupName,
+			NetworkResourceGroupName:    p.NetworkResourceGroupName,
+			VirtualNetwork:              p.VirtualNetwork,
+			ControlPlaneSubnet:          p.ControlPlaneSubnet,
+			ComputeSubnet:               p.ComputeSubnet,
 		},
 	}
 }
 
 func (p *AzureCloudBuilder) addMachinePoolPlatform(o *Builder, mp *hivev1.MachinePool) {
 	mp.Spec.Platform.Azure = &hivev1azure.MachinePool{
-		InstanceType: azureInstanceType,
+		InstanceType: p.instanceType(o),
 		OSDisk: hivev1azure.OSDisk{
 			DiskSizeGB: 128,
 		},
@@ -86,15 +107,30 @@ func (p *AzureCloudBuilder) addInstallConfigPlatform(o *Builder, ic *installerty
 		Azure: &azureinstallertypes.Platform{
 			Region:                      p.Region,
 			BaseDomainResourceGroupName: p.BaseDomainResourceGroupName,
+			ResourceGroupName:           p.ResourceGroupName,
+			NetworkResourceGroupName:    p.NetworkResourceGroupName,
+			VirtualNetwork:              p.VirtualNetwork,
+			ControlPlaneSubnet:          p.ControlPlaneSubnet,
+			ComputeSubnet:               p.ComputeSubnet,
 		},
 	}
 
 	// Used for both control plane and workers.
 	mpp := &azureinstallertypes.MachinePool{}
+	if o.Architecture == string(hivev1.ARM64PoolArchitecture) {
+		mpp.InstanceType = p.instanceType(o)
+	}
 	ic.ControlPlane.Platform.Azure = mpp
 	ic.Compute[0].Platform.Azure = mpp
 }
 
+func (p *AzureCloudBuilder) instanceType(o *Builder) string {
+	if o.Architecture == string(hivev1.ARM64PoolArchitecture) {
+		return azureARM64InstanceType
+	}
+	return azureInstanceType
+}
+
 func (p *AzureCloudBuilder) CredsSecretName(o *Builder) string {
 	return fmt.Sprintf("%s-azure-creds", o.Name)
 }
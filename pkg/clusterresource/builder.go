@@ -129,6 +129,10 @@ type Builder struct {
 	// InstallConfig Secret to be used as template for deployment install-config
 	InstallConfigTemplate string
 
+	// InstallConfig is a user-supplied install-config that is used as is for the install-config Secret, instead of
+	// generating one from the fields of the Builder. Its name and base domain must match Name and BaseDomain.
+	InstallConfig string
+
 	// CentralMachineManagement
 	CentralMachineManagement bool
 
@@ -137,6 +141,10 @@ type Builder struct {
 
 	// PublishStrategy defines the publishing strategy for the install-config.
 	PublishStrategy string
+
+	// Architecture is the architecture of the control plane and compute machines of the cluster, such as arm64.
+	// Instance types matching the architecture are used by default. Defaults to the installer default (amd64).
+	Architecture string
 }
 
 // Validate ensures that the builder's fields are logically configured and usable to generate the cluster resources.
@@ -175,6 +183,22 @@ func (o *Builder) Validate() error {
 		}
 	}
 
+	if o.InstallConfig != "" {
+		if o.InstallConfigTemplate != "" {
+			return fmt.Errorf("cannot set both InstallConfig and InstallConfigTemplate")
+		}
+		ic, err := ParseInstallConfig([]byte(o.InstallConfig))
+		if err != nil {
+			return err
+		}
+		if ic.ObjectMeta.Name != o.Name {
+			return fmt.Errorf("install-config name %q does not match name %q", ic.ObjectMeta.Name, o.Name)
+		}
+		if ic.BaseDomain != o.BaseDomain {
+			return fmt.Errorf("install-config base domain %q does not match BaseDomain %q", ic.BaseDomain, o.BaseDomain)
+		}
+	}
+
 	if len(o.AdditionalTrustBundle) > 0 {
 		if err := validate.CABundle(o.AdditionalTrustBundle); err != nil {
 			return fmt.Errorf("AdditionalTrustBundle is not valid: %s", err.Error())
@@ -198,13 +222,16 @@ func (o *Builder) Build() ([]runtime.Object, error) {
 		allObjects = append(allObjects, o.generateMachinePool())
 	}
 
-	if o.InstallConfigTemplate != "" {
+	switch {
+	case o.InstallConfig != "":
+		allObjects = append(allObjects, o.generateInstallConfigSecretFromData([]byte(o.InstallConfig)))
+	case o.InstallConfigTemplate != "":
 		installConfigSecret, err := o.mergeInstallConfigTemplate()
 		if err != nil {
 			return nil, fmt.Errorf("Encountered problems merging InstallConfigTemplate: %s", err.Error())
 		}
 		allObjects = append(allObjects, installConfigSecret)
-	} else {
+	default:
 		installConfigSecret, err := o.generateInstallConfigSecret()
 		if err != nil {
 			return nil, err
@@ -403,26 +430,18 @@ func (o *Builder) generateInstallConfigSecret() (*corev1.Secret, error) {
 		Publish:               installertypes.PublishingStrategy(o.PublishStrategy),
 	}
 
+	if o.Architecture != "" {
+		installConfig.ControlPlane.Architecture = installertypes.Architecture(o.Architecture)
+		installConfig.Compute[0].Architecture = installertypes.Architecture(o.Architecture)
+	}
+
 	o.CloudBuilder.addInstallConfigPlatform(o, installConfig)
 
 	d, err := yaml.Marshal(installConfig)
 	if err != nil {
 		return nil, err
 	}
-	return &corev1.Secret{
-		TypeMeta: metav1.TypeMeta{
-			Kind:       "Secret",
-			APIVersion: corev1.SchemeGroupVersion.String(),
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      o.getInstallConfigSecretName(),
-			Namespace: o.Namespace,
-		},
-		Type: corev1.SecretTypeOpaque,
-		StringData: map[string]string{
-			"install-config.yaml": string(d),
-		},
-	}, nil
+	return o.generateInstallConfigSecretFromData(d), nil
 }
 
 func (o *Builder) mergeInstallConfigTemplate() (*corev1.Secret, error) {
@@ -439,6 +458,11 @@ func (o *Builder) mergeInstallConfigTemplate() (*corev1.Secret, error) {
 		return nil, err
 	}
 
+	return o.generateInstallConfigSecretFromData(d), nil
+}
+
+// generateInstallConfigSecretFromData returns the install-config Secret holding the install-config data.
+func (o *Builder) generateInstallConfigSecretFromData(data []byte) *corev1.Secret {
 	return &corev1.Secret{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Secret",
@@ -450,9 +474,9 @@ func (o *Builder) mergeInstallConfigTemplate() (*corev1.Secret, error) {
 		},
 		Type: corev1.SecretTypeOpaque,
 		StringData: map[string]string{
-			"install-config.yaml": string(d),
+			"install-config.yaml": string(data),
 		},
-	}, nil
+	}
 }
 
 func (o *Builder) generateMachinePool() *hivev1.MachinePool {
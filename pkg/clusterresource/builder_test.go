@@ -8,6 +8,7 @@ import (
 	"github.com/ghodss/yaml"
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	installertypes "github.com/openshift/installer/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -67,6 +68,16 @@ networking:
   networkType: OpenShiftSDN
   serviceNetwork:
   - 172.30.0.0/16
+`
+	fakePassthroughInstallConfigYaml = `apiVersion: v1
+baseDomain: example.com
+metadata:
+  name: mycluster
+platform:
+  aws:
+    region: us-east-1
+    amiID: ami-0123456789
+pullSecret: fakepullsecret
 `
 )
 
@@ -166,6 +177,26 @@ func TestBuildClusterResources(t *testing.T) {
 				assert.Equal(t, awsInstanceType, workerPool.Spec.Platform.AWS.InstanceType)
 			},
 		},
+		{
+			name: "AWS arm64 cluster",
+			builder: func() *Builder {
+				b := createAWSClusterBuilder()
+				b.Architecture = "arm64"
+				return b
+			}(),
+			validate: func(t *testing.T, allObjects []runtime.Object) {
+				workerPool := findMachinePool(allObjects, fmt.Sprintf("%s-%s", clusterName, "worker"))
+				assert.Equal(t, awsARM64InstanceType, workerPool.Spec.Platform.AWS.InstanceType)
+
+				installConfigSecret := findSecret(allObjects, fmt.Sprintf("%s-install-config", clusterName))
+				require.NotNil(t, installConfigSecret)
+				installConfig := &installertypes.InstallConfig{}
+				require.NoError(t, yaml.Unmarshal([]byte(installConfigSecret.StringData["install-config.yaml"]), installConfig))
+				assert.Equal(t, installertypes.Architecture("arm64"), installConfig.ControlPlane.Architecture)
+				assert.Equal(t, installertypes.Architecture("arm64"), installConfig.Compute[0].Architecture)
+				assert.Equal(t, awsARM64InstanceType, installConfig.Compute[0].Platform.AWS.InstanceType)
+			},
+		},
 		{
 			name: "adopt AWS cluster",
 			builder: func() *Builder {
@@ -203,6 +234,37 @@ func TestBuildClusterResources(t *testing.T) {
 				assert.Equal(t, azureInstanceType, workerPool.Spec.Platform.Azure.InstanceType)
 			},
 		},
+		{
+			name: "Azure cluster in existing resource group and VNet",
+			builder: func() *Builder {
+				b := createAzureClusterBuilder()
+				azureBuilder := b.CloudBuilder.(*AzureCloudBuilder)
+				azureBuilder.ResourceGroupName = "existing-rg"
+				azureBuilder.NetworkResourceGroupName = "network-rg"
+				azureBuilder.VirtualNetwork = "existing-vnet"
+				azureBuilder.ControlPlaneSubnet = "control-plane-subnet"
+				azureBuilder.ComputeSubnet = "compute-subnet"
+				return b
+			}(),
+			validate: func(t *testing.T, allObjects []runtime.Object) {
+				cd := findClusterDeployment(allObjects, clusterName)
+				assert.Equal(t, "existing-rg", cd.Spec.Platform.Azure.ResourceGroupName)
+				assert.Equal(t, "network-rg", cd.Spec.Platform.Azure.NetworkResourceGroupName)
+				assert.Equal(t, "existing-vnet", cd.Spec.Platform.Azure.VirtualNetwork)
+				assert.Equal(t, "control-plane-subnet", cd.Spec.Platform.Azure.ControlPlaneSubnet)
+				assert.Equal(t, "compute-subnet", cd.Spec.Platform.Azure.ComputeSubnet)
+
+				installConfigSecret := findSecret(allObjects, fmt.Sprintf("%s-install-config", clusterName))
+				require.NotNil(t, installConfigSecret)
+				installConfig := &installertypes.InstallConfig{}
+				require.NoError(t, yaml.Unmarshal([]byte(installConfigSecret.StringData["install-config.yaml"]), installConfig))
+				assert.Equal(t, "existing-rg", installConfig.Platform.Azure.ResourceGroupName)
+				assert.Equal(t, "network-rg", installConfig.Platform.Azure.NetworkResourceGroupName)
+				assert.Equal(t, "existing-vnet", installConfig.Platform.Azure.VirtualNetwork)
+				assert.Equal(t, "control-plane-subnet", installConfig.Platform.Azure.ControlPlaneSubnet)
+				assert.Equal(t, "compute-subnet", installConfig.Platform.Azure.ComputeSubnet)
+			},
+		},
 		{
 			name:    "GCP cluster",
 			builder: createGCPClusterBuilder(),
@@ -268,6 +330,18 @@ func TestBuildClusterResources(t *testing.T) {
 
 				assert.YAMLEq(t, updatedYaml, installConfigSecret.StringData["install-config.yaml"])
 			},
+		}, {
+			name: "pass through InstallConfig",
+			builder: func() *Builder {
+				b := createAWSClusterBuilder()
+				b.InstallConfig = fakePassthroughInstallConfigYaml
+				return b
+			}(),
+			validate: func(t *testing.T, allObjects []runtime.Object) {
+				installConfigSecret := findSecret(allObjects, fmt.Sprintf("%s-install-config", clusterName))
+				assert.Equal(t, fakePassthroughInstallConfigYaml, installConfigSecret.StringData["install-config.yaml"],
+					"expected the install-config to be passed through as is")
+			},
 		},
 	}
 
@@ -314,6 +388,47 @@ func TestBuildClusterResources(t *testing.T) {
 
 }
 
+func TestValidateInstallConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		builder       func(b *Builder)
+		expectedError string
+	}{
+		{
+			name: "name mismatch",
+			builder: func(b *Builder) {
+				b.Name = "othercluster"
+			},
+			expectedError: `install-config name "mycluster" does not match name "othercluster"`,
+		},
+		{
+			name: "base domain mismatch",
+			builder: func(b *Builder) {
+				b.BaseDomain = "other.example.com"
+			},
+			expectedError: `install-config base domain "example.com" does not match BaseDomain "other.example.com"`,
+		},
+		{
+			name: "template also set",
+			builder: func(b *Builder) {
+				b.InstallConfigTemplate = fakeInstallConfigYaml
+			},
+			expectedError: "cannot set both InstallConfig and InstallConfigTemplate",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := createAWSClusterBuilder()
+			b.InstallConfig = fakePassthroughInstallConfigYaml
+			test.builder(b)
+			err := b.Validate()
+			if assert.Error(t, err, "expected validation error") {
+				assert.Contains(t, err.Error(), test.expectedError, "unexpected validation error")
+			}
+		})
+	}
+}
+
 func findSecret(allObjects []runtime.Object, name string) *corev1.Secret {
 	for _, ro := range allObjects {
 		obj, ok := ro.(*corev1.Secret)
@@ -18,7 +18,8 @@ import (
 )
 
 const (
-	gcpInstanceType = "n1-standard-4"
+	gcpInstanceType      = "n1-standard-4"
+	gcpARM64InstanceType = "t2a-standard-4"
 )
 
 var _ CloudBuilder = (*GCPCloudBuilder)(nil)
@@ -33,6 +34,9 @@ type GCPCloudBuilder struct {
 
 	// Region is the GCP region to which to install the cluster.
 	Region string
+
+	// PrivateServiceConnect enables access to the cluster using GCP Private Service Connect.
+	PrivateServiceConnect bool
 }
 
 func NewGCPCloudBuilderFromSecret(credsSecret *corev1.Secret) (*GCPCloudBuilder, error) {
@@ -65,7 +69,7 @@ func (p *GCPCloudBuilder) GenerateCredentialsSecret(o *Builder) *corev1.Secret {
 }
 
 func (p *GCPCloudBuilder) GetCloudPlatform(o *Builder) hivev1.Platform {
-	return hivev1.Platform{
+	plat := hivev1.Platform{
 		GCP: &hivev1gcp.Platform{
 			CredentialsSecretRef: corev1.LocalObjectReference{
 				Name: p.CredsSecretName(o),
@@ -73,11 +77,17 @@ func (p *GCPCloudBuilder) GetCloudPlatform(o *Builder) hivev1.Platform {
 			Region: p.Region,
 		},
 	}
+	if p.PrivateServiceConnect {
+		plat.GCP.PrivateServiceConnect = &hivev1gcp.PrivateServiceConnectAccess{
+			Enabled: true,
+		}
+	}
+	return plat
 }
 
 func (p *GCPCloudBuilder) addMachinePoolPlatform(o *Builder, mp *hivev1.MachinePool) {
 	mp.Spec.Platform.GCP = &hivev1gcp.MachinePool{
-		InstanceType: gcpInstanceType,
+		InstanceType: p.instanceType(o),
 	}
 
 }
@@ -92,12 +102,19 @@ func (p *GCPCloudBuilder) addInstallConfigPlatform(o *Builder, ic *installertype
 
 	// Used for both control plane and workers.
 	mpp := &installergcp.MachinePool{
-		InstanceType: gcpInstanceType,
+		InstanceType: p.instanceType(o),
 	}
 	ic.ControlPlane.Platform.GCP = mpp
 	ic.Compute[0].Platform.GCP = mpp
 }
 
+func (p *GCPCloudBuilder) instanceType(o *Builder) string {
+	if o.Architecture == string(hivev1.ARM64PoolArchitecture) {
+		return gcpARM64InstanceType
+	}
+	return gcpInstanceType
+}
+
 func (p *GCPCloudBuilder) CredsSecretName(o *Builder) string {
 	return fmt.Sprintf("%s-gcp-creds", o.Name)
 }
@@ -0,0 +1,53 @@
+package clusterresource
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	installertypes "github.com/openshift/installer/pkg/types"
+	awsinstallertypes "github.com/openshift/installer/pkg/types/aws"
+	azureinstallertypes "github.com/openshift/installer/pkg/types/azure"
+	gcpinstallertypes "github.com/openshift/installer/pkg/types/gcp"
+	openstackinstallertypes "github.com/openshift/installer/pkg/types/openstack"
+	ovirtinstallertypes "github.com/openshift/installer/pkg/types/ovirt"
+	vsphereinstallertypes "github.com/openshift/installer/pkg/types/vsphere"
+)
+
+// ParseInstallConfig parses a user-supplied install-config and validates the fields from which the ClusterDeployment
+// is derived. Fields unknown to Hive are ignored, so that install-configs using installer features Hive does not
+// model can be passed through to the installer as is.
+func ParseInstallConfig(data []byte) (*installertypes.InstallConfig, error) {
+	ic := &installertypes.InstallConfig{}
+	if err := yaml.Unmarshal(data, ic); err != nil {
+		return nil, fmt.Errorf("could not parse install-config: %v", err)
+	}
+	if ic.APIVersion != installertypes.InstallConfigVersion {
+		return nil, fmt.Errorf("install-config apiVersion must be %q", installertypes.InstallConfigVersion)
+	}
+	if ic.ObjectMeta.Name == "" {
+		return nil, fmt.Errorf("install-config metadata.name is required")
+	}
+	if ic.BaseDomain == "" {
+		return nil, fmt.Errorf("install-config baseDomain is required")
+	}
+	switch platform := ic.Platform.Name(); platform {
+	case awsinstallertypes.Name:
+		if ic.Platform.AWS.Region == "" {
+			return nil, fmt.Errorf("install-config platform.aws.region is required")
+		}
+	case azureinstallertypes.Name:
+		if ic.Platform.Azure.Region == "" {
+			return nil, fmt.Errorf("install-config platform.azure.region is required")
+		}
+	case gcpinstallertypes.Name:
+		if ic.Platform.GCP.Region == "" {
+			return nil, fmt.Errorf("install-config platform.gcp.region is required")
+		}
+	case openstackinstallertypes.Name, vsphereinstallertypes.Name, ovirtinstallertypes.Name:
+	case "":
+		return nil, fmt.Errorf("install-config platform is required")
+	default:
+		return nil, fmt.Errorf("install-config platform %q is not supported", platform)
+	}
+	return ic, nil
+}
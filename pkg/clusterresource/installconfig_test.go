@@ -0,0 +1,118 @@
+package clusterresource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInstallConfig(t *testing.T) {
+	tests := []struct {
+		name             string
+		installConfig    string
+		expectedPlatform string
+		expectedError    string
+	}{
+		{
+			name:             "valid AWS install-config",
+			installConfig:    fakePassthroughInstallConfigYaml,
+			expectedPlatform: "aws",
+		},
+		{
+			name: "valid vSphere install-config",
+			installConfig: `apiVersion: v1
+baseDomain: example.com
+metadata:
+  name: mycluster
+platform:
+  vsphere:
+    vcenter: vcenter.example.com
+`,
+			expectedPlatform: "vsphere",
+		},
+		{
+			name:          "not YAML",
+			installConfig: "- not\n- an install-config",
+			expectedError: "could not parse install-config",
+		},
+		{
+			name: "wrong apiVersion",
+			installConfig: `apiVersion: v2
+baseDomain: example.com
+metadata:
+  name: mycluster
+platform:
+  aws:
+    region: us-east-1
+`,
+			expectedError: `install-config apiVersion must be "v1"`,
+		},
+		{
+			name: "missing name",
+			installConfig: `apiVersion: v1
+baseDomain: example.com
+platform:
+  aws:
+    region: us-east-1
+`,
+			expectedError: "install-config metadata.name is required",
+		},
+		{
+			name: "missing base domain",
+			installConfig: `apiVersion: v1
+metadata:
+  name: mycluster
+platform:
+  aws:
+    region: us-east-1
+`,
+			expectedError: "install-config baseDomain is required",
+		},
+		{
+			name: "missing region",
+			installConfig: `apiVersion: v1
+baseDomain: example.com
+metadata:
+  name: mycluster
+platform:
+  gcp:
+    projectID: myproject
+`,
+			expectedError: "install-config platform.gcp.region is required",
+		},
+		{
+			name: "missing platform",
+			installConfig: `apiVersion: v1
+baseDomain: example.com
+metadata:
+  name: mycluster
+`,
+			expectedError: "install-config platform is required",
+		},
+		{
+			name: "unsupported platform",
+			installConfig: `apiVersion: v1
+baseDomain: example.com
+metadata:
+  name: mycluster
+platform:
+  none: {}
+`,
+			expectedError: `install-config platform "none" is not supported`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ic, err := ParseInstallConfig([]byte(test.installConfig))
+			if test.expectedError != "" {
+				if assert.Error(t, err, "expected error parsing install-config") {
+					assert.Contains(t, err.Error(), test.expectedError, "unexpected error")
+				}
+				return
+			}
+			if assert.NoError(t, err, "unexpected error parsing install-config") {
+				assert.Equal(t, test.expectedPlatform, ic.Platform.Name(), "unexpected platform")
+			}
+		})
+	}
+}
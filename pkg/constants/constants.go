@@ -11,11 +11,14 @@ const (
 	PlatformBaremetal      = "baremetal"
 	PlatformAgentBaremetal = "agent-baremetal"
 	PlatformGCP            = "gcp"
+	PlatformNutanix        = "nutanix"
 	PlatformOpenStack      = "openstack"
+	PlatformPowerVS        = "powervs"
 	PlatformUnknown        = "unknown"
 	PlatformVSphere        = "vsphere"
 
-	mergedPullSecretSuffix = "merged-pull-secret"
+	mergedPullSecretSuffix       = "merged-pull-secret"
+	viewerKubeconfigSecretSuffix = "viewer-kubeconfig"
 
 	// VeleroBackupEnvVar is the name of the environment variable used to tell the controller manager to enable velero backup integration.
 	VeleroBackupEnvVar = "HIVE_VELERO_BACKUP"
@@ -53,6 +56,15 @@ const (
 	// has been deleted.
 	ClusterPoolNameLabel = "hive.openshift.io/cluster-pool-name"
 
+	// ClusterPoolSpecHashAnnotation is the annotation set on ClusterDeployments created for a ClusterPool recording
+	// a hash of the pool spec that the ClusterDeployment was created from. It is used to find unclaimed clusters that
+	// are stale after the pool spec changes.
+	ClusterPoolSpecHashAnnotation = "hive.openshift.io/cluster-pool-spec-hash"
+
+	// ClusterPoolArchitectureAnnotation is the annotation set on ClusterDeployments created for a ClusterPool recording
+	// the architecture of the pool when the ClusterDeployment was created.
+	ClusterPoolArchitectureAnnotation = "hive.openshift.io/cluster-pool-architecture"
+
 	// SyncSetNameLabel is the label that is used to identify a relationship to a given syncset object.
 	SyncSetNameLabel = "hive.openshift.io/syncset-name"
 
@@ -92,6 +104,14 @@ const (
 	// SecretTypeKubeConfig is used as a value of SecretTypeLabel that says the secret is specifically used for storing a kubeconfig.
 	SecretTypeKubeConfig = "kubeconfig"
 
+	// SecretTypeViewerKubeConfig is used as a value of SecretTypeLabel that says the secret is specifically used for storing
+	// the viewer kubeconfig of a cluster.
+	SecretTypeViewerKubeConfig = "viewer-kubeconfig"
+
+	// SecretTypeInstallState is used as a value of SecretTypeLabel that says the secret is specifically used for storing
+	// a checkpoint of the installer state.
+	SecretTypeInstallState = "install-state"
+
 	// SecretTypeKubeAdminCreds is used as a value of SecretTypeLabel that says the secret is specifically used for storing kubeadmin credentials.
 	SecretTypeKubeAdminCreds = "kubeadmincreds"
 
@@ -107,6 +127,15 @@ const (
 	// SyncSetTypeIdentityProvider is used as a value of SyncSetTypeLabel that says the syncset is specifically used to distribute identity provider information.
 	SyncSetTypeIdentityProvider = "identityprovider"
 
+	// SyncSetTypeAuditLog is used as a value of SyncSetTypeLabel that says the syncset is specifically used to distribute audit log configuration.
+	SyncSetTypeAuditLog = "auditlog"
+
+	// SyncSetTypeAdditionalTrustBundle is used as a value of SyncSetTypeLabel that says the syncset is specifically used to distribute the additional trust bundle.
+	SyncSetTypeAdditionalTrustBundle = "additionaltrustbundle"
+
+	// SyncSetTypePullSecret is used as a value of SyncSetTypeLabel that says the syncset is specifically used to distribute the updated pull secret of an installed cluster.
+	SyncSetTypePullSecret = "pullsecret"
+
 	// GlobalPullSecret is the environment variable for controllers to get the global pull secret
 	GlobalPullSecret = "GLOBAL_PULL_SECRET"
 
@@ -122,6 +151,8 @@ const (
 	CheckpointName = "hive"
 
 	// SyncsetPauseAnnotation is a annotation used by clusterDeployment, if it's true, then we will disable syncing to a specific cluster
+	//
+	// Deprecated: set spec.paused on the ClusterDeployment instead, which pauses all of the controllers.
 	SyncsetPauseAnnotation = "hive.openshift.io/syncset-pause"
 
 	// HiveManagedLabel is a label added to any resources we sync to the remote cluster to help identify that they are
@@ -184,6 +215,9 @@ const (
 	// where Azure credentials can be found.
 	AzureCredentialsEnvVar = "AZURE_AUTH_LOCATION"
 
+	// IBMCloudAPIKeySecretKey is the key of the IBM Cloud API key in credentials secrets.
+	IBMCloudAPIKeySecretKey = "ibmcloud_api_key"
+
 	// OpenStackCredentialsName is the name of the OpenStack credentials file.
 	OpenStackCredentialsName = "clouds.yaml"
 
@@ -203,6 +237,10 @@ const (
 	// ServiceAccount signing key will be projected into the install pod.
 	BoundServiceAccountSigningKeyFile = "bound-service-account-signing-key.key"
 
+	// AdditionalTrustBundleConfigMapKey is the ConfigMap key and filename where the additional trust bundle of a
+	// cluster will be projected into the install pod.
+	AdditionalTrustBundleConfigMapKey = "ca-bundle.crt"
+
 	// FakeClusterInstallEnvVar is the environment variable Hive will set for the installmanager pod to request
 	// a fake install.
 	FakeClusterInstallEnvVar = "FAKE_INSTALL"
@@ -210,12 +248,23 @@ const (
 	// ControlPlaneCertificateSuffix is the suffix used when naming objects having to do control plane certificates.
 	ControlPlaneCertificateSuffix = "cp-certs"
 
+	// PullSecretSyncSetSuffix is the suffix used when naming the SyncSet that distributes the pull secret of an
+	// installed cluster.
+	PullSecretSyncSetSuffix = "pull-secret"
+
 	// ClusterIngressSuffix is the suffix used when naming objects having to do with cluster ingress.
 	ClusterIngressSuffix = "clusteringress"
 
 	// IdentityProviderSuffix is the suffix used when naming objects having to do with identity provider
 	IdentityProviderSuffix = "idp"
 
+	// AuditLogSuffix is the suffix used when naming objects having to do with the audit logs of a cluster.
+	AuditLogSuffix = "audit-log"
+
+	// AdditionalTrustBundleSuffix is the suffix used when naming objects having to do with the additional trust
+	// bundle of a cluster.
+	AdditionalTrustBundleSuffix = "trust-bundle"
+
 	// KubeconfigSecretKey is the key used inside of a secret containing a kubeconfig
 	KubeconfigSecretKey = "kubeconfig"
 
@@ -234,9 +283,21 @@ const (
 	// AWSChinaRegionPrefix is the prefix for regions in AWS China.
 	AWSChinaRegionPrefix = "cn-"
 
+	// AWSGovCloudRoute53Region is the region to use for AWS GovCloud route53 operations.
+	AWSGovCloudRoute53Region = "us-gov-west-1"
+
+	// AWSC2SRoute53Region is the region to use for C2S (aws-iso) route53 operations.
+	AWSC2SRoute53Region = "us-iso-east-1"
+
+	// AWSSC2SRoute53Region is the region to use for SC2S (aws-iso-b) route53 operations.
+	AWSSC2SRoute53Region = "us-isob-east-1"
+
 	// SSHPrivateKeySecretKey is the key we use in a Kubernetes Secret containing an SSH private key.
 	SSHPrivateKeySecretKey = "ssh-privatekey"
 
+	// SSHKnownHostsSecretKey is the key we use in a Kubernetes Secret containing the known hosts for an SSH host.
+	SSHKnownHostsSecretKey = "ssh-knownhosts"
+
 	// RawKubeconfigSecretKey is the key we use in a Kubernetes Secret containing the raw (unmodified) form of
 	// an admin kubeconfig. (before Hive injects things such as additional CAs)
 	RawKubeconfigSecretKey = "raw-kubeconfig"
@@ -280,6 +341,15 @@ const (
 	// VSphereDataStoreEnvVar is the environment variable specifying the vSphere default datastore.
 	VSphereDataStoreEnvVar = "GOVC_DATASTORE"
 
+	// NutanixUsernameEnvVar is the environment variable specifying the Prism Central username.
+	NutanixUsernameEnvVar = "NUTANIX_USERNAME"
+
+	// NutanixPasswordEnvVar is the environment variable specifying the Prism Central password.
+	NutanixPasswordEnvVar = "NUTANIX_PASSWORD"
+
+	// PowerVSAPIKeyEnvVar is the environment variable specifying the IBM Cloud API key for IBM Power Virtual Server.
+	PowerVSAPIKeyEnvVar = "IBMCLOUD_API_KEY"
+
 	// VersionMajorLabel is a label applied to ClusterDeployments to show the version of the cluster
 	// in the form "[MAJOR]".
 	VersionMajorLabel = "hive.openshift.io/version-major"
@@ -292,6 +362,10 @@ const (
 	// in the form "[MAJOR].[MINOR].[PATCH]".
 	VersionMajorMinorPatchLabel = "hive.openshift.io/version-major-minor-patch"
 
+	// GPUPoolsLabel is a label applied to ClusterDeployments that have MachinePools with GPUs, so that SelectorSyncSets
+	// installing GPU drivers can select them.
+	GPUPoolsLabel = "hive.openshift.io/gpu-pools"
+
 	// OvirtCredentialsName is the name of the oVirt credentials file.
 	OvirtCredentialsName = "ovirt-config.yaml"
 
@@ -316,6 +390,15 @@ const (
 	// InstallLogsAWSS3BucketEnvVar is the environment variable specifying the S3 bucket to use.
 	InstallLogsAWSS3BucketEnvVar = "HIVE_INSTALL_LOGS_AWS_S3_BUCKET"
 
+	// AdditionalInstallLogRegexesConfigMapEnvVar is the environment variable specifying the name of a ConfigMap in
+	// the hive namespace with additional regexes used to classify install failures.
+	AdditionalInstallLogRegexesConfigMapEnvVar = "HIVE_ADDITIONAL_INSTALL_LOG_REGEXES_CONFIGMAP"
+
+	// FailedProvisionRetryReasonsEnvVar is the environment variable specifying a comma-separated list of the install
+	// failure reasons for which a failed install is retried. When the variable is not set, all failed installs are
+	// retried.
+	FailedProvisionRetryReasonsEnvVar = "HIVE_FAILED_PROVISION_RETRY_REASONS"
+
 	// HiveFakeClusterAnnotation can be set to true on a cluster deployment to create a fake cluster that never
 	// provisions resources, and all communication with the cluster will be faked.
 	HiveFakeClusterAnnotation = "hive.openshift.io/fake-cluster"
@@ -328,11 +411,31 @@ const (
 	// group for which first applied metrics can be reported
 	SyncSetMetricsGroupAnnotation = "hive.openshift.io/syncset-metrics-group"
 
+	// PruneProtectAnnotation can be set to "true" on a resource of a SyncSet or SelectorSyncSet in Sync mode so that
+	// the resource is left in the cluster instead of being deleted when it is removed from the syncset.
+	PruneProtectAnnotation = "hive.openshift.io/prune-protect"
+
+	// PruneProtectKindsAnnotation can be set on a SyncSet or SelectorSyncSet in Sync mode to a comma-separated list of
+	// kinds whose resources are left in the cluster instead of being deleted when they are removed from the syncset.
+	PruneProtectKindsAnnotation = "hive.openshift.io/prune-protect-kinds"
+
 	// ClusterClaimRemoveClusterAnnotation is used by the cluster claim controller to mark that the cluster
 	// that are previously claimed is no longer required and therefore should be removed/deprovisioned and removed
 	// from the pool.
 	ClusterClaimRemoveClusterAnnotation = "hive.openshift.io/remove-claimed-cluster-from-pool"
 
+	// ClusterClaimClaimantUsernameAnnotation is set by the admission webhook on ClusterClaims to the name of the user
+	// or service account which created the claim.
+	ClusterClaimClaimantUsernameAnnotation = "hive.openshift.io/claimant-username"
+
+	// ClusterClaimClaimantGroupsAnnotation is set by the admission webhook on ClusterClaims to the comma-separated
+	// groups of the user or service account which created the claim.
+	ClusterClaimClaimantGroupsAnnotation = "hive.openshift.io/claimant-groups"
+
+	// ClusterClaimClaimantTimestampAnnotation is set by the admission webhook on ClusterClaims to the time, in RFC 3339
+	// format, at which the claim was created.
+	ClusterClaimClaimantTimestampAnnotation = "hive.openshift.io/claimant-timestamp"
+
 	// HiveAWSServiceProviderCredentialsSecretRefEnvVar is the environment variable specifying what secret to use for
 	// assuming the service provider credentials for AWS clusters.
 	HiveAWSServiceProviderCredentialsSecretRefEnvVar = "HIVE_AWS_SERVICE_PROVIDER_CREDENTIALS_SECRET"
@@ -344,14 +447,166 @@ const (
 	// MachineManagementAnnotation
 	MachineManagementAnnotation = "hive.openshift.io/machine-management-cluster-name"
 
+	// SyncedPullSecretHashAnnotation is set on the merged pull secret of a ClusterDeployment to record the hash of
+	// the pull secret last known to be in use by the installed cluster.
+	SyncedPullSecretHashAnnotation = "hive.openshift.io/synced-pull-secret-hash"
+
+	// PullSecretHashAnnotation is set on the pull secret of an installed cluster by the SyncSet distributing it, to
+	// the hash of the pull secret it distributes.
+	PullSecretHashAnnotation = "hive.openshift.io/pull-secret-hash"
+
+	// WorkersStoppedReplicasAnnotation is set on the MachineSets of a cluster scaled to zero for the WorkersStopped
+	// power state to record the replicas to restore when the cluster is resumed.
+	WorkersStoppedReplicasAnnotation = "hive.openshift.io/workers-stopped-replicas"
+
+	// PowerStateInitiatorAnnotation is set on a ClusterDeployment along with a change of its power state to record
+	// what requested the change. It is recorded in the power state history of the ClusterDeployment and removed once
+	// the transition starts.
+	PowerStateInitiatorAnnotation = "hive.openshift.io/power-state-initiator"
+
+	// PowerStateInitiatorHibernateAfter is the initiator of the power state transitions caused by HibernateAfter.
+	PowerStateInitiatorHibernateAfter = "HibernateAfter"
+
+	// PowerStateInitiatorPowerStatePolicy is the initiator of the power state transitions caused by the power state
+	// policies of the hibernation defaults in the HiveConfig.
+	PowerStateInitiatorPowerStatePolicy = "PowerStatePolicy"
+
+	// PowerStateInitiatorClusterPool is the initiator of the power state transitions requested by a ClusterPool.
+	PowerStateInitiatorClusterPool = "ClusterPool"
+
+	// PowerStateInitiatorClusterClaim is the initiator of the power state transitions requested by a ClusterClaim.
+	PowerStateInitiatorClusterClaim = "ClusterClaim"
+
 	// AWSPrivateLinkControllerConfigFileEnvVar if present, points to a simple text
 	// file that includes configuration for aws-private-link-controller
 	AWSPrivateLinkControllerConfigFileEnvVar = "AWS_PRIVATELINK_CONTROLLER_CONFIG_FILE"
 
+	// GCPPrivateServiceConnectControllerConfigFileEnvVar if present, points to a simple text
+	// file that includes configuration for gcp-private-service-connect-controller
+	GCPPrivateServiceConnectControllerConfigFileEnvVar = "GCP_PRIVATESERVICECONNECT_CONTROLLER_CONFIG_FILE"
+
+	// ClusterImageSetDiscoveryControllerConfigFileEnvVar if present, points to a simple text
+	// file that includes configuration for clusterimagesetdiscovery-controller
+	ClusterImageSetDiscoveryControllerConfigFileEnvVar = "CLUSTERIMAGESET_DISCOVERY_CONTROLLER_CONFIG_FILE"
+
+	// ControllerLogLevelsFileEnvVar if present, points to a file with a JSON map of controller names to the log
+	// levels of those controllers, which is re-read while the controllers are running.
+	ControllerLogLevelsFileEnvVar = "HIVE_CONTROLLER_LOG_LEVELS_FILE"
+
+	// ClusterImageSetDiscoveredLabel is a label applied to ClusterImageSets created by the
+	// clusterimagesetdiscovery controller. The value is the version of the release.
+	ClusterImageSetDiscoveredLabel = "hive.openshift.io/clusterimageset-discovered"
+
+	// ClusterImageSetChannelsAnnotation is an annotation on ClusterImageSets created by the
+	// clusterimagesetdiscovery controller listing the update graph channels in which the release was found.
+	ClusterImageSetChannelsAnnotation = "hive.openshift.io/clusterimageset-channels"
+
+	// ReleaseImageValidationArchitecturesEnvVar is the environment variable that enables the validation of the
+	// release images of ClusterImageSets. Its value is a comma-separated list of the allowed architectures, which
+	// may be empty to allow all architectures.
+	ReleaseImageValidationArchitecturesEnvVar = "RELEASE_IMAGE_VALIDATION_ARCHITECTURES"
+
+	// ReleaseImageVerificationSignatureStoresEnvVar is the environment variable that enables the verification of the
+	// release images of clusters before they are provisioned. Its value is a comma-separated list of the base URLs
+	// of the signature stores.
+	ReleaseImageVerificationSignatureStoresEnvVar = "RELEASE_IMAGE_VERIFICATION_SIGNATURE_STORES"
+
+	// ReleaseImageVerificationKeysSecretEnvVar is the environment variable specifying the name of the secret in the
+	// hive namespace holding the GPG public keys trusted to sign release images.
+	ReleaseImageVerificationKeysSecretEnvVar = "RELEASE_IMAGE_VERIFICATION_KEYS_SECRET"
+
+	// AllowedInstallerEnvEnvVar is the environment variable for the admission webhooks specifying the comma-separated
+	// list of environment variable names that ClusterDeployments may pass through to the installer.
+	AllowedInstallerEnvEnvVar = "HIVE_ALLOWED_INSTALLER_ENV"
+
+	// TracingEnvVar is the environment variable for the Hive controllers with the JSON configuration of the export of
+	// traces of reconciles. Reconciles are not traced when it is not set.
+	TracingEnvVar = "HIVE_TRACING"
+
+	// AdmissionWarnRulesEnvVar is the environment variable for the admission webhooks specifying the comma-separated
+	// list of the admission rules in Warn mode.
+	AdmissionWarnRulesEnvVar = "HIVE_ADMISSION_WARN_RULES"
+
+	// NamespaceQuotasFileEnvVar if present, points to a file containing the JSON list of namespace quotas from
+	// HiveConfig.
+	NamespaceQuotasFileEnvVar = "HIVE_NAMESPACE_QUOTAS_FILE"
+
+	// WorkloadSchedulingFileEnvVar if present, points to a file containing the JSON scheduling configuration of the
+	// pods created by Hive from HiveConfig.
+	WorkloadSchedulingFileEnvVar = "HIVE_WORKLOAD_SCHEDULING_FILE"
+
+	// JobProxyFileEnvVar if present, points to a file containing the JSON proxy configuration of the pods created by
+	// Hive from HiveConfig.
+	JobProxyFileEnvVar = "HIVE_JOB_PROXY_FILE"
+
+	// JobTrustedCABundleFileEnvVar if present, points to a file containing the PEM bundle of the additional
+	// certificate authorities trusted by the pods created by Hive from HiveConfig.
+	JobTrustedCABundleFileEnvVar = "HIVE_JOB_TRUSTED_CA_BUNDLE_FILE"
+
+	// CloudClientProxyFileEnvVar if present, points to a file containing the JSON proxy configuration of the clients
+	// of the cloud APIs of the Hive controllers from HiveConfig.
+	CloudClientProxyFileEnvVar = "HIVE_CLOUD_CLIENT_PROXY_FILE"
+
+	// CloudClientTrustedCABundleFileEnvVar if present, points to a file containing the PEM bundle of the additional
+	// certificate authorities trusted by the clients of the cloud APIs of the Hive controllers from HiveConfig.
+	CloudClientTrustedCABundleFileEnvVar = "HIVE_CLOUD_CLIENT_TRUSTED_CA_BUNDLE_FILE"
+
+	// BackupExportConfigFileEnvVar if present, points to a file containing the JSON configuration of the export of
+	// Hive resources to object storage from HiveConfig.
+	BackupExportConfigFileEnvVar = "HIVE_BACKUP_EXPORT_CONFIG_FILE"
+
+	// CredentialsExpiryWarningPeriodEnvVar is the environment variable for the credentials expiry controller with the
+	// duration before the expiry of the certificates of a cluster at which the CredentialsExpiringSoon condition is set.
+	CredentialsExpiryWarningPeriodEnvVar = "CREDENTIALS_EXPIRY_WARNING_PERIOD"
+
+	// ParentLinkCheckIntervalEnvVar is the environment variable for the DNS endpoint controller with the interval at
+	// which the delegation of a DNSZone from its parent domain is re-verified.
+	ParentLinkCheckIntervalEnvVar = "PARENT_LINK_CHECK_INTERVAL"
+
+	// ParentLinkResolversEnvVar is the environment variable for the DNS endpoint controller with the comma-separated
+	// addresses of the DNS resolvers queried to verify the delegation of a DNSZone from its parent domain.
+	ParentLinkResolversEnvVar = "PARENT_LINK_RESOLVERS"
+
+	// UnreachableProbeBackoffEnvVar is the environment variable for the unreachable controller with the JSON
+	// configuration of the backoff of the probes of clusters that have been unreachable for a long time.
+	UnreachableProbeBackoffEnvVar = "UNREACHABLE_PROBE_BACKOFF"
+
+	// HibernationDefaultsEnvVar is the environment variable for the hibernation controller and the ClusterDeployment
+	// admission webhooks with the JSON hibernation defaults of the HiveConfig.
+	HibernationDefaultsEnvVar = "HIVE_HIBERNATION_DEFAULTS"
+
+	// EndpointHealthEnvVar is the environment variable for the endpointhealth controller with the JSON configuration
+	// of the probes of the endpoints of clusters. The endpoints are not probed when it is not set.
+	EndpointHealthEnvVar = "ENDPOINT_HEALTH"
+
+	// AWSRoute53RateLimitEnvVar is the environment variable for the Hive controllers with the JSON configuration of
+	// the rate limiting of the Route53 API calls. The defaults are used when it is not set.
+	AWSRoute53RateLimitEnvVar = "HIVE_AWS_ROUTE53_RATE_LIMIT"
+
+	// AWSClientCacheEnvVar is the environment variable for the Hive controllers with the JSON configuration of the
+	// cache of the responses of read-only AWS API calls. Responses are not cached when it is not set.
+	AWSClientCacheEnvVar = "HIVE_AWS_CLIENT_CACHE"
+
+	// CanaryNamespaceSelectorEnvVar is the environment variable for the Hive controllers with the label selector of
+	// the namespaces reconciled by the canary controllers while a canary rollout is progressing.
+	CanaryNamespaceSelectorEnvVar = "HIVE_CANARY_NAMESPACE_SELECTOR"
+
+	// CredentialsBrokerEnvVar is the environment variable for the Hive controllers with the JSON configuration of the
+	// credentials broker minting short-lived cloud credentials for the resources with a credentials source.
+	CredentialsBrokerEnvVar = "HIVE_CREDENTIALS_BROKER"
+
+	// CanaryEnvVar is the environment variable set to "true" for the canary controllers.
+	CanaryEnvVar = "HIVE_CANARY"
+
 	// HiveConfigName is the one and only name for a HiveConfig supported in the cluster. Any others will be ignored.
 	HiveConfigName = "hive"
 )
 
+// GetViewerKubeconfigSecretName returns the name of the secret holding the viewer kubeconfig of the cluster deployment
+func GetViewerKubeconfigSecretName(cd *hivev1.ClusterDeployment) string {
+	return apihelpers.GetResourceName(cd.Name, viewerKubeconfigSecretSuffix)
+}
+
 // GetMergedPullSecretName returns name for merged pull secret name per cluster deployment
 func GetMergedPullSecretName(cd *hivev1.ClusterDeployment) string {
 	return apihelpers.GetResourceName(cd.Name, mergedPullSecretSuffix)
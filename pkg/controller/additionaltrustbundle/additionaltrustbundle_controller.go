@@ -0,0 +1,309 @@
+package additionaltrustbundle
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sigs.k8s.io/yaml"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/resource"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	ControllerName = hivev1.AdditionalTrustBundleControllerName
+
+	openshiftConfigNamespace = "openshift-config"
+	// userCABundleName is the name of the ConfigMap on the cluster in which the installer stores the
+	// additionalTrustBundle of the install-config, and which is referenced by the proxy configuration of the cluster.
+	userCABundleName = "user-ca-bundle"
+
+	installConfigSecretInstallConfigKey = "install-config.yaml"
+
+	proxyTrustedCAPatch = `{"spec": {"trustedCA": {"name": "` + userCABundleName + `"}}}`
+)
+
+var (
+	configMapCheckInterval = 2 * time.Minute
+)
+
+type applier interface {
+	ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error)
+}
+
+// Add creates a new AdditionalTrustBundle Controller and adds it to the Manager with default RBAC. The Manager will
+// set fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := controllerutils.ControllerLogEntry(ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileAdditionalTrustBundle
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileAdditionalTrustBundle {
+	logger := controllerutils.ControllerLogEntry(ControllerName)
+	helper, err := resource.NewHelperWithMetricsFromRESTConfig(mgr.GetConfig(), ControllerName, logger)
+	if err != nil {
+		// Hard exit if we can't create this controller
+		logger.WithError(err).Fatal("unable to create resource helper")
+	}
+	return &ReconcileAdditionalTrustBundle{
+		Client:  controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme:  mgr.GetScheme(),
+		logger:  logger,
+		applier: helper,
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileAdditionalTrustBundle, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controllerutils.NewController("additionaltrustbundle-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to the trust bundles of cluster deployments
+	if err := c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(requestsForTrustBundle(r.Client, r.logger))); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func requestsForTrustBundle(c client.Client, logger log.FieldLogger) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		cdList := &hivev1.ClusterDeploymentList{}
+		if err := c.List(context.Background(), cdList, client.InNamespace(o.GetNamespace())); err != nil {
+			logger.WithError(err).Error("failed to list cluster deployments for config map")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, cd := range cdList.Items {
+			if trustBundle := cd.Spec.AdditionalTrustBundle; trustBundle == nil || !trustBundle.SyncToCluster || trustBundle.ConfigMapRef.Name != o.GetName() {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name},
+			})
+		}
+		return requests
+	}
+}
+
+var _ reconcile.Reconciler = &ReconcileAdditionalTrustBundle{}
+
+// ReconcileAdditionalTrustBundle syncs the additional trust bundle of a ClusterDeployment to the cluster.
+type ReconcileAdditionalTrustBundle struct {
+	client.Client
+	scheme  *runtime.Scheme
+	logger  log.FieldLogger
+	applier applier
+}
+
+// Reconcile renders the additional trust bundle of a ClusterDeployment into a SyncSet that keeps the trusted CA
+// bundle of the proxy configuration of the cluster in sync with it.
+func (r *ReconcileAdditionalTrustBundle) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), request.NamespacedName, cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// Ensure owner references are correctly set
+	if err := controllerutils.ReconcileOwnerReferences(cd, generateOwnershipUniqueKeys(cd), r, r.scheme, cdLog); err != nil {
+		cdLog.WithError(err).Error("Error reconciling object ownership")
+		return reconcile.Result{}, err
+	}
+
+	if controllerutils.IsClusterDeploymentPaused(cd, cdLog) {
+		return reconcile.Result{}, nil
+	}
+
+	if cd.DeletionTimestamp != nil || !cd.Spec.Installed {
+		return reconcile.Result{}, nil
+	}
+
+	trustBundle := cd.Spec.AdditionalTrustBundle
+	if trustBundle == nil || !trustBundle.SyncToCluster {
+		cdLog.Debug("additional trust bundle is not synced to the cluster, removing any existing syncset")
+		return reconcile.Result{}, resource.DeleteAnyExistingObject(
+			r,
+			types.NamespacedName{Namespace: cd.Namespace, Name: GenerateAdditionalTrustBundleSyncSetName(cd.Name)},
+			&hivev1.SyncSet{},
+			cdLog,
+		)
+	}
+
+	cm := &corev1.ConfigMap{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: trustBundle.ConfigMapRef.Name}, cm); {
+	case apierrors.IsNotFound(err):
+		cdLog.WithField("configMap", trustBundle.ConfigMapRef.Name).Infof("additional trust bundle is not available yet, requeueing clusterdeployment for %s", configMapCheckInterval)
+		return reconcile.Result{RequeueAfter: configMapCheckInterval}, nil
+	case err != nil:
+		cdLog.WithError(err).WithField("configMap", trustBundle.ConfigMapRef.Name).Error("error retrieving additional trust bundle")
+		return reconcile.Result{}, err
+	}
+	caBundle, ok := cm.Data[constants.AdditionalTrustBundleConfigMapKey]
+	if !ok {
+		cdLog.WithField("configMap", cm.Name).Errorf("additional trust bundle has no %s key", constants.AdditionalTrustBundleConfigMapKey)
+		return reconcile.Result{}, nil
+	}
+
+	// The installer stores the additionalTrustBundle of the install config in the same ConfigMap, so its certificates
+	// are kept ahead of those of the ConfigMap, as they were at install time.
+	installConfigBundle, err := r.installConfigTrustBundle(cd)
+	if err != nil {
+		cdLog.WithError(err).Error("error retrieving additional trust bundle of install config")
+		return reconcile.Result{}, err
+	}
+	if installConfigBundle != "" && !strings.HasSuffix(installConfigBundle, "\n") {
+		installConfigBundle += "\n"
+	}
+	caBundle = installConfigBundle + caBundle
+
+	syncSet, err := r.generateAdditionalTrustBundleSyncSet(cd, caBundle, cdLog)
+	if err != nil {
+		cdLog.WithError(err).Error("failed to generate additional trust bundle syncset")
+		return reconcile.Result{}, err
+	}
+	if _, err := r.applier.ApplyRuntimeObject(syncSet, r.scheme); err != nil {
+		cdLog.WithError(err).Error("failed to apply additional trust bundle syncset")
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// installConfigTrustBundle returns the additionalTrustBundle of the install config of the ClusterDeployment, if any.
+func (r *ReconcileAdditionalTrustBundle) installConfigTrustBundle(cd *hivev1.ClusterDeployment) (string, error) {
+	if cd.Spec.Provisioning == nil || cd.Spec.Provisioning.InstallConfigSecretRef == nil {
+		return "", nil
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.Provisioning.InstallConfigSecretRef.Name}, secret); err != nil {
+		return "", errors.Wrap(err, "failed to fetch install config secret")
+	}
+	ic := struct {
+		AdditionalTrustBundle string `json:"additionalTrustBundle,omitempty"`
+	}{}
+	if err := yaml.Unmarshal(secret.Data[installConfigSecretInstallConfigKey], &ic); err != nil {
+		return "", errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	return ic.AdditionalTrustBundle, nil
+}
+
+func (r *ReconcileAdditionalTrustBundle) generateAdditionalTrustBundleSyncSet(cd *hivev1.ClusterDeployment, caBundle string, cdLog log.FieldLogger) (*hivev1.SyncSet, error) {
+	cdLog.Debug("generating syncset for additional trust bundle")
+	userCABundle := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: openshiftConfigNamespace,
+			Name:      userCABundleName,
+		},
+		Data: map[string]string{
+			constants.AdditionalTrustBundleConfigMapKey: caBundle,
+		},
+	}
+	syncSet := &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        GenerateAdditionalTrustBundleSyncSetName(cd.Name),
+			Namespace:   cd.Namespace,
+			Annotations: map[string]string{constants.SyncSetMetricsGroupAnnotation: "trust-bundle"},
+		},
+		Spec: hivev1.SyncSetSpec{
+			SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
+				// The trust bundle is left on the cluster when syncing is disabled, as the proxy configuration of the
+				// cluster still refers to it.
+				ResourceApplyMode: hivev1.UpsertResourceApplyMode,
+				Resources:         []runtime.RawExtension{{Object: userCABundle}},
+				Patches: []hivev1.SyncObjectPatch{{
+					APIVersion: "config.openshift.io/v1",
+					Kind:       "Proxy",
+					Name:       "cluster",
+					Patch:      proxyTrustedCAPatch,
+					PatchType:  "merge",
+				}},
+			},
+			ClusterDeploymentRefs: []corev1.LocalObjectReference{
+				{
+					Name: cd.Name,
+				},
+			},
+		},
+	}
+
+	// ensure the syncset gets cleaned up when the clusterdeployment is deleted
+	syncSet.Labels = k8slabels.AddLabel(syncSet.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+	syncSet.Labels = k8slabels.AddLabel(syncSet.Labels, constants.SyncSetTypeLabel, constants.SyncSetTypeAdditionalTrustBundle)
+	if err := controllerutil.SetControllerReference(cd, syncSet, r.scheme); err != nil {
+		cdLog.WithError(err).Error("error setting owner reference")
+		return nil, err
+	}
+
+	return syncSet, nil
+}
+
+// GenerateAdditionalTrustBundleSyncSetName generates the name of the SyncSet that holds the additional trust bundle
+// to sync.
+func GenerateAdditionalTrustBundleSyncSetName(name string) string {
+	return apihelpers.GetResourceName(name, constants.AdditionalTrustBundleSuffix)
+}
+
+func generateOwnershipUniqueKeys(owner hivev1.MetaRuntimeObject) []*controllerutils.OwnershipUniqueKey {
+	return []*controllerutils.OwnershipUniqueKey{
+		{
+			TypeToList: &hivev1.SyncSetList{},
+			LabelSelector: map[string]string{
+				constants.ClusterDeploymentNameLabel: owner.GetName(),
+				constants.SyncSetTypeLabel:           constants.SyncSetTypeAdditionalTrustBundle,
+			},
+			Controlled: true,
+		},
+	}
+}
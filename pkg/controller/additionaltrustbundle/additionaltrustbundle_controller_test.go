@@ -0,0 +1,230 @@
+package additionaltrustbundle
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	fakeName      = "fake-cluster"
+	fakeNamespace = "fake-namespace"
+	fakeConfigMap = "fake-trust-bundle"
+	fakeCABundle  = "-----BEGIN CERTIFICATE-----\nfake\n-----END CERTIFICATE-----\n"
+
+	fakeInstallConfigSecret = "fake-install-config"
+	fakeInstallConfigBundle = "-----BEGIN CERTIFICATE-----\ninstallconfig\n-----END CERTIFICATE-----"
+)
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestReconcileAdditionalTrustBundle(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	tests := []struct {
+		name         string
+		trustBundle  *hivev1.AdditionalTrustBundle
+		notInstalled bool
+		// installConfig is the install config of the cluster, if it has one.
+		installConfig *string
+		existing      []runtime.Object
+
+		expectNoSyncSet      bool
+		expectErr            bool
+		expectedCABundle     string
+		expectRequeue        bool
+		expectSyncSetDeleted bool
+	}{
+		{
+			name:            "no additional trust bundle",
+			expectNoSyncSet: true,
+		},
+		{
+			name:            "additional trust bundle not synced",
+			trustBundle:     &hivev1.AdditionalTrustBundle{ConfigMapRef: corev1.LocalObjectReference{Name: fakeConfigMap}},
+			existing:        []runtime.Object{fakeTrustBundle(true)},
+			expectNoSyncSet: true,
+		},
+		{
+			name:                 "syncing disabled",
+			trustBundle:          &hivev1.AdditionalTrustBundle{ConfigMapRef: corev1.LocalObjectReference{Name: fakeConfigMap}},
+			existing:             []runtime.Object{fakeTrustBundle(true), fakeSyncSet()},
+			expectNoSyncSet:      true,
+			expectSyncSetDeleted: true,
+		},
+		{
+			name:        "additional trust bundle synced",
+			trustBundle: &hivev1.AdditionalTrustBundle{ConfigMapRef: corev1.LocalObjectReference{Name: fakeConfigMap}, SyncToCluster: true},
+			existing:    []runtime.Object{fakeTrustBundle(true)},
+		},
+		{
+			name:             "install config without additional trust bundle",
+			trustBundle:      &hivev1.AdditionalTrustBundle{ConfigMapRef: corev1.LocalObjectReference{Name: fakeConfigMap}, SyncToCluster: true},
+			installConfig:    pointer.StringPtr("baseDomain: example.com\n"),
+			existing:         []runtime.Object{fakeTrustBundle(true)},
+			expectedCABundle: fakeCABundle,
+		},
+		{
+			name:             "install config with additional trust bundle",
+			trustBundle:      &hivev1.AdditionalTrustBundle{ConfigMapRef: corev1.LocalObjectReference{Name: fakeConfigMap}, SyncToCluster: true},
+			installConfig:    pointer.StringPtr("baseDomain: example.com\nadditionalTrustBundle: |\n  " + strings.ReplaceAll(fakeInstallConfigBundle, "\n", "\n  ") + "\n"),
+			existing:         []runtime.Object{fakeTrustBundle(true)},
+			expectedCABundle: fakeInstallConfigBundle + "\n" + fakeCABundle,
+		},
+		{
+			name:            "missing install config",
+			trustBundle:     &hivev1.AdditionalTrustBundle{ConfigMapRef: corev1.LocalObjectReference{Name: fakeConfigMap}, SyncToCluster: true},
+			existing:        []runtime.Object{fakeTrustBundle(true)},
+			installConfig:   pointer.StringPtr(""),
+			expectNoSyncSet: true,
+			expectErr:       true,
+		},
+		{
+			name:            "cluster not installed",
+			trustBundle:     &hivev1.AdditionalTrustBundle{ConfigMapRef: corev1.LocalObjectReference{Name: fakeConfigMap}, SyncToCluster: true},
+			notInstalled:    true,
+			existing:        []runtime.Object{fakeTrustBundle(true)},
+			expectNoSyncSet: true,
+		},
+		{
+			name:            "missing config map",
+			trustBundle:     &hivev1.AdditionalTrustBundle{ConfigMapRef: corev1.LocalObjectReference{Name: fakeConfigMap}, SyncToCluster: true},
+			expectNoSyncSet: true,
+			expectRequeue:   true,
+		},
+		{
+			name:            "missing config map key",
+			trustBundle:     &hivev1.AdditionalTrustBundle{ConfigMapRef: corev1.LocalObjectReference{Name: fakeConfigMap}, SyncToCluster: true},
+			existing:        []runtime.Object{fakeTrustBundle(false)},
+			expectNoSyncSet: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cd := &hivev1.ClusterDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fakeName,
+					Namespace: fakeNamespace,
+				},
+				Spec: hivev1.ClusterDeploymentSpec{
+					Installed:             !test.notInstalled,
+					AdditionalTrustBundle: test.trustBundle,
+				},
+			}
+			existing := test.existing
+			if test.installConfig != nil {
+				cd.Spec.Provisioning = &hivev1.Provisioning{
+					InstallConfigSecretRef: &corev1.LocalObjectReference{Name: fakeInstallConfigSecret},
+				}
+				if *test.installConfig != "" {
+					existing = append(existing, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Namespace: fakeNamespace, Name: fakeInstallConfigSecret},
+						Data:       map[string][]byte{"install-config.yaml": []byte(*test.installConfig)},
+					})
+				}
+			}
+			fakeClient := fake.NewFakeClient(append(existing, cd)...)
+			applier := &fakeApplier{}
+			r := &ReconcileAdditionalTrustBundle{
+				Client:  fakeClient,
+				scheme:  scheme.Scheme,
+				applier: applier,
+			}
+
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: fakeName, Namespace: fakeNamespace},
+			})
+			if test.expectErr {
+				require.Error(t, err, "expected error from reconcile")
+			} else {
+				require.NoError(t, err, "unexpected error from reconcile")
+			}
+			assert.Equal(t, test.expectRequeue, result.RequeueAfter > 0, "unexpected requeue")
+
+			if test.expectSyncSetDeleted {
+				err := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: fakeNamespace, Name: GenerateAdditionalTrustBundleSyncSetName(fakeName)}, &hivev1.SyncSet{})
+				assert.True(t, apierrors.IsNotFound(err), "expected syncset to be deleted")
+			}
+			if test.expectNoSyncSet {
+				assert.Empty(t, applier.appliedObjects, "unexpected syncset apply")
+				return
+			}
+			require.Len(t, applier.appliedObjects, 1, "single apply expected")
+			require.IsType(t, &hivev1.SyncSet{}, applier.appliedObjects[0], "syncset apply expected")
+			ss := applier.appliedObjects[0].(*hivev1.SyncSet)
+
+			assert.Equal(t, hivev1.UpsertResourceApplyMode, ss.Spec.ResourceApplyMode, "unexpected resource apply mode")
+			assert.Equal(t, constants.SyncSetTypeAdditionalTrustBundle, ss.Labels[constants.SyncSetTypeLabel], "incorrect syncset type label")
+
+			if assert.Len(t, ss.Spec.Resources, 1, "expected a single resource") {
+				cm, ok := ss.Spec.Resources[0].Object.(*corev1.ConfigMap)
+				if assert.True(t, ok, "expected a config map resource") {
+					assert.Equal(t, openshiftConfigNamespace, cm.Namespace, "unexpected config map namespace")
+					assert.Equal(t, userCABundleName, cm.Name, "unexpected config map name")
+					expectedCABundle := test.expectedCABundle
+					if expectedCABundle == "" {
+						expectedCABundle = fakeCABundle
+					}
+					assert.Equal(t, expectedCABundle, cm.Data[constants.AdditionalTrustBundleConfigMapKey], "unexpected trust bundle")
+				}
+			}
+			if assert.Len(t, ss.Spec.Patches, 1, "expected a single patch") {
+				assert.Equal(t, "Proxy", ss.Spec.Patches[0].Kind, "unexpected patch kind")
+				assert.Equal(t, proxyTrustedCAPatch, ss.Spec.Patches[0].Patch, "unexpected patch")
+			}
+		})
+	}
+}
+
+type fakeApplier struct {
+	appliedObjects []runtime.Object
+}
+
+func (a *fakeApplier) ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error) {
+	a.appliedObjects = append(a.appliedObjects, obj)
+	return "", nil
+}
+
+func fakeTrustBundle(withKey bool) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fakeConfigMap,
+			Namespace: fakeNamespace,
+		},
+	}
+	if withKey {
+		cm.Data = map[string]string{constants.AdditionalTrustBundleConfigMapKey: fakeCABundle}
+	}
+	return cm
+}
+
+func fakeSyncSet() *hivev1.SyncSet {
+	return &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GenerateAdditionalTrustBundleSyncSetName(fakeName),
+			Namespace: fakeNamespace,
+		},
+	}
+}
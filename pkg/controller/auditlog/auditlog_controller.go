@@ -0,0 +1,303 @@
+package auditlog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/resource"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	ControllerName = hivev1.AuditLogControllerName
+
+	loggingNamespace     = "openshift-logging"
+	logForwarderName     = "instance"
+	auditLogPipelineName = "hive-audit-logs"
+
+	auditProfilePatchTemplate = `{"spec": {"audit": {"profile": %q}}}`
+)
+
+var (
+	secretCheckInterval = 2 * time.Minute
+)
+
+type applier interface {
+	ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error)
+}
+
+// Add creates a new AuditLog Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := controllerutils.ControllerLogEntry(ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
+	logger := controllerutils.ControllerLogEntry(ControllerName)
+	helper, err := resource.NewHelperWithMetricsFromRESTConfig(mgr.GetConfig(), ControllerName, logger)
+	if err != nil {
+		// Hard exit if we can't create this controller
+		logger.WithError(err).Fatal("unable to create resource helper")
+	}
+	return &ReconcileAuditLog{
+		Client:  controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme:  mgr.GetScheme(),
+		applier: helper,
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controllerutils.NewController("auditlog-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileAuditLog{}
+
+// ReconcileAuditLog reconciles the audit log configuration of a ClusterDeployment into a SyncSet for the cluster.
+type ReconcileAuditLog struct {
+	client.Client
+	scheme  *runtime.Scheme
+	applier applier
+}
+
+// Reconcile renders the audit policy and audit log forwarding configured for a ClusterDeployment into a SyncSet that
+// sets the audit profile of the APIServer configuration and the ClusterLogForwarder of the cluster.
+func (r *ReconcileAuditLog) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), request.NamespacedName, cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// Ensure owner references are correctly set
+	if err := controllerutils.ReconcileOwnerReferences(cd, generateOwnershipUniqueKeys(cd), r, r.scheme, cdLog); err != nil {
+		cdLog.WithError(err).Error("Error reconciling object ownership")
+		return reconcile.Result{}, err
+	}
+
+	if controllerutils.IsClusterDeploymentPaused(cd, cdLog) {
+		return reconcile.Result{}, nil
+	}
+
+	if cd.DeletionTimestamp != nil || !cd.Spec.Installed {
+		return reconcile.Result{}, nil
+	}
+
+	auditLog := cd.Spec.ControlPlaneConfig.AuditLog
+	if auditLog == nil || (auditLog.Profile == "" && len(auditLog.Outputs) == 0) {
+		cdLog.Debug("no audit log configuration, removing any existing syncset")
+		return reconcile.Result{}, resource.DeleteAnyExistingObject(
+			r,
+			types.NamespacedName{Namespace: cd.Namespace, Name: GenerateAuditLogSyncSetName(cd.Name)},
+			&hivev1.SyncSet{},
+			cdLog,
+		)
+	}
+
+	for _, output := range auditLog.Outputs {
+		if output.SecretRef == nil {
+			continue
+		}
+		secret := &corev1.Secret{}
+		switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: output.SecretRef.Name}, secret); {
+		case apierrors.IsNotFound(err):
+			cdLog.WithField("secret", output.SecretRef.Name).Infof("audit log output secret is not available yet, requeueing clusterdeployment for %s", secretCheckInterval)
+			return reconcile.Result{RequeueAfter: secretCheckInterval}, nil
+		case err != nil:
+			cdLog.WithError(err).WithField("secret", output.SecretRef.Name).Error("error retrieving audit log output secret")
+			return reconcile.Result{}, err
+		}
+	}
+
+	syncSet, err := r.generateAuditLogSyncSet(cd, auditLog, cdLog)
+	if err != nil {
+		cdLog.WithError(err).Error("failed to generate audit log syncset")
+		return reconcile.Result{}, err
+	}
+	if _, err := r.applier.ApplyRuntimeObject(syncSet, r.scheme); err != nil {
+		cdLog.WithError(err).Error("failed to apply audit log syncset")
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func (r *ReconcileAuditLog) generateAuditLogSyncSet(cd *hivev1.ClusterDeployment, auditLog *hivev1.AuditLogConfig, cdLog log.FieldLogger) (*hivev1.SyncSet, error) {
+	cdLog.Debug("generating syncset for audit log configuration")
+	syncSet := &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        GenerateAuditLogSyncSetName(cd.Name),
+			Namespace:   cd.Namespace,
+			Annotations: map[string]string{constants.SyncSetMetricsGroupAnnotation: "audit-log"},
+		},
+		Spec: hivev1.SyncSetSpec{
+			SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
+				// Sync mode removes the log forwarder from the cluster once all outputs are removed.
+				ResourceApplyMode: hivev1.SyncResourceApplyMode,
+			},
+			ClusterDeploymentRefs: []corev1.LocalObjectReference{
+				{
+					Name: cd.Name,
+				},
+			},
+		},
+	}
+
+	if auditLog.Profile != "" {
+		cdLog.WithField("profile", auditLog.Profile).Debug("setting audit profile of the cluster")
+		syncSet.Spec.Patches = []hivev1.SyncObjectPatch{{
+			APIVersion: "config.openshift.io/v1",
+			Kind:       "APIServer",
+			Name:       "cluster",
+			Patch:      fmt.Sprintf(auditProfilePatchTemplate, auditLog.Profile),
+			PatchType:  "merge",
+		}}
+	}
+
+	if len(auditLog.Outputs) > 0 {
+		secretMappings := []hivev1.SecretMapping{}
+		for _, output := range auditLog.Outputs {
+			if output.SecretRef == nil {
+				continue
+			}
+			cdLog.WithField("secret", output.SecretRef.Name).Debug("adding secret to secretMappings list")
+			secretMappings = append(secretMappings, hivev1.SecretMapping{
+				SourceRef: hivev1.SecretReference{
+					Namespace: cd.Namespace,
+					Name:      output.SecretRef.Name,
+				},
+				TargetRef: hivev1.SecretReference{
+					Namespace: loggingNamespace,
+					Name:      remoteSecretName(output.SecretRef.Name, cd),
+				},
+			})
+		}
+		syncSet.Spec.Secrets = secretMappings
+		syncSet.Spec.Resources = []runtime.RawExtension{{Object: logForwarder(cd, auditLog.Outputs)}}
+	}
+
+	// ensure the syncset gets cleaned up when the clusterdeployment is deleted
+	syncSet.Labels = k8slabels.AddLabel(syncSet.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+	syncSet.Labels = k8slabels.AddLabel(syncSet.Labels, constants.SyncSetTypeLabel, constants.SyncSetTypeAuditLog)
+	if err := controllerutil.SetControllerReference(cd, syncSet, r.scheme); err != nil {
+		cdLog.WithError(err).Error("error setting owner reference")
+		return nil, err
+	}
+
+	return syncSet, nil
+}
+
+// logForwarder returns the ClusterLogForwarder that forwards the audit logs of the cluster to the outputs. The
+// ClusterLogForwarder is built as an unstructured object as the logging API is not vendored.
+func logForwarder(cd *hivev1.ClusterDeployment, outputs []hivev1.AuditLogOutput) *unstructured.Unstructured {
+	forwarderOutputs := make([]interface{}, len(outputs))
+	outputRefs := make([]interface{}, len(outputs))
+	for i, output := range outputs {
+		forwarderOutput := map[string]interface{}{
+			"name": output.Name,
+			"type": output.Type,
+		}
+		if output.URL != "" {
+			forwarderOutput["url"] = output.URL
+		}
+		if output.SecretRef != nil {
+			forwarderOutput["secret"] = map[string]interface{}{
+				"name": remoteSecretName(output.SecretRef.Name, cd),
+			}
+		}
+		forwarderOutputs[i] = forwarderOutput
+		outputRefs[i] = output.Name
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "logging.openshift.io/v1",
+		"kind":       "ClusterLogForwarder",
+		"metadata": map[string]interface{}{
+			"name":      logForwarderName,
+			"namespace": loggingNamespace,
+		},
+		"spec": map[string]interface{}{
+			"outputs": forwarderOutputs,
+			"pipelines": []interface{}{
+				map[string]interface{}{
+					"name":       auditLogPipelineName,
+					"inputRefs":  []interface{}{"audit"},
+					"outputRefs": outputRefs,
+				},
+			},
+		},
+	}}
+}
+
+func remoteSecretName(secretName string, cd *hivev1.ClusterDeployment) string {
+	return apihelpers.GetResourceName(cd.Name, secretName)
+}
+
+// GenerateAuditLogSyncSetName generates the name of the SyncSet that holds the audit log configuration to sync.
+func GenerateAuditLogSyncSetName(name string) string {
+	return apihelpers.GetResourceName(name, constants.AuditLogSuffix)
+}
+
+func generateOwnershipUniqueKeys(owner hivev1.MetaRuntimeObject) []*controllerutils.OwnershipUniqueKey {
+	return []*controllerutils.OwnershipUniqueKey{
+		{
+			TypeToList: &hivev1.SyncSetList{},
+			LabelSelector: map[string]string{
+				constants.ClusterDeploymentNameLabel: owner.GetName(),
+				constants.SyncSetTypeLabel:           constants.SyncSetTypeAuditLog,
+			},
+			Controlled: true,
+		},
+	}
+}
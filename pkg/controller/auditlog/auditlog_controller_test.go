@@ -0,0 +1,204 @@
+package auditlog
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/resource"
+	testsecret "github.com/openshift/hive/pkg/test/secret"
+)
+
+const (
+	fakeName      = "fake-cluster"
+	fakeNamespace = "fake-namespace"
+)
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestReconcileAuditLog(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	syslogOutput := hivev1.AuditLogOutput{
+		Name: "remote-syslog",
+		Type: "syslog",
+		URL:  "tls://syslog.example.com:6514",
+	}
+	elasticsearchOutput := hivev1.AuditLogOutput{
+		Name:      "remote-elasticsearch",
+		Type:      "elasticsearch",
+		URL:       "https://elasticsearch.example.com:9200",
+		SecretRef: &corev1.LocalObjectReference{Name: "es-secret"},
+	}
+
+	tests := []struct {
+		name     string
+		auditLog *hivev1.AuditLogConfig
+		existing []runtime.Object
+
+		expectNoSyncSet       bool
+		expectRequeue         bool
+		expectSyncSetDeleted  bool
+		expectedPatch         string
+		expectedOutputs       []interface{}
+		expectedSecretTargets []string
+	}{
+		{
+			name:            "no audit log configuration",
+			expectNoSyncSet: true,
+		},
+		{
+			name:                 "audit log configuration removed",
+			existing:             []runtime.Object{fakeSyncSet()},
+			expectNoSyncSet:      true,
+			expectSyncSetDeleted: true,
+		},
+		{
+			name:          "audit profile only",
+			auditLog:      &hivev1.AuditLogConfig{Profile: hivev1.WriteRequestBodiesAuditProfileType},
+			expectedPatch: `{"spec": {"audit": {"profile": "WriteRequestBodies"}}}`,
+		},
+		{
+			name: "audit profile and outputs",
+			auditLog: &hivev1.AuditLogConfig{
+				Profile: hivev1.AllRequestBodiesAuditProfileType,
+				Outputs: []hivev1.AuditLogOutput{syslogOutput, elasticsearchOutput},
+			},
+			existing: []runtime.Object{
+				testsecret.FullBuilder(fakeNamespace, "es-secret", scheme.Scheme).Build(),
+			},
+			expectedPatch: `{"spec": {"audit": {"profile": "AllRequestBodies"}}}`,
+			expectedOutputs: []interface{}{
+				map[string]interface{}{
+					"name": "remote-syslog",
+					"type": "syslog",
+					"url":  "tls://syslog.example.com:6514",
+				},
+				map[string]interface{}{
+					"name":   "remote-elasticsearch",
+					"type":   "elasticsearch",
+					"url":    "https://elasticsearch.example.com:9200",
+					"secret": map[string]interface{}{"name": "fake-cluster-es-secret"},
+				},
+			},
+			expectedSecretTargets: []string{"fake-cluster-es-secret"},
+		},
+		{
+			name: "missing output secret",
+			auditLog: &hivev1.AuditLogConfig{
+				Outputs: []hivev1.AuditLogOutput{elasticsearchOutput},
+			},
+			expectNoSyncSet: true,
+			expectRequeue:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cd := &hivev1.ClusterDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fakeName,
+					Namespace: fakeNamespace,
+				},
+				Spec: hivev1.ClusterDeploymentSpec{
+					Installed: true,
+					ControlPlaneConfig: hivev1.ControlPlaneConfigSpec{
+						AuditLog: test.auditLog,
+					},
+				},
+			}
+			fakeClient := fake.NewFakeClient(append(test.existing, cd)...)
+			applier := &fakeApplier{}
+			r := &ReconcileAuditLog{
+				Client:  fakeClient,
+				scheme:  scheme.Scheme,
+				applier: applier,
+			}
+
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: fakeName, Namespace: fakeNamespace},
+			})
+			require.NoError(t, err, "unexpected error from reconcile")
+			assert.Equal(t, test.expectRequeue, result.RequeueAfter > 0, "unexpected requeue")
+
+			if test.expectSyncSetDeleted {
+				err := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: fakeNamespace, Name: GenerateAuditLogSyncSetName(fakeName)}, &hivev1.SyncSet{})
+				assert.True(t, apierrors.IsNotFound(err), "expected syncset to be deleted")
+			}
+			if test.expectNoSyncSet {
+				assert.Empty(t, applier.appliedObjects, "unexpected syncset apply")
+				return
+			}
+			require.Len(t, applier.appliedObjects, 1, "single apply expected")
+			require.IsType(t, &hivev1.SyncSet{}, applier.appliedObjects[0], "syncset apply expected")
+			ss := applier.appliedObjects[0].(*hivev1.SyncSet)
+
+			assert.Equal(t, hivev1.SyncResourceApplyMode, ss.Spec.ResourceApplyMode, "unexpected resource apply mode")
+			assert.Equal(t, constants.SyncSetTypeAuditLog, ss.Labels[constants.SyncSetTypeLabel], "incorrect syncset type label")
+
+			if test.expectedPatch == "" {
+				assert.Empty(t, ss.Spec.Patches, "unexpected patches")
+			} else if assert.Len(t, ss.Spec.Patches, 1, "expected a single patch") {
+				assert.Equal(t, "APIServer", ss.Spec.Patches[0].Kind, "unexpected patch kind")
+				assert.Equal(t, test.expectedPatch, ss.Spec.Patches[0].Patch, "unexpected patch")
+			}
+
+			if test.expectedOutputs == nil {
+				assert.Empty(t, ss.Spec.Resources, "unexpected resources")
+			} else if assert.Len(t, ss.Spec.Resources, 1, "expected a single resource") {
+				forwarder := ss.Spec.Resources[0].Object.(*unstructured.Unstructured)
+				assert.Equal(t, "ClusterLogForwarder", forwarder.GetKind(), "unexpected resource kind")
+				outputs, _, _ := unstructured.NestedSlice(forwarder.Object, "spec", "outputs")
+				assert.Equal(t, test.expectedOutputs, outputs, "unexpected outputs")
+				pipelines, _, _ := unstructured.NestedSlice(forwarder.Object, "spec", "pipelines")
+				if assert.Len(t, pipelines, 1, "expected a single pipeline") {
+					assert.Equal(t, []interface{}{"audit"}, pipelines[0].(map[string]interface{})["inputRefs"], "unexpected pipeline inputs")
+					assert.Len(t, pipelines[0].(map[string]interface{})["outputRefs"], len(test.expectedOutputs), "unexpected pipeline outputs")
+				}
+			}
+
+			var secretTargets []string
+			for _, mapping := range ss.Spec.Secrets {
+				assert.Equal(t, loggingNamespace, mapping.TargetRef.Namespace, "unexpected secret target namespace")
+				secretTargets = append(secretTargets, mapping.TargetRef.Name)
+			}
+			assert.Equal(t, test.expectedSecretTargets, secretTargets, "unexpected secret targets")
+		})
+	}
+}
+
+type fakeApplier struct {
+	appliedObjects []runtime.Object
+}
+
+func (a *fakeApplier) ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (resource.ApplyResult, error) {
+	a.appliedObjects = append(a.appliedObjects, obj)
+	return "", nil
+}
+
+func fakeSyncSet() *hivev1.SyncSet {
+	return &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GenerateAuditLogSyncSetName(fakeName),
+			Namespace: fakeNamespace,
+		},
+	}
+}
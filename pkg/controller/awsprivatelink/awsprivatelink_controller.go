@@ -56,7 +56,7 @@ const (
 // Add creates a new AWSPrivateLink Controller and adds it to the Manager with default RBAC.
 // The Manager will set fields on the Controller and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
 	if err != nil {
 		logger.WithError(err).Error("could not get controller configurations")
@@ -72,7 +72,7 @@ func Add(mgr manager.Manager) error {
 
 // NewReconciler returns a new ReconcileClusterClaim
 func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (*ReconcileAWSPrivateLink, error) {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	reconciler := &ReconcileAWSPrivateLink{
 		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
 	}
@@ -90,7 +90,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (*R
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r *ReconcileAWSPrivateLink, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
-	c, err := controller.New("awsprivatelink-controller", mgr, controller.Options{
+	c, err := controllerutils.NewController("awsprivatelink-controller", mgr, controller.Options{
 		Reconciler:              r,
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
@@ -102,7 +102,7 @@ func AddToManager(mgr manager.Manager, r *ReconcileAWSPrivateLink, concurrentRec
 	// Watch for changes to ClusterDeployment
 	err = c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{})
 	if err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deployment")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error watching cluster deployment")
 		return err
 	}
 
@@ -112,7 +112,7 @@ func AddToManager(mgr manager.Manager, r *ReconcileAWSPrivateLink, concurrentRec
 			IsController: true,
 			OwnerType:    &hivev1.ClusterDeployment{},
 		}); err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster provision")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error watching cluster provision")
 		return err
 	}
 
@@ -122,7 +122,7 @@ func AddToManager(mgr manager.Manager, r *ReconcileAWSPrivateLink, concurrentRec
 			IsController: true,
 			OwnerType:    &hivev1.ClusterDeployment{},
 		}); err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deprovision")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error watching cluster deprovision")
 		return err
 	}
 
@@ -168,10 +168,16 @@ func (r *ReconcileAWSPrivateLink) Reconcile(ctx context.Context, request reconci
 		logger.Debug("controller cannot service the clusterdeployment, so skipping")
 		return reconcile.Result{}, nil
 	}
+
+	// The resources of a paused cluster deployment are left untouched, even when private link has been disabled.
+	if controllerutils.IsClusterDeploymentPaused(cd, logger) {
+		return reconcile.Result{}, nil
+	}
+
 	if !cd.Spec.Platform.AWS.PrivateLink.Enabled {
 		if cleanupRequired(cd) {
 			// private link was disabled for this cluster so cleanup is required.
-			return r.cleanupClusterDeployment(cd, cd.Spec.ClusterMetadata, logger)
+			return r.cleanupClusterDeployment(ctx, cd, cd.Spec.ClusterMetadata, logger)
 		}
 
 		logger.Debug("cluster deployment does not have private link enabled, so skipping")
@@ -179,7 +185,7 @@ func (r *ReconcileAWSPrivateLink) Reconcile(ctx context.Context, request reconci
 	}
 
 	if cd.DeletionTimestamp != nil {
-		return r.cleanupClusterDeployment(cd, cd.Spec.ClusterMetadata, logger)
+		return r.cleanupClusterDeployment(ctx, cd, cd.Spec.ClusterMetadata, logger)
 	}
 
 	// Add finalizer if not already present
@@ -192,14 +198,7 @@ func (r *ReconcileAWSPrivateLink) Reconcile(ctx context.Context, request reconci
 		}
 	}
 
-	supportedRegion := false
-	for _, item := range r.controllerconfig.EndpointVPCInventory {
-		if strings.EqualFold(item.Region, cd.Spec.Platform.AWS.Region) {
-			supportedRegion = true
-			break
-		}
-	}
-	if !supportedRegion {
+	if _, supportedRegion := EndpointRegion(r.controllerconfig, cd); !supportedRegion {
 		err := errors.Errorf("cluster deployment region %q is not supported as there is no inventory to create necessary resources",
 			cd.Spec.Platform.AWS.Region)
 		logger.WithError(err).Error("cluster deployment region is not supported, so skipping")
@@ -224,7 +223,7 @@ func (r *ReconcileAWSPrivateLink) Reconcile(ctx context.Context, request reconci
 
 	if cd.Spec.Installed {
 		logger.Debug("reconciling already installed cluster deployment")
-		return r.reconcilePrivateLink(cd, cd.Spec.ClusterMetadata, logger)
+		return r.reconcilePrivateLink(ctx, cd, cd.Spec.ClusterMetadata, logger)
 	}
 
 	if cd.Status.ProvisionRef == nil {
@@ -250,7 +249,7 @@ func (r *ReconcileAWSPrivateLink) Reconcile(ctx context.Context, request reconci
 			logger.WithField("prevInfraID", *cp.Spec.PrevInfraID).
 				Info("cleaning up PrivateLink resources from previous attempt")
 
-			if err := r.cleanupPreviousProvisionAttempt(cd, cp, logger); err != nil {
+			if err := r.cleanupPreviousProvisionAttempt(ctx, cd, cp, logger); err != nil {
 				logger.WithError(err).Error("error cleaning up PrivateLink resources for ClusterDeployment")
 
 				if err := r.setErrCondition(cd, "CleanupForProvisionReattemptFailed", err, logger); err != nil {
@@ -280,7 +279,7 @@ func (r *ReconcileAWSPrivateLink) Reconcile(ctx context.Context, request reconci
 		return reconcile.Result{}, nil
 	}
 
-	return r.reconcilePrivateLink(cd, &hivev1.ClusterMetadata{InfraID: *cp.Spec.InfraID, AdminKubeconfigSecretRef: *cp.Spec.AdminKubeconfigSecretRef}, logger)
+	return r.reconcilePrivateLink(ctx, cd, &hivev1.ClusterMetadata{InfraID: *cp.Spec.InfraID, AdminKubeconfigSecretRef: *cp.Spec.AdminKubeconfigSecretRef}, logger)
 }
 
 // shouldSync returns if we should sync the desired ClusterDeployment. If it returns false, it also returns
@@ -413,7 +412,7 @@ func (r *ReconcileAWSPrivateLink) setReadyCondition(cd *hivev1.ClusterDeployment
 	return r.Status().Update(context.TODO(), curr)
 }
 
-func (r *ReconcileAWSPrivateLink) reconcilePrivateLink(cd *hivev1.ClusterDeployment, clusterMetadata *hivev1.ClusterMetadata, logger log.FieldLogger) (reconcile.Result, error) {
+func (r *ReconcileAWSPrivateLink) reconcilePrivateLink(ctx context.Context, cd *hivev1.ClusterDeployment, clusterMetadata *hivev1.ClusterMetadata, logger log.FieldLogger) (reconcile.Result, error) {
 	logger.Debug("reconciling PrivateLink resources")
 	awsClient, err := newAWSClient(r, cd)
 	if err != nil {
@@ -422,7 +421,7 @@ func (r *ReconcileAWSPrivateLink) reconcilePrivateLink(cd *hivev1.ClusterDeploym
 	}
 
 	// discover the NLB for the cluster.
-	nlbARN, err := discoverNLBForCluster(awsClient.user, clusterMetadata.InfraID, logger)
+	nlbARN, err := discoverNLBForCluster(ctx, awsClient.user, clusterMetadata.InfraID, logger)
 	if err != nil {
 		if awsErrCodeEquals(err, "LoadBalancerNotFound") {
 			logger.WithField("infraID", clusterMetadata.InfraID).Debug("NLB is not yet created for the cluster, will retry later")
@@ -447,7 +446,7 @@ func (r *ReconcileAWSPrivateLink) reconcilePrivateLink(cd *hivev1.ClusterDeploym
 	}
 
 	// reconcile the VPC Endpoint Service
-	serviceModified, vpcEndpointService, err := r.reconcileVPCEndpointService(awsClient, cd, clusterMetadata, nlbARN, logger)
+	serviceModified, vpcEndpointService, err := r.reconcileVPCEndpointService(ctx, awsClient, cd, clusterMetadata, nlbARN, logger)
 	if err != nil {
 		logger.WithError(err).Error("failed to reconcile the VPC Endpoint Service")
 
@@ -468,7 +467,7 @@ func (r *ReconcileAWSPrivateLink) reconcilePrivateLink(cd *hivev1.ClusterDeploym
 	}
 
 	// Create the VPC endpoint with the chosen VPC.
-	endpointModified, vpcEndpoint, err := r.reconcileVPCEndpoint(awsClient, cd, clusterMetadata, vpcEndpointService, logger)
+	endpointModified, vpcEndpoint, err := r.reconcileVPCEndpoint(ctx, awsClient, cd, clusterMetadata, vpcEndpointService, logger)
 	if err != nil {
 		logger.WithError(err).Error("failed to reconcile the VPC Endpoint")
 		reason := "VPCEndpointReconcileFailed"
@@ -510,7 +509,7 @@ func (r *ReconcileAWSPrivateLink) reconcilePrivateLink(cd *hivev1.ClusterDeploym
 	}
 
 	// Create the Private Hosted Zone for the VPC Endpoint.
-	hzModified, hostedZoneID, err := r.reconcileHostedZone(awsClient, cd, clusterMetadata, vpcEndpoint, apiDomain, logger)
+	hzModified, hostedZoneID, err := r.reconcileHostedZone(ctx, awsClient, cd, clusterMetadata, vpcEndpoint, apiDomain, logger)
 	if err != nil {
 		logger.WithError(err).Error("could not reconcile the Hosted Zone")
 
@@ -532,7 +531,7 @@ func (r *ReconcileAWSPrivateLink) reconcilePrivateLink(cd *hivev1.ClusterDeploym
 	}
 
 	// Associate the VPCs to the hosted zone.
-	associationsModified, err := r.reconcileHostedZoneAssociations(awsClient, cd, hostedZoneID, vpcEndpoint, logger)
+	associationsModified, err := r.reconcileHostedZoneAssociations(ctx, awsClient, cd, hostedZoneID, vpcEndpoint, logger)
 	if err != nil {
 		logger.WithError(err).Error("could not reconcile the associations of the Hosted Zone")
 
@@ -566,9 +565,9 @@ func (r *ReconcileAWSPrivateLink) reconcilePrivateLink(cd *hivev1.ClusterDeploym
 
 // discoverNLBForCluster uses the AWS client to find the NLB for cluster's internal APIserver.
 // The NLB created by the installer is named as {infraID}-int
-func discoverNLBForCluster(client awsclient.Client, infraID string, logger log.FieldLogger) (string, error) {
+func discoverNLBForCluster(ctx context.Context, client awsclient.Client, infraID string, logger log.FieldLogger) (string, error) {
 	nlbName := infraID + "-int"
-	nlbs, err := client.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
+	nlbs, err := client.DescribeLoadBalancers(ctx, &elbv2.DescribeLoadBalancersInput{
 		Names: aws.StringSlice([]string{nlbName}),
 	})
 	if err != nil {
@@ -578,7 +577,7 @@ func discoverNLBForCluster(client awsclient.Client, infraID string, logger log.F
 	nlbLog := logger.WithField("nlbARN", nlbARN)
 
 	if err := waitForState(elbv2.LoadBalancerStateEnumActive, 1*time.Minute, func() (string, error) {
-		resp, err := client.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
+		resp, err := client.DescribeLoadBalancers(ctx, &elbv2.DescribeLoadBalancersInput{
 			LoadBalancerArns: aws.StringSlice([]string{nlbARN}),
 		})
 		if err != nil {
@@ -614,13 +613,13 @@ func waitForState(state string, timeout time.Duration, currentState func() (stri
 // also makes sure that acceptance is not required when a VPC endpoint is created for the service.
 // The function also continously makes sure that the NLB used by the service is always the one computed
 // by the controller for the cluster.
-func (r *ReconcileAWSPrivateLink) reconcileVPCEndpointService(awsClient *awsClient,
+func (r *ReconcileAWSPrivateLink) reconcileVPCEndpointService(ctx context.Context, awsClient *awsClient,
 	cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
 	nlbARN string,
 	logger log.FieldLogger) (bool, *ec2.ServiceConfiguration, error) {
 	modified := false
 
-	serviceModified, serviceConfig, err := r.ensureVPCEndpointService(awsClient.user, cd, metadata, nlbARN, logger)
+	serviceModified, serviceConfig, err := r.ensureVPCEndpointService(ctx, awsClient.user, cd, metadata, nlbARN, logger)
 	if err != nil {
 		logger.WithError(err).Error("error making sure VPC Endpoint Service exists for the cluster")
 		return modified, nil, err
@@ -646,20 +645,20 @@ func (r *ReconcileAWSPrivateLink) reconcileVPCEndpointService(awsClient *awsClie
 			modification.RemoveNetworkLoadBalancerArns = aws.StringSlice(removed)
 		}
 
-		_, err := awsClient.user.ModifyVpcEndpointServiceConfiguration(modification)
+		_, err := awsClient.user.ModifyVpcEndpointServiceConfiguration(ctx, modification)
 		if err != nil {
 			serviceLog.WithError(err).Error("error updating VPC Endpoint Service configuration to match the desired state")
 			return modified, nil, err
 		}
 	}
 
-	stsResp, err := awsClient.hub.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	stsResp, err := awsClient.hub.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
 	if err != nil {
 		serviceLog.WithError(err).Error("error getting the identity of the user that will create the VPC Endpoint")
 		return modified, nil, err
 	}
 
-	permResp, err := awsClient.user.DescribeVpcEndpointServicePermissions(&ec2.DescribeVpcEndpointServicePermissionsInput{
+	permResp, err := awsClient.user.DescribeVpcEndpointServicePermissions(ctx, &ec2.DescribeVpcEndpointServicePermissionsInput{
 		ServiceId: serviceConfig.ServiceId,
 	})
 	if err != nil {
@@ -672,6 +671,7 @@ func (r *ReconcileAWSPrivateLink) reconcileVPCEndpointService(awsClient *awsClie
 		oldPerms.Insert(aws.StringValue(allowed.Principal))
 	}
 	desriredPerms := sets.NewString(aws.StringValue(stsResp.Arn))
+	desriredPerms.Insert(cd.Spec.Platform.AWS.PrivateLink.AdditionalAllowedPrincipals...)
 
 	if !desriredPerms.Equal(oldPerms) {
 		modified = true
@@ -684,7 +684,7 @@ func (r *ReconcileAWSPrivateLink) reconcileVPCEndpointService(awsClient *awsClie
 		if removed := oldPerms.Difference(desriredPerms).List(); len(removed) > 0 {
 			input.RemoveAllowedPrincipals = aws.StringSlice(removed)
 		}
-		_, err := awsClient.user.ModifyVpcEndpointServicePermissions(input)
+		_, err := awsClient.user.ModifyVpcEndpointServicePermissions(ctx, input)
 		if err != nil {
 			serviceLog.WithField("addAllowed", aws.StringValueSlice(input.AddAllowedPrincipals)).
 				WithField("removeAllowed", aws.StringValueSlice(input.RemoveAllowedPrincipals)).
@@ -693,16 +693,27 @@ func (r *ReconcileAWSPrivateLink) reconcileVPCEndpointService(awsClient *awsClie
 		}
 	}
 
+	if awsClient.isCrossRegion(cd) && !vpcEndpointCreated(cd) {
+		// the VPC Endpoint is created in another region, so that region must be supported by the
+		// service before the VPC Endpoint can be created.
+		if err := awsClient.user.AddVpcEndpointServiceSupportedRegions(ctx, *serviceConfig.ServiceId,
+			[]string{awsClient.endpointRegion}); err != nil {
+			serviceLog.WithField("endpointRegion", awsClient.endpointRegion).
+				WithError(err).Error("error adding the VPC Endpoint region to the supported regions of the VPC Endpoint Service")
+			return modified, nil, err
+		}
+	}
+
 	return modified, serviceConfig, nil
 }
 
-func (r *ReconcileAWSPrivateLink) ensureVPCEndpointService(awsClient awsclient.Client, cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, clusterNLB string, logger log.FieldLogger) (bool, *ec2.ServiceConfiguration, error) {
+func (r *ReconcileAWSPrivateLink) ensureVPCEndpointService(ctx context.Context, awsClient awsclient.Client, cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, clusterNLB string, logger log.FieldLogger) (bool, *ec2.ServiceConfiguration, error) {
 	modified := false
 	tag := ec2FilterForCluster(metadata)
 	serviceLog := logger.WithField("tag:key", aws.StringValue(tag.Name)).WithField("tag:value", aws.StringValueSlice(tag.Values))
 
 	var serviceConfig *ec2.ServiceConfiguration
-	resp, err := awsClient.DescribeVpcEndpointServiceConfigurations(&ec2.DescribeVpcEndpointServiceConfigurationsInput{
+	resp, err := awsClient.DescribeVpcEndpointServiceConfigurations(ctx, &ec2.DescribeVpcEndpointServiceConfigurationsInput{
 		Filters: []*ec2.Filter{tag},
 	})
 	if err != nil {
@@ -711,7 +722,7 @@ func (r *ReconcileAWSPrivateLink) ensureVPCEndpointService(awsClient awsclient.C
 	}
 	if len(resp.ServiceConfigurations) == 0 {
 		modified = true
-		serviceConfig, err = createVPCEndpointService(awsClient, cd, metadata, clusterNLB, logger)
+		serviceConfig, err = createVPCEndpointService(ctx, awsClient, cd, metadata, clusterNLB, logger)
 		if err != nil {
 			logger.WithError(err).Error("failed to create VPC Endpoint Service for cluster")
 			return modified, nil, errors.Wrap(err, "failed to create VPC Enpoint Service for cluster")
@@ -733,8 +744,8 @@ func (r *ReconcileAWSPrivateLink) ensureVPCEndpointService(awsClient awsclient.C
 	return modified, serviceConfig, nil
 }
 
-func createVPCEndpointService(awsClient awsclient.Client, cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, clusterNLB string, logger log.FieldLogger) (*ec2.ServiceConfiguration, error) {
-	resp, err := awsClient.CreateVpcEndpointServiceConfiguration(&ec2.CreateVpcEndpointServiceConfigurationInput{
+func createVPCEndpointService(ctx context.Context, awsClient awsclient.Client, cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, clusterNLB string, logger log.FieldLogger) (*ec2.ServiceConfiguration, error) {
+	resp, err := awsClient.CreateVpcEndpointServiceConfiguration(ctx, &ec2.CreateVpcEndpointServiceConfigurationInput{
 		AcceptanceRequired:      aws.Bool(false),
 		NetworkLoadBalancerArns: aws.StringSlice([]string{clusterNLB}),
 		TagSpecifications:       []*ec2.TagSpecification{ec2TagSpecification(metadata, "vpc-endpoint-service")},
@@ -747,7 +758,7 @@ func createVPCEndpointService(awsClient awsclient.Client, cd *hivev1.ClusterDepl
 	serviceLog := logger.WithField("serviceID", *resp.ServiceConfiguration.ServiceId)
 
 	if err := waitForState(ec2.ServiceStateAvailable, 1*time.Minute, func() (string, error) {
-		resp, err := awsClient.DescribeVpcEndpointServiceConfigurations(&ec2.DescribeVpcEndpointServiceConfigurationsInput{
+		resp, err := awsClient.DescribeVpcEndpointServiceConfigurations(ctx, &ec2.DescribeVpcEndpointServiceConfigurationsInput{
 			ServiceIds: aws.StringSlice([]string{*resp.ServiceConfiguration.ServiceId}),
 		})
 		if err != nil {
@@ -769,31 +780,42 @@ func createVPCEndpointService(awsClient awsclient.Client, cd *hivev1.ClusterDepl
 //	- VPC that has at least one subnet in the AZs supported by the VPC endpoint service
 //	- VPC that has VPC endpoints < 255
 // It currently doesn't manage any properties of the VPC endpoint once it is created.
-func (r *ReconcileAWSPrivateLink) reconcileVPCEndpoint(awsClient *awsClient,
+// When a pre-created VPC endpoint is provided in the spec, it is used instead after verifying
+// that it is connected to the VPC endpoint service.
+func (r *ReconcileAWSPrivateLink) reconcileVPCEndpoint(ctx context.Context, awsClient *awsClient,
 	cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
 	vpcEndpointService *ec2.ServiceConfiguration,
 	logger log.FieldLogger) (bool, *ec2.VpcEndpoint, error) {
 	modified := false
-	tag := ec2FilterForCluster(metadata)
-	endpointLog := logger.WithField("tag:key", aws.StringValue(tag.Name)).WithField("tag:value", aws.StringValueSlice(tag.Values))
 
 	var vpcEndpoint *ec2.VpcEndpoint
-	resp, err := awsClient.hub.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
-		Filters: []*ec2.Filter{tag},
-	})
-	if err != nil {
-		endpointLog.WithError(err).Error("error getting VPC Endpoint")
-		return modified, nil, err
-	}
-	if len(resp.VpcEndpoints) == 0 {
-		modified = true
-		vpcEndpoint, err = r.createVPCEndpoint(awsClient.hub, cd, metadata, vpcEndpointService, logger)
+	if preCreatedID := cd.Spec.Platform.AWS.PrivateLink.VPCEndpointID; preCreatedID != "" {
+		endpoint, err := getPreCreatedVPCEndpoint(ctx, awsClient.hub, preCreatedID, vpcEndpointService, logger)
 		if err != nil {
-			logger.WithError(err).Error("error creating VPC Endpoint for service")
 			return modified, nil, err
 		}
+		vpcEndpoint = endpoint
 	} else {
-		vpcEndpoint = resp.VpcEndpoints[0]
+		tag := ec2FilterForCluster(metadata)
+		endpointLog := logger.WithField("tag:key", aws.StringValue(tag.Name)).WithField("tag:value", aws.StringValueSlice(tag.Values))
+
+		resp, err := awsClient.hub.DescribeVpcEndpoints(ctx, &ec2.DescribeVpcEndpointsInput{
+			Filters: []*ec2.Filter{tag},
+		})
+		if err != nil {
+			endpointLog.WithError(err).Error("error getting VPC Endpoint")
+			return modified, nil, err
+		}
+		if len(resp.VpcEndpoints) == 0 {
+			modified = true
+			vpcEndpoint, err = r.createVPCEndpoint(ctx, awsClient, cd, metadata, vpcEndpointService, logger)
+			if err != nil {
+				logger.WithError(err).Error("error creating VPC Endpoint for service")
+				return modified, nil, err
+			}
+		} else {
+			vpcEndpoint = resp.VpcEndpoints[0]
+		}
 	}
 
 	initPrivateLinkStatus(cd)
@@ -806,11 +828,11 @@ func (r *ReconcileAWSPrivateLink) reconcileVPCEndpoint(awsClient *awsClient,
 	return modified, vpcEndpoint, nil
 }
 
-func (r *ReconcileAWSPrivateLink) createVPCEndpoint(awsClient awsclient.Client,
+func (r *ReconcileAWSPrivateLink) createVPCEndpoint(ctx context.Context, awsClient *awsClient,
 	cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
 	vpcEndpointService *ec2.ServiceConfiguration,
 	logger log.FieldLogger) (*ec2.VpcEndpoint, error) {
-	chosen, err := r.chooseVPCForVPCEndpoint(awsClient, cd, *vpcEndpointService.ServiceName, logger)
+	chosen, err := r.chooseVPCForVPCEndpoint(ctx, awsClient, cd, *vpcEndpointService.ServiceName, logger)
 	if err != nil {
 		logger.WithError(err).Error("failed to choose VPC for the VPC Endpoint from the inventory")
 		return nil, err
@@ -820,14 +842,20 @@ func (r *ReconcileAWSPrivateLink) createVPCEndpoint(awsClient awsclient.Client,
 	for _, subnet := range chosen.Subnets {
 		subnetIDs = append(subnetIDs, subnet.SubnetID)
 	}
-	resp, err := awsClient.CreateVpcEndpoint(&ec2.CreateVpcEndpointInput{
+	input := &ec2.CreateVpcEndpointInput{
 		PrivateDnsEnabled: aws.Bool(false),
 		ServiceName:       vpcEndpointService.ServiceName,
 		SubnetIds:         aws.StringSlice(subnetIDs),
 		TagSpecifications: []*ec2.TagSpecification{ec2TagSpecification(metadata, "vpc-endpoint")},
 		VpcEndpointType:   aws.String(ec2.VpcEndpointTypeInterface),
 		VpcId:             aws.String(chosen.VPCID),
-	})
+	}
+	var resp *ec2.CreateVpcEndpointOutput
+	if awsClient.isCrossRegion(cd) {
+		resp, err = awsClient.hub.CreateVpcEndpointForServiceRegion(ctx, input, cd.Spec.Platform.AWS.Region)
+	} else {
+		resp, err = awsClient.hub.CreateVpcEndpoint(ctx, input)
+	}
 	if err != nil {
 		logger.WithError(err).Error("error creating VPC Endpoint")
 		return nil, err
@@ -835,7 +863,7 @@ func (r *ReconcileAWSPrivateLink) createVPCEndpoint(awsClient awsclient.Client,
 	endpointLog := logger.WithField("endpointID", *resp.VpcEndpoint.VpcEndpointId)
 
 	if err := waitForState("available", 1*time.Minute, func() (string, error) {
-		resp, err := awsClient.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
+		resp, err := awsClient.hub.DescribeVpcEndpoints(ctx, &ec2.DescribeVpcEndpointsInput{
 			VpcEndpointIds: aws.StringSlice([]string{*resp.VpcEndpoint.VpcEndpointId}),
 		})
 		if err != nil {
@@ -850,14 +878,41 @@ func (r *ReconcileAWSPrivateLink) createVPCEndpoint(awsClient awsclient.Client,
 	return resp.VpcEndpoint, nil
 }
 
+var errVPCEndpointNotConnectedToService = errors.New("pre-created VPC Endpoint is not connected to the VPC Endpoint Service of the cluster")
+
+// getPreCreatedVPCEndpoint returns the pre-created VPC endpoint with the given ID, making sure that
+// it is connected to the VPC endpoint service of the cluster.
+func getPreCreatedVPCEndpoint(ctx context.Context, awsClient awsclient.Client, endpointID string,
+	vpcEndpointService *ec2.ServiceConfiguration,
+	logger log.FieldLogger) (*ec2.VpcEndpoint, error) {
+	endpointLog := logger.WithField("vpcEndpointID", endpointID)
+	resp, err := awsClient.DescribeVpcEndpoints(ctx, &ec2.DescribeVpcEndpointsInput{
+		VpcEndpointIds: aws.StringSlice([]string{endpointID}),
+	})
+	if err != nil {
+		endpointLog.WithError(err).Error("error getting the pre-created VPC Endpoint")
+		return nil, err
+	}
+	if len(resp.VpcEndpoints) == 0 {
+		return nil, errors.Errorf("pre-created VPC Endpoint %s not found", endpointID)
+	}
+	endpoint := resp.VpcEndpoints[0]
+	if aws.StringValue(endpoint.ServiceName) != aws.StringValue(vpcEndpointService.ServiceName) {
+		endpointLog.WithField("serviceName", aws.StringValue(endpoint.ServiceName)).
+			Error(errVPCEndpointNotConnectedToService.Error())
+		return nil, errVPCEndpointNotConnectedToService
+	}
+	return endpoint, nil
+}
+
 // reconcileHostedZone ensures that a Private Hosted Zone apiDomain exists for the VPC
 // where VPC endpoint was created. It also make sure the DNS zone has an ALIAS record pointing
 // to the regional DNS name of the VPC endpoint.
-func (r *ReconcileAWSPrivateLink) reconcileHostedZone(awsClient *awsClient,
+func (r *ReconcileAWSPrivateLink) reconcileHostedZone(ctx context.Context, awsClient *awsClient,
 	cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
 	vpcEndpoint *ec2.VpcEndpoint, apiDomain string,
 	logger log.FieldLogger) (bool, string, error) {
-	modified, hostedZoneID, err := r.ensureHostedZone(awsClient.hub, cd, vpcEndpoint, apiDomain, logger)
+	modified, hostedZoneID, err := r.ensureHostedZone(ctx, awsClient.hub, cd, vpcEndpoint, awsClient.endpointRegion, apiDomain, logger)
 	if err != nil {
 		logger.WithError(err).Error("error ensuring Hosted Zone was created")
 		return modified, "", err
@@ -868,7 +923,7 @@ func (r *ReconcileAWSPrivateLink) reconcileHostedZone(awsClient *awsClient,
 	endpointDNSName := vpcEndpoint.DnsEntries[0].DnsName
 	endpointDNSHostedZone := vpcEndpoint.DnsEntries[0].HostedZoneId
 
-	_, err = awsClient.hub.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+	_, err = awsClient.hub.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
 		HostedZoneId: aws.String(hostedZoneID),
 		ChangeBatch: &route53.ChangeBatch{
 			Changes: []*route53.Change{{
@@ -894,15 +949,15 @@ func (r *ReconcileAWSPrivateLink) reconcileHostedZone(awsClient *awsClient,
 	return modified, hostedZoneID, nil
 }
 
-func (r *ReconcileAWSPrivateLink) ensureHostedZone(awsClient awsclient.Client,
+func (r *ReconcileAWSPrivateLink) ensureHostedZone(ctx context.Context, awsClient awsclient.Client,
 	cd *hivev1.ClusterDeployment,
-	endpoint *ec2.VpcEndpoint, apiDomain string,
+	endpoint *ec2.VpcEndpoint, endpointRegion, apiDomain string,
 	logger log.FieldLogger) (bool, string, error) {
 	modified := false
-	hzID, err := findHostedZone(awsClient, *endpoint.VpcId, cd.Spec.Platform.AWS.Region, apiDomain, logger)
+	hzID, err := findHostedZone(ctx, awsClient, *endpoint.VpcId, endpointRegion, apiDomain, logger)
 	if err != nil && errors.Is(err, errNoHostedZoneFoundForVPC) {
 		modified = true
-		hzID, err = r.createHostedZone(awsClient, cd, endpoint, apiDomain, logger)
+		hzID, err = r.createHostedZone(ctx, awsClient, cd, endpoint, endpointRegion, apiDomain, logger)
 		if err != nil {
 			return modified, "", err
 		}
@@ -927,7 +982,7 @@ var errNoHostedZoneFoundForVPC = errors.New("no hosted zone found")
 // findHostedZone finds a Private Hosted Zone for apiDomain that is associated with the given
 // VPC.
 // If no such hosted zone exists, it return an errNoHostedZoneFoundForVPC error.
-func findHostedZone(awsClient awsclient.Client, vpcID, vpcRegion, apiDomain string, logger log.FieldLogger) (string, error) {
+func findHostedZone(ctx context.Context, awsClient awsclient.Client, vpcID, vpcRegion, apiDomain string, logger log.FieldLogger) (string, error) {
 	input := &route53.ListHostedZonesByVPCInput{
 		VPCId:     aws.String(vpcID),
 		VPCRegion: aws.String(vpcRegion),
@@ -937,7 +992,7 @@ func findHostedZone(awsClient awsclient.Client, vpcID, vpcRegion, apiDomain stri
 	var nextToken *string
 	for {
 		input.NextToken = nextToken
-		resp, err := awsClient.ListHostedZonesByVPC(input)
+		resp, err := awsClient.ListHostedZonesByVPC(ctx, input)
 		if err != nil {
 			return "", err
 		}
@@ -954,12 +1009,12 @@ func findHostedZone(awsClient awsclient.Client, vpcID, vpcRegion, apiDomain stri
 	return "", errNoHostedZoneFoundForVPC
 }
 
-func (r *ReconcileAWSPrivateLink) createHostedZone(awsClient awsclient.Client,
+func (r *ReconcileAWSPrivateLink) createHostedZone(ctx context.Context, awsClient awsclient.Client,
 	cd *hivev1.ClusterDeployment,
-	endpoint *ec2.VpcEndpoint, apiDomain string,
+	endpoint *ec2.VpcEndpoint, endpointRegion, apiDomain string,
 	logger log.FieldLogger) (string, error) {
 	hzLog := logger.WithField("vpcID", *endpoint.VpcId).WithField("apiDomain", apiDomain)
-	resp, err := awsClient.CreateHostedZone(&route53.CreateHostedZoneInput{
+	resp, err := awsClient.CreateHostedZone(ctx, &route53.CreateHostedZoneInput{
 		CallerReference: aws.String(time.Now().String()),
 		Name:            aws.String(apiDomain),
 		HostedZoneConfig: &route53.HostedZoneConfig{
@@ -967,7 +1022,7 @@ func (r *ReconcileAWSPrivateLink) createHostedZone(awsClient awsclient.Client,
 		},
 		VPC: &route53.VPC{
 			VPCId:     endpoint.VpcId,
-			VPCRegion: aws.String(cd.Spec.Platform.AWS.Region),
+			VPCRegion: aws.String(endpointRegion),
 		},
 	})
 	if err != nil {
@@ -980,7 +1035,7 @@ func (r *ReconcileAWSPrivateLink) createHostedZone(awsClient awsclient.Client,
 
 // reconcileHostedZoneAssociations ensures that the all the VPCs in the associatedVPCs list from
 // the controller config are associated to the PHZ hostedZoneID.
-func (r *ReconcileAWSPrivateLink) reconcileHostedZoneAssociations(awsClient *awsClient,
+func (r *ReconcileAWSPrivateLink) reconcileHostedZoneAssociations(ctx context.Context, awsClient *awsClient,
 	cd *hivev1.ClusterDeployment,
 	hostedZoneID string, vpcEndpoint *ec2.VpcEndpoint,
 	logger log.FieldLogger) (bool, error) {
@@ -992,7 +1047,7 @@ func (r *ReconcileAWSPrivateLink) reconcileHostedZoneAssociations(awsClient *aws
 		vpcIdx[v.VPCID] = i
 	}
 
-	zoneResp, err := awsClient.hub.GetHostedZone(&route53.GetHostedZoneInput{
+	zoneResp, err := awsClient.hub.GetHostedZone(ctx, &route53.GetHostedZoneInput{
 		Id: aws.String(hostedZoneID),
 	})
 	if err != nil {
@@ -1015,6 +1070,15 @@ func (r *ReconcileAWSPrivateLink) reconcileHostedZoneAssociations(awsClient *aws
 		}
 	}
 	desiredVPCs := sets.NewString(*vpcEndpoint.VpcId)
+	if _, ok := vpcIdx[*vpcEndpoint.VpcId]; !ok {
+		vpcInfo = append(vpcInfo, hivev1.AWSAssociatedVPC{
+			AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
+				VPCID:  *vpcEndpoint.VpcId,
+				Region: awsClient.endpointRegion,
+			},
+		})
+		vpcIdx[*vpcEndpoint.VpcId] = len(vpcInfo) - 1
+	}
 	for _, vpc := range r.controllerconfig.AssociatedVPCs {
 		desiredVPCs.Insert(vpc.VPCID)
 	}
@@ -1036,7 +1100,7 @@ func (r *ReconcileAWSPrivateLink) reconcileHostedZoneAssociations(awsClient *aws
 		awsAssociationClient := awsClient.hub
 		if info.CredentialsSecretRef != nil {
 			// since this VPC is in different account we need to authorize before continuing
-			_, err := awsClient.hub.CreateVPCAssociationAuthorization(&route53.CreateVPCAssociationAuthorizationInput{
+			_, err := awsClient.hub.CreateVPCAssociationAuthorization(ctx, &route53.CreateVPCAssociationAuthorizationInput{
 				HostedZoneId: aws.String(hostedZoneID),
 				VPC: &route53.VPC{
 					VPCId:     aws.String(vpc),
@@ -1049,7 +1113,8 @@ func (r *ReconcileAWSPrivateLink) reconcileHostedZoneAssociations(awsClient *aws
 			}
 
 			awsAssociationClient, err = r.awsClientFn(r.Client, awsclient.Options{
-				Region: info.Region,
+				Controller: hivev1.AWSPrivateLinkControllerName,
+				Region:     info.Region,
 				CredentialsSource: awsclient.CredentialsSource{
 					Secret: &awsclient.SecretCredentialsSource{
 						Namespace: controllerutils.GetHiveNamespace(),
@@ -1063,7 +1128,7 @@ func (r *ReconcileAWSPrivateLink) reconcileHostedZoneAssociations(awsClient *aws
 			}
 		}
 
-		_, err = awsAssociationClient.AssociateVPCWithHostedZone(&route53.AssociateVPCWithHostedZoneInput{
+		_, err = awsAssociationClient.AssociateVPCWithHostedZone(ctx, &route53.AssociateVPCWithHostedZoneInput{
 			HostedZoneId: aws.String(hostedZoneID),
 			VPC: &route53.VPC{
 				VPCId:     aws.String(vpc),
@@ -1078,7 +1143,7 @@ func (r *ReconcileAWSPrivateLink) reconcileHostedZoneAssociations(awsClient *aws
 		if info.CredentialsSecretRef != nil {
 			// since we created an authorization and association is complete, we should remove the object
 			// as recommended by AWS best practices.
-			_, err := awsClient.hub.DeleteVPCAssociationAuthorization(&route53.DeleteVPCAssociationAuthorizationInput{
+			_, err := awsClient.hub.DeleteVPCAssociationAuthorization(ctx, &route53.DeleteVPCAssociationAuthorizationInput{
 				HostedZoneId: aws.String(hostedZoneID),
 				VPC: &route53.VPC{
 					VPCId:     aws.String(vpc),
@@ -1094,7 +1159,7 @@ func (r *ReconcileAWSPrivateLink) reconcileHostedZoneAssociations(awsClient *aws
 	for _, vpc := range removed {
 		vpcLog := hzLog.WithField("vpc", vpc)
 		info := vpcInfo[vpcIdx[vpc]]
-		_, err = awsClient.hub.DisassociateVPCFromHostedZone(&route53.DisassociateVPCFromHostedZoneInput{
+		_, err = awsClient.hub.DisassociateVPCFromHostedZone(ctx, &route53.DisassociateVPCFromHostedZoneInput{
 			HostedZoneId: aws.String(hostedZoneID),
 			VPC: &route53.VPC{
 				VPCId:     aws.String(vpc),
@@ -1141,30 +1206,69 @@ func filterErrorMessage(err error) string {
 type awsClient struct {
 	hub  awsclient.Client
 	user awsclient.Client
+
+	// endpointRegion is the region of the hub client, where the VPC Endpoint for the cluster is created.
+	endpointRegion string
+}
+
+// isCrossRegion returns true when the VPC Endpoint for the cluster is created in a region different
+// from the region of the cluster.
+func (c *awsClient) isCrossRegion(cd *hivev1.ClusterDeployment) bool {
+	return !strings.EqualFold(c.endpointRegion, cd.Spec.Platform.AWS.Region)
+}
+
+// EndpointRegion returns the region in which the VPC Endpoint for the cluster should be created, and
+// whether there is any such region. VPCs in the inventory that are in the same region as the cluster are
+// preferred, followed by VPCs that serve the cluster's region using cross-region PrivateLink. Clusters
+// using a pre-created VPC Endpoint do not require an inventory and use their own region.
+func EndpointRegion(config *hivev1.AWSPrivateLinkConfig, cd *hivev1.ClusterDeployment) (string, bool) {
+	clusterRegion := cd.Spec.Platform.AWS.Region
+	if config != nil {
+		for _, item := range config.EndpointVPCInventory {
+			if strings.EqualFold(item.Region, clusterRegion) {
+				return item.Region, true
+			}
+		}
+		for _, item := range config.EndpointVPCInventory {
+			for _, served := range item.ServedRegions {
+				if strings.EqualFold(served, clusterRegion) {
+					return item.Region, true
+				}
+			}
+		}
+	}
+	if cd.Spec.Platform.AWS.PrivateLink != nil && cd.Spec.Platform.AWS.PrivateLink.VPCEndpointID != "" {
+		return clusterRegion, true
+	}
+	return "", false
+}
+
+// vpcEndpointCreated returns true when the status of the cluster deployment records a VPC Endpoint.
+func vpcEndpointCreated(cd *hivev1.ClusterDeployment) bool {
+	return cd.Status.Platform != nil &&
+		cd.Status.Platform.AWS != nil &&
+		cd.Status.Platform.AWS.PrivateLink != nil &&
+		cd.Status.Platform.AWS.PrivateLink.VPCEndpointID != ""
 }
 
 func newAWSClient(r *ReconcileAWSPrivateLink, cd *hivev1.ClusterDeployment) (*awsClient, error) {
+	hubRegion, ok := EndpointRegion(r.controllerconfig, cd)
+	if !ok {
+		hubRegion = cd.Spec.Platform.AWS.Region
+	}
 	uClient, err := r.awsClientFn(r.Client, awsclient.Options{
-		Region: cd.Spec.Platform.AWS.Region,
-		CredentialsSource: awsclient.CredentialsSource{
-			Secret: &awsclient.SecretCredentialsSource{
-				Namespace: cd.Namespace,
-				Ref:       &cd.Spec.Platform.AWS.CredentialsSecretRef,
-			},
-			AssumeRole: &awsclient.AssumeRoleCredentialsSource{
-				SecretRef: corev1.SecretReference{
-					Name:      os.Getenv(constants.HiveAWSServiceProviderCredentialsSecretRefEnvVar),
-					Namespace: controllerutils.GetHiveNamespace(),
-				},
-				Role: cd.Spec.Platform.AWS.CredentialsAssumeRole,
-			},
-		},
+		Controller:        hivev1.AWSPrivateLinkControllerName,
+		Region:            cd.Spec.Platform.AWS.Region,
+		CredentialsSource: awsclient.NewCredentialsSource(cd.Namespace, &cd.Spec.Platform.AWS.CredentialsSecretRef, cd.Spec.Platform.AWS.CredentialsAssumeRole, cd.Spec.Platform.AWS.CredentialsSource),
+		ServiceEndpoints:  cd.Spec.Platform.AWS.ServiceEndpoints,
+		ProxySecret:       &awsclient.ProxySecretSource{Namespace: cd.Namespace, Ref: cd.Spec.CloudClientProxySecretRef},
 	})
 	if err != nil {
 		return nil, err
 	}
 	hClient, err := r.awsClientFn(r.Client, awsclient.Options{
-		Region: cd.Spec.Platform.AWS.Region,
+		Controller: hivev1.AWSPrivateLinkControllerName,
+		Region:     hubRegion,
 		CredentialsSource: awsclient.CredentialsSource{
 			Secret: &awsclient.SecretCredentialsSource{
 				Namespace: controllerutils.GetHiveNamespace(),
@@ -1175,7 +1279,7 @@ func newAWSClient(r *ReconcileAWSPrivateLink, cd *hivev1.ClusterDeployment) (*aw
 	if err != nil {
 		return nil, err
 	}
-	return &awsClient{hub: hClient, user: uClient}, nil
+	return &awsClient{hub: hClient, user: uClient, endpointRegion: hubRegion}, nil
 }
 
 // initialURL returns the initial API URL for the ClusterProvision.
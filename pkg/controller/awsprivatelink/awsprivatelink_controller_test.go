@@ -489,7 +489,7 @@ users:
 				Code: aws.String(elbv2.LoadBalancerStateEnumActive),
 			},
 		}
-		m.EXPECT().DescribeLoadBalancers(gomock.Any()).
+		m.EXPECT().DescribeLoadBalancers(gomock.Any(), gomock.Any()).
 			Return(&elbv2.DescribeLoadBalancersOutput{
 				LoadBalancers: []*elbv2.LoadBalancer{clusternlb},
 			}, nil).AnyTimes()
@@ -505,23 +505,23 @@ users:
 			NetworkLoadBalancerArns: aws.StringSlice([]string{clusternlb}),
 			AvailabilityZones:       aws.StringSlice([]string{"us-east-1b", "us-east-1c"}),
 		}
-		m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any()).
+		m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any(), gomock.Any()).
 			Return(&ec2.DescribeVpcEndpointServiceConfigurationsOutput{}, nil)
-		m.EXPECT().CreateVpcEndpointServiceConfiguration(gomock.Any()).
+		m.EXPECT().CreateVpcEndpointServiceConfiguration(gomock.Any(), gomock.Any()).
 			Return(&ec2.CreateVpcEndpointServiceConfigurationOutput{
 				ServiceConfiguration: service,
 			}, nil)
-		m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any()).
+		m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any(), gomock.Any()).
 			Return(&ec2.DescribeVpcEndpointServiceConfigurationsOutput{
 				ServiceConfigurations: []*ec2.ServiceConfiguration{service},
 			}, nil)
 		return service
 	}
 	mockServicePerms := func(m *mock.MockClient, service *ec2.ServiceConfiguration) {
-		m.EXPECT().GetCallerIdentity(gomock.Any()).Return(&sts.GetCallerIdentityOutput{Arn: aws.String("aws:iam:12345:hub-user")}, nil)
-		m.EXPECT().DescribeVpcEndpointServicePermissions(gomock.Any()).
+		m.EXPECT().GetCallerIdentity(gomock.Any(), gomock.Any()).Return(&sts.GetCallerIdentityOutput{Arn: aws.String("aws:iam:12345:hub-user")}, nil)
+		m.EXPECT().DescribeVpcEndpointServicePermissions(gomock.Any(), gomock.Any()).
 			Return(&ec2.DescribeVpcEndpointServicePermissionsOutput{}, nil)
-		m.EXPECT().ModifyVpcEndpointServicePermissions(&ec2.ModifyVpcEndpointServicePermissionsInput{
+		m.EXPECT().ModifyVpcEndpointServicePermissions(gomock.Any(), &ec2.ModifyVpcEndpointServicePermissionsInput{
 			AddAllowedPrincipals: aws.StringSlice([]string{"aws:iam:12345:hub-user"}),
 			ServiceId:            service.ServiceId,
 		}).Return(nil, nil)
@@ -535,7 +535,7 @@ users:
 			NetworkLoadBalancerArns: aws.StringSlice([]string{clusternlb}),
 		}
 		modify(service)
-		m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any()).
+		m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any(), gomock.Any()).
 			Return(&ec2.DescribeVpcEndpointServiceConfigurationsOutput{
 				ServiceConfigurations: []*ec2.ServiceConfiguration{service},
 			}, nil)
@@ -543,9 +543,9 @@ users:
 	}
 
 	mockCreateEndpoint := func(m *mock.MockClient, service *ec2.ServiceConfiguration) *ec2.VpcEndpoint {
-		m.EXPECT().DescribeVpcEndpoints(gomock.Any()).
+		m.EXPECT().DescribeVpcEndpoints(gomock.Any(), gomock.Any()).
 			Return(&ec2.DescribeVpcEndpointsOutput{}, nil).Times(2)
-		m.EXPECT().DescribeVpcEndpointServices(&ec2.DescribeVpcEndpointServicesInput{
+		m.EXPECT().DescribeVpcEndpointServices(gomock.Any(), &ec2.DescribeVpcEndpointServicesInput{
 			ServiceNames: aws.StringSlice([]string{*service.ServiceName}),
 		}).Return(&ec2.DescribeVpcEndpointServicesOutput{
 			ServiceDetails: []*ec2.ServiceDetail{{AvailabilityZones: service.AvailabilityZones}},
@@ -560,9 +560,9 @@ users:
 				HostedZoneId: aws.String("HZ23456"),
 			}},
 		}
-		m.EXPECT().CreateVpcEndpoint(gomock.Any()).
+		m.EXPECT().CreateVpcEndpoint(gomock.Any(), gomock.Any()).
 			Return(&ec2.CreateVpcEndpointOutput{VpcEndpoint: endpoint}, nil)
-		m.EXPECT().DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
+		m.EXPECT().DescribeVpcEndpoints(gomock.Any(), &ec2.DescribeVpcEndpointsInput{
 			VpcEndpointIds: aws.StringSlice([]string{*endpoint.VpcEndpointId}),
 		}).Return(&ec2.DescribeVpcEndpointsOutput{
 			VpcEndpoints: []*ec2.VpcEndpoint{endpoint},
@@ -575,7 +575,7 @@ users:
 		if existingSummary != nil {
 			byVPCOut.HostedZoneSummaries = []*route53.HostedZoneSummary{existingSummary}
 		}
-		m.EXPECT().ListHostedZonesByVPC(&route53.ListHostedZonesByVPCInput{
+		m.EXPECT().ListHostedZonesByVPC(gomock.Any(), &route53.ListHostedZonesByVPCInput{
 			MaxItems:  aws.String("100"),
 			VPCId:     endpoint.VpcId,
 			VPCRegion: aws.String("us-east-1"),
@@ -583,7 +583,7 @@ users:
 		var hzID string
 		if existingSummary == nil {
 			hzID = "HZ12345"
-			m.EXPECT().CreateHostedZone(newCreateHostedZoneInputMatcher(&route53.CreateHostedZoneInput{
+			m.EXPECT().CreateHostedZone(gomock.Any(), newCreateHostedZoneInputMatcher(&route53.CreateHostedZoneInput{
 				HostedZoneConfig: &route53.HostedZoneConfig{
 					PrivateZone: aws.Bool(true),
 				},
@@ -601,7 +601,7 @@ users:
 			hzID = aws.StringValue(existingSummary.HostedZoneId)
 		}
 
-		m.EXPECT().ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		m.EXPECT().ChangeResourceRecordSets(gomock.Any(), &route53.ChangeResourceRecordSetsInput{
 			ChangeBatch: &route53.ChangeBatch{
 				Changes: []*route53.Change{{
 					Action: aws.String("UPSERT"),
@@ -655,6 +655,21 @@ users:
 			cdBuilder.Build(testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1",
 				PrivateLink: &hivev1aws.PrivateLinkAccess{Enabled: false}})),
 		},
+	}, {
+		name: "paused cd with privatelink disabled and resources to clean up",
+
+		existing: []runtime.Object{
+			cdBuilder.GenericOptions(generic.WithFinalizer(finalizer)).Build(
+				testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1",
+					PrivateLink: &hivev1aws.PrivateLinkAccess{Enabled: false}}),
+				withClusterMetadata("test-cd-1234", "test-cd-kubeconfig"),
+				withPrivateLink(&hivev1aws.PrivateLinkAccessStatus{VPCEndpointID: "vpce-12345"}),
+				testcd.Paused(),
+			),
+		},
+
+		hasFinalizer:   true,
+		expectedStatus: &hivev1aws.PrivateLinkAccessStatus{VPCEndpointID: "vpce-12345"},
 	}, {
 		name: "cd with privatelink enabled, no inventory",
 
@@ -721,7 +736,7 @@ users:
 		},
 		inventory: validInventory,
 		configureAWSClient: func(m *mock.MockClient) {
-			m.EXPECT().DescribeLoadBalancers(gomock.Any()).
+			m.EXPECT().DescribeLoadBalancers(gomock.Any(), gomock.Any()).
 				Return(nil, awserr.New("AccessDenied", "not authorized to DescribeLoadBalancers", nil))
 		},
 
@@ -740,7 +755,7 @@ users:
 		},
 		inventory: validInventory,
 		configureAWSClient: func(m *mock.MockClient) {
-			m.EXPECT().DescribeLoadBalancers(gomock.Any()).
+			m.EXPECT().DescribeLoadBalancers(gomock.Any(), gomock.Any()).
 				Return(nil, awserr.New("LoadBalancerNotFound", "Loadbalance could not be found", nil))
 		},
 		expectedConditions: []hivev1.ClusterDeploymentCondition{{
@@ -766,7 +781,7 @@ users:
 			service := mockCreateService(m, clusternlb)
 			mockServicePerms(m, service)
 
-			m.EXPECT().DescribeVpcEndpoints(gomock.Any()).Return(nil, awserr.New("AccessDenied", "not authorized to DescribeVpcEndpoints", nil))
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), gomock.Any()).Return(nil, awserr.New("AccessDenied", "not authorized to DescribeVpcEndpoints", nil))
 		},
 
 		hasFinalizer: true,
@@ -792,14 +807,14 @@ users:
 				s.AcceptanceRequired = aws.Bool(true)
 			})
 
-			m.EXPECT().ModifyVpcEndpointServiceConfiguration(&ec2.ModifyVpcEndpointServiceConfigurationInput{
+			m.EXPECT().ModifyVpcEndpointServiceConfiguration(gomock.Any(), &ec2.ModifyVpcEndpointServiceConfigurationInput{
 				ServiceId:          service.ServiceId,
 				AcceptanceRequired: aws.Bool(false),
 			}).Return(&ec2.ModifyVpcEndpointServiceConfigurationOutput{}, nil)
 
 			mockServicePerms(m, service)
 
-			m.EXPECT().DescribeVpcEndpoints(gomock.Any()).Return(nil, awserr.New("AccessDenied", "not authorized to DescribeVpcEndpoints", nil))
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), gomock.Any()).Return(nil, awserr.New("AccessDenied", "not authorized to DescribeVpcEndpoints", nil))
 		},
 
 		hasFinalizer: true,
@@ -825,7 +840,7 @@ users:
 				s.NetworkLoadBalancerArns = aws.StringSlice([]string{clusternlb, "aws:elb:12345:not-cluster-nlb-arn"})
 			})
 
-			m.EXPECT().ModifyVpcEndpointServiceConfiguration(&ec2.ModifyVpcEndpointServiceConfigurationInput{
+			m.EXPECT().ModifyVpcEndpointServiceConfiguration(gomock.Any(), &ec2.ModifyVpcEndpointServiceConfigurationInput{
 				ServiceId:                     service.ServiceId,
 				AcceptanceRequired:            aws.Bool(false),
 				RemoveNetworkLoadBalancerArns: aws.StringSlice([]string{"aws:elb:12345:not-cluster-nlb-arn"}),
@@ -833,7 +848,7 @@ users:
 
 			mockServicePerms(m, service)
 
-			m.EXPECT().DescribeVpcEndpoints(gomock.Any()).Return(nil, awserr.New("AccessDenied", "not authorized to DescribeVpcEndpoints", nil))
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), gomock.Any()).Return(nil, awserr.New("AccessDenied", "not authorized to DescribeVpcEndpoints", nil))
 		},
 
 		hasFinalizer: true,
@@ -857,20 +872,20 @@ users:
 			clusternlb := mockDiscoverLB(m)
 			service := mockExistingService(m, clusternlb, func(s *ec2.ServiceConfiguration) {})
 
-			m.EXPECT().GetCallerIdentity(gomock.Any()).Return(&sts.GetCallerIdentityOutput{Arn: aws.String("aws:iam:12345:hub-user")}, nil)
-			m.EXPECT().DescribeVpcEndpointServicePermissions(gomock.Any()).
+			m.EXPECT().GetCallerIdentity(gomock.Any(), gomock.Any()).Return(&sts.GetCallerIdentityOutput{Arn: aws.String("aws:iam:12345:hub-user")}, nil)
+			m.EXPECT().DescribeVpcEndpointServicePermissions(gomock.Any(), gomock.Any()).
 				Return(&ec2.DescribeVpcEndpointServicePermissionsOutput{
 					AllowedPrincipals: []*ec2.AllowedPrincipal{{
 						Principal: aws.String("aws:iam:12345:some-that-should-not-be-allowed"),
 					}},
 				}, nil)
-			m.EXPECT().ModifyVpcEndpointServicePermissions(&ec2.ModifyVpcEndpointServicePermissionsInput{
+			m.EXPECT().ModifyVpcEndpointServicePermissions(gomock.Any(), &ec2.ModifyVpcEndpointServicePermissionsInput{
 				AddAllowedPrincipals:    aws.StringSlice([]string{"aws:iam:12345:hub-user"}),
 				RemoveAllowedPrincipals: aws.StringSlice([]string{"aws:iam:12345:some-that-should-not-be-allowed"}),
 				ServiceId:               service.ServiceId,
 			}).Return(nil, nil)
 
-			m.EXPECT().DescribeVpcEndpoints(gomock.Any()).Return(nil, awserr.New("AccessDenied", "not authorized to DescribeVpcEndpoints", nil))
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), gomock.Any()).Return(nil, awserr.New("AccessDenied", "not authorized to DescribeVpcEndpoints", nil))
 		},
 
 		hasFinalizer: true,
@@ -900,9 +915,9 @@ users:
 			service := mockCreateService(m, clusternlb)
 			mockServicePerms(m, service)
 
-			m.EXPECT().DescribeVpcEndpoints(gomock.Any()).
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), gomock.Any()).
 				Return(&ec2.DescribeVpcEndpointsOutput{}, nil)
-			m.EXPECT().DescribeVpcEndpointServices(&ec2.DescribeVpcEndpointServicesInput{
+			m.EXPECT().DescribeVpcEndpointServices(gomock.Any(), &ec2.DescribeVpcEndpointServicesInput{
 				ServiceNames: aws.StringSlice([]string{*service.ServiceName}),
 			}).Return(&ec2.DescribeVpcEndpointServicesOutput{
 				ServiceDetails: []*ec2.ServiceDetail{{AvailabilityZones: service.AvailabilityZones}},
@@ -931,9 +946,9 @@ users:
 			service := mockCreateService(m, clusternlb)
 			mockServicePerms(m, service)
 
-			m.EXPECT().DescribeVpcEndpoints(gomock.Any()).
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), gomock.Any()).
 				Return(&ec2.DescribeVpcEndpointsOutput{}, nil)
-			m.EXPECT().DescribeVpcEndpointServices(&ec2.DescribeVpcEndpointServicesInput{
+			m.EXPECT().DescribeVpcEndpointServices(gomock.Any(), &ec2.DescribeVpcEndpointServicesInput{
 				ServiceNames: aws.StringSlice([]string{*service.ServiceName}),
 			}).Return(&ec2.DescribeVpcEndpointServicesOutput{
 				ServiceDetails: []*ec2.ServiceDetail{{AvailabilityZones: service.AvailabilityZones}},
@@ -946,7 +961,7 @@ users:
 					VpcId:         aws.String("vpc-1"),
 				})
 			}
-			m.EXPECT().DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), &ec2.DescribeVpcEndpointsInput{
 				Filters: []*ec2.Filter{{
 					Name:   aws.String("vpc-id"),
 					Values: aws.StringSlice([]string{"vpc-1"}),
@@ -1005,7 +1020,7 @@ users:
 
 			hzID := mockPHZ(m, endpoint, "api.test-cluster", nil)
 
-			m.EXPECT().GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
+			m.EXPECT().GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
 				HostedZone: &route53.HostedZone{
 					Id: aws.String(hzID),
 				},
@@ -1046,7 +1061,7 @@ users:
 				Name:         aws.String("api.test-cluster"),
 			})
 
-			m.EXPECT().GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
+			m.EXPECT().GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
 				HostedZone: &route53.HostedZone{
 					Id: aws.String(hzID),
 				},
@@ -1093,7 +1108,7 @@ users:
 				Name:         aws.String("api.test-cluster"),
 			})
 
-			m.EXPECT().GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
+			m.EXPECT().GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
 				HostedZone: &route53.HostedZone{
 					Id: aws.String(hzID),
 				},
@@ -1103,7 +1118,7 @@ users:
 				}},
 			}, nil)
 
-			m.EXPECT().AssociateVPCWithHostedZone(&route53.AssociateVPCWithHostedZoneInput{
+			m.EXPECT().AssociateVPCWithHostedZone(gomock.Any(), &route53.AssociateVPCWithHostedZoneInput{
 				HostedZoneId: aws.String("HZ12345"),
 				VPC: &route53.VPC{
 					VPCId:     aws.String("vpc-hive1"),
@@ -1149,7 +1164,7 @@ users:
 				Name:         aws.String("api.test-cluster"),
 			})
 
-			m.EXPECT().GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
+			m.EXPECT().GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
 				HostedZone: &route53.HostedZone{
 					Id: aws.String(hzID),
 				},
@@ -1159,7 +1174,7 @@ users:
 				}},
 			}, nil)
 
-			m.EXPECT().AssociateVPCWithHostedZone(&route53.AssociateVPCWithHostedZoneInput{
+			m.EXPECT().AssociateVPCWithHostedZone(gomock.Any(), &route53.AssociateVPCWithHostedZoneInput{
 				HostedZoneId: aws.String("HZ12345"),
 				VPC: &route53.VPC{
 					VPCId:     aws.String("vpc-hive1"),
@@ -1204,7 +1219,7 @@ users:
 				Name:         aws.String("api.test-cluster"),
 			})
 
-			m.EXPECT().GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
+			m.EXPECT().GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
 				HostedZone: &route53.HostedZone{
 					Id: aws.String(hzID),
 				},
@@ -1217,14 +1232,14 @@ users:
 				}},
 			}, nil)
 
-			m.EXPECT().AssociateVPCWithHostedZone(&route53.AssociateVPCWithHostedZoneInput{
+			m.EXPECT().AssociateVPCWithHostedZone(gomock.Any(), &route53.AssociateVPCWithHostedZoneInput{
 				HostedZoneId: aws.String("HZ12345"),
 				VPC: &route53.VPC{
 					VPCId:     aws.String("vpc-hive1"),
 					VPCRegion: aws.String("us-west-1"),
 				},
 			}).Return(nil, nil)
-			m.EXPECT().DisassociateVPCFromHostedZone(&route53.DisassociateVPCFromHostedZoneInput{
+			m.EXPECT().DisassociateVPCFromHostedZone(gomock.Any(), &route53.DisassociateVPCFromHostedZoneInput{
 				HostedZoneId: aws.String("HZ12345"),
 				VPC: &route53.VPC{
 					VPCId:     aws.String("vpc-hive1-removed"),
@@ -1272,7 +1287,7 @@ users:
 				Name:         aws.String("api.test-cluster"),
 			})
 
-			m.EXPECT().GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
+			m.EXPECT().GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
 				HostedZone: &route53.HostedZone{
 					Id: aws.String(hzID),
 				},
@@ -1282,21 +1297,21 @@ users:
 				}},
 			}, nil)
 
-			m.EXPECT().CreateVPCAssociationAuthorization(&route53.CreateVPCAssociationAuthorizationInput{
+			m.EXPECT().CreateVPCAssociationAuthorization(gomock.Any(), &route53.CreateVPCAssociationAuthorizationInput{
 				HostedZoneId: aws.String("HZ12345"),
 				VPC: &route53.VPC{
 					VPCId:     aws.String("vpc-hive1"),
 					VPCRegion: aws.String("us-west-1"),
 				},
 			}).Return(nil, nil)
-			m.EXPECT().AssociateVPCWithHostedZone(&route53.AssociateVPCWithHostedZoneInput{
+			m.EXPECT().AssociateVPCWithHostedZone(gomock.Any(), &route53.AssociateVPCWithHostedZoneInput{
 				HostedZoneId: aws.String("HZ12345"),
 				VPC: &route53.VPC{
 					VPCId:     aws.String("vpc-hive1"),
 					VPCRegion: aws.String("us-west-1"),
 				},
 			}).Return(nil, nil)
-			m.EXPECT().DeleteVPCAssociationAuthorization(&route53.DeleteVPCAssociationAuthorizationInput{
+			m.EXPECT().DeleteVPCAssociationAuthorization(gomock.Any(), &route53.DeleteVPCAssociationAuthorizationInput{
 				HostedZoneId: aws.String("HZ12345"),
 				VPC: &route53.VPC{
 					VPCId:     aws.String("vpc-hive1"),
@@ -1305,6 +1320,210 @@ users:
 			}).Return(nil, nil)
 		},
 
+		hasFinalizer: true,
+		expectedStatus: &hivev1aws.PrivateLinkAccessStatus{
+			VPCEndpointService: hivev1aws.VPCEndpointService{Name: "vpce-svc-12345.vpc.amazon.com", ID: "vpce-svc-12345"},
+			VPCEndpointID:      "vpce-12345",
+			HostedZoneID:       "HZ12345",
+		},
+		expectedConditions: getExpectedConditions(false, "PrivateLinkAccessReady",
+			"private link access is ready for use"),
+	}, {
+		name: "cd with privatelink enabled, additional allowed principals, endpoint access denied",
+
+		existing: []runtime.Object{
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig("test-cd-provision-0-kubeconfig")),
+			cdBuilder.Options(testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1",
+				PrivateLink: &hivev1aws.PrivateLinkAccess{
+					Enabled:                     true,
+					AdditionalAllowedPrincipals: []string{"aws:iam:67890:network-user"},
+				}})).Build(withClusterProvision("test-cd-provision-0")),
+		},
+		inventory: validInventory,
+		configureAWSClient: func(m *mock.MockClient) {
+			clusternlb := mockDiscoverLB(m)
+			service := mockCreateService(m, clusternlb)
+
+			m.EXPECT().GetCallerIdentity(gomock.Any(), gomock.Any()).Return(&sts.GetCallerIdentityOutput{Arn: aws.String("aws:iam:12345:hub-user")}, nil)
+			m.EXPECT().DescribeVpcEndpointServicePermissions(gomock.Any(), gomock.Any()).
+				Return(&ec2.DescribeVpcEndpointServicePermissionsOutput{}, nil)
+			m.EXPECT().ModifyVpcEndpointServicePermissions(gomock.Any(), &ec2.ModifyVpcEndpointServicePermissionsInput{
+				AddAllowedPrincipals: aws.StringSlice([]string{"aws:iam:12345:hub-user", "aws:iam:67890:network-user"}),
+				ServiceId:            service.ServiceId,
+			}).Return(nil, nil)
+
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), gomock.Any()).Return(nil, awserr.New("AccessDenied", "not authorized to DescribeVpcEndpoints", nil))
+		},
+
+		hasFinalizer: true,
+		expectedStatus: &hivev1aws.PrivateLinkAccessStatus{
+			VPCEndpointService: hivev1aws.VPCEndpointService{Name: "vpce-svc-12345.vpc.amazon.com", ID: "vpce-svc-12345"},
+		},
+		expectedConditions: getExpectedConditions(true, "VPCEndpointReconcileFailed",
+			"AccessDenied: not authorized to DescribeVpcEndpoints"),
+		err: "failed to reconcile the VPC Endpoint: AccessDenied: not authorized to DescribeVpcEndpoints",
+	}, {
+		name: "cd with privatelink enabled, pre-created endpoint, no inventory",
+
+		existing: []runtime.Object{
+			testSecret("test-cd-provision-0-kubeconfig", kubeConfigSecret),
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig("test-cd-provision-0-kubeconfig")),
+			cdBuilder.Options(testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1",
+				PrivateLink: &hivev1aws.PrivateLinkAccess{
+					Enabled:       true,
+					VPCEndpointID: "vpce-precreated",
+				}})).Build(withClusterProvision("test-cd-provision-0")),
+		},
+		configureAWSClient: func(m *mock.MockClient) {
+			clusternlb := mockDiscoverLB(m)
+			service := mockCreateService(m, clusternlb)
+			mockServicePerms(m, service)
+
+			endpoint := &ec2.VpcEndpoint{
+				VpcEndpointId: aws.String("vpce-precreated"),
+				VpcId:         aws.String("vpc-network"),
+				ServiceName:   service.ServiceName,
+				State:         aws.String("available"),
+				DnsEntries: []*ec2.DnsEntry{{
+					DnsName:      aws.String("vpce-precreated-us-east-1.vpce-svc-12345.vpc.amazonaws.com"),
+					HostedZoneId: aws.String("HZ23456"),
+				}},
+			}
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), &ec2.DescribeVpcEndpointsInput{
+				VpcEndpointIds: aws.StringSlice([]string{"vpce-precreated"}),
+			}).Return(&ec2.DescribeVpcEndpointsOutput{
+				VpcEndpoints: []*ec2.VpcEndpoint{endpoint},
+			}, nil)
+
+			hzID := mockPHZ(m, endpoint, "api.test-cluster", nil)
+
+			m.EXPECT().GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
+				HostedZone: &route53.HostedZone{
+					Id: aws.String(hzID),
+				},
+				VPCs: []*route53.VPC{{
+					VPCId:     endpoint.VpcId,
+					VPCRegion: aws.String("us-east-1"),
+				}},
+			}, nil)
+		},
+
+		hasFinalizer: true,
+		expectedStatus: &hivev1aws.PrivateLinkAccessStatus{
+			VPCEndpointService: hivev1aws.VPCEndpointService{Name: "vpce-svc-12345.vpc.amazon.com", ID: "vpce-svc-12345"},
+			VPCEndpointID:      "vpce-precreated",
+			HostedZoneID:       "HZ12345",
+		},
+		expectedConditions: getExpectedConditions(false, "PrivateLinkAccessReady",
+			"private link access is ready for use"),
+	}, {
+		name: "cd with privatelink enabled, pre-created endpoint for another service",
+
+		existing: []runtime.Object{
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig("test-cd-provision-0-kubeconfig")),
+			cdBuilder.Options(testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1",
+				PrivateLink: &hivev1aws.PrivateLinkAccess{
+					Enabled:       true,
+					VPCEndpointID: "vpce-precreated",
+				}})).Build(withClusterProvision("test-cd-provision-0")),
+		},
+		inventory: validInventory,
+		configureAWSClient: func(m *mock.MockClient) {
+			clusternlb := mockDiscoverLB(m)
+			service := mockCreateService(m, clusternlb)
+			mockServicePerms(m, service)
+
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), &ec2.DescribeVpcEndpointsInput{
+				VpcEndpointIds: aws.StringSlice([]string{"vpce-precreated"}),
+			}).Return(&ec2.DescribeVpcEndpointsOutput{
+				VpcEndpoints: []*ec2.VpcEndpoint{{
+					VpcEndpointId: aws.String("vpce-precreated"),
+					VpcId:         aws.String("vpc-network"),
+					ServiceName:   aws.String("vpce-svc-67890.vpc.amazon.com"),
+				}},
+			}, nil)
+		},
+
+		hasFinalizer: true,
+		expectedStatus: &hivev1aws.PrivateLinkAccessStatus{
+			VPCEndpointService: hivev1aws.VPCEndpointService{Name: "vpce-svc-12345.vpc.amazon.com", ID: "vpce-svc-12345"},
+		},
+		expectedConditions: getExpectedConditions(true, "VPCEndpointReconcileFailed",
+			"pre-created VPC Endpoint is not connected to the VPC Endpoint Service of the cluster"),
+		err: "failed to reconcile the VPC Endpoint: pre-created VPC Endpoint is not connected to the VPC Endpoint Service of the cluster",
+	}, {
+		name: "cd with privatelink enabled, cross-region endpoint",
+
+		existing: []runtime.Object{
+			testSecret("test-cd-provision-0-kubeconfig", kubeConfigSecret),
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig("test-cd-provision-0-kubeconfig")),
+			cdBuilder.Options(testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-2",
+				PrivateLink: &hivev1aws.PrivateLinkAccess{Enabled: true}})).
+				Build(withClusterProvision("test-cd-provision-0")),
+		},
+		inventory: []hivev1.AWSPrivateLinkInventory{{
+			AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
+				Region: "us-east-1",
+				VPCID:  "vpc-1",
+			},
+			Subnets: []hivev1.AWSPrivateLinkSubnet{{
+				AvailabilityZone: "us-east-1a",
+				SubnetID:         "subnet-1",
+			}},
+			ServedRegions: []string{"us-east-2"},
+		}},
+		configureAWSClient: func(m *mock.MockClient) {
+			clusternlb := mockDiscoverLB(m)
+			service := mockCreateService(m, clusternlb)
+			mockServicePerms(m, service)
+			m.EXPECT().AddVpcEndpointServiceSupportedRegions(gomock.Any(), "vpce-svc-12345", []string{"us-east-1"}).Return(nil)
+
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), gomock.Any()).
+				Return(&ec2.DescribeVpcEndpointsOutput{}, nil).Times(2)
+			endpoint := &ec2.VpcEndpoint{
+				VpcEndpointId: aws.String("vpce-12345"),
+				VpcId:         aws.String("vpc-1"),
+				State:         aws.String("available"),
+				DnsEntries: []*ec2.DnsEntry{{
+					DnsName:      aws.String("vpce-12345-us-east-1.vpce-svc-12345.vpc.amazonaws.com"),
+					HostedZoneId: aws.String("HZ23456"),
+				}},
+			}
+			m.EXPECT().CreateVpcEndpointForServiceRegion(gomock.Any(), &ec2.CreateVpcEndpointInput{
+				PrivateDnsEnabled: aws.Bool(false),
+				ServiceName:       service.ServiceName,
+				SubnetIds:         aws.StringSlice([]string{"subnet-1"}),
+				TagSpecifications: []*ec2.TagSpecification{ec2TagSpecification(&hivev1.ClusterMetadata{InfraID: "test-cd-1234"}, "vpc-endpoint")},
+				VpcEndpointType:   aws.String(ec2.VpcEndpointTypeInterface),
+				VpcId:             aws.String("vpc-1"),
+			}, "us-east-2").Return(&ec2.CreateVpcEndpointOutput{VpcEndpoint: endpoint}, nil)
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), &ec2.DescribeVpcEndpointsInput{
+				VpcEndpointIds: aws.StringSlice([]string{*endpoint.VpcEndpointId}),
+			}).Return(&ec2.DescribeVpcEndpointsOutput{
+				VpcEndpoints: []*ec2.VpcEndpoint{endpoint},
+			}, nil)
+
+			hzID := mockPHZ(m, endpoint, "api.test-cluster", nil)
+
+			m.EXPECT().GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
+				HostedZone: &route53.HostedZone{
+					Id: aws.String(hzID),
+				},
+				VPCs: []*route53.VPC{{
+					VPCId:     endpoint.VpcId,
+					VPCRegion: aws.String("us-east-1"),
+				}},
+			}, nil)
+		},
+
 		hasFinalizer: true,
 		expectedStatus: &hivev1aws.PrivateLinkAccessStatus{
 			VPCEndpointService: hivev1aws.VPCEndpointService{Name: "vpce-svc-12345.vpc.amazon.com", ID: "vpce-svc-12345"},
@@ -1349,7 +1568,7 @@ users:
 					DNSName: aws.String("vpc.."),
 				},
 			}
-			m.EXPECT().ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+			m.EXPECT().ListResourceRecordSets(gomock.Any(), &route53.ListResourceRecordSetsInput{
 				HostedZoneId: aws.String("HZ12345"),
 			}).Return(&route53.ListResourceRecordSetsOutput{
 				ResourceRecordSets: []*route53.ResourceRecordSet{{
@@ -1358,7 +1577,7 @@ users:
 					Type: aws.String("SOA"),
 				}, rr},
 			}, nil)
-			m.EXPECT().ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+			m.EXPECT().ChangeResourceRecordSets(gomock.Any(), &route53.ChangeResourceRecordSetsInput{
 				HostedZoneId: aws.String("HZ12345"),
 				ChangeBatch: &route53.ChangeBatch{
 					Changes: []*route53.Change{{
@@ -1367,7 +1586,7 @@ users:
 					}},
 				},
 			}).Return(nil, nil)
-			m.EXPECT().DeleteHostedZone(&route53.DeleteHostedZoneInput{
+			m.EXPECT().DeleteHostedZone(gomock.Any(), &route53.DeleteHostedZoneInput{
 				Id: aws.String("HZ12345"),
 			}).Return(nil, nil)
 
@@ -1375,24 +1594,24 @@ users:
 				VpcEndpointId: aws.String("vpce-12345"),
 				VpcId:         aws.String("vpc-1"),
 			}
-			m.EXPECT().DescribeVpcEndpoints(gomock.Any()).
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), gomock.Any()).
 				Return(&ec2.DescribeVpcEndpointsOutput{
 					VpcEndpoints: []*ec2.VpcEndpoint{{
 						VpcEndpointId: endpoint.VpcEndpointId,
 						VpcId:         endpoint.VpcId,
 					}},
 				}, nil).Times(1)
-			m.EXPECT().DeleteVpcEndpoints(&ec2.DeleteVpcEndpointsInput{
+			m.EXPECT().DeleteVpcEndpoints(gomock.Any(), &ec2.DeleteVpcEndpointsInput{
 				VpcEndpointIds: aws.StringSlice([]string{*endpoint.VpcEndpointId}),
 			}).Return(nil, nil)
 
-			m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any()).
+			m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any(), gomock.Any()).
 				Return(&ec2.DescribeVpcEndpointServiceConfigurationsOutput{
 					ServiceConfigurations: []*ec2.ServiceConfiguration{{
 						ServiceId: aws.String("vpce-svc-12345"),
 					}},
 				}, nil)
-			m.EXPECT().DeleteVpcEndpointServiceConfigurations(&ec2.DeleteVpcEndpointServiceConfigurationsInput{
+			m.EXPECT().DeleteVpcEndpointServiceConfigurations(gomock.Any(), &ec2.DeleteVpcEndpointServiceConfigurationsInput{
 				ServiceIds: aws.StringSlice([]string{"vpce-svc-12345"}),
 			}).Return(nil, nil)
 		},
@@ -1406,6 +1625,166 @@ users:
 			Reason:  "PrivateLinkAccessReady",
 			Message: "private link access is ready for use",
 		}},
+	}, {
+		name: "cd with privatelink enabled, pre-created endpoint, previous provision failed, new started",
+
+		existing: []runtime.Object{
+			testSecret("test-cd-provision-0-kubeconfig", kubeConfigSecret),
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig("test-cd-provision-0-kubeconfig"),
+				provisionWithFailed()),
+			testProvision("test-cd-provision-1",
+				provisionWithPrevInfraID("test-cd-1234")),
+			cdBuilder.Options(testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1",
+				PrivateLink: &hivev1aws.PrivateLinkAccess{
+					Enabled:       true,
+					VPCEndpointID: "vpce-precreated",
+				}})).Build(
+				withClusterMetadata("test-cd-1234", "test-cd-provision-0-kubeconfig"),
+				withClusterProvision("test-cd-provision-1"),
+				withPrivateLink(&hivev1aws.PrivateLinkAccessStatus{
+					VPCEndpointService: hivev1aws.VPCEndpointService{Name: "vpce-svc-12345.vpc.amazon.com", ID: "vpce-svc-12345"},
+					VPCEndpointID:      "vpce-precreated",
+					HostedZoneID:       "HZ12345",
+				}),
+			),
+		},
+		configureAWSClient: func(m *mock.MockClient) {
+			m.EXPECT().ListResourceRecordSets(gomock.Any(), &route53.ListResourceRecordSetsInput{
+				HostedZoneId: aws.String("HZ12345"),
+			}).Return(&route53.ListResourceRecordSetsOutput{
+				ResourceRecordSets: []*route53.ResourceRecordSet{{
+					Type: aws.String("NS"),
+				}, {
+					Type: aws.String("SOA"),
+				}},
+			}, nil)
+			m.EXPECT().DeleteHostedZone(gomock.Any(), &route53.DeleteHostedZoneInput{
+				Id: aws.String("HZ12345"),
+			}).Return(nil, nil)
+
+			// The pre-created endpoint carries the tag of the cluster, but it is not deleted.
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), gomock.Any()).
+				Return(&ec2.DescribeVpcEndpointsOutput{
+					VpcEndpoints: []*ec2.VpcEndpoint{{
+						VpcEndpointId: aws.String("vpce-precreated"),
+						VpcId:         aws.String("vpc-network"),
+					}},
+				}, nil).Times(1)
+
+			m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any(), gomock.Any()).
+				Return(&ec2.DescribeVpcEndpointServiceConfigurationsOutput{
+					ServiceConfigurations: []*ec2.ServiceConfiguration{{
+						ServiceId: aws.String("vpce-svc-12345"),
+					}},
+				}, nil)
+			// The connection of the pre-created endpoint is rejected so that the service can be deleted.
+			m.EXPECT().RejectVpcEndpointConnections(gomock.Any(), &ec2.RejectVpcEndpointConnectionsInput{
+				ServiceId:      aws.String("vpce-svc-12345"),
+				VpcEndpointIds: aws.StringSlice([]string{"vpce-precreated"}),
+			}).Return(&ec2.RejectVpcEndpointConnectionsOutput{}, nil)
+			m.EXPECT().DeleteVpcEndpointServiceConfigurations(gomock.Any(), &ec2.DeleteVpcEndpointServiceConfigurationsInput{
+				ServiceIds: aws.StringSlice([]string{"vpce-svc-12345"}),
+			}).Return(nil, nil)
+		},
+
+		hasFinalizer: true,
+		expectedAnnotations: map[string]string{
+			lastCleanupAnnotationKey: "test-cd-1234",
+		}, expectedConditions: []hivev1.ClusterDeploymentCondition{{
+			Type:    hivev1.AWSPrivateLinkReadyClusterDeploymentCondition,
+			Status:  corev1.ConditionFalse,
+			Reason:  "PreviousAttemptCleanupComplete",
+			Message: "successfully cleaned up resources from previous provision attempt so that next attempt can start",
+		}},
+	}, {
+		name: "cd with privatelink enabled, pre-created endpoint without cluster tag, hosted zone not in status, previous provision failed, new started",
+
+		existing: []runtime.Object{
+			testSecret("test-cd-provision-0-kubeconfig", kubeConfigSecret),
+			testProvision("test-cd-provision-0",
+				provisionWithInfraID("test-cd-1234"),
+				provisionWithAdminKubeconfig("test-cd-provision-0-kubeconfig"),
+				provisionWithFailed()),
+			testProvision("test-cd-provision-1",
+				provisionWithPrevInfraID("test-cd-1234")),
+			cdBuilder.Options(testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1",
+				PrivateLink: &hivev1aws.PrivateLinkAccess{
+					Enabled:       true,
+					VPCEndpointID: "vpce-precreated",
+				}})).Build(
+				withClusterMetadata("test-cd-1234", "test-cd-provision-0-kubeconfig"),
+				withClusterProvision("test-cd-provision-1"),
+				withPrivateLink(&hivev1aws.PrivateLinkAccessStatus{
+					VPCEndpointService: hivev1aws.VPCEndpointService{Name: "vpce-svc-12345.vpc.amazon.com", ID: "vpce-svc-12345"},
+					VPCEndpointID:      "vpce-precreated",
+				}),
+			),
+		},
+		configureAWSClient: func(m *mock.MockClient) {
+			// The hosted zone is found through the pre-created endpoint, looked up by its ID.
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), &ec2.DescribeVpcEndpointsInput{
+				VpcEndpointIds: aws.StringSlice([]string{"vpce-precreated"}),
+			}).Return(&ec2.DescribeVpcEndpointsOutput{
+				VpcEndpoints: []*ec2.VpcEndpoint{{
+					VpcEndpointId: aws.String("vpce-precreated"),
+					VpcId:         aws.String("vpc-network"),
+				}},
+			}, nil)
+			m.EXPECT().ListHostedZonesByVPC(gomock.Any(), &route53.ListHostedZonesByVPCInput{
+				MaxItems:  aws.String("100"),
+				VPCId:     aws.String("vpc-network"),
+				VPCRegion: aws.String("us-east-1"),
+			}).Return(&route53.ListHostedZonesByVPCOutput{
+				HostedZoneSummaries: []*route53.HostedZoneSummary{{
+					HostedZoneId: aws.String("HZ12345"),
+					Name:         aws.String("api.test-cluster."),
+				}},
+			}, nil)
+			m.EXPECT().ListResourceRecordSets(gomock.Any(), &route53.ListResourceRecordSetsInput{
+				HostedZoneId: aws.String("HZ12345"),
+			}).Return(&route53.ListResourceRecordSetsOutput{
+				ResourceRecordSets: []*route53.ResourceRecordSet{{
+					Type: aws.String("NS"),
+				}, {
+					Type: aws.String("SOA"),
+				}},
+			}, nil)
+			m.EXPECT().DeleteHostedZone(gomock.Any(), &route53.DeleteHostedZoneInput{
+				Id: aws.String("HZ12345"),
+			}).Return(nil, nil)
+
+			// The pre-created endpoint does not carry the tag of the cluster.
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), &ec2.DescribeVpcEndpointsInput{
+				Filters: []*ec2.Filter{ec2FilterForCluster(&hivev1.ClusterMetadata{InfraID: "test-cd-1234"})},
+			}).Return(&ec2.DescribeVpcEndpointsOutput{}, nil)
+
+			m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any(), gomock.Any()).
+				Return(&ec2.DescribeVpcEndpointServiceConfigurationsOutput{
+					ServiceConfigurations: []*ec2.ServiceConfiguration{{
+						ServiceId: aws.String("vpce-svc-12345"),
+					}},
+				}, nil)
+			// The connection of the pre-created endpoint is rejected so that the service can be deleted.
+			m.EXPECT().RejectVpcEndpointConnections(gomock.Any(), &ec2.RejectVpcEndpointConnectionsInput{
+				ServiceId:      aws.String("vpce-svc-12345"),
+				VpcEndpointIds: aws.StringSlice([]string{"vpce-precreated"}),
+			}).Return(&ec2.RejectVpcEndpointConnectionsOutput{}, nil)
+			m.EXPECT().DeleteVpcEndpointServiceConfigurations(gomock.Any(), &ec2.DeleteVpcEndpointServiceConfigurationsInput{
+				ServiceIds: aws.StringSlice([]string{"vpce-svc-12345"}),
+			}).Return(nil, nil)
+		},
+
+		hasFinalizer: true,
+		expectedAnnotations: map[string]string{
+			lastCleanupAnnotationKey: "test-cd-1234",
+		}, expectedConditions: []hivev1.ClusterDeploymentCondition{{
+			Type:    hivev1.AWSPrivateLinkReadyClusterDeploymentCondition,
+			Status:  corev1.ConditionFalse,
+			Reason:  "PreviousAttemptCleanupComplete",
+			Message: "successfully cleaned up resources from previous provision attempt so that next attempt can start",
+		}},
 	}, {
 		name: "cd with privatelink enabled, previous provision failed, new started, cleanup already done",
 
@@ -20,13 +20,13 @@ import (
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
-func (r *ReconcileAWSPrivateLink) cleanupClusterDeployment(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, logger log.FieldLogger) (reconcile.Result, error) {
+func (r *ReconcileAWSPrivateLink) cleanupClusterDeployment(ctx context.Context, cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, logger log.FieldLogger) (reconcile.Result, error) {
 	if !controllerutils.HasFinalizer(cd, finalizer) {
 		return reconcile.Result{}, nil
 	}
 
 	if metadata != nil && cleanupRequired(cd) {
-		if err := r.cleanupPrivateLink(cd, metadata, logger); err != nil {
+		if err := r.cleanupPrivateLink(ctx, cd, metadata, logger); err != nil {
 			logger.WithError(err).Error("error cleaning up PrivateLink resources for ClusterDeployment")
 
 			if err := r.setErrCondition(cd, "CleanupForDeprovisionFailed", err, logger); err != nil {
@@ -55,7 +55,7 @@ func (r *ReconcileAWSPrivateLink) cleanupClusterDeployment(cd *hivev1.ClusterDep
 	return reconcile.Result{}, nil
 }
 
-func (r *ReconcileAWSPrivateLink) cleanupPreviousProvisionAttempt(cd *hivev1.ClusterDeployment, cp *hivev1.ClusterProvision,
+func (r *ReconcileAWSPrivateLink) cleanupPreviousProvisionAttempt(ctx context.Context, cd *hivev1.ClusterDeployment, cp *hivev1.ClusterProvision,
 	logger log.FieldLogger) error {
 	if cd.Spec.ClusterMetadata == nil {
 		return errors.New("cannot cleanup previous resources because the admin kubeconfig is not available")
@@ -65,7 +65,7 @@ func (r *ReconcileAWSPrivateLink) cleanupPreviousProvisionAttempt(cd *hivev1.Clu
 		AdminKubeconfigSecretRef: cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef,
 	}
 
-	if err := r.cleanupPrivateLink(cd, metadata, logger); err != nil {
+	if err := r.cleanupPrivateLink(ctx, cd, metadata, logger); err != nil {
 		logger.WithError(err).Error("error cleaning up PrivateLink resources for ClusterDeployment")
 		return err
 	}
@@ -87,22 +87,22 @@ func cleanupRequired(cd *hivev1.ClusterDeployment) bool {
 		plStatus.HostedZoneID != ""
 }
 
-func (r *ReconcileAWSPrivateLink) cleanupPrivateLink(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, logger log.FieldLogger) error {
+func (r *ReconcileAWSPrivateLink) cleanupPrivateLink(ctx context.Context, cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, logger log.FieldLogger) error {
 	awsClient, err := newAWSClient(r, cd)
 	if err != nil {
 		logger.WithError(err).Error("error creating AWS client for the cluster")
 		return err
 	}
 
-	if err := r.cleanupHostedZone(awsClient.hub, cd, metadata, logger); err != nil {
+	if err := r.cleanupHostedZone(ctx, awsClient, cd, metadata, logger); err != nil {
 		logger.WithError(err).Error("error cleaning up Hosted Zone")
 		return err
 	}
-	if err := r.cleanupVPCEndpoint(awsClient.hub, cd, metadata, logger); err != nil {
+	if err := r.cleanupVPCEndpoint(ctx, awsClient.hub, cd, metadata, logger); err != nil {
 		logger.WithError(err).Error("error cleaning up VPCEndpoint")
 		return err
 	}
-	if err := r.cleanupVPCEndpointService(awsClient.user, cd, metadata, logger); err != nil {
+	if err := r.cleanupVPCEndpointService(ctx, awsClient.user, cd, metadata, logger); err != nil {
 		logger.WithError(err).Error("error cleaning up VPCEndpoint Service")
 		return err
 	}
@@ -117,9 +117,10 @@ func (r *ReconcileAWSPrivateLink) cleanupPrivateLink(cd *hivev1.ClusterDeploymen
 	return nil
 }
 
-func (r *ReconcileAWSPrivateLink) cleanupHostedZone(awsClient awsclient.Client,
+func (r *ReconcileAWSPrivateLink) cleanupHostedZone(ctx context.Context, clients *awsClient,
 	cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
 	logger log.FieldLogger) error {
+	awsClient := clients.hub
 
 	var hzID string
 	if cd.Status.Platform != nil &&
@@ -141,9 +142,20 @@ func (r *ReconcileAWSPrivateLink) cleanupHostedZone(awsClient awsclient.Client,
 		}
 
 		idLog := logger.WithField("infraID", metadata.InfraID)
-		endpointResp, err := awsClient.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
+		input := &ec2.DescribeVpcEndpointsInput{
 			Filters: []*ec2.Filter{ec2FilterForCluster(metadata)},
-		})
+		}
+		// A pre-created VPC Endpoint may not carry the tag of the cluster.
+		if preCreatedID := preCreatedVPCEndpointID(cd); preCreatedID != "" {
+			input = &ec2.DescribeVpcEndpointsInput{
+				VpcEndpointIds: aws.StringSlice([]string{preCreatedID}),
+			}
+			idLog = idLog.WithField("vpcEndpointID", preCreatedID)
+		}
+		endpointResp, err := awsClient.DescribeVpcEndpoints(ctx, input)
+		if awsErrCodeEquals(err, "InvalidVpcEndpointId.NotFound") {
+			return nil // no work
+		}
 		if err != nil {
 			idLog.WithError(err).Error("error getting the VPC Endpoint")
 			return err
@@ -153,7 +165,7 @@ func (r *ReconcileAWSPrivateLink) cleanupHostedZone(awsClient awsclient.Client,
 		}
 
 		vpcEndpoint := endpointResp.VpcEndpoints[0]
-		hzID, err = findHostedZone(awsClient, *vpcEndpoint.VpcId, cd.Spec.Platform.AWS.Region, apiDomain, logger)
+		hzID, err = findHostedZone(ctx, awsClient, *vpcEndpoint.VpcId, clients.endpointRegion, apiDomain, logger)
 		if err != nil && errors.Is(err, errNoHostedZoneFoundForVPC) {
 			return nil // no work
 		}
@@ -164,7 +176,7 @@ func (r *ReconcileAWSPrivateLink) cleanupHostedZone(awsClient awsclient.Client,
 	}
 
 	hzLog := logger.WithField("hostedZoneID", hzID)
-	recordsResp, err := awsClient.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+	recordsResp, err := awsClient.ListResourceRecordSets(ctx, &route53.ListResourceRecordSetsInput{
 		HostedZoneId: aws.String(hzID),
 	})
 	if awsErrCodeEquals(err, "NoSuchHostedZone") {
@@ -179,7 +191,7 @@ func (r *ReconcileAWSPrivateLink) cleanupHostedZone(awsClient awsclient.Client,
 			// can't delete SOA and NS types
 			continue
 		}
-		_, err := awsClient.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		_, err := awsClient.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
 			HostedZoneId: aws.String(hzID),
 			ChangeBatch: &route53.ChangeBatch{
 				Changes: []*route53.Change{{
@@ -194,7 +206,7 @@ func (r *ReconcileAWSPrivateLink) cleanupHostedZone(awsClient awsclient.Client,
 		}
 	}
 
-	_, err = awsClient.DeleteHostedZone(&route53.DeleteHostedZoneInput{
+	_, err = awsClient.DeleteHostedZone(ctx, &route53.DeleteHostedZoneInput{
 		Id: aws.String(hzID),
 	})
 	if err != nil && !awsErrCodeEquals(err, "NoSuchHostedZone") {
@@ -206,40 +218,45 @@ func (r *ReconcileAWSPrivateLink) cleanupHostedZone(awsClient awsclient.Client,
 
 }
 
-func (r *ReconcileAWSPrivateLink) cleanupVPCEndpoint(awsClient awsclient.Client,
+func (r *ReconcileAWSPrivateLink) cleanupVPCEndpoint(ctx context.Context, awsClient awsclient.Client,
 	cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
 	logger log.FieldLogger) error {
 	idLog := logger.WithField("infraID", metadata.InfraID)
-	resp, err := awsClient.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
+	resp, err := awsClient.DescribeVpcEndpoints(ctx, &ec2.DescribeVpcEndpointsInput{
 		Filters: []*ec2.Filter{ec2FilterForCluster(metadata)},
 	})
 	if err != nil {
 		idLog.WithError(err).Error("error getting the VPC Endpoint")
 		return err
 	}
-	if len(resp.VpcEndpoints) == 0 {
-		return nil // no work
-	}
 
-	vpcEndpoint := resp.VpcEndpoints[0]
-	endpointLog := logger.WithField("vpcEndpointID", *vpcEndpoint.VpcEndpointId)
+	// A pre-created VPC Endpoint is owned by the user, so it is left in place even when it carries the tag of the
+	// cluster.
+	preCreatedID := preCreatedVPCEndpointID(cd)
+	for _, vpcEndpoint := range resp.VpcEndpoints {
+		endpointLog := logger.WithField("vpcEndpointID", aws.StringValue(vpcEndpoint.VpcEndpointId))
+		if preCreatedID != "" && aws.StringValue(vpcEndpoint.VpcEndpointId) == preCreatedID {
+			endpointLog.Debug("skipping deletion of the pre-created VPC Endpoint")
+			continue
+		}
 
-	_, err = awsClient.DeleteVpcEndpoints(&ec2.DeleteVpcEndpointsInput{
-		VpcEndpointIds: aws.StringSlice([]string{*vpcEndpoint.VpcEndpointId}),
-	})
-	if err != nil && !awsErrCodeEquals(err, "InvalidVpcEndpointId.NotFound") {
-		endpointLog.WithError(err).Error("error deleting the VPC Endpoint")
-		return err
+		_, err = awsClient.DeleteVpcEndpoints(ctx, &ec2.DeleteVpcEndpointsInput{
+			VpcEndpointIds: []*string{vpcEndpoint.VpcEndpointId},
+		})
+		if err != nil && !awsErrCodeEquals(err, "InvalidVpcEndpointId.NotFound") {
+			endpointLog.WithError(err).Error("error deleting the VPC Endpoint")
+			return err
+		}
 	}
 
 	return nil
 }
 
-func (r *ReconcileAWSPrivateLink) cleanupVPCEndpointService(awsClient awsclient.Client,
+func (r *ReconcileAWSPrivateLink) cleanupVPCEndpointService(ctx context.Context, awsClient awsclient.Client,
 	cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
 	logger log.FieldLogger) error {
 	idLog := logger.WithField("infraID", metadata.InfraID)
-	resp, err := awsClient.DescribeVpcEndpointServiceConfigurations(&ec2.DescribeVpcEndpointServiceConfigurationsInput{
+	resp, err := awsClient.DescribeVpcEndpointServiceConfigurations(ctx, &ec2.DescribeVpcEndpointServiceConfigurationsInput{
 		Filters: []*ec2.Filter{ec2FilterForCluster(metadata)},
 	})
 	if err != nil {
@@ -253,7 +270,20 @@ func (r *ReconcileAWSPrivateLink) cleanupVPCEndpointService(awsClient awsclient.
 	service := resp.ServiceConfigurations[0]
 	serviceLog := logger.WithField("vpcEndpointServiceID", *service.ServiceId)
 
-	_, err = awsClient.DeleteVpcEndpointServiceConfigurations(&ec2.DeleteVpcEndpointServiceConfigurationsInput{
+	// The pre-created VPC Endpoint is left in place, so it is still connected to the service, which cannot be deleted
+	// until the connection is rejected.
+	if preCreatedID := preCreatedVPCEndpointID(cd); preCreatedID != "" {
+		_, err := awsClient.RejectVpcEndpointConnections(ctx, &ec2.RejectVpcEndpointConnectionsInput{
+			ServiceId:      service.ServiceId,
+			VpcEndpointIds: aws.StringSlice([]string{preCreatedID}),
+		})
+		if err != nil && !awsErrCodeEquals(err, "InvalidVpcEndpointService.NotFound") {
+			serviceLog.WithField("vpcEndpointID", preCreatedID).WithError(err).Error("error rejecting the connection of the pre-created VPC Endpoint")
+			return err
+		}
+	}
+
+	_, err = awsClient.DeleteVpcEndpointServiceConfigurations(ctx, &ec2.DeleteVpcEndpointServiceConfigurationsInput{
 		ServiceIds: aws.StringSlice([]string{*service.ServiceId}),
 	})
 	if err != nil && !awsErrCodeEquals(err, "InvalidVpcEndpointService.NotFound") {
@@ -263,3 +293,11 @@ func (r *ReconcileAWSPrivateLink) cleanupVPCEndpointService(awsClient awsclient.
 
 	return nil
 }
+
+// preCreatedVPCEndpointID returns the ID of the VPC Endpoint managed outside of Hive for the cluster, if any.
+func preCreatedVPCEndpointID(cd *hivev1.ClusterDeployment) string {
+	if cd.Spec.Platform.AWS == nil || cd.Spec.Platform.AWS.PrivateLink == nil {
+		return ""
+	}
+	return cd.Spec.Platform.AWS.PrivateLink.VPCEndpointID
+}
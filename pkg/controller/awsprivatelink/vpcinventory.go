@@ -1,6 +1,7 @@
 package awsprivatelink
 
 import (
+	"context"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -8,7 +9,6 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
-	"github.com/openshift/hive/pkg/awsclient"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -19,34 +19,42 @@ var (
 	errNoVPCWithQuotaInInventory = errors.New("no supported VPC in inventory with available quota")
 )
 
-func (r *ReconcileAWSPrivateLink) chooseVPCForVPCEndpoint(awsClient awsclient.Client,
+func (r *ReconcileAWSPrivateLink) chooseVPCForVPCEndpoint(ctx context.Context, clients *awsClient,
 	cd *hivev1.ClusterDeployment, vpcEndpointServiceName string,
 	logger log.FieldLogger) (*hivev1.AWSPrivateLinkInventory, error) {
+	awsClient := clients.hub
 	serviceLog := logger.WithField("serviceName", vpcEndpointServiceName)
-	// Filter out the VPCs in cluster region.
-	candidates := filterVPCInventory(r.controllerconfig.DeepCopy().EndpointVPCInventory, toSupportedRegion(cd.Spec.Platform.AWS.Region))
+	// Filter out the VPCs in the region chosen for the VPC Endpoint.
+	candidates := filterVPCInventory(r.controllerconfig.DeepCopy().EndpointVPCInventory, toSupportedRegion(clients.endpointRegion))
+	if clients.isCrossRegion(cd) {
+		candidates = filterVPCInventory(candidates, toServedRegion(cd.Spec.Platform.AWS.Region))
+	}
 	if len(candidates) == 0 {
-		serviceLog.WithField("region", cd.Spec.Platform.AWS.Region).Error("no supported VPC in inventory")
+		serviceLog.WithField("region", clients.endpointRegion).Error("no supported VPC in inventory")
 		return nil, errors.New("no supported VPC in inventory for the cluster")
 	}
 
-	// Figure out the AZs supported by the service.
-	servicesResp, err := awsClient.DescribeVpcEndpointServices(&ec2.DescribeVpcEndpointServicesInput{
-		ServiceNames: aws.StringSlice([]string{vpcEndpointServiceName}),
-	})
-	if err != nil {
-		serviceLog.WithError(err).Error("error getting VPC Endpoint Service in hub account")
-		return nil, err
-	}
+	// The AZs supported by a service in another region have no relation to the AZs of the
+	// VPC Endpoint, so only filter the subnets for VPC Endpoints in the region of the service.
+	if !clients.isCrossRegion(cd) {
+		// Figure out the AZs supported by the service.
+		servicesResp, err := awsClient.DescribeVpcEndpointServices(ctx, &ec2.DescribeVpcEndpointServicesInput{
+			ServiceNames: aws.StringSlice([]string{vpcEndpointServiceName}),
+		})
+		if err != nil {
+			serviceLog.WithError(err).Error("error getting VPC Endpoint Service in hub account")
+			return nil, err
+		}
 
-	// Filter candidates that don't have at least one subnet in supported AZs.
-	supportedAZSet := sets.NewString(aws.StringValueSlice(servicesResp.ServiceDetails[0].AvailabilityZones)...)
-	candidates = filterVPCInventory(candidates, toSupportedSubnets(supportedAZSet))
-	if len(candidates) == 0 {
-		logger.WithField("region", cd.Spec.Platform.AWS.Region).
-			WithField("requiredAZs", supportedAZSet.List()).
-			Error(errNoSupportedAZsInInventory.Error())
-		return nil, errNoSupportedAZsInInventory
+		// Filter candidates that don't have at least one subnet in supported AZs.
+		supportedAZSet := sets.NewString(aws.StringValueSlice(servicesResp.ServiceDetails[0].AvailabilityZones)...)
+		candidates = filterVPCInventory(candidates, toSupportedSubnets(supportedAZSet))
+		if len(candidates) == 0 {
+			logger.WithField("region", cd.Spec.Platform.AWS.Region).
+				WithField("requiredAZs", supportedAZSet.List()).
+				Error(errNoSupportedAZsInInventory.Error())
+			return nil, errNoSupportedAZsInInventory
+		}
 	}
 
 	// Figure out which VPCs have quota available for endpoints.
@@ -56,7 +64,7 @@ func (r *ReconcileAWSPrivateLink) chooseVPCForVPCEndpoint(awsClient awsclient.Cl
 		vpcs = append(vpcs, cand.VPCID)
 		endpointsPerVPC[cand.VPCID] = 0
 	}
-	endpointsResp, err := awsClient.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
+	endpointsResp, err := awsClient.DescribeVpcEndpoints(ctx, &ec2.DescribeVpcEndpointsInput{
 		Filters: []*ec2.Filter{{Name: aws.String("vpc-id"), Values: aws.StringSlice(vpcs)}},
 	})
 	if err != nil {
@@ -97,6 +105,17 @@ func toSupportedRegion(region string) filterVPCInventoryFn {
 	}
 }
 
+func toServedRegion(region string) filterVPCInventoryFn {
+	return func(inv *hivev1.AWSPrivateLinkInventory) bool {
+		for _, served := range inv.ServedRegions {
+			if strings.EqualFold(region, served) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 func toSupportedSubnets(azs sets.String) filterVPCInventoryFn {
 	return func(inv *hivev1.AWSPrivateLinkInventory) bool {
 		n := 0
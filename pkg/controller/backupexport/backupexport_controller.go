@@ -0,0 +1,465 @@
+package backupexport
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.BackupExportControllerName
+
+	defaultInterval  = 24 * time.Hour
+	defaultRetention = 7
+
+	// exportTimeFormat is the format of the timestamp naming the folder of an export in the bucket.
+	exportTimeFormat = "20060102T150405Z"
+
+	resourcesKey = "resources.json"
+	secretsKey   = "secrets.json.enc"
+
+	// encryptionKeySecretKey is the data entry of the encryption key secret holding the AES key.
+	encryptionKeySecretKey = "key"
+)
+
+var (
+	// exportedListTypes are the types of the resources exported.
+	exportedListTypes = []client.ObjectList{
+		&hivev1.ClusterDeploymentList{},
+		&hivev1.ClusterPoolList{},
+		&hivev1.ClusterClaimList{},
+		&hivev1.MachinePoolList{},
+		&hivev1.SyncSetList{},
+		&hivev1.SelectorSyncSetList{},
+		&hivev1.DNSZoneList{},
+		&hivev1.ClusterImageSetList{},
+	}
+
+	metricLastExport = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "hive_backup_export_last_success_timestamp_seconds",
+		Help: "Time of the last successful export of Hive resources to object storage, as seconds since the epoch.",
+	})
+	metricExportErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "hive_backup_export_errors_total",
+		Help: "Counter incremented every time an export of Hive resources to object storage fails.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricLastExport)
+	metrics.Registry.MustRegister(metricExportErrors)
+}
+
+// Add creates a new BackupExport Controller and adds it to the Manager with default RBAC. The Manager will set fields
+// on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := controllerutils.ControllerLogEntry(ControllerName)
+	config, err := ReadBackupExportConfigFile()
+	if err != nil {
+		logger.WithError(err).Error("could not read backup export configuration")
+		return err
+	}
+	// Don't run the controller unless the export is configured.
+	if config == nil {
+		logger.Debug("backup export is not configured")
+		return nil
+	}
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter, config), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter, config *hivev1.BackupExportConfig) reconcile.Reconciler {
+	return &ReconcileBackupExport{
+		Client:      controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		apiReader:   mgr.GetAPIReader(),
+		scheme:      mgr.GetScheme(),
+		config:      config,
+		awsClientFn: awsclient.New,
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	// Create a new controller
+	c, err := controllerutils.NewController("backupexport-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// The exports are scheduled from the HiveConfig.
+	if err := c.Watch(&source.Kind{Type: &hivev1.HiveConfig{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileBackupExport{}
+
+// ReconcileBackupExport periodically exports Hive resources to object storage
+type ReconcileBackupExport struct {
+	client.Client
+	scheme *runtime.Scheme
+
+	// apiReader reads the secrets directly from the API server, so that the controller does not start an informer
+	// on every secret of the hub for an export made once a day.
+	apiReader client.Reader
+
+	config *hivev1.BackupExportConfig
+
+	// awsClientFn is the function to build an AWS client, here for testing
+	awsClientFn func(client.Client, awsclient.Options) (awsclient.Client, error)
+}
+
+// Reconcile exports the Hive resources to the bucket once the interval since the last export in the bucket has
+// elapsed, and deletes the exports beyond the retention.
+func (r *ReconcileBackupExport) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "hiveConfig", request.NamespacedName)
+	if request.Name != constants.HiveConfigName {
+		logger.Debug("ignoring HiveConfig with unsupported name")
+		return reconcile.Result{}, nil
+	}
+	logger.Info("reconciling backup export")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	awsClient, err := r.awsClientFn(r.Client, awsclient.Options{
+		Controller: hivev1.BackupExportControllerName,
+		Region:     r.config.S3.Region,
+		CredentialsSource: awsclient.CredentialsSource{
+			Secret: &awsclient.SecretCredentialsSource{
+				Namespace: controllerutils.GetHiveNamespace(),
+				Ref:       &r.config.S3.CredentialsSecretRef,
+			},
+		},
+		Endpoint: r.config.S3.Endpoint,
+	})
+	if err != nil {
+		logger.WithError(err).Error("error creating AWS client")
+		return reconcile.Result{}, err
+	}
+
+	exports, err := r.listExports(ctx, awsClient)
+	if err != nil {
+		logger.WithError(err).Error("error listing exports")
+		return reconcile.Result{}, err
+	}
+
+	interval := defaultInterval
+	if r.config.Interval != nil {
+		interval = r.config.Interval.Duration
+	}
+	now := time.Now().UTC()
+	if len(exports) > 0 {
+		last := exports[len(exports)-1]
+		metricLastExport.Set(float64(last.Unix()))
+		if next := last.Add(interval); now.Before(next) {
+			logger.WithField("lastExport", last).Debug("export not due yet")
+			return reconcile.Result{RequeueAfter: next.Sub(now)}, nil
+		}
+	}
+
+	if err := r.export(ctx, awsClient, now, logger); err != nil {
+		metricExportErrors.Inc()
+		logger.WithError(err).Error("error exporting resources")
+		return reconcile.Result{}, err
+	}
+	metricLastExport.Set(float64(now.Unix()))
+	exports = append(exports, now)
+
+	retention := defaultRetention
+	if r.config.Retention != nil {
+		retention = int(*r.config.Retention)
+	}
+	if len(exports) > retention {
+		for _, expired := range exports[:len(exports)-retention] {
+			if err := r.deleteExport(ctx, awsClient, expired, logger); err != nil {
+				logger.WithError(err).WithField("export", r.exportFolder(expired)).Error("error deleting expired export")
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+// export uploads the resources, and the encrypted secrets if configured, to the folder of the export at the time.
+func (r *ReconcileBackupExport) export(ctx context.Context, awsClient awsclient.Client, exportTime time.Time, logger log.FieldLogger) error {
+	folder := r.exportFolder(exportTime)
+	logger = logger.WithField("export", folder)
+
+	var items []runtime.Object
+	namespaces := sets.NewString()
+	for _, listType := range exportedListTypes {
+		list := listType.DeepCopyObject().(client.ObjectList)
+		if err := r.List(context.TODO(), list); err != nil {
+			return errors.Wrap(err, "could not list resources")
+		}
+		objs, err := meta.ExtractList(list)
+		if err != nil {
+			return errors.Wrap(err, "could not extract resources")
+		}
+		for _, obj := range objs {
+			switch o := obj.(type) {
+			case *hivev1.ClusterDeployment:
+				namespaces.Insert(o.Namespace)
+			case *hivev1.ClusterPool:
+				namespaces.Insert(o.Namespace)
+			}
+		}
+		items = append(items, objs...)
+	}
+	resources, err := r.marshalList(items)
+	if err != nil {
+		return err
+	}
+	if err := upload(ctx, awsClient, r.config.S3.Bucket, path.Join(folder, resourcesKey), resources); err != nil {
+		return err
+	}
+	logger.WithField("resources", len(items)).Info("exported resources")
+
+	if r.config.SecretsEncryptionKeySecretRef == nil {
+		return nil
+	}
+	key, err := r.encryptionKey(ctx)
+	if err != nil {
+		return err
+	}
+	var secrets []runtime.Object
+	for _, namespace := range namespaces.List() {
+		secretList := &corev1.SecretList{}
+		if err := r.apiReader.List(ctx, secretList, client.InNamespace(namespace)); err != nil {
+			return errors.Wrap(err, "could not list secrets")
+		}
+		for i := range secretList.Items {
+			// Tokens of service accounts are recreated along with the service accounts.
+			if secretList.Items[i].Type == corev1.SecretTypeServiceAccountToken {
+				continue
+			}
+			secrets = append(secrets, &secretList.Items[i])
+		}
+	}
+	data, err := r.marshalList(secrets)
+	if err != nil {
+		return err
+	}
+	encrypted, err := encrypt(key, data)
+	if err != nil {
+		return err
+	}
+	if err := upload(ctx, awsClient, r.config.S3.Bucket, path.Join(folder, secretsKey), encrypted); err != nil {
+		return err
+	}
+	logger.WithField("secrets", len(secrets)).Info("exported secrets")
+	return nil
+}
+
+// marshalList marshals the objects as a List, clearing the fields set by the API server.
+func (r *ReconcileBackupExport) marshalList(objs []runtime.Object) ([]byte, error) {
+	list := &corev1.List{}
+	list.APIVersion = "v1"
+	list.Kind = "List"
+	for _, obj := range objs {
+		gvk, err := apiutil.GVKForObject(obj, r.scheme)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get kind of resource")
+		}
+		obj = obj.DeepCopyObject()
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		}
+		accessor.SetResourceVersion("")
+		accessor.SetUID("")
+		accessor.SetManagedFields(nil)
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not marshal resource")
+		}
+		list.Items = append(list.Items, runtime.RawExtension{Raw: raw})
+	}
+	return json.Marshal(list)
+}
+
+// encryptionKey returns the AES key of the encryption key secret.
+func (r *ReconcileBackupExport) encryptionKey(ctx context.Context) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := r.apiReader.Get(ctx, types.NamespacedName{Namespace: controllerutils.GetHiveNamespace(), Name: r.config.SecretsEncryptionKeySecretRef.Name}, secret); err != nil {
+		return nil, errors.Wrap(err, "could not get encryption key secret")
+	}
+	key := secret.Data[encryptionKeySecretKey]
+	if len(key) != 32 {
+		return nil, errors.Errorf("the %q entry of the encryption key secret must be a 32 byte key", encryptionKeySecretKey)
+	}
+	return key, nil
+}
+
+// listExports returns the times of the exports in the bucket, from the oldest to the newest.
+func (r *ReconcileBackupExport) listExports(ctx context.Context, awsClient awsclient.Client) ([]time.Time, error) {
+	prefix := r.config.S3.Prefix
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	var exports []time.Time
+	err := awsClient.GetS3API().ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket:    aws.String(r.config.S3.Bucket),
+		Prefix:    aws.String(prefix),
+		Delimiter: aws.String("/"),
+	}, func(out *s3.ListObjectsV2Output, _ bool) bool {
+		for _, p := range out.CommonPrefixes {
+			name := strings.TrimSuffix(strings.TrimPrefix(aws.StringValue(p.Prefix), prefix), "/")
+			// Other folders in the bucket are not exports.
+			if t, err := time.Parse(exportTimeFormat, name); err == nil {
+				exports = append(exports, t)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(exports, func(i, j int) bool { return exports[i].Before(exports[j]) })
+	return exports, nil
+}
+
+// deleteExport deletes the objects of the export at the time. The objects are deleted one at a time since
+// DeleteObjects is not supported by all the S3-compatible stores, such as the interoperability API of Google Cloud
+// Storage, and an export only has a couple of objects.
+func (r *ReconcileBackupExport) deleteExport(ctx context.Context, awsClient awsclient.Client, exportTime time.Time, logger log.FieldLogger) error {
+	folder := r.exportFolder(exportTime) + "/"
+	s3API := awsClient.GetS3API()
+	var keys []*string
+	err := s3API.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(r.config.S3.Bucket),
+		Prefix: aws.String(folder),
+	}, func(out *s3.ListObjectsV2Output, _ bool) bool {
+		for _, o := range out.Contents {
+			keys = append(keys, o.Key)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	logger.WithField("export", folder).Info("deleting expired export")
+	for _, key := range keys {
+		if _, err := s3API.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(r.config.S3.Bucket),
+			Key:    key,
+		}); err != nil {
+			return errors.Wrapf(err, "could not delete %s", aws.StringValue(key))
+		}
+	}
+	return nil
+}
+
+// exportFolder returns the key of the folder of the export at the time.
+func (r *ReconcileBackupExport) exportFolder(exportTime time.Time) string {
+	return path.Join(r.config.S3.Prefix, exportTime.UTC().Format(exportTimeFormat))
+}
+
+func upload(ctx context.Context, awsClient awsclient.Client, bucket, key string, data []byte) error {
+	if _, err := awsClient.Upload(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(data),
+	}); err != nil {
+		return errors.Wrapf(err, "could not upload %s", key)
+	}
+	return nil
+}
+
+// encrypt encrypts the data with AES-GCM, prefixing the ciphertext with the nonce.
+func encrypt(key, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, data, nil), nil
+}
+
+// ReadBackupExportConfigFile reads the configuration of the export from the file pointed to by the
+// HIVE_BACKUP_EXPORT_CONFIG_FILE environment variable. No configuration is returned if the environment variable is
+// not set or the file does not exist or is empty.
+func ReadBackupExportConfigFile() (*hivev1.BackupExportConfig, error) {
+	path := os.Getenv(constants.BackupExportConfigFileEnvVar)
+	if path == "" {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the backup export config file")
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	config := &hivev1.BackupExportConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the backup export config file")
+	}
+	return config, nil
+}
@@ -0,0 +1,272 @@
+package backupexport
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
+	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	testBucket    = "hive-backups"
+	testPrefix    = "prod"
+	testNamespace = "cluster-ns"
+	testKeySecret = "export-key"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestBackupExportReconcile(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	now := time.Now().UTC()
+	tests := []struct {
+		name               string
+		existingExports    []time.Time
+		encryptSecrets     bool
+		expectExport       bool
+		expectSecrets      bool
+		expectedExports    int
+		expectRequeueAfter time.Duration
+	}{
+		{
+			name:               "first export",
+			expectExport:       true,
+			expectedExports:    1,
+			expectRequeueAfter: defaultInterval,
+		},
+		{
+			name:               "export not due",
+			existingExports:    []time.Time{now.Add(-time.Hour)},
+			expectedExports:    1,
+			expectRequeueAfter: 23 * time.Hour,
+		},
+		{
+			name:               "export due",
+			existingExports:    []time.Time{now.Add(-25 * time.Hour)},
+			expectExport:       true,
+			expectedExports:    2,
+			expectRequeueAfter: defaultInterval,
+		},
+		{
+			name: "expired exports deleted",
+			existingExports: []time.Time{
+				now.Add(-8 * 24 * time.Hour),
+				now.Add(-7 * 24 * time.Hour),
+				now.Add(-6 * 24 * time.Hour),
+				now.Add(-5 * 24 * time.Hour),
+				now.Add(-4 * 24 * time.Hour),
+				now.Add(-3 * 24 * time.Hour),
+				now.Add(-2 * 24 * time.Hour),
+			},
+			expectExport:       true,
+			expectedExports:    defaultRetention,
+			expectRequeueAfter: defaultInterval,
+		},
+		{
+			name:               "encrypted secrets",
+			encryptSecrets:     true,
+			expectExport:       true,
+			expectSecrets:      true,
+			expectedExports:    1,
+			expectRequeueAfter: defaultInterval,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			existing := []runtime.Object{
+				&hivev1.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "cd", ResourceVersion: "5"}},
+				&hivev1.ClusterImageSet{ObjectMeta: metav1.ObjectMeta{Name: "imageset"}},
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "pull-secret"}},
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "token"}, Type: corev1.SecretTypeServiceAccountToken},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: constants.DefaultHiveNamespace, Name: testKeySecret},
+					Data:       map[string][]byte{encryptionKeySecretKey: testKey},
+				},
+			}
+			fakeClient := fake.NewFakeClient(existing...)
+
+			bucket := &fakeBucket{objects: map[string][]byte{}}
+			for _, e := range test.existingExports {
+				bucket.objects[path.Join(testPrefix, e.Format(exportTimeFormat), resourcesKey)] = []byte("{}")
+			}
+			bucket.objects[path.Join(testPrefix, "other", "file")] = []byte("{}")
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockAWSClient := mockaws.NewMockClient(mockCtrl)
+			mockAWSClient.EXPECT().GetS3API().Return(bucket).AnyTimes()
+			mockAWSClient.EXPECT().Upload(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, in *s3manager.UploadInput) (*s3manager.UploadOutput, error) {
+				data, err := ioutil.ReadAll(in.Body)
+				require.NoError(t, err)
+				bucket.objects[aws.StringValue(in.Key)] = data
+				return &s3manager.UploadOutput{}, nil
+			}).AnyTimes()
+
+			config := &hivev1.BackupExportConfig{
+				S3: hivev1.BackupExportS3Config{
+					Bucket:               testBucket,
+					Region:               "us-east-1",
+					Prefix:               testPrefix,
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: "creds"},
+				},
+			}
+			if test.encryptSecrets {
+				config.SecretsEncryptionKeySecretRef = &corev1.LocalObjectReference{Name: testKeySecret}
+			}
+			r := &ReconcileBackupExport{
+				Client:    fakeClient,
+				apiReader: fakeClient,
+				scheme:    scheme.Scheme,
+				config:    config,
+				awsClientFn: func(_ client.Client, options awsclient.Options) (awsclient.Client, error) {
+					assert.Equal(t, "creds", options.CredentialsSource.Secret.Ref.Name, "unexpected credentials secret")
+					return mockAWSClient, nil
+				},
+			}
+
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: constants.HiveConfigName},
+			})
+			require.NoError(t, err, "unexpected error from reconcile")
+			assert.InDelta(t, test.expectRequeueAfter, result.RequeueAfter, float64(time.Minute), "unexpected requeue")
+
+			exports := bucket.exports()
+			assert.Len(t, exports, test.expectedExports, "unexpected number of exports")
+			_, otherKept := bucket.objects[path.Join(testPrefix, "other", "file")]
+			assert.True(t, otherKept, "unexpected deletion of non-export object")
+			if !test.expectExport {
+				return
+			}
+			latest := exports[len(exports)-1]
+			for _, e := range test.existingExports {
+				assert.NotEqual(t, e.Format(exportTimeFormat), latest, "expected a new export")
+			}
+
+			list := &corev1.List{}
+			require.NoError(t, json.Unmarshal(bucket.objects[path.Join(testPrefix, latest, resourcesKey)], list), "could not unmarshal resources")
+			var kinds []string
+			for _, item := range list.Items {
+				obj := &metav1.PartialObjectMetadata{}
+				require.NoError(t, json.Unmarshal(item.Raw, obj))
+				assert.Empty(t, obj.ResourceVersion, "unexpected resource version")
+				kinds = append(kinds, obj.Kind)
+			}
+			assert.ElementsMatch(t, []string{"ClusterDeployment", "ClusterImageSet"}, kinds, "unexpected exported resources")
+
+			encrypted, ok := bucket.objects[path.Join(testPrefix, latest, secretsKey)]
+			if !test.expectSecrets {
+				assert.False(t, ok, "unexpected secrets export")
+				return
+			}
+			require.True(t, ok, "missing secrets export")
+			secrets := &corev1.List{}
+			require.NoError(t, json.Unmarshal(decrypt(t, encrypted), secrets), "could not unmarshal secrets")
+			if assert.Len(t, secrets.Items, 1, "unexpected number of secrets") {
+				secret := &corev1.Secret{}
+				require.NoError(t, json.Unmarshal(secrets.Items[0].Raw, secret))
+				assert.Equal(t, "pull-secret", secret.Name, "unexpected secret")
+			}
+		})
+	}
+}
+
+func decrypt(t *testing.T, data []byte) []byte {
+	block, err := aes.NewCipher(testKey)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	require.NoError(t, err, "could not decrypt secrets")
+	return plaintext
+}
+
+// fakeBucket is an in-memory S3 bucket supporting the calls made by the controller.
+type fakeBucket struct {
+	s3iface.S3API
+	objects map[string][]byte
+}
+
+func (b *fakeBucket) ListObjectsV2PagesWithContext(_ aws.Context, in *s3.ListObjectsV2Input, fn func(*s3.ListObjectsV2Output, bool) bool, _ ...request.Option) error {
+	prefix := aws.StringValue(in.Prefix)
+	delimiter := aws.StringValue(in.Delimiter)
+	out := &s3.ListObjectsV2Output{}
+	commonPrefixes := map[string]bool{}
+	for key := range b.objects {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if delimiter != "" {
+			if i := strings.Index(key[len(prefix):], delimiter); i >= 0 {
+				p := key[:len(prefix)+i+1]
+				if !commonPrefixes[p] {
+					commonPrefixes[p] = true
+					out.CommonPrefixes = append(out.CommonPrefixes, &s3.CommonPrefix{Prefix: aws.String(p)})
+				}
+				continue
+			}
+		}
+		out.Contents = append(out.Contents, &s3.Object{Key: aws.String(key)})
+	}
+	fn(out, true)
+	return nil
+}
+
+func (b *fakeBucket) DeleteObjectWithContext(_ aws.Context, in *s3.DeleteObjectInput, _ ...request.Option) (*s3.DeleteObjectOutput, error) {
+	delete(b.objects, aws.StringValue(in.Key))
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+// exports returns the names of the export folders in the bucket, from the oldest to the newest.
+func (b *fakeBucket) exports() []string {
+	names := map[string]bool{}
+	for key := range b.objects {
+		parts := strings.Split(key, "/")
+		if _, err := time.Parse(exportTimeFormat, parts[1]); err == nil {
+			names[parts[1]] = true
+		}
+	}
+	var exports []string
+	for n := range names {
+		exports = append(exports, n)
+	}
+	sort.Strings(exports)
+	return exports
+}
@@ -0,0 +1,215 @@
+package clusteradoption
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	openshiftapiv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	ControllerName = hivev1.ClusterAdoptionControllerName
+
+	clusterVersionObjectName = "version"
+	infrastructureObjectName = "cluster"
+
+	secretNotFoundReason   = "SecretNotFound"
+	connectionFailedReason = "ConnectionFailed"
+	infraIDMismatchReason  = "InfraIDMismatch"
+	adoptedReason          = "Adopted"
+)
+
+// Add creates a new ClusterAdoption controller and adds it to the Manager with default RBAC. The Manager will set
+// fields on the controller and start it when the Manager is started.
+func Add(mgr manager.Manager) error {
+	logger := controllerutils.ControllerLogEntry(ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileClusterAdoption
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterAdoption {
+	r := &ReconcileClusterAdoption{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger: controllerutils.ControllerLogEntry(ControllerName),
+	}
+	r.remoteClusterAPIClientBuilder = func(secret *corev1.Secret) remoteclient.Builder {
+		return remoteclient.NewBuilderFromKubeconfig(r.Client, secret)
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileClusterAdoption, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controllerutils.NewController("clusteradoption-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		r.logger.WithError(err).Error("error watching cluster deployments")
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterAdoption{}
+
+// ReconcileClusterAdoption adopts existing clusters requested to be adopted by ClusterDeployments.
+type ReconcileClusterAdoption struct {
+	client.Client
+	logger log.FieldLogger
+
+	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server from the admin kubeconfig secret of the cluster
+	remoteClusterAPIClientBuilder func(secret *corev1.Secret) remoteclient.Builder
+}
+
+// Reconcile connects to the cluster requested to be adopted by a ClusterDeployment with its admin kubeconfig, verifies
+// that the cluster has the expected infra ID, and marks the ClusterDeployment installed with the metadata of the
+// cluster. The controllers acting on installed clusters take over from there.
+func (r *ReconcileClusterAdoption) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Debug("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	switch err := r.Get(context.TODO(), request.NamespacedName, cd); {
+	case apierrors.IsNotFound(err):
+		cdLog.Debug("cluster deployment not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		cdLog.WithError(err).Error("error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	if controllerutils.IsClusterDeploymentPaused(cd, cdLog) {
+		return reconcile.Result{}, nil
+	}
+	if cd.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+	adoption := cd.Spec.Adoption
+	if adoption == nil || cd.Spec.Installed {
+		cdLog.Debug("no adoption pending")
+		return reconcile.Result{}, nil
+	}
+	cdLog = cdLog.WithField("infraID", adoption.InfraID)
+
+	kubeconfigSecret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: adoption.AdminKubeconfigSecretRef.Name}, kubeconfigSecret); err != nil {
+		cdLog.WithError(err).Error("could not get admin kubeconfig secret")
+		return reconcile.Result{}, r.setAdoptionFailedCondition(cd, secretNotFoundReason, errors.Wrap(err, "could not get admin kubeconfig secret"), cdLog)
+	}
+	if ref := adoption.AdminPasswordSecretRef; ref != nil {
+		if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: ref.Name}, &corev1.Secret{}); err != nil {
+			cdLog.WithError(err).Error("could not get admin password secret")
+			return reconcile.Result{}, r.setAdoptionFailedCondition(cd, secretNotFoundReason, errors.Wrap(err, "could not get admin password secret"), cdLog)
+		}
+	}
+
+	clusterID, infraName, err := r.discoverCluster(kubeconfigSecret)
+	if err != nil {
+		cdLog.WithError(err).Error("could not connect to the cluster")
+		return reconcile.Result{}, r.setAdoptionFailedCondition(cd, connectionFailedReason, err, cdLog)
+	}
+	if infraName != adoption.InfraID {
+		// The cluster will not change its infra ID, so there is no point in retrying until the spec is fixed.
+		cdLog.WithField("infrastructureName", infraName).Error("infra ID does not match the cluster")
+		message := fmt.Sprintf("infra ID %s does not match the infrastructure name %s of the cluster", adoption.InfraID, infraName)
+		return reconcile.Result{}, r.setAdoptionCondition(cd, corev1.ConditionTrue, infraIDMismatchReason, message, cdLog)
+	}
+
+	cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+		ClusterID:                clusterID,
+		InfraID:                  adoption.InfraID,
+		AdminKubeconfigSecretRef: adoption.AdminKubeconfigSecretRef,
+	}
+	if adoption.AdminPasswordSecretRef != nil {
+		cd.Spec.ClusterMetadata.AdminPasswordSecretRef = *adoption.AdminPasswordSecretRef
+	}
+	cd.Spec.Installed = true
+	if err := r.Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not mark cluster deployment installed")
+		return reconcile.Result{}, err
+	}
+	cdLog.WithField("clusterID", clusterID).Info("cluster adopted")
+
+	return reconcile.Result{}, r.setAdoptionCondition(cd, corev1.ConditionFalse, adoptedReason, "cluster adopted", cdLog)
+}
+
+// discoverCluster connects to the cluster with the admin kubeconfig and returns the cluster ID and the infrastructure
+// name of the cluster.
+func (r *ReconcileClusterAdoption) discoverCluster(kubeconfigSecret *corev1.Secret) (string, string, error) {
+	remoteClient, err := r.remoteClusterAPIClientBuilder(kubeconfigSecret).Build()
+	if err != nil {
+		return "", "", errors.Wrap(err, "could not build client for the cluster")
+	}
+	clusterVersion := &openshiftapiv1.ClusterVersion{}
+	if err := remoteClient.Get(context.TODO(), types.NamespacedName{Name: clusterVersionObjectName}, clusterVersion); err != nil {
+		return "", "", errors.Wrap(err, "could not get the clusterversion of the cluster")
+	}
+	infrastructure := &openshiftapiv1.Infrastructure{}
+	if err := remoteClient.Get(context.TODO(), types.NamespacedName{Name: infrastructureObjectName}, infrastructure); err != nil {
+		return "", "", errors.Wrap(err, "could not get the infrastructure of the cluster")
+	}
+	return string(clusterVersion.Spec.ClusterID), infrastructure.Status.InfrastructureName, nil
+}
+
+// setAdoptionFailedCondition sets the AdoptionFailed condition to true for the error and returns the error.
+func (r *ReconcileClusterAdoption) setAdoptionFailedCondition(cd *hivev1.ClusterDeployment, reason string, err error, cdLog log.FieldLogger) error {
+	if updateErr := r.setAdoptionCondition(cd, corev1.ConditionTrue, reason, err.Error(), cdLog); updateErr != nil {
+		return updateErr
+	}
+	return err
+}
+
+func (r *ReconcileClusterAdoption) setAdoptionCondition(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason, message string, cdLog log.FieldLogger) error {
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.AdoptionFailedClusterDeploymentCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conditions
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not update AdoptionFailed condition")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,291 @@
+package clusteradoption
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	openshiftapiv1 "github.com/openshift/api/config/v1"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+)
+
+const (
+	testName             = "foo"
+	testNamespace        = "default"
+	testInfraID          = "foo-lqmsh"
+	testClusterID        = "4c3f1a5e-43b4-4a2c-9d0e-8e1f2a3b4c5d"
+	testKubeconfigSecret = "foo-admin-kubeconfig"
+	testPasswordSecret   = "foo-admin-password"
+)
+
+func testClusterDeployment(mods ...func(*hivev1.ClusterDeployment)) *hivev1.ClusterDeployment {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: testName,
+			BaseDomain:  "example.com",
+			Platform: hivev1.Platform{
+				AWS: &hivev1aws.Platform{
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: "aws-credentials"},
+					Region:               "us-east-1",
+				},
+			},
+			Adoption: &hivev1.ClusterAdoption{
+				InfraID:                  testInfraID,
+				AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: testKubeconfigSecret},
+			},
+		},
+	}
+	for _, mod := range mods {
+		mod(cd)
+	}
+	return cd
+}
+
+func testSecret(name string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+		},
+		Data: map[string][]byte{constants.KubeconfigSecretKey: []byte("kubeconfig")},
+	}
+}
+
+func testRemoteObjects(infraName string) []runtime.Object {
+	return []runtime.Object{
+		&openshiftapiv1.ClusterVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterVersionObjectName},
+			Spec:       openshiftapiv1.ClusterVersionSpec{ClusterID: testClusterID},
+		},
+		&openshiftapiv1.Infrastructure{
+			ObjectMeta: metav1.ObjectMeta{Name: infrastructureObjectName},
+			Status:     openshiftapiv1.InfrastructureStatus{InfrastructureName: infraName},
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	openshiftapiv1.Install(scheme.Scheme)
+
+	cases := []struct {
+		name              string
+		cd                *hivev1.ClusterDeployment
+		secrets           []string
+		remoteObjects     []runtime.Object
+		remoteBuildErr    error
+		expectRemoteCall  bool
+		expectErr         bool
+		expectInstalled   bool
+		expectedMetadata  *hivev1.ClusterMetadata
+		expectedCondition *hivev1.ClusterDeploymentCondition
+	}{
+		{
+			name: "no adoption",
+			cd: testClusterDeployment(func(cd *hivev1.ClusterDeployment) {
+				cd.Spec.Adoption = nil
+			}),
+		},
+		{
+			name: "already adopted",
+			cd: testClusterDeployment(func(cd *hivev1.ClusterDeployment) {
+				cd.Spec.Installed = true
+			}),
+			expectInstalled: true,
+		},
+		{
+			name: "paused",
+			cd: testClusterDeployment(func(cd *hivev1.ClusterDeployment) {
+				cd.Spec.Paused = true
+			}),
+			secrets: []string{testKubeconfigSecret},
+		},
+		{
+			name:      "kubeconfig secret not found",
+			cd:        testClusterDeployment(),
+			expectErr: true,
+			expectedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionTrue,
+				Reason: secretNotFoundReason,
+			},
+		},
+		{
+			name: "password secret not found",
+			cd: testClusterDeployment(func(cd *hivev1.ClusterDeployment) {
+				cd.Spec.Adoption.AdminPasswordSecretRef = &corev1.LocalObjectReference{Name: testPasswordSecret}
+			}),
+			secrets:   []string{testKubeconfigSecret},
+			expectErr: true,
+			expectedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionTrue,
+				Reason: secretNotFoundReason,
+			},
+		},
+		{
+			name:             "cluster unreachable",
+			cd:               testClusterDeployment(),
+			secrets:          []string{testKubeconfigSecret},
+			remoteBuildErr:   errors.New("connection refused"),
+			expectRemoteCall: true,
+			expectErr:        true,
+			expectedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionTrue,
+				Reason: connectionFailedReason,
+			},
+		},
+		{
+			name:             "not an OpenShift cluster",
+			cd:               testClusterDeployment(),
+			secrets:          []string{testKubeconfigSecret},
+			expectRemoteCall: true,
+			expectErr:        true,
+			expectedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionTrue,
+				Reason: connectionFailedReason,
+			},
+		},
+		{
+			name:             "infra ID mismatch",
+			cd:               testClusterDeployment(),
+			secrets:          []string{testKubeconfigSecret},
+			remoteObjects:    testRemoteObjects("bar-abcde"),
+			expectRemoteCall: true,
+			expectedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionTrue,
+				Reason: infraIDMismatchReason,
+			},
+		},
+		{
+			name:             "adopted",
+			cd:               testClusterDeployment(),
+			secrets:          []string{testKubeconfigSecret},
+			remoteObjects:    testRemoteObjects(testInfraID),
+			expectRemoteCall: true,
+			expectInstalled:  true,
+			expectedMetadata: &hivev1.ClusterMetadata{
+				ClusterID:                testClusterID,
+				InfraID:                  testInfraID,
+				AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: testKubeconfigSecret},
+			},
+			expectedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionFalse,
+				Reason: adoptedReason,
+			},
+		},
+		{
+			name: "adopted with admin password",
+			cd: testClusterDeployment(func(cd *hivev1.ClusterDeployment) {
+				cd.Spec.Adoption.AdminPasswordSecretRef = &corev1.LocalObjectReference{Name: testPasswordSecret}
+			}),
+			secrets:          []string{testKubeconfigSecret, testPasswordSecret},
+			remoteObjects:    testRemoteObjects(testInfraID),
+			expectRemoteCall: true,
+			expectInstalled:  true,
+			expectedMetadata: &hivev1.ClusterMetadata{
+				ClusterID:                testClusterID,
+				InfraID:                  testInfraID,
+				AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: testKubeconfigSecret},
+				AdminPasswordSecretRef:   corev1.LocalObjectReference{Name: testPasswordSecret},
+			},
+			expectedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionFalse,
+				Reason: adoptedReason,
+			},
+		},
+		{
+			name: "adopted after failure",
+			cd: testClusterDeployment(func(cd *hivev1.ClusterDeployment) {
+				cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{{
+					Type:   hivev1.AdoptionFailedClusterDeploymentCondition,
+					Status: corev1.ConditionTrue,
+					Reason: connectionFailedReason,
+				}}
+			}),
+			secrets:          []string{testKubeconfigSecret},
+			remoteObjects:    testRemoteObjects(testInfraID),
+			expectRemoteCall: true,
+			expectInstalled:  true,
+			expectedMetadata: &hivev1.ClusterMetadata{
+				ClusterID:                testClusterID,
+				InfraID:                  testInfraID,
+				AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: testKubeconfigSecret},
+			},
+			expectedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionFalse,
+				Reason: adoptedReason,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			existing := []runtime.Object{tc.cd}
+			for _, name := range tc.secrets {
+				existing = append(existing, testSecret(name))
+			}
+			fakeClient := fake.NewFakeClientWithScheme(scheme.Scheme, existing...)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			if tc.expectRemoteCall {
+				if tc.remoteBuildErr != nil {
+					mockRemoteClientBuilder.EXPECT().Build().Return(nil, tc.remoteBuildErr)
+				} else {
+					mockRemoteClientBuilder.EXPECT().Build().Return(fake.NewFakeClientWithScheme(scheme.Scheme, tc.remoteObjects...), nil)
+				}
+			}
+			r := &ReconcileClusterAdoption{
+				Client: fakeClient,
+				logger: log.WithField("controller", ControllerName),
+				remoteClusterAPIClientBuilder: func(secret *corev1.Secret) remoteclient.Builder {
+					assert.Equal(t, testKubeconfigSecret, secret.Name, "unexpected kubeconfig secret")
+					return mockRemoteClientBuilder
+				},
+			}
+
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}})
+			if tc.expectErr {
+				assert.Error(t, err, "expected error from reconcile")
+			} else {
+				assert.NoError(t, err, "unexpected error from reconcile")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, cd))
+			assert.Equal(t, tc.expectInstalled, cd.Spec.Installed, "unexpected installed")
+			assert.Equal(t, tc.expectedMetadata, cd.Spec.ClusterMetadata, "unexpected cluster metadata")
+			cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.AdoptionFailedClusterDeploymentCondition)
+			if tc.expectedCondition == nil {
+				assert.Nil(t, cond, "unexpected AdoptionFailed condition")
+				return
+			}
+			if assert.NotNil(t, cond, "missing AdoptionFailed condition") {
+				assert.Equal(t, tc.expectedCondition.Status, cond.Status, "unexpected condition status")
+				assert.Equal(t, tc.expectedCondition.Reason, cond.Reason, "unexpected condition reason")
+			}
+		})
+	}
+}
@@ -38,7 +38,7 @@ const (
 // Add creates a new ClusterClaim Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
 	if err != nil {
 		logger.WithError(err).Error("could not get controller configurations")
@@ -49,7 +49,7 @@ func Add(mgr manager.Manager) error {
 
 // NewReconciler returns a new ReconcileClusterClaim
 func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterClaim {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	return &ReconcileClusterClaim{
 		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
 		logger: logger,
@@ -59,7 +59,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *Re
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r *ReconcileClusterClaim, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
-	c, err := controller.New("clusterclaim-controller", mgr, controller.Options{
+	c, err := controllerutils.NewController("clusterclaim-controller", mgr, controller.Options{
 		Reconciler:              r,
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
@@ -395,6 +395,13 @@ func (r *ReconcileClusterClaim) reconcileForNewAssignment(claim *hivev1.ClusterC
 	logger.Info("cluster assigned to claim")
 	cd.Spec.ClusterPoolRef.ClaimName = claim.Name
 	cd.Spec.PowerState = hivev1.RunningClusterPowerState
+	if claim.Spec.PowerState != "" {
+		cd.Spec.PowerState = claim.Spec.PowerState
+	}
+	if cd.Annotations == nil {
+		cd.Annotations = map[string]string{}
+	}
+	cd.Annotations[constants.PowerStateInitiatorAnnotation] = constants.PowerStateInitiatorClusterClaim
 	if err := r.Update(context.Background(), cd); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not set claim for ClusterDeployment")
 		return reconcile.Result{}, err
@@ -432,6 +439,21 @@ func (r *ReconcileClusterClaim) reconcileForExistingAssignment(claim *hivev1.Clu
 			controllerutils.UpdateConditionIfReasonOrMessageChange,
 		)
 		statusChanged = statusChanged || changed
+	} else if requested := claim.Spec.PowerState; requested != "" && requested != hivev1.RunningClusterPowerState && cd.Spec.PowerState == requested {
+		log.WithField("powerState", requested).Debug("cluster is in the power state requested by the claim")
+		reason, message := "Hibernating", "Cluster is hibernating as requested by the claim"
+		if requested == hivev1.WorkersStoppedClusterPowerState {
+			reason, message = "WorkersStopped", "Workers of the cluster are stopped as requested by the claim"
+		}
+		conds, changed = controllerutils.SetClusterClaimConditionWithChangeCheck(
+			conds,
+			hivev1.ClusterRunningCondition,
+			corev1.ConditionFalse,
+			reason,
+			message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		statusChanged = statusChanged || changed
 	} else {
 		log.Debug("waiting for cluster to be running")
 		conds, changed = controllerutils.SetClusterClaimConditionWithChangeCheck(
@@ -402,6 +402,63 @@ func TestReconcileClusterClaim(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:  "new assignment keeps cluster hibernating when requested by claim",
+			claim: claimBuilder.Build(testclaim.WithCluster(clusterName), testclaim.WithPowerState(hivev1.HibernatingClusterPowerState)),
+			cd: cdBuilder.Build(
+				testcd.WithUnclaimedClusterPoolReference(claimNamespace, "test-pool"),
+				testcd.WithPowerState(hivev1.HibernatingClusterPowerState),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.ClusterHibernatingCondition,
+					Status: corev1.ConditionTrue,
+				}),
+			),
+			expectCompletedClaim: true,
+			expectRBAC:           true,
+			expectHibernating:    true,
+			expectedConditions: []hivev1.ClusterClaimCondition{
+				{
+					Type:    hivev1.ClusterClaimPendingCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "ClusterClaimed",
+					Message: "Cluster claimed",
+				},
+				{
+					Type:    hivev1.ClusterRunningCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Hibernating",
+					Message: "Cluster is hibernating as requested by the claim",
+				},
+			},
+		},
+		{
+			name:  "new assignment stops workers when requested by claim",
+			claim: claimBuilder.Build(testclaim.WithCluster(clusterName), testclaim.WithPowerState(hivev1.WorkersStoppedClusterPowerState)),
+			cd: cdBuilder.Build(
+				testcd.WithUnclaimedClusterPoolReference(claimNamespace, "test-pool"),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.ClusterHibernatingCondition,
+					Status: corev1.ConditionTrue,
+					Reason: hivev1.WorkersStoppedHibernationReason,
+				}),
+			),
+			expectCompletedClaim: true,
+			expectRBAC:           true,
+			expectedConditions: []hivev1.ClusterClaimCondition{
+				{
+					Type:    hivev1.ClusterClaimPendingCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "ClusterClaimed",
+					Message: "Cluster claimed",
+				},
+				{
+					Type:    hivev1.ClusterRunningCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "WorkersStopped",
+					Message: "Workers of the cluster are stopped as requested by the claim",
+				},
+			},
+		},
 		{
 			name:  "existing assignment does not change power state",
 			claim: claimBuilder.Build(testclaim.WithCluster(clusterName)),
@@ -37,10 +37,14 @@ import (
 	apihelpers "github.com/openshift/hive/apis/helpers"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/imageset"
+	"github.com/openshift/hive/pkg/preflight"
+	"github.com/openshift/hive/pkg/registryclient"
+	"github.com/openshift/hive/pkg/releasesignature"
 	"github.com/openshift/hive/pkg/remoteclient"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
 )
@@ -69,6 +73,7 @@ const (
 
 	installAttemptsLimitReachedReason = "InstallAttemptsLimitReached"
 	installOnlyOnceSetReason          = "InstallOnlyOnceSet"
+	failureNotRetryableReason         = "FailureNotRetryable"
 	provisionNotStoppedReason         = "ProvisionNotStopped"
 
 	deleteAfterAnnotation    = "hive.openshift.io/delete-after" // contains a duration after which the cluster should be cleaned up.
@@ -79,7 +84,7 @@ const (
 
 // Add creates a new ClusterDeployment controller and adds it to the manager with default RBAC.
 func Add(mgr manager.Manager) error {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
 	if err != nil {
 		logger.WithError(err).Error("could not get controller configurations")
@@ -97,11 +102,27 @@ func NewReconciler(mgr manager.Manager, logger log.FieldLogger, rateLimiter flow
 		expectations:                            controllerutils.NewExpectations(logger),
 		watchingClusterInstall:                  map[string]struct{}{},
 		validateCredentialsForClusterDeployment: controllerutils.ValidateCredentialsForClusterDeployment,
+		missingAWSPermissions:                   missingAWSPermissionsForClusterDeployment,
+		missingGCPPermissions:                   missingGCPPermissionsForClusterDeployment,
+		awsClientFn:                             awsclient.New,
+		hostFIPSEnabled:                         controllerutils.HostFIPSEnabled,
+		registryClientFn:                        registryclient.NewClient,
+		releaseSignatureVerifierFn:              releasesignature.NewVerifier,
 	}
 	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
 		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
 	}
 
+	if stores := os.Getenv(constants.ReleaseImageVerificationSignatureStoresEnvVar); stores != "" {
+		logger.Info("Release image verification enabled")
+		for _, store := range strings.Split(stores, ",") {
+			if store = strings.TrimSpace(store); store != "" {
+				r.releaseSignatureStores = append(r.releaseSignatureStores, store)
+			}
+		}
+		r.releaseSignatureKeysSecret = os.Getenv(constants.ReleaseImageVerificationKeysSecretEnvVar)
+	}
+
 	protectedDeleteEnvVar := os.Getenv(constants.ProtectedDeleteEnvVar)
 	if protectedDelete, err := strconv.ParseBool(protectedDeleteEnvVar); protectedDelete && err == nil {
 		logger.Info("Protected Delete enabled")
@@ -118,13 +139,13 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 		return errors.New("reconciler supplied is not a ReconcileClusterDeployment")
 	}
 
-	c, err := controller.New("clusterdeployment-controller", mgr, controller.Options{
+	c, err := controllerutils.NewController("clusterdeployment-controller", mgr, controller.Options{
 		Reconciler:              r,
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
 	if err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("could not create controller")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("could not create controller")
 		return err
 	}
 
@@ -133,14 +154,14 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 
 	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &hivev1.ClusterDeployment{},
 		clusterInstallIndexFieldName, indexClusterInstall); err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("Error indexing cluster deployment for cluster install")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error indexing cluster deployment for cluster install")
 		return err
 	}
 
 	// Watch for changes to ClusterDeployment
 	err = c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{})
 	if err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deployment")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error watching cluster deployment")
 		return err
 	}
 
@@ -155,14 +176,14 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 		OwnerType:    &hivev1.ClusterDeployment{},
 	})
 	if err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deployment job")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error watching cluster deployment job")
 		return err
 	}
 
 	// Watch for pods created by an install job
 	err = c.Watch(&source.Kind{Type: &corev1.Pod{}}, handler.EnqueueRequestsFromMapFunc(selectorPodWatchHandler))
 	if err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deployment pods")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error watching cluster deployment pods")
 		return err
 	}
 
@@ -172,7 +193,7 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 		OwnerType:    &hivev1.ClusterDeployment{},
 	})
 	if err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("Error watching deprovision request created by cluster deployment")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error watching deprovision request created by cluster deployment")
 		return err
 	}
 
@@ -182,10 +203,28 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 		OwnerType:    &hivev1.ClusterDeployment{},
 	})
 	if err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deployment dnszones")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error watching cluster deployment dnszones")
 		return err
 	}
 
+	// Watch for changes to the global pull secret in order to sync it to the installed clusters
+	if globalPullSecretName := os.Getenv(constants.GlobalPullSecret); globalPullSecretName != "" {
+		if err := c.Watch(
+			&source.Kind{Type: &corev1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(requestsForGlobalPullSecret(cdReconciler.Client, globalPullSecretName, cdReconciler.logger)),
+		); err != nil {
+			return errors.Wrap(err, "cannot start watch on the global pull secret")
+		}
+	}
+
+	// Watch for changes to the platform credentials secrets so that rotated credentials are validated promptly
+	if err := c.Watch(
+		&source.Kind{Type: &corev1.Secret{}},
+		handler.EnqueueRequestsFromMapFunc(requestsForCredentialsSecret(cdReconciler.Client, cdReconciler.logger)),
+	); err != nil {
+		return errors.Wrap(err, "cannot start watch on platform credentials secrets")
+	}
+
 	// Watch for changes to ClusterSyncs
 	if err := c.Watch(
 		&source.Kind{Type: &hiveintv1alpha1.ClusterSync{}},
@@ -225,6 +264,36 @@ type ReconcileClusterDeployment struct {
 	// that the platform creds are good (used for testing)
 	validateCredentialsForClusterDeployment func(client.Client, *hivev1.ClusterDeployment, log.FieldLogger) (bool, error)
 
+	// missingAWSPermissions is what this controller will call to find the permissions that the AWS platform creds
+	// are missing for a set of operations (used for testing)
+	missingAWSPermissions func(context.Context, client.Client, *hivev1.ClusterDeployment, []preflight.Operation) ([]string, error)
+
+	// missingGCPPermissions is what this controller will call to find the permissions that the GCP platform creds
+	// are missing for a set of operations (used for testing)
+	missingGCPPermissions func(context.Context, client.Client, *hivev1.ClusterDeployment, []preflight.Operation) ([]string, error)
+
+	// awsClientFn is what this controller will call to build AWS clients (used for testing)
+	awsClientFn func(client.Client, awsclient.Options) (awsclient.Client, error)
+
+	// hostFIPSEnabled is what this controller will call to find whether the hub is running in FIPS mode (used for
+	// testing)
+	hostFIPSEnabled func() (bool, error)
+
+	// releaseSignatureStores are the signature stores of release images. Release images are verified before
+	// provisioning only when set.
+	releaseSignatureStores []string
+
+	// releaseSignatureKeysSecret is the name of the secret in the hive namespace holding the public keys trusted to
+	// sign release images.
+	releaseSignatureKeysSecret string
+
+	// registryClientFn is what this controller will call to build registry clients (used for testing)
+	registryClientFn func(pullSecret string) (registryclient.Client, error)
+
+	// releaseSignatureVerifierFn is what this controller will call to build release signature verifiers (used for
+	// testing)
+	releaseSignatureVerifierFn func(stores []string, publicKeys [][]byte) (releasesignature.Verifier, error)
+
 	protectedDelete bool
 }
 
@@ -236,6 +305,12 @@ func (r *ReconcileClusterDeployment) Reconcile(ctx context.Context, request reco
 	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
 	defer recobsrv.ObserveControllerReconcileTime()
 
+	// The requests made to the hub and the cloud during the reconcile are traced as part of its span.
+	ctx = recobsrv.StartSpan(ctx, request.NamespacedName)
+	traced := *r
+	traced.Client = controllerutils.TraceClient(ctx, r.Client)
+	r = &traced
+
 	// Fetch the ClusterDeployment instance
 	cd := &hivev1.ClusterDeployment{}
 	err := r.Get(context.TODO(), request.NamespacedName, cd)
@@ -245,6 +320,7 @@ func (r *ReconcileClusterDeployment) Reconcile(ctx context.Context, request reco
 			// For additional cleanup logic use finalizers.
 			cdLog.Info("cluster deployment Not Found")
 			r.expectations.DeleteExpectations(request.NamespacedName.String())
+			remoteclient.CloseSSHBastionConnection(request.Namespace, request.Name)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -259,7 +335,7 @@ func (r *ReconcileClusterDeployment) Reconcile(ctx context.Context, request reco
 		return reconcile.Result{}, err
 	}
 
-	return r.reconcile(request, cd, cdLog)
+	return r.reconcile(ctx, request, cd, cdLog)
 }
 
 func (r *ReconcileClusterDeployment) SetWatcher(w controllerutils.Watcher) {
@@ -370,7 +446,7 @@ func (r *ReconcileClusterDeployment) addAdditionalKubeconfigCAs(cd *hivev1.Clust
 	return nil
 }
 
-func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (result reconcile.Result, returnErr error) {
+func (r *ReconcileClusterDeployment) reconcile(ctx context.Context, request reconcile.Request, cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (result reconcile.Result, returnErr error) {
 	// Set platform label on the ClusterDeployment
 	if platform := getClusterPlatform(cd); cd.Labels[hivev1.HiveClusterPlatformLabel] != platform {
 		if cd.Labels == nil {
@@ -407,6 +483,14 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 		return reconcile.Result{}, err
 	}
 
+	if err := r.setPausedCondition(cd, cdLog); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not update Paused condition")
+		return reconcile.Result{}, err
+	}
+	if controllerutils.IsClusterDeploymentPaused(cd, cdLog) {
+		return reconcile.Result{}, nil
+	}
+
 	if cd.DeletionTimestamp != nil {
 		if !controllerutils.HasFinalizer(cd, hivev1.FinalizerDeprovision) {
 			// Make sure we have no deprovision underway metric even though this was probably cleared when we
@@ -423,7 +507,7 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 			hivemetrics.GetClusterDeploymentType(cd)).Set(
 			time.Since(cd.DeletionTimestamp.Time).Seconds())
 
-		return r.syncDeletedClusterDeployment(cd, cdLog)
+		return r.syncDeletedClusterDeployment(ctx, cd, cdLog)
 	}
 
 	// Check for the delete-after annotation, and if the cluster has expired, delete it
@@ -487,6 +571,14 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 			return reconcile.Result{}, err
 		}
 
+		// Adopted clusters are never provisioned, so their managed DNS zone is ensured here. The records of the
+		// cluster are maintained in the zone by the clusterdnsrecords controller.
+		if cd.Spec.ManageDNS && controllerutils.IsClusterAdopted(cd) {
+			if _, err := r.ensureManagedDNSZone(cd, cdLog); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+
 		switch {
 		case cd.Spec.Provisioning != nil:
 			if r, err := r.reconcileInstalledClusterProvision(cd, cdLog); err != nil {
@@ -515,10 +607,20 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 				return r.setClusterStatusURLs(cd, cdLog)
 			}
 
+			if !controllerutils.IsFakeCluster(cd) {
+				return r.reconcileInstalledPullSecret(cd, cdLog)
+			}
 		}
 		return reconcile.Result{}, nil
 	}
 
+	// Clusters requested to be adopted are never provisioned. They are marked installed by the clusteradoption
+	// controller once it has verified the cluster.
+	if cd.Spec.Adoption != nil {
+		cdLog.Debug("waiting for the cluster to be adopted")
+		return reconcile.Result{}, nil
+	}
+
 	// If the ClusterDeployment is being relocated to another Hive instance, stop any current provisioning and do not
 	// do any more reconciling.
 	switch _, relocateStatus, err := controllerutils.IsRelocating(cd); {
@@ -579,6 +681,17 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 		return reconcile.Result{Requeue: true}, nil
 	}
 
+	// The release image is verified before the imageset job runs, so that the job and the install pods use the
+	// verified release image pinned to its digest.
+	switch verifiedImage, result, err := r.checkReleaseImageForProvision(cd, releaseImage, cdLog); {
+	case err != nil:
+		return reconcile.Result{}, err
+	case result != nil:
+		return *result, nil
+	default:
+		releaseImage = verifiedImage
+	}
+
 	switch result, err := r.resolveInstallerImage(cd, releaseImage, cdLog); {
 	case err != nil:
 		return reconcile.Result{}, err
@@ -615,7 +728,7 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 
 	switch {
 	case cd.Spec.Provisioning != nil:
-		return r.reconcileInstallingClusterProvision(cd, releaseImage, cdLog)
+		return r.reconcileInstallingClusterProvision(ctx, cd, releaseImage, cdLog)
 	case cd.Spec.ClusterInstallRef != nil:
 		return r.reconcileInstallingClusterInstall(cd, cdLog)
 	default:
@@ -623,11 +736,40 @@ func (r *ReconcileClusterDeployment) reconcile(request reconcile.Request, cd *hi
 	}
 }
 
-func (r *ReconcileClusterDeployment) reconcileInstallingClusterProvision(cd *hivev1.ClusterDeployment, releaseImage string, logger log.FieldLogger) (reconcile.Result, error) {
+func (r *ReconcileClusterDeployment) reconcileInstallingClusterProvision(ctx context.Context, cd *hivev1.ClusterDeployment, releaseImage string, logger log.FieldLogger) (reconcile.Result, error) {
 	if cd.Status.ProvisionRef == nil {
+		switch result, err := r.checkPermissionsForProvision(ctx, cd, logger); {
+		case err != nil:
+			return reconcile.Result{}, err
+		case result != nil:
+			return *result, nil
+		}
+		switch result, err := r.checkFIPSForProvision(cd, logger); {
+		case err != nil:
+			return reconcile.Result{}, err
+		case result != nil:
+			return *result, nil
+		}
 		return r.startNewProvision(cd, releaseImage, logger)
 	}
-	return r.reconcileExistingProvision(cd, logger)
+	sharedVPCRequeueAfter, err := r.reconcileSharedVPC(ctx, cd, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	// The install pod keeps loading the credentials minted by the credentials broker until the install completes.
+	credentialsRequeueAfter, err := r.refreshAWSCredentialsSourceSecret(cd)
+	if err != nil {
+		logger.WithError(err).Error("could not refresh the credentials minted by the credentials broker")
+		return reconcile.Result{}, err
+	}
+	result, err := r.reconcileExistingProvision(cd, logger)
+	for _, requeueAfter := range []time.Duration{sharedVPCRequeueAfter, credentialsRequeueAfter} {
+		if err == nil && requeueAfter > 0 && !result.Requeue &&
+			(result.RequeueAfter == 0 || requeueAfter < result.RequeueAfter) {
+			result.RequeueAfter = requeueAfter
+		}
+	}
+	return result, err
 }
 
 func (r *ReconcileClusterDeployment) reconcileInstalledClusterProvision(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
@@ -768,6 +910,14 @@ func (r *ReconcileClusterDeployment) resolveInstallerImage(cd *hivev1.ClusterDep
 			os.Getenv("HTTP_PROXY"),
 			os.Getenv("HTTPS_PROXY"),
 			os.Getenv("NO_PROXY"))
+		if err := controllerutils.ApplyWorkloadSchedulingFromFile(&job.Spec.Template.Spec); err != nil {
+			cdLog.WithError(err).Error("error applying workload scheduling to job")
+			return nil, err
+		}
+		if err := controllerutils.ApplyJobProxyFromFiles(r, cd.Namespace, &job.Spec.Template.Spec, cdLog); err != nil {
+			cdLog.WithError(err).Error("error applying job proxy to job")
+			return nil, err
+		}
 
 		cdLog.WithField("derivedObject", job.Name).Debug("Setting labels on derived object")
 		job.Labels = k8slabels.AddLabel(job.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
@@ -879,6 +1029,26 @@ func (r *ReconcileClusterDeployment) setDNSNotReadyCondition(cd *hivev1.ClusterD
 	return r.Status().Update(context.TODO(), cd)
 }
 
+func (r *ReconcileClusterDeployment) setPausedCondition(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	status, reason, message := corev1.ConditionFalse, hivev1.NotPausedReason, "Reconciling the cluster deployment is not paused"
+	if cd.Spec.Paused {
+		status, reason, message = corev1.ConditionTrue, hivev1.PausedReason, "Reconciling the cluster deployment is paused by spec.paused"
+	}
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.PausedClusterDeploymentCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conditions
+	cdLog.Debugf("setting PausedCondition to %v", status)
+	return r.Status().Update(context.TODO(), cd)
+}
+
 func (r *ReconcileClusterDeployment) setAuthenticationFailure(cd *hivev1.ClusterDeployment, authSuccessful bool, cdLog log.FieldLogger) (bool, error) {
 
 	var status corev1.ConditionStatus
@@ -1136,7 +1306,7 @@ func (r *ReconcileClusterDeployment) ensureClusterDeprovisioned(cd *hivev1.Clust
 	return true, nil
 }
 
-func (r *ReconcileClusterDeployment) syncDeletedClusterDeployment(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (reconcile.Result, error) {
+func (r *ReconcileClusterDeployment) syncDeletedClusterDeployment(ctx context.Context, cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (reconcile.Result, error) {
 	switch _, relocateStatus, err := controllerutils.IsRelocating(cd); {
 	case err != nil:
 		cdLog.WithError(err).Error("could not determine relocate status")
@@ -1171,6 +1341,13 @@ func (r *ReconcileClusterDeployment) syncDeletedClusterDeployment(cd *hivev1.Clu
 		return reconcile.Result{}, err
 	}
 
+	// The shared VPC is cleaned up before the deprovision as the installer cannot do so, and the private hosted zone
+	// of the cluster cannot be deleted while it is associated with a VPC of another account.
+	if err := r.cleanupSharedVPC(ctx, cd, cdLog); err != nil {
+		cdLog.WithError(err).Error("failed to clean up the shared VPC")
+		return reconcile.Result{}, err
+	}
+
 	deprovisioned, err := r.ensureClusterDeprovisioned(cd, cdLog)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -1348,13 +1525,17 @@ func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDepl
 		for k, v := range cd.Spec.Platform.AWS.UserTags {
 			additionalTags = append(additionalTags, hivev1.AWSResourceTag{Key: k, Value: v})
 		}
+		// The zone is managed in the partition of the cluster, such as AWS China or GovCloud, and the default region
+		// is left unset.
 		region := ""
-		if strings.HasPrefix(cd.Spec.Platform.AWS.Region, constants.AWSChinaRegionPrefix) {
-			region = constants.AWSChinaRoute53Region
+		if r := awsclient.Route53Region(cd.Spec.Platform.AWS.Region); r != constants.AWSRoute53Region {
+			region = r
 		}
 		dnsZone.Spec.AWS = &hivev1.AWSDNSZoneSpec{
 			CredentialsSecretRef:  cd.Spec.Platform.AWS.CredentialsSecretRef,
 			CredentialsAssumeRole: cd.Spec.Platform.AWS.CredentialsAssumeRole,
+			CredentialsSource:     cd.Spec.Platform.AWS.CredentialsSource,
+			ServiceEndpoints:      cd.Spec.Platform.AWS.ServiceEndpoints,
 			AdditionalTags:        additionalTags,
 			Region:                region,
 		}
@@ -1479,15 +1660,19 @@ func generateDeprovision(cd *hivev1.ClusterDeployment) (*hivev1.ClusterDeprovisi
 			Region:                cd.Spec.Platform.AWS.Region,
 			CredentialsSecretRef:  &cd.Spec.Platform.AWS.CredentialsSecretRef,
 			CredentialsAssumeRole: cd.Spec.Platform.AWS.CredentialsAssumeRole,
+			CredentialsSource:     cd.Spec.Platform.AWS.CredentialsSource,
+			ServiceEndpoints:      cd.Spec.Platform.AWS.ServiceEndpoints,
 		}
 	case cd.Spec.Platform.Azure != nil:
 		req.Spec.Platform.Azure = &hivev1.AzureClusterDeprovision{
 			CredentialsSecretRef: &cd.Spec.Platform.Azure.CredentialsSecretRef,
+			ResourceGroupName:    cd.Spec.Platform.Azure.ResourceGroupName,
 		}
 	case cd.Spec.Platform.GCP != nil:
 		req.Spec.Platform.GCP = &hivev1.GCPClusterDeprovision{
 			Region:               cd.Spec.Platform.GCP.Region,
 			CredentialsSecretRef: &cd.Spec.Platform.GCP.CredentialsSecretRef,
+			NetworkProjectID:     cd.Spec.Platform.GCP.NetworkProjectID,
 		}
 	case cd.Spec.Platform.OpenStack != nil:
 		req.Spec.Platform.OpenStack = &hivev1.OpenStackClusterDeprovision{
@@ -1507,6 +1692,18 @@ func generateDeprovision(cd *hivev1.ClusterDeployment) (*hivev1.ClusterDeprovisi
 			CertificatesSecretRef: cd.Spec.Platform.Ovirt.CertificatesSecretRef,
 			ClusterID:             cd.Spec.Platform.Ovirt.ClusterID,
 		}
+	case cd.Spec.Platform.Nutanix != nil:
+		req.Spec.Platform.Nutanix = &hivev1.NutanixClusterDeprovision{
+			PrismCentral:          cd.Spec.Platform.Nutanix.PrismCentral,
+			CredentialsSecretRef:  cd.Spec.Platform.Nutanix.CredentialsSecretRef,
+			CertificatesSecretRef: cd.Spec.Platform.Nutanix.CertificatesSecretRef,
+		}
+	case cd.Spec.Platform.PowerVS != nil:
+		req.Spec.Platform.PowerVS = &hivev1.PowerVSClusterDeprovision{
+			Region:               cd.Spec.Platform.PowerVS.Region,
+			ServiceInstanceID:    cd.Spec.Platform.PowerVS.ServiceInstanceID,
+			CredentialsSecretRef: cd.Spec.Platform.PowerVS.CredentialsSecretRef,
+		}
 	default:
 		return nil, errors.New("unsupported cloud provider for deprovision")
 	}
@@ -1714,6 +1911,7 @@ func (r *ReconcileClusterDeployment) adoptProvision(cd *hivev1.ClusterDeployment
 		n := metav1.Now()
 		cd.Status.InstallStartedTimestamp = &n
 	}
+	cd.Status.ManualCredentialsMode = manualCredentialsMode(cd)
 	if err := r.Status().Update(context.TODO(), cd); err != nil {
 		pLog.WithError(err).Log(controllerutils.LogLevel(err), "could not adopt provision")
 		return err
@@ -1722,6 +1920,24 @@ func (r *ReconcileClusterDeployment) adoptProvision(cd *hivev1.ClusterDeployment
 	return nil
 }
 
+// manualCredentialsMode returns the source of the cloud credentials of a cluster provisioned with manual credentials.
+func manualCredentialsMode(cd *hivev1.ClusterDeployment) hivev1.ManualCredentialsMode {
+	if cd.Spec.Provisioning == nil || cd.Spec.Provisioning.ManualCredentials == nil {
+		return ""
+	}
+	mc := cd.Spec.Provisioning.ManualCredentials
+	switch {
+	case mc.AWSSTS != nil:
+		return hivev1.ManualCredentialsModeAWSSTS
+	case mc.GCPWorkloadIdentity != nil:
+		return hivev1.ManualCredentialsModeGCPWorkloadIdentity
+	case mc.AzureWorkloadIdentity != nil:
+		return hivev1.ManualCredentialsModeAzureWorkloadIdentity
+	default:
+		return hivev1.ManualCredentialsModeManifests
+	}
+}
+
 func (r *ReconcileClusterDeployment) deleteStaleProvisions(provs []*hivev1.ClusterProvision, cdLog log.FieldLogger) {
 	// Cap the number of existing provisions. Always keep the earliest provision as
 	// it is used to determine the total time that it took to install. Take off
@@ -1760,6 +1976,30 @@ func (r *ReconcileClusterDeployment) validatePlatformCreds(cd *hivev1.ClusterDep
 	return r.validateCredentialsForClusterDeployment(r.Client, cd, logger)
 }
 
+// requestsForCredentialsSecret returns a map function that enqueues the ClusterDeployments using a secret for their
+// platform credentials. The platform credentials are validated on every reconcile, so this clears a stale
+// AuthenticationFailure condition as soon as the credentials are rotated, rather than when the ClusterDeployment
+// comes out of backoff.
+func requestsForCredentialsSecret(c client.Client, logger log.FieldLogger) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		cdList := &hivev1.ClusterDeploymentList{}
+		if err := c.List(context.Background(), cdList, client.InNamespace(o.GetNamespace())); err != nil {
+			logger.WithError(err).Error("failed to list cluster deployments for credentials secret")
+			return nil
+		}
+		var requests []reconcile.Request
+		for i := range cdList.Items {
+			if controllerutils.CredentialsSecretName(&cdList.Items[i]) != o.GetName() {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: o.GetNamespace(), Name: cdList.Items[i].Name},
+			})
+		}
+		return requests
+	}
+}
+
 // checkForFailedSync returns true if it finds that the ClusterSync has the Failed condition set
 func checkForFailedSync(clusterSync *hiveintv1alpha1.ClusterSync) bool {
 	for _, cond := range clusterSync.Status.Conditions {
@@ -1876,6 +2116,10 @@ func getClusterPlatform(cd *hivev1.ClusterDeployment) string {
 		return constants.PlatformOpenStack
 	case cd.Spec.Platform.VSphere != nil:
 		return constants.PlatformVSphere
+	case cd.Spec.Platform.Nutanix != nil:
+		return constants.PlatformNutanix
+	case cd.Spec.Platform.PowerVS != nil:
+		return constants.PlatformPowerVS
 	case cd.Spec.Platform.BareMetal != nil:
 		return constants.PlatformBaremetal
 	case cd.Spec.Platform.AgentBareMetal != nil:
@@ -2,6 +2,7 @@ package clusterdeployment
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -37,9 +38,11 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	"github.com/openshift/hive/apis/hive/v1/baremetal"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
 	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/preflight"
 	"github.com/openshift/hive/pkg/remoteclient"
 	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
 	testclusterdeployment "github.com/openshift/hive/pkg/test/clusterdeployment"
@@ -133,6 +136,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 		expectConsoleRouteFetch       bool
 		validate                      func(client.Client, *testing.T)
 		reconcilerSetup               func(*ReconcileClusterDeployment)
+		envVars                       map[string]string
 		platformCredentialsValidation func(client.Client, *hivev1.ClusterDeployment, log.FieldLogger) (bool, error)
 	}{
 		{
@@ -161,6 +165,148 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				assert.Len(t, provisions, 1, "expected provision to exist")
 			},
 		},
+		{
+			name: "Provision not created when paused",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeploymentWithDefaultConditions(testClusterDeployment())
+					cd.Spec.Paused = true
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no provision")
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.PausedClusterDeploymentCondition)
+					if assert.NotNil(t, cond, "missing Paused condition") {
+						assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected Paused condition status")
+						assert.Equal(t, hivev1.PausedReason, cond.Reason, "unexpected Paused condition reason")
+					}
+				}
+			},
+		},
+		{
+			name: "Deprovision not created when paused",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeploymentWithProvision()
+					now := metav1.Now()
+					cd.DeletionTimestamp = &now
+					cd.Spec.Paused = true
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.Contains(t, cd.Finalizers, hivev1.FinalizerDeprovision, "expected hive finalizer")
+				}
+				assert.Nil(t, getDeprovision(c), "expected no deprovision request")
+			},
+		},
+		{
+			name: "Provision not created for cluster to adopt",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeploymentWithDefaultConditions(testClusterDeployment())
+					cd.Spec.Provisioning = nil
+					cd.Spec.Adoption = &hivev1.ClusterAdoption{
+						InfraID:                  "test-infra-id",
+						AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: adminKubeconfigSecret},
+					}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.Empty(t, getProvisions(c), "expected no provision")
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.False(t, cd.Spec.Installed, "expected cluster to not be installed")
+				}
+			},
+		},
+		{
+			name: "Provision not created when permissions are missing",
+			existing: []runtime.Object{
+				testClusterDeploymentWithDefaultConditions(testClusterDeployment()),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.missingAWSPermissions = func(context.Context, client.Client, *hivev1.ClusterDeployment, []preflight.Operation) ([]string, error) {
+					return []string{"ec2:RunInstances", "s3:CreateBucket"}, nil
+				}
+			},
+			expectedRequeueAfter: permissionsPreflightRequeueAt,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				assert.Empty(t, provisions, "expected provision to not exist")
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.InsufficientPermissionsClusterDeploymentCondition)
+				if assert.NotNil(t, cond, "expected InsufficientPermissions condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, permissionsMissingReason, cond.Reason, "unexpected condition reason")
+					assert.Equal(t, "credentials are missing 2 required permission(s): ec2:RunInstances, s3:CreateBucket", cond.Message, "unexpected condition message")
+				}
+			},
+		},
+		{
+			name: "Provision not created when permissions in the Shared VPC host project are missing",
+			existing: []runtime.Object{
+				testClusterDeploymentWithDefaultConditions(testGCPSharedVPCClusterDeployment()),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.missingGCPPermissions = func(_ context.Context, _ client.Client, cd *hivev1.ClusterDeployment, operations []preflight.Operation) ([]string, error) {
+					assert.Equal(t, []preflight.Operation{preflight.OperationSharedVPCNetwork}, operations, "unexpected operations")
+					return []string{"compute.subnetworks.use"}, nil
+				}
+			},
+			expectedRequeueAfter: permissionsPreflightRequeueAt,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				assert.Empty(t, provisions, "expected provision to not exist")
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.InsufficientPermissionsClusterDeploymentCondition)
+				if assert.NotNil(t, cond, "expected InsufficientPermissions condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, "credentials are missing 1 required permission(s): compute.subnetworks.use", cond.Message, "unexpected condition message")
+				}
+			},
+		},
+		{
+			name: "Provision created when permissions check fails",
+			existing: []runtime.Object{
+				testClusterDeploymentWithDefaultConditions(testClusterDeployment()),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.missingAWSPermissions = func(context.Context, client.Client, *hivev1.ClusterDeployment, []preflight.Operation) ([]string, error) {
+					return nil, errors.New("AccessDenied")
+				}
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				assert.Len(t, provisions, 1, "expected provision to exist")
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.InsufficientPermissionsClusterDeploymentCondition)
+				if assert.NotNil(t, cond, "expected InsufficientPermissions condition") {
+					assert.Equal(t, corev1.ConditionUnknown, cond.Status, "unexpected condition status")
+					assert.Equal(t, permissionsCheckFailedReason, cond.Reason, "unexpected condition reason")
+				}
+			},
+		},
 		{
 			name: "Provision not created when pending create",
 			existing: []runtime.Object{
@@ -315,6 +461,40 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Completed provision waiting for private service connect",
+			existing: []runtime.Object{
+				testPrivateServiceConnectClusterDeployment(corev1.ConditionFalse),
+				testSuccessfulProvision(),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.False(t, cd.Spec.Installed, "expected cluster to not be installed")
+				}
+			},
+		},
+		{
+			name: "Completed provision with private service connect ready",
+			existing: []runtime.Object{
+				testPrivateServiceConnectClusterDeployment(corev1.ConditionTrue),
+				testSuccessfulProvision(),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.True(t, cd.Spec.Installed, "expected cluster to be installed")
+				}
+			},
+		},
 		{
 			name: "clusterdeployment must specify pull secret when there is no global pull secret ",
 			existing: []runtime.Object{
@@ -620,6 +800,59 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Ensure verified release image is used to generate imageset job",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Status.InstallerImage = nil
+					cd.Spec.Provisioning.ReleaseImage = "embedded-release-image:latest"
+					cd.Status.VerifiedReleaseImage = "embedded-release-image@sha256:abc"
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.releaseSignatureStores = []string{"https://signatures.example.com"}
+			},
+			validate: func(c client.Client, t *testing.T) {
+				job := getImageSetJob(c)
+				require.NotNil(t, job, "did not find expected imageset job")
+				assert.Equal(t, "embedded-release-image@sha256:abc", job.Spec.Template.Spec.InitContainers[0].Image, "unexpected release image used in job")
+			},
+		},
+		{
+			name: "Ensure verified release image is used as override image in install job",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeploymentWithDefaultConditions(testClusterDeployment())
+					cd.Status.InstallerImage = pointer.StringPtr("test-installer-image:latest")
+					cd.Spec.Provisioning.ReleaseImage = "embedded-release-image:latest"
+					cd.Status.VerifiedReleaseImage = "embedded-release-image@sha256:abc"
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.releaseSignatureStores = []string{"https://signatures.example.com"}
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				if assert.Len(t, provisions, 1, "expected provision to exist") {
+					found := false
+					for _, e := range provisions[0].Spec.PodSpec.Containers[0].Env {
+						if e.Name == "OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE" {
+							found = true
+							assert.Equal(t, "embedded-release-image@sha256:abc", e.Value, "unexpected override image in install job")
+						}
+					}
+					assert.True(t, found, "did not find expected override environment variable in job")
+				}
+			},
+		},
 		{
 			name: "Create DNSZone when manageDNS is true",
 			existing: []runtime.Object{
@@ -638,6 +871,44 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				assert.Equal(t, constants.DNSZoneTypeChild, zone.Labels[constants.DNSZoneTypeLabel], "incorrect dnszone type label")
 			},
 		},
+		{
+			name: "Create DNSZone in the partition of a GovCloud cluster",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.ManageDNS = true
+					cd.Spec.Platform.AWS.Region = "us-gov-east-1"
+					cd.Labels[hivev1.HiveClusterRegionLabel] = "us-gov-east-1"
+					cd.Spec.Platform.AWS.ServiceEndpoints = []hivev1aws.ServiceEndpoint{{Name: "route53", URL: "https://route53.us-gov.amazonaws.com"}}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				zone := getDNSZone(c)
+				require.NotNil(t, zone, "dns zone should exist")
+				assert.Equal(t, constants.AWSGovCloudRoute53Region, zone.Spec.AWS.Region, "unexpected dnszone region")
+				assert.Equal(t, []hivev1aws.ServiceEndpoint{{Name: "route53", URL: "https://route53.us-gov.amazonaws.com"}}, zone.Spec.AWS.ServiceEndpoints, "unexpected dnszone service endpoints")
+			},
+		},
+		{
+			name: "Create DNSZone for adopted cluster when manageDNS is true",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testInstalledClusterDeployment(time.Now())
+					cd.Spec.ManageDNS = true
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeOpaque, adminPasswordSecret, "password", adminPassword),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				zone := getDNSZone(c)
+				require.NotNil(t, zone, "dns zone should exist")
+				assert.Equal(t, constants.DNSZoneTypeChild, zone.Labels[constants.DNSZoneTypeLabel], "incorrect dnszone type label")
+			},
+		},
 		{
 			name: "Wait when DNSZone is not available yet",
 			existing: []runtime.Object{
@@ -903,6 +1174,28 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Adopt provision with manual credentials",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.Provisioning.ManualCredentials = &hivev1.ManualCredentials{
+						GCPWorkloadIdentity: &hivev1.ManualCredentialsGCPWorkloadIdentity{},
+					}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				testProvision(),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing cluster deployment") {
+					assert.NotNil(t, cd.Status.ProvisionRef, "provision reference not set")
+					assert.Equal(t, hivev1.ManualCredentialsModeGCPWorkloadIdentity, cd.Status.ManualCredentialsMode, "unexpected manual credentials mode")
+				}
+			},
+		},
 		{
 			name: "Do not adopt failed provision",
 			existing: []runtime.Object{
@@ -986,6 +1279,70 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Clear out provision that failed for a reason that is not retried",
+			existing: []runtime.Object{
+				testClusterDeploymentWithProvision(),
+				func() runtime.Object {
+					provision := testFailedProvisionTime(time.Now())
+					provision.Status.Conditions[0].Reason = "ProxyError"
+					return provision
+				}(),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			envVars: map[string]string{constants.FailedProvisionRetryReasonsEnvVar: "KubeAPIWaitTimeout"},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.Nil(t, cd.Status.ProvisionRef, "expected empty provision ref")
+					assertConditionReason(t, cd, hivev1.ProvisionFailedCondition, "ProxyError")
+				}
+			},
+		},
+		{
+			name: "Stop provisioning after failure that is not retried",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeployment()
+					cd.Status.InstallRestarts = 1
+					cd.Status.Conditions = addOrUpdateClusterDeploymentCondition(*cd, hivev1.ProvisionFailedCondition,
+						corev1.ConditionTrue, "ProxyError", "test-message")
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			envVars: map[string]string{constants.FailedProvisionRetryReasonsEnvVar: "KubeAPIWaitTimeout"},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "could not get ClusterDeployment")
+				assertConditionStatus(t, cd, hivev1.ProvisionStoppedCondition, corev1.ConditionTrue)
+				assertConditionReason(t, cd, hivev1.ProvisionStoppedCondition, failureNotRetryableReason)
+			},
+		},
+		{
+			name: "Continue provisioning after failure that is retried",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeployment()
+					cd.Status.InstallRestarts = 1
+					cd.Status.Conditions = addOrUpdateClusterDeploymentCondition(*cd, hivev1.ProvisionFailedCondition,
+						corev1.ConditionTrue, "KubeAPIWaitTimeout", "test-message")
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			envVars: map[string]string{constants.FailedProvisionRetryReasonsEnvVar: "ProxyError, KubeAPIWaitTimeout"},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "could not get ClusterDeployment")
+				assertConditionStatus(t, cd, hivev1.ProvisionStoppedCondition, corev1.ConditionFalse)
+			},
+		},
 		{
 			name: "Delete outstanding provision on delete",
 			existing: []runtime.Object{
@@ -1236,7 +1593,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 			expectErr: true,
 			validate: func(c client.Client, t *testing.T) {
 				cd := getCD(c)
-				require.Equal(t, 2, len(cd.Status.Conditions))
+				require.Equal(t, 3, len(cd.Status.Conditions))
 				assertConditionStatus(t, cd, hivev1.ClusterImageSetNotFoundCondition, corev1.ConditionTrue)
 				assertConditionReason(t, cd, hivev1.ClusterImageSetNotFoundCondition, clusterImageSetNotFoundReason)
 			},
@@ -1258,7 +1615,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 			expectPendingCreation: true,
 			validate: func(c client.Client, t *testing.T) {
 				cd := getCD(c)
-				require.Equal(t, 4, len(cd.Status.Conditions))
+				require.Equal(t, 6, len(cd.Status.Conditions))
 				assertConditionStatus(t, cd, hivev1.ClusterImageSetNotFoundCondition, corev1.ConditionFalse)
 				assertConditionReason(t, cd, hivev1.ClusterImageSetNotFoundCondition, clusterImageSetFoundReason)
 			},
@@ -1833,6 +2190,10 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
+			for k, v := range test.envVars {
+				os.Setenv(k, v)
+				defer os.Unsetenv(k)
+			}
 			logger := log.WithField("controller", "clusterDeployment")
 			fakeClient := fake.NewFakeClient(test.existing...)
 			controllerExpectations := controllerutils.NewExpectations(logger)
@@ -1853,6 +2214,9 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				expectations:                            controllerExpectations,
 				remoteClusterAPIClientBuilder:           func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
 				validateCredentialsForClusterDeployment: test.platformCredentialsValidation,
+				missingAWSPermissions: func(context.Context, client.Client, *hivev1.ClusterDeployment, []preflight.Operation) ([]string, error) {
+					return nil, nil
+				},
 				watchingClusterInstall: map[string]struct{}{
 					(schema.GroupVersionKind{Group: "hive.openshift.io", Version: "v1", Kind: "FakeClusterInstall"}).String(): {},
 				},
@@ -2163,6 +2527,23 @@ func testClusterDeployment() *hivev1.ClusterDeployment {
 	return cd
 }
 
+func testGCPSharedVPCClusterDeployment() *hivev1.ClusterDeployment {
+	cd := testClusterDeployment()
+	cd.Spec.Platform = hivev1.Platform{
+		GCP: &hivev1gcp.Platform{
+			CredentialsSecretRef: corev1.LocalObjectReference{Name: "gcp-credentials"},
+			Region:               "us-central1",
+			NetworkProjectID:     "host-project",
+			Network:              "shared-network",
+			ControlPlaneSubnet:   "control-plane-subnet",
+			ComputeSubnet:        "compute-subnet",
+		},
+	}
+	cd.Labels[hivev1.HiveClusterPlatformLabel] = "gcp"
+	cd.Labels[hivev1.HiveClusterRegionLabel] = "us-central1"
+	return cd
+}
+
 func testClusterDeploymentWithDefaultConditions(cd *hivev1.ClusterDeployment) *hivev1.ClusterDeployment {
 	cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{
 		{
@@ -2183,6 +2564,12 @@ func testClusterDeploymentWithDefaultConditions(cd *hivev1.ClusterDeployment) *h
 			Reason:  "ProvisionNotStopped",
 			Message: "Provision is not stopped",
 		},
+		{
+			Status:  corev1.ConditionFalse,
+			Type:    hivev1.PausedClusterDeploymentCondition,
+			Reason:  hivev1.NotPausedReason,
+			Message: "Reconciling the cluster deployment is not paused",
+		},
 	}
 	return cd
 }
@@ -2337,6 +2724,21 @@ func testProvision() *hivev1.ClusterProvision {
 	return provision
 }
 
+func testPrivateServiceConnectClusterDeployment(ready corev1.ConditionStatus) *hivev1.ClusterDeployment {
+	cd := testClusterDeploymentWithProvision()
+	cd.Spec.Platform = hivev1.Platform{GCP: &hivev1gcp.Platform{
+		Region:                "us-central1",
+		PrivateServiceConnect: &hivev1gcp.PrivateServiceConnectAccess{Enabled: true},
+	}}
+	cd.Labels[hivev1.HiveClusterPlatformLabel] = "gcp"
+	cd.Labels[hivev1.HiveClusterRegionLabel] = "us-central1"
+	cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+		Type:   hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+		Status: ready,
+	})
+	return cd
+}
+
 func testSuccessfulProvision() *hivev1.ClusterProvision {
 	provision := testProvision()
 	provision.Spec.Stage = hivev1.ClusterProvisionStageComplete
@@ -3050,6 +3452,29 @@ func testCompletedFailedImageSetJob() *batchv1.Job {
 	}
 }
 
+func TestRequestsForCredentialsSecret(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	otherCD := testClusterDeployment()
+	otherCD.Name = "other-cd"
+	otherCD.Spec.Platform.AWS.CredentialsSecretRef.Name = "other-credentials"
+	otherNamespaceCD := testClusterDeployment()
+	otherNamespaceCD.Namespace = "other-namespace"
+	c := fake.NewFakeClientWithScheme(scheme.Scheme, testClusterDeployment(), otherCD, otherNamespaceCD)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      "aws-credentials",
+		},
+	}
+	requests := requestsForCredentialsSecret(c, log.WithField("controller", "test"))(secret)
+	assert.Equal(t,
+		[]reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}}},
+		requests,
+		"unexpected requests",
+	)
+}
+
 func dnsZoneBase() testdnszone.Option {
 	return func(dnsZone *hivev1.DNSZone) {
 		dnsZone.Name = controllerutils.DNSZoneName(testName)
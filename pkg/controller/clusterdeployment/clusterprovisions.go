@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -91,6 +92,27 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		return reconcile.Result{}, nil
 	}
 
+	if failedCond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ProvisionFailedCondition); cd.Status.InstallRestarts > 0 &&
+		failedCond != nil && failedCond.Status == corev1.ConditionTrue && !isRetryableFailure(failedCond.Reason) {
+		logger.WithField("reason", failedCond.Reason).Debug("not creating new provision since the last provision failed for a reason that is not retried")
+		conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			cd.Status.Conditions,
+			hivev1.ProvisionStoppedCondition,
+			corev1.ConditionTrue,
+			failureNotRetryableReason,
+			fmt.Sprintf("Install failed with reason %s, which is not retried", failedCond.Reason),
+			controllerutils.UpdateConditionIfReasonOrMessageChange)
+		if changed {
+			cd.Status.Conditions = conditions
+			logger.Debugf("setting ProvisionStoppedCondition to %v", corev1.ConditionTrue)
+			if err := r.Status().Update(context.TODO(), cd); err != nil {
+				logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{}, nil
+	}
+
 	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
 		cd.Status.Conditions,
 		hivev1.ProvisionStoppedCondition,
@@ -138,6 +160,14 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		logger.WithError(err).Error("could not generate installer pod spec")
 		return reconcile.Result{}, err
 	}
+	if err := controllerutils.ApplyWorkloadSchedulingFromFile(podSpec); err != nil {
+		logger.WithError(err).Error("could not apply workload scheduling to installer pod spec")
+		return reconcile.Result{}, err
+	}
+	if err := controllerutils.ApplyJobProxyFromFiles(r, cd.Namespace, podSpec, logger); err != nil {
+		logger.WithError(err).Error("could not apply job proxy to installer pod spec")
+		return reconcile.Result{}, err
+	}
 
 	provision := &hivev1.ClusterProvision{
 		ObjectMeta: metav1.ObjectMeta{
@@ -317,9 +347,14 @@ func (r *ReconcileClusterDeployment) reconcileFailedProvision(cd *hivev1.Cluster
 
 	failedCond := controllerutils.FindClusterProvisionCondition(provision.Status.Conditions, hivev1.ClusterProvisionFailedCondition)
 	if failedCond != nil && failedCond.Status == corev1.ConditionTrue {
-		nextProvisionTime = calculateNextProvisionTime(failedCond.LastTransitionTime.Time, cd.Status.InstallRestarts, cdLog)
 		reason = failedCond.Reason
-		message = fmt.Sprintf("Provision %s failed. Next provision at %s.\n\n%s", provision.Name, nextProvisionTime.UTC().Format(time.RFC3339), failedCond.Message)
+		if isRetryableFailure(reason) {
+			nextProvisionTime = calculateNextProvisionTime(failedCond.LastTransitionTime.Time, cd.Status.InstallRestarts, cdLog)
+			message = fmt.Sprintf("Provision %s failed. Next provision at %s.\n\n%s", provision.Name, nextProvisionTime.UTC().Format(time.RFC3339), failedCond.Message)
+		} else {
+			// There is no need to wait before clearing out the provision as no new provision will be started.
+			message = fmt.Sprintf("Provision %s failed. Failures with reason %s are not retried.\n\n%s", provision.Name, reason, failedCond.Message)
+		}
 	} else {
 		cdLog.Warnf("failed provision does not have a %s condition", hivev1.ClusterProvisionFailedCondition)
 	}
@@ -349,6 +384,21 @@ func (r *ReconcileClusterDeployment) reconcileFailedProvision(cd *hivev1.Cluster
 	return r.clearOutCurrentProvision(cd, cdLog)
 }
 
+// isRetryableFailure returns whether a failed install with the given reason should be retried. All failures are
+// retried unless HiveConfig limits the retried failures to a list of reasons.
+func isRetryableFailure(reason string) bool {
+	retryReasons, ok := os.LookupEnv(constants.FailedProvisionRetryReasonsEnvVar)
+	if !ok {
+		return true
+	}
+	for _, r := range strings.Split(retryReasons, ",") {
+		if strings.TrimSpace(r) == reason {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *ReconcileClusterDeployment) reconcileCompletedProvision(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision, cdLog log.FieldLogger) (reconcile.Result, error) {
 	cdLog.Info("provision completed successfully")
 
@@ -381,6 +431,11 @@ func (r *ReconcileClusterDeployment) reconcileCompletedProvision(cd *hivev1.Clus
 		return reconcile.Result{}, nil
 	}
 
+	if waitingForPrivateServiceConnect(cd) {
+		cdLog.Info("waiting for private service connect access to be ready before marking the cluster installed")
+		return reconcile.Result{}, nil
+	}
+
 	cd.Spec.Installed = true
 
 	if r.protectedDelete {
@@ -417,6 +472,19 @@ func (r *ReconcileClusterDeployment) reconcileCompletedProvision(cd *hivev1.Clus
 	return reconcile.Result{}, nil
 }
 
+// waitingForPrivateServiceConnect returns true when the cluster uses GCP Private Service Connect and the
+// access to the cluster's API through it is not ready yet. Such clusters are not reachable by hive, so
+// they are not marked as installed until connectivity is established.
+func waitingForPrivateServiceConnect(cd *hivev1.ClusterDeployment) bool {
+	if cd.Spec.Platform.GCP == nil ||
+		cd.Spec.Platform.GCP.PrivateServiceConnect == nil ||
+		!cd.Spec.Platform.GCP.PrivateServiceConnect.Enabled {
+		return false
+	}
+	ready := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition)
+	return ready == nil || ready.Status != corev1.ConditionTrue
+}
+
 func getClusterImageSetFromProvisioning(cd *hivev1.ClusterDeployment) string {
 	if cd.Spec.Provisioning.ImageSetRef != nil {
 		return cd.Spec.Provisioning.ImageSetRef.Name
@@ -511,14 +579,28 @@ func getAWSServiceProviderEnvVars(cd *hivev1.ClusterDeployment, secretPrefix str
 }
 
 func (r *ReconcileClusterDeployment) setupAWSCredentialForAssumeRole(cd *hivev1.ClusterDeployment) error {
-	if cd.Spec.Platform.AWS == nil ||
-		cd.Spec.Platform.AWS.CredentialsSecretRef.Name != "" ||
-		cd.Spec.Platform.AWS.CredentialsAssumeRole == nil {
+	if cd.Spec.Platform.AWS == nil || cd.Spec.Platform.AWS.CredentialsSecretRef.Name != "" {
 		// no setup required
 		return nil
 	}
 
-	return install.AWSAssumeRoleCLIConfig(r.Client, cd.Spec.Platform.AWS.CredentialsAssumeRole, install.AWSAssumeRoleSecretName(cd.Name), cd.Namespace, cd, r.scheme)
+	switch {
+	case cd.Spec.Platform.AWS.CredentialsSource != nil:
+		_, err := r.refreshAWSCredentialsSourceSecret(cd)
+		return err
+	case cd.Spec.Platform.AWS.CredentialsAssumeRole != nil:
+		return install.AWSAssumeRoleCLIConfig(r.Client, cd.Spec.Platform.AWS.CredentialsAssumeRole, install.AWSAssumeRoleSecretName(cd.Name), cd.Namespace, cd, r.scheme)
+	}
+	return nil
+}
+
+// refreshAWSCredentialsSourceSecret creates or refreshes the secret of the install pods with the credentials minted
+// by the credentials broker for the credentials source of the cluster, and returns how long until it must be refreshed.
+func (r *ReconcileClusterDeployment) refreshAWSCredentialsSourceSecret(cd *hivev1.ClusterDeployment) (time.Duration, error) {
+	if cd.Spec.Platform.AWS == nil || cd.Spec.Platform.AWS.CredentialsSource == nil || cd.Spec.Platform.AWS.CredentialsSecretRef.Name != "" {
+		return 0, nil
+	}
+	return install.AWSCredentialsSourceSecret(r.Client, cd.Spec.Platform.AWS.CredentialsSource, cd.Spec.Platform.AWS.Region, install.AWSAssumeRoleSecretName(cd.Name), cd.Namespace, cd, r.scheme)
 }
 
 func (r *ReconcileClusterDeployment) watchClusterProvisions(c controller.Controller) error {
@@ -0,0 +1,130 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// skipFIPSPreflightAnnotation can be set to "true" to provision a cluster in FIPS mode without first checking
+	// that the release image and the hub support it.
+	skipFIPSPreflightAnnotation = "hive.openshift.io/skip-fips-preflight"
+
+	fipsRequirementsMetReason           = "FIPSRequirementsMet"
+	fipsArchitectureNotSupportedReason  = "ArchitectureNotSupported"
+	fipsHubNotEnabledReason             = "HubNotFIPSEnabled"
+	fipsCheckFailedReason               = "FIPSCheckFailed"
+	fipsPreflightRequeueAfter           = 5 * time.Minute
+	installConfigSecretInstallConfigKey = "install-config.yaml"
+)
+
+// fipsArchitectures are the architectures of the release images that can be installed in FIPS mode.
+var fipsArchitectures = sets.NewString("amd64", "ppc64le", "s390x")
+
+// checkFIPSForProvision checks that a cluster whose InstallConfig requests FIPS mode can be installed in FIPS mode,
+// and records the result in the FIPSPreconditionFailed condition. A non-nil result is returned when the provision
+// must not be started. The release image must support an architecture that can be installed in FIPS mode, as
+// recorded by the validation of its ClusterImageSet, and the hub must be running in FIPS mode since the installer
+// refuses to install a FIPS cluster from a host that is not.
+func (r *ReconcileClusterDeployment) checkFIPSForProvision(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (*reconcile.Result, error) {
+	if cd.Annotations[skipFIPSPreflightAnnotation] == "true" {
+		return nil, nil
+	}
+	fips, err := clusterDeploymentRequestsFIPS(r.Client, cd)
+	if err != nil {
+		logger.WithError(err).Warn("could not determine whether the cluster is installed in FIPS mode")
+		return nil, nil
+	}
+	if !fips {
+		return nil, nil
+	}
+
+	status := corev1.ConditionFalse
+	reason := fipsRequirementsMetReason
+	message := "The release image and the hub support installing the cluster in FIPS mode"
+	archs, err := r.releaseImageArchitectures(cd)
+	if err == nil && len(archs) > 0 && !fipsArchitectures.HasAny(archs...) {
+		status = corev1.ConditionTrue
+		reason = fipsArchitectureNotSupportedReason
+		message = fmt.Sprintf("The release image architectures %v do not support FIPS mode, which requires one of %v",
+			archs, fipsArchitectures.List())
+	}
+	if err == nil && status == corev1.ConditionFalse {
+		var hubFIPS bool
+		if hubFIPS, err = r.hostFIPSEnabled(); err == nil && !hubFIPS {
+			status = corev1.ConditionTrue
+			reason = fipsHubNotEnabledReason
+			message = "The hub is not running in FIPS mode, which the installer requires to install a cluster in FIPS mode"
+		}
+	}
+	if err != nil {
+		logger.WithError(err).Warn("could not check the FIPS requirements of the cluster")
+		status = corev1.ConditionUnknown
+		reason = fipsCheckFailedReason
+		message = "Could not check the FIPS requirements of the cluster (see controller logs for details)"
+	}
+
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.FIPSPreconditionFailedClusterDeploymentCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed {
+		cd.Status.Conditions = conditions
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update FIPSPreconditionFailed condition")
+			return nil, err
+		}
+	}
+
+	if status == corev1.ConditionTrue {
+		logger.WithField("reason", reason).Warn("cluster cannot be installed in FIPS mode")
+		return &reconcile.Result{RequeueAfter: fipsPreflightRequeueAfter}, nil
+	}
+	return nil, nil
+}
+
+// clusterDeploymentRequestsFIPS returns whether the InstallConfig of the ClusterDeployment requests FIPS mode.
+func clusterDeploymentRequestsFIPS(c client.Client, cd *hivev1.ClusterDeployment) (bool, error) {
+	if cd.Spec.Provisioning == nil || cd.Spec.Provisioning.InstallConfigSecretRef == nil {
+		return false, nil
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Namespace: cd.Namespace, Name: cd.Spec.Provisioning.InstallConfigSecretRef.Name}, secret); err != nil {
+		return false, errors.Wrap(err, "failed to fetch install config secret")
+	}
+	return controllerutils.InstallConfigRequestsFIPS(secret.Data[installConfigSecretInstallConfigKey])
+}
+
+// releaseImageArchitectures returns the architectures of the release image recorded by the validation of the
+// ClusterImageSet of the ClusterDeployment. No architectures are returned when the release image is not taken from a
+// ClusterImageSet or its architectures are not known.
+func (r *ReconcileClusterDeployment) releaseImageArchitectures(cd *hivev1.ClusterDeployment) ([]string, error) {
+	if cd.Spec.Provisioning.ReleaseImage != "" {
+		return nil, nil
+	}
+	imageSetName := getClusterImageSetFromProvisioning(cd)
+	if imageSetName == "" {
+		return nil, nil
+	}
+	imageSet := &hivev1.ClusterImageSet{}
+	if err := r.Get(context.TODO(), client.ObjectKey{Name: imageSetName}, imageSet); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch cluster image set")
+	}
+	return imageSet.Status.Architectures, nil
+}
@@ -0,0 +1,150 @@
+package clusterdeployment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+func TestCheckFIPSForProvision(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	installConfigSecret := func(fips bool) *corev1.Secret {
+		ic := "baseDomain: example.com\n"
+		if fips {
+			ic += "fips: true\n"
+		}
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "install-config-secret"},
+			Data:       map[string][]byte{"install-config.yaml": []byte(ic)},
+		}
+	}
+	imageSet := func(archs ...string) *hivev1.ClusterImageSet {
+		return &hivev1.ClusterImageSet{
+			ObjectMeta: metav1.ObjectMeta{Name: testClusterImageSetName},
+			Status:     hivev1.ClusterImageSetStatus{Architectures: archs},
+		}
+	}
+	imageSetCD := func() *hivev1.ClusterDeployment {
+		cd := testClusterDeployment()
+		cd.Spec.Provisioning.ImageSetRef = &hivev1.ClusterImageSetReference{Name: testClusterImageSetName}
+		return cd
+	}
+
+	cases := []struct {
+		name            string
+		cd              *hivev1.ClusterDeployment
+		existing        []runtime.Object
+		hubFIPS         bool
+		hubFIPSErr      error
+		expectedStatus  corev1.ConditionStatus
+		expectedReason  string
+		expectedRequeue bool
+	}{
+		{
+			name:     "FIPS not requested",
+			cd:       testClusterDeployment(),
+			existing: []runtime.Object{installConfigSecret(false)},
+		},
+		{
+			name: "skipped",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				cd.Annotations = map[string]string{skipFIPSPreflightAnnotation: "true"}
+				return cd
+			}(),
+			existing: []runtime.Object{installConfigSecret(true)},
+		},
+		{
+			name:           "requirements met",
+			cd:             imageSetCD(),
+			existing:       []runtime.Object{installConfigSecret(true), imageSet("amd64", "arm64")},
+			hubFIPS:        true,
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: fipsRequirementsMetReason,
+		},
+		{
+			name:           "release image architectures unknown",
+			cd:             testClusterDeployment(),
+			existing:       []runtime.Object{installConfigSecret(true)},
+			hubFIPS:        true,
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: fipsRequirementsMetReason,
+		},
+		{
+			name:            "architecture not supported",
+			cd:              imageSetCD(),
+			existing:        []runtime.Object{installConfigSecret(true), imageSet("arm64")},
+			hubFIPS:         true,
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  fipsArchitectureNotSupportedReason,
+			expectedRequeue: true,
+		},
+		{
+			name:            "hub not in FIPS mode",
+			cd:              imageSetCD(),
+			existing:        []runtime.Object{installConfigSecret(true), imageSet("amd64")},
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  fipsHubNotEnabledReason,
+			expectedRequeue: true,
+		},
+		{
+			name:           "check failed",
+			cd:             testClusterDeployment(),
+			existing:       []runtime.Object{installConfigSecret(true)},
+			hubFIPSErr:     errors.New("permission denied"),
+			expectedStatus: corev1.ConditionUnknown,
+			expectedReason: fipsCheckFailedReason,
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			fakeClient := fake.NewFakeClientWithScheme(scheme.Scheme, append(test.existing, test.cd)...)
+			r := &ReconcileClusterDeployment{
+				Client: fakeClient,
+				scheme: scheme.Scheme,
+				logger: log.WithField("controller", "clusterDeployment"),
+				hostFIPSEnabled: func() (bool, error) {
+					return test.hubFIPS, test.hubFIPSErr
+				},
+			}
+
+			result, err := r.checkFIPSForProvision(test.cd, r.logger)
+			require.NoError(t, err, "unexpected error")
+			if test.expectedRequeue {
+				if assert.NotNil(t, result, "expected provision to be blocked") {
+					assert.Equal(t, fipsPreflightRequeueAfter, result.RequeueAfter, "unexpected requeue")
+				}
+			} else {
+				assert.Nil(t, result, "unexpected result")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: testName}, cd))
+			cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.FIPSPreconditionFailedClusterDeploymentCondition)
+			if test.expectedStatus == "" {
+				assert.Nil(t, cond, "unexpected FIPSPreconditionFailed condition")
+				return
+			}
+			if assert.NotNil(t, cond, "missing FIPSPreconditionFailed condition") {
+				assert.Equal(t, test.expectedStatus, cond.Status, "unexpected condition status")
+				assert.Equal(t, test.expectedReason, cond.Reason, "unexpected condition reason")
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package clusterdeployment
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/gcpclient"
+	"github.com/openshift/hive/pkg/preflight"
+)
+
+const (
+	// skipPermissionsPreflightAnnotation can be set to "true" to provision a cluster without first checking the
+	// permissions of the platform credentials, for instance when the check reports permissions as missing that are
+	// granted in a way that cannot be simulated.
+	skipPermissionsPreflightAnnotation = "hive.openshift.io/skip-permissions-preflight"
+
+	permissionsMissingReason      = "MissingPermissions"
+	permissionsVerifiedReason     = "PermissionsVerified"
+	permissionsCheckFailedReason  = "PermissionsCheckFailed"
+	permissionsPreflightRequeueAt = 5 * time.Minute
+)
+
+// checkPermissionsForProvision checks that the platform credentials have the permissions needed to provision the
+// cluster, and to hibernate it if the cluster may be hibernated, and records the result in the
+// InsufficientPermissions condition. A non-nil result is returned when the provision must not be started.
+// AWS credentials are checked, and GCP credentials are checked for the host project of a Shared VPC. A failure to run
+// the check is recorded but does not block the provision.
+func (r *ReconcileClusterDeployment) checkPermissionsForProvision(ctx context.Context, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (*reconcile.Result, error) {
+	if cd.Annotations[skipPermissionsPreflightAnnotation] == "true" {
+		return nil, nil
+	}
+
+	var missing []string
+	var err error
+	switch {
+	case cd.Spec.Platform.AWS != nil:
+		operations := []preflight.Operation{preflight.OperationProvision}
+		if cd.Spec.HibernateAfter != nil || cd.Spec.ClusterPoolRef != nil || cd.Spec.PowerState == hivev1.HibernatingClusterPowerState {
+			operations = append(operations, preflight.OperationHibernation)
+		}
+		if usesAWSSharedVPC(cd) {
+			operations = append(operations, preflight.OperationSharedVPC)
+		}
+		missing, err = r.missingAWSPermissions(ctx, r.Client, cd, operations)
+	case usesGCPSharedVPC(cd):
+		missing, err = r.missingGCPPermissions(ctx, r.Client, cd, []preflight.Operation{preflight.OperationSharedVPCNetwork})
+	default:
+		return nil, nil
+	}
+
+	var status corev1.ConditionStatus
+	var reason, message string
+	switch {
+	case err != nil:
+		logger.WithError(err).Warn("could not check the permissions of the platform credentials")
+		status = corev1.ConditionUnknown
+		reason = permissionsCheckFailedReason
+		message = "Could not check the permissions of the platform credentials (see controller logs for details)"
+	case len(missing) > 0:
+		logger.WithField("missing", missing).Warn("platform credentials are missing permissions")
+		status = corev1.ConditionTrue
+		reason = permissionsMissingReason
+		message = preflight.MissingPermissionsMessage(missing)
+	default:
+		status = corev1.ConditionFalse
+		reason = permissionsVerifiedReason
+		message = "Platform credentials have the required permissions"
+	}
+
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.InsufficientPermissionsClusterDeploymentCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed {
+		cd.Status.Conditions = conditions
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update InsufficientPermissions condition")
+			return nil, err
+		}
+	}
+
+	if status == corev1.ConditionTrue {
+		// The credentials secret is watched, so a change to the credentials is picked up straight away. The requeue
+		// catches permissions granted to the existing credentials.
+		return &reconcile.Result{RequeueAfter: permissionsPreflightRequeueAt}, nil
+	}
+	return nil, nil
+}
+
+// missingAWSPermissionsForClusterDeployment returns the AWS actions needed for the given operations that the
+// platform credentials of the ClusterDeployment are not allowed to perform. For a cluster installed into a shared VPC,
+// the actions needed of the credentials of the network account that they are not allowed to perform are included,
+// prefixed with "network:".
+func missingAWSPermissionsForClusterDeployment(ctx context.Context, c client.Client, cd *hivev1.ClusterDeployment, operations []preflight.Operation) ([]string, error) {
+	awsClient, err := awsclient.New(c, platformAWSClientOptions(cd))
+	if err != nil {
+		return nil, err
+	}
+	missing, err := preflight.MissingAWSPermissions(ctx, awsClient, operations...)
+	if err != nil || !usesAWSSharedVPC(cd) {
+		return missing, err
+	}
+
+	networkClient, err := awsclient.New(c, sharedVPCAWSClientOptions(cd))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create AWS client for the network account")
+	}
+	missingNetwork, err := preflight.MissingAWSPermissions(ctx, networkClient, preflight.OperationSharedVPCNetwork)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not check the permissions of the network account credentials")
+	}
+	for _, action := range missingNetwork {
+		missing = append(missing, "network:"+action)
+	}
+	return missing, nil
+}
+
+// missingGCPPermissionsForClusterDeployment returns the GCP permissions needed for the given operations in the host
+// project of the Shared VPC of the ClusterDeployment that the platform credentials are not granted.
+func missingGCPPermissionsForClusterDeployment(ctx context.Context, c client.Client, cd *hivev1.ClusterDeployment, operations []preflight.Operation) ([]string, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Namespace: cd.Namespace, Name: cd.Spec.Platform.GCP.CredentialsSecretRef.Name}, secret); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch GCP credentials secret")
+	}
+	proxy, err := controllerutils.GetCloudClientProxy(c, cd.Namespace, cd.Spec.CloudClientProxySecretRef)
+	if err != nil {
+		return nil, err
+	}
+	gcpClient, err := gcpclient.NewClientFromSecretWithOptions(secret, gcpclient.Options{Proxy: proxy, Controller: hivev1.ClusterDeploymentControllerName})
+	if err != nil {
+		return nil, err
+	}
+	return preflight.MissingGCPPermissions(ctx, gcpClient, cd.Spec.Platform.GCP.NetworkProjectID, operations...)
+}
+
+func usesGCPSharedVPC(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.GCP != nil && cd.Spec.Platform.GCP.NetworkProjectID != ""
+}
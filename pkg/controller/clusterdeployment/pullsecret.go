@@ -0,0 +1,252 @@
+package clusterdeployment
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	remotePullSecretNamespace = "openshift-config"
+	remotePullSecretName      = "pull-secret"
+)
+
+// reconcileInstalledPullSecret keeps the pull secret of an installed cluster in sync with the merged pull secret. When
+// the global pull secret or the pull secret of the ClusterDeployment changes, the merged pull secret is updated and
+// distributed to the cluster by a SyncSet. The PullSecretSyncFailed condition reports the result of the rollout for the
+// cluster, as applied by the clustersync controller.
+func (r *ReconcileClusterDeployment) reconcileInstalledPullSecret(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (reconcile.Result, error) {
+	mergedSecret := &corev1.Secret{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: constants.GetMergedPullSecretName(cd)}, mergedSecret); {
+	case apierrors.IsNotFound(err):
+		// The cluster was not provisioned by Hive, so there is no record of the pull secret in use by the cluster.
+		cdLog.Debug("no merged pull secret for the cluster, skipping pull secret sync")
+		return reconcile.Result{}, nil
+	case err != nil:
+		cdLog.WithError(err).Error("error getting the merged pull secret")
+		return reconcile.Result{}, err
+	}
+
+	syncedHash, ok := mergedSecret.Annotations[constants.SyncedPullSecretHashAnnotation]
+	if !ok {
+		// The merged pull secret was last used to install the cluster, so record it as the one in use by the cluster.
+		syncedHash = pullSecretHash(string(mergedSecret.Data[corev1.DockerConfigJsonKey]))
+		if mergedSecret.Annotations == nil {
+			mergedSecret.Annotations = map[string]string{}
+		}
+		mergedSecret.Annotations[constants.SyncedPullSecretHashAnnotation] = syncedHash
+		if err := r.Update(context.TODO(), mergedSecret); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error recording the pull secret in use by the cluster")
+			return reconcile.Result{}, err
+		}
+	}
+
+	pullSecret, err := r.mergePullSecrets(cd, cdLog)
+	if err != nil {
+		cdLog.WithError(err).Error("Error merging pull secrets")
+		return reconcile.Result{}, err
+	}
+	hash := pullSecretHash(pullSecret)
+	if hash == syncedHash {
+		return reconcile.Result{}, nil
+	}
+
+	if _, err := r.updatePullSecretInfo(pullSecret, cd, cdLog); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "Error updating the merged pull secret")
+		return reconcile.Result{}, err
+	}
+
+	syncSet, err := r.ensurePullSecretSyncSet(cd, mergedSecret.Name, hash, cdLog)
+	if err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error applying the pull secret syncset")
+		return reconcile.Result{}, err
+	}
+
+	// The ClusterSync is watched, so the rollout is checked again once the syncset has been applied.
+	syncStatus, err := r.pullSecretSyncStatus(cd, syncSet)
+	if err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not get ClusterSync")
+		return reconcile.Result{}, err
+	}
+	if syncStatus == nil {
+		cdLog.Debug("waiting for the updated pull secret to be synced to the cluster")
+		return reconcile.Result{}, nil
+	}
+	if syncStatus.Result != hiveintv1alpha1.SuccessSyncSetResult {
+		cdLog.WithField("failure", syncStatus.FailureMessage).Warn("the updated pull secret could not be synced to the cluster")
+		return reconcile.Result{}, r.setPullSecretSyncFailedCondition(cd, errors.New(syncStatus.FailureMessage), cdLog)
+	}
+
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: mergedSecret.Namespace, Name: mergedSecret.Name}, mergedSecret); err != nil {
+		cdLog.WithError(err).Error("error getting the merged pull secret")
+		return reconcile.Result{}, err
+	}
+	if mergedSecret.Annotations == nil {
+		mergedSecret.Annotations = map[string]string{}
+	}
+	mergedSecret.Annotations[constants.SyncedPullSecretHashAnnotation] = hash
+	if err := r.Update(context.TODO(), mergedSecret); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error recording the pull secret synced to the cluster")
+		return reconcile.Result{}, err
+	}
+	cdLog.Info("synced the updated pull secret to the cluster")
+	return reconcile.Result{}, r.setPullSecretSyncFailedCondition(cd, nil, cdLog)
+}
+
+// requestsForGlobalPullSecret returns a map function that enqueues all the installed ClusterDeployments when the
+// global pull secret changes.
+func requestsForGlobalPullSecret(c client.Client, globalPullSecretName string, logger log.FieldLogger) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		if o.GetNamespace() != controllerutils.GetHiveNamespace() || o.GetName() != globalPullSecretName {
+			return nil
+		}
+		cdList := &hivev1.ClusterDeploymentList{}
+		if err := c.List(context.Background(), cdList); err != nil {
+			logger.WithError(err).Error("failed to list cluster deployments for the global pull secret")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, cd := range cdList.Items {
+			if !cd.Spec.Installed {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name},
+			})
+		}
+		return requests
+	}
+}
+
+// ensurePullSecretSyncSet creates or updates the SyncSet distributing the merged pull secret to the pull secret of the
+// cluster. The hash of the pull secret is patched onto the pull secret of the cluster so that the generation of the
+// SyncSet changes, and the clustersync controller applies it again, whenever the pull secret changes.
+func (r *ReconcileClusterDeployment) ensurePullSecretSyncSet(cd *hivev1.ClusterDeployment, mergedSecretName, hash string, cdLog log.FieldLogger) (*hivev1.SyncSet, error) {
+	desired := &hivev1.SyncSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        GeneratePullSecretSyncSetName(cd.Name),
+			Namespace:   cd.Namespace,
+			Annotations: map[string]string{constants.SyncSetMetricsGroupAnnotation: "pull-secret"},
+		},
+		Spec: hivev1.SyncSetSpec{
+			SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
+				ResourceApplyMode: hivev1.UpsertResourceApplyMode,
+				Secrets: []hivev1.SecretMapping{{
+					SourceRef: hivev1.SecretReference{Namespace: cd.Namespace, Name: mergedSecretName},
+					TargetRef: hivev1.SecretReference{Namespace: remotePullSecretNamespace, Name: remotePullSecretName},
+				}},
+				Patches: []hivev1.SyncObjectPatch{{
+					APIVersion: "v1",
+					Kind:       "Secret",
+					Namespace:  remotePullSecretNamespace,
+					Name:       remotePullSecretName,
+					Patch:      fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, constants.PullSecretHashAnnotation, hash),
+					PatchType:  "merge",
+				}},
+			},
+			ClusterDeploymentRefs: []corev1.LocalObjectReference{{Name: cd.Name}},
+		},
+	}
+	desired.Labels = k8slabels.AddLabel(desired.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+	desired.Labels = k8slabels.AddLabel(desired.Labels, constants.SyncSetTypeLabel, constants.SyncSetTypePullSecret)
+	if err := controllerutil.SetControllerReference(cd, desired, r.scheme); err != nil {
+		return nil, errors.Wrap(err, "could not set the owner of the pull secret syncset")
+	}
+
+	existing := &hivev1.SyncSet{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, existing); {
+	case apierrors.IsNotFound(err):
+		cdLog.WithField("syncSet", desired.Name).Info("creating the pull secret syncset")
+		if err := r.Create(context.TODO(), desired); err != nil {
+			return nil, errors.Wrap(err, "could not create the pull secret syncset")
+		}
+		return desired, nil
+	case err != nil:
+		return nil, errors.Wrap(err, "could not get the pull secret syncset")
+	}
+	if reflect.DeepEqual(existing.Spec, desired.Spec) {
+		return existing, nil
+	}
+	cdLog.WithField("syncSet", desired.Name).Info("updating the pull secret syncset")
+	existing.Spec = desired.Spec
+	if err := r.Update(context.TODO(), existing); err != nil {
+		return nil, errors.Wrap(err, "could not update the pull secret syncset")
+	}
+	return existing, nil
+}
+
+// pullSecretSyncStatus returns the status of the pull secret SyncSet in the ClusterSync of the cluster, or nil when the
+// clustersync controller has not applied the current generation of the SyncSet yet.
+func (r *ReconcileClusterDeployment) pullSecretSyncStatus(cd *hivev1.ClusterDeployment, syncSet *hivev1.SyncSet) (*hiveintv1alpha1.SyncStatus, error) {
+	clusterSync := &hiveintv1alpha1.ClusterSync{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, clusterSync); {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	for i, status := range clusterSync.Status.SyncSets {
+		if status.Name == syncSet.Name && status.ObservedGeneration == syncSet.Generation {
+			return &clusterSync.Status.SyncSets[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// GeneratePullSecretSyncSetName generates the name of the SyncSet that distributes the pull secret of an installed
+// cluster.
+func GeneratePullSecretSyncSetName(name string) string {
+	return apihelpers.GetResourceName(name, constants.PullSecretSyncSetSuffix)
+}
+
+func (r *ReconcileClusterDeployment) setPullSecretSyncFailedCondition(cd *hivev1.ClusterDeployment, syncErr error, cdLog log.FieldLogger) error {
+	status := corev1.ConditionFalse
+	reason := "PullSecretSynced"
+	message := "the updated pull secret has been synced to the cluster"
+	if syncErr != nil {
+		status = corev1.ConditionTrue
+		reason = "PullSecretSyncFailed"
+		message = syncErr.Error()
+	}
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.PullSecretSyncFailedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conditions
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not update PullSecretSyncFailed condition")
+		return err
+	}
+	return nil
+}
+
+func pullSecretHash(pullSecret string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(pullSecret)))
+}
@@ -0,0 +1,207 @@
+package clusterdeployment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	oldPullSecret = `{"auths":{"registry.example.com":{"auth":"b2xk"}}}`
+	newPullSecret = `{"auths":{"registry.example.com":{"auth":"bmV3"}}}`
+)
+
+func TestReconcileInstalledPullSecret(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	mergedSecret := func(data string, annotations map[string]string) *corev1.Secret {
+		s := testSecret(corev1.SecretTypeDockerConfigJson, testName+"-merged-pull-secret", corev1.DockerConfigJsonKey, data)
+		s.Annotations = annotations
+		return s
+	}
+	clusterSync := func(statuses ...hiveintv1alpha1.SyncStatus) *hiveintv1alpha1.ClusterSync {
+		return &hiveintv1alpha1.ClusterSync{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testName},
+			Status:     hiveintv1alpha1.ClusterSyncStatus{SyncSets: statuses},
+		}
+	}
+	syncStatus := func(generation int64, result hiveintv1alpha1.SyncSetResult, failure string) hiveintv1alpha1.SyncStatus {
+		return hiveintv1alpha1.SyncStatus{
+			Name:               GeneratePullSecretSyncSetName(testName),
+			ObservedGeneration: generation,
+			Result:             result,
+			FailureMessage:     failure,
+		}
+	}
+
+	cases := []struct {
+		name                 string
+		existing             []runtime.Object
+		expectedMerged       string
+		expectedSyncedHash   string
+		expectedSyncSetHash  string
+		expectedCondition    corev1.ConditionStatus
+		expectedCondReason   string
+		expectNoSyncedRecord bool
+	}{
+		{
+			name: "no merged pull secret",
+			existing: []runtime.Object{
+				testInstalledClusterDeployment(time.Now()),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, newPullSecret),
+			},
+			expectNoSyncedRecord: true,
+		},
+		{
+			name: "record pull secret in use by the cluster",
+			existing: []runtime.Object{
+				testInstalledClusterDeployment(time.Now()),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, oldPullSecret),
+				mergedSecret(oldPullSecret, nil),
+			},
+			expectedMerged:     oldPullSecret,
+			expectedSyncedHash: pullSecretHash(oldPullSecret),
+		},
+		{
+			name: "pull secret already synced",
+			existing: []runtime.Object{
+				testInstalledClusterDeployment(time.Now()),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, newPullSecret),
+				mergedSecret(newPullSecret, map[string]string{constants.SyncedPullSecretHashAnnotation: pullSecretHash(newPullSecret)}),
+			},
+			expectedMerged:     newPullSecret,
+			expectedSyncedHash: pullSecretHash(newPullSecret),
+		},
+		{
+			name: "generate syncset for updated pull secret",
+			existing: []runtime.Object{
+				testInstalledClusterDeployment(time.Now()),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, newPullSecret),
+				mergedSecret(oldPullSecret, map[string]string{constants.SyncedPullSecretHashAnnotation: pullSecretHash(oldPullSecret)}),
+			},
+			expectedMerged:      newPullSecret,
+			expectedSyncedHash:  pullSecretHash(oldPullSecret),
+			expectedSyncSetHash: pullSecretHash(newPullSecret),
+		},
+		{
+			name: "generate syncset for cluster without record",
+			existing: []runtime.Object{
+				testInstalledClusterDeployment(time.Now()),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, newPullSecret),
+				mergedSecret(oldPullSecret, nil),
+			},
+			expectedMerged:      newPullSecret,
+			expectedSyncedHash:  pullSecretHash(oldPullSecret),
+			expectedSyncSetHash: pullSecretHash(newPullSecret),
+		},
+		{
+			name: "syncset applied",
+			existing: []runtime.Object{
+				testInstalledClusterDeployment(time.Now()),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, newPullSecret),
+				mergedSecret(oldPullSecret, map[string]string{constants.SyncedPullSecretHashAnnotation: pullSecretHash(oldPullSecret)}),
+				clusterSync(syncStatus(0, hiveintv1alpha1.SuccessSyncSetResult, "")),
+			},
+			expectedMerged:      newPullSecret,
+			expectedSyncedHash:  pullSecretHash(newPullSecret),
+			expectedSyncSetHash: pullSecretHash(newPullSecret),
+			expectedCondition:   corev1.ConditionFalse,
+			expectedCondReason:  "PullSecretSynced",
+		},
+		{
+			name: "previous generation of syncset applied",
+			existing: []runtime.Object{
+				testInstalledClusterDeployment(time.Now()),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, newPullSecret),
+				mergedSecret(oldPullSecret, map[string]string{constants.SyncedPullSecretHashAnnotation: pullSecretHash(oldPullSecret)}),
+				clusterSync(syncStatus(1, hiveintv1alpha1.SuccessSyncSetResult, "")),
+			},
+			expectedMerged:      newPullSecret,
+			expectedSyncedHash:  pullSecretHash(oldPullSecret),
+			expectedSyncSetHash: pullSecretHash(newPullSecret),
+		},
+		{
+			name: "syncset apply fails",
+			existing: []runtime.Object{
+				testInstalledClusterDeployment(time.Now()),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, newPullSecret),
+				mergedSecret(oldPullSecret, map[string]string{constants.SyncedPullSecretHashAnnotation: pullSecretHash(oldPullSecret)}),
+				clusterSync(syncStatus(0, hiveintv1alpha1.FailureSyncSetResult, "failed to apply secret 0")),
+			},
+			expectedMerged:      newPullSecret,
+			expectedSyncedHash:  pullSecretHash(oldPullSecret),
+			expectedSyncSetHash: pullSecretHash(newPullSecret),
+			expectedCondition:   corev1.ConditionTrue,
+			expectedCondReason:  "PullSecretSyncFailed",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := log.WithField("controller", "clusterDeployment")
+			fakeClient := fake.NewFakeClient(tc.existing...)
+			rcd := &ReconcileClusterDeployment{
+				Client:       fakeClient,
+				scheme:       scheme.Scheme,
+				logger:       logger,
+				expectations: controllerutils.NewExpectations(logger),
+			}
+
+			cd := getCDFromClient(fakeClient)
+			_, err := rcd.reconcileInstalledPullSecret(cd, logger)
+			assert.NoError(t, err, "unexpected error syncing pull secret")
+
+			merged := &corev1.Secret{}
+			err = fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: testName + "-merged-pull-secret"}, merged)
+			if tc.expectNoSyncedRecord {
+				assert.Error(t, err, "expected no merged pull secret")
+			} else {
+				require.NoError(t, err, "unexpected error getting merged pull secret")
+				assert.Equal(t, tc.expectedMerged, string(merged.Data[corev1.DockerConfigJsonKey]), "unexpected merged pull secret")
+				assert.Equal(t, tc.expectedSyncedHash, merged.Annotations[constants.SyncedPullSecretHashAnnotation], "unexpected synced pull secret hash")
+			}
+
+			syncSet := &hivev1.SyncSet{}
+			err = fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: GeneratePullSecretSyncSetName(testName)}, syncSet)
+			if tc.expectedSyncSetHash == "" {
+				assert.True(t, apierrors.IsNotFound(err), "expected no pull secret syncset")
+			} else {
+				require.NoError(t, err, "unexpected error getting pull secret syncset")
+				assert.Equal(t, constants.SyncSetTypePullSecret, syncSet.Labels[constants.SyncSetTypeLabel], "unexpected syncset type")
+				assert.Equal(t, []corev1.LocalObjectReference{{Name: testName}}, syncSet.Spec.ClusterDeploymentRefs, "unexpected cluster deployment refs")
+				assert.Equal(t, []hivev1.SecretMapping{{
+					SourceRef: hivev1.SecretReference{Namespace: testNamespace, Name: testName + "-merged-pull-secret"},
+					TargetRef: hivev1.SecretReference{Namespace: remotePullSecretNamespace, Name: remotePullSecretName},
+				}}, syncSet.Spec.Secrets, "unexpected secret mappings")
+				if assert.Len(t, syncSet.Spec.Patches, 1, "expected pull secret hash patch") {
+					assert.Contains(t, syncSet.Spec.Patches[0].Patch, tc.expectedSyncSetHash, "expected pull secret hash in patch")
+				}
+			}
+
+			cond := controllerutils.FindClusterDeploymentCondition(getCDFromClient(fakeClient).Status.Conditions, hivev1.PullSecretSyncFailedCondition)
+			if tc.expectedCondition == "" {
+				assert.Nil(t, cond, "unexpected PullSecretSyncFailed condition")
+			} else if assert.NotNil(t, cond, "missing PullSecretSyncFailed condition") {
+				assert.Equal(t, tc.expectedCondition, cond.Status, "unexpected condition status")
+				assert.Equal(t, tc.expectedCondReason, cond.Reason, "unexpected condition reason")
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/registryclient"
+	"github.com/openshift/hive/pkg/releasesignature"
+)
+
+const (
+	releaseImageVerifiedReason             = "ReleaseImageVerified"
+	releaseImageSignatureNotVerifiedReason = "SignatureNotVerified"
+	releaseImageArchitectureMismatchReason = "ArchitectureMismatch"
+	releaseImageVerificationErrorReason    = "ReleaseImageVerificationError"
+	releaseImageVerificationRequeue        = 5 * time.Minute
+	releaseImageVerificationTimeout        = 2 * time.Minute
+)
+
+// checkReleaseImageForProvision verifies the release image of a cluster before it is provisioned, when release image
+// verification is enabled in HiveConfig, and records the result in the ReleaseImageVerificationFailed condition. The
+// release image is resolved to a digest once, and the architectures and signature of that digest are verified. The
+// release image to provision with is returned, pinned to the verified digest and recorded in the status of the
+// ClusterDeployment so that the imageset job and the install pods run the same payload. A non-nil result is returned
+// when the provision must not be started. The architectures of the release image must include the architecture of
+// every machine pool of the InstallConfig, the instance types of the machine pools must match their architecture, and
+// the release image must be signed by one of the trusted keys.
+func (r *ReconcileClusterDeployment) checkReleaseImageForProvision(cd *hivev1.ClusterDeployment, releaseImage string, logger log.FieldLogger) (string, *reconcile.Result, error) {
+	if cd.Status.VerifiedReleaseImage != "" {
+		return cd.Status.VerifiedReleaseImage, nil, nil
+	}
+	if len(r.releaseSignatureStores) == 0 || cd.Spec.Provisioning == nil || cd.Status.ProvisionRef != nil {
+		return releaseImage, nil, nil
+	}
+	logger = logger.WithField("releaseImage", releaseImage)
+
+	verifiedImage, status, reason, message, err := r.verifyReleaseImage(cd, releaseImage, logger)
+	if err != nil {
+		logger.WithError(err).Warn("could not verify the release image")
+		verifiedImage = ""
+		status = corev1.ConditionTrue
+		reason = releaseImageVerificationErrorReason
+		message = fmt.Sprintf("Could not verify the release image: %v", err)
+	}
+
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.ReleaseImageVerificationFailedClusterDeploymentCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed || verifiedImage != "" {
+		cd.Status.Conditions = conditions
+		cd.Status.VerifiedReleaseImage = verifiedImage
+		if err := r.Status().Update(context.TODO(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update ReleaseImageVerificationFailed condition")
+			return "", nil, err
+		}
+	}
+
+	if status == corev1.ConditionTrue {
+		logger.WithField("reason", reason).Warn("release image could not be verified")
+		return "", &reconcile.Result{RequeueAfter: releaseImageVerificationRequeue}, nil
+	}
+	return verifiedImage, nil, nil
+}
+
+// verifyReleaseImage returns the release image pinned to its verified digest, and the status, reason, and message of
+// the ReleaseImageVerificationFailed condition for the release image. The pinned release image is empty when the
+// release image could not be verified. An error is returned when the release image could not be inspected.
+func (r *ReconcileClusterDeployment) verifyReleaseImage(cd *hivev1.ClusterDeployment, releaseImage string, logger log.FieldLogger) (string, corev1.ConditionStatus, string, string, error) {
+	pullSecret, err := controllerutils.LoadSecretData(r.Client, constants.GetMergedPullSecretName(cd), cd.Namespace, corev1.DockerConfigJsonKey)
+	if err != nil {
+		return "", "", "", "", errors.Wrap(err, "could not load the pull secret")
+	}
+	registryClient, err := r.registryClientFn(pullSecret)
+	if err != nil {
+		return "", "", "", "", errors.Wrap(err, "could not create registry client")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), releaseImageVerificationTimeout)
+	defer cancel()
+	digest, err := registryClient.ImageDigest(ctx, releaseImage)
+	if err != nil {
+		return "", "", "", "", errors.Wrap(err, "could not get the digest of the release image")
+	}
+	pinnedImage, err := registryclient.ImageWithDigest(releaseImage, digest)
+	if err != nil {
+		return "", "", "", "", err
+	}
+	logger = logger.WithField("digest", digest)
+
+	archs, err := registryClient.ImageArchitectures(ctx, pinnedImage)
+	if err != nil {
+		return "", "", "", "", errors.Wrap(err, "could not get the architectures of the release image")
+	}
+	if message, err := r.releaseImageArchitectureMismatch(cd, archs); err != nil {
+		return "", "", "", "", err
+	} else if message != "" {
+		return "", corev1.ConditionTrue, releaseImageArchitectureMismatchReason, message, nil
+	}
+
+	publicKeys, err := r.releaseSignaturePublicKeys()
+	if err != nil {
+		return "", "", "", "", err
+	}
+	verifier, err := r.releaseSignatureVerifierFn(r.releaseSignatureStores, publicKeys)
+	if err != nil {
+		return "", "", "", "", errors.Wrap(err, "could not create signature verifier")
+	}
+	switch err := verifier.Verify(ctx, digest); err {
+	case nil:
+	case releasesignature.ErrSignatureNotFound, releasesignature.ErrSignatureNotVerified:
+		return "", corev1.ConditionTrue, releaseImageSignatureNotVerifiedReason,
+			fmt.Sprintf("The signature of release image digest %s could not be verified: %v", digest, err), nil
+	default:
+		return "", "", "", "", err
+	}
+	logger.Debug("release image verified")
+	return pinnedImage, corev1.ConditionFalse, releaseImageVerifiedReason,
+		fmt.Sprintf("The release image digest %s is signed and supports the architectures of the cluster", digest), nil
+}
+
+// releaseImageArchitectureMismatch returns a message describing why the machine pools of the InstallConfig of the
+// ClusterDeployment cannot be installed with a release image with the given architectures, or an empty message if
+// they can.
+func (r *ReconcileClusterDeployment) releaseImageArchitectureMismatch(cd *hivev1.ClusterDeployment, archs []string) (string, error) {
+	if cd.Spec.Provisioning == nil || cd.Spec.Provisioning.InstallConfigSecretRef == nil {
+		return "", nil
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), client.ObjectKey{Namespace: cd.Namespace, Name: cd.Spec.Provisioning.InstallConfigSecretRef.Name}, secret); err != nil {
+		return "", errors.Wrap(err, "failed to fetch install config secret")
+	}
+	machinePools, err := controllerutils.InstallConfigMachinePools(secret.Data[installConfigSecretInstallConfigKey])
+	if err != nil {
+		return "", err
+	}
+	releaseArchs := sets.NewString(archs...)
+	for _, mp := range machinePools {
+		if !releaseArchs.Has(mp.Architecture) {
+			return fmt.Sprintf("Machine pool %s has architecture %s, which the release image does not support: %v",
+				mp.Name, mp.Architecture, releaseArchs.List()), nil
+		}
+		if mp.InstanceType != "" && controllerutils.InstanceTypeArchitecture(mp.InstanceType) != mp.Architecture {
+			return fmt.Sprintf("Instance type %s of machine pool %s does not have architecture %s",
+				mp.InstanceType, mp.Name, mp.Architecture), nil
+		}
+	}
+	return "", nil
+}
+
+// releaseSignaturePublicKeys returns the public keys trusted to sign release images, from the secret configured in
+// HiveConfig.
+func (r *ReconcileClusterDeployment) releaseSignaturePublicKeys() ([][]byte, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), client.ObjectKey{Namespace: controllerutils.GetHiveNamespace(), Name: r.releaseSignatureKeysSecret}, secret); err != nil {
+		return nil, errors.Wrap(err, "could not load the release image public keys")
+	}
+	keys := make([][]byte, 0, len(secret.Data))
+	for _, key := range sets.StringKeySet(secret.Data).List() {
+		keys = append(keys, secret.Data[key])
+	}
+	return keys, nil
+}
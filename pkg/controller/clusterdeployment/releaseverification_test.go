@@ -0,0 +1,245 @@
+package clusterdeployment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/registryclient"
+	registrymock "github.com/openshift/hive/pkg/registryclient/mock"
+	"github.com/openshift/hive/pkg/releasesignature"
+	signaturemock "github.com/openshift/hive/pkg/releasesignature/mock"
+)
+
+func TestCheckReleaseImageForProvision(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	const (
+		releaseImage  = "quay.io/openshift-release-dev/ocp-release:4.15.3-multi"
+		digest        = "sha256:abc"
+		verifiedImage = "quay.io/openshift-release-dev/ocp-release@sha256:abc"
+		keysSecret    = "release-keys"
+	)
+	installConfigSecret := func(ic string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "install-config-secret"},
+			Data:       map[string][]byte{"install-config.yaml": []byte(ic)},
+		}
+	}
+	const armInstallConfig = `
+controlPlane:
+  name: master
+  architecture: arm64
+  platform:
+    aws:
+      type: m6g.xlarge
+compute:
+- name: worker
+  architecture: arm64
+`
+	keys := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: constants.DefaultHiveNamespace, Name: keysSecret},
+		Data:       map[string][]byte{"key": []byte("public key")},
+	}
+	pullSecret := testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}")
+
+	cases := []struct {
+		name            string
+		disabled        bool
+		existing        []runtime.Object
+		configureCD     func(*hivev1.ClusterDeployment)
+		archs           []string
+		archsErr        error
+		verifyErr       error
+		expectVerify    bool
+		expectedStatus  corev1.ConditionStatus
+		expectedReason  string
+		expectedRequeue bool
+		expectedImage   string
+	}{
+		{
+			name:          "disabled",
+			disabled:      true,
+			expectedImage: releaseImage,
+		},
+		{
+			name:           "verified",
+			existing:       []runtime.Object{pullSecret, keys, installConfigSecret(armInstallConfig)},
+			archs:          []string{"amd64", "arm64"},
+			expectVerify:   true,
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: releaseImageVerifiedReason,
+			expectedImage:  verifiedImage,
+		},
+		{
+			name: "already verified",
+			configureCD: func(cd *hivev1.ClusterDeployment) {
+				cd.Status.VerifiedReleaseImage = verifiedImage
+			},
+			expectedImage: verifiedImage,
+		},
+		{
+			name: "provision already started",
+			configureCD: func(cd *hivev1.ClusterDeployment) {
+				cd.Status.ProvisionRef = &corev1.LocalObjectReference{Name: "test-provision"}
+			},
+			expectedImage: releaseImage,
+		},
+		{
+			name:            "signature not verified",
+			existing:        []runtime.Object{pullSecret, keys, installConfigSecret(armInstallConfig)},
+			archs:           []string{"arm64"},
+			expectVerify:    true,
+			verifyErr:       releasesignature.ErrSignatureNotVerified,
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  releaseImageSignatureNotVerifiedReason,
+			expectedRequeue: true,
+		},
+		{
+			name:            "signature not found",
+			existing:        []runtime.Object{pullSecret, keys, installConfigSecret(armInstallConfig)},
+			archs:           []string{"arm64"},
+			expectVerify:    true,
+			verifyErr:       releasesignature.ErrSignatureNotFound,
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  releaseImageSignatureNotVerifiedReason,
+			expectedRequeue: true,
+		},
+		{
+			name:            "architecture not in release image",
+			existing:        []runtime.Object{pullSecret, keys, installConfigSecret(armInstallConfig)},
+			archs:           []string{"amd64"},
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  releaseImageArchitectureMismatchReason,
+			expectedRequeue: true,
+		},
+		{
+			name:            "default architecture not in release image",
+			existing:        []runtime.Object{pullSecret, keys, installConfigSecret("controlPlane:\n  name: master\n")},
+			archs:           []string{"arm64"},
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  releaseImageArchitectureMismatchReason,
+			expectedRequeue: true,
+		},
+		{
+			name: "instance type does not match architecture",
+			existing: []runtime.Object{pullSecret, keys, installConfigSecret(`
+controlPlane:
+  name: master
+  platform:
+    aws:
+      type: m6g.xlarge
+`)},
+			archs:           []string{"amd64", "arm64"},
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  releaseImageArchitectureMismatchReason,
+			expectedRequeue: true,
+		},
+		{
+			name:            "release image cannot be inspected",
+			existing:        []runtime.Object{pullSecret, keys, installConfigSecret(armInstallConfig)},
+			archsErr:        registryclient.ErrUnauthorized,
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  releaseImageVerificationErrorReason,
+			expectedRequeue: true,
+		},
+		{
+			name:            "public keys missing",
+			existing:        []runtime.Object{pullSecret, installConfigSecret(armInstallConfig)},
+			archs:           []string{"arm64"},
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  releaseImageVerificationErrorReason,
+			expectedRequeue: true,
+		},
+		{
+			name:            "verification failed",
+			existing:        []runtime.Object{pullSecret, keys, installConfigSecret(armInstallConfig)},
+			archs:           []string{"arm64"},
+			expectVerify:    true,
+			verifyErr:       errors.New("store unavailable"),
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  releaseImageVerificationErrorReason,
+			expectedRequeue: true,
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRegistryClient := registrymock.NewMockClient(mockCtrl)
+			mockVerifier := signaturemock.NewMockVerifier(mockCtrl)
+			mockRegistryClient.EXPECT().ImageArchitectures(gomock.Any(), verifiedImage).Return(test.archs, test.archsErr).AnyTimes()
+			mockRegistryClient.EXPECT().ImageDigest(gomock.Any(), releaseImage).Return(digest, nil).AnyTimes()
+			if test.expectVerify {
+				mockVerifier.EXPECT().Verify(gomock.Any(), digest).Return(test.verifyErr)
+			}
+
+			cd := testClusterDeployment()
+			cd.Spec.Provisioning.InstallConfigSecretRef = &corev1.LocalObjectReference{Name: "install-config-secret"}
+			if test.configureCD != nil {
+				test.configureCD(cd)
+			}
+			fakeClient := fake.NewFakeClientWithScheme(scheme.Scheme, append(test.existing, cd)...)
+			r := &ReconcileClusterDeployment{
+				Client:                     fakeClient,
+				scheme:                     scheme.Scheme,
+				logger:                     log.WithField("controller", "clusterDeployment"),
+				releaseSignatureKeysSecret: keysSecret,
+				registryClientFn: func(string) (registryclient.Client, error) {
+					return mockRegistryClient, nil
+				},
+				releaseSignatureVerifierFn: func(stores []string, publicKeys [][]byte) (releasesignature.Verifier, error) {
+					assert.Equal(t, [][]byte{[]byte("public key")}, publicKeys, "unexpected public keys")
+					return mockVerifier, nil
+				},
+			}
+			if !test.disabled {
+				r.releaseSignatureStores = []string{"https://signatures.example.com"}
+			}
+
+			image, result, err := r.checkReleaseImageForProvision(cd, releaseImage, r.logger)
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, test.expectedImage, image, "unexpected release image")
+			if test.expectedRequeue {
+				if assert.NotNil(t, result, "expected provision to be blocked") {
+					assert.Equal(t, releaseImageVerificationRequeue, result.RequeueAfter, "unexpected requeue")
+				}
+			} else {
+				assert.Nil(t, result, "unexpected result")
+			}
+
+			actual := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: testName}, actual))
+			if test.expectedReason == releaseImageVerifiedReason {
+				assert.Equal(t, verifiedImage, actual.Status.VerifiedReleaseImage, "unexpected verified release image")
+			} else if test.expectedStatus != "" {
+				assert.Empty(t, actual.Status.VerifiedReleaseImage, "unexpected verified release image")
+			}
+			cond := controllerutils.FindClusterDeploymentCondition(actual.Status.Conditions, hivev1.ReleaseImageVerificationFailedClusterDeploymentCondition)
+			if test.expectedStatus == "" {
+				assert.Nil(t, cond, "unexpected ReleaseImageVerificationFailed condition")
+				return
+			}
+			if assert.NotNil(t, cond, "missing ReleaseImageVerificationFailed condition") {
+				assert.Equal(t, test.expectedStatus, cond.Status, "unexpected condition status")
+				assert.Equal(t, test.expectedReason, cond.Reason, "unexpected condition reason")
+			}
+		})
+	}
+}
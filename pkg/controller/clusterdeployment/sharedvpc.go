@@ -0,0 +1,293 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// sharedSubnetTagValue is the value of the kubernetes.io/cluster/<infraID> tag on the shared subnets of a
+	// cluster, marking them as used but not owned by the cluster.
+	sharedSubnetTagValue = "shared"
+
+	sharedVPCConfiguredReason          = "SharedVPCConfigured"
+	sharedVPCWaitingForZoneReason      = "WaitingForPrivateHostedZone"
+	sharedVPCConfigurationFailedReason = "SharedVPCConfigurationFailed"
+	sharedVPCCleanedUpReason           = "SharedVPCCleanedUp"
+	sharedVPCRequeueAfter              = time.Minute
+)
+
+// requestIDRE matches the request IDs in AWS errors, which are scrubbed from condition messages so that a repeated
+// failure does not change the condition.
+var requestIDRE = regexp.MustCompile(`(request id|Request ID): ([-0-9a-f]+)`)
+
+// platformAWSClientOptions returns the options for an AWS client authenticated with the platform credentials of the
+// ClusterDeployment.
+func platformAWSClientOptions(cd *hivev1.ClusterDeployment) awsclient.Options {
+	return awsclient.Options{
+		Controller:        hivev1.ClusterDeploymentControllerName,
+		Region:            cd.Spec.Platform.AWS.Region,
+		CredentialsSource: awsclient.NewCredentialsSource(cd.Namespace, &cd.Spec.Platform.AWS.CredentialsSecretRef, cd.Spec.Platform.AWS.CredentialsAssumeRole, cd.Spec.Platform.AWS.CredentialsSource),
+		ServiceEndpoints:  cd.Spec.Platform.AWS.ServiceEndpoints,
+		ProxySecret:       &awsclient.ProxySecretSource{Namespace: cd.Namespace, Ref: cd.Spec.CloudClientProxySecretRef},
+	}
+}
+
+// sharedVPCAWSClientOptions returns the options for an AWS client authenticated with the credentials of the network
+// account owning the shared VPC of the ClusterDeployment.
+func sharedVPCAWSClientOptions(cd *hivev1.ClusterDeployment) awsclient.Options {
+	sharedVPC := cd.Spec.Platform.AWS.SharedVPC
+	return awsclient.Options{
+		Controller:        hivev1.ClusterDeploymentControllerName,
+		Region:            cd.Spec.Platform.AWS.Region,
+		CredentialsSource: awsclient.NewCredentialsSource(cd.Namespace, sharedVPC.CredentialsSecretRef, sharedVPC.CredentialsAssumeRole, nil),
+		ServiceEndpoints:  cd.Spec.Platform.AWS.ServiceEndpoints,
+		ProxySecret:       &awsclient.ProxySecretSource{Namespace: cd.Namespace, Ref: cd.Spec.CloudClientProxySecretRef},
+	}
+}
+
+func usesAWSSharedVPC(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.AWS != nil && cd.Spec.Platform.AWS.SharedVPC != nil
+}
+
+// reconcileSharedVPC prepares the shared VPC of a cluster being installed into subnets shared from another account.
+// Once the infra ID of the cluster is known, the shared subnets are tagged for the cluster with the credentials of the
+// network account. Once the installer has created the private hosted zone of the cluster, the association of the zone
+// with the shared VPC is authorized with the platform credentials and made with the credentials of the network
+// account. The result is recorded in the AWSSharedVPCReady condition. A non-zero duration is returned while the
+// private hosted zone is still to be associated.
+func (r *ReconcileClusterDeployment) reconcileSharedVPC(ctx context.Context, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (time.Duration, error) {
+	if !usesAWSSharedVPC(cd) || cd.Spec.ClusterMetadata == nil || cd.Spec.ClusterMetadata.InfraID == "" {
+		return 0, nil
+	}
+	ready := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.AWSSharedVPCReadyClusterDeploymentCondition)
+	if ready != nil && ready.Status == corev1.ConditionTrue {
+		return 0, nil
+	}
+	logger = logger.WithField("infraID", cd.Spec.ClusterMetadata.InfraID)
+
+	associated, err := r.setupSharedVPC(ctx, cd, logger)
+	status, reason, message := corev1.ConditionFalse, sharedVPCWaitingForZoneReason, "Waiting for the private hosted zone of the cluster to be created"
+	switch {
+	case err != nil:
+		logger.WithError(err).Error("failed to set up the shared VPC")
+		reason = sharedVPCConfigurationFailedReason
+		message = requestIDRE.ReplaceAllString(err.Error(), "${1}: XXXX")
+	case associated:
+		status = corev1.ConditionTrue
+		reason = sharedVPCConfiguredReason
+		message = "Shared subnets are tagged and the private hosted zone is associated with the shared VPC"
+	}
+	if err := r.setSharedVPCReadyCondition(cd, status, reason, message, logger); err != nil {
+		return 0, err
+	}
+	if status == corev1.ConditionTrue {
+		return 0, nil
+	}
+	// A failure is recorded in the condition and retried without holding up the rest of the reconcile.
+	return sharedVPCRequeueAfter, nil
+}
+
+// setupSharedVPC tags the shared subnets and associates the private hosted zone of the cluster with the shared VPC,
+// returning whether the private hosted zone has been associated.
+func (r *ReconcileClusterDeployment) setupSharedVPC(ctx context.Context, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (bool, error) {
+	networkClient, err := r.awsClientFn(r.Client, sharedVPCAWSClientOptions(cd))
+	if err != nil {
+		return false, errors.Wrap(err, "could not create AWS client for the network account")
+	}
+	vpcID, err := sharedVPCID(ctx, networkClient, cd.Spec.Platform.AWS.SharedVPC.Subnets)
+	if err != nil {
+		return false, err
+	}
+	if _, err := networkClient.CreateTags(ctx, &ec2.CreateTagsInput{
+		Resources: aws.StringSlice(cd.Spec.Platform.AWS.SharedVPC.Subnets),
+		Tags: []*ec2.Tag{{
+			Key:   aws.String(sharedSubnetTagKey(cd.Spec.ClusterMetadata.InfraID)),
+			Value: aws.String(sharedSubnetTagValue),
+		}},
+	}); err != nil {
+		return false, errors.Wrap(err, "could not tag the shared subnets")
+	}
+	logger.Debug("tagged the shared subnets for the cluster")
+
+	clusterClient, err := r.awsClientFn(r.Client, platformAWSClientOptions(cd))
+	if err != nil {
+		return false, errors.Wrap(err, "could not create AWS client")
+	}
+	zoneID, err := privateHostedZoneID(ctx, clusterClient, cd)
+	if err != nil || zoneID == "" {
+		return false, err
+	}
+	zone, err := clusterClient.GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: aws.String(zoneID)})
+	if err != nil {
+		return false, errors.Wrap(err, "could not get the private hosted zone")
+	}
+	for _, vpc := range zone.VPCs {
+		if aws.StringValue(vpc.VPCId) == vpcID {
+			return true, nil
+		}
+	}
+
+	vpc := &route53.VPC{
+		VPCId:     aws.String(vpcID),
+		VPCRegion: aws.String(cd.Spec.Platform.AWS.Region),
+	}
+	logger = logger.WithFields(log.Fields{"hostedZoneID": zoneID, "vpc": vpcID})
+	if _, err := clusterClient.CreateVPCAssociationAuthorization(ctx, &route53.CreateVPCAssociationAuthorizationInput{
+		HostedZoneId: aws.String(zoneID),
+		VPC:          vpc,
+	}); err != nil {
+		return false, errors.Wrap(err, "could not authorize the association of the private hosted zone with the shared VPC")
+	}
+	if _, err := networkClient.AssociateVPCWithHostedZone(ctx, &route53.AssociateVPCWithHostedZoneInput{
+		HostedZoneId: aws.String(zoneID),
+		VPC:          vpc,
+	}); err != nil {
+		return false, errors.Wrap(err, "could not associate the private hosted zone with the shared VPC")
+	}
+	// The authorization is no longer needed once the association is made.
+	if _, err := clusterClient.DeleteVPCAssociationAuthorization(ctx, &route53.DeleteVPCAssociationAuthorizationInput{
+		HostedZoneId: aws.String(zoneID),
+		VPC:          vpc,
+	}); err != nil {
+		logger.WithError(err).Warn("could not delete the authorization of the association of the private hosted zone")
+	}
+	logger.Info("associated the private hosted zone with the shared VPC")
+	return true, nil
+}
+
+// cleanupSharedVPC disassociates the private hosted zone of the cluster from the shared VPC and removes the tags of
+// the cluster from the shared subnets. The installer cannot do either when destroying the cluster as the VPC belongs
+// to another account.
+func (r *ReconcileClusterDeployment) cleanupSharedVPC(ctx context.Context, cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	if !usesAWSSharedVPC(cd) || cd.Spec.ClusterMetadata == nil || cd.Spec.ClusterMetadata.InfraID == "" {
+		return nil
+	}
+	ready := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.AWSSharedVPCReadyClusterDeploymentCondition)
+	if ready != nil && ready.Reason == sharedVPCCleanedUpReason {
+		return nil
+	}
+	networkClient, err := r.awsClientFn(r.Client, sharedVPCAWSClientOptions(cd))
+	if err != nil {
+		return errors.Wrap(err, "could not create AWS client for the network account")
+	}
+	vpcID, err := sharedVPCID(ctx, networkClient, cd.Spec.Platform.AWS.SharedVPC.Subnets)
+	if err != nil {
+		return err
+	}
+	clusterClient, err := r.awsClientFn(r.Client, platformAWSClientOptions(cd))
+	if err != nil {
+		return errors.Wrap(err, "could not create AWS client")
+	}
+	zoneID, err := privateHostedZoneID(ctx, clusterClient, cd)
+	if err != nil {
+		return err
+	}
+	if zoneID != "" {
+		zone, err := clusterClient.GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: aws.String(zoneID)})
+		if err != nil {
+			return errors.Wrap(err, "could not get the private hosted zone")
+		}
+		for _, vpc := range zone.VPCs {
+			if aws.StringValue(vpc.VPCId) != vpcID {
+				continue
+			}
+			if _, err := networkClient.DisassociateVPCFromHostedZone(ctx, &route53.DisassociateVPCFromHostedZoneInput{
+				HostedZoneId: aws.String(zoneID),
+				VPC:          vpc,
+			}); err != nil {
+				return errors.Wrap(err, "could not disassociate the private hosted zone from the shared VPC")
+			}
+			logger.WithFields(log.Fields{"hostedZoneID": zoneID, "vpc": vpcID}).Info("disassociated the private hosted zone from the shared VPC")
+		}
+	}
+	if _, err := networkClient.DeleteTags(ctx, &ec2.DeleteTagsInput{
+		Resources: aws.StringSlice(cd.Spec.Platform.AWS.SharedVPC.Subnets),
+		Tags:      []*ec2.Tag{{Key: aws.String(sharedSubnetTagKey(cd.Spec.ClusterMetadata.InfraID))}},
+	}); err != nil {
+		return errors.Wrap(err, "could not remove the cluster tags from the shared subnets")
+	}
+	logger.Info("removed the cluster tags from the shared subnets")
+	return r.setSharedVPCReadyCondition(cd, corev1.ConditionFalse, sharedVPCCleanedUpReason,
+		"Private hosted zone is disassociated from the shared VPC and the cluster tags are removed from the shared subnets", logger)
+}
+
+func (r *ReconcileClusterDeployment) setSharedVPCReadyCondition(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason, message string, logger log.FieldLogger) error {
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.AWSSharedVPCReadyClusterDeploymentCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conditions
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update AWSSharedVPCReady condition")
+		return err
+	}
+	return nil
+}
+
+// sharedVPCID returns the ID of the VPC of the shared subnets, which must all be in the same VPC.
+func sharedVPCID(ctx context.Context, client awsclient.Client, subnets []string) (string, error) {
+	resp, err := client.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{SubnetIds: aws.StringSlice(subnets)})
+	if err != nil {
+		return "", errors.Wrap(err, "could not describe the shared subnets")
+	}
+	vpcID := ""
+	for _, subnet := range resp.Subnets {
+		switch id := aws.StringValue(subnet.VpcId); {
+		case vpcID == "":
+			vpcID = id
+		case id != vpcID:
+			return "", fmt.Errorf("shared subnets are in more than one VPC: %s, %s", vpcID, id)
+		}
+	}
+	if vpcID == "" {
+		return "", errors.New("shared subnets not found")
+	}
+	return vpcID, nil
+}
+
+// privateHostedZoneID returns the ID of the private hosted zone of the cluster, or an empty string if the installer
+// has not created it yet.
+func privateHostedZoneID(ctx context.Context, client awsclient.Client, cd *hivev1.ClusterDeployment) (string, error) {
+	name := fmt.Sprintf("%s.%s.", cd.Spec.ClusterName, cd.Spec.BaseDomain)
+	resp, err := client.ListHostedZonesByName(ctx, &route53.ListHostedZonesByNameInput{DNSName: aws.String(name)})
+	if err != nil {
+		return "", errors.Wrap(err, "could not list hosted zones")
+	}
+	for _, zone := range resp.HostedZones {
+		if aws.StringValue(zone.Name) != name {
+			// Hosted zones are listed in order of name, so no further zone has the name.
+			break
+		}
+		if zone.Config != nil && aws.BoolValue(zone.Config.PrivateZone) {
+			return strings.TrimPrefix(aws.StringValue(zone.Id), "/hostedzone/"), nil
+		}
+	}
+	return "", nil
+}
+
+func sharedSubnetTagKey(infraID string) string {
+	return fmt.Sprintf("kubernetes.io/cluster/%s", infraID)
+}
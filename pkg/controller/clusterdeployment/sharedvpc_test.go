@@ -0,0 +1,312 @@
+package clusterdeployment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/awsclient"
+	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	testSharedVPCID    = "vpc-shared"
+	testHostedZoneID   = "Z1234"
+	testSharedSubnetID = "subnet-shared"
+)
+
+func TestReconcileSharedVPC(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	cases := []struct {
+		name            string
+		cd              *hivev1.ClusterDeployment
+		setupMock       func(*mockaws.MockClient)
+		expectedStatus  corev1.ConditionStatus
+		expectedReason  string
+		expectedRequeue bool
+	}{
+		{
+			name: "no shared VPC",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeploymentWithProvision()
+				cd.Spec.BaseDomain = "example.com"
+				return cd
+			}(),
+		},
+		{
+			name: "no infra ID",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testSharedVPCClusterDeployment()
+				cd.Spec.ClusterMetadata = nil
+				return cd
+			}(),
+		},
+		{
+			name: "already ready",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testSharedVPCClusterDeployment()
+				cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{{
+					Type:   hivev1.AWSSharedVPCReadyClusterDeploymentCondition,
+					Status: corev1.ConditionTrue,
+					Reason: sharedVPCConfiguredReason,
+				}}
+				return cd
+			}(),
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: sharedVPCConfiguredReason,
+		},
+		{
+			name: "waiting for private hosted zone",
+			cd:   testSharedVPCClusterDeployment(),
+			setupMock: func(m *mockaws.MockClient) {
+				mockDescribeSharedSubnets(m)
+				mockTagSharedSubnets(m)
+				m.EXPECT().ListHostedZonesByName(gomock.Any(), gomock.Any()).Return(&route53.ListHostedZonesByNameOutput{}, nil)
+			},
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  sharedVPCWaitingForZoneReason,
+			expectedRequeue: true,
+		},
+		{
+			name: "associate private hosted zone",
+			cd:   testSharedVPCClusterDeployment(),
+			setupMock: func(m *mockaws.MockClient) {
+				mockDescribeSharedSubnets(m)
+				mockTagSharedSubnets(m)
+				mockListPrivateHostedZone(m)
+				m.EXPECT().GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
+					VPCs: []*route53.VPC{{VPCId: aws.String("vpc-other")}},
+				}, nil)
+				m.EXPECT().CreateVPCAssociationAuthorization(gomock.Any(), gomock.Any()).Return(nil, nil)
+				m.EXPECT().AssociateVPCWithHostedZone(gomock.Any(), &route53.AssociateVPCWithHostedZoneInput{
+					HostedZoneId: aws.String(testHostedZoneID),
+					VPC: &route53.VPC{
+						VPCId:     aws.String(testSharedVPCID),
+						VPCRegion: aws.String("us-east-1"),
+					},
+				}).Return(nil, nil)
+				m.EXPECT().DeleteVPCAssociationAuthorization(gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: sharedVPCConfiguredReason,
+		},
+		{
+			name: "private hosted zone already associated",
+			cd:   testSharedVPCClusterDeployment(),
+			setupMock: func(m *mockaws.MockClient) {
+				mockDescribeSharedSubnets(m)
+				mockTagSharedSubnets(m)
+				mockListPrivateHostedZone(m)
+				m.EXPECT().GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
+					VPCs: []*route53.VPC{{VPCId: aws.String(testSharedVPCID)}},
+				}, nil)
+			},
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: sharedVPCConfiguredReason,
+		},
+		{
+			name: "tagging fails",
+			cd:   testSharedVPCClusterDeployment(),
+			setupMock: func(m *mockaws.MockClient) {
+				mockDescribeSharedSubnets(m)
+				m.EXPECT().CreateTags(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+			},
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  sharedVPCConfigurationFailedReason,
+			expectedRequeue: true,
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockAWSClient := mockaws.NewMockClient(mockCtrl)
+			if test.setupMock != nil {
+				test.setupMock(mockAWSClient)
+			}
+			fakeClient := fake.NewFakeClient(test.cd)
+			r := &ReconcileClusterDeployment{
+				Client: fakeClient,
+				scheme: scheme.Scheme,
+				logger: log.WithField("controller", "clusterDeployment"),
+				awsClientFn: func(client.Client, awsclient.Options) (awsclient.Client, error) {
+					return mockAWSClient, nil
+				},
+			}
+
+			requeueAfter, err := r.reconcileSharedVPC(context.TODO(), test.cd, r.logger)
+			require.NoError(t, err, "unexpected error")
+			if test.expectedRequeue {
+				assert.Equal(t, sharedVPCRequeueAfter, requeueAfter, "unexpected requeue")
+			} else {
+				assert.Equal(t, time.Duration(0), requeueAfter, "unexpected requeue")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: testName}, cd))
+			cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.AWSSharedVPCReadyClusterDeploymentCondition)
+			if test.expectedStatus == "" {
+				assert.Nil(t, cond, "unexpected AWSSharedVPCReady condition")
+				return
+			}
+			if assert.NotNil(t, cond, "missing AWSSharedVPCReady condition") {
+				assert.Equal(t, test.expectedStatus, cond.Status, "unexpected condition status")
+				assert.Equal(t, test.expectedReason, cond.Reason, "unexpected condition reason")
+			}
+		})
+	}
+}
+
+func TestCleanupSharedVPC(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	cases := []struct {
+		name           string
+		cd             *hivev1.ClusterDeployment
+		setupMock      func(*mockaws.MockClient)
+		expectedReason string
+	}{
+		{
+			name: "disassociate and untag",
+			cd:   testSharedVPCClusterDeployment(),
+			setupMock: func(m *mockaws.MockClient) {
+				mockDescribeSharedSubnets(m)
+				mockListPrivateHostedZone(m)
+				m.EXPECT().GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
+					VPCs: []*route53.VPC{
+						{VPCId: aws.String("vpc-other")},
+						{VPCId: aws.String(testSharedVPCID), VPCRegion: aws.String("us-east-1")},
+					},
+				}, nil)
+				m.EXPECT().DisassociateVPCFromHostedZone(gomock.Any(), &route53.DisassociateVPCFromHostedZoneInput{
+					HostedZoneId: aws.String(testHostedZoneID),
+					VPC:          &route53.VPC{VPCId: aws.String(testSharedVPCID), VPCRegion: aws.String("us-east-1")},
+				}).Return(nil, nil)
+				mockUntagSharedSubnets(m)
+			},
+			expectedReason: sharedVPCCleanedUpReason,
+		},
+		{
+			name: "private hosted zone already gone",
+			cd:   testSharedVPCClusterDeployment(),
+			setupMock: func(m *mockaws.MockClient) {
+				mockDescribeSharedSubnets(m)
+				m.EXPECT().ListHostedZonesByName(gomock.Any(), gomock.Any()).Return(&route53.ListHostedZonesByNameOutput{}, nil)
+				mockUntagSharedSubnets(m)
+			},
+			expectedReason: sharedVPCCleanedUpReason,
+		},
+		{
+			name: "already cleaned up",
+			cd: func() *hivev1.ClusterDeployment {
+				cd := testSharedVPCClusterDeployment()
+				cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{{
+					Type:   hivev1.AWSSharedVPCReadyClusterDeploymentCondition,
+					Status: corev1.ConditionFalse,
+					Reason: sharedVPCCleanedUpReason,
+				}}
+				return cd
+			}(),
+			expectedReason: sharedVPCCleanedUpReason,
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockAWSClient := mockaws.NewMockClient(mockCtrl)
+			if test.setupMock != nil {
+				test.setupMock(mockAWSClient)
+			}
+			fakeClient := fake.NewFakeClient(test.cd)
+			r := &ReconcileClusterDeployment{
+				Client: fakeClient,
+				scheme: scheme.Scheme,
+				logger: log.WithField("controller", "clusterDeployment"),
+				awsClientFn: func(client.Client, awsclient.Options) (awsclient.Client, error) {
+					return mockAWSClient, nil
+				},
+			}
+
+			require.NoError(t, r.cleanupSharedVPC(context.TODO(), test.cd, r.logger), "unexpected error")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: testName}, cd))
+			cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.AWSSharedVPCReadyClusterDeploymentCondition)
+			if assert.NotNil(t, cond, "missing AWSSharedVPCReady condition") {
+				assert.Equal(t, test.expectedReason, cond.Reason, "unexpected condition reason")
+			}
+		})
+	}
+}
+
+func testSharedVPCClusterDeployment() *hivev1.ClusterDeployment {
+	cd := testClusterDeploymentWithProvision()
+	cd.Spec.BaseDomain = "example.com"
+	cd.Spec.Platform.AWS.SharedVPC = &hivev1aws.SharedVPC{
+		Subnets:              []string{testSharedSubnetID},
+		CredentialsSecretRef: &corev1.LocalObjectReference{Name: "network-credentials"},
+	}
+	return cd
+}
+
+func mockDescribeSharedSubnets(m *mockaws.MockClient) {
+	m.EXPECT().DescribeSubnets(gomock.Any(), &ec2.DescribeSubnetsInput{SubnetIds: aws.StringSlice([]string{testSharedSubnetID})}).
+		Return(&ec2.DescribeSubnetsOutput{
+			Subnets: []*ec2.Subnet{{SubnetId: aws.String(testSharedSubnetID), VpcId: aws.String(testSharedVPCID)}},
+		}, nil)
+}
+
+func mockTagSharedSubnets(m *mockaws.MockClient) {
+	m.EXPECT().CreateTags(gomock.Any(), &ec2.CreateTagsInput{
+		Resources: aws.StringSlice([]string{testSharedSubnetID}),
+		Tags: []*ec2.Tag{{
+			Key:   aws.String("kubernetes.io/cluster/" + testInfraID),
+			Value: aws.String("shared"),
+		}},
+	}).Return(nil, nil)
+}
+
+func mockUntagSharedSubnets(m *mockaws.MockClient) {
+	m.EXPECT().DeleteTags(gomock.Any(), &ec2.DeleteTagsInput{
+		Resources: aws.StringSlice([]string{testSharedSubnetID}),
+		Tags:      []*ec2.Tag{{Key: aws.String("kubernetes.io/cluster/" + testInfraID)}},
+	}).Return(nil, nil)
+}
+
+func mockListPrivateHostedZone(m *mockaws.MockClient) {
+	name := testClusterName + ".example.com."
+	m.EXPECT().ListHostedZonesByName(gomock.Any(), &route53.ListHostedZonesByNameInput{DNSName: aws.String(name)}).
+		Return(&route53.ListHostedZonesByNameOutput{
+			HostedZones: []*route53.HostedZone{
+				{
+					Id:     aws.String("/hostedzone/Zpublic"),
+					Name:   aws.String(name),
+					Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(false)},
+				},
+				{
+					Id:     aws.String("/hostedzone/" + testHostedZoneID),
+					Name:   aws.String(name),
+					Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(true)},
+				},
+			},
+		}, nil)
+}
@@ -0,0 +1,202 @@
+package clusterdeploymentsummary
+
+import (
+	"context"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.ClusterDeploymentSummaryControllerName
+
+	healthProvisioning    = "Provisioning"
+	healthProvisionFailed = "ProvisionFailed"
+	healthHealthy         = "Healthy"
+	healthUnreachable     = "Unreachable"
+	healthDeprovisioning  = "Deprovisioning"
+
+	unknown = "Unknown"
+)
+
+// Add creates a new ClusterDeploymentSummary Controller and adds it to the Manager with default RBAC. The Manager
+// will set fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := controllerutils.ControllerLogEntry(ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
+	return &ReconcileClusterDeploymentSummary{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controllerutils.NewController("clusterdeploymentsummary-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Every change to a ClusterDeployment, and to the summary itself, is mapped to the one summary. The queue
+	// collapses the resulting requests so the summary is recomputed once per batch of changes.
+	summaryRequest := handler.EnqueueRequestsFromMapFunc(func(client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: hivev1.ClusterDeploymentSummaryName}}}
+	})
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, summaryRequest); err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeploymentSummary
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeploymentSummary{}}, summaryRequest); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterDeploymentSummary{}
+
+// ReconcileClusterDeploymentSummary maintains the ClusterDeploymentSummary from the ClusterDeployments on the hub.
+type ReconcileClusterDeploymentSummary struct {
+	client.Client
+}
+
+// Reconcile counts all of the ClusterDeployments by health, power state, version and platform, and records the
+// counts in the status of the ClusterDeploymentSummary, creating the summary if it does not exist.
+func (r *ReconcileClusterDeploymentSummary) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterDeploymentSummary", request.NamespacedName)
+	logger.Debug("reconciling cluster deployment summary")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	if request.Name != hivev1.ClusterDeploymentSummaryName {
+		logger.Debug("ignoring cluster deployment summary with unexpected name")
+		return reconcile.Result{}, nil
+	}
+
+	summary := &hivev1.ClusterDeploymentSummary{}
+	switch err := r.Get(context.TODO(), request.NamespacedName, summary); {
+	case apierrors.IsNotFound(err):
+		logger.Info("creating cluster deployment summary")
+		summary.Name = hivev1.ClusterDeploymentSummaryName
+		if err := r.Create(context.TODO(), summary); err != nil {
+			logger.WithError(err).Error("error creating cluster deployment summary")
+			return reconcile.Result{}, err
+		}
+	case err != nil:
+		logger.WithError(err).Error("error getting cluster deployment summary")
+		return reconcile.Result{}, err
+	}
+
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := r.List(context.TODO(), cdList); err != nil {
+		logger.WithError(err).Error("error listing cluster deployments")
+		return reconcile.Result{}, err
+	}
+
+	status := summarize(cdList.Items)
+	// A summary that has never been updated is always updated, so that LastUpdated shows that the summary is
+	// being maintained even when there are no ClusterDeployments.
+	status.LastUpdated = summary.Status.LastUpdated
+	if status.LastUpdated != nil && reflect.DeepEqual(status, summary.Status) {
+		logger.Debug("cluster deployment summary is up to date")
+		return reconcile.Result{}, nil
+	}
+	now := metav1.Now()
+	status.LastUpdated = &now
+	summary.Status = status
+	if err := r.Status().Update(context.TODO(), summary); err != nil {
+		logger.WithError(err).Error("error updating cluster deployment summary status")
+		return reconcile.Result{}, err
+	}
+	logger.WithField("total", status.Total).Info("updated cluster deployment summary")
+	return reconcile.Result{}, nil
+}
+
+func summarize(cds []hivev1.ClusterDeployment) hivev1.ClusterDeploymentSummaryStatus {
+	status := hivev1.ClusterDeploymentSummaryStatus{}
+	for i := range cds {
+		cd := &cds[i]
+		status.Total++
+		increment(&status.Health, health(cd))
+		increment(&status.Platforms, labelOrUnknown(cd, hivev1.HiveClusterPlatformLabel))
+		if !cd.Spec.Installed {
+			continue
+		}
+		increment(&status.PowerStates, powerState(cd))
+		increment(&status.Versions, labelOrUnknown(cd, constants.VersionMajorMinorPatchLabel))
+	}
+	return status
+}
+
+func health(cd *hivev1.ClusterDeployment) string {
+	switch {
+	case cd.DeletionTimestamp != nil:
+		return healthDeprovisioning
+	case !cd.Spec.Installed:
+		if isConditionTrue(cd, hivev1.ProvisionStoppedCondition) {
+			return healthProvisionFailed
+		}
+		return healthProvisioning
+	case isConditionTrue(cd, hivev1.UnreachableCondition):
+		return healthUnreachable
+	default:
+		return healthHealthy
+	}
+}
+
+func powerState(cd *hivev1.ClusterDeployment) string {
+	if cd.Spec.PowerState == "" {
+		return string(hivev1.RunningClusterPowerState)
+	}
+	return string(cd.Spec.PowerState)
+}
+
+func labelOrUnknown(cd *hivev1.ClusterDeployment, label string) string {
+	if value := cd.Labels[label]; value != "" {
+		return value
+	}
+	return unknown
+}
+
+func isConditionTrue(cd *hivev1.ClusterDeployment, conditionType hivev1.ClusterDeploymentConditionType) bool {
+	cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, conditionType)
+	return cond != nil && cond.Status == corev1.ConditionTrue
+}
+
+func increment(counts *map[string]int32, key string) {
+	if *counts == nil {
+		*counts = map[string]int32{}
+	}
+	(*counts)[key]++
+}
@@ -0,0 +1,146 @@
+package clusterdeploymentsummary
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	"github.com/openshift/hive/pkg/test/generic"
+)
+
+const (
+	testNamespace = "test-namespace"
+)
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestReconcileClusterDeploymentSummary(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	cdBuilder := testcd.FullBuilder(testNamespace, "test-cd", scheme.Scheme)
+	installed := func(name, platform, version string, opts ...testcd.Option) runtime.Object {
+		return cdBuilder.Build(append([]testcd.Option{
+			testcd.WithName(name),
+			testcd.Installed(),
+			testcd.WithLabel(hivev1.HiveClusterPlatformLabel, platform),
+			testcd.WithLabel(constants.VersionMajorMinorPatchLabel, version),
+		}, opts...)...)
+	}
+	condition := func(conditionType hivev1.ClusterDeploymentConditionType, status corev1.ConditionStatus, reason string) testcd.Option {
+		return testcd.WithCondition(hivev1.ClusterDeploymentCondition{Type: conditionType, Status: status, Reason: reason})
+	}
+	lastUpdated := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+
+	tests := []struct {
+		name              string
+		existing          []runtime.Object
+		expected          hivev1.ClusterDeploymentSummaryStatus
+		expectLastUpdated *metav1.Time
+	}{
+		{
+			name: "no cluster deployments",
+		},
+		{
+			name: "mixed cluster deployments",
+			existing: []runtime.Object{
+				installed("running", "aws", "4.8.2", testcd.WithPowerState(hivev1.RunningClusterPowerState)),
+				installed("hibernating", "aws", "4.8.2", testcd.WithPowerState(hivev1.HibernatingClusterPowerState),
+					condition(hivev1.ClusterHibernatingCondition, corev1.ConditionFalse, hivev1.StoppingHibernationReason)),
+				installed("unreachable", "gcp", "4.9.0",
+					condition(hivev1.UnreachableCondition, corev1.ConditionTrue, "ErrorConnectingToCluster")),
+				cdBuilder.Build(testcd.WithName("provisioning"), testcd.WithLabel(hivev1.HiveClusterPlatformLabel, "azure")),
+				cdBuilder.Build(testcd.WithName("failed"), testcd.WithLabel(hivev1.HiveClusterPlatformLabel, "azure"),
+					condition(hivev1.ProvisionStoppedCondition, corev1.ConditionTrue, "InstallAttemptsLimitReached")),
+				cdBuilder.GenericOptions(generic.Deleted(), generic.WithFinalizer(hivev1.FinalizerDeprovision)).Build(
+					testcd.WithName("deleting")),
+			},
+			expected: hivev1.ClusterDeploymentSummaryStatus{
+				Total: 6,
+				Health: map[string]int32{
+					healthHealthy:         2,
+					healthUnreachable:     1,
+					healthProvisioning:    1,
+					healthProvisionFailed: 1,
+					healthDeprovisioning:  1,
+				},
+				PowerStates: map[string]int32{
+					string(hivev1.RunningClusterPowerState):     2,
+					string(hivev1.HibernatingClusterPowerState): 1,
+				},
+				Versions: map[string]int32{
+					"4.8.2": 2,
+					"4.9.0": 1,
+				},
+				Platforms: map[string]int32{
+					"aws":   2,
+					"gcp":   1,
+					"azure": 2,
+					unknown: 1,
+				},
+			},
+		},
+		{
+			name: "unchanged summary is not updated",
+			existing: []runtime.Object{
+				installed("running", "aws", "4.8.2"),
+				&hivev1.ClusterDeploymentSummary{
+					ObjectMeta: metav1.ObjectMeta{Name: hivev1.ClusterDeploymentSummaryName},
+					Status: hivev1.ClusterDeploymentSummaryStatus{
+						LastUpdated: &lastUpdated,
+						Total:       1,
+						Health:      map[string]int32{healthHealthy: 1},
+						PowerStates: map[string]int32{string(hivev1.RunningClusterPowerState): 1},
+						Versions:    map[string]int32{"4.8.2": 1},
+						Platforms:   map[string]int32{"aws": 1},
+					},
+				},
+			},
+			expected: hivev1.ClusterDeploymentSummaryStatus{
+				Total:       1,
+				Health:      map[string]int32{healthHealthy: 1},
+				PowerStates: map[string]int32{string(hivev1.RunningClusterPowerState): 1},
+				Versions:    map[string]int32{"4.8.2": 1},
+				Platforms:   map[string]int32{"aws": 1},
+			},
+			expectLastUpdated: &lastUpdated,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, test.existing...)
+			r := &ReconcileClusterDeploymentSummary{Client: c}
+
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: hivev1.ClusterDeploymentSummaryName},
+			})
+			require.NoError(t, err, "unexpected error from Reconcile")
+
+			summary := &hivev1.ClusterDeploymentSummary{}
+			err = c.Get(context.TODO(), types.NamespacedName{Name: hivev1.ClusterDeploymentSummaryName}, summary)
+			require.NoError(t, err, "error getting cluster deployment summary")
+			if assert.NotNil(t, summary.Status.LastUpdated, "expected last updated to be set") && test.expectLastUpdated != nil {
+				assert.True(t, test.expectLastUpdated.Equal(summary.Status.LastUpdated), "expected last updated to be unchanged")
+			}
+			summary.Status.LastUpdated = nil
+			assert.Equal(t, test.expected, summary.Status, "unexpected cluster deployment summary status")
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package clusterdeprovision
 
 import (
+	"context"
+
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	log "github.com/sirupsen/logrus"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -12,5 +14,5 @@ type Actuator interface {
 	CanHandle(cd *hivev1.ClusterDeprovision) bool
 
 	// TestCredentials returns nil if the credential check succeeds. Otherwise returns the error.
-	TestCredentials(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error
+	TestCredentials(ctx context.Context, clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error
 }
@@ -1,18 +1,14 @@
 package clusterdeprovision
 
 import (
-	"os"
-
+	"context"
 	log "github.com/sirupsen/logrus"
-	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/aws/aws-sdk-go/service/sts"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
-	"github.com/openshift/hive/pkg/constants"
-	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 func init() {
@@ -34,13 +30,13 @@ func (a *awsActuator) CanHandle(clusterDeprovision *hivev1.ClusterDeprovision) b
 }
 
 // TestCredentials ensures that the the aws credentials are usable.
-func (a *awsActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error {
+func (a *awsActuator) TestCredentials(ctx context.Context, clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error {
 	awsClient, err := a.awsClientFn(clusterDeprovision, c, logger)
 	if err != nil {
 		return err
 	}
 
-	_, err = awsClient.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	_, err = awsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
 	if err != nil {
 		// Code "InvalidClientTokenId" means aws_access_key_id is invalid
 		// Code "SignatureDoesNotMatch" means aws_secret_access_key is invalid
@@ -53,20 +49,10 @@ func (a *awsActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovis
 
 func getAWSClient(cd *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (awsclient.Client, error) {
 	options := awsclient.Options{
-		Region: cd.Spec.Platform.AWS.Region,
-		CredentialsSource: awsclient.CredentialsSource{
-			Secret: &awsclient.SecretCredentialsSource{
-				Namespace: cd.Namespace,
-				Ref:       cd.Spec.Platform.AWS.CredentialsSecretRef,
-			},
-			AssumeRole: &awsclient.AssumeRoleCredentialsSource{
-				SecretRef: corev1.SecretReference{
-					Name:      os.Getenv(constants.HiveAWSServiceProviderCredentialsSecretRefEnvVar),
-					Namespace: controllerutils.GetHiveNamespace(),
-				},
-				Role: cd.Spec.Platform.AWS.CredentialsAssumeRole,
-			},
-		},
+		Controller:        hivev1.ClusterDeprovisionControllerName,
+		Region:            cd.Spec.Platform.AWS.Region,
+		CredentialsSource: awsclient.NewCredentialsSource(cd.Namespace, cd.Spec.Platform.AWS.CredentialsSecretRef, cd.Spec.Platform.AWS.CredentialsAssumeRole, cd.Spec.Platform.AWS.CredentialsSource),
+		ServiceEndpoints:  cd.Spec.Platform.AWS.ServiceEndpoints,
 	}
 
 	return awsclient.New(c, options)
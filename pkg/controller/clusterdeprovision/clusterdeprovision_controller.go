@@ -6,6 +6,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
@@ -71,7 +72,7 @@ func init() {
 // Add creates a new ClusterDeprovision Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
 	if err != nil {
 		logger.WithError(err).Error("could not get controller configurations")
@@ -106,20 +107,20 @@ func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (re
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
-	c, err := controller.New("clusterdeprovision-controller", mgr, controller.Options{
+	c, err := controllerutils.NewController("clusterdeprovision-controller", mgr, controller.Options{
 		Reconciler:              r,
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
 	if err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("Error getting new clusterdeprovision-controller")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error getting new clusterdeprovision-controller")
 		return err
 	}
 
 	// Watch for changes to ClusterDeprovision
 	err = c.Watch(&source.Kind{Type: &hivev1.ClusterDeprovision{}}, &handler.EnqueueRequestForObject{})
 	if err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("Error watching changes to clusterdeprovision")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error watching changes to clusterdeprovision")
 		return err
 	}
 
@@ -129,7 +130,15 @@ func add(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int,
 		OwnerType:    &hivev1.ClusterDeprovision{},
 	})
 	if err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("Error watching  uninstall jobs created for clusterdeprovisionreques")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error watching  uninstall jobs created for clusterdeprovisionreques")
+		return err
+	}
+
+	// Watch for changes to the ClusterDeployments being deprovisioned, so that a deprovision resumes once its
+	// ClusterDeployment is unpaused
+	err = c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, handler.EnqueueRequestsFromMapFunc(clusterDeploymentWatchHandler))
+	if err != nil {
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error watching changes to clusterdeployments")
 		return err
 	}
 
@@ -213,6 +222,9 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 		rLog.Error("deprovision blocked for ClusterDeployment with protected delete on")
 		return reconcile.Result{}, nil
 	}
+	if controllerutils.IsClusterDeploymentPaused(cd, rLog) {
+		return reconcile.Result{}, nil
+	}
 
 	// Check if deprovisions are currently disabled: (originates in HiveConfig in real world)
 	if r.deprovisionsDisabled {
@@ -225,7 +237,7 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 		rLog.Debug("No actuator found for this provider")
 	} else {
 		// actuator found, ensure creds work.
-		err := actuator.TestCredentials(instance, r.Client, rLog)
+		err := actuator.TestCredentials(ctx, instance, r.Client, rLog)
 		if err != nil {
 			rLog.WithError(err).Warn("Credential check failed")
 
@@ -273,7 +285,8 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 		return reconcile.Result{}, err
 	}
 
-	if err := r.setupAWSCredentialForAssumeRole(instance); err != nil {
+	credentialsRequeueAfter, err := r.setupAWSCredentialForAssumeRole(instance)
+	if err != nil {
 		if !apierrors.IsAlreadyExists(err) {
 			// Couldn't create the assume role credentials secret for a reason other than it already exists.
 			// If the secret already exists, then we should just use that secret.
@@ -299,6 +312,14 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 		rLog.Errorf("error generating uninstaller job: %v", err)
 		return reconcile.Result{}, err
 	}
+	if err := controllerutils.ApplyWorkloadSchedulingFromFile(&uninstallJob.Spec.Template.Spec); err != nil {
+		rLog.WithError(err).Error("error applying workload scheduling to uninstaller job")
+		return reconcile.Result{}, err
+	}
+	if err := controllerutils.ApplyJobProxyFromFiles(r, instance.Namespace, &uninstallJob.Spec.Template.Spec, rLog); err != nil {
+		rLog.WithError(err).Error("error applying job proxy to uninstaller job")
+		return reconcile.Result{}, err
+	}
 
 	rLog.Debug("setting uninstall job controller reference")
 	rLog.WithField("derivedObject", uninstallJob.Name).Debug("Setting labels on derived object")
@@ -330,7 +351,7 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 			rLog.WithError(err).Log(controllerutils.LogLevel(err), "error creating uninstall job")
 			return reconcile.Result{}, err
 		}
-		return reconcile.Result{}, nil
+		return reconcile.Result{RequeueAfter: credentialsRequeueAfter}, nil
 	} else if err != nil {
 		rLog.WithError(err).Errorf("error getting uninstall job")
 		return reconcile.Result{}, err
@@ -407,7 +428,30 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 	}
 
 	rLog.Infof("uninstall job not yet successful")
-	return reconcile.Result{}, nil
+	return reconcile.Result{RequeueAfter: credentialsRequeueAfter}, nil
+}
+
+// clusterDeploymentWatchHandler maps a deleted ClusterDeployment to its ClusterDeprovision, which shares its name.
+func clusterDeploymentWatchHandler(a client.Object) []reconcile.Request {
+	cd, ok := a.(*hivev1.ClusterDeployment)
+	if !ok {
+		// Wasn't a ClusterDeployment, bail out. This should not happen.
+		log.Errorf("Error converting MapObject.Object to ClusterDeployment. Value: %+v", a)
+		return nil
+	}
+
+	if cd.DeletionTimestamp == nil {
+		return nil
+	}
+
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Name:      cd.Name,
+				Namespace: cd.Namespace,
+			},
+		},
+	}
 }
 
 func generateOwnershipUniqueKeys(owner hivev1.MetaRuntimeObject) []*controllerutils.OwnershipUniqueKey {
@@ -450,14 +494,20 @@ func getAWSServiceProviderEnvVars(cd *hivev1.ClusterDeprovision, secretPrefix st
 	return extraEnvVars
 }
 
-func (r *ReconcileClusterDeprovision) setupAWSCredentialForAssumeRole(cd *hivev1.ClusterDeprovision) error {
-	if cd.Spec.Platform.AWS == nil ||
-		cd.Spec.Platform.AWS.CredentialsSecretRef.Name != "" ||
-		cd.Spec.Platform.AWS.CredentialsAssumeRole == nil {
+// setupAWSCredentialForAssumeRole creates the secret with the credentials of the uninstall pod when they are not in a
+// secret of the namespace. The credentials minted by the credentials broker must be refreshed before the returned
+// duration passes, when it is not zero.
+func (r *ReconcileClusterDeprovision) setupAWSCredentialForAssumeRole(cd *hivev1.ClusterDeprovision) (time.Duration, error) {
+	if cd.Spec.Platform.AWS == nil || cd.Spec.Platform.AWS.CredentialsSecretRef.Name != "" {
 		// no setup required
-		return nil
+		return 0, nil
 	}
 
-	return install.AWSAssumeRoleCLIConfig(r.Client, cd.Spec.Platform.AWS.CredentialsAssumeRole, install.AWSAssumeRoleSecretName(cd.Name), cd.Namespace, cd, r.scheme)
-
+	switch {
+	case cd.Spec.Platform.AWS.CredentialsSource != nil:
+		return install.AWSCredentialsSourceSecret(r.Client, cd.Spec.Platform.AWS.CredentialsSource, cd.Spec.Platform.AWS.Region, install.AWSAssumeRoleSecretName(cd.Name), cd.Namespace, cd, r.scheme)
+	case cd.Spec.Platform.AWS.CredentialsAssumeRole != nil:
+		return 0, install.AWSAssumeRoleCLIConfig(r.Client, cd.Spec.Platform.AWS.CredentialsAssumeRole, install.AWSAssumeRoleSecretName(cd.Name), cd.Namespace, cd, r.scheme)
+	}
+	return 0, nil
 }
@@ -3,6 +3,9 @@ package clusterdeprovision
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -53,6 +56,7 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 		validate                       func(t *testing.T, c client.Client)
 		expectErr                      bool
 		deprovisionsDisabled           bool
+		workloadScheduling             string
 	}{
 		{
 			name: "no-op deleting",
@@ -104,6 +108,19 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name:        "no-op if cluster deployment is paused",
+			deprovision: testClusterDeprovision(),
+			deployment: func() *hivev1.ClusterDeployment {
+				cd := testDeletedClusterDeployment()
+				cd.Spec.Paused = true
+				return cd
+			}(),
+			validate: func(t *testing.T, c client.Client) {
+				validateNoJobExists(t, c)
+				validateNotCompleted(t, c)
+			},
+		},
 		{
 			name:                  "create uninstall job",
 			deprovision:           testClusterDeprovision(),
@@ -113,6 +130,20 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 				validateJobExists(t, c)
 			},
 		},
+		{
+			name:                  "create uninstall job with workload scheduling",
+			deprovision:           testClusterDeprovision(),
+			deployment:            testDeletedClusterDeployment(),
+			mockGetCallerIdentity: true,
+			workloadScheduling:    `{"nodeSelector":{"node-role.kubernetes.io/infra":""},"priorityClassName":"hive-workloads"}`,
+			validate: func(t *testing.T, c client.Client) {
+				validateJobExists(t, c)
+				job := &batchv1.Job{}
+				require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName + "-uninstall"}, job))
+				assert.Equal(t, map[string]string{"node-role.kubernetes.io/infra": ""}, job.Spec.Template.Spec.NodeSelector, "unexpected node selector")
+				assert.Equal(t, "hive-workloads", job.Spec.Template.Spec.PriorityClassName, "unexpected priority class")
+			},
+		},
 		{
 			name:                 "do not create uninstall job when deprovisions are disabled",
 			deprovision:          testClusterDeprovision(),
@@ -300,6 +331,13 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 			}
 			existing := append(test.existing, test.deprovision, test.deployment)
 
+			if test.workloadScheduling != "" {
+				path := filepath.Join(t.TempDir(), "workload-scheduling")
+				require.NoError(t, ioutil.WriteFile(path, []byte(test.workloadScheduling), 0600))
+				os.Setenv(constants.WorkloadSchedulingFileEnvVar, path)
+				defer os.Unsetenv(constants.WorkloadSchedulingFileEnvVar)
+			}
+
 			mocks := setupDefaultMocks(t, existing...)
 
 			// This is necessary for the mocks to report failures like methods not being called an expected number of times.
@@ -307,7 +345,7 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 
 			if test.mockGetCallerIdentity {
 				mocks.mockAWSClient.EXPECT().
-					GetCallerIdentity(gomock.Any()).
+					GetCallerIdentity(gomock.Any(), gomock.Any()).
 					Return(nil, test.expectedGetCallerIdentityError)
 			}
 
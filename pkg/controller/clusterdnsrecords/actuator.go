@@ -0,0 +1,67 @@
+package clusterdnsrecords
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+)
+
+var errUnsupportedPlatform = errors.New("managed DNS records of adopted clusters are not supported on the platform of the cluster")
+
+// actuator discovers the load balancers of a cluster on a cloud platform and maintains records in the managed DNS
+// zone of the cluster.
+type actuator interface {
+	// apiAddress returns the hostname or IP address of the external load balancer of the API of the cluster.
+	apiAddress(ctx context.Context, cd *hivev1.ClusterDeployment) (string, error)
+
+	// ensureRecord creates or updates the record with the given fully-qualified name to point to the hostname or IP
+	// address.
+	ensureRecord(ctx context.Context, name, address string) error
+}
+
+// routingActuator is implemented by the actuators of platforms on which records with a routing policy can be
+// maintained in a zone shared with other clusters.
+type routingActuator interface {
+	// ensureHealthCheck creates or updates the health check of the API of the cluster at the hostname or IP address,
+	// and returns its ID. The health check is replaced when it cannot be updated, in which case the returned ID
+	// differs from the given one and the previous health check is left to the caller to delete.
+	ensureHealthCheck(ctx context.Context, cd *hivev1.ClusterDeployment, healthCheckID, address string) (string, error)
+
+	// deleteHealthCheck deletes the health check if it exists.
+	deleteHealthCheck(ctx context.Context, healthCheckID string) error
+
+	// ensureRoutedRecord creates or updates the record of the cluster with the given fully-qualified name and the
+	// routing policy of the cluster to point to the hostname or IP address.
+	ensureRoutedRecord(ctx context.Context, cd *hivev1.ClusterDeployment, name, address, healthCheckID string) error
+
+	// deleteRoutedRecords deletes the records of the cluster with a routing policy if they exist.
+	deleteRoutedRecords(ctx context.Context, status *hivev1aws.DNSRoutingStatus) error
+}
+
+// newActuator returns the actuator for the platform of the cluster.
+func newActuator(c client.Client, cd *hivev1.ClusterDeployment, dnsZone *hivev1.DNSZone, logger log.FieldLogger) (actuator, error) {
+	switch {
+	case cd.Spec.Platform.AWS != nil && dnsZone.Spec.AWS != nil:
+		return newAWSActuator(c, cd, dnsZone)
+	case cd.Spec.Platform.GCP != nil && dnsZone.Spec.GCP != nil:
+		return newGCPActuator(c, cd, dnsZone)
+	case cd.Spec.Platform.Azure != nil && dnsZone.Spec.Azure != nil:
+		return newAzureActuator(c, cd, dnsZone)
+	default:
+		return nil, errUnsupportedPlatform
+	}
+}
+
+// recordType returns the type of record for a hostname or IP address.
+func recordType(address string) string {
+	if net.ParseIP(address) != nil {
+		return "A"
+	}
+	return "CNAME"
+}
@@ -0,0 +1,258 @@
+package clusterdnsrecords
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	dns "google.golang.org/api/dns/v1"
+
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	awsclientmock "github.com/openshift/hive/pkg/awsclient/mock"
+	"github.com/openshift/hive/pkg/gcpclient"
+	gcpclientmock "github.com/openshift/hive/pkg/gcpclient/mock"
+)
+
+func TestRecordType(t *testing.T) {
+	assert.Equal(t, "A", recordType("10.0.0.1"))
+	assert.Equal(t, "CNAME", recordType(testAPIAddress))
+}
+
+func TestAWSEnsureRecord(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dnsClient := awsclientmock.NewMockClient(mockCtrl)
+	dnsClient.EXPECT().ChangeResourceRecordSets(gomock.Any(), &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String("Z1234"),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{{
+				Action: aws.String(route53.ChangeActionUpsert),
+				ResourceRecordSet: &route53.ResourceRecordSet{
+					Name:            aws.String("api.foo." + testZone),
+					Type:            aws.String("CNAME"),
+					TTL:             aws.Int64(recordTTL),
+					ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(testAPIAddress)}},
+				},
+			}},
+		},
+	}).Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+
+	a := &awsActuator{dnsClient: dnsClient, zoneID: "Z1234"}
+	assert.NoError(t, a.ensureRecord(context.TODO(), "api.foo."+testZone, testAPIAddress))
+}
+
+func TestAWSEnsureRoutedRecord(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dnsClient := awsclientmock.NewMockClient(mockCtrl)
+	dnsClient.EXPECT().ChangeResourceRecordSets(gomock.Any(), &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String("Z5678"),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{{
+				Action: aws.String(route53.ChangeActionUpsert),
+				ResourceRecordSet: &route53.ResourceRecordSet{
+					Name:            aws.String("api.shared." + testZone),
+					Type:            aws.String("CNAME"),
+					TTL:             aws.Int64(recordTTL),
+					ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(testAPIAddress)}},
+					SetIdentifier:   aws.String(testInfraID),
+					Failover:        aws.String(route53.ResourceRecordSetFailoverPrimary),
+					HealthCheckId:   aws.String("hc-1"),
+				},
+			}},
+		},
+	}).Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+
+	a := &awsActuator{dnsClient: dnsClient, zoneID: "Z1234"}
+	assert.NoError(t, a.ensureRoutedRecord(context.TODO(), testClusterDeployment(testDNSRouting), "api.shared."+testZone, testAPIAddress, "hc-1"))
+}
+
+func TestAWSEnsureHealthCheck(t *testing.T) {
+	desired := &route53.HealthCheckConfig{
+		Type:                     aws.String("HTTPS"),
+		FullyQualifiedDomainName: aws.String(testAPIAddress),
+		Port:                     aws.Int64(6443),
+		ResourcePath:             aws.String("/readyz"),
+		FailureThreshold:         aws.Int64(3),
+	}
+	cases := []struct {
+		name          string
+		healthCheckID string
+		expect        func(m *awsclientmock.MockClient)
+		expectedID    string
+	}{
+		{
+			name: "missing health check",
+			expect: func(m *awsclientmock.MockClient) {
+				m.EXPECT().CreateHealthCheck(gomock.Any(), &route53.CreateHealthCheckInput{
+					CallerReference:   aws.String("1234-2"),
+					HealthCheckConfig: desired,
+				}).Return(&route53.CreateHealthCheckOutput{HealthCheck: &route53.HealthCheck{Id: aws.String("hc-2")}}, nil)
+			},
+			expectedID: "hc-2",
+		},
+		{
+			name:          "up to date health check",
+			healthCheckID: "hc-1",
+			expect: func(m *awsclientmock.MockClient) {
+				m.EXPECT().GetHealthCheck(gomock.Any(), &route53.GetHealthCheckInput{HealthCheckId: aws.String("hc-1")}).
+					Return(&route53.GetHealthCheckOutput{HealthCheck: &route53.HealthCheck{Id: aws.String("hc-1"), HealthCheckConfig: desired}}, nil)
+			},
+			expectedID: "hc-1",
+		},
+		{
+			name:          "outdated health check",
+			healthCheckID: "hc-1",
+			expect: func(m *awsclientmock.MockClient) {
+				current := *desired
+				current.Port = aws.Int64(443)
+				m.EXPECT().GetHealthCheck(gomock.Any(), &route53.GetHealthCheckInput{HealthCheckId: aws.String("hc-1")}).
+					Return(&route53.GetHealthCheckOutput{HealthCheck: &route53.HealthCheck{Id: aws.String("hc-1"), HealthCheckConfig: &current}}, nil)
+				m.EXPECT().UpdateHealthCheck(gomock.Any(), &route53.UpdateHealthCheckInput{
+					HealthCheckId:            aws.String("hc-1"),
+					FullyQualifiedDomainName: desired.FullyQualifiedDomainName,
+					Port:                     desired.Port,
+					ResourcePath:             desired.ResourcePath,
+					FailureThreshold:         desired.FailureThreshold,
+				}).Return(&route53.UpdateHealthCheckOutput{}, nil)
+			},
+			expectedID: "hc-1",
+		},
+		{
+			name:          "health check of another type",
+			healthCheckID: "hc-1",
+			expect: func(m *awsclientmock.MockClient) {
+				current := *desired
+				current.Type = aws.String("TCP")
+				m.EXPECT().GetHealthCheck(gomock.Any(), &route53.GetHealthCheckInput{HealthCheckId: aws.String("hc-1")}).
+					Return(&route53.GetHealthCheckOutput{HealthCheck: &route53.HealthCheck{Id: aws.String("hc-1"), HealthCheckConfig: &current}}, nil)
+				m.EXPECT().CreateHealthCheck(gomock.Any(), gomock.Any()).
+					Return(&route53.CreateHealthCheckOutput{HealthCheck: &route53.HealthCheck{Id: aws.String("hc-2")}}, nil)
+			},
+			expectedID: "hc-2",
+		},
+		{
+			name:          "deleted health check",
+			healthCheckID: "hc-1",
+			expect: func(m *awsclientmock.MockClient) {
+				m.EXPECT().GetHealthCheck(gomock.Any(), gomock.Any()).Return(nil, awserr.New(route53.ErrCodeNoSuchHealthCheck, "not found", nil))
+				m.EXPECT().CreateHealthCheck(gomock.Any(), gomock.Any()).
+					Return(&route53.CreateHealthCheckOutput{HealthCheck: &route53.HealthCheck{Id: aws.String("hc-2")}}, nil)
+			},
+			expectedID: "hc-2",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			dnsClient := awsclientmock.NewMockClient(mockCtrl)
+			tc.expect(dnsClient)
+
+			cd := testClusterDeployment(testDNSRouting)
+			cd.UID = "1234"
+			cd.Generation = 2
+			a := &awsActuator{dnsClient: dnsClient}
+			id, err := a.ensureHealthCheck(context.TODO(), cd, tc.healthCheckID, testAPIAddress)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedID, id, "unexpected health check ID")
+		})
+	}
+}
+
+func TestAWSDeleteRoutedRecords(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	dnsClient := awsclientmock.NewMockClient(mockCtrl)
+	otherRecord := &route53.ResourceRecordSet{
+		Name:          aws.String(`\052.apps.shared.` + testZone + "."),
+		Type:          aws.String("CNAME"),
+		SetIdentifier: aws.String("other"),
+	}
+	record := &route53.ResourceRecordSet{
+		Name:          aws.String(`\052.apps.shared.` + testZone + "."),
+		Type:          aws.String("CNAME"),
+		SetIdentifier: aws.String(testInfraID),
+	}
+	dnsClient.EXPECT().ListResourceRecordSets(gomock.Any(), &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String("Z5678"),
+		StartRecordName: aws.String("api.shared." + testZone),
+	}).Return(&route53.ListResourceRecordSetsOutput{ResourceRecordSets: []*route53.ResourceRecordSet{otherRecord}}, nil)
+	dnsClient.EXPECT().ListResourceRecordSets(gomock.Any(), &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String("Z5678"),
+		StartRecordName: aws.String("*.apps.shared." + testZone),
+	}).Return(&route53.ListResourceRecordSetsOutput{ResourceRecordSets: []*route53.ResourceRecordSet{otherRecord, record}}, nil)
+	dnsClient.EXPECT().ChangeResourceRecordSets(gomock.Any(), &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String("Z5678"),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{{
+				Action:            aws.String(route53.ChangeActionDelete),
+				ResourceRecordSet: record,
+			}},
+		},
+	}).Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+
+	a := &awsActuator{dnsClient: dnsClient}
+	assert.NoError(t, a.deleteRoutedRecords(context.TODO(), &hivev1aws.DNSRoutingStatus{
+		HostedZoneID:  "Z5678",
+		RecordNames:   []string{"api.shared." + testZone, "*.apps.shared." + testZone},
+		SetIdentifier: testInfraID,
+	}))
+}
+
+func TestGCPEnsureRecord(t *testing.T) {
+	const zoneName = "foo-zone"
+	desired := &dns.ResourceRecordSet{
+		Name:    "api.foo." + testZone + ".",
+		Type:    "A",
+		Ttl:     recordTTL,
+		Rrdatas: []string{"10.0.0.1"},
+	}
+	cases := []struct {
+		name     string
+		existing []*dns.ResourceRecordSet
+		expect   func(m *gcpclientmock.MockClient)
+	}{
+		{
+			name: "missing record",
+			expect: func(m *gcpclientmock.MockClient) {
+				m.EXPECT().AddResourceRecordSet(gomock.Any(), zoneName, desired).Return(nil)
+			},
+		},
+		{
+			name: "outdated record",
+			existing: []*dns.ResourceRecordSet{{
+				Name:    desired.Name,
+				Type:    "A",
+				Ttl:     recordTTL,
+				Rrdatas: []string{"10.0.0.2"},
+			}},
+			expect: func(m *gcpclientmock.MockClient) {
+				m.EXPECT().UpdateResourceRecordSet(gomock.Any(), zoneName, desired, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:     "up to date record",
+			existing: []*dns.ResourceRecordSet{desired},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			dnsClient := gcpclientmock.NewMockClient(mockCtrl)
+			dnsClient.EXPECT().ListResourceRecordSets(gomock.Any(), zoneName, gcpclient.ListResourceRecordSetsOptions{Name: desired.Name, Type: "A"}).
+				Return(&dns.ResourceRecordSetsListResponse{Rrsets: tc.existing}, nil)
+			if tc.expect != nil {
+				tc.expect(dnsClient)
+			}
+
+			a := &gcpActuator{dnsClient: dnsClient, zoneName: zoneName}
+			assert.NoError(t, a.ensureRecord(context.TODO(), "api.foo."+testZone, "10.0.0.1"))
+		})
+	}
+}
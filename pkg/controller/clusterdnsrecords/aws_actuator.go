@@ -0,0 +1,277 @@
+package clusterdnsrecords
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+type awsActuator struct {
+	// clusterClient is the client for the account of the cluster.
+	clusterClient awsclient.Client
+	// dnsClient is the client for the account of the managed DNS zone.
+	dnsClient awsclient.Client
+	zoneID    string
+}
+
+var _ actuator = &awsActuator{}
+var _ routingActuator = &awsActuator{}
+
+const (
+	defaultHealthCheckPort             = 6443
+	defaultHealthCheckPath             = "/readyz"
+	defaultHealthCheckFailureThreshold = 3
+)
+
+func newAWSActuator(c client.Client, cd *hivev1.ClusterDeployment, dnsZone *hivev1.DNSZone) (*awsActuator, error) {
+	if dnsZone.Status.AWS == nil || dnsZone.Status.AWS.ZoneID == nil {
+		return nil, errors.New("managed DNS zone has no hosted zone ID")
+	}
+	clusterClient, err := awsclient.New(c, awsclient.Options{
+		Controller:        hivev1.ClusterDNSRecordsControllerName,
+		Region:            cd.Spec.Platform.AWS.Region,
+		CredentialsSource: awsclient.NewCredentialsSource(cd.Namespace, &cd.Spec.Platform.AWS.CredentialsSecretRef, cd.Spec.Platform.AWS.CredentialsAssumeRole, cd.Spec.Platform.AWS.CredentialsSource),
+		ServiceEndpoints:  cd.Spec.Platform.AWS.ServiceEndpoints,
+		ProxySecret:       &awsclient.ProxySecretSource{Namespace: cd.Namespace, Ref: cd.Spec.CloudClientProxySecretRef},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create AWS client for the cluster")
+	}
+	region := dnsZone.Spec.AWS.Region
+	if region == "" {
+		region = constants.AWSRoute53Region
+	}
+	dnsClient, err := awsclient.New(c, awsclient.Options{
+		Controller:        hivev1.ClusterDNSRecordsControllerName,
+		Region:            region,
+		CredentialsSource: awsclient.NewCredentialsSource(dnsZone.Namespace, &dnsZone.Spec.AWS.CredentialsSecretRef, dnsZone.Spec.AWS.CredentialsAssumeRole, dnsZone.Spec.AWS.CredentialsSource),
+		ServiceEndpoints:  dnsZone.Spec.AWS.ServiceEndpoints,
+		ProxySecret:       &awsclient.ProxySecretSource{Namespace: dnsZone.Namespace, Ref: dnsZone.Spec.CloudClientProxySecretRef},
+		AssumeRole:        dnsZone.Spec.AWS.AssumeRole,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create AWS client for the managed DNS zone")
+	}
+	return &awsActuator{
+		clusterClient: clusterClient,
+		dnsClient:     dnsClient,
+		zoneID:        *dnsZone.Status.AWS.ZoneID,
+	}, nil
+}
+
+// apiAddress returns the hostname of the external API NLB, which the installer names {infraID}-ext.
+func (a *awsActuator) apiAddress(ctx context.Context, cd *hivev1.ClusterDeployment) (string, error) {
+	nlbName := cd.Spec.ClusterMetadata.InfraID + "-ext"
+	out, err := a.clusterClient.DescribeLoadBalancers(ctx, &elbv2.DescribeLoadBalancersInput{
+		Names: aws.StringSlice([]string{nlbName}),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe load balancer %s", nlbName)
+	}
+	if len(out.LoadBalancers) == 0 || aws.StringValue(out.LoadBalancers[0].DNSName) == "" {
+		return "", fmt.Errorf("load balancer %s not found", nlbName)
+	}
+	return aws.StringValue(out.LoadBalancers[0].DNSName), nil
+}
+
+func (a *awsActuator) ensureRecord(ctx context.Context, name, address string) error {
+	_, err := a.dnsClient.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(a.zoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{{
+				Action: aws.String(route53.ChangeActionUpsert),
+				ResourceRecordSet: &route53.ResourceRecordSet{
+					Name:            aws.String(name),
+					Type:            aws.String(recordType(address)),
+					TTL:             aws.Int64(recordTTL),
+					ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(address)}},
+				},
+			}},
+		},
+	})
+	return err
+}
+
+// healthCheckConfig returns the configuration of the health check of the API at the hostname or IP address.
+func healthCheckConfig(hc *hivev1aws.DNSHealthCheck, address string) *route53.HealthCheckConfig {
+	config := &route53.HealthCheckConfig{
+		Type:             aws.String(string(hivev1aws.HTTPSDNSHealthCheckType)),
+		Port:             aws.Int64(defaultHealthCheckPort),
+		FailureThreshold: aws.Int64(defaultHealthCheckFailureThreshold),
+	}
+	if hc.Type != "" {
+		config.Type = aws.String(string(hc.Type))
+	}
+	if hc.Port != nil {
+		config.Port = hc.Port
+	}
+	if hc.FailureThreshold != nil {
+		config.FailureThreshold = hc.FailureThreshold
+	}
+	if hc.Type != hivev1aws.TCPDNSHealthCheckType {
+		config.ResourcePath = aws.String(defaultHealthCheckPath)
+		if hc.Path != "" {
+			config.ResourcePath = aws.String(hc.Path)
+		}
+	}
+	if recordType(address) == "A" {
+		config.IPAddress = aws.String(address)
+	} else {
+		config.FullyQualifiedDomainName = aws.String(address)
+	}
+	return config
+}
+
+func (a *awsActuator) ensureHealthCheck(ctx context.Context, cd *hivev1.ClusterDeployment, healthCheckID, address string) (string, error) {
+	desired := healthCheckConfig(cd.Spec.DNSRouting.AWS.HealthCheck, address)
+	if healthCheckID != "" {
+		out, err := a.dnsClient.GetHealthCheck(ctx, &route53.GetHealthCheckInput{HealthCheckId: aws.String(healthCheckID)})
+		if err != nil && !isAWSErrorCode(err, route53.ErrCodeNoSuchHealthCheck) {
+			return "", errors.Wrapf(err, "failed to get health check %s", healthCheckID)
+		}
+		// The type of a health check cannot be updated, so a health check of another type is replaced.
+		if err == nil && aws.StringValue(out.HealthCheck.HealthCheckConfig.Type) == aws.StringValue(desired.Type) {
+			current := out.HealthCheck.HealthCheckConfig
+			if aws.StringValue(current.FullyQualifiedDomainName) == aws.StringValue(desired.FullyQualifiedDomainName) &&
+				aws.StringValue(current.IPAddress) == aws.StringValue(desired.IPAddress) &&
+				aws.Int64Value(current.Port) == aws.Int64Value(desired.Port) &&
+				aws.StringValue(current.ResourcePath) == aws.StringValue(desired.ResourcePath) &&
+				aws.Int64Value(current.FailureThreshold) == aws.Int64Value(desired.FailureThreshold) {
+				return healthCheckID, nil
+			}
+			_, err := a.dnsClient.UpdateHealthCheck(ctx, &route53.UpdateHealthCheckInput{
+				HealthCheckId:            aws.String(healthCheckID),
+				FullyQualifiedDomainName: desired.FullyQualifiedDomainName,
+				IPAddress:                desired.IPAddress,
+				Port:                     desired.Port,
+				ResourcePath:             desired.ResourcePath,
+				FailureThreshold:         desired.FailureThreshold,
+			})
+			return healthCheckID, errors.Wrapf(err, "failed to update health check %s", healthCheckID)
+		}
+	}
+	// The caller reference makes the creation idempotent for a generation of the cluster deployment, so that a
+	// health check created before its ID could be recorded is not created again.
+	out, err := a.dnsClient.CreateHealthCheck(ctx, &route53.CreateHealthCheckInput{
+		CallerReference:   aws.String(fmt.Sprintf("%s-%d", cd.UID, cd.Generation)),
+		HealthCheckConfig: desired,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create health check")
+	}
+	return aws.StringValue(out.HealthCheck.Id), nil
+}
+
+func (a *awsActuator) deleteHealthCheck(ctx context.Context, healthCheckID string) error {
+	_, err := a.dnsClient.DeleteHealthCheck(ctx, &route53.DeleteHealthCheckInput{HealthCheckId: aws.String(healthCheckID)})
+	if err != nil && !isAWSErrorCode(err, route53.ErrCodeNoSuchHealthCheck) {
+		return errors.Wrapf(err, "failed to delete health check %s", healthCheckID)
+	}
+	return nil
+}
+
+func (a *awsActuator) ensureRoutedRecord(ctx context.Context, cd *hivev1.ClusterDeployment, name, address, healthCheckID string) error {
+	routing := cd.Spec.DNSRouting.AWS
+	recordSet := &route53.ResourceRecordSet{
+		Name:            aws.String(name),
+		Type:            aws.String(recordType(address)),
+		TTL:             aws.Int64(recordTTL),
+		ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(address)}},
+		SetIdentifier:   aws.String(routingSetIdentifier(cd)),
+	}
+	switch routing.Policy {
+	case hivev1aws.WeightedDNSRoutingPolicy:
+		recordSet.Weight = routing.Weight
+	case hivev1aws.LatencyDNSRoutingPolicy:
+		recordSet.Region = aws.String(cd.Spec.Platform.AWS.Region)
+	case hivev1aws.FailoverDNSRoutingPolicy:
+		recordSet.Failover = aws.String(strings.ToUpper(string(routing.Failover)))
+	}
+	if healthCheckID != "" {
+		recordSet.HealthCheckId = aws.String(healthCheckID)
+	}
+	_, err := a.dnsClient.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(routing.HostedZoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{{
+				Action:            aws.String(route53.ChangeActionUpsert),
+				ResourceRecordSet: recordSet,
+			}},
+		},
+	})
+	return err
+}
+
+func (a *awsActuator) deleteRoutedRecords(ctx context.Context, status *hivev1aws.DNSRoutingStatus) error {
+	for _, name := range status.RecordNames {
+		recordSet, err := a.findRoutedRecord(ctx, status.HostedZoneID, name, status.SetIdentifier)
+		if err != nil {
+			return err
+		}
+		if recordSet == nil {
+			continue
+		}
+		_, err = a.dnsClient.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
+			HostedZoneId: aws.String(status.HostedZoneID),
+			ChangeBatch: &route53.ChangeBatch{
+				Changes: []*route53.Change{{
+					Action:            aws.String(route53.ChangeActionDelete),
+					ResourceRecordSet: recordSet,
+				}},
+			},
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete record %s", name)
+		}
+	}
+	return nil
+}
+
+// findRoutedRecord returns the record set with the name and set identifier in the hosted zone, or nil if there is
+// none.
+func (a *awsActuator) findRoutedRecord(ctx context.Context, zoneID, name, setIdentifier string) (*route53.ResourceRecordSet, error) {
+	input := &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(zoneID),
+		StartRecordName: aws.String(name),
+	}
+	for {
+		out, err := a.dnsClient.ListResourceRecordSets(ctx, input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list records %s", name)
+		}
+		for _, recordSet := range out.ResourceRecordSets {
+			// Route53 returns the names fully-qualified, with the wildcard escaped.
+			recordName := strings.TrimSuffix(strings.Replace(aws.StringValue(recordSet.Name), `\052`, "*", 1), ".")
+			if !strings.EqualFold(recordName, strings.TrimSuffix(name, ".")) {
+				return nil, nil
+			}
+			if aws.StringValue(recordSet.SetIdentifier) == setIdentifier {
+				return recordSet, nil
+			}
+		}
+		if !aws.BoolValue(out.IsTruncated) {
+			return nil, nil
+		}
+		input.StartRecordName = out.NextRecordName
+		input.StartRecordType = out.NextRecordType
+		input.StartRecordIdentifier = out.NextRecordIdentifier
+	}
+}
+
+func isAWSErrorCode(err error, code string) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == code
+}
@@ -0,0 +1,91 @@
+package clusterdnsrecords
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
+	"github.com/openshift/hive/pkg/azureclient"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+type azureActuator struct {
+	// clusterClient is the client for the subscription of the cluster.
+	clusterClient azureclient.Client
+	// dnsClient is the client for the subscription of the managed DNS zone.
+	dnsClient        azureclient.Client
+	dnsResourceGroup string
+	zone             string
+}
+
+var _ actuator = &azureActuator{}
+
+func newAzureActuator(c client.Client, cd *hivev1.ClusterDeployment, dnsZone *hivev1.DNSZone) (*azureActuator, error) {
+	clusterClient, err := azureClientFromSecret(c, cd.Namespace, cd.Spec.Platform.Azure.CredentialsSecretRef.Name, hivev1azure.PublicCloud, cd.Spec.CloudClientProxySecretRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create Azure client for the cluster")
+	}
+	dnsClient, err := azureClientFromSecret(c, dnsZone.Namespace, dnsZone.Spec.Azure.CredentialsSecretRef.Name, dnsZone.Spec.Azure.CloudName, dnsZone.Spec.CloudClientProxySecretRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create Azure client for the managed DNS zone")
+	}
+	return &azureActuator{
+		clusterClient:    clusterClient,
+		dnsClient:        dnsClient,
+		dnsResourceGroup: dnsZone.Spec.Azure.ResourceGroupName,
+		zone:             dnsZone.Spec.Zone,
+	}, nil
+}
+
+func azureClientFromSecret(c client.Client, namespace, name string, cloudName hivev1azure.CloudEnvironment, proxySecretRef *corev1.LocalObjectReference) (azureclient.Client, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch Azure credentials secret")
+	}
+	proxy, err := controllerutils.GetCloudClientProxy(c, namespace, proxySecretRef)
+	if err != nil {
+		return nil, err
+	}
+	return azureclient.NewClientFromSecretWithOptions(secret, cloudName, azureclient.Options{Proxy: proxy, Controller: hivev1.ClusterDNSRecordsControllerName})
+}
+
+// apiAddress returns the IP address of the external API load balancer, which the installer names
+// {infraID}-pip-v4 in the {infraID}-rg resource group.
+func (a *azureActuator) apiAddress(ctx context.Context, cd *hivev1.ClusterDeployment) (string, error) {
+	infraID := cd.Spec.ClusterMetadata.InfraID
+	name := infraID + "-pip-v4"
+	ip, err := a.clusterClient.GetPublicIPAddress(ctx, infraID+"-rg", name)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get public IP address %s", name)
+	}
+	if ip.PublicIPAddressPropertiesFormat == nil || to.String(ip.IPAddress) == "" {
+		return "", errors.Errorf("public IP address %s has no IP address", name)
+	}
+	return to.String(ip.IPAddress), nil
+}
+
+func (a *azureActuator) ensureRecord(ctx context.Context, name, address string) error {
+	rrType := recordType(address)
+	recordSet := dns.RecordSet{
+		RecordSetProperties: &dns.RecordSetProperties{
+			TTL: to.Int64Ptr(recordTTL),
+		},
+	}
+	if rrType == "CNAME" {
+		recordSet.CnameRecord = &dns.CnameRecord{Cname: to.StringPtr(address)}
+	} else {
+		recordSet.ARecords = &[]dns.ARecord{{Ipv4Address: to.StringPtr(address)}}
+	}
+	relativeName := strings.TrimSuffix(name, "."+a.zone)
+	_, err := a.dnsClient.CreateOrUpdateRecordSet(ctx, a.dnsResourceGroup, a.zone, relativeName, dns.RecordType(rrType), recordSet)
+	return err
+}
@@ -0,0 +1,284 @@
+package clusterdnsrecords
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/manageddns"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	ControllerName = hivev1.ClusterDNSRecordsControllerName
+
+	// resyncInterval is how often the records are checked against the load balancers of the cluster, as the
+	// load balancers may be replaced on the cluster.
+	resyncInterval = 30 * time.Minute
+
+	// recordTTL is the TTL of the records maintained for the cluster.
+	recordTTL = 60
+
+	routerNamespace   = "openshift-ingress"
+	routerServiceName = "router-default"
+
+	unsupportedPlatformReason       = "UnsupportedPlatform"
+	dnsZoneNotAvailableReason       = "DNSZoneNotAvailable"
+	addressDiscoveryFailedReason    = "AddressDiscoveryFailed"
+	recordsUpdateFailedReason       = "RecordsUpdateFailed"
+	routedRecordsUpdateFailedReason = "RoutedRecordsUpdateFailed"
+	recordsReadyReason              = "RecordsReady"
+)
+
+// Add creates a new ClusterDNSRecords controller and adds it to the Manager with default RBAC. The Manager will set
+// fields on the controller and start it when the Manager is started.
+func Add(mgr manager.Manager) error {
+	logger := controllerutils.ControllerLogEntry(ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	r := NewReconciler(mgr, clientRateLimiter)
+	if r.managedDomains, err = manageddns.ReadManagedDomainsFile(); err != nil {
+		logger.WithError(err).Error("could not read managed domains file")
+		return err
+	}
+	return AddToManager(mgr, r, concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileClusterDNSRecords
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterDNSRecords {
+	r := &ReconcileClusterDNSRecords{
+		Client:          controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger:          controllerutils.ControllerLogEntry(ControllerName),
+		actuatorBuilder: newActuator,
+	}
+	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileClusterDNSRecords, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controllerutils.NewController("clusterdnsrecords-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		r.logger.WithError(err).Error("error watching cluster deployments")
+		return err
+	}
+
+	// Watch for the managed DNS zones of cluster deployments becoming available.
+	if err := c.Watch(&source.Kind{Type: &hivev1.DNSZone{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &hivev1.ClusterDeployment{},
+	}); err != nil {
+		r.logger.WithError(err).Error("error watching dns zones")
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterDNSRecords{}
+
+// ReconcileClusterDNSRecords maintains the API and ingress records of adopted clusters in their managed DNS zones.
+type ReconcileClusterDNSRecords struct {
+	client.Client
+	logger log.FieldLogger
+
+	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server
+	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+
+	// actuatorBuilder is the function to build the actuator for the platform of a cluster, exposed for testing.
+	actuatorBuilder func(c client.Client, cd *hivev1.ClusterDeployment, dnsZone *hivev1.DNSZone, logger log.FieldLogger) (actuator, error)
+
+	// managedDomains are the managed domains of HiveConfig, which list the shared hosted zones allowed for records
+	// with a routing policy.
+	managedDomains []hivev1.ManageDNSConfig
+}
+
+// Reconcile discovers the addresses of the API and ingress load balancers of an adopted cluster with managed DNS and
+// points the records of the cluster in its managed DNS zone to them.
+func (r *ReconcileClusterDNSRecords) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Debug("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	switch err := r.Get(context.TODO(), request.NamespacedName, cd); {
+	case apierrors.IsNotFound(err):
+		cdLog.Debug("cluster deployment not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		cdLog.WithError(err).Error("error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	if controllerutils.IsClusterDeploymentPaused(cd, cdLog) {
+		return reconcile.Result{}, nil
+	}
+
+	if cd.DeletionTimestamp != nil {
+		if controllerutils.HasFinalizer(cd, dnsRoutingFinalizer) {
+			return reconcile.Result{}, r.cleanupDNSRouting(ctx, cd, cdLog)
+		}
+		return reconcile.Result{}, nil
+	}
+	if awsDNSRouting(cd) == nil && controllerutils.HasFinalizer(cd, dnsRoutingFinalizer) {
+		cdLog.Info("DNS routing removed, cleaning up records with a routing policy")
+		if err := r.cleanupDNSRouting(ctx, cd, cdLog); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	// Records of clusters installed by Hive are created by the installer.
+	if !cd.Spec.ManageDNS || !controllerutils.IsClusterAdopted(cd) {
+		cdLog.Debug("not an adopted cluster with managed DNS")
+		return reconcile.Result{}, nil
+	}
+	if cd.Spec.ClusterMetadata == nil {
+		cdLog.Error("installed cluster with no cluster metadata")
+		return reconcile.Result{}, nil
+	}
+
+	dnsZone := &hivev1.DNSZone{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: controllerutils.DNSZoneName(cd.Name)}, dnsZone); {
+	case apierrors.IsNotFound(err):
+		cdLog.Debug("managed DNS zone not found")
+		return reconcile.Result{}, r.setRecordsReadyCondition(cd, corev1.ConditionFalse, dnsZoneNotAvailableReason, "managed DNS zone does not exist yet", cdLog)
+	case err != nil:
+		cdLog.WithError(err).Error("error getting managed DNS zone")
+		return reconcile.Result{}, err
+	}
+	if cond := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition); cond == nil || cond.Status != corev1.ConditionTrue {
+		cdLog.Debug("managed DNS zone is not available")
+		return reconcile.Result{}, r.setRecordsReadyCondition(cd, corev1.ConditionFalse, dnsZoneNotAvailableReason, "managed DNS zone is not available yet", cdLog)
+	}
+
+	act, err := r.actuatorBuilder(r.Client, cd, dnsZone, cdLog)
+	if err == errUnsupportedPlatform {
+		cdLog.Info("managed DNS records of adopted clusters are not supported on the platform")
+		return reconcile.Result{}, r.setRecordsReadyCondition(cd, corev1.ConditionFalse, unsupportedPlatformReason, err.Error(), cdLog)
+	}
+	if err != nil {
+		cdLog.WithError(err).Error("could not create actuator")
+		return reconcile.Result{}, err
+	}
+
+	remoteClient, unreachable, requeue := remoteclient.ConnectToRemoteCluster(cd, r.remoteClusterAPIClientBuilder(cd), r.Client, cdLog)
+	if unreachable {
+		return reconcile.Result{Requeue: requeue}, nil
+	}
+
+	apiAddress, err := act.apiAddress(ctx, cd)
+	if err != nil {
+		cdLog.WithError(err).Error("could not discover API load balancer address")
+		return reconcile.Result{}, r.setRecordsFailedCondition(cd, addressDiscoveryFailedReason, errors.Wrap(err, "could not discover API load balancer address"), cdLog)
+	}
+	ingressAddress, err := ingressAddress(remoteClient)
+	if err != nil {
+		cdLog.WithError(err).Error("could not discover ingress load balancer address")
+		return reconcile.Result{}, r.setRecordsFailedCondition(cd, addressDiscoveryFailedReason, errors.Wrap(err, "could not discover ingress load balancer address"), cdLog)
+	}
+
+	clusterDomain := cd.Spec.ClusterName + "." + dnsZone.Spec.Zone
+	records := []struct{ name, address string }{
+		{name: "api." + clusterDomain, address: apiAddress},
+		{name: "*.apps." + clusterDomain, address: ingressAddress},
+	}
+	for _, record := range records {
+		recordLog := cdLog.WithField("record", record.name).WithField("address", record.address)
+		if err := act.ensureRecord(ctx, record.name, record.address); err != nil {
+			recordLog.WithError(err).Error("could not update record")
+			return reconcile.Result{}, r.setRecordsFailedCondition(cd, recordsUpdateFailedReason, errors.Wrapf(err, "could not update record %s", record.name), cdLog)
+		}
+		recordLog.Debug("record is up to date")
+	}
+	if awsDNSRouting(cd) != nil {
+		if err := r.ensureDNSRouting(ctx, cd, act, apiAddress, ingressAddress, cdLog); err != nil {
+			cdLog.WithError(err).Error("could not update records with a routing policy")
+			return reconcile.Result{}, r.setRecordsFailedCondition(cd, routedRecordsUpdateFailedReason, err, cdLog)
+		}
+		cdLog.Debug("records with a routing policy are up to date")
+	}
+
+	message := fmt.Sprintf("API records point to %s and ingress records point to %s", apiAddress, ingressAddress)
+	if err := r.setRecordsReadyCondition(cd, corev1.ConditionTrue, recordsReadyReason, message, cdLog); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: resyncInterval}, nil
+}
+
+// ingressAddress returns the hostname or IP address of the load balancer of the default ingress controller of the
+// remote cluster.
+func ingressAddress(remoteClient client.Client) (string, error) {
+	svc := &corev1.Service{}
+	if err := remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: routerNamespace, Name: routerServiceName}, svc); err != nil {
+		return "", err
+	}
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.Hostname != "" {
+			return ingress.Hostname, nil
+		}
+		if ingress.IP != "" {
+			return ingress.IP, nil
+		}
+	}
+	return "", errors.New("router service has no load balancer address")
+}
+
+// setRecordsFailedCondition sets the ManagedDNSRecordsReady condition to false for the error and returns the error.
+func (r *ReconcileClusterDNSRecords) setRecordsFailedCondition(cd *hivev1.ClusterDeployment, reason string, err error, cdLog log.FieldLogger) error {
+	if updateErr := r.setRecordsReadyCondition(cd, corev1.ConditionFalse, reason, err.Error(), cdLog); updateErr != nil {
+		return updateErr
+	}
+	return err
+}
+
+func (r *ReconcileClusterDNSRecords) setRecordsReadyCondition(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason, message string, cdLog log.FieldLogger) error {
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.ManagedDNSRecordsReadyClusterDeploymentCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conditions
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not update ManagedDNSRecordsReady condition")
+		return err
+	}
+	return nil
+}
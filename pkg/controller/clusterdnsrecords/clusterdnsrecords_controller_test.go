@@ -0,0 +1,573 @@
+package clusterdnsrecords
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+)
+
+const (
+	testName           = "foo"
+	testNamespace      = "default"
+	testInfraID        = "foo-lqmsh"
+	testZone           = "example.com"
+	testAPIAddress     = "foo-lqmsh-ext-1234.elb.us-east-1.amazonaws.com"
+	testIngressAddress = "a1b2c3-1234.us-east-1.elb.amazonaws.com"
+)
+
+type fakeActuator struct {
+	apiAddressErr error
+	recordErr     error
+	records       map[string]string
+}
+
+// fakeRoutingActuator is a fakeActuator that also maintains records with a routing policy.
+type fakeRoutingActuator struct {
+	fakeActuator
+	routedRecords        map[string]string
+	healthCheckID        string
+	deletedRecords       []string
+	deletedHealthChecks  []string
+	routedRecordErr      error
+	ensuredHealthCheckID string
+}
+
+func (a *fakeRoutingActuator) ensureHealthCheck(ctx context.Context, cd *hivev1.ClusterDeployment, healthCheckID, address string) (string, error) {
+	a.ensuredHealthCheckID = healthCheckID
+	return a.healthCheckID, nil
+}
+
+func (a *fakeRoutingActuator) deleteHealthCheck(ctx context.Context, healthCheckID string) error {
+	a.deletedHealthChecks = append(a.deletedHealthChecks, healthCheckID)
+	return nil
+}
+
+func (a *fakeRoutingActuator) ensureRoutedRecord(ctx context.Context, cd *hivev1.ClusterDeployment, name, address, healthCheckID string) error {
+	if a.routedRecordErr != nil {
+		return a.routedRecordErr
+	}
+	a.routedRecords[name] = address + "/" + routingSetIdentifier(cd) + "/" + healthCheckID
+	return nil
+}
+
+func (a *fakeRoutingActuator) deleteRoutedRecords(ctx context.Context, status *hivev1aws.DNSRoutingStatus) error {
+	for _, name := range status.RecordNames {
+		a.deletedRecords = append(a.deletedRecords, status.HostedZoneID+"/"+name+"/"+status.SetIdentifier)
+	}
+	return nil
+}
+
+func (a *fakeActuator) apiAddress(ctx context.Context, cd *hivev1.ClusterDeployment) (string, error) {
+	return testAPIAddress, a.apiAddressErr
+}
+
+func (a *fakeActuator) ensureRecord(ctx context.Context, name, address string) error {
+	if a.recordErr != nil {
+		return a.recordErr
+	}
+	a.records[name] = address
+	return nil
+}
+
+func testClusterDeployment(mods ...func(*hivev1.ClusterDeployment)) *hivev1.ClusterDeployment {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: testName,
+			BaseDomain:  "example.com",
+			ManageDNS:   true,
+			Platform: hivev1.Platform{
+				AWS: &hivev1aws.Platform{
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: "aws-credentials"},
+					Region:               "us-east-1",
+				},
+			},
+			ClusterMetadata: &hivev1.ClusterMetadata{
+				InfraID:                  testInfraID,
+				AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: "kubeconfig-secret"},
+			},
+			Installed: true,
+		},
+		Status: hivev1.ClusterDeploymentStatus{
+			Conditions: []hivev1.ClusterDeploymentCondition{{
+				Type:   hivev1.UnreachableCondition,
+				Status: corev1.ConditionFalse,
+			}},
+		},
+	}
+	for _, mod := range mods {
+		mod(cd)
+	}
+	return cd
+}
+
+func testDNSZone(available bool) *hivev1.DNSZone {
+	status := corev1.ConditionFalse
+	if available {
+		status = corev1.ConditionTrue
+	}
+	return &hivev1.DNSZone{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      controllerutils.DNSZoneName(testName),
+			Namespace: testNamespace,
+		},
+		Spec: hivev1.DNSZoneSpec{
+			Zone: testZone,
+			AWS:  &hivev1.AWSDNSZoneSpec{},
+		},
+		Status: hivev1.DNSZoneStatus{
+			AWS: &hivev1.AWSDNSZoneStatus{ZoneID: pointer.StringPtr("Z1234")},
+			Conditions: []hivev1.DNSZoneCondition{{
+				Type:   hivev1.ZoneAvailableDNSZoneCondition,
+				Status: status,
+			}},
+		},
+	}
+}
+
+func testRouterService(ingress ...corev1.LoadBalancerIngress) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      routerServiceName,
+			Namespace: routerNamespace,
+		},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{Ingress: ingress},
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	cases := []struct {
+		name              string
+		cd                *hivev1.ClusterDeployment
+		dnsZone           *hivev1.DNSZone
+		routerService     *corev1.Service
+		actuator          *fakeActuator
+		actuatorErr       error
+		expectRemoteCall  bool
+		expectErr         bool
+		expectedRecords   map[string]string
+		expectedCondition *hivev1.ClusterDeploymentCondition
+	}{
+		{
+			name: "installed by hive",
+			cd: testClusterDeployment(func(cd *hivev1.ClusterDeployment) {
+				cd.Status.ProvisionRef = &corev1.LocalObjectReference{Name: "foo-0-abcde"}
+			}),
+			dnsZone: testDNSZone(true),
+		},
+		{
+			name: "managed DNS disabled",
+			cd: testClusterDeployment(func(cd *hivev1.ClusterDeployment) {
+				cd.Spec.ManageDNS = false
+			}),
+			dnsZone: testDNSZone(true),
+		},
+		{
+			name: "no DNS zone",
+			cd:   testClusterDeployment(),
+			expectedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionFalse,
+				Reason: dnsZoneNotAvailableReason,
+			},
+		},
+		{
+			name:    "DNS zone not available",
+			cd:      testClusterDeployment(),
+			dnsZone: testDNSZone(false),
+			expectedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionFalse,
+				Reason: dnsZoneNotAvailableReason,
+			},
+		},
+		{
+			name:        "unsupported platform",
+			cd:          testClusterDeployment(),
+			dnsZone:     testDNSZone(true),
+			actuatorErr: errUnsupportedPlatform,
+			expectedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionFalse,
+				Reason: unsupportedPlatformReason,
+			},
+		},
+		{
+			name:             "records updated",
+			cd:               testClusterDeployment(),
+			dnsZone:          testDNSZone(true),
+			routerService:    testRouterService(corev1.LoadBalancerIngress{Hostname: testIngressAddress}),
+			actuator:         &fakeActuator{},
+			expectRemoteCall: true,
+			expectedRecords: map[string]string{
+				"api.foo." + testZone:    testAPIAddress,
+				"*.apps.foo." + testZone: testIngressAddress,
+			},
+			expectedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionTrue,
+				Reason: recordsReadyReason,
+			},
+		},
+		{
+			name:             "ingress IP address",
+			cd:               testClusterDeployment(),
+			dnsZone:          testDNSZone(true),
+			routerService:    testRouterService(corev1.LoadBalancerIngress{IP: "10.0.0.1"}),
+			actuator:         &fakeActuator{},
+			expectRemoteCall: true,
+			expectedRecords: map[string]string{
+				"api.foo." + testZone:    testAPIAddress,
+				"*.apps.foo." + testZone: "10.0.0.1",
+			},
+			expectedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionTrue,
+				Reason: recordsReadyReason,
+			},
+		},
+		{
+			name:             "API load balancer not found",
+			cd:               testClusterDeployment(),
+			dnsZone:          testDNSZone(true),
+			routerService:    testRouterService(corev1.LoadBalancerIngress{Hostname: testIngressAddress}),
+			actuator:         &fakeActuator{apiAddressErr: errors.New("load balancer not found")},
+			expectRemoteCall: true,
+			expectErr:        true,
+			expectedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionFalse,
+				Reason: addressDiscoveryFailedReason,
+			},
+		},
+		{
+			name:             "router service without load balancer",
+			cd:               testClusterDeployment(),
+			dnsZone:          testDNSZone(true),
+			routerService:    testRouterService(),
+			actuator:         &fakeActuator{},
+			expectRemoteCall: true,
+			expectErr:        true,
+			expectedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionFalse,
+				Reason: addressDiscoveryFailedReason,
+			},
+		},
+		{
+			name:             "record update failed",
+			cd:               testClusterDeployment(),
+			dnsZone:          testDNSZone(true),
+			routerService:    testRouterService(corev1.LoadBalancerIngress{Hostname: testIngressAddress}),
+			actuator:         &fakeActuator{recordErr: errors.New("access denied")},
+			expectRemoteCall: true,
+			expectErr:        true,
+			expectedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionFalse,
+				Reason: recordsUpdateFailedReason,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			existing := []runtime.Object{tc.cd}
+			if tc.dnsZone != nil {
+				existing = append(existing, tc.dnsZone)
+			}
+			fakeClient := fake.NewFakeClientWithScheme(scheme.Scheme, existing...)
+			var remoteObjects []runtime.Object
+			if tc.routerService != nil {
+				remoteObjects = append(remoteObjects, tc.routerService)
+			}
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			if tc.expectRemoteCall {
+				mockRemoteClientBuilder.EXPECT().Build().Return(fake.NewFakeClientWithScheme(scheme.Scheme, remoteObjects...), nil)
+			}
+			if tc.actuator != nil {
+				tc.actuator.records = map[string]string{}
+			}
+			r := &ReconcileClusterDNSRecords{
+				Client:                        fakeClient,
+				logger:                        log.WithField("controller", ControllerName),
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+				actuatorBuilder: func(client.Client, *hivev1.ClusterDeployment, *hivev1.DNSZone, log.FieldLogger) (actuator, error) {
+					if tc.actuatorErr != nil {
+						return nil, tc.actuatorErr
+					}
+					return tc.actuator, nil
+				},
+			}
+
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}})
+			if tc.expectErr {
+				assert.Error(t, err, "expected error from reconcile")
+			} else {
+				assert.NoError(t, err, "unexpected error from reconcile")
+			}
+			if tc.expectedRecords != nil {
+				assert.Equal(t, tc.expectedRecords, tc.actuator.records, "unexpected records")
+				assert.Equal(t, resyncInterval, result.RequeueAfter, "unexpected requeue")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, cd))
+			cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ManagedDNSRecordsReadyClusterDeploymentCondition)
+			if tc.expectedCondition == nil {
+				assert.Nil(t, cond, "unexpected ManagedDNSRecordsReady condition")
+				return
+			}
+			if assert.NotNil(t, cond, "missing ManagedDNSRecordsReady condition") {
+				assert.Equal(t, tc.expectedCondition.Status, cond.Status, "unexpected condition status")
+				assert.Equal(t, tc.expectedCondition.Reason, cond.Reason, "unexpected condition reason")
+			}
+		})
+	}
+}
+
+func testDNSRouting(cd *hivev1.ClusterDeployment) {
+	cd.Spec.DNSRouting = &hivev1.DNSRouting{
+		AWS: &hivev1aws.DNSRouting{
+			HostedZoneID: "Z5678",
+			Domain:       "shared." + testZone,
+			Policy:       hivev1aws.FailoverDNSRoutingPolicy,
+			Failover:     hivev1aws.PrimaryDNSFailoverRole,
+			HealthCheck:  &hivev1aws.DNSHealthCheck{},
+		},
+	}
+}
+
+func withDNSRoutingStatus(status *hivev1aws.DNSRoutingStatus) func(*hivev1.ClusterDeployment) {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Finalizers = append(cd.Finalizers, dnsRoutingFinalizer)
+		cd.Status.Platform = &hivev1.PlatformStatus{AWS: &hivev1aws.PlatformStatus{DNSRouting: status}}
+	}
+}
+
+func TestReconcileDNSRouting(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	sharedRecords := []string{"api.shared." + testZone, "*.apps.shared." + testZone}
+	cases := []struct {
+		name                       string
+		cd                         *hivev1.ClusterDeployment
+		healthCheckID              string
+		routedRecordErr            error
+		expectRemoteCall           bool
+		expectErr                  bool
+		expectedRoutedRecords      map[string]string
+		expectedDeletedRecords     []string
+		expectedDeletedChecks      []string
+		expectedStatus             *hivev1aws.DNSRoutingStatus
+		expectedFinalizer          bool
+		expectedConditionReason    string
+		expectedEnsuredHealthCheck string
+	}{
+		{
+			name:             "routed records created",
+			cd:               testClusterDeployment(testDNSRouting),
+			healthCheckID:    "hc-1",
+			expectRemoteCall: true,
+			expectedRoutedRecords: map[string]string{
+				"api.shared." + testZone:    testAPIAddress + "/" + testInfraID + "/hc-1",
+				"*.apps.shared." + testZone: testIngressAddress + "/" + testInfraID + "/hc-1",
+			},
+			expectedStatus: &hivev1aws.DNSRoutingStatus{
+				HostedZoneID:  "Z5678",
+				RecordNames:   sharedRecords,
+				SetIdentifier: testInfraID,
+				HealthCheckID: "hc-1",
+			},
+			expectedFinalizer:       true,
+			expectedConditionReason: recordsReadyReason,
+		},
+		{
+			name: "routed records moved to another zone",
+			cd: testClusterDeployment(testDNSRouting, withDNSRoutingStatus(&hivev1aws.DNSRoutingStatus{
+				HostedZoneID:  "Z0000",
+				RecordNames:   sharedRecords,
+				SetIdentifier: testInfraID,
+				HealthCheckID: "hc-1",
+			})),
+			healthCheckID:    "hc-1",
+			expectRemoteCall: true,
+			expectedRoutedRecords: map[string]string{
+				"api.shared." + testZone:    testAPIAddress + "/" + testInfraID + "/hc-1",
+				"*.apps.shared." + testZone: testIngressAddress + "/" + testInfraID + "/hc-1",
+			},
+			expectedDeletedRecords: []string{
+				"Z0000/api.shared." + testZone + "/" + testInfraID,
+				"Z0000/*.apps.shared." + testZone + "/" + testInfraID,
+			},
+			expectedStatus: &hivev1aws.DNSRoutingStatus{
+				HostedZoneID:  "Z5678",
+				RecordNames:   sharedRecords,
+				SetIdentifier: testInfraID,
+				HealthCheckID: "hc-1",
+			},
+			expectedFinalizer:          true,
+			expectedConditionReason:    recordsReadyReason,
+			expectedEnsuredHealthCheck: "hc-1",
+		},
+		{
+			name: "health check replaced",
+			cd: testClusterDeployment(testDNSRouting, withDNSRoutingStatus(&hivev1aws.DNSRoutingStatus{
+				HostedZoneID:  "Z5678",
+				RecordNames:   sharedRecords,
+				SetIdentifier: testInfraID,
+				HealthCheckID: "hc-1",
+			})),
+			healthCheckID:    "hc-2",
+			expectRemoteCall: true,
+			expectedRoutedRecords: map[string]string{
+				"api.shared." + testZone:    testAPIAddress + "/" + testInfraID + "/hc-2",
+				"*.apps.shared." + testZone: testIngressAddress + "/" + testInfraID + "/hc-2",
+			},
+			expectedDeletedChecks: []string{"hc-1"},
+			expectedStatus: &hivev1aws.DNSRoutingStatus{
+				HostedZoneID:  "Z5678",
+				RecordNames:   sharedRecords,
+				SetIdentifier: testInfraID,
+				HealthCheckID: "hc-2",
+			},
+			expectedFinalizer:          true,
+			expectedConditionReason:    recordsReadyReason,
+			expectedEnsuredHealthCheck: "hc-1",
+		},
+		{
+			name:             "routed record update failed",
+			cd:               testClusterDeployment(testDNSRouting),
+			routedRecordErr:  errors.New("access denied"),
+			expectRemoteCall: true,
+			expectErr:        true,
+			expectedStatus: &hivev1aws.DNSRoutingStatus{
+				HostedZoneID:  "Z5678",
+				RecordNames:   sharedRecords,
+				SetIdentifier: testInfraID,
+			},
+			expectedFinalizer:       true,
+			expectedConditionReason: routedRecordsUpdateFailedReason,
+		},
+		{
+			name: "hosted zone not allowed",
+			cd: testClusterDeployment(testDNSRouting, func(cd *hivev1.ClusterDeployment) {
+				cd.Spec.DNSRouting.AWS.HostedZoneID = "Z9999"
+			}),
+			expectRemoteCall:        true,
+			expectErr:               true,
+			expectedRoutedRecords:   map[string]string{},
+			expectedConditionReason: routedRecordsUpdateFailedReason,
+		},
+		{
+			name: "DNS routing removed",
+			cd: testClusterDeployment(withDNSRoutingStatus(&hivev1aws.DNSRoutingStatus{
+				HostedZoneID:  "Z5678",
+				RecordNames:   sharedRecords,
+				SetIdentifier: testInfraID,
+				HealthCheckID: "hc-1",
+			})),
+			expectRemoteCall: true,
+			expectedDeletedRecords: []string{
+				"Z5678/api.shared." + testZone + "/" + testInfraID,
+				"Z5678/*.apps.shared." + testZone + "/" + testInfraID,
+			},
+			expectedDeletedChecks:   []string{"hc-1"},
+			expectedConditionReason: recordsReadyReason,
+		},
+		{
+			name: "deleted cluster deployment",
+			cd: testClusterDeployment(testDNSRouting, withDNSRoutingStatus(&hivev1aws.DNSRoutingStatus{
+				HostedZoneID:  "Z5678",
+				RecordNames:   sharedRecords,
+				SetIdentifier: testInfraID,
+				HealthCheckID: "hc-1",
+			}), func(cd *hivev1.ClusterDeployment) {
+				now := metav1.Now()
+				cd.DeletionTimestamp = &now
+				cd.Finalizers = append(cd.Finalizers, hivev1.FinalizerDeprovision)
+			}),
+			expectedDeletedRecords: []string{
+				"Z5678/api.shared." + testZone + "/" + testInfraID,
+				"Z5678/*.apps.shared." + testZone + "/" + testInfraID,
+			},
+			expectedDeletedChecks: []string{"hc-1"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewFakeClientWithScheme(scheme.Scheme, tc.cd, testDNSZone(true))
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			if tc.expectRemoteCall {
+				mockRemoteClientBuilder.EXPECT().Build().Return(fake.NewFakeClientWithScheme(scheme.Scheme,
+					testRouterService(corev1.LoadBalancerIngress{Hostname: testIngressAddress})), nil)
+			}
+			act := &fakeRoutingActuator{
+				fakeActuator:    fakeActuator{records: map[string]string{}},
+				routedRecords:   map[string]string{},
+				healthCheckID:   tc.healthCheckID,
+				routedRecordErr: tc.routedRecordErr,
+			}
+			r := &ReconcileClusterDNSRecords{
+				Client:                        fakeClient,
+				logger:                        log.WithField("controller", ControllerName),
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+				actuatorBuilder: func(client.Client, *hivev1.ClusterDeployment, *hivev1.DNSZone, log.FieldLogger) (actuator, error) {
+					return act, nil
+				},
+				managedDomains: []hivev1.ManageDNSConfig{{
+					Domains: []string{"com"},
+					AWS:     &hivev1.ManageDNSAWSConfig{RoutingHostedZoneIDs: []string{"Z5678", "Z0000"}},
+				}},
+			}
+
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}})
+			if tc.expectErr {
+				assert.Error(t, err, "expected error from reconcile")
+			} else {
+				assert.NoError(t, err, "unexpected error from reconcile")
+			}
+			if tc.expectedRoutedRecords != nil {
+				assert.Equal(t, tc.expectedRoutedRecords, act.routedRecords, "unexpected routed records")
+			}
+			assert.Equal(t, tc.expectedDeletedRecords, act.deletedRecords, "unexpected deleted records")
+			assert.Equal(t, tc.expectedDeletedChecks, act.deletedHealthChecks, "unexpected deleted health checks")
+			assert.Equal(t, tc.expectedEnsuredHealthCheck, act.ensuredHealthCheckID, "unexpected current health check")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, cd))
+			assert.Equal(t, tc.expectedStatus, dnsRoutingStatus(cd), "unexpected DNS routing status")
+			assert.Equal(t, tc.expectedFinalizer, controllerutils.HasFinalizer(cd, dnsRoutingFinalizer), "unexpected DNS routing finalizer")
+			if tc.expectedConditionReason != "" {
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ManagedDNSRecordsReadyClusterDeploymentCondition)
+				if assert.NotNil(t, cond, "missing ManagedDNSRecordsReady condition") {
+					assert.Equal(t, tc.expectedConditionReason, cond.Reason, "unexpected condition reason")
+				}
+			}
+		})
+	}
+}
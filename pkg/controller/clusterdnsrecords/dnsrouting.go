@@ -0,0 +1,186 @@
+package clusterdnsrecords
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/manageddns"
+)
+
+// dnsRoutingFinalizer is used on ClusterDeployments to remove their records with a routing policy, and their health
+// checks, from the shared zone.
+const dnsRoutingFinalizer = "hive.openshift.io/dns-routing"
+
+// awsDNSRouting returns the Route53 routing configuration of the cluster, or nil if there is none.
+func awsDNSRouting(cd *hivev1.ClusterDeployment) *hivev1aws.DNSRouting {
+	if cd.Spec.DNSRouting == nil {
+		return nil
+	}
+	return cd.Spec.DNSRouting.AWS
+}
+
+// dnsRoutingStatus returns the observed state of the records with a routing policy of the cluster, or nil if there
+// are none.
+func dnsRoutingStatus(cd *hivev1.ClusterDeployment) *hivev1aws.DNSRoutingStatus {
+	if cd.Status.Platform == nil || cd.Status.Platform.AWS == nil {
+		return nil
+	}
+	return cd.Status.Platform.AWS.DNSRouting
+}
+
+// routingSetIdentifier returns the set identifier of the records with a routing policy of the cluster. It is always
+// the infra ID of the cluster, so that a cluster cannot take over the records of another cluster in the shared zone.
+func routingSetIdentifier(cd *hivev1.ClusterDeployment) string {
+	return cd.Spec.ClusterMetadata.InfraID
+}
+
+// ensureDNSRouting maintains the records with a routing policy of the cluster in the shared zone, pointing to the
+// API and ingress addresses, along with the health check of the API.
+func (r *ReconcileClusterDNSRecords) ensureDNSRouting(ctx context.Context, cd *hivev1.ClusterDeployment, act actuator, apiAddress, ingressAddress string, cdLog log.FieldLogger) error {
+	routingAct, ok := act.(routingActuator)
+	if !ok {
+		return errors.New("records with a routing policy are not supported on the platform of the managed DNS zone")
+	}
+	routing := awsDNSRouting(cd)
+	// The records are maintained with the credentials of the managed DNS zone, which may be able to write to hosted
+	// zones of other clusters.
+	if !manageddns.RoutingHostedZoneAllowed(r.managedDomains, cd.Spec.BaseDomain, routing.HostedZoneID) {
+		return fmt.Errorf("hosted zone %s is not allowed for records with a routing policy of clusters in %s",
+			routing.HostedZoneID, cd.Spec.BaseDomain)
+	}
+	if !controllerutils.HasFinalizer(cd, dnsRoutingFinalizer) {
+		cdLog.Debug("adding DNS routing finalizer")
+		controllerutils.AddFinalizer(cd, dnsRoutingFinalizer)
+		if err := r.Update(context.TODO(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not add DNS routing finalizer")
+			return err
+		}
+	}
+
+	desired := &hivev1aws.DNSRoutingStatus{
+		HostedZoneID:  routing.HostedZoneID,
+		RecordNames:   []string{"api." + routing.Domain, "*.apps." + routing.Domain},
+		SetIdentifier: routingSetIdentifier(cd),
+	}
+	current := dnsRoutingStatus(cd)
+	var currentHealthCheckID string
+	if current != nil {
+		currentHealthCheckID = current.HealthCheckID
+		// Records left under another zone, name or set identifier would keep receiving traffic.
+		if current.HostedZoneID != desired.HostedZoneID || current.SetIdentifier != desired.SetIdentifier ||
+			!reflect.DeepEqual(current.RecordNames, desired.RecordNames) {
+			cdLog.Info("removing records with a routing policy of the previous configuration")
+			if err := routingAct.deleteRoutedRecords(ctx, current); err != nil {
+				return err
+			}
+		}
+	}
+
+	if routing.HealthCheck != nil {
+		healthCheckID, err := routingAct.ensureHealthCheck(ctx, cd, currentHealthCheckID, apiAddress)
+		if err != nil {
+			return err
+		}
+		desired.HealthCheckID = healthCheckID
+	}
+	// The records are recorded before they are created so that they are removed even if they are only partially
+	// created.
+	if err := r.setDNSRoutingStatus(cd, desired, cdLog); err != nil {
+		return err
+	}
+
+	addresses := []string{apiAddress, ingressAddress}
+	for i, name := range desired.RecordNames {
+		if err := routingAct.ensureRoutedRecord(ctx, cd, name, addresses[i], desired.HealthCheckID); err != nil {
+			return errors.Wrapf(err, "could not update record %s with routing policy", name)
+		}
+	}
+
+	if currentHealthCheckID != "" && currentHealthCheckID != desired.HealthCheckID {
+		cdLog.WithField("healthCheck", currentHealthCheckID).Info("deleting replaced health check")
+		if err := routingAct.deleteHealthCheck(ctx, currentHealthCheckID); err != nil {
+			// The health check is no longer recorded, so it has to be deleted manually.
+			cdLog.WithError(err).WithField("healthCheck", currentHealthCheckID).Error("could not delete replaced health check")
+		}
+	}
+	return nil
+}
+
+// cleanupDNSRouting removes the records with a routing policy of the cluster from the shared zone, along with the
+// health check of the API, and removes the DNS routing finalizer.
+func (r *ReconcileClusterDNSRecords) cleanupDNSRouting(ctx context.Context, cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	if status := dnsRoutingStatus(cd); status != nil {
+		if err := r.deleteRoutedRecords(ctx, cd, status, cdLog); err != nil {
+			cdLog.WithError(err).Error("could not remove records with a routing policy")
+			return err
+		}
+		if err := r.setDNSRoutingStatus(cd, nil, cdLog); err != nil {
+			return err
+		}
+	}
+	if controllerutils.HasFinalizer(cd, dnsRoutingFinalizer) {
+		cdLog.Debug("removing DNS routing finalizer")
+		controllerutils.DeleteFinalizer(cd, dnsRoutingFinalizer)
+		if err := r.Update(context.TODO(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not remove DNS routing finalizer")
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ReconcileClusterDNSRecords) deleteRoutedRecords(ctx context.Context, cd *hivev1.ClusterDeployment, status *hivev1aws.DNSRoutingStatus, cdLog log.FieldLogger) error {
+	// The records are maintained with the credentials of the managed DNS zone.
+	dnsZone := &hivev1.DNSZone{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: controllerutils.DNSZoneName(cd.Name)}, dnsZone); {
+	case apierrors.IsNotFound(err):
+		cdLog.WithField("zone", status.HostedZoneID).WithField("healthCheck", status.HealthCheckID).
+			Warn("managed DNS zone is gone, records with a routing policy and the health check must be removed manually")
+		return nil
+	case err != nil:
+		return errors.Wrap(err, "could not get managed DNS zone")
+	}
+	act, err := r.actuatorBuilder(r.Client, cd, dnsZone, cdLog)
+	if err != nil {
+		return err
+	}
+	routingAct, ok := act.(routingActuator)
+	if !ok {
+		return errors.New("records with a routing policy are not supported on the platform of the managed DNS zone")
+	}
+	if err := routingAct.deleteRoutedRecords(ctx, status); err != nil {
+		return err
+	}
+	if status.HealthCheckID != "" {
+		return routingAct.deleteHealthCheck(ctx, status.HealthCheckID)
+	}
+	return nil
+}
+
+func (r *ReconcileClusterDNSRecords) setDNSRoutingStatus(cd *hivev1.ClusterDeployment, status *hivev1aws.DNSRoutingStatus, cdLog log.FieldLogger) error {
+	if reflect.DeepEqual(dnsRoutingStatus(cd), status) {
+		return nil
+	}
+	if cd.Status.Platform == nil {
+		cd.Status.Platform = &hivev1.PlatformStatus{}
+	}
+	if cd.Status.Platform.AWS == nil {
+		cd.Status.Platform.AWS = &hivev1aws.PlatformStatus{}
+	}
+	cd.Status.Platform.AWS.DNSRouting = status
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not update DNS routing status")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package clusterdnsrecords
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+	dns "google.golang.org/api/dns/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+type gcpActuator struct {
+	// clusterClient is the client for the project of the cluster.
+	clusterClient gcpclient.Client
+	// dnsClient is the client for the project of the managed DNS zone.
+	dnsClient gcpclient.Client
+	region    string
+	zoneName  string
+}
+
+var _ actuator = &gcpActuator{}
+
+func newGCPActuator(c client.Client, cd *hivev1.ClusterDeployment, dnsZone *hivev1.DNSZone) (*gcpActuator, error) {
+	if dnsZone.Status.GCP == nil || dnsZone.Status.GCP.ZoneName == nil {
+		return nil, errors.New("managed DNS zone has no zone name")
+	}
+	clusterClient, err := gcpClientFromSecret(c, cd.Namespace, cd.Spec.Platform.GCP.CredentialsSecretRef.Name, cd.Spec.CloudClientProxySecretRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create GCP client for the cluster")
+	}
+	dnsClient, err := gcpClientFromSecret(c, dnsZone.Namespace, dnsZone.Spec.GCP.CredentialsSecretRef.Name, dnsZone.Spec.CloudClientProxySecretRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create GCP client for the managed DNS zone")
+	}
+	return &gcpActuator{
+		clusterClient: clusterClient,
+		dnsClient:     dnsClient,
+		region:        cd.Spec.Platform.GCP.Region,
+		zoneName:      *dnsZone.Status.GCP.ZoneName,
+	}, nil
+}
+
+func gcpClientFromSecret(c client.Client, namespace, name string, proxySecretRef *corev1.LocalObjectReference) (gcpclient.Client, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch GCP credentials secret")
+	}
+	proxy, err := controllerutils.GetCloudClientProxy(c, namespace, proxySecretRef)
+	if err != nil {
+		return nil, err
+	}
+	return gcpclient.NewClientFromSecretWithOptions(secret, gcpclient.Options{Proxy: proxy, Controller: hivev1.ClusterDNSRecordsControllerName})
+}
+
+// apiAddress returns the IP address of the external API load balancer, which the installer names
+// {infraID}-cluster-public-ip.
+func (a *gcpActuator) apiAddress(ctx context.Context, cd *hivev1.ClusterDeployment) (string, error) {
+	name := cd.Spec.ClusterMetadata.InfraID + "-cluster-public-ip"
+	address, err := a.clusterClient.GetAddress(ctx, a.region, name)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get address %s", name)
+	}
+	if address.Address == "" {
+		return "", errors.Errorf("address %s has no IP address", name)
+	}
+	return address.Address, nil
+}
+
+func (a *gcpActuator) ensureRecord(ctx context.Context, name, address string) error {
+	rrType := recordType(address)
+	if rrType == "CNAME" {
+		address += "."
+	}
+	desired := &dns.ResourceRecordSet{
+		Name:    name + ".",
+		Type:    rrType,
+		Ttl:     recordTTL,
+		Rrdatas: []string{address},
+	}
+	existing, err := a.dnsClient.ListResourceRecordSets(ctx, a.zoneName, gcpclient.ListResourceRecordSetsOptions{
+		Name: desired.Name,
+		Type: desired.Type,
+	})
+	if err != nil {
+		return err
+	}
+	if len(existing.Rrsets) == 0 {
+		return a.dnsClient.AddResourceRecordSet(ctx, a.zoneName, desired)
+	}
+	current := existing.Rrsets[0]
+	if current.Ttl == desired.Ttl && reflect.DeepEqual(current.Rrdatas, desired.Rrdatas) {
+		return nil
+	}
+	return a.dnsClient.UpdateResourceRecordSet(ctx, a.zoneName, desired, current)
+}
@@ -0,0 +1,212 @@
+package clusterimageset
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/registryclient"
+)
+
+const (
+	ControllerName = hivev1.ClusterImageSetControllerName
+
+	// revalidateInterval is how long to wait before validating an invalid release image again, as the image may
+	// be pushed or the pull secret fixed later.
+	revalidateInterval = 10 * time.Minute
+
+	validationTimeout = 2 * time.Minute
+)
+
+// Add creates a new ClusterImageSet controller and adds it to the manager with default RBAC. The Manager will set
+// fields on the controller and start it when the Manager is started. The controller is only added when release
+// image validation is enabled in HiveConfig.
+func Add(mgr manager.Manager) error {
+	logger := controllerutils.ControllerLogEntry(ControllerName)
+	archs, enabled := os.LookupEnv(constants.ReleaseImageValidationArchitecturesEnvVar)
+	if !enabled {
+		logger.Info("release image validation is disabled")
+		return nil
+	}
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, archs, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileClusterImageSet
+func NewReconciler(mgr manager.Manager, allowedArchitectures string, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterImageSet {
+	r := &ReconcileClusterImageSet{
+		Client:               controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger:               controllerutils.ControllerLogEntry(ControllerName),
+		allowedArchitectures: sets.NewString(),
+		registryClientFn:     registryclient.NewClient,
+	}
+	for _, arch := range strings.Split(allowedArchitectures, ",") {
+		if arch = strings.TrimSpace(arch); arch != "" {
+			r.allowedArchitectures.Insert(arch)
+		}
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileClusterImageSet, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controllerutils.NewController("clusterimageset-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterImageSet{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		r.logger.WithError(err).Error("error watching cluster image sets")
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterImageSet{}
+
+// ReconcileClusterImageSet validates the release images of ClusterImageSets.
+type ReconcileClusterImageSet struct {
+	client.Client
+	logger log.FieldLogger
+
+	// allowedArchitectures are the architectures allowed for release images. All architectures are allowed when
+	// empty.
+	allowedArchitectures sets.String
+
+	// registryClientFn is the function to build a registry client, exposed for testing.
+	registryClientFn func(pullSecret string) (registryclient.Client, error)
+}
+
+// Reconcile validates the release image of a ClusterImageSet and records the result in its Valid condition.
+func (r *ReconcileClusterImageSet) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterImageSet", request.NamespacedName)
+	logger.Debug("reconciling cluster image set")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	imageSet := &hivev1.ClusterImageSet{}
+	switch err := r.Get(context.TODO(), request.NamespacedName, imageSet); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("cluster image set not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		logger.WithError(err).Error("error getting cluster image set")
+		return reconcile.Result{}, err
+	}
+	if imageSet.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+	logger = logger.WithField("releaseImage", imageSet.Spec.ReleaseImage)
+
+	validCond := controllerutils.FindClusterImageSetCondition(imageSet.Status.Conditions, hivev1.ValidClusterImageSetCondition)
+	if validCond != nil && imageSet.Status.ObservedReleaseImage == imageSet.Spec.ReleaseImage {
+		if validCond.Status == corev1.ConditionTrue {
+			logger.Debug("release image already validated")
+			return reconcile.Result{}, nil
+		}
+		if wait := revalidateInterval - time.Since(validCond.LastProbeTime.Time); wait > 0 {
+			logger.Debug("release image recently found to be invalid")
+			return reconcile.Result{RequeueAfter: wait}, nil
+		}
+	}
+
+	status, reason, message, archs, err := r.validate(ctx, imageSet.Spec.ReleaseImage)
+	if err != nil {
+		logger.WithError(err).Error("could not validate release image")
+		return reconcile.Result{}, err
+	}
+	logger.WithField("valid", status).WithField("reason", reason).Info("validated release image")
+
+	imageSet.Status.ObservedReleaseImage = imageSet.Spec.ReleaseImage
+	imageSet.Status.Architectures = archs
+	imageSet.Status.Conditions, _ = controllerutils.SetClusterImageSetConditionWithChangeCheck(
+		imageSet.Status.Conditions,
+		hivev1.ValidClusterImageSetCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if cond := controllerutils.FindClusterImageSetCondition(imageSet.Status.Conditions, hivev1.ValidClusterImageSetCondition); cond != nil {
+		// Record the time of this validation so that invalid release images are only validated again after
+		// the revalidate interval.
+		cond.LastProbeTime = metav1.Now()
+	}
+	if err := r.Status().Update(context.TODO(), imageSet); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update cluster image set status")
+		return reconcile.Result{}, err
+	}
+
+	if status != corev1.ConditionTrue {
+		return reconcile.Result{RequeueAfter: revalidateInterval}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// validate inspects the release image, returning the status, reason, and message of the Valid condition along with
+// the architectures of the image. An error is returned only when the validation could not be attempted.
+func (r *ReconcileClusterImageSet) validate(ctx context.Context, releaseImage string) (corev1.ConditionStatus, string, string, []string, error) {
+	var pullSecret string
+	if globalPullSecretName := os.Getenv(constants.GlobalPullSecret); globalPullSecretName != "" {
+		var err error
+		pullSecret, err = controllerutils.LoadSecretData(r.Client, globalPullSecretName, controllerutils.GetHiveNamespace(), corev1.DockerConfigJsonKey)
+		if err != nil {
+			return "", "", "", nil, errors.Wrap(err, "global pull secret could not be retrieved")
+		}
+	}
+	registryClient, err := r.registryClientFn(pullSecret)
+	if err != nil {
+		return corev1.ConditionFalse, "InvalidPullSecret", err.Error(), nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, validationTimeout)
+	defer cancel()
+	archs, err := registryClient.ImageArchitectures(ctx, releaseImage)
+	switch errors.Cause(err) {
+	case nil:
+	case registryclient.ErrImageNotFound:
+		return corev1.ConditionFalse, "ReleaseImageNotFound", "release image does not exist", nil, nil
+	case registryclient.ErrUnauthorized:
+		return corev1.ConditionFalse, "ReleaseImageNotPullable", "release image cannot be pulled with the global pull secret", nil, nil
+	default:
+		return corev1.ConditionFalse, "ReleaseImageInspectionFailed", err.Error(), nil, nil
+	}
+
+	if r.allowedArchitectures.Len() > 0 && !r.allowedArchitectures.HasAny(archs...) {
+		return corev1.ConditionFalse, "ArchitectureNotAllowed",
+			fmt.Sprintf("release image architectures %v are not among the allowed architectures %v", archs, r.allowedArchitectures.List()),
+			archs, nil
+	}
+	return corev1.ConditionTrue, "ReleaseImageValid", "release image exists and can be pulled", archs, nil
+}
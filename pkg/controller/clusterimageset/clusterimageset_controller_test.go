@@ -0,0 +1,210 @@
+package clusterimageset
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/registryclient"
+	registryclientmock "github.com/openshift/hive/pkg/registryclient/mock"
+)
+
+const (
+	testName             = "openshift-v4.15.3"
+	testReleaseImage     = "quay.io/openshift-release-dev/ocp-release:4.15.3-x86_64"
+	testGlobalPullSecret = "global-pull-secret"
+	testPullSecretData   = `{"auths":{"quay.io":{"auth":"dXNlcjpwYXNz"}}}`
+)
+
+func testImageSet(mods ...func(*hivev1.ClusterImageSet)) *hivev1.ClusterImageSet {
+	is := &hivev1.ClusterImageSet{
+		ObjectMeta: metav1.ObjectMeta{Name: testName},
+		Spec:       hivev1.ClusterImageSetSpec{ReleaseImage: testReleaseImage},
+	}
+	for _, mod := range mods {
+		mod(is)
+	}
+	return is
+}
+
+func withValidCondition(status corev1.ConditionStatus, observed string, probed time.Time) func(*hivev1.ClusterImageSet) {
+	return func(is *hivev1.ClusterImageSet) {
+		is.Status.ObservedReleaseImage = observed
+		is.Status.Conditions = []hivev1.ClusterImageSetCondition{{
+			Type:          hivev1.ValidClusterImageSetCondition,
+			Status:        status,
+			Reason:        "Previous",
+			LastProbeTime: metav1.NewTime(probed),
+		}}
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	os.Setenv(constants.GlobalPullSecret, testGlobalPullSecret)
+	defer os.Unsetenv(constants.GlobalPullSecret)
+
+	globalPullSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: constants.DefaultHiveNamespace, Name: testGlobalPullSecret},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(testPullSecretData)},
+	}
+
+	cases := []struct {
+		name                  string
+		imageSet              *hivev1.ClusterImageSet
+		allowedArchitectures  []string
+		archs                 []string
+		inspectErr            error
+		expectInspect         bool
+		expectedStatus        corev1.ConditionStatus
+		expectedReason        string
+		expectedArchitectures []string
+		expectRequeue         bool
+	}{
+		{
+			name:                  "valid release image",
+			imageSet:              testImageSet(),
+			archs:                 []string{"amd64"},
+			expectInspect:         true,
+			expectedStatus:        corev1.ConditionTrue,
+			expectedReason:        "ReleaseImageValid",
+			expectedArchitectures: []string{"amd64"},
+		},
+		{
+			name:           "release image not found",
+			imageSet:       testImageSet(),
+			inspectErr:     registryclient.ErrImageNotFound,
+			expectInspect:  true,
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "ReleaseImageNotFound",
+			expectRequeue:  true,
+		},
+		{
+			name:           "release image not pullable",
+			imageSet:       testImageSet(),
+			inspectErr:     registryclient.ErrUnauthorized,
+			expectInspect:  true,
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "ReleaseImageNotPullable",
+			expectRequeue:  true,
+		},
+		{
+			name:           "registry error",
+			imageSet:       testImageSet(),
+			inspectErr:     errors.New("connection refused"),
+			expectInspect:  true,
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "ReleaseImageInspectionFailed",
+			expectRequeue:  true,
+		},
+		{
+			name:                  "architecture not allowed",
+			imageSet:              testImageSet(),
+			allowedArchitectures:  []string{"arm64"},
+			archs:                 []string{"amd64"},
+			expectInspect:         true,
+			expectedStatus:        corev1.ConditionFalse,
+			expectedReason:        "ArchitectureNotAllowed",
+			expectedArchitectures: []string{"amd64"},
+			expectRequeue:         true,
+		},
+		{
+			name:                  "one of multiple architectures allowed",
+			imageSet:              testImageSet(),
+			allowedArchitectures:  []string{"arm64"},
+			archs:                 []string{"amd64", "arm64"},
+			expectInspect:         true,
+			expectedStatus:        corev1.ConditionTrue,
+			expectedReason:        "ReleaseImageValid",
+			expectedArchitectures: []string{"amd64", "arm64"},
+		},
+		{
+			name:           "already valid",
+			imageSet:       testImageSet(withValidCondition(corev1.ConditionTrue, testReleaseImage, time.Now().Add(-time.Hour))),
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: "Previous",
+		},
+		{
+			name:                  "release image changed",
+			imageSet:              testImageSet(withValidCondition(corev1.ConditionTrue, "quay.io/old", time.Now())),
+			archs:                 []string{"amd64"},
+			expectInspect:         true,
+			expectedStatus:        corev1.ConditionTrue,
+			expectedReason:        "ReleaseImageValid",
+			expectedArchitectures: []string{"amd64"},
+		},
+		{
+			name:           "recently invalid",
+			imageSet:       testImageSet(withValidCondition(corev1.ConditionFalse, testReleaseImage, time.Now())),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "Previous",
+			expectRequeue:  true,
+		},
+		{
+			name:                  "revalidate invalid",
+			imageSet:              testImageSet(withValidCondition(corev1.ConditionFalse, testReleaseImage, time.Now().Add(-time.Hour))),
+			archs:                 []string{"amd64"},
+			expectInspect:         true,
+			expectedStatus:        corev1.ConditionTrue,
+			expectedReason:        "ReleaseImageValid",
+			expectedArchitectures: []string{"amd64"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewFakeClientWithScheme(scheme.Scheme, []runtime.Object{tc.imageSet, globalPullSecret}...)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRegistryClient := registryclientmock.NewMockClient(mockCtrl)
+			if tc.expectInspect {
+				mockRegistryClient.EXPECT().ImageArchitectures(gomock.Any(), testReleaseImage).Return(tc.archs, tc.inspectErr)
+			}
+			r := &ReconcileClusterImageSet{
+				Client:               fakeClient,
+				logger:               log.WithField("controller", ControllerName),
+				allowedArchitectures: sets.NewString(tc.allowedArchitectures...),
+				registryClientFn: func(pullSecret string) (registryclient.Client, error) {
+					assert.Equal(t, testPullSecretData, pullSecret, "unexpected pull secret")
+					return mockRegistryClient, nil
+				},
+			}
+
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: testName}})
+			require.NoError(t, err, "unexpected error from reconcile")
+			assert.Equal(t, tc.expectRequeue, result.RequeueAfter > 0, "unexpected requeue")
+
+			imageSet := &hivev1.ClusterImageSet{}
+			require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: testName}, imageSet), "unexpected error getting image set")
+			cond := controllerutils.FindClusterImageSetCondition(imageSet.Status.Conditions, hivev1.ValidClusterImageSetCondition)
+			if assert.NotNil(t, cond, "missing Valid condition") {
+				assert.Equal(t, tc.expectedStatus, cond.Status, "unexpected condition status")
+				assert.Equal(t, tc.expectedReason, cond.Reason, "unexpected condition reason")
+			}
+			if tc.expectInspect {
+				assert.Equal(t, testReleaseImage, imageSet.Status.ObservedReleaseImage, "unexpected observed release image")
+				assert.Equal(t, tc.expectedArchitectures, imageSet.Status.Architectures, "unexpected architectures")
+			}
+		})
+	}
+}
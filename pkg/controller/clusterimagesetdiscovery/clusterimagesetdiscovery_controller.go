@@ -0,0 +1,337 @@
+package clusterimagesetdiscovery
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.ClusterImageSetDiscoveryControllerName
+
+	defaultUpstreamURL     = "https://api.openshift.com/api/upgrades_info/v1/graph"
+	defaultRefreshInterval = time.Hour
+	defaultArch            = "amd64"
+
+	// discoveryRequestName is the name of the single request through which all discovery is done.
+	discoveryRequestName = "clusterimageset-discovery"
+
+	graphQueryTimeout = 30 * time.Second
+)
+
+// Add creates a new ClusterImageSetDiscovery controller and adds it to the manager with default RBAC. The Manager
+// will set fields on the controller and start it when the Manager is started.
+func Add(mgr manager.Manager) error {
+	logger := controllerutils.ControllerLogEntry(ControllerName)
+	config, err := ReadClusterImageSetDiscoveryControllerConfigFile()
+	if err != nil {
+		logger.WithError(err).Error("could not load configuration")
+		return err
+	}
+	if config == nil || len(config.Channels) == 0 {
+		logger.Info("no update graph channels configured, clusterimageset discovery is disabled")
+		return nil
+	}
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, config, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileClusterImageSetDiscovery
+func NewReconciler(mgr manager.Manager, config *hivev1.ClusterImageSetDiscoveryConfig, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterImageSetDiscovery {
+	return &ReconcileClusterImageSetDiscovery{
+		Client:     controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		config:     config,
+		httpClient: &http.Client{Timeout: graphQueryTimeout},
+		logger:     controllerutils.ControllerLogEntry(ControllerName),
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileClusterImageSetDiscovery, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controllerutils.NewController("clusterimagesetdiscovery-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Trigger the initial discovery. Later discoveries are triggered by requeueing after the refresh interval.
+	initial := make(chan event.GenericEvent, 1)
+	initial <- event.GenericEvent{Object: &hivev1.ClusterImageSet{ObjectMeta: metav1.ObjectMeta{Name: discoveryRequestName}}}
+	if err := c.Watch(&source.Channel{Source: initial}, &handler.EnqueueRequestForObject{}); err != nil {
+		r.logger.WithError(err).Error("error watching initial discovery trigger")
+		return err
+	}
+
+	// Watch for changes to discovered ClusterImageSets so that they are restored if changed or deleted.
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterImageSet{}}, handler.EnqueueRequestsFromMapFunc(
+		func(o client.Object) []reconcile.Request {
+			if _, ok := o.GetLabels()[constants.ClusterImageSetDiscoveredLabel]; !ok {
+				return nil
+			}
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: discoveryRequestName}}}
+		},
+	)); err != nil {
+		r.logger.WithError(err).Error("error watching cluster image sets")
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterImageSetDiscovery{}
+
+// ReconcileClusterImageSetDiscovery creates ClusterImageSets for the releases in channels of an update graph.
+type ReconcileClusterImageSetDiscovery struct {
+	client.Client
+	config     *hivev1.ClusterImageSetDiscoveryConfig
+	httpClient *http.Client
+	logger     log.FieldLogger
+}
+
+// discoveredImageSet is a ClusterImageSet that should exist for a discovered release.
+type discoveredImageSet struct {
+	version      string
+	releaseImage string
+	channels     sets.String
+}
+
+// Reconcile queries the update graph and creates or updates the ClusterImageSets of the latest releases in the
+// configured channels.
+func (r *ReconcileClusterImageSetDiscovery) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "discovery", request.NamespacedName)
+	logger.Info("discovering releases")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	upstream := r.config.UpstreamURL
+	if upstream == "" {
+		upstream = defaultUpstreamURL
+	}
+
+	desired := map[string]*discoveredImageSet{}
+	var discoveryErrs []string
+	for _, channel := range r.config.Channels {
+		arch := channel.Arch
+		if arch == "" {
+			arch = defaultArch
+		}
+		latest := channel.Latest
+		if latest < 1 {
+			latest = 1
+		}
+		chLogger := logger.WithField("channel", channel.Name).WithField("arch", arch)
+		releases, err := latestReleases(ctx, r.httpClient, upstream, channel.Name, arch, latest)
+		if err != nil {
+			chLogger.WithError(err).Error("could not discover releases in channel")
+			discoveryErrs = append(discoveryErrs, errors.Wrapf(err, "channel %s", channel.Name).Error())
+			continue
+		}
+		if len(releases) == 0 {
+			chLogger.Warn("no releases found in channel")
+		}
+		for _, rel := range releases {
+			name := imageSetName(rel.version.String(), arch)
+			if d, ok := desired[name]; ok {
+				d.channels.Insert(channel.Name)
+				continue
+			}
+			desired[name] = &discoveredImageSet{
+				version:      rel.version.String(),
+				releaseImage: rel.payload,
+				channels:     sets.NewString(channel.Name),
+			}
+		}
+	}
+
+	names := make([]string, 0, len(desired))
+	for name := range desired {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := r.ensureImageSet(name, desired[name], logger); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if len(discoveryErrs) > 0 {
+		// Do not prune when discovery failed for a channel, as the latest releases of that channel are unknown.
+		return reconcile.Result{}, errors.Errorf("failed to discover releases: %s", strings.Join(discoveryErrs, "; "))
+	}
+
+	if r.config.Prune {
+		if err := r.pruneImageSets(desired, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	refreshInterval := defaultRefreshInterval
+	if r.config.RefreshInterval != nil && r.config.RefreshInterval.Duration > 0 {
+		refreshInterval = r.config.RefreshInterval.Duration
+	}
+	return reconcile.Result{RequeueAfter: refreshInterval}, nil
+}
+
+// ensureImageSet creates the ClusterImageSet for a discovered release, or updates it if it was previously
+// discovered. ClusterImageSets with the same name that were not created by discovery are left alone.
+func (r *ReconcileClusterImageSetDiscovery) ensureImageSet(name string, d *discoveredImageSet, logger log.FieldLogger) error {
+	logger = logger.WithField("clusterImageSet", name)
+	channels := strings.Join(d.channels.List(), ",")
+
+	imageSet := &hivev1.ClusterImageSet{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Name: name}, imageSet); {
+	case apierrors.IsNotFound(err):
+		imageSet = &hivev1.ClusterImageSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Labels:      map[string]string{constants.ClusterImageSetDiscoveredLabel: d.version},
+				Annotations: map[string]string{constants.ClusterImageSetChannelsAnnotation: channels},
+			},
+			Spec: hivev1.ClusterImageSetSpec{
+				ReleaseImage: d.releaseImage,
+			},
+		}
+		logger.WithField("releaseImage", d.releaseImage).Info("creating discovered cluster image set")
+		if err := r.Create(context.TODO(), imageSet); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not create cluster image set")
+			return err
+		}
+		return nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get cluster image set")
+		return err
+	}
+
+	if _, ok := imageSet.Labels[constants.ClusterImageSetDiscoveredLabel]; !ok {
+		logger.Debug("cluster image set was not discovered, leaving it alone")
+		return nil
+	}
+	if imageSet.Spec.ReleaseImage == d.releaseImage &&
+		imageSet.Labels[constants.ClusterImageSetDiscoveredLabel] == d.version &&
+		imageSet.Annotations[constants.ClusterImageSetChannelsAnnotation] == channels {
+		return nil
+	}
+	imageSet.Spec.ReleaseImage = d.releaseImage
+	imageSet.Labels[constants.ClusterImageSetDiscoveredLabel] = d.version
+	if imageSet.Annotations == nil {
+		imageSet.Annotations = map[string]string{}
+	}
+	imageSet.Annotations[constants.ClusterImageSetChannelsAnnotation] = channels
+	logger.WithField("releaseImage", d.releaseImage).Info("updating discovered cluster image set")
+	if err := r.Update(context.TODO(), imageSet); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update cluster image set")
+		return err
+	}
+	return nil
+}
+
+// pruneImageSets deletes discovered ClusterImageSets that are no longer desired and are not referenced by any
+// ClusterPool or ClusterDeployment.
+func (r *ReconcileClusterImageSetDiscovery) pruneImageSets(desired map[string]*discoveredImageSet, logger log.FieldLogger) error {
+	imageSets := &hivev1.ClusterImageSetList{}
+	if err := r.List(context.TODO(), imageSets, client.HasLabels{constants.ClusterImageSetDiscoveredLabel}); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list cluster image sets")
+		return err
+	}
+	pools := &hivev1.ClusterPoolList{}
+	if err := r.List(context.TODO(), pools); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list cluster pools")
+		return err
+	}
+	cds := &hivev1.ClusterDeploymentList{}
+	if err := r.List(context.TODO(), cds); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list cluster deployments")
+		return err
+	}
+	inUse := sets.NewString()
+	for _, pool := range pools.Items {
+		inUse.Insert(pool.Spec.ImageSetRef.Name)
+	}
+	for _, cd := range cds.Items {
+		if cd.Spec.Provisioning != nil && cd.Spec.Provisioning.ImageSetRef != nil {
+			inUse.Insert(cd.Spec.Provisioning.ImageSetRef.Name)
+		}
+	}
+	for i := range imageSets.Items {
+		imageSet := &imageSets.Items[i]
+		if _, ok := desired[imageSet.Name]; ok {
+			continue
+		}
+		isLogger := logger.WithField("clusterImageSet", imageSet.Name)
+		if inUse.Has(imageSet.Name) {
+			isLogger.Debug("not pruning cluster image set referenced by a cluster pool or cluster deployment")
+			continue
+		}
+		isLogger.Info("pruning discovered cluster image set")
+		if err := r.Delete(context.TODO(), imageSet); err != nil && !apierrors.IsNotFound(err) {
+			isLogger.WithError(err).Log(controllerutils.LogLevel(err), "could not delete cluster image set")
+			return err
+		}
+	}
+	return nil
+}
+
+// imageSetName returns the name of the ClusterImageSet for a release.
+func imageSetName(version, arch string) string {
+	name := "openshift-v" + version
+	if arch != defaultArch {
+		name += "-" + arch
+	}
+	return name
+}
+
+// ReadClusterImageSetDiscoveryControllerConfigFile reads the configuration from the env
+// and returns the configuration if present. A nil configuration is returned when discovery is not configured.
+func ReadClusterImageSetDiscoveryControllerConfigFile() (*hivev1.ClusterImageSetDiscoveryConfig, error) {
+	fPath := os.Getenv(constants.ClusterImageSetDiscoveryControllerConfigFileEnvVar)
+	if len(fPath) == 0 {
+		return nil, nil
+	}
+
+	fileBytes, err := ioutil.ReadFile(fPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the clusterimageset discovery controller config file")
+	}
+	config := &hivev1.ClusterImageSetDiscoveryConfig{}
+	if err := json.Unmarshal(fileBytes, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
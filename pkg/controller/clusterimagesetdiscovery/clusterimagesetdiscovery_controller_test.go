@@ -0,0 +1,220 @@
+package clusterimagesetdiscovery
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	stable415 = `{"nodes":[
+		{"version":"4.15.1","payload":"quay.io/openshift-release-dev/ocp-release@sha256:1"},
+		{"version":"4.15.3","payload":"quay.io/openshift-release-dev/ocp-release@sha256:3"},
+		{"version":"4.15.2","payload":"quay.io/openshift-release-dev/ocp-release@sha256:2"}
+	],"edges":[[0,1],[0,2],[2,1]]}`
+	fast415 = `{"nodes":[
+		{"version":"4.15.3","payload":"quay.io/openshift-release-dev/ocp-release@sha256:3"},
+		{"version":"4.15.4","payload":"quay.io/openshift-release-dev/ocp-release@sha256:4"}
+	],"edges":[[0,1]]}`
+	stable415ARM = `{"nodes":[
+		{"version":"4.15.3","payload":"quay.io/openshift-release-dev/ocp-release@sha256:3-arm"}
+	],"edges":[]}`
+)
+
+func testImageSet(name, releaseImage, version string) *hivev1.ClusterImageSet {
+	is := &hivev1.ClusterImageSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       hivev1.ClusterImageSetSpec{ReleaseImage: releaseImage},
+	}
+	if version != "" {
+		is.Labels = map[string]string{constants.ClusterImageSetDiscoveredLabel: version}
+	}
+	return is
+}
+
+func testPool(imageSetName string) *hivev1.ClusterPool {
+	return &hivev1.ClusterPool{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "pools", Name: "pool"},
+		Spec:       hivev1.ClusterPoolSpec{ImageSetRef: hivev1.ClusterImageSetReference{Name: imageSetName}},
+	}
+}
+
+func testClusterDeployment(imageSetName string) *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "cd"},
+		Spec: hivev1.ClusterDeploymentSpec{
+			Provisioning: &hivev1.Provisioning{ImageSetRef: &hivev1.ClusterImageSetReference{Name: imageSetName}},
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	graphs := map[string]string{
+		"stable-4.15/amd64": stable415,
+		"fast-4.15/amd64":   fast415,
+		"stable-4.15/arm64": stable415ARM,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g, ok := graphs[r.URL.Query().Get("channel")+"/"+r.URL.Query().Get("arch")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, g)
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		name              string
+		channels          []hivev1.ClusterImageSetDiscoveryChannel
+		prune             bool
+		existing          []runtime.Object
+		expectErr         bool
+		expectedImageSets map[string]string
+		expectedChannels  map[string]string
+	}{
+		{
+			name:     "latest release in channel",
+			channels: []hivev1.ClusterImageSetDiscoveryChannel{{Name: "stable-4.15"}},
+			expectedImageSets: map[string]string{
+				"openshift-v4.15.3": "quay.io/openshift-release-dev/ocp-release@sha256:3",
+			},
+			expectedChannels: map[string]string{"openshift-v4.15.3": "stable-4.15"},
+		},
+		{
+			name:     "latest releases in multiple channels",
+			channels: []hivev1.ClusterImageSetDiscoveryChannel{{Name: "stable-4.15", Latest: 2}, {Name: "fast-4.15", Latest: 2}, {Name: "stable-4.15", Arch: "arm64"}},
+			expectedImageSets: map[string]string{
+				"openshift-v4.15.2":       "quay.io/openshift-release-dev/ocp-release@sha256:2",
+				"openshift-v4.15.3":       "quay.io/openshift-release-dev/ocp-release@sha256:3",
+				"openshift-v4.15.4":       "quay.io/openshift-release-dev/ocp-release@sha256:4",
+				"openshift-v4.15.3-arm64": "quay.io/openshift-release-dev/ocp-release@sha256:3-arm",
+			},
+			expectedChannels: map[string]string{
+				"openshift-v4.15.3": "fast-4.15,stable-4.15",
+				"openshift-v4.15.4": "fast-4.15",
+			},
+		},
+		{
+			name:     "update discovered image set",
+			channels: []hivev1.ClusterImageSetDiscoveryChannel{{Name: "stable-4.15"}},
+			existing: []runtime.Object{testImageSet("openshift-v4.15.3", "quay.io/old", "4.15.3")},
+			expectedImageSets: map[string]string{
+				"openshift-v4.15.3": "quay.io/openshift-release-dev/ocp-release@sha256:3",
+			},
+		},
+		{
+			name:     "do not update image set that was not discovered",
+			channels: []hivev1.ClusterImageSetDiscoveryChannel{{Name: "stable-4.15"}},
+			existing: []runtime.Object{testImageSet("openshift-v4.15.3", "quay.io/mirror", "")},
+			expectedImageSets: map[string]string{
+				"openshift-v4.15.3": "quay.io/mirror",
+			},
+		},
+		{
+			name:     "no pruning by default",
+			channels: []hivev1.ClusterImageSetDiscoveryChannel{{Name: "stable-4.15"}},
+			existing: []runtime.Object{testImageSet("openshift-v4.15.0", "quay.io/old", "4.15.0")},
+			expectedImageSets: map[string]string{
+				"openshift-v4.15.0": "quay.io/old",
+				"openshift-v4.15.3": "quay.io/openshift-release-dev/ocp-release@sha256:3",
+			},
+		},
+		{
+			name:     "prune",
+			channels: []hivev1.ClusterImageSetDiscoveryChannel{{Name: "stable-4.15"}},
+			prune:    true,
+			existing: []runtime.Object{
+				testImageSet("openshift-v4.15.0", "quay.io/old", "4.15.0"),
+				testImageSet("openshift-v4.15.1", "quay.io/pooled", "4.15.1"),
+				testImageSet("custom", "quay.io/custom", ""),
+				testPool("openshift-v4.15.1"),
+			},
+			expectedImageSets: map[string]string{
+				"openshift-v4.15.1": "quay.io/pooled",
+				"openshift-v4.15.3": "quay.io/openshift-release-dev/ocp-release@sha256:3",
+				"custom":            "quay.io/custom",
+			},
+		},
+		{
+			name:     "no pruning of image sets used by cluster deployments",
+			channels: []hivev1.ClusterImageSetDiscoveryChannel{{Name: "stable-4.15"}},
+			prune:    true,
+			existing: []runtime.Object{
+				testImageSet("openshift-v4.15.0", "quay.io/old", "4.15.0"),
+				testImageSet("openshift-v4.15.1", "quay.io/provisioned", "4.15.1"),
+				testClusterDeployment("openshift-v4.15.1"),
+			},
+			expectedImageSets: map[string]string{
+				"openshift-v4.15.1": "quay.io/provisioned",
+				"openshift-v4.15.3": "quay.io/openshift-release-dev/ocp-release@sha256:3",
+			},
+		},
+		{
+			name:      "no pruning when a channel fails",
+			channels:  []hivev1.ClusterImageSetDiscoveryChannel{{Name: "stable-4.15"}, {Name: "missing"}},
+			prune:     true,
+			existing:  []runtime.Object{testImageSet("openshift-v4.15.0", "quay.io/old", "4.15.0")},
+			expectErr: true,
+			expectedImageSets: map[string]string{
+				"openshift-v4.15.0": "quay.io/old",
+				"openshift-v4.15.3": "quay.io/openshift-release-dev/ocp-release@sha256:3",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewFakeClientWithScheme(scheme.Scheme, tc.existing...)
+			r := &ReconcileClusterImageSetDiscovery{
+				Client: fakeClient,
+				config: &hivev1.ClusterImageSetDiscoveryConfig{
+					UpstreamURL:     server.URL,
+					Channels:        tc.channels,
+					Prune:           tc.prune,
+					RefreshInterval: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+				httpClient: server.Client(),
+				logger:     log.WithField("controller", ControllerName),
+			}
+
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: discoveryRequestName}})
+			if tc.expectErr {
+				assert.Error(t, err, "expected error from reconcile")
+			} else {
+				assert.NoError(t, err, "unexpected error from reconcile")
+				assert.Equal(t, 10*time.Minute, result.RequeueAfter, "unexpected requeue")
+			}
+
+			imageSets := &hivev1.ClusterImageSetList{}
+			require.NoError(t, fakeClient.List(context.TODO(), imageSets), "unexpected error listing image sets")
+			actual := map[string]string{}
+			for _, is := range imageSets.Items {
+				actual[is.Name] = is.Spec.ReleaseImage
+				if expected, ok := tc.expectedChannels[is.Name]; ok {
+					assert.Equal(t, expected, is.Annotations[constants.ClusterImageSetChannelsAnnotation], "unexpected channels for %s", is.Name)
+				}
+			}
+			assert.Equal(t, tc.expectedImageSets, actual, "unexpected image sets")
+		})
+	}
+}
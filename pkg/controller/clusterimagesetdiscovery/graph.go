@@ -0,0 +1,80 @@
+package clusterimagesetdiscovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+)
+
+// release is a release found in a channel of the update graph.
+type release struct {
+	version semver.Version
+	payload string
+}
+
+// graph is the response of the update graph (Cincinnati) API.
+type graph struct {
+	Nodes []graphNode `json:"nodes"`
+}
+
+type graphNode struct {
+	Version string `json:"version"`
+	Payload string `json:"payload"`
+}
+
+// latestReleases queries the update graph for the releases in the channel for the architecture and returns
+// up to count of the newest releases, newest first.
+func latestReleases(ctx context.Context, httpClient *http.Client, upstream, channel, arch string, count int) ([]release, error) {
+	u, err := url.Parse(upstream)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid update graph URL")
+	}
+	q := u.Query()
+	q.Set("channel", channel)
+	q.Set("arch", arch)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create update graph request")
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not query update graph")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response from update graph: %s", resp.Status)
+	}
+
+	g := &graph{}
+	if err := json.NewDecoder(resp.Body).Decode(g); err != nil {
+		return nil, errors.Wrap(err, "could not decode update graph")
+	}
+
+	releases := make([]release, 0, len(g.Nodes))
+	for _, node := range g.Nodes {
+		if node.Payload == "" {
+			continue
+		}
+		v, err := semver.Parse(node.Version)
+		if err != nil {
+			continue
+		}
+		releases = append(releases, release{version: v, payload: node.Payload})
+	}
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].version.GT(releases[j].version)
+	})
+	if len(releases) > count {
+		releases = releases[:count]
+	}
+	return releases, nil
+}
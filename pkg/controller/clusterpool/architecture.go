@@ -0,0 +1,53 @@
+package clusterpool
+
+import (
+	"fmt"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// verifyClusterImageSetArchitecture returns an error if the release image of the ClusterImageSet does not support
+// the architecture of the pool. The architectures of a release image are only known when release image validation
+// is enabled; when they are not known, the ClusterImageSet is assumed to support the architecture of the pool.
+func verifyClusterImageSetArchitecture(pool *hivev1.ClusterPool, imageSet *hivev1.ClusterImageSet) error {
+	archs := imageSet.Status.Architectures
+	if pool.Spec.Architecture == "" || len(archs) == 0 {
+		return nil
+	}
+	if pool.Spec.Architecture == hivev1.MultiPoolArchitecture {
+		if len(archs) < 2 {
+			return fmt.Errorf("cluster image set %s is not a multi-architecture release: %v", imageSet.Name, archs)
+		}
+		return nil
+	}
+	for _, arch := range archs {
+		if arch == string(pool.Spec.Architecture) {
+			return nil
+		}
+	}
+	return fmt.Errorf("cluster image set %s does not support architecture %s: %v", imageSet.Name, pool.Spec.Architecture, archs)
+}
+
+// validateInstallConfigTemplateArchitecture returns an error if the machine pools of the install config template do
+// not match the architecture of the pool. With amd64 or arm64, every machine pool must have the architecture of the
+// pool, which the installer defaults to amd64. With multi, each machine pool may have any architecture. In all cases
+// the instance type of a machine pool, if set, must match the architecture of the machine pool.
+func validateInstallConfigTemplateArchitecture(pool *hivev1.ClusterPool, installConfigTemplate string) error {
+	if pool.Spec.Architecture == "" || installConfigTemplate == "" {
+		return nil
+	}
+	machinePools, err := controllerutils.InstallConfigMachinePools([]byte(installConfigTemplate))
+	if err != nil {
+		return err
+	}
+	for _, mp := range machinePools {
+		if pool.Spec.Architecture != hivev1.MultiPoolArchitecture && mp.Architecture != string(pool.Spec.Architecture) {
+			return fmt.Errorf("machine pool %s of the install config template has architecture %s, not %s", mp.Name, mp.Architecture, pool.Spec.Architecture)
+		}
+		if mp.InstanceType != "" && controllerutils.InstanceTypeArchitecture(mp.InstanceType) != mp.Architecture {
+			return fmt.Errorf("instance type %s of machine pool %s of the install config template does not have architecture %s", mp.InstanceType, mp.Name, mp.Architecture)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package clusterpool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestVerifyClusterImageSetArchitecture(t *testing.T) {
+	tests := []struct {
+		name          string
+		architecture  hivev1.PoolArchitecture
+		archs         []string
+		expectedError bool
+	}{
+		{
+			name:  "no pool architecture",
+			archs: []string{"amd64"},
+		},
+		{
+			name:         "unknown image set architectures",
+			architecture: hivev1.ARM64PoolArchitecture,
+		},
+		{
+			name:         "supported architecture",
+			architecture: hivev1.ARM64PoolArchitecture,
+			archs:        []string{"arm64"},
+		},
+		{
+			name:          "unsupported architecture",
+			architecture:  hivev1.ARM64PoolArchitecture,
+			archs:         []string{"amd64"},
+			expectedError: true,
+		},
+		{
+			name:         "multi-architecture release",
+			architecture: hivev1.MultiPoolArchitecture,
+			archs:        []string{"amd64", "arm64"},
+		},
+		{
+			name:          "single architecture release for multi pool",
+			architecture:  hivev1.MultiPoolArchitecture,
+			archs:         []string{"amd64"},
+			expectedError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &hivev1.ClusterPool{Spec: hivev1.ClusterPoolSpec{Architecture: test.architecture}}
+			imageSet := &hivev1.ClusterImageSet{
+				ObjectMeta: metav1.ObjectMeta{Name: imageSetName},
+				Status:     hivev1.ClusterImageSetStatus{Architectures: test.archs},
+			}
+			err := verifyClusterImageSetArchitecture(pool, imageSet)
+			if test.expectedError {
+				assert.Error(t, err, "expected error")
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+		})
+	}
+}
+
+func TestValidateInstallConfigTemplateArchitecture(t *testing.T) {
+	tests := []struct {
+		name          string
+		architecture  hivev1.PoolArchitecture
+		template      string
+		expectedError bool
+	}{
+		{
+			name: "no pool architecture",
+			template: `
+compute:
+- name: worker
+  architecture: arm64
+  platform:
+    aws:
+      type: m5.xlarge
+`,
+		},
+		{
+			name:         "arm64 pool",
+			architecture: hivev1.ARM64PoolArchitecture,
+			template: `
+controlPlane:
+  name: master
+  architecture: arm64
+  platform:
+    aws:
+      type: m6g.xlarge
+compute:
+- name: worker
+  architecture: arm64
+  platform:
+    aws:
+      type: c7gn.2xlarge
+`,
+		},
+		{
+			name:         "default architecture in arm64 pool",
+			architecture: hivev1.ARM64PoolArchitecture,
+			template: `
+compute:
+- name: worker
+  platform:
+    aws:
+      type: m6g.xlarge
+`,
+			expectedError: true,
+		},
+		{
+			name:         "amd64 instance type in arm64 pool",
+			architecture: hivev1.ARM64PoolArchitecture,
+			template: `
+compute:
+- name: worker
+  architecture: arm64
+  platform:
+    gcp:
+      type: n2-standard-4
+`,
+			expectedError: true,
+		},
+		{
+			name:         "arm64 instance type in amd64 pool",
+			architecture: hivev1.AMD64PoolArchitecture,
+			template: `
+compute:
+- name: worker
+  platform:
+    azure:
+      type: Standard_D4ps_v5
+`,
+			expectedError: true,
+		},
+		{
+			name:         "multi pool",
+			architecture: hivev1.MultiPoolArchitecture,
+			template: `
+controlPlane:
+  name: master
+  platform:
+    aws:
+      type: m5.xlarge
+compute:
+- name: worker
+  architecture: arm64
+  platform:
+    aws:
+      type: t4g.xlarge
+`,
+		},
+		{
+			name:         "mismatched instance type in multi pool",
+			architecture: hivev1.MultiPoolArchitecture,
+			template: `
+compute:
+- name: worker
+  architecture: arm64
+  platform:
+    aws:
+      type: m5.xlarge
+`,
+			expectedError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &hivev1.ClusterPool{Spec: hivev1.ClusterPoolSpec{Architecture: test.architecture}}
+			err := validateInstallConfigTemplateArchitecture(pool, test.template)
+			if test.expectedError {
+				assert.Error(t, err, "expected error")
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+		})
+	}
+}
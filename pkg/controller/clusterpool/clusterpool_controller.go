@@ -2,6 +2,9 @@ package clusterpool
 
 import (
 	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math"
 	"reflect"
@@ -53,18 +56,25 @@ var (
 // Add creates a new ClusterPool Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
 	if err != nil {
 		logger.WithError(err).Error("could not get controller configurations")
 		return err
 	}
-	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+	namespaceQuotas, err := controllerutils.ReadNamespaceQuotasFile()
+	if err != nil {
+		logger.WithError(err).Error("could not read namespace quotas")
+		return err
+	}
+	r := NewReconciler(mgr, clientRateLimiter)
+	r.namespaceQuotas = namespaceQuotas
+	return AddToManager(mgr, r, concurrentReconciles, queueRateLimiter)
 }
 
 // NewReconciler returns a new ReconcileClusterPool
 func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterPool {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	return &ReconcileClusterPool{
 		Client:       controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
 		logger:       logger,
@@ -75,7 +85,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *Re
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r *ReconcileClusterPool, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
-	c, err := controller.New("clusterpool-controller", mgr, controller.Options{
+	c, err := controllerutils.NewController("clusterpool-controller", mgr, controller.Options{
 		Reconciler:              r,
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
@@ -190,6 +200,8 @@ type ReconcileClusterPool struct {
 	logger log.FieldLogger
 	// A TTLCache of ClusterDeployment creates each ClusterPool expects to see
 	expectations controllerutils.ExpectationsInterface
+	// namespaceQuotas limit the clusters that can be assigned to the ClusterClaims in a namespace
+	namespaceQuotas []hivev1.NamespaceQuota
 }
 
 // Reconcile reads the state of the ClusterPool, checks if we currently have enough ClusterDeployments waiting, and
@@ -234,6 +246,12 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, nil
 	}
 
+	poolVersion, err := calculatePoolVersion(clp)
+	if err != nil {
+		logger.WithError(err).Error("error calculating pool version")
+		return reconcile.Result{}, err
+	}
+
 	// Find all ClusterDeployments from this pool:
 	claimedCDs, unClaminedCDs, err := r.getAllClusterDeploymentsForPool(clp, logger)
 	if err != nil {
@@ -247,6 +265,8 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		switch {
 		case cd.DeletionTimestamp != nil:
 			numberOfDeletingClaimedCDs++
+		case toRemove && controllerutils.IsClusterDeploymentPaused(cd, logger.WithField("cluster", cd.Name)):
+			// The cluster is deleted once it is unpaused.
 		case toRemove:
 			toRemoveClaimedCDs = append(toRemoveClaimedCDs, cd)
 		}
@@ -255,10 +275,15 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 	var installingCDs []*hivev1.ClusterDeployment
 	var readyCDs []*hivev1.ClusterDeployment
 	numberOfDeletingCDs := 0
+	numberOfPausedCDs := 0
 	for _, cd := range unClaminedCDs {
 		switch {
 		case cd.DeletionTimestamp != nil:
 			numberOfDeletingCDs++
+		// Paused clusters count towards the size of the pool, but they are not assigned to claims, replaced or
+		// deleted until they are unpaused.
+		case controllerutils.IsClusterDeploymentPaused(cd, logger.WithField("cluster", cd.Name)):
+			numberOfPausedCDs++
 		case !cd.Spec.Installed:
 			installingCDs = append(installingCDs, cd)
 		default:
@@ -269,12 +294,13 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 	logger.WithFields(log.Fields{
 		"installing": len(installingCDs),
 		"deleting":   numberOfDeletingCDs,
+		"paused":     numberOfPausedCDs,
 		"total":      len(unClaminedCDs),
 		"ready":      len(readyCDs),
 	}).Debug("found clusters for ClusterPool")
 
 	origStatus := clp.Status.DeepCopy()
-	clp.Status.Size = int32(len(installingCDs) + len(readyCDs))
+	clp.Status.Size = int32(len(installingCDs) + len(readyCDs) + numberOfPausedCDs)
 	clp.Status.Ready = int32(len(readyCDs))
 	if !reflect.DeepEqual(origStatus, &clp.Status) {
 		if err := r.Status().Update(context.Background(), clp); err != nil {
@@ -305,14 +331,25 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 	}
 	logger.WithField("count", len(pendingClaims)).Debug("found pending claims for ClusterPool")
 
+	pendingClaims, err = r.applyNamespaceQuota(clp, pendingClaims, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// reserveSize is the number of clusters that the pool currently has in reserve
-	reserveSize := len(installingCDs) + len(readyCDs) - len(pendingClaims)
+	reserveSize := len(installingCDs) + len(readyCDs) + numberOfPausedCDs - len(pendingClaims)
 
 	readyCDs, err = r.assignClustersToClaims(pendingClaims, readyCDs, logger)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
+	staleCDs := staleClusters(poolVersion, installingCDs, readyCDs)
+	if err := r.setAllClustersCurrentCondition(clp, len(staleCDs), logger); err != nil {
+		logger.WithError(err).Error("error setting AllClustersCurrent condition")
+		return reconcile.Result{}, err
+	}
+
 	availableCurrent := math.MaxInt32
 	if clp.Spec.MaxConcurrent != nil {
 		availableCurrent = int(*clp.Spec.MaxConcurrent) - len(installingCDs) - numberOfDeletingCDs - numberOfDeletingClaimedCDs
@@ -352,10 +389,20 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 			break
 		}
 		toAdd := minIntVarible(-drift, availableCapacity, availableCurrent)
-		if err := r.addClusters(clp, toAdd, logger); err != nil {
+		if err := r.addClusters(clp, poolVersion, toAdd, logger); err != nil {
 			log.WithError(err).Error("error adding clusters")
 			return reconcile.Result{}, err
 		}
+	// If the pool is at its desired size, replace a stale cluster. This is done one cluster at a time, and only once
+	// the clusters created from the current pool spec have finished installing, so that the pool is not drained.
+	case len(staleCDs) > 0 && clp.Spec.StaleClusterPolicy == hivev1.ReplaceStaleClusterPolicy:
+		if len(staleClusters(poolVersion, installingCDs, nil)) < len(installingCDs) {
+			logger.Debug("waiting for current clusters to install before replacing stale clusters")
+			break
+		}
+		if err := r.replaceStaleCluster(staleCDs, logger); err != nil {
+			return reconcile.Result{}, err
+		}
 	}
 
 	if err := r.reconcileRBAC(clp, logger); err != nil {
@@ -474,8 +521,56 @@ func (r *ReconcileClusterPool) applyRoleBinding(desired, observed *rbacv1.RoleBi
 	return nil
 }
 
+// calculatePoolVersion computes a hash of the fields of the pool spec that change what is installed for the
+// ClusterDeployments of the pool. Other fields, such as the size of the pool or the labels of its clusters, are left
+// out, so that changing them does not make the existing clusters stale.
+func calculatePoolVersion(clp *hivev1.ClusterPool) (string, error) {
+	b, err := json.Marshal(struct {
+		Platform                       hivev1.Platform
+		ImageSetRef                    hivev1.ClusterImageSetReference
+		InstallConfigSecretTemplateRef *corev1.LocalObjectReference
+	}{
+		Platform:                       clp.Spec.Platform,
+		ImageSetRef:                    clp.Spec.ImageSetRef,
+		InstallConfigSecretTemplateRef: clp.Spec.InstallConfigSecretTemplateRef,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "could not marshal ClusterPool spec")
+	}
+	hasher := md5.New()
+	hasher.Write(b)
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// staleClusters returns the installing and ready clusters that were created from a different version of the pool
+// spec, with the installing clusters first. Clusters created before the pool version was recorded on them are not
+// considered stale.
+func staleClusters(poolVersion string, installingCDs, readyCDs []*hivev1.ClusterDeployment) []*hivev1.ClusterDeployment {
+	var stale []*hivev1.ClusterDeployment
+	for _, cds := range [][]*hivev1.ClusterDeployment{installingCDs, readyCDs} {
+		for _, cd := range cds {
+			if version, ok := cd.Annotations[constants.ClusterPoolSpecHashAnnotation]; ok && version != poolVersion {
+				stale = append(stale, cd)
+			}
+		}
+	}
+	return stale
+}
+
+func (r *ReconcileClusterPool) replaceStaleCluster(staleCDs []*hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	cd := staleCDs[0]
+	cdLog := logger.WithField("cluster", cd.Name)
+	cdLog.WithField("stale", len(staleCDs)).Info("deleting stale cluster deployment so that it is replaced")
+	if err := r.Client.Delete(context.Background(), cd); err != nil {
+		cdLog.WithError(err).Error("error deleting stale cluster deployment")
+		return err
+	}
+	return nil
+}
+
 func (r *ReconcileClusterPool) addClusters(
 	clp *hivev1.ClusterPool,
+	poolVersion string,
 	newClusterCount int,
 	logger log.FieldLogger,
 ) error {
@@ -497,6 +592,9 @@ func (r *ReconcileClusterPool) addClusters(
 	installConfigTemplate, err := r.getInstallConfigTemplate(clp, logger)
 	if err != nil {
 		errs = append(errs, fmt.Errorf("%s: %w", icSecretDependent, err))
+	} else if err := validateInstallConfigTemplateArchitecture(clp, installConfigTemplate); err != nil {
+		logger.WithError(err).Warn("install config template does not match the architecture of the pool")
+		errs = append(errs, fmt.Errorf("%s: %w", icSecretDependent, err))
 	}
 
 	cloudBuilder, err := r.createCloudBuilder(clp, logger)
@@ -515,7 +613,7 @@ func (r *ReconcileClusterPool) addClusters(
 	}
 
 	for i := 0; i < newClusterCount; i++ {
-		if err := r.createCluster(clp, cloudBuilder, pullSecret, installConfigTemplate, logger); err != nil {
+		if err := r.createCluster(clp, poolVersion, cloudBuilder, pullSecret, installConfigTemplate, logger); err != nil {
 			return err
 		}
 	}
@@ -525,6 +623,7 @@ func (r *ReconcileClusterPool) addClusters(
 
 func (r *ReconcileClusterPool) createCluster(
 	clp *hivev1.ClusterPool,
+	poolVersion string,
 	cloudBuilder clusterresource.CloudBuilder,
 	pullSecret string,
 	installConfigTemplate string,
@@ -552,6 +651,9 @@ func (r *ReconcileClusterPool) createCluster(
 		InstallConfigTemplate: installConfigTemplate,
 		SkipMachinePools:      clp.Spec.SkipMachinePools,
 	}
+	if clp.Spec.Architecture != hivev1.MultiPoolArchitecture {
+		builder.Architecture = string(clp.Spec.Architecture)
+	}
 
 	if clp.Spec.HibernateAfter != nil {
 		builder.HibernateAfter = &clp.Spec.HibernateAfter.Duration
@@ -573,6 +675,14 @@ func (r *ReconcileClusterPool) createCluster(
 		poolRef := poolReference(clp)
 		cd.Spec.ClusterPoolRef = &poolRef
 		cd.Spec.PowerState = hivev1.HibernatingClusterPowerState
+		if cd.Annotations == nil {
+			cd.Annotations = map[string]string{}
+		}
+		cd.Annotations[constants.ClusterPoolSpecHashAnnotation] = poolVersion
+		cd.Annotations[constants.PowerStateInitiatorAnnotation] = constants.PowerStateInitiatorClusterPool
+		if clp.Spec.Architecture != "" {
+			cd.Annotations[constants.ClusterPoolArchitectureAnnotation] = string(clp.Spec.Architecture)
+		}
 		lastIndex := len(objs) - 1
 		objs[i], objs[lastIndex] = objs[lastIndex], objs[i]
 	}
@@ -651,15 +761,25 @@ func (r *ReconcileClusterPool) reconcileDeletedPool(pool *hivev1.ClusterPool, lo
 	if err != nil {
 		return err
 	}
+	numberOfPausedCDs := 0
 	for _, cd := range unClaimedCDs {
 		if cd.DeletionTimestamp != nil {
 			continue
 		}
+		if controllerutils.IsClusterDeploymentPaused(cd, logger.WithField("cluster", cd.Name)) {
+			numberOfPausedCDs++
+			continue
+		}
 		if err := r.Delete(context.Background(), cd); err != nil {
 			logger.WithError(err).WithField("cluster", cd.Name).Log(controllerutils.LogLevel(err), "could not delete ClusterDeployment")
 			return errors.Wrap(err, "could not delete ClusterDeployment")
 		}
 	}
+	// Keep the finalizer until the paused clusters are unpaused and deleted, so that they are not left behind.
+	if numberOfPausedCDs > 0 {
+		logger.WithField("paused", numberOfPausedCDs).Info("waiting for paused clusters to be unpaused before deleting them")
+		return nil
+	}
 	controllerutils.DeleteFinalizer(pool, finalizer)
 	if err := r.Update(context.Background(), pool); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not remove finalizer from ClusterPool")
@@ -766,12 +886,52 @@ func (r *ReconcileClusterPool) setAvailableCapacityCondition(pool *hivev1.Cluste
 	return nil
 }
 
+func (r *ReconcileClusterPool) setAllClustersCurrentCondition(pool *hivev1.ClusterPool, staleCount int, logger log.FieldLogger) error {
+	status := corev1.ConditionTrue
+	reason := "ClustersCurrent"
+	message := "All unclaimed clusters were created from the current pool spec."
+	updateConditionCheck := controllerutils.UpdateConditionNever
+	if staleCount > 0 {
+		status = corev1.ConditionFalse
+		reason = "SomeClustersStale"
+		message = fmt.Sprintf("%d unclaimed cluster(s) were created from an earlier version of the pool spec.", staleCount)
+		if pool.Spec.StaleClusterPolicy == hivev1.ReplaceStaleClusterPolicy {
+			message += " They will be replaced one at a time."
+		} else {
+			message += " They will be kept until they are claimed."
+		}
+		updateConditionCheck = controllerutils.UpdateConditionIfReasonOrMessageChange
+	}
+	conds, changed := controllerutils.SetClusterPoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.ClusterPoolAllClustersCurrentCondition,
+		status,
+		reason,
+		message,
+		updateConditionCheck,
+	)
+	if changed {
+		pool.Status.Conditions = conds
+		if err := r.Status().Update(context.Background(), pool); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool conditions")
+			return errors.Wrap(err, "could not update ClusterPool conditions")
+		}
+	}
+	return nil
+}
+
 func (r *ReconcileClusterPool) verifyClusterImageSet(pool *hivev1.ClusterPool, logger log.FieldLogger) error {
-	err := r.Get(context.Background(), client.ObjectKey{Name: pool.Spec.ImageSetRef.Name}, &hivev1.ClusterImageSet{})
+	imageSet := &hivev1.ClusterImageSet{}
+	err := r.Get(context.Background(), client.ObjectKey{Name: pool.Spec.ImageSetRef.Name}, imageSet)
 	if err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting cluster image set")
+		return err
+	}
+	if err := verifyClusterImageSetArchitecture(pool, imageSet); err != nil {
+		logger.WithError(err).Warn("cluster image set does not support the architecture of the pool")
+		return err
 	}
-	return err
+	return nil
 }
 
 func (r *ReconcileClusterPool) getInstallConfigTemplate(pool *hivev1.ClusterPool, logger log.FieldLogger) (string, error) {
@@ -893,6 +1053,52 @@ func (r *ReconcileClusterPool) getAllPendingClusterClaims(pool *hivev1.ClusterPo
 	return pendingClaims, nil
 }
 
+// applyNamespaceQuota returns the pending claims that can be assigned a cluster without exceeding the cluster quota of
+// the namespace of the pool. The remaining claims are marked as pending on the quota, and are neither assigned a
+// cluster nor counted when sizing the pool.
+func (r *ReconcileClusterPool) applyNamespaceQuota(pool *hivev1.ClusterPool, claims []*hivev1.ClusterClaim, logger log.FieldLogger) ([]*hivev1.ClusterClaim, error) {
+	quota := controllerutils.FindNamespaceQuota(r.namespaceQuotas, pool.Namespace)
+	if quota == nil || quota.MaxClusters == nil || len(claims) == 0 {
+		return claims, nil
+	}
+	count, err := controllerutils.NamespaceClusterCount(r.Client, pool.Namespace)
+	if err != nil {
+		logger.WithError(err).Error("could not count clusters for namespace quota")
+		return nil, err
+	}
+	available := int(*quota.MaxClusters) - count
+	if available < 0 {
+		available = 0
+	}
+	if available >= len(claims) {
+		return claims, nil
+	}
+	logger.WithFields(log.Fields{
+		"clusters":    count,
+		"maxClusters": *quota.MaxClusters,
+		"claims":      len(claims),
+	}).Info("namespace quota prevents assigning clusters to some claims")
+	for _, claim := range claims[available:] {
+		conds, changed := controllerutils.SetClusterClaimConditionWithChangeCheck(
+			claim.Status.Conditions,
+			hivev1.ClusterClaimPendingCondition,
+			corev1.ConditionTrue,
+			"QuotaExceeded",
+			fmt.Sprintf("Namespace %s is at its quota of %d clusters", pool.Namespace, *quota.MaxClusters),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		if !changed {
+			continue
+		}
+		claim.Status.Conditions = conds
+		if err := r.Status().Update(context.Background(), claim); err != nil {
+			logger.WithError(err).WithField("claim", claim.Name).Log(controllerutils.LogLevel(err), "could not update status of ClusterClaim")
+			return nil, err
+		}
+	}
+	return claims[:available], nil
+}
+
 func (r *ReconcileClusterPool) assignClustersToClaims(claims []*hivev1.ClusterClaim, cds []*hivev1.ClusterDeployment, logger log.FieldLogger) ([]*hivev1.ClusterDeployment, error) {
 	for _, claim := range claims {
 		logger := logger.WithField("claim", claim.Name)
@@ -900,12 +1106,14 @@ func (r *ReconcileClusterPool) assignClustersToClaims(claims []*hivev1.ClusterCl
 		var statusChanged bool
 		if len(cds) > 0 {
 			claim.Spec.Namespace = cds[0].Namespace
+			architecture := hivev1.PoolArchitecture(cds[0].Annotations[constants.ClusterPoolArchitectureAnnotation])
 			cds = cds[1:]
 			logger.WithField("cluster", claim.Spec.Namespace).Info("assigning cluster to claim")
 			if err := r.Update(context.Background(), claim); err != nil {
 				logger.WithError(err).Log(controllerutils.LogLevel(err), "could not assign cluster to claim")
 				return cds, err
 			}
+			claim.Status.Architecture = architecture
 			conds = controllerutils.SetClusterClaimCondition(
 				claim.Status.Conditions,
 				hivev1.ClusterClaimPendingCondition,
@@ -65,6 +65,18 @@ func TestReconcileClusterPool(t *testing.T) {
 			testcd.WithUnclaimedClusterPoolReference(testNamespace, testLeasePoolName),
 		)
 	}
+	poolVersion, err := calculatePoolVersion(poolBuilder.Build())
+	require.NoError(t, err, "unexpected error calculating pool version")
+	currentCDBuilder := func(name string) testcd.Builder {
+		return unclaimedCDBuilder(name).GenericOptions(
+			testgeneric.WithAnnotation(constants.ClusterPoolSpecHashAnnotation, poolVersion),
+		)
+	}
+	staleCDBuilder := func(name string) testcd.Builder {
+		return unclaimedCDBuilder(name).GenericOptions(
+			testgeneric.WithAnnotation(constants.ClusterPoolSpecHashAnnotation, "stale"),
+		)
+	}
 
 	tests := []struct {
 		name                               string
@@ -79,6 +91,9 @@ func TestReconcileClusterPool(t *testing.T) {
 		expectFinalizerRemoved             bool
 		expectedMissingDependenciesStatus  *bool
 		expectedCapacityStatus             *bool
+		expectedAllClustersCurrentStatus   *bool
+		expectPoolVersion                  bool
+		namespaceQuotas                    []hivev1.NamespaceQuota
 		expectedMissingDependenciesMessage string
 		expectedAssignedClaims             int
 		expectedUnassignedClaims           int
@@ -94,6 +109,15 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedObservedReady: 0,
 			expectedLabels:        map[string]string{"foo": "bar"},
 		},
+		{
+			name: "new clusters record pool version",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+			},
+			expectedTotalClusters:            2,
+			expectPoolVersion:                true,
+			expectedAllClustersCurrentStatus: pointer.BoolPtr(true),
+		},
 		{
 			name: "scale up",
 			existing: []runtime.Object{
@@ -304,6 +328,19 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedObservedSize:  6,
 			expectedObservedReady: 6,
 		},
+		{
+			name: "scale down does not delete paused clusters",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(1)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed(), testcd.Paused()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:   1,
+			expectedObservedSize:    3,
+			expectedObservedReady:   2,
+			expectedDeletedClusters: []string{"c2", "c3"},
+		},
 		{
 			name: "delete installing clusters first",
 			existing: []runtime.Object{
@@ -359,6 +396,16 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedTotalClusters:  0,
 			expectFinalizerRemoved: true,
 		},
+		{
+			name: "paused clusters not deleted when clusterpool deleted",
+			existing: []runtime.Object{
+				poolBuilder.GenericOptions(testgeneric.Deleted()).Build(testcp.WithSize(3)),
+				unclaimedCDBuilder("c1").Build(testcd.Paused()),
+				unclaimedCDBuilder("c2").Build(),
+			},
+			expectedTotalClusters:   1,
+			expectedDeletedClusters: []string{"c2"},
+		},
 		{
 			name: "finalizer added to clusterpool",
 			existing: []runtime.Object{
@@ -579,6 +626,20 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedAssignedClaims:   1,
 			expectedUnassignedClaims: 0,
 		},
+		{
+			name: "do not assign paused clusters to claims",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed(), testcd.Paused()),
+				unclaimedCDBuilder("c2").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			expectedTotalClusters:    3,
+			expectedObservedSize:     2,
+			expectedObservedReady:    0,
+			expectedAssignedClaims:   0,
+			expectedUnassignedClaims: 1,
+		},
 		{
 			name: "no ready clusters to assign to claim",
 			existing: []runtime.Object{
@@ -611,6 +672,42 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedAssignedClaims:   2,
 			expectedUnassignedClaims: 1,
 		},
+		{
+			name: "namespace quota limits assignment to claims",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim-1", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+				testclaim.FullBuilder(testNamespace, "test-claim-2", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+				testclaim.FullBuilder(testNamespace, "test-claim-3", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			namespaceQuotas:          []hivev1.NamespaceQuota{{Namespace: testNamespace, MaxClusters: pointer.Int32Ptr(1)}},
+			expectedTotalClusters:    4,
+			expectedObservedSize:     3,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   1,
+			expectedUnassignedClaims: 2,
+		},
+		{
+			name: "namespace quota for other namespace",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim-1", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+				testclaim.FullBuilder(testNamespace, "test-claim-2", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+				testclaim.FullBuilder(testNamespace, "test-claim-3", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			namespaceQuotas:          []hivev1.NamespaceQuota{{Namespace: "other", MaxClusters: pointer.Int32Ptr(1)}},
+			expectedTotalClusters:    6,
+			expectedObservedSize:     3,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   2,
+			expectedUnassignedClaims: 1,
+		},
 		{
 			name: "do not assign to claims for other pools",
 			existing: []runtime.Object{
@@ -691,6 +788,24 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedObservedReady:   2,
 			expectedDeletedClusters: []string{"c4"},
 		},
+		{
+			name: "do not delete paused previously claimed clusters",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				cdBuilder("c4").
+					GenericOptions(testgeneric.WithAnnotation(constants.ClusterClaimRemoveClusterAnnotation, "true")).
+					Build(
+						testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "test-claim"),
+						testcd.Paused(),
+					),
+			},
+			expectedTotalClusters: 4,
+			expectedObservedSize:  3,
+			expectedObservedReady: 2,
+		},
 		{
 			name: "deleting previously claimed clusters should use max concurrent",
 			existing: []runtime.Object{
@@ -868,6 +983,146 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedObservedReady:   2,
 			expectedDeletedClusters: []string{"c4"},
 		},
+		{
+			name: "replace stale cluster",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithStaleClusterPolicy(hivev1.ReplaceStaleClusterPolicy)),
+				staleCDBuilder("c1").Build(testcd.Installed()),
+				currentCDBuilder("c2").Build(testcd.Installed()),
+				currentCDBuilder("c3").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:            2,
+			expectedObservedSize:             3,
+			expectedObservedReady:            3,
+			expectedDeletedClusters:          []string{"c1"},
+			expectedAllClustersCurrentStatus: pointer.BoolPtr(false),
+		},
+		{
+			name: "do not replace paused stale cluster",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithStaleClusterPolicy(hivev1.ReplaceStaleClusterPolicy)),
+				staleCDBuilder("c1").Build(testcd.Installed(), testcd.Paused()),
+				currentCDBuilder("c2").Build(testcd.Installed()),
+				currentCDBuilder("c3").Build(testcd.Installed()),
+			},
+			expectedTotalClusters: 3,
+			expectedObservedSize:  3,
+			expectedObservedReady: 2,
+		},
+		{
+			name: "replace stale installing cluster first",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithStaleClusterPolicy(hivev1.ReplaceStaleClusterPolicy)),
+				staleCDBuilder("c1").Build(testcd.Installed()),
+				staleCDBuilder("c2").Build(),
+				currentCDBuilder("c3").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:            2,
+			expectedObservedSize:             3,
+			expectedObservedReady:            2,
+			expectedDeletedClusters:          []string{"c2"},
+			expectedAllClustersCurrentStatus: pointer.BoolPtr(false),
+		},
+		{
+			name: "do not replace stale cluster while current clusters are installing",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithStaleClusterPolicy(hivev1.ReplaceStaleClusterPolicy)),
+				staleCDBuilder("c1").Build(testcd.Installed()),
+				currentCDBuilder("c2").Build(testcd.Installed()),
+				currentCDBuilder("c3").Build(),
+			},
+			expectedTotalClusters:            3,
+			expectedObservedSize:             3,
+			expectedObservedReady:            2,
+			expectedAllClustersCurrentStatus: pointer.BoolPtr(false),
+		},
+		{
+			name: "do not replace stale cluster when pool is scaling up",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(4), testcp.WithStaleClusterPolicy(hivev1.ReplaceStaleClusterPolicy)),
+				staleCDBuilder("c1").Build(testcd.Installed()),
+				currentCDBuilder("c2").Build(testcd.Installed()),
+				currentCDBuilder("c3").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:            4,
+			expectedObservedSize:             3,
+			expectedObservedReady:            3,
+			expectedAllClustersCurrentStatus: pointer.BoolPtr(false),
+		},
+		{
+			name: "keep stale clusters",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3), testcp.WithStaleClusterPolicy(hivev1.KeepStaleClusterPolicy)),
+				staleCDBuilder("c1").Build(testcd.Installed()),
+				staleCDBuilder("c2").Build(testcd.Installed()),
+				currentCDBuilder("c3").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:            3,
+			expectedObservedSize:             3,
+			expectedObservedReady:            3,
+			expectedAllClustersCurrentStatus: pointer.BoolPtr(false),
+		},
+		{
+			name: "size changes do not make clusters stale",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithMaxSize(10), testcp.WithMaxConcurrent(5)),
+				currentCDBuilder("c1").Build(testcd.Installed()),
+				currentCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:            2,
+			expectedObservedSize:             2,
+			expectedObservedReady:            2,
+			expectedAllClustersCurrentStatus: pointer.BoolPtr(true),
+		},
+		{
+			name: "keep stale clusters by default",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(3)),
+				staleCDBuilder("c1").Build(testcd.Installed()),
+				currentCDBuilder("c2").Build(testcd.Installed()),
+				currentCDBuilder("c3").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:            3,
+			expectedObservedSize:             3,
+			expectedObservedReady:            3,
+			expectedAllClustersCurrentStatus: pointer.BoolPtr(false),
+		},
+		{
+			name: "label changes do not make clusters stale",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithClusterDeploymentLabels(map[string]string{"new": "label"})),
+				currentCDBuilder("c1").Build(testcd.Installed()),
+				currentCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:            2,
+			expectedObservedSize:             2,
+			expectedObservedReady:            2,
+			expectedAllClustersCurrentStatus: pointer.BoolPtr(true),
+		},
+		{
+			name: "image set changes make clusters stale",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2), testcp.WithImageSet("new-image-set")),
+				currentCDBuilder("c1").Build(testcd.Installed()),
+				currentCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:            2,
+			expectedObservedSize:             2,
+			expectedObservedReady:            2,
+			expectedAllClustersCurrentStatus: pointer.BoolPtr(false),
+		},
+		{
+			name: "clusters without pool version are not stale",
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:            2,
+			expectedObservedSize:             2,
+			expectedObservedReady:            2,
+			expectedAllClustersCurrentStatus: pointer.BoolPtr(true),
+		},
 	}
 
 	for _, test := range tests {
@@ -892,9 +1147,10 @@ func TestReconcileClusterPool(t *testing.T) {
 			logger.SetLevel(log.DebugLevel)
 			controllerExpectations := controllerutils.NewExpectations(logger)
 			rcp := &ReconcileClusterPool{
-				Client:       fakeClient,
-				logger:       logger,
-				expectations: controllerExpectations,
+				Client:          fakeClient,
+				logger:          logger,
+				expectations:    controllerExpectations,
+				namespaceQuotas: test.namespaceQuotas,
 			}
 
 			reconcileRequest := reconcile.Request{
@@ -930,6 +1186,9 @@ func TestReconcileClusterPool(t *testing.T) {
 						assert.Equal(t, v, cd.Labels[k])
 					}
 				}
+				if test.expectPoolVersion {
+					assert.Equal(t, poolVersion, cd.Annotations[constants.ClusterPoolSpecHashAnnotation], "unexpected pool version")
+				}
 			}
 
 			pool := &hivev1.ClusterPool{}
@@ -964,6 +1223,16 @@ func TestReconcileClusterPool(t *testing.T) {
 				}
 				assert.Equal(t, expectedStatus, capacityAvailableCondition.Status, "expected CapacityAvailable condition to be true")
 			}
+
+			allClustersCurrentCondition := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolAllClustersCurrentCondition)
+			if test.expectedAllClustersCurrentStatus != nil {
+				require.NotNil(t, allClustersCurrentCondition)
+				expectedStatus := corev1.ConditionFalse
+				if *test.expectedAllClustersCurrentStatus {
+					expectedStatus = corev1.ConditionTrue
+				}
+				assert.Equal(t, expectedStatus, allClustersCurrentCondition.Status, "unexpected AllClustersCurrent condition status")
+			}
 			claims := &hivev1.ClusterClaimList{}
 			err = fakeClient.List(context.Background(), claims)
 			require.NoError(t, err)
@@ -68,12 +68,13 @@ var (
 func init() {
 	metrics.Registry.MustRegister(metricInstallErrors)
 	metrics.Registry.MustRegister(metricClusterProvisionsTotal)
+	metrics.Registry.MustRegister(metricInstallPhaseDuration)
 }
 
 // Add creates a new ClusterProvision Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
 	if err != nil {
 		logger.WithError(err).Error("could not get controller configurations")
@@ -84,7 +85,7 @@ func Add(mgr manager.Manager) error {
 
 // newReconciler returns a new reconcile.Reconciler
 func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	return &ReconcileClusterProvision{
 		Client:       controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
 		scheme:       mgr.GetScheme(),
@@ -101,7 +102,7 @@ func add(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int,
 	}
 
 	// Create a new controller
-	c, err := controller.New("clusterprovision-controller", mgr, controller.Options{
+	c, err := controllerutils.NewController("clusterprovision-controller", mgr, controller.Options{
 		Reconciler:              r,
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
@@ -147,6 +148,12 @@ func (r *ReconcileClusterProvision) Reconcile(ctx context.Context, request recon
 	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, pLog)
 	defer recobsrv.ObserveControllerReconcileTime()
 
+	// The requests made to the hub and the cloud during the reconcile are traced as part of its span.
+	ctx = recobsrv.StartSpan(ctx, request.NamespacedName)
+	traced := *r
+	traced.Client = controllerutils.TraceClient(ctx, r.Client)
+	r = &traced
+
 	// Fetch the ClusterProvision instance
 	instance := &hivev1.ClusterProvision{}
 	err := r.Get(context.TODO(), request.NamespacedName, instance)
@@ -161,6 +168,11 @@ func (r *ReconcileClusterProvision) Reconcile(ctx context.Context, request recon
 		return reconcile.Result{}, err
 	}
 
+	// The provision is resumed when its ClusterDeployment is unpaused, which enqueues the provision.
+	if paused, err := r.isClusterDeploymentPaused(instance, pLog); err != nil || paused {
+		return reconcile.Result{}, err
+	}
+
 	// Ensure owner references are correctly set
 	err = controllerutils.ReconcileOwnerReferences(instance, generateOwnershipUniqueKeys(instance), r, r.scheme, pLog)
 	if err != nil {
@@ -373,9 +385,11 @@ func (r *ReconcileClusterProvision) getInstallPod(job *batchv1.Job, pLog log.Fie
 
 func (r *ReconcileClusterProvision) reconcileSuccessfulJob(instance *hivev1.ClusterProvision, job *batchv1.Job, pLog log.FieldLogger) (reconcile.Result, error) {
 	pLog.Info("install job succeeded")
+	instance.Status.InstallPhases = parseInstallPhases(instance.Spec.InstallLog)
 	result, err := r.transitionStage(instance, hivev1.ClusterProvisionStageComplete, "InstallComplete", "Install job has completed successfully", pLog)
 	if err == nil {
 		metricClusterProvisionsTotal.WithLabelValues(hivemetrics.GetClusterDeploymentType(instance), resultSuccess).Inc()
+		r.observeInstallPhases(instance, pLog)
 	}
 	return result, err
 }
@@ -386,11 +400,13 @@ func (r *ReconcileClusterProvision) reconcileFailedJob(instance *hivev1.ClusterP
 	if controllerutils.IsDeadlineExceeded(job) && reason == unknownReason {
 		reason, message = "AttemptDeadlineExceeded", "Install job failed due to deadline being exceeded for the attempt"
 	}
+	instance.Status.InstallPhases = parseInstallPhases(instance.Spec.InstallLog)
 	result, err := r.transitionStage(instance, hivev1.ClusterProvisionStageFailed, reason, message, pLog)
 	if err == nil {
 		// Increment a counter metric for this cluster type and error reason:
 		metricInstallErrors.WithLabelValues(hivemetrics.GetClusterDeploymentType(instance), reason).Inc()
 		metricClusterProvisionsTotal.WithLabelValues(hivemetrics.GetClusterDeploymentType(instance), resultFailure).Inc()
+		r.observeInstallPhases(instance, pLog)
 	}
 	return result, err
 }
@@ -505,6 +521,19 @@ func (r *ReconcileClusterProvision) existingJobs(provision *hivev1.ClusterProvis
 	return jobs, nil
 }
 
+// isClusterDeploymentPaused returns true if the reconciliation of the ClusterDeployment of the provision is paused.
+func (r *ReconcileClusterProvision) isClusterDeploymentPaused(provision *hivev1.ClusterProvision, pLog log.FieldLogger) (bool, error) {
+	cd := &hivev1.ClusterDeployment{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: provision.Namespace, Name: provision.Spec.ClusterDeploymentRef.Name}, cd); {
+	case apierrors.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		pLog.WithError(err).Error("cannot get ClusterDeployment of ClusterProvision")
+		return false, err
+	}
+	return controllerutils.IsClusterDeploymentPaused(cd, pLog), nil
+}
+
 func clusterDeploymentWatchHandler(a client.Object) []reconcile.Request {
 	cd := a.(*hivev1.ClusterDeployment)
 	if cd == nil {
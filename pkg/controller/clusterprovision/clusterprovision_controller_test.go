@@ -91,6 +91,16 @@ func TestClusterProvisionReconcile(t *testing.T) {
 			expectNoJobReference:  true,
 			expectPendingCreation: true,
 		},
+		{
+			name: "job not created when cluster deployment paused",
+			existing: []runtime.Object{
+				testProvision(),
+				testClusterDeployment(paused()),
+			},
+			expectedStage:        hivev1.ClusterProvisionStageInitializing,
+			expectNoJob:          true,
+			expectNoJobReference: true,
+		},
 		{
 			name: "adopt job",
 			existing: []runtime.Object{
@@ -399,6 +409,29 @@ func withFailedCondition(reason string) provisionOption {
 	}
 }
 
+type clusterDeploymentOption func(*hivev1.ClusterDeployment)
+
+func testClusterDeployment(opts ...clusterDeploymentOption) *hivev1.ClusterDeployment {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testDeploymentName,
+			Namespace: testNamespace,
+		},
+	}
+
+	for _, o := range opts {
+		o(cd)
+	}
+
+	return cd
+}
+
+func paused() clusterDeploymentOption {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.Paused = true
+	}
+}
+
 func testJob(opts ...testjob.Option) *batchv1.Job {
 	provision := testProvision()
 	job, err := install.GenerateInstallerJob(provision)
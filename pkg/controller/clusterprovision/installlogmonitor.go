@@ -2,6 +2,7 @@ package clusterprovision
 
 import (
 	"context"
+	"os"
 	"regexp"
 	"strings"
 
@@ -11,6 +12,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 
+	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
@@ -52,22 +54,10 @@ func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldL
 		return unknownReason, regexBadMessage
 	}
 
-	// Load additional regex configmap, continue anyway if configmap isn't present
-	additionalRegexes := []installLogRegex{}
-	additionalRegexCM := &corev1.ConfigMap{}
-	if additionalRegexCMErr := r.Get(context.TODO(), types.NamespacedName{Name: additionalRegexConfigMapName, Namespace: controllerutils.GetHiveNamespace()}, additionalRegexCM); additionalRegexCMErr != nil {
-		pLog.WithError(additionalRegexCMErr).Errorf("error loading %s configmap", additionalRegexConfigMapName)
-	} else {
-		additionalRegexesRaw, ok := additionalRegexCM.Data[regexDataEntryName]
-		if !ok {
-			pLog.Errorf("%s configmap does not have a %q data entry", additionalRegexConfigMapName, regexDataEntryName)
-		} else {
-			if additionalRegexesRaw != "" {
-				if err := yaml.Unmarshal([]byte(additionalRegexesRaw), &additionalRegexes); err != nil {
-					pLog.WithError(err).Errorf("cannot unmarshal data from %s configmap", regexConfigMapName)
-				}
-			}
-		}
+	// Load additional regex configmaps, continue anyway if the configmaps aren't present
+	additionalRegexes := r.loadAdditionalRegexes(additionalRegexConfigMapName, pLog)
+	if name := os.Getenv(constants.AdditionalInstallLogRegexesConfigMapEnvVar); name != "" && name != additionalRegexConfigMapName {
+		additionalRegexes = append(additionalRegexes, r.loadAdditionalRegexes(name, pLog)...)
 	}
 
 	pLog.Info("processing new install log")
@@ -98,3 +88,25 @@ func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldL
 
 	return unknownReason, *log
 }
+
+// loadAdditionalRegexes loads the regexes from the named configmap in the hive namespace. Errors are logged rather
+// than returned as the additional regexes are optional.
+func (r *ReconcileClusterProvision) loadAdditionalRegexes(name string, pLog log.FieldLogger) []installLogRegex {
+	regexes := []installLogRegex{}
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: controllerutils.GetHiveNamespace()}, cm); err != nil {
+		pLog.WithError(err).Errorf("error loading %s configmap", name)
+		return regexes
+	}
+	regexesRaw, ok := cm.Data[regexDataEntryName]
+	if !ok {
+		pLog.Errorf("%s configmap does not have a %q data entry", name, regexDataEntryName)
+		return regexes
+	}
+	if regexesRaw != "" {
+		if err := yaml.Unmarshal([]byte(regexesRaw), &regexes); err != nil {
+			pLog.WithError(err).Errorf("cannot unmarshal data from %s configmap", name)
+		}
+	}
+	return regexes
+}
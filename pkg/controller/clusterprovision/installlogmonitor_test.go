@@ -1,6 +1,7 @@
 package clusterprovision
 
 import (
+	"os"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -34,17 +35,19 @@ const (
 	genericLimitExceeded    = "blahblah\ntime=\"2021-01-06T03:35:44Z\" level=error msg=\"Error: Error creating Generic: GenericLimitExceeded: The maximum number of Generics has been reached.\""
 	invalidCredentials      = "blahblah\ntime=\"2021-01-06T03:35:44Z\" level=error msg=\"Error: error waiting for Route53 Hosted Zone (Z1009177L956IM4ANFHL) creation: InvalidClientTokenId: The security token included in the request is invalid.\""
 	kubeAPIWaitFailedLog    = "blahblah\ntime=\"2021-01-06T03:35:44Z\" level=error msg=\"Failed waiting for Kubernetes API. This error usually happens when there is a problem on the bootstrap host that prevents creating a temporary control plane.\""
+	proxyErrorLog           = "blahblah\ntime=\"2021-01-06T03:35:44Z\" level=error msg=\"Error: Get https://registry.example.com/v2/: proxy.example.com: 403 Forbidden\""
 	noMatchLog              = "an example of something that doesn't match the log regexes"
 )
 
 func TestParseInstallLog(t *testing.T) {
 	apis.AddToScheme(scheme.Scheme)
 	tests := []struct {
-		name            string
-		log             *string
-		existing        []runtime.Object
-		expectedReason  string
-		expectedMessage *string
+		name                string
+		log                 *string
+		existing            []runtime.Object
+		additionalConfigMap string
+		expectedReason      string
+		expectedMessage     *string
 	}{
 		{
 			name:           "DNS already exists",
@@ -174,6 +177,37 @@ func TestParseInstallLog(t *testing.T) {
 			},
 			expectedReason: "KubeAPIWaitTimeoutRegexes",
 		},
+		{
+			name: "regexes from HiveConfig configmap",
+			log:  pointer.StringPtr(proxyErrorLog),
+			existing: []runtime.Object{
+				buildRegexConfigMap(),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "site-install-log-regexes",
+						Namespace: constants.DefaultHiveNamespace,
+					},
+					Data: map[string]string{
+						"regexes": `
+- name: ProxyError
+  searchRegexStrings:
+  - "proxy.example.com: 403 Forbidden"
+  installFailingReason: ProxyError
+  installFailingMessage: The site proxy rejected a request from the installer
+`,
+					},
+				},
+			},
+			additionalConfigMap: "site-install-log-regexes",
+			expectedReason:      "ProxyError",
+		},
+		{
+			name:                "missing HiveConfig configmap",
+			log:                 pointer.StringPtr(dnsAlreadyExistsLog),
+			existing:            []runtime.Object{buildRegexConfigMap()},
+			additionalConfigMap: "site-install-log-regexes",
+			expectedReason:      "DNSAlreadyExists",
+		},
 		{
 			name:           "no log",
 			existing:       []runtime.Object{buildRegexConfigMap()},
@@ -266,6 +300,10 @@ func TestParseInstallLog(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
+			if test.additionalConfigMap != "" {
+				os.Setenv(constants.AdditionalInstallLogRegexesConfigMapEnvVar, test.additionalConfigMap)
+				defer os.Unsetenv(constants.AdditionalInstallLogRegexesConfigMapEnvVar)
+			}
 			fakeClient := fake.NewFakeClient(test.existing...)
 			r := &ReconcileClusterProvision{
 				Client: fakeClient,
@@ -0,0 +1,136 @@
+package clusterprovision
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+)
+
+var (
+	metricInstallPhaseDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "hive_cluster_provision_install_phase_duration_seconds",
+			Help:    "Distribution of the duration of the phases of installs, parsed from the install logs of finished provisions.",
+			Buckets: []float64{60, 300, 600, 900, 1200, 1800, 2400, 3600},
+		},
+		[]string{"cluster_type", "platform", "version", "phase"},
+	)
+
+	// installLogTimeRegex matches the timestamp of a line of the install log.
+	installLogTimeRegex = regexp.MustCompile(`^time="([^"]+)"`)
+
+	// installPhaseMarkers are the messages of the install log that start and complete each phase of the install.
+	installPhaseMarkers = []struct {
+		name     hivev1.InstallPhaseName
+		start    *regexp.Regexp
+		complete *regexp.Regexp
+	}{
+		{
+			name:     hivev1.InfrastructureInstallPhase,
+			start:    regexp.MustCompile(`Creating infrastructure resources`),
+			complete: regexp.MustCompile(`Waiting up to \S+ .*for the Kubernetes API`),
+		},
+		{
+			name:     hivev1.BootstrapInstallPhase,
+			start:    regexp.MustCompile(`Waiting up to \S+ .*for the Kubernetes API`),
+			complete: regexp.MustCompile(`It is now safe to remove the bootstrap resources|Destroying the bootstrap resources`),
+		},
+		{
+			name:     hivev1.ClusterOperatorsInstallPhase,
+			start:    regexp.MustCompile(`Waiting up to \S+ .*for the cluster at \S+ to initialize`),
+			complete: regexp.MustCompile(`Install complete!`),
+		},
+	}
+)
+
+// parseInstallPhases returns the timings of the phases of the install that completed, from the timestamps of the
+// messages of the install log that start and complete each phase. A phase is timed from the first message starting
+// it to the first message completing it after that, so that the install log of an install retried with
+// "wait-for install-complete" is timed from the first attempt.
+func parseInstallPhases(installLog *string) []hivev1.InstallPhase {
+	if installLog == nil {
+		return nil
+	}
+	starts := make([]time.Time, len(installPhaseMarkers))
+	completions := make([]time.Time, len(installPhaseMarkers))
+	for _, line := range strings.Split(*installLog, "\n") {
+		m := installLogTimeRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, m[1])
+		if err != nil {
+			continue
+		}
+		for i, marker := range installPhaseMarkers {
+			if starts[i].IsZero() && marker.start.MatchString(line) {
+				starts[i] = t
+			} else if !starts[i].IsZero() && completions[i].IsZero() && marker.complete.MatchString(line) {
+				completions[i] = t
+			}
+		}
+	}
+
+	var phases []hivev1.InstallPhase
+	for i, marker := range installPhaseMarkers {
+		if starts[i].IsZero() || completions[i].IsZero() {
+			continue
+		}
+		phases = append(phases, hivev1.InstallPhase{
+			Name:           marker.name,
+			StartTime:      metav1.NewTime(starts[i]),
+			CompletionTime: metav1.NewTime(completions[i]),
+			Duration:       metav1.Duration{Duration: completions[i].Sub(starts[i])},
+		})
+	}
+	return phases
+}
+
+// observeInstallPhases records the durations of the phases of the install in the install phase duration metric,
+// labeled with the platform of the cluster and the major and minor version of its release.
+func (r *ReconcileClusterProvision) observeInstallPhases(instance *hivev1.ClusterProvision, pLog log.FieldLogger) {
+	if len(instance.Status.InstallPhases) == 0 {
+		return
+	}
+	platform := instance.Labels[hivev1.HiveClusterPlatformLabel]
+	if platform == "" {
+		platform = "unknown"
+	}
+	var installVersion *string
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: instance.Namespace, Name: instance.Spec.ClusterDeploymentRef.Name}, cd); err != nil {
+		pLog.WithError(err).Warn("could not get clusterdeployment for the install phase metrics")
+	} else {
+		installVersion = cd.Status.InstallVersion
+	}
+	version := majorMinorVersion(installVersion)
+	for _, phase := range instance.Status.InstallPhases {
+		metricInstallPhaseDuration.WithLabelValues(hivemetrics.GetClusterDeploymentType(instance), platform, version, string(phase.Name)).
+			Observe(phase.Duration.Seconds())
+	}
+}
+
+// majorMinorVersion returns the major and minor version of a release version, such as 4.15 for 4.15.3, or
+// "unknown" if the version cannot be parsed.
+func majorMinorVersion(version *string) string {
+	if version == nil {
+		return "unknown"
+	}
+	v, err := semver.ParseTolerant(*version)
+	if err != nil {
+		return "unknown"
+	}
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
@@ -0,0 +1,96 @@
+package clusterprovision
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	completedInstallLog = `time="2024-03-12T10:00:00Z" level=info msg="Consuming Install Config from target directory"
+time="2024-03-12T10:00:05Z" level=info msg="Creating infrastructure resources..."
+time="2024-03-12T10:04:05Z" level=info msg="Waiting up to 20m0s (until 10:24AM) for the Kubernetes API at https://api.test.example.com:6443..."
+time="2024-03-12T10:06:00Z" level=info msg="API v1.28.6+6216ea1 up"
+time="2024-03-12T10:06:00Z" level=info msg="Waiting up to 30m0s (until 10:36AM) for bootstrapping to complete..."
+time="2024-03-12T10:16:05Z" level=info msg="Destroying the bootstrap resources..."
+time="2024-03-12T10:17:00Z" level=info msg="Waiting up to 40m0s (until 10:57AM) for the cluster at https://api.test.example.com:6443 to initialize..."
+time="2024-03-12T10:37:00Z" level=info msg="Checking to see if there is a route at openshift-console/console..."
+time="2024-03-12T10:37:30Z" level=info msg="Install complete!"
+`
+	bootstrapFailedInstallLog = `time="2024-03-12T10:00:05Z" level=info msg="Creating infrastructure resources..."
+time="2024-03-12T10:03:00Z" level=info msg="Waiting up to 20m0s (until 10:23AM) for the Kubernetes API at https://api.test.example.com:6443..."
+time="2024-03-12T10:33:00Z" level=error msg="Bootstrap failed to complete: timed out waiting for the condition"
+`
+)
+
+func TestParseInstallPhases(t *testing.T) {
+	cases := []struct {
+		name      string
+		log       *string
+		expected  map[hivev1.InstallPhaseName]time.Duration
+		expectNil bool
+	}{
+		{
+			name: "completed install",
+			log:  pointer.StringPtr(completedInstallLog),
+			expected: map[hivev1.InstallPhaseName]time.Duration{
+				hivev1.InfrastructureInstallPhase:   4 * time.Minute,
+				hivev1.BootstrapInstallPhase:        12 * time.Minute,
+				hivev1.ClusterOperatorsInstallPhase: 20*time.Minute + 30*time.Second,
+			},
+		},
+		{
+			name: "bootstrap failed",
+			log:  pointer.StringPtr(bootstrapFailedInstallLog),
+			expected: map[hivev1.InstallPhaseName]time.Duration{
+				hivev1.InfrastructureInstallPhase: 2*time.Minute + 55*time.Second,
+			},
+		},
+		{
+			name: "retried wait for install complete",
+			log: pointer.StringPtr(`time="2024-03-12T10:00:00Z" level=info msg="Waiting up to 40m0s for the cluster at https://api.test.example.com:6443 to initialize..."
+time="2024-03-12T10:40:00Z" level=error msg="failed to initialize the cluster: timed out waiting for the condition"
+time="2024-03-12T10:40:05Z" level=info msg="Waiting up to 40m0s for the cluster at https://api.test.example.com:6443 to initialize..."
+time="2024-03-12T10:50:00Z" level=info msg="Install complete!"
+`),
+			expected: map[hivev1.InstallPhaseName]time.Duration{
+				hivev1.ClusterOperatorsInstallPhase: 50 * time.Minute,
+			},
+		},
+		{
+			name:      "no timestamps",
+			log:       pointer.StringPtr(`level=info msg="Creating infrastructure resources..."`),
+			expectNil: true,
+		},
+		{
+			name:      "no log",
+			expectNil: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			phases := parseInstallPhases(tc.log)
+			if tc.expectNil {
+				assert.Nil(t, phases, "expected no phases")
+				return
+			}
+			actual := map[hivev1.InstallPhaseName]time.Duration{}
+			for _, phase := range phases {
+				actual[phase.Name] = phase.Duration.Duration
+				assert.Equal(t, phase.Duration.Duration, phase.CompletionTime.Sub(phase.StartTime.Time), "unexpected duration for phase %s", phase.Name)
+			}
+			assert.Equal(t, tc.expected, actual, "unexpected phases")
+		})
+	}
+}
+
+func TestMajorMinorVersion(t *testing.T) {
+	assert.Equal(t, "4.15", majorMinorVersion(pointer.StringPtr("4.15.3")), "unexpected version")
+	assert.Equal(t, "4.16", majorMinorVersion(pointer.StringPtr("4.16.0-rc.1")), "unexpected version")
+	assert.Equal(t, "unknown", majorMinorVersion(pointer.StringPtr("latest")), "unexpected version")
+	assert.Equal(t, "unknown", majorMinorVersion(nil), "unexpected version")
+}
@@ -0,0 +1,122 @@
+package clusterrelocate
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// batchRequeueAfter is how often a ClusterDeployment waiting for the current batch to finish is reconciled again.
+const batchRequeueAfter = time.Minute
+
+// admitToBatch checks whether the ClusterDeployment may start relocating under the batching of the ClusterRelocate,
+// adding it to the current batch or starting a new batch as needed. A non-nil result is returned when the
+// ClusterDeployment must wait for a later batch.
+func (r *ReconcileClusterRelocate) admitToBatch(cd *hivev1.ClusterDeployment, cr *hivev1.ClusterRelocate, logger log.FieldLogger) (*reconcile.Result, error) {
+	batching := cr.Spec.Batching
+	if batching == nil {
+		return nil, nil
+	}
+	key := types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}.String()
+	for _, c := range cr.Status.BatchClusters {
+		if c == key {
+			return nil, nil
+		}
+	}
+	logger = logger.WithField("batch", cr.Status.Batch)
+
+	if cr.Status.Batch > 0 && cr.Status.BatchCompletionTime == nil {
+		if len(cr.Status.BatchClusters) < batching.Size {
+			logger.Info("adding clusterdeployment to current batch")
+			cr.Status.BatchClusters = append(cr.Status.BatchClusters, key)
+			return nil, r.updateBatchStatus(cr, logger)
+		}
+		finished, err := r.batchFinished(cr, logger)
+		if err != nil {
+			return nil, err
+		}
+		if !finished {
+			logger.Debug("waiting for current batch to finish")
+			return &reconcile.Result{RequeueAfter: batchRequeueAfter}, nil
+		}
+		logger.Info("batch finished")
+		now := metav1.Now()
+		cr.Status.BatchCompletionTime = &now
+	}
+
+	if batching.ApprovedBatches != nil && cr.Status.Batch >= *batching.ApprovedBatches {
+		logger.Debug("waiting for next batch to be approved")
+		// The ClusterDeployment is reconciled again when the ClusterRelocate is changed to approve the next batch.
+		return &reconcile.Result{}, r.setBatchPhase(cr, hivev1.AwaitingApprovalClusterRelocateBatchPhase, logger)
+	}
+	if cr.Status.BatchCompletionTime != nil && batching.Interval != nil {
+		if wait := batching.Interval.Duration - time.Since(cr.Status.BatchCompletionTime.Time); wait > 0 {
+			logger.WithField("wait", wait).Debug("waiting for batching interval before next batch")
+			return &reconcile.Result{RequeueAfter: wait}, r.setBatchPhase(cr, hivev1.PausedClusterRelocateBatchPhase, logger)
+		}
+	}
+
+	cr.Status.Batch++
+	cr.Status.BatchPhase = hivev1.RelocatingClusterRelocateBatchPhase
+	cr.Status.BatchClusters = []string{key}
+	cr.Status.BatchCompletionTime = nil
+	logger.WithField("batch", cr.Status.Batch).Info("starting batch")
+	return nil, r.updateBatchStatus(cr, logger)
+}
+
+// batchFinished returns whether all of the clusters of the current batch of the ClusterRelocate have been relocated
+// or no longer match the ClusterRelocate.
+func (r *ReconcileClusterRelocate) batchFinished(cr *hivev1.ClusterRelocate, logger log.FieldLogger) (bool, error) {
+	for _, c := range cr.Status.BatchClusters {
+		parts := strings.SplitN(c, "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		cd := &hivev1.ClusterDeployment{}
+		switch err := r.Get(context.Background(), types.NamespacedName{Namespace: parts[0], Name: parts[1]}, cd); {
+		case apierrors.IsNotFound(err):
+			// Relocated clusters are deleted.
+			continue
+		case err != nil:
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to get clusterdeployment of batch")
+			return false, errors.Wrap(err, "failed to get clusterdeployment of batch")
+		}
+		if relocateName, _, _ := controllerutils.IsRelocating(cd); relocateName == cr.Name {
+			return false, nil
+		}
+		matches, err := r.relocateMatches(cr, cd)
+		if err != nil {
+			return false, err
+		}
+		if matches {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (r *ReconcileClusterRelocate) setBatchPhase(cr *hivev1.ClusterRelocate, phase hivev1.ClusterRelocateBatchPhase, logger log.FieldLogger) error {
+	if cr.Status.BatchPhase == phase {
+		return nil
+	}
+	cr.Status.BatchPhase = phase
+	return r.updateBatchStatus(cr, logger)
+}
+
+func (r *ReconcileClusterRelocate) updateBatchStatus(cr *hivev1.ClusterRelocate, logger log.FieldLogger) error {
+	if err := r.Status().Update(context.Background(), cr); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update clusterrelocate batch status")
+		return errors.Wrap(err, "failed to update clusterrelocate batch status")
+	}
+	return nil
+}
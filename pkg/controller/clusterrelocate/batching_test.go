@@ -0,0 +1,221 @@
+package clusterrelocate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcr "github.com/openshift/hive/pkg/test/clusterrelocate"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+)
+
+func TestAdmitToBatch(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.DebugLevel)
+
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+
+	const otherCDName = "other-cluster-deployment"
+	cdKey := namespace + "/" + cdName
+	otherCDKey := namespace + "/" + otherCDName
+
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).GenericOptions(
+		testgeneric.WithLabel(labelKey, labelValue),
+	)
+	otherCDBuilder := testcd.FullBuilder(namespace, otherCDName, scheme)
+	crBuilder := testcr.FullBuilder(crName, scheme).Options(
+		testcr.WithKubeconfigSecret(kubeconfigNamespace, kubeconfigName),
+		testcr.WithClusterDeploymentSelector(labelKey, labelValue),
+	)
+	intPtr := func(i int) *int { return &i }
+	completedAgo := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(time.Now().Add(-d))
+		return &t
+	}
+
+	cases := []struct {
+		name               string
+		batching           *hivev1.ClusterRelocateBatching
+		status             hivev1.ClusterRelocateStatus
+		existing           []runtime.Object
+		expectWait         bool
+		expectedRequeue    time.Duration
+		expectedBatch      int
+		expectedPhase      hivev1.ClusterRelocateBatchPhase
+		expectedClusters   []string
+		expectedCompletion bool
+	}{
+		{
+			name: "no batching",
+		},
+		{
+			name:             "first batch",
+			batching:         &hivev1.ClusterRelocateBatching{Size: 2},
+			expectedBatch:    1,
+			expectedPhase:    hivev1.RelocatingClusterRelocateBatchPhase,
+			expectedClusters: []string{cdKey},
+		},
+		{
+			name:     "join current batch",
+			batching: &hivev1.ClusterRelocateBatching{Size: 2},
+			status: hivev1.ClusterRelocateStatus{
+				Batch:         1,
+				BatchPhase:    hivev1.RelocatingClusterRelocateBatchPhase,
+				BatchClusters: []string{otherCDKey},
+			},
+			expectedBatch:    1,
+			expectedPhase:    hivev1.RelocatingClusterRelocateBatchPhase,
+			expectedClusters: []string{otherCDKey, cdKey},
+		},
+		{
+			name:     "already in current batch",
+			batching: &hivev1.ClusterRelocateBatching{Size: 1},
+			status: hivev1.ClusterRelocateStatus{
+				Batch:         1,
+				BatchPhase:    hivev1.RelocatingClusterRelocateBatchPhase,
+				BatchClusters: []string{cdKey},
+			},
+			expectedBatch:    1,
+			expectedPhase:    hivev1.RelocatingClusterRelocateBatchPhase,
+			expectedClusters: []string{cdKey},
+		},
+		{
+			name:     "current batch full",
+			batching: &hivev1.ClusterRelocateBatching{Size: 1},
+			status: hivev1.ClusterRelocateStatus{
+				Batch:         1,
+				BatchPhase:    hivev1.RelocatingClusterRelocateBatchPhase,
+				BatchClusters: []string{otherCDKey},
+			},
+			existing: []runtime.Object{
+				otherCDBuilder.Build(testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateOutgoing))),
+			},
+			expectWait:       true,
+			expectedRequeue:  batchRequeueAfter,
+			expectedBatch:    1,
+			expectedPhase:    hivev1.RelocatingClusterRelocateBatchPhase,
+			expectedClusters: []string{otherCDKey},
+		},
+		{
+			name:     "current batch finished",
+			batching: &hivev1.ClusterRelocateBatching{Size: 1},
+			status: hivev1.ClusterRelocateStatus{
+				Batch:         1,
+				BatchPhase:    hivev1.RelocatingClusterRelocateBatchPhase,
+				BatchClusters: []string{otherCDKey},
+			},
+			expectedBatch:    2,
+			expectedPhase:    hivev1.RelocatingClusterRelocateBatchPhase,
+			expectedClusters: []string{cdKey},
+		},
+		{
+			name:     "current batch finished with unmatched cluster",
+			batching: &hivev1.ClusterRelocateBatching{Size: 1},
+			status: hivev1.ClusterRelocateStatus{
+				Batch:         1,
+				BatchPhase:    hivev1.RelocatingClusterRelocateBatchPhase,
+				BatchClusters: []string{otherCDKey},
+			},
+			existing:         []runtime.Object{otherCDBuilder.Build()},
+			expectedBatch:    2,
+			expectedPhase:    hivev1.RelocatingClusterRelocateBatchPhase,
+			expectedClusters: []string{cdKey},
+		},
+		{
+			name:     "awaiting approval",
+			batching: &hivev1.ClusterRelocateBatching{Size: 1, ApprovedBatches: intPtr(1)},
+			status: hivev1.ClusterRelocateStatus{
+				Batch:         1,
+				BatchPhase:    hivev1.RelocatingClusterRelocateBatchPhase,
+				BatchClusters: []string{otherCDKey},
+			},
+			expectWait:         true,
+			expectedBatch:      1,
+			expectedPhase:      hivev1.AwaitingApprovalClusterRelocateBatchPhase,
+			expectedClusters:   []string{otherCDKey},
+			expectedCompletion: true,
+		},
+		{
+			name:     "approved",
+			batching: &hivev1.ClusterRelocateBatching{Size: 1, ApprovedBatches: intPtr(2)},
+			status: hivev1.ClusterRelocateStatus{
+				Batch:               1,
+				BatchPhase:          hivev1.AwaitingApprovalClusterRelocateBatchPhase,
+				BatchClusters:       []string{otherCDKey},
+				BatchCompletionTime: completedAgo(time.Minute),
+			},
+			expectedBatch:    2,
+			expectedPhase:    hivev1.RelocatingClusterRelocateBatchPhase,
+			expectedClusters: []string{cdKey},
+		},
+		{
+			name:     "paused for interval",
+			batching: &hivev1.ClusterRelocateBatching{Size: 1, Interval: &metav1.Duration{Duration: time.Hour}},
+			status: hivev1.ClusterRelocateStatus{
+				Batch:               1,
+				BatchPhase:          hivev1.RelocatingClusterRelocateBatchPhase,
+				BatchClusters:       []string{otherCDKey},
+				BatchCompletionTime: completedAgo(10 * time.Minute),
+			},
+			expectWait:         true,
+			expectedRequeue:    50 * time.Minute,
+			expectedBatch:      1,
+			expectedPhase:      hivev1.PausedClusterRelocateBatchPhase,
+			expectedClusters:   []string{otherCDKey},
+			expectedCompletion: true,
+		},
+		{
+			name:     "interval elapsed",
+			batching: &hivev1.ClusterRelocateBatching{Size: 1, Interval: &metav1.Duration{Duration: time.Hour}},
+			status: hivev1.ClusterRelocateStatus{
+				Batch:               1,
+				BatchPhase:          hivev1.PausedClusterRelocateBatchPhase,
+				BatchClusters:       []string{otherCDKey},
+				BatchCompletionTime: completedAgo(2 * time.Hour),
+			},
+			expectedBatch:    2,
+			expectedPhase:    hivev1.RelocatingClusterRelocateBatchPhase,
+			expectedClusters: []string{cdKey},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := cdBuilder.Build()
+			cr := crBuilder.Build(testcr.WithBatching(tc.batching), testcr.WithStatus(tc.status))
+			c := fake.NewFakeClientWithScheme(scheme, append(tc.existing, cd, cr)...)
+			r := &ReconcileClusterRelocate{
+				Client: c,
+				logger: logger,
+			}
+
+			result, err := r.admitToBatch(cd, cr, logger)
+			require.NoError(t, err, "unexpected error")
+			if !tc.expectWait {
+				assert.Nil(t, result, "expected clusterdeployment to be admitted")
+			} else if assert.NotNil(t, result, "expected clusterdeployment to wait") {
+				assert.InDelta(t, tc.expectedRequeue, result.RequeueAfter, float64(time.Minute), "unexpected requeue")
+			}
+
+			actual := &hivev1.ClusterRelocate{}
+			require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: crName}, actual), "failed to get clusterrelocate")
+			assert.Equal(t, tc.expectedBatch, actual.Status.Batch, "unexpected batch")
+			assert.Equal(t, tc.expectedPhase, actual.Status.BatchPhase, "unexpected batch phase")
+			assert.Equal(t, tc.expectedClusters, actual.Status.BatchClusters, "unexpected batch clusters")
+			assert.Equal(t, tc.expectedCompletion, actual.Status.BatchCompletionTime != nil, "unexpected batch completion time")
+		})
+	}
+}
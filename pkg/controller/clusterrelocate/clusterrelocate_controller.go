@@ -65,7 +65,7 @@ func init() {
 
 // Add creates a new ClusterRelocate controller and adds it to the manager with default RBAC.
 func Add(mgr manager.Manager) error {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
 	if err != nil {
 		logger.WithError(err).Error("could not get controller configurations")
@@ -80,7 +80,7 @@ func Add(mgr manager.Manager) error {
 		return remoteclient.NewBuilderFromKubeconfig(r.Client, secret)
 	}
 
-	c, err := controller.New("clusterrelocate-controller", mgr, controller.Options{
+	c, err := controllerutils.NewController("clusterrelocate-controller", mgr, controller.Options{
 		Reconciler:              r,
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             queueRateLimiter,
@@ -167,6 +167,10 @@ func (r *ReconcileClusterRelocate) Reconcile(ctx context.Context, request reconc
 		return reconcile.Result{}, err
 	}
 
+	if controllerutils.IsClusterDeploymentPaused(cd, logger) {
+		return reconcile.Result{}, nil
+	}
+
 	currentRelocateName, relocateStatus, err := controllerutils.IsRelocating(cd)
 	if err != nil {
 		logger.WithError(err).Error("could not determine relocate status")
@@ -233,6 +237,16 @@ func (r *ReconcileClusterRelocate) reconcileSingleMatch(cd *hivev1.ClusterDeploy
 
 	logger = logger.WithField("clusterRelocate", desiredRelocate.Name)
 
+	// Wait for a later batch, unless the relocation has already started
+	if oldRelocateStatus != hivev1.RelocateOutgoing || oldRelocateName != desiredRelocate.Name {
+		switch result, err := r.admitToBatch(cd, desiredRelocate, logger); {
+		case err != nil:
+			return reconcile.Result{}, err
+		case result != nil:
+			return *result, nil
+		}
+	}
+
 	kubeconfigSecret := &corev1.Secret{}
 	if err := r.Get(
 		context.Background(),
@@ -391,20 +405,47 @@ func (r *ReconcileClusterRelocate) findMatchingRelocates(cd *hivev1.ClusterDeplo
 	}
 	var matches []*hivev1.ClusterRelocate
 	for i, cr := range clusterRelocates.Items {
-		labelSelector, err := metav1.LabelSelectorAsSelector(&cr.Spec.ClusterDeploymentSelector)
+		matched, err := r.relocateMatches(&cr, cd)
 		if err != nil {
-			r.logger.WithError(err).
-				WithField("clusterRelocate", cr.Name).
-				Warn("cannot parse clusterdeployment selector")
-			continue
+			return nil, err
 		}
-		if labelSelector.Matches(labels.Set(cd.Labels)) {
+		if matched {
 			matches = append(matches, &clusterRelocates.Items[i])
 		}
 	}
 	return matches, nil
 }
 
+// relocateMatches returns whether the ClusterDeployment matches the selectors of the ClusterRelocate. Invalid
+// selectors match no ClusterDeployments.
+func (r *ReconcileClusterRelocate) relocateMatches(cr *hivev1.ClusterRelocate, cd *hivev1.ClusterDeployment) (bool, error) {
+	labelSelector, err := metav1.LabelSelectorAsSelector(&cr.Spec.ClusterDeploymentSelector)
+	if err != nil {
+		r.logger.WithError(err).
+			WithField("clusterRelocate", cr.Name).
+			Warn("cannot parse clusterdeployment selector")
+		return false, nil
+	}
+	if !labelSelector.Matches(labels.Set(cd.Labels)) {
+		return false, nil
+	}
+	if cr.Spec.NamespaceSelector == nil {
+		return true, nil
+	}
+	namespaceSelector, err := metav1.LabelSelectorAsSelector(cr.Spec.NamespaceSelector)
+	if err != nil {
+		r.logger.WithError(err).
+			WithField("clusterRelocate", cr.Name).
+			Warn("cannot parse namespace selector")
+		return false, nil
+	}
+	namespace := &corev1.Namespace{}
+	if err := r.Get(context.Background(), client.ObjectKey{Name: cd.Namespace}, namespace); err != nil {
+		return false, errors.Wrap(err, "failed to get namespace")
+	}
+	return namespaceSelector.Matches(labels.Set(namespace.Labels)), nil
+}
+
 func (r *ReconcileClusterRelocate) stopRelocating(cd *hivev1.ClusterDeployment, currentRelocateName string, logger log.FieldLogger) error {
 	if currentRelocateName == "" {
 		return nil
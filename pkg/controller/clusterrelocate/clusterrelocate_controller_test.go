@@ -776,6 +776,7 @@ func TestReconcileClusterRelocate_Reconcile_RelocateStatus(t *testing.T) {
 		expectedRelocateStatus            hivev1.RelocateStatus
 		expectedDeletionTimestamp         bool
 		expectedRelocationFailedCondition *hivev1.ClusterDeploymentCondition
+		expectNoRelocationFailedCondition bool
 		validate                          func(t *testing.T, cd *hivev1.ClusterDeployment)
 	}{
 		{
@@ -788,6 +789,15 @@ func TestReconcileClusterRelocate_Reconcile_RelocateStatus(t *testing.T) {
 			expectedRelocateStatus:    hivev1.RelocateComplete,
 			expectedDeletionTimestamp: true,
 		},
+		{
+			name:    "paused clusterdeployment",
+			cd:      cdBuilder.Build(testcd.Paused()),
+			dnsZone: dnsZoneBuilder.Build(),
+			srcResources: []runtime.Object{
+				crBuilder.Build(),
+			},
+			expectNoRelocationFailedCondition: true,
+		},
 		{
 			name: "clusterdeployment with dnszone already relocating",
 			cd:   cdBuilder.Build(),
@@ -814,6 +824,88 @@ func TestReconcileClusterRelocate_Reconcile_RelocateStatus(t *testing.T) {
 			expectedRelocateStatus:    hivev1.RelocateComplete,
 			expectedDeletionTimestamp: true,
 		},
+		{
+			name:    "namespace selected",
+			cd:      cdBuilder.Build(),
+			dnsZone: dnsZoneBuilder.Build(),
+			srcResources: []runtime.Object{
+				crBuilder.Build(testcr.WithNamespaceSelector("relocate", "true")),
+				testnamespace.FullBuilder(namespace, scheme).Build(
+					testnamespace.Generic(testgeneric.WithLabel("relocate", "true")),
+				),
+			},
+			expectedRelocateStatus:    hivev1.RelocateComplete,
+			expectedDeletionTimestamp: true,
+		},
+		{
+			name:    "namespace not selected",
+			cd:      cdBuilder.Build(),
+			dnsZone: dnsZoneBuilder.Build(),
+			srcResources: []runtime.Object{
+				crBuilder.Build(testcr.WithNamespaceSelector("relocate", "true")),
+				testnamespace.FullBuilder(namespace, scheme).Build(),
+			},
+			expectedRelocationFailedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionFalse,
+				Reason: "NoMatchingRelocates",
+			},
+		},
+		{
+			name:    "first batch",
+			cd:      cdBuilder.Build(),
+			dnsZone: dnsZoneBuilder.Build(),
+			srcResources: []runtime.Object{
+				crBuilder.Build(testcr.WithBatching(&hivev1.ClusterRelocateBatching{Size: 1})),
+			},
+			expectedRelocateStatus:    hivev1.RelocateComplete,
+			expectedDeletionTimestamp: true,
+		},
+		{
+			name: "batch full",
+			cd: cdBuilder.Build(
+				testcd.WithCondition(controllerutils.InitializeClusterDeploymentConditions(nil,
+					[]hivev1.ClusterDeploymentConditionType{hivev1.RelocationFailedCondition})[0]),
+			),
+			dnsZone: dnsZoneBuilder.Build(),
+			srcResources: []runtime.Object{
+				crBuilder.Build(
+					testcr.WithBatching(&hivev1.ClusterRelocateBatching{Size: 1}),
+					testcr.WithStatus(hivev1.ClusterRelocateStatus{
+						Batch:         1,
+						BatchPhase:    hivev1.RelocatingClusterRelocateBatchPhase,
+						BatchClusters: []string{namespace + "/other-cluster"},
+					}),
+				),
+				testcd.FullBuilder(namespace, "other-cluster", scheme).Build(
+					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateOutgoing)),
+				),
+			},
+			expectedRelocationFailedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionUnknown,
+				Reason: hivev1.InitializedConditionReason,
+			},
+		},
+		{
+			name: "already relocating in batch",
+			cd: cdBuilder.Build(
+				testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateOutgoing)),
+			),
+			dnsZone: dnsZoneBuilder.Build(
+				testdnszone.Generic(withRelocateAnnotation(crName, hivev1.RelocateOutgoing)),
+			),
+			srcResources: []runtime.Object{
+				crBuilder.Build(
+					testcr.WithBatching(&hivev1.ClusterRelocateBatching{Size: 1}),
+					testcr.WithStatus(hivev1.ClusterRelocateStatus{
+						Batch:         1,
+						BatchPhase:    hivev1.RelocatingClusterRelocateBatchPhase,
+						BatchClusters: []string{namespace + "/other-cluster"},
+					}),
+				),
+			},
+			expectedRelocateStatus:    hivev1.RelocateComplete,
+			expectedDeletionTimestamp: true,
+		},
 		{
 			name:    "multiple relocates",
 			cd:      cdBuilder.Build(),
@@ -1079,7 +1171,9 @@ func TestReconcileClusterRelocate_Reconcile_RelocateStatus(t *testing.T) {
 			}
 
 			cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.RelocationFailedCondition)
-			if tc.expectedRelocationFailedCondition != nil {
+			if tc.expectNoRelocationFailedCondition {
+				assert.Nil(t, cond, "unexpected relocating condition")
+			} else if tc.expectedRelocationFailedCondition != nil {
 				if assert.NotNil(t, cond, "missing relocating condition") {
 					assert.Equal(t, tc.expectedRelocationFailedCondition.Status, cond.Status, "unexpected condition status")
 					assert.Equal(t, tc.expectedRelocationFailedCondition.Reason, cond.Reason, "unexpected condition reason")
@@ -40,7 +40,7 @@ const (
 
 // Add creates a new ClusterState controller and adds it to the manager with default RBAC.
 func Add(mgr manager.Manager) error {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
 	if err != nil {
 		logger.WithError(err).Error("could not get controller configurations")
@@ -54,7 +54,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 	r := &ReconcileClusterState{
 		Client:       controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
 		scheme:       mgr.GetScheme(),
-		logger:       log.WithField("controller", ControllerName),
+		logger:       controllerutils.ControllerLogEntry(ControllerName),
 		updateStatus: updateClusterStateStatus,
 	}
 	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
@@ -65,20 +65,20 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
-	c, err := controller.New("clusterstate-controller", mgr, controller.Options{
+	c, err := controllerutils.NewController("clusterstate-controller", mgr, controller.Options{
 		Reconciler:              r,
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
 	if err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("Error creating new clusterstate controller")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error creating new clusterstate controller")
 		return err
 	}
 
 	// Watch for changes to ClusterDeployment
 	err = c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{})
 	if err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deployment")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error watching cluster deployment")
 		return err
 	}
 	return nil
@@ -128,6 +128,10 @@ func (r *ReconcileClusterState) Reconcile(ctx context.Context, request reconcile
 		return reconcile.Result{}, err
 	}
 
+	if controllerutils.IsClusterDeploymentPaused(cd, logger) {
+		return reconcile.Result{}, nil
+	}
+
 	if !cd.DeletionTimestamp.IsZero() {
 		logger.Debug("ClusterDeployment resource has been deleted")
 		return reconcile.Result{}, nil
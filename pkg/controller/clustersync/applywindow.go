@@ -0,0 +1,122 @@
+package clustersync
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+// applyWindowState returns whether one of the apply windows is open at the time, and the time at which the next
+// window opens. Windows with an invalid start are ignored.
+func applyWindowState(windows []hivev1.SyncSetApplyWindow, now time.Time) (open bool, next time.Time) {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	for _, window := range windows {
+		start, err := time.Parse("15:04", window.Start)
+		if err != nil {
+			continue
+		}
+		offset := time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute
+		// Windows opened during the last week may still be open, and every window opens during the next week.
+		for day := -7; day <= 7; day++ {
+			opens := today.AddDate(0, 0, day).Add(offset)
+			if !opensOn(window, opens.Weekday()) {
+				continue
+			}
+			if !opens.After(now) && now.Before(opens.Add(window.Duration.Duration)) {
+				open = true
+			}
+			if opens.After(now) && (next.IsZero() || opens.Before(next)) {
+				next = opens
+			}
+		}
+	}
+	return open, next
+}
+
+func opensOn(window hivev1.SyncSetApplyWindow, weekday time.Weekday) bool {
+	if len(window.Days) == 0 {
+		return true
+	}
+	for _, day := range window.Days {
+		if string(day) == weekday.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// contentHashes returns the hashes of the resources, secret mappings and patches of the syncset.
+func contentHashes(spec *hivev1.SyncSetCommonSpec) []string {
+	var hashes []string
+	add := func(item interface{}) {
+		if hash, err := controllerutils.GetChecksumOfObject(item); err == nil {
+			hashes = append(hashes, hash)
+		}
+	}
+	for _, r := range spec.Resources {
+		add(r)
+	}
+	for _, s := range spec.Secrets {
+		add(s)
+	}
+	for _, p := range spec.Patches {
+		add(p)
+	}
+	return hashes
+}
+
+// reapplyUnchangedContent re-applies the resources, secret mappings and patches of a syncset whose changes are
+// deferred to the next apply window that were part of the syncset when it was last applied, correcting drift on the
+// cluster without rolling out the changes. Failures are only logged, as the status of the syncset is left as it is
+// until the changes are applied.
+func (r *ReconcileClusterSync) reapplyUnchangedContent(syncSet CommonSyncSet, appliedHashes []string, resourceHelper resource.Helper, logger log.FieldLogger) {
+	applied := sets.NewString(appliedHashes...)
+	unchanged := func(item interface{}) bool {
+		hash, err := controllerutils.GetChecksumOfObject(item)
+		return err == nil && applied.Has(hash)
+	}
+
+	var copied CommonSyncSet
+	switch obj := syncSet.AsRuntimeObject().DeepCopyObject().(type) {
+	case *hivev1.SyncSet:
+		copied = (*SyncSetAsCommon)(obj)
+	case *hivev1.SelectorSyncSet:
+		copied = (*SelectorSyncSetAsCommon)(obj)
+	default:
+		return
+	}
+	spec := copied.GetSpec()
+	spec.Resources = spec.Resources[:0]
+	for _, res := range syncSet.GetSpec().Resources {
+		if unchanged(res) {
+			spec.Resources = append(spec.Resources, res)
+		}
+	}
+	spec.Secrets = spec.Secrets[:0]
+	for _, s := range syncSet.GetSpec().Secrets {
+		if unchanged(s) {
+			spec.Secrets = append(spec.Secrets, s)
+		}
+	}
+	spec.Patches = spec.Patches[:0]
+	for _, p := range syncSet.GetSpec().Patches {
+		if unchanged(p) {
+			spec.Patches = append(spec.Patches, p)
+		}
+	}
+	if len(spec.Resources)+len(spec.Secrets)+len(spec.Patches) == 0 {
+		return
+	}
+
+	logger.Debug("re-applying the unchanged content of deferred syncset")
+	if _, _, _, _, err := r.applySyncSet(copied, resourceHelper, logger); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not re-apply the unchanged content of deferred syncset")
+	}
+}
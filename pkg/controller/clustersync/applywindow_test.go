@@ -0,0 +1,68 @@
+package clustersync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestApplyWindowState(t *testing.T) {
+	// A Thursday
+	now := time.Date(2026, 10, 15, 12, 30, 0, 0, time.UTC)
+	window := func(start string, duration time.Duration, days ...hivev1.SyncSetApplyWindowDay) hivev1.SyncSetApplyWindow {
+		return hivev1.SyncSetApplyWindow{Start: start, Duration: metav1.Duration{Duration: duration}, Days: days}
+	}
+	cases := []struct {
+		name         string
+		windows      []hivev1.SyncSetApplyWindow
+		expectedOpen bool
+		expectedNext time.Time
+	}{
+		{
+			name:         "daily window open",
+			windows:      []hivev1.SyncSetApplyWindow{window("12:00", time.Hour)},
+			expectedOpen: true,
+			expectedNext: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "daily window closed",
+			windows:      []hivev1.SyncSetApplyWindow{window("02:00", 4*time.Hour)},
+			expectedNext: time.Date(2026, 10, 16, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "window open since previous day",
+			windows:      []hivev1.SyncSetApplyWindow{window("22:00", 16*time.Hour, "Wednesday")},
+			expectedOpen: true,
+			expectedNext: time.Date(2026, 10, 21, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "weekend window",
+			windows:      []hivev1.SyncSetApplyWindow{window("00:00", 48*time.Hour, "Saturday")},
+			expectedNext: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "earliest of several windows",
+			windows: []hivev1.SyncSetApplyWindow{
+				window("03:00", time.Hour, "Monday"),
+				window("18:00", time.Hour, "Thursday"),
+			},
+			expectedNext: time.Date(2026, 10, 15, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "invalid start",
+			windows: []hivev1.SyncSetApplyWindow{window("noon", time.Hour)},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			open, next := applyWindowState(tc.windows, now)
+			assert.Equal(t, tc.expectedOpen, open, "unexpected open")
+			assert.Equal(t, tc.expectedNext, next, "unexpected next window")
+		})
+	}
+}
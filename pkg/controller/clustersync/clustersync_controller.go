@@ -25,6 +25,7 @@ import (
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -43,6 +44,7 @@ import (
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/remoteclient"
 	"github.com/openshift/hive/pkg/resource"
+	secretutils "github.com/openshift/hive/pkg/util/secrets"
 )
 
 const (
@@ -58,6 +60,10 @@ const (
 	metricResultSuccess    = "success"
 	metricResultError      = "error"
 	stsName                = "hive-clustersync"
+
+	// Reasons for the events emitted on ClusterDeployments
+	syncSetFailedReason    = "SyncSetFailed"
+	syncSetRecoveredReason = "SyncSetRecovered"
 )
 
 var (
@@ -114,7 +120,7 @@ func init() {
 // Add creates a new clustersync Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
 	if err != nil {
 		logger.WithError(err).Error("could not get controller configurations")
@@ -149,7 +155,7 @@ func Add(mgr manager.Manager) error {
 
 // NewReconciler returns a new ReconcileClusterSync
 func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (*ReconcileClusterSync, error) {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	reapplyInterval := defaultReapplyInterval
 	if envReapplyInterval := os.Getenv(reapplyIntervalEnvKey); len(envReapplyInterval) > 0 {
 		var err error
@@ -169,6 +175,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (*R
 		remoteClusterAPIClientBuilder: func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
 			return remoteclient.NewBuilder(c, cd, ControllerName)
 		},
+		eventRecorder: mgr.GetEventRecorderFor(ControllerName.String()),
 	}, nil
 }
 
@@ -182,7 +189,7 @@ func resourceHelperBuilderFunc(restConfig *rest.Config, fakeCluster bool, logger
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r *ReconcileClusterSync, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
-	c, err := controller.New("clusterSync-controller", mgr, controller.Options{
+	c, err := controllerutils.NewController("clusterSync-controller", mgr, controller.Options{
 		Reconciler:              r,
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
@@ -266,6 +273,9 @@ type ReconcileClusterSync struct {
 	// for the remote cluster's API server
 	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
 
+	// eventRecorder records events on the ClusterDeployments when the result of applying a syncset changes
+	eventRecorder record.EventRecorder
+
 	ordinalID int64
 }
 
@@ -335,6 +345,12 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
 	defer recobsrv.ObserveControllerReconcileTime()
 
+	// The requests made to the hub and the cloud during the reconcile are traced as part of its span.
+	ctx = recobsrv.StartSpan(ctx, request.NamespacedName)
+	traced := *r
+	traced.Client = controllerutils.TraceClient(ctx, r.Client)
+	r = &traced
+
 	recobsrv.SetOutcome(hivemetrics.ReconcileOutcomeNoOp)
 
 	// Fetch the ClusterDeployment instance
@@ -398,6 +414,7 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 		log.WithError(err).Error("cannot create helper")
 		return reconcile.Result{}, err
 	}
+	resourceHelper = resource.NewTracedHelper(ctx, resourceHelper)
 
 	needToCreateClusterSync := false
 	clusterSync := &hiveintv1alpha1.ClusterSync{}
@@ -449,13 +466,27 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 	}
 	recobsrv.SetOutcome(hivemetrics.ReconcileOutcomeFullSync)
 
+	// Outside of the apply windows of the cluster, changes to the syncsets wait for the next window. The initial sync
+	// of the cluster is not deferred, so that new clusters get their syncsets right away.
+	deferChanges := false
+	var nextApplyWindow time.Time
+	if len(cd.Spec.SyncSetApplyWindows) > 0 && clusterSync.Status.FirstSuccessTime != nil {
+		var open bool
+		open, nextApplyWindow = applyWindowState(cd.Spec.SyncSetApplyWindows, time.Now())
+		deferChanges = !open
+		if deferChanges {
+			logger.WithField("nextApplyWindow", nextApplyWindow).Debug("outside of apply windows, deferring changes to syncsets")
+		}
+	}
+
 	// Apply SyncSets
-	syncStatusesForSyncSets, syncSetsNeedRequeue := r.applySyncSets(
+	syncStatusesForSyncSets, pendingSyncSets, syncSetsNeedRequeue := r.applySyncSets(
 		cd,
 		"SyncSet",
 		syncSets,
 		clusterSync.Status.SyncSets,
 		needToDoFullReapply,
+		deferChanges,
 		false, // no need to report SelectorSyncSet metrics if we're reconciling non-selector SyncSets
 		resourceHelper,
 		logger,
@@ -463,18 +494,29 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 	clusterSync.Status.SyncSets = syncStatusesForSyncSets
 
 	// Apply SelectorSyncSets
-	syncStatusesForSelectorSyncSets, selectorSyncSetsNeedRequeue := r.applySyncSets(
+	syncStatusesForSelectorSyncSets, pendingSelectorSyncSets, selectorSyncSetsNeedRequeue := r.applySyncSets(
 		cd,
 		"SelectorSyncSet",
 		selectorSyncSets,
 		clusterSync.Status.SelectorSyncSets,
 		needToDoFullReapply,
+		deferChanges,
 		clusterSync.Status.FirstSuccessTime == nil, // only report SelectorSyncSet metrics if we haven't reached first success
 		resourceHelper,
 		logger,
 	)
 	clusterSync.Status.SelectorSyncSets = syncStatusesForSelectorSyncSets
 
+	clusterSync.Status.PendingSyncSets = pendingSyncSets
+	clusterSync.Status.PendingSelectorSyncSets = pendingSelectorSyncSets
+	hasPendingChanges := len(pendingSyncSets)+len(pendingSelectorSyncSets) > 0
+	switch {
+	case !hasPendingChanges || nextApplyWindow.IsZero():
+		clusterSync.Status.NextApplyWindowTime = nil
+	case clusterSync.Status.NextApplyWindowTime == nil || !clusterSync.Status.NextApplyWindowTime.Time.Equal(nextApplyWindow):
+		clusterSync.Status.NextApplyWindowTime = &metav1.Time{Time: nextApplyWindow}
+	}
+
 	setFailedCondition(clusterSync)
 
 	// Set clusterSync.Status.FirstSyncSetsSuccessTime
@@ -516,6 +558,11 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 	}
 
 	result := reconcile.Result{Requeue: true, RequeueAfter: r.timeUntilFullReapply(lease)}
+	if hasPendingChanges && !nextApplyWindow.IsZero() {
+		if untilWindow := time.Until(nextApplyWindow); untilWindow < result.RequeueAfter {
+			result.RequeueAfter = untilWindow
+		}
+	}
 	if syncSetsNeedRequeue || selectorSyncSetsNeedRequeue {
 		result.RequeueAfter = 0
 	}
@@ -528,10 +575,11 @@ func (r *ReconcileClusterSync) applySyncSets(
 	syncSets []CommonSyncSet,
 	syncStatuses []hiveintv1alpha1.SyncStatus,
 	needToDoFullReapply bool,
+	deferChanges bool,
 	reportSelectorSyncSetMetrics bool,
 	resourceHelper resource.Helper,
 	logger log.FieldLogger,
-) (newSyncStatuses []hiveintv1alpha1.SyncStatus, requeue bool) {
+) (newSyncStatuses []hiveintv1alpha1.SyncStatus, pending []string, requeue bool) {
 	// Sort the syncsets to a consistent ordering. This prevents thrashing in the ClusterSync status due to the order
 	// of the syncset status changing from one reconcile to the next.
 	sort.Slice(syncSets, func(i, j int) bool {
@@ -549,6 +597,19 @@ func (r *ReconcileClusterSync) applySyncSets(
 			syncStatuses = syncStatuses[:last]
 		}
 
+		// Changes wait for the next apply window. The parts of the syncset that did not change may still be re-applied.
+		if deferChanges && (indexOfOldStatus < 0 || oldSyncStatus.ObservedGeneration != syncSet.AsMetaObject().GetGeneration()) {
+			logger.Debug("deferring apply of changed syncset until the next apply window")
+			pending = append(pending, syncSet.AsMetaObject().GetName())
+			if indexOfOldStatus >= 0 {
+				if needToDoFullReapply {
+					r.reapplyUnchangedContent(syncSet, oldSyncStatus.AppliedContentHashes, resourceHelper, logger)
+				}
+				newSyncStatuses = append(newSyncStatuses, oldSyncStatus)
+			}
+			continue
+		}
+
 		// Determine if the syncset needs to be applied
 		switch {
 		case needToDoFullReapply:
@@ -566,11 +627,12 @@ func (r *ReconcileClusterSync) applySyncSets(
 		}
 
 		// Apply the syncset
-		resourcesApplied, resourcesInSyncSet, syncSetNeedsRequeue, err := r.applySyncSet(syncSet, resourceHelper, logger)
+		resourcesApplied, resourcesInSyncSet, resourcesProtected, syncSetNeedsRequeue, err := r.applySyncSet(syncSet, resourceHelper, logger)
 		newSyncStatus := hiveintv1alpha1.SyncStatus{
-			Name:               syncSet.AsMetaObject().GetName(),
-			ObservedGeneration: syncSet.AsMetaObject().GetGeneration(),
-			Result:             hiveintv1alpha1.SuccessSyncSetResult,
+			Name:                 syncSet.AsMetaObject().GetName(),
+			ObservedGeneration:   syncSet.AsMetaObject().GetGeneration(),
+			Result:               hiveintv1alpha1.SuccessSyncSetResult,
+			AppliedContentHashes: contentHashes(syncSet.GetSpec()),
 		}
 		applyMode := syncSet.GetSpec().ResourceApplyMode
 		if applyMode == hivev1.SyncResourceApplyMode {
@@ -590,12 +652,23 @@ func (r *ReconcileClusterSync) applySyncSets(
 		}
 
 		if indexOfOldStatus >= 0 {
+			removedFromSyncSet := func(r hiveintv1alpha1.SyncResourceReference) bool {
+				return !containsResource(resourcesInSyncSet, r)
+			}
+			// Prune-protected resources that are no longer included are left in the cluster and no longer tracked.
+			resourcesToDelete, skipped := skipPruneProtected(oldSyncStatus.ResourcesToDelete, oldSyncStatus.PruneProtectedResources, removedFromSyncSet)
+			logSkippedDeletions(skipped, logger)
+			for _, r := range oldSyncStatus.SkippedDeletions {
+				if removedFromSyncSet(r) {
+					newSyncStatus.SkippedDeletions = append(newSyncStatus.SkippedDeletions, r)
+				}
+			}
+			newSyncStatus.SkippedDeletions = mergeResources(newSyncStatus.SkippedDeletions, skipped)
+
 			// Delete any resources that were included in the syncset previously but are no longer included now.
 			remainingResources, err := deleteFromTargetCluster(
-				oldSyncStatus.ResourcesToDelete,
-				func(r hiveintv1alpha1.SyncResourceReference) bool {
-					return !containsResource(resourcesInSyncSet, r)
-				},
+				resourcesToDelete,
+				removedFromSyncSet,
 				resourceHelper,
 				logger,
 			)
@@ -613,7 +686,11 @@ func (r *ReconcileClusterSync) applySyncSets(
 			newSyncStatus.FirstSuccessTime = oldSyncStatus.FirstSuccessTime
 		}
 
-		// Update the last transition time if there were any changes to the sync status.
+		newSyncStatus.PruneProtectedResources = filterResources(resourcesProtected, newSyncStatus.ResourcesToDelete)
+
+		// Update the last transition time if there were any changes to the sync status. The content hashes are left
+		// out, as they only change along with the generation of the syncset, except when they are first recorded.
+		oldSyncStatus.AppliedContentHashes = newSyncStatus.AppliedContentHashes
 		if !reflect.DeepEqual(oldSyncStatus, newSyncStatus) {
 			newSyncStatus.LastTransitionTime = metav1.Now()
 		}
@@ -655,22 +732,39 @@ func (r *ReconcileClusterSync) applySyncSets(
 			}
 		}
 
+		r.recordSyncStatusEvent(cd, syncSetType, oldSyncStatus, newSyncStatus, indexOfOldStatus >= 0)
+
 		// Sort ResourcesToDelete to prevent update thrashing.
 		sort.Slice(newSyncStatus.ResourcesToDelete, func(i, j int) bool {
 			return orderResources(newSyncStatus.ResourcesToDelete[i], newSyncStatus.ResourcesToDelete[j])
 		})
+		sort.Slice(newSyncStatus.PruneProtectedResources, func(i, j int) bool {
+			return orderResources(newSyncStatus.PruneProtectedResources[i], newSyncStatus.PruneProtectedResources[j])
+		})
+		sort.Slice(newSyncStatus.SkippedDeletions, func(i, j int) bool {
+			return orderResources(newSyncStatus.SkippedDeletions[i], newSyncStatus.SkippedDeletions[j])
+		})
 		newSyncStatuses = append(newSyncStatuses, newSyncStatus)
 	}
 
 	// The remaining sync statuses in syncStatuses do not match any syncsets. Any resources to delete in the sync status
 	// need to be deleted.
 	for _, oldSyncStatus := range syncStatuses {
-		remainingResources, err := deleteFromTargetCluster(oldSyncStatus.ResourcesToDelete, nil, resourceHelper, logger)
+		if deferChanges && len(oldSyncStatus.ResourcesToDelete) > 0 {
+			logger.WithField(syncSetType, oldSyncStatus.Name).Debug("deferring deletion of resources of removed syncset until the next apply window")
+			pending = append(pending, oldSyncStatus.Name)
+			newSyncStatuses = append(newSyncStatuses, oldSyncStatus)
+			continue
+		}
+		resourcesToDelete, skipped := skipPruneProtected(oldSyncStatus.ResourcesToDelete, oldSyncStatus.PruneProtectedResources, nil)
+		logSkippedDeletions(skipped, logger.WithField(syncSetType, oldSyncStatus.Name))
+		remainingResources, err := deleteFromTargetCluster(resourcesToDelete, nil, resourceHelper, logger)
 		if err != nil {
 			requeue = true
 			newSyncStatus := hiveintv1alpha1.SyncStatus{
 				Name:               oldSyncStatus.Name,
 				ResourcesToDelete:  remainingResources,
+				SkippedDeletions:   mergeResources(oldSyncStatus.SkippedDeletions, skipped),
 				Result:             hiveintv1alpha1.FailureSyncSetResult,
 				FailureMessage:     err.Error(),
 				LastTransitionTime: oldSyncStatus.LastTransitionTime,
@@ -683,6 +777,7 @@ func (r *ReconcileClusterSync) applySyncSets(
 		}
 	}
 
+	sort.Strings(pending)
 	return
 }
 
@@ -695,6 +790,28 @@ func getOldSyncStatus(syncSet CommonSyncSet, syncSetStatuses []hiveintv1alpha1.S
 	return hiveintv1alpha1.SyncStatus{}, -1
 }
 
+// recordSyncStatusEvent records an event on the ClusterDeployment when a syncset fails to apply, or when the failure
+// message changes, and when a syncset that previously failed to apply is applied successfully.
+func (r *ReconcileClusterSync) recordSyncStatusEvent(
+	cd *hivev1.ClusterDeployment,
+	syncSetType string,
+	oldSyncStatus, newSyncStatus hiveintv1alpha1.SyncStatus,
+	hasOldSyncStatus bool,
+) {
+	oldFailed := hasOldSyncStatus && oldSyncStatus.Result == hiveintv1alpha1.FailureSyncSetResult
+	switch {
+	case newSyncStatus.Result == hiveintv1alpha1.FailureSyncSetResult:
+		if oldFailed && oldSyncStatus.FailureMessage == newSyncStatus.FailureMessage {
+			return
+		}
+		r.eventRecorder.Eventf(cd, corev1.EventTypeWarning, syncSetFailedReason,
+			"%s %s failed to apply: %s", syncSetType, newSyncStatus.Name, newSyncStatus.FailureMessage)
+	case oldFailed:
+		r.eventRecorder.Eventf(cd, corev1.EventTypeNormal, syncSetRecoveredReason,
+			"%s %s applied successfully", syncSetType, newSyncStatus.Name)
+	}
+}
+
 func (r *ReconcileClusterSync) applySyncSet(
 	syncSet CommonSyncSet,
 	resourceHelper resource.Helper,
@@ -702,12 +819,14 @@ func (r *ReconcileClusterSync) applySyncSet(
 ) (
 	resourcesApplied []hiveintv1alpha1.SyncResourceReference,
 	resourcesInSyncSet []hiveintv1alpha1.SyncResourceReference,
+	resourcesProtected []hiveintv1alpha1.SyncResourceReference,
 	requeue bool,
 	returnErr error,
 ) {
 	resources, referencesToResources, decodeErr := decodeResources(syncSet, logger)
 	referencesToSecrets := referencesToSecrets(syncSet)
 	resourcesInSyncSet = append(referencesToResources, referencesToSecrets...)
+	resourcesProtected = pruneProtectedResources(syncSet, resources, referencesToResources, referencesToSecrets)
 	if decodeErr != nil {
 		returnErr = decodeErr
 		return
@@ -853,6 +972,10 @@ func (r *ReconcileClusterSync) applySecret(
 		Annotations: secret.Annotations,
 		Labels:      secret.Labels,
 	}
+	if err := secretutils.Transform(secret, secretMapping); err != nil {
+		logger.WithError(err).Warn("cannot transform secret")
+		return errors.Wrapf(err, "failed to transform secret %d", secretIndex), true
+	}
 	logger.Debug("applying secret")
 	if err := applyToTargetCluster(secret, applyFnMetricsLabel, applyFn, logger); err != nil {
 		return errors.Wrapf(err, "failed to apply secret %d", secretIndex), true
@@ -947,6 +1070,16 @@ func deleteFromTargetCluster(
 	return remainingResources, utilerrors.NewAggregate(allErrs)
 }
 
+func logSkippedDeletions(resources []hiveintv1alpha1.SyncResourceReference, logger log.FieldLogger) {
+	for _, r := range resources {
+		logger.WithField("resourceNamespace", r.Namespace).
+			WithField("resourceName", r.Name).
+			WithField("resourceAPIVersion", r.APIVersion).
+			WithField("resourceKind", r.Kind).
+			Info("leaving prune-protected resource in cluster")
+	}
+}
+
 func (r *ReconcileClusterSync) getSyncSetsForClusterDeployment(cd *hivev1.ClusterDeployment, logger log.FieldLogger) ([]CommonSyncSet, error) {
 	syncSetsList := &hivev1.SyncSetList{}
 	if err := r.List(context.Background(), syncSetsList, client.InNamespace(cd.Namespace)); err != nil {
@@ -958,6 +1091,10 @@ func (r *ReconcileClusterSync) getSyncSetsForClusterDeployment(cd *hivev1.Cluste
 		if !doesSyncSetApplyToClusterDeployment(&ss, cd) {
 			continue
 		}
+		if ss.Spec.ApplyAfterClaim && isUnclaimedPoolCluster(cd) {
+			logger.WithField("syncSet", ss.Name).Debug("skipping SyncSet until the cluster is claimed")
+			continue
+		}
 		syncSets = append(syncSets, (*SyncSetAsCommon)(&syncSetsList.Items[i]))
 	}
 	return syncSets, nil
@@ -974,6 +1111,10 @@ func (r *ReconcileClusterSync) getSelectorSyncSetsForClusterDeployment(cd *hivev
 		if !doesSelectorSyncSetApplyToClusterDeployment(&sss, cd, logger) {
 			continue
 		}
+		if sss.Spec.ApplyAfterClaim && isUnclaimedPoolCluster(cd) {
+			logger.WithField("selectorSyncSet", sss.Name).Debug("skipping SelectorSyncSet until the cluster is claimed")
+			continue
+		}
 		selectorSyncSets = append(selectorSyncSets, (*SelectorSyncSetAsCommon)(&selectorSyncSetsList.Items[i]))
 	}
 	return selectorSyncSets, nil
@@ -997,6 +1138,11 @@ func doesSelectorSyncSetApplyToClusterDeployment(selectorSyncSet *hivev1.Selecto
 	return labelSelector.Matches(labels.Set(cd.Labels))
 }
 
+// isUnclaimedPoolCluster returns true if the ClusterDeployment is from a ClusterPool and has not been claimed.
+func isUnclaimedPoolCluster(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.ClusterPoolRef != nil && cd.Spec.ClusterPoolRef.ClaimName == ""
+}
+
 func setFailedCondition(clusterSync *hiveintv1alpha1.ClusterSync) {
 	status := corev1.ConditionFalse
 	reason := "Success"
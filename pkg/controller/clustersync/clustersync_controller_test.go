@@ -23,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -65,6 +66,7 @@ type reconcileTest struct {
 	mockCtrl                *gomock.Controller
 	mockResourceHelper      *resourcemock.MockHelper
 	mockRemoteClientBuilder *remoteclientmock.MockBuilder
+	recorder                *record.FakeRecorder
 	expectedFailedMessage   string
 
 	// A zero LastTransitionTime indicates that the time should be set to now.
@@ -72,9 +74,14 @@ type reconcileTest struct {
 	expectedSyncSetStatuses         []hiveintv1alpha1.SyncStatus
 	expectedSelectorSyncSetStatuses []hiveintv1alpha1.SyncStatus
 
+	expectedPendingSyncSets         []string
+	expectedPendingSelectorSyncSets []string
+
 	expectUnchangedLeaseRenewTime bool
 	expectRequeue                 bool
 	expectNoWorkDone              bool
+	// maxRequeueAfter, when set, replaces the expected requeue after the full reapply interval.
+	maxRequeueAfter time.Duration
 }
 
 func newReconcileTest(t *testing.T, mockCtrl *gomock.Controller, scheme *runtime.Scheme, existing ...runtime.Object) *reconcileTest {
@@ -85,6 +92,7 @@ func newReconcileTest(t *testing.T, mockCtrl *gomock.Controller, scheme *runtime
 
 	mockResourceHelper := resourcemock.NewMockHelper(mockCtrl)
 	mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+	recorder := record.NewFakeRecorder(100)
 
 	r := &ReconcileClusterSync{
 		ordinalID:       0,
@@ -97,6 +105,7 @@ func newReconcileTest(t *testing.T, mockCtrl *gomock.Controller, scheme *runtime
 		remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder {
 			return mockRemoteClientBuilder
 		},
+		eventRecorder: recorder,
 	}
 
 	return &reconcileTest{
@@ -106,6 +115,7 @@ func newReconcileTest(t *testing.T, mockCtrl *gomock.Controller, scheme *runtime
 		mockCtrl:                mockCtrl,
 		mockResourceHelper:      mockResourceHelper,
 		mockRemoteClientBuilder: mockRemoteClientBuilder,
+		recorder:                recorder,
 	}
 }
 
@@ -149,6 +159,9 @@ func (rt *reconcileTest) run(t *testing.T) {
 	assert.True(t, result.Requeue, "expected requeue to be true")
 	if rt.expectRequeue {
 		assert.Zero(t, result.RequeueAfter, "unexpected requeue after")
+	} else if rt.maxRequeueAfter != 0 {
+		assert.Greater(t, int64(result.RequeueAfter), int64(0), "requeue after too small")
+		assert.LessOrEqual(t, int64(result.RequeueAfter), int64(rt.maxRequeueAfter), "requeue after too large")
 	} else {
 		var minRequeueAfter, maxRequeueAfter float64
 		if rt.expectUnchangedLeaseRenewTime {
@@ -215,6 +228,13 @@ func (rt *reconcileTest) run(t *testing.T) {
 
 	areSyncStatusesEqual(t, "syncset", rt.expectedSyncSetStatuses, clusterSync.Status.SyncSets, startTime, endTime)
 	areSyncStatusesEqual(t, "selectorsyncset", rt.expectedSelectorSyncSetStatuses, clusterSync.Status.SelectorSyncSets, startTime, endTime)
+	assert.Equal(t, rt.expectedPendingSyncSets, clusterSync.Status.PendingSyncSets, "unexpected pending syncsets")
+	assert.Equal(t, rt.expectedPendingSelectorSyncSets, clusterSync.Status.PendingSelectorSyncSets, "unexpected pending selectorsyncsets")
+	if len(rt.expectedPendingSyncSets)+len(rt.expectedPendingSelectorSyncSets) > 0 {
+		assert.NotNil(t, clusterSync.Status.NextApplyWindowTime, "expected next apply window time")
+	} else {
+		assert.Nil(t, clusterSync.Status.NextApplyWindowTime, "unexpected next apply window time")
+	}
 }
 
 func areSyncStatusesEqual(t *testing.T, syncSetType string, expectedStatuses, actualStatuses []hiveintv1alpha1.SyncStatus, startTime, endTime time.Time) {
@@ -234,6 +254,10 @@ func areSyncStatusesEqual(t *testing.T, syncSetType string, expectedStatuses, ac
 				*expectedStatuses[i].FirstSuccessTime = *actualStatuses[i].FirstSuccessTime
 			}
 		}
+		// The content hashes are only checked when expected.
+		if expectedStatus.AppliedContentHashes == nil {
+			expectedStatuses[i].AppliedContentHashes = actualStatuses[i].AppliedContentHashes
+		}
 	}
 	assert.Equalf(t, expectedStatuses, actualStatuses, "unexpected %s statuses", syncSetType)
 }
@@ -304,6 +328,10 @@ func TestReconcileClusterSync_NoWorkToDo(t *testing.T) {
 			name: "syncset pause",
 			cd:   cdBuilder(scheme).GenericOptions(testgeneric.WithAnnotation(constants.SyncsetPauseAnnotation, "true")).Build(),
 		},
+		{
+			name: "spec paused",
+			cd:   cdBuilder(scheme).Options(testcd.Paused()).Build(),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -606,6 +634,50 @@ func TestReconcileClusterSync_ApplySecret(t *testing.T) {
 	}
 }
 
+func TestReconcileClusterSync_ApplyTransformedSecret(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	secretMapping := testSecretMapping("test-secret", "dest-namespace", "dest-name")
+	secretMapping.Keys = []hivev1.SecretKeyMapping{{SourceKey: "username", TargetKey: "user"}}
+	secretMapping.Templates = []hivev1.SecretKeyTemplate{{
+		Key:      corev1.DockerConfigJsonKey,
+		Template: `{"auths":{"{{ .registry }}":{"auth":"{{ printf "%s:%s" .username .password | b64enc }}"}}}`,
+	}}
+	secretMapping.Type = corev1.SecretTypeDockerConfigJson
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithSecrets(secretMapping),
+	)
+	srcSecret := testsecret.FullBuilder(testNamespace, "test-secret", scheme).Build(
+		testsecret.WithDataKeyValue("registry", []byte("quay.io")),
+		testsecret.WithDataKeyValue("username", []byte("user")),
+		testsecret.WithDataKeyValue("password", []byte("pass")),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(),
+		clusterSyncBuilder(scheme).Build(),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		syncSet,
+		srcSecret)
+	secretToApply := testsecret.BasicBuilder().GenericOptions(
+		testgeneric.WithNamespace("dest-namespace"),
+		testgeneric.WithName("dest-name"),
+		testgeneric.WithTypeMeta(scheme),
+	).Build(
+		testsecret.WithType(corev1.SecretTypeDockerConfigJson),
+		testsecret.WithDataKeyValue("user", []byte("user")),
+		testsecret.WithDataKeyValue(corev1.DockerConfigJsonKey, []byte(`{"auths":{"quay.io":{"auth":"dXNlcjpwYXNz"}}}`)),
+	)
+	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(secretToApply)).Return(resource.CreatedApplyResult, nil)
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{newSyncStatusBuilder("test-syncset").Build()}
+	rt.run(t)
+}
+
 func TestReconcileClusterSync_ApplyPatch(t *testing.T) {
 	cases := []struct {
 		applyMode hivev1.SyncSetResourceApplyMode
@@ -967,6 +1039,154 @@ func TestReconcileClusterSync_ResourceRemovedFromSyncSet(t *testing.T) {
 	}
 }
 
+func TestReconcileClusterSync_PruneProtectedResourceRemovedFromSyncSet(t *testing.T) {
+	protectedConfigMap := func(namespace, name string) *corev1.ConfigMap {
+		cm := testConfigMap(namespace, name)
+		cm.Annotations = map[string]string{constants.PruneProtectAnnotation: "true"}
+		return cm
+	}
+	cases := []struct {
+		name                     string
+		resourceToApply          *corev1.ConfigMap
+		protectKinds             string
+		existingProtected        []hiveintv1alpha1.SyncResourceReference
+		existingSkippedDeletions []hiveintv1alpha1.SyncResourceReference
+		expectDelete             bool
+		expectedProtected        []hiveintv1alpha1.SyncResourceReference
+		expectedSkippedDeletions []hiveintv1alpha1.SyncResourceReference
+	}{
+		{
+			name:            "not protected",
+			resourceToApply: testConfigMap("dest-namespace", "retained-resource"),
+			expectDelete:    true,
+		},
+		{
+			name:              "protected by annotation",
+			resourceToApply:   protectedConfigMap("dest-namespace", "retained-resource"),
+			existingProtected: []hiveintv1alpha1.SyncResourceReference{testConfigMapRef("dest-namespace", "deleted-resource")},
+			expectedProtected: []hiveintv1alpha1.SyncResourceReference{testConfigMapRef("dest-namespace", "retained-resource")},
+			expectedSkippedDeletions: []hiveintv1alpha1.SyncResourceReference{
+				testConfigMapRef("dest-namespace", "deleted-resource"),
+			},
+		},
+		{
+			name:              "protected by kind",
+			resourceToApply:   testConfigMap("dest-namespace", "retained-resource"),
+			protectKinds:      "Secret, ConfigMap",
+			existingProtected: []hiveintv1alpha1.SyncResourceReference{testConfigMapRef("dest-namespace", "deleted-resource")},
+			expectedProtected: []hiveintv1alpha1.SyncResourceReference{testConfigMapRef("dest-namespace", "retained-resource")},
+			expectedSkippedDeletions: []hiveintv1alpha1.SyncResourceReference{
+				testConfigMapRef("dest-namespace", "deleted-resource"),
+			},
+		},
+		{
+			name:              "protection removed",
+			resourceToApply:   testConfigMap("dest-namespace", "retained-resource"),
+			existingProtected: []hiveintv1alpha1.SyncResourceReference{testConfigMapRef("dest-namespace", "retained-resource")},
+			expectDelete:      true,
+		},
+		{
+			name:              "skipped deletion re-added",
+			resourceToApply:   testConfigMap("dest-namespace", "retained-resource"),
+			existingProtected: []hiveintv1alpha1.SyncResourceReference{testConfigMapRef("dest-namespace", "deleted-resource")},
+			existingSkippedDeletions: []hiveintv1alpha1.SyncResourceReference{
+				testConfigMapRef("dest-namespace", "other-resource"),
+				testConfigMapRef("dest-namespace", "retained-resource"),
+			},
+			expectedSkippedDeletions: []hiveintv1alpha1.SyncResourceReference{
+				testConfigMapRef("dest-namespace", "deleted-resource"),
+				testConfigMapRef("dest-namespace", "other-resource"),
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			syncSetOptions := []testsyncset.Option{
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(2),
+				testsyncset.WithResources(tc.resourceToApply),
+				testsyncset.WithApplyMode(hivev1.SyncResourceApplyMode),
+			}
+			if tc.protectKinds != "" {
+				syncSetOptions = append(syncSetOptions, testsyncset.Generic(testgeneric.WithAnnotation(constants.PruneProtectKindsAnnotation, tc.protectKinds)))
+			}
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(syncSetOptions...)
+			existingSyncStatus := newSyncStatusBuilder("test-syncset").Build(
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withResourcesToDelete(
+					testConfigMapRef("dest-namespace", "deleted-resource"),
+					testConfigMapRef("dest-namespace", "retained-resource"),
+				),
+			)
+			existingSyncStatus.PruneProtectedResources = tc.existingProtected
+			existingSyncStatus.SkippedDeletions = tc.existingSkippedDeletions
+			clusterSync := clusterSyncBuilder(scheme).Build(testcs.WithSyncSetStatus(existingSyncStatus))
+			lease := buildSyncLease(time.Now().Add(-1 * time.Hour))
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet,
+				clusterSync,
+				lease)
+			rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(tc.resourceToApply)).Return(resource.CreatedApplyResult, nil)
+			if tc.expectDelete {
+				rt.mockResourceHelper.EXPECT().
+					Delete("v1", "ConfigMap", "dest-namespace", "deleted-resource").
+					Return(nil)
+			}
+			expectedSyncStatus := newSyncStatusBuilder("test-syncset").Build(
+				withObservedGeneration(2),
+				withFirstSuccessTimeInThePast(),
+				withResourcesToDelete(testConfigMapRef("dest-namespace", "retained-resource")),
+			)
+			expectedSyncStatus.PruneProtectedResources = tc.expectedProtected
+			expectedSyncStatus.SkippedDeletions = tc.expectedSkippedDeletions
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{expectedSyncStatus}
+			rt.expectUnchangedLeaseRenewTime = true
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_PruneProtectedResourceOfDeletedSyncSet(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	existingSyncStatus := newSyncStatusBuilder("test-syncset").Build(
+		withTransitionInThePast(),
+		withFirstSuccessTimeInThePast(),
+		withResourcesToDelete(
+			testConfigMapRef("dest-namespace", "deleted-resource"),
+			testConfigMapRef("dest-namespace", "protected-resource"),
+		),
+	)
+	existingSyncStatus.PruneProtectedResources = []hiveintv1alpha1.SyncResourceReference{
+		testConfigMapRef("dest-namespace", "protected-resource"),
+	}
+	clusterSync := clusterSyncBuilder(scheme).Build(testcs.WithSyncSetStatus(existingSyncStatus))
+	lease := buildSyncLease(time.Now().Add(-1 * time.Hour))
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		clusterSync,
+		lease)
+	rt.mockResourceHelper.EXPECT().
+		Delete("v1", "ConfigMap", "dest-namespace", "deleted-resource").
+		Return(nil)
+	rt.expectUnchangedLeaseRenewTime = true
+	rt.run(t)
+}
+
 func TestReconcileClusterSync_ErrorApplyingResource(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -1511,6 +1731,13 @@ func TestReconcileClusterSync_FailingSyncSetDoesNotBlockOtherSyncSets(t *testing
 			}
 			rt.expectRequeue = true
 			rt.run(t)
+			if assert.Len(t, rt.recorder.Events, 1, "expected a single event") {
+				assert.Equal(t,
+					fmt.Sprintf("Warning SyncSetFailed SyncSet test-syncset-%d failed to apply: failed to apply resource 0: test apply error", tc.failingSyncSet),
+					<-rt.recorder.Events,
+					"unexpected event",
+				)
+			}
 		})
 	}
 }
@@ -1905,6 +2132,243 @@ func TestReconcileClusterSync_IgnoreNotApplicableSyncSets(t *testing.T) {
 	rt.run(t)
 }
 
+func TestReconcileClusterSync_ApplyAfterClaim(t *testing.T) {
+	cases := []struct {
+		name         string
+		cdOptions    []testcd.Option
+		expectApply  bool
+		existingSync bool
+		expectDelete bool
+	}{
+		{
+			name: "not from pool",
+			cdOptions: []testcd.Option{
+				testcd.WithLabel("test-label-key", "test-label-value"),
+			},
+			expectApply: true,
+		},
+		{
+			name: "unclaimed",
+			cdOptions: []testcd.Option{
+				testcd.WithLabel("test-label-key", "test-label-value"),
+				testcd.WithUnclaimedClusterPoolReference(testNamespace, "test-pool"),
+			},
+		},
+		{
+			name: "claimed",
+			cdOptions: []testcd.Option{
+				testcd.WithLabel("test-label-key", "test-label-value"),
+				testcd.WithClusterPoolReference(testNamespace, "test-pool", "test-claim"),
+			},
+			expectApply: true,
+		},
+		{
+			name: "no longer claimed",
+			cdOptions: []testcd.Option{
+				testcd.WithLabel("test-label-key", "test-label-value"),
+				testcd.WithUnclaimedClusterPoolReference(testNamespace, "test-pool"),
+			},
+			existingSync: true,
+			expectDelete: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			syncSetResource := testConfigMap("dest-namespace", "resource-from-syncset")
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithApplyAfterClaim(),
+				testsyncset.WithApplyMode(hivev1.SyncResourceApplyMode),
+				testsyncset.WithResources(syncSetResource),
+			)
+			selectorSyncSetResource := testConfigMap("dest-namespace", "resource-from-selectorsyncset")
+			selectorSyncSet := testselectorsyncset.FullBuilder("test-selectorsyncset", scheme).Build(
+				testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+				testselectorsyncset.WithGeneration(1),
+				testselectorsyncset.WithApplyAfterClaim(),
+				testselectorsyncset.WithResources(selectorSyncSetResource),
+			)
+			clusterSync := clusterSyncBuilder(scheme).Build()
+			if tc.existingSync {
+				clusterSync = clusterSyncBuilder(scheme).Build(testcs.WithSyncSetStatus(
+					buildSyncStatus("test-syncset",
+						withResourcesToDelete(testConfigMapRef("dest-namespace", "resource-from-syncset")),
+						withTransitionInThePast(),
+						withFirstSuccessTimeInThePast(),
+					),
+				))
+			}
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(tc.cdOptions...),
+				clusterSync,
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet,
+				selectorSyncSet,
+			)
+			if tc.expectApply {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(syncSetResource)).Return(resource.CreatedApplyResult, nil)
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(selectorSyncSetResource)).Return(resource.CreatedApplyResult, nil)
+				rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+					withResourcesToDelete(testConfigMapRef("dest-namespace", "resource-from-syncset")),
+				)}
+				rt.expectedSelectorSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-selectorsyncset")}
+			}
+			if tc.expectDelete {
+				rt.mockResourceHelper.EXPECT().Delete("v1", "ConfigMap", "dest-namespace", "resource-from-syncset").Return(nil)
+			}
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_ApplyWindows(t *testing.T) {
+	now := time.Now().UTC()
+	openWindow := hivev1.SyncSetApplyWindow{
+		Start:    now.Add(-time.Hour).Format("15:04"),
+		Duration: metav1.Duration{Duration: 2 * time.Hour},
+	}
+	closedWindow := hivev1.SyncSetApplyWindow{
+		Start:    now.Add(2 * time.Hour).Format("15:04"),
+		Duration: metav1.Duration{Duration: time.Hour},
+	}
+	cases := []struct {
+		name        string
+		window      hivev1.SyncSetApplyWindow
+		initialSync bool
+		fullReapply bool
+		expectApply bool
+	}{
+		{
+			name:        "window open",
+			window:      openWindow,
+			expectApply: true,
+		},
+		{
+			name:   "window closed",
+			window: closedWindow,
+		},
+		{
+			name:        "window closed during initial sync",
+			window:      closedWindow,
+			initialSync: true,
+			expectApply: true,
+		},
+		{
+			name:        "window closed with full reapply",
+			window:      closedWindow,
+			fullReapply: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			newResource := testConfigMap("dest-namespace", "new-resource")
+			newSyncSet := testsyncset.FullBuilder(testNamespace, "new-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithResources(newResource),
+			)
+			// The changed syncset keeps one of its resources, and replaces the other.
+			keptResource := testConfigMap("dest-namespace", "kept-resource")
+			changedResource := testConfigMap("dest-namespace", "changed-resource")
+			changedSyncSet := testsyncset.FullBuilder(testNamespace, "changed-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(2),
+				testsyncset.WithResources(keptResource, changedResource),
+			)
+			appliedChangedSyncSet := testsyncset.FullBuilder(testNamespace, "changed-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithResources(keptResource, testConfigMap("dest-namespace", "replaced-resource")),
+			)
+			unchangedResource := testConfigMap("dest-namespace", "unchanged-resource")
+			unchangedSyncSet := testsyncset.FullBuilder(testNamespace, "unchanged-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithResources(unchangedResource),
+			)
+			newSelectorResource := testConfigMap("dest-namespace", "new-selector-resource")
+			newSelectorSyncSet := testselectorsyncset.FullBuilder("new-selectorsyncset", scheme).Build(
+				testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+				testselectorsyncset.WithGeneration(1),
+				testselectorsyncset.WithResources(newSelectorResource),
+			)
+			changedStatus := buildSyncStatus("changed-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast())
+			changedStatus.AppliedContentHashes = contentHashes((*SyncSetAsCommon)(appliedChangedSyncSet).GetSpec())
+			unchangedStatus := buildSyncStatus("unchanged-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast())
+			unchangedStatus.AppliedContentHashes = contentHashes((*SyncSetAsCommon)(unchangedSyncSet).GetSpec())
+			removedStatus := buildSyncStatus("removed-syncset",
+				withResourcesToDelete(testConfigMapRef("dest-namespace", "removed-resource")),
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+			)
+			cd := cdBuilder(scheme).Build(testcd.WithLabel("test-label-key", "test-label-value"))
+			cd.Spec.SyncSetApplyWindows = []hivev1.SyncSetApplyWindow{tc.window}
+			firstSuccessTime := testcs.WithFirstSuccessTime(now.Add(-24 * time.Hour))
+			if tc.initialSync {
+				firstSuccessTime = testcs.WithNoFirstSuccessTime()
+			}
+			renewTime := time.Now().Add(-1 * time.Hour)
+			if tc.fullReapply {
+				renewTime = time.Now().Add(-3 * time.Hour)
+			}
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cd,
+				clusterSyncBuilder(scheme).Build(
+					testcs.WithSyncSetStatus(changedStatus),
+					testcs.WithSyncSetStatus(unchangedStatus),
+					testcs.WithSyncSetStatus(removedStatus),
+					firstSuccessTime,
+				),
+				buildSyncLease(renewTime),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				newSyncSet,
+				changedSyncSet,
+				unchangedSyncSet,
+				newSelectorSyncSet,
+			)
+			rt.expectUnchangedLeaseRenewTime = !tc.fullReapply
+			if tc.expectApply {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(newResource)).Return(resource.CreatedApplyResult, nil)
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(keptResource)).Return(resource.UnchangedApplyResult, nil)
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(changedResource)).Return(resource.CreatedApplyResult, nil)
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(newSelectorResource)).Return(resource.CreatedApplyResult, nil)
+				rt.mockResourceHelper.EXPECT().Delete("v1", "ConfigMap", "dest-namespace", "removed-resource").Return(nil)
+				rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{
+					buildSyncStatus("changed-syncset", withObservedGeneration(2), withFirstSuccessTimeInThePast()),
+					buildSyncStatus("new-syncset"),
+					unchangedStatus,
+				}
+				rt.expectedSyncSetStatuses[0].AppliedContentHashes = contentHashes((*SyncSetAsCommon)(changedSyncSet).GetSpec())
+				rt.expectedSelectorSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("new-selectorsyncset")}
+			} else {
+				if tc.fullReapply {
+					// Drift is corrected on the content that was applied before, but the changes wait for the window.
+					rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(keptResource)).Return(resource.UnchangedApplyResult, nil)
+					rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(unchangedResource)).Return(resource.UnchangedApplyResult, nil)
+				}
+				rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{changedStatus, unchangedStatus, removedStatus}
+				rt.expectedPendingSyncSets = []string{"changed-syncset", "new-syncset", "removed-syncset"}
+				rt.expectedPendingSelectorSyncSets = []string{"new-selectorsyncset"}
+				rt.maxRequeueAfter = 2 * time.Hour
+			}
+			rt.run(t)
+		})
+	}
+}
+
 func TestReconcileClusterSync_ApplySecretForSelectorSyncSet(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
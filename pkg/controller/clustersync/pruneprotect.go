@@ -0,0 +1,70 @@
+package clustersync
+
+import (
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// pruneProtectedResources returns the references to the resources and secrets of the syncset that are
+// prune-protected, either by the prune-protect annotation on the resource or by the prune-protect-kinds annotation on
+// the syncset. The resources and their references must be in the same order.
+func pruneProtectedResources(
+	syncSet CommonSyncSet,
+	resources []*unstructured.Unstructured,
+	referencesToResources []hiveintv1alpha1.SyncResourceReference,
+	referencesToSecrets []hiveintv1alpha1.SyncResourceReference,
+) (protected []hiveintv1alpha1.SyncResourceReference) {
+	kinds := sets.NewString()
+	for _, kind := range strings.Split(syncSet.AsMetaObject().GetAnnotations()[constants.PruneProtectKindsAnnotation], ",") {
+		if kind = strings.TrimSpace(kind); kind != "" {
+			kinds.Insert(kind)
+		}
+	}
+	for i, resource := range resources {
+		isProtected, _ := strconv.ParseBool(resource.GetAnnotations()[constants.PruneProtectAnnotation])
+		if isProtected || kinds.Has(referencesToResources[i].Kind) {
+			protected = append(protected, referencesToResources[i])
+		}
+	}
+	for _, r := range referencesToSecrets {
+		if kinds.Has(r.Kind) {
+			protected = append(protected, r)
+		}
+	}
+	return protected
+}
+
+// skipPruneProtected splits the resources to delete into the resources that are still tracked for deletion and the
+// prune-protected resources whose deletion is skipped. The deletion of a protected resource is skipped when
+// shouldDelete is nil or returns true for it.
+func skipPruneProtected(
+	resources []hiveintv1alpha1.SyncResourceReference,
+	protected []hiveintv1alpha1.SyncResourceReference,
+	shouldDelete func(hiveintv1alpha1.SyncResourceReference) bool,
+) (tracked, skipped []hiveintv1alpha1.SyncResourceReference) {
+	for _, r := range resources {
+		if containsResource(protected, r) && (shouldDelete == nil || shouldDelete(r)) {
+			skipped = append(skipped, r)
+			continue
+		}
+		tracked = append(tracked, r)
+	}
+	return tracked, skipped
+}
+
+// filterResources returns the resources that are in the filter.
+func filterResources(resources, filter []hiveintv1alpha1.SyncResourceReference) []hiveintv1alpha1.SyncResourceReference {
+	var filtered []hiveintv1alpha1.SyncResourceReference
+	for _, r := range resources {
+		if containsResource(filter, r) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/blang/semver/v4"
 	log "github.com/sirupsen/logrus"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -36,7 +37,7 @@ const (
 // Add creates a new ClusterDeployment Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
 	if err != nil {
 		logger.WithError(err).Error("could not get controller configurations")
@@ -60,7 +61,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
-	c, err := controller.New("clusterversion-controller", mgr, controller.Options{
+	c, err := controllerutils.NewController("clusterversion-controller", mgr, controller.Options{
 		Reconciler:              r,
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
@@ -110,6 +111,11 @@ func (r *ReconcileClusterVersion) Reconcile(ctx context.Context, request reconci
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, err
 	}
+
+	if controllerutils.IsClusterDeploymentPaused(cd, cdLog) {
+		return reconcile.Result{}, nil
+	}
+
 	// If the clusterdeployment is deleted, do not reconcile.
 	if cd.DeletionTimestamp != nil {
 		return reconcile.Result{}, nil
@@ -147,6 +153,10 @@ func (r *ReconcileClusterVersion) Reconcile(ctx context.Context, request reconci
 		return reconcile.Result{}, err
 	}
 
+	if err := r.updateClusterVersionStatus(cd, clusterVersion, cdLog); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	cdLog.Debug("reconcile complete")
 	return reconcile.Result{}, nil
 }
@@ -186,3 +196,23 @@ func (r *ReconcileClusterVersion) updateClusterVersionLabels(cd *hivev1.ClusterD
 	}
 	return nil
 }
+
+// updateClusterVersionStatus syncs the version history and the available updates of the remote cluster to the status
+// of the ClusterDeployment.
+func (r *ReconcileClusterVersion) updateClusterVersionStatus(cd *hivev1.ClusterDeployment, clusterVersion *openshiftapiv1.ClusterVersion, cdLog log.FieldLogger) error {
+	versionStatus := &hivev1.ClusterVersionStatus{
+		DesiredVersion:   clusterVersion.Status.Desired.Version,
+		History:          clusterVersion.Status.History,
+		AvailableUpdates: clusterVersion.Status.AvailableUpdates,
+	}
+	if apiequality.Semantic.DeepEqual(cd.Status.ClusterVersion, versionStatus) {
+		cdLog.Debug("cluster version status has not changed, nothing to update")
+		return nil
+	}
+	cd.Status.ClusterVersion = versionStatus
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating cluster deployment cluster version status")
+		return err
+	}
+	return nil
+}
@@ -78,6 +78,48 @@ func TestClusterVersionReconcile(t *testing.T) {
 				assert.Equal(t, "2.3.4", cd.Labels[constants.VersionMajorMinorPatchLabel], "unexpected version major-minor-patch label")
 			},
 		},
+		{
+			name: "version status synced",
+			existing: []runtime.Object{
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				if assert.NotNil(t, cd.Status.ClusterVersion, "expected cluster version status") {
+					assert.Equal(t, "2.3.4+somebuild", cd.Status.ClusterVersion.DesiredVersion, "unexpected desired version")
+					if assert.Len(t, cd.Status.ClusterVersion.History, 1, "unexpected version history") {
+						assert.Equal(t, testRemoteClusterCurrentVersion, cd.Status.ClusterVersion.History[0].Version, "unexpected version in history")
+					}
+					if assert.Len(t, cd.Status.ClusterVersion.AvailableUpdates, 1, "unexpected available updates") {
+						assert.Equal(t, "2.3.5", cd.Status.ClusterVersion.AvailableUpdates[0].Version, "unexpected available update")
+					}
+				}
+			},
+		},
+		{
+			name: "stale version status replaced",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Status.ClusterVersion = &hivev1.ClusterVersionStatus{
+						DesiredVersion: "2.3.3",
+						AvailableUpdates: []configv1.Update{
+							{Version: "2.3.4"},
+							{Version: "2.3.5"},
+						},
+					}
+					return cd
+				}(),
+				testKubeconfigSecret(),
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				if assert.NotNil(t, cd.Status.ClusterVersion, "expected cluster version status") {
+					assert.Equal(t, "2.3.4+somebuild", cd.Status.ClusterVersion.DesiredVersion, "unexpected desired version")
+					assert.Len(t, cd.Status.ClusterVersion.History, 1, "unexpected version history")
+					assert.Len(t, cd.Status.ClusterVersion.AvailableUpdates, 1, "unexpected available updates")
+				}
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -210,6 +252,12 @@ func testRemoteClusterVersionStatus() *configv1.ClusterVersionStatus {
 				CompletionTime: &zeroTime,
 			},
 		},
+		AvailableUpdates: []configv1.Update{
+			{
+				Version: "2.3.5",
+				Image:   "TESTUPDATEIMAGE",
+			},
+		},
 		ObservedGeneration: 123456789,
 		VersionHash:        "TESTVERSIONHASH",
 	}
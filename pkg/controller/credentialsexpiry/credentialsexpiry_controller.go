@@ -0,0 +1,342 @@
+package credentialsexpiry
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	ControllerName = hivev1.CredentialsExpiryControllerName
+
+	// defaultWarningPeriod is the duration before the expiry of a certificate at which the CredentialsExpiringSoon
+	// condition is set, unless configured in HiveConfig.
+	defaultWarningPeriod = 30 * 24 * time.Hour
+
+	// checkInterval is the interval at which the certificates of a cluster are inspected. The serving certificate
+	// of the API is rotated by the cluster and must be inspected periodically.
+	checkInterval = time.Hour
+
+	// dialTimeout is the timeout to connect to the API of the cluster to retrieve its serving certificate.
+	dialTimeout = 30 * time.Second
+
+	credentialAdminKubeconfig = "admin_kubeconfig_client_certificate"
+	credentialAPIServing      = "api_serving_certificate"
+)
+
+var (
+	metricCredentialsExpiry = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_cluster_deployment_credentials_expiry_timestamp_seconds",
+		Help: "Expiry of the credentials of a cluster deployment, as seconds since the epoch.",
+	}, []string{"namespace", "cluster_deployment", "credential"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricCredentialsExpiry)
+}
+
+// Add creates a new CredentialsExpiry Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := controllerutils.ControllerLogEntry(ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	warningPeriod := defaultWarningPeriod
+	if envWarningPeriod := os.Getenv(constants.CredentialsExpiryWarningPeriodEnvVar); envWarningPeriod != "" {
+		warningPeriod, err = time.ParseDuration(envWarningPeriod)
+		if err != nil {
+			logger.WithError(err).WithField("warningPeriod", envWarningPeriod).Errorf("unable to parse %s", constants.CredentialsExpiryWarningPeriodEnvVar)
+			return err
+		}
+	}
+	logger.WithField("warningPeriod", warningPeriod).Info("credentials expiry warning period set")
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter, warningPeriod), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter, warningPeriod time.Duration) reconcile.Reconciler {
+	r := &ReconcileCredentialsExpiry{
+		Client:                     controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		warningPeriod:              warningPeriod,
+		servingCertificateNotAfter: servingCertificateNotAfter,
+	}
+	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	// Create a new controller
+	c, err := controllerutils.NewController("credentialsexpiry-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileCredentialsExpiry{}
+
+// ReconcileCredentialsExpiry monitors the expiry of the credentials of a ClusterDeployment
+type ReconcileCredentialsExpiry struct {
+	client.Client
+
+	// warningPeriod is the duration before the expiry of a certificate at which the CredentialsExpiringSoon
+	// condition is set.
+	warningPeriod time.Duration
+
+	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server
+	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+
+	// servingCertificateNotAfter is a function pointer to the function that retrieves the expiry of the serving
+	// certificate of the API server of the REST config.
+	servingCertificateNotAfter func(cfg *rest.Config) (time.Time, error)
+}
+
+// credentialExpiry is the expiry of a credential of a cluster.
+type credentialExpiry struct {
+	description string
+	notAfter    time.Time
+}
+
+// Reconcile inspects the expiry of the client certificate of the admin kubeconfig and of the serving certificate of
+// the API of the cluster of a ClusterDeployment, exposes them as metrics, and sets the CredentialsExpiringSoon
+// condition when one of them expires within the warning period.
+func (r *ReconcileCredentialsExpiry) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	// Fetch the ClusterDeployment instance
+	cd := &hivev1.ClusterDeployment{}
+	err := r.Get(context.TODO(), request.NamespacedName, cd)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Object not found, clear its metrics.
+			clearMetrics(request.NamespacedName)
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	if controllerutils.IsClusterDeploymentPaused(cd, cdLog) {
+		return reconcile.Result{}, nil
+	}
+
+	// If the clusterdeployment is deleted, do not reconcile.
+	if cd.DeletionTimestamp != nil {
+		clearMetrics(request.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+
+	// If the cluster is not installed, do not reconcile.
+	if !cd.Spec.Installed {
+		cdLog.Debug("cluster installation is not complete")
+		return reconcile.Result{}, nil
+	}
+
+	if cd.Spec.ClusterMetadata == nil {
+		cdLog.Error("installed cluster with no cluster metadata")
+		return reconcile.Result{}, nil
+	}
+
+	var expiries []credentialExpiry
+
+	notAfter, err := r.adminKubeconfigClientCertificateNotAfter(cd)
+	switch {
+	case err != nil:
+		cdLog.WithError(err).Error("error inspecting the client certificate of the admin kubeconfig")
+		return reconcile.Result{}, err
+	case notAfter == nil:
+		cdLog.Debug("admin kubeconfig does not use a client certificate")
+		metricCredentialsExpiry.DeleteLabelValues(cd.Namespace, cd.Name, credentialAdminKubeconfig)
+	default:
+		metricCredentialsExpiry.WithLabelValues(cd.Namespace, cd.Name, credentialAdminKubeconfig).Set(float64(notAfter.Unix()))
+		expiries = append(expiries, credentialExpiry{description: "client certificate of the admin kubeconfig", notAfter: *notAfter})
+	}
+
+	// The serving certificate can only be retrieved from a reachable cluster. The last expiry observed is kept in
+	// the meantime.
+	if unreachable, _ := remoteclient.Unreachable(cd); unreachable || controllerutils.IsFakeCluster(cd) {
+		cdLog.Debug("skipping the serving certificate of an unreachable or fake cluster")
+	} else {
+		cfg, err := r.remoteClusterAPIClientBuilder(cd).RESTConfig()
+		if err != nil {
+			cdLog.WithError(err).Error("error building the REST config for the cluster")
+			return reconcile.Result{}, err
+		}
+		servingNotAfter, err := r.servingCertificateNotAfter(cfg)
+		if err != nil {
+			cdLog.WithError(err).Error("error retrieving the serving certificate of the API of the cluster")
+			return reconcile.Result{}, err
+		}
+		metricCredentialsExpiry.WithLabelValues(cd.Namespace, cd.Name, credentialAPIServing).Set(float64(servingNotAfter.Unix()))
+		expiries = append(expiries, credentialExpiry{description: "serving certificate of the API", notAfter: servingNotAfter})
+	}
+
+	if err := r.setExpiringSoonCondition(cd, expiries, cdLog); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	cdLog.Debug("reconcile complete")
+	return reconcile.Result{RequeueAfter: checkInterval}, nil
+}
+
+// setExpiringSoonCondition sets the CredentialsExpiringSoon condition of the ClusterDeployment from the expiries of
+// its credentials.
+func (r *ReconcileCredentialsExpiry) setExpiringSoonCondition(cd *hivev1.ClusterDeployment, expiries []credentialExpiry, cdLog log.FieldLogger) error {
+	now := time.Now()
+	var expired, expiringSoon []string
+	for _, e := range expiries {
+		switch {
+		case !now.Before(e.notAfter):
+			expired = append(expired, fmt.Sprintf("%s expired at %s", e.description, e.notAfter.UTC().Format(time.RFC3339)))
+		case e.notAfter.Sub(now) < r.warningPeriod:
+			expiringSoon = append(expiringSoon, fmt.Sprintf("%s expires at %s", e.description, e.notAfter.UTC().Format(time.RFC3339)))
+		}
+	}
+	status, reason, message := corev1.ConditionFalse, hivev1.CredentialsValidReason, "Credentials are not expiring soon"
+	switch {
+	case len(expired) > 0:
+		status, reason, message = corev1.ConditionTrue, hivev1.CredentialsExpiredReason, strings.Join(append(expired, expiringSoon...), "; ")
+	case len(expiringSoon) > 0:
+		status, reason, message = corev1.ConditionTrue, hivev1.CredentialsExpiringSoonReason, strings.Join(expiringSoon, "; ")
+	}
+	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.CredentialsExpiringSoonClusterDeploymentCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	if status == corev1.ConditionTrue {
+		cdLog.WithField("reason", reason).Warn(message)
+	}
+	cd.Status.Conditions = conds
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating credentials expiring soon condition")
+		return err
+	}
+	return nil
+}
+
+// adminKubeconfigClientCertificateNotAfter returns the expiry of the client certificate of the current context of the
+// admin kubeconfig of the ClusterDeployment, or nil if the kubeconfig does not use a client certificate.
+func (r *ReconcileCredentialsExpiry) adminKubeconfigClientCertificateNotAfter(cd *hivev1.ClusterDeployment) (*time.Time, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name}, secret); err != nil {
+		return nil, errors.Wrap(err, "could not get admin kubeconfig secret")
+	}
+	config, err := clientcmd.Load(secret.Data[constants.KubeconfigSecretKey])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load admin kubeconfig")
+	}
+	kubeContext, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return nil, errors.Errorf("admin kubeconfig has no context %q", config.CurrentContext)
+	}
+	authInfo, ok := config.AuthInfos[kubeContext.AuthInfo]
+	if !ok || len(authInfo.ClientCertificateData) == 0 {
+		return nil, nil
+	}
+	block, _ := pem.Decode(authInfo.ClientCertificateData)
+	if block == nil {
+		return nil, errors.New("could not decode the client certificate of the admin kubeconfig")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse the client certificate of the admin kubeconfig")
+	}
+	return &cert.NotAfter, nil
+}
+
+// servingCertificateNotAfter connects to the API server of the REST config and returns the expiry of its serving
+// certificate.
+func servingCertificateNotAfter(cfg *rest.Config) (time.Time, error) {
+	tlsConfig, err := rest.TLSConfigFor(cfg)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "could not build TLS config")
+	}
+	if tlsConfig == nil {
+		return time.Time{}, errors.Errorf("API server %s does not use TLS", cfg.Host)
+	}
+	apiURL, err := url.Parse(cfg.Host)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "could not parse API URL")
+	}
+	host := apiURL.Host
+	if apiURL.Port() == "" {
+		host = net.JoinHostPort(apiURL.Hostname(), "443")
+	}
+	tlsConfig.ServerName = apiURL.Hostname()
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: dialTimeout}, "tcp", host, tlsConfig)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "could not connect to the API server")
+	}
+	defer conn.Close()
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return time.Time{}, errors.New("API server presented no certificate")
+	}
+	return certs[0].NotAfter, nil
+}
+
+// clearMetrics removes the metrics of a ClusterDeployment.
+func clearMetrics(cd types.NamespacedName) {
+	metricCredentialsExpiry.DeleteLabelValues(cd.Namespace, cd.Name, credentialAdminKubeconfig)
+	metricCredentialsExpiry.DeleteLabelValues(cd.Namespace, cd.Name, credentialAPIServing)
+}
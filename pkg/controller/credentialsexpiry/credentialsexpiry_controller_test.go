@@ -0,0 +1,274 @@
+package credentialsexpiry
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+)
+
+const (
+	testName            = "foo"
+	testNamespace       = "default"
+	adminKubeconfigName = "foo-admin-kubeconfig"
+	testServer          = "https://api.foo.example.com:6443"
+	testWarningPeriod   = 30 * 24 * time.Hour
+)
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestCredentialsExpiryReconcile(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	notInstalled := func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.Installed = false
+	}
+	unreachable := func(cd *hivev1.ClusterDeployment) {
+		cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{{
+			Type:   hivev1.UnreachableCondition,
+			Status: corev1.ConditionTrue,
+		}}
+	}
+	paused := func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.Paused = true
+	}
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name              string
+		cd                *hivev1.ClusterDeployment
+		clientCertExpiry  *time.Duration
+		servingCertExpiry time.Duration
+		noRemoteCall      bool
+		expectNoCondition bool
+		expectedStatus    corev1.ConditionStatus
+		expectedReason    string
+	}{
+		{
+			name:              "not installed",
+			cd:                testClusterDeployment(notInstalled),
+			noRemoteCall:      true,
+			expectNoCondition: true,
+		},
+		{
+			name:              "paused",
+			cd:                testClusterDeployment(paused),
+			noRemoteCall:      true,
+			expectNoCondition: true,
+		},
+		{
+			name:              "credentials valid",
+			cd:                testClusterDeployment(),
+			clientCertExpiry:  durationPtr(365 * day),
+			servingCertExpiry: 90 * day,
+			expectedStatus:    corev1.ConditionFalse,
+			expectedReason:    hivev1.CredentialsValidReason,
+		},
+		{
+			name:              "token kubeconfig",
+			cd:                testClusterDeployment(),
+			servingCertExpiry: 90 * day,
+			expectedStatus:    corev1.ConditionFalse,
+			expectedReason:    hivev1.CredentialsValidReason,
+		},
+		{
+			name:              "client certificate expiring soon",
+			cd:                testClusterDeployment(),
+			clientCertExpiry:  durationPtr(10 * day),
+			servingCertExpiry: 90 * day,
+			expectedStatus:    corev1.ConditionTrue,
+			expectedReason:    hivev1.CredentialsExpiringSoonReason,
+		},
+		{
+			name:              "serving certificate expiring soon",
+			cd:                testClusterDeployment(),
+			clientCertExpiry:  durationPtr(365 * day),
+			servingCertExpiry: 2 * day,
+			expectedStatus:    corev1.ConditionTrue,
+			expectedReason:    hivev1.CredentialsExpiringSoonReason,
+		},
+		{
+			name:              "client certificate expired",
+			cd:                testClusterDeployment(),
+			clientCertExpiry:  durationPtr(-day),
+			servingCertExpiry: 2 * day,
+			expectedStatus:    corev1.ConditionTrue,
+			expectedReason:    hivev1.CredentialsExpiredReason,
+		},
+		{
+			name:             "unreachable",
+			cd:               testClusterDeployment(unreachable),
+			clientCertExpiry: durationPtr(10 * day),
+			noRemoteCall:     true,
+			expectedStatus:   corev1.ConditionTrue,
+			expectedReason:   hivev1.CredentialsExpiringSoonReason,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			existing := []runtime.Object{test.cd, testAdminKubeconfigSecret(t, test.clientCertExpiry)}
+			fakeClient := fake.NewFakeClient(existing...)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			if !test.noRemoteCall {
+				mockRemoteClientBuilder.EXPECT().RESTConfig().Return(&rest.Config{Host: testServer}, nil)
+			}
+			r := &ReconcileCredentialsExpiry{
+				Client:                        fakeClient,
+				warningPeriod:                 testWarningPeriod,
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+				servingCertificateNotAfter: func(cfg *rest.Config) (time.Time, error) {
+					assert.Equal(t, testServer, cfg.Host, "unexpected API server")
+					return time.Now().Add(test.servingCertExpiry), nil
+				},
+			}
+
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName},
+			})
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, cd))
+			cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.CredentialsExpiringSoonClusterDeploymentCondition)
+			if test.expectNoCondition {
+				assert.Nil(t, cond, "unexpected credentials expiring soon condition")
+				return
+			}
+			if assert.NotNil(t, cond, "missing credentials expiring soon condition") {
+				assert.Equal(t, test.expectedStatus, cond.Status, "unexpected condition status")
+				assert.Equal(t, test.expectedReason, cond.Reason, "unexpected condition reason")
+			}
+		})
+	}
+}
+
+func TestServingCertificateNotAfter(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	serverCert := server.Certificate()
+	cfg := &rest.Config{
+		Host: server.URL,
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: serverCert.Raw}),
+		},
+	}
+	notAfter, err := servingCertificateNotAfter(cfg)
+	require.NoError(t, err, "unexpected error retrieving serving certificate")
+	assert.True(t, serverCert.NotAfter.Equal(notAfter), "unexpected serving certificate expiry")
+}
+
+func durationPtr(d time.Duration) *time.Duration {
+	return &d
+}
+
+func testClusterDeployment(opts ...func(*hivev1.ClusterDeployment)) *hivev1.ClusterDeployment {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+			UID:       types.UID("1234"),
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: testName,
+			Installed:   true,
+			ClusterMetadata: &hivev1.ClusterMetadata{
+				ClusterID:                "cluster-id",
+				InfraID:                  "infra-id",
+				AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: adminKubeconfigName},
+			},
+		},
+		Status: hivev1.ClusterDeploymentStatus{
+			Conditions: []hivev1.ClusterDeploymentCondition{{
+				Type:               hivev1.UnreachableCondition,
+				Status:             corev1.ConditionFalse,
+				LastProbeTime:      metav1.NewTime(time.Now()),
+				LastTransitionTime: metav1.NewTime(time.Now()),
+			}},
+		},
+	}
+	for _, o := range opts {
+		o(cd)
+	}
+	return cd
+}
+
+// testAdminKubeconfigSecret returns an admin kubeconfig secret authenticating with a client certificate expiring
+// after clientCertExpiry, or with a token when clientCertExpiry is nil.
+func testAdminKubeconfigSecret(t *testing.T, clientCertExpiry *time.Duration) *corev1.Secret {
+	user := `    token: admin-token
+`
+	if clientCertExpiry != nil {
+		user = `    client-certificate-data: ` + testClientCertificate(t, time.Now().Add(*clientCertExpiry)) + `
+`
+	}
+	kubeconfig := []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: cluster
+  cluster:
+    server: ` + testServer + `
+users:
+- name: admin
+  user:
+` + user + `contexts:
+- name: admin
+  context:
+    cluster: cluster
+    user: admin
+current-context: admin
+`)
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: adminKubeconfigName},
+		Data:       map[string][]byte{constants.KubeconfigSecretKey: kubeconfig},
+	}
+}
+
+// testClientCertificate returns a base64 encoded self-signed PEM certificate expiring at notAfter.
+func testClientCertificate(t *testing.T, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err, "unexpected error generating key")
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "system:admin"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err, "unexpected error creating certificate")
+	return base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
@@ -3,6 +3,8 @@ package dnsendpoint
 import (
 	"context"
 	"fmt"
+	"net"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -35,7 +37,7 @@ const (
 // Add creates a new DNSZone Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
 	if err != nil {
 		logger.WithError(err).Error("could not get controller configurations")
@@ -53,7 +55,7 @@ func Add(mgr manager.Manager) error {
 		return nil
 	}
 
-	ctrl, err := controller.New(
+	ctrl, err := controllerutils.NewController(
 		ControllerName.String(),
 		mgr,
 		controller.Options{
@@ -80,7 +82,7 @@ func Add(mgr manager.Manager) error {
 
 	if nameServerChangeNotifier != nil {
 		if err := ctrl.Watch(&source.Channel{Source: nameServerChangeNotifier}, &handler.EnqueueRequestForObject{}); err != nil {
-			log.WithField("controller", ControllerName).WithError(err).Error("unable to set up watch for name server changes")
+			controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("unable to set up watch for name server changes")
 			return err
 		}
 	}
@@ -96,14 +98,19 @@ type nameServerTool struct {
 func newReconciler(mgr manager.Manager, kubeClient client.Client) (*ReconcileDNSEndpoint, chan event.GenericEvent, error) {
 	nsTools := []nameServerTool{}
 
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 
 	reconciler := &ReconcileDNSEndpoint{
 		Client:          kubeClient,
 		scheme:          mgr.GetScheme(),
 		logger:          logger,
 		nameServerTools: nsTools,
+
+		parentLinkCheckInterval: parentLinkCheckInterval(logger),
 	}
+	resolvers := parentLinkResolvers()
+	reconciler.lookupNS = newResolversLookupNS(resolvers)
+	logger.WithField("interval", reconciler.parentLinkCheckInterval).WithField("resolvers", resolvers).Info("parent link check configuration")
 
 	managedDomains, err := manageddns.ReadManagedDomainsFile()
 	if err != nil {
@@ -155,6 +162,12 @@ type ReconcileDNSEndpoint struct {
 	scheme          *runtime.Scheme
 	logger          log.FieldLogger
 	nameServerTools []nameServerTool
+
+	// parentLinkCheckInterval is the interval at which the delegation of available zones is re-verified. The
+	// delegation is not verified when it is zero.
+	parentLinkCheckInterval time.Duration
+	// lookupNS resolves the NS records of a domain with the parent link resolvers.
+	lookupNS func(ctx context.Context, domain string) ([]*net.NS, error)
 }
 
 // Reconcile reads that state of the cluster for a DNSEndpoint object and makes changes based on the state read
@@ -243,7 +256,7 @@ func (r *ReconcileDNSEndpoint) Reconcile(ctx context.Context, request reconcile.
 	// NS needs to be created or updated
 	case !isDeleted && len(desiredNameServers) > 0:
 		dnsLog.Info("creating NS record")
-		if err := nsTool.queryClient.Create(rootDomain, fullDomain, desiredNameServers); err != nil {
+		if err := nsTool.queryClient.Create(ctx, rootDomain, fullDomain, desiredNameServers); err != nil {
 			dnsLog.WithError(err).Error("error creating NS record")
 			return reconcile.Result{}, err
 		}
@@ -254,7 +267,7 @@ func (r *ReconcileDNSEndpoint) Reconcile(ctx context.Context, request reconcile.
 	// there are no targets for the NS.
 	default:
 		dnsLog.Info("deleting NS record")
-		if err := nsTool.queryClient.Delete(rootDomain, fullDomain, currentNameServers); err != nil {
+		if err := nsTool.queryClient.Delete(ctx, rootDomain, fullDomain, currentNameServers); err != nil {
 			dnsLog.WithError(err).Error("error deleting NS record")
 			return reconcile.Result{}, err
 		}
@@ -276,8 +289,12 @@ func (r *ReconcileDNSEndpoint) Reconcile(ctx context.Context, request reconcile.
 			dnsLog.WithError(err).Log(controllerutils.LogLevel(err), "error deleting finalizer")
 			return reconcile.Result{}, err
 		}
+		return reconcile.Result{}, nil
 	}
 
+	if parentLinkCreated {
+		return r.checkParentLink(instance, desiredNameServers, dnsLog)
+	}
 	return reconcile.Result{}, nil
 }
 
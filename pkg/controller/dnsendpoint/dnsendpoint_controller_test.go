@@ -77,7 +77,7 @@ func TestDNSEndpointReconcile(t *testing.T) {
 				rootDomain: nameServersMap{},
 			},
 			configureQuery: func(mockQuery *mock.MockQuery) {
-				mockQuery.EXPECT().Create(rootDomain, dnsName, sets.NewString("test-value-1", "test-value-2", "test-value-3")).Return(nil)
+				mockQuery.EXPECT().Create(gomock.Any(), rootDomain, dnsName, sets.NewString("test-value-1", "test-value-2", "test-value-3")).Return(nil)
 			},
 			expectedNameServers: rootDomainsMap{
 				rootDomain: nameServersMap{
@@ -134,7 +134,7 @@ func TestDNSEndpointReconcile(t *testing.T) {
 				},
 			},
 			configureQuery: func(mockQuery *mock.MockQuery) {
-				mockQuery.EXPECT().Create(rootDomain, dnsName, sets.NewString("test-value-1", "test-value-2", "test-value-3")).Return(nil)
+				mockQuery.EXPECT().Create(gomock.Any(), rootDomain, dnsName, sets.NewString("test-value-1", "test-value-2", "test-value-3")).Return(nil)
 			},
 			expectedNameServers: rootDomainsMap{
 				rootDomain: nameServersMap{
@@ -164,7 +164,7 @@ func TestDNSEndpointReconcile(t *testing.T) {
 				},
 			},
 			configureQuery: func(mockQuery *mock.MockQuery) {
-				mockQuery.EXPECT().Delete(rootDomain, dnsName, sets.NewString("test-value-1", "test-value-2", "test-value-3")).Return(nil)
+				mockQuery.EXPECT().Delete(gomock.Any(), rootDomain, dnsName, sets.NewString("test-value-1", "test-value-2", "test-value-3")).Return(nil)
 			},
 			expectedNameServers: rootDomainsMap{
 				rootDomain: nameServersMap{},
@@ -177,7 +177,7 @@ func TestDNSEndpointReconcile(t *testing.T) {
 				rootDomain: nameServersMap{},
 			},
 			configureQuery: func(mockQuery *mock.MockQuery) {
-				mockQuery.EXPECT().Delete(rootDomain, dnsName, nil).Return(nil)
+				mockQuery.EXPECT().Delete(gomock.Any(), rootDomain, dnsName, nil).Return(nil)
 			},
 			expectedNameServers: rootDomainsMap{
 				rootDomain: nameServersMap{},
@@ -190,7 +190,7 @@ func TestDNSEndpointReconcile(t *testing.T) {
 				rootDomain: nameServersMap{},
 			},
 			configureQuery: func(mockQuery *mock.MockQuery) {
-				mockQuery.EXPECT().Create(rootDomain, dnsName, sets.NewString("test-value-1", "test-value-2", "test-value-3")).
+				mockQuery.EXPECT().Create(gomock.Any(), rootDomain, dnsName, sets.NewString("test-value-1", "test-value-2", "test-value-3")).
 					Return(errors.New("create error"))
 			},
 			expectErr: true,
@@ -211,7 +211,7 @@ func TestDNSEndpointReconcile(t *testing.T) {
 				rootDomain: nameServersMap{},
 			},
 			configureQuery: func(mockQuery *mock.MockQuery) {
-				mockQuery.EXPECT().Delete(rootDomain, dnsName, nil).
+				mockQuery.EXPECT().Delete(gomock.Any(), rootDomain, dnsName, nil).
 					Return(errors.New("delete error"))
 			},
 			expectErr: true,
@@ -290,7 +290,7 @@ func TestDNSEndpointReconcile(t *testing.T) {
 				},
 			},
 			configureQuery: func(mockQuery *mock.MockQuery) {
-				mockQuery.EXPECT().Delete(rootDomain, dnsName, sets.NewString("test-value-1", "test-value-2", "test-value-3")).Return(nil)
+				mockQuery.EXPECT().Delete(gomock.Any(), rootDomain, dnsName, sets.NewString("test-value-1", "test-value-2", "test-value-3")).Return(nil)
 			},
 			expectedNameServers: rootDomainsMap{
 				rootDomain: nameServersMap{},
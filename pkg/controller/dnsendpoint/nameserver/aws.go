@@ -1,59 +1,86 @@
 package nameserver
 
 import (
+	"context"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/pkg/errors"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
+// awsZoneIDs caches the IDs of the public hosted zones of domains, which do not change for the life of the zones.
+var awsZoneIDs = controllerutils.NewExpiringCache("aws_public_zone_id", 10*time.Minute, 1000)
+
+// errNoPublicZone is returned when loading the ID of a domain without a public hosted zone, so that the absence of a
+// zone is not cached.
+var errNoPublicZone = errors.New("no public hosted zone found for domain")
+
 // NewAWSQuery creates a new name server query for AWS.
 func NewAWSQuery(c client.Client, credsSecretName string, region string) Query {
 	return &awsQuery{
 		getAWSClient: func() (awsclient.Client, error) {
-			awsClient, err := awsclient.NewClient(c, credsSecretName, controllerutils.GetHiveNamespace(), region)
+			awsClient, err := awsclient.New(c, awsclient.Options{
+				Controller: hivev1.DNSEndpointControllerName,
+				Region:     region,
+				CredentialsSource: awsclient.CredentialsSource{
+					Secret: &awsclient.SecretCredentialsSource{
+						Namespace: controllerutils.GetHiveNamespace(),
+						Ref:       &corev1.LocalObjectReference{Name: credsSecretName},
+					},
+				},
+			})
 			return awsClient, errors.Wrap(err, "error creating AWS client")
 		},
+		zoneIDs:      awsZoneIDs,
+		zoneIDPrefix: fmt.Sprintf("%s/%s", credsSecretName, region),
 	}
 }
 
 type awsQuery struct {
 	getAWSClient func() (awsclient.Client, error)
+	// zoneIDs caches the IDs of the hosted zones. The keys are the domains prefixed with zoneIDPrefix, as the zones
+	// visible depend on the credentials used.
+	zoneIDs      *controllerutils.ExpiringCache
+	zoneIDPrefix string
 }
 
 var _ Query = (*awsQuery)(nil)
 
 // Get implements Query.Get.
-func (q *awsQuery) Get(domain string) (map[string]sets.String, error) {
+func (q *awsQuery) Get(ctx context.Context, domain string) (map[string]sets.String, error) {
 	awsClient, err := q.getAWSClient()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get AWS client")
 	}
-	zoneID, err := q.queryZoneID(awsClient, domain)
+	zoneID, err := q.cachedZoneID(ctx, awsClient, domain)
 	if err != nil {
 		return nil, errors.Wrap(err, "error querying zone ID")
 	}
 	if zoneID == nil {
 		return nil, nil
 	}
-	currentNameServers, err := q.queryNameServers(awsClient, *zoneID)
+	currentNameServers, err := q.queryNameServers(ctx, awsClient, *zoneID)
 	return currentNameServers, errors.Wrap(err, "error querying name servers")
 }
 
 // Create implements Query.Create.
-func (q *awsQuery) Create(rootDomain string, domain string, values sets.String) error {
+func (q *awsQuery) Create(ctx context.Context, rootDomain string, domain string, values sets.String) error {
 	awsClient, err := q.getAWSClient()
 	if err != nil {
 		return errors.Wrap(err, "failed to get AWS client")
 	}
-	zoneID, err := q.queryZoneID(awsClient, rootDomain)
+	zoneID, err := q.cachedZoneID(ctx, awsClient, rootDomain)
 	if err != nil {
 		return errors.Wrap(err, "error querying zone ID")
 	}
@@ -61,18 +88,18 @@ func (q *awsQuery) Create(rootDomain string, domain string, values sets.String)
 		return errors.New("no public hosted zone found for domain")
 	}
 	return errors.Wrap(
-		q.changeNameServers(awsClient, *zoneID, domain, values, route53.ChangeActionUpsert),
+		q.changeNameServers(ctx, awsClient, *zoneID, domain, values, route53.ChangeActionUpsert),
 		"error creating the name server",
 	)
 }
 
 // Delete implements Query.Delete.
-func (q *awsQuery) Delete(rootDomain string, domain string, values sets.String) error {
+func (q *awsQuery) Delete(ctx context.Context, rootDomain string, domain string, values sets.String) error {
 	awsClient, err := q.getAWSClient()
 	if err != nil {
 		return errors.Wrap(err, "failed to get AWS client")
 	}
-	zoneID, err := q.queryZoneID(awsClient, rootDomain)
+	zoneID, err := q.cachedZoneID(ctx, awsClient, rootDomain)
 	if err != nil {
 		return errors.Wrap(err, "error querying zone ID")
 	}
@@ -82,7 +109,7 @@ func (q *awsQuery) Delete(rootDomain string, domain string, values sets.String)
 	if len(values) != 0 {
 		// If values were provided for the name servers, attempt to perform a
 		// delete using those values.
-		err = q.changeNameServers(awsClient, *zoneID, domain, values, route53.ChangeActionDelete)
+		err = q.changeNameServers(ctx, awsClient, *zoneID, domain, values, route53.ChangeActionDelete)
 		awsErr, ok := err.(awserr.Error)
 		if !ok || awsErr.Code() != route53.ErrCodeInvalidChangeBatch {
 			return errors.Wrap(err, "error deleting the name server")
@@ -96,7 +123,7 @@ func (q *awsQuery) Delete(rootDomain string, domain string, values sets.String)
 	}
 	// Since we do not have up-to-date values for the name servers, we need
 	// to query AWS for the current values to use them in the delete.
-	values, err = q.queryNameServer(awsClient, *zoneID, domain)
+	values, err = q.queryNameServer(ctx, awsClient, *zoneID, domain)
 	if err != nil {
 		return errors.Wrap(err, "error querying the current values of the name server")
 	}
@@ -104,13 +131,31 @@ func (q *awsQuery) Delete(rootDomain string, domain string, values sets.String)
 		return nil
 	}
 	return errors.Wrap(
-		q.changeNameServers(awsClient, *zoneID, domain, values, route53.ChangeActionDelete),
+		q.changeNameServers(ctx, awsClient, *zoneID, domain, values, route53.ChangeActionDelete),
 		"error deleting the name server with recently read values",
 	)
 }
 
+// cachedZoneID returns the ID of the public hosted zone for the specified domain, querying AWS if it is not cached.
+func (q *awsQuery) cachedZoneID(ctx context.Context, awsClient awsclient.Client, domain string) (*string, error) {
+	zoneID, err := q.zoneIDs.GetOrLoad(fmt.Sprintf("%s/%s", q.zoneIDPrefix, domain), func() (interface{}, error) {
+		zoneID, err := q.queryZoneID(ctx, awsClient, domain)
+		if err == nil && zoneID == nil {
+			return nil, errNoPublicZone
+		}
+		return zoneID, err
+	})
+	switch {
+	case err == errNoPublicZone:
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return zoneID.(*string), nil
+}
+
 // queryZoneID queries AWS for the public hosted zone for the specified domain.
-func (q *awsQuery) queryZoneID(awsClient awsclient.Client, domain string) (*string, error) {
+func (q *awsQuery) queryZoneID(ctx context.Context, awsClient awsclient.Client, domain string) (*string, error) {
 	maxItems := "5"
 	domain = controllerutils.Dotted(domain)
 	listInput := &route53.ListHostedZonesByNameInput{
@@ -118,7 +163,7 @@ func (q *awsQuery) queryZoneID(awsClient awsclient.Client, domain string) (*stri
 		MaxItems: &maxItems,
 	}
 	for {
-		listOutput, err := awsClient.ListHostedZonesByName(listInput)
+		listOutput, err := awsClient.ListHostedZonesByName(ctx, listInput)
 		if err != nil {
 			return nil, err
 		}
@@ -140,7 +185,7 @@ func (q *awsQuery) queryZoneID(awsClient awsclient.Client, domain string) (*stri
 }
 
 // queryNameServers queries AWS for the name servers in the specified hosted zone.
-func (q *awsQuery) queryNameServers(awsClient awsclient.Client, hostedZoneID string) (map[string]sets.String, error) {
+func (q *awsQuery) queryNameServers(ctx context.Context, awsClient awsclient.Client, hostedZoneID string) (map[string]sets.String, error) {
 	nameServers := map[string]sets.String{}
 	maxItems := "100"
 	listInput := &route53.ListResourceRecordSetsInput{
@@ -148,7 +193,7 @@ func (q *awsQuery) queryNameServers(awsClient awsclient.Client, hostedZoneID str
 		MaxItems:     &maxItems,
 	}
 	for {
-		listOutput, err := awsClient.ListResourceRecordSets(listInput)
+		listOutput, err := awsClient.ListResourceRecordSets(ctx, listInput)
 		if err != nil {
 			return nil, err
 		}
@@ -175,10 +220,10 @@ func (q *awsQuery) queryNameServers(awsClient awsclient.Client, hostedZoneID str
 }
 
 // queryNameServer queries AWS for the name servers in the specified hosted zone for the specified domain.
-func (q *awsQuery) queryNameServer(awsClient awsclient.Client, hostedZoneID string, domain string) (sets.String, error) {
+func (q *awsQuery) queryNameServer(ctx context.Context, awsClient awsclient.Client, hostedZoneID string, domain string) (sets.String, error) {
 	maxItems := "1"
 	recordType := route53.RRTypeNs
-	listOutput, err := awsClient.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+	listOutput, err := awsClient.ListResourceRecordSets(ctx, &route53.ListResourceRecordSetsInput{
 		HostedZoneId:    &hostedZoneID,
 		MaxItems:        &maxItems,
 		StartRecordName: &domain,
@@ -208,7 +253,7 @@ func (q *awsQuery) queryNameServer(awsClient awsclient.Client, hostedZoneID stri
 }
 
 // changeNameServers changes the name servers for the specified domain in the specified hosted zone.
-func (q *awsQuery) changeNameServers(awsClient awsclient.Client, hostedZoneID string, domain string, values sets.String, action string) error {
+func (q *awsQuery) changeNameServers(ctx context.Context, awsClient awsclient.Client, hostedZoneID string, domain string, values sets.String, action string) error {
 	recordType := route53.RRTypeNs
 	ttl := int64(60)
 	records := make([]*route53.ResourceRecord, 0, len(values))
@@ -230,6 +275,6 @@ func (q *awsQuery) changeNameServers(awsClient awsclient.Client, hostedZoneID st
 			}},
 		},
 	}
-	_, err := awsClient.ChangeResourceRecordSets(changeInput)
+	_, err := awsClient.ChangeResourceRecordSets(ctx, changeInput)
 	return err
 }
@@ -1,6 +1,7 @@
 package nameserver
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"os"
@@ -34,13 +35,13 @@ type LiveAWSTestSuite struct {
 }
 
 func (s *LiveAWSTestSuite) TestGetForNonExistentZone() {
-	nameServers, err := s.getCUT().Get("non-existent.zone.live-aws-test.com")
+	nameServers, err := s.getCUT().Get(context.TODO(), "non-existent.zone.live-aws-test.com")
 	s.NoError(err, "expected no error")
 	s.Empty(nameServers, "expected no name servers")
 }
 
 func (s *LiveAWSTestSuite) TestGetForExistentZone() {
-	nameServers, err := s.getCUT().Get(s.rootDomain)
+	nameServers, err := s.getCUT().Get(context.TODO(), s.rootDomain)
 	s.NoError(err, "expected no error")
 	s.NotEmpty(nameServers, "expected some name servers")
 	s.Len(nameServers[s.rootDomain], 4, "expected NS to have 4 values")
@@ -82,14 +83,14 @@ func (s *LiveAWSTestSuite) TestCreateAndDelete() {
 			cut := s.getCUT()
 			domain := fmt.Sprintf("live-aws-test-%08d.%s", rand.Intn(100000000), s.rootDomain)
 			s.T().Logf("domain = %q", domain)
-			err := cut.Create(s.rootDomain, domain, sets.NewString(tc.createValues...))
+			err := cut.Create(context.TODO(), s.rootDomain, domain, sets.NewString(tc.createValues...))
 			if s.NoError(err, "unexpected error creating NS") {
 				defer func() {
-					err := cut.Delete(s.rootDomain, domain, sets.NewString(tc.deleteValues...))
+					err := cut.Delete(context.TODO(), s.rootDomain, domain, sets.NewString(tc.deleteValues...))
 					s.NoError(err, "unexpected error deleting NS")
 				}()
 			}
-			nameServers, err := cut.Get(s.rootDomain)
+			nameServers, err := cut.Get(context.TODO(), s.rootDomain)
 			s.NoError(err, "unexpected error querying domain")
 			s.NotEmpty(nameServers, "expected some name servers")
 			actualValues := nameServers[domain]
@@ -113,7 +114,7 @@ func (s *LiveAWSTestSuite) TestDeleteOfNonExistentNS() {
 	}
 	for _, tc := range cases {
 		s.T().Run(tc.name, func(t *testing.T) {
-			err := s.getCUT().Delete(s.rootDomain, fmt.Sprintf("non-existent.subdomain.%s", s.rootDomain), sets.NewString(tc.deleteValues...))
+			err := s.getCUT().Delete(context.TODO(), s.rootDomain, fmt.Sprintf("non-existent.subdomain.%s", s.rootDomain), sets.NewString(tc.deleteValues...))
 			s.NoError(err, "expected no error")
 		})
 	}
@@ -1,6 +1,7 @@
 package nameserver
 
 import (
+	"context"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/route53"
@@ -191,7 +192,7 @@ func TestAWSGet(t *testing.T) {
 					in.HostedZoneId = pointer.StringPtr("next-hosted-zone-id")
 				}
 				mockAWSClient.EXPECT().
-					ListHostedZonesByName(gomock.Eq(in)).
+					ListHostedZonesByName(gomock.Any(), gomock.Eq(in)).
 					Return(out, nil)
 			}
 			for i, out := range tc.listResourceRecordSetsOutputs {
@@ -204,10 +205,10 @@ func TestAWSGet(t *testing.T) {
 					in.StartRecordType = pointer.StringPtr("next-record-type")
 				}
 				mockAWSClient.EXPECT().
-					ListResourceRecordSets(gomock.Eq(in)).
+					ListResourceRecordSets(gomock.Any(), gomock.Eq(in)).
 					Return(out, nil)
 			}
-			actualNameServers, err := awsQuery.Get("test-domain")
+			actualNameServers, err := awsQuery.Get(context.TODO(), "test-domain")
 			assert.NoError(t, err, "expected no error from querying")
 			if len(tc.expectedNameServers) == 0 {
 				assert.Empty(t, actualNameServers, "expected no name servers")
@@ -13,6 +13,8 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	"github.com/openshift/hive/pkg/azureclient"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
@@ -37,7 +39,7 @@ func NewAzureQuery(c client.Client, credsSecretName string, resourceGroupName st
 			); err != nil {
 				return nil, errors.Wrap(err, "could not get the creds secret")
 			}
-			azureClient, err := azureclient.NewClientFromSecret(credsSecret)
+			azureClient, err := azureclient.NewClientFromSecretWithOptions(credsSecret, hivev1azure.PublicCloud, azureclient.Options{Controller: hivev1.DNSEndpointControllerName})
 			return azureClient, errors.Wrap(err, "error creating Azure client")
 		},
 		resourceGroupName: resourceGroupName,
@@ -52,48 +54,48 @@ type azureQuery struct {
 var _ Query = (*azureQuery)(nil)
 
 // Get implements Query.Get.
-func (q *azureQuery) Get(domain string) (map[string]sets.String, error) {
+func (q *azureQuery) Get(ctx context.Context, domain string) (map[string]sets.String, error) {
 	azureClient, err := q.getAzureClient()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get azure client")
 	}
-	currentNameServers, err := q.queryNameServers(azureClient, domain)
+	currentNameServers, err := q.queryNameServers(ctx, azureClient, domain)
 	return currentNameServers, errors.Wrap(err, "error querying name servers")
 }
 
 // Create implements Query.Create.
-func (q *azureQuery) Create(rootDomain string, domain string, values sets.String) error {
+func (q *azureQuery) Create(ctx context.Context, rootDomain string, domain string, values sets.String) error {
 	azureClient, err := q.getAzureClient()
 	if err != nil {
 		return errors.Wrap(err, "failed to get Azure client")
 	}
 
-	return errors.Wrap(q.createNameServers(azureClient, rootDomain, domain, values), "error creating the name server")
+	return errors.Wrap(q.createNameServers(ctx, azureClient, rootDomain, domain, values), "error creating the name server")
 }
 
 // Delete implements Query.Delete.
-func (q *azureQuery) Delete(rootDomain string, domain string, values sets.String) error {
+func (q *azureQuery) Delete(ctx context.Context, rootDomain string, domain string, values sets.String) error {
 	azureClient, err := q.getAzureClient()
 	if err != nil {
 		return errors.Wrap(err, "failed to get Azure client")
 	}
 
-	return errors.Wrap(q.deleteNameServers(azureClient, rootDomain, domain),
+	return errors.Wrap(q.deleteNameServers(ctx, azureClient, rootDomain, domain),
 		"error deleting the name servers",
 	)
 }
 
 // deleteNameServers deletes the name servers for the specified domain in the specified managed zone.
-func (q *azureQuery) deleteNameServers(azureClient azureclient.Client, rootDomain string, domain string) error {
-	ctx, cancel := contextWithTimeout(context.TODO())
+func (q *azureQuery) deleteNameServers(ctx context.Context, azureClient azureclient.Client, rootDomain string, domain string) error {
+	ctx, cancel := contextWithTimeout(ctx)
 	defer cancel()
 
 	return azureClient.DeleteRecordSet(ctx, q.resourceGroupName, rootDomain, q.getRelativeDomain(rootDomain, domain), dns.NS)
 }
 
 // createNameServers creates the name servers for the specified domain in the specified managed zone.
-func (q *azureQuery) createNameServers(azureClient azureclient.Client, rootDomain string, domain string, values sets.String) error {
-	ctx, cancel := contextWithTimeout(context.TODO())
+func (q *azureQuery) createNameServers(ctx context.Context, azureClient azureclient.Client, rootDomain string, domain string, values sets.String) error {
+	ctx, cancel := contextWithTimeout(ctx)
 	defer cancel()
 
 	_, err := azureClient.CreateOrUpdateRecordSet(ctx, q.resourceGroupName, rootDomain, q.getRelativeDomain(rootDomain, domain), dns.NS, q.recordSet(values))
@@ -119,8 +121,8 @@ func (q *azureQuery) recordSet(values sets.String) dns.RecordSet {
 }
 
 // queryNameServers queries Azure for the name servers in the specified managed zone.
-func (q *azureQuery) queryNameServers(azureClient azureclient.Client, rootDomain string) (map[string]sets.String, error) {
-	ctx, cancel := contextWithTimeout(context.TODO())
+func (q *azureQuery) queryNameServers(ctx context.Context, azureClient azureclient.Client, rootDomain string) (map[string]sets.String, error) {
+	ctx, cancel := contextWithTimeout(ctx)
 	defer cancel()
 
 	nameServers := map[string]sets.String{}
@@ -1,6 +1,7 @@
 package nameserver
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	"github.com/openshift/hive/pkg/azureclient"
 	"github.com/openshift/hive/pkg/constants"
 )
@@ -42,13 +44,13 @@ type LiveAzureTestSuite struct {
 }
 
 func (s *LiveAzureTestSuite) TestGetForNonExistentZone() {
-	nameServers, err := s.getCUT().Get("non-existent.zone.live-azure.test.com")
+	nameServers, err := s.getCUT().Get(context.TODO(), "non-existent.zone.live-azure.test.com")
 	s.Error(err, "expected error")
 	s.Empty(nameServers, "expected no name servers")
 }
 
 func (s *LiveAzureTestSuite) TestGetForExistentZone() {
-	nameServers, err := s.getCUT().Get(s.rootDomain)
+	nameServers, err := s.getCUT().Get(context.TODO(), s.rootDomain)
 	s.NoError(err, "expected no error")
 	s.NotEmpty(nameServers, "expected some name servers")
 	s.Len(nameServers[s.rootDomain], 4, "expected NS to have 4 values")
@@ -117,7 +119,7 @@ func (s *LiveAzureTestSuite) TestDeleteOfNonExistentNS() {
 	}
 	for _, tc := range cases {
 		s.T().Run(tc.name, func(t *testing.T) {
-			err := s.getCUT().Delete(s.rootDomain, fmt.Sprintf("non-existent.subdomain.%s", s.rootDomain), sets.NewString(tc.deleteValues...))
+			err := s.getCUT().Delete(context.TODO(), s.rootDomain, fmt.Sprintf("non-existent.subdomain.%s", s.rootDomain), sets.NewString(tc.deleteValues...))
 			s.NoError(err, "expected no error")
 		})
 	}
@@ -127,15 +129,15 @@ func (s *LiveAzureTestSuite) testCreateAndDelete(tc *testCreateAndDeleteCase) {
 	cut := s.getCUT()
 	domain := fmt.Sprintf("live-azure-test-%08d.%s", rand.Intn(100000000), s.rootDomain)
 	s.T().Logf("domain = %q", domain)
-	err := cut.Create(s.rootDomain, domain, sets.NewString(tc.createValues...))
+	err := cut.Create(context.TODO(), s.rootDomain, domain, sets.NewString(tc.createValues...))
 	if s.NoError(err, "unexpected error creating NS") {
 		defer func() {
-			err := cut.Delete(s.rootDomain, domain, sets.NewString(tc.deleteValues...))
+			err := cut.Delete(context.TODO(), s.rootDomain, domain, sets.NewString(tc.deleteValues...))
 			s.NoError(err, "unexpected error deleting NS")
 		}()
 	}
 
-	nameServers, err := cut.Get(s.rootDomain)
+	nameServers, err := cut.Get(context.TODO(), s.rootDomain)
 	s.NoError(err, "unexpected error querying domain")
 	s.NotEmpty(nameServers, "expected some name servers")
 	actualValues := nameServers[domain]
@@ -146,19 +148,19 @@ func (s *LiveAzureTestSuite) testCreateThenUpdate(tc *testCreateThenUpdateCase)
 	cut := s.getCUT()
 	domain := fmt.Sprintf("live-azure-test-%08d.%s", rand.Intn(100000000), s.rootDomain)
 	s.T().Logf("domain = %q", domain)
-	err := cut.Create(s.rootDomain, domain, sets.NewString(tc.createValues...))
+	err := cut.Create(context.TODO(), s.rootDomain, domain, sets.NewString(tc.createValues...))
 	if s.NoError(err, "unexpected error creating NS") {
 		defer func() {
-			err := cut.Delete(s.rootDomain, domain, sets.NewString())
+			err := cut.Delete(context.TODO(), s.rootDomain, domain, sets.NewString())
 			s.NoError(err, "unexpected error deleting NS")
 		}()
 	}
 
 	// now test updating by re-issuing a Create()
-	err = cut.Create(s.rootDomain, domain, sets.NewString(tc.updateValues...))
+	err = cut.Create(context.TODO(), s.rootDomain, domain, sets.NewString(tc.updateValues...))
 	s.NoError(err, "unexpected error updating NS")
 
-	nameServers, err := cut.Get(s.rootDomain)
+	nameServers, err := cut.Get(context.TODO(), s.rootDomain)
 	s.NoError(err, "unexpected error querying domain")
 	s.NotEmpty(nameServers, "expected some name servers")
 	actualValues := nameServers[domain]
@@ -173,7 +175,7 @@ func (s *LiveAzureTestSuite) getCUT() *azureQuery {
 	credsFile := filepath.Join(usr.HomeDir, ".azure", constants.AzureCredentialsName)
 	return &azureQuery{
 		getAzureClient: func() (azureclient.Client, error) {
-			return azureclient.NewClientFromFile(credsFile)
+			return azureclient.NewClientFromFile(credsFile, hivev1azure.PublicCloud)
 		},
 		resourceGroupName: s.resourceGroupName,
 	}
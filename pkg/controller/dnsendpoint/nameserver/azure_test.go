@@ -1,6 +1,7 @@
 package nameserver
 
 import (
+	"context"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
@@ -88,7 +89,7 @@ func TestAzureGet(t *testing.T) {
 				mockListRecordSets(mockCtrl, mockAzureClient, out)
 			}
 
-			actualNameservers, err := azureQuery.Get("test-domain")
+			actualNameservers, err := azureQuery.Get(context.TODO(), "test-domain")
 			assert.NoError(t, err, "expected no error from querying")
 			if len(tc.expectedNameServers) == 0 {
 				assert.Empty(t, actualNameservers, "expected no name servers")
@@ -13,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	gcpclient "github.com/openshift/hive/pkg/gcpclient"
 )
@@ -29,7 +30,7 @@ func NewGCPQuery(c client.Client, credsSecretName string) Query {
 			); err != nil {
 				return nil, errors.Wrap(err, "could not get the creds secret")
 			}
-			gcpClient, err := gcpclient.NewClientFromSecret(credsSecret)
+			gcpClient, err := gcpclient.NewClientFromSecretWithOptions(credsSecret, gcpclient.Options{Controller: hivev1.DNSEndpointControllerName})
 			return gcpClient, errors.Wrap(err, "error creating GCP client")
 		},
 	}
@@ -42,29 +43,29 @@ type gcpQuery struct {
 var _ Query = (*gcpQuery)(nil)
 
 // Get implements Query.Get.
-func (q *gcpQuery) Get(domain string) (map[string]sets.String, error) {
+func (q *gcpQuery) Get(ctx context.Context, domain string) (map[string]sets.String, error) {
 	gcpClient, err := q.getGCPClient()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get GCP client")
 	}
-	zoneName, err := q.queryZoneName(gcpClient, domain)
+	zoneName, err := q.queryZoneName(ctx, gcpClient, domain)
 	if err != nil {
 		return nil, errors.Wrap(err, "error querying zone name")
 	}
 	if zoneName == "" {
 		return nil, nil
 	}
-	currentNameServers, err := q.queryNameServers(gcpClient, zoneName)
+	currentNameServers, err := q.queryNameServers(ctx, gcpClient, zoneName)
 	return currentNameServers, errors.Wrap(err, "error querying name servers")
 }
 
 // Create implements Query.Create.
-func (q *gcpQuery) Create(rootDomain string, domain string, values sets.String) error {
+func (q *gcpQuery) Create(ctx context.Context, rootDomain string, domain string, values sets.String) error {
 	gcpClient, err := q.getGCPClient()
 	if err != nil {
 		return errors.Wrap(err, "failed to get GCP client")
 	}
-	zoneName, err := q.queryZoneName(gcpClient, rootDomain)
+	zoneName, err := q.queryZoneName(ctx, gcpClient, rootDomain)
 	if err != nil {
 		return errors.Wrap(err, "error querying zone name")
 	}
@@ -72,18 +73,18 @@ func (q *gcpQuery) Create(rootDomain string, domain string, values sets.String)
 		return errors.New("no public managed zone found for domain")
 	}
 	return errors.Wrap(
-		q.createNameServers(gcpClient, zoneName, domain, values),
+		q.createNameServers(ctx, gcpClient, zoneName, domain, values),
 		"error creating the name server",
 	)
 }
 
 // Delete implements Query.Delete.
-func (q *gcpQuery) Delete(rootDomain string, domain string, values sets.String) error {
+func (q *gcpQuery) Delete(ctx context.Context, rootDomain string, domain string, values sets.String) error {
 	gcpClient, err := q.getGCPClient()
 	if err != nil {
 		return errors.Wrap(err, "failed to get GCP client")
 	}
-	zoneName, err := q.queryZoneName(gcpClient, rootDomain)
+	zoneName, err := q.queryZoneName(ctx, gcpClient, rootDomain)
 	if err != nil {
 		return errors.Wrap(err, "error querying zone name")
 	}
@@ -93,7 +94,7 @@ func (q *gcpQuery) Delete(rootDomain string, domain string, values sets.String)
 	if len(values) != 0 {
 		// If values were provided for the name servers, attempt to perform a
 		// delete using those values.
-		err = q.deleteNameServers(gcpClient, zoneName, domain, values)
+		err = q.deleteNameServers(ctx, gcpClient, zoneName, domain, values)
 		gcpErr, ok := err.(*googleapi.Error)
 		if !ok {
 			return errors.Wrap(err, "error deleting the name server")
@@ -107,7 +108,7 @@ func (q *gcpQuery) Delete(rootDomain string, domain string, values sets.String)
 	}
 	// Since we do not have up-to-date values for the name servers, we need
 	// to query GCP for the current values to use them in the delete.
-	values, err = q.queryNameServer(gcpClient, zoneName, domain)
+	values, err = q.queryNameServer(ctx, gcpClient, zoneName, domain)
 	if err != nil {
 		return errors.Wrap(err, "error querying the current values of the name server")
 	}
@@ -115,18 +116,18 @@ func (q *gcpQuery) Delete(rootDomain string, domain string, values sets.String)
 		return nil
 	}
 	return errors.Wrap(
-		q.deleteNameServers(gcpClient, zoneName, domain, values),
+		q.deleteNameServers(ctx, gcpClient, zoneName, domain, values),
 		"error deleting the name server with recently read values",
 	)
 }
 
 // queryZoneName queries GCP for the public managed zone for the specified domain.
-func (q *gcpQuery) queryZoneName(gcpClient gcpclient.Client, domain string) (string, error) {
+func (q *gcpQuery) queryZoneName(ctx context.Context, gcpClient gcpclient.Client, domain string) (string, error) {
 	listOpts := gcpclient.ListManagedZonesOptions{
 		DNSName: controllerutils.Dotted(domain),
 	}
 	for {
-		listOutput, err := gcpClient.ListManagedZones(listOpts)
+		listOutput, err := gcpClient.ListManagedZones(ctx, listOpts)
 		if err != nil {
 			return "", err
 		}
@@ -144,11 +145,11 @@ func (q *gcpQuery) queryZoneName(gcpClient gcpclient.Client, domain string) (str
 }
 
 // queryNameServers queries GCP for the name servers in the specified managed zone.
-func (q *gcpQuery) queryNameServers(gcpClient gcpclient.Client, managedZone string) (map[string]sets.String, error) {
+func (q *gcpQuery) queryNameServers(ctx context.Context, gcpClient gcpclient.Client, managedZone string) (map[string]sets.String, error) {
 	nameServers := map[string]sets.String{}
 	listOpts := gcpclient.ListResourceRecordSetsOptions{}
 	for {
-		listOutput, err := gcpClient.ListResourceRecordSets(managedZone, listOpts)
+		listOutput, err := gcpClient.ListResourceRecordSets(ctx, managedZone, listOpts)
 		if err != nil {
 			return nil, err
 		}
@@ -170,8 +171,9 @@ func (q *gcpQuery) queryNameServers(gcpClient gcpclient.Client, managedZone stri
 }
 
 // queryNameServer queries GCP for the name servers for the specified domain in the specified managed zone.
-func (q *gcpQuery) queryNameServer(gcpClient gcpclient.Client, managedZone string, domain string) (sets.String, error) {
+func (q *gcpQuery) queryNameServer(ctx context.Context, gcpClient gcpclient.Client, managedZone string, domain string) (sets.String, error) {
 	listOutput, err := gcpClient.ListResourceRecordSets(
+		ctx,
 		managedZone,
 		gcpclient.ListResourceRecordSetsOptions{
 			MaxResults: 1,
@@ -193,9 +195,9 @@ func (q *gcpQuery) queryNameServer(gcpClient gcpclient.Client, managedZone strin
 }
 
 // createNameServers creates the name servers for the specified domain in the specified managed zone.
-func (q *gcpQuery) createNameServers(gcpClient gcpclient.Client, managedZone string, domain string, values sets.String) error {
+func (q *gcpQuery) createNameServers(ctx context.Context, gcpClient gcpclient.Client, managedZone string, domain string, values sets.String) error {
 
-	err := gcpClient.AddResourceRecordSet(managedZone, q.resourceRecordSet(domain, values))
+	err := gcpClient.AddResourceRecordSet(ctx, managedZone, q.resourceRecordSet(domain, values))
 	if gcpErr, ok := err.(*googleapi.Error); ok && gcpErr.Code == http.StatusConflict {
 		// this means there is already an existing resource record, so we need
 		// to fall through to the update path
@@ -207,7 +209,7 @@ func (q *gcpQuery) createNameServers(gcpClient gcpclient.Client, managedZone str
 
 	// An update using the GCP API involves listing the current set of records
 	// for removal, and adding the new desired set of records as additions.
-	response, err := gcpClient.ListResourceRecordSets(managedZone, gcpclient.ListResourceRecordSetsOptions{
+	response, err := gcpClient.ListResourceRecordSets(ctx, managedZone, gcpclient.ListResourceRecordSetsOptions{
 		Name: controllerutils.Dotted(domain),
 		Type: "NS",
 	})
@@ -222,7 +224,7 @@ func (q *gcpQuery) createNameServers(gcpClient gcpclient.Client, managedZone str
 		addRRSet := q.resourceRecordSet(domain, values)
 		removeRRSet := q.resourceRecordSet(domain, currentNSValues)
 
-		err := gcpClient.UpdateResourceRecordSet(managedZone, addRRSet, removeRRSet)
+		err := gcpClient.UpdateResourceRecordSet(ctx, managedZone, addRRSet, removeRRSet)
 		return errors.Wrap(err, "failed to update existing NS entry")
 	default:
 		return fmt.Errorf("unexpected response when querying domain")
@@ -230,8 +232,8 @@ func (q *gcpQuery) createNameServers(gcpClient gcpclient.Client, managedZone str
 }
 
 // deleteNameServers deletes the name servers for the specified domain in the specified managed zone.
-func (q *gcpQuery) deleteNameServers(gcpClient gcpclient.Client, managedZone string, domain string, values sets.String) error {
-	return gcpClient.DeleteResourceRecordSet(managedZone, q.resourceRecordSet(domain, values))
+func (q *gcpQuery) deleteNameServers(ctx context.Context, gcpClient gcpclient.Client, managedZone string, domain string, values sets.String) error {
+	return gcpClient.DeleteResourceRecordSet(ctx, managedZone, q.resourceRecordSet(domain, values))
 }
 
 func (q *gcpQuery) resourceRecordSet(domain string, values sets.String) *dns.ResourceRecordSet {
@@ -1,6 +1,7 @@
 package nameserver
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"os"
@@ -37,13 +38,13 @@ type LiveGCPTestSuite struct {
 }
 
 func (s *LiveGCPTestSuite) TestGetForNonExistentZone() {
-	nameServers, err := s.getCUT().Get("non-existent.zone.live-gcp-test.com")
+	nameServers, err := s.getCUT().Get(context.TODO(), "non-existent.zone.live-gcp-test.com")
 	s.NoError(err, "expected no error")
 	s.Empty(nameServers, "expected no name servers")
 }
 
 func (s *LiveGCPTestSuite) TestGetForExistentZone() {
-	nameServers, err := s.getCUT().Get(s.rootDomain)
+	nameServers, err := s.getCUT().Get(context.TODO(), s.rootDomain)
 	s.NoError(err, "expected no error")
 	s.NotEmpty(nameServers, "expected some name servers")
 	s.Len(nameServers[s.rootDomain], 4, "expected NS to have 4 values")
@@ -101,14 +102,14 @@ func (s *LiveGCPTestSuite) testCreateAndDelete(tc *testCreateAndDeleteCase) {
 	cut := s.getCUT()
 	domain := fmt.Sprintf("live-gcp-test-%08d.%s", rand.Intn(100000000), s.rootDomain)
 	s.T().Logf("domain = %q", domain)
-	err := cut.Create(s.rootDomain, domain, sets.NewString(tc.createValues...))
+	err := cut.Create(context.TODO(), s.rootDomain, domain, sets.NewString(tc.createValues...))
 	if s.NoError(err, "unexpected error creating NS") {
 		defer func() {
-			err := cut.Delete(s.rootDomain, domain, sets.NewString(tc.deleteValues...))
+			err := cut.Delete(context.TODO(), s.rootDomain, domain, sets.NewString(tc.deleteValues...))
 			s.NoError(err, "unexpected error deleting NS")
 		}()
 	}
-	nameServers, err := cut.Get(s.rootDomain)
+	nameServers, err := cut.Get(context.TODO(), s.rootDomain)
 	s.NoError(err, "unexpected error querying domain")
 	s.NotEmpty(nameServers, "expected some name servers")
 	actualValues := nameServers[domain]
@@ -130,7 +131,7 @@ func (s *LiveGCPTestSuite) TestDeleteOfNonExistentNS() {
 	}
 	for _, tc := range cases {
 		s.T().Run(tc.name, func(t *testing.T) {
-			err := s.getCUT().Delete(s.rootDomain, fmt.Sprintf("non-existent.subdomain.%s", s.rootDomain), sets.NewString(tc.deleteValues...))
+			err := s.getCUT().Delete(context.TODO(), s.rootDomain, fmt.Sprintf("non-existent.subdomain.%s", s.rootDomain), sets.NewString(tc.deleteValues...))
 			s.NoError(err, "expected no error")
 		})
 	}
@@ -153,19 +154,19 @@ func (s *LiveGCPTestSuite) testCreateThenUpdate(tc *testCreateThenUpdateCase) {
 	cut := s.getCUT()
 	domain := fmt.Sprintf("live-gcp-test-%08d.%s", rand.Intn(100000000), s.rootDomain)
 	s.T().Logf("domain = %q", domain)
-	err := cut.Create(s.rootDomain, domain, sets.NewString(tc.createValues...))
+	err := cut.Create(context.TODO(), s.rootDomain, domain, sets.NewString(tc.createValues...))
 	if s.NoError(err, "unexpected error creating NS") {
 		defer func() {
-			err := cut.Delete(s.rootDomain, domain, sets.NewString())
+			err := cut.Delete(context.TODO(), s.rootDomain, domain, sets.NewString())
 			s.NoError(err, "unexpected error deleting NS")
 		}()
 	}
 
 	// now test updating by re-issuing a Create()
-	err = cut.Create(s.rootDomain, domain, sets.NewString(tc.updateValues...))
+	err = cut.Create(context.TODO(), s.rootDomain, domain, sets.NewString(tc.updateValues...))
 	s.NoError(err, "unexpected error updating NS")
 
-	nameServers, err := cut.Get(s.rootDomain)
+	nameServers, err := cut.Get(context.TODO(), s.rootDomain)
 	s.NoError(err, "unexpected error querying domain")
 	s.NotEmpty(nameServers, "expected some name servers")
 	actualValues := nameServers[domain]
@@ -1,6 +1,7 @@
 package nameserver
 
 import (
+	"context"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -182,7 +183,7 @@ func TestGCPGet(t *testing.T) {
 					opts.PageToken = "next-page-token"
 				}
 				mockGCPClient.EXPECT().
-					ListManagedZones(gomock.Eq(opts)).
+					ListManagedZones(gomock.Any(), gomock.Eq(opts)).
 					Return(out, nil)
 			}
 			for i, out := range tc.listResourceRecordSetsResponses {
@@ -191,10 +192,10 @@ func TestGCPGet(t *testing.T) {
 					opts.PageToken = "next-page-token"
 				}
 				mockGCPClient.EXPECT().
-					ListResourceRecordSets(gomock.Eq("test-zone-name"), gomock.Eq(opts)).
+					ListResourceRecordSets(gomock.Any(), gomock.Eq("test-zone-name"), gomock.Eq(opts)).
 					Return(out, nil)
 			}
-			actualNameServers, err := gcpQuery.Get("test-domain")
+			actualNameServers, err := gcpQuery.Get(context.TODO(), "test-domain")
 			assert.NoError(t, err, "expected no error from querying")
 			if len(tc.expectedNameServers) == 0 {
 				assert.Empty(t, actualNameServers, "expected no name servers")
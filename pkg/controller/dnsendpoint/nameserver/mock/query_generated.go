@@ -5,6 +5,7 @@
 package mock
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	sets "k8s.io/apimachinery/pkg/util/sets"
 	reflect "reflect"
@@ -34,44 +35,44 @@ func (m *MockQuery) EXPECT() *MockQueryMockRecorder {
 }
 
 // Get mocks base method
-func (m *MockQuery) Get(rootDomain string) (map[string]sets.String, error) {
+func (m *MockQuery) Get(ctx context.Context, rootDomain string) (map[string]sets.String, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", rootDomain)
+	ret := m.ctrl.Call(m, "Get", ctx, rootDomain)
 	ret0, _ := ret[0].(map[string]sets.String)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get
-func (mr *MockQueryMockRecorder) Get(rootDomain interface{}) *gomock.Call {
+func (mr *MockQueryMockRecorder) Get(ctx, rootDomain interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockQuery)(nil).Get), rootDomain)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockQuery)(nil).Get), ctx, rootDomain)
 }
 
 // Create mocks base method
-func (m *MockQuery) Create(rootDomain, domain string, values sets.String) error {
+func (m *MockQuery) Create(ctx context.Context, rootDomain, domain string, values sets.String) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", rootDomain, domain, values)
+	ret := m.ctrl.Call(m, "Create", ctx, rootDomain, domain, values)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create
-func (mr *MockQueryMockRecorder) Create(rootDomain, domain, values interface{}) *gomock.Call {
+func (mr *MockQueryMockRecorder) Create(ctx, rootDomain, domain, values interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockQuery)(nil).Create), rootDomain, domain, values)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockQuery)(nil).Create), ctx, rootDomain, domain, values)
 }
 
 // Delete mocks base method
-func (m *MockQuery) Delete(rootDomain, domain string, values sets.String) error {
+func (m *MockQuery) Delete(ctx context.Context, rootDomain, domain string, values sets.String) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", rootDomain, domain, values)
+	ret := m.ctrl.Call(m, "Delete", ctx, rootDomain, domain, values)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete
-func (mr *MockQueryMockRecorder) Delete(rootDomain, domain, values interface{}) *gomock.Call {
+func (mr *MockQueryMockRecorder) Delete(ctx, rootDomain, domain, values interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockQuery)(nil).Delete), rootDomain, domain, values)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockQuery)(nil).Delete), ctx, rootDomain, domain, values)
 }
@@ -114,7 +114,7 @@ func (s *nameServerScraper) Start(ctx context.Context) error {
 					s.queue.Forget(obj)
 					return
 				}
-				if err := s.scrape(domain); err == nil {
+				if err := s.scrape(ctx, domain); err == nil {
 					s.logger.WithField("domain", domain).Info("scrape name servers for domain")
 					s.queue.Forget(obj)
 					s.queue.AddAfter(domain, s.scrapePeriod)
@@ -129,8 +129,8 @@ func (s *nameServerScraper) Start(ctx context.Context) error {
 	return nil
 }
 
-func (s *nameServerScraper) scrape(rootDomain string) error {
-	currentNameServers, err := s.nameServerQuery.Get(rootDomain)
+func (s *nameServerScraper) scrape(ctx context.Context, rootDomain string) error {
+	currentNameServers, err := s.nameServerQuery.Get(ctx, rootDomain)
 	if err != nil {
 		return errors.Wrap(err, "error querying name servers")
 	}
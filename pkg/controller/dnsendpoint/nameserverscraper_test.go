@@ -344,9 +344,9 @@ func TestHasBeenScraped(t *testing.T) {
 			mockCtrl := gomock.NewController(t)
 			defer mockCtrl.Finish()
 			mockQuery := mock.NewMockQuery(mockCtrl)
-			mockQuery.EXPECT().Get(tc.rootDomainToScrape).Return(nil, nil)
+			mockQuery.EXPECT().Get(gomock.Any(), tc.rootDomainToScrape).Return(nil, nil)
 			cut := newNameServerScraper(log.StandardLogger(), mockQuery, tc.rootDomains, nil)
-			if err := cut.scrape(tc.rootDomainToScrape); !assert.NoError(t, err, "error scraping") {
+			if err := cut.scrape(context.TODO(), tc.rootDomainToScrape); !assert.NoError(t, err, "error scraping") {
 				return
 			}
 			actualResult := cut.HasBeenScraped(domain)
@@ -370,16 +370,16 @@ func TestScrape(t *testing.T) {
 			name:        "single root domain",
 			rootDomains: []string{"domain.com"},
 			configureQuery: func(mockQuery *mock.MockQuery) {
-				mockQuery.EXPECT().Get("domain.com").Return(nil, nil)
+				mockQuery.EXPECT().Get(gomock.Any(), "domain.com").Return(nil, nil)
 			},
 		},
 		{
 			name:        "multiple root domains",
 			rootDomains: []string{"domain1.com", "domain2.com", "domain3.com"},
 			configureQuery: func(mockQuery *mock.MockQuery) {
-				mockQuery.EXPECT().Get("domain1.com").Return(nil, nil)
-				mockQuery.EXPECT().Get("domain2.com").Return(nil, nil)
-				mockQuery.EXPECT().Get("domain3.com").Return(nil, nil)
+				mockQuery.EXPECT().Get(gomock.Any(), "domain1.com").Return(nil, nil)
+				mockQuery.EXPECT().Get(gomock.Any(), "domain2.com").Return(nil, nil)
+				mockQuery.EXPECT().Get(gomock.Any(), "domain3.com").Return(nil, nil)
 			},
 		},
 		{
@@ -387,8 +387,8 @@ func TestScrape(t *testing.T) {
 			scrapePeriod: 2 * time.Second,
 			rootDomains:  []string{"domain1.com", "domain2.com"},
 			configureQuery: func(mockQuery *mock.MockQuery) {
-				mockQuery.EXPECT().Get("domain1.com").Return(nil, nil).Times(2)
-				mockQuery.EXPECT().Get("domain2.com").Return(nil, nil).Times(2)
+				mockQuery.EXPECT().Get(gomock.Any(), "domain1.com").Return(nil, nil).Times(2)
+				mockQuery.EXPECT().Get(gomock.Any(), "domain2.com").Return(nil, nil).Times(2)
 			},
 		},
 		{
@@ -396,16 +396,16 @@ func TestScrape(t *testing.T) {
 			testDuration: 12 * time.Second,
 			rootDomains:  []string{"domain1.com", "domain2.com"},
 			configureQuery: func(mockQuery *mock.MockQuery) {
-				mockQuery.EXPECT().Get("domain1.com").Return(nil, errors.New("test error"))
-				mockQuery.EXPECT().Get("domain1.com").Return(nil, nil)
-				mockQuery.EXPECT().Get("domain2.com").Return(nil, nil)
+				mockQuery.EXPECT().Get(gomock.Any(), "domain1.com").Return(nil, errors.New("test error"))
+				mockQuery.EXPECT().Get(gomock.Any(), "domain1.com").Return(nil, nil)
+				mockQuery.EXPECT().Get(gomock.Any(), "domain2.com").Return(nil, nil)
 			},
 		},
 		{
 			name:        "untracked domain",
 			rootDomains: []string{"domain.com"},
 			configureQuery: func(mockQuery *mock.MockQuery) {
-				mockQuery.EXPECT().Get("domain.com").
+				mockQuery.EXPECT().Get(gomock.Any(), "domain.com").
 					Return(
 						map[string]sets.String{
 							"test.domain.com": sets.NewString("test-value"),
@@ -426,7 +426,7 @@ func TestScrape(t *testing.T) {
 				},
 			},
 			configureQuery: func(mockQuery *mock.MockQuery) {
-				mockQuery.EXPECT().Get("domain.com").
+				mockQuery.EXPECT().Get(gomock.Any(), "domain.com").
 					Return(
 						map[string]sets.String{
 							"test.domain.com": sets.NewString("test-value"),
@@ -456,7 +456,7 @@ func TestScrape(t *testing.T) {
 				},
 			},
 			configureQuery: func(mockQuery *mock.MockQuery) {
-				mockQuery.EXPECT().Get("domain.com").
+				mockQuery.EXPECT().Get(gomock.Any(), "domain.com").
 					Return(
 						map[string]sets.String{
 							"test.domain.com": sets.NewString("test-value"),
@@ -497,7 +497,7 @@ func TestScrape(t *testing.T) {
 				},
 			},
 			configureQuery: func(mockQuery *mock.MockQuery) {
-				mockQuery.EXPECT().Get("domain.com").
+				mockQuery.EXPECT().Get(gomock.Any(), "domain.com").
 					Return(
 						map[string]sets.String{
 							"changed-1.domain.com": sets.NewString("test-value-1"),
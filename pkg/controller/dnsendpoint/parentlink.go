@@ -0,0 +1,248 @@
+package dnsendpoint
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	defaultParentLinkCheckInterval = time.Hour
+	parentLinkLookupTimeout        = 30 * time.Second
+	// parentLinkVerifyInterval is how often the delegation of a zone that is not yet available is checked until it
+	// is verified.
+	parentLinkVerifyInterval = time.Minute
+
+	parentLinkVerifiedReason     = "DelegationVerified"
+	parentLinkMismatchReason     = "DelegationMismatch"
+	parentLinkLookupFailedReason = "DelegationLookupFailed"
+)
+
+var (
+	// defaultParentLinkResolvers are the public resolvers of Google and Cloudflare.
+	defaultParentLinkResolvers = []string{"8.8.8.8", "1.1.1.1"}
+
+	metricParentLinkChecks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_dnszone_parent_link_checks_total",
+		Help: "Counter incremented every time the delegation of a dnszone from its parent domain is checked, by result.",
+	}, []string{"result"})
+	metricParentLinkVerificationSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "hive_dnszone_parent_link_verification_seconds",
+		Help:    "Time from the creation of the parent link of a dnszone until its delegation is first verified.",
+		Buckets: []float64{30, 60, 120, 300, 600, 1800, 3600, 7200},
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricParentLinkChecks)
+	metrics.Registry.MustRegister(metricParentLinkVerificationSeconds)
+}
+
+// parentLinkCheckInterval returns the interval at which the delegation of zones is re-verified from the environment.
+func parentLinkCheckInterval(logger log.FieldLogger) time.Duration {
+	envInterval := os.Getenv(constants.ParentLinkCheckIntervalEnvVar)
+	if envInterval == "" {
+		return defaultParentLinkCheckInterval
+	}
+	interval, err := time.ParseDuration(envInterval)
+	if err != nil {
+		logger.WithError(err).WithField("interval", envInterval).Errorf("unable to parse %s, using default", constants.ParentLinkCheckIntervalEnvVar)
+		return defaultParentLinkCheckInterval
+	}
+	return interval
+}
+
+// parentLinkResolvers returns the addresses of the resolvers queried to verify the delegation of zones from the
+// environment.
+func parentLinkResolvers() []string {
+	resolvers := []string{}
+	for _, resolver := range strings.Split(os.Getenv(constants.ParentLinkResolversEnvVar), ",") {
+		if resolver = strings.TrimSpace(resolver); resolver != "" {
+			resolvers = append(resolvers, resolver)
+		}
+	}
+	if len(resolvers) == 0 {
+		return defaultParentLinkResolvers
+	}
+	return resolvers
+}
+
+// newResolversLookupNS returns a function resolving the NS records of a domain with each of the resolvers, which
+// returns the name servers resolved by any of them so that resolvers that disagree are reported as a mismatch.
+func newResolversLookupNS(resolvers []string) func(ctx context.Context, domain string) ([]*net.NS, error) {
+	lookups := make([]func(ctx context.Context, domain string) ([]*net.NS, error), len(resolvers))
+	for i, resolver := range resolvers {
+		address := resolver
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			address = net.JoinHostPort(address, "53")
+		}
+		lookups[i] = (&net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, address)
+			},
+		}).LookupNS
+	}
+	return func(ctx context.Context, domain string) ([]*net.NS, error) {
+		var all []*net.NS
+		for i, lookup := range lookups {
+			records, err := lookup(ctx, domain)
+			if err != nil {
+				return nil, errors.Wrapf(err, "resolver %s", resolvers[i])
+			}
+			all = append(all, records...)
+		}
+		return all, nil
+	}
+}
+
+// checkParentLink verifies that the delegation from the parent domain of a zone resolves to the name servers of the
+// zone, and records the result in the ParentLinkVerified condition and, once the zone is available, in the
+// ParentLinkFailed condition. Until the delegation is first verified, it is checked every parentLinkVerifyInterval,
+// so that a delegation that does not propagate is reported before the installs of the clusters using the zone fail on
+// it. Once the zone is available, it is re-verified at most once per check interval, as it can be broken by changes
+// outside of Hive, such as at the registrar of the parent domain, which would otherwise only be noticed when the
+// certificates of the clusters using the zone fail to renew.
+func (r *ReconcileDNSEndpoint) checkParentLink(dnsZone *hivev1.DNSZone, nameServers sets.String, logger log.FieldLogger) (reconcile.Result, error) {
+	if r.parentLinkCheckInterval <= 0 {
+		return reconcile.Result{}, nil
+	}
+	verified := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, hivev1.ParentLinkVerifiedCondition)
+	available := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
+	if available == nil || available.Status != corev1.ConditionTrue {
+		if verified != nil && verified.Status == corev1.ConditionTrue {
+			// The zone becomes available once the delegation has propagated to the resolvers of the hub.
+			return reconcile.Result{}, nil
+		}
+		if verified != nil {
+			if wait := parentLinkVerifyInterval - time.Since(verified.LastProbeTime.Time); wait > 0 {
+				return reconcile.Result{RequeueAfter: wait}, nil
+			}
+		}
+		failedStatus, reason, message := r.resolveParentLink(dnsZone, nameServers, logger)
+		// The delegation may still be propagating, so it is not reported as failed before the zone is available.
+		r.recordParentLinkVerified(dnsZone, failedStatus == corev1.ConditionFalse, reason, message)
+		if err := r.updateParentLinkConditions(dnsZone, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		if failedStatus == corev1.ConditionTrue {
+			return reconcile.Result{RequeueAfter: parentLinkVerifyInterval}, nil
+		}
+		if created := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, hivev1.ParentLinkCreatedCondition); created != nil && !created.LastTransitionTime.IsZero() {
+			metricParentLinkVerificationSeconds.Observe(time.Since(created.LastTransitionTime.Time).Seconds())
+		}
+		return reconcile.Result{}, nil
+	}
+	failed := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, hivev1.ParentLinkFailedCondition)
+	if failed != nil {
+		if wait := r.parentLinkCheckInterval - time.Since(failed.LastProbeTime.Time); wait > 0 {
+			return reconcile.Result{RequeueAfter: wait}, nil
+		}
+	}
+
+	status, reason, message := r.resolveParentLink(dnsZone, nameServers, logger)
+	r.recordParentLinkVerified(dnsZone, status == corev1.ConditionFalse, reason, message)
+	// The probe time of the condition records when the delegation was last checked, so it is updated on every check.
+	now := metav1.Now()
+	if failed == nil {
+		dnsZone.Status.Conditions = append(dnsZone.Status.Conditions, hivev1.DNSZoneCondition{
+			Type:               hivev1.ParentLinkFailedCondition,
+			LastTransitionTime: now,
+		})
+		failed = &dnsZone.Status.Conditions[len(dnsZone.Status.Conditions)-1]
+	} else if failed.Status != status {
+		failed.LastTransitionTime = now
+	}
+	failed.Status = status
+	failed.Reason = reason
+	failed.Message = message
+	failed.LastProbeTime = now
+	if err := r.updateParentLinkConditions(dnsZone, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: r.parentLinkCheckInterval}, nil
+}
+
+// resolveParentLink resolves the delegation of the zone and compares it to the name servers of the zone, returning the
+// status, reason and message of the ParentLinkFailed condition.
+func (r *ReconcileDNSEndpoint) resolveParentLink(dnsZone *hivev1.DNSZone, nameServers sets.String, logger log.FieldLogger) (corev1.ConditionStatus, string, string) {
+	ctx, cancel := context.WithTimeout(context.Background(), parentLinkLookupTimeout)
+	defer cancel()
+	records, err := r.lookupNS(ctx, dnsZone.Spec.Zone)
+	if err != nil {
+		logger.WithError(err).Warn("could not look up the delegation of the zone")
+		metricParentLinkChecks.WithLabelValues(parentLinkLookupFailedReason).Inc()
+		return corev1.ConditionTrue, parentLinkLookupFailedReason, fmt.Sprintf("Could not resolve the name servers of the zone: %v", err)
+	}
+	resolved := sets.NewString()
+	for _, record := range records {
+		resolved.Insert(normalizeNameServer(record.Host))
+	}
+	expected := sets.NewString()
+	for _, ns := range nameServers.List() {
+		expected.Insert(normalizeNameServer(ns))
+	}
+	if !resolved.Equal(expected) {
+		logger.WithFields(log.Fields{"resolved": resolved.List(), "expected": expected.List()}).
+			Warn("delegation of the zone does not resolve to the name servers of the zone")
+		metricParentLinkChecks.WithLabelValues(parentLinkMismatchReason).Inc()
+		return corev1.ConditionTrue, parentLinkMismatchReason, fmt.Sprintf("Delegation resolves to name servers %v instead of %v", resolved.List(), expected.List())
+	}
+	metricParentLinkChecks.WithLabelValues(parentLinkVerifiedReason).Inc()
+	return corev1.ConditionFalse, parentLinkVerifiedReason, "Delegation from the parent domain resolves to the name servers of the zone"
+}
+
+// recordParentLinkVerified sets the ParentLinkVerified condition of the zone from the result of a check.
+func (r *ReconcileDNSEndpoint) recordParentLinkVerified(dnsZone *hivev1.DNSZone, isVerified bool, reason, message string) {
+	status := corev1.ConditionFalse
+	if isVerified {
+		status = corev1.ConditionTrue
+	}
+	now := metav1.Now()
+	verified := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, hivev1.ParentLinkVerifiedCondition)
+	if verified == nil {
+		dnsZone.Status.Conditions = append(dnsZone.Status.Conditions, hivev1.DNSZoneCondition{
+			Type:               hivev1.ParentLinkVerifiedCondition,
+			LastTransitionTime: now,
+		})
+		verified = &dnsZone.Status.Conditions[len(dnsZone.Status.Conditions)-1]
+	} else if verified.Status != status {
+		verified.LastTransitionTime = now
+	}
+	verified.Status = status
+	verified.Reason = reason
+	verified.Message = message
+	verified.LastProbeTime = now
+}
+
+func (r *ReconcileDNSEndpoint) updateParentLinkConditions(dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
+	if err := r.Status().Update(context.Background(), dnsZone); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update parent link conditions")
+		return err
+	}
+	return nil
+}
+
+// normalizeNameServer returns the name server in lower case without a trailing dot, as name servers are compared
+// between DNS responses and cloud provider APIs which may differ in both.
+func normalizeNameServer(ns string) string {
+	return strings.TrimSuffix(strings.ToLower(ns), ".")
+}
@@ -0,0 +1,298 @@
+package dnsendpoint
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/controller/dnsendpoint/nameserver/mock"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const testParentLinkCheckInterval = time.Hour
+
+func TestParentLinkCheck(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	objectKey := client.ObjectKey{Namespace: testNamespace, Name: testName}
+
+	cases := []struct {
+		name              string
+		dnsZone           *hivev1.DNSZone
+		interval          time.Duration
+		resolved          []string
+		lookupErr         error
+		expectLookup      bool
+		expectedResult    reconcile.Result
+		expectRequeueWait bool
+		expectedCondition *hivev1.DNSZoneCondition
+		expectedVerified  *hivev1.DNSZoneCondition
+	}{
+		{
+			name:           "delegation verified before zone is available",
+			dnsZone:        testDNSZone(),
+			interval:       testParentLinkCheckInterval,
+			resolved:       []string{"test-value-1", "test-value-2", "test-value-3"},
+			expectLookup:   true,
+			expectedResult: reconcile.Result{},
+			expectedVerified: &hivev1.DNSZoneCondition{
+				Status: corev1.ConditionTrue,
+				Reason: parentLinkVerifiedReason,
+			},
+		},
+		{
+			name:           "delegation not yet propagated",
+			dnsZone:        testDNSZone(),
+			interval:       testParentLinkCheckInterval,
+			resolved:       []string{"other-value"},
+			expectLookup:   true,
+			expectedResult: reconcile.Result{RequeueAfter: parentLinkVerifyInterval},
+			expectedVerified: &hivev1.DNSZoneCondition{
+				Status: corev1.ConditionFalse,
+				Reason: parentLinkMismatchReason,
+			},
+		},
+		{
+			name: "delegation already verified before zone is available",
+			dnsZone: func() *hivev1.DNSZone {
+				z := testDNSZone()
+				z.Status.Conditions = append(z.Status.Conditions, hivev1.DNSZoneCondition{
+					Type:          hivev1.ParentLinkVerifiedCondition,
+					Status:        corev1.ConditionTrue,
+					Reason:        parentLinkVerifiedReason,
+					LastProbeTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+				})
+				return z
+			}(),
+			interval:       testParentLinkCheckInterval,
+			expectedResult: reconcile.Result{},
+			expectedVerified: &hivev1.DNSZoneCondition{
+				Status: corev1.ConditionTrue,
+				Reason: parentLinkVerifiedReason,
+			},
+		},
+		{
+			name: "delegation recently checked before zone is available",
+			dnsZone: func() *hivev1.DNSZone {
+				z := testDNSZone()
+				z.Status.Conditions = append(z.Status.Conditions, hivev1.DNSZoneCondition{
+					Type:          hivev1.ParentLinkVerifiedCondition,
+					Status:        corev1.ConditionFalse,
+					Reason:        parentLinkMismatchReason,
+					LastProbeTime: metav1.NewTime(time.Now().Add(-10 * time.Second)),
+				})
+				return z
+			}(),
+			interval:          parentLinkVerifyInterval * 2,
+			expectRequeueWait: true,
+			expectedVerified: &hivev1.DNSZoneCondition{
+				Status: corev1.ConditionFalse,
+				Reason: parentLinkMismatchReason,
+			},
+		},
+		{
+			name:           "check disabled",
+			dnsZone:        testAvailableDNSZone(),
+			interval:       0,
+			expectedResult: reconcile.Result{},
+		},
+		{
+			name:           "delegation verified",
+			dnsZone:        testAvailableDNSZone(),
+			interval:       testParentLinkCheckInterval,
+			resolved:       []string{"TEST-VALUE-1.", "test-value-2.", "test-value-3"},
+			expectLookup:   true,
+			expectedResult: reconcile.Result{RequeueAfter: testParentLinkCheckInterval},
+			expectedCondition: &hivev1.DNSZoneCondition{
+				Status: corev1.ConditionFalse,
+				Reason: parentLinkVerifiedReason,
+			},
+			expectedVerified: &hivev1.DNSZoneCondition{
+				Status: corev1.ConditionTrue,
+				Reason: parentLinkVerifiedReason,
+			},
+		},
+		{
+			name:           "delegation mismatch",
+			dnsZone:        testAvailableDNSZone(),
+			interval:       testParentLinkCheckInterval,
+			resolved:       []string{"test-value-1", "other-value"},
+			expectLookup:   true,
+			expectedResult: reconcile.Result{RequeueAfter: testParentLinkCheckInterval},
+			expectedCondition: &hivev1.DNSZoneCondition{
+				Status: corev1.ConditionTrue,
+				Reason: parentLinkMismatchReason,
+			},
+			expectedVerified: &hivev1.DNSZoneCondition{
+				Status: corev1.ConditionFalse,
+				Reason: parentLinkMismatchReason,
+			},
+		},
+		{
+			name:           "delegation lookup failed",
+			dnsZone:        testAvailableDNSZone(),
+			interval:       testParentLinkCheckInterval,
+			lookupErr:      errors.New("no such host"),
+			expectLookup:   true,
+			expectedResult: reconcile.Result{RequeueAfter: testParentLinkCheckInterval},
+			expectedCondition: &hivev1.DNSZoneCondition{
+				Status: corev1.ConditionTrue,
+				Reason: parentLinkLookupFailedReason,
+			},
+			expectedVerified: &hivev1.DNSZoneCondition{
+				Status: corev1.ConditionFalse,
+				Reason: parentLinkLookupFailedReason,
+			},
+		},
+		{
+			name: "recently checked",
+			dnsZone: func() *hivev1.DNSZone {
+				z := testAvailableDNSZone()
+				z.Status.Conditions = append(z.Status.Conditions, hivev1.DNSZoneCondition{
+					Type:          hivev1.ParentLinkFailedCondition,
+					Status:        corev1.ConditionFalse,
+					Reason:        parentLinkVerifiedReason,
+					LastProbeTime: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+				})
+				return z
+			}(),
+			interval:          testParentLinkCheckInterval,
+			expectRequeueWait: true,
+			expectedCondition: &hivev1.DNSZoneCondition{
+				Status: corev1.ConditionFalse,
+				Reason: parentLinkVerifiedReason,
+			},
+		},
+		{
+			name: "check due after recovery",
+			dnsZone: func() *hivev1.DNSZone {
+				z := testAvailableDNSZone()
+				z.Status.Conditions = append(z.Status.Conditions, hivev1.DNSZoneCondition{
+					Type:          hivev1.ParentLinkFailedCondition,
+					Status:        corev1.ConditionTrue,
+					Reason:        parentLinkMismatchReason,
+					LastProbeTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+				})
+				return z
+			}(),
+			interval:       testParentLinkCheckInterval,
+			resolved:       []string{"test-value-1", "test-value-2", "test-value-3"},
+			expectLookup:   true,
+			expectedResult: reconcile.Result{RequeueAfter: testParentLinkCheckInterval},
+			expectedCondition: &hivev1.DNSZoneCondition{
+				Status: corev1.ConditionFalse,
+				Reason: parentLinkVerifiedReason,
+			},
+			expectedVerified: &hivev1.DNSZoneCondition{
+				Status: corev1.ConditionTrue,
+				Reason: parentLinkVerifiedReason,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			logger := log.WithField("controller", ControllerName)
+			fakeClient := fake.NewFakeClient(tc.dnsZone)
+			mockQuery := mock.NewMockQuery(mockCtrl)
+			scraper := newNameServerScraper(logger, mockQuery, []string{rootDomain}, nil)
+			scraper.nameServers = rootDomainsMap{
+				rootDomain: nameServersMap{
+					dnsName: endpointState{
+						dnsZone:  testDNSZone(),
+						nsValues: sets.NewString("test-value-1", "test-value-2", "test-value-3"),
+					},
+				},
+			}
+
+			lookedUp := false
+			cut := &ReconcileDNSEndpoint{
+				Client: fakeClient,
+				scheme: scheme.Scheme,
+				logger: logger,
+				nameServerTools: []nameServerTool{
+					{
+						scraper:     scraper,
+						queryClient: mockQuery,
+					},
+				},
+				parentLinkCheckInterval: tc.interval,
+				lookupNS: func(_ context.Context, domain string) ([]*net.NS, error) {
+					lookedUp = true
+					assert.Equal(t, dnsName, domain, "unexpected domain looked up")
+					if tc.lookupErr != nil {
+						return nil, tc.lookupErr
+					}
+					records := make([]*net.NS, len(tc.resolved))
+					for i, host := range tc.resolved {
+						records[i] = &net.NS{Host: host}
+					}
+					return records, nil
+				},
+			}
+			result, err := cut.Reconcile(context.TODO(), reconcile.Request{NamespacedName: objectKey})
+			require.NoError(t, err, "expected no error from reconcile")
+			assert.Equal(t, tc.expectLookup, lookedUp, "unexpected delegation lookup")
+			if tc.expectRequeueWait {
+				assert.True(t, result.RequeueAfter > 0 && result.RequeueAfter < tc.interval, "expected requeue before the next check")
+			} else {
+				assert.Equal(t, tc.expectedResult, result, "unexpected reconcile result")
+			}
+
+			dnsZone := &hivev1.DNSZone{}
+			require.NoError(t, fakeClient.Get(context.Background(), objectKey, dnsZone), "unexpected error getting DNSZone")
+			verified := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, hivev1.ParentLinkVerifiedCondition)
+			if tc.expectedVerified == nil {
+				assert.Nil(t, verified, "unexpected ParentLinkVerified condition")
+			} else if assert.NotNil(t, verified, "expected ParentLinkVerified condition") {
+				assert.Equal(t, tc.expectedVerified.Status, verified.Status, "unexpected ParentLinkVerified status")
+				assert.Equal(t, tc.expectedVerified.Reason, verified.Reason, "unexpected ParentLinkVerified reason")
+			}
+			cond := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, hivev1.ParentLinkFailedCondition)
+			if tc.expectedCondition == nil {
+				assert.Nil(t, cond, "unexpected ParentLinkFailed condition")
+				return
+			}
+			if assert.NotNil(t, cond, "expected ParentLinkFailed condition") {
+				assert.Equal(t, tc.expectedCondition.Status, cond.Status, "unexpected condition status")
+				assert.Equal(t, tc.expectedCondition.Reason, cond.Reason, "unexpected condition reason")
+				if tc.expectLookup {
+					assert.WithinDuration(t, time.Now(), cond.LastProbeTime.Time, time.Minute, "expected probe time to be updated")
+				}
+			}
+		})
+	}
+}
+
+func testAvailableDNSZone() *hivev1.DNSZone {
+	z := testDNSZone()
+	z.Status.Conditions = []hivev1.DNSZoneCondition{
+		{
+			Type:   hivev1.ZoneAvailableDNSZoneCondition,
+			Status: corev1.ConditionTrue,
+		},
+		{
+			Type:   hivev1.ParentLinkCreatedCondition,
+			Status: corev1.ConditionTrue,
+		},
+	}
+	return z
+}
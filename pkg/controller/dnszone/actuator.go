@@ -1,28 +1,74 @@
 package dnszone
 
+import (
+	"context"
+	"path"
+	"strings"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
 // Actuator interface is the interface that is used to add dns provider support to the dnszone controller.
 type Actuator interface {
 	// Create tells the actuator to make a zone in the dns provider.
 	// The platform-specific DNSZone status fields will be populated (eg the zone name).
-	Create() error
+	Create(ctx context.Context) error
 
 	// Delete tells the actuator to remove the zone from the dns provider.
-	Delete() error
+	Delete(ctx context.Context) error
 
 	// Exists queries if the zone is in the dns provider.
-	Exists() (bool, error)
+	Exists(ctx context.Context) (bool, error)
 
 	// UpdateMetadata tells the actuator to update the zone's metadata in the dns provider.
-	UpdateMetadata() error
+	UpdateMetadata(ctx context.Context) error
 
 	// GetNameServers returns a list of nameservers that service the zone in the dns provider.
-	GetNameServers() ([]string, error)
+	GetNameServers(ctx context.Context) ([]string, error)
 
 	// Refresh signals to the actuator that it should get the latest version of the zone from the dns provider.
 	// Refresh MUST be called before any other function is called by the actuator.
 	// Refresh will update the DNSZone object's platform-specific status fields.
-	Refresh() error
+	Refresh(ctx context.Context) error
 
 	// SetConditionsForError sets conditions on the dnszone given a specific error
-	SetConditionsForError(err error) bool
+	SetConditionsForError(ctx context.Context, err error) bool
+}
+
+// RecordSetLister is implemented by actuators that can enumerate the record sets of the zone, which is needed to
+// publish the record set summary of the DNSZone and to enforce its Fail record cleanup policy.
+type RecordSetLister interface {
+	// ListRecordSets returns the record sets of the zone in the dns provider.
+	// Refresh MUST be called before ListRecordSets, and the zone MUST exist.
+	ListRecordSets(ctx context.Context) ([]RecordSet, error)
+}
+
+// RecordSet identifies a record set of a zone.
+type RecordSet struct {
+	// Name is the fully qualified name of the record set, with a trailing dot.
+	Name string
+
+	// Type is the type of the record set, for example "A" or "CNAME".
+	Type string
+}
+
+// isZoneRecordSet returns whether the record set is one of the NS and SOA record sets that are created with the zone,
+// and that are deleted with it.
+func isZoneRecordSet(dnsZone *hivev1.DNSZone, recordSet RecordSet) bool {
+	return strings.EqualFold(recordSet.Name, controllerutils.Dotted(dnsZone.Spec.Zone)) &&
+		(recordSet.Type == "NS" || recordSet.Type == "SOA")
+}
+
+// isPreservedRecordSet returns whether the name of the record set matches one of the PreservedRecordNames of the
+// DNSZone, in which case Hive never deletes it.
+func isPreservedRecordSet(dnsZone *hivev1.DNSZone, name string) bool {
+	// Route53 returns the asterisk of wildcard record names escaped.
+	name = strings.ToLower(controllerutils.Undotted(strings.ReplaceAll(name, `\052`, "*")))
+	for _, pattern := range dnsZone.Spec.PreservedRecordNames {
+		if ok, _ := path.Match(strings.ToLower(pattern), name); ok {
+			return true
+		}
+	}
+	return false
 }
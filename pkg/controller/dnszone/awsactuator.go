@@ -1,9 +1,12 @@
 package dnszone
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -20,15 +23,30 @@ import (
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/preflight"
 )
 
 const (
 	hiveDNSZoneAWSTag = "hive.openshift.io/dnszone"
+
+	// hiveKeySigningKeyName is the name of the DNSSEC key-signing key created by Hive in hosted zones.
+	hiveKeySigningKeyName = "hive"
 )
 
+// awsZoneIDsByTag caches the IDs of the hosted zones found by tag for DNSZones, so that DNSZones whose status is not
+// yet updated with the ID of their hosted zone do not search the tags of the account on every reconcile.
+var awsZoneIDsByTag = controllerutils.NewExpiringCache("aws_dnszone_zone_ids", 10*time.Minute, 5000)
+
+// errNoZoneWithTag is returned when loading the IDs of the hosted zones tagged for a DNSZone without any, so that the
+// absence of a zone is not cached.
+var errNoZoneWithTag = errors.New("no hosted zone found with the tag of the DNSZone")
+
 // Ensure AWSActuator implements the Actuator interface. This will fail at compile time when false.
 var _ Actuator = &AWSActuator{}
 
+// Ensure AWSActuator implements the RecordSetLister interface. This will fail at compile time when false.
+var _ RecordSetLister = &AWSActuator{}
+
 // AWSActuator manages getting the desired state, getting the current state and reconciling the two.
 type AWSActuator struct {
 	// logger is the logger used for this controller
@@ -43,8 +61,15 @@ type AWSActuator struct {
 	// currentTags are the list of tags associated with the currentHostedZone
 	currentHostedZoneTags []*route53.Tag
 
+	// hostedZoneVPCs are the VPCs associated with the hosted zone, for private hosted zones.
+	hostedZoneVPCs []*route53.VPC
+
 	// The DNSZone that represents the desired state.
 	dnsZone *hivev1.DNSZone
+
+	// zoneIDs caches the IDs of the hosted zones found by tag, keyed by the UID of the DNSZone. A nil cache always
+	// searches the tags.
+	zoneIDs *controllerutils.ExpiringCache
 }
 
 type awsClientBuilderType func(client.Client, awsclient.Options) (awsclient.Client, error)
@@ -62,8 +87,12 @@ func NewAWSActuator(
 		region = constants.AWSRoute53Region
 	}
 	awsClient, err := awsClientBuilder(kubeClient, awsclient.Options{
+		Controller:        hivev1.DNSZoneControllerName,
 		Region:            region,
 		CredentialsSource: credentials,
+		ServiceEndpoints:  dnsZone.Spec.AWS.ServiceEndpoints,
+		ProxySecret:       &awsclient.ProxySecretSource{Namespace: dnsZone.Namespace, Ref: dnsZone.Spec.CloudClientProxySecretRef},
+		AssumeRole:        dnsZone.Spec.AWS.AssumeRole,
 	})
 	if err != nil {
 		logger.WithError(err).Error("Error creating AWSClient")
@@ -80,17 +109,305 @@ func NewAWSActuator(
 }
 
 // UpdateMetadata ensures that the Route53 hosted zone metadata is current with the DNSZone
-func (a *AWSActuator) UpdateMetadata() error {
+func (a *AWSActuator) UpdateMetadata(ctx context.Context) error {
 	if a.hostedZone == nil {
 		return errors.New("hostedZone is unpopulated")
 	}
 
-	// For now, tags are the only things we can sync with existing zones.
-	return a.syncTags()
+	if err := a.syncTags(ctx); err != nil {
+		return err
+	}
+	if a.private() {
+		if err := a.syncVPCs(ctx); err != nil {
+			return err
+		}
+	}
+	if err := a.syncDNSSEC(ctx); err != nil {
+		return err
+	}
+	return a.syncQueryLogging(ctx)
+}
+
+// dnssecEnabled returns whether DNSSEC signing is enabled in the spec of the DNSZone.
+func (a *AWSActuator) dnssecEnabled() bool {
+	return a.dnsZone.Spec.AWS != nil && a.dnsZone.Spec.AWS.EnableDNSSEC
+}
+
+// dnssecConfigured returns whether Hive has configured DNSSEC signing on the hosted zone.
+func (a *AWSActuator) dnssecConfigured() bool {
+	return a.dnsZone.Status.AWS != nil && a.dnsZone.Status.AWS.DNSSEC != nil
+}
+
+// syncDNSSEC creates the key-signing key of the zone and enables DNSSEC signing when DNSSEC is enabled in the spec,
+// and disables signing and deletes the key-signing key when it no longer is.
+func (a *AWSActuator) syncDNSSEC(ctx context.Context) error {
+	if !a.dnssecEnabled() {
+		if !a.dnssecConfigured() {
+			return nil
+		}
+		if err := a.disableDNSSEC(ctx); err != nil {
+			return err
+		}
+		a.dnsZone.Status.AWS.DNSSEC = nil
+		return nil
+	}
+
+	logger := a.logger.WithField("id", aws.StringValue(a.hostedZone.Id))
+	dnssec, err := a.awsClient.GetDNSSEC(ctx, &route53.GetDNSSECInput{HostedZoneId: a.hostedZone.Id})
+	if err != nil {
+		logger.WithError(err).Error("cannot get DNSSEC status of hosted zone")
+		return err
+	}
+	changed := false
+	if findKeySigningKey(dnssec.KeySigningKeys, hiveKeySigningKeyName) == nil {
+		logger.Info("creating DNSSEC key-signing key")
+		if _, err := a.awsClient.CreateKeySigningKey(ctx, &route53.CreateKeySigningKeyInput{
+			// The generation makes the caller reference unique when the key is recreated after DNSSEC was disabled.
+			CallerReference:         aws.String(fmt.Sprintf("%s-ksk-%d", a.dnsZone.UID, a.dnsZone.Generation)),
+			HostedZoneId:            a.hostedZone.Id,
+			KeyManagementServiceArn: aws.String(a.dnsZone.Spec.AWS.DNSSECKMSKeyARN),
+			Name:                    aws.String(hiveKeySigningKeyName),
+			Status:                  aws.String("ACTIVE"),
+		}); err != nil {
+			logger.WithError(err).Error("cannot create DNSSEC key-signing key")
+			return err
+		}
+		changed = true
+	}
+	if aws.StringValue(dnssec.Status.ServeSignature) == "NOT_SIGNING" {
+		logger.Info("enabling DNSSEC signing of hosted zone")
+		if _, err := a.awsClient.EnableHostedZoneDNSSEC(ctx, &route53.EnableHostedZoneDNSSECInput{
+			HostedZoneId: a.hostedZone.Id,
+		}); err != nil {
+			logger.WithError(err).Error("cannot enable DNSSEC signing of hosted zone")
+			return err
+		}
+		changed = true
+	}
+	if changed {
+		dnssec, err = a.awsClient.GetDNSSEC(ctx, &route53.GetDNSSECInput{HostedZoneId: a.hostedZone.Id})
+		if err != nil {
+			logger.WithError(err).Error("cannot get DNSSEC status of hosted zone")
+			return err
+		}
+	}
+
+	status := &hivev1.AWSDNSSECStatus{
+		ServeSignature: aws.StringValue(dnssec.Status.ServeSignature),
+		StatusMessage:  aws.StringValue(dnssec.Status.StatusMessage),
+	}
+	if ksk := findKeySigningKey(dnssec.KeySigningKeys, hiveKeySigningKeyName); ksk != nil {
+		status.KeySigningKeyStatus = aws.StringValue(ksk.Status)
+		status.DSRecord = aws.StringValue(ksk.DSRecord)
+		if msg := aws.StringValue(ksk.StatusMessage); msg != "" {
+			status.StatusMessage = msg
+		}
+	}
+	a.dnsZone.Status.AWS.DNSSEC = status
+	return nil
+}
+
+// disableDNSSEC disables DNSSEC signing of the hosted zone, and deactivates and deletes the key-signing key created
+// by Hive. Route53 refuses to deactivate a key-signing key which is still referenced by a DS record in the parent
+// zone.
+func (a *AWSActuator) disableDNSSEC(ctx context.Context) error {
+	logger := a.logger.WithField("id", aws.StringValue(a.hostedZone.Id))
+	dnssec, err := a.awsClient.GetDNSSEC(ctx, &route53.GetDNSSECInput{HostedZoneId: a.hostedZone.Id})
+	if err != nil {
+		logger.WithError(err).Error("cannot get DNSSEC status of hosted zone")
+		return err
+	}
+	if aws.StringValue(dnssec.Status.ServeSignature) == "SIGNING" {
+		logger.Info("disabling DNSSEC signing of hosted zone")
+		if _, err := a.awsClient.DisableHostedZoneDNSSEC(ctx, &route53.DisableHostedZoneDNSSECInput{
+			HostedZoneId: a.hostedZone.Id,
+		}); err != nil {
+			logger.WithError(err).Error("cannot disable DNSSEC signing of hosted zone")
+			return err
+		}
+	}
+	ksk := findKeySigningKey(dnssec.KeySigningKeys, hiveKeySigningKeyName)
+	if ksk == nil {
+		return nil
+	}
+	if aws.StringValue(ksk.Status) == "ACTIVE" {
+		logger.Info("deactivating DNSSEC key-signing key")
+		if _, err := a.awsClient.DeactivateKeySigningKey(ctx, &route53.DeactivateKeySigningKeyInput{
+			HostedZoneId: a.hostedZone.Id,
+			Name:         ksk.Name,
+		}); err != nil {
+			logger.WithError(err).Error("cannot deactivate DNSSEC key-signing key")
+			return err
+		}
+	}
+	logger.Info("deleting DNSSEC key-signing key")
+	if _, err := a.awsClient.DeleteKeySigningKey(ctx, &route53.DeleteKeySigningKeyInput{
+		HostedZoneId: a.hostedZone.Id,
+		Name:         ksk.Name,
+	}); err != nil {
+		logger.WithError(err).Error("cannot delete DNSSEC key-signing key")
+		return err
+	}
+	return nil
+}
+
+func findKeySigningKey(keys []*route53.KeySigningKey, name string) *route53.KeySigningKey {
+	for _, key := range keys {
+		if aws.StringValue(key.Name) == name {
+			return key
+		}
+	}
+	return nil
+}
+
+// queryLoggingLogGroupARN returns the ARN of the log group receiving the query logs of the zone, or the empty string
+// if query logging is not enabled in the spec of the DNSZone.
+func (a *AWSActuator) queryLoggingLogGroupARN() string {
+	if a.dnsZone.Spec.AWS == nil || a.dnsZone.Spec.AWS.QueryLogging == nil {
+		return ""
+	}
+	return a.dnsZone.Spec.AWS.QueryLogging.CloudWatchLogsLogGroupARN
+}
+
+// queryLoggingConfigured returns whether Hive has configured query logging of the hosted zone.
+func (a *AWSActuator) queryLoggingConfigured() bool {
+	return a.dnsZone.Status.AWS != nil && a.dnsZone.Status.AWS.QueryLoggingConfigID != ""
+}
+
+// syncQueryLogging configures query logging of the hosted zone to the log group of the spec when query logging is
+// enabled, replacing a configuration logging to another log group, and deletes the configuration created by Hive
+// when it no longer is.
+func (a *AWSActuator) syncQueryLogging(ctx context.Context) error {
+	logGroupARN := a.queryLoggingLogGroupARN()
+	if logGroupARN == "" && !a.queryLoggingConfigured() {
+		return nil
+	}
+
+	logger := a.logger.WithField("id", aws.StringValue(a.hostedZone.Id))
+	resp, err := a.awsClient.ListQueryLoggingConfigs(ctx, &route53.ListQueryLoggingConfigsInput{
+		HostedZoneId: a.hostedZone.Id,
+	})
+	if err != nil {
+		logger.WithError(err).Error("cannot list query logging configurations of hosted zone")
+		return err
+	}
+	// A hosted zone has at most one query logging configuration.
+	var current *route53.QueryLoggingConfig
+	if len(resp.QueryLoggingConfigs) > 0 {
+		current = resp.QueryLoggingConfigs[0]
+	}
+
+	if logGroupARN == "" {
+		// Query logging configured outside of Hive is left alone.
+		if current != nil && aws.StringValue(current.Id) == a.dnsZone.Status.AWS.QueryLoggingConfigID {
+			logger.WithField("queryLoggingConfig", aws.StringValue(current.Id)).Info("deleting query logging configuration of hosted zone")
+			if err := a.deleteQueryLoggingConfig(ctx, current.Id); err != nil {
+				return err
+			}
+		}
+		a.dnsZone.Status.AWS.QueryLoggingConfigID = ""
+		return nil
+	}
+
+	if current != nil && aws.StringValue(current.CloudWatchLogsLogGroupArn) != logGroupARN {
+		logger.WithField("queryLoggingConfig", aws.StringValue(current.Id)).
+			WithField("logGroup", aws.StringValue(current.CloudWatchLogsLogGroupArn)).
+			Info("replacing query logging configuration of hosted zone logging to another log group")
+		if err := a.deleteQueryLoggingConfig(ctx, current.Id); err != nil {
+			return err
+		}
+		current = nil
+	}
+	if current == nil {
+		logger.WithField("logGroup", logGroupARN).Info("enabling query logging of hosted zone")
+		created, err := a.awsClient.CreateQueryLoggingConfig(ctx, &route53.CreateQueryLoggingConfigInput{
+			CloudWatchLogsLogGroupArn: aws.String(logGroupARN),
+			HostedZoneId:              a.hostedZone.Id,
+		})
+		if err != nil {
+			logger.WithError(err).Error("cannot create query logging configuration of hosted zone")
+			return err
+		}
+		current = created.QueryLoggingConfig
+	}
+	a.dnsZone.Status.AWS.QueryLoggingConfigID = aws.StringValue(current.Id)
+	return nil
+}
+
+func (a *AWSActuator) deleteQueryLoggingConfig(ctx context.Context, id *string) error {
+	if _, err := a.awsClient.DeleteQueryLoggingConfig(ctx, &route53.DeleteQueryLoggingConfigInput{Id: id}); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == route53.ErrCodeNoSuchQueryLoggingConfig {
+			return nil
+		}
+		a.logger.WithError(err).WithField("queryLoggingConfig", aws.StringValue(id)).Error("cannot delete query logging configuration")
+		return err
+	}
+	return nil
+}
+
+// private returns whether the zone is a Route53 private hosted zone.
+func (a *AWSActuator) private() bool {
+	return isAWSPrivateZone(a.dnsZone)
+}
+
+// syncVPCs associates the VPCs in the spec with the private hosted zone, and disassociates the VPCs that are not in
+// the spec. VPCs are associated first, as a private hosted zone must always be associated with at least one VPC.
+func (a *AWSActuator) syncVPCs(ctx context.Context) error {
+	logger := a.logger.WithField("id", aws.StringValue(a.hostedZone.Id))
+	vpcKey := func(vpcID, region string) string {
+		return fmt.Sprintf("%s/%s", region, vpcID)
+	}
+	existing := map[string]bool{}
+	for _, vpc := range a.hostedZoneVPCs {
+		existing[vpcKey(aws.StringValue(vpc.VPCId), aws.StringValue(vpc.VPCRegion))] = true
+	}
+	expected := map[string]bool{}
+	for _, vpc := range a.dnsZone.Spec.AWS.VPCs {
+		key := vpcKey(vpc.VPCID, vpc.Region)
+		expected[key] = true
+		if existing[key] {
+			continue
+		}
+		vpcLogger := logger.WithField("vpc", vpc.VPCID).WithField("region", vpc.Region)
+		vpcLogger.Info("associating VPC with hosted zone")
+		if _, err := a.awsClient.AssociateVPCWithHostedZone(ctx, &route53.AssociateVPCWithHostedZoneInput{
+			HostedZoneId: a.hostedZone.Id,
+			VPC: &route53.VPC{
+				VPCId:     aws.String(vpc.VPCID),
+				VPCRegion: aws.String(vpc.Region),
+			},
+		}); err != nil {
+			vpcLogger.WithError(err).Error("cannot associate VPC with hosted zone")
+			return err
+		}
+		a.hostedZoneVPCs = append(a.hostedZoneVPCs, &route53.VPC{
+			VPCId:     aws.String(vpc.VPCID),
+			VPCRegion: aws.String(vpc.Region),
+		})
+	}
+
+	remaining := []*route53.VPC{}
+	for _, vpc := range a.hostedZoneVPCs {
+		if expected[vpcKey(aws.StringValue(vpc.VPCId), aws.StringValue(vpc.VPCRegion))] {
+			remaining = append(remaining, vpc)
+			continue
+		}
+		vpcLogger := logger.WithField("vpc", aws.StringValue(vpc.VPCId)).WithField("region", aws.StringValue(vpc.VPCRegion))
+		vpcLogger.Info("disassociating VPC from hosted zone")
+		if _, err := a.awsClient.DisassociateVPCFromHostedZone(ctx, &route53.DisassociateVPCFromHostedZoneInput{
+			HostedZoneId: a.hostedZone.Id,
+			VPC:          vpc,
+		}); err != nil {
+			vpcLogger.WithError(err).Error("cannot disassociate VPC from hosted zone")
+			return err
+		}
+	}
+	a.hostedZoneVPCs = remaining
+	return nil
 }
 
 // syncTags determines if there are changes that need to happen to match tags in the spec
-func (a *AWSActuator) syncTags() error {
+func (a *AWSActuator) syncTags(ctx context.Context) error {
 	existingTags := a.currentHostedZoneTags
 	expected := a.expectedTags()
 	toAdd := []*route53.Tag{}
@@ -152,7 +469,7 @@ func (a *AWSActuator) syncTags() error {
 		}
 
 		logger.Debugf("Adding %d tags, deleting %d tags", len(toAddSegment), len(keysToDeleteSegment))
-		_, err := a.awsClient.ChangeTagsForResource(&route53.ChangeTagsForResourceInput{
+		_, err := a.awsClient.ChangeTagsForResource(ctx, &route53.ChangeTagsForResourceInput{
 			AddTags:       toAddSegment,
 			RemoveTagKeys: keysToDeleteSegment,
 			ResourceId:    a.hostedZone.Id,
@@ -174,9 +491,10 @@ func (a *AWSActuator) modifyStatus() error {
 		return errors.New("zoneID is unpopulated")
 	}
 
-	a.dnsZone.Status.AWS = &hivev1.AWSDNSZoneStatus{
-		ZoneID: a.hostedZone.Id,
+	if a.dnsZone.Status.AWS == nil {
+		a.dnsZone.Status.AWS = &hivev1.AWSDNSZoneStatus{}
 	}
+	a.dnsZone.Status.AWS.ZoneID = a.hostedZone.Id
 
 	return nil
 }
@@ -189,49 +507,64 @@ func min(a, b int) int {
 }
 
 // Refresh gets the AWS object for the zone.
-// If a zone cannot be found or no longer exists, actuator.zoneID remains unset.
-func (a *AWSActuator) Refresh() error {
+// If a zone cannot be found or no longer exists, actuator.hostedZone remains unset.
+func (a *AWSActuator) Refresh(ctx context.Context) error {
 	var zoneIDs []string
 	var err error
-	if a.dnsZone.Status.AWS != nil && a.dnsZone.Status.AWS.ZoneID != nil {
+	zoneIDsFromTags := false
+	adoptedZoneID := a.dnsZone.Spec.AWS.ZoneID
+	if adoptedZoneID != "" {
+		a.logger.WithField("id", adoptedZoneID).Debug("Zone ID to adopt is set in spec, will retrieve by ID")
+		zoneIDs = []string{adoptedZoneID}
+	} else if a.dnsZone.Status.AWS != nil && a.dnsZone.Status.AWS.ZoneID != nil {
 		a.logger.Debug("Zone ID is set in status, will retrieve by ID")
 		zoneIDs = []string{*a.dnsZone.Status.AWS.ZoneID}
 	}
 	if len(zoneIDs) == 0 {
 		a.logger.Debug("Zone ID is not set in status, looking up by tag")
-		zoneIDs, err = a.findZoneIDsByTag()
+		zoneIDs, err = a.cachedZoneIDsByTag(ctx)
 		if err != nil {
 			a.logger.WithError(err).Error("Failed to lookup zone by tag")
 			return err
 		}
+		zoneIDsFromTags = true
 	}
 	if len(zoneIDs) == 0 {
 		a.logger.Debug("No matching existing zone found")
 		return nil
 	}
 
-	// Fetch the hosted zone
+	// Fetch the hosted zones and their tags
+	lookups := a.lookupHostedZones(ctx, zoneIDs)
 	a.hostedZone = nil
-	for _, zoneID := range zoneIDs {
+	for i, zoneID := range zoneIDs {
+		lookup := lookups[i]
 		logger := a.logger.WithField("id", zoneID)
-		logger.Debug("Fetching hosted zone by ID")
-		resp, err := a.awsClient.GetHostedZone(&route53.GetHostedZoneInput{Id: aws.String(zoneID)})
-		if err != nil {
-			if awsErr, ok := err.(awserr.Error); ok {
+		if lookup.zoneErr != nil {
+			if awsErr, ok := lookup.zoneErr.(awserr.Error); ok {
 				if awsErr.Code() == route53.ErrCodeNoSuchHostedZone {
 					logger.Debug("Zone no longer exists")
 					continue
 				}
 			}
-			logger.WithError(err).Error("Cannot get hosted zone")
-			return err
+			logger.WithError(lookup.zoneErr).Error("Cannot get hosted zone")
+			return lookup.zoneErr
 		}
-		if name := *resp.HostedZone.Name; name != controllerutils.Dotted(a.dnsZone.Spec.Zone) {
+		if name := *lookup.zone.HostedZone.Name; name != controllerutils.Dotted(a.dnsZone.Spec.Zone) {
+			if adoptedZoneID != "" {
+				return fmt.Errorf("hosted zone %s to adopt is for %s, not %s", adoptedZoneID, name, a.dnsZone.Spec.Zone)
+			}
 			logger.WithField("zoneName", name).Debug("Zone name does not match expected name")
 			continue
 		}
+		if lookup.tagsErr != nil {
+			logger.WithError(lookup.tagsErr).Error("Cannot get hosted zone tags")
+			return lookup.tagsErr
+		}
 		logger.Debug("Found hosted zone")
-		a.hostedZone = resp.HostedZone
+		a.hostedZone = lookup.zone.HostedZone
+		a.hostedZoneVPCs = lookup.zone.VPCs
+		a.currentHostedZoneTags = lookup.tags
 
 		// Update dnsZone status now that we have the zoneID
 		if err := a.modifyStatus(); err != nil {
@@ -241,23 +574,69 @@ func (a *AWSActuator) Refresh() error {
 	}
 
 	if a.hostedZone == nil {
+		if zoneIDsFromTags {
+			// The cached zones are gone, search the tags again on the next refresh.
+			a.zoneIDs.Delete(string(a.dnsZone.UID))
+		}
 		a.logger.Debug("No existing zone found")
-		return nil
 	}
+	return nil
+}
 
-	logger := a.logger.WithField("id", a.hostedZone.Id)
-	logger.Debug("Fetching hosted zone tags")
-	tags, err := a.existingTags(a.hostedZone.Id)
-	if err != nil {
-		logger.WithError(err).Error("Cannot get hosted zone tags")
-		return err
+// awsHostedZoneLookup is the result of fetching a hosted zone and its tags.
+type awsHostedZoneLookup struct {
+	zone    *route53.GetHostedZoneOutput
+	zoneErr error
+	tags    []*route53.Tag
+	tagsErr error
+}
+
+// lookupHostedZones fetches the hosted zones with the given IDs and their tags concurrently. The results are in the
+// order of the IDs.
+func (a *AWSActuator) lookupHostedZones(ctx context.Context, zoneIDs []string) []awsHostedZoneLookup {
+	lookups := make([]awsHostedZoneLookup, len(zoneIDs))
+	var wg sync.WaitGroup
+	for i := range zoneIDs {
+		lookup := &lookups[i]
+		zoneID := aws.String(zoneIDs[i])
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			a.logger.WithField("id", *zoneID).Debug("Fetching hosted zone by ID")
+			lookup.zone, lookup.zoneErr = a.awsClient.GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: zoneID})
+		}()
+		go func() {
+			defer wg.Done()
+			lookup.tags, lookup.tagsErr = a.existingTags(ctx, zoneID)
+		}()
 	}
-	a.currentHostedZoneTags = tags
+	wg.Wait()
+	return lookups
+}
 
-	return nil
+// cachedZoneIDsByTag returns the IDs of the hosted zones tagged for the DNSZone, from the cache if they were found
+// recently.
+func (a *AWSActuator) cachedZoneIDsByTag(ctx context.Context) ([]string, error) {
+	zoneIDs, err := a.zoneIDs.GetOrLoad(string(a.dnsZone.UID), func() (interface{}, error) {
+		zoneIDs, err := a.findZoneIDsByTag(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(zoneIDs) == 0 {
+			return nil, errNoZoneWithTag
+		}
+		return zoneIDs, nil
+	})
+	if err == errNoZoneWithTag {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return zoneIDs.([]string), nil
 }
 
-func (a *AWSActuator) findZoneIDsByTag() ([]string, error) {
+func (a *AWSActuator) findZoneIDsByTag(ctx context.Context) ([]string, error) {
 	var ids []string
 	tagFilter := &resourcegroupstaggingapi.TagFilter{
 		Key:    aws.String(hiveDNSZoneAWSTag),
@@ -266,7 +645,7 @@ func (a *AWSActuator) findZoneIDsByTag() ([]string, error) {
 	filterString := fmt.Sprintf("%s=%s", aws.StringValue(tagFilter.Key), aws.StringValue(tagFilter.Values[0]))
 	a.logger.WithField("filter", filterString).Debug("Searching for zone by tag")
 	id := ""
-	err := a.awsClient.GetResourcesPages(&resourcegroupstaggingapi.GetResourcesInput{
+	err := a.awsClient.GetResourcesPages(ctx, &resourcegroupstaggingapi.GetResourcesInput{
 		ResourceTypeFilters: []*string{aws.String("route53:hostedzone")},
 		TagFilters:          []*resourcegroupstaggingapi.TagFilter{tagFilter},
 	}, func(resp *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
@@ -311,10 +690,10 @@ func (a *AWSActuator) expectedTags() []*route53.Tag {
 	return tags
 }
 
-func (a *AWSActuator) existingTags(zoneID *string) ([]*route53.Tag, error) {
+func (a *AWSActuator) existingTags(ctx context.Context, zoneID *string) ([]*route53.Tag, error) {
 	logger := a.logger.WithField("id", aws.StringValue(zoneID))
 	logger.Debug("listing existing tags for zone")
-	resp, err := a.awsClient.ListTagsForResource(&route53.ListTagsForResourceInput{
+	resp, err := a.awsClient.ListTagsForResource(ctx, &route53.ListTagsForResourceInput{
 		ResourceId:   zoneID,
 		ResourceType: aws.String("hostedzone"),
 	})
@@ -327,27 +706,54 @@ func (a *AWSActuator) existingTags(zoneID *string) ([]*route53.Tag, error) {
 }
 
 // Create makes an AWS Route53 hosted zone given the DNSZone object.
-func (a *AWSActuator) Create() error {
+func (a *AWSActuator) Create(ctx context.Context) error {
 	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+	if zoneID := a.dnsZone.Spec.AWS.ZoneID; zoneID != "" {
+		// Zones to adopt are never created, as that would silently replace the zone of the other tooling.
+		return fmt.Errorf("hosted zone %s to adopt does not exist", zoneID)
+	}
 	logger.Info("Creating route53 hostedzone")
 	var hostedZone *route53.HostedZone
-	resp, err := a.awsClient.CreateHostedZone(&route53.CreateHostedZoneInput{
+	var hostedZoneVPCs []*route53.VPC
+	input := &route53.CreateHostedZoneInput{
 		Name: aws.String(a.dnsZone.Spec.Zone),
 		// We use the UID of the HostedZone resource as the caller reference so that if
 		// we fail to update the status of the HostedZone with the ID of the recently
 		// created zone, we don't attempt to recreate it. Same if communication fails on
 		// the response from AWS.
 		CallerReference: aws.String(string(a.dnsZone.UID)),
-	})
+	}
+	if a.private() {
+		// A private hosted zone is created associated with the first VPC. The other VPCs are associated once the
+		// zone is created.
+		if len(a.dnsZone.Spec.AWS.VPCs) == 0 {
+			return errors.New("a private hosted zone must be associated with at least one VPC")
+		}
+		vpc := a.dnsZone.Spec.AWS.VPCs[0]
+		input.HostedZoneConfig = &route53.HostedZoneConfig{PrivateZone: aws.Bool(true)}
+		input.VPC = &route53.VPC{
+			VPCId:     aws.String(vpc.VPCID),
+			VPCRegion: aws.String(vpc.Region),
+		}
+	}
+	resp, err := a.awsClient.CreateHostedZone(ctx, input)
 	if err != nil {
 		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == route53.ErrCodeHostedZoneAlreadyExists {
 			// If the zone was already created, we need to find its ID
 			logger.WithField("callerRef", a.dnsZone.UID).Debug("Hosted zone already exists, looking up by caller reference")
-			hostedZone, err = a.findZoneByCallerReference(a.dnsZone.Spec.Zone, string(a.dnsZone.UID))
+			hostedZone, err = a.findZoneByCallerReference(ctx, a.dnsZone.Spec.Zone, string(a.dnsZone.UID))
 			if err != nil {
 				logger.Error("Failed to find zone by caller reference")
 				return err
 			}
+			if a.private() {
+				getResp, err := a.awsClient.GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: hostedZone.Id})
+				if err != nil {
+					logger.WithError(err).Error("Cannot get hosted zone")
+					return err
+				}
+				hostedZoneVPCs = getResp.VPCs
+			}
 		} else {
 			logger.WithError(err).Error("Error creating hosted zone")
 			return err
@@ -355,17 +761,21 @@ func (a *AWSActuator) Create() error {
 	} else {
 		logger.Debug("Hosted zone successfully created")
 		hostedZone = resp.HostedZone
+		if resp.VPC != nil {
+			hostedZoneVPCs = []*route53.VPC{resp.VPC}
+		}
 	}
 
 	logger = logger.WithField("id", aws.StringValue(hostedZone.Id))
 	logger.Debug("Fetching zone tags")
-	existingTags, err := a.existingTags(hostedZone.Id)
+	existingTags, err := a.existingTags(ctx, hostedZone.Id)
 	if err != nil {
 		logger.WithError(err).Error("Failed to fetch zone tags")
 		return err
 	}
 
 	a.hostedZone = hostedZone
+	a.hostedZoneVPCs = hostedZoneVPCs
 	if err := a.modifyStatus(); err != nil {
 		logger.WithError(err).Error("failed to populate DNSZone status")
 		return err
@@ -373,7 +783,7 @@ func (a *AWSActuator) Create() error {
 	a.currentHostedZoneTags = existingTags
 
 	logger.Debug("Syncing zone tags")
-	err = a.syncTags()
+	err = a.syncTags(ctx)
 	if err != nil {
 		// When an error occurs tagging the resource, we return an error. This will result in a retry of the create call.
 		// Because we're using the DNSZone's UID as the CallerReference, the create should succeed without creating a duplicate
@@ -382,17 +792,35 @@ func (a *AWSActuator) Create() error {
 		return err
 	}
 
+	if a.private() {
+		logger.Debug("Syncing zone VPCs")
+		if err := a.syncVPCs(ctx); err != nil {
+			logger.WithError(err).Error("Failed to associate VPCs with newly created zone")
+			return err
+		}
+	}
+
+	if err := a.syncDNSSEC(ctx); err != nil {
+		logger.WithError(err).Error("Failed to enable DNSSEC on newly created zone")
+		return err
+	}
+
+	if err := a.syncQueryLogging(ctx); err != nil {
+		logger.WithError(err).Error("Failed to enable query logging on newly created zone")
+		return err
+	}
+
 	return err
 }
 
-func (a *AWSActuator) findZoneByCallerReference(domain, callerRef string) (*route53.HostedZone, error) {
+func (a *AWSActuator) findZoneByCallerReference(ctx context.Context, domain, callerRef string) (*route53.HostedZone, error) {
 	logger := a.logger.WithField("domain", domain).WithField("callerRef", callerRef)
 	logger.Debug("Searching for zone by domain and callerRef")
 	var nextZoneID *string
 	var nextName = aws.String(domain)
 	for {
 		logger.Debug("listing hosted zones by name")
-		resp, err := a.awsClient.ListHostedZonesByName(&route53.ListHostedZonesByNameInput{
+		resp, err := a.awsClient.ListHostedZonesByName(ctx, &route53.ListHostedZonesByNameInput{
 			DNSName:      nextName,
 			HostedZoneId: nextZoneID,
 			MaxItems:     aws.String("50"),
@@ -421,20 +849,35 @@ func (a *AWSActuator) findZoneByCallerReference(domain, callerRef string) (*rout
 }
 
 // Delete removes an AWS Route53 hosted zone, typically because the DNSZone object is in a deleting state.
-func (a *AWSActuator) Delete() error {
+func (a *AWSActuator) Delete(ctx context.Context) error {
 	if a.hostedZone == nil {
 		return errors.New("hostedZone is unpopulated")
 	}
 
 	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("id", aws.StringValue(a.hostedZone.Id))
 
+	if a.dnssecEnabled() || a.dnssecConfigured() {
+		// The key-signing key must be deleted before the hosted zone can be.
+		logger.Info("Disabling DNSSEC of hostedzone")
+		if err := a.disableDNSSEC(ctx); err != nil {
+			return err
+		}
+	}
+
+	if a.queryLoggingConfigured() {
+		logger.Info("Deleting query logging configuration of hostedzone")
+		if err := a.deleteQueryLoggingConfig(ctx, aws.String(a.dnsZone.Status.AWS.QueryLoggingConfigID)); err != nil {
+			return err
+		}
+	}
+
 	logger.Info("Deleting route53 recordsets in hostedzone")
-	if err := DeleteAWSRecordSets(a.awsClient, a.dnsZone, logger); err != nil {
+	if err := DeleteAWSRecordSets(ctx, a.awsClient, a.dnsZone, logger); err != nil {
 		return err
 	}
 
 	logger.Info("Deleting route53 hostedzone")
-	_, err := a.awsClient.DeleteHostedZone(&route53.DeleteHostedZoneInput{
+	_, err := a.awsClient.DeleteHostedZone(ctx, &route53.DeleteHostedZoneInput{
 		Id: a.hostedZone.Id,
 	})
 	if err != nil {
@@ -443,12 +886,14 @@ func (a *AWSActuator) Delete() error {
 			logLevel = log.InfoLevel
 		}
 		log.WithError(err).Log(logLevel, "Cannot delete hosted zone")
+		return err
 	}
-	return err
+	a.zoneIDs.Delete(string(a.dnsZone.UID))
+	return nil
 }
 
 // DeleteAWSRecordSets will clean up a DNS zone down to the minimum required record entries
-func DeleteAWSRecordSets(awsClient awsclient.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
+func DeleteAWSRecordSets(ctx context.Context, awsClient awsclient.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
 
 	maxItems := "100"
 	listInput := &route53.ListResourceRecordSetsInput{
@@ -456,7 +901,7 @@ func DeleteAWSRecordSets(awsClient awsclient.Client, dnsZone *hivev1.DNSZone, lo
 		MaxItems:     &maxItems,
 	}
 	for {
-		listOutput, err := awsClient.ListResourceRecordSets(listInput)
+		listOutput, err := awsClient.ListResourceRecordSets(ctx, listInput)
 		if err != nil {
 			return err
 		}
@@ -466,6 +911,10 @@ func DeleteAWSRecordSets(awsClient awsclient.Client, dnsZone *hivev1.DNSZone, lo
 			if n, t := aws.StringValue(recordSet.Name), aws.StringValue(recordSet.Type); n == controllerutils.Dotted(dnsZone.Spec.Zone) && (t == route53.RRTypeNs || t == route53.RRTypeSoa) {
 				continue
 			}
+			if isPreservedRecordSet(dnsZone, aws.StringValue(recordSet.Name)) {
+				logger.WithField("name", aws.StringValue(recordSet.Name)).WithField("type", aws.StringValue(recordSet.Type)).Info("preserving recordset")
+				continue
+			}
 
 			logger.WithField("name", aws.StringValue(recordSet.Name)).WithField("type", aws.StringValue(recordSet.Type)).Info("recordset set for deletion")
 			changes = append(changes, &route53.Change{
@@ -475,7 +924,7 @@ func DeleteAWSRecordSets(awsClient awsclient.Client, dnsZone *hivev1.DNSZone, lo
 		}
 		if len(changes) > 0 {
 			logger.WithField("count", len(changes)).Info("deleting recordsets")
-			if _, err := awsClient.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+			if _, err := awsClient.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
 				ChangeBatch:  &route53.ChangeBatch{Changes: changes},
 				HostedZoneId: dnsZone.Status.AWS.ZoneID,
 			}); err != nil {
@@ -493,15 +942,50 @@ func DeleteAWSRecordSets(awsClient awsclient.Client, dnsZone *hivev1.DNSZone, lo
 
 }
 
+// ListRecordSets implements the ListRecordSets call of the RecordSetLister interface
+func (a *AWSActuator) ListRecordSets(ctx context.Context) ([]RecordSet, error) {
+	if a.hostedZone == nil {
+		return nil, errors.New("hostedZone is unpopulated")
+	}
+
+	var recordSets []RecordSet
+	listInput := &route53.ListResourceRecordSetsInput{
+		HostedZoneId: a.hostedZone.Id,
+		MaxItems:     aws.String("100"),
+	}
+	for {
+		listOutput, err := a.awsClient.ListResourceRecordSets(ctx, listInput)
+		if err != nil {
+			return nil, err
+		}
+		for _, recordSet := range listOutput.ResourceRecordSets {
+			recordSets = append(recordSets, RecordSet{
+				Name: aws.StringValue(recordSet.Name),
+				Type: aws.StringValue(recordSet.Type),
+			})
+		}
+		if !aws.BoolValue(listOutput.IsTruncated) {
+			return recordSets, nil
+		}
+		listInput.StartRecordIdentifier = listOutput.NextRecordIdentifier
+		listInput.StartRecordName = listOutput.NextRecordName
+		listInput.StartRecordType = listOutput.NextRecordType
+	}
+}
+
 // GetNameServers returns the nameservers listed in the route53 hosted zone NS record.
-func (a *AWSActuator) GetNameServers() ([]string, error) {
+func (a *AWSActuator) GetNameServers(ctx context.Context) ([]string, error) {
 	if a.hostedZone == nil {
 		return nil, errors.New("hostedZone is unpopulated")
 	}
+	if a.private() {
+		// The name servers of private hosted zones only serve the associated VPCs, and cannot be delegated to.
+		return nil, nil
+	}
 
 	logger := a.logger.WithField("id", a.hostedZone.Id)
 	logger.Debug("Listing hosted zone NS records")
-	resp, err := a.awsClient.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+	resp, err := a.awsClient.ListResourceRecordSets(ctx, &route53.ListResourceRecordSetsInput{
 		HostedZoneId:    aws.String(*a.hostedZone.Id),
 		StartRecordType: aws.String("NS"),
 		StartRecordName: aws.String(a.dnsZone.Spec.Zone),
@@ -535,7 +1019,7 @@ func (a *AWSActuator) GetNameServers() ([]string, error) {
 }
 
 // Exists determines if the route53 hosted zone corresponding to the DNSZone exists
-func (a *AWSActuator) Exists() (bool, error) {
+func (a *AWSActuator) Exists(ctx context.Context) (bool, error) {
 	return a.hostedZone != nil, nil
 }
 
@@ -555,15 +1039,23 @@ func (a *AWSActuator) setInsufficientCredentialsConditionToFalse() bool {
 	return accessDeniedCondsChanged
 }
 
-func (a *AWSActuator) setInsufficientCredentialsConditionToTrue(message string) bool {
+func (a *AWSActuator) setInsufficientCredentialsConditionToTrue(ctx context.Context) bool {
+	// FIXME: including the error message as is leads to status update hotloop when
+	// error message includes a dynamically generated AWS user https://issues.redhat.com/browse/HIVE-1542
+	message := "AccessDenied error encountered (see controller logs for details)"
+	// Report the permissions that are missing when they can be determined.
+	switch missing, err := preflight.MissingAWSPermissions(ctx, a.awsClient, preflight.OperationDNS); {
+	case err != nil:
+		a.logger.WithError(err).Info("could not determine the missing permissions")
+	case len(missing) > 0:
+		message = preflight.MissingPermissionsMessage(missing)
+	}
 	accessDeniedConds, accessDeniedCondsChanged := controllerutils.SetDNSZoneConditionWithChangeCheck(
 		a.dnsZone.Status.Conditions,
 		hivev1.InsufficientCredentialsCondition,
 		corev1.ConditionTrue,
 		accessDeniedReason,
-		// FIXME: including the error message as is leads to status update hotloop when
-		// error message includes a dynamically generated AWS user https://issues.redhat.com/browse/HIVE-1542
-		"AccessDenied error encountered (see controller logs for details)",
+		message,
 		controllerutils.UpdateConditionIfReasonOrMessageChange,
 	)
 
@@ -610,7 +1102,7 @@ func (a *AWSActuator) setAuthenticationFailureConditionToTrue(message string) bo
 }
 
 // SetConditionsForError sets conditions on the dnszone given a specific error. Returns true if conditions changed.
-func (a *AWSActuator) SetConditionsForError(err error) bool {
+func (a *AWSActuator) SetConditionsForError(ctx context.Context, err error) bool {
 	awsErr, ok := err.(awserr.Error)
 	if !ok {
 		accessDeniedCondsChanged := a.setInsufficientCredentialsConditionToFalse()
@@ -623,7 +1115,7 @@ func (a *AWSActuator) SetConditionsForError(err error) bool {
 	authenticationFailureCondsChanged := false
 
 	if awsErr.Code() == "AccessDeniedException" || awsErr.Code() == "AccessDenied" {
-		accessDeniedCondsChanged = a.setInsufficientCredentialsConditionToTrue(awsErr.Message())
+		accessDeniedCondsChanged = a.setInsufficientCredentialsConditionToTrue(ctx)
 	} else {
 		accessDeniedCondsChanged = a.setInsufficientCredentialsConditionToFalse()
 	}
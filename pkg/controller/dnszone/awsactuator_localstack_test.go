@@ -0,0 +1,88 @@
+// +build localstack
+
+package dnszone
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/test/localstack"
+)
+
+func TestAWSActuatorLocalStack(t *testing.T) {
+	h := localstack.New(t)
+
+	zone := localstack.UniqueName("hive") + ".example.com"
+	dnsZone := &hivev1.DNSZone{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: localstack.Namespace,
+			Name:      "dnszone",
+			UID:       types.UID(localstack.UniqueName("uid")),
+		},
+		Spec: hivev1.DNSZoneSpec{
+			Zone: zone,
+			AWS: &hivev1.AWSDNSZoneSpec{
+				Region:         localstack.Region,
+				AdditionalTags: []hivev1.AWSResourceTag{{Key: "team", Value: "hive"}},
+			},
+		},
+	}
+	credentials := awsclient.CredentialsSource{
+		Secret: &awsclient.SecretCredentialsSource{
+			Namespace: localstack.Namespace,
+			Ref:       &corev1.LocalObjectReference{Name: localstack.CredentialsSecretName},
+		},
+	}
+	newActuator := func() *AWSActuator {
+		actuator, err := NewAWSActuator(log.WithField("test", t.Name()), h.KubeClient, credentials, dnsZone, h.ClientBuilder())
+		require.NoError(t, err, "unexpected error creating actuator")
+		return actuator
+	}
+
+	require.NoError(t, newActuator().Create(context.TODO()), "unexpected error creating hosted zone")
+	require.NotNil(t, dnsZone.Status.AWS, "expected zone ID in status")
+	zoneID := aws.StringValue(dnsZone.Status.AWS.ZoneID)
+	t.Cleanup(func() {
+		// Best effort, the zone is expected to be deleted by the test.
+		h.Client(t).DeleteHostedZone(context.TODO(), &route53.DeleteHostedZoneInput{Id: aws.String(zoneID)})
+	})
+
+	actuator := newActuator()
+	require.NoError(t, actuator.Refresh(context.TODO()), "unexpected error refreshing zone")
+	exists, err := actuator.Exists(context.TODO())
+	require.NoError(t, err, "unexpected error checking zone existence")
+	require.True(t, exists, "expected zone to exist")
+	for _, expected := range actuator.expectedTags() {
+		found := false
+		for _, tag := range actuator.currentHostedZoneTags {
+			found = found || tagEquals(expected, tag)
+		}
+		assert.True(t, found, "missing zone tag %s", tagString(expected))
+	}
+	nameServers, err := actuator.GetNameServers(context.TODO())
+	require.NoError(t, err, "unexpected error getting name servers")
+	assert.NotEmpty(t, nameServers, "expected name servers")
+
+	// More record sets than fit in a page of ListResourceRecordSets, so that deleting the zone has to page through
+	// the record sets.
+	h.CreateRecordSets(t, zoneID, zone, 250)
+	require.NoError(t, actuator.Delete(context.TODO()), "unexpected error deleting zone")
+
+	actuator = newActuator()
+	require.NoError(t, actuator.Refresh(context.TODO()), "unexpected error refreshing deleted zone")
+	exists, err = actuator.Exists(context.TODO())
+	require.NoError(t, err, "unexpected error checking zone existence")
+	assert.False(t, exists, "expected zone to be deleted")
+}
@@ -1,8 +1,10 @@
 package dnszone
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -13,11 +15,15 @@ import (
 	"github.com/openshift/hive/pkg/awsclient/mock"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 func init() {
@@ -62,11 +68,76 @@ func TestNewAWSActuator(t *testing.T) {
 	}
 }
 
+// TestNewAWSActuatorAssumeRole tests that the AWS client of the actuator assumes the role of the DNSZone.
+func TestNewAWSActuatorAssumeRole(t *testing.T) {
+	dnsZone := validDNSZone()
+	dnsZone.Spec.AWS.AssumeRole = &hivev1aws.AssumeRole{
+		RoleARN:    "arn:aws:iam::123456789012:role/dns-manager",
+		ExternalID: "external-id",
+	}
+	var options awsclient.Options
+	_, err := NewAWSActuator(
+		log.WithField("controller", ControllerName),
+		nil, awsclient.CredentialsSource{},
+		dnsZone,
+		func(_ client.Client, o awsclient.Options) (awsclient.Client, error) {
+			options = o
+			return nil, nil
+		},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, dnsZone.Spec.AWS.AssumeRole, options.AssumeRole, "expected the role of the DNSZone to be assumed")
+}
+
+// TestAWSRefreshCachesZoneIDsByTag tests that the hosted zones found by tag are cached across refreshes until they no
+// longer exist.
+func TestAWSRefreshCachesZoneIDsByTag(t *testing.T) {
+	mocks := setupDefaultMocks(t)
+	defer mocks.mockCtrl.Finish()
+	cache := controllerutils.NewExpiringCache("test_aws_dnszone_zone_ids", time.Minute, 10)
+	refresh := func() *AWSActuator {
+		actuator, err := NewAWSActuator(
+			log.WithField("controller", ControllerName),
+			nil, awsclient.CredentialsSource{},
+			validDNSZoneWithoutID(),
+			fakeAWSClientBuilder(mocks.mockAWSClient),
+		)
+		require.NoError(t, err)
+		actuator.zoneIDs = cache
+		require.NoError(t, actuator.Refresh(context.TODO()))
+		return actuator
+	}
+	expect := mocks.mockAWSClient.EXPECT()
+
+	// The first refresh searches the tags
+	mockAWSZoneExists(expect, validDNSZoneWithoutID())
+	mockExistingAWSTags(expect)
+	actuator := refresh()
+	assert.NotNil(t, actuator.hostedZone, "expected the zone to be found")
+	assert.Equal(t, "1234", aws.StringValue(actuator.dnsZone.Status.AWS.ZoneID))
+
+	// The second refresh uses the cached zone ID
+	mockAWSZoneExists(expect, validDNSZone())
+	mockExistingAWSTags(expect)
+	actuator = refresh()
+	assert.NotNil(t, actuator.hostedZone, "expected the cached zone to be found")
+
+	// The cached zone no longer exists, and is evicted
+	mockAWSZoneDoesntExist(expect, validDNSZone())
+	actuator = refresh()
+	assert.Nil(t, actuator.hostedZone, "expected the deleted zone not to be found")
+
+	// The tags are searched again
+	mockAWSZoneDoesntExist(expect, validDNSZoneWithoutID())
+	actuator = refresh()
+	assert.Nil(t, actuator.hostedZone)
+}
+
 func mockAWSZoneExists(expect *mock.MockClientMockRecorder, zone *hivev1.DNSZone) {
 
 	if zone.Status.AWS == nil || aws.StringValue(zone.Status.AWS.ZoneID) == "" {
-		expect.GetResourcesPages(gomock.Any(), gomock.Any()).
-			Do(func(input *resourcegroupstaggingapi.GetResourcesInput, f func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) {
+		expect.GetResourcesPages(gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_ context.Context, input *resourcegroupstaggingapi.GetResourcesInput, f func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) {
 				f(&resourcegroupstaggingapi.GetResourcesOutput{
 					ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
 						{
@@ -76,7 +147,7 @@ func mockAWSZoneExists(expect *mock.MockClientMockRecorder, zone *hivev1.DNSZone
 				}, true)
 			}).Return(nil).Times(1)
 	}
-	expect.GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
+	expect.GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
 		HostedZone: &route53.HostedZone{
 			Id:   aws.String("1234"),
 			Name: aws.String("blah.example.com."),
@@ -86,15 +157,18 @@ func mockAWSZoneExists(expect *mock.MockClientMockRecorder, zone *hivev1.DNSZone
 
 func mockAWSZoneDoesntExist(expect *mock.MockClientMockRecorder, zone *hivev1.DNSZone) {
 	if zone.Status.AWS != nil && aws.StringValue(zone.Status.AWS.ZoneID) != "" {
-		expect.GetHostedZone(gomock.Any()).
+		expect.GetHostedZone(gomock.Any(), gomock.Any()).
+			Return(nil, awserr.New(route53.ErrCodeNoSuchHostedZone, "doesnt exist", fmt.Errorf("doesnt exist"))).Times(1)
+		// The tags are fetched along with the zone
+		expect.ListTagsForResource(gomock.Any(), gomock.Any()).
 			Return(nil, awserr.New(route53.ErrCodeNoSuchHostedZone, "doesnt exist", fmt.Errorf("doesnt exist"))).Times(1)
 		return
 	}
-	expect.GetResourcesPages(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	expect.GetResourcesPages(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 }
 
 func mockCreateAWSZone(expect *mock.MockClientMockRecorder) {
-	expect.CreateHostedZone(gomock.Any()).Return(&route53.CreateHostedZoneOutput{
+	expect.CreateHostedZone(gomock.Any(), gomock.Any()).Return(&route53.CreateHostedZoneOutput{
 		HostedZone: &route53.HostedZone{
 			Id:   aws.String("1234"),
 			Name: aws.String("blah.example.com."),
@@ -102,12 +176,97 @@ func mockCreateAWSZone(expect *mock.MockClientMockRecorder) {
 	}, nil).Times(1)
 }
 
+func mockAWSPrivateZoneExists(expect *mock.MockClientMockRecorder, vpcs ...*route53.VPC) {
+	expect.GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
+		HostedZone: &route53.HostedZone{
+			Id:     aws.String("1234"),
+			Name:   aws.String("blah.example.com."),
+			Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(true)},
+		},
+		VPCs: vpcs,
+	}, nil).Times(1)
+}
+
+func mockCreateAWSPrivateZone(expect *mock.MockClientMockRecorder) {
+	expect.CreateHostedZone(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, input *route53.CreateHostedZoneInput) (*route53.CreateHostedZoneOutput, error) {
+		if !aws.BoolValue(input.HostedZoneConfig.PrivateZone) {
+			return nil, fmt.Errorf("expected a private hosted zone")
+		}
+		return &route53.CreateHostedZoneOutput{
+			HostedZone: &route53.HostedZone{
+				Id:     aws.String("1234"),
+				Name:   aws.String("blah.example.com."),
+				Config: input.HostedZoneConfig,
+			},
+			VPC: input.VPC,
+		}, nil
+	}).Times(1)
+}
+
+func awsVPC(vpcID, region string) *route53.VPC {
+	return &route53.VPC{VPCId: aws.String(vpcID), VPCRegion: aws.String(region)}
+}
+
+func mockAWSGetDNSSEC(expect *mock.MockClientMockRecorder, serveSignature string, keys ...*route53.KeySigningKey) *gomock.Call {
+	return expect.GetDNSSEC(gomock.Any(), &route53.GetDNSSECInput{HostedZoneId: aws.String("1234")}).Return(&route53.GetDNSSECOutput{
+		Status:         &route53.DNSSECStatus{ServeSignature: aws.String(serveSignature)},
+		KeySigningKeys: keys,
+	}, nil).Times(1)
+}
+
+func awsKeySigningKey(status string) *route53.KeySigningKey {
+	return &route53.KeySigningKey{
+		Name:     aws.String(hiveKeySigningKeyName),
+		Status:   aws.String(status),
+		DSRecord: aws.String("12345 13 2 ABCDEF"),
+	}
+}
+
+func mockDisableAWSDNSSEC(expect *mock.MockClientMockRecorder) {
+	expect.DisableHostedZoneDNSSEC(gomock.Any(), &route53.DisableHostedZoneDNSSECInput{
+		HostedZoneId: aws.String("1234"),
+	}).Return(&route53.DisableHostedZoneDNSSECOutput{}, nil).Times(1)
+	expect.DeactivateKeySigningKey(gomock.Any(), &route53.DeactivateKeySigningKeyInput{
+		HostedZoneId: aws.String("1234"),
+		Name:         aws.String(hiveKeySigningKeyName),
+	}).Return(&route53.DeactivateKeySigningKeyOutput{}, nil).Times(1)
+	expect.DeleteKeySigningKey(gomock.Any(), &route53.DeleteKeySigningKeyInput{
+		HostedZoneId: aws.String("1234"),
+		Name:         aws.String(hiveKeySigningKeyName),
+	}).Return(&route53.DeleteKeySigningKeyOutput{}, nil).Times(1)
+}
+
+func mockAWSListQueryLoggingConfigs(expect *mock.MockClientMockRecorder, configs ...*route53.QueryLoggingConfig) *gomock.Call {
+	return expect.ListQueryLoggingConfigs(gomock.Any(), &route53.ListQueryLoggingConfigsInput{HostedZoneId: aws.String("1234")}).
+		Return(&route53.ListQueryLoggingConfigsOutput{QueryLoggingConfigs: configs}, nil).Times(1)
+}
+
+func mockCreateAWSQueryLoggingConfig(expect *mock.MockClientMockRecorder, id, logGroupARN string) *gomock.Call {
+	return expect.CreateQueryLoggingConfig(gomock.Any(), &route53.CreateQueryLoggingConfigInput{
+		CloudWatchLogsLogGroupArn: aws.String(logGroupARN),
+		HostedZoneId:              aws.String("1234"),
+	}).Return(&route53.CreateQueryLoggingConfigOutput{QueryLoggingConfig: awsQueryLoggingConfig(id, logGroupARN)}, nil).Times(1)
+}
+
+func mockDeleteAWSQueryLoggingConfig(expect *mock.MockClientMockRecorder, id string) *gomock.Call {
+	return expect.DeleteQueryLoggingConfig(gomock.Any(), &route53.DeleteQueryLoggingConfigInput{Id: aws.String(id)}).
+		Return(&route53.DeleteQueryLoggingConfigOutput{}, nil).Times(1)
+}
+
+func awsQueryLoggingConfig(id, logGroupARN string) *route53.QueryLoggingConfig {
+	return &route53.QueryLoggingConfig{
+		Id:                        aws.String(id),
+		HostedZoneId:              aws.String("1234"),
+		CloudWatchLogsLogGroupArn: aws.String(logGroupARN),
+	}
+}
+
 func mockCreateAWSZoneDuplicateFailure(expect *mock.MockClientMockRecorder) {
-	expect.CreateHostedZone(gomock.Any()).Return(nil, awserr.New(route53.ErrCodeHostedZoneAlreadyExists, "already exists", fmt.Errorf("already exists"))).Times(1)
+	expect.CreateHostedZone(gomock.Any(), gomock.Any()).Return(nil, awserr.New(route53.ErrCodeHostedZoneAlreadyExists, "already exists", fmt.Errorf("already exists"))).Times(1)
 }
 
 func mockNoExistingAWSTags(expect *mock.MockClientMockRecorder) {
-	expect.ListTagsForResource(gomock.Any()).Return(&route53.ListTagsForResourceOutput{
+	expect.ListTagsForResource(gomock.Any(), gomock.Any()).Return(&route53.ListTagsForResourceOutput{
 		ResourceTagSet: &route53.ResourceTagSet{
 			ResourceId: aws.String("1234"),
 			Tags:       []*route53.Tag{},
@@ -116,7 +275,7 @@ func mockNoExistingAWSTags(expect *mock.MockClientMockRecorder) {
 }
 
 func mockExistingAWSTags(expect *mock.MockClientMockRecorder) {
-	expect.ListTagsForResource(gomock.Any()).Return(&route53.ListTagsForResourceOutput{
+	expect.ListTagsForResource(gomock.Any(), gomock.Any()).Return(&route53.ListTagsForResourceOutput{
 		ResourceTagSet: &route53.ResourceTagSet{
 			ResourceId: aws.String("1234"),
 			Tags: []*route53.Tag{
@@ -134,11 +293,11 @@ func mockExistingAWSTags(expect *mock.MockClientMockRecorder) {
 }
 
 func mockSyncAWSTags(expect *mock.MockClientMockRecorder) {
-	expect.ChangeTagsForResource(gomock.Any()).Return(&route53.ChangeTagsForResourceOutput{}, nil).AnyTimes()
+	expect.ChangeTagsForResource(gomock.Any(), gomock.Any()).Return(&route53.ChangeTagsForResourceOutput{}, nil).AnyTimes()
 }
 
 func mockAWSGetNSRecord(expect *mock.MockClientMockRecorder) {
-	expect.ListResourceRecordSets(gomock.Any()).Return(&route53.ListResourceRecordSetsOutput{
+	expect.ListResourceRecordSets(gomock.Any(), gomock.Any()).Return(&route53.ListResourceRecordSetsOutput{
 		ResourceRecordSets: []*route53.ResourceRecordSet{
 			{
 				Type: aws.String("NS"),
@@ -156,8 +315,24 @@ func mockAWSGetNSRecord(expect *mock.MockClientMockRecorder) {
 	}, nil)
 }
 
+func mockAWSListRecordSets(expect *mock.MockClientMockRecorder) {
+	expect.ListResourceRecordSets(gomock.Any(), gomock.Any()).Return(&route53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []*route53.ResourceRecordSet{
+			{Type: aws.String("NS"), Name: aws.String("blah.example.com.")},
+			{Type: aws.String("SOA"), Name: aws.String("blah.example.com.")},
+			{Type: aws.String("A"), Name: aws.String("api.blah.example.com.")},
+		},
+	}, nil).Times(1)
+}
+
+func validAWSRecordSetSummaryDNSZone() *hivev1.DNSZone {
+	zone := validDNSZone()
+	zone.Spec.PublishRecordSetSummary = true
+	return zone
+}
+
 func mockListAWSZonesByNameFound(expect *mock.MockClientMockRecorder, zone *hivev1.DNSZone) {
-	expect.ListHostedZonesByName(gomock.Any()).Return(&route53.ListHostedZonesByNameOutput{
+	expect.ListHostedZonesByName(gomock.Any(), gomock.Any()).Return(&route53.ListHostedZonesByNameOutput{
 		HostedZones: []*route53.HostedZone{
 			{
 				Id:              aws.String("1234"),
@@ -169,12 +344,12 @@ func mockListAWSZonesByNameFound(expect *mock.MockClientMockRecorder, zone *hive
 }
 
 func mockDeleteAWSZone(expect *mock.MockClientMockRecorder) {
-	expect.ListResourceRecordSets(gomock.Any()).Return(&route53.ListResourceRecordSetsOutput{}, nil).Times(1)
-	expect.DeleteHostedZone(gomock.Any()).Return(nil, nil).Times(1)
+	expect.ListResourceRecordSets(gomock.Any(), gomock.Any()).Return(&route53.ListResourceRecordSetsOutput{}, nil).Times(1)
+	expect.DeleteHostedZone(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 }
 
 func mockGetResourcePages(expect *mock.MockClientMockRecorder) {
-	expect.GetResourcesPages(gomock.Any(), gomock.Any()).Return(nil).Do(func(i *resourcegroupstaggingapi.GetResourcesInput, f func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) {
+	expect.GetResourcesPages(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Do(func(_ context.Context, i *resourcegroupstaggingapi.GetResourcesInput, f func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) {
 		getResourcesOutput := &resourcegroupstaggingapi.GetResourcesOutput{
 			ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
 				{
@@ -4,15 +4,20 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
+	"github.com/Azure/go-autorest/autorest/to"
 	log "github.com/sirupsen/logrus"
 
 	corev1 "k8s.io/api/core/v1"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	"github.com/openshift/hive/pkg/azureclient"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 // AzureActuator attempts to make the current state reflect the given desired state.
@@ -28,9 +33,19 @@ type AzureActuator struct {
 
 	// managedZone is the Azure DNS Managed zone object.
 	managedZone *dns.Zone
+
+	// privateZone is the Azure Private DNS zone object, for Private zones.
+	privateZone *privatedns.PrivateZone
+
+	// virtualNetworkLinks are the virtual network links of the Private zone.
+	virtualNetworkLinks []privatedns.VirtualNetworkLink
 }
 
-type azureClientBuilderType func(secret *corev1.Secret) (azureclient.Client, error)
+// azureDNSZoneTag is the tag on the virtual network links created by Hive, set to the namespace and name of the
+// DNSZone.
+const azureDNSZoneTag = "hive-dnszone"
+
+type azureClientBuilderType func(secret *corev1.Secret, cloudName hivev1azure.CloudEnvironment) (azureclient.Client, error)
 
 // NewAzureActuator creates a new NewAzureActuator object. A new NewAzureActuator is expected to be created for each controller sync.
 func NewAzureActuator(
@@ -39,7 +54,11 @@ func NewAzureActuator(
 	dnsZone *hivev1.DNSZone,
 	azureClientBuilder azureClientBuilderType,
 ) (*AzureActuator, error) {
-	azureClient, err := azureClientBuilder(secret)
+	var cloudName hivev1azure.CloudEnvironment
+	if dnsZone.Spec.Azure != nil {
+		cloudName = dnsZone.Spec.Azure.CloudName
+	}
+	azureClient, err := azureClientBuilder(secret, cloudName)
 	if err != nil {
 		logger.WithError(err).Error("Error creating AzureClient")
 		return nil, err
@@ -57,15 +76,26 @@ func NewAzureActuator(
 // Ensure AzureActuator implements the Actuator interface. This will fail at compile time when false.
 var _ Actuator = &AzureActuator{}
 
+// Ensure AzureActuator implements the RecordSetLister interface. This will fail at compile time when false.
+var _ RecordSetLister = &AzureActuator{}
+
+// private returns whether the zone is an Azure Private DNS zone.
+func (a *AzureActuator) private() bool {
+	return isAzurePrivateZone(a.dnsZone)
+}
+
 // Create implements the Create call of the actuator interface
-func (a *AzureActuator) Create() error {
+func (a *AzureActuator) Create(ctx context.Context) error {
+	if a.private() {
+		return a.createPrivateZone(ctx)
+	}
 	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
 	logger.Info("Creating managed zone")
 
 	resourceGroupName := a.dnsZone.Spec.Azure.ResourceGroupName
 
 	zone := a.dnsZone.Spec.Zone
-	managedZone, err := a.azureClient.CreateOrUpdateZone(context.TODO(), resourceGroupName, zone)
+	managedZone, err := a.azureClient.CreateOrUpdateZone(ctx, resourceGroupName, zone)
 	if err != nil {
 		logger.WithError(err).Error("Error creating managed zone")
 		return err
@@ -81,7 +111,10 @@ func (a *AzureActuator) Create() error {
 }
 
 // Delete implements the Delete call of the actuator interface
-func (a *AzureActuator) Delete() error {
+func (a *AzureActuator) Delete(ctx context.Context) error {
+	if a.private() {
+		return a.deletePrivateZone(ctx)
+	}
 	if a.managedZone == nil {
 		return errors.New("managedZone is unpopulated")
 	}
@@ -90,12 +123,12 @@ func (a *AzureActuator) Delete() error {
 	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
 
 	logger.Info("Deleting recordsets in managedzone")
-	if err := DeleteAzureRecordSets(a.azureClient, a.dnsZone, logger); err != nil {
+	if err := DeleteAzureRecordSets(ctx, a.azureClient, a.dnsZone, logger); err != nil {
 		return err
 	}
 
 	logger.Info("Deleting managed zone")
-	err := a.azureClient.DeleteZone(context.TODO(), resourceGroupName, a.dnsZone.Spec.Zone)
+	err := a.azureClient.DeleteZone(ctx, resourceGroupName, a.dnsZone.Spec.Zone)
 	if err != nil {
 		log.WithError(err).Error("Cannot delete managed zone")
 	}
@@ -104,10 +137,10 @@ func (a *AzureActuator) Delete() error {
 }
 
 // DeleteAzureRecordSets will remove all non-essential records from the DNSZone provided.
-func DeleteAzureRecordSets(azureClient azureclient.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
+func DeleteAzureRecordSets(ctx context.Context, azureClient azureclient.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
 	resourceGroupName := dnsZone.Spec.Azure.ResourceGroupName
 	zoneName := dnsZone.Spec.Zone
-	recordSetsPage, err := azureClient.ListRecordSetsByZone(context.Background(), resourceGroupName, zoneName, "")
+	recordSetsPage, err := azureClient.ListRecordSetsByZone(ctx, resourceGroupName, zoneName, "")
 	if err != nil {
 		return err
 	}
@@ -126,25 +159,88 @@ func DeleteAzureRecordSets(azureClient azureclient.Client, dnsZone *hivev1.DNSZo
 			if name == "@" && (recordType == dns.NS || recordType == dns.SOA) {
 				continue
 			}
+			if isPreservedRecordSet(dnsZone, azureRecordSetFQDN(name, zoneName)) {
+				logger.WithField("name", name).WithField("type", recordType).Info("preserving recordset")
+				continue
+			}
 			logger.WithField("name", name).WithField("type", recordType).Info("deleting recordset")
-			if err := azureClient.DeleteRecordSet(context.Background(), resourceGroupName, zoneName, name, recordType); err != nil {
+			if err := azureClient.DeleteRecordSet(ctx, resourceGroupName, zoneName, name, recordType); err != nil {
 				return err
 			}
 		}
-		if err := recordSetsPage.NextWithContext(context.Background()); err != nil {
+		if err := recordSetsPage.NextWithContext(ctx); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// ListRecordSets implements the ListRecordSets call of the RecordSetLister interface
+func (a *AzureActuator) ListRecordSets(ctx context.Context) ([]RecordSet, error) {
+	resourceGroupName := a.dnsZone.Spec.Azure.ResourceGroupName
+	zoneName := a.dnsZone.Spec.Zone
+	var recordSets []RecordSet
+	// addRecordSet adds a record set from its name relative to the zone, and its type, which comes in as, for
+	// example, "Microsoft.Network/dnszones/NS".
+	addRecordSet := func(name, recordType *string) {
+		typeParts := strings.Split(to.String(recordType), "/")
+		recordSets = append(recordSets, RecordSet{Name: azureRecordSetFQDN(to.String(name), zoneName), Type: typeParts[len(typeParts)-1]})
+	}
+
+	if a.private() {
+		if a.privateZone == nil {
+			return nil, errors.New("privateZone is unpopulated")
+		}
+		recordSetsPage, err := a.azureClient.ListPrivateRecordSets(ctx, resourceGroupName, zoneName)
+		if err != nil {
+			return nil, err
+		}
+		for recordSetsPage.NotDone() {
+			for _, recordSet := range recordSetsPage.Values() {
+				addRecordSet(recordSet.Name, recordSet.Type)
+			}
+			if err := recordSetsPage.NextWithContext(ctx); err != nil {
+				return nil, err
+			}
+		}
+		return recordSets, nil
+	}
+
+	if a.managedZone == nil {
+		return nil, errors.New("managedZone is unpopulated")
+	}
+	recordSetsPage, err := a.azureClient.ListRecordSetsByZone(ctx, resourceGroupName, zoneName, "")
+	if err != nil {
+		return nil, err
+	}
+	for recordSetsPage.NotDone() {
+		for _, recordSet := range recordSetsPage.Values() {
+			addRecordSet(recordSet.Name, recordSet.Type)
+		}
+		if err := recordSetsPage.NextWithContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return recordSets, nil
+}
+
 // Exists implements the Exists call of the actuator interface
-func (a *AzureActuator) Exists() (bool, error) {
+func (a *AzureActuator) Exists(ctx context.Context) (bool, error) {
+	if a.private() {
+		return a.privateZone != nil, nil
+	}
 	return a.managedZone != nil, nil
 }
 
 // GetNameServers implements the GetNameServers call of the actuator interface
-func (a *AzureActuator) GetNameServers() ([]string, error) {
+func (a *AzureActuator) GetNameServers(ctx context.Context) ([]string, error) {
+	if a.private() {
+		// Private zones are resolved by the linked virtual networks, and are not delegated to name servers.
+		if a.privateZone == nil {
+			return nil, errors.New("privateZone is unpopulated")
+		}
+		return nil, nil
+	}
 	if a.managedZone == nil {
 		return nil, errors.New("managedZone is unpopulated")
 	}
@@ -157,6 +253,24 @@ func (a *AzureActuator) GetNameServers() ([]string, error) {
 
 // modifyStatus updates the DnsZone's status with Azure specific information.
 func (a *AzureActuator) modifyStatus() error {
+	if a.private() {
+		if a.privateZone == nil {
+			return errors.New("privateZone is unpopulated")
+		}
+		status := &hivev1.AzureDNSZoneStatus{}
+		for _, link := range a.virtualNetworkLinks {
+			linkStatus := hivev1.AzureVirtualNetworkLinkStatus{Name: to.String(link.Name)}
+			if link.VirtualNetworkLinkProperties != nil {
+				if link.VirtualNetwork != nil {
+					linkStatus.VirtualNetworkID = to.String(link.VirtualNetwork.ID)
+				}
+				linkStatus.State = string(link.VirtualNetworkLinkState)
+			}
+			status.VirtualNetworkLinks = append(status.VirtualNetworkLinks, linkStatus)
+		}
+		a.dnsZone.Status.Azure = status
+		return nil
+	}
 	if a.managedZone == nil {
 		return errors.New("managedZone is unpopulated")
 	}
@@ -165,7 +279,10 @@ func (a *AzureActuator) modifyStatus() error {
 }
 
 // Refresh implements the Refresh call of the actuator interface
-func (a *AzureActuator) Refresh() error {
+func (a *AzureActuator) Refresh(ctx context.Context) error {
+	if a.private() {
+		return a.refreshPrivateZone(ctx)
+	}
 
 	zoneName := a.dnsZone.Spec.Zone
 	resourceGroupName := a.dnsZone.Spec.Azure.ResourceGroupName
@@ -173,7 +290,7 @@ func (a *AzureActuator) Refresh() error {
 	// Fetch the managed zone
 	logger := a.logger.WithField("zone", zoneName)
 	logger.Debug("Fetching managed zone by zone name")
-	resp, err := a.azureClient.GetZone(context.TODO(), resourceGroupName, zoneName)
+	resp, err := a.azureClient.GetZone(ctx, resourceGroupName, zoneName)
 	if err != nil {
 		if resp.StatusCode == http.StatusNotFound {
 			logger.Debug("Zone not found, clearing out the cached object")
@@ -195,11 +312,220 @@ func (a *AzureActuator) Refresh() error {
 }
 
 // UpdateMetadata implements the UpdateMetadata call of the actuator interface
-func (a *AzureActuator) UpdateMetadata() error {
+func (a *AzureActuator) UpdateMetadata(ctx context.Context) error {
+	if a.private() {
+		return a.syncVirtualNetworkLinks(ctx)
+	}
 	return nil
 }
 
+func (a *AzureActuator) createPrivateZone(ctx context.Context) error {
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+	logger.Info("Creating private zone")
+
+	privateZone, err := a.azureClient.CreateOrUpdatePrivateZone(ctx, a.dnsZone.Spec.Azure.ResourceGroupName, a.dnsZone.Spec.Zone)
+	if err != nil {
+		logger.WithError(err).Error("Error creating private zone")
+		return err
+	}
+
+	logger.Debug("Private zone successfully created")
+	a.privateZone = &privateZone
+	a.virtualNetworkLinks = nil
+	return a.syncVirtualNetworkLinks(ctx)
+}
+
+func (a *AzureActuator) deletePrivateZone(ctx context.Context) error {
+	if a.privateZone == nil {
+		return errors.New("privateZone is unpopulated")
+	}
+
+	resourceGroupName := a.dnsZone.Spec.Azure.ResourceGroupName
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+
+	logger.Info("Deleting recordsets in private zone")
+	if err := DeleteAzurePrivateRecordSets(ctx, a.azureClient, a.dnsZone, logger); err != nil {
+		return err
+	}
+
+	// A private zone cannot be deleted while it is linked to virtual networks.
+	for _, link := range a.virtualNetworkLinks {
+		logger.WithField("link", to.String(link.Name)).Info("Deleting virtual network link")
+		if err := a.azureClient.DeleteVirtualNetworkLink(ctx, resourceGroupName, a.dnsZone.Spec.Zone, to.String(link.Name)); err != nil {
+			logger.WithError(err).Error("Cannot delete virtual network link")
+			return err
+		}
+	}
+
+	logger.Info("Deleting private zone")
+	err := a.azureClient.DeletePrivateZone(ctx, resourceGroupName, a.dnsZone.Spec.Zone)
+	if err != nil {
+		logger.WithError(err).Error("Cannot delete private zone")
+	}
+	return err
+}
+
+func (a *AzureActuator) refreshPrivateZone(ctx context.Context) error {
+	zoneName := a.dnsZone.Spec.Zone
+	resourceGroupName := a.dnsZone.Spec.Azure.ResourceGroupName
+
+	logger := a.logger.WithField("zone", zoneName)
+	logger.Debug("Fetching private zone by zone name")
+	resp, err := a.azureClient.GetPrivateZone(ctx, resourceGroupName, zoneName)
+	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			logger.Debug("Private zone not found, clearing out the cached object")
+			a.privateZone = nil
+			a.virtualNetworkLinks = nil
+			return nil
+		}
+
+		logger.WithError(err).Error("Cannot get private zone")
+		return err
+	}
+
+	logger.Debug("Found private zone")
+	a.privateZone = &resp
+	a.virtualNetworkLinks = nil
+	linksPage, err := a.azureClient.ListVirtualNetworkLinks(ctx, resourceGroupName, zoneName)
+	if err != nil {
+		logger.WithError(err).Error("Cannot list virtual network links")
+		return err
+	}
+	for linksPage.NotDone() {
+		a.virtualNetworkLinks = append(a.virtualNetworkLinks, linksPage.Values()...)
+		if err := linksPage.NextWithContext(ctx); err != nil {
+			logger.WithError(err).Error("Cannot list virtual network links")
+			return err
+		}
+	}
+	if err := a.modifyStatus(); err != nil {
+		logger.WithError(err).Error("failed to modify DNSZone status")
+		return err
+	}
+	return nil
+}
+
+// syncVirtualNetworkLinks creates or updates the virtual network links in the spec of the DNSZone, and deletes the
+// links created by Hive that are no longer in the spec.
+func (a *AzureActuator) syncVirtualNetworkLinks(ctx context.Context) error {
+	resourceGroupName := a.dnsZone.Spec.Azure.ResourceGroupName
+	zoneName := a.dnsZone.Spec.Zone
+	logger := a.logger.WithField("zone", zoneName)
+	tagValue := a.dnsZone.Namespace + "/" + a.dnsZone.Name
+
+	existing := map[string]privatedns.VirtualNetworkLink{}
+	for _, link := range a.virtualNetworkLinks {
+		existing[to.String(link.Name)] = link
+	}
+
+	var links []privatedns.VirtualNetworkLink
+	inSpec := map[string]bool{}
+	for _, specLink := range a.dnsZone.Spec.Azure.VirtualNetworkLinks {
+		inSpec[specLink.Name] = true
+		if link, ok := existing[specLink.Name]; ok && virtualNetworkLinkMatches(link, specLink) {
+			links = append(links, link)
+			continue
+		}
+		linkLogger := logger.WithField("link", specLink.Name).WithField("virtualNetwork", specLink.VirtualNetworkID)
+		linkLogger.Info("Linking virtual network to private zone")
+		link, err := a.azureClient.CreateOrUpdateVirtualNetworkLink(ctx, resourceGroupName, zoneName, specLink.Name, privatedns.VirtualNetworkLink{
+			Location: to.StringPtr("global"),
+			Tags:     map[string]*string{azureDNSZoneTag: to.StringPtr(tagValue)},
+			VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
+				VirtualNetwork:      &privatedns.SubResource{ID: to.StringPtr(specLink.VirtualNetworkID)},
+				RegistrationEnabled: to.BoolPtr(specLink.RegistrationEnabled),
+			},
+		})
+		if err != nil {
+			linkLogger.WithError(err).Error("Cannot link virtual network to private zone")
+			return err
+		}
+		links = append(links, link)
+	}
+
+	for name, link := range existing {
+		if inSpec[name] {
+			continue
+		}
+		if to.String(link.Tags[azureDNSZoneTag]) != tagValue {
+			// Links not created by Hive are left alone.
+			links = append(links, link)
+			continue
+		}
+		logger.WithField("link", name).Info("Deleting virtual network link removed from DNSZone")
+		if err := a.azureClient.DeleteVirtualNetworkLink(ctx, resourceGroupName, zoneName, name); err != nil {
+			logger.WithError(err).WithField("link", name).Error("Cannot delete virtual network link")
+			return err
+		}
+	}
+
+	sort.Slice(links, func(i, j int) bool { return to.String(links[i].Name) < to.String(links[j].Name) })
+	a.virtualNetworkLinks = links
+	if err := a.modifyStatus(); err != nil {
+		logger.WithError(err).Error("failed to modify DNSZone status")
+		return err
+	}
+	return nil
+}
+
+// virtualNetworkLinkMatches returns whether the virtual network link matches the link in the spec of the DNSZone.
+func virtualNetworkLinkMatches(link privatedns.VirtualNetworkLink, specLink hivev1.AzureVirtualNetworkLink) bool {
+	if link.VirtualNetworkLinkProperties == nil || link.VirtualNetwork == nil {
+		return false
+	}
+	return strings.EqualFold(to.String(link.VirtualNetwork.ID), specLink.VirtualNetworkID) &&
+		to.Bool(link.RegistrationEnabled) == specLink.RegistrationEnabled
+}
+
+// DeleteAzurePrivateRecordSets will remove all non-essential records from the private DNSZone provided.
+func DeleteAzurePrivateRecordSets(ctx context.Context, azureClient azureclient.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
+	resourceGroupName := dnsZone.Spec.Azure.ResourceGroupName
+	zoneName := dnsZone.Spec.Zone
+	recordSetsPage, err := azureClient.ListPrivateRecordSets(ctx, resourceGroupName, zoneName)
+	if err != nil {
+		return err
+	}
+	for recordSetsPage.NotDone() {
+		for _, recordSet := range recordSetsPage.Values() {
+			if recordSet.Name == nil || recordSet.Type == nil {
+				logger.Warn("found recordset with missing name or type")
+				continue
+			}
+			name := *recordSet.Name
+			// The type comes in as, for example, "Microsoft.Network/privateDnsZones/A".
+			typeParts := strings.Split(*recordSet.Type, "/")
+			recordType := privatedns.RecordType(typeParts[len(typeParts)-1])
+			// Ignore the SOA recordset that is created with the private zone and that cannot be deleted
+			if name == "@" && recordType == privatedns.SOA {
+				continue
+			}
+			if isPreservedRecordSet(dnsZone, azureRecordSetFQDN(name, zoneName)) {
+				logger.WithField("name", name).WithField("type", recordType).Info("preserving recordset")
+				continue
+			}
+			logger.WithField("name", name).WithField("type", recordType).Info("deleting recordset")
+			if err := azureClient.DeletePrivateRecordSet(ctx, resourceGroupName, zoneName, name, recordType); err != nil {
+				return err
+			}
+		}
+		if err := recordSetsPage.NextWithContext(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// azureRecordSetFQDN returns the fully qualified name, with a trailing dot, of the record set of the zone with the
+// given name relative to the zone.
+func azureRecordSetFQDN(name, zone string) string {
+	if name == "@" {
+		return controllerutils.Dotted(zone)
+	}
+	return name + "." + controllerutils.Dotted(zone)
+}
+
 // SetConditionsForError sets conditions on the dnszone given a specific error. Returns true if conditions changed.
-func (a *AzureActuator) SetConditionsForError(err error) bool {
+func (a *AzureActuator) SetConditionsForError(ctx context.Context, err error) bool {
 	return false // Not implemented for Azure yet.
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/golang/mock/gomock"
@@ -15,21 +16,34 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
+	"github.com/openshift/hive/pkg/azureclient"
 	"github.com/openshift/hive/pkg/azureclient/mock"
 )
 
 // TestNewAzureActuator tests that a new AzureActuator object can be created.
 func TestNewAzureActuator(t *testing.T) {
 	cases := []struct {
-		name    string
-		dnsZone *hivev1.DNSZone
-		secret  *corev1.Secret
+		name              string
+		dnsZone           *hivev1.DNSZone
+		secret            *corev1.Secret
+		expectedCloudName hivev1azure.CloudEnvironment
 	}{
 		{
 			name:    "Successfully create new zone",
 			dnsZone: validAzureDNSZone(),
 			secret:  validAzureSecret(),
 		},
+		{
+			name: "Zone in the US government cloud",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validAzureDNSZone()
+				dz.Spec.Azure.CloudName = hivev1azure.USGovernmentCloud
+				return dz
+			}(),
+			secret:            validAzureSecret(),
+			expectedCloudName: hivev1azure.USGovernmentCloud,
+		},
 	}
 
 	for _, tc := range cases {
@@ -41,12 +55,18 @@ func TestNewAzureActuator(t *testing.T) {
 				dnsZone: tc.dnsZone,
 			}
 
+			var cloudName hivev1azure.CloudEnvironment
+			builder := func(secret *corev1.Secret, c hivev1azure.CloudEnvironment) (azureclient.Client, error) {
+				cloudName = c
+				return fakeAzureClientBuilder(mocks.mockAzureClient)(secret, c)
+			}
+
 			// Act
 			zr, err := NewAzureActuator(
 				expectedAzureActuator.logger,
 				tc.secret,
 				tc.dnsZone,
-				fakeAzureClientBuilder(mocks.mockAzureClient),
+				builder,
 			)
 			expectedAzureActuator.azureClient = zr.azureClient // Function pointers can't be compared reliably. Don't compare.
 
@@ -54,6 +74,7 @@ func TestNewAzureActuator(t *testing.T) {
 			assert.Nil(t, err)
 			assert.NotNil(t, zr.azureClient)
 			assert.Equal(t, expectedAzureActuator, zr)
+			assert.Equal(t, tc.expectedCloudName, cloudName, "unexpected cloud name for the Azure client")
 		})
 	}
 }
@@ -93,3 +114,76 @@ func mockDeleteAzureZone(mockCtrl *gomock.Controller, expect *mock.MockClientMoc
 	expect.ListRecordSetsByZone(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(recordSetPage, nil)
 	expect.DeleteZone(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 }
+
+func azureVirtualNetworkLink(name, virtualNetworkID, dnsZoneTag string) privatedns.VirtualNetworkLink {
+	link := privatedns.VirtualNetworkLink{
+		Name: to.StringPtr(name),
+		VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
+			VirtualNetwork:          &privatedns.SubResource{ID: to.StringPtr(virtualNetworkID)},
+			RegistrationEnabled:     to.BoolPtr(false),
+			VirtualNetworkLinkState: privatedns.Completed,
+		},
+	}
+	if dnsZoneTag != "" {
+		link.Tags = map[string]*string{azureDNSZoneTag: to.StringPtr(dnsZoneTag)}
+	}
+	return link
+}
+
+func mockAzurePrivateZoneExists(mockCtrl *gomock.Controller, expect *mock.MockClientMockRecorder, links ...privatedns.VirtualNetworkLink) {
+	expect.GetPrivateZone(gomock.Any(), gomock.Any(), gomock.Any()).Return(privatedns.PrivateZone{
+		Name: to.StringPtr("blah.example.com"),
+	}, nil).Times(1)
+	linksPage := mock.NewMockVirtualNetworkLinkPage(mockCtrl)
+	gomock.InOrder(
+		linksPage.EXPECT().NotDone().Return(true),
+		linksPage.EXPECT().Values().Return(links),
+		linksPage.EXPECT().NextWithContext(gomock.Any()).Return(nil),
+		linksPage.EXPECT().NotDone().Return(false),
+	)
+	expect.ListVirtualNetworkLinks(gomock.Any(), gomock.Any(), gomock.Any()).Return(linksPage, nil).Times(1)
+}
+
+func mockAzurePrivateZoneDoesntExist(expect *mock.MockClientMockRecorder) {
+	expect.GetPrivateZone(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(privatedns.PrivateZone{
+			Response: autorest.Response{
+				Response: &http.Response{
+					StatusCode: http.StatusNotFound,
+				},
+			},
+		}, errors.New("Not found")).Times(1)
+}
+
+func mockCreateAzurePrivateZone(expect *mock.MockClientMockRecorder) {
+	expect.CreateOrUpdatePrivateZone(gomock.Any(), gomock.Any(), gomock.Any()).Return(privatedns.PrivateZone{
+		Name: to.StringPtr("blah.example.com"),
+	}, nil).Times(1)
+}
+
+func mockCreateAzureVirtualNetworkLink(expect *mock.MockClientMockRecorder, dnsZone *hivev1.DNSZone) {
+	specLink := dnsZone.Spec.Azure.VirtualNetworkLinks[0]
+	expect.CreateOrUpdateVirtualNetworkLink(gomock.Any(), "default", "blah.example.com", specLink.Name, gomock.Any()).
+		DoAndReturn(func(_, _, _, _ interface{}, link privatedns.VirtualNetworkLink) (privatedns.VirtualNetworkLink, error) {
+			link.Name = to.StringPtr(specLink.Name)
+			link.VirtualNetworkLinkState = privatedns.Completed
+			return link, nil
+		}).Times(1)
+}
+
+func mockDeleteAzurePrivateZone(mockCtrl *gomock.Controller, expect *mock.MockClientMockRecorder) {
+	recordSetPage := mock.NewMockPrivateRecordSetPage(mockCtrl)
+	gomock.InOrder(
+		recordSetPage.EXPECT().NotDone().Return(true),
+		recordSetPage.EXPECT().Values().Return([]privatedns.RecordSet{
+			{Name: to.StringPtr("@"), Type: to.StringPtr("Microsoft.Network/privateDnsZones/SOA")},
+			{Name: to.StringPtr("api"), Type: to.StringPtr("Microsoft.Network/privateDnsZones/A")},
+		}),
+		recordSetPage.EXPECT().NextWithContext(gomock.Any()).Return(nil),
+		recordSetPage.EXPECT().NotDone().Return(false),
+	)
+	expect.ListPrivateRecordSets(gomock.Any(), gomock.Any(), gomock.Any()).Return(recordSetPage, nil)
+	expect.DeletePrivateRecordSet(gomock.Any(), "default", "blah.example.com", "api", privatedns.A).Return(nil).Times(1)
+	expect.DeleteVirtualNetworkLink(gomock.Any(), "default", "blah.example.com", "vnet-link").Return(nil).Times(1)
+	expect.DeletePrivateZone(gomock.Any(), "default", "blah.example.com").Return(nil).Times(1)
+}
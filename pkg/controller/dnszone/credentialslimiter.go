@@ -0,0 +1,55 @@
+package dnszone
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// credentialsLimiter caps the number of concurrent reconciles of DNSZones using the same credentials secret, so that
+// many zones sharing a cloud account neither serialize behind one another nor stampede the API of the account during
+// mass provisioning.
+type credentialsLimiter struct {
+	limit int
+
+	mutex    sync.Mutex
+	inFlight map[types.NamespacedName]int
+}
+
+// newCredentialsLimiter returns a limiter allowing limit concurrent reconciles per credentials secret. A limit of 0
+// does not cap the reconciles.
+func newCredentialsLimiter(limit int) *credentialsLimiter {
+	return &credentialsLimiter{
+		limit:    limit,
+		inFlight: map[types.NamespacedName]int{},
+	}
+}
+
+// tryAcquire reserves a reconcile of the credentials secret, returning false if the cap of the secret is reached.
+// Reservations must be released with release.
+func (l *credentialsLimiter) tryAcquire(secret types.NamespacedName) bool {
+	if l == nil || l.limit <= 0 {
+		return true
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.inFlight[secret] >= l.limit {
+		return false
+	}
+	l.inFlight[secret]++
+	return true
+}
+
+// release releases a reconcile of the credentials secret reserved with tryAcquire.
+func (l *credentialsLimiter) release(secret types.NamespacedName) {
+	if l == nil || l.limit <= 0 {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.inFlight[secret] <= 1 {
+		delete(l.inFlight, secret)
+		return
+	}
+	l.inFlight[secret]--
+}
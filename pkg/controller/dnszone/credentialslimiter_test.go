@@ -0,0 +1,38 @@
+package dnszone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestCredentialsLimiter(t *testing.T) {
+	secretA := types.NamespacedName{Namespace: "ns", Name: "a"}
+	secretB := types.NamespacedName{Namespace: "ns", Name: "b"}
+
+	l := newCredentialsLimiter(2)
+	assert.True(t, l.tryAcquire(secretA), "expected first reconcile of secret a to be allowed")
+	assert.True(t, l.tryAcquire(secretA), "expected second reconcile of secret a to be allowed")
+	assert.False(t, l.tryAcquire(secretA), "expected third reconcile of secret a to be throttled")
+	assert.True(t, l.tryAcquire(secretB), "expected reconcile of secret b to be allowed")
+
+	l.release(secretA)
+	assert.True(t, l.tryAcquire(secretA), "expected reconcile of secret a to be allowed after release")
+
+	l.release(secretA)
+	l.release(secretA)
+	l.release(secretB)
+	assert.Empty(t, l.inFlight, "expected no reconciles in flight")
+}
+
+func TestCredentialsLimiterWithoutLimit(t *testing.T) {
+	secret := types.NamespacedName{Namespace: "ns", Name: "a"}
+	for _, l := range []*credentialsLimiter{nil, newCredentialsLimiter(0)} {
+		for i := 0; i < 10; i++ {
+			assert.True(t, l.tryAcquire(secret), "expected reconcile to be allowed without limit")
+		}
+		l.release(secret)
+	}
+}
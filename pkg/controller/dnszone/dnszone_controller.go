@@ -2,9 +2,12 @@ package dnszone
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -14,17 +17,20 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/azureclient"
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	gcpclient "github.com/openshift/hive/pkg/gcpclient"
+	"github.com/openshift/hive/pkg/ibmclient"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -47,6 +53,22 @@ const (
 	accessGrantedReason             = "AccessGranted"
 	authenticationFailedReason      = "AuthenticationFailed"
 	authenticationSucceededReason   = "AuthenticationSucceeded"
+
+	// maxBlockingRecordSets is the number of record sets listed in the ZoneDeletionBlocked condition.
+	maxBlockingRecordSets = 10
+
+	// reasons of the events emitted on DNSZones
+	zoneCreatedReason           = "ZoneCreated"
+	zoneCreateFailedReason      = "ZoneCreateFailed"
+	zoneDeletedReason           = "ZoneDeleted"
+	zonePreservedReason         = "ZonePreserved"
+	zoneDeletionBlockedReason   = "ZoneDeletionBlocked"
+	delegationEstablishedReason = "DelegationEstablished"
+	recordSetsChangedReason     = "RecordSetsChanged"
+
+	// credentialsThrottledRequeueAfter is the delay before retrying to reconcile a DNSZone whose credentials secret
+	// has reached the cap of concurrent reconciles.
+	credentialsThrottledRequeueAfter = 5 * time.Second
 )
 
 var (
@@ -56,38 +78,51 @@ var (
 	},
 		[]string{"force"},
 	)
+	metricDNSZonesCredentialsThrottled = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "hive_dnszones_credentials_throttled_total",
+		Help: "Counter incremented every time a dnszone reconcile is requeued because its credentials secret reached the cap of concurrent reconciles.",
+	})
 )
 
 func init() {
 	metrics.Registry.MustRegister(metricDNSZonesDeleted)
+	metrics.Registry.MustRegister(metricDNSZonesCredentialsThrottled)
 }
 
 // Add creates a new DNSZone Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
 	if err != nil {
 		logger.WithError(err).Error("could not get controller configurations")
 		return err
 	}
-	return add(mgr, newReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+	concurrentReconcilesPerCredentials, err := controllerutils.GetConcurrentReconcilesPerCredentials(ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get concurrent reconciles per credentials")
+		return err
+	}
+	return add(mgr, newReconciler(mgr, clientRateLimiter, concurrentReconcilesPerCredentials), concurrentReconciles, queueRateLimiter)
 }
 
 // newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileDNSZone {
+func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter, concurrentReconcilesPerCredentials int) *ReconcileDNSZone {
 	return &ReconcileDNSZone{
-		Client:    controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
-		scheme:    mgr.GetScheme(),
-		logger:    log.WithField("controller", ControllerName),
-		soaLookup: lookupSOARecord,
+		Client:             controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme:             mgr.GetScheme(),
+		logger:             controllerutils.ControllerLogEntry(ControllerName),
+		soaLookup:          lookupSOARecord,
+		eventRecorder:      mgr.GetEventRecorderFor(ControllerName.String()),
+		credentialsLimiter: newCredentialsLimiter(concurrentReconcilesPerCredentials),
+		awsZoneIDs:         awsZoneIDsByTag,
 	}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r *ReconcileDNSZone, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
-	c, err := controller.New(ControllerName.String(), mgr, controller.Options{
+	c, err := controllerutils.NewController(ControllerName.String(), mgr, controller.Options{
 		Reconciler:              r,
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
@@ -109,6 +144,14 @@ func add(mgr manager.Manager, r *ReconcileDNSZone, concurrentReconciles int, rat
 		return err
 	}
 
+	// Watch for changes to the credentials secrets used by DNSZones
+	if err := c.Watch(
+		&source.Kind{Type: &corev1.Secret{}},
+		controllerutils.EnqueueDNSZonesForCredentialsSecret(r, r.logger),
+	); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -123,6 +166,14 @@ type ReconcileDNSZone struct {
 
 	// soaLookup is a function that looks up a zone's SOA record
 	soaLookup func(string, log.FieldLogger) (bool, error)
+
+	eventRecorder record.EventRecorder
+
+	// credentialsLimiter caps the concurrent reconciles of DNSZones using the same credentials secret
+	credentialsLimiter *credentialsLimiter
+
+	// awsZoneIDs caches the IDs of the hosted zones found by tag for AWS DNSZones across reconciles
+	awsZoneIDs *controllerutils.ExpiringCache
 }
 
 // Reconcile reads that state of the cluster for a DNSZone object and makes changes based on the state read
@@ -133,6 +184,12 @@ func (r *ReconcileDNSZone) Reconcile(ctx context.Context, request reconcile.Requ
 	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, dnsLog)
 	defer recobsrv.ObserveControllerReconcileTime()
 
+	// The requests made to the hub and the cloud during the reconcile are traced as part of its span.
+	ctx = recobsrv.StartSpan(ctx, request.NamespacedName)
+	traced := *r
+	traced.Client = controllerutils.TraceClient(ctx, r.Client)
+	r = &traced
+
 	// Fetch the DNSZone object
 	desiredState := &hivev1.DNSZone{}
 	err := r.Get(context.TODO(), request.NamespacedName, desiredState)
@@ -153,6 +210,12 @@ func (r *ReconcileDNSZone) Reconcile(ctx context.Context, request reconcile.Requ
 		return *result, nil
 	}
 
+	if paused, err := r.isClusterDeploymentPaused(desiredState, dnsLog); err != nil {
+		return reconcile.Result{}, err
+	} else if paused {
+		return reconcile.Result{}, nil
+	}
+
 	// See if we need to sync. This is what rate limits our dns provider API usage, but allows for immediate syncing
 	// on spec changes and deletes.
 	shouldSync, delta := shouldSync(desiredState)
@@ -166,6 +229,21 @@ func (r *ReconcileDNSZone) Reconcile(ctx context.Context, request reconcile.Requ
 		return reconcile.Result{}, nil
 	}
 
+	if desiredState.DeletionTimestamp != nil && desiredState.Spec.PreserveOnDelete {
+		// The zone is left in place, so there is no need to call the dns provider.
+		return r.preserveZoneOnDelete(desiredState, dnsLog)
+	}
+
+	if secretName := controllerutils.DNSZoneCredentialsSecretName(desiredState); secretName != "" {
+		secret := types.NamespacedName{Namespace: desiredState.Namespace, Name: secretName}
+		if !r.credentialsLimiter.tryAcquire(secret) {
+			dnsLog.WithField("secret", secretName).Debug("too many concurrent reconciles with the credentials secret, requeueing")
+			metricDNSZonesCredentialsThrottled.Inc()
+			return reconcile.Result{RequeueAfter: credentialsThrottledRequeueAfter}, nil
+		}
+		defer r.credentialsLimiter.release(secret)
+	}
+
 	actuator, err := r.getActuator(desiredState, dnsLog)
 	if err != nil {
 		// Handle an edge case here where if the DNSZone has been deleted, it has its finalizer, the actuator couldn't be
@@ -200,6 +278,9 @@ func (r *ReconcileDNSZone) Reconcile(ctx context.Context, request reconcile.Requ
 		}
 
 		dnsLog.WithError(err).Error("error instantiating actuator")
+		if desiredState.DeletionTimestamp != nil {
+			r.eventRecorder.Eventf(desiredState, corev1.EventTypeWarning, zoneDeletionBlockedReason, "Cannot delete hosted zone: %v", err)
+		}
 		return reconcile.Result{}, err
 	}
 
@@ -209,8 +290,8 @@ func (r *ReconcileDNSZone) Reconcile(ctx context.Context, request reconcile.Requ
 		"currentGeneration":  desiredState.Generation,
 		"lastSyncGeneration": desiredState.Status.LastSyncGeneration,
 	}).Info("Syncing DNS Zone")
-	result, err := r.reconcileDNSProvider(actuator, desiredState)
-	conditionsChanged := actuator.SetConditionsForError(err)
+	result, err := r.reconcileDNSProvider(ctx, actuator, desiredState)
+	conditionsChanged := actuator.SetConditionsForError(ctx, err)
 
 	if conditionsChanged {
 		if err := r.Status().Update(context.Background(), desiredState); err != nil {
@@ -223,16 +304,48 @@ func (r *ReconcileDNSZone) Reconcile(ctx context.Context, request reconcile.Requ
 	return result, err
 }
 
+// preserveZoneOnDelete removes the finalizer of a deleted DNSZone that has PreserveOnDelete set, leaving its zone in
+// the dns provider.
+func (r *ReconcileDNSZone) preserveZoneOnDelete(dnsZone *hivev1.DNSZone, logger log.FieldLogger) (reconcile.Result, error) {
+	logger.Info("DNSZone resource is deleted with PreserveOnDelete set, leaving the hosted zone in place")
+	controllerutils.DeleteFinalizer(dnsZone, hivev1.FinalizerDNSZone)
+	if err := r.Client.Update(context.TODO(), dnsZone); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to remove DNSZone finalizer")
+		return reconcile.Result{}, err
+	}
+	r.eventRecorder.Event(dnsZone, corev1.EventTypeNormal, zonePreservedReason, "Preserved hosted zone on deletion of the DNSZone")
+	metricDNSZonesDeleted.WithLabelValues("false").Inc()
+	return reconcile.Result{}, nil
+}
+
+// isClusterDeploymentPaused returns true if the DNSZone is the child zone of a ClusterDeployment whose reconciliation
+// is paused.
+func (r *ReconcileDNSZone) isClusterDeploymentPaused(dnsZone *hivev1.DNSZone, logger log.FieldLogger) (bool, error) {
+	cdName, ok := dnsZone.Labels[constants.ClusterDeploymentNameLabel]
+	if !ok || dnsZone.Labels[constants.DNSZoneTypeLabel] != constants.DNSZoneTypeChild {
+		return false, nil
+	}
+	cd := &hivev1.ClusterDeployment{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: dnsZone.Namespace, Name: cdName}, cd); {
+	case apierrors.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		logger.WithError(err).Error("error getting cluster deployment of dns zone")
+		return false, err
+	}
+	return controllerutils.IsClusterDeploymentPaused(cd, logger), nil
+}
+
 // ReconcileDNSProvider attempts to make the current state reflect the desired state. It does this idempotently.
-func (r *ReconcileDNSZone) reconcileDNSProvider(actuator Actuator, dnsZone *hivev1.DNSZone) (reconcile.Result, error) {
+func (r *ReconcileDNSZone) reconcileDNSProvider(ctx context.Context, actuator Actuator, dnsZone *hivev1.DNSZone) (reconcile.Result, error) {
 	r.logger.Debug("Retrieving current state")
-	err := actuator.Refresh()
+	err := actuator.Refresh(ctx)
 	if err != nil {
 		r.logger.WithError(err).Error("Failed to retrieve hosted zone and corresponding tags")
 		return reconcile.Result{}, err
 	}
 
-	zoneFound, err := actuator.Exists()
+	zoneFound, err := actuator.Exists(ctx)
 	if err != nil {
 		r.logger.WithError(err).Error("Failed while checking if hosted zone exists")
 		return reconcile.Result{}, err
@@ -241,10 +354,15 @@ func (r *ReconcileDNSZone) reconcileDNSProvider(actuator Actuator, dnsZone *hive
 	if dnsZone.DeletionTimestamp != nil {
 		if zoneFound {
 			r.logger.Debug("DNSZone resource is deleted, deleting hosted zone")
-			err := actuator.Delete()
+			err := r.checkRecordCleanupPolicy(ctx, actuator, dnsZone)
+			if err == nil {
+				err = actuator.Delete(ctx)
+			}
 			if err != nil {
+				r.eventRecorder.Eventf(dnsZone, corev1.EventTypeWarning, zoneDeletionBlockedReason, "Failed to delete hosted zone: %v", err)
 				return reconcile.Result{}, err
 			}
+			r.eventRecorder.Event(dnsZone, corev1.EventTypeNormal, zoneDeletedReason, "Deleted hosted zone")
 		}
 		if controllerutils.HasFinalizer(dnsZone, hivev1.FinalizerDNSZone) {
 			// Remove the finalizer from the DNSZone. It will be persisted when we persist status
@@ -269,29 +387,43 @@ func (r *ReconcileDNSZone) reconcileDNSProvider(actuator Actuator, dnsZone *hive
 	}
 	if !zoneFound {
 		r.logger.Info("No corresponding hosted zone found on cloud provider, creating one")
-		err := actuator.Create()
+		err := actuator.Create(ctx)
 		if err != nil {
 			r.logger.WithError(err).Error("Failed to create hosted zone")
+			r.eventRecorder.Eventf(dnsZone, corev1.EventTypeWarning, zoneCreateFailedReason, "Failed to create hosted zone: %v", err)
 			return reconcile.Result{}, err
 		}
+		r.eventRecorder.Event(dnsZone, corev1.EventTypeNormal, zoneCreatedReason, "Created hosted zone")
 	} else {
 		r.logger.Info("Existing hosted zone found. Syncing with DNSZone resource")
-		err := actuator.UpdateMetadata()
+		err := actuator.UpdateMetadata(ctx)
 		if err != nil {
 			r.logger.WithError(err).Error("failed to sync tags for hosted zone")
 			return reconcile.Result{}, err
 		}
 	}
 
-	nameServers, err := actuator.GetNameServers()
+	nameServers, err := actuator.GetNameServers(ctx)
 	if err != nil {
 		r.logger.WithError(err).Error("Failed to get hosted zone name servers")
 		return reconcile.Result{}, err
 	}
 
-	isZoneSOAAvailable, err := r.soaLookup(dnsZone.Spec.Zone, r.logger)
-	if err != nil {
-		r.logger.WithError(err).Error("error looking up SOA record for zone")
+	if err := r.syncRecordSetSummary(ctx, actuator, dnsZone); err != nil {
+		r.logger.WithError(err).Error("Failed to list hosted zone record sets")
+		return reconcile.Result{}, err
+	}
+
+	isZoneSOAAvailable := true
+	if isAzurePrivateZone(dnsZone) || isAWSPrivateZone(dnsZone) || isGCPPrivateZone(dnsZone) {
+		// Private zones only resolve from the linked virtual networks, associated VPCs or attached networks, so they
+		// are available once created.
+		r.logger.Debug("skipping SOA lookup for private zone")
+	} else {
+		isZoneSOAAvailable, err = r.soaLookup(dnsZone.Spec.Zone, r.logger)
+		if err != nil {
+			r.logger.WithError(err).Error("error looking up SOA record for zone")
+		}
 	}
 
 	reconcileResult := reconcile.Result{}
@@ -303,6 +435,120 @@ func (r *ReconcileDNSZone) reconcileDNSProvider(actuator Actuator, dnsZone *hive
 	return reconcileResult, r.updateStatus(nameServers, isZoneSOAAvailable, dnsZone)
 }
 
+// syncRecordSetSummary sets the record set summary in the status of the DNSZone when requested in its spec, and emits
+// an event when the record sets of the zone changed since the summary was last published.
+func (r *ReconcileDNSZone) syncRecordSetSummary(ctx context.Context, actuator Actuator, dnsZone *hivev1.DNSZone) error {
+	if !dnsZone.Spec.PublishRecordSetSummary {
+		dnsZone.Status.RecordSetSummary = nil
+		return nil
+	}
+	lister, ok := actuator.(RecordSetLister)
+	if !ok {
+		r.logger.Debug("record set summary is not supported for the DNS provider of the zone")
+		dnsZone.Status.RecordSetSummary = nil
+		return nil
+	}
+
+	recordSets, err := lister.ListRecordSets(ctx)
+	if err != nil {
+		return err
+	}
+	lines := make([]string, len(recordSets))
+	for i, recordSet := range recordSets {
+		lines[i] = recordSet.Name + " " + recordSet.Type
+	}
+	sort.Strings(lines)
+	hash := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	summary := &hivev1.DNSZoneRecordSetSummary{
+		Count: len(recordSets),
+		Hash:  hex.EncodeToString(hash[:]),
+	}
+
+	previous := dnsZone.Status.RecordSetSummary
+	switch {
+	case previous == nil:
+		// First summary of the zone, there is nothing to compare against.
+	case previous.Hash != summary.Hash:
+		r.logger.WithField("previousCount", previous.Count).WithField("count", summary.Count).Info("record sets of the zone changed")
+		r.eventRecorder.Eventf(dnsZone, corev1.EventTypeNormal, recordSetsChangedReason,
+			"Record sets of the zone changed from %d to %d record sets", previous.Count, summary.Count)
+		now := metav1.Now()
+		summary.LastChangeTime = &now
+	default:
+		summary.LastChangeTime = previous.LastChangeTime
+	}
+	dnsZone.Status.RecordSetSummary = summary
+	return nil
+}
+
+// checkRecordCleanupPolicy returns an error when the DNSZone has the Fail record cleanup policy and its zone has
+// records that would be deleted with it, and sets the ZoneDeletionBlocked condition of the DNSZone accordingly.
+func (r *ReconcileDNSZone) checkRecordCleanupPolicy(ctx context.Context, actuator Actuator, dnsZone *hivev1.DNSZone) error {
+	if dnsZone.Spec.RecordCleanupPolicy != hivev1.FailDNSZoneRecordCleanupPolicy {
+		return nil
+	}
+	lister, ok := actuator.(RecordSetLister)
+	if !ok {
+		return errors.New("the Fail record cleanup policy is not supported for the DNS provider of the zone")
+	}
+
+	recordSets, err := lister.ListRecordSets(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not list the record sets of the zone")
+	}
+	var blocking []string
+	for _, recordSet := range recordSets {
+		if isZoneRecordSet(dnsZone, recordSet) || isPreservedRecordSet(dnsZone, recordSet.Name) {
+			continue
+		}
+		blocking = append(blocking, recordSet.Name+" "+recordSet.Type)
+	}
+	sort.Strings(blocking)
+
+	listed := blocking
+	if len(listed) > maxBlockingRecordSets {
+		listed = append(listed[:maxBlockingRecordSets:maxBlockingRecordSets], fmt.Sprintf("and %d more", len(blocking)-maxBlockingRecordSets))
+	}
+	status, reason, message := corev1.ConditionFalse, "NoRecordsToDelete", "Zone has no records that would be deleted with it"
+	if len(blocking) > 0 {
+		status, reason = corev1.ConditionTrue, "RecordsExist"
+		message = fmt.Sprintf("Zone has record sets that would be deleted with it: %s", strings.Join(listed, ", "))
+	}
+	conditions, changed := controllerutils.SetDNSZoneConditionWithChangeCheck(
+		dnsZone.Status.Conditions,
+		hivev1.ZoneDeletionBlockedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+	if changed {
+		dnsZone.Status.Conditions = conditions
+		if err := r.Client.Status().Update(context.TODO(), dnsZone); err != nil {
+			r.logger.WithError(err).Log(controllerutils.LogLevel(err), "Cannot update DNSZone status")
+			return err
+		}
+	}
+	if len(blocking) > 0 {
+		return errors.Errorf("zone has record sets that would be deleted with it: %s", strings.Join(listed, ", "))
+	}
+	return nil
+}
+
+// isAzurePrivateZone returns whether the DNSZone is an Azure Private DNS zone.
+func isAzurePrivateZone(dnsZone *hivev1.DNSZone) bool {
+	return dnsZone.Spec.Azure != nil && dnsZone.Spec.Azure.ZoneType == hivev1.AzurePrivateDNSZoneType
+}
+
+// isAWSPrivateZone returns whether the DNSZone is a Route53 private hosted zone.
+func isAWSPrivateZone(dnsZone *hivev1.DNSZone) bool {
+	return dnsZone.Spec.AWS != nil && dnsZone.Spec.AWS.ZoneType == hivev1.AWSPrivateDNSZoneType
+}
+
+// isGCPPrivateZone returns whether the DNSZone is a Cloud DNS private managed zone.
+func isGCPPrivateZone(dnsZone *hivev1.DNSZone) bool {
+	return dnsZone.Spec.GCP != nil && dnsZone.Spec.GCP.ZoneType == hivev1.GCPPrivateDNSZoneType
+}
+
 func shouldSync(desiredState *hivev1.DNSZone) (bool, time.Duration) {
 	if desiredState.DeletionTimestamp != nil && !controllerutils.HasFinalizer(desiredState, hivev1.FinalizerDNSZone) {
 		return false, 0 // No finalizer means our cleanup has been completed. There's nothing left to do.
@@ -320,6 +566,15 @@ func shouldSync(desiredState *hivev1.DNSZone) (bool, time.Duration) {
 		return true, 0 // Spec has changed since last sync, sync now.
 	}
 
+	for _, credsConditionType := range []hivev1.DNSZoneConditionType{
+		hivev1.InsufficientCredentialsCondition,
+		hivev1.AuthenticationFailureCondition,
+	} {
+		if cond := controllerutils.FindDNSZoneCondition(desiredState.Status.Conditions, credsConditionType); cond != nil && cond.Status == corev1.ConditionTrue {
+			return true, 0 // The credentials were failing, sync now to check whether they have been rotated.
+		}
+	}
+
 	if desiredState.Spec.LinkToParentDomain {
 		availableCondition := controllerutils.FindDNSZoneCondition(desiredState.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
 		if availableCondition == nil || availableCondition.Status == corev1.ConditionFalse {
@@ -339,21 +594,14 @@ func shouldSync(desiredState *hivev1.DNSZone) (bool, time.Duration) {
 
 func (r *ReconcileDNSZone) getActuator(dnsZone *hivev1.DNSZone, dnsLog log.FieldLogger) (Actuator, error) {
 	if dnsZone.Spec.AWS != nil {
-		credentials := awsclient.CredentialsSource{
-			Secret: &awsclient.SecretCredentialsSource{
-				Ref:       &dnsZone.Spec.AWS.CredentialsSecretRef,
-				Namespace: dnsZone.Namespace,
-			},
-			AssumeRole: &awsclient.AssumeRoleCredentialsSource{
-				SecretRef: corev1.SecretReference{
-					Namespace: controllerutils.GetHiveNamespace(),
-					Name:      os.Getenv(constants.HiveAWSServiceProviderCredentialsSecretRefEnvVar),
-				},
-				Role: dnsZone.Spec.AWS.CredentialsAssumeRole,
-			},
-		}
+		credentials := awsclient.NewCredentialsSource(dnsZone.Namespace, &dnsZone.Spec.AWS.CredentialsSecretRef, dnsZone.Spec.AWS.CredentialsAssumeRole, dnsZone.Spec.AWS.CredentialsSource)
 
-		return NewAWSActuator(dnsLog, r.Client, credentials, dnsZone, awsclient.New)
+		actuator, err := NewAWSActuator(dnsLog, r.Client, credentials, dnsZone, awsclient.New)
+		if err != nil {
+			return nil, err
+		}
+		actuator.zoneIDs = r.awsZoneIDs
+		return actuator, nil
 	}
 
 	if dnsZone.Spec.GCP != nil {
@@ -368,7 +616,14 @@ func (r *ReconcileDNSZone) getActuator(dnsZone *hivev1.DNSZone, dnsLog log.Field
 			return nil, err
 		}
 
-		return NewGCPActuator(dnsLog, secret, dnsZone, gcpclient.NewClientFromSecret)
+		proxy, err := controllerutils.GetCloudClientProxy(r, dnsZone.Namespace, dnsZone.Spec.CloudClientProxySecretRef)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewGCPActuator(dnsLog, secret, dnsZone, func(secret *corev1.Secret) (gcpclient.Client, error) {
+			return gcpclient.NewClientFromSecretWithOptions(secret, gcpclient.Options{Proxy: proxy, Controller: ControllerName})
+		})
 	}
 
 	if dnsZone.Spec.Azure != nil {
@@ -383,7 +638,38 @@ func (r *ReconcileDNSZone) getActuator(dnsZone *hivev1.DNSZone, dnsLog log.Field
 			return nil, err
 		}
 
-		return NewAzureActuator(dnsLog, secret, dnsZone, azureclient.NewClientFromSecret)
+		proxy, err := controllerutils.GetCloudClientProxy(r, dnsZone.Namespace, dnsZone.Spec.CloudClientProxySecretRef)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewAzureActuator(dnsLog, secret, dnsZone, func(secret *corev1.Secret, cloudName hivev1azure.CloudEnvironment) (azureclient.Client, error) {
+			return azureclient.NewClientFromSecretWithOptions(secret, cloudName, azureclient.Options{Proxy: proxy, Controller: ControllerName})
+		})
+	}
+
+	if dnsZone.Spec.IBMCloud != nil {
+		secret := &corev1.Secret{}
+		err := r.Get(context.TODO(),
+			types.NamespacedName{
+				Name:      dnsZone.Spec.IBMCloud.CredentialsSecretRef.Name,
+				Namespace: dnsZone.Namespace,
+			},
+			secret)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewIBMCloudActuator(dnsLog, secret, dnsZone, ibmclient.NewClientFromSecret)
+	}
+
+	if dnsZone.Spec.Webhook != nil {
+		httpClient, token, err := newWebhookHTTPClient(r.Client, dnsZone)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewWebhookActuator(dnsLog, httpClient, token, dnsZone)
 	}
 
 	return nil, errors.New("unable to determine which actuator to use")
@@ -423,8 +709,13 @@ func (r *ReconcileDNSZone) updateStatus(nameServers []string, isSOAAvailable boo
 		err := r.Client.Status().Update(context.TODO(), dnsZone)
 		if err != nil {
 			r.logger.WithError(err).Log(controllerutils.LogLevel(err), "Cannot update DNSZone status")
+			return err
+		}
+		if isSOAAvailable {
+			if cond := controllerutils.FindDNSZoneCondition(orig.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition); cond == nil || cond.Status != corev1.ConditionTrue {
+				r.eventRecorder.Event(dnsZone, corev1.EventTypeNormal, delegationEstablishedReason, "DNS SOA record for zone is reachable")
+			}
 		}
-		return err
 	}
 	return nil
 }
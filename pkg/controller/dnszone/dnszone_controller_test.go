@@ -5,23 +5,35 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	dns "google.golang.org/api/dns/v1"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	fakekubeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/awsclient/mock"
 	awsmock "github.com/openshift/hive/pkg/awsclient/mock"
 	azuremock "github.com/openshift/hive/pkg/azureclient/mock"
+	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	gcpmock "github.com/openshift/hive/pkg/gcpclient/mock"
+	"github.com/openshift/hive/pkg/ibmclient"
+	ibmmock "github.com/openshift/hive/pkg/ibmclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
 	testdnszone "github.com/openshift/hive/pkg/test/dnszone"
 	testgeneric "github.com/openshift/hive/pkg/test/generic"
 )
@@ -38,6 +50,7 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 		validateZone    func(*testing.T, *hivev1.DNSZone)
 		errorExpected   bool
 		soaLookupResult bool
+		expectedEvents  []string
 	}{
 		{
 			name:    "DNSZone without finalizer",
@@ -66,6 +79,7 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 				assert.Equal(t, *zone.Status.AWS.ZoneID, "1234")
 				assert.Equal(t, zone.Status.NameServers, []string{"ns1.example.com", "ns2.example.com"}, "nameservers must be set in status")
 			},
+			expectedEvents: []string{"Normal ZoneCreated Created hosted zone"},
 		},
 		{
 			name:    "Adopt existing zone, No ID Set",
@@ -124,6 +138,98 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
 				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
 			},
+			expectedEvents: []string{"Normal ZoneDeleted Deleted hosted zone"},
+		},
+		{
+			name: "Delete hosted zone with records under Fail record cleanup policy",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validDNSZoneBeingDeleted()
+				dz.Spec.RecordCleanupPolicy = hivev1.FailDNSZoneRecordCleanupPolicy
+				return dz
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZoneWithAdditionalTags())
+				mockExistingAWSTags(expect)
+				mockAWSListRecordSets(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.True(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
+				condition := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ZoneDeletionBlockedCondition)
+				if assert.NotNil(t, condition, "zone deletion blocked condition should be set on dnszone") {
+					assert.Equal(t, corev1.ConditionTrue, condition.Status)
+					assert.Equal(t, "Zone has record sets that would be deleted with it: api.blah.example.com. A", condition.Message)
+				}
+			},
+			errorExpected:  true,
+			expectedEvents: []string{"Warning ZoneDeletionBlocked Failed to delete hosted zone: zone has record sets that would be deleted with it: api.blah.example.com. A"},
+		},
+		{
+			name: "Delete hosted zone with preserved records under Fail record cleanup policy",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validDNSZoneBeingDeleted()
+				dz.Spec.RecordCleanupPolicy = hivev1.FailDNSZoneRecordCleanupPolicy
+				dz.Spec.PreservedRecordNames = []string{"api.*"}
+				return dz
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZoneWithAdditionalTags())
+				mockExistingAWSTags(expect)
+				mockAWSListRecordSets(expect)
+				mockAWSListRecordSets(expect)
+				expect.DeleteHostedZone(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
+			},
+			expectedEvents: []string{"Normal ZoneDeleted Deleted hosted zone"},
+		},
+		{
+			name: "Delete hosted zone with preserved records",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validDNSZoneBeingDeleted()
+				dz.Spec.PreservedRecordNames = []string{"_acme-challenge.*"}
+				return dz
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZoneWithAdditionalTags())
+				mockExistingAWSTags(expect)
+				expect.ListResourceRecordSets(gomock.Any(), gomock.Any()).Return(&route53.ListResourceRecordSetsOutput{
+					ResourceRecordSets: []*route53.ResourceRecordSet{
+						{Type: aws.String("NS"), Name: aws.String("blah.example.com.")},
+						{Type: aws.String("SOA"), Name: aws.String("blah.example.com.")},
+						{Type: aws.String("TXT"), Name: aws.String("_acme-challenge.blah.example.com.")},
+						{Type: aws.String("A"), Name: aws.String("api.blah.example.com.")},
+					},
+				}, nil).Times(1)
+				expect.ChangeResourceRecordSets(gomock.Any(), &route53.ChangeResourceRecordSetsInput{
+					ChangeBatch: &route53.ChangeBatch{Changes: []*route53.Change{{
+						Action:            aws.String(route53.ChangeActionDelete),
+						ResourceRecordSet: &route53.ResourceRecordSet{Type: aws.String("A"), Name: aws.String("api.blah.example.com.")},
+					}}},
+					HostedZoneId: aws.String("1234"),
+				}).Return(&route53.ChangeResourceRecordSetsOutput{}, nil).Times(1)
+				expect.DeleteHostedZone(gomock.Any(), gomock.Any()).Return(nil, awserr.New(route53.ErrCodeHostedZoneNotEmpty, "hosted zone is not empty", nil)).Times(1)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.True(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone), "zone with preserved records cannot be deleted")
+			},
+			errorExpected:  true,
+			expectedEvents: []string{"Warning ZoneDeletionBlocked Failed to delete hosted zone: HostedZoneNotEmpty: hosted zone is not empty"},
+		},
+		{
+			name:    "Delete hosted zone that is not empty",
+			dnsZone: validDNSZoneBeingDeleted(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZoneWithAdditionalTags())
+				mockExistingAWSTags(expect)
+				expect.ListResourceRecordSets(gomock.Any(), gomock.Any()).Return(&route53.ListResourceRecordSetsOutput{}, nil).Times(1)
+				expect.DeleteHostedZone(gomock.Any(), gomock.Any()).Return(nil, awserr.New(route53.ErrCodeHostedZoneNotEmpty, "hosted zone is not empty", nil)).Times(1)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.True(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
+			},
+			errorExpected:  true,
+			expectedEvents: []string{"Warning ZoneDeletionBlocked Failed to delete hosted zone: HostedZoneNotEmpty: hosted zone is not empty"},
 		},
 		{
 			name:    "Delete non-existent hosted zone",
@@ -149,17 +255,605 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 				mockDeleteAWSZone(expect)
 			},
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
-				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
+				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
+			},
+		},
+		{
+			name:            "Existing zone, link to parent, reachable SOA",
+			dnsZone:         validDNSZoneWithLinkToParent(),
+			soaLookupResult: true,
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZoneWithAdditionalTags())
+				mockExistingAWSTags(expect)
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				condition := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
+				assert.NotNil(t, condition, "zone available condition should be set on dnszone")
+			},
+			expectedEvents: []string{"Normal DelegationEstablished DNS SOA record for zone is reachable"},
+		},
+		{
+			name: "Create private hosted zone",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validAWSPrivateDNSZone()
+				dz.Status.AWS = nil
+				return dz
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				expect.GetResourcesPages(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				mockCreateAWSPrivateZone(expect)
+				mockNoExistingAWSTags(expect)
+				mockSyncAWSTags(expect)
+				expect.AssociateVPCWithHostedZone(gomock.Any(), &route53.AssociateVPCWithHostedZoneInput{
+					HostedZoneId: aws.String("1234"),
+					VPC:          awsVPC("vpc-2", "us-west-2"),
+				}).Return(&route53.AssociateVPCWithHostedZoneOutput{}, nil).Times(1)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Equal(t, "1234", aws.StringValue(zone.Status.AWS.ZoneID))
+				assert.Empty(t, zone.Status.NameServers, "private zones must not have nameservers")
+				condition := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
+				if assert.NotNil(t, condition, "zone available condition should be set on dnszone") {
+					assert.Equal(t, corev1.ConditionTrue, condition.Status, "private zone should be available without an SOA lookup")
+				}
+			},
+			expectedEvents: []string{"Normal ZoneCreated Created hosted zone"},
+		},
+		{
+			name:    "Sync VPCs of private hosted zone",
+			dnsZone: validAWSPrivateDNSZone(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSPrivateZoneExists(expect, awsVPC("vpc-1", "us-east-1"), awsVPC("vpc-removed", "us-east-1"))
+				mockExistingAWSTags(expect)
+				mockSyncAWSTags(expect)
+				expect.AssociateVPCWithHostedZone(gomock.Any(), &route53.AssociateVPCWithHostedZoneInput{
+					HostedZoneId: aws.String("1234"),
+					VPC:          awsVPC("vpc-2", "us-west-2"),
+				}).Return(&route53.AssociateVPCWithHostedZoneOutput{}, nil).Times(1)
+				expect.DisassociateVPCFromHostedZone(gomock.Any(), &route53.DisassociateVPCFromHostedZoneInput{
+					HostedZoneId: aws.String("1234"),
+					VPC:          awsVPC("vpc-removed", "us-east-1"),
+				}).Return(&route53.DisassociateVPCFromHostedZoneOutput{}, nil).Times(1)
+			},
+		},
+		{
+			name:    "Existing private hosted zone with VPCs in sync",
+			dnsZone: validAWSPrivateDNSZone(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSPrivateZoneExists(expect, awsVPC("vpc-2", "us-west-2"), awsVPC("vpc-1", "us-east-1"))
+				mockExistingAWSTags(expect)
+				mockSyncAWSTags(expect)
+			},
+		},
+		{
+			name:    "Enable DNSSEC on existing hosted zone",
+			dnsZone: validAWSDNSSECDNSZone(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validAWSDNSSECDNSZone())
+				mockExistingAWSTags(expect)
+				gomock.InOrder(
+					mockAWSGetDNSSEC(expect, "NOT_SIGNING"),
+					expect.CreateKeySigningKey(gomock.Any(), &route53.CreateKeySigningKeyInput{
+						CallerReference:         aws.String("abcdef-ksk-6"),
+						HostedZoneId:            aws.String("1234"),
+						KeyManagementServiceArn: aws.String("arn:aws:kms:us-east-1:123456789012:key/1234"),
+						Name:                    aws.String(hiveKeySigningKeyName),
+						Status:                  aws.String("ACTIVE"),
+					}).Return(&route53.CreateKeySigningKeyOutput{}, nil).Times(1),
+					expect.EnableHostedZoneDNSSEC(gomock.Any(), &route53.EnableHostedZoneDNSSECInput{
+						HostedZoneId: aws.String("1234"),
+					}).Return(&route53.EnableHostedZoneDNSSECOutput{}, nil).Times(1),
+					mockAWSGetDNSSEC(expect, "SIGNING", awsKeySigningKey("ACTIVE")),
+				)
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Equal(t, "1234", aws.StringValue(zone.Status.AWS.ZoneID))
+				if assert.NotNil(t, zone.Status.AWS.DNSSEC, "DNSSEC status must be set") {
+					assert.Equal(t, "SIGNING", zone.Status.AWS.DNSSEC.ServeSignature)
+					assert.Equal(t, "ACTIVE", zone.Status.AWS.DNSSEC.KeySigningKeyStatus)
+					assert.Equal(t, "12345 13 2 ABCDEF", zone.Status.AWS.DNSSEC.DSRecord)
+				}
+			},
+		},
+		{
+			name: "Disable DNSSEC on existing hosted zone",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validDNSZone()
+				dz.Status.AWS.DNSSEC = &hivev1.AWSDNSSECStatus{ServeSignature: "SIGNING"}
+				return dz
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZone())
+				mockExistingAWSTags(expect)
+				mockAWSGetDNSSEC(expect, "SIGNING", awsKeySigningKey("ACTIVE"))
+				mockDisableAWSDNSSEC(expect)
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Nil(t, zone.Status.AWS.DNSSEC, "DNSSEC status must be cleared")
+			},
+		},
+		{
+			name: "Enable query logging on created hosted zone",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validAWSQueryLoggingDNSZone()
+				dz.Status.AWS = nil
+				return dz
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneDoesntExist(expect, validDNSZoneWithoutID())
+				mockCreateAWSZone(expect)
+				mockNoExistingAWSTags(expect)
+				mockSyncAWSTags(expect)
+				gomock.InOrder(
+					mockAWSListQueryLoggingConfigs(expect),
+					mockCreateAWSQueryLoggingConfig(expect, "qlc-1", awsQueryLogGroupARN("dns-queries")),
+				)
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Equal(t, "qlc-1", zone.Status.AWS.QueryLoggingConfigID)
+			},
+			expectedEvents: []string{"Normal ZoneCreated Created hosted zone"},
+		},
+		{
+			name:    "Query logging in sync on existing hosted zone",
+			dnsZone: validAWSQueryLoggingDNSZone(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validAWSQueryLoggingDNSZone())
+				mockExistingAWSTags(expect)
+				mockAWSListQueryLoggingConfigs(expect, awsQueryLoggingConfig("qlc-1", awsQueryLogGroupARN("dns-queries")))
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Equal(t, "qlc-1", zone.Status.AWS.QueryLoggingConfigID)
+			},
+		},
+		{
+			name:    "Replace query logging to another log group on existing hosted zone",
+			dnsZone: validAWSQueryLoggingDNSZone(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validAWSQueryLoggingDNSZone())
+				mockExistingAWSTags(expect)
+				gomock.InOrder(
+					mockAWSListQueryLoggingConfigs(expect, awsQueryLoggingConfig("qlc-1", awsQueryLogGroupARN("other"))),
+					mockDeleteAWSQueryLoggingConfig(expect, "qlc-1"),
+					mockCreateAWSQueryLoggingConfig(expect, "qlc-2", awsQueryLogGroupARN("dns-queries")),
+				)
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Equal(t, "qlc-2", zone.Status.AWS.QueryLoggingConfigID)
+			},
+		},
+		{
+			name: "Disable query logging on existing hosted zone",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validDNSZone()
+				dz.Status.AWS.QueryLoggingConfigID = "qlc-1"
+				return dz
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZone())
+				mockExistingAWSTags(expect)
+				gomock.InOrder(
+					mockAWSListQueryLoggingConfigs(expect, awsQueryLoggingConfig("qlc-1", awsQueryLogGroupARN("dns-queries"))),
+					mockDeleteAWSQueryLoggingConfig(expect, "qlc-1"),
+				)
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Empty(t, zone.Status.AWS.QueryLoggingConfigID, "query logging config must be cleared")
+			},
+		},
+		{
+			name: "Disable query logging keeps configuration created outside of Hive",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validDNSZone()
+				dz.Status.AWS.QueryLoggingConfigID = "qlc-1"
+				return dz
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZone())
+				mockExistingAWSTags(expect)
+				mockAWSListQueryLoggingConfigs(expect, awsQueryLoggingConfig("qlc-other", awsQueryLogGroupARN("other")))
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Empty(t, zone.Status.AWS.QueryLoggingConfigID, "query logging config must be cleared")
+			},
+		},
+		{
+			name:    "Publish record set summary",
+			dnsZone: validAWSRecordSetSummaryDNSZone(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validAWSRecordSetSummaryDNSZone())
+				mockExistingAWSTags(expect)
+				mockAWSGetNSRecord(expect)
+				mockAWSListRecordSets(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				if assert.NotNil(t, zone.Status.RecordSetSummary, "record set summary must be set") {
+					assert.Equal(t, 3, zone.Status.RecordSetSummary.Count)
+					assert.Len(t, zone.Status.RecordSetSummary.Hash, 64)
+					assert.Nil(t, zone.Status.RecordSetSummary.LastChangeTime, "first summary is not a change")
+				}
+			},
+		},
+		{
+			name: "Record sets changed since last summary",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validAWSRecordSetSummaryDNSZone()
+				dz.Status.RecordSetSummary = &hivev1.DNSZoneRecordSetSummary{Count: 2, Hash: "stale"}
+				return dz
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validAWSRecordSetSummaryDNSZone())
+				mockExistingAWSTags(expect)
+				mockAWSGetNSRecord(expect)
+				mockAWSListRecordSets(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				if assert.NotNil(t, zone.Status.RecordSetSummary, "record set summary must be set") {
+					assert.Equal(t, 3, zone.Status.RecordSetSummary.Count)
+					assert.NotEqual(t, "stale", zone.Status.RecordSetSummary.Hash)
+					assert.NotNil(t, zone.Status.RecordSetSummary.LastChangeTime, "change time must be set")
+				}
+			},
+			expectedEvents: []string{"Normal RecordSetsChanged Record sets of the zone changed from 2 to 3 record sets"},
+		},
+		{
+			name: "Clear record set summary",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validDNSZone()
+				dz.Status.RecordSetSummary = &hivev1.DNSZoneRecordSetSummary{Count: 2, Hash: "stale"}
+				return dz
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZone())
+				mockExistingAWSTags(expect)
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Nil(t, zone.Status.RecordSetSummary, "record set summary must be cleared")
+			},
+		},
+		{
+			name: "Adopt hosted zone by ID",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validDNSZoneWithoutID()
+				dz.Spec.AWS.ZoneID = "1234"
+				return dz
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validDNSZone())
+				mockNoExistingAWSTags(expect)
+				mockSyncAWSTags(expect)
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Equal(t, "1234", aws.StringValue(zone.Status.AWS.ZoneID))
+				assert.Equal(t, zone.Status.NameServers, []string{"ns1.example.com", "ns2.example.com"}, "nameservers must be set in status")
+			},
+		},
+		{
+			name: "Adopt hosted zone of another domain",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validDNSZoneWithoutID()
+				dz.Spec.AWS.ZoneID = "1234"
+				return dz
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				expect.GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
+					HostedZone: &route53.HostedZone{
+						Id:   aws.String("1234"),
+						Name: aws.String("other.example.com."),
+					},
+				}, nil).Times(1)
+				mockNoExistingAWSTags(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Nil(t, zone.Status.AWS, "the zone of another domain must not be adopted")
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Adopt missing hosted zone",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validDNSZoneWithoutID()
+				dz.Spec.AWS.ZoneID = "1234"
+				return dz
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneDoesntExist(expect, validDNSZone())
+			},
+			errorExpected:  true,
+			expectedEvents: []string{"Warning ZoneCreateFailed Failed to create hosted zone: hosted zone 1234 to adopt does not exist"},
+		},
+		{
+			name: "Delete hosted zone with DNSSEC",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validAWSDNSSECDNSZone()
+				dz.DeletionTimestamp = kubeTimeNow
+				return dz
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validAWSDNSSECDNSZone())
+				mockExistingAWSTags(expect)
+				mockAWSGetDNSSEC(expect, "SIGNING", awsKeySigningKey("ACTIVE"))
+				mockDisableAWSDNSSEC(expect)
+				mockDeleteAWSZone(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
+			},
+		},
+		{
+			name: "Delete hosted zone with query logging",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validAWSQueryLoggingDNSZone()
+				dz.Status.AWS.QueryLoggingConfigID = "qlc-1"
+				dz.DeletionTimestamp = kubeTimeNow
+				return dz
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				mockAWSZoneExists(expect, validAWSQueryLoggingDNSZone())
+				mockExistingAWSTags(expect)
+				mockDeleteAWSQueryLoggingConfig(expect, "qlc-1")
+				mockDeleteAWSZone(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mocks := setupDefaultMocks(t)
+
+			zr, _ := NewAWSActuator(
+				log.WithField("controller", ControllerName),
+				mocks.fakeKubeClient,
+				awsclient.CredentialsSource{},
+				tc.dnsZone,
+				fakeAWSClientBuilder(mocks.mockAWSClient),
+			)
+
+			recorder := record.NewFakeRecorder(10)
+			r := ReconcileDNSZone{
+				Client:        mocks.fakeKubeClient,
+				logger:        zr.logger,
+				scheme:        scheme.Scheme,
+				eventRecorder: recorder,
+			}
+
+			r.soaLookup = func(string, log.FieldLogger) (bool, error) {
+				return tc.soaLookupResult, nil
+			}
+
+			// This is necessary for the mocks to report failures like methods not being called an expected number of times.
+			defer mocks.mockCtrl.Finish()
+
+			setFakeDNSZoneInKube(mocks, tc.dnsZone)
+
+			if tc.setupAWSMock != nil {
+				tc.setupAWSMock(mocks.mockAWSClient.EXPECT())
+			}
+
+			// Act
+			_, err := r.reconcileDNSProvider(context.TODO(), zr, tc.dnsZone)
+
+			// Assert
+			if tc.errorExpected {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			// Validate
+			zone := &hivev1.DNSZone{}
+			err = mocks.fakeKubeClient.Get(context.TODO(), types.NamespacedName{Namespace: tc.dnsZone.Namespace, Name: tc.dnsZone.Name}, zone)
+			if err != nil {
+				t.Fatalf("unexpected: %v", err)
+			}
+			if tc.validateZone != nil {
+				tc.validateZone(t, zone)
+			}
+			var events []string
+			for len(recorder.Events) > 0 {
+				events = append(events, <-recorder.Events)
+			}
+			for _, e := range tc.expectedEvents {
+				assert.Contains(t, events, e, "expected event not recorded")
+			}
+		})
+	}
+}
+
+// TestReconcileDNSProviderForGCP tests that ReconcileDNSProvider reacts properly under different reconciliation states on GCP.
+func TestReconcileDNSProviderForGCP(t *testing.T) {
+
+	log.SetLevel(log.DebugLevel)
+
+	cases := []struct {
+		name            string
+		dnsZone         *hivev1.DNSZone
+		setupGCPMock    func(*gcpmock.MockClientMockRecorder)
+		validateZone    func(*testing.T, *hivev1.DNSZone)
+		errorExpected   bool
+		soaLookupResult bool
+	}{
+		{
+			name:    "DNSZone without finalizer",
+			dnsZone: validDNSZoneWithoutFinalizer(),
+			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
+				mockGCPZoneExists(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.True(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
+			},
+		},
+		{
+			name:    "Create Managed Zone, No ZoneName Set",
+			dnsZone: validDNSZoneWithoutID(),
+			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
+				mockGCPZoneDoesntExist(expect)
+				mockCreateGCPZone(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.NotNil(t, zone.Status.GCP)
+				assert.NotNil(t, zone.Status.GCP.ZoneName)
+				assert.Equal(t, *zone.Status.GCP.ZoneName, "hive-blah-example-com")
+				assert.Equal(t, zone.Status.NameServers, []string{"ns1.example.com", "ns2.example.com"}, "nameservers must be set in status")
+			},
+		},
+		{
+			name:    "Adopt existing zone, No ZoneName Set",
+			dnsZone: validDNSZoneWithoutID(),
+			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
+				mockGCPZoneExists(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.NotNil(t, zone.Status.GCP)
+				assert.NotNil(t, zone.Status.GCP.ZoneName)
+				assert.Equal(t, *zone.Status.GCP.ZoneName, "hive-blah-example-com")
+				assert.Equal(t, zone.Status.NameServers, []string{"ns1.example.com", "ns2.example.com"}, "nameservers must be set in status")
+			},
+		},
+		{
+			name: "Delete managed zone",
+			dnsZone: testdnszone.BasicBuilder().
+				Options(
+					testdnszone.WithGCPPlatform("testDNSZone"),
+				).
+				GenericOptions(
+					testgeneric.WithNamespace("testNamespace"),
+					testgeneric.WithName("testDNSZone"),
+					testgeneric.Deleted(),
+				).
+				Build(),
+			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
+				mockGCPZoneExists(expect)
+				mockDeleteGCPZone(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
+			},
+		},
+		{
+			name:    "Delete non-existent managed zone",
+			dnsZone: validDNSZoneBeingDeleted(),
+			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
+				mockGCPZoneDoesntExist(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
+			},
+		},
+		{
+			name:    "Create private managed zone",
+			dnsZone: validGCPPrivateDNSZone(),
+			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
+				mockGCPZoneDoesntExist(expect)
+				mockCreateGCPPrivateZone(expect, nil)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				if assert.NotNil(t, zone.Status.GCP) {
+					assert.Equal(t, "hive-blah-example-com", *zone.Status.GCP.ZoneName)
+				}
+				condition := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
+				if assert.NotNil(t, condition, "zone available condition should be set on dnszone") {
+					assert.Equal(t, corev1.ConditionTrue, condition.Status, "private zone should be available without an SOA lookup")
+				}
+			},
+		},
+		{
+			name: "Create peering managed zone",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validGCPPrivateDNSZone()
+				dz.Spec.GCP.Peering = &hivev1.GCPDNSZonePeering{TargetNetworkURL: gcpNetworkURL("hub")}
+				return dz
+			}(),
+			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
+				mockGCPZoneDoesntExist(expect)
+				mockCreateGCPPrivateZone(expect, &dns.ManagedZonePeeringConfig{
+					TargetNetwork: &dns.ManagedZonePeeringConfigTargetNetwork{NetworkUrl: gcpNetworkURL("hub")},
+				})
+			},
+		},
+		{
+			name:    "Sync networks of private managed zone",
+			dnsZone: validGCPPrivateDNSZone(),
+			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
+				mockGCPPrivateZoneExists(expect, "network-1", "network-removed")
+				expect.UpdateManagedZone(gomock.Any(), "hive-blah-example-com", &dns.ManagedZone{
+					PrivateVisibilityConfig: gcpPrivateVisibilityConfig("network-1", "network-2"),
+				}).Return(nil).Times(1)
+			},
+		},
+		{
+			name:    "Existing private managed zone with networks in sync",
+			dnsZone: validGCPPrivateDNSZone(),
+			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
+				mockGCPPrivateZoneExists(expect, "network-2", "network-1")
+			},
+		},
+		{
+			name: "Adopt managed zone by name",
+			dnsZone: testdnszone.BasicBuilder().
+				Options(
+					testdnszone.WithGCPPlatform("testDNSZone"),
+					func(dz *hivev1.DNSZone) {
+						dz.Spec.Zone = "blah.example.com"
+						dz.Spec.GCP.ZoneName = "corp-zone"
+					},
+				).
+				GenericOptions(
+					testgeneric.WithNamespace("testNamespace"),
+					testgeneric.WithName("testDNSZone"),
+					testgeneric.WithFinalizer(hivev1.FinalizerDNSZone),
+				).
+				Build(),
+			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
+				expect.GetManagedZone(gomock.Any(), "corp-zone").Return(&dns.ManagedZone{
+					DnsName:     "blah.example.com.",
+					Name:        "corp-zone",
+					NameServers: []string{"ns1.example.com", "ns2.example.com"},
+				}, nil).Times(1)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				if assert.NotNil(t, zone.Status.GCP) {
+					assert.Equal(t, "corp-zone", *zone.Status.GCP.ZoneName)
+				}
+			},
+		},
+		{
+			name: "Publish record set summary",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validDNSZone()
+				dz.Spec.PublishRecordSetSummary = true
+				return dz
+			}(),
+			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
+				mockGCPZoneExists(expect)
+				mockGCPListRecordSets(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				if assert.NotNil(t, zone.Status.RecordSetSummary, "record set summary must be set") {
+					assert.Equal(t, 3, zone.Status.RecordSetSummary.Count, "expected the record sets of all pages")
+				}
 			},
 		},
 		{
 			name:            "Existing zone, link to parent, reachable SOA",
 			dnsZone:         validDNSZoneWithLinkToParent(),
 			soaLookupResult: true,
-			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
-				mockAWSZoneExists(expect, validDNSZoneWithAdditionalTags())
-				mockExistingAWSTags(expect)
-				mockAWSGetNSRecord(expect)
+			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
+				mockGCPZoneExists(expect)
 			},
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
 				condition := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
@@ -173,18 +867,18 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 			// Arrange
 			mocks := setupDefaultMocks(t)
 
-			zr, _ := NewAWSActuator(
+			zr, _ := NewGCPActuator(
 				log.WithField("controller", ControllerName),
-				mocks.fakeKubeClient,
-				awsclient.CredentialsSource{},
+				validGCPSecret(),
 				tc.dnsZone,
-				fakeAWSClientBuilder(mocks.mockAWSClient),
+				fakeGCPClientBuilder(mocks.mockGCPClient),
 			)
 
 			r := ReconcileDNSZone{
-				Client: mocks.fakeKubeClient,
-				logger: zr.logger,
-				scheme: scheme.Scheme,
+				Client:        mocks.fakeKubeClient,
+				logger:        zr.logger,
+				scheme:        scheme.Scheme,
+				eventRecorder: record.NewFakeRecorder(10),
 			}
 
 			r.soaLookup = func(string, log.FieldLogger) (bool, error) {
@@ -194,14 +888,15 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 			// This is necessary for the mocks to report failures like methods not being called an expected number of times.
 			defer mocks.mockCtrl.Finish()
 
-			setFakeDNSZoneInKube(mocks, tc.dnsZone)
+			err := setFakeDNSZoneInKube(mocks, tc.dnsZone)
+			require.NoError(t, err, "failed to create DNSZone into fake client")
 
-			if tc.setupAWSMock != nil {
-				tc.setupAWSMock(mocks.mockAWSClient.EXPECT())
+			if tc.setupGCPMock != nil {
+				tc.setupGCPMock(mocks.mockGCPClient.EXPECT())
 			}
 
 			// Act
-			_, err := r.reconcileDNSProvider(zr, tc.dnsZone)
+			_, err = r.reconcileDNSProvider(context.TODO(), zr, tc.dnsZone)
 
 			// Assert
 			if tc.errorExpected {
@@ -223,98 +918,85 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 	}
 }
 
-// TestReconcileDNSProviderForGCP tests that ReconcileDNSProvider reacts properly under different reconciliation states on GCP.
-func TestReconcileDNSProviderForGCP(t *testing.T) {
+// TestReconcileDNSProviderForIBMCloud tests that ReconcileDNSProvider reacts properly under different reconciliation states on IBM Cloud.
+func TestReconcileDNSProviderForIBMCloud(t *testing.T) {
 
 	log.SetLevel(log.DebugLevel)
 
 	cases := []struct {
 		name            string
 		dnsZone         *hivev1.DNSZone
-		setupGCPMock    func(*gcpmock.MockClientMockRecorder)
+		setupIBMMock    func(*ibmmock.MockClientMockRecorder)
 		validateZone    func(*testing.T, *hivev1.DNSZone)
 		errorExpected   bool
 		soaLookupResult bool
 	}{
 		{
-			name:    "DNSZone without finalizer",
-			dnsZone: validDNSZoneWithoutFinalizer(),
-			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
-				mockGCPZoneExists(expect)
+			name: "Create CIS zone, No ID Set",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validIBMCloudDNSZone()
+				dz.Status.IBMCloud = nil
+				return dz
+			}(),
+			setupIBMMock: func(expect *ibmmock.MockClientMockRecorder) {
+				mockIBMCloudListZones(expect, ibmclient.Zone{ID: "other", Name: "other.example.com"})
+				mockCreateIBMCloudZone(expect)
 			},
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
-				assert.True(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
+				if assert.NotNil(t, zone.Status.IBMCloud) {
+					assert.Equal(t, "1234", aws.StringValue(zone.Status.IBMCloud.ZoneID))
+				}
+				assert.Equal(t, []string{"ns1.example.com", "ns2.example.com"}, zone.Status.NameServers, "nameservers must be set in status")
 			},
 		},
 		{
-			name:    "Create Managed Zone, No ZoneName Set",
-			dnsZone: validDNSZoneWithoutID(),
-			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
-				mockGCPZoneDoesntExist(expect)
-				mockCreateGCPZone(expect)
+			name: "Adopt existing zone, No ID Set",
+			dnsZone: func() *hivev1.DNSZone {
+				dz := validIBMCloudDNSZone()
+				dz.Status.IBMCloud = nil
+				return dz
+			}(),
+			setupIBMMock: func(expect *ibmmock.MockClientMockRecorder) {
+				mockIBMCloudListZones(expect, *ibmCloudZone())
 			},
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
-				assert.NotNil(t, zone.Status.GCP)
-				assert.NotNil(t, zone.Status.GCP.ZoneName)
-				assert.Equal(t, *zone.Status.GCP.ZoneName, "hive-blah-example-com")
-				assert.Equal(t, zone.Status.NameServers, []string{"ns1.example.com", "ns2.example.com"}, "nameservers must be set in status")
+				if assert.NotNil(t, zone.Status.IBMCloud) {
+					assert.Equal(t, "1234", aws.StringValue(zone.Status.IBMCloud.ZoneID))
+				}
+				assert.Equal(t, []string{"ns1.example.com", "ns2.example.com"}, zone.Status.NameServers, "nameservers must be set in status")
 			},
 		},
 		{
-			name:    "Adopt existing zone, No ZoneName Set",
-			dnsZone: validDNSZoneWithoutID(),
-			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
-				mockGCPZoneExists(expect)
+			name:    "Existing zone",
+			dnsZone: validIBMCloudDNSZone(),
+			setupIBMMock: func(expect *ibmmock.MockClientMockRecorder) {
+				mockIBMCloudZoneExists(expect)
 			},
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
-				assert.NotNil(t, zone.Status.GCP)
-				assert.NotNil(t, zone.Status.GCP.ZoneName)
-				assert.Equal(t, *zone.Status.GCP.ZoneName, "hive-blah-example-com")
-				assert.Equal(t, zone.Status.NameServers, []string{"ns1.example.com", "ns2.example.com"}, "nameservers must be set in status")
+				assert.Equal(t, []string{"ns1.example.com", "ns2.example.com"}, zone.Status.NameServers, "nameservers must be set in status")
 			},
 		},
 		{
-			name: "Delete managed zone",
-			dnsZone: testdnszone.BasicBuilder().
-				Options(
-					testdnszone.WithGCPPlatform("testDNSZone"),
-				).
-				GenericOptions(
-					testgeneric.WithNamespace("testNamespace"),
-					testgeneric.WithName("testDNSZone"),
-					testgeneric.Deleted(),
-				).
-				Build(),
-			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
-				mockGCPZoneExists(expect)
-				mockDeleteGCPZone(expect)
+			name:    "Delete CIS zone",
+			dnsZone: validIBMCloudDNSZoneBeingDeleted(),
+			setupIBMMock: func(expect *ibmmock.MockClientMockRecorder) {
+				mockIBMCloudZoneExists(expect)
+				mockDeleteIBMCloudZone(expect)
 			},
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
 				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
 			},
 		},
 		{
-			name:    "Delete non-existent managed zone",
-			dnsZone: validDNSZoneBeingDeleted(),
-			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
-				mockGCPZoneDoesntExist(expect)
+			name:    "Delete non-existent CIS zone",
+			dnsZone: validIBMCloudDNSZoneBeingDeleted(),
+			setupIBMMock: func(expect *ibmmock.MockClientMockRecorder) {
+				mockIBMCloudZoneDoesntExist(expect)
 			},
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
 				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
 			},
 		},
-		{
-			name:            "Existing zone, link to parent, reachable SOA",
-			dnsZone:         validDNSZoneWithLinkToParent(),
-			soaLookupResult: true,
-			setupGCPMock: func(expect *gcpmock.MockClientMockRecorder) {
-				mockGCPZoneExists(expect)
-			},
-			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
-				condition := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
-				assert.NotNil(t, condition, "zone available condition should be set on dnszone")
-			},
-		},
 	}
 
 	for _, tc := range cases {
@@ -322,17 +1004,18 @@ func TestReconcileDNSProviderForGCP(t *testing.T) {
 			// Arrange
 			mocks := setupDefaultMocks(t)
 
-			zr, _ := NewGCPActuator(
+			zr, _ := NewIBMCloudActuator(
 				log.WithField("controller", ControllerName),
-				validGCPSecret(),
+				validIBMCloudSecret(),
 				tc.dnsZone,
-				fakeGCPClientBuilder(mocks.mockGCPClient),
+				fakeIBMClientBuilder(mocks.mockIBMClient),
 			)
 
 			r := ReconcileDNSZone{
-				Client: mocks.fakeKubeClient,
-				logger: zr.logger,
-				scheme: scheme.Scheme,
+				Client:        mocks.fakeKubeClient,
+				logger:        zr.logger,
+				scheme:        scheme.Scheme,
+				eventRecorder: record.NewFakeRecorder(10),
 			}
 
 			r.soaLookup = func(string, log.FieldLogger) (bool, error) {
@@ -345,12 +1028,12 @@ func TestReconcileDNSProviderForGCP(t *testing.T) {
 			err := setFakeDNSZoneInKube(mocks, tc.dnsZone)
 			require.NoError(t, err, "failed to create DNSZone into fake client")
 
-			if tc.setupGCPMock != nil {
-				tc.setupGCPMock(mocks.mockGCPClient.EXPECT())
+			if tc.setupIBMMock != nil {
+				tc.setupIBMMock(mocks.mockIBMClient.EXPECT())
 			}
 
 			// Act
-			_, err = r.reconcileDNSProvider(zr, tc.dnsZone)
+			_, err = r.reconcileDNSProvider(context.TODO(), zr, tc.dnsZone)
 
 			// Assert
 			if tc.errorExpected {
@@ -372,6 +1055,121 @@ func TestReconcileDNSProviderForGCP(t *testing.T) {
 	}
 }
 
+// TestReconcileDNSProviderForWebhook tests that ReconcileDNSProvider reacts properly under different reconciliation states with a DNS webhook.
+func TestReconcileDNSProviderForWebhook(t *testing.T) {
+
+	log.SetLevel(log.DebugLevel)
+
+	cases := []struct {
+		name          string
+		existingZones []string
+		deleted       bool
+		token         string
+		validateZone  func(*testing.T, *hivev1.DNSZone)
+		expectedZones []string
+		errorExpected bool
+	}{
+		{
+			name: "Create zone",
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Equal(t, []string{"ns1.example.com", "ns2.example.com"}, zone.Status.NameServers, "nameservers must be set in status")
+			},
+			expectedZones: []string{"blah.example.com"},
+		},
+		{
+			name:          "Existing zone",
+			existingZones: []string{"blah.example.com"},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Equal(t, []string{"ns1.example.com", "ns2.example.com"}, zone.Status.NameServers, "nameservers must be set in status")
+			},
+			expectedZones: []string{"blah.example.com"},
+		},
+		{
+			name:          "Delete zone",
+			existingZones: []string{"blah.example.com", "other.example.com"},
+			deleted:       true,
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
+			},
+			expectedZones: []string{"other.example.com"},
+		},
+		{
+			name:    "Delete non-existent zone",
+			deleted: true,
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
+			},
+		},
+		{
+			name:          "Webhook error",
+			existingZones: []string{"blah.example.com"},
+			token:         "wrong",
+			errorExpected: true,
+			expectedZones: []string{"blah.example.com"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mocks := setupDefaultMocks(t)
+			defer mocks.mockCtrl.Finish()
+
+			webhook := newFakeDNSWebhook(tc.existingZones...)
+			defer webhook.Close()
+
+			dnsZone := webhookDNSZone(webhook.URL)
+			if tc.deleted {
+				dnsZone.DeletionTimestamp = kubeTimeNow
+			}
+			token := tc.token
+			if token == "" {
+				token = testWebhookToken
+			}
+			zr, err := NewWebhookActuator(log.WithField("controller", ControllerName), webhook.Client(), token, dnsZone)
+			require.NoError(t, err)
+
+			r := ReconcileDNSZone{
+				Client:        mocks.fakeKubeClient,
+				logger:        zr.logger,
+				scheme:        scheme.Scheme,
+				eventRecorder: record.NewFakeRecorder(10),
+			}
+			r.soaLookup = func(string, log.FieldLogger) (bool, error) {
+				return false, nil
+			}
+
+			err = setFakeDNSZoneInKube(mocks, dnsZone)
+			require.NoError(t, err, "failed to create DNSZone into fake client")
+
+			// Act
+			_, err = r.reconcileDNSProvider(context.TODO(), zr, dnsZone)
+
+			// Assert
+			if tc.errorExpected {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			var zones []string
+			for zone := range webhook.zones {
+				zones = append(zones, zone)
+			}
+			assert.ElementsMatch(t, tc.expectedZones, zones, "unexpected zones in webhook")
+
+			// Validate
+			zone := &hivev1.DNSZone{}
+			err = mocks.fakeKubeClient.Get(context.TODO(), types.NamespacedName{Namespace: dnsZone.Namespace, Name: dnsZone.Name}, zone)
+			if err != nil {
+				t.Fatalf("unexpected: %v", err)
+			}
+			if tc.validateZone != nil {
+				tc.validateZone(t, zone)
+			}
+		})
+	}
+}
+
 // TestReconcileDNSProviderForAzure tests that ReconcileDNSProvider reacts properly under different reconciliation states on Azure.
 func TestReconcileDNSProviderForAzure(t *testing.T) {
 
@@ -449,6 +1247,71 @@ func TestReconcileDNSProviderForAzure(t *testing.T) {
 				assert.NotNil(t, condition, "zone available condition should be set on dnszone")
 			},
 		},
+		{
+			name:    "Create private zone",
+			dnsZone: validAzurePrivateDNSZone(),
+			setupAzureMock: func(_ *gomock.Controller, expect *azuremock.MockClientMockRecorder) {
+				mockAzurePrivateZoneDoesntExist(expect)
+				mockCreateAzurePrivateZone(expect)
+				mockCreateAzureVirtualNetworkLink(expect, validAzurePrivateDNSZone())
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Empty(t, zone.Status.NameServers, "private zones must not have nameservers")
+				condition := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
+				if assert.NotNil(t, condition, "zone available condition should be set on dnszone") {
+					assert.Equal(t, corev1.ConditionTrue, condition.Status, "private zone should be available without an SOA lookup")
+				}
+				assert.Equal(t, []hivev1.AzureVirtualNetworkLinkStatus{{
+					Name:             "vnet-link",
+					VirtualNetworkID: "/subscriptions/sub/resourceGroups/default/providers/Microsoft.Network/virtualNetworks/vnet",
+					State:            "Completed",
+				}}, zone.Status.Azure.VirtualNetworkLinks, "unexpected virtual network links in status")
+			},
+		},
+		{
+			name:    "Sync virtual network links of private zone",
+			dnsZone: validAzurePrivateDNSZone(),
+			setupAzureMock: func(mockCtrl *gomock.Controller, expect *azuremock.MockClientMockRecorder) {
+				mockAzurePrivateZoneExists(mockCtrl, expect,
+					azureVirtualNetworkLink("removed-link", "removed-vnet", "ns/dnszoneobject"),
+					azureVirtualNetworkLink("other-link", "other-vnet", ""),
+				)
+				mockCreateAzureVirtualNetworkLink(expect, validAzurePrivateDNSZone())
+				expect.DeleteVirtualNetworkLink(gomock.Any(), "default", "blah.example.com", "removed-link").Return(nil).Times(1)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				var names []string
+				for _, link := range zone.Status.Azure.VirtualNetworkLinks {
+					names = append(names, link.Name)
+				}
+				assert.Equal(t, []string{"other-link", "vnet-link"}, names, "unexpected virtual network links in status")
+			},
+		},
+		{
+			name:    "Existing private zone with links in sync",
+			dnsZone: validAzurePrivateDNSZone(),
+			setupAzureMock: func(mockCtrl *gomock.Controller, expect *azuremock.MockClientMockRecorder) {
+				mockAzurePrivateZoneExists(mockCtrl, expect,
+					azureVirtualNetworkLink("vnet-link", "/subscriptions/sub/resourceGroups/default/providers/Microsoft.Network/virtualNetworks/vnet", "ns/dnszoneobject"),
+				)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.Len(t, zone.Status.Azure.VirtualNetworkLinks, 1, "unexpected virtual network links in status")
+			},
+		},
+		{
+			name:    "Delete private zone",
+			dnsZone: validAzurePrivateDNSZoneBeingDeleted(),
+			setupAzureMock: func(mockCtrl *gomock.Controller, expect *azuremock.MockClientMockRecorder) {
+				mockAzurePrivateZoneExists(mockCtrl, expect,
+					azureVirtualNetworkLink("vnet-link", "/subscriptions/sub/resourceGroups/default/providers/Microsoft.Network/virtualNetworks/vnet", "ns/dnszoneobject"),
+				)
+				mockDeleteAzurePrivateZone(mockCtrl, expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				assert.False(t, controllerutils.HasFinalizer(zone, hivev1.FinalizerDNSZone))
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -464,9 +1327,10 @@ func TestReconcileDNSProviderForAzure(t *testing.T) {
 			)
 
 			r := ReconcileDNSZone{
-				Client: mocks.fakeKubeClient,
-				logger: zr.logger,
-				scheme: scheme.Scheme,
+				Client:        mocks.fakeKubeClient,
+				logger:        zr.logger,
+				scheme:        scheme.Scheme,
+				eventRecorder: record.NewFakeRecorder(10),
 			}
 
 			r.soaLookup = func(string, log.FieldLogger) (bool, error) {
@@ -483,7 +1347,7 @@ func TestReconcileDNSProviderForAzure(t *testing.T) {
 			}
 
 			// Act
-			_, err := r.reconcileDNSProvider(zr, tc.dnsZone)
+			_, err := r.reconcileDNSProvider(context.TODO(), zr, tc.dnsZone)
 
 			// Assert
 			if tc.errorExpected {
@@ -513,12 +1377,16 @@ func TestSetConditionsForErrorForAWS(t *testing.T) {
 		name            string
 		dnsZone         *hivev1.DNSZone
 		error           error
+		setupAWSMock    func(*mock.MockClientMockRecorder)
 		expectCondition *hivev1.DNSZoneCondition
 	}{
 		{
 			name:    "Set InsufficientCredentialsCondition on DNSZone for AccessDeniedException error",
 			dnsZone: validDNSZone(),
 			error:   testAccessDeniedExceptionError(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				expect.GetCallerIdentity(gomock.Any(), gomock.Any()).Return(nil, testAccessDeniedExceptionError())
+			},
 			expectCondition: &hivev1.DNSZoneCondition{
 				Type:    hivev1.InsufficientCredentialsCondition,
 				Status:  corev1.ConditionTrue,
@@ -526,6 +1394,34 @@ func TestSetConditionsForErrorForAWS(t *testing.T) {
 				Message: "AccessDenied error encountered (see controller logs for details)",
 			},
 		},
+		{
+			name:    "Set InsufficientCredentialsCondition with missing permissions on DNSZone for AccessDeniedException error",
+			dnsZone: validDNSZone(),
+			error:   testAccessDeniedExceptionError(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				expect.GetCallerIdentity(gomock.Any(), gomock.Any()).Return(&sts.GetCallerIdentityOutput{
+					Arn: aws.String("arn:aws:iam::123456789012:user/hive"),
+				}, nil)
+				expect.SimulatePrincipalPolicy(gomock.Any(), gomock.Any()).Return(&iam.SimulatePolicyResponse{
+					EvaluationResults: []*iam.EvaluationResult{
+						{
+							EvalActionName: aws.String("route53:CreateHostedZone"),
+							EvalDecision:   aws.String(iam.PolicyEvaluationDecisionTypeImplicitDeny),
+						},
+						{
+							EvalActionName: aws.String("route53:GetHostedZone"),
+							EvalDecision:   aws.String(iam.PolicyEvaluationDecisionTypeAllowed),
+						},
+					},
+				}, nil)
+			},
+			expectCondition: &hivev1.DNSZoneCondition{
+				Type:    hivev1.InsufficientCredentialsCondition,
+				Status:  corev1.ConditionTrue,
+				Reason:  accessDeniedReason,
+				Message: "credentials are missing 1 required permission(s): route53:CreateHostedZone",
+			},
+		},
 		{
 			name:    "Set AuthenticationFailureCondition on DNSZone for UnrecognizedClientException error",
 			dnsZone: validDNSZone(),
@@ -564,11 +1460,15 @@ func TestSetConditionsForErrorForAWS(t *testing.T) {
 
 			zr.dnsZone = tc.dnsZone
 
+			if tc.setupAWSMock != nil {
+				tc.setupAWSMock(mocks.mockAWSClient.EXPECT())
+			}
+
 			// This is necessary for the mocks to report failures like methods not being called an expected number of times.
 			defer mocks.mockCtrl.Finish()
 
 			// Act
-			zr.SetConditionsForError(tc.error)
+			zr.SetConditionsForError(context.TODO(), tc.error)
 
 			// Assert
 			if tc.expectCondition != nil {
@@ -606,3 +1506,134 @@ func testInvalidSignatureExceptionError() error {
 		fmt.Errorf("The request signature we calculated does not match the signature you provided. Check your AWS Secret Access Key and signing method. Consult the service documentation for details"))
 	return invalidSignatureErr
 }
+
+func TestShouldSync(t *testing.T) {
+	recentlySynced := func() *hivev1.DNSZone {
+		zone := validDNSZone()
+		zone.Status.LastSyncTimestamp = kubeTimeNow
+		zone.Status.LastSyncGeneration = zone.Generation
+		return zone
+	}
+	withCondition := func(zone *hivev1.DNSZone, conditionType hivev1.DNSZoneConditionType, status corev1.ConditionStatus) *hivev1.DNSZone {
+		zone.Status.Conditions = append(zone.Status.Conditions, hivev1.DNSZoneCondition{
+			Type:   conditionType,
+			Status: status,
+		})
+		return zone
+	}
+	cases := []struct {
+		name     string
+		dnsZone  *hivev1.DNSZone
+		expected bool
+	}{
+		{
+			name:     "never synced",
+			dnsZone:  validDNSZone(),
+			expected: true,
+		},
+		{
+			name:     "recently synced",
+			dnsZone:  recentlySynced(),
+			expected: false,
+		},
+		{
+			name:     "recently synced with insufficient credentials",
+			dnsZone:  withCondition(recentlySynced(), hivev1.InsufficientCredentialsCondition, corev1.ConditionTrue),
+			expected: true,
+		},
+		{
+			name:     "recently synced with authentication failure",
+			dnsZone:  withCondition(recentlySynced(), hivev1.AuthenticationFailureCondition, corev1.ConditionTrue),
+			expected: true,
+		},
+		{
+			name:     "recently synced with cleared authentication failure",
+			dnsZone:  withCondition(recentlySynced(), hivev1.AuthenticationFailureCondition, corev1.ConditionFalse),
+			expected: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, _ := shouldSync(tc.dnsZone)
+			assert.Equal(t, tc.expected, actual, "unexpected shouldSync result")
+		})
+	}
+}
+
+// TestReconcileDNSZoneForPausedClusterDeployment tests that the child DNSZone of a paused ClusterDeployment is not
+// reconciled.
+func TestReconcileDNSZoneForPausedClusterDeployment(t *testing.T) {
+	cases := []struct {
+		name          string
+		paused        bool
+		expectedError bool
+	}{
+		{
+			name:   "paused",
+			paused: true,
+		},
+		{
+			// The actuator cannot be created without the credentials secret.
+			name:          "not paused",
+			expectedError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			zone := validDNSZoneWithoutFinalizer()
+			zone.Labels = map[string]string{
+				constants.DNSZoneTypeLabel:           constants.DNSZoneTypeChild,
+				constants.ClusterDeploymentNameLabel: "test-cluster",
+			}
+			cd := testcd.Build(
+				testcd.WithNamespace(zone.Namespace),
+				testcd.WithName("test-cluster"),
+			)
+			cd.Spec.Paused = tc.paused
+			fakeClient := fakekubeclient.NewFakeClientWithScheme(scheme.Scheme, zone, cd)
+			r := ReconcileDNSZone{
+				Client:        fakeClient,
+				logger:        log.WithField("controller", ControllerName),
+				scheme:        scheme.Scheme,
+				eventRecorder: record.NewFakeRecorder(10),
+			}
+
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: zone.Namespace, Name: zone.Name}})
+			if tc.expectedError {
+				assert.Error(t, err, "expected error reconciling dns zone")
+			} else {
+				assert.NoError(t, err, "unexpected error reconciling dns zone")
+			}
+
+			actual := &hivev1.DNSZone{}
+			require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: zone.Namespace, Name: zone.Name}, actual))
+			assert.False(t, controllerutils.HasFinalizer(actual, hivev1.FinalizerDNSZone), "unexpected finalizer on dns zone")
+		})
+	}
+}
+
+// TestReconcileDNSZonePreserveOnDelete tests that deleting a DNSZone with PreserveOnDelete set removes its finalizer
+// without deleting the hosted zone.
+func TestReconcileDNSZonePreserveOnDelete(t *testing.T) {
+	// The credentials secret is absent, so any call to the dns provider would fail.
+	zone := validDNSZoneBeingDeleted()
+	zone.Spec.PreserveOnDelete = true
+	fakeClient := fakekubeclient.NewFakeClientWithScheme(scheme.Scheme, zone)
+	recorder := record.NewFakeRecorder(10)
+	r := ReconcileDNSZone{
+		Client:        fakeClient,
+		logger:        log.WithField("controller", ControllerName),
+		scheme:        scheme.Scheme,
+		eventRecorder: recorder,
+	}
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: zone.Namespace, Name: zone.Name}})
+	require.NoError(t, err, "unexpected error reconciling dns zone")
+
+	actual := &hivev1.DNSZone{}
+	require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: zone.Namespace, Name: zone.Name}, actual))
+	assert.False(t, controllerutils.HasFinalizer(actual, hivev1.FinalizerDNSZone), "expected the finalizer to be removed")
+	if assert.Len(t, recorder.Events, 1) {
+		assert.Equal(t, "Normal ZonePreserved Preserved hosted zone on deletion of the DNSZone", <-recorder.Events)
+	}
+}
@@ -1,6 +1,7 @@
 package dnszone
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
@@ -12,12 +13,16 @@ import (
 
 	dns "google.golang.org/api/dns/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 const (
 	zoneNotEmptyReason = "containerNotEmpty"
+
+	// gcpPrivateVisibility is the visibility of Cloud DNS private managed zones.
+	gcpPrivateVisibility = "private"
 )
 
 // GCPActuator attempts to make the current state reflect the given desired state.
@@ -64,19 +69,34 @@ func NewGCPActuator(
 // Ensure GCPActuator implements the Actuator interface. This will fail at compile time when false.
 var _ Actuator = &GCPActuator{}
 
+// Ensure GCPActuator implements the RecordSetLister interface. This will fail at compile time when false.
+var _ RecordSetLister = &GCPActuator{}
+
 // Create implements the Create call of the actuator interface
-func (a *GCPActuator) Create() error {
+func (a *GCPActuator) Create(ctx context.Context) error {
 	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+	if zoneName := a.adoptedZoneName(); zoneName != "" {
+		// Zones to adopt are never created, as that would silently replace the zone of the other tooling.
+		return errors.Errorf("managed zone %s to adopt does not exist", zoneName)
+	}
 	logger.Info("Creating managed zone")
 
 	zone := a.dnsZone.Spec.Zone
-	managedZone, err := a.gcpClient.CreateManagedZone(
-		&dns.ManagedZone{
-			Name:        generateManagedZoneName(zone),
-			Description: managedByHiveDescription,
-			DnsName:     controllerutils.Dotted(zone),
-		},
-	)
+	desired := &dns.ManagedZone{
+		Name:        generateManagedZoneName(zone),
+		Description: managedByHiveDescription,
+		DnsName:     controllerutils.Dotted(zone),
+	}
+	if a.private() {
+		desired.Visibility = gcpPrivateVisibility
+		desired.PrivateVisibilityConfig = a.privateVisibilityConfig()
+		if peering := a.dnsZone.Spec.GCP.Peering; peering != nil {
+			desired.PeeringConfig = &dns.ManagedZonePeeringConfig{
+				TargetNetwork: &dns.ManagedZonePeeringConfigTargetNetwork{NetworkUrl: peering.TargetNetworkURL},
+			}
+		}
+	}
+	managedZone, err := a.gcpClient.CreateManagedZone(ctx, desired)
 
 	if err != nil {
 		logger.WithError(err).Error("Error creating managed zone")
@@ -94,7 +114,7 @@ func (a *GCPActuator) Create() error {
 }
 
 // Delete implements the Delete call of the actuator interface
-func (a *GCPActuator) Delete() error {
+func (a *GCPActuator) Delete(ctx context.Context) error {
 	if a.dnsZone.Status.GCP == nil {
 		return errors.New("deleting non-GCP DNSZone with GCP actuator")
 	}
@@ -106,12 +126,12 @@ func (a *GCPActuator) Delete() error {
 	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("zoneName", zoneName)
 
 	logger.Info("Deleting recordsets in managedzone")
-	if err := DeleteGCPRecordSets(a.gcpClient, a.dnsZone, logger); err != nil {
+	if err := DeleteGCPRecordSets(ctx, a.gcpClient, a.dnsZone, logger); err != nil {
 		return err
 	}
 
 	logger.Info("Deleting managed zone")
-	err := a.gcpClient.DeleteManagedZone(zoneName)
+	err := a.gcpClient.DeleteManagedZone(ctx, zoneName)
 	if err != nil {
 		logLevel := log.ErrorLevel
 		if gcpErr, ok := err.(*googleapi.Error); ok && gcpErr.Code == http.StatusBadRequest {
@@ -128,10 +148,10 @@ func (a *GCPActuator) Delete() error {
 }
 
 // DeleteGCPRecordSets will delete all non-essential DNS records in the DNSZone provided
-func DeleteGCPRecordSets(gcpClient gcpclient.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
+func DeleteGCPRecordSets(ctx context.Context, gcpClient gcpclient.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
 	listOpts := gcpclient.ListResourceRecordSetsOptions{}
 	for {
-		listOutput, err := gcpClient.ListResourceRecordSets(*dnsZone.Status.GCP.ZoneName, listOpts)
+		listOutput, err := gcpClient.ListResourceRecordSets(ctx, *dnsZone.Status.GCP.ZoneName, listOpts)
 		if err != nil {
 			return err
 		}
@@ -141,12 +161,16 @@ func DeleteGCPRecordSets(gcpClient gcpclient.Client, dnsZone *hivev1.DNSZone, lo
 			if n, t := recordSet.Name, recordSet.Type; n == controllerutils.Dotted(dnsZone.Spec.Zone) && (t == "NS" || t == "SOA") {
 				continue
 			}
+			if isPreservedRecordSet(dnsZone, recordSet.Name) {
+				logger.WithField("name", recordSet.Name).WithField("type", recordSet.Type).Info("preserving recordset")
+				continue
+			}
 			logger.WithField("name", recordSet.Name).WithField("type", recordSet.Type).Info("recordset set for deletion")
 			recordSetsToDelete = append(recordSetsToDelete, recordSet)
 		}
 		if len(recordSetsToDelete) > 0 {
 			logger.WithField("count", len(recordSetsToDelete)).Info("deleting recordsets")
-			if err := gcpClient.DeleteResourceRecordSets(*dnsZone.Status.GCP.ZoneName, recordSetsToDelete); err != nil {
+			if err := gcpClient.DeleteResourceRecordSets(ctx, *dnsZone.Status.GCP.ZoneName, recordSetsToDelete); err != nil {
 				return err
 			}
 		}
@@ -158,17 +182,92 @@ func DeleteGCPRecordSets(gcpClient gcpclient.Client, dnsZone *hivev1.DNSZone, lo
 	return nil
 }
 
+// ListRecordSets implements the ListRecordSets call of the RecordSetLister interface
+func (a *GCPActuator) ListRecordSets(ctx context.Context) ([]RecordSet, error) {
+	if a.managedZone == nil {
+		return nil, errors.New("managedZone is unpopulated")
+	}
+
+	var recordSets []RecordSet
+	listOpts := gcpclient.ListResourceRecordSetsOptions{}
+	for {
+		listOutput, err := a.gcpClient.ListResourceRecordSets(ctx, a.managedZone.Name, listOpts)
+		if err != nil {
+			return nil, err
+		}
+		for _, recordSet := range listOutput.Rrsets {
+			recordSets = append(recordSets, RecordSet{Name: recordSet.Name, Type: recordSet.Type})
+		}
+		if listOutput.NextPageToken == "" {
+			return recordSets, nil
+		}
+		listOpts.PageToken = listOutput.NextPageToken
+	}
+}
+
 // Exists implements the Exists call of the actuator interface
-func (a *GCPActuator) Exists() (bool, error) {
+func (a *GCPActuator) Exists(ctx context.Context) (bool, error) {
 	return a.managedZone != nil, nil
 }
 
 // UpdateMetadata implements the UpdateMetadata call of the actuator interface
-func (a *GCPActuator) UpdateMetadata() error {
-	// Nothing to do here since GCP CloudDNS doesn't support tags.
+func (a *GCPActuator) UpdateMetadata(ctx context.Context) error {
+	// GCP CloudDNS doesn't support tags, so only the networks of private zones are synced.
+	if !a.private() {
+		return nil
+	}
+	return a.syncNetworks(ctx)
+}
+
+// syncNetworks attaches the networks in the spec to the private managed zone, and detaches the networks that are not
+// in the spec.
+func (a *GCPActuator) syncNetworks(ctx context.Context) error {
+	if a.managedZone == nil {
+		return errors.New("managedZone is unpopulated")
+	}
+
+	current := sets.NewString()
+	if a.managedZone.PrivateVisibilityConfig != nil {
+		for _, network := range a.managedZone.PrivateVisibilityConfig.Networks {
+			current.Insert(network.NetworkUrl)
+		}
+	}
+	desired := a.privateVisibilityConfig()
+	desiredURLs := sets.NewString()
+	for _, network := range desired.Networks {
+		desiredURLs.Insert(network.NetworkUrl)
+	}
+	if current.Equal(desiredURLs) {
+		return nil
+	}
+
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("networks", desiredURLs.List())
+	logger.Info("Updating the networks of the private managed zone")
+	if err := a.gcpClient.UpdateManagedZone(ctx, a.managedZone.Name, &dns.ManagedZone{PrivateVisibilityConfig: desired}); err != nil {
+		logger.WithError(err).Error("Cannot update the networks of the private managed zone")
+		return err
+	}
+	a.managedZone.PrivateVisibilityConfig = desired
 	return nil
 }
 
+// privateVisibilityConfig returns the private visibility configuration of the managed zone for the networks in the
+// spec.
+func (a *GCPActuator) privateVisibilityConfig() *dns.ManagedZonePrivateVisibilityConfig {
+	config := &dns.ManagedZonePrivateVisibilityConfig{}
+	for _, network := range a.dnsZone.Spec.GCP.Networks {
+		config.Networks = append(config.Networks, &dns.ManagedZonePrivateVisibilityConfigNetwork{
+			NetworkUrl: network.NetworkURL,
+		})
+	}
+	return config
+}
+
+// private returns whether the zone is a Cloud DNS private managed zone.
+func (a *GCPActuator) private() bool {
+	return isGCPPrivateZone(a.dnsZone)
+}
+
 // modifyStatus updates the DnsZone's status with GCP specific information.
 func (a *GCPActuator) modifyStatus() error {
 	if a.managedZone == nil {
@@ -183,7 +282,7 @@ func (a *GCPActuator) modifyStatus() error {
 }
 
 // GetNameServers implements the GetNameServers call of the actuator interface
-func (a *GCPActuator) GetNameServers() ([]string, error) {
+func (a *GCPActuator) GetNameServers(ctx context.Context) ([]string, error) {
 	if a.managedZone == nil {
 		return nil, errors.New("managedZone is unpopulated")
 	}
@@ -195,9 +294,13 @@ func (a *GCPActuator) GetNameServers() ([]string, error) {
 }
 
 // Refresh implements the Refresh call of the actuator interface
-func (a *GCPActuator) Refresh() error {
+func (a *GCPActuator) Refresh(ctx context.Context) error {
 	var zoneName string
-	if a.dnsZone.Status.GCP != nil && a.dnsZone.Status.GCP.ZoneName != nil {
+	adoptedZoneName := a.adoptedZoneName()
+	if adoptedZoneName != "" {
+		a.logger.Debug("ZoneName to adopt is set in spec, will retrieve by that name")
+		zoneName = adoptedZoneName
+	} else if a.dnsZone.Status.GCP != nil && a.dnsZone.Status.GCP.ZoneName != nil {
 		a.logger.Debug("ZoneName is set in status, will retrieve by that name")
 		zoneName = *a.dnsZone.Status.GCP.ZoneName
 	}
@@ -210,7 +313,7 @@ func (a *GCPActuator) Refresh() error {
 	// Fetch the managed zone
 	logger := a.logger.WithField("zoneName", zoneName)
 	logger.Debug("Fetching managed zone by zone name")
-	resp, err := a.gcpClient.GetManagedZone(zoneName)
+	resp, err := a.gcpClient.GetManagedZone(ctx, zoneName)
 	if err != nil {
 		if gerr, ok := err.(*googleapi.Error); ok {
 			if gerr.Code == http.StatusNotFound {
@@ -224,6 +327,10 @@ func (a *GCPActuator) Refresh() error {
 		return err
 	}
 
+	if adoptedZoneName != "" && controllerutils.Dotted(resp.DnsName) != controllerutils.Dotted(a.dnsZone.Spec.Zone) {
+		return errors.Errorf("managed zone %s to adopt is for %s, not %s", adoptedZoneName, resp.DnsName, a.dnsZone.Spec.Zone)
+	}
+
 	logger.Debug("Found managed zone")
 	a.managedZone = resp
 	if err := a.modifyStatus(); err != nil {
@@ -235,10 +342,18 @@ func (a *GCPActuator) Refresh() error {
 }
 
 // SetConditionsForError sets conditions on the dnszone given a specific error. Returns true if conditions changed.
-func (a *GCPActuator) SetConditionsForError(err error) bool {
+func (a *GCPActuator) SetConditionsForError(ctx context.Context, err error) bool {
 	return false // Not implemented for GCP yet.
 }
 
+// adoptedZoneName returns the name of the existing managed zone to adopt, if any.
+func (a *GCPActuator) adoptedZoneName() string {
+	if a.dnsZone.Spec.GCP == nil {
+		return ""
+	}
+	return a.dnsZone.Spec.GCP.ZoneName
+}
+
 func generateManagedZoneName(zone string) string {
 	tmp := strings.ToLower(zone)
 	tmp = strings.ReplaceAll(tmp, ".", "-")
@@ -5,6 +5,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/gcpclient"
 	"github.com/openshift/hive/pkg/gcpclient/mock"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -54,7 +55,7 @@ func TestNewGCPActuator(t *testing.T) {
 }
 
 func mockGCPZoneExists(expect *mock.MockClientMockRecorder) {
-	expect.GetManagedZone(gomock.Any()).Return(&dns.ManagedZone{
+	expect.GetManagedZone(gomock.Any(), gomock.Any()).Return(&dns.ManagedZone{
 		DnsName:     "blah.example.com",
 		Name:        "hive-blah-example-com",
 		NameServers: []string{"ns1.example.com", "ns2.example.com"},
@@ -62,20 +63,75 @@ func mockGCPZoneExists(expect *mock.MockClientMockRecorder) {
 }
 
 func mockGCPZoneDoesntExist(expect *mock.MockClientMockRecorder) {
-	expect.GetManagedZone(gomock.Any()).
+	expect.GetManagedZone(gomock.Any(), gomock.Any()).
 		Return(nil, &googleapi.Error{Code: 404}).
 		Times(1)
 }
 
 func mockCreateGCPZone(expect *mock.MockClientMockRecorder) {
-	expect.CreateManagedZone(gomock.Any()).Return(&dns.ManagedZone{
+	expect.CreateManagedZone(gomock.Any(), gomock.Any()).Return(&dns.ManagedZone{
 		DnsName:     "blah.example.com",
 		Name:        "hive-blah-example-com",
 		NameServers: []string{"ns1.example.com", "ns2.example.com"},
 	}, nil).Times(1)
 }
 
+func mockCreateGCPPrivateZone(expect *mock.MockClientMockRecorder, peering *dns.ManagedZonePeeringConfig) {
+	expect.CreateManagedZone(gomock.Any(), &dns.ManagedZone{
+		Name:                    "hive-blah-example-com",
+		Description:             managedByHiveDescription,
+		DnsName:                 "blah.example.com.",
+		Visibility:              "private",
+		PrivateVisibilityConfig: gcpPrivateVisibilityConfig("network-1", "network-2"),
+		PeeringConfig:           peering,
+	}).Return(&dns.ManagedZone{
+		DnsName:                 "blah.example.com.",
+		Name:                    "hive-blah-example-com",
+		Visibility:              "private",
+		PrivateVisibilityConfig: gcpPrivateVisibilityConfig("network-1", "network-2"),
+		PeeringConfig:           peering,
+	}, nil).Times(1)
+}
+
+func mockGCPPrivateZoneExists(expect *mock.MockClientMockRecorder, networks ...string) {
+	expect.GetManagedZone(gomock.Any(), gomock.Any()).Return(&dns.ManagedZone{
+		DnsName:                 "blah.example.com.",
+		Name:                    "hive-blah-example-com",
+		Visibility:              "private",
+		PrivateVisibilityConfig: gcpPrivateVisibilityConfig(networks...),
+	}, nil).Times(1)
+}
+
+func gcpPrivateVisibilityConfig(networks ...string) *dns.ManagedZonePrivateVisibilityConfig {
+	config := &dns.ManagedZonePrivateVisibilityConfig{}
+	for _, network := range networks {
+		config.Networks = append(config.Networks, &dns.ManagedZonePrivateVisibilityConfigNetwork{
+			NetworkUrl: gcpNetworkURL(network),
+		})
+	}
+	return config
+}
+
 func mockDeleteGCPZone(expect *mock.MockClientMockRecorder) {
-	expect.ListResourceRecordSets(gomock.Any(), gomock.Any()).Return(&dns.ResourceRecordSetsListResponse{}, nil)
-	expect.DeleteManagedZone(gomock.Any()).Return(nil).Times(1)
+	expect.ListResourceRecordSets(gomock.Any(), gomock.Any(), gomock.Any()).Return(&dns.ResourceRecordSetsListResponse{}, nil)
+	expect.DeleteManagedZone(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+}
+
+func mockGCPListRecordSets(expect *mock.MockClientMockRecorder) {
+	gomock.InOrder(
+		expect.ListResourceRecordSets(gomock.Any(), "hive-blah-example-com", gcpclient.ListResourceRecordSetsOptions{}).
+			Return(&dns.ResourceRecordSetsListResponse{
+				Rrsets: []*dns.ResourceRecordSet{
+					{Name: "blah.example.com.", Type: "NS"},
+					{Name: "blah.example.com.", Type: "SOA"},
+				},
+				NextPageToken: "page2",
+			}, nil).Times(1),
+		expect.ListResourceRecordSets(gomock.Any(), "hive-blah-example-com", gcpclient.ListResourceRecordSetsOptions{PageToken: "page2"}).
+			Return(&dns.ResourceRecordSetsListResponse{
+				Rrsets: []*dns.ResourceRecordSet{
+					{Name: "api.blah.example.com.", Type: "A"},
+				},
+			}, nil).Times(1),
+	)
 }
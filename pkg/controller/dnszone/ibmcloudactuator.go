@@ -0,0 +1,239 @@
+package dnszone
+
+import (
+	"context"
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/ibmclient"
+)
+
+// IBMCloudActuator attempts to make the current state reflect the given desired state.
+type IBMCloudActuator struct {
+	// logger is the logger used for this controller
+	logger log.FieldLogger
+
+	// ibmClient is a utility for making it easy for controllers to interface with IBM Cloud Internet Services
+	ibmClient ibmclient.Client
+
+	// dnsZone is the DNSZone that represents the desired state.
+	dnsZone *hivev1.DNSZone
+
+	// zone is the IBM Cloud Internet Services zone object.
+	zone *ibmclient.Zone
+}
+
+type ibmClientBuilderType func(secret *corev1.Secret) (ibmclient.Client, error)
+
+// NewIBMCloudActuator creates a new IBMCloudActuator object. A new IBMCloudActuator is expected to be created for each
+// controller sync.
+func NewIBMCloudActuator(
+	logger log.FieldLogger,
+	secret *corev1.Secret,
+	dnsZone *hivev1.DNSZone,
+	ibmClientBuilder ibmClientBuilderType,
+) (*IBMCloudActuator, error) {
+	ibmClient, err := ibmClientBuilder(secret)
+	if err != nil {
+		logger.WithError(err).Error("Error creating IBMClient")
+		return nil, err
+	}
+
+	ibmCloudActuator := &IBMCloudActuator{
+		logger:    logger,
+		ibmClient: ibmClient,
+		dnsZone:   dnsZone,
+	}
+
+	return ibmCloudActuator, nil
+}
+
+// Ensure IBMCloudActuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &IBMCloudActuator{}
+
+// Ensure IBMCloudActuator implements the RecordSetLister interface. This will fail at compile time when false.
+var _ RecordSetLister = &IBMCloudActuator{}
+
+// Create implements the Create call of the actuator interface
+func (a *IBMCloudActuator) Create(ctx context.Context) error {
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+	logger.Info("Creating CIS zone")
+
+	zone, err := a.ibmClient.CreateZone(ctx, a.dnsZone.Spec.IBMCloud.CISInstanceCRN, a.dnsZone.Spec.Zone)
+	if err != nil {
+		logger.WithError(err).Error("Error creating CIS zone")
+		return err
+	}
+
+	logger.WithField("id", zone.ID).Debug("CIS zone successfully created")
+	a.zone = zone
+	if err := a.modifyStatus(); err != nil {
+		logger.WithError(err).Error("failed to sync DNSZone status fields")
+		return err
+	}
+
+	return nil
+}
+
+// Delete implements the Delete call of the actuator interface
+func (a *IBMCloudActuator) Delete(ctx context.Context) error {
+	if a.zone == nil {
+		return errors.New("zone is unpopulated")
+	}
+
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("id", a.zone.ID)
+
+	logger.Info("Deleting DNS records in CIS zone")
+	if err := DeleteIBMCloudDNSRecords(ctx, a.ibmClient, a.dnsZone, logger); err != nil {
+		return err
+	}
+
+	logger.Info("Deleting CIS zone")
+	err := a.ibmClient.DeleteZone(ctx, a.dnsZone.Spec.IBMCloud.CISInstanceCRN, a.zone.ID)
+	if err != nil {
+		logger.WithError(err).Error("Cannot delete CIS zone")
+	}
+	return err
+}
+
+// DeleteIBMCloudDNSRecords will delete all non-essential DNS records in the DNSZone provided
+func DeleteIBMCloudDNSRecords(ctx context.Context, ibmClient ibmclient.Client, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
+	if dnsZone.Status.IBMCloud == nil || dnsZone.Status.IBMCloud.ZoneID == nil {
+		return errors.New("zone ID not found in DNSZone status")
+	}
+	crn := dnsZone.Spec.IBMCloud.CISInstanceCRN
+	zoneID := *dnsZone.Status.IBMCloud.ZoneID
+	records, err := ibmClient.ListDNSRecords(ctx, crn, zoneID)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		// Ignore the NS records of the zone, which are managed by CIS
+		if record.Name == controllerutils.Undotted(dnsZone.Spec.Zone) && record.Type == "NS" {
+			continue
+		}
+		if isPreservedRecordSet(dnsZone, record.Name) {
+			logger.WithField("name", record.Name).WithField("type", record.Type).Info("preserving DNS record")
+			continue
+		}
+		logger.WithField("name", record.Name).WithField("type", record.Type).Info("deleting DNS record")
+		if err := ibmClient.DeleteDNSRecord(ctx, crn, zoneID, record.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListRecordSets implements the ListRecordSets call of the RecordSetLister interface. Each DNS record of the zone is
+// returned as a record set.
+func (a *IBMCloudActuator) ListRecordSets(ctx context.Context) ([]RecordSet, error) {
+	if a.zone == nil {
+		return nil, errors.New("zone is unpopulated")
+	}
+
+	records, err := a.ibmClient.ListDNSRecords(ctx, a.dnsZone.Spec.IBMCloud.CISInstanceCRN, a.zone.ID)
+	if err != nil {
+		return nil, err
+	}
+	recordSets := make([]RecordSet, len(records))
+	for i, record := range records {
+		recordSets[i] = RecordSet{Name: controllerutils.Dotted(record.Name), Type: record.Type}
+	}
+	return recordSets, nil
+}
+
+// Exists implements the Exists call of the actuator interface
+func (a *IBMCloudActuator) Exists(ctx context.Context) (bool, error) {
+	return a.zone != nil, nil
+}
+
+// UpdateMetadata implements the UpdateMetadata call of the actuator interface
+func (a *IBMCloudActuator) UpdateMetadata(ctx context.Context) error {
+	// Nothing to do here since CIS zones don't support tags.
+	return nil
+}
+
+// modifyStatus updates the DnsZone's status with IBM Cloud specific information.
+func (a *IBMCloudActuator) modifyStatus() error {
+	if a.zone == nil {
+		return errors.New("zone is unpopulated")
+	}
+
+	a.dnsZone.Status.IBMCloud = &hivev1.IBMCloudDNSZoneStatus{
+		ZoneID: &a.zone.ID,
+	}
+
+	return nil
+}
+
+// GetNameServers implements the GetNameServers call of the actuator interface
+func (a *IBMCloudActuator) GetNameServers(ctx context.Context) ([]string, error) {
+	if a.zone == nil {
+		return nil, errors.New("zone is unpopulated")
+	}
+
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+	result := a.zone.NameServers
+	logger.WithField("nameservers", result).Debug("found CIS zone name servers")
+	return result, nil
+}
+
+// Refresh implements the Refresh call of the actuator interface
+func (a *IBMCloudActuator) Refresh(ctx context.Context) error {
+	crn := a.dnsZone.Spec.IBMCloud.CISInstanceCRN
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+
+	if a.dnsZone.Status.IBMCloud != nil && a.dnsZone.Status.IBMCloud.ZoneID != nil {
+		zoneID := *a.dnsZone.Status.IBMCloud.ZoneID
+		logger = logger.WithField("id", zoneID)
+		logger.Debug("Zone ID is set in status, will retrieve by that ID")
+		zone, err := a.ibmClient.GetZone(ctx, crn, zoneID)
+		if err != nil {
+			if ibmclient.IsNotFound(err) {
+				logger.Debug("Zone not found, clearing out the cached object")
+				a.zone = nil
+				return nil
+			}
+
+			logger.WithError(err).Error("Cannot get CIS zone")
+			return err
+		}
+		a.zone = zone
+	} else {
+		logger.Debug("Zone ID is not set in status, looking up by name")
+		zones, err := a.ibmClient.ListZones(ctx, crn)
+		if err != nil {
+			logger.WithError(err).Error("Cannot list CIS zones")
+			return err
+		}
+		a.zone = nil
+		for i := range zones {
+			if zones[i].Name == controllerutils.Undotted(a.dnsZone.Spec.Zone) {
+				a.zone = &zones[i]
+				break
+			}
+		}
+		if a.zone == nil {
+			logger.Debug("Zone not found")
+			return nil
+		}
+	}
+
+	logger.Debug("Found CIS zone")
+	if err := a.modifyStatus(); err != nil {
+		logger.WithError(err).Error("failed to sync DNSZone status fields")
+		return err
+	}
+
+	return nil
+}
+
+// SetConditionsForError sets conditions on the dnszone given a specific error. Returns true if conditions changed.
+func (a *IBMCloudActuator) SetConditionsForError(ctx context.Context, err error) bool {
+	return false // Not implemented for IBM Cloud yet.
+}
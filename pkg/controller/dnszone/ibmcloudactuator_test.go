@@ -0,0 +1,91 @@
+package dnszone
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/ibmclient"
+	"github.com/openshift/hive/pkg/ibmclient/mock"
+)
+
+// TestNewIBMCloudActuator tests that a new IBMCloudActuator object can be created.
+func TestNewIBMCloudActuator(t *testing.T) {
+	cases := []struct {
+		name    string
+		dnsZone *hivev1.DNSZone
+		secret  *corev1.Secret
+	}{
+		{
+			name:    "Successfully create new zone",
+			dnsZone: validIBMCloudDNSZone(),
+			secret:  validIBMCloudSecret(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mocks := setupDefaultMocks(t)
+			expectedIBMCloudActuator := &IBMCloudActuator{
+				logger:  log.WithField("controller", ControllerName),
+				dnsZone: tc.dnsZone,
+			}
+
+			// Act
+			zr, err := NewIBMCloudActuator(
+				expectedIBMCloudActuator.logger,
+				tc.secret,
+				tc.dnsZone,
+				fakeIBMClientBuilder(mocks.mockIBMClient),
+			)
+			expectedIBMCloudActuator.ibmClient = zr.ibmClient // Function pointers can't be compared reliably. Don't compare.
+
+			// Assert
+			assert.Nil(t, err)
+			assert.NotNil(t, zr.ibmClient)
+			assert.Equal(t, expectedIBMCloudActuator, zr)
+		})
+	}
+}
+
+func ibmCloudZone() *ibmclient.Zone {
+	return &ibmclient.Zone{
+		ID:          "1234",
+		Name:        "blah.example.com",
+		Status:      "active",
+		NameServers: []string{"ns1.example.com", "ns2.example.com"},
+	}
+}
+
+func mockIBMCloudZoneExists(expect *mock.MockClientMockRecorder) {
+	expect.GetZone(gomock.Any(), gomock.Any(), "1234").Return(ibmCloudZone(), nil).Times(1)
+}
+
+func mockIBMCloudZoneDoesntExist(expect *mock.MockClientMockRecorder) {
+	expect.GetZone(gomock.Any(), gomock.Any(), "1234").
+		Return(nil, &ibmclient.Error{StatusCode: http.StatusNotFound}).
+		Times(1)
+}
+
+func mockIBMCloudListZones(expect *mock.MockClientMockRecorder, zones ...ibmclient.Zone) {
+	expect.ListZones(gomock.Any(), gomock.Any()).Return(zones, nil).Times(1)
+}
+
+func mockCreateIBMCloudZone(expect *mock.MockClientMockRecorder) {
+	expect.CreateZone(gomock.Any(), gomock.Any(), "blah.example.com").Return(ibmCloudZone(), nil).Times(1)
+}
+
+func mockDeleteIBMCloudZone(expect *mock.MockClientMockRecorder) {
+	expect.ListDNSRecords(gomock.Any(), gomock.Any(), "1234").Return([]ibmclient.DNSRecord{
+		{ID: "ns", Name: "blah.example.com", Type: "NS", Content: "ns1.example.com"},
+		{ID: "api", Name: "api.blah.example.com", Type: "CNAME", Content: "lb.example.com"},
+	}, nil).Times(1)
+	expect.DeleteDNSRecord(gomock.Any(), gomock.Any(), "1234", "api").Return(nil).Times(1)
+	expect.DeleteZone(gomock.Any(), gomock.Any(), "1234").Return(nil).Times(1)
+}
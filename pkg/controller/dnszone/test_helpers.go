@@ -12,15 +12,18 @@ import (
 	fakekubeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	azureclient "github.com/openshift/hive/pkg/azureclient"
 	gcpclient "github.com/openshift/hive/pkg/gcpclient"
+	ibmclient "github.com/openshift/hive/pkg/ibmclient"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/golang/mock/gomock"
 	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
 	mockazure "github.com/openshift/hive/pkg/azureclient/mock"
 	mockgcp "github.com/openshift/hive/pkg/gcpclient/mock"
+	mockibm "github.com/openshift/hive/pkg/ibmclient/mock"
 )
 
 var (
@@ -81,6 +84,24 @@ var (
 		}
 	}
 
+	validAzurePrivateDNSZone = func() *hivev1.DNSZone {
+		zone := validAzureDNSZone()
+		zone.Spec.Azure.ZoneType = hivev1.AzurePrivateDNSZoneType
+		zone.Spec.Azure.VirtualNetworkLinks = []hivev1.AzureVirtualNetworkLink{
+			{
+				Name:             "vnet-link",
+				VirtualNetworkID: "/subscriptions/sub/resourceGroups/default/providers/Microsoft.Network/virtualNetworks/vnet",
+			},
+		}
+		return zone
+	}
+
+	validAzurePrivateDNSZoneBeingDeleted = func() *hivev1.DNSZone {
+		zone := validAzurePrivateDNSZone()
+		zone.DeletionTimestamp = kubeTimeNow
+		return zone
+	}
+
 	validGCPSecret = func() *corev1.Secret {
 		return &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
@@ -105,6 +126,50 @@ var (
 		}
 	}
 
+	validIBMCloudDNSZone = func() *hivev1.DNSZone {
+		return &hivev1.DNSZone{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "dnszoneobject",
+				Namespace:  "ns",
+				Generation: 6,
+				Finalizers: []string{hivev1.FinalizerDNSZone},
+				UID:        types.UID("abcdef"),
+			},
+			Spec: hivev1.DNSZoneSpec{
+				Zone: "blah.example.com",
+				IBMCloud: &hivev1.IBMCloudDNSZoneSpec{
+					CredentialsSecretRef: corev1.LocalObjectReference{
+						Name: "somesecret",
+					},
+					CISInstanceCRN: "crn:v1:bluemix:public:internet-svcs:global:a/account:instance::",
+				},
+			},
+			Status: hivev1.DNSZoneStatus{
+				IBMCloud: &hivev1.IBMCloudDNSZoneStatus{
+					ZoneID: aws.String("1234"),
+				},
+			},
+		}
+	}
+
+	validIBMCloudDNSZoneBeingDeleted = func() *hivev1.DNSZone {
+		zone := validIBMCloudDNSZone()
+		zone.DeletionTimestamp = kubeTimeNow
+		return zone
+	}
+
+	validIBMCloudSecret = func() *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "somesecret",
+				Namespace: "ns",
+			},
+			Data: map[string][]byte{
+				"ibmcloud_api_key": []byte("notrealapikey"),
+			},
+		}
+	}
+
 	validDNSZoneWithLinkToParent = func() *hivev1.DNSZone {
 		zone := validDNSZone()
 		zone.Spec.LinkToParentDomain = true
@@ -150,6 +215,45 @@ var (
 		return zone
 	}
 
+	validAWSPrivateDNSZone = func() *hivev1.DNSZone {
+		zone := validDNSZone()
+		zone.Spec.AWS.ZoneType = hivev1.AWSPrivateDNSZoneType
+		zone.Spec.AWS.VPCs = []hivev1.AWSDNSZoneVPC{
+			{VPCID: "vpc-1", Region: "us-east-1"},
+			{VPCID: "vpc-2", Region: "us-west-2"},
+		}
+		return zone
+	}
+
+	validAWSDNSSECDNSZone = func() *hivev1.DNSZone {
+		zone := validDNSZone()
+		zone.Spec.AWS.EnableDNSSEC = true
+		zone.Spec.AWS.DNSSECKMSKeyARN = "arn:aws:kms:us-east-1:123456789012:key/1234"
+		return zone
+	}
+
+	validAWSQueryLoggingDNSZone = func() *hivev1.DNSZone {
+		zone := validDNSZone()
+		zone.Spec.AWS.QueryLogging = &hivev1.AWSDNSZoneQueryLogging{
+			CloudWatchLogsLogGroupARN: awsQueryLogGroupARN("dns-queries"),
+		}
+		return zone
+	}
+
+	validGCPPrivateDNSZone = func() *hivev1.DNSZone {
+		zone := validDNSZone()
+		zone.Spec.AWS = nil
+		zone.Spec.GCP = &hivev1.GCPDNSZoneSpec{
+			ZoneType: hivev1.GCPPrivateDNSZoneType,
+			Networks: []hivev1.GCPDNSZoneNetwork{
+				{NetworkURL: gcpNetworkURL("network-1")},
+				{NetworkURL: gcpNetworkURL("network-2")},
+			},
+		}
+		zone.Status.AWS = nil
+		return zone
+	}
+
 	validDNSZoneBeingDeleted = func() *hivev1.DNSZone {
 		// Take a copy of the default validDNSZone object
 		zone := validDNSZone()
@@ -175,6 +279,7 @@ type mocks struct {
 	mockAWSClient   *mockaws.MockClient
 	mockGCPClient   *mockgcp.MockClient
 	mockAzureClient *mockazure.MockClient
+	mockIBMClient   *mockibm.MockClient
 }
 
 // setupDefaultMocks is an easy way to setup all of the default mocks
@@ -187,6 +292,7 @@ func setupDefaultMocks(t *testing.T) *mocks {
 	mocks.mockAWSClient = mockaws.NewMockClient(mocks.mockCtrl)
 	mocks.mockGCPClient = mockgcp.NewMockClient(mocks.mockCtrl)
 	mocks.mockAzureClient = mockazure.NewMockClient(mocks.mockCtrl)
+	mocks.mockIBMClient = mockibm.NewMockClient(mocks.mockCtrl)
 
 	return mocks
 }
@@ -197,6 +303,11 @@ func fakeAWSClientBuilder(mockAWSClient *mockaws.MockClient) awsClientBuilderTyp
 	}
 }
 
+// gcpNetworkURL returns the URL of the GCP network with the given name.
+func gcpNetworkURL(network string) string {
+	return "https://www.googleapis.com/compute/v1/projects/myproject/global/networks/" + network
+}
+
 func fakeGCPClientBuilder(mockGCPClient *mockgcp.MockClient) gcpClientBuilderType {
 	return func(secret *corev1.Secret) (gcpclient.Client, error) {
 		return mockGCPClient, nil
@@ -204,12 +315,22 @@ func fakeGCPClientBuilder(mockGCPClient *mockgcp.MockClient) gcpClientBuilderTyp
 }
 
 func fakeAzureClientBuilder(mockAzureClient *mockazure.MockClient) azureClientBuilderType {
-	return func(secret *corev1.Secret) (azureclient.Client, error) {
+	return func(secret *corev1.Secret, cloudName hivev1azure.CloudEnvironment) (azureclient.Client, error) {
 		return mockAzureClient, nil
 	}
 }
 
+func fakeIBMClientBuilder(mockIBMClient *mockibm.MockClient) ibmClientBuilderType {
+	return func(secret *corev1.Secret) (ibmclient.Client, error) {
+		return mockIBMClient, nil
+	}
+}
+
 // setFakeDNSZoneInKube is an easy way to register a dns zone object with kube.
 func setFakeDNSZoneInKube(mocks *mocks, dnsZone *hivev1.DNSZone) error {
 	return mocks.fakeKubeClient.Create(context.TODO(), dnsZone)
 }
+
+func awsQueryLogGroupARN(name string) string {
+	return "arn:aws:logs:us-east-1:123456789012:log-group:/aws/route53/" + name
+}
@@ -0,0 +1,287 @@
+package dnszone
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// webhookCASecretKey is the key of the CA bundle in the CA secret of a DNS webhook.
+	webhookCASecretKey = "ca.crt"
+
+	// webhookTokenSecretKey is the key of the bearer token in the auth secret of a DNS webhook.
+	webhookTokenSecretKey = "token"
+
+	// webhookCallTimeout is the timeout of each call to a DNS webhook.
+	webhookCallTimeout = time.Minute
+)
+
+// webhookZone is a zone as sent and returned by a DNS webhook.
+type webhookZone struct {
+	Zone        string   `json:"zone"`
+	NameServers []string `json:"nameServers,omitempty"`
+}
+
+// webhookError is an error returned by a DNS webhook.
+type webhookError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *webhookError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("DNS webhook returned status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("DNS webhook returned status %d: %s", e.StatusCode, e.Message)
+}
+
+// WebhookActuator delegates the management of the zone to an external DNS webhook, for DNS providers that Hive does
+// not support natively. The webhook is called with:
+//
+//	GET    <url>/zones/<zone>  returns the zone, or 404 when the zone does not exist
+//	POST   <url>/zones         creates the zone sent in the body, and returns it
+//	DELETE <url>/zones/<zone>  deletes the zone along with all of its records
+//
+// Zones are sent and returned as JSON objects with the fields "zone" and "nameServers".
+type WebhookActuator struct {
+	// logger is the logger used for this controller
+	logger log.FieldLogger
+
+	// httpClient is the client used to call the webhook
+	httpClient *http.Client
+
+	// url is the base URL of the webhook
+	url string
+
+	// token is the bearer token sent to the webhook, if any
+	token string
+
+	// dnsZone is the DNSZone that represents the desired state.
+	dnsZone *hivev1.DNSZone
+
+	// zone is the zone returned by the webhook.
+	zone *webhookZone
+}
+
+// NewWebhookActuator creates a new WebhookActuator object. A new WebhookActuator is expected to be created for each
+// controller sync.
+func NewWebhookActuator(
+	logger log.FieldLogger,
+	httpClient *http.Client,
+	token string,
+	dnsZone *hivev1.DNSZone,
+) (*WebhookActuator, error) {
+	if _, err := url.Parse(dnsZone.Spec.Webhook.URL); err != nil {
+		logger.WithError(err).Error("Invalid DNS webhook URL")
+		return nil, err
+	}
+
+	webhookActuator := &WebhookActuator{
+		logger:     logger.WithField("webhook", dnsZone.Spec.Webhook.URL),
+		httpClient: httpClient,
+		url:        strings.TrimSuffix(dnsZone.Spec.Webhook.URL, "/"),
+		token:      token,
+		dnsZone:    dnsZone,
+	}
+
+	return webhookActuator, nil
+}
+
+// Ensure WebhookActuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &WebhookActuator{}
+
+// newWebhookHTTPClient returns the HTTP client and the bearer token used to call the DNS webhook of the DNSZone, read
+// from the CA and auth secrets of the webhook.
+func newWebhookHTTPClient(c client.Client, dnsZone *hivev1.DNSZone) (*http.Client, string, error) {
+	spec := dnsZone.Spec.Webhook
+	tlsConfig := &tls.Config{}
+	if spec.CASecretRef != nil {
+		secret := &corev1.Secret{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Namespace: dnsZone.Namespace, Name: spec.CASecretRef.Name}, secret); err != nil {
+			return nil, "", err
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(secret.Data[webhookCASecretKey]) {
+			return nil, "", fmt.Errorf("no certificates found in %q of secret %s", webhookCASecretKey, spec.CASecretRef.Name)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	var token string
+	if spec.AuthSecretRef != nil {
+		secret := &corev1.Secret{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Namespace: dnsZone.Namespace, Name: spec.AuthSecretRef.Name}, secret); err != nil {
+			return nil, "", err
+		}
+		token = strings.TrimSpace(string(secret.Data[webhookTokenSecretKey]))
+		if token == "" {
+			return nil, "", fmt.Errorf("no %q found in secret %s", webhookTokenSecretKey, spec.AuthSecretRef.Name)
+		}
+	}
+
+	// The client is built for every reconcile, so that changes to the secrets are picked up. Keep-alives are disabled
+	// so that the connections of the discarded clients are closed rather than left idle.
+	httpClient := &http.Client{
+		Timeout: webhookCallTimeout,
+		Transport: &http.Transport{
+			Proxy:             http.ProxyFromEnvironment,
+			TLSClientConfig:   tlsConfig,
+			DisableKeepAlives: true,
+		},
+	}
+	return httpClient, token, nil
+}
+
+// Create implements the Create call of the actuator interface
+func (a *WebhookActuator) Create(ctx context.Context) error {
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+	logger.Info("Creating zone with DNS webhook")
+
+	zone := &webhookZone{}
+	if err := a.do(ctx, http.MethodPost, "/zones", &webhookZone{Zone: controllerutils.Undotted(a.dnsZone.Spec.Zone)}, zone); err != nil {
+		logger.WithError(err).Error("Error creating zone with DNS webhook")
+		return err
+	}
+
+	logger.Debug("Zone successfully created with DNS webhook")
+	a.zone = zone
+	return nil
+}
+
+// Delete implements the Delete call of the actuator interface
+func (a *WebhookActuator) Delete(ctx context.Context) error {
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+	logger.Info("Deleting zone with DNS webhook")
+
+	err := a.do(ctx, http.MethodDelete, a.zonePath(), nil, nil)
+	if webhookErr, ok := err.(*webhookError); ok && webhookErr.StatusCode == http.StatusNotFound {
+		logger.Debug("Zone already deleted")
+		return nil
+	}
+	if err != nil {
+		logger.WithError(err).Error("Cannot delete zone with DNS webhook")
+	}
+	return err
+}
+
+// Exists implements the Exists call of the actuator interface
+func (a *WebhookActuator) Exists(ctx context.Context) (bool, error) {
+	return a.zone != nil, nil
+}
+
+// UpdateMetadata implements the UpdateMetadata call of the actuator interface
+func (a *WebhookActuator) UpdateMetadata(ctx context.Context) error {
+	// Nothing to do here since the webhook protocol has no zone metadata.
+	return nil
+}
+
+// GetNameServers implements the GetNameServers call of the actuator interface
+func (a *WebhookActuator) GetNameServers(ctx context.Context) ([]string, error) {
+	if a.zone == nil {
+		return nil, errors.New("zone is unpopulated")
+	}
+
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+	result := a.zone.NameServers
+	logger.WithField("nameservers", result).Debug("found webhook zone name servers")
+	return result, nil
+}
+
+// Refresh implements the Refresh call of the actuator interface
+func (a *WebhookActuator) Refresh(ctx context.Context) error {
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+	logger.Debug("Fetching zone from DNS webhook")
+
+	zone := &webhookZone{}
+	err := a.do(ctx, http.MethodGet, a.zonePath(), nil, zone)
+	if webhookErr, ok := err.(*webhookError); ok && webhookErr.StatusCode == http.StatusNotFound {
+		logger.Debug("Zone not found, clearing out the cached object")
+		a.zone = nil
+		return nil
+	}
+	if err != nil {
+		logger.WithError(err).Error("Cannot get zone from DNS webhook")
+		return err
+	}
+
+	logger.Debug("Found zone")
+	a.zone = zone
+	return nil
+}
+
+// SetConditionsForError sets conditions on the dnszone given a specific error. Returns true if conditions changed.
+func (a *WebhookActuator) SetConditionsForError(ctx context.Context, err error) bool {
+	return false // Not implemented for webhooks yet.
+}
+
+func (a *WebhookActuator) zonePath() string {
+	return "/zones/" + url.PathEscape(controllerutils.Undotted(a.dnsZone.Spec.Zone))
+}
+
+// do sends a request to the webhook, and decodes the response into out. Unsuccessful responses are returned as
+// *webhookError.
+func (a *WebhookActuator) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, a.url+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", "openshift.io hive/v1")
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if a.token != "" {
+		req.Header.Set("Authorization", "Bearer "+a.token)
+	}
+	res, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		webhookErr := &webhookError{StatusCode: res.StatusCode}
+		var errResp struct {
+			Message string `json:"message"`
+		}
+		if data, err := ioutil.ReadAll(io.LimitReader(res.Body, 4096)); err == nil && json.Unmarshal(data, &errResp) == nil {
+			webhookErr.Message = errResp.Message
+		}
+		return webhookErr
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
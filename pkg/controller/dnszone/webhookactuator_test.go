@@ -0,0 +1,165 @@
+package dnszone
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekubeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const testWebhookToken = "webhook-token"
+
+// fakeDNSWebhook is a DNS webhook that keeps its zones in memory.
+type fakeDNSWebhook struct {
+	*httptest.Server
+
+	mutex sync.Mutex
+	zones map[string]bool
+}
+
+func newFakeDNSWebhook(zones ...string) *fakeDNSWebhook {
+	w := &fakeDNSWebhook{zones: map[string]bool{}}
+	for _, zone := range zones {
+		w.zones[zone] = true
+	}
+	w.Server = httptest.NewTLSServer(http.HandlerFunc(w.serveHTTP))
+	return w
+}
+
+func (w *fakeDNSWebhook) serveHTTP(rw http.ResponseWriter, r *http.Request) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if r.Header.Get("Authorization") != "Bearer "+testWebhookToken {
+		rw.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(rw).Encode(map[string]string{"message": "invalid token"})
+		return
+	}
+	writeZone := func(zone string) {
+		json.NewEncoder(rw).Encode(&webhookZone{Zone: zone, NameServers: []string{"ns1.example.com", "ns2.example.com"}})
+	}
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/zones":
+		zone := &webhookZone{}
+		if err := json.NewDecoder(r.Body).Decode(zone); err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.zones[zone.Zone] = true
+		rw.WriteHeader(http.StatusCreated)
+		writeZone(zone.Zone)
+	case strings.HasPrefix(r.URL.Path, "/v1/zones/"):
+		zone := strings.TrimPrefix(r.URL.Path, "/v1/zones/")
+		if !w.zones[zone] {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeZone(zone)
+		case http.MethodDelete:
+			delete(w.zones, zone)
+			rw.WriteHeader(http.StatusNoContent)
+		default:
+			rw.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	default:
+		rw.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func webhookDNSZone(url string) *hivev1.DNSZone {
+	zone := validDNSZone()
+	zone.Spec.AWS = nil
+	zone.Status.AWS = nil
+	zone.Spec.Webhook = &hivev1.WebhookDNSZoneSpec{
+		URL:           url + "/v1/",
+		CASecretRef:   &corev1.LocalObjectReference{Name: "webhook-ca"},
+		AuthSecretRef: &corev1.LocalObjectReference{Name: "webhook-auth"},
+	}
+	return zone
+}
+
+func webhookSecrets(server *httptest.Server) []*corev1.Secret {
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	return []*corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "webhook-ca"},
+			Data:       map[string][]byte{"ca.crt": ca},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "webhook-auth"},
+			Data:       map[string][]byte{"token": []byte(testWebhookToken + "\n")},
+		},
+	}
+}
+
+// TestNewWebhookHTTPClient tests that the HTTP client of a DNS webhook trusts the CA and sends the token of the
+// secrets of the webhook, without keeping its connections alive.
+func TestNewWebhookHTTPClient(t *testing.T) {
+	webhook := newFakeDNSWebhook("blah.example.com")
+	defer webhook.Close()
+
+	cases := []struct {
+		name          string
+		secrets       []*corev1.Secret
+		expectedError string
+	}{
+		{
+			name:    "CA and token",
+			secrets: webhookSecrets(webhook.Server),
+		},
+		{
+			name:          "missing secrets",
+			expectedError: "not found",
+		},
+		{
+			name: "empty token",
+			secrets: []*corev1.Secret{
+				webhookSecrets(webhook.Server)[0],
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "webhook-auth"}},
+			},
+			expectedError: `no "token" found in secret webhook-auth`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fakekubeclient.NewFakeClient()
+			for _, secret := range tc.secrets {
+				require.NoError(t, c.Create(context.TODO(), secret))
+			}
+			dnsZone := webhookDNSZone(webhook.URL)
+
+			httpClient, token, err := newWebhookHTTPClient(c, dnsZone)
+			if tc.expectedError != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedError)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testWebhookToken, token)
+			if transport, ok := httpClient.Transport.(*http.Transport); assert.True(t, ok, "unexpected transport") {
+				assert.True(t, transport.DisableKeepAlives, "expected keep-alives to be disabled")
+			}
+
+			actuator, err := NewWebhookActuator(log.WithField("controller", ControllerName), httpClient, token, dnsZone)
+			require.NoError(t, err)
+			require.NoError(t, actuator.Refresh(context.TODO()), "expected the webhook to be reachable with its CA")
+			exists, _ := actuator.Exists(context.TODO())
+			assert.True(t, exists)
+		})
+	}
+}
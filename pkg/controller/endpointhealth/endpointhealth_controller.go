@@ -0,0 +1,395 @@
+// Package endpointhealth provides a controller which periodically probes the API, console and ingress endpoints of
+// installed clusters over HTTPS and maintains the EndpointsUnhealthy condition and metrics of the ClusterDeployments
+// as a result. It detects degraded endpoints of clusters whose API is still reachable.
+package endpointhealth
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.EndpointHealthControllerName
+
+	defaultProbeInterval = 5 * time.Minute
+	defaultProbeTimeout  = 10 * time.Second
+
+	// apiHealthPath is the path of the API that is probed. It is served to anonymous clients and responds with 200
+	// once the API server is ready.
+	apiHealthPath = "/readyz"
+
+	endpointAPI           = "api"
+	endpointConsole       = "console"
+	endpointIngressPrefix = "ingress-"
+)
+
+var (
+	metricEndpointHealthy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_cluster_deployment_endpoint_healthy",
+		Help: "Whether an endpoint of a cluster deployment was healthy when last probed, 1 if healthy and 0 otherwise.",
+	}, []string{"namespace", "cluster_deployment", "endpoint"})
+	metricEndpointProbeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "hive_cluster_deployment_endpoint_probe_duration_seconds",
+		Help:    "Distribution of the durations of the probes of the endpoints of cluster deployments.",
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"endpoint", "healthy"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricEndpointHealthy)
+	metrics.Registry.MustRegister(metricEndpointProbeDuration)
+}
+
+// Add creates a new EndpointHealth Controller and adds it to the Manager with default RBAC when endpoint health is
+// configured in the HiveConfig. The Manager will set fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := controllerutils.ControllerLogEntry(ControllerName)
+	envConfig := os.Getenv(constants.EndpointHealthEnvVar)
+	if envConfig == "" {
+		logger.Debug("endpoint health is not configured, not probing endpoints")
+		return nil
+	}
+	config := &hivev1.EndpointHealthConfig{}
+	if err := json.Unmarshal([]byte(envConfig), config); err != nil {
+		logger.WithError(err).WithField("config", envConfig).Errorf("unable to parse %s", constants.EndpointHealthEnvVar)
+		return err
+	}
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	r, err := NewReconciler(mgr, clientRateLimiter, config)
+	if err != nil {
+		logger.WithError(err).Error("invalid endpoint health configuration")
+		return err
+	}
+	return AddToManager(mgr, r, concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter, config *hivev1.EndpointHealthConfig) (reconcile.Reconciler, error) {
+	interval, err := parseDuration(config.Interval, defaultProbeInterval)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse interval")
+	}
+	timeout, err := parseDuration(config.Timeout, defaultProbeTimeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse timeout")
+	}
+	return &ReconcileEndpointHealth{
+		Client:           controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		interval:         interval,
+		ingressEndpoints: config.IngressEndpoints,
+		httpClient:       newProbeClient(timeout),
+		lastProbes:       map[types.NamespacedName]time.Time{},
+	}, nil
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	// Create a new controller
+	c, err := controllerutils.NewController("endpointhealth-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileEndpointHealth{}
+
+// ReconcileEndpointHealth probes the endpoints of the cluster of a ClusterDeployment
+type ReconcileEndpointHealth struct {
+	client.Client
+
+	// interval is the interval between the probes of the endpoints of a cluster
+	interval time.Duration
+
+	// ingressEndpoints are the endpoints of the default ingress controller probed in addition to the API and console
+	ingressEndpoints []hivev1.EndpointHealthIngressEndpoint
+
+	// httpClient is the client used to probe the endpoints
+	httpClient *http.Client
+
+	// lastProbes are the times at which the endpoints of the clusters were last probed, so that changes to a
+	// ClusterDeployment do not cause its endpoints to be probed more often than the interval.
+	lastProbes     map[types.NamespacedName]time.Time
+	lastProbesLock sync.Mutex
+}
+
+// endpoint is an endpoint of a cluster that is probed.
+type endpoint struct {
+	name                string
+	url                 string
+	expectedStatusCodes []int32
+}
+
+// Reconcile probes the endpoints of the cluster of a ClusterDeployment, exposes their health as metrics, and sets the
+// EndpointsUnhealthy condition when one of them is unhealthy.
+func (r *ReconcileEndpointHealth) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	// Fetch the ClusterDeployment instance
+	cd := &hivev1.ClusterDeployment{}
+	err := r.Get(context.TODO(), request.NamespacedName, cd)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Object not found, clear its metrics.
+			r.forget(request.NamespacedName)
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	if controllerutils.IsClusterDeploymentPaused(cd, cdLog) {
+		return reconcile.Result{}, nil
+	}
+
+	// If the clusterdeployment is deleted, do not reconcile.
+	if cd.DeletionTimestamp != nil {
+		r.forget(request.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+
+	if !cd.Spec.Installed || controllerutils.IsFakeCluster(cd) {
+		cdLog.Debug("skipping cluster that is not installed or fake")
+		return reconcile.Result{}, nil
+	}
+
+	// The endpoints of clusters that are not running are expected to be down.
+	hibernating := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
+	if cd.Spec.PowerState != "" && cd.Spec.PowerState != hivev1.RunningClusterPowerState ||
+		hibernating != nil && hibernating.Status == corev1.ConditionTrue {
+		cdLog.Debug("skipping cluster that is not running")
+		r.forget(request.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+
+	if delay := r.interval - time.Since(r.lastProbe(request.NamespacedName)); delay > 0 {
+		cdLog.WithField("delay", delay).Debug("waiting to probe endpoints")
+		return reconcile.Result{RequeueAfter: delay}, nil
+	}
+
+	var failures []string
+	for _, e := range r.endpoints(cd) {
+		start := time.Now()
+		err := r.probe(e)
+		healthy := err == nil
+		metricEndpointProbeDuration.WithLabelValues(e.name, fmt.Sprint(healthy)).Observe(time.Since(start).Seconds())
+		if healthy {
+			metricEndpointHealthy.WithLabelValues(cd.Namespace, cd.Name, e.name).Set(1)
+			continue
+		}
+		cdLog.WithError(err).WithField("endpoint", e.name).WithField("url", e.url).Info("endpoint is unhealthy")
+		metricEndpointHealthy.WithLabelValues(cd.Namespace, cd.Name, e.name).Set(0)
+		failures = append(failures, fmt.Sprintf("%s: %v", e.name, err))
+	}
+	r.setLastProbe(request.NamespacedName, time.Now())
+
+	if err := r.setEndpointsUnhealthyCondition(cd, failures, cdLog); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	cdLog.Debug("reconcile complete")
+	return reconcile.Result{RequeueAfter: r.interval}, nil
+}
+
+// endpoints returns the endpoints of the cluster of the ClusterDeployment that are probed.
+func (r *ReconcileEndpointHealth) endpoints(cd *hivev1.ClusterDeployment) []endpoint {
+	var endpoints []endpoint
+	if cd.Status.APIURL != "" {
+		endpoints = append(endpoints, endpoint{
+			name:                endpointAPI,
+			url:                 strings.TrimSuffix(cd.Status.APIURL, "/") + apiHealthPath,
+			expectedStatusCodes: []int32{http.StatusOK},
+		})
+	}
+	if cd.Status.WebConsoleURL != "" {
+		endpoints = append(endpoints, endpoint{name: endpointConsole, url: cd.Status.WebConsoleURL})
+	}
+	domain := ingressDomain(cd)
+	for _, ie := range r.ingressEndpoints {
+		path := ie.Path
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		endpoints = append(endpoints, endpoint{
+			name:                endpointIngressPrefix + ie.Name,
+			url:                 fmt.Sprintf("https://%s.%s%s", ie.Host, domain, path),
+			expectedStatusCodes: ie.ExpectedStatusCodes,
+		})
+	}
+	return endpoints
+}
+
+// probe sends a request to the endpoint and returns an error describing why the endpoint is unhealthy, if it is.
+func (r *ReconcileEndpointHealth) probe(e endpoint) error {
+	resp, err := r.httpClient.Get(e.url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.TLS == nil || len(resp.TLS.PeerCertificates) == 0 {
+		return errors.New("endpoint is not served over HTTPS")
+	}
+	cert := resp.TLS.PeerCertificates[0]
+	if now := time.Now(); now.After(cert.NotAfter) {
+		return errors.Errorf("certificate expired at %s", cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+	if err := cert.VerifyHostname(resp.Request.URL.Hostname()); err != nil {
+		return errors.Wrap(err, "certificate is not valid for the host")
+	}
+	if !statusCodeExpected(resp.StatusCode, e.expectedStatusCodes) {
+		return errors.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// setEndpointsUnhealthyCondition sets the EndpointsUnhealthy condition of the ClusterDeployment from the failures of
+// the probes of its endpoints.
+func (r *ReconcileEndpointHealth) setEndpointsUnhealthyCondition(cd *hivev1.ClusterDeployment, failures []string, cdLog log.FieldLogger) error {
+	status, reason, message := corev1.ConditionFalse, hivev1.EndpointsHealthyReason, "All endpoints are healthy"
+	if len(failures) > 0 {
+		status, reason, message = corev1.ConditionTrue, hivev1.EndpointsUnhealthyReason, strings.Join(failures, "; ")
+	}
+	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.EndpointsUnhealthyClusterDeploymentCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conds
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating endpoints unhealthy condition")
+		return err
+	}
+	return nil
+}
+
+func (r *ReconcileEndpointHealth) lastProbe(cd types.NamespacedName) time.Time {
+	r.lastProbesLock.Lock()
+	defer r.lastProbesLock.Unlock()
+	return r.lastProbes[cd]
+}
+
+func (r *ReconcileEndpointHealth) setLastProbe(cd types.NamespacedName, t time.Time) {
+	r.lastProbesLock.Lock()
+	defer r.lastProbesLock.Unlock()
+	r.lastProbes[cd] = t
+}
+
+// forget removes the last probe time and the metrics of a ClusterDeployment.
+func (r *ReconcileEndpointHealth) forget(cd types.NamespacedName) {
+	r.lastProbesLock.Lock()
+	delete(r.lastProbes, cd)
+	r.lastProbesLock.Unlock()
+	metricEndpointHealthy.DeleteLabelValues(cd.Namespace, cd.Name, endpointAPI)
+	metricEndpointHealthy.DeleteLabelValues(cd.Namespace, cd.Name, endpointConsole)
+	for _, ie := range r.ingressEndpoints {
+		metricEndpointHealthy.DeleteLabelValues(cd.Namespace, cd.Name, endpointIngressPrefix+ie.Name)
+	}
+}
+
+// ingressDomain returns the domain of the default ingress controller of the cluster.
+func ingressDomain(cd *hivev1.ClusterDeployment) string {
+	for _, ingress := range cd.Spec.Ingress {
+		if ingress.Name == "default" && ingress.Domain != "" {
+			return ingress.Domain
+		}
+	}
+	return fmt.Sprintf("apps.%s.%s", cd.Spec.ClusterName, cd.Spec.BaseDomain)
+}
+
+// statusCodeExpected returns whether the status code is one of the expected status codes, or any 2xx or 3xx status
+// code when no status codes are expected.
+func statusCodeExpected(statusCode int, expected []int32) bool {
+	if len(expected) == 0 {
+		return statusCode >= 200 && statusCode < 400
+	}
+	for _, code := range expected {
+		if int(code) == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// newProbeClient returns the HTTP client used to probe endpoints. The certificates of the endpoints are checked by
+// the probes rather than verified against trusted CAs, as the ingress and API certificates of clusters are commonly
+// signed by CAs of the clusters that the hub does not trust.
+func newProbeClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:             http.ProxyFromEnvironment,
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			DisableKeepAlives: true,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func parseDuration(value string, def time.Duration) (time.Duration, error) {
+	if value == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, errors.Errorf("duration %s must be positive", value)
+	}
+	return d, nil
+}
@@ -0,0 +1,225 @@
+package endpointhealth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	testName      = "foo"
+	testNamespace = "default"
+)
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestEndpointHealthReconcile(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	// The cluster serves a ready API, and a console redirecting to the login page.
+	cluster := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case apiHealthPath:
+			w.WriteHeader(http.StatusOK)
+		case "/console":
+			http.Redirect(w, r, "/login", http.StatusFound)
+		case "/failing":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer cluster.Close()
+	plainServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer plainServer.Close()
+
+	notInstalled := func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.Installed = false
+	}
+	hibernating := func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.PowerState = hivev1.HibernatingClusterPowerState
+	}
+	withAPIURL := func(url string) func(*hivev1.ClusterDeployment) {
+		return func(cd *hivev1.ClusterDeployment) {
+			cd.Status.APIURL = url
+		}
+	}
+	withConsoleURL := func(url string) func(*hivev1.ClusterDeployment) {
+		return func(cd *hivev1.ClusterDeployment) {
+			cd.Status.WebConsoleURL = url
+		}
+	}
+
+	tests := []struct {
+		name                   string
+		cd                     *hivev1.ClusterDeployment
+		lastProbe              time.Duration
+		expectNoCondition      bool
+		expectedStatus         corev1.ConditionStatus
+		expectedReason         string
+		expectedMessageContent []string
+	}{
+		{
+			name:              "not installed",
+			cd:                testClusterDeployment(cluster.URL, notInstalled),
+			expectNoCondition: true,
+		},
+		{
+			name:              "hibernating",
+			cd:                testClusterDeployment(cluster.URL, hibernating),
+			expectNoCondition: true,
+		},
+		{
+			name:              "probed recently",
+			cd:                testClusterDeployment(cluster.URL),
+			lastProbe:         time.Minute,
+			expectNoCondition: true,
+		},
+		{
+			name:           "healthy",
+			cd:             testClusterDeployment(cluster.URL),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: hivev1.EndpointsHealthyReason,
+		},
+		{
+			name:                   "API not found",
+			cd:                     testClusterDeployment(cluster.URL, withAPIURL(cluster.URL+"/failing")),
+			expectedStatus:         corev1.ConditionTrue,
+			expectedReason:         hivev1.EndpointsUnhealthyReason,
+			expectedMessageContent: []string{"api: unexpected status code 404"},
+		},
+		{
+			name:                   "console not found",
+			cd:                     testClusterDeployment(cluster.URL, withConsoleURL(cluster.URL+"/missing")),
+			expectedStatus:         corev1.ConditionTrue,
+			expectedReason:         hivev1.EndpointsUnhealthyReason,
+			expectedMessageContent: []string{"console: unexpected status code 404"},
+		},
+		{
+			name:                   "console not served over HTTPS",
+			cd:                     testClusterDeployment(cluster.URL, withConsoleURL(plainServer.URL)),
+			expectedStatus:         corev1.ConditionTrue,
+			expectedReason:         hivev1.EndpointsUnhealthyReason,
+			expectedMessageContent: []string{"console: endpoint is not served over HTTPS"},
+		},
+		{
+			name: "certificate not valid for host",
+			cd: testClusterDeployment(cluster.URL,
+				withAPIURL(strings.Replace(cluster.URL, "127.0.0.1", "localhost", 1))),
+			expectedStatus:         corev1.ConditionTrue,
+			expectedReason:         hivev1.EndpointsUnhealthyReason,
+			expectedMessageContent: []string{"api: certificate is not valid for the host"},
+		},
+		{
+			name: "all endpoints unhealthy",
+			cd: testClusterDeployment(cluster.URL,
+				withAPIURL(cluster.URL+"/failing"), withConsoleURL(cluster.URL+"/failing")),
+			expectedStatus:         corev1.ConditionTrue,
+			expectedReason:         hivev1.EndpointsUnhealthyReason,
+			expectedMessageContent: []string{"api: unexpected status code 404", "console: unexpected status code 503"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeClient := fake.NewFakeClient(test.cd)
+			key := types.NamespacedName{Namespace: testNamespace, Name: testName}
+			r := &ReconcileEndpointHealth{
+				Client:     fakeClient,
+				interval:   defaultProbeInterval,
+				httpClient: newProbeClient(defaultProbeTimeout),
+				lastProbes: map[types.NamespacedName]time.Time{},
+			}
+			if test.lastProbe != 0 {
+				r.lastProbes[key] = time.Now().Add(-test.lastProbe)
+			}
+
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: key})
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), key, cd))
+			cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.EndpointsUnhealthyClusterDeploymentCondition)
+			if test.expectNoCondition {
+				assert.Nil(t, cond, "unexpected endpoints unhealthy condition")
+				return
+			}
+			if assert.NotNil(t, cond, "missing endpoints unhealthy condition") {
+				assert.Equal(t, test.expectedStatus, cond.Status, "unexpected condition status")
+				assert.Equal(t, test.expectedReason, cond.Reason, "unexpected condition reason")
+				for _, content := range test.expectedMessageContent {
+					assert.Contains(t, cond.Message, content, "missing content in condition message")
+				}
+			}
+			assert.False(t, r.lastProbe(key).IsZero(), "expected last probe to be recorded")
+		})
+	}
+}
+
+func TestEndpoints(t *testing.T) {
+	r := &ReconcileEndpointHealth{
+		ingressEndpoints: []hivev1.EndpointHealthIngressEndpoint{
+			{Name: "oauth", Host: "oauth-openshift", Path: "healthz", ExpectedStatusCodes: []int32{200}},
+			{Name: "app", Host: "app"},
+		},
+	}
+	cd := testClusterDeployment("https://api.foo.example.com:6443")
+	cd.Spec.BaseDomain = "example.com"
+	expected := []endpoint{
+		{name: "api", url: "https://api.foo.example.com:6443/readyz", expectedStatusCodes: []int32{200}},
+		{name: "console", url: "https://api.foo.example.com:6443/console"},
+		{name: "ingress-oauth", url: "https://oauth-openshift.apps.foo.example.com/healthz", expectedStatusCodes: []int32{200}},
+		{name: "ingress-app", url: "https://app.apps.foo.example.com/"},
+	}
+	assert.Equal(t, expected, r.endpoints(cd), "unexpected endpoints")
+
+	cd.Spec.Ingress = []hivev1.ClusterIngress{{Name: "default", Domain: "apps.custom.example.com"}}
+	endpoints := r.endpoints(cd)
+	assert.Equal(t, "https://oauth-openshift.apps.custom.example.com/healthz", endpoints[2].url, "unexpected URL for custom ingress domain")
+}
+
+func testClusterDeployment(serverURL string, opts ...func(*hivev1.ClusterDeployment)) *hivev1.ClusterDeployment {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+			UID:       types.UID("1234"),
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: testName,
+			Installed:   true,
+			ClusterMetadata: &hivev1.ClusterMetadata{
+				ClusterID: "cluster-id",
+				InfraID:   "infra-id",
+			},
+		},
+		Status: hivev1.ClusterDeploymentStatus{
+			APIURL:        serverURL,
+			WebConsoleURL: serverURL + "/console",
+		},
+	}
+	for _, o := range opts {
+		o(cd)
+	}
+	return cd
+}
@@ -35,7 +35,7 @@ const (
 
 // Add creates a new FakeClusterInstall controller and adds it to the manager with default RBAC.
 func Add(mgr manager.Manager) error {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
 	if err != nil {
 		logger.WithError(err).Error("could not get controller configurations")
@@ -49,27 +49,27 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 	r := &ReconcileClusterInstall{
 		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
 		scheme: mgr.GetScheme(),
-		logger: log.WithField("controller", ControllerName),
+		logger: controllerutils.ControllerLogEntry(ControllerName),
 	}
 	return r
 }
 
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
-	c, err := controller.New("fakeclusterinstall-controller", mgr, controller.Options{
+	c, err := controllerutils.NewController("fakeclusterinstall-controller", mgr, controller.Options{
 		Reconciler:              r,
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
 	if err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("Error creating new fakeclusterinstall controller")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error creating new fakeclusterinstall controller")
 		return err
 	}
 
 	// Watch for changes to FakeClusterInstall
 	err = c.Watch(&source.Kind{Type: &hiveint.FakeClusterInstall{}}, &handler.EnqueueRequestForObject{})
 	if err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("Error watching FakeClusterInstall")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error watching FakeClusterInstall")
 		return err
 	}
 
@@ -0,0 +1,175 @@
+package gcpprivateserviceconnect
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	dns "google.golang.org/api/dns/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+func (r *ReconcileGCPPrivateServiceConnect) cleanupClusterDeployment(ctx context.Context, cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, logger log.FieldLogger) (reconcile.Result, error) {
+	if !controllerutils.HasFinalizer(cd, finalizer) {
+		return reconcile.Result{}, nil
+	}
+
+	if metadata != nil && cleanupRequired(cd) {
+		cleaned, err := r.cleanupPrivateServiceConnect(ctx, cd, metadata, logger)
+		if err != nil {
+			logger.WithError(err).Error("error cleaning up Private Service Connect resources for ClusterDeployment")
+
+			if err := r.setErrCondition(cd, "CleanupForDeprovisionFailed", err, logger); err != nil {
+				logger.WithError(err).Error("failed to update condition on cluster deployment")
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{}, err
+		}
+		if !cleaned {
+			logger.Debug("waiting for Private Service Connect resources to be deleted")
+			return reconcile.Result{RequeueAfter: operationRequeue}, nil
+		}
+
+		if err := r.setReadyCondition(cd, corev1.ConditionFalse,
+			"DeprovisionCleanupComplete",
+			"successfully cleaned up private service connect resources created to deprovision cluster",
+			logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+			return reconcile.Result{}, err
+		}
+
+		// the status updates above changed the resource version, so refresh the object before updating it.
+		if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, cd); err != nil {
+			logger.WithError(err).Error("failed to get the latest cluster deployment")
+			return reconcile.Result{}, err
+		}
+	}
+
+	logger.Info("removing finalizer from ClusterDeployment")
+	controllerutils.DeleteFinalizer(cd, finalizer)
+	if err := r.Update(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not remove finalizer from ClusterDeployment")
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func (r *ReconcileGCPPrivateServiceConnect) cleanupPreviousProvisionAttempt(ctx context.Context, cd *hivev1.ClusterDeployment, cp *hivev1.ClusterProvision,
+	logger log.FieldLogger) (bool, error) {
+	if cd.Spec.ClusterMetadata == nil {
+		return false, errors.New("cannot cleanup previous resources because the admin kubeconfig is not available")
+	}
+	metadata := &hivev1.ClusterMetadata{
+		InfraID:                  *cp.Spec.PrevInfraID,
+		AdminKubeconfigSecretRef: cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef,
+	}
+
+	cleaned, err := r.cleanupPrivateServiceConnect(ctx, cd, metadata, logger)
+	if err != nil || !cleaned {
+		return cleaned, err
+	}
+	if cd.Annotations == nil {
+		cd.Annotations = map[string]string{}
+	}
+	cd.Annotations[lastCleanupAnnotationKey] = metadata.InfraID
+	return true, updateAnnotations(r.Client, cd)
+}
+
+func cleanupRequired(cd *hivev1.ClusterDeployment) bool {
+	var pscStatus hivev1gcp.PrivateServiceConnectAccessStatus
+	if cd.Status.Platform != nil && cd.Status.Platform.GCP != nil && cd.Status.Platform.GCP.PrivateServiceConnect != nil {
+		pscStatus = *cd.Status.Platform.GCP.PrivateServiceConnect
+	}
+	return pscStatus != hivev1gcp.PrivateServiceConnectAccessStatus{}
+}
+
+// cleanupPrivateServiceConnect deletes all the Private Service Connect resources for the cluster. Since GCP deletes
+// the resources asynchronously, it returns true only once none of the resources exist anymore.
+func (r *ReconcileGCPPrivateServiceConnect) cleanupPrivateServiceConnect(ctx context.Context, cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, logger log.FieldLogger) (bool, error) {
+	gcpClient, err := r.newGCPClient(cd)
+	if err != nil {
+		logger.WithError(err).Error("error creating GCP client for the cluster")
+		return false, err
+	}
+	region := cd.Spec.Platform.GCP.Region
+	name := resourceName(metadata)
+
+	if err := cleanupDNSZone(ctx, gcpClient.hub, name, logger); err != nil {
+		logger.WithError(err).Error("error cleaning up the private DNS zone")
+		return false, err
+	}
+
+	// The resources are deleted in order of dependency, and each one is deleted only once the
+	// previous one is gone.
+	deletions := []struct {
+		kind string
+		fn   func() error
+	}{
+		{kind: "endpoint", fn: func() error { return gcpClient.hub.DeleteForwardingRule(ctx, region, name) }},
+		{kind: "endpoint address", fn: func() error { return gcpClient.hub.DeleteAddress(ctx, region, name) }},
+		{kind: "Service Attachment", fn: func() error { return gcpClient.user.DeleteServiceAttachment(ctx, region, name) }},
+		{kind: "Service Attachment subnet", fn: func() error { return gcpClient.user.DeleteSubnetwork(ctx, region, name) }},
+	}
+	for _, d := range deletions {
+		err := d.fn()
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return false, errors.Wrapf(err, "error deleting the %s", d.kind)
+		}
+		logger.WithField("name", name).Infof("deleting the %s", d.kind)
+		return false, nil
+	}
+
+	initPrivateServiceConnectStatus(cd)
+	cd.Status.Platform.GCP.PrivateServiceConnect = nil
+	if err := r.updatePrivateServiceConnectStatus(cd, logger); err != nil {
+		logger.WithError(err).Error("error updating clusterdeployment after cleanup of private service connect")
+		return false, err
+	}
+
+	return true, nil
+}
+
+func cleanupDNSZone(ctx context.Context, client gcpclient.Client, zoneName string, logger log.FieldLogger) error {
+	if _, err := client.GetManagedZone(ctx, zoneName); err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "error getting the private DNS zone")
+	}
+
+	records, err := client.ListResourceRecordSets(ctx, zoneName, gcpclient.ListResourceRecordSetsOptions{})
+	if err != nil {
+		return errors.Wrap(err, "error listing the records of the private DNS zone")
+	}
+	var toDelete []*dns.ResourceRecordSet
+	for _, rs := range records.Rrsets {
+		// The NS and SOA records are managed by Cloud DNS and are removed with the zone.
+		if rs.Type == "NS" || rs.Type == "SOA" {
+			continue
+		}
+		toDelete = append(toDelete, rs)
+	}
+	if len(toDelete) > 0 {
+		if err := client.DeleteResourceRecordSets(ctx, zoneName, toDelete); err != nil {
+			return errors.Wrap(err, "error deleting the records of the private DNS zone")
+		}
+	}
+
+	logger.WithField("zone", zoneName).Info("deleting the private DNS zone")
+	if err := client.DeleteManagedZone(ctx, zoneName); err != nil && !isNotFound(err) {
+		return errors.Wrap(err, "error deleting the private DNS zone")
+	}
+	return nil
+}
@@ -0,0 +1,1043 @@
+package gcpprivateserviceconnect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	compute "google.golang.org/api/compute/v1"
+	dns "google.golang.org/api/dns/v1"
+	"google.golang.org/api/googleapi"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+const (
+	ControllerName = hivev1.GCPPrivateServiceConnectControllerName
+	finalizer      = "hive.openshift.io/gcp-private-service-connect"
+
+	lastCleanupAnnotationKey = "gcp-private-service-connect-controller.hive.openshift.io/last-cleanup-for"
+
+	defaultRequeueLater = 1 * time.Minute
+	// operationRequeue is the duration after which the controller checks on resources that were
+	// created asynchronously by GCP.
+	operationRequeue = 15 * time.Second
+
+	defaultServiceAttachmentSubnetCIDR = "172.16.0.0/29"
+
+	// connectionLimit is the number of endpoints the hub project is allowed to connect to the
+	// Service Attachment of a cluster.
+	connectionLimit = 1
+
+	endpointStatusAccepted = "ACCEPTED"
+	endpointStatusPending  = "PENDING"
+)
+
+var (
+	errEndpointConnectionPending = errors.New("the Private Service Connect endpoint connection is pending")
+)
+
+// Add creates a new GCPPrivateServiceConnect Controller and adds it to the Manager with default RBAC.
+// The Manager will set fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := controllerutils.ControllerLogEntry(ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	reconciler, err := NewReconciler(mgr, clientRateLimiter)
+	if err != nil {
+		logger.WithError(err).Error("could not create reconciler")
+		return err
+	}
+	return AddToManager(mgr, reconciler, concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new ReconcileGCPPrivateServiceConnect
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (*ReconcileGCPPrivateServiceConnect, error) {
+	logger := controllerutils.ControllerLogEntry(ControllerName)
+	reconciler := &ReconcileGCPPrivateServiceConnect{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+	}
+
+	config, err := ReadGCPPrivateServiceConnectControllerConfigFile()
+	if err != nil {
+		logger.WithError(err).Error("could not get load configuration")
+		return reconciler, err
+	}
+	reconciler.controllerconfig = config
+	reconciler.gcpClientFn = func(secret *corev1.Secret) (gcpclient.Client, error) {
+		return gcpclient.NewClientFromSecretWithOptions(secret, gcpclient.Options{Controller: ControllerName})
+	}
+	reconciler.projectIDFn = gcpclient.ProjectIDFromSecret
+	return reconciler, nil
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileGCPPrivateServiceConnect, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	// Create a new controller
+	c, err := controllerutils.NewController("gcpprivateserviceconnect-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	err = c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error watching cluster deployment")
+		return err
+	}
+
+	// Watch for changes to ClusterProvision
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterProvision{}},
+		&handler.EnqueueRequestForOwner{
+			IsController: true,
+			OwnerType:    &hivev1.ClusterDeployment{},
+		}); err != nil {
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error watching cluster provision")
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileGCPPrivateServiceConnect{}
+
+// ReconcileGCPPrivateServiceConnect reconciles Private Service Connect for clusterdeployment object
+type ReconcileGCPPrivateServiceConnect struct {
+	client.Client
+
+	controllerconfig *hivev1.GCPPrivateServiceConnectConfig
+
+	// testing purpose
+	gcpClientFn gcpClientFn
+	projectIDFn projectIDFn
+}
+
+type gcpClientFn func(*corev1.Secret) (gcpclient.Client, error)
+
+type projectIDFn func(*corev1.Secret) (string, error)
+
+// Reconcile reconciles Private Service Connect for ClusterDeployment.
+func (r *ReconcileGCPPrivateServiceConnect) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, returnErr error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	logger.Debug("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	// Fetch the ClusterDeployment instance
+	cd := &hivev1.ClusterDeployment{}
+	err := r.Get(context.TODO(), request.NamespacedName, cd)
+	if apierrors.IsNotFound(err) {
+		logger.Debug("cluster deployment not found")
+		return reconcile.Result{}, nil
+	}
+	if err != nil {
+		// Error reading the object - requeue the request.
+		logger.WithError(err).Error("error getting ClusterDeployment")
+		return reconcile.Result{}, err
+	}
+
+	if cd.Spec.Platform.GCP == nil ||
+		cd.Spec.Platform.GCP.PrivateServiceConnect == nil {
+		logger.Debug("controller cannot service the clusterdeployment, so skipping")
+		return reconcile.Result{}, nil
+	}
+
+	// The resources of a paused cluster deployment are left untouched, even when private service connect has been disabled.
+	if controllerutils.IsClusterDeploymentPaused(cd, logger) {
+		return reconcile.Result{}, nil
+	}
+
+	if !cd.Spec.Platform.GCP.PrivateServiceConnect.Enabled {
+		if cleanupRequired(cd) {
+			// private service connect was disabled for this cluster so cleanup is required.
+			return r.cleanupClusterDeployment(ctx, cd, cd.Spec.ClusterMetadata, logger)
+		}
+
+		logger.Debug("cluster deployment does not have private service connect enabled, so skipping")
+		return reconcile.Result{}, nil
+	}
+
+	if cd.DeletionTimestamp != nil {
+		return r.cleanupClusterDeployment(ctx, cd, cd.Spec.ClusterMetadata, logger)
+	}
+
+	// Add finalizer if not already present
+	if !controllerutils.HasFinalizer(cd, finalizer) {
+		logger.Debug("adding finalizer to ClusterDeployment")
+		controllerutils.AddFinalizer(cd, finalizer)
+		if err := r.Update(context.Background(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "error adding finalizer to ClusterDeployment")
+			return reconcile.Result{}, err
+		}
+	}
+
+	if inventoryForRegion(r.controllerconfig, cd.Spec.Platform.GCP.Region) == nil {
+		err := errors.Errorf("cluster deployment region %q is not supported as there is no inventory to create necessary resources",
+			cd.Spec.Platform.GCP.Region)
+		logger.WithError(err).Error("cluster deployment region is not supported, so skipping")
+
+		if err := r.setErrCondition(cd, "UnsupportedRegion", err, logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	// See if we need to sync. This is what rate limits our cloud API usage, but allows for immediate syncing
+	// on changes and deletes.
+	shouldSync, syncAfter := shouldSync(cd)
+	if !shouldSync {
+		logger.WithFields(log.Fields{
+			"syncAfter": syncAfter,
+		}).Debug("Sync not needed")
+
+		return reconcile.Result{RequeueAfter: syncAfter}, nil
+	}
+
+	if cd.Spec.Installed {
+		logger.Debug("reconciling already installed cluster deployment")
+		return r.reconcilePrivateServiceConnect(ctx, cd, cd.Spec.ClusterMetadata, logger)
+	}
+
+	if cd.Status.ProvisionRef == nil {
+		logger.Debug("waiting for cluster deployment provision to start, will retry soon.")
+		return reconcile.Result{}, nil
+	}
+
+	cpLog := logger.WithField("provision", cd.Status.ProvisionRef.Name)
+	cp := &hivev1.ClusterProvision{}
+	err = r.Get(context.TODO(), types.NamespacedName{Name: cd.Status.ProvisionRef.Name, Namespace: cd.Namespace}, cp)
+	if apierrors.IsNotFound(err) {
+		cpLog.Warn("linked cluster provision not found")
+		return reconcile.Result{}, err
+	}
+	if err != nil {
+		cpLog.WithError(err).Error("could not get provision")
+		return reconcile.Result{}, err
+	}
+
+	if cp.Spec.PrevInfraID != nil && *cp.Spec.PrevInfraID != "" && cleanupRequired(cd) {
+		lastCleanup := cd.Annotations[lastCleanupAnnotationKey]
+		if lastCleanup != *cp.Spec.PrevInfraID {
+			logger.WithField("prevInfraID", *cp.Spec.PrevInfraID).
+				Info("cleaning up Private Service Connect resources from previous attempt")
+
+			cleaned, err := r.cleanupPreviousProvisionAttempt(ctx, cd, cp, logger)
+			if err != nil {
+				logger.WithError(err).Error("error cleaning up Private Service Connect resources for ClusterDeployment")
+
+				if err := r.setErrCondition(cd, "CleanupForProvisionReattemptFailed", err, logger); err != nil {
+					logger.WithError(err).Error("failed to update condition on cluster deployment")
+					return reconcile.Result{}, err
+				}
+				return reconcile.Result{}, err
+			}
+			if !cleaned {
+				logger.Debug("waiting for Private Service Connect resources from previous attempt to be deleted")
+				return reconcile.Result{RequeueAfter: operationRequeue}, nil
+			}
+
+			if err := r.setReadyCondition(cd, corev1.ConditionFalse,
+				"PreviousAttemptCleanupComplete",
+				"successfully cleaned up resources from previous provision attempt so that next attempt can start",
+				logger); err != nil {
+				logger.WithError(err).Error("failed to update condition on cluster deployment")
+				return reconcile.Result{}, err
+			}
+
+			return reconcile.Result{Requeue: true}, nil
+		}
+	}
+
+	if cp.Spec.InfraID == nil ||
+		(cp.Spec.InfraID != nil && *cp.Spec.InfraID == "") ||
+		(cp.Spec.AdminKubeconfigSecretRef == nil) ||
+		(cp.Spec.AdminKubeconfigSecretRef != nil && cp.Spec.AdminKubeconfigSecretRef.Name == "") {
+		logger.Debug("waiting for cluster deployment provision to provide ClusterMetadata, will retry soon.")
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcilePrivateServiceConnect(ctx, cd, &hivev1.ClusterMetadata{InfraID: *cp.Spec.InfraID, AdminKubeconfigSecretRef: *cp.Spec.AdminKubeconfigSecretRef}, logger)
+}
+
+// shouldSync returns if we should sync the desired ClusterDeployment. If it returns false, it also returns
+// the duration after which we should try to check if sync is required.
+func shouldSync(desired *hivev1.ClusterDeployment) (bool, time.Duration) {
+	window := 2 * time.Hour
+	if desired.DeletionTimestamp != nil && !controllerutils.HasFinalizer(desired, finalizer) {
+		return false, 0 // No finalizer means our cleanup has been completed. There's nothing left to do.
+	}
+
+	if desired.DeletionTimestamp != nil {
+		return true, 0 // We're in a deleting state, sync now.
+	}
+
+	failedCondition := controllerutils.FindClusterDeploymentCondition(desired.Status.Conditions, hivev1.GCPPrivateServiceConnectFailedClusterDeploymentCondition)
+	if failedCondition != nil && failedCondition.Status == corev1.ConditionTrue {
+		return true, 0 // we have failed to reconcile and therefore should continue to retry for quick recovery
+	}
+
+	readyCondition := controllerutils.FindClusterDeploymentCondition(desired.Status.Conditions, hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition)
+	if readyCondition == nil || readyCondition.Status != corev1.ConditionTrue {
+		return true, 0 // we have not reached Ready level
+	}
+	delta := time.Now().Sub(readyCondition.LastProbeTime.Time)
+
+	if !desired.Spec.Installed {
+		// as cluster is installing, but the private service connect has been setup once, we wait
+		// for a shorter duration before reconciling again.
+		window = 10 * time.Minute
+	}
+
+	if delta >= window {
+		// We haven't sync'd in over resync duration time, sync now.
+		return true, 0
+	}
+
+	syncAfter := (window - delta).Round(time.Minute)
+	if syncAfter == 0 {
+		// if it is less than a minute, sync after a minute
+		syncAfter = time.Minute
+	}
+	// We didn't meet any of the criteria above, so we should not sync.
+	return false, syncAfter
+}
+
+func (r *ReconcileGCPPrivateServiceConnect) setErrCondition(cd *hivev1.ClusterDeployment,
+	reason string, err error,
+	logger log.FieldLogger) error {
+	curr := &hivev1.ClusterDeployment{}
+	errGet := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, curr)
+	if errGet != nil {
+		return errGet
+	}
+	message := filterErrorMessage(err)
+	conditions, failedChanged := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		curr.Status.Conditions,
+		hivev1.GCPPrivateServiceConnectFailedClusterDeploymentCondition,
+		corev1.ConditionTrue,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+	conditions, readyChanged := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		conditions,
+		hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+		corev1.ConditionFalse,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+	if !readyChanged && !failedChanged {
+		return nil
+	}
+	curr.Status.Conditions = conditions
+	logger.Debug("setting GCPPrivateServiceConnectFailedClusterDeploymentCondition to true")
+	return r.Status().Update(context.TODO(), curr)
+}
+
+func (r *ReconcileGCPPrivateServiceConnect) setReadyCondition(cd *hivev1.ClusterDeployment,
+	completed corev1.ConditionStatus,
+	reason string, message string,
+	logger log.FieldLogger) error {
+
+	curr := &hivev1.ClusterDeployment{}
+	errGet := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, curr)
+	if errGet != nil {
+		return errGet
+	}
+
+	conditions := curr.Status.Conditions
+
+	var failedChanged bool
+	if completed == corev1.ConditionTrue {
+		conditions, failedChanged = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			conditions,
+			hivev1.GCPPrivateServiceConnectFailedClusterDeploymentCondition,
+			corev1.ConditionFalse,
+			reason,
+			message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange)
+	}
+
+	var readyChanged bool
+	ready := controllerutils.FindClusterDeploymentCondition(conditions, hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition)
+	if ready == nil || ready.Status != corev1.ConditionTrue {
+		// we want to allow Ready condition to reach Ready level
+		conditions, readyChanged = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			conditions,
+			hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+			completed,
+			reason,
+			message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange)
+	} else if completed == corev1.ConditionTrue {
+		// allow reinforcing Ready level to track the last Ready probe.
+		// we have a higher level control of when to sync an already Ready cluster
+		conditions, readyChanged = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			conditions,
+			hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+			corev1.ConditionTrue,
+			reason,
+			message,
+			controllerutils.UpdateConditionAlways)
+	}
+	if !readyChanged && !failedChanged {
+		return nil
+	}
+	curr.Status.Conditions = conditions
+	logger.Debugf("setting GCPPrivateServiceConnectReadyClusterDeploymentCondition to %s", completed)
+	return r.Status().Update(context.TODO(), curr)
+}
+
+func (r *ReconcileGCPPrivateServiceConnect) reconcilePrivateServiceConnect(ctx context.Context, cd *hivev1.ClusterDeployment, clusterMetadata *hivev1.ClusterMetadata, logger log.FieldLogger) (reconcile.Result, error) {
+	logger.Debug("reconciling Private Service Connect resources")
+	gcpClient, err := r.newGCPClient(cd)
+	if err != nil {
+		logger.WithError(err).Error("error creating GCP client for the cluster")
+		return reconcile.Result{}, err
+	}
+	region := cd.Spec.Platform.GCP.Region
+	inventory := inventoryForRegion(r.controllerconfig, region)
+	initPrivateServiceConnectStatus(cd)
+
+	// discover the internal load balancer for the cluster.
+	apiForwardingRule, err := gcpClient.user.GetForwardingRule(ctx, region, clusterMetadata.InfraID+"-api-internal")
+	if err != nil {
+		if isNotFound(err) {
+			logger.WithField("infraID", clusterMetadata.InfraID).Debug("internal load balancer is not yet created for the cluster, will retry later")
+
+			if err := r.setReadyCondition(cd, corev1.ConditionFalse,
+				"DiscoveringInternalLoadBalancerNotYetFound",
+				"discovering internal load balancer for the cluster, but it does not exist yet",
+				logger); err != nil {
+				logger.WithError(err).Error("failed to update condition on cluster deployment")
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{RequeueAfter: defaultRequeueLater}, nil
+		}
+
+		logger.WithField("infraID", clusterMetadata.InfraID).WithError(err).Error("error discovering internal load balancer for the cluster")
+
+		if err := r.setErrCondition(cd, "DiscoveringInternalLoadBalancerFailed", err, logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, err
+	}
+
+	steps := []struct {
+		reason  string
+		message string
+		failure string
+		fn      func() (bool, error)
+	}{{
+		reason:  "ReconciledServiceAttachmentSubnet",
+		message: "reconciled the NAT subnet of the Service Attachment for the cluster",
+		failure: "ServiceAttachmentSubnetReconcileFailed",
+		fn: func() (bool, error) {
+			return r.reconcileServiceAttachmentSubnet(ctx, gcpClient, cd, clusterMetadata, apiForwardingRule, logger)
+		},
+	}, {
+		reason:  "ReconciledServiceAttachment",
+		message: "reconciled the Service Attachment for the cluster",
+		failure: "ServiceAttachmentReconcileFailed",
+		fn: func() (bool, error) {
+			return r.reconcileServiceAttachment(ctx, gcpClient, cd, clusterMetadata, apiForwardingRule, logger)
+		},
+	}, {
+		reason:  "ReconciledEndpointAddress",
+		message: "reconciled the address of the Private Service Connect endpoint for the cluster",
+		failure: "EndpointAddressReconcileFailed",
+		fn: func() (bool, error) {
+			return r.reconcileEndpointAddress(ctx, gcpClient, cd, clusterMetadata, inventory, logger)
+		},
+	}, {
+		reason:  "ReconciledEndpoint",
+		message: "reconciled the Private Service Connect endpoint for the cluster",
+		failure: "EndpointReconcileFailed",
+		fn: func() (bool, error) {
+			return r.reconcileEndpoint(ctx, gcpClient, cd, clusterMetadata, inventory, logger)
+		},
+	}}
+	for _, step := range steps {
+		modified, err := step.fn()
+		if err != nil {
+			logger.WithError(err).Error("failed to reconcile Private Service Connect resources")
+
+			if err := r.setErrCondition(cd, step.failure, err, logger); err != nil {
+				logger.WithError(err).Error("failed to update condition on cluster deployment")
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{}, err
+		}
+		if modified {
+			if err := r.setReadyCondition(cd, corev1.ConditionFalse, step.reason, step.message, logger); err != nil {
+				logger.WithError(err).Error("failed to update condition on cluster deployment")
+				return reconcile.Result{}, err
+			}
+			// the resources are created asynchronously, so check on them again soon.
+			return reconcile.Result{RequeueAfter: operationRequeue}, nil
+		}
+	}
+
+	// Make sure the endpoint was accepted by the Service Attachment.
+	if err := checkEndpointConnection(ctx, gcpClient, cd, clusterMetadata); err != nil {
+		if errors.Is(err, errEndpointConnectionPending) {
+			logger.Debug("waiting for the Private Service Connect endpoint to be accepted")
+			if err := r.setReadyCondition(cd, corev1.ConditionFalse,
+				"WaitingForEndpointConnection",
+				"waiting for the Private Service Connect endpoint to be accepted by the Service Attachment",
+				logger); err != nil {
+				logger.WithError(err).Error("failed to update condition on cluster deployment")
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{RequeueAfter: operationRequeue}, nil
+		}
+		logger.WithError(err).Error("Private Service Connect endpoint is not connected")
+
+		if err := r.setErrCondition(cd, "EndpointNotConnected", err, logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, err
+	}
+
+	// Figure out the API address for cluster.
+	apiDomain, err := initialURL(r.Client,
+		client.ObjectKey{Namespace: cd.Namespace, Name: clusterMetadata.AdminKubeconfigSecretRef.Name})
+	if err != nil {
+		logger.WithError(err).Error("could not get API URL from kubeconfig")
+
+		if err := r.setErrCondition(cd, "CouldNotCalculateAPIDomain", err, logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, err
+	}
+
+	// Create the private DNS zone for the endpoint.
+	dnsModified, err := r.reconcileDNSZone(ctx, gcpClient, cd, clusterMetadata, inventory, apiDomain, logger)
+	if err != nil {
+		logger.WithError(err).Error("could not reconcile the private DNS zone")
+
+		if err := r.setErrCondition(cd, "PrivateDNSZoneReconcileFailed", err, logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, err
+	}
+
+	if dnsModified {
+		if err := r.setReadyCondition(cd, corev1.ConditionFalse,
+			"ReconciledPrivateDNSZone",
+			"reconciled the private DNS zone for the Private Service Connect endpoint of the cluster",
+			logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+			return reconcile.Result{}, err
+		}
+	}
+
+	if err := r.setReadyCondition(cd, corev1.ConditionTrue,
+		"PrivateServiceConnectAccessReady",
+		"private service connect access is ready for use",
+		logger); err != nil {
+		logger.WithError(err).Error("failed to update condition on cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// reconcileServiceAttachmentSubnet ensures that the subnet used for the NAT of the Service Attachment exists in
+// the network of the cluster's internal load balancer.
+func (r *ReconcileGCPPrivateServiceConnect) reconcileServiceAttachmentSubnet(ctx context.Context, gcpClient *gcpClient,
+	cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
+	apiForwardingRule *compute.ForwardingRule,
+	logger log.FieldLogger) (bool, error) {
+	region := cd.Spec.Platform.GCP.Region
+	name := resourceName(metadata)
+
+	subnet, err := gcpClient.user.GetSubnetwork(ctx, region, name)
+	if err != nil && !isNotFound(err) {
+		return false, errors.Wrap(err, "error getting the Service Attachment subnet")
+	}
+	if err == nil {
+		return r.updateStatus(cd, func(s *hivev1gcp.PrivateServiceConnectAccessStatus) {
+			s.ServiceAttachmentSubnet = subnet.SelfLink
+		}, logger)
+	}
+
+	cidr := cd.Spec.Platform.GCP.PrivateServiceConnect.ServiceAttachmentSubnetCIDR
+	if cidr == "" {
+		cidr = defaultServiceAttachmentSubnetCIDR
+	}
+	logger.WithField("cidr", cidr).Info("creating the Service Attachment subnet for the cluster")
+	if err := gcpClient.user.CreateSubnetwork(ctx, region, &compute.Subnetwork{
+		Name:        name,
+		Description: resourceDescription(metadata),
+		Network:     apiForwardingRule.Network,
+		IpCidrRange: cidr,
+		Purpose:     "PRIVATE_SERVICE_CONNECT",
+	}); err != nil {
+		return false, errors.Wrap(err, "error creating the Service Attachment subnet")
+	}
+	return true, nil
+}
+
+// reconcileServiceAttachment ensures that a Service Attachment is created for the cluster's internal load balancer,
+// and that only the hub project is allowed to connect to it.
+func (r *ReconcileGCPPrivateServiceConnect) reconcileServiceAttachment(ctx context.Context, gcpClient *gcpClient,
+	cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
+	apiForwardingRule *compute.ForwardingRule,
+	logger log.FieldLogger) (bool, error) {
+	region := cd.Spec.Platform.GCP.Region
+	name := resourceName(metadata)
+
+	attachment, err := gcpClient.user.GetServiceAttachment(ctx, region, name)
+	if err != nil && !isNotFound(err) {
+		return false, errors.Wrap(err, "error getting the Service Attachment")
+	}
+	if err == nil {
+		if attachment.TargetService != apiForwardingRule.SelfLink {
+			return false, errors.Errorf("Service Attachment %s does not target the internal load balancer of the cluster", name)
+		}
+		return r.updateStatus(cd, func(s *hivev1gcp.PrivateServiceConnectAccessStatus) {
+			s.ServiceAttachment = attachment.SelfLink
+		}, logger)
+	}
+
+	logger.Info("creating the Service Attachment for the cluster")
+	if err := gcpClient.user.CreateServiceAttachment(ctx, region, &gcpclient.ServiceAttachment{
+		Name:                 name,
+		Description:          resourceDescription(metadata),
+		TargetService:        apiForwardingRule.SelfLink,
+		ConnectionPreference: "ACCEPT_MANUAL",
+		NatSubnets:           []string{cd.Status.Platform.GCP.PrivateServiceConnect.ServiceAttachmentSubnet},
+		ConsumerAcceptLists: []*gcpclient.ServiceAttachmentConsumerProjectLimit{{
+			ProjectIdOrNum:  gcpClient.hubProject,
+			ConnectionLimit: connectionLimit,
+		}},
+	}); err != nil {
+		return false, errors.Wrap(err, "error creating the Service Attachment")
+	}
+	return true, nil
+}
+
+// reconcileEndpointAddress ensures that an internal address is reserved in the inventory subnet for the
+// Private Service Connect endpoint.
+func (r *ReconcileGCPPrivateServiceConnect) reconcileEndpointAddress(ctx context.Context, gcpClient *gcpClient,
+	cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
+	inventory *hivev1.GCPPrivateServiceConnectInventory,
+	logger log.FieldLogger) (bool, error) {
+	region := cd.Spec.Platform.GCP.Region
+	name := resourceName(metadata)
+
+	address, err := gcpClient.hub.GetAddress(ctx, region, name)
+	if err != nil && !isNotFound(err) {
+		return false, errors.Wrap(err, "error getting the endpoint address")
+	}
+	if err == nil {
+		if address.Address == "" {
+			// the address is still being reserved.
+			return true, nil
+		}
+		return r.updateStatus(cd, func(s *hivev1gcp.PrivateServiceConnectAccessStatus) {
+			s.EndpointAddress = address.Address
+		}, logger)
+	}
+
+	logger.WithField("subnet", inventory.Subnet).Info("reserving the endpoint address for the cluster")
+	if err := gcpClient.hub.CreateAddress(ctx, region, &compute.Address{
+		Name:        name,
+		Description: resourceDescription(metadata),
+		AddressType: "INTERNAL",
+		Subnetwork:  inventory.Subnet,
+	}); err != nil {
+		return false, errors.Wrap(err, "error reserving the endpoint address")
+	}
+	return true, nil
+}
+
+// reconcileEndpoint ensures that the Private Service Connect endpoint for the cluster's Service Attachment
+// exists in the inventory network.
+func (r *ReconcileGCPPrivateServiceConnect) reconcileEndpoint(ctx context.Context, gcpClient *gcpClient,
+	cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
+	inventory *hivev1.GCPPrivateServiceConnectInventory,
+	logger log.FieldLogger) (bool, error) {
+	region := cd.Spec.Platform.GCP.Region
+	name := resourceName(metadata)
+	status := cd.Status.Platform.GCP.PrivateServiceConnect
+
+	endpoint, err := gcpClient.hub.GetForwardingRule(ctx, region, name)
+	if err != nil && !isNotFound(err) {
+		return false, errors.Wrap(err, "error getting the endpoint")
+	}
+	if err == nil {
+		if endpoint.Target != status.ServiceAttachment {
+			return false, errors.Errorf("endpoint %s does not target the Service Attachment of the cluster", name)
+		}
+		return r.updateStatus(cd, func(s *hivev1gcp.PrivateServiceConnectAccessStatus) {
+			s.Endpoint = endpoint.SelfLink
+		}, logger)
+	}
+
+	logger.WithField("network", inventory.Network).Info("creating the Private Service Connect endpoint for the cluster")
+	if err := gcpClient.hub.CreateForwardingRule(ctx, region, &compute.ForwardingRule{
+		Name:        name,
+		Description: resourceDescription(metadata),
+		Network:     inventory.Network,
+		IPAddress:   fmt.Sprintf("projects/%s/regions/%s/addresses/%s", gcpClient.hubProject, region, name),
+		Target:      status.ServiceAttachment,
+	}); err != nil {
+		return false, errors.Wrap(err, "error creating the endpoint")
+	}
+	return true, nil
+}
+
+// checkEndpointConnection makes sure that the endpoint in the hub project has been accepted by the
+// Service Attachment of the cluster. It returns errEndpointConnectionPending while the connection
+// is being established.
+func checkEndpointConnection(ctx context.Context, gcpClient *gcpClient, cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata) error {
+	region := cd.Spec.Platform.GCP.Region
+	attachment, err := gcpClient.user.GetServiceAttachment(ctx, region, resourceName(metadata))
+	if err != nil {
+		return errors.Wrap(err, "error getting the Service Attachment")
+	}
+	endpoint := fmt.Sprintf("projects/%s/regions/%s/forwardingRules/%s", gcpClient.hubProject, region, resourceName(metadata))
+	for _, e := range attachment.ConnectedEndpoints {
+		if !strings.HasSuffix(e.Endpoint, endpoint) {
+			continue
+		}
+		switch e.Status {
+		case endpointStatusAccepted:
+			return nil
+		case endpointStatusPending:
+			return errEndpointConnectionPending
+		default:
+			return errors.Errorf("the Private Service Connect endpoint connection is %s", e.Status)
+		}
+	}
+	return errEndpointConnectionPending
+}
+
+// reconcileDNSZone ensures that a private managed zone for the cluster's API exists in the hub project, that it
+// is visible to the inventory network and the associated networks, and that the API record points to the
+// Private Service Connect endpoint.
+func (r *ReconcileGCPPrivateServiceConnect) reconcileDNSZone(ctx context.Context, gcpClient *gcpClient,
+	cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
+	inventory *hivev1.GCPPrivateServiceConnectInventory,
+	apiDomain string,
+	logger log.FieldLogger) (bool, error) {
+	modified := false
+	zoneName := resourceName(metadata)
+	dnsName := apiDomain + "."
+	endpointAddress := cd.Status.Platform.GCP.PrivateServiceConnect.EndpointAddress
+
+	networks := sets.NewString(inventory.Network)
+	networks.Insert(r.controllerconfig.AssociatedNetworks...)
+	visibility := &dns.ManagedZonePrivateVisibilityConfig{}
+	for _, n := range networks.List() {
+		visibility.Networks = append(visibility.Networks, &dns.ManagedZonePrivateVisibilityConfigNetwork{NetworkUrl: n})
+	}
+
+	zone, err := gcpClient.hub.GetManagedZone(ctx, zoneName)
+	if err != nil && !isNotFound(err) {
+		return modified, errors.Wrap(err, "error getting the private DNS zone")
+	}
+	if isNotFound(err) {
+		logger.WithField("dnsName", dnsName).Info("creating the private DNS zone for the cluster")
+		if _, err := gcpClient.hub.CreateManagedZone(ctx, &dns.ManagedZone{
+			Name:                    zoneName,
+			DnsName:                 dnsName,
+			Description:             resourceDescription(metadata),
+			Visibility:              "private",
+			PrivateVisibilityConfig: visibility,
+		}); err != nil {
+			return modified, errors.Wrap(err, "error creating the private DNS zone")
+		}
+		modified = true
+	} else {
+		current := sets.NewString()
+		if zone.PrivateVisibilityConfig != nil {
+			for _, n := range zone.PrivateVisibilityConfig.Networks {
+				current.Insert(n.NetworkUrl)
+			}
+		}
+		if !current.Equal(networks) {
+			logger.WithField("networks", networks.List()).Info("updating the networks of the private DNS zone")
+			if err := gcpClient.hub.UpdateManagedZone(ctx, zoneName, &dns.ManagedZone{
+				PrivateVisibilityConfig: visibility,
+			}); err != nil {
+				return modified, errors.Wrap(err, "error updating the networks of the private DNS zone")
+			}
+			modified = true
+		}
+	}
+	if _, err := r.updateStatus(cd, func(s *hivev1gcp.PrivateServiceConnectAccessStatus) {
+		s.DNSZone = zoneName
+	}, logger); err != nil {
+		return modified, err
+	}
+
+	desired := &dns.ResourceRecordSet{
+		Name:    dnsName,
+		Type:    "A",
+		Ttl:     60,
+		Rrdatas: []string{endpointAddress},
+	}
+	records, err := gcpClient.hub.ListResourceRecordSets(ctx, zoneName, gcpclient.ListResourceRecordSetsOptions{
+		Name: dnsName,
+		Type: "A",
+	})
+	if err != nil {
+		return modified, errors.Wrap(err, "error listing the records of the private DNS zone")
+	}
+	if len(records.Rrsets) == 0 {
+		logger.WithField("address", endpointAddress).Info("adding the API record to the private DNS zone")
+		if err := gcpClient.hub.AddResourceRecordSet(ctx, zoneName, desired); err != nil {
+			return modified, errors.Wrap(err, "error adding the API record to the private DNS zone")
+		}
+		return true, nil
+	}
+	if existing := records.Rrsets[0]; len(existing.Rrdatas) != 1 || existing.Rrdatas[0] != endpointAddress {
+		logger.WithField("address", endpointAddress).Info("updating the API record in the private DNS zone")
+		if err := gcpClient.hub.UpdateResourceRecordSet(ctx, zoneName, desired, existing); err != nil {
+			return modified, errors.Wrap(err, "error updating the API record in the private DNS zone")
+		}
+		return true, nil
+	}
+	return modified, nil
+}
+
+// updateStatus applies the mutation to the Private Service Connect status of the ClusterDeployment and
+// persists it when it changed. It never reports the resources as modified.
+func (r *ReconcileGCPPrivateServiceConnect) updateStatus(cd *hivev1.ClusterDeployment,
+	mutate func(*hivev1gcp.PrivateServiceConnectAccessStatus),
+	logger log.FieldLogger) (bool, error) {
+	initPrivateServiceConnectStatus(cd)
+	status := cd.Status.Platform.GCP.PrivateServiceConnect
+	orig := *status
+	mutate(status)
+	if *status == orig {
+		return false, nil
+	}
+	if err := r.updatePrivateServiceConnectStatus(cd, logger); err != nil {
+		logger.WithError(err).Error("error updating clusterdeployment status with private service connect resources")
+		return false, err
+	}
+	return false, nil
+}
+
+// resourceName returns the name used for all the Private Service Connect resources of the cluster.
+func resourceName(metadata *hivev1.ClusterMetadata) string {
+	return metadata.InfraID + "-psc"
+}
+
+func resourceDescription(metadata *hivev1.ClusterMetadata) string {
+	return fmt.Sprintf("private service connect access for %s", metadata.InfraID)
+}
+
+// inventoryForRegion returns the inventory that can be used for clusters in the region, if any.
+func inventoryForRegion(config *hivev1.GCPPrivateServiceConnectConfig, region string) *hivev1.GCPPrivateServiceConnectInventory {
+	if config == nil {
+		return nil
+	}
+	for i, inv := range config.EndpointInventory {
+		if inv.Region == region {
+			return &config.EndpointInventory[i]
+		}
+	}
+	return nil
+}
+
+func filterErrorMessage(err error) string {
+	skipRequestIDRE := regexp.MustCompile(`(request id|Request ID): ([-0-9a-f]+)`)
+	return skipRequestIDRE.ReplaceAllString(err.Error(), "${1}: XXXX")
+}
+
+type gcpClient struct {
+	hub  gcpclient.Client
+	user gcpclient.Client
+
+	hubProject string
+}
+
+func (r *ReconcileGCPPrivateServiceConnect) newGCPClient(cd *hivev1.ClusterDeployment) (*gcpClient, error) {
+	userSecret := &corev1.Secret{}
+	if err := r.Get(context.TODO(),
+		types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.Platform.GCP.CredentialsSecretRef.Name},
+		userSecret); err != nil {
+		return nil, errors.Wrap(err, "failed to get the cluster credentials")
+	}
+	uClient, err := r.gcpClientFn(userSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	hubSecret := &corev1.Secret{}
+	if err := r.Get(context.TODO(),
+		types.NamespacedName{Namespace: controllerutils.GetHiveNamespace(), Name: r.controllerconfig.CredentialsSecretRef.Name},
+		hubSecret); err != nil {
+		return nil, errors.Wrap(err, "failed to get the hub credentials")
+	}
+	hClient, err := r.gcpClientFn(hubSecret)
+	if err != nil {
+		return nil, err
+	}
+	hubProject, err := r.projectIDFn(hubSecret)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the hub project")
+	}
+	return &gcpClient{hub: hClient, user: uClient, hubProject: hubProject}, nil
+}
+
+// initialURL returns the initial API URL for the ClusterProvision.
+func initialURL(c client.Client, key client.ObjectKey) (string, error) {
+	kubeconfigSecret := &corev1.Secret{}
+	if err := c.Get(
+		context.Background(),
+		key,
+		kubeconfigSecret,
+	); err != nil {
+		return "", err
+	}
+	cfg, err := restConfigFromSecret(kubeconfigSecret)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to load the kubeconfig")
+	}
+
+	u, err := url.Parse(cfg.Host)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(u.Hostname(), "."), nil
+}
+
+func restConfigFromSecret(kubeconfigSecret *corev1.Secret) (*rest.Config, error) {
+	kubeconfigData := kubeconfigSecret.Data[constants.RawKubeconfigSecretKey]
+	if len(kubeconfigData) == 0 {
+		kubeconfigData = kubeconfigSecret.Data[constants.KubeconfigSecretKey]
+	}
+	if len(kubeconfigData) == 0 {
+		return nil, errors.New("kubeconfig secret does not contain necessary data")
+	}
+	config, err := clientcmd.Load(kubeconfigData)
+	if err != nil {
+		return nil, err
+	}
+	kubeConfig := clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{})
+	return kubeConfig.ClientConfig()
+}
+
+// isNotFound returns true if the error is a GCP API error for a resource that does not exist.
+func isNotFound(err error) bool {
+	var gerr *googleapi.Error
+	return errors.As(err, &gerr) && gerr.Code == http.StatusNotFound
+}
+
+// ReadGCPPrivateServiceConnectControllerConfigFile reads the configuration from the env
+// and unmarshals. If the env is set to a file but that file doesn't exist it returns
+// a zero value configuration.
+func ReadGCPPrivateServiceConnectControllerConfigFile() (*hivev1.GCPPrivateServiceConnectConfig, error) {
+	fPath := os.Getenv(constants.GCPPrivateServiceConnectControllerConfigFileEnvVar)
+	if len(fPath) == 0 {
+		return nil, nil
+	}
+
+	config := &hivev1.GCPPrivateServiceConnectConfig{}
+
+	fileBytes, err := ioutil.ReadFile(fPath)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return config, errors.Wrap(err, "failed to read the gcp private service connect controller config file")
+	}
+	if err := json.Unmarshal(fileBytes, &config); err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+var retryBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 1 * time.Second,
+	Factor:   1.0,
+	Jitter:   0.1,
+}
+
+func (r *ReconcileGCPPrivateServiceConnect) updatePrivateServiceConnectStatus(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	return retry.RetryOnConflict(retryBackoff, func() error {
+		curr := &hivev1.ClusterDeployment{}
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, curr)
+		if err != nil {
+			return err
+		}
+
+		initPrivateServiceConnectStatus(curr)
+		curr.Status.Platform.GCP.PrivateServiceConnect = cd.Status.Platform.GCP.PrivateServiceConnect
+		return r.Client.Status().Update(context.TODO(), curr)
+	})
+}
+
+func initPrivateServiceConnectStatus(cd *hivev1.ClusterDeployment) {
+	if cd.Status.Platform == nil {
+		cd.Status.Platform = &hivev1.PlatformStatus{}
+	}
+	if cd.Status.Platform.GCP == nil {
+		cd.Status.Platform.GCP = &hivev1gcp.PlatformStatus{}
+	}
+	if cd.Status.Platform.GCP.PrivateServiceConnect == nil {
+		cd.Status.Platform.GCP.PrivateServiceConnect = &hivev1gcp.PrivateServiceConnectAccessStatus{}
+	}
+}
+
+func updateAnnotations(client client.Client, cd *hivev1.ClusterDeployment) error {
+	return retry.RetryOnConflict(retryBackoff, func() error {
+		curr := &hivev1.ClusterDeployment{}
+		err := client.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, curr)
+		if err != nil {
+			return err
+		}
+		curr.Annotations = cd.Annotations
+		return client.Update(context.TODO(), curr)
+	})
+}
@@ -0,0 +1,611 @@
+package gcpprivateserviceconnect
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	compute "google.golang.org/api/compute/v1"
+	dns "google.golang.org/api/dns/v1"
+	"google.golang.org/api/googleapi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/gcpclient"
+	"github.com/openshift/hive/pkg/gcpclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	"github.com/openshift/hive/pkg/test/generic"
+)
+
+const (
+	testNS = "test-namespace"
+
+	testInfraID    = "test-cd-infra"
+	testRegion     = "us-central1"
+	testHubProject = "hub-project"
+
+	apiForwardingRuleURL = "https://compute/projects/user-project/regions/us-central1/forwardingRules/test-cd-infra-api-internal"
+	subnetURL            = "https://compute/projects/user-project/regions/us-central1/subnetworks/test-cd-infra-psc"
+	attachmentURL        = "https://compute/projects/user-project/regions/us-central1/serviceAttachments/test-cd-infra-psc"
+	endpointURL          = "https://compute/projects/hub-project/regions/us-central1/forwardingRules/test-cd-infra-psc"
+	hubNetworkURL        = "https://compute/projects/hub-project/global/networks/hub-network"
+	hubSubnetURL         = "https://compute/projects/hub-project/regions/us-central1/subnetworks/hub-subnet"
+)
+
+var notFound = &googleapi.Error{Code: http.StatusNotFound}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+
+	key := client.ObjectKey{Name: "test-cd", Namespace: testNS}
+	cdBuilder := testcd.FullBuilder(testNS, "test-cd", scheme)
+	enabledBuilder := cdBuilder.Options(
+		testcd.WithGCPPlatform(&hivev1gcp.Platform{
+			Region:                testRegion,
+			CredentialsSecretRef:  corev1.LocalObjectReference{Name: "user-creds"},
+			PrivateServiceConnect: &hivev1gcp.PrivateServiceConnectAccess{Enabled: true},
+		}),
+		testcd.Installed(),
+		withClusterMetadata(testInfraID, "test-cd-kubeconfig"),
+	)
+	validInventory := []hivev1.GCPPrivateServiceConnectInventory{{
+		Region:  testRegion,
+		Network: hubNetworkURL,
+		Subnet:  hubSubnetURL,
+	}}
+	secrets := []runtime.Object{
+		testSecret(testNS, "user-creds", map[string]string{constants.GCPCredentialsName: "{}"}),
+		testSecret(constants.DefaultHiveNamespace, "hub-creds", map[string]string{constants.GCPCredentialsName: "{}"}),
+		testSecret(testNS, "test-cd-kubeconfig", map[string]string{
+			"kubeconfig": `apiVersion: v1
+clusters:
+- cluster:
+    server: https://api.test-cluster:6443
+  name: test-cluster
+contexts:
+- context:
+    cluster: test-cluster
+    user: admin
+  name: admin
+current-context: admin
+kind: Config
+users:
+- name: admin`,
+		}),
+	}
+	completeStatus := &hivev1gcp.PrivateServiceConnectAccessStatus{
+		ServiceAttachmentSubnet: subnetURL,
+		ServiceAttachment:       attachmentURL,
+		Endpoint:                endpointURL,
+		EndpointAddress:         "10.0.0.5",
+		DNSZone:                 "test-cd-infra-psc",
+	}
+
+	mockAPIForwardingRule := func(m *mock.MockClient) {
+		m.EXPECT().GetForwardingRule(gomock.Any(), testRegion, "test-cd-infra-api-internal").
+			Return(&compute.ForwardingRule{SelfLink: apiForwardingRuleURL, Network: "user-network"}, nil)
+	}
+	mockExistingResources := func(user, hub *mock.MockClient, endpointStatus string) {
+		mockAPIForwardingRule(user)
+		user.EXPECT().GetSubnetwork(gomock.Any(), testRegion, "test-cd-infra-psc").
+			Return(&compute.Subnetwork{SelfLink: subnetURL}, nil)
+		attachment := &gcpclient.ServiceAttachment{
+			SelfLink:      attachmentURL,
+			TargetService: apiForwardingRuleURL,
+			ConnectedEndpoints: []*gcpclient.ServiceAttachmentConnectedEndpoint{{
+				Endpoint: endpointURL,
+				Status:   endpointStatus,
+			}},
+		}
+		user.EXPECT().GetServiceAttachment(gomock.Any(), testRegion, "test-cd-infra-psc").Return(attachment, nil).Times(2)
+		hub.EXPECT().GetAddress(gomock.Any(), testRegion, "test-cd-infra-psc").
+			Return(&compute.Address{Address: "10.0.0.5"}, nil)
+		hub.EXPECT().GetForwardingRule(gomock.Any(), testRegion, "test-cd-infra-psc").
+			Return(&compute.ForwardingRule{SelfLink: endpointURL, Target: attachmentURL}, nil)
+	}
+
+	cases := []struct {
+		name string
+
+		existing         []runtime.Object
+		inventory        []hivev1.GCPPrivateServiceConnectInventory
+		associate        []string
+		configureUser    func(*mock.MockClient)
+		configureHub     func(*mock.MockClient)
+		configureClients func(user, hub *mock.MockClient)
+
+		hasFinalizer       bool
+		expectedStatus     *hivev1gcp.PrivateServiceConnectAccessStatus
+		expectedConditions []hivev1.ClusterDeploymentCondition
+		err                string
+	}{{
+		name: "cd with aws platform",
+
+		existing: []runtime.Object{
+			cdBuilder.Build(testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1"})),
+		},
+	}, {
+		name: "cd with private service connect disabled",
+
+		existing: []runtime.Object{
+			cdBuilder.Build(testcd.WithGCPPlatform(&hivev1gcp.Platform{Region: testRegion,
+				PrivateServiceConnect: &hivev1gcp.PrivateServiceConnectAccess{Enabled: false}})),
+		},
+	}, {
+		name: "paused cd with private service connect disabled and resources to clean up",
+
+		existing: append(secrets, enabledBuilder.GenericOptions(generic.WithFinalizer(finalizer)).Build(
+			testcd.WithGCPPlatform(&hivev1gcp.Platform{Region: testRegion,
+				PrivateServiceConnect: &hivev1gcp.PrivateServiceConnectAccess{Enabled: false}}),
+			withPrivateServiceConnect(completeStatus),
+			testcd.Paused(),
+		)),
+		inventory: validInventory,
+
+		hasFinalizer:   true,
+		expectedStatus: completeStatus,
+	}, {
+		name: "cd with private service connect enabled, no inventory in given region",
+
+		existing: []runtime.Object{
+			enabledBuilder.Build(),
+		},
+		inventory: []hivev1.GCPPrivateServiceConnectInventory{{
+			Region:  "us-east1",
+			Network: hubNetworkURL,
+			Subnet:  hubSubnetURL,
+		}},
+
+		hasFinalizer: true,
+		expectedConditions: getExpectedConditions(true, "UnsupportedRegion",
+			"cluster deployment region \"us-central1\" is not supported as there is no inventory to create necessary resources"),
+	}, {
+		name: "internal load balancer not found",
+
+		existing:  append(secrets, enabledBuilder.Build()),
+		inventory: validInventory,
+		configureUser: func(m *mock.MockClient) {
+			m.EXPECT().GetForwardingRule(gomock.Any(), testRegion, "test-cd-infra-api-internal").Return(nil, notFound)
+		},
+
+		hasFinalizer: true,
+		expectedConditions: []hivev1.ClusterDeploymentCondition{{
+			Type:    hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+			Status:  corev1.ConditionFalse,
+			Reason:  "DiscoveringInternalLoadBalancerNotYetFound",
+			Message: "discovering internal load balancer for the cluster, but it does not exist yet",
+		}},
+	}, {
+		name: "create service attachment subnet",
+
+		existing:  append(secrets, enabledBuilder.Build()),
+		inventory: validInventory,
+		configureUser: func(m *mock.MockClient) {
+			mockAPIForwardingRule(m)
+			m.EXPECT().GetSubnetwork(gomock.Any(), testRegion, "test-cd-infra-psc").Return(nil, notFound)
+			m.EXPECT().CreateSubnetwork(gomock.Any(), testRegion, &compute.Subnetwork{
+				Name:        "test-cd-infra-psc",
+				Description: "private service connect access for test-cd-infra",
+				Network:     "user-network",
+				IpCidrRange: "172.16.0.0/29",
+				Purpose:     "PRIVATE_SERVICE_CONNECT",
+			}).Return(nil)
+		},
+
+		hasFinalizer: true,
+		expectedConditions: []hivev1.ClusterDeploymentCondition{{
+			Type:    hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+			Status:  corev1.ConditionFalse,
+			Reason:  "ReconciledServiceAttachmentSubnet",
+			Message: "reconciled the NAT subnet of the Service Attachment for the cluster",
+		}},
+	}, {
+		name: "create service attachment",
+
+		existing:  append(secrets, enabledBuilder.Build()),
+		inventory: validInventory,
+		configureUser: func(m *mock.MockClient) {
+			mockAPIForwardingRule(m)
+			m.EXPECT().GetSubnetwork(gomock.Any(), testRegion, "test-cd-infra-psc").
+				Return(&compute.Subnetwork{SelfLink: subnetURL}, nil)
+			m.EXPECT().GetServiceAttachment(gomock.Any(), testRegion, "test-cd-infra-psc").Return(nil, notFound)
+			m.EXPECT().CreateServiceAttachment(gomock.Any(), testRegion, &gcpclient.ServiceAttachment{
+				Name:                 "test-cd-infra-psc",
+				Description:          "private service connect access for test-cd-infra",
+				TargetService:        apiForwardingRuleURL,
+				ConnectionPreference: "ACCEPT_MANUAL",
+				NatSubnets:           []string{subnetURL},
+				ConsumerAcceptLists: []*gcpclient.ServiceAttachmentConsumerProjectLimit{{
+					ProjectIdOrNum:  testHubProject,
+					ConnectionLimit: 1,
+				}},
+			}).Return(nil)
+		},
+
+		hasFinalizer: true,
+		expectedStatus: &hivev1gcp.PrivateServiceConnectAccessStatus{
+			ServiceAttachmentSubnet: subnetURL,
+		},
+		expectedConditions: []hivev1.ClusterDeploymentCondition{{
+			Type:    hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+			Status:  corev1.ConditionFalse,
+			Reason:  "ReconciledServiceAttachment",
+			Message: "reconciled the Service Attachment for the cluster",
+		}},
+	}, {
+		name: "create endpoint",
+
+		existing:  append(secrets, enabledBuilder.Build()),
+		inventory: validInventory,
+		configureClients: func(user, hub *mock.MockClient) {
+			mockAPIForwardingRule(user)
+			user.EXPECT().GetSubnetwork(gomock.Any(), testRegion, "test-cd-infra-psc").
+				Return(&compute.Subnetwork{SelfLink: subnetURL}, nil)
+			user.EXPECT().GetServiceAttachment(gomock.Any(), testRegion, "test-cd-infra-psc").
+				Return(&gcpclient.ServiceAttachment{SelfLink: attachmentURL, TargetService: apiForwardingRuleURL}, nil)
+			hub.EXPECT().GetAddress(gomock.Any(), testRegion, "test-cd-infra-psc").
+				Return(&compute.Address{Address: "10.0.0.5"}, nil)
+			hub.EXPECT().GetForwardingRule(gomock.Any(), testRegion, "test-cd-infra-psc").Return(nil, notFound)
+			hub.EXPECT().CreateForwardingRule(gomock.Any(), testRegion, &compute.ForwardingRule{
+				Name:        "test-cd-infra-psc",
+				Description: "private service connect access for test-cd-infra",
+				Network:     hubNetworkURL,
+				IPAddress:   "projects/hub-project/regions/us-central1/addresses/test-cd-infra-psc",
+				Target:      attachmentURL,
+			}).Return(nil)
+		},
+
+		hasFinalizer: true,
+		expectedStatus: &hivev1gcp.PrivateServiceConnectAccessStatus{
+			ServiceAttachmentSubnet: subnetURL,
+			ServiceAttachment:       attachmentURL,
+			EndpointAddress:         "10.0.0.5",
+		},
+		expectedConditions: []hivev1.ClusterDeploymentCondition{{
+			Type:    hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+			Status:  corev1.ConditionFalse,
+			Reason:  "ReconciledEndpoint",
+			Message: "reconciled the Private Service Connect endpoint for the cluster",
+		}},
+	}, {
+		name: "endpoint connection pending",
+
+		existing:  append(secrets, enabledBuilder.Build()),
+		inventory: validInventory,
+		configureClients: func(user, hub *mock.MockClient) {
+			mockExistingResources(user, hub, "PENDING")
+		},
+
+		hasFinalizer: true,
+		expectedStatus: &hivev1gcp.PrivateServiceConnectAccessStatus{
+			ServiceAttachmentSubnet: subnetURL,
+			ServiceAttachment:       attachmentURL,
+			Endpoint:                endpointURL,
+			EndpointAddress:         "10.0.0.5",
+		},
+		expectedConditions: []hivev1.ClusterDeploymentCondition{{
+			Type:    hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+			Status:  corev1.ConditionFalse,
+			Reason:  "WaitingForEndpointConnection",
+			Message: "waiting for the Private Service Connect endpoint to be accepted by the Service Attachment",
+		}},
+	}, {
+		name: "endpoint connection rejected",
+
+		existing:  append(secrets, enabledBuilder.Build()),
+		inventory: validInventory,
+		configureClients: func(user, hub *mock.MockClient) {
+			mockExistingResources(user, hub, "REJECTED")
+		},
+
+		hasFinalizer: true,
+		expectedStatus: &hivev1gcp.PrivateServiceConnectAccessStatus{
+			ServiceAttachmentSubnet: subnetURL,
+			ServiceAttachment:       attachmentURL,
+			Endpoint:                endpointURL,
+			EndpointAddress:         "10.0.0.5",
+		},
+		expectedConditions: getExpectedConditions(true, "EndpointNotConnected",
+			"the Private Service Connect endpoint connection is REJECTED"),
+		err: "the Private Service Connect endpoint connection is REJECTED",
+	}, {
+		name: "create private dns zone",
+
+		existing:  append(secrets, enabledBuilder.Build()),
+		inventory: validInventory,
+		associate: []string{"https://compute/projects/hub-project/global/networks/hive-network"},
+		configureClients: func(user, hub *mock.MockClient) {
+			mockExistingResources(user, hub, "ACCEPTED")
+			hub.EXPECT().GetManagedZone(gomock.Any(), "test-cd-infra-psc").Return(nil, notFound)
+			hub.EXPECT().CreateManagedZone(gomock.Any(), &dns.ManagedZone{
+				Name:        "test-cd-infra-psc",
+				DnsName:     "api.test-cluster.",
+				Description: "private service connect access for test-cd-infra",
+				Visibility:  "private",
+				PrivateVisibilityConfig: &dns.ManagedZonePrivateVisibilityConfig{
+					Networks: []*dns.ManagedZonePrivateVisibilityConfigNetwork{
+						{NetworkUrl: "https://compute/projects/hub-project/global/networks/hive-network"},
+						{NetworkUrl: hubNetworkURL},
+					},
+				},
+			}).Return(nil, nil)
+			hub.EXPECT().ListResourceRecordSets(gomock.Any(), "test-cd-infra-psc", gcpclient.ListResourceRecordSetsOptions{
+				Name: "api.test-cluster.",
+				Type: "A",
+			}).Return(&dns.ResourceRecordSetsListResponse{}, nil)
+			hub.EXPECT().AddResourceRecordSet(gomock.Any(), "test-cd-infra-psc", &dns.ResourceRecordSet{
+				Name:    "api.test-cluster.",
+				Type:    "A",
+				Ttl:     60,
+				Rrdatas: []string{"10.0.0.5"},
+			}).Return(nil)
+		},
+
+		hasFinalizer:       true,
+		expectedStatus:     completeStatus,
+		expectedConditions: getExpectedConditions(false, "PrivateServiceConnectAccessReady", "private service connect access is ready for use"),
+	}, {
+		name: "all resources ready",
+
+		existing:  append(secrets, enabledBuilder.Build()),
+		inventory: validInventory,
+		configureClients: func(user, hub *mock.MockClient) {
+			mockExistingResources(user, hub, "ACCEPTED")
+			hub.EXPECT().GetManagedZone(gomock.Any(), "test-cd-infra-psc").Return(&dns.ManagedZone{
+				PrivateVisibilityConfig: &dns.ManagedZonePrivateVisibilityConfig{
+					Networks: []*dns.ManagedZonePrivateVisibilityConfigNetwork{{NetworkUrl: hubNetworkURL}},
+				},
+			}, nil)
+			hub.EXPECT().ListResourceRecordSets(gomock.Any(), "test-cd-infra-psc", gomock.Any()).
+				Return(&dns.ResourceRecordSetsListResponse{
+					Rrsets: []*dns.ResourceRecordSet{{Name: "api.test-cluster.", Type: "A", Rrdatas: []string{"10.0.0.5"}}},
+				}, nil)
+		},
+
+		hasFinalizer:       true,
+		expectedStatus:     completeStatus,
+		expectedConditions: getExpectedConditions(false, "PrivateServiceConnectAccessReady", "private service connect access is ready for use"),
+	}, {
+		name: "cleanup deletes the dns zone and the endpoint",
+
+		existing: append(secrets, enabledBuilder.GenericOptions(generic.Deleted(), generic.WithFinalizer(finalizer)).Build(
+			withPrivateServiceConnect(completeStatus),
+		)),
+		inventory: validInventory,
+		configureHub: func(m *mock.MockClient) {
+			m.EXPECT().GetManagedZone(gomock.Any(), "test-cd-infra-psc").Return(&dns.ManagedZone{}, nil)
+			m.EXPECT().ListResourceRecordSets(gomock.Any(), "test-cd-infra-psc", gcpclient.ListResourceRecordSetsOptions{}).
+				Return(&dns.ResourceRecordSetsListResponse{
+					Rrsets: []*dns.ResourceRecordSet{
+						{Name: "api.test-cluster.", Type: "NS"},
+						{Name: "api.test-cluster.", Type: "SOA"},
+						{Name: "api.test-cluster.", Type: "A", Rrdatas: []string{"10.0.0.5"}},
+					},
+				}, nil)
+			m.EXPECT().DeleteResourceRecordSets(gomock.Any(), "test-cd-infra-psc", []*dns.ResourceRecordSet{
+				{Name: "api.test-cluster.", Type: "A", Rrdatas: []string{"10.0.0.5"}},
+			}).Return(nil)
+			m.EXPECT().DeleteManagedZone(gomock.Any(), "test-cd-infra-psc").Return(nil)
+			m.EXPECT().DeleteForwardingRule(gomock.Any(), testRegion, "test-cd-infra-psc").Return(nil)
+		},
+
+		hasFinalizer:   true,
+		expectedStatus: completeStatus,
+	}, {
+		name: "cleanup complete",
+
+		existing: append(secrets, enabledBuilder.GenericOptions(generic.Deleted(), generic.WithFinalizer(finalizer)).Build(
+			withPrivateServiceConnect(completeStatus),
+		)),
+		inventory: validInventory,
+		configureClients: func(user, hub *mock.MockClient) {
+			hub.EXPECT().GetManagedZone(gomock.Any(), "test-cd-infra-psc").Return(nil, notFound)
+			hub.EXPECT().DeleteForwardingRule(gomock.Any(), testRegion, "test-cd-infra-psc").Return(notFound)
+			hub.EXPECT().DeleteAddress(gomock.Any(), testRegion, "test-cd-infra-psc").Return(notFound)
+			user.EXPECT().DeleteServiceAttachment(gomock.Any(), testRegion, "test-cd-infra-psc").Return(notFound)
+			user.EXPECT().DeleteSubnetwork(gomock.Any(), testRegion, "test-cd-infra-psc").Return(notFound)
+		},
+
+		expectedConditions: []hivev1.ClusterDeploymentCondition{{
+			Type:    hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+			Status:  corev1.ConditionFalse,
+			Reason:  "DeprovisionCleanupComplete",
+			Message: "successfully cleaned up private service connect resources created to deprovision cluster",
+		}},
+	}}
+
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockedUserClient := mock.NewMockClient(mockCtrl)
+			mockedHubClient := mock.NewMockClient(mockCtrl)
+
+			if test.configureUser != nil {
+				test.configureUser(mockedUserClient)
+			}
+			if test.configureHub != nil {
+				test.configureHub(mockedHubClient)
+			}
+			if test.configureClients != nil {
+				test.configureClients(mockedUserClient, mockedHubClient)
+			}
+
+			fakeClient := fake.NewFakeClientWithScheme(scheme, test.existing...)
+			log.SetLevel(log.DebugLevel)
+			reconciler := &ReconcileGCPPrivateServiceConnect{
+				Client: fakeClient,
+				controllerconfig: &hivev1.GCPPrivateServiceConnectConfig{
+					CredentialsSecretRef: corev1.LocalObjectReference{Name: "hub-creds"},
+					EndpointInventory:    test.inventory,
+					AssociatedNetworks:   test.associate,
+				},
+				gcpClientFn: func(secret *corev1.Secret) (gcpclient.Client, error) {
+					if secret.Namespace == constants.DefaultHiveNamespace {
+						return mockedHubClient, nil
+					}
+					return mockedUserClient, nil
+				},
+				projectIDFn: func(_ *corev1.Secret) (string, error) {
+					return testHubProject, nil
+				},
+			}
+
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: key})
+			if test.err == "" {
+				assert.NoError(t, err, "unexpected error from Reconcile")
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+			cd := &hivev1.ClusterDeployment{}
+			err = fakeClient.Get(context.TODO(), key, cd)
+			require.NoError(t, err)
+
+			if test.hasFinalizer {
+				assert.Contains(t, cd.ObjectMeta.Finalizers, finalizer)
+			} else {
+				assert.NotContains(t, cd.ObjectMeta.Finalizers, finalizer)
+			}
+
+			for i := range cd.Status.Conditions {
+				cd.Status.Conditions[i].LastProbeTime = metav1.Time{}
+				cd.Status.Conditions[i].LastTransitionTime = metav1.Time{}
+			}
+			assert.ElementsMatch(t, test.expectedConditions, cd.Status.Conditions)
+
+			if cd.Status.Platform == nil {
+				cd.Status.Platform = &hivev1.PlatformStatus{}
+			}
+			if cd.Status.Platform.GCP == nil {
+				cd.Status.Platform.GCP = &hivev1gcp.PlatformStatus{}
+			}
+			assert.Equal(t, test.expectedStatus, cd.Status.Platform.GCP.PrivateServiceConnect)
+		})
+	}
+}
+
+func Test_shouldSync(t *testing.T) {
+	cases := []struct {
+		name string
+
+		cd *hivev1.ClusterDeployment
+
+		expectedSync      bool
+		expectedSyncAfter time.Duration
+	}{{
+		name: "not ready",
+
+		cd:           testcd.Build(),
+		expectedSync: true,
+	}, {
+		name: "failed",
+
+		cd: testcd.Build(testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.GCPPrivateServiceConnectFailedClusterDeploymentCondition,
+			Status: corev1.ConditionTrue,
+		})),
+		expectedSync: true,
+	}, {
+		name: "ready recently, installed",
+
+		cd: testcd.Build(testcd.Installed(), testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:          hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+			Status:        corev1.ConditionTrue,
+			LastProbeTime: metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+		})),
+		expectedSyncAfter: time.Hour,
+	}, {
+		name: "ready recently, installing",
+
+		cd: testcd.Build(testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:          hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+			Status:        corev1.ConditionTrue,
+			LastProbeTime: metav1.NewTime(time.Now().Add(-5 * time.Minute)),
+		})),
+		expectedSyncAfter: 5 * time.Minute,
+	}, {
+		name: "ready long ago",
+
+		cd: testcd.Build(testcd.Installed(), testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:          hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+			Status:        corev1.ConditionTrue,
+			LastProbeTime: metav1.NewTime(time.Now().Add(-3 * time.Hour)),
+		})),
+		expectedSync: true,
+	}}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			sync, syncAfter := shouldSync(test.cd)
+			assert.Equal(t, test.expectedSync, sync)
+			assert.Equal(t, test.expectedSyncAfter, syncAfter)
+		})
+	}
+}
+
+func testSecret(namespace, name string, data map[string]string) *corev1.Secret {
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{},
+	}
+	for k, v := range data {
+		s.Data[k] = []byte(v)
+	}
+	return s
+}
+
+func withClusterMetadata(infraID, kubeconfigSecretName string) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+			InfraID: infraID,
+			AdminKubeconfigSecretRef: corev1.LocalObjectReference{
+				Name: kubeconfigSecretName,
+			},
+		}
+	}
+}
+
+func withPrivateServiceConnect(s *hivev1gcp.PrivateServiceConnectAccessStatus) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		status := *s
+		cd.Status.Platform = &hivev1.PlatformStatus{GCP: &hivev1gcp.PlatformStatus{PrivateServiceConnect: &status}}
+	}
+}
+
+// getExpectedConditions should be called when only one of Ready and Failed conditions is true,
+// and both have the same reason and message
+func getExpectedConditions(failed bool, reason string, message string) []hivev1.ClusterDeploymentCondition {
+	ready := corev1.ConditionTrue
+	failedStatus := corev1.ConditionFalse
+	if failed {
+		ready, failedStatus = corev1.ConditionFalse, corev1.ConditionTrue
+	}
+	return []hivev1.ClusterDeploymentCondition{{
+		Status:  failedStatus,
+		Type:    hivev1.GCPPrivateServiceConnectFailedClusterDeploymentCondition,
+		Reason:  reason,
+		Message: message,
+	}, {
+		Status:  ready,
+		Type:    hivev1.GCPPrivateServiceConnectReadyClusterDeploymentCondition,
+		Reason:  reason,
+		Message: message,
+	}}
+}
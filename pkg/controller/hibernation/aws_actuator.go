@@ -1,13 +1,13 @@
 package hibernation
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	log "github.com/sirupsen/logrus"
-	corev1 "k8s.io/api/core/v1"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -15,7 +15,6 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 
 	awsclient "github.com/openshift/hive/pkg/awsclient"
-	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
@@ -31,12 +30,25 @@ var (
 )
 
 func init() {
-	RegisterActuator(&awsActuator{awsClientFn: getAWSClient})
+	RegisterActuator(&awsActuator{
+		awsClientFn: getAWSClient,
+		instances:   controllerutils.NewExpiringCache("aws_hibernation_instances", 30*time.Second, 1000),
+	})
 }
 
 type awsActuator struct {
 	// awsClientFn is the function to build an AWS client, here for testing
 	awsClientFn func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (awsclient.Client, error)
+	// instances caches the instances of clusters briefly, as the hibernation controller checks whether the machines
+	// are running or stopped repeatedly while resuming or hibernating a cluster. The instances of a cluster are
+	// removed from the cache when they are started or stopped.
+	instances *controllerutils.ExpiringCache
+}
+
+// awsInstance is an instance of a cluster and its state.
+type awsInstance struct {
+	id    *string
+	state string
 }
 
 // CanHandle returns true if the actuator can handle a particular ClusterDeployment
@@ -45,13 +57,13 @@ func (a *awsActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
 }
 
 // StopMachines will stop machines belonging to the given ClusterDeployment
-func (a *awsActuator) StopMachines(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
+func (a *awsActuator) StopMachines(ctx context.Context, cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
 	logger = logger.WithField("cloud", "aws")
 	awsClient, err := a.awsClientFn(cd, c, logger)
 	if err != nil {
 		return err
 	}
-	instanceIDs, err := getClusterInstanceIDs(cd, awsClient, runningOrPendingStates, logger)
+	instanceIDs, err := a.getClusterInstanceIDs(ctx, cd, awsClient, runningOrPendingStates, logger)
 	if err != nil {
 		return err
 	}
@@ -60,9 +72,10 @@ func (a *awsActuator) StopMachines(cd *hivev1.ClusterDeployment, c client.Client
 		return nil
 	}
 	logger.WithField("instanceIDs", instanceIDs).Info("Stopping cluster instances")
-	_, err = awsClient.StopInstances(&ec2.StopInstancesInput{
+	_, err = awsClient.StopInstances(ctx, &ec2.StopInstancesInput{
 		InstanceIds: instanceIDs,
 	})
+	a.instances.Delete(instancesCacheKey(cd))
 	if err != nil {
 		logger.WithError(err).Error("failed to stop instances")
 	}
@@ -70,13 +83,13 @@ func (a *awsActuator) StopMachines(cd *hivev1.ClusterDeployment, c client.Client
 }
 
 // StartMachines will select machines belonging to the given ClusterDeployment
-func (a *awsActuator) StartMachines(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
+func (a *awsActuator) StartMachines(ctx context.Context, cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
 	logger = logger.WithField("cloud", "aws")
 	awsClient, err := a.awsClientFn(cd, c, logger)
 	if err != nil {
 		return err
 	}
-	instanceIDs, err := getClusterInstanceIDs(cd, awsClient, stoppedOrStoppingStates, logger)
+	instanceIDs, err := a.getClusterInstanceIDs(ctx, cd, awsClient, stoppedOrStoppingStates, logger)
 	if err != nil {
 		return err
 	}
@@ -85,9 +98,10 @@ func (a *awsActuator) StartMachines(cd *hivev1.ClusterDeployment, c client.Clien
 		return nil
 	}
 	logger.WithField("instanceIDs", instanceIDs).Info("Starting cluster instances")
-	_, err = awsClient.StartInstances(&ec2.StartInstancesInput{
+	_, err = awsClient.StartInstances(ctx, &ec2.StartInstancesInput{
 		InstanceIds: instanceIDs,
 	})
+	a.instances.Delete(instancesCacheKey(cd))
 	if err != nil {
 		logger.WithError(err).Error("failed to start instances")
 	}
@@ -96,14 +110,14 @@ func (a *awsActuator) StartMachines(cd *hivev1.ClusterDeployment, c client.Clien
 
 // MachinesRunning will return true if the machines associated with the given
 // ClusterDeployment are in a running state.
-func (a *awsActuator) MachinesRunning(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (bool, error) {
+func (a *awsActuator) MachinesRunning(ctx context.Context, cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (bool, error) {
 	logger = logger.WithField("cloud", "aws")
 	logger.Infof("checking whether machines are running")
 	awsClient, err := a.awsClientFn(cd, c, logger)
 	if err != nil {
 		return false, err
 	}
-	instanceIDs, err := getClusterInstanceIDs(cd, awsClient, notRunningStates, logger)
+	instanceIDs, err := a.getClusterInstanceIDs(ctx, cd, awsClient, notRunningStates, logger)
 	if err != nil {
 		return false, err
 	}
@@ -112,14 +126,14 @@ func (a *awsActuator) MachinesRunning(cd *hivev1.ClusterDeployment, c client.Cli
 
 // MachinesStopped will return true if the machines associated with the given
 // ClusterDeployment are in a stopped state.
-func (a *awsActuator) MachinesStopped(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (bool, error) {
+func (a *awsActuator) MachinesStopped(ctx context.Context, cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (bool, error) {
 	logger = logger.WithField("cloud", "aws")
 	logger.Infof("checking whether machines are stopped")
 	awsClient, err := a.awsClientFn(cd, c, logger)
 	if err != nil {
 		return false, err
 	}
-	instanceIDs, err := getClusterInstanceIDs(cd, awsClient, notStoppedStates, logger)
+	instanceIDs, err := a.getClusterInstanceIDs(ctx, cd, awsClient, notStoppedStates, logger)
 	if err != nil {
 		return false, err
 	}
@@ -128,30 +142,42 @@ func (a *awsActuator) MachinesStopped(cd *hivev1.ClusterDeployment, c client.Cli
 
 func getAWSClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (awsclient.Client, error) {
 	options := awsclient.Options{
-		Region: cd.Spec.Platform.AWS.Region,
-		CredentialsSource: awsclient.CredentialsSource{
-			Secret: &awsclient.SecretCredentialsSource{
-				Namespace: cd.Namespace,
-				Ref:       &cd.Spec.Platform.AWS.CredentialsSecretRef,
-			},
-			AssumeRole: &awsclient.AssumeRoleCredentialsSource{
-				SecretRef: corev1.SecretReference{
-					Name:      os.Getenv(constants.HiveAWSServiceProviderCredentialsSecretRefEnvVar),
-					Namespace: controllerutils.GetHiveNamespace(),
-				},
-				Role: cd.Spec.Platform.AWS.CredentialsAssumeRole,
-			},
-		},
+		Controller:        hivev1.HibernationControllerName,
+		Region:            cd.Spec.Platform.AWS.Region,
+		CredentialsSource: awsclient.NewCredentialsSource(cd.Namespace, &cd.Spec.Platform.AWS.CredentialsSecretRef, cd.Spec.Platform.AWS.CredentialsAssumeRole, cd.Spec.Platform.AWS.CredentialsSource),
+		ServiceEndpoints:  cd.Spec.Platform.AWS.ServiceEndpoints,
+		ProxySecret:       &awsclient.ProxySecretSource{Namespace: cd.Namespace, Ref: cd.Spec.CloudClientProxySecretRef},
 	}
 
 	return awsclient.New(c, options)
 }
 
-func getClusterInstanceIDs(cd *hivev1.ClusterDeployment, c awsclient.Client, states sets.String, logger log.FieldLogger) ([]*string, error) {
+func (a *awsActuator) getClusterInstanceIDs(ctx context.Context, cd *hivev1.ClusterDeployment, c awsclient.Client, states sets.String, logger log.FieldLogger) ([]*string, error) {
 	infraID := cd.Spec.ClusterMetadata.InfraID
 	logger = logger.WithField("infraID", infraID)
+	instances, err := a.instances.GetOrLoad(instancesCacheKey(cd), func() (interface{}, error) {
+		return listClusterInstances(ctx, infraID, c, logger)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := []*string{}
+	for _, i := range instances.([]awsInstance) {
+		if states.Has(i.state) {
+			result = append(result, i.id)
+		}
+	}
+	logger.WithField("count", len(result)).WithField("states", states).Debug("result of listing instances")
+	return result, nil
+}
+
+func instancesCacheKey(cd *hivev1.ClusterDeployment) string {
+	return fmt.Sprintf("%s/%s/%s", cd.Namespace, cd.Name, cd.Spec.ClusterMetadata.InfraID)
+}
+
+func listClusterInstances(ctx context.Context, infraID string, c awsclient.Client, logger log.FieldLogger) ([]awsInstance, error) {
 	logger.Debug("listing cluster instances")
-	out, err := c.DescribeInstances(&ec2.DescribeInstancesInput{
+	out, err := c.DescribeInstances(ctx, &ec2.DescribeInstancesInput{
 		Filters: []*ec2.Filter{
 			{
 				Name:   aws.String(fmt.Sprintf("tag:kubernetes.io/cluster/%s", infraID)),
@@ -163,14 +189,11 @@ func getClusterInstanceIDs(cd *hivev1.ClusterDeployment, c awsclient.Client, sta
 		logger.WithError(err).Error("failed to list instances")
 		return nil, err
 	}
-	result := []*string{}
+	result := []awsInstance{}
 	for _, r := range out.Reservations {
 		for _, i := range r.Instances {
-			if states.Has(aws.StringValue(i.State.Name)) {
-				result = append(result, i.InstanceId)
-			}
+			result = append(result, awsInstance{id: i.InstanceId, state: aws.StringValue(i.State.Name)})
 		}
 	}
-	logger.WithField("count", len(result)).WithField("states", states).Debug("result of listing instances")
 	return result, nil
 }
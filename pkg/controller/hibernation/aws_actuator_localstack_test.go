@@ -0,0 +1,120 @@
+// +build localstack
+
+package hibernation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/test/localstack"
+)
+
+func TestAWSActuatorLocalStack(t *testing.T) {
+	h := localstack.New(t)
+
+	infraID := localstack.UniqueName("infra")
+	clusterInstances := h.CreateClusterInstances(t, infraID, 3)
+	otherInstances := h.CreateClusterInstances(t, localstack.UniqueName("other"), 1)
+
+	cd := &hivev1.ClusterDeployment{
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterMetadata: &hivev1.ClusterMetadata{InfraID: infraID},
+		},
+	}
+	awsClient := h.Client(t)
+	actuator := &awsActuator{
+		awsClientFn: func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (awsclient.Client, error) {
+			return awsClient, nil
+		},
+	}
+	logger := log.WithField("test", t.Name())
+	machinesIn := func(check func(context.Context, *hivev1.ClusterDeployment, client.Client, log.FieldLogger) (bool, error)) func() bool {
+		return func() bool {
+			done, err := check(context.TODO(), cd, h.KubeClient, logger)
+			require.NoError(t, err, "unexpected error checking machines")
+			return done
+		}
+	}
+
+	require.Eventually(t, machinesIn(actuator.MachinesRunning), time.Minute, time.Second, "machines did not start running")
+
+	require.NoError(t, actuator.StopMachines(context.TODO(), cd, h.KubeClient, logger), "unexpected error stopping machines")
+	require.Eventually(t, machinesIn(actuator.MachinesStopped), time.Minute, time.Second, "machines did not stop")
+	assert.Equal(t, map[string]string{otherInstances[0]: "running"}, instanceStates(t, awsClient, otherInstances), "instances of other clusters must not be stopped")
+
+	require.NoError(t, actuator.StartMachines(context.TODO(), cd, h.KubeClient, logger), "unexpected error starting machines")
+	require.Eventually(t, machinesIn(actuator.MachinesRunning), time.Minute, time.Second, "machines did not start")
+	for id, state := range instanceStates(t, awsClient, clusterInstances) {
+		assert.Equal(t, "running", state, "unexpected state of instance %s", id)
+	}
+}
+
+func TestAWSActuatorLocalStackThrottling(t *testing.T) {
+	h := localstack.New(t)
+
+	infraID := localstack.UniqueName("infra")
+	h.CreateClusterInstances(t, infraID, 1)
+	cd := &hivev1.ClusterDeployment{
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterMetadata: &hivev1.ClusterMetadata{InfraID: infraID},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		failures    int32
+		expectError bool
+	}{
+		{
+			name:     "retried",
+			failures: 2,
+		},
+		{
+			name:        "retries exhausted",
+			failures:    100,
+			expectError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := h.Options()
+			options.Endpoint = h.ThrottlingEndpoint(t, test.failures)
+			awsClient, err := awsclient.New(h.KubeClient, options)
+			require.NoError(t, err, "unexpected error creating AWS client")
+			actuator := &awsActuator{
+				awsClientFn: func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (awsclient.Client, error) {
+					return awsClient, nil
+				},
+			}
+			_, err = actuator.MachinesRunning(context.TODO(), cd, h.KubeClient, log.WithField("test", t.Name()))
+			if test.expectError {
+				assert.Error(t, err, "expected throttling error")
+			} else {
+				assert.NoError(t, err, "expected throttled requests to be retried")
+			}
+		})
+	}
+}
+
+func instanceStates(t *testing.T, c awsclient.Client, ids []string) map[string]string {
+	out, err := c.DescribeInstances(context.TODO(), &ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice(ids)})
+	require.NoError(t, err, "unexpected error describing instances")
+	states := map[string]string{}
+	for _, r := range out.Reservations {
+		for _, i := range r.Instances {
+			states[aws.StringValue(i.InstanceId)] = aws.StringValue(i.State.Name)
+		}
+	}
+	return states
+}
@@ -1,6 +1,7 @@
 package hibernation
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -49,8 +50,8 @@ func TestStopAndStartMachines(t *testing.T) {
 			testFunc:  "StopMachines",
 			instances: map[string]int{"terminated": 2, "running": 2},
 			setupClient: func(t *testing.T, c *mockawsclient.MockClient) {
-				c.EXPECT().StopInstances(gomock.Any()).Do(
-					func(input *ec2.StopInstancesInput) {
+				c.EXPECT().StopInstances(gomock.Any(), gomock.Any()).Do(
+					func(_ context.Context, input *ec2.StopInstancesInput) {
 						matchInstanceIDs(t, input.InstanceIds, map[string]int{"running": 2})
 					}).Return(nil, nil)
 			},
@@ -60,8 +61,8 @@ func TestStopAndStartMachines(t *testing.T) {
 			testFunc:  "StopMachines",
 			instances: map[string]int{"terminated": 5, "shutting-down": 3, "stopped": 4, "pending": 1, "running": 3},
 			setupClient: func(t *testing.T, c *mockawsclient.MockClient) {
-				c.EXPECT().StopInstances(gomock.Any()).Do(
-					func(input *ec2.StopInstancesInput) {
+				c.EXPECT().StopInstances(gomock.Any(), gomock.Any()).Do(
+					func(_ context.Context, input *ec2.StopInstancesInput) {
 						matchInstanceIDs(t, input.InstanceIds, map[string]int{"pending": 1, "running": 3})
 					}).Return(nil, nil)
 			},
@@ -76,8 +77,8 @@ func TestStopAndStartMachines(t *testing.T) {
 			testFunc:  "StartMachines",
 			instances: map[string]int{"stopped": 3, "terminated": 2, "running": 3},
 			setupClient: func(t *testing.T, c *mockawsclient.MockClient) {
-				c.EXPECT().StartInstances(gomock.Any()).Do(
-					func(input *ec2.StartInstancesInput) {
+				c.EXPECT().StartInstances(gomock.Any(), gomock.Any()).Do(
+					func(_ context.Context, input *ec2.StartInstancesInput) {
 						matchInstanceIDs(t, input.InstanceIds, map[string]int{"stopped": 3})
 					}).Return(nil, nil)
 			},
@@ -87,8 +88,8 @@ func TestStopAndStartMachines(t *testing.T) {
 			testFunc:  "StartMachines",
 			instances: map[string]int{"stopped": 3, "stopping": 1, "terminated": 3},
 			setupClient: func(t *testing.T, c *mockawsclient.MockClient) {
-				c.EXPECT().StartInstances(gomock.Any()).Do(
-					func(input *ec2.StartInstancesInput) {
+				c.EXPECT().StartInstances(gomock.Any(), gomock.Any()).Do(
+					func(_ context.Context, input *ec2.StartInstancesInput) {
 						matchInstanceIDs(t, input.InstanceIds, map[string]int{"stopped": 3, "stopping": 1})
 					}).Return(nil, nil)
 			},
@@ -107,9 +108,9 @@ func TestStopAndStartMachines(t *testing.T) {
 			var err error
 			switch test.testFunc {
 			case "StopMachines":
-				err = actuator.StopMachines(testClusterDeployment(), nil, log.New())
+				err = actuator.StopMachines(context.TODO(), testClusterDeployment(), nil, log.New())
 			case "StartMachines":
-				err = actuator.StartMachines(testClusterDeployment(), nil, log.New())
+				err = actuator.StartMachines(context.TODO(), testClusterDeployment(), nil, log.New())
 			default:
 				t.Fatal("Invalid function to test")
 			}
@@ -177,9 +178,9 @@ func TestMachinesStoppedAndRunning(t *testing.T) {
 			var result bool
 			switch test.testFunc {
 			case "MachinesStopped":
-				result, err = actuator.MachinesStopped(testClusterDeployment(), nil, log.New())
+				result, err = actuator.MachinesStopped(context.TODO(), testClusterDeployment(), nil, log.New())
 			case "MachinesRunning":
-				result, err = actuator.MachinesRunning(testClusterDeployment(), nil, log.New())
+				result, err = actuator.MachinesRunning(context.TODO(), testClusterDeployment(), nil, log.New())
 			default:
 				t.Fatal("Invalid function to test")
 			}
@@ -233,7 +234,7 @@ func setupClientInstances(awsClient *mockawsclient.MockClient, states map[string
 	}
 	reservation := &ec2.Reservation{Instances: instances}
 	reservations := []*ec2.Reservation{reservation}
-	awsClient.EXPECT().DescribeInstances(gomock.Any()).Times(1).Return(
+	awsClient.EXPECT().DescribeInstances(gomock.Any(), gomock.Any()).Times(1).Return(
 		&ec2.DescribeInstancesOutput{
 			Reservations: reservations,
 		},
@@ -17,6 +17,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	"github.com/openshift/hive/pkg/azureclient"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
@@ -52,13 +53,13 @@ func (a *azureActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
 }
 
 // StopMachines will stop machines belonging to the given ClusterDeployment
-func (a *azureActuator) StopMachines(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
+func (a *azureActuator) StopMachines(ctx context.Context, cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
 	logger = logger.WithField("cloud", "azure")
 	azureClient, err := a.azureClientFn(cd, c, logger)
 	if err != nil {
 		return err
 	}
-	machines, err := listAzureMachines(cd, azureClient, azureRunningOrPendingStates, logger)
+	machines, err := listAzureMachines(ctx, cd, azureClient, azureRunningOrPendingStates, logger)
 	if err != nil {
 		return err
 	}
@@ -69,7 +70,7 @@ func (a *azureActuator) StopMachines(cd *hivev1.ClusterDeployment, c client.Clie
 	var errs []error
 	for _, machineName := range azureMachineNames(machines) {
 		logger.WithField("machine", machineName).Info("Stopping cluster machine")
-		_, err = azureClient.DeallocateVirtualMachine(context.TODO(), clusterDeploymentResourceGroup(cd), machineName)
+		_, err = azureClient.DeallocateVirtualMachine(ctx, clusterDeploymentResourceGroup(cd), machineName)
 		if err != nil {
 			errs = append(errs, err)
 			logger.WithError(err).WithField("machine", machineName).Error("Failed to stop machine")
@@ -79,13 +80,13 @@ func (a *azureActuator) StopMachines(cd *hivev1.ClusterDeployment, c client.Clie
 }
 
 // StartMachines will select machines belonging to the given ClusterDeployment
-func (a *azureActuator) StartMachines(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
+func (a *azureActuator) StartMachines(ctx context.Context, cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) error {
 	logger = logger.WithField("cloud", "azure")
 	azureClient, err := a.azureClientFn(cd, c, logger)
 	if err != nil {
 		return err
 	}
-	machines, err := listAzureMachines(cd, azureClient, azureStoppedOrStoppingStates, logger)
+	machines, err := listAzureMachines(ctx, cd, azureClient, azureStoppedOrStoppingStates, logger)
 	if err != nil {
 		return err
 	}
@@ -96,7 +97,7 @@ func (a *azureActuator) StartMachines(cd *hivev1.ClusterDeployment, c client.Cli
 	var errs []error
 	for _, machineName := range azureMachineNames(machines) {
 		logger.WithField("machine", machineName).Info("Starting cluster machine")
-		_, err = azureClient.StartVirtualMachine(context.TODO(), clusterDeploymentResourceGroup(cd), machineName)
+		_, err = azureClient.StartVirtualMachine(ctx, clusterDeploymentResourceGroup(cd), machineName)
 		if err != nil {
 			errs = append(errs, err)
 			logger.WithError(err).WithField("machine", machineName).Error("Failed to start machine")
@@ -107,13 +108,13 @@ func (a *azureActuator) StartMachines(cd *hivev1.ClusterDeployment, c client.Cli
 
 // MachinesRunning will return true if the machines associated with the given
 // ClusterDeployment are in a running state.
-func (a *azureActuator) MachinesRunning(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (bool, error) {
+func (a *azureActuator) MachinesRunning(ctx context.Context, cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (bool, error) {
 	logger = logger.WithField("cloud", "azure")
 	azureClient, err := a.azureClientFn(cd, c, logger)
 	if err != nil {
 		return false, err
 	}
-	machines, err := listAzureMachines(cd, azureClient, azureNotRunningStates, logger)
+	machines, err := listAzureMachines(ctx, cd, azureClient, azureNotRunningStates, logger)
 	if err != nil {
 		return false, err
 	}
@@ -122,28 +123,28 @@ func (a *azureActuator) MachinesRunning(cd *hivev1.ClusterDeployment, c client.C
 
 // MachinesStopped will return true if the machines associated with the given
 // ClusterDeployment are in a stopped state.
-func (a *azureActuator) MachinesStopped(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (bool, error) {
+func (a *azureActuator) MachinesStopped(ctx context.Context, cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (bool, error) {
 	logger = logger.WithField("cloud", "azure")
 	azureClient, err := a.azureClientFn(cd, c, logger)
 	if err != nil {
 		return false, err
 	}
-	machines, err := listAzureMachines(cd, azureClient, azureNotStoppedStates, logger)
+	machines, err := listAzureMachines(ctx, cd, azureClient, azureNotStoppedStates, logger)
 	if err != nil {
 		return false, err
 	}
 	return len(machines) == 0, nil
 }
 
-func listAzureMachines(cd *hivev1.ClusterDeployment, azureClient azureclient.Client, states sets.String, logger log.FieldLogger) ([]compute.VirtualMachine, error) {
-	page, err := azureClient.ListAllVirtualMachines(context.TODO(), "true")
+func listAzureMachines(ctx context.Context, cd *hivev1.ClusterDeployment, azureClient azureclient.Client, states sets.String, logger log.FieldLogger) ([]compute.VirtualMachine, error) {
+	page, err := azureClient.ListAllVirtualMachines(ctx, "true")
 	if err != nil {
 		return nil, err
 	}
 	var result []compute.VirtualMachine
 	for page.NotDone() {
 		result = append(result, filterByResourceGroupAndState(page.Values(), clusterDeploymentResourceGroup(cd), states, logger)...)
-		if err = page.Next(); err != nil {
+		if err = page.NextWithContext(ctx); err != nil {
 			return nil, err
 		}
 	}
@@ -213,7 +214,11 @@ func getAzureClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.Fi
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to fetch Azure credentials secret")
 		return nil, errors.Wrap(err, "failed to fetch Azure credentials secret")
 	}
-	azureClient, err := azureclient.NewClientFromSecret(secret)
+	proxy, err := controllerutils.GetCloudClientProxy(c, cd.Namespace, cd.Spec.CloudClientProxySecretRef)
+	if err != nil {
+		return nil, err
+	}
+	azureClient, err := azureclient.NewClientFromSecretWithOptions(secret, hivev1azure.PublicCloud, azureclient.Options{Proxy: proxy, Controller: hivev1.HibernationControllerName})
 	if err != nil {
 		logger.WithError(err).Error("failed to get Azure client")
 	}
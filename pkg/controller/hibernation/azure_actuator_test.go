@@ -101,9 +101,9 @@ func TestAzureStopAndStartMachines(t *testing.T) {
 			var err error
 			switch test.testFunc {
 			case "StopMachines":
-				err = actuator.StopMachines(testAzureClusterDeployment(), nil, log.New())
+				err = actuator.StopMachines(context.TODO(), testAzureClusterDeployment(), nil, log.New())
 			case "StartMachines":
-				err = actuator.StartMachines(testAzureClusterDeployment(), nil, log.New())
+				err = actuator.StartMachines(context.TODO(), testAzureClusterDeployment(), nil, log.New())
 			default:
 				t.Fatal("Invalid function to test")
 			}
@@ -171,9 +171,9 @@ func TestAzureMachinesStoppedAndRunning(t *testing.T) {
 			var result bool
 			switch test.testFunc {
 			case "MachinesStopped":
-				result, err = actuator.MachinesStopped(testAzureClusterDeployment(), nil, log.New())
+				result, err = actuator.MachinesStopped(context.TODO(), testAzureClusterDeployment(), nil, log.New())
 			case "MachinesRunning":
-				result, err = actuator.MachinesRunning(testAzureClusterDeployment(), nil, log.New())
+				result, err = actuator.MachinesRunning(context.TODO(), testAzureClusterDeployment(), nil, log.New())
 			default:
 				t.Fatal("Invalid function to test")
 			}
@@ -47,20 +47,20 @@ func (a *gcpActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
 }
 
 // StopMachines will start machines belonging to the given ClusterDeployment
-func (a *gcpActuator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+func (a *gcpActuator) StopMachines(ctx context.Context, cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
 	logger = logger.WithField("cloud", "GCP")
 	gcpClient, err := a.getGCPClientFn(cd, hiveClient, logger)
 	if err != nil {
 		return err
 	}
-	instances, err := gcpListComputeInstances(gcpClient, cd, gcpRunningOrPendingStatuses, logger)
+	instances, err := gcpListComputeInstances(ctx, gcpClient, cd, gcpRunningOrPendingStatuses, logger)
 	if err != nil {
 		return err
 	}
 	var errs []error
 	for _, instance := range instances {
 		logger.WithField("instance", instance.Name).Info("Stopping instance")
-		err = gcpClient.StopInstance(instance)
+		err = gcpClient.StopInstance(ctx, instance)
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -69,13 +69,13 @@ func (a *gcpActuator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient clie
 }
 
 // StartMachines will select machines belonging to the given ClusterDeployment
-func (a *gcpActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+func (a *gcpActuator) StartMachines(ctx context.Context, cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
 	logger = logger.WithField("cloud", "GCP")
 	gcpClient, err := a.getGCPClientFn(cd, hiveClient, logger)
 	if err != nil {
 		return err
 	}
-	instances, err := gcpListComputeInstances(gcpClient, cd, gcpStoppedOrStoppingStatuses, logger)
+	instances, err := gcpListComputeInstances(ctx, gcpClient, cd, gcpStoppedOrStoppingStatuses, logger)
 	if err != nil {
 		return err
 	}
@@ -86,7 +86,7 @@ func (a *gcpActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient cli
 	var errs []error
 	for _, instance := range instances {
 		logger.WithField("instance", instance.Name).Info("Starting instance")
-		err = gcpClient.StartInstance(instance)
+		err = gcpClient.StartInstance(ctx, instance)
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -96,13 +96,13 @@ func (a *gcpActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient cli
 
 // MachinesRunning will return true if the machines associated with the given
 // ClusterDeployment are in a running state.
-func (a *gcpActuator) MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error) {
+func (a *gcpActuator) MachinesRunning(ctx context.Context, cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error) {
 	logger = logger.WithField("cloud", "GCP")
 	gcpClient, err := a.getGCPClientFn(cd, hiveClient, logger)
 	if err != nil {
 		return false, err
 	}
-	instances, err := gcpListComputeInstances(gcpClient, cd, gcpNotRunningStatuses, logger)
+	instances, err := gcpListComputeInstances(ctx, gcpClient, cd, gcpNotRunningStatuses, logger)
 	if err != nil {
 		return false, err
 	}
@@ -111,13 +111,13 @@ func (a *gcpActuator) MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient c
 
 // MachinesStopped will return true if the machines associated with the given
 // ClusterDeployment are in a stopped state.
-func (a *gcpActuator) MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error) {
+func (a *gcpActuator) MachinesStopped(ctx context.Context, cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error) {
 	logger = logger.WithField("cloud", "GCP")
 	gcpClient, err := a.getGCPClientFn(cd, hiveClient, logger)
 	if err != nil {
 		return false, err
 	}
-	instances, err := gcpListComputeInstances(gcpClient, cd, gcpNotStoppedStatuses, logger)
+	instances, err := gcpListComputeInstances(ctx, gcpClient, cd, gcpNotStoppedStatuses, logger)
 	if err != nil {
 		return false, err
 	}
@@ -134,17 +134,21 @@ func getGCPClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.Fiel
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to fetch GCP credentials secret")
 		return nil, errors.Wrap(err, "failed to fetch GCP credentials secret")
 	}
-	return gcpclient.NewClientFromSecret(secret)
+	proxy, err := controllerutils.GetCloudClientProxy(c, cd.Namespace, cd.Spec.CloudClientProxySecretRef)
+	if err != nil {
+		return nil, err
+	}
+	return gcpclient.NewClientFromSecretWithOptions(secret, gcpclient.Options{Proxy: proxy, Controller: hivev1.HibernationControllerName})
 }
 
 func instanceFilter(cd *hivev1.ClusterDeployment) string {
 	return fmt.Sprintf("name eq \"%s-.*\"", cd.Spec.ClusterMetadata.InfraID)
 }
 
-func gcpListComputeInstances(gcpClient gcpclient.Client, cd *hivev1.ClusterDeployment, statuses sets.String, logger log.FieldLogger) ([]*compute.Instance, error) {
+func gcpListComputeInstances(ctx context.Context, gcpClient gcpclient.Client, cd *hivev1.ClusterDeployment, statuses sets.String, logger log.FieldLogger) ([]*compute.Instance, error) {
 	var instances []*compute.Instance
 	logger.Debug("listing client instances")
-	err := gcpClient.ListComputeInstances(gcpclient.ListComputeInstancesOptions{
+	err := gcpClient.ListComputeInstances(ctx, gcpclient.ListComputeInstancesOptions{
 		Filter: instanceFilter(cd),
 		Fields: instanceFields,
 	}, func(list *compute.InstanceAggregatedList) error {
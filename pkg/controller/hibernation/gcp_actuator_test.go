@@ -1,6 +1,7 @@
 package hibernation
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -47,8 +48,8 @@ func TestGCPStopAndStartMachines(t *testing.T) {
 			testFunc:  "StopMachines",
 			instances: map[string]int{"TERMINATED": 5, "RUNNING": 2},
 			setupClient: func(t *testing.T, c *mockgcpclient.MockClient) {
-				c.EXPECT().StopInstance(gomock.Any()).Times(2).Do(
-					func(instance *compute.Instance) {
+				c.EXPECT().StopInstance(gomock.Any(), gomock.Any()).Times(2).Do(
+					func(_ context.Context, instance *compute.Instance) {
 						assert.True(t, instance.Status == "RUNNING")
 					},
 				)
@@ -59,8 +60,8 @@ func TestGCPStopAndStartMachines(t *testing.T) {
 			testFunc:  "StopMachines",
 			instances: map[string]int{"TERMINATED": 5, "STOPPING": 3, "STOPPED": 4, "STAGING": 7, "RUNNING": 3},
 			setupClient: func(t *testing.T, c *mockgcpclient.MockClient) {
-				c.EXPECT().StopInstance(gomock.Any()).Times(10).Do(
-					func(instance *compute.Instance) {
+				c.EXPECT().StopInstance(gomock.Any(), gomock.Any()).Times(10).Do(
+					func(_ context.Context, instance *compute.Instance) {
 						assert.True(t, instance.Status == "STAGING" || instance.Status == "RUNNING")
 					},
 				)
@@ -76,8 +77,8 @@ func TestGCPStopAndStartMachines(t *testing.T) {
 			testFunc:  "StartMachines",
 			instances: map[string]int{"STOPPED": 3, "TERMINATED": 2, "RUNNING": 4},
 			setupClient: func(t *testing.T, c *mockgcpclient.MockClient) {
-				c.EXPECT().StartInstance(gomock.Any()).Times(5).Do(
-					func(instance *compute.Instance) {
+				c.EXPECT().StartInstance(gomock.Any(), gomock.Any()).Times(5).Do(
+					func(_ context.Context, instance *compute.Instance) {
 						assert.True(t, instance.Status == "STOPPED" || instance.Status == "TERMINATED")
 					},
 				)
@@ -88,8 +89,8 @@ func TestGCPStopAndStartMachines(t *testing.T) {
 			testFunc:  "StartMachines",
 			instances: map[string]int{"STOPPED": 3, "STOPPING": 1, "TERMINATED": 7},
 			setupClient: func(t *testing.T, c *mockgcpclient.MockClient) {
-				c.EXPECT().StartInstance(gomock.Any()).Times(11).Do(
-					func(instance *compute.Instance) {
+				c.EXPECT().StartInstance(gomock.Any(), gomock.Any()).Times(11).Do(
+					func(_ context.Context, instance *compute.Instance) {
 						assert.True(t, instance.Status == "STOPPED" || instance.Status == "STOPPING" || instance.Status == "TERMINATED")
 					},
 				)
@@ -109,9 +110,9 @@ func TestGCPStopAndStartMachines(t *testing.T) {
 			var err error
 			switch test.testFunc {
 			case "StopMachines":
-				err = actuator.StopMachines(testClusterDeployment(), nil, log.New())
+				err = actuator.StopMachines(context.TODO(), testClusterDeployment(), nil, log.New())
 			case "StartMachines":
-				err = actuator.StartMachines(testClusterDeployment(), nil, log.New())
+				err = actuator.StartMachines(context.TODO(), testClusterDeployment(), nil, log.New())
 			default:
 				t.Fatal("Invalid function to test")
 			}
@@ -181,9 +182,9 @@ func TestGCPMachinesStoppedAndRunning(t *testing.T) {
 			var result bool
 			switch test.testFunc {
 			case "MachinesStopped":
-				result, err = actuator.MachinesStopped(testClusterDeployment(), nil, log.New())
+				result, err = actuator.MachinesStopped(context.TODO(), testClusterDeployment(), nil, log.New())
 			case "MachinesRunning":
-				result, err = actuator.MachinesRunning(testClusterDeployment(), nil, log.New())
+				result, err = actuator.MachinesRunning(context.TODO(), testClusterDeployment(), nil, log.New())
 			default:
 				t.Fatal("Invalid function to test")
 			}
@@ -211,8 +212,8 @@ func setupGCPClientInstances(gcpClient *mockgcpclient.MockClient, statuses map[s
 			})
 		}
 	}
-	gcpClient.EXPECT().ListComputeInstances(gomock.Any(), gomock.Any()).Times(1).Do(
-		func(opts gcpclient.ListComputeInstancesOptions, f func(*compute.InstanceAggregatedList) error) {
+	gcpClient.EXPECT().ListComputeInstances(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Do(
+		func(_ context.Context, opts gcpclient.ListComputeInstancesOptions, f func(*compute.InstanceAggregatedList) error) {
 			aggregatedList := &compute.InstanceAggregatedList{
 				Items: map[string]compute.InstancesScopedList{
 					"result": {
@@ -3,6 +3,8 @@ package hibernation
 //go:generate mockgen -source=./hibernation_actuator.go -destination=./mock/hibernation_actuator_generated.go -package=mock
 
 import (
+	"context"
+
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -16,13 +18,13 @@ type HibernationActuator interface {
 	// CanHandle returns true if the actuator can handle a particular ClusterDeployment
 	CanHandle(cd *hivev1.ClusterDeployment) bool
 	// StopMachines will start machines belonging to the given ClusterDeployment
-	StopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error
+	StopMachines(ctx context.Context, cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error
 	// StartMachines will select machines belonging to the given ClusterDeployment
-	StartMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error
+	StartMachines(ctx context.Context, cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error
 	// MachinesRunning will return true if the machines associated with the given
 	// ClusterDeployment are in a running state.
-	MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error)
+	MachinesRunning(ctx context.Context, cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error)
 	// MachinesStopped will return true if the machines associated with the given
 	// ClusterDeployment are in a stopped state.
-	MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error)
+	MachinesStopped(ctx context.Context, cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, error)
 }
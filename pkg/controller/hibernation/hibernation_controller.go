@@ -74,7 +74,7 @@ var (
 
 // Add creates a new Hibernation controller and adds it to the manager with default RBAC.
 func Add(mgr manager.Manager) error {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
 	if err != nil {
 		logger.WithError(err).Error("could not get controller configurations")
@@ -97,11 +97,14 @@ type hibernationReconciler struct {
 	csrUtil csrHelper
 
 	remoteClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+
+	// hibernationDefaults are the hibernation defaults of the HiveConfig, or nil if there are none.
+	hibernationDefaults *hivev1.HibernationDefaultsConfig
 }
 
 // NewReconciler returns a new Reconciler
 func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *hibernationReconciler {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	r := &hibernationReconciler{
 		Client:  controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
 		logger:  logger,
@@ -110,25 +113,30 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *hi
 	r.remoteClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
 		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
 	}
+	defaults, err := controllerutils.ReadHibernationDefaults()
+	if err != nil {
+		logger.WithError(err).Error("unable to read the hibernation defaults, ignoring them")
+	}
+	r.hibernationDefaults = defaults
 	return r
 }
 
 // AddToManager adds a new Controller to the controller manager
 func AddToManager(mgr manager.Manager, r *hibernationReconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
-	c, err := controller.New("hibernation-controller", mgr, controller.Options{
+	c, err := controllerutils.NewController("hibernation-controller", mgr, controller.Options{
 		Reconciler:              r,
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
 	if err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Log(controllerutils.LogLevel(err), "Error creating controller")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Log(controllerutils.LogLevel(err), "Error creating controller")
 		return err
 	}
 
 	// Watch for changes to ClusterDeployment
 	err = c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{})
 	if err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Log(controllerutils.LogLevel(err), "Error setting up a watch on ClusterDeployment")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Log(controllerutils.LogLevel(err), "Error setting up a watch on ClusterDeployment")
 		return err
 	}
 	return nil
@@ -156,6 +164,10 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 		return reconcile.Result{}, err
 	}
 
+	if controllerutils.IsClusterDeploymentPaused(cd, cdLog) {
+		return reconcile.Result{}, nil
+	}
+
 	// If cluster is already deleted, skip any processing
 	if !cd.DeletionTimestamp.IsZero() {
 		return reconcile.Result{}, nil
@@ -181,6 +193,21 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 		return r.setHibernatingCondition(cd, hivev1.HibernatingHibernationReason, "Skipping hibernation for fake cluster", corev1.ConditionFalse, cdLog)
 	}
 
+	// Keep the cluster running if its power state is not allowed by the power state policies of the HiveConfig.
+	powerStateAllowed, err := controllerutils.IsPowerStateAllowed(r.hibernationDefaults, cd, cd.Spec.PowerState)
+	if err != nil {
+		cdLog.WithError(err).Error("failed to evaluate the power state policies")
+		return reconcile.Result{}, err
+	}
+	if !powerStateAllowed {
+		return reconcile.Result{}, r.enforcePowerStatePolicies(cd, cdLog)
+	}
+
+	// Stopping the workers of a cluster does not depend on an actuator for the platform of the cluster.
+	if handled, result, err := r.reconcileWorkersPowerState(cd, cdLog); handled {
+		return result, err
+	}
+
 	// set hibernating condition to false for unsupported clouds
 	if supported, msg := r.hibernationSupported(cd); !supported {
 		return r.setHibernatingCondition(cd, hivev1.UnsupportedHibernationReason, msg, corev1.ConditionFalse, cdLog)
@@ -215,8 +242,15 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 		}
 	}
 
+	// HibernateAfter is ignored for clusters that the power state policies do not allow to hibernate.
+	hibernationAllowed, err := controllerutils.IsPowerStateAllowed(r.hibernationDefaults, cd, hivev1.HibernatingClusterPowerState)
+	if err != nil {
+		cdLog.WithError(err).Error("failed to evaluate the power state policies")
+		return reconcile.Result{}, err
+	}
+
 	// Check if HibernateAfter is set, and if the cluster has been in running state for longer than this duration, put it to sleep.
-	if cd.Spec.HibernateAfter != nil && cd.Spec.PowerState != hivev1.HibernatingClusterPowerState {
+	if cd.Spec.HibernateAfter != nil && cd.Spec.PowerState != hivev1.HibernatingClusterPowerState && hibernationAllowed {
 		hibernateAfterDur := cd.Spec.HibernateAfter.Duration
 		runningSince := cd.Status.InstalledTimestamp.Time
 		hibLog := cdLog.WithFields(log.Fields{
@@ -242,6 +276,10 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 			if time.Now().After(expiry) {
 				hibLog.WithField("expiry", expiry).Debug("cluster has been running longer than hibernate-after duration, moving to hibernating powerState")
 				cd.Spec.PowerState = hivev1.HibernatingClusterPowerState
+				if cd.Annotations == nil {
+					cd.Annotations = map[string]string{}
+				}
+				cd.Annotations[constants.PowerStateInitiatorAnnotation] = constants.PowerStateInitiatorHibernateAfter
 				err := r.Update(context.TODO(), cd)
 				if err != nil {
 					hibLog.WithError(err).Log(controllerutils.LogLevel(err), "error hibernating cluster")
@@ -272,9 +310,16 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 		}
 		switch hibernatingCondition.Reason {
 		case hivev1.StoppingHibernationReason, hivev1.HibernatingHibernationReason, hivev1.FailedToStartHibernationReason:
-			return r.startMachines(cd, cdLog)
+			return r.startMachines(ctx, cd, cdLog)
 		case hivev1.ResumingHibernationReason:
-			return r.checkClusterResumed(cd, cdLog)
+			if timedOut, msg := r.resumeTimedOut(cd); timedOut {
+				cdLog.Warn(msg)
+				return r.setHibernatingCondition(cd, hivev1.ResumeTimedOutHibernationReason, msg, corev1.ConditionTrue, cdLog)
+			}
+			return r.checkClusterResumed(ctx, cd, cdLog)
+		case hivev1.ResumeTimedOutHibernationReason:
+			// Keep resuming the cluster after the timeout, so that it is running once it recovers.
+			return r.checkClusterResumed(ctx, cd, cdLog)
 		}
 		return reconcile.Result{}, nil
 	}
@@ -282,23 +327,24 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 	if (hibernatingCondition.Status == corev1.ConditionUnknown || hibernatingCondition.Status == corev1.ConditionFalse &&
 		hibernatingCondition.Reason != hivev1.UnsupportedHibernationReason) ||
 		hibernatingCondition.Reason == hivev1.ResumingHibernationReason ||
+		hibernatingCondition.Reason == hivev1.ResumeTimedOutHibernationReason ||
 		(cd.Spec.PowerState == hivev1.HibernatingClusterPowerState && hibernatingCondition.Reason == hivev1.FailedToStartHibernationReason) {
-		return r.stopMachines(cd, cdLog)
+		return r.stopMachines(ctx, cd, cdLog)
 	}
 	if hibernatingCondition.Reason == hivev1.StoppingHibernationReason {
-		return r.checkClusterStopped(cd, false, cdLog)
+		return r.checkClusterStopped(ctx, cd, false, cdLog)
 	}
 	return reconcile.Result{}, nil
 }
 
-func (r *hibernationReconciler) startMachines(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
+func (r *hibernationReconciler) startMachines(ctx context.Context, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
 	actuator := r.getActuator(cd)
 	if actuator == nil {
 		logger.Warning("No compatible actuator found to start cluster machines")
 		return reconcile.Result{}, nil
 	}
 	logger.Info("Resuming cluster")
-	if err := actuator.StartMachines(cd, r.Client, logger); err != nil {
+	if err := actuator.StartMachines(ctx, cd, r.Client, logger); err != nil {
 		msg := fmt.Sprintf("Failed to start machines: %v", err)
 		result, condErr := r.setHibernatingCondition(cd, hivev1.FailedToStartHibernationReason, msg, corev1.ConditionTrue, logger)
 		if condErr != nil {
@@ -310,27 +356,27 @@ func (r *hibernationReconciler) startMachines(cd *hivev1.ClusterDeployment, logg
 	return r.setHibernatingCondition(cd, hivev1.ResumingHibernationReason, "Starting cluster machines", corev1.ConditionTrue, logger)
 }
 
-func (r *hibernationReconciler) stopMachines(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
+func (r *hibernationReconciler) stopMachines(ctx context.Context, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
 	actuator := r.getActuator(cd)
 	if actuator == nil {
 		logger.Warning("No compatible actuator found to start cluster machines")
 		return reconcile.Result{}, nil
 	}
 	logger.Info("Stopping cluster")
-	if err := actuator.StopMachines(cd, r.Client, logger); err != nil {
+	if err := actuator.StopMachines(ctx, cd, r.Client, logger); err != nil {
 		msg := fmt.Sprintf("Failed to stop machines: %v", err)
 		return r.setHibernatingCondition(cd, hivev1.FailedToStopHibernationReason, msg, corev1.ConditionFalse, logger)
 	}
 	return r.setHibernatingCondition(cd, hivev1.StoppingHibernationReason, "Stopping cluster machines", corev1.ConditionTrue, logger)
 }
 
-func (r *hibernationReconciler) checkClusterStopped(cd *hivev1.ClusterDeployment, expectRunning bool, logger log.FieldLogger) (reconcile.Result, error) {
+func (r *hibernationReconciler) checkClusterStopped(ctx context.Context, cd *hivev1.ClusterDeployment, expectRunning bool, logger log.FieldLogger) (reconcile.Result, error) {
 	actuator := r.getActuator(cd)
 	if actuator == nil {
 		logger.Warning("No compatible actuator found to check machine status")
 		return reconcile.Result{}, nil
 	}
-	stopped, err := actuator.MachinesStopped(cd, r.Client, logger)
+	stopped, err := actuator.MachinesStopped(ctx, cd, r.Client, logger)
 	if err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to check whether machines are stopped.")
 		return reconcile.Result{}, err
@@ -342,20 +388,20 @@ func (r *hibernationReconciler) checkClusterStopped(cd *hivev1.ClusterDeployment
 	return r.setHibernatingCondition(cd, hivev1.HibernatingHibernationReason, "Cluster is stopped", corev1.ConditionTrue, logger)
 }
 
-func (r *hibernationReconciler) checkClusterResumed(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
+func (r *hibernationReconciler) checkClusterResumed(ctx context.Context, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
 	actuator := r.getActuator(cd)
 	if actuator == nil {
 		logger.Warning("No compatible actuator found to check machine status")
 		return reconcile.Result{}, nil
 	}
-	running, err := actuator.MachinesRunning(cd, r.Client, logger)
+	running, err := actuator.MachinesRunning(ctx, cd, r.Client, logger)
 	if err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to check whether machines are running.")
 		return reconcile.Result{}, err
 	}
 	if !running {
 		// Ensure all machines have been started. Should have been handled already but we've seen VMs left in stopped state.
-		if err := actuator.StartMachines(cd, r.Client, logger); err != nil {
+		if err := actuator.StartMachines(ctx, cd, r.Client, logger); err != nil {
 			logger.WithError(err).Error("error starting machines")
 			return reconcile.Result{}, err
 		}
@@ -379,6 +425,43 @@ func (r *hibernationReconciler) checkClusterResumed(cd *hivev1.ClusterDeployment
 	return r.setHibernatingCondition(cd, hivev1.RunningHibernationReason, "All machines are started and nodes are ready", corev1.ConditionFalse, logger)
 }
 
+// enforcePowerStatePolicies sets the power state of a ClusterDeployment whose power state is not allowed by the power
+// state policies of the HiveConfig to Running.
+func (r *hibernationReconciler) enforcePowerStatePolicies(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	logger.WithField("powerState", cd.Spec.PowerState).Warn("power state is not allowed by the power state policies, moving to running powerState")
+	cd.Spec.PowerState = hivev1.RunningClusterPowerState
+	if cd.Annotations == nil {
+		cd.Annotations = map[string]string{}
+	}
+	cd.Annotations[constants.PowerStateInitiatorAnnotation] = constants.PowerStateInitiatorPowerStatePolicy
+	if err := r.Update(context.TODO(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error resuming cluster")
+		return err
+	}
+	return nil
+}
+
+// resumeTimedOut returns whether the resume of the cluster has taken longer than the resume timeout of the HiveConfig,
+// along with a message for the Hibernating condition. The resume started with the power state transition in progress.
+func (r *hibernationReconciler) resumeTimedOut(cd *hivev1.ClusterDeployment) (bool, string) {
+	if r.hibernationDefaults == nil || r.hibernationDefaults.ResumeTimeout == nil || r.hibernationDefaults.ResumeTimeout.Duration <= 0 {
+		return false, ""
+	}
+	n := len(cd.Status.PowerStateHistory)
+	if n == 0 {
+		return false, ""
+	}
+	transition := cd.Status.PowerStateHistory[n-1]
+	if transition.CompletionTime != nil || transition.RequestedState != hivev1.RunningClusterPowerState {
+		return false, ""
+	}
+	timeout := r.hibernationDefaults.ResumeTimeout.Duration
+	if time.Since(transition.StartTime.Time) < timeout {
+		return false, ""
+	}
+	return true, fmt.Sprintf("Cluster has not resumed within %v", timeout)
+}
+
 func (r *hibernationReconciler) setHibernatingCondition(cd *hivev1.ClusterDeployment, reason, message string, status corev1.ConditionStatus, logger log.FieldLogger) (result reconcile.Result, returnErr error) {
 	changed := false
 	cd.Status.Conditions, changed = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
@@ -402,11 +485,18 @@ func (r *hibernationReconciler) setHibernatingCondition(cd *hivev1.ClusterDeploy
 	}
 
 	if changed {
+		transitionStarted := recordPowerStateTransition(cd, reason, message)
 		if err := r.Status().Update(context.TODO(), cd); err != nil {
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to update hibernating condition")
 			return reconcile.Result{}, errors.Wrap(err, "failed to update hibernating condition")
 		}
 		logger.WithField("reason", reason).Info("Hibernating condition updated on cluster deployment.")
+		if transitionStarted {
+			if err := r.clearPowerStateInitiator(cd); err != nil {
+				logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to clear power state initiator")
+				return reconcile.Result{}, errors.Wrap(err, "failed to clear power state initiator")
+			}
+		}
 	}
 	return reconcile.Result{}, nil
 }
@@ -80,6 +80,16 @@ func TestReconcile(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "cluster paused",
+			cd:   cdBuilder.Options(o.shouldHibernate, testcd.Paused()).Build(),
+			cs:   csBuilder.Build(),
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				if getHibernatingCondition(cd) != nil {
+					t.Errorf("not expecting hibernating condition")
+				}
+			},
+		},
 		{
 			name: "hibernation condition initialized",
 			cd:   cdBuilder.Options(o.notInstalled, o.shouldHibernate).Build(),
@@ -130,7 +140,7 @@ func TestReconcile(t *testing.T) {
 			cd:   cdBuilder.Options(o.shouldHibernate, testcd.InstalledTimestamp(time.Now().Add(-15*time.Minute))).Build(),
 			cs:   csBuilder.Options(testcs.WithNoFirstSuccessTime()).Build(),
 			setupActuator: func(actuator *mock.MockHibernationActuator) {
-				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
 			},
 			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
 				cond := getHibernatingCondition(cd)
@@ -144,7 +154,7 @@ func TestReconcile(t *testing.T) {
 			cd:   cdBuilder.Options(o.shouldHibernate).Build(),
 			cs:   csBuilder.Build(),
 			setupActuator: func(actuator *mock.MockHibernationActuator) {
-				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
 			},
 			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
 				cond := getHibernatingCondition(cd)
@@ -158,7 +168,7 @@ func TestReconcile(t *testing.T) {
 			cd:   cdBuilder.Options(o.shouldHibernate).Build(),
 			cs:   csBuilder.Build(),
 			setupActuator: func(actuator *mock.MockHibernationActuator) {
-				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(fmt.Errorf("error"))
+				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(fmt.Errorf("error"))
 			},
 			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
 				cond := getHibernatingCondition(cd)
@@ -172,7 +182,7 @@ func TestReconcile(t *testing.T) {
 			cd:   cdBuilder.Options(o.shouldHibernate, o.stopping).Build(),
 			cs:   csBuilder.Build(),
 			setupActuator: func(actuator *mock.MockHibernationActuator) {
-				actuator.EXPECT().MachinesStopped(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+				actuator.EXPECT().MachinesStopped(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
 			},
 			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
 				cond := getHibernatingCondition(cd)
@@ -186,7 +196,7 @@ func TestReconcile(t *testing.T) {
 			cd:   cdBuilder.Options(o.shouldHibernate, o.stopping).Build(),
 			cs:   csBuilder.Build(),
 			setupActuator: func(actuator *mock.MockHibernationActuator) {
-				actuator.EXPECT().MachinesStopped(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+				actuator.EXPECT().MachinesStopped(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
 			},
 			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
 				cond := getHibernatingCondition(cd)
@@ -207,7 +217,7 @@ func TestReconcile(t *testing.T) {
 			cs: csBuilder.Build(),
 			setupActuator: func(actuator *mock.MockHibernationActuator) {
 				// Ensure we try to stop machines in this state (bugfix)
-				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
 			},
 			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
 				cond := getHibernatingCondition(cd)
@@ -221,7 +231,7 @@ func TestReconcile(t *testing.T) {
 			cd:   cdBuilder.Options(o.hibernating).Build(),
 			cs:   csBuilder.Build(),
 			setupActuator: func(actuator *mock.MockHibernationActuator) {
-				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
 			},
 			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
 				cond := getHibernatingCondition(cd)
@@ -235,7 +245,7 @@ func TestReconcile(t *testing.T) {
 			cd:   cdBuilder.Options(o.hibernating).Build(),
 			cs:   csBuilder.Build(),
 			setupActuator: func(actuator *mock.MockHibernationActuator) {
-				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(fmt.Errorf("error"))
+				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(fmt.Errorf("error"))
 			},
 			expectError: true,
 			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
@@ -257,7 +267,7 @@ func TestReconcile(t *testing.T) {
 			cs: csBuilder.Build(),
 			setupActuator: func(actuator *mock.MockHibernationActuator) {
 				// Call will succeed which should clear the FailedToStart reason:
-				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
 			},
 			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
 				cond := getHibernatingCondition(cd)
@@ -271,8 +281,8 @@ func TestReconcile(t *testing.T) {
 			cd:   cdBuilder.Options(o.resuming).Build(),
 			cs:   csBuilder.Build(),
 			setupActuator: func(actuator *mock.MockHibernationActuator) {
-				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
-				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
 			},
 			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
 				cond := getHibernatingCondition(cd)
@@ -286,7 +296,7 @@ func TestReconcile(t *testing.T) {
 			cd:   cdBuilder.Options(o.resuming).Build(),
 			cs:   csBuilder.Build(),
 			setupActuator: func(actuator *mock.MockHibernationActuator) {
-				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
 			},
 			setupRemote: func(builder *remoteclientmock.MockBuilder) {
 				c := fake.NewFakeClientWithScheme(scheme, readyNodes()...)
@@ -304,7 +314,7 @@ func TestReconcile(t *testing.T) {
 			cd:   cdBuilder.Options(o.resuming).Build(),
 			cs:   csBuilder.Build(),
 			setupActuator: func(actuator *mock.MockHibernationActuator) {
-				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
 			},
 			setupRemote: func(builder *remoteclientmock.MockBuilder) {
 				fakeClient := fake.NewFakeClientWithScheme(scheme, unreadyNode()...)
@@ -324,7 +334,7 @@ func TestReconcile(t *testing.T) {
 			cd:   cdBuilder.Options(o.resuming).Build(),
 			cs:   csBuilder.Build(),
 			setupActuator: func(actuator *mock.MockHibernationActuator) {
-				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
 			},
 			setupRemote: func(builder *remoteclientmock.MockBuilder) {
 				fakeClient := fake.NewFakeClientWithScheme(scheme, unreadyNode()...)
@@ -511,8 +521,8 @@ func TestHibernateAfter(t *testing.T) {
 		{
 			name: "cluster waking from hibernate",
 			setupActuator: func(actuator *mock.MockHibernationActuator) {
-				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
-				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
 			},
 			cd: cdBuilder.Build(
 				testcd.WithHibernateAfter(8*time.Hour),
@@ -618,6 +628,169 @@ func TestHibernateAfter(t *testing.T) {
 	}
 }
 
+func TestHibernationDefaults(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+	hiveintv1alpha1.AddToScheme(scheme)
+
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).Options(
+		testcd.Installed(),
+		testcd.WithClusterVersion("4.4.9"),
+	)
+	o := clusterDeploymentOptions{}
+	csBuilder := testcs.FullBuilder(namespace, cdName, scheme).Options(
+		testcs.WithFirstSuccessTime(time.Now().Add(-10 * time.Hour)),
+	)
+	productionPolicy := &hivev1.HibernationDefaultsConfig{
+		PowerStatePolicies: []hivev1.PowerStatePolicy{{
+			Selector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "production"}},
+		}},
+	}
+	resumeTimeout := &hivev1.HibernationDefaultsConfig{
+		ResumeTimeout: &metav1.Duration{Duration: time.Hour},
+	}
+	resumeStarted := func(ago time.Duration) testcd.Option {
+		return func(cd *hivev1.ClusterDeployment) {
+			cd.Status.PowerStateHistory = []hivev1.PowerStateTransition{{
+				RequestedState: hivev1.RunningClusterPowerState,
+				State:          hivev1.ResumingHibernationReason,
+				StartTime:      metav1.NewTime(time.Now().Add(-ago)),
+			}}
+		}
+	}
+
+	tests := []struct {
+		name          string
+		cd            *hivev1.ClusterDeployment
+		defaults      *hivev1.HibernationDefaultsConfig
+		setupActuator func(actuator *mock.MockHibernationActuator)
+		setupRemote   func(builder *remoteclientmock.MockBuilder)
+		validate      func(t *testing.T, cd *hivev1.ClusterDeployment)
+	}{
+		{
+			name:     "hibernation not allowed by policy",
+			cd:       cdBuilder.Options(o.shouldHibernate, testcd.WithLabel("env", "production")).Build(),
+			defaults: productionPolicy,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				assert.Equal(t, hivev1.RunningClusterPowerState, cd.Spec.PowerState, "unexpected PowerState")
+				assert.Equal(t, constants.PowerStateInitiatorPowerStatePolicy, cd.Annotations[constants.PowerStateInitiatorAnnotation], "unexpected power state initiator")
+			},
+		},
+		{
+			name:     "hibernation allowed by policy",
+			cd:       cdBuilder.Options(o.shouldHibernate, testcd.WithLabel("env", "dev")).Build(),
+			defaults: productionPolicy,
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				assert.Equal(t, hivev1.HibernatingClusterPowerState, cd.Spec.PowerState, "unexpected PowerState")
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, hivev1.StoppingHibernationReason, cond.Reason)
+			},
+		},
+		{
+			name: "hibernate after ignored when hibernation not allowed by policy",
+			cd: cdBuilder.Build(
+				testcd.WithLabel("env", "production"),
+				testcd.WithHibernateAfter(time.Hour),
+				testcd.InstalledTimestamp(time.Now().Add(-10*time.Hour))),
+			defaults: productionPolicy,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				assert.Equal(t, hivev1.ClusterPowerState(""), cd.Spec.PowerState, "unexpected PowerState")
+			},
+		},
+		{
+			name:     "resume timed out",
+			cd:       cdBuilder.Options(o.resuming, resumeStarted(2*time.Hour)).Build(),
+			defaults: resumeTimeout,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionTrue, cond.Status)
+				assert.Equal(t, hivev1.ResumeTimedOutHibernationReason, cond.Reason)
+				require.Len(t, cd.Status.PowerStateHistory, 1, "unexpected power state history")
+				assert.Equal(t, cond.Message, cd.Status.PowerStateHistory[0].FailureReason, "unexpected failure reason")
+			},
+		},
+		{
+			name:     "resume within timeout",
+			cd:       cdBuilder.Options(o.resuming, resumeStarted(10*time.Minute)).Build(),
+			defaults: resumeTimeout,
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, hivev1.ResumingHibernationReason, cond.Reason)
+			},
+		},
+		{
+			name: "resume timed out, cluster recovered",
+			cd: cdBuilder.Options(resumeStarted(2*time.Hour), func(cd *hivev1.ClusterDeployment) {
+				cd.Status.Conditions = append(cd.Status.Conditions, hibernatingCondition(corev1.ConditionTrue, hivev1.ResumeTimedOutHibernationReason, time.Hour))
+			}).Build(),
+			defaults: resumeTimeout,
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesRunning(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+			},
+			setupRemote: func(builder *remoteclientmock.MockBuilder) {
+				c := fake.NewFakeClientWithScheme(scheme, readyNodes()...)
+				builder.EXPECT().Build().Times(1).Return(c, nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := getHibernatingCondition(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionFalse, cond.Status)
+				assert.Equal(t, hivev1.RunningHibernationReason, cond.Reason)
+				require.Len(t, cd.Status.PowerStateHistory, 1, "unexpected power state history")
+				assert.NotNil(t, cd.Status.PowerStateHistory[0].CompletionTime, "expected the resume to be completed")
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockActuator := mock.NewMockHibernationActuator(ctrl)
+			mockActuator.EXPECT().CanHandle(gomock.Any()).AnyTimes().Return(true)
+			if test.setupActuator != nil {
+				test.setupActuator(mockActuator)
+			}
+			mockBuilder := remoteclientmock.NewMockBuilder(ctrl)
+			if test.setupRemote != nil {
+				test.setupRemote(mockBuilder)
+			}
+			actuators = []HibernationActuator{mockActuator}
+			c := fake.NewFakeClientWithScheme(scheme, test.cd, csBuilder.Build())
+
+			reconciler := hibernationReconciler{
+				Client: c,
+				logger: log.WithField("controller", "hibernation"),
+				remoteClientBuilder: func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+					return mockBuilder
+				},
+				csrUtil:             mock.NewMockcsrHelper(ctrl),
+				hibernationDefaults: test.defaults,
+			}
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: cdName},
+			})
+			assert.NoError(t, err, "expected no error from reconcile")
+
+			cd := &hivev1.ClusterDeployment{}
+			err = c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName}, cd)
+			require.NoError(t, err, "error looking up ClusterDeployment")
+			test.validate(t, cd)
+		})
+	}
+}
+
 func hibernatingCondition(status corev1.ConditionStatus, reason string, lastTransitionAgo time.Duration) hivev1.ClusterDeploymentCondition {
 	return hivev1.ClusterDeploymentCondition{
 		Type:               hivev1.ClusterHibernatingCondition,
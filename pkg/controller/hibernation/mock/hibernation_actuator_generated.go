@@ -5,6 +5,7 @@
 package mock
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	v1 "github.com/openshift/hive/apis/hive/v1"
 	logrus "github.com/sirupsen/logrus"
@@ -50,59 +51,59 @@ func (mr *MockHibernationActuatorMockRecorder) CanHandle(cd interface{}) *gomock
 }
 
 // StopMachines mocks base method
-func (m *MockHibernationActuator) StopMachines(cd *v1.ClusterDeployment, hiveClient client.Client, logger logrus.FieldLogger) error {
+func (m *MockHibernationActuator) StopMachines(ctx context.Context, cd *v1.ClusterDeployment, hiveClient client.Client, logger logrus.FieldLogger) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StopMachines", cd, hiveClient, logger)
+	ret := m.ctrl.Call(m, "StopMachines", ctx, cd, hiveClient, logger)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // StopMachines indicates an expected call of StopMachines
-func (mr *MockHibernationActuatorMockRecorder) StopMachines(cd, hiveClient, logger interface{}) *gomock.Call {
+func (mr *MockHibernationActuatorMockRecorder) StopMachines(ctx, cd, hiveClient, logger interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopMachines", reflect.TypeOf((*MockHibernationActuator)(nil).StopMachines), cd, hiveClient, logger)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopMachines", reflect.TypeOf((*MockHibernationActuator)(nil).StopMachines), ctx, cd, hiveClient, logger)
 }
 
 // StartMachines mocks base method
-func (m *MockHibernationActuator) StartMachines(cd *v1.ClusterDeployment, hiveClient client.Client, logger logrus.FieldLogger) error {
+func (m *MockHibernationActuator) StartMachines(ctx context.Context, cd *v1.ClusterDeployment, hiveClient client.Client, logger logrus.FieldLogger) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StartMachines", cd, hiveClient, logger)
+	ret := m.ctrl.Call(m, "StartMachines", ctx, cd, hiveClient, logger)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // StartMachines indicates an expected call of StartMachines
-func (mr *MockHibernationActuatorMockRecorder) StartMachines(cd, hiveClient, logger interface{}) *gomock.Call {
+func (mr *MockHibernationActuatorMockRecorder) StartMachines(ctx, cd, hiveClient, logger interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartMachines", reflect.TypeOf((*MockHibernationActuator)(nil).StartMachines), cd, hiveClient, logger)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartMachines", reflect.TypeOf((*MockHibernationActuator)(nil).StartMachines), ctx, cd, hiveClient, logger)
 }
 
 // MachinesRunning mocks base method
-func (m *MockHibernationActuator) MachinesRunning(cd *v1.ClusterDeployment, hiveClient client.Client, logger logrus.FieldLogger) (bool, error) {
+func (m *MockHibernationActuator) MachinesRunning(ctx context.Context, cd *v1.ClusterDeployment, hiveClient client.Client, logger logrus.FieldLogger) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MachinesRunning", cd, hiveClient, logger)
+	ret := m.ctrl.Call(m, "MachinesRunning", ctx, cd, hiveClient, logger)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // MachinesRunning indicates an expected call of MachinesRunning
-func (mr *MockHibernationActuatorMockRecorder) MachinesRunning(cd, hiveClient, logger interface{}) *gomock.Call {
+func (mr *MockHibernationActuatorMockRecorder) MachinesRunning(ctx, cd, hiveClient, logger interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MachinesRunning", reflect.TypeOf((*MockHibernationActuator)(nil).MachinesRunning), cd, hiveClient, logger)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MachinesRunning", reflect.TypeOf((*MockHibernationActuator)(nil).MachinesRunning), ctx, cd, hiveClient, logger)
 }
 
 // MachinesStopped mocks base method
-func (m *MockHibernationActuator) MachinesStopped(cd *v1.ClusterDeployment, hiveClient client.Client, logger logrus.FieldLogger) (bool, error) {
+func (m *MockHibernationActuator) MachinesStopped(ctx context.Context, cd *v1.ClusterDeployment, hiveClient client.Client, logger logrus.FieldLogger) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MachinesStopped", cd, hiveClient, logger)
+	ret := m.ctrl.Call(m, "MachinesStopped", ctx, cd, hiveClient, logger)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // MachinesStopped indicates an expected call of MachinesStopped
-func (mr *MockHibernationActuatorMockRecorder) MachinesStopped(cd, hiveClient, logger interface{}) *gomock.Call {
+func (mr *MockHibernationActuatorMockRecorder) MachinesStopped(ctx, cd, hiveClient, logger interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MachinesStopped", reflect.TypeOf((*MockHibernationActuator)(nil).MachinesStopped), cd, hiveClient, logger)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MachinesStopped", reflect.TypeOf((*MockHibernationActuator)(nil).MachinesStopped), ctx, cd, hiveClient, logger)
 }
@@ -0,0 +1,107 @@
+package hibernation
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// maxPowerStateHistory is the number of power state transitions kept in the status of a ClusterDeployment.
+const maxPowerStateHistory = 10
+
+var (
+	// powerStateTransitionStartedReasons are the reasons of the Hibernating condition set when a cluster starts
+	// moving to a new power state.
+	powerStateTransitionStartedReasons = sets.NewString(
+		hivev1.StoppingHibernationReason,
+		hivev1.ResumingHibernationReason,
+		hivev1.StoppingWorkersHibernationReason,
+		hivev1.ResumingWorkersHibernationReason,
+	)
+
+	// powerStateTransitionCompletedReasons are the reasons of the Hibernating condition set when a cluster has
+	// reached a power state.
+	powerStateTransitionCompletedReasons = sets.NewString(
+		hivev1.HibernatingHibernationReason,
+		hivev1.RunningHibernationReason,
+		hivev1.WorkersStoppedHibernationReason,
+	)
+
+	// powerStateTransitionFailedReasons are the reasons of the Hibernating condition set when a cluster failed to
+	// move to a new power state.
+	powerStateTransitionFailedReasons = sets.NewString(
+		hivev1.FailedToStopHibernationReason,
+		hivev1.FailedToStartHibernationReason,
+		hivev1.ResumeTimedOutHibernationReason,
+	)
+)
+
+// recordPowerStateTransition updates the power state history of the ClusterDeployment for the new reason of its
+// Hibernating condition. A transition is added to the history when the cluster starts moving to, or fails to move
+// to, the requested power state while no transition is in progress, and the transition in progress is updated
+// otherwise. It returns true if a new transition was added.
+func recordPowerStateTransition(cd *hivev1.ClusterDeployment, reason, message string) bool {
+	started := powerStateTransitionStartedReasons.Has(reason)
+	completed := powerStateTransitionCompletedReasons.Has(reason)
+	failed := powerStateTransitionFailedReasons.Has(reason)
+	if !started && !completed && !failed {
+		return false
+	}
+
+	requestedState := cd.Spec.PowerState
+	if requestedState == "" {
+		requestedState = hivev1.RunningClusterPowerState
+	}
+	var current *hivev1.PowerStateTransition
+	if n := len(cd.Status.PowerStateHistory); n > 0 {
+		last := &cd.Status.PowerStateHistory[n-1]
+		if last.CompletionTime == nil && last.RequestedState == requestedState {
+			current = last
+		}
+	}
+
+	now := metav1.Now()
+	added := false
+	if current == nil {
+		if completed {
+			// The cluster reached a power state without a recorded transition, as when a cluster whose hibernation
+			// was unsupported becomes supported.
+			return false
+		}
+		cd.Status.PowerStateHistory = append(cd.Status.PowerStateHistory, hivev1.PowerStateTransition{
+			RequestedState: requestedState,
+			Initiator:      cd.Annotations[constants.PowerStateInitiatorAnnotation],
+			StartTime:      now,
+		})
+		if n := len(cd.Status.PowerStateHistory); n > maxPowerStateHistory {
+			cd.Status.PowerStateHistory = cd.Status.PowerStateHistory[n-maxPowerStateHistory:]
+		}
+		current = &cd.Status.PowerStateHistory[len(cd.Status.PowerStateHistory)-1]
+		added = true
+	}
+
+	current.State = reason
+	switch {
+	case completed:
+		current.CompletionTime = &now
+	case failed:
+		current.FailureReason = message
+	}
+	return added
+}
+
+// clearPowerStateInitiator removes the power state initiator annotation from the ClusterDeployment once it has been
+// recorded in the power state history, so that it is not recorded again for a later transition.
+func (r *hibernationReconciler) clearPowerStateInitiator(cd *hivev1.ClusterDeployment) error {
+	if _, ok := cd.Annotations[constants.PowerStateInitiatorAnnotation]; !ok {
+		return nil
+	}
+	patched := cd.DeepCopy()
+	delete(patched.Annotations, constants.PowerStateInitiatorAnnotation)
+	return r.Patch(context.TODO(), patched, client.MergeFrom(cd))
+}
@@ -0,0 +1,171 @@
+package hibernation
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestRecordPowerStateTransition(t *testing.T) {
+	completed := metav1.Now()
+	inProgress := func(requestedState hivev1.ClusterPowerState, state string) hivev1.PowerStateTransition {
+		return hivev1.PowerStateTransition{RequestedState: requestedState, State: state, StartTime: metav1.Now()}
+	}
+	done := func(requestedState hivev1.ClusterPowerState, state string) hivev1.PowerStateTransition {
+		t := inProgress(requestedState, state)
+		t.CompletionTime = &completed
+		return t
+	}
+
+	tests := []struct {
+		name              string
+		powerState        hivev1.ClusterPowerState
+		initiator         string
+		history           []hivev1.PowerStateTransition
+		reason            string
+		message           string
+		expectAdded       bool
+		expectedLen       int
+		expectedState     string
+		expectedInitiator string
+		expectCompleted   bool
+		expectedFailure   string
+	}{
+		{
+			name:              "transition started",
+			powerState:        hivev1.HibernatingClusterPowerState,
+			initiator:         constants.PowerStateInitiatorHibernateAfter,
+			history:           []hivev1.PowerStateTransition{done(hivev1.RunningClusterPowerState, hivev1.RunningHibernationReason)},
+			reason:            hivev1.StoppingHibernationReason,
+			expectAdded:       true,
+			expectedLen:       2,
+			expectedState:     hivev1.StoppingHibernationReason,
+			expectedInitiator: constants.PowerStateInitiatorHibernateAfter,
+		},
+		{
+			name:            "transition completed",
+			powerState:      hivev1.HibernatingClusterPowerState,
+			history:         []hivev1.PowerStateTransition{inProgress(hivev1.HibernatingClusterPowerState, hivev1.StoppingHibernationReason)},
+			reason:          hivev1.HibernatingHibernationReason,
+			expectedLen:     1,
+			expectedState:   hivev1.HibernatingHibernationReason,
+			expectCompleted: true,
+		},
+		{
+			name:            "default power state",
+			history:         []hivev1.PowerStateTransition{inProgress(hivev1.RunningClusterPowerState, hivev1.ResumingHibernationReason)},
+			reason:          hivev1.RunningHibernationReason,
+			expectedLen:     1,
+			expectedState:   hivev1.RunningHibernationReason,
+			expectCompleted: true,
+		},
+		{
+			name:            "transition failed",
+			powerState:      hivev1.RunningClusterPowerState,
+			history:         []hivev1.PowerStateTransition{inProgress(hivev1.RunningClusterPowerState, hivev1.ResumingHibernationReason)},
+			reason:          hivev1.FailedToStartHibernationReason,
+			message:         "Failed to start machines: boom",
+			expectedLen:     1,
+			expectedState:   hivev1.FailedToStartHibernationReason,
+			expectedFailure: "Failed to start machines: boom",
+		},
+		{
+			name:            "failed before starting",
+			powerState:      hivev1.HibernatingClusterPowerState,
+			reason:          hivev1.FailedToStopHibernationReason,
+			message:         "Failed to stop machines: boom",
+			expectAdded:     true,
+			expectedLen:     1,
+			expectedState:   hivev1.FailedToStopHibernationReason,
+			expectedFailure: "Failed to stop machines: boom",
+		},
+		{
+			name:        "completed without transition",
+			powerState:  hivev1.RunningClusterPowerState,
+			reason:      hivev1.RunningHibernationReason,
+			expectedLen: 0,
+		},
+		{
+			name:        "not a power state reason",
+			powerState:  hivev1.HibernatingClusterPowerState,
+			reason:      hivev1.UnsupportedHibernationReason,
+			expectedLen: 0,
+		},
+		{
+			name:       "history is bounded",
+			powerState: hivev1.WorkersStoppedClusterPowerState,
+			history: func() []hivev1.PowerStateTransition {
+				history := make([]hivev1.PowerStateTransition, maxPowerStateHistory)
+				for i := range history {
+					history[i] = done(hivev1.RunningClusterPowerState, hivev1.RunningHibernationReason)
+				}
+				return history
+			}(),
+			reason:        hivev1.StoppingWorkersHibernationReason,
+			expectAdded:   true,
+			expectedLen:   maxPowerStateHistory,
+			expectedState: hivev1.StoppingWorkersHibernationReason,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cd := &hivev1.ClusterDeployment{}
+			cd.Spec.PowerState = test.powerState
+			cd.Status.PowerStateHistory = test.history
+			if test.initiator != "" {
+				cd.Annotations = map[string]string{constants.PowerStateInitiatorAnnotation: test.initiator}
+			}
+
+			added := recordPowerStateTransition(cd, test.reason, test.message)
+			assert.Equal(t, test.expectAdded, added, "unexpected transition added")
+			require.Len(t, cd.Status.PowerStateHistory, test.expectedLen, "unexpected history length")
+			if test.expectedLen == 0 {
+				return
+			}
+			last := cd.Status.PowerStateHistory[test.expectedLen-1]
+			assert.Equal(t, test.expectedState, last.State, "unexpected state")
+			assert.Equal(t, test.expectedInitiator, last.Initiator, "unexpected initiator")
+			assert.Equal(t, test.expectCompleted, last.CompletionTime != nil, "unexpected completion")
+			assert.Equal(t, test.expectedFailure, last.FailureReason, "unexpected failure reason")
+		})
+	}
+}
+
+func TestSetHibernatingConditionClearsPowerStateInitiator(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        cdName,
+			Annotations: map[string]string{constants.PowerStateInitiatorAnnotation: constants.PowerStateInitiatorClusterClaim},
+		},
+		Spec: hivev1.ClusterDeploymentSpec{PowerState: hivev1.HibernatingClusterPowerState},
+	}
+	c := fake.NewFakeClientWithScheme(scheme, cd)
+	r := &hibernationReconciler{Client: c, logger: log.WithField("controller", "hibernation")}
+
+	_, err := r.setHibernatingCondition(cd, hivev1.StoppingHibernationReason, "Stopping cluster machines", corev1.ConditionTrue, r.logger)
+	require.NoError(t, err, "unexpected error setting hibernating condition")
+
+	actual := &hivev1.ClusterDeployment{}
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: cdName}, actual))
+	assert.NotContains(t, actual.Annotations, constants.PowerStateInitiatorAnnotation, "expected initiator annotation to be removed")
+	if assert.Len(t, actual.Status.PowerStateHistory, 1, "expected transition to be recorded") {
+		assert.Equal(t, constants.PowerStateInitiatorClusterClaim, actual.Status.PowerStateHistory[0].Initiator, "unexpected initiator")
+	}
+}
@@ -0,0 +1,208 @@
+package hibernation
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// workersPowerStateReasons are the reasons of the Hibernating condition of a cluster in, or moving to or from, the
+// WorkersStopped power state.
+var workersPowerStateReasons = sets.NewString(
+	hivev1.StoppingWorkersHibernationReason,
+	hivev1.WorkersStoppedHibernationReason,
+	hivev1.ResumingWorkersHibernationReason,
+)
+
+// reconcileWorkersPowerState handles the WorkersStopped power state, in which the worker MachineSets of the cluster
+// are scaled to zero rather than having a platform actuator stop the machines of the cluster. It returns false if the
+// cluster is neither in nor moving to or from the WorkersStopped power state.
+func (r *hibernationReconciler) reconcileWorkersPowerState(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (bool, reconcile.Result, error) {
+	hibernatingCondition := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
+	if hibernatingCondition == nil {
+		return false, reconcile.Result{}, nil
+	}
+	inWorkersPowerState := workersPowerStateReasons.Has(hibernatingCondition.Reason)
+
+	if cd.Spec.PowerState != hivev1.WorkersStoppedClusterPowerState {
+		if !inWorkersPowerState {
+			return false, reconcile.Result{}, nil
+		}
+		result, err := r.resumeWorkers(cd, hibernatingCondition.Reason, logger)
+		return true, result, err
+	}
+
+	// A hibernating cluster is resumed by its actuator before its workers are stopped.
+	if !inWorkersPowerState && hibernatingCondition.Status == corev1.ConditionTrue {
+		return false, reconcile.Result{}, nil
+	}
+	result, err := r.stopWorkers(cd, hibernatingCondition.Reason, logger)
+	return true, result, err
+}
+
+func (r *hibernationReconciler) stopWorkers(cd *hivev1.ClusterDeployment, reason string, logger log.FieldLogger) (reconcile.Result, error) {
+	if reason == hivev1.WorkersStoppedHibernationReason {
+		return reconcile.Result{}, nil
+	}
+	remoteClient, err := r.remoteClientBuilder(cd).Build()
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to connect to target cluster")
+		return reconcile.Result{}, err
+	}
+	if reason != hivev1.StoppingWorkersHibernationReason {
+		logger.Info("Stopping cluster workers")
+		if err := scaleDownWorkers(remoteClient, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return r.setHibernatingCondition(cd, hivev1.StoppingWorkersHibernationReason, "Scaling worker MachineSets to zero", corev1.ConditionTrue, logger)
+	}
+	stopped, err := workersStopped(remoteClient)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to check whether workers are stopped")
+		return reconcile.Result{}, err
+	}
+	if !stopped {
+		return reconcile.Result{RequeueAfter: stateCheckInterval}, nil
+	}
+	logger.Info("Cluster workers have stopped")
+	return r.setHibernatingCondition(cd, hivev1.WorkersStoppedHibernationReason, "Worker MachineSets are scaled to zero", corev1.ConditionTrue, logger)
+}
+
+func (r *hibernationReconciler) resumeWorkers(cd *hivev1.ClusterDeployment, reason string, logger log.FieldLogger) (reconcile.Result, error) {
+	remoteClient, err := r.remoteClientBuilder(cd).Build()
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to connect to target cluster")
+		return reconcile.Result{}, err
+	}
+	if reason != hivev1.ResumingWorkersHibernationReason {
+		logger.Info("Resuming cluster workers")
+		if err := scaleUpWorkers(remoteClient, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		return r.setHibernatingCondition(cd, hivev1.ResumingWorkersHibernationReason, "Restoring worker MachineSet replicas", corev1.ConditionTrue, logger)
+	}
+	ready, err := workersReady(remoteClient)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to check whether workers are ready")
+		return reconcile.Result{}, err
+	}
+	if !ready {
+		return reconcile.Result{RequeueAfter: stateCheckInterval}, nil
+	}
+	logger.Info("Cluster workers have started and the cluster is in Running state")
+	return r.setHibernatingCondition(cd, hivev1.RunningHibernationReason, "All worker MachineSets are ready", corev1.ConditionFalse, logger)
+}
+
+func listMachineSets(remoteClient client.Client) ([]machineapi.MachineSet, error) {
+	machineSets := &machineapi.MachineSetList{}
+	if err := remoteClient.List(context.TODO(), machineSets); err != nil {
+		return nil, errors.Wrap(err, "failed to list machine sets")
+	}
+	return machineSets.Items, nil
+}
+
+// scaleDownWorkers scales all MachineSets of the cluster to zero, recording their replicas in an annotation so that
+// they can be restored by scaleUpWorkers.
+func scaleDownWorkers(remoteClient client.Client, logger log.FieldLogger) error {
+	machineSets, err := listMachineSets(remoteClient)
+	if err != nil {
+		return err
+	}
+	for i := range machineSets {
+		ms := &machineSets[i]
+		// Keep the replicas recorded by a previous attempt that was interrupted.
+		if _, recorded := ms.Annotations[constants.WorkersStoppedReplicasAnnotation]; recorded {
+			if replicas(ms) == 0 {
+				continue
+			}
+		} else {
+			if ms.Annotations == nil {
+				ms.Annotations = map[string]string{}
+			}
+			ms.Annotations[constants.WorkersStoppedReplicasAnnotation] = strconv.Itoa(int(replicas(ms)))
+		}
+		zero := int32(0)
+		ms.Spec.Replicas = &zero
+		logger.WithField("machineset", ms.Name).Info("Scaling machineset to zero")
+		if err := remoteClient.Update(context.TODO(), ms); err != nil {
+			return errors.Wrapf(err, "failed to scale down machine set %s", ms.Name)
+		}
+	}
+	return nil
+}
+
+// scaleUpWorkers restores the replicas of the MachineSets of the cluster recorded by scaleDownWorkers.
+func scaleUpWorkers(remoteClient client.Client, logger log.FieldLogger) error {
+	machineSets, err := listMachineSets(remoteClient)
+	if err != nil {
+		return err
+	}
+	for i := range machineSets {
+		ms := &machineSets[i]
+		value, recorded := ms.Annotations[constants.WorkersStoppedReplicasAnnotation]
+		if !recorded {
+			continue
+		}
+		restored, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			logger.WithField("machineset", ms.Name).WithError(err).Warn("Ignoring invalid recorded replicas")
+		} else {
+			r := int32(restored)
+			ms.Spec.Replicas = &r
+		}
+		delete(ms.Annotations, constants.WorkersStoppedReplicasAnnotation)
+		logger.WithField("machineset", ms.Name).WithField("replicas", replicas(ms)).Info("Restoring machineset replicas")
+		if err := remoteClient.Update(context.TODO(), ms); err != nil {
+			return errors.Wrapf(err, "failed to scale up machine set %s", ms.Name)
+		}
+	}
+	return nil
+}
+
+// workersStopped returns true if no MachineSet of the cluster has any replicas left.
+func workersStopped(remoteClient client.Client) (bool, error) {
+	machineSets, err := listMachineSets(remoteClient)
+	if err != nil {
+		return false, err
+	}
+	for _, ms := range machineSets {
+		if ms.Status.Replicas != 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// workersReady returns true if all replicas of all MachineSets of the cluster are ready.
+func workersReady(remoteClient client.Client) (bool, error) {
+	machineSets, err := listMachineSets(remoteClient)
+	if err != nil {
+		return false, err
+	}
+	for i := range machineSets {
+		if machineSets[i].Status.ReadyReplicas != replicas(&machineSets[i]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func replicas(ms *machineapi.MachineSet) int32 {
+	if ms.Spec.Replicas == nil {
+		return 0
+	}
+	return *ms.Spec.Replicas
+}
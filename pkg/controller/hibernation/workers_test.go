@@ -0,0 +1,212 @@
+package hibernation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/hibernation/mock"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+func TestWorkersPowerState(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+	machineapi.AddToScheme(scheme)
+
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).Options(
+		testcd.Installed(),
+		testcd.WithClusterVersion("4.4.9"),
+	)
+	withHibernatingCondition := func(status corev1.ConditionStatus, reason string) testcd.Option {
+		return func(cd *hivev1.ClusterDeployment) {
+			cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.ClusterHibernatingCondition,
+				Status: status,
+				Reason: reason,
+			})
+		}
+	}
+	withPowerState := func(powerState hivev1.ClusterPowerState) testcd.Option {
+		return func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.PowerState = powerState
+		}
+	}
+
+	tests := []struct {
+		name                 string
+		cd                   *hivev1.ClusterDeployment
+		machineSets          []runtime.Object
+		expectRemote         bool
+		expectedStatus       corev1.ConditionStatus
+		expectedReason       string
+		expectedReplicas     map[string]int32
+		expectedAnnotations  map[string]string
+		expectRequeueAfter   bool
+		expectActuatorResume bool
+	}{
+		{
+			name:             "start stopping workers",
+			cd:               cdBuilder.Build(withPowerState(hivev1.WorkersStoppedClusterPowerState), withHibernatingCondition(corev1.ConditionFalse, hivev1.RunningHibernationReason)),
+			machineSets:      []runtime.Object{testMachineSet("worker-a", 3, 3, ""), testMachineSet("worker-b", 1, 1, "")},
+			expectRemote:     true,
+			expectedStatus:   corev1.ConditionTrue,
+			expectedReason:   hivev1.StoppingWorkersHibernationReason,
+			expectedReplicas: map[string]int32{"worker-a": 0, "worker-b": 0},
+			expectedAnnotations: map[string]string{
+				"worker-a": "3",
+				"worker-b": "1",
+			},
+		},
+		{
+			name:               "stopping workers, machines remaining",
+			cd:                 cdBuilder.Build(withPowerState(hivev1.WorkersStoppedClusterPowerState), withHibernatingCondition(corev1.ConditionTrue, hivev1.StoppingWorkersHibernationReason)),
+			machineSets:        []runtime.Object{testMachineSet("worker-a", 0, 2, "3")},
+			expectRemote:       true,
+			expectedStatus:     corev1.ConditionTrue,
+			expectedReason:     hivev1.StoppingWorkersHibernationReason,
+			expectRequeueAfter: true,
+		},
+		{
+			name:           "stopping workers, machines gone",
+			cd:             cdBuilder.Build(withPowerState(hivev1.WorkersStoppedClusterPowerState), withHibernatingCondition(corev1.ConditionTrue, hivev1.StoppingWorkersHibernationReason)),
+			machineSets:    []runtime.Object{testMachineSet("worker-a", 0, 0, "3")},
+			expectRemote:   true,
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: hivev1.WorkersStoppedHibernationReason,
+		},
+		{
+			name:           "workers stopped",
+			cd:             cdBuilder.Build(withPowerState(hivev1.WorkersStoppedClusterPowerState), withHibernatingCondition(corev1.ConditionTrue, hivev1.WorkersStoppedHibernationReason)),
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: hivev1.WorkersStoppedHibernationReason,
+		},
+		{
+			name:                "start resuming workers",
+			cd:                  cdBuilder.Build(withPowerState(hivev1.RunningClusterPowerState), withHibernatingCondition(corev1.ConditionTrue, hivev1.WorkersStoppedHibernationReason)),
+			machineSets:         []runtime.Object{testMachineSet("worker-a", 0, 0, "3"), testMachineSet("infra", 2, 2, "")},
+			expectRemote:        true,
+			expectedStatus:      corev1.ConditionTrue,
+			expectedReason:      hivev1.ResumingWorkersHibernationReason,
+			expectedReplicas:    map[string]int32{"worker-a": 3, "infra": 2},
+			expectedAnnotations: map[string]string{},
+		},
+		{
+			name:               "resuming workers, machines not ready",
+			cd:                 cdBuilder.Build(withHibernatingCondition(corev1.ConditionTrue, hivev1.ResumingWorkersHibernationReason)),
+			machineSets:        []runtime.Object{testMachineSet("worker-a", 3, 1, "")},
+			expectRemote:       true,
+			expectedStatus:     corev1.ConditionTrue,
+			expectedReason:     hivev1.ResumingWorkersHibernationReason,
+			expectRequeueAfter: true,
+		},
+		{
+			name:           "resuming workers, machines ready",
+			cd:             cdBuilder.Build(withHibernatingCondition(corev1.ConditionTrue, hivev1.ResumingWorkersHibernationReason)),
+			machineSets:    []runtime.Object{testMachineSet("worker-a", 3, 3, "")},
+			expectRemote:   true,
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: hivev1.RunningHibernationReason,
+		},
+		{
+			name:                 "hibernating cluster is resumed before stopping workers",
+			cd:                   cdBuilder.Build(withPowerState(hivev1.WorkersStoppedClusterPowerState), withHibernatingCondition(corev1.ConditionTrue, hivev1.HibernatingHibernationReason)),
+			expectedStatus:       corev1.ConditionTrue,
+			expectedReason:       hivev1.ResumingHibernationReason,
+			expectActuatorResume: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockActuator := mock.NewMockHibernationActuator(ctrl)
+			mockActuator.EXPECT().CanHandle(gomock.Any()).AnyTimes().Return(true)
+			if test.expectActuatorResume {
+				mockActuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			}
+			actuators = []HibernationActuator{mockActuator}
+			remoteClient := fake.NewFakeClientWithScheme(scheme, test.machineSets...)
+			mockBuilder := remoteclientmock.NewMockBuilder(ctrl)
+			if test.expectRemote {
+				mockBuilder.EXPECT().Build().Times(1).Return(remoteClient, nil)
+			}
+			c := fake.NewFakeClientWithScheme(scheme, test.cd)
+
+			reconciler := hibernationReconciler{
+				Client: c,
+				logger: log.WithField("controller", "hibernation"),
+				remoteClientBuilder: func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+					return mockBuilder
+				},
+				csrUtil: mock.NewMockcsrHelper(ctrl),
+			}
+			result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: cdName},
+			})
+			require.NoError(t, err, "unexpected error from reconcile")
+			assert.Equal(t, test.expectRequeueAfter, result.RequeueAfter == stateCheckInterval, "unexpected requeue")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName}, cd))
+			cond := getHibernatingCondition(cd)
+			require.NotNil(t, cond)
+			assert.Equal(t, test.expectedStatus, cond.Status, "unexpected condition status")
+			assert.Equal(t, test.expectedReason, cond.Reason, "unexpected condition reason")
+
+			machineSets := &machineapi.MachineSetList{}
+			require.NoError(t, remoteClient.List(context.TODO(), machineSets))
+			for _, ms := range machineSets.Items {
+				if expected, ok := test.expectedReplicas[ms.Name]; ok {
+					assert.Equal(t, expected, replicas(&ms), "unexpected replicas for machineset %s", ms.Name)
+				}
+				if test.expectedAnnotations != nil {
+					value, recorded := ms.Annotations[constants.WorkersStoppedReplicasAnnotation]
+					expected, expectRecorded := test.expectedAnnotations[ms.Name]
+					assert.Equal(t, expectRecorded, recorded, "unexpected recorded replicas annotation for machineset %s", ms.Name)
+					assert.Equal(t, expected, value, "unexpected recorded replicas for machineset %s", ms.Name)
+				}
+			}
+		})
+	}
+}
+
+func testMachineSet(name string, specReplicas, statusReplicas int32, recordedReplicas string) *machineapi.MachineSet {
+	ms := &machineapi.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "openshift-machine-api",
+			Name:      name,
+		},
+		Spec: machineapi.MachineSetSpec{
+			Replicas: &specReplicas,
+		},
+		Status: machineapi.MachineSetStatus{
+			Replicas:      statusReplicas,
+			ReadyReplicas: statusReplicas,
+		},
+	}
+	if recordedReplicas != "" {
+		ms.Annotations = map[string]string{constants.WorkersStoppedReplicasAnnotation: recordedReplicas}
+	}
+	return ms
+}
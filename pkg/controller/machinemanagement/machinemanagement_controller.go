@@ -44,7 +44,7 @@ const (
 
 // Add creates a new ClusterDeployment controller and adds it to the manager with default RBAC.
 func Add(mgr manager.Manager) error {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
 	if err != nil {
 		logger.WithError(err).Error("could not get controller configurations")
@@ -68,20 +68,20 @@ func NewReconciler(mgr manager.Manager, logger log.FieldLogger, rateLimiter flow
 
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
-	c, err := controller.New("machinemanagement-controller", mgr, controller.Options{
+	c, err := controllerutils.NewController("machinemanagement-controller", mgr, controller.Options{
 		Reconciler:              r,
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
 	if err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("could not create controller")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("could not create controller")
 		return err
 	}
 
 	// Watch for changes to ClusterDeployment
 	err = c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{})
 	if err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deployment")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error watching cluster deployment")
 		return err
 	}
 
@@ -100,7 +100,7 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 		return res
 	})
 	if err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("Error indexing cluster deployment secrets")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error indexing cluster deployment secrets")
 		return err
 	}
 
@@ -126,7 +126,7 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 		return retval
 	}))
 	if err != nil {
-		log.WithField("controller", ControllerName).WithError(err).Error("Error watching cluster deployment secrets")
+		controllerutils.ControllerLogEntry(ControllerName).WithError(err).Error("Error watching cluster deployment secrets")
 		return err
 	}
 
@@ -173,6 +173,10 @@ func (r *ReconcileMachineManagement) Reconcile(ctx context.Context, request reco
 }
 
 func (r *ReconcileMachineManagement) reconcile(request reconcile.Request, cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (result reconcile.Result, returnErr error) {
+	if controllerutils.IsClusterDeploymentPaused(cd, cdLog) {
+		return reconcile.Result{}, nil
+	}
+
 	// Return early if cluster deployment was deleted
 	if !cd.DeletionTimestamp.IsZero() {
 		if controllerutil.ContainsFinalizer(cd, hivev1.FinalizerMachineManagementTargetNamespace) {
@@ -7,9 +7,12 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -18,7 +21,9 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/imageset"
+	"github.com/openshift/hive/pkg/tracing"
 )
 
 const (
@@ -170,6 +175,11 @@ type Calculator struct {
 
 	// Interval is the length of time we sleep between metrics calculations.
 	Interval time.Duration
+
+	// provisionOutcomes and deprovisionOutcomes remember outcomes across calculations for the rolling window
+	// success ratios.
+	provisionOutcomes   *outcomeTracker
+	deprovisionOutcomes *outcomeTracker
 }
 
 // Start begins the metrics calculation loop.
@@ -178,7 +188,7 @@ func (mc *Calculator) Start(ctx context.Context) error {
 
 	// Run forever, sleep at the end:
 	wait.UntilWithContext(ctx, func(ctx context.Context) {
-		mcLog := log.WithField("controller", "metrics")
+		mcLog := controllerutils.ControllerLogEntry(ControllerName)
 		recobsrv := NewReconcileObserver(ControllerName, mcLog)
 		defer recobsrv.ObserveControllerReconcileTime()
 
@@ -210,7 +220,7 @@ func (mc *Calculator) Start(ctx context.Context) error {
 						time.Since(cd.CreationTimestamp.Time).Seconds())
 				}
 
-				if paused, err := strconv.ParseBool(cd.Annotations[constants.SyncsetPauseAnnotation]); err == nil && paused {
+				if paused, err := strconv.ParseBool(cd.Annotations[constants.SyncsetPauseAnnotation]); cd.Spec.Paused || (err == nil && paused) {
 					metricClusterDeploymentSyncsetPaused.WithLabelValues(
 						cd.Name,
 						cd.Namespace,
@@ -250,6 +260,8 @@ func (mc *Calculator) Start(ctx context.Context) error {
 				metricClusterDeploymentsDeprovisioningTotal,
 				metricClusterDeploymentsWithConditionTotal,
 				mcLog)
+
+			mc.calculateOutcomeMetrics(clusterDeployments.Items, mcLog)
 		}
 		mcLog.Debug("calculating metrics across all install jobs")
 
@@ -606,6 +618,7 @@ type ReconcileObserver struct {
 	controllerName hivev1.ControllerName
 	logger         log.FieldLogger
 	outcome        ReconcileOutcome
+	span           trace.Span
 }
 
 func NewReconcileObserver(controllerName hivev1.ControllerName, logger log.FieldLogger) *ReconcileObserver {
@@ -617,8 +630,26 @@ func NewReconcileObserver(controllerName hivev1.ControllerName, logger log.Field
 	}
 }
 
+// StartSpan starts the span of the reconcile of the named object, which is ended by ObserveControllerReconcileTime,
+// and returns the context of the span. The span is only recorded when tracing is enabled in HiveConfig and the
+// reconcile is sampled.
+func (ro *ReconcileObserver) StartSpan(ctx context.Context, name types.NamespacedName) context.Context {
+	ctx, ro.span = tracing.Tracer().Start(ctx, ro.controllerName.String()+".Reconcile",
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("namespace", name.Namespace),
+			attribute.String("name", name.Name),
+		),
+	)
+	return ctx
+}
+
 func (ro *ReconcileObserver) ObserveControllerReconcileTime() {
 	dur := time.Since(ro.startTime)
+	if ro.span != nil {
+		ro.span.SetAttributes(attribute.String("outcome", string(ro.outcome)))
+		ro.span.End()
+	}
 	metricControllerReconcileTime.WithLabelValues(string(ro.controllerName), string(ro.outcome)).Observe(dur.Seconds())
 	fields := log.Fields{"elapsedMillis": dur.Milliseconds(), "outcome": ro.outcome}
 	// Add a log field to categorize request duration into buckets. We can't easily query > in Kibana in
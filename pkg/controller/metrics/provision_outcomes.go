@@ -0,0 +1,274 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	outcomeSuccess = "success"
+	outcomeFailure = "failure"
+)
+
+var (
+	// outcomeWindows are the rolling windows over which provision and deprovision success ratios are reported.
+	outcomeWindows = []string{"1h", "24h", "168h"}
+
+	metricClusterProvisionOutcomes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_cluster_provision_outcomes",
+		Help: "Number of cluster provisions that finished within the rolling window by platform, image set and outcome.",
+	}, []string{"platform", "image_set", "window", "outcome"})
+	metricClusterProvisionSuccessRatio = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_cluster_provision_success_ratio",
+		Help: "Ratio of cluster provisions that succeeded to all cluster provisions that finished within the rolling window.",
+	}, []string{"platform", "image_set", "window"})
+	metricClusterDeprovisionOutcomes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_cluster_deprovision_outcomes",
+		Help: "Number of cluster deprovisions that finished within the rolling window by platform, image set and outcome.",
+	}, []string{"platform", "image_set", "window", "outcome"})
+	metricClusterDeprovisionSuccessRatio = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_cluster_deprovision_success_ratio",
+		Help: "Ratio of cluster deprovisions that succeeded to all cluster deprovisions that finished within the rolling window.",
+	}, []string{"platform", "image_set", "window"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricClusterProvisionOutcomes)
+	metrics.Registry.MustRegister(metricClusterProvisionSuccessRatio)
+	metrics.Registry.MustRegister(metricClusterDeprovisionOutcomes)
+	metrics.Registry.MustRegister(metricClusterDeprovisionSuccessRatio)
+}
+
+// outcome is the result of a single provision or deprovision.
+type outcome struct {
+	platform string
+	imageSet string
+	success  bool
+	time     time.Time
+}
+
+// outcomeTracker remembers the outcomes of provisions and deprovisions for the length of the longest window, keyed by
+// the UID of the ClusterProvision or ClusterDeprovision. Outcomes are kept after the object is deleted, so that
+// clusters that are deleted after installing, or deprovisions that are garbage collected along with their
+// ClusterDeployment, are still counted. Outcomes are lost when the controller restarts, and are recovered from the
+// objects that still exist.
+type outcomeTracker struct {
+	outcomes map[types.UID]outcome
+}
+
+func newOutcomeTracker() *outcomeTracker {
+	return &outcomeTracker{outcomes: map[types.UID]outcome{}}
+}
+
+// record records the outcome of an object. A later outcome for the same object replaces the earlier one, so that a
+// deprovision that failed and was then retried successfully counts as a success.
+func (ot *outcomeTracker) record(uid types.UID, o outcome) {
+	if existing, ok := ot.outcomes[uid]; ok && existing.success == o.success {
+		return
+	}
+	ot.outcomes[uid] = o
+}
+
+// prune forgets outcomes older than maxAge.
+func (ot *outcomeTracker) prune(now time.Time, maxAge time.Duration) {
+	for uid, o := range ot.outcomes {
+		if now.Sub(o.time) > maxAge {
+			delete(ot.outcomes, uid)
+		}
+	}
+}
+
+// setMetrics resets the given metrics and sets them from the outcomes within each window.
+func (ot *outcomeTracker) setMetrics(now time.Time, windows []time.Duration, windowNames []string, outcomes, successRatio *prometheus.GaugeVec) {
+	type key struct {
+		platform, imageSet string
+	}
+	outcomes.Reset()
+	successRatio.Reset()
+	for i, window := range windows {
+		succeeded := map[key]int{}
+		failed := map[key]int{}
+		for _, o := range ot.outcomes {
+			if now.Sub(o.time) > window {
+				continue
+			}
+			k := key{platform: o.platform, imageSet: o.imageSet}
+			if o.success {
+				succeeded[k]++
+			} else {
+				failed[k]++
+			}
+		}
+		keys := map[key]bool{}
+		for k := range succeeded {
+			keys[k] = true
+		}
+		for k := range failed {
+			keys[k] = true
+		}
+		for k := range keys {
+			outcomes.WithLabelValues(k.platform, k.imageSet, windowNames[i], outcomeSuccess).Set(float64(succeeded[k]))
+			outcomes.WithLabelValues(k.platform, k.imageSet, windowNames[i], outcomeFailure).Set(float64(failed[k]))
+			successRatio.WithLabelValues(k.platform, k.imageSet, windowNames[i]).Set(
+				float64(succeeded[k]) / float64(succeeded[k]+failed[k]))
+		}
+	}
+}
+
+// provisionOutcome returns the outcome of a ClusterProvision, and false if the provision has not finished.
+func provisionOutcome(provision *hivev1.ClusterProvision, cd *hivev1.ClusterDeployment, now time.Time) (outcome, bool) {
+	var conditionType hivev1.ClusterProvisionConditionType
+	switch provision.Spec.Stage {
+	case hivev1.ClusterProvisionStageComplete:
+		conditionType = hivev1.ClusterProvisionCompletedCondition
+	case hivev1.ClusterProvisionStageFailed:
+		conditionType = hivev1.ClusterProvisionFailedCondition
+	default:
+		return outcome{}, false
+	}
+	o := outcome{
+		platform: provision.Labels[hivev1.HiveClusterPlatformLabel],
+		imageSet: "none",
+		success:  provision.Spec.Stage == hivev1.ClusterProvisionStageComplete,
+		time:     now,
+	}
+	if cond := controllerutils.FindClusterProvisionCondition(provision.Status.Conditions, conditionType); cond != nil &&
+		cond.Status == corev1.ConditionTrue && !cond.LastTransitionTime.IsZero() {
+		o.time = cond.LastTransitionTime.Time
+	}
+	if cd != nil {
+		if platform := cd.Labels[hivev1.HiveClusterPlatformLabel]; platform != "" {
+			o.platform = platform
+		}
+		o.imageSet = clusterImageSet(cd)
+	}
+	if o.platform == "" {
+		o.platform = constants.PlatformUnknown
+	}
+	return o, true
+}
+
+// deprovisionOutcome returns the outcome of a ClusterDeprovision, and false if the deprovision has neither completed
+// nor failed. A failed deprovision is retried, and so may later complete.
+func deprovisionOutcome(deprovision *hivev1.ClusterDeprovision, cd *hivev1.ClusterDeployment, now time.Time) (outcome, bool) {
+	o := outcome{
+		platform: deprovisionPlatform(deprovision),
+		imageSet: "none",
+		success:  deprovision.Status.Completed,
+		time:     now,
+	}
+	if !o.success {
+		cond := controllerutils.FindClusterDeprovisionCondition(deprovision.Status.Conditions, hivev1.DeprovisionFailedClusterDeprovisionCondition)
+		if cond == nil || cond.Status != corev1.ConditionTrue {
+			return outcome{}, false
+		}
+		if !cond.LastTransitionTime.IsZero() {
+			o.time = cond.LastTransitionTime.Time
+		}
+	}
+	if cd != nil {
+		if platform := cd.Labels[hivev1.HiveClusterPlatformLabel]; platform != "" {
+			o.platform = platform
+		}
+		o.imageSet = clusterImageSet(cd)
+	}
+	return o, true
+}
+
+// deprovisionPlatform returns the platform of a ClusterDeprovision, for when its ClusterDeployment is gone.
+func deprovisionPlatform(deprovision *hivev1.ClusterDeprovision) string {
+	switch p := deprovision.Spec.Platform; {
+	case p.AWS != nil:
+		return constants.PlatformAWS
+	case p.Azure != nil:
+		return constants.PlatformAzure
+	case p.GCP != nil:
+		return constants.PlatformGCP
+	case p.OpenStack != nil:
+		return constants.PlatformOpenStack
+	case p.VSphere != nil:
+		return constants.PlatformVSphere
+	case p.Nutanix != nil:
+		return constants.PlatformNutanix
+	case p.PowerVS != nil:
+		return constants.PlatformPowerVS
+	}
+	return constants.PlatformUnknown
+}
+
+func clusterImageSet(cd *hivev1.ClusterDeployment) string {
+	if cd.Spec.Provisioning != nil && cd.Spec.Provisioning.ImageSetRef != nil {
+		return cd.Spec.Provisioning.ImageSetRef.Name
+	}
+	return "none"
+}
+
+// calculateOutcomeMetrics records the outcomes of all finished ClusterProvisions and ClusterDeprovisions and publishes
+// the success ratios over each of the outcomeWindows.
+func (mc *Calculator) calculateOutcomeMetrics(clusterDeployments []hivev1.ClusterDeployment, mcLog log.FieldLogger) {
+	mcLog.Debug("calculating provision and deprovision outcome metrics")
+	if mc.provisionOutcomes == nil {
+		mc.provisionOutcomes = newOutcomeTracker()
+	}
+	if mc.deprovisionOutcomes == nil {
+		mc.deprovisionOutcomes = newOutcomeTracker()
+	}
+
+	windows := make([]time.Duration, len(outcomeWindows))
+	var maxWindow time.Duration
+	for i, w := range outcomeWindows {
+		// outcomeWindows are constants which are known to parse
+		windows[i], _ = time.ParseDuration(w)
+		if windows[i] > maxWindow {
+			maxWindow = windows[i]
+		}
+	}
+
+	cds := map[types.NamespacedName]*hivev1.ClusterDeployment{}
+	for i := range clusterDeployments {
+		cd := &clusterDeployments[i]
+		cds[types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}] = cd
+	}
+
+	now := time.Now()
+	provisions := &hivev1.ClusterProvisionList{}
+	if err := mc.Client.List(context.Background(), provisions); err != nil {
+		mcLog.WithError(err).Error("error listing cluster provisions")
+	} else {
+		for i := range provisions.Items {
+			provision := &provisions.Items[i]
+			cd := cds[types.NamespacedName{Namespace: provision.Namespace, Name: provision.Spec.ClusterDeploymentRef.Name}]
+			if o, ok := provisionOutcome(provision, cd, now); ok {
+				mc.provisionOutcomes.record(provision.UID, o)
+			}
+		}
+	}
+	mc.provisionOutcomes.prune(now, maxWindow)
+	mc.provisionOutcomes.setMetrics(now, windows, outcomeWindows, metricClusterProvisionOutcomes, metricClusterProvisionSuccessRatio)
+
+	deprovisions := &hivev1.ClusterDeprovisionList{}
+	if err := mc.Client.List(context.Background(), deprovisions); err != nil {
+		mcLog.WithError(err).Error("error listing cluster deprovisions")
+	} else {
+		for i := range deprovisions.Items {
+			deprovision := &deprovisions.Items[i]
+			cd := cds[types.NamespacedName{Namespace: deprovision.Namespace, Name: deprovision.Name}]
+			if o, ok := deprovisionOutcome(deprovision, cd, now); ok {
+				mc.deprovisionOutcomes.record(deprovision.UID, o)
+			}
+		}
+	}
+	mc.deprovisionOutcomes.prune(now, maxWindow)
+	mc.deprovisionOutcomes.setMetrics(now, windows, outcomeWindows, metricClusterDeprovisionOutcomes, metricClusterDeprovisionSuccessRatio)
+}
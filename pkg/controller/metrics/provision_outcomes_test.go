@@ -0,0 +1,115 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+func testProvision(name, cdName string, stage hivev1.ClusterProvisionStage, finished time.Time) *hivev1.ClusterProvision {
+	provision := &hivev1.ClusterProvision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      name,
+			UID:       types.UID(name),
+		},
+		Spec: hivev1.ClusterProvisionSpec{
+			ClusterDeploymentRef: corev1.LocalObjectReference{Name: cdName},
+			Stage:                stage,
+		},
+	}
+	conditionType := hivev1.ClusterProvisionCompletedCondition
+	if stage == hivev1.ClusterProvisionStageFailed {
+		conditionType = hivev1.ClusterProvisionFailedCondition
+	}
+	provision.Status.Conditions = []hivev1.ClusterProvisionCondition{{
+		Type:               conditionType,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(finished),
+	}}
+	return provision
+}
+
+func testDeprovision(name string, completed bool, failed bool) *hivev1.ClusterDeprovision {
+	deprovision := &hivev1.ClusterDeprovision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      name,
+			UID:       types.UID(name),
+		},
+		Spec: hivev1.ClusterDeprovisionSpec{
+			Platform: hivev1.ClusterDeprovisionPlatform{
+				AWS: &hivev1.AWSClusterDeprovision{Region: "us-east-1"},
+			},
+		},
+		Status: hivev1.ClusterDeprovisionStatus{Completed: completed},
+	}
+	if failed {
+		deprovision.Status.Conditions = []hivev1.ClusterDeprovisionCondition{{
+			Type:               hivev1.DeprovisionFailedClusterDeprovisionCondition,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+		}}
+	}
+	return deprovision
+}
+
+func TestCalculateOutcomeMetrics(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+
+	cd := testcd.FullBuilder("ns", "cd", scheme).Build(
+		testcd.WithLabel(hivev1.HiveClusterPlatformLabel, "aws"),
+		func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.Provisioning = &hivev1.Provisioning{ImageSetRef: &hivev1.ClusterImageSetReference{Name: "4.8"}}
+		},
+	)
+	now := time.Now()
+	existing := []runtime.Object{
+		cd,
+		testProvision("p1", "cd", hivev1.ClusterProvisionStageFailed, now.Add(-3*time.Hour)),
+		testProvision("p2", "cd", hivev1.ClusterProvisionStageFailed, now.Add(-30*time.Minute)),
+		testProvision("p3", "cd", hivev1.ClusterProvisionStageComplete, now.Add(-10*time.Minute)),
+		testProvision("p4", "cd", hivev1.ClusterProvisionStageProvisioning, now),
+		testProvision("p5", "cd", hivev1.ClusterProvisionStageComplete, now.Add(-30*24*time.Hour)),
+		testDeprovision("cd", false, true),
+		testDeprovision("gone", true, false),
+		testDeprovision("running", false, false),
+	}
+	c := fake.NewFakeClientWithScheme(scheme, existing...)
+	mc := &Calculator{Client: c}
+	mc.calculateOutcomeMetrics([]hivev1.ClusterDeployment{*cd}, log.WithField("test", t.Name()))
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metricClusterProvisionOutcomes.WithLabelValues("aws", "4.8", "1h", outcomeSuccess)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metricClusterProvisionOutcomes.WithLabelValues("aws", "4.8", "1h", outcomeFailure)))
+	assert.Equal(t, 0.5, testutil.ToFloat64(metricClusterProvisionSuccessRatio.WithLabelValues("aws", "4.8", "1h")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(metricClusterProvisionOutcomes.WithLabelValues("aws", "4.8", "24h", outcomeFailure)))
+	assert.InDelta(t, 1.0/3, testutil.ToFloat64(metricClusterProvisionSuccessRatio.WithLabelValues("aws", "4.8", "24h")), 0.0001)
+	assert.Equal(t, 1.0/3, testutil.ToFloat64(metricClusterProvisionSuccessRatio.WithLabelValues("aws", "4.8", "168h")))
+	assert.Len(t, mc.provisionOutcomes.outcomes, 3, "expected unfinished and expired provisions to be excluded")
+
+	assert.Equal(t, 0.0, testutil.ToFloat64(metricClusterDeprovisionSuccessRatio.WithLabelValues("aws", "4.8", "1h")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metricClusterDeprovisionSuccessRatio.WithLabelValues("aws", "none", "1h")))
+	assert.Len(t, mc.deprovisionOutcomes.outcomes, 2, "expected running deprovision to be excluded")
+
+	// The failed deprovision is retried successfully, and the successful provision and deprovision are deleted
+	// along with their ClusterDeployment.
+	c = fake.NewFakeClientWithScheme(scheme, testDeprovision("cd", true, true))
+	mc.Client = c
+	mc.calculateOutcomeMetrics([]hivev1.ClusterDeployment{*cd}, log.WithField("test", t.Name()))
+
+	assert.Equal(t, 0.5, testutil.ToFloat64(metricClusterProvisionSuccessRatio.WithLabelValues("aws", "4.8", "1h")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metricClusterDeprovisionSuccessRatio.WithLabelValues("aws", "4.8", "1h")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metricClusterDeprovisionOutcomes.WithLabelValues("aws", "4.8", "1h", outcomeFailure)))
+}
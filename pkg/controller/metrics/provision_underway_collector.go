@@ -22,6 +22,9 @@ var (
 		hivev1.InstallLaunchErrorCondition,
 		hivev1.ProvisionFailedCondition,
 		hivev1.AuthenticationFailureClusterDeploymentCondition,
+		hivev1.InsufficientPermissionsClusterDeploymentCondition,
+		hivev1.FIPSPreconditionFailedClusterDeploymentCondition,
+		hivev1.ReleaseImageVerificationFailedClusterDeploymentCondition,
 		hivev1.InstallImagesNotResolvedCondition,
 	}
 )
@@ -42,7 +45,7 @@ type provisioningUnderwayCollector struct {
 
 // collects the metrics for provisioningUnderwayCollector
 func (cc provisioningUnderwayCollector) Collect(ch chan<- prometheus.Metric) {
-	ccLog := log.WithField("controller", "metrics")
+	ccLog := controllerutils.ControllerLogEntry(ControllerName)
 	ccLog.Info("calculating provisioning underway metrics across all ClusterDeployments")
 
 	// Load all ClusterDeployments so we can accumulate facts about them.
@@ -129,7 +132,7 @@ type provisioningUnderwayInstallRestartsCollector struct {
 
 // collects the metrics for provisioningUnderwayInstallRestartsCollector
 func (cc provisioningUnderwayInstallRestartsCollector) Collect(ch chan<- prometheus.Metric) {
-	ccLog := log.WithField("controller", "metrics")
+	ccLog := controllerutils.ControllerLogEntry(ControllerName)
 	ccLog.Info("calculating provisioning underway install restarts metrics across all ClusterDeployments")
 
 	// Load all ClusterDeployments so we can accumulate facts about them.
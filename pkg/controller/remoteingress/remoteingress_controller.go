@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/md5"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"sort"
@@ -51,6 +52,9 @@ const (
 	ingressCertificateNotFoundReason = "IngressCertificateNotFound"
 	ingressCertificateFoundReason    = "IngressCertificateFound"
 
+	// prefix of the name of the LoadBalancer service of an ingressController in openshift-ingress
+	routerServicePrefix = "router-"
+
 	// requeueAfter2 is just a static 2 minute delay for when to requeue
 	// for the case when a necessary secret is missing
 	requeueAfter2 = time.Minute * 2
@@ -64,7 +68,7 @@ type kubeCLIApplier interface {
 // Add creates a new RemoteMachineSet Controller and adds it to the Manager with default RBAC. The Manager will set fields on the
 // Controller and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
 	if err != nil {
 		logger.WithError(err).Error("could not get controller configurations")
@@ -75,7 +79,7 @@ func Add(mgr manager.Manager) error {
 
 // NewReconciler returns a new reconcile.Reconciler
 func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	helper, err := resource.NewHelperWithMetricsFromRESTConfig(mgr.GetConfig(), ControllerName, logger)
 	if err != nil {
 		// Hard exit if we can't create this controller
@@ -84,7 +88,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 	return &ReconcileRemoteClusterIngress{
 		Client:  controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
 		scheme:  mgr.GetScheme(),
-		logger:  log.WithField("controller", ControllerName),
+		logger:  controllerutils.ControllerLogEntry(ControllerName),
 		kubeCLI: helper,
 	}
 }
@@ -92,7 +96,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
-	c, err := controller.New("remoteingress-controller", mgr, controller.Options{
+	c, err := controllerutils.NewController("remoteingress-controller", mgr, controller.Options{
 		Reconciler:              r,
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
@@ -159,6 +163,10 @@ func (r *ReconcileRemoteClusterIngress) Reconcile(ctx context.Context, request r
 		return reconcile.Result{}, err
 	}
 
+	if controllerutils.IsClusterDeploymentPaused(cd, cdLog) {
+		return reconcile.Result{}, nil
+	}
+
 	// If the clusterdeployment is deleted, do not reconcile.
 	if cd.DeletionTimestamp != nil {
 		return reconcile.Result{}, nil
@@ -218,7 +226,11 @@ func (r *ReconcileRemoteClusterIngress) syncClusterIngress(rContext *reconcileCo
 
 	rawList := rawExtensionsFromClusterDeployment(rContext)
 	secretMappings := secretMappingsFromClusterDeployment(rContext)
-	return r.syncSyncSet(rContext, rawList, secretMappings)
+	patches, err := patchesFromClusterDeployment(rContext)
+	if err != nil {
+		return err
+	}
+	return r.syncSyncSet(rContext, rawList, secretMappings, patches)
 }
 
 // rawExtensionsFromClusterDeployment will return the slice of runtime.RawExtension objects
@@ -256,11 +268,42 @@ func secretMappingsFromClusterDeployment(rContext *reconcileContext) []hivev1.Se
 	return secretMappings
 }
 
-func newSyncSetSpec(cd *hivev1.ClusterDeployment, rawExtensions []runtime.RawExtension, secretMappings []hivev1.SecretMapping) *hivev1.SyncSetSpec {
+// patchesFromClusterDeployment will return the slice of hivev1.SyncObjectPatch objects
+// (really the syncSet.Spec.Patches) adding the load balancer annotations of the ingress config
+// for the clusterDeployment to the LoadBalancer services of the ingressControllers
+func patchesFromClusterDeployment(rContext *reconcileContext) ([]hivev1.SyncObjectPatch, error) {
+	var patches []hivev1.SyncObjectPatch
+
+	for _, ingress := range rContext.clusterDeployment.Spec.Ingress {
+		if ingress.LoadBalancer == nil || len(ingress.LoadBalancer.Annotations) == 0 {
+			continue
+		}
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": ingress.LoadBalancer.Annotations,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal load balancer annotations for ingress %s: %v", ingress.Name, err)
+		}
+		patches = append(patches, hivev1.SyncObjectPatch{
+			APIVersion: "v1",
+			Kind:       "Service",
+			Name:       routerServicePrefix + ingress.Name,
+			Namespace:  remoteIngressControllerSecretsNamespace,
+			Patch:      string(patch),
+			PatchType:  "merge",
+		})
+	}
+	return patches, nil
+}
+
+func newSyncSetSpec(cd *hivev1.ClusterDeployment, rawExtensions []runtime.RawExtension, secretMappings []hivev1.SecretMapping, patches []hivev1.SyncObjectPatch) *hivev1.SyncSetSpec {
 	ssSpec := &hivev1.SyncSetSpec{
 		SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
 			Resources:         rawExtensions,
 			Secrets:           secretMappings,
+			Patches:           patches,
 			ResourceApplyMode: hivev1.SyncResourceApplyMode,
 		},
 		ClusterDeploymentRefs: []corev1.LocalObjectReference{
@@ -273,10 +316,10 @@ func newSyncSetSpec(cd *hivev1.ClusterDeployment, rawExtensions []runtime.RawExt
 }
 
 // syncSyncSet builds up a syncSet object with the passed-in rawExtensions as the spec.Resources
-func (r *ReconcileRemoteClusterIngress) syncSyncSet(rContext *reconcileContext, rawExtensions []runtime.RawExtension, secretMappings []hivev1.SecretMapping) error {
+func (r *ReconcileRemoteClusterIngress) syncSyncSet(rContext *reconcileContext, rawExtensions []runtime.RawExtension, secretMappings []hivev1.SecretMapping, patches []hivev1.SyncObjectPatch) error {
 	ssName := GenerateRemoteIngressSyncSetName(rContext.clusterDeployment.Name)
 
-	newSyncSetSpec := newSyncSetSpec(rContext.clusterDeployment, rawExtensions, secretMappings)
+	newSyncSetSpec := newSyncSetSpec(rContext.clusterDeployment, rawExtensions, secretMappings, patches)
 	syncSet := &hivev1.SyncSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        ssName,
@@ -323,9 +366,26 @@ func createIngressController(cd *hivev1.ClusterDeployment, ingress hivev1.Cluste
 			Domain:            ingress.Domain,
 			RouteSelector:     ingress.RouteSelector,
 			NamespaceSelector: ingress.NamespaceSelector,
+			Replicas:          ingress.Replicas,
 		},
 	}
 
+	if ingress.NodePlacement != nil {
+		newIngress.Spec.NodePlacement = &ingresscontroller.NodePlacement{
+			NodeSelector: ingress.NodePlacement.NodeSelector,
+			Tolerations:  ingress.NodePlacement.Tolerations,
+		}
+	}
+
+	if ingress.LoadBalancer != nil {
+		newIngress.Spec.EndpointPublishingStrategy = &ingresscontroller.EndpointPublishingStrategy{
+			Type: ingresscontroller.LoadBalancerServiceStrategyType,
+			LoadBalancer: &ingresscontroller.LoadBalancerStrategy{
+				Scope: ingresscontroller.LoadBalancerScope(ingress.LoadBalancer.Scope),
+			},
+		}
+	}
+
 	// if the ingress entry references a certBundle, make sure to put the appropriate looking
 	// entry in the ingressController object
 	if ingress.ServingCertificate != "" {
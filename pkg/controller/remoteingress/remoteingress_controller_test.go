@@ -7,6 +7,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -475,7 +476,7 @@ func syncSetFromClusterDeployment(cd *hivev1.ClusterDeployment) *hivev1.SyncSet
 	}
 	rawExtensions := rawExtensionsFromClusterDeployment(&rContext)
 	sMappings := secretMappingsFromClusterDeployment(&rContext)
-	ssSpec := newSyncSetSpec(cd, rawExtensions, sMappings)
+	ssSpec := newSyncSetSpec(cd, rawExtensions, sMappings, nil)
 	return &hivev1.SyncSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cd.Name + "clusteringress",
@@ -686,6 +687,65 @@ func validateSyncSet(t *testing.T, existingSyncSet createdSyncSetInfo, expectedS
 	return
 }
 
+func TestIngressControllerCustomization(t *testing.T) {
+	replicas := int32(3)
+	nodeSelector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{"node-role.kubernetes.io/infra": ""},
+	}
+	tolerations := []corev1.Toleration{{
+		Key:    "node-role.kubernetes.io/infra",
+		Effect: corev1.TaintEffectNoSchedule,
+	}}
+
+	cd := testClusterDeploymentWithoutIngress()
+	cd.Spec.Ingress = []hivev1.ClusterIngress{
+		{
+			Name:     testDefaultIngressName,
+			Domain:   testIngressDomain,
+			Replicas: &replicas,
+			NodePlacement: &hivev1.IngressNodePlacement{
+				NodeSelector: nodeSelector,
+				Tolerations:  tolerations,
+			},
+			LoadBalancer: &hivev1.IngressLoadBalancer{
+				Scope: hivev1.InternalIngressLoadBalancerScope,
+				Annotations: map[string]string{
+					"service.beta.kubernetes.io/aws-load-balancer-type": "nlb",
+				},
+			},
+		},
+		{
+			Name:   "secondingress",
+			Domain: "moreingress.example.com",
+		},
+	}
+
+	ic := createIngressController(cd, cd.Spec.Ingress[0], nil)
+	assert.Equal(t, &replicas, ic.Spec.Replicas, "unexpected replicas")
+	if assert.NotNil(t, ic.Spec.NodePlacement, "expected node placement") {
+		assert.Equal(t, nodeSelector, ic.Spec.NodePlacement.NodeSelector, "unexpected node selector")
+		assert.Equal(t, tolerations, ic.Spec.NodePlacement.Tolerations, "unexpected tolerations")
+	}
+	if assert.NotNil(t, ic.Spec.EndpointPublishingStrategy, "expected endpoint publishing strategy") {
+		assert.Equal(t, ingresscontroller.LoadBalancerServiceStrategyType, ic.Spec.EndpointPublishingStrategy.Type, "unexpected endpoint publishing strategy")
+		assert.Equal(t, ingresscontroller.InternalLoadBalancer, ic.Spec.EndpointPublishingStrategy.LoadBalancer.Scope, "unexpected load balancer scope")
+	}
+
+	ic = createIngressController(cd, cd.Spec.Ingress[1], nil)
+	assert.Nil(t, ic.Spec.Replicas, "unexpected replicas")
+	assert.Nil(t, ic.Spec.NodePlacement, "unexpected node placement")
+	assert.Nil(t, ic.Spec.EndpointPublishingStrategy, "unexpected endpoint publishing strategy")
+
+	patches, err := patchesFromClusterDeployment(&reconcileContext{clusterDeployment: cd})
+	require.NoError(t, err, "unexpected error generating patches")
+	if assert.Len(t, patches, 1, "expected a single patch") {
+		assert.Equal(t, "Service", patches[0].Kind, "unexpected patch kind")
+		assert.Equal(t, "router-default", patches[0].Name, "unexpected patch name")
+		assert.Equal(t, "openshift-ingress", patches[0].Namespace, "unexpected patch namespace")
+		assert.JSONEq(t, `{"metadata": {"annotations": {"service.beta.kubernetes.io/aws-load-balancer-type": "nlb"}}}`, patches[0].Patch, "unexpected patch")
+	}
+}
+
 func TestSecretHash(t *testing.T) {
 	secret1 := &corev1.Secret{
 		Data: map[string][]byte{
@@ -1,8 +1,17 @@
 package remotemachineset
 
 import (
+	"context"
+
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
 	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -18,5 +27,97 @@ type Actuator interface {
 	// Returns the list of generated machine sets, a boolean indicating if the controller should proceed with reconcile
 	// or not, and an error. The boolean may be set in situations where we have not encountered an error, but still need
 	// to wait before we can proceed with reconciling. (e.g. obtaining a pool name lease)
-	GenerateMachineSets(*hivev1.ClusterDeployment, *hivev1.MachinePool, log.FieldLogger) (msets []*machineapi.MachineSet, proceed bool, genError error)
+	GenerateMachineSets(context.Context, *hivev1.ClusterDeployment, *hivev1.MachinePool, log.FieldLogger) (msets []*machineapi.MachineSet, proceed bool, genError error)
+}
+
+// patchRawProviderSpec renders the provider spec of a MachineSet to raw JSON and applies patch to it. This is used to
+// set fields of the Machine API that are newer than the vendored provider spec types. The provider spec can no longer
+// be modified as a typed object afterwards, so this must be the last change made to it.
+func patchRawProviderSpec(ms *machineapi.MachineSet, patch func(providerSpec map[string]interface{})) error {
+	raw, err := json.Marshal(ms.Spec.Template.Spec.ProviderSpec.Value.Object)
+	if err != nil {
+		return err
+	}
+	providerSpec := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &providerSpec); err != nil {
+		return err
+	}
+	patch(providerSpec)
+	raw, err = json.Marshal(providerSpec)
+	if err != nil {
+		return err
+	}
+	ms.Spec.Template.Spec.ProviderSpec.Value = &runtime.RawExtension{Raw: raw}
+	return nil
+}
+
+// decodeRawProviderSpec decodes the provider spec of a Machine to a map, for platforms without vendored Machine API
+// types, and checks that it is of the kind expected.
+func decodeRawProviderSpec(rawExt *runtime.RawExtension, kind string) (map[string]interface{}, error) {
+	if rawExt == nil || len(rawExt.Raw) == 0 {
+		return nil, fmt.Errorf("Machine has no ProviderSpec")
+	}
+	providerSpec := map[string]interface{}{}
+	if err := json.Unmarshal(rawExt.Raw, &providerSpec); err != nil {
+		return nil, fmt.Errorf("could not decode %s: %v", kind, err)
+	}
+	if k := providerSpec["kind"]; k != kind {
+		return nil, fmt.Errorf("Unexpected ProviderSpec kind: %v", k)
+	}
+	return providerSpec, nil
+}
+
+// rawProviderSpecMachineSet returns the single MachineSet of a MachinePool, with the provider spec given as a map, for
+// platforms without vendored installer or Machine API types. The MachineSet is named and labelled as the installer
+// does for the worker MachineSets it creates.
+func rawProviderSpecMachineSet(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, providerSpec map[string]interface{}) (*machineapi.MachineSet, error) {
+	raw, err := json.Marshal(providerSpec)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate machinesets")
+	}
+
+	clusterID := cd.Spec.ClusterMetadata.InfraID
+	total := int32(0)
+	if pool.Spec.Replicas != nil {
+		total = int32(*pool.Spec.Replicas)
+	}
+	name := fmt.Sprintf("%s-%s", clusterID, pool.Spec.Name)
+	return &machineapi.MachineSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "machine.openshift.io/v1beta1",
+			Kind:       "MachineSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "openshift-machine-api",
+			Name:      name,
+			Labels: map[string]string{
+				"machine.openshift.io/cluster-api-cluster": clusterID,
+			},
+		},
+		Spec: machineapi.MachineSetSpec{
+			Replicas: &total,
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"machine.openshift.io/cluster-api-machineset": name,
+					"machine.openshift.io/cluster-api-cluster":    clusterID,
+				},
+			},
+			Template: machineapi.MachineTemplateSpec{
+				ObjectMeta: machineapi.ObjectMeta{
+					Labels: map[string]string{
+						"machine.openshift.io/cluster-api-machineset":   name,
+						"machine.openshift.io/cluster-api-cluster":      clusterID,
+						"machine.openshift.io/cluster-api-machine-role": workerRole,
+						"machine.openshift.io/cluster-api-machine-type": workerRole,
+					},
+				},
+				Spec: machineapi.MachineSpec{
+					ProviderSpec: machineapi.ProviderSpec{
+						Value: &runtime.RawExtension{Raw: raw},
+					},
+					// we don't need to set Versions, because we control those via cluster operators.
+				},
+			},
+		},
+	}, nil
 }
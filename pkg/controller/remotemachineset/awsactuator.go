@@ -48,6 +48,9 @@ var (
 	versionsSupportingSpotInstances = semver.MustParseRange(">=4.5.0")
 )
 
+// awsEdgeRootVolumeType is the only root volume type supported for machines on Outposts and in Local Zones.
+const awsEdgeRootVolumeType = "gp2"
+
 func addAWSProviderToScheme(scheme *runtime.Scheme) error {
 	return awsprovider.AddToScheme(scheme)
 }
@@ -55,14 +58,13 @@ func addAWSProviderToScheme(scheme *runtime.Scheme) error {
 // NewAWSActuator is the constructor for building a AWSActuator
 func NewAWSActuator(
 	client client.Client,
-	credentials awsclient.CredentialsSource,
-	region string,
+	options awsclient.Options,
 	pool *hivev1.MachinePool,
 	masterMachine *machineapi.Machine,
 	scheme *runtime.Scheme,
 	logger log.FieldLogger,
 ) (*AWSActuator, error) {
-	awsClient, err := awsclient.New(client, awsclient.Options{Region: region, CredentialsSource: credentials})
+	awsClient, err := awsclient.New(client, options)
 	if err != nil {
 		logger.WithError(err).Warn("failed to create AWS client")
 		return nil, err
@@ -81,7 +83,7 @@ func NewAWSActuator(
 		client:    client,
 		awsClient: awsClient,
 		logger:    logger,
-		region:    region,
+		region:    options.Region,
 		amiID:     amiID,
 	}
 	return actuator, nil
@@ -89,7 +91,7 @@ func NewAWSActuator(
 
 // GenerateMachineSets satisfies the Actuator interface and will take a clusterDeployment and return a list of MachineSets
 // to sync to the remote cluster.
-func (a *AWSActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, logger log.FieldLogger) ([]*machineapi.MachineSet, bool, error) {
+func (a *AWSActuator) GenerateMachineSets(ctx context.Context, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, logger log.FieldLogger) ([]*machineapi.MachineSet, bool, error) {
 	if cd.Spec.ClusterMetadata == nil {
 		return nil, false, errors.New("ClusterDeployment does not have cluster metadata")
 	}
@@ -145,7 +147,13 @@ func (a *AWSActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 	}
 
 	if len(computePool.Platform.AWS.Zones) == 0 {
-		zones, err := a.fetchAvailabilityZones()
+		for _, zs := range pool.Spec.Platform.AWS.ZoneSubnets {
+			computePool.Platform.AWS.Zones = append(computePool.Platform.AWS.Zones, zs.Zone)
+		}
+	}
+
+	if len(computePool.Platform.AWS.Zones) == 0 {
+		zones, err := a.fetchAvailabilityZones(ctx)
 		if err != nil {
 			return nil, false, errors.Wrap(err, "compute pool not providing list of zones and failed to fetch list of zones")
 		}
@@ -158,12 +166,48 @@ func (a *AWSActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 	subnets := map[string]string{}
 	// Fetching private subnets from the machinepool and then mapping availability zones to subnets
 	if len(pool.Spec.Platform.AWS.Subnets) > 0 {
-		subnetsByAvailabilityZone, err := a.getPrivateSubnetsByAvailabilityZone(pool)
+		subnetsByAvailabilityZone, err := a.getPrivateSubnetsByAvailabilityZone(ctx, pool)
 		if err != nil {
 			return nil, false, errors.Wrap(err, "describing subnets")
 		}
 		subnets = subnetsByAvailabilityZone
+	} else if len(pool.Spec.Platform.AWS.Zones) > 0 && len(pool.Spec.Platform.AWS.ZoneSubnets) == 0 {
+		// Zones may have been added to the pool after installation, in which case there is no installer subnet
+		// for them and their subnets must be discovered.
+		subnetsByAvailabilityZone, err := a.getSubnetsForZones(ctx, cd.Spec.ClusterMetadata.InfraID, pool)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "discovering subnets for zones")
+		}
+		subnets = subnetsByAvailabilityZone
+	}
+	// An explicit mapping of availability zones to subnets is used as is
+	for _, zs := range pool.Spec.Platform.AWS.ZoneSubnets {
+		subnets[zs.Zone] = zs.Subnet
+	}
+
+	unsupported, err := a.validateEdgePlacement(ctx, pool, computePool.Platform.AWS.Zones, subnets)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "validating edge placement")
+	}
+	if unsupported != "" {
+		logger.WithField("reason", unsupported).Debug("unsupported Outpost or Local Zone configuration")
+		conds, changed := controllerutils.SetMachinePoolConditionWithChangeCheck(
+			pool.Status.Conditions,
+			hivev1.UnsupportedConfigurationMachinePoolCondition,
+			corev1.ConditionTrue,
+			"UnsupportedEdgeConfiguration",
+			unsupported,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		if statusChanged || changed {
+			pool.Status.Conditions = conds
+			if err := a.client.Status().Update(context.Background(), pool); err != nil {
+				return nil, false, errors.Wrap(err, "could not update MachinePool status")
+			}
+		}
+		return nil, false, nil
 	}
+
 	// userTags are settings available in the installconfig that we are choosing
 	// to ignore for the timebeing. These empty settings should be updated to feed
 	// from the machinepool / installconfig in the future.
@@ -239,15 +283,20 @@ func getAWSAMIID(masterMachine *machineapi.Machine, scheme *runtime.Scheme, logg
 }
 
 // fetchAvailabilityZones fetches availability zones for the AWS region
-func (a *AWSActuator) fetchAvailabilityZones() ([]string, error) {
+func (a *AWSActuator) fetchAvailabilityZones(ctx context.Context) ([]string, error) {
 	zoneFilter := &ec2.Filter{
 		Name:   aws.String("region-name"),
 		Values: []*string{aws.String(a.region)},
 	}
+	// Local Zones and Wavelength Zones that the account opted in to are only used when listed in the pool.
+	zoneTypeFilter := &ec2.Filter{
+		Name:   aws.String("zone-type"),
+		Values: []*string{aws.String("availability-zone")},
+	}
 	req := &ec2.DescribeAvailabilityZonesInput{
-		Filters: []*ec2.Filter{zoneFilter},
+		Filters: []*ec2.Filter{zoneFilter, zoneTypeFilter},
 	}
-	resp, err := a.awsClient.DescribeAvailabilityZones(req)
+	resp, err := a.awsClient.DescribeAvailabilityZones(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -258,6 +307,92 @@ func (a *AWSActuator) fetchAvailabilityZones() ([]string, error) {
 	return zones, nil
 }
 
+// validateEdgePlacement checks the constraints of machines on an Outpost or in Local Zones. It returns a message
+// describing why the pool cannot be placed, or an empty message when the placement is supported.
+func (a *AWSActuator) validateEdgePlacement(ctx context.Context, pool *hivev1.MachinePool, zones []string, subnets map[string]string) (string, error) {
+	platform := pool.Spec.Platform.AWS
+
+	if platform.OutpostARN != "" {
+		if platform.EC2RootVolume.Type != awsEdgeRootVolumeType {
+			return fmt.Sprintf("Outposts only support %s root volumes", awsEdgeRootVolumeType), nil
+		}
+		if len(subnets) == 0 {
+			return "the subnets of the Outpost must be set for the pool", nil
+		}
+		subnetIDs := sets.NewString()
+		for _, subnetID := range subnets {
+			subnetIDs.Insert(subnetID)
+		}
+		resp, err := a.awsClient.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{SubnetIds: aws.StringSlice(subnetIDs.List())})
+		if err != nil {
+			return "", err
+		}
+		for _, subnet := range resp.Subnets {
+			if aws.StringValue(subnet.OutpostArn) != platform.OutpostARN {
+				return fmt.Sprintf("subnet %s is not on Outpost %s", aws.StringValue(subnet.SubnetId), platform.OutpostARN), nil
+			}
+		}
+		return "", nil
+	}
+
+	// Only look up the type of zones named like Local Zones rather than like the availability zones of the region.
+	candidates := []*string{}
+	for _, zone := range zones {
+		if isEdgeZoneName(a.region, zone) {
+			candidates = append(candidates, aws.String(zone))
+		}
+	}
+	if len(candidates) == 0 {
+		return "", nil
+	}
+	zonesResp, err := a.awsClient.DescribeAvailabilityZones(ctx, &ec2.DescribeAvailabilityZonesInput{
+		AllAvailabilityZones: aws.Bool(true),
+		ZoneNames:            candidates,
+	})
+	if err != nil {
+		return "", err
+	}
+	localZones := []*string{}
+	for _, zone := range zonesResp.AvailabilityZones {
+		if aws.StringValue(zone.ZoneType) == "local-zone" {
+			localZones = append(localZones, zone.ZoneName)
+		}
+	}
+	if len(localZones) == 0 {
+		return "", nil
+	}
+	if platform.EC2RootVolume.Type != awsEdgeRootVolumeType {
+		return fmt.Sprintf("Local Zones only support %s root volumes", awsEdgeRootVolumeType), nil
+	}
+	// The offerings are filtered to a single instance type in a few zones, so they fit in one page.
+	offeringsResp, err := a.awsClient.DescribeInstanceTypeOfferings(ctx, &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: aws.String(ec2.LocationTypeAvailabilityZone),
+		Filters: []*ec2.Filter{
+			{Name: aws.String("location"), Values: localZones},
+			{Name: aws.String("instance-type"), Values: []*string{aws.String(platform.InstanceType)}},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	offered := sets.NewString()
+	for _, offering := range offeringsResp.InstanceTypeOfferings {
+		offered.Insert(aws.StringValue(offering.Location))
+	}
+	for _, zone := range localZones {
+		if !offered.Has(aws.StringValue(zone)) {
+			return fmt.Sprintf("instance type %s is not offered in Local Zone %s", platform.InstanceType, aws.StringValue(zone)), nil
+		}
+	}
+	return "", nil
+}
+
+// isEdgeZoneName returns whether the zone is named like a Local Zone or Wavelength Zone of the region, such as
+// us-east-1-bos-1a, rather than like an availability zone of the region, such as us-east-1a.
+func isEdgeZoneName(region, zone string) bool {
+	return strings.HasPrefix(zone, region+"-")
+}
+
 func decodeAWSMachineProviderSpec(rawExt *runtime.RawExtension, scheme *runtime.Scheme) (*awsproviderv1beta1.AWSMachineProviderConfig, error) {
 	codecFactory := serializer.NewCodecFactory(scheme)
 	decoder := codecFactory.UniversalDecoder(awsproviderv1beta1.SchemeGroupVersion)
@@ -313,14 +448,92 @@ func (a *AWSActuator) updateProviderConfig(machineSet *machineapi.MachineSet, in
 
 }
 
+// getSubnetsForZones maps the zones of a pool which does not specify its subnets to private subnets. When every zone
+// has a private subnet created by the installer, an empty mapping is returned and the installer subnets are found by
+// name. Otherwise the zones without an installer subnet, typically zones added to the pool after installation, are
+// mapped to the private subnets of the VPC of the cluster tagged for the cluster in those zones.
+func (a *AWSActuator) getSubnetsForZones(ctx context.Context, infraID string, pool *hivev1.MachinePool) (map[string]string, error) {
+	zones := pool.Spec.Platform.AWS.Zones
+	names := make([]*string, len(zones))
+	for i, zone := range zones {
+		names[i] = aws.String(fmt.Sprintf("%s-private-%s", infraID, zone))
+	}
+	results, err := a.awsClient.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+		Filters: []*ec2.Filter{{Name: aws.String("tag:Name"), Values: names}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	subnetsByAvailabilityZone := map[string]string{}
+	vpc := ""
+	for _, subnet := range results.Subnets {
+		subnetsByAvailabilityZone[aws.StringValue(subnet.AvailabilityZone)] = aws.StringValue(subnet.SubnetId)
+		vpc = aws.StringValue(subnet.VpcId)
+	}
+	missingZones := []*string{}
+	for _, zone := range zones {
+		if _, ok := subnetsByAvailabilityZone[zone]; !ok {
+			missingZones = append(missingZones, aws.String(zone))
+		}
+	}
+	// Without any installer subnet, the subnets of the cluster cannot be told apart and the installer subnets are
+	// assumed to exist.
+	if len(missingZones) == 0 || vpc == "" {
+		return map[string]string{}, nil
+	}
+
+	a.logger.WithField("zones", aws.StringValueSlice(missingZones)).Info("discovering subnets for zones without an installer subnet")
+	results, err = a.awsClient.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("vpc-id"), Values: []*string{aws.String(vpc)}},
+			{Name: aws.String("availability-zone"), Values: missingZones},
+			{Name: aws.String("tag-key"), Values: []*string{aws.String(fmt.Sprintf("kubernetes.io/cluster/%s", infraID))}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Subnets) == 0 {
+		// The missing zones are reported when generating the machinesets.
+		return subnetsByAvailabilityZone, nil
+	}
+	routeTables, err := a.awsClient.DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("vpc-id"),
+			Values: []*string{aws.String(vpc)},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	privateSubnets := map[string]ec2.Subnet{}
+	for _, subnet := range results.Subnets {
+		isPublic, err := isSubnetPublic(routeTables.RouteTables, *subnet.SubnetId, a.logger)
+		if err != nil {
+			return nil, err
+		}
+		if !isPublic {
+			privateSubnets[*subnet.SubnetId] = *subnet
+		}
+	}
+	discovered, err := a.validateSubnets(privateSubnets, pool)
+	if err != nil {
+		return nil, err
+	}
+	for zone, subnet := range discovered {
+		subnetsByAvailabilityZone[zone] = subnet
+	}
+	return subnetsByAvailabilityZone, nil
+}
+
 // getPrivateSubnetsByAvailabilityZones maps availability zones to private subnet
-func (a *AWSActuator) getPrivateSubnetsByAvailabilityZone(pool *hivev1.MachinePool) (map[string]string, error) {
+func (a *AWSActuator) getPrivateSubnetsByAvailabilityZone(ctx context.Context, pool *hivev1.MachinePool) (map[string]string, error) {
 	idPointers := make([]*string, len(pool.Spec.Platform.AWS.Subnets))
 	for i, id := range pool.Spec.Platform.AWS.Subnets {
 		idPointers[i] = aws.String(id)
 	}
 
-	results, err := a.awsClient.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: idPointers})
+	results, err := a.awsClient.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{SubnetIds: idPointers})
 	if err != nil || len(results.Subnets) == 0 {
 		if strings.Contains(err.Error(), "InvalidSubnetID.NotFound") {
 			var conditionMessage string
@@ -353,7 +566,7 @@ func (a *AWSActuator) getPrivateSubnetsByAvailabilityZone(pool *hivev1.MachinePo
 		return nil, errors.Errorf("%s has no VPC", *results.Subnets[0].SubnetId)
 	}
 
-	routeTables, err := a.awsClient.DescribeRouteTables(&ec2.DescribeRouteTablesInput{
+	routeTables, err := a.awsClient.DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{
 		Filters: []*ec2.Filter{{
 			Name:   aws.String("vpc-id"),
 			Values: []*string{aws.String(vpc)},
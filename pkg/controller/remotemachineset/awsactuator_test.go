@@ -30,6 +30,11 @@ import (
 	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
 )
 
+const (
+	testOutpostARN = "arn:aws:outposts:test-region:123456789012:outpost/op-0123456789abcdef0"
+	testLocalZone  = "test-region-lax-1a"
+)
+
 func TestAWSActuator(t *testing.T) {
 	tests := []struct {
 		name                         string
@@ -83,11 +88,63 @@ func TestAWSActuator(t *testing.T) {
 					return pool
 				}(),
 			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeInstallerSubnets(client, []string{"zone1", "zone2", "zone3"}, []string{"zone1", "zone2", "zone3"}, "vpc-1")
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAWSMachineSetName("zone1"): 1,
+				generateAWSMachineSetName("zone2"): 1,
+				generateAWSMachineSetName("zone3"): 1,
+			},
+		},
+		{
+			name:              "generate machinesets for zone added after installation",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				func() *hivev1.MachinePool {
+					pool := testMachinePool()
+					pool.Spec.Platform.AWS.Zones = []string{"zone1", "zone2", "zone3"}
+					return pool
+				}(),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeInstallerSubnets(client, []string{"zone1", "zone2", "zone3"}, []string{"zone1", "zone2"}, "vpc-1")
+				mockDescribeClusterSubnets(client, []string{"zone3"}, []string{"zone3", "zone3"},
+					[]string{"subnet-zone3", "pubSubnet-zone3"}, "vpc-1")
+				mockDescribeRouteTables(client, map[string]bool{
+					"subnet-zone3":    false,
+					"pubSubnet-zone3": true,
+				}, "vpc-1")
+			},
 			expectedMachineSetReplicas: map[string]int64{
 				generateAWSMachineSetName("zone1"): 1,
 				generateAWSMachineSetName("zone2"): 1,
 				generateAWSMachineSetName("zone3"): 1,
 			},
+			expectedSubnetIDInMachineSet: true,
+		},
+		{
+			name:              "no subnet for zone added after installation",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				func() *hivev1.MachinePool {
+					pool := testMachinePool()
+					pool.Spec.Platform.AWS.Zones = []string{"zone1", "zone2", "zone3"}
+					return pool
+				}(),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeInstallerSubnets(client, []string{"zone1", "zone2", "zone3"}, []string{"zone1", "zone2"}, "vpc-1")
+				mockDescribeClusterSubnets(client, []string{"zone3"}, nil, nil, "vpc-1")
+			},
+			expectedErr: true,
+			expectedCondition: &hivev1.MachinePoolCondition{
+				Type:   hivev1.InvalidSubnetsMachinePoolCondition,
+				Status: corev1.ConditionTrue,
+				Reason: "NoSubnetForAvailabilityZone",
+			},
 		},
 		{
 			name:              "generate machinesets for specified zones and subnets",
@@ -122,6 +179,119 @@ func TestAWSActuator(t *testing.T) {
 			},
 			expectedSubnetIDInMachineSet: true,
 		},
+		{
+			name:              "generate machinesets for zone subnets",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				func() *hivev1.MachinePool {
+					pool := testMachinePool()
+					pool.Spec.Platform.AWS.ZoneSubnets = []awshivev1.ZoneSubnet{
+						{Zone: "zone1", Subnet: "subnet-zone1"},
+						{Zone: "zone2", Subnet: "subnet-zone2"},
+					}
+					return pool
+				}(),
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAWSMachineSetName("zone1"): 2,
+				generateAWSMachineSetName("zone2"): 1,
+			},
+			expectedSubnetIDInMachineSet: true,
+		},
+		{
+			name:              "generate machinesets for outpost subnets",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				withOutpost(testMachinePool(), "gp2"),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeOutpostSubnets(client, map[string]string{"subnet-zone1": testOutpostARN})
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAWSMachineSetName("zone1"): 3,
+			},
+			expectedSubnetIDInMachineSet: true,
+		},
+		{
+			name:              "subnet not on outpost",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				withOutpost(testMachinePool(), "gp2"),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeOutpostSubnets(client, map[string]string{"subnet-zone1": ""})
+			},
+			expectedCondition: &hivev1.MachinePoolCondition{
+				Type:   hivev1.UnsupportedConfigurationMachinePoolCondition,
+				Status: corev1.ConditionTrue,
+				Reason: "UnsupportedEdgeConfiguration",
+			},
+		},
+		{
+			name:              "unsupported root volume type on outpost",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				withOutpost(testMachinePool(), "gp3"),
+			},
+			expectedCondition: &hivev1.MachinePoolCondition{
+				Type:   hivev1.UnsupportedConfigurationMachinePoolCondition,
+				Status: corev1.ConditionTrue,
+				Reason: "UnsupportedEdgeConfiguration",
+			},
+		},
+		{
+			name:              "generate machinesets for local zone",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				withLocalZone(testMachinePool(), "gp2"),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeLocalZones(client, testLocalZone)
+				mockDescribeInstanceTypeOfferings(client, []string{testLocalZone}, []string{testLocalZone})
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAWSMachineSetName(testLocalZone): 3,
+			},
+			expectedSubnetIDInMachineSet: true,
+		},
+		{
+			name:              "instance type not offered in local zone",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				withLocalZone(testMachinePool(), "gp2"),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeLocalZones(client, testLocalZone)
+				mockDescribeInstanceTypeOfferings(client, []string{testLocalZone}, nil)
+			},
+			expectedCondition: &hivev1.MachinePoolCondition{
+				Type:   hivev1.UnsupportedConfigurationMachinePoolCondition,
+				Status: corev1.ConditionTrue,
+				Reason: "UnsupportedEdgeConfiguration",
+			},
+		},
+		{
+			name:              "unsupported root volume type in local zone",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				withLocalZone(testMachinePool(), "io1"),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeLocalZones(client, testLocalZone)
+			},
+			expectedCondition: &hivev1.MachinePoolCondition{
+				Type:   hivev1.UnsupportedConfigurationMachinePoolCondition,
+				Status: corev1.ConditionTrue,
+				Reason: "UnsupportedEdgeConfiguration",
+			},
+		},
 		{
 			name:              "list zones returns zero",
 			clusterDeployment: testClusterDeployment(),
@@ -334,7 +504,7 @@ func TestAWSActuator(t *testing.T) {
 			err := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: test.poolName}, pool)
 			require.NoError(t, err)
 
-			generatedMachineSets, _, err := actuator.GenerateMachineSets(test.clusterDeployment, pool, actuator.logger)
+			generatedMachineSets, _, err := actuator.GenerateMachineSets(context.TODO(), test.clusterDeployment, pool, actuator.logger)
 			if test.expectedErr {
 				assert.Error(t, err, "expected error for test case")
 			} else {
@@ -419,10 +589,16 @@ func validateAWSMachineSets(t *testing.T, mSets []*machineapi.MachineSet, expect
 
 func mockDescribeAvailabilityZones(client *mockaws.MockClient, zones []string) {
 	input := &ec2.DescribeAvailabilityZonesInput{
-		Filters: []*ec2.Filter{{
-			Name:   pointer.StringPtr("region-name"),
-			Values: []*string{pointer.StringPtr(testRegion)},
-		}},
+		Filters: []*ec2.Filter{
+			{
+				Name:   pointer.StringPtr("region-name"),
+				Values: []*string{pointer.StringPtr(testRegion)},
+			},
+			{
+				Name:   pointer.StringPtr("zone-type"),
+				Values: []*string{pointer.StringPtr("availability-zone")},
+			},
+		},
 	}
 	availabilityZones := make([]*ec2.AvailabilityZone, len(zones))
 	for i := range zones {
@@ -433,7 +609,55 @@ func mockDescribeAvailabilityZones(client *mockaws.MockClient, zones []string) {
 	output := &ec2.DescribeAvailabilityZonesOutput{
 		AvailabilityZones: availabilityZones,
 	}
-	client.EXPECT().DescribeAvailabilityZones(input).Return(output, nil)
+	client.EXPECT().DescribeAvailabilityZones(gomock.Any(), input).Return(output, nil)
+}
+
+func mockDescribeLocalZones(client *mockaws.MockClient, zone string) {
+	input := &ec2.DescribeAvailabilityZonesInput{
+		AllAvailabilityZones: aws.Bool(true),
+		ZoneNames:            []*string{aws.String(zone)},
+	}
+	output := &ec2.DescribeAvailabilityZonesOutput{
+		AvailabilityZones: []*ec2.AvailabilityZone{{
+			ZoneName: aws.String(zone),
+			ZoneType: aws.String("local-zone"),
+		}},
+	}
+	client.EXPECT().DescribeAvailabilityZones(gomock.Any(), input).Return(output, nil)
+}
+
+func mockDescribeInstanceTypeOfferings(client *mockaws.MockClient, zones []string, offeredZones []string) {
+	input := &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: aws.String("availability-zone"),
+		Filters: []*ec2.Filter{
+			{Name: aws.String("location"), Values: aws.StringSlice(zones)},
+			{Name: aws.String("instance-type"), Values: []*string{aws.String(testInstanceType)}},
+		},
+	}
+	output := &ec2.DescribeInstanceTypeOfferingsOutput{}
+	for _, zone := range offeredZones {
+		output.InstanceTypeOfferings = append(output.InstanceTypeOfferings, &ec2.InstanceTypeOffering{
+			InstanceType: aws.String(testInstanceType),
+			Location:     aws.String(zone),
+			LocationType: aws.String("availability-zone"),
+		})
+	}
+	client.EXPECT().DescribeInstanceTypeOfferings(gomock.Any(), input).Return(output, nil)
+}
+
+// mockDescribeOutpostSubnets mocks the lookup of the Outposts of subnets, given as a map of subnet ID to Outpost ARN.
+func mockDescribeOutpostSubnets(client *mockaws.MockClient, outposts map[string]string) {
+	input := &ec2.DescribeSubnetsInput{}
+	output := &ec2.DescribeSubnetsOutput{}
+	for subnetID, outpostARN := range outposts {
+		input.SubnetIds = append(input.SubnetIds, aws.String(subnetID))
+		subnet := &ec2.Subnet{SubnetId: aws.String(subnetID)}
+		if outpostARN != "" {
+			subnet.OutpostArn = aws.String(outpostARN)
+		}
+		output.Subnets = append(output.Subnets, subnet)
+	}
+	client.EXPECT().DescribeSubnets(gomock.Any(), input).Return(output, nil)
 }
 
 func mockDescribeSubnets(client *mockaws.MockClient, zones []string, privateSubnetIDs []string, pubSubnetIDs []string, vpcID string) {
@@ -465,7 +689,48 @@ func mockDescribeSubnets(client *mockaws.MockClient, zones []string, privateSubn
 	output := &ec2.DescribeSubnetsOutput{
 		Subnets: subnets,
 	}
-	client.EXPECT().DescribeSubnets(input).Return(output, nil)
+	client.EXPECT().DescribeSubnets(gomock.Any(), input).Return(output, nil)
+}
+
+// mockDescribeInstallerSubnets mocks the lookup of the private subnets created by the installer for the zones, of
+// which subnets are found for the installedZones.
+func mockDescribeInstallerSubnets(client *mockaws.MockClient, zones []string, installedZones []string, vpcID string) {
+	names := make([]*string, len(zones))
+	for i, zone := range zones {
+		names[i] = aws.String(fmt.Sprintf("%s-private-%s", testInfraID, zone))
+	}
+	input := &ec2.DescribeSubnetsInput{
+		Filters: []*ec2.Filter{{Name: aws.String("tag:Name"), Values: names}},
+	}
+	subnets := make([]*ec2.Subnet, len(installedZones))
+	for i, zone := range installedZones {
+		subnets[i] = &ec2.Subnet{
+			SubnetId:         aws.String("subnet-" + zone),
+			AvailabilityZone: aws.String(zone),
+			VpcId:            aws.String(vpcID),
+		}
+	}
+	client.EXPECT().DescribeSubnets(gomock.Any(), input).Return(&ec2.DescribeSubnetsOutput{Subnets: subnets}, nil)
+}
+
+// mockDescribeClusterSubnets mocks the discovery of the subnets tagged for the cluster in the missingZones.
+func mockDescribeClusterSubnets(client *mockaws.MockClient, missingZones []string, zones []string, subnetIDs []string, vpcID string) {
+	input := &ec2.DescribeSubnetsInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("vpc-id"), Values: []*string{aws.String(vpcID)}},
+			{Name: aws.String("availability-zone"), Values: aws.StringSlice(missingZones)},
+			{Name: aws.String("tag-key"), Values: []*string{aws.String(fmt.Sprintf("kubernetes.io/cluster/%s", testInfraID))}},
+		},
+	}
+	subnets := make([]*ec2.Subnet, len(subnetIDs))
+	for i := range subnetIDs {
+		subnets[i] = &ec2.Subnet{
+			SubnetId:         aws.String(subnetIDs[i]),
+			AvailabilityZone: aws.String(zones[i]),
+			VpcId:            aws.String(vpcID),
+		}
+	}
+	client.EXPECT().DescribeSubnets(gomock.Any(), input).Return(&ec2.DescribeSubnetsOutput{Subnets: subnets}, nil)
 }
 
 func mockDescribeMissingSubnets(client *mockaws.MockClient, subnetIDs []string) {
@@ -476,7 +741,7 @@ func mockDescribeMissingSubnets(client *mockaws.MockClient, subnetIDs []string)
 	input := &ec2.DescribeSubnetsInput{
 		SubnetIds: idPointers,
 	}
-	client.EXPECT().DescribeSubnets(input).Return(nil, fmt.Errorf("InvalidSubnets"))
+	client.EXPECT().DescribeSubnets(gomock.Any(), input).Return(nil, fmt.Errorf("InvalidSubnets"))
 }
 
 func mockDescribeRouteTables(client *mockaws.MockClient, subnets map[string]bool, vpc string) {
@@ -491,7 +756,7 @@ func mockDescribeRouteTables(client *mockaws.MockClient, subnets map[string]bool
 		RouteTables: constructRouteTables(subnets),
 	}
 
-	client.EXPECT().DescribeRouteTables(input).Return(output, nil)
+	client.EXPECT().DescribeRouteTables(gomock.Any(), input).Return(output, nil)
 }
 
 // Takes a list of subnets with bool indicating if the corresponding subnet is public
@@ -553,3 +818,16 @@ func withSpotMarketOptions(pool *hivev1.MachinePool) *hivev1.MachinePool {
 	pool.Spec.Platform.AWS.SpotMarketOptions = &awshivev1.SpotMarketOptions{}
 	return pool
 }
+
+func withOutpost(pool *hivev1.MachinePool, rootVolumeType string) *hivev1.MachinePool {
+	pool.Spec.Platform.AWS.OutpostARN = testOutpostARN
+	pool.Spec.Platform.AWS.ZoneSubnets = []awshivev1.ZoneSubnet{{Zone: "zone1", Subnet: "subnet-zone1"}}
+	pool.Spec.Platform.AWS.EC2RootVolume.Type = rootVolumeType
+	return pool
+}
+
+func withLocalZone(pool *hivev1.MachinePool, rootVolumeType string) *hivev1.MachinePool {
+	pool.Spec.Platform.AWS.ZoneSubnets = []awshivev1.ZoneSubnet{{Zone: testLocalZone, Subnet: "subnet-" + testLocalZone}}
+	pool.Spec.Platform.AWS.EC2RootVolume.Type = rootVolumeType
+	return pool
+}
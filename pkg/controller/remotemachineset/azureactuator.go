@@ -15,9 +15,12 @@ import (
 	installazure "github.com/openshift/installer/pkg/asset/machines/azure"
 	installertypes "github.com/openshift/installer/pkg/types"
 	installertypesazure "github.com/openshift/installer/pkg/types/azure"
+	azureprovider "sigs.k8s.io/cluster-api-provider-azure/pkg/apis/azureprovider/v1beta1"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	"github.com/openshift/hive/pkg/azureclient"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 // AzureActuator encapsulates the pieces necessary to be able to generate
@@ -30,8 +33,8 @@ type AzureActuator struct {
 var _ Actuator = &AzureActuator{}
 
 // NewAzureActuator is the constructor for building a AzureActuator
-func NewAzureActuator(azureCreds *corev1.Secret, logger log.FieldLogger) (*AzureActuator, error) {
-	azureClient, err := azureclient.NewClientFromSecret(azureCreds)
+func NewAzureActuator(azureCreds *corev1.Secret, proxy *controllerutils.CloudClientProxy, logger log.FieldLogger) (*AzureActuator, error) {
+	azureClient, err := azureclient.NewClientFromSecretWithOptions(azureCreds, hivev1azure.PublicCloud, azureclient.Options{Proxy: proxy, Controller: hivev1.RemoteMachinesetControllerName})
 	if err != nil {
 		logger.WithError(err).Warn("failed to create Azure client with creds in clusterDeployment's secret")
 		return nil, err
@@ -45,7 +48,7 @@ func NewAzureActuator(azureCreds *corev1.Secret, logger log.FieldLogger) (*Azure
 
 // GenerateMachineSets satisfies the Actuator interface and will take a clusterDeployment and return a list of MachineSets
 // to sync to the remote cluster.
-func (a *AzureActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, logger log.FieldLogger) ([]*machineapi.MachineSet, bool, error) {
+func (a *AzureActuator) GenerateMachineSets(ctx context.Context, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, logger log.FieldLogger) ([]*machineapi.MachineSet, bool, error) {
 	if cd.Spec.ClusterMetadata == nil {
 		return nil, false, errors.New("ClusterDeployment does not have cluster metadata")
 	}
@@ -70,11 +73,18 @@ func (a *AzureActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *
 		InstanceType: pool.Spec.Platform.Azure.InstanceType,
 		OSDisk: installertypesazure.OSDisk{
 			DiskSizeGB: pool.Spec.Platform.Azure.OSDisk.DiskSizeGB,
+			DiskType:   pool.Spec.Platform.Azure.OSDisk.DiskType,
 		},
 	}
 
 	if len(computePool.Platform.Azure.Zones) == 0 {
-		zones, err := a.getZones(cd.Spec.Platform.Azure.Region, pool.Spec.Platform.Azure.InstanceType)
+		for _, zs := range pool.Spec.Platform.Azure.ZoneSubnets {
+			computePool.Platform.Azure.Zones = append(computePool.Platform.Azure.Zones, zs.Zone)
+		}
+	}
+
+	if len(computePool.Platform.Azure.Zones) == 0 {
+		zones, err := a.getZones(ctx, cd.Spec.Platform.Azure.Region, pool.Spec.Platform.Azure.InstanceType)
 		if err != nil {
 			return nil, false, errors.Wrap(err, "compute pool not providing list of zones and failed to fetch list of zones")
 		}
@@ -95,11 +105,55 @@ func (a *AzureActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *
 		workerRole,
 		workerUserDataName,
 	)
-	return installerMachineSets, err == nil, errors.Wrap(err, "failed to generate machinesets")
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to generate machinesets")
+	}
+
+	if des := pool.Spec.Platform.Azure.OSDisk.DiskEncryptionSet; des != nil {
+		for _, ms := range installerMachineSets {
+			providerSpec := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*azureprovider.AzureMachineProviderSpec)
+			providerSpec.OSDisk.ManagedDisk.DiskEncryptionSet = &azureprovider.DiskEncryptionSetParameters{ID: des.ID()}
+		}
+	}
+
+	if len(pool.Spec.Platform.Azure.ZoneSubnets) > 0 {
+		subnets := map[string]string{}
+		for _, zs := range pool.Spec.Platform.Azure.ZoneSubnets {
+			subnets[zs.Zone] = zs.Subnet
+		}
+		for _, ms := range installerMachineSets {
+			providerSpec := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*azureprovider.AzureMachineProviderSpec)
+			zone := to.String(providerSpec.Zone)
+			subnet, ok := subnets[zone]
+			if !ok {
+				return nil, false, errors.Errorf("no subnet for zone %s", zone)
+			}
+			providerSpec.Subnet = subnet
+		}
+	}
+
+	if pool.Spec.Platform.Azure.EncryptionAtHost {
+		for _, ms := range installerMachineSets {
+			if err := setAzureEncryptionAtHost(ms); err != nil {
+				return nil, false, errors.Wrap(err, "failed to enable encryption at host")
+			}
+		}
+	}
+
+	return installerMachineSets, true, nil
+}
+
+// setAzureEncryptionAtHost enables encryption at host in the provider spec of a MachineSet. The vendored
+// AzureMachineProviderSpec predates the securityProfile of the Machine API, so it is added to the raw provider spec.
+// This must be the last change made to the provider spec.
+func setAzureEncryptionAtHost(ms *machineapi.MachineSet) error {
+	return patchRawProviderSpec(ms, func(providerSpec map[string]interface{}) {
+		providerSpec["securityProfile"] = map[string]interface{}{"encryptionAtHost": true}
+	})
 }
 
-func (a *AzureActuator) getZones(region string, instanceType string) ([]string, error) {
-	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+func (a *AzureActuator) getZones(ctx context.Context, region string, instanceType string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	var res azureclient.ResourceSKUsPage
@@ -1,6 +1,8 @@
 package remotemachineset
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -8,6 +10,7 @@ import (
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/pointer"
 
@@ -26,6 +29,9 @@ func TestAzureActuator(t *testing.T) {
 		clusterDeployment          *hivev1.ClusterDeployment
 		pool                       *hivev1.MachinePool
 		expectedMachineSetReplicas map[string]int64
+		expectedSubnets            map[string]string
+		expectedDiskType           string
+		expectedDiskEncryptionSet  string
 		expectedErr                bool
 	}{
 		{
@@ -67,6 +73,51 @@ func TestAzureActuator(t *testing.T) {
 				generateAzureMachineSetName("zone3"): 1,
 			},
 		},
+		{
+			name:              "generate machinesets for zone subnets",
+			clusterDeployment: testAzureClusterDeployment(),
+			pool: func() *hivev1.MachinePool {
+				pool := testAzurePool()
+				pool.Spec.Platform.Azure.ZoneSubnets = []hivev1azure.ZoneSubnet{
+					{Zone: "zone1", Subnet: "subnet1"},
+					{Zone: "zone2", Subnet: "subnet2"},
+					{Zone: "zone3", Subnet: "subnet3"},
+				}
+				return pool
+			}(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAzureMachineSetName("zone1"): 1,
+				generateAzureMachineSetName("zone2"): 1,
+				generateAzureMachineSetName("zone3"): 1,
+			},
+			expectedSubnets: map[string]string{
+				"zone1": "subnet1",
+				"zone2": "subnet2",
+				"zone3": "subnet3",
+			},
+		},
+		{
+			name:              "generate machinesets with disk options",
+			clusterDeployment: testAzureClusterDeployment(),
+			pool: func() *hivev1.MachinePool {
+				pool := testAzurePool()
+				pool.Spec.Platform.Azure.Zones = []string{"zone1"}
+				pool.Spec.Platform.Azure.OSDisk.DiskType = "StandardSSD_LRS"
+				pool.Spec.Platform.Azure.OSDisk.DiskEncryptionSet = &hivev1azure.DiskEncryptionSet{
+					SubscriptionID: "test-subscription",
+					ResourceGroup:  "test-resource-group",
+					Name:           "test-disk-encryption-set",
+				}
+				return pool
+			}(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAzureMachineSetName("zone1"): 3,
+			},
+			expectedDiskType:          "StandardSSD_LRS",
+			expectedDiskEncryptionSet: "/subscriptions/test-subscription/resourceGroups/test-resource-group/providers/Microsoft.Compute/diskEncryptionSets/test-disk-encryption-set",
+		},
 		{
 			name:              "more replicas than zones",
 			clusterDeployment: testAzureClusterDeployment(),
@@ -126,13 +177,33 @@ func TestAzureActuator(t *testing.T) {
 				logger: log.WithField("actuator", "azureactuator"),
 			}
 
-			generatedMachineSets, _, err := actuator.GenerateMachineSets(test.clusterDeployment, test.pool, actuator.logger)
+			generatedMachineSets, _, err := actuator.GenerateMachineSets(context.TODO(), test.clusterDeployment, test.pool, actuator.logger)
 
 			if test.expectedErr {
 				assert.Error(t, err, "expected error for test case")
 			} else {
 				validateAzureMachineSets(t, generatedMachineSets, test.expectedMachineSetReplicas)
 			}
+			for _, ms := range generatedMachineSets {
+				azureProvider := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*azureprovider.AzureMachineProviderSpec)
+				expectedDiskType := test.expectedDiskType
+				if expectedDiskType == "" {
+					expectedDiskType = "Premium_LRS"
+				}
+				assert.Equal(t, expectedDiskType, azureProvider.OSDisk.ManagedDisk.StorageAccountType, "unexpected disk type")
+				if test.expectedDiskEncryptionSet == "" {
+					assert.Nil(t, azureProvider.OSDisk.ManagedDisk.DiskEncryptionSet, "unexpected disk encryption set")
+				} else if assert.NotNil(t, azureProvider.OSDisk.ManagedDisk.DiskEncryptionSet, "missing disk encryption set") {
+					assert.Equal(t, test.expectedDiskEncryptionSet, azureProvider.OSDisk.ManagedDisk.DiskEncryptionSet.ID, "unexpected disk encryption set")
+				}
+			}
+			if test.expectedSubnets != nil {
+				for _, ms := range generatedMachineSets {
+					azureProvider := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*azureprovider.AzureMachineProviderSpec)
+					zone := pointer.StringPtrDerefOr(azureProvider.Zone, "")
+					assert.Equal(t, test.expectedSubnets[zone], azureProvider.Subnet, "unexpected subnet for zone %s", zone)
+				}
+			}
 		})
 	}
 }
@@ -198,3 +269,20 @@ func testAzureClusterDeployment() *hivev1.ClusterDeployment {
 	}
 	return cd
 }
+
+func TestSetAzureEncryptionAtHost(t *testing.T) {
+	actuator := &AzureActuator{logger: log.WithField("actuator", "azureactuator")}
+	pool := testAzurePool()
+	pool.Spec.Platform.Azure.Zones = []string{"zone1"}
+	pool.Spec.Platform.Azure.EncryptionAtHost = true
+
+	generatedMachineSets, _, err := actuator.GenerateMachineSets(context.TODO(), testAzureClusterDeployment(), pool, actuator.logger)
+	require.NoError(t, err, "unexpected error generating machinesets")
+	require.Len(t, generatedMachineSets, 1, "unexpected number of machinesets")
+
+	providerSpec := map[string]interface{}{}
+	err = json.Unmarshal(generatedMachineSets[0].Spec.Template.Spec.ProviderSpec.Value.Raw, &providerSpec)
+	require.NoError(t, err, "unexpected error decoding provider spec")
+	assert.Equal(t, map[string]interface{}{"encryptionAtHost": true}, providerSpec["securityProfile"], "unexpected security profile")
+	assert.Equal(t, testInstanceType, providerSpec["vmSize"], "unexpected instance type")
+}
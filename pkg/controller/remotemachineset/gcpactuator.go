@@ -38,6 +38,13 @@ const (
 
 	defaultGCPDiskType   = "pd-ssd"
 	defaultGCPDiskSizeGB = 128
+
+	// Local SSDs on GCP always have a fixed size.
+	gcpLocalSSDDiskType = "local-ssd"
+	gcpLocalSSDSizeGB   = 375
+
+	// gcpTerminateOnHostMaintenance is the host maintenance behavior of instances that cannot be live migrated.
+	gcpTerminateOnHostMaintenance = "Terminate"
 )
 
 var (
@@ -71,6 +78,7 @@ func addGCPProviderToScheme(scheme *runtime.Scheme) error {
 func NewGCPActuator(
 	client client.Client,
 	gcpCreds *corev1.Secret,
+	proxy *controllerutils.CloudClientProxy,
 	clusterVersion string,
 	masterMachine *machineapi.Machine,
 	remoteMachineSets []machineapi.MachineSet,
@@ -78,7 +86,7 @@ func NewGCPActuator(
 	expectations controllerutils.ExpectationsInterface,
 	logger log.FieldLogger,
 ) (*GCPActuator, error) {
-	gcpClient, err := gcpclient.NewClientFromSecret(gcpCreds)
+	gcpClient, err := gcpclient.NewClientFromSecretWithOptions(gcpCreds, gcpclient.Options{Proxy: proxy, Controller: hivev1.RemoteMachinesetControllerName})
 	if err != nil {
 		logger.WithError(err).Warn("failed to create GCP client with creds in clusterDeployment's secret")
 		return nil, err
@@ -119,7 +127,7 @@ func NewGCPActuator(
 
 // GenerateMachineSets satisfies the Actuator interface and will take a clusterDeployment and return a list of MachineSets
 // to sync to the remote cluster.
-func (a *GCPActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, logger log.FieldLogger) ([]*machineapi.MachineSet, bool, error) {
+func (a *GCPActuator) GenerateMachineSets(ctx context.Context, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, logger log.FieldLogger) ([]*machineapi.MachineSet, bool, error) {
 	if cd.Spec.ClusterMetadata == nil {
 		return nil, false, errors.New("ClusterDeployment does not have cluster metadata")
 	}
@@ -185,7 +193,7 @@ func (a *GCPActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 	computePool.Name = poolName
 	computePool.Platform.GCP = &installertypesgcp.MachinePool{
 		Zones:        pool.Spec.Platform.GCP.Zones,
-		InstanceType: pool.Spec.Platform.GCP.InstanceType,
+		InstanceType: pool.Spec.Platform.GCP.MachineType(),
 		// May be overridden below:
 		OSDisk: installertypesgcp.OSDisk{
 			DiskType:   defaultGCPDiskType,
@@ -217,7 +225,13 @@ func (a *GCPActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 	}
 
 	if len(computePool.Platform.GCP.Zones) == 0 {
-		zones, err := a.getZones(cd.Spec.Platform.GCP.Region)
+		for _, zs := range poolGCP.ZoneSubnets {
+			computePool.Platform.GCP.Zones = append(computePool.Platform.GCP.Zones, zs.Zone)
+		}
+	}
+
+	if len(computePool.Platform.GCP.Zones) == 0 {
+		zones, err := a.getZones(ctx, cd.Spec.Platform.GCP.Region)
 		if err != nil {
 			return nil, false, errors.Wrap(err, "compute pool not providing list of zones and failed to fetch list of zones")
 		}
@@ -236,10 +250,80 @@ func (a *GCPActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 		workerRole,
 		workerUserDataName,
 	)
-	return installerMachineSets, err == nil, errors.Wrap(err, "failed to generate machinesets")
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to generate machinesets")
+	}
+
+	if len(poolGCP.ZoneSubnets) > 0 {
+		subnets := map[string]string{}
+		for _, zs := range poolGCP.ZoneSubnets {
+			subnets[zs.Zone] = zs.Subnet
+		}
+		for _, ms := range installerMachineSets {
+			providerSpec := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*gcpproviderv1beta1.GCPMachineProviderSpec)
+			subnet, ok := subnets[providerSpec.Zone]
+			if !ok {
+				return nil, false, errors.Errorf("no subnet for zone %s", providerSpec.Zone)
+			}
+			for _, nic := range providerSpec.NetworkInterfaces {
+				nic.Subnetwork = subnet
+			}
+		}
+	}
+
+	if localSSDs := poolGCP.LocalSSDs; localSSDs != nil {
+		for _, ms := range installerMachineSets {
+			providerSpec := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*gcpproviderv1beta1.GCPMachineProviderSpec)
+			for i := int32(0); i < localSSDs.Count; i++ {
+				providerSpec.Disks = append(providerSpec.Disks, &gcpproviderv1beta1.GCPDisk{
+					AutoDelete: true,
+					SizeGb:     gcpLocalSSDSizeGB,
+					Type:       gcpLocalSSDDiskType,
+				})
+			}
+		}
+	}
+
+	onHostMaintenance := poolGCP.OnHostMaintenance
+	if onHostMaintenance == "" && poolGCP.GPU != nil {
+		// Instances with GPUs cannot be live migrated.
+		onHostMaintenance = gcpTerminateOnHostMaintenance
+	}
+	if onHostMaintenance != "" || poolGCP.GPU != nil || (poolGCP.LocalSSDs != nil && poolGCP.LocalSSDs.Interface != "") {
+		// The vendored GCPMachineProviderSpec predates the onHostMaintenance and the GPUs of the Machine API and the
+		// interface of its disks.
+		for _, ms := range installerMachineSets {
+			if err := patchRawProviderSpec(ms, func(providerSpec map[string]interface{}) {
+				if onHostMaintenance != "" {
+					providerSpec["onHostMaintenance"] = onHostMaintenance
+				}
+				if gpu := poolGCP.GPU; gpu != nil {
+					providerSpec["gpus"] = []interface{}{
+						map[string]interface{}{
+							"type":  gpu.Type,
+							"count": int64(gpu.Count),
+						},
+					}
+				}
+				if poolGCP.LocalSSDs == nil || poolGCP.LocalSSDs.Interface == "" {
+					return
+				}
+				disks, _ := providerSpec["disks"].([]interface{})
+				for _, d := range disks {
+					if disk, ok := d.(map[string]interface{}); ok && disk["type"] == gcpLocalSSDDiskType {
+						disk["interface"] = poolGCP.LocalSSDs.Interface
+					}
+				}
+			}); err != nil {
+				return nil, false, errors.Wrap(err, "failed to set host maintenance, GPU and local SSD options")
+			}
+		}
+	}
+
+	return installerMachineSets, true, nil
 }
 
-func (a *GCPActuator) getZones(region string) ([]string, error) {
+func (a *GCPActuator) getZones(ctx context.Context, region string) ([]string, error) {
 	zones := []string{}
 
 	// Filter to regions matching '.*<region>.*' (where the zone is actually UP)
@@ -248,7 +332,7 @@ func (a *GCPActuator) getZones(region string) ([]string, error) {
 	pageToken := ""
 
 	for {
-		zoneList, err := a.gcpClient.ListComputeZones(gcpclient.ListComputeZonesOptions{
+		zoneList, err := a.gcpClient.ListComputeZones(ctx, gcpclient.ListComputeZonesOptions{
 			Filter:    zoneFilter,
 			PageToken: pageToken,
 		})
@@ -3,6 +3,7 @@ package remotemachineset
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -48,6 +49,8 @@ func TestGCPActuator(t *testing.T) {
 		setupPendingCreationExpectation bool
 
 		expectedMachineSetReplicas map[string]int64
+		expectedMachineType        string
+		expectedLocalSSDs          int
 		expectedErr                bool
 	}{
 		{
@@ -85,6 +88,40 @@ func TestGCPActuator(t *testing.T) {
 				generateGCPMachineSetName("worker", "zone3"): 1,
 			},
 		},
+		{
+			name: "generate machinesets for zone subnets",
+			pool: func() *hivev1.MachinePool {
+				pool := testGCPPool(testPoolName)
+				pool.Spec.Platform.GCP.ZoneSubnets = []hivev1gcp.ZoneSubnet{
+					{Zone: "zone1", Subnet: "subnet1"},
+					{Zone: "zone2", Subnet: "subnet2"},
+					{Zone: "zone3", Subnet: "subnet3"},
+				}
+				return pool
+			}(),
+			expectedMachineSetReplicas: map[string]int64{
+				generateGCPMachineSetName("worker", "zone1"): 1,
+				generateGCPMachineSetName("worker", "zone2"): 1,
+				generateGCPMachineSetName("worker", "zone3"): 1,
+			},
+		},
+		{
+			name: "generate machinesets with custom machine type and local SSDs",
+			pool: func() *hivev1.MachinePool {
+				pool := testGCPPool(testPoolName)
+				pool.Spec.Platform.GCP.Zones = []string{"zone1"}
+				pool.Spec.Platform.GCP.InstanceType = "n2"
+				pool.Spec.Platform.GCP.CustomCPUs = 4
+				pool.Spec.Platform.GCP.CustomMemoryMB = 16384
+				pool.Spec.Platform.GCP.LocalSSDs = &hivev1gcp.LocalSSDs{Count: 2}
+				return pool
+			}(),
+			expectedMachineSetReplicas: map[string]int64{
+				generateGCPMachineSetName("worker", "zone1"): 3,
+			},
+			expectedMachineType: "n2-custom-4-16384",
+			expectedLocalSSDs:   2,
+		},
 		{
 			name: "list zones returns zero",
 			pool: testGCPPool(testPoolName),
@@ -212,7 +249,7 @@ func TestGCPActuator(t *testing.T) {
 				subnet:         testSubnetID,
 			}
 
-			generatedMachineSets, _, err := ga.GenerateMachineSets(clusterDeployment, test.pool, ga.logger)
+			generatedMachineSets, _, err := ga.GenerateMachineSets(context.TODO(), clusterDeployment, test.pool, ga.logger)
 
 			if test.expectedErr {
 				assert.Error(t, err, "expected error for test case")
@@ -228,11 +265,29 @@ func TestGCPActuator(t *testing.T) {
 					gcpProvider, ok := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*gcpprovider.GCPMachineProviderSpec)
 					assert.True(t, ok, "failed to convert to gcpProviderSpec")
 
-					assert.Equal(t, testInstanceType, gcpProvider.MachineType, "unexpected instance type")
+					expectedMachineType := test.expectedMachineType
+					if expectedMachineType == "" {
+						expectedMachineType = testInstanceType
+					}
+					assert.Equal(t, expectedMachineType, gcpProvider.MachineType, "unexpected instance type")
+					localSSDs := 0
+					for _, disk := range gcpProvider.Disks {
+						if disk.Type == gcpLocalSSDDiskType {
+							localSSDs++
+							assert.Equal(t, int64(gcpLocalSSDSizeGB), disk.SizeGb, "unexpected local SSD size")
+						}
+					}
+					assert.Equal(t, test.expectedLocalSSDs, localSSDs, "unexpected number of local SSDs")
 
 					// Ensure network details are propagated correctly.
 					assert.Equal(t, ga.network, gcpProvider.NetworkInterfaces[0].Network)
-					assert.Equal(t, ga.subnet, gcpProvider.NetworkInterfaces[0].Subnetwork)
+					expectedSubnet := ga.subnet
+					for _, zs := range test.pool.Spec.Platform.GCP.ZoneSubnets {
+						if zs.Zone == gcpProvider.Zone {
+							expectedSubnet = zs.Subnet
+						}
+					}
+					assert.Equal(t, expectedSubnet, gcpProvider.NetworkInterfaces[0].Subnetwork)
 
 					// Ensure GCP disk type and size was correctly set or defaulted and made it to the resulting MachineSets:
 					expectedDiskType := test.pool.Spec.Platform.GCP.OSDisk.DiskType
@@ -686,6 +741,68 @@ func TestRequireLeases(t *testing.T) {
 	}
 }
 
+func TestGCPActuatorHostMaintenanceAndLocalSSDInterface(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	clusterDeployment := testGCPClusterDeployment(testName, testInfraID)
+	logger := log.WithField("actuator", "gcpactuator")
+	ga := &GCPActuator{
+		logger:       logger,
+		client:       fake.NewFakeClient(clusterDeployment),
+		scheme:       scheme.Scheme,
+		expectations: controllerutils.NewExpectations(logger),
+		projectID:    testProjectID,
+		network:      testNetworkID,
+		subnet:       testSubnetID,
+	}
+	pool := testGCPPool(testPoolName)
+	pool.Spec.Platform.GCP.Zones = []string{"zone1"}
+	pool.Spec.Platform.GCP.LocalSSDs = &hivev1gcp.LocalSSDs{Count: 1, Interface: "NVME"}
+	pool.Spec.Platform.GCP.OnHostMaintenance = "Terminate"
+
+	generatedMachineSets, _, err := ga.GenerateMachineSets(context.TODO(), clusterDeployment, pool, logger)
+	require.NoError(t, err, "unexpected error generating machinesets")
+	require.Len(t, generatedMachineSets, 1, "unexpected number of machinesets")
+
+	providerSpec := map[string]interface{}{}
+	err = json.Unmarshal(generatedMachineSets[0].Spec.Template.Spec.ProviderSpec.Value.Raw, &providerSpec)
+	require.NoError(t, err, "unexpected error decoding provider spec")
+	assert.Equal(t, "Terminate", providerSpec["onHostMaintenance"], "unexpected on host maintenance")
+	disks := providerSpec["disks"].([]interface{})
+	require.Len(t, disks, 2, "unexpected number of disks")
+	assert.Nil(t, disks[0].(map[string]interface{})["interface"], "unexpected interface for boot disk")
+	assert.Equal(t, "NVME", disks[1].(map[string]interface{})["interface"], "unexpected interface for local SSD")
+}
+
+func TestGCPActuatorGPU(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	clusterDeployment := testGCPClusterDeployment(testName, testInfraID)
+	logger := log.WithField("actuator", "gcpactuator")
+	ga := &GCPActuator{
+		logger:       logger,
+		client:       fake.NewFakeClient(clusterDeployment),
+		scheme:       scheme.Scheme,
+		expectations: controllerutils.NewExpectations(logger),
+		projectID:    testProjectID,
+		network:      testNetworkID,
+		subnet:       testSubnetID,
+	}
+	pool := testGCPPool(testPoolName)
+	pool.Spec.Platform.GCP.Zones = []string{"zone1"}
+	pool.Spec.Platform.GCP.GPU = &hivev1gcp.GPU{Type: "nvidia-tesla-t4", Count: 2}
+
+	generatedMachineSets, _, err := ga.GenerateMachineSets(context.TODO(), clusterDeployment, pool, logger)
+	require.NoError(t, err, "unexpected error generating machinesets")
+	require.Len(t, generatedMachineSets, 1, "unexpected number of machinesets")
+
+	providerSpec := map[string]interface{}{}
+	err = json.Unmarshal(generatedMachineSets[0].Spec.Template.Spec.ProviderSpec.Value.Raw, &providerSpec)
+	require.NoError(t, err, "unexpected error decoding provider spec")
+	assert.Equal(t, "Terminate", providerSpec["onHostMaintenance"], "expected instances with GPUs to terminate on host maintenance")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "nvidia-tesla-t4", "count": float64(2)},
+	}, providerSpec["gpus"], "unexpected GPUs")
+}
+
 func TestGetNetwork(t *testing.T) {
 	cases := []struct {
 		name              string
@@ -825,7 +942,7 @@ func mockListComputeZones(gClient *mockgcp.MockClient, zones []string, region st
 	filter := gcpclient.ListComputeZonesOptions{
 		Filter: fmt.Sprintf("(region eq '.*%s.*') (status eq UP)", region),
 	}
-	gClient.EXPECT().ListComputeZones(gomock.Eq(filter)).Return(
+	gClient.EXPECT().ListComputeZones(gomock.Any(), gomock.Eq(filter)).Return(
 		zoneList, nil,
 	)
 }
@@ -842,7 +959,7 @@ func mockListComputeImage(gClient *mockgcp.MockClient, images []string, infraID
 	filter := gcpclient.ListComputeImagesOptions{
 		Filter: fmt.Sprintf("name eq \"%s-.*\"", infraID),
 	}
-	gClient.EXPECT().ListComputeImages(gomock.Eq(filter)).Return(
+	gClient.EXPECT().ListComputeImages(gomock.Any(), gomock.Eq(filter)).Return(
 		computeImages, nil,
 	)
 }
@@ -5,6 +5,7 @@
 package mock
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	v1 "github.com/openshift/hive/apis/hive/v1"
 	v1beta1 "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
@@ -36,9 +37,9 @@ func (m *MockActuator) EXPECT() *MockActuatorMockRecorder {
 }
 
 // GenerateMachineSets mocks base method
-func (m *MockActuator) GenerateMachineSets(arg0 *v1.ClusterDeployment, arg1 *v1.MachinePool, arg2 logrus.FieldLogger) ([]*v1beta1.MachineSet, bool, error) {
+func (m *MockActuator) GenerateMachineSets(arg0 context.Context, arg1 *v1.ClusterDeployment, arg2 *v1.MachinePool, arg3 logrus.FieldLogger) ([]*v1beta1.MachineSet, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateMachineSets", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GenerateMachineSets", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]*v1beta1.MachineSet)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
@@ -46,7 +47,7 @@ func (m *MockActuator) GenerateMachineSets(arg0 *v1.ClusterDeployment, arg1 *v1.
 }
 
 // GenerateMachineSets indicates an expected call of GenerateMachineSets
-func (mr *MockActuatorMockRecorder) GenerateMachineSets(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockActuatorMockRecorder) GenerateMachineSets(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateMachineSets", reflect.TypeOf((*MockActuator)(nil).GenerateMachineSets), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateMachineSets", reflect.TypeOf((*MockActuator)(nil).GenerateMachineSets), arg0, arg1, arg2, arg3)
 }
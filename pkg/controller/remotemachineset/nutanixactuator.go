@@ -0,0 +1,78 @@
+package remotemachineset
+
+import (
+	"context"
+
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// NutanixActuator encapsulates the pieces necessary to be able to generate
+// a list of MachineSets to sync to the remote cluster.
+//
+// Neither the installer nor the Machine API types for Nutanix are vendored, so the provider spec of the worker
+// MachineSets is that of the master Machine, with the resources of the virtual machines taken from the MachinePool.
+// This keeps the image, cluster, subnets and credentials the installer chose for the cluster.
+type NutanixActuator struct {
+	logger log.FieldLogger
+	// masterProviderSpec is the raw provider spec of the master Machine.
+	masterProviderSpec map[string]interface{}
+}
+
+var _ Actuator = &NutanixActuator{}
+
+// NewNutanixActuator is the constructor for building a NutanixActuator
+func NewNutanixActuator(masterMachine *machineapi.Machine, logger log.FieldLogger) (*NutanixActuator, error) {
+	providerSpec, err := decodeRawProviderSpec(masterMachine.Spec.ProviderSpec.Value, "NutanixMachineProviderConfig")
+	if err != nil {
+		logger.WithError(err).Error("error getting provider spec from master machine")
+		return nil, err
+	}
+	actuator := &NutanixActuator{
+		logger:             logger,
+		masterProviderSpec: providerSpec,
+	}
+	return actuator, nil
+}
+
+// GenerateMachineSets satisfies the Actuator interface and will take a clusterDeployment and return a list of MachineSets
+// to sync to the remote cluster.
+func (a *NutanixActuator) GenerateMachineSets(ctx context.Context, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, logger log.FieldLogger) ([]*machineapi.MachineSet, bool, error) {
+	if cd.Spec.ClusterMetadata == nil {
+		return nil, false, errors.New("ClusterDeployment does not have cluster metadata")
+	}
+	if cd.Spec.Platform.Nutanix == nil {
+		return nil, false, errors.New("ClusterDeployment is not for Nutanix")
+	}
+	if pool.Spec.Platform.Nutanix == nil {
+		return nil, false, errors.New("MachinePool is not for Nutanix")
+	}
+	mpool := pool.Spec.Platform.Nutanix
+
+	// The provider spec is copied so that the one of the master Machine is left untouched.
+	providerSpec := map[string]interface{}{}
+	for k, v := range a.masterProviderSpec {
+		providerSpec[k] = v
+	}
+	coresPerSocket := mpool.NumCoresPerSocket
+	if coresPerSocket <= 0 {
+		coresPerSocket = 1
+	}
+	providerSpec["vcpusPerSocket"] = coresPerSocket
+	providerSpec["vcpuSockets"] = mpool.NumCPUs / coresPerSocket
+	providerSpec["memorySize"] = fmt.Sprintf("%dMi", mpool.MemoryMiB)
+	providerSpec["systemDiskSize"] = fmt.Sprintf("%dGi", mpool.OSDisk.DiskSizeGiB)
+	providerSpec["userDataSecret"] = map[string]interface{}{"name": workerUserDataName}
+
+	mset, err := rawProviderSpecMachineSet(cd, pool, providerSpec)
+	if err != nil {
+		return nil, false, err
+	}
+	return []*machineapi.MachineSet{mset}, true, nil
+}
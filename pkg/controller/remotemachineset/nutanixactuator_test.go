@@ -0,0 +1,127 @@
+package remotemachineset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1nutanix "github.com/openshift/hive/apis/hive/v1/nutanix"
+)
+
+const testNutanixMasterProviderSpec = `{
+	"apiVersion": "machine.openshift.io/v1",
+	"kind": "NutanixMachineProviderConfig",
+	"cluster": {"type": "uuid", "uuid": "pe-uuid"},
+	"image": {"type": "name", "name": "foo-12345-rhcos"},
+	"subnets": [{"type": "uuid", "uuid": "subnet-uuid"}],
+	"credentialsSecret": {"name": "nutanix-credentials"},
+	"userDataSecret": {"name": "master-user-data"},
+	"vcpusPerSocket": 1,
+	"vcpuSockets": 8,
+	"memorySize": "16Gi",
+	"systemDiskSize": "120Gi"
+}`
+
+func TestNutanixActuator(t *testing.T) {
+	tests := []struct {
+		name                 string
+		masterProviderSpec   string
+		pool                 *hivev1.MachinePool
+		expectedProviderSpec map[string]interface{}
+		expectedErr          bool
+	}{
+		{
+			name:               "generate machineset",
+			masterProviderSpec: testNutanixMasterProviderSpec,
+			pool:               testNutanixPool(),
+			expectedProviderSpec: map[string]interface{}{
+				"apiVersion":        "machine.openshift.io/v1",
+				"kind":              "NutanixMachineProviderConfig",
+				"cluster":           map[string]interface{}{"type": "uuid", "uuid": "pe-uuid"},
+				"image":             map[string]interface{}{"type": "name", "name": "foo-12345-rhcos"},
+				"subnets":           []interface{}{map[string]interface{}{"type": "uuid", "uuid": "subnet-uuid"}},
+				"credentialsSecret": map[string]interface{}{"name": "nutanix-credentials"},
+				"userDataSecret":    map[string]interface{}{"name": "worker-user-data"},
+				"vcpusPerSocket":    float64(2),
+				"vcpuSockets":       float64(2),
+				"memorySize":        "32768Mi",
+				"systemDiskSize":    "200Gi",
+			},
+		},
+		{
+			name:               "not a nutanix master",
+			masterProviderSpec: `{"kind": "VSphereMachineProviderSpec"}`,
+			pool:               testNutanixPool(),
+			expectedErr:        true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			masterMachine := &machineapi.Machine{
+				Spec: machineapi.MachineSpec{
+					ProviderSpec: machineapi.ProviderSpec{
+						Value: &runtime.RawExtension{Raw: []byte(test.masterProviderSpec)},
+					},
+				},
+			}
+			logger := log.WithField("actuator", "nutanixactuator_test")
+			actuator, err := NewNutanixActuator(masterMachine, logger)
+			if test.expectedErr {
+				assert.Error(t, err, "expected error for test case")
+				return
+			}
+			require.NoError(t, err, "unexpected error creating actuator")
+
+			generatedMachineSets, _, err := actuator.GenerateMachineSets(context.TODO(), testNutanixClusterDeployment(), test.pool, logger)
+			require.NoError(t, err, "unexpected error for test case")
+			if assert.Len(t, generatedMachineSets, 1, "unexpected number of machine sets") {
+				ms := generatedMachineSets[0]
+				assert.Equal(t, fmt.Sprintf("%s-worker", testInfraID), ms.Name, "unexpected machine set name")
+				assert.Equal(t, int32(3), *ms.Spec.Replicas, "replica mismatch")
+				providerSpec := map[string]interface{}{}
+				require.NoError(t, json.Unmarshal(ms.Spec.Template.Spec.ProviderSpec.Value.Raw, &providerSpec))
+				assert.Equal(t, test.expectedProviderSpec, providerSpec, "unexpected provider spec")
+			}
+		})
+	}
+}
+
+func testNutanixPool() *hivev1.MachinePool {
+	p := testMachinePool()
+	p.Spec.Platform = hivev1.MachinePoolPlatform{
+		Nutanix: &hivev1nutanix.MachinePool{
+			NumCPUs:           4,
+			NumCoresPerSocket: 2,
+			MemoryMiB:         32 * 1024,
+			OSDisk: hivev1nutanix.OSDisk{
+				DiskSizeGiB: 200,
+			},
+		},
+	}
+	return p
+}
+
+func testNutanixClusterDeployment() *hivev1.ClusterDeployment {
+	cd := testClusterDeployment()
+	cd.Spec.Platform = hivev1.Platform{
+		Nutanix: &hivev1nutanix.Platform{
+			PrismCentral: hivev1nutanix.PrismEndpoint{Address: "prism.example.com"},
+			CredentialsSecretRef: corev1.LocalObjectReference{
+				Name: "nutanix-credentials",
+			},
+		},
+	}
+	return cd
+}
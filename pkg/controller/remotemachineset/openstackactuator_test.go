@@ -1,6 +1,7 @@
 package remotemachineset
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -47,7 +48,7 @@ func BROKEN__TestOpenStackActuator(t *testing.T) {
 				logger: log.WithField("actuator", "openstackactuator_test"),
 			}
 
-			generatedMachineSets, _, err := actuator.GenerateMachineSets(test.clusterDeployment, test.pool, actuator.logger)
+			generatedMachineSets, _, err := actuator.GenerateMachineSets(context.TODO(), test.clusterDeployment, test.pool, actuator.logger)
 
 			if test.expectedErr {
 				assert.Error(t, err, "expected error for test case")
@@ -0,0 +1,80 @@
+package remotemachineset
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// PowerVSActuator encapsulates the pieces necessary to be able to generate
+// a list of MachineSets to sync to the remote cluster.
+//
+// Neither the installer nor the Machine API types for IBM Power Virtual Server are vendored, so the provider spec of
+// the worker MachineSets is that of the master Machine, with the resources of the instances the MachinePool sets
+// taken from it. This keeps the image, network and workspace the installer chose for the cluster.
+type PowerVSActuator struct {
+	logger log.FieldLogger
+	// masterProviderSpec is the raw provider spec of the master Machine.
+	masterProviderSpec map[string]interface{}
+}
+
+var _ Actuator = &PowerVSActuator{}
+
+// NewPowerVSActuator is the constructor for building a PowerVSActuator
+func NewPowerVSActuator(masterMachine *machineapi.Machine, logger log.FieldLogger) (*PowerVSActuator, error) {
+	providerSpec, err := decodeRawProviderSpec(masterMachine.Spec.ProviderSpec.Value, "PowerVSMachineProviderConfig")
+	if err != nil {
+		logger.WithError(err).Error("error getting provider spec from master machine")
+		return nil, err
+	}
+	actuator := &PowerVSActuator{
+		logger:             logger,
+		masterProviderSpec: providerSpec,
+	}
+	return actuator, nil
+}
+
+// GenerateMachineSets satisfies the Actuator interface and will take a clusterDeployment and return a list of MachineSets
+// to sync to the remote cluster.
+func (a *PowerVSActuator) GenerateMachineSets(ctx context.Context, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, logger log.FieldLogger) ([]*machineapi.MachineSet, bool, error) {
+	if cd.Spec.ClusterMetadata == nil {
+		return nil, false, errors.New("ClusterDeployment does not have cluster metadata")
+	}
+	if cd.Spec.Platform.PowerVS == nil {
+		return nil, false, errors.New("ClusterDeployment is not for PowerVS")
+	}
+	if pool.Spec.Platform.PowerVS == nil {
+		return nil, false, errors.New("MachinePool is not for PowerVS")
+	}
+	mpool := pool.Spec.Platform.PowerVS
+
+	// The provider spec is copied so that the one of the master Machine is left untouched.
+	providerSpec := map[string]interface{}{}
+	for k, v := range a.masterProviderSpec {
+		providerSpec[k] = v
+	}
+	if mpool.ProcType != "" {
+		providerSpec["processorType"] = mpool.ProcType
+	}
+	if mpool.Processors != "" {
+		providerSpec["processors"] = mpool.Processors
+	}
+	if mpool.MemoryGiB != 0 {
+		providerSpec["memoryGiB"] = mpool.MemoryGiB
+	}
+	if mpool.SysType != "" {
+		providerSpec["systemType"] = mpool.SysType
+	}
+	providerSpec["userDataSecret"] = map[string]interface{}{"name": workerUserDataName}
+
+	mset, err := rawProviderSpecMachineSet(cd, pool, providerSpec)
+	if err != nil {
+		return nil, false, err
+	}
+	return []*machineapi.MachineSet{mset}, true, nil
+}
@@ -0,0 +1,143 @@
+package remotemachineset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1powervs "github.com/openshift/hive/apis/hive/v1/powervs"
+)
+
+const testPowerVSMasterProviderSpec = `{
+	"apiVersion": "machine.openshift.io/v1",
+	"kind": "PowerVSMachineProviderConfig",
+	"serviceInstance": {"type": "ID", "id": "workspace-guid"},
+	"image": {"type": "Name", "name": "rhcos-foo-12345"},
+	"network": {"type": "RegEx", "regex": "^DHCPSERVER.*foo-12345.*_Private$"},
+	"credentialsSecret": {"name": "powervs-credentials"},
+	"userDataSecret": {"name": "master-user-data"},
+	"keyPairName": "foo-12345-key",
+	"systemType": "s922",
+	"processorType": "Shared",
+	"processors": "0.5",
+	"memoryGiB": 32
+}`
+
+func TestPowerVSActuator(t *testing.T) {
+	tests := []struct {
+		name                 string
+		masterProviderSpec   string
+		pool                 *hivev1.MachinePool
+		expectedProviderSpec map[string]interface{}
+		expectedErr          bool
+	}{
+		{
+			name:               "generate machineset",
+			masterProviderSpec: testPowerVSMasterProviderSpec,
+			pool:               testPowerVSPool(&hivev1powervs.MachinePool{ProcType: "Dedicated", Processors: "2", MemoryGiB: 64, SysType: "e980"}),
+			expectedProviderSpec: map[string]interface{}{
+				"apiVersion":        "machine.openshift.io/v1",
+				"kind":              "PowerVSMachineProviderConfig",
+				"serviceInstance":   map[string]interface{}{"type": "ID", "id": "workspace-guid"},
+				"image":             map[string]interface{}{"type": "Name", "name": "rhcos-foo-12345"},
+				"network":           map[string]interface{}{"type": "RegEx", "regex": "^DHCPSERVER.*foo-12345.*_Private$"},
+				"credentialsSecret": map[string]interface{}{"name": "powervs-credentials"},
+				"userDataSecret":    map[string]interface{}{"name": "worker-user-data"},
+				"keyPairName":       "foo-12345-key",
+				"systemType":        "e980",
+				"processorType":     "Dedicated",
+				"processors":        "2",
+				"memoryGiB":         float64(64),
+			},
+		},
+		{
+			name:               "resources of the master",
+			masterProviderSpec: testPowerVSMasterProviderSpec,
+			pool:               testPowerVSPool(&hivev1powervs.MachinePool{}),
+			expectedProviderSpec: map[string]interface{}{
+				"apiVersion":        "machine.openshift.io/v1",
+				"kind":              "PowerVSMachineProviderConfig",
+				"serviceInstance":   map[string]interface{}{"type": "ID", "id": "workspace-guid"},
+				"image":             map[string]interface{}{"type": "Name", "name": "rhcos-foo-12345"},
+				"network":           map[string]interface{}{"type": "RegEx", "regex": "^DHCPSERVER.*foo-12345.*_Private$"},
+				"credentialsSecret": map[string]interface{}{"name": "powervs-credentials"},
+				"userDataSecret":    map[string]interface{}{"name": "worker-user-data"},
+				"keyPairName":       "foo-12345-key",
+				"systemType":        "s922",
+				"processorType":     "Shared",
+				"processors":        "0.5",
+				"memoryGiB":         float64(32),
+			},
+		},
+		{
+			name:               "not a powervs master",
+			masterProviderSpec: `{"kind": "NutanixMachineProviderConfig"}`,
+			pool:               testPowerVSPool(&hivev1powervs.MachinePool{}),
+			expectedErr:        true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			masterMachine := &machineapi.Machine{
+				Spec: machineapi.MachineSpec{
+					ProviderSpec: machineapi.ProviderSpec{
+						Value: &runtime.RawExtension{Raw: []byte(test.masterProviderSpec)},
+					},
+				},
+			}
+			logger := log.WithField("actuator", "powervsactuator_test")
+			actuator, err := NewPowerVSActuator(masterMachine, logger)
+			if test.expectedErr {
+				assert.Error(t, err, "expected error for test case")
+				return
+			}
+			require.NoError(t, err, "unexpected error creating actuator")
+
+			generatedMachineSets, _, err := actuator.GenerateMachineSets(context.TODO(), testPowerVSClusterDeployment(), test.pool, logger)
+			require.NoError(t, err, "unexpected error for test case")
+			if assert.Len(t, generatedMachineSets, 1, "unexpected number of machine sets") {
+				ms := generatedMachineSets[0]
+				assert.Equal(t, fmt.Sprintf("%s-worker", testInfraID), ms.Name, "unexpected machine set name")
+				assert.Equal(t, int32(3), *ms.Spec.Replicas, "replica mismatch")
+				providerSpec := map[string]interface{}{}
+				require.NoError(t, json.Unmarshal(ms.Spec.Template.Spec.ProviderSpec.Value.Raw, &providerSpec))
+				assert.Equal(t, test.expectedProviderSpec, providerSpec, "unexpected provider spec")
+			}
+		})
+	}
+}
+
+func testPowerVSPool(mpool *hivev1powervs.MachinePool) *hivev1.MachinePool {
+	p := testMachinePool()
+	p.Spec.Platform = hivev1.MachinePoolPlatform{
+		PowerVS: mpool,
+	}
+	return p
+}
+
+func testPowerVSClusterDeployment() *hivev1.ClusterDeployment {
+	cd := testClusterDeployment()
+	cd.Spec.Platform = hivev1.Platform{
+		PowerVS: &hivev1powervs.Platform{
+			CredentialsSecretRef: corev1.LocalObjectReference{
+				Name: "powervs-credentials",
+			},
+			Region:            "dal",
+			Zone:              "dal12",
+			ServiceInstanceID: "workspace-guid",
+		},
+	}
+	return cd
+}
@@ -3,7 +3,6 @@ package remotemachineset
 import (
 	"context"
 	"fmt"
-	"os"
 	"reflect"
 	"strings"
 	"time"
@@ -55,7 +54,7 @@ var controllerKind = hivev1.SchemeGroupVersion.WithKind("MachinePool")
 // Add creates a new RemoteMachineSet Controller and adds it to the Manager with default RBAC. The Manager will set fields on the
 // Controller and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 
 	scheme := mgr.GetScheme()
 	if err := addAWSProviderToScheme(scheme); err != nil {
@@ -93,7 +92,7 @@ func Add(mgr manager.Manager) error {
 	}
 
 	// Create a new controller
-	c, err := controller.New("remotemachineset-controller", mgr, controller.Options{
+	c, err := controllerutils.NewController("remotemachineset-controller", mgr, controller.Options{
 		Reconciler:              r,
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             queueRateLimiter,
@@ -262,6 +261,11 @@ func (r *ReconcileRemoteMachineSet) Reconcile(ctx context.Context, request recon
 		return reconcile.Result{}, err
 	}
 
+	if err := r.syncGPUPoolsLabel(cd, logger); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not sync GPU pools label")
+		return reconcile.Result{}, err
+	}
+
 	if controllerutils.IsFakeCluster(cd) {
 		logger.Info("skipping reconcile for fake cluster")
 		return reconcile.Result{}, nil
@@ -290,7 +294,7 @@ func (r *ReconcileRemoteMachineSet) Reconcile(ctx context.Context, request recon
 		return reconcile.Result{}, err
 	}
 
-	generatedMachineSets, proceed, err := r.generateMachineSets(pool, cd, masterMachine, remoteMachineSets, logger)
+	generatedMachineSets, proceed, err := r.generateMachineSets(ctx, pool, cd, masterMachine, remoteMachineSets, logger)
 	if err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not generateMachineSets")
 		return reconcile.Result{}, err
@@ -330,6 +334,50 @@ func (r *ReconcileRemoteMachineSet) Reconcile(ctx context.Context, request recon
 	return r.updatePoolStatusForMachineSets(pool, machineSets, logger)
 }
 
+// syncGPUPoolsLabel labels the ClusterDeployment with the GPU pools label while any of its MachinePools that are not
+// being deleted has GPUs.
+func (r *ReconcileRemoteMachineSet) syncGPUPoolsLabel(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	pools := &hivev1.MachinePoolList{}
+	if err := r.List(context.TODO(), pools, client.InNamespace(cd.Namespace)); err != nil {
+		return errors.Wrap(err, "could not list machine pools")
+	}
+	hasGPUPools := false
+	for i := range pools.Items {
+		pool := &pools.Items[i]
+		if pool.Spec.ClusterDeploymentRef.Name == cd.Name && pool.DeletionTimestamp == nil && machinePoolHasGPUs(pool) {
+			hasGPUPools = true
+			break
+		}
+	}
+	if _, labeled := cd.Labels[constants.GPUPoolsLabel]; labeled == hasGPUPools {
+		return nil
+	}
+	patched := cd.DeepCopy()
+	if hasGPUPools {
+		logger.Info("labeling cluster deployment with GPU pools")
+		if patched.Labels == nil {
+			patched.Labels = map[string]string{}
+		}
+		patched.Labels[constants.GPUPoolsLabel] = "true"
+	} else {
+		logger.Info("removing GPU pools label from cluster deployment")
+		delete(patched.Labels, constants.GPUPoolsLabel)
+	}
+	if err := r.Patch(context.TODO(), patched, client.MergeFrom(cd)); err != nil {
+		return err
+	}
+	cd.Labels = patched.Labels
+	return nil
+}
+
+// machinePoolHasGPUs returns whether the machines of the MachinePool have GPUs.
+func machinePoolHasGPUs(pool *hivev1.MachinePool) bool {
+	platform := pool.Spec.Platform
+	return (platform.AWS != nil && platform.AWS.GPU != nil) ||
+		(platform.Azure != nil && platform.Azure.GPU != nil) ||
+		(platform.GCP != nil && platform.GCP.GPU != nil)
+}
+
 func (r *ReconcileRemoteMachineSet) getMasterMachine(
 	cd *hivev1.ClusterDeployment,
 	remoteClusterAPIClient client.Client,
@@ -378,7 +426,7 @@ func (r *ReconcileRemoteMachineSet) getRemoteMachineSets(
 }
 
 func (r *ReconcileRemoteMachineSet) generateMachineSets(
-	pool *hivev1.MachinePool,
+	ctx context.Context, pool *hivev1.MachinePool,
 	cd *hivev1.ClusterDeployment,
 	masterMachine *machineapi.Machine,
 	remoteMachineSets *machineapi.MachineSetList,
@@ -395,7 +443,7 @@ func (r *ReconcileRemoteMachineSet) generateMachineSets(
 	}
 
 	// Generate expected MachineSets for Platform from InstallConfig
-	generatedMachineSets, proceed, err := actuator.GenerateMachineSets(cd, pool, logger)
+	generatedMachineSets, proceed, err := actuator.GenerateMachineSets(ctx, cd, pool, logger)
 	if err != nil {
 		return nil, false, errors.Wrap(err, "could not generate machinesets")
 	} else if !proceed {
@@ -408,6 +456,10 @@ func (r *ReconcileRemoteMachineSet) generateMachineSets(
 			min, _ := getMinMaxReplicasForMachineSet(pool, generatedMachineSets, i)
 			ms.Spec.Replicas = &min
 		}
+		if workersStopped(cd) {
+			zero := int32(0)
+			ms.Spec.Replicas = &zero
+		}
 
 		if ms.Labels == nil {
 			ms.Labels = make(map[string]string, 2)
@@ -513,7 +565,15 @@ func (r *ReconcileRemoteMachineSet) syncMachineSets(
 				resourcemerge.EnsureObjectMeta(&objectMetaModified, &rMS.ObjectMeta, ms.ObjectMeta)
 				msLog := logger.WithField("machineset", rMS.Name)
 
-				if pool.Spec.Autoscaling == nil {
+				if workersStopped(cd) {
+					// The workers are scaled to zero by the hibernation controller.
+					if rMS.Spec.Replicas == nil || *rMS.Spec.Replicas != 0 {
+						msLog.WithField("observed", rMS.Spec.Replicas).Info("scaling machineset to zero for stopped workers")
+						zero := int32(0)
+						rMS.Spec.Replicas = &zero
+						objectModified = true
+					}
+				} else if pool.Spec.Autoscaling == nil {
 					if *rMS.Spec.Replicas != *ms.Spec.Replicas {
 						msLog.WithFields(log.Fields{
 							"desired":  *ms.Spec.Replicas,
@@ -650,7 +710,11 @@ func (r *ReconcileRemoteMachineSet) syncMachineAutoscalers(
 	machineAutoscalersToCreate := []*autoscalingv1beta1.MachineAutoscaler{}
 	machineAutoscalersToUpdate := []*autoscalingv1beta1.MachineAutoscaler{}
 
-	if pool.DeletionTimestamp == nil && pool.Spec.Autoscaling != nil {
+	// The MachineAutoscalers are removed while the workers are stopped so that they do not scale the
+	// MachineSets back up.
+	autoscaling := pool.Spec.Autoscaling != nil && !workersStopped(cd)
+
+	if pool.DeletionTimestamp == nil && autoscaling {
 		// Find MachineAutoscalers that need updating/creating
 		for i, ms := range machineSets {
 			minReplicas, maxReplicas := getMinMaxReplicasForMachineSet(pool, machineSets, i)
@@ -715,7 +779,7 @@ func (r *ReconcileRemoteMachineSet) syncMachineAutoscalers(
 			continue
 		}
 		delete := true
-		if pool.DeletionTimestamp == nil && pool.Spec.Autoscaling != nil {
+		if pool.DeletionTimestamp == nil && autoscaling {
 			for _, ms := range machineSets {
 				if rMA.Name == ms.Name {
 					delete = false
@@ -873,20 +937,14 @@ func (r *ReconcileRemoteMachineSet) createActuator(
 ) (Actuator, error) {
 	switch {
 	case cd.Spec.Platform.AWS != nil:
-		creds := awsclient.CredentialsSource{
-			Secret: &awsclient.SecretCredentialsSource{
-				Ref:       &cd.Spec.Platform.AWS.CredentialsSecretRef,
-				Namespace: cd.Namespace,
-			},
-			AssumeRole: &awsclient.AssumeRoleCredentialsSource{
-				SecretRef: corev1.SecretReference{
-					Namespace: controllerutils.GetHiveNamespace(),
-					Name:      os.Getenv(constants.HiveAWSServiceProviderCredentialsSecretRefEnvVar),
-				},
-				Role: cd.Spec.Platform.AWS.CredentialsAssumeRole,
-			},
+		options := awsclient.Options{
+			Controller:        hivev1.RemoteMachinesetControllerName,
+			Region:            cd.Spec.Platform.AWS.Region,
+			CredentialsSource: awsclient.NewCredentialsSource(cd.Namespace, &cd.Spec.Platform.AWS.CredentialsSecretRef, cd.Spec.Platform.AWS.CredentialsAssumeRole, cd.Spec.Platform.AWS.CredentialsSource),
+			ServiceEndpoints:  cd.Spec.Platform.AWS.ServiceEndpoints,
+			ProxySecret:       &awsclient.ProxySecretSource{Namespace: cd.Namespace, Ref: cd.Spec.CloudClientProxySecretRef},
 		}
-		return NewAWSActuator(r.Client, creds, cd.Spec.Platform.AWS.Region, pool, masterMachine, r.scheme, logger)
+		return NewAWSActuator(r.Client, options, pool, masterMachine, r.scheme, logger)
 	case cd.Spec.Platform.GCP != nil:
 		creds := &corev1.Secret{}
 		if err := r.Get(
@@ -903,7 +961,11 @@ func (r *ReconcileRemoteMachineSet) createActuator(
 		if err != nil {
 			return nil, err
 		}
-		return NewGCPActuator(r.Client, creds, clusterVersion, masterMachine, remoteMachineSets, r.scheme, r.expectations, logger)
+		proxy, err := controllerutils.GetCloudClientProxy(r, cd.Namespace, cd.Spec.CloudClientProxySecretRef)
+		if err != nil {
+			return nil, err
+		}
+		return NewGCPActuator(r.Client, creds, proxy, clusterVersion, masterMachine, remoteMachineSets, r.scheme, r.expectations, logger)
 	case cd.Spec.Platform.Azure != nil:
 		creds := &corev1.Secret{}
 		if err := r.Get(
@@ -916,13 +978,21 @@ func (r *ReconcileRemoteMachineSet) createActuator(
 		); err != nil {
 			return nil, err
 		}
-		return NewAzureActuator(creds, logger)
+		proxy, err := controllerutils.GetCloudClientProxy(r, cd.Namespace, cd.Spec.CloudClientProxySecretRef)
+		if err != nil {
+			return nil, err
+		}
+		return NewAzureActuator(creds, proxy, logger)
 	case cd.Spec.Platform.OpenStack != nil:
 		return NewOpenStackActuator(masterMachine, r.scheme, r.Client, logger)
 	case cd.Spec.Platform.VSphere != nil:
 		return NewVSphereActuator(masterMachine, r.scheme, logger)
 	case cd.Spec.Platform.Ovirt != nil:
 		return NewOvirtActuator(masterMachine, r.scheme, logger)
+	case cd.Spec.Platform.Nutanix != nil:
+		return NewNutanixActuator(masterMachine, logger)
+	case cd.Spec.Platform.PowerVS != nil:
+		return NewPowerVSActuator(masterMachine, logger)
 	default:
 		return nil, errors.New("unsupported platform")
 	}
@@ -935,6 +1005,12 @@ func baseMachinePool(pool *hivev1.MachinePool) *installertypes.MachinePool {
 	}
 }
 
+// workersStopped returns true if the worker MachineSets of the cluster are to be scaled to zero for the
+// WorkersStopped power state.
+func workersStopped(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.PowerState == hivev1.WorkersStoppedClusterPowerState
+}
+
 func isControlledByMachinePool(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, obj metav1.Object) bool {
 	prefix := strings.Join([]string{cd.Spec.ClusterName, pool.Spec.Name, ""}, "-")
 	return strings.HasPrefix(obj.GetName(), prefix) ||
@@ -13,6 +13,7 @@ import (
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -190,6 +191,31 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 1, 1),
 			},
 		},
+		{
+			name: "Scale machine sets to zero when workers are stopped",
+			clusterDeployment: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				cd.Spec.PowerState = hivev1.WorkersStoppedClusterPowerState
+				return cd
+			}(),
+			machinePool: testMachinePool(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 0, 0),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 0, 1),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 0, 1),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 0, 0),
+			},
+		},
 		{
 			name:              "Create missing machine set",
 			clusterDeployment: testClusterDeployment(),
@@ -592,6 +618,38 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				*testClusterAutoscaler("1"),
 			},
 		},
+		{
+			name: "Delete machine autoscalers when workers are stopped",
+			clusterDeployment: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				cd.Spec.PowerState = hivev1.WorkersStoppedClusterPowerState
+				return cd
+			}(),
+			machinePool: testAutoscalingMachinePool(3, 5),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 0, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 0, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 0, 0),
+				testClusterAutoscaler("1"),
+				testMachineAutoscaler("foo-12345-worker-us-east-1a", "1", 1, 2),
+				testMachineAutoscaler("foo-12345-worker-us-east-1b", "1", 1, 2),
+				testMachineAutoscaler("foo-12345-worker-us-east-1c", "1", 1, 1),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 0, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 0, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 0, 0),
+			},
+			expectedRemoteClusterAutoscalers: []autoscalingv1.ClusterAutoscaler{
+				*testClusterAutoscaler("1"),
+			},
+		},
 		{
 			name:              "Delete remote resources for deleted auto-scaling machinepool",
 			clusterDeployment: testClusterDeployment(),
@@ -667,7 +725,7 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 			mockActuator := mock.NewMockActuator(mockCtrl)
 			if test.generatedMachineSets != nil {
 				mockActuator.EXPECT().
-					GenerateMachineSets(test.clusterDeployment, test.machinePool, gomock.Any()).
+					GenerateMachineSets(gomock.Any(), test.clusterDeployment, test.machinePool, gomock.Any()).
 					Return(test.generatedMachineSets, !test.actuatorDoNotProceed, nil)
 			}
 
@@ -777,6 +835,89 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 	}
 }
 
+func TestSyncGPUPoolsLabel(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	gpuPool := func(name string) *hivev1.MachinePool {
+		pool := testMachinePool()
+		pool.Name = fmt.Sprintf("%s-%s", testName, name)
+		pool.Spec.Name = name
+		pool.Spec.Platform.AWS.GPU = &hivev1aws.GPU{Count: 1}
+		return pool
+	}
+	labeledClusterDeployment := func() *hivev1.ClusterDeployment {
+		cd := testClusterDeployment()
+		cd.Labels[constants.GPUPoolsLabel] = "true"
+		return cd
+	}
+
+	tests := []struct {
+		name              string
+		clusterDeployment *hivev1.ClusterDeployment
+		machinePools      []runtime.Object
+		expectLabel       bool
+	}{
+		{
+			name:              "no GPU pools",
+			clusterDeployment: testClusterDeployment(),
+			machinePools:      []runtime.Object{testMachinePool()},
+		},
+		{
+			name:              "GPU pool",
+			clusterDeployment: testClusterDeployment(),
+			machinePools:      []runtime.Object{testMachinePool(), gpuPool("gpu")},
+			expectLabel:       true,
+		},
+		{
+			name:              "GPU pool already labeled",
+			clusterDeployment: labeledClusterDeployment(),
+			machinePools:      []runtime.Object{gpuPool("gpu")},
+			expectLabel:       true,
+		},
+		{
+			name:              "GPU pool removed",
+			clusterDeployment: labeledClusterDeployment(),
+			machinePools:      []runtime.Object{testMachinePool()},
+		},
+		{
+			name:              "GPU pool being deleted",
+			clusterDeployment: labeledClusterDeployment(),
+			machinePools: []runtime.Object{func() *hivev1.MachinePool {
+				pool := gpuPool("gpu")
+				now := metav1.Now()
+				pool.DeletionTimestamp = &now
+				return pool
+			}()},
+		},
+		{
+			name:              "GPU pool of other cluster",
+			clusterDeployment: testClusterDeployment(),
+			machinePools: []runtime.Object{func() *hivev1.MachinePool {
+				pool := gpuPool("gpu")
+				pool.Spec.ClusterDeploymentRef.Name = "other"
+				return pool
+			}()},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithRuntimeObjects(append(test.machinePools, test.clusterDeployment)...).Build()
+			r := &ReconcileRemoteMachineSet{
+				Client: fakeClient,
+				scheme: scheme.Scheme,
+				logger: log.WithField("controller", "remotemachineset"),
+			}
+			err := r.syncGPUPoolsLabel(test.clusterDeployment, r.logger)
+			require.NoError(t, err, "unexpected error syncing GPU pools label")
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: testName}, cd), "error getting cluster deployment")
+			_, labeled := cd.Labels[constants.GPUPoolsLabel]
+			assert.Equal(t, test.expectLabel, labeled, "unexpected GPU pools label")
+			assert.Equal(t, cd.Labels, test.clusterDeployment.Labels, "expected labels of cluster deployment to be updated")
+		})
+	}
+}
+
 func testMachinePool() *hivev1.MachinePool {
 	return &hivev1.MachinePool{
 		TypeMeta: metav1.TypeMeta{
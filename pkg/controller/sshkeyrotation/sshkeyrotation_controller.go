@@ -0,0 +1,564 @@
+package sshkeyrotation
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	ControllerName = hivev1.SSHKeyRotationControllerName
+
+	// rotationIDAnnotation is the annotation on the staging secret recording the rotation the key pair was
+	// generated for.
+	rotationIDAnnotation = "hive.openshift.io/ssh-key-rotation-id"
+
+	// sshPublicKeySecretKey is the key of the public key in the staging secret and in the SSH key secret of the
+	// ClusterDeployment.
+	sshPublicKeySecretKey = "ssh-publickey"
+
+	// coreUser is the user on the machines of the cluster that the SSH key is configured for.
+	coreUser = "core"
+
+	// publicKeyComment is the comment of the generated public keys, which marks the authorized keys managed by Hive.
+	publicKeyComment = "hive-ssh-key-rotation"
+
+	// rsaKeyBits is the size of the generated RSA keys.
+	rsaKeyBits = 4096
+
+	// rolloutRequeueInterval is the interval at which to check whether the new key has been rolled out to the
+	// machines of the cluster.
+	rolloutRequeueInterval = time.Minute
+)
+
+var (
+	machineConfigGVK     = schema.GroupVersionKind{Group: "machineconfiguration.openshift.io", Version: "v1", Kind: "MachineConfig"}
+	machineConfigPoolGVK = schema.GroupVersionKind{Group: "machineconfiguration.openshift.io", Version: "v1", Kind: "MachineConfigPool"}
+
+	// machineConfigPools are the pools of the cluster that the new key is rolled out to. Custom pools inherit the
+	// MachineConfigs of the worker pool.
+	machineConfigPools = []string{"master", "worker"}
+)
+
+// Add creates a new SSHKeyRotation Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := controllerutils.ControllerLogEntry(ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
+	r := &ReconcileSSHKeyRotation{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme: mgr.GetScheme(),
+	}
+	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	// Create a new controller
+	c, err := controllerutils.NewController("sshkeyrotation-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to the staging secrets of the rotations
+	if err := c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &hivev1.ClusterDeployment{},
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileSSHKeyRotation{}
+
+// ReconcileSSHKeyRotation reconciles the rotation of the SSH key of a ClusterDeployment
+type ReconcileSSHKeyRotation struct {
+	client.Client
+	scheme *runtime.Scheme
+
+	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server
+	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+}
+
+// Reconcile rotates the SSH key of a ClusterDeployment when a new rotation is requested. The new key pair is kept in
+// a staging secret while the public key is rolled out to the machines of the cluster. Once all machines have been
+// updated, the key pair is stored in the rotated SSH key secret of the ClusterDeployment and
+// Provisioning.SSHPrivateKeySecretRef is pointed at it.
+func (r *ReconcileSSHKeyRotation) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	// Fetch the ClusterDeployment instance
+	cd := &hivev1.ClusterDeployment{}
+	err := r.Get(context.TODO(), request.NamespacedName, cd)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Object not found, return. The staging secret is garbage collected.
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	if controllerutils.IsClusterDeploymentPaused(cd, cdLog) {
+		return reconcile.Result{}, nil
+	}
+
+	// If the clusterdeployment is deleted, do not reconcile.
+	if cd.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	// If the cluster is not installed, do not reconcile.
+	if !cd.Spec.Installed {
+		cdLog.Debug("cluster installation is not complete")
+		return reconcile.Result{}, nil
+	}
+
+	rotation := cd.Spec.SSHKeyRotation
+	if rotation == nil || rotation.RotationID == "" {
+		cdLog.Debug("SSH key rotation not requested")
+		return reconcile.Result{}, nil
+	}
+	if status := cd.Status.SSHKeyRotation; status != nil && status.RotationID == rotation.RotationID {
+		cdLog.Debug("SSH key rotation is complete")
+		return reconcile.Result{}, nil
+	}
+	cdLog = cdLog.WithField("rotationID", rotation.RotationID)
+
+	if cd.Spec.Provisioning == nil || cd.Spec.Provisioning.SSHPrivateKeySecretRef == nil {
+		cdLog.Warn("cannot rotate the SSH key of a cluster without an SSH private key secret")
+		return reconcile.Result{}, r.setCondition(cd, corev1.ConditionFalse, hivev1.SSHKeyRotationFailedReason,
+			"spec.provisioning.sshPrivateKeySecretRef must be set to rotate the SSH key", cdLog)
+	}
+
+	if unreachable, _ := remoteclient.Unreachable(cd); unreachable {
+		cdLog.Debug("cluster is unreachable, SSH key will be rotated once it is reachable")
+		return reconcile.Result{}, nil
+	}
+
+	replacedKey, err := r.currentPublicKey(cd)
+	if err != nil {
+		return reconcile.Result{}, r.setFailed(cd, err, cdLog)
+	}
+
+	stagingSecret, err := r.ensureStagingSecret(cd, cdLog)
+	if err != nil {
+		return reconcile.Result{}, r.setFailed(cd, err, cdLog)
+	}
+	publicKey := strings.TrimSpace(string(stagingSecret.Data[sshPublicKeySecretKey]))
+
+	remoteClient, err := r.remoteClusterAPIClientBuilder(cd).Build()
+	if err != nil {
+		cdLog.WithError(err).Error("error building client for the cluster")
+		return reconcile.Result{}, err
+	}
+
+	var pending []string
+	for _, pool := range machineConfigPools {
+		if err := ensureMachineConfigKey(remoteClient, pool, publicKey, replacedKey, cdLog); err != nil {
+			return reconcile.Result{}, r.setFailed(cd, err, cdLog)
+		}
+		rolledOut, err := poolRolledOut(remoteClient, pool, publicKey)
+		if err != nil {
+			return reconcile.Result{}, r.setFailed(cd, err, cdLog)
+		}
+		if !rolledOut {
+			pending = append(pending, pool)
+		}
+	}
+	if len(pending) > 0 {
+		cdLog.WithField("pools", pending).Debug("waiting for the new SSH key to be rolled out")
+		message := fmt.Sprintf("Waiting for the new SSH key to be rolled out to the machine config pools: %s", strings.Join(pending, ", "))
+		if err := r.setCondition(cd, corev1.ConditionTrue, hivev1.SSHKeyRolloutInProgressReason, message, cdLog); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: rolloutRequeueInterval}, nil
+	}
+
+	if err := r.completeRotation(cd, stagingSecret, cdLog); err != nil {
+		return reconcile.Result{}, r.setFailed(cd, err, cdLog)
+	}
+
+	cdLog.Debug("reconcile complete")
+	return reconcile.Result{}, nil
+}
+
+// currentPublicKey returns the public key of the SSH private key secret the ClusterDeployment points at, which is the
+// key being replaced by the rotation.
+func (r *ReconcileSSHKeyRotation) currentPublicKey(cd *hivev1.ClusterDeployment) (ssh.PublicKey, error) {
+	secret := &corev1.Secret{}
+	name := cd.Spec.Provisioning.SSHPrivateKeySecretRef.Name
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: name}, secret); err != nil {
+		return nil, errors.Wrapf(err, "could not get SSH key secret %s", name)
+	}
+	signer, err := ssh.ParsePrivateKey(secret.Data[constants.SSHPrivateKeySecretKey])
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse the SSH private key of secret %s", name)
+	}
+	return signer.PublicKey(), nil
+}
+
+// ensureStagingSecret returns the secret holding the key pair of the requested rotation, generating a new key pair
+// when there is none for the rotation.
+func (r *ReconcileSSHKeyRotation) ensureStagingSecret(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (*corev1.Secret, error) {
+	rotationID := cd.Spec.SSHKeyRotation.RotationID
+	secret := &corev1.Secret{}
+	err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: stagingSecretName(cd)}, secret)
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return nil, errors.Wrap(err, "could not get SSH key rotation secret")
+	case secret.Annotations[rotationIDAnnotation] == rotationID:
+		return secret, nil
+	default:
+		// The rotation was superseded before it completed, so start over with a new key pair.
+		cdLog.Info("deleting SSH key rotation secret of a superseded rotation")
+		if err := r.Delete(context.TODO(), secret); err != nil && !apierrors.IsNotFound(err) {
+			return nil, errors.Wrap(err, "could not delete SSH key rotation secret")
+		}
+	}
+
+	privateKey, publicKey, err := generateKeyPair()
+	if err != nil {
+		return nil, err
+	}
+	cdLog.Info("creating SSH key rotation secret")
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   cd.Namespace,
+			Name:        stagingSecretName(cd),
+			Annotations: map[string]string{rotationIDAnnotation: rotationID},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			constants.SSHPrivateKeySecretKey: privateKey,
+			sshPublicKeySecretKey:            publicKey,
+		},
+	}
+	if err := controllerutil.SetControllerReference(cd, secret, r.scheme); err != nil {
+		return nil, errors.Wrap(err, "could not set controller reference on SSH key rotation secret")
+	}
+	if err := r.Create(context.TODO(), secret); err != nil {
+		return nil, errors.Wrap(err, "could not create SSH key rotation secret")
+	}
+	return secret, nil
+}
+
+// ensureMachineConfigKey configures the public key as the authorized key of the core user managed by Hive in the SSH
+// MachineConfig of the pool. The replaced key and the keys generated for earlier rotations are removed, while the
+// other authorized keys of the core user are left in place.
+func ensureMachineConfigKey(c client.Client, pool, publicKey string, replacedKey ssh.PublicKey, cdLog log.FieldLogger) error {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(machineConfigGVK)
+	name := fmt.Sprintf("99-%s-ssh", pool)
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: name}, mc); err != nil {
+		return errors.Wrapf(err, "could not get MachineConfig %s", name)
+	}
+	users, _, err := unstructured.NestedSlice(mc.Object, "spec", "config", "passwd", "users")
+	if err != nil {
+		return errors.Wrapf(err, "could not read the users of MachineConfig %s", name)
+	}
+	currentKeys := authorizedKeys(users)
+	keys := []string{}
+	for _, key := range currentKeys {
+		if key != publicKey && !isManagedKey(key, replacedKey) {
+			keys = append(keys, key)
+		}
+	}
+	keys = append(keys, publicKey)
+	if reflect.DeepEqual(keys, currentKeys) {
+		return nil
+	}
+	sshAuthorizedKeys := make([]interface{}, len(keys))
+	for i, key := range keys {
+		sshAuthorizedKeys[i] = key
+	}
+	found := false
+	for i, u := range users {
+		user, ok := u.(map[string]interface{})
+		if !ok || user["name"] != coreUser {
+			continue
+		}
+		user["sshAuthorizedKeys"] = sshAuthorizedKeys
+		users[i] = user
+		found = true
+	}
+	if !found {
+		users = append(users, map[string]interface{}{
+			"name":              coreUser,
+			"sshAuthorizedKeys": sshAuthorizedKeys,
+		})
+	}
+	if err := unstructured.SetNestedSlice(mc.Object, users, "spec", "config", "passwd", "users"); err != nil {
+		return errors.Wrapf(err, "could not set the users of MachineConfig %s", name)
+	}
+	cdLog.WithField("machineConfig", name).Info("updating SSH key of MachineConfig")
+	if err := c.Update(context.TODO(), mc); err != nil {
+		return errors.Wrapf(err, "could not update MachineConfig %s", name)
+	}
+	return nil
+}
+
+// isManagedKey returns whether the authorized key is the replaced key or a key generated by Hive.
+func isManagedKey(key string, replacedKey ssh.PublicKey) bool {
+	parsed, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(key))
+	if err != nil {
+		return false
+	}
+	return comment == publicKeyComment || bytes.Equal(parsed.Marshal(), replacedKey.Marshal())
+}
+
+// poolRolledOut returns whether the rendered configuration of the pool contains the public key and all machines of
+// the pool have been updated to it.
+func poolRolledOut(c client.Client, pool, publicKey string) (bool, error) {
+	mcp := &unstructured.Unstructured{}
+	mcp.SetGroupVersionKind(machineConfigPoolGVK)
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: pool}, mcp); err != nil {
+		return false, errors.Wrapf(err, "could not get MachineConfigPool %s", pool)
+	}
+
+	// The pool reports being updated to the previous configuration until the new configuration has been rendered,
+	// so check that the rendered configuration the machines are on contains the new key.
+	renderedName, _, _ := unstructured.NestedString(mcp.Object, "status", "configuration", "name")
+	if renderedName == "" {
+		return false, nil
+	}
+	rendered := &unstructured.Unstructured{}
+	rendered.SetGroupVersionKind(machineConfigGVK)
+	switch err := c.Get(context.TODO(), types.NamespacedName{Name: renderedName}, rendered); {
+	case apierrors.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, errors.Wrapf(err, "could not get MachineConfig %s", renderedName)
+	}
+	users, _, _ := unstructured.NestedSlice(rendered.Object, "spec", "config", "passwd", "users")
+	hasKey := false
+	for _, key := range authorizedKeys(users) {
+		if key == publicKey {
+			hasKey = true
+		}
+	}
+	if !hasKey {
+		return false, nil
+	}
+
+	machineCount, _, _ := unstructured.NestedInt64(mcp.Object, "status", "machineCount")
+	updatedMachineCount, _, _ := unstructured.NestedInt64(mcp.Object, "status", "updatedMachineCount")
+	if updatedMachineCount != machineCount {
+		return false, nil
+	}
+	conditions, _, _ := unstructured.NestedSlice(mcp.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if ok && cond["type"] == "Updated" {
+			return cond["status"] == string(corev1.ConditionTrue), nil
+		}
+	}
+	return false, nil
+}
+
+// authorizedKeys returns the SSH authorized keys of the core user.
+func authorizedKeys(users []interface{}) []string {
+	for _, u := range users {
+		user, ok := u.(map[string]interface{})
+		if !ok || user["name"] != coreUser {
+			continue
+		}
+		keys, _, _ := unstructured.NestedStringSlice(user, "sshAuthorizedKeys")
+		return keys
+	}
+	return nil
+}
+
+// completeRotation stores the rolled out key pair in the rotated SSH key secret of the ClusterDeployment, points the
+// ClusterDeployment at it, deletes the staging secret and records the rotation in the status of the ClusterDeployment.
+// The SSH key secret the cluster was installed with is left untouched, as it may be shared with other clusters.
+func (r *ReconcileSSHKeyRotation) completeRotation(cd *hivev1.ClusterDeployment, stagingSecret *corev1.Secret, cdLog log.FieldLogger) error {
+	secret := &corev1.Secret{}
+	err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: rotatedSecretName(cd)}, secret)
+	switch {
+	case apierrors.IsNotFound(err):
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cd.Namespace,
+				Name:      rotatedSecretName(cd),
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				constants.SSHPrivateKeySecretKey: stagingSecret.Data[constants.SSHPrivateKeySecretKey],
+				sshPublicKeySecretKey:            stagingSecret.Data[sshPublicKeySecretKey],
+			},
+		}
+		if err := controllerutil.SetControllerReference(cd, secret, r.scheme); err != nil {
+			return errors.Wrap(err, "could not set controller reference on rotated SSH key secret")
+		}
+		cdLog.Info("storing rotated SSH key in a new SSH key secret")
+		if err := r.Create(context.TODO(), secret); err != nil {
+			return errors.Wrap(err, "could not create rotated SSH key secret")
+		}
+	case err != nil:
+		return errors.Wrap(err, "could not get rotated SSH key secret")
+	default:
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[constants.SSHPrivateKeySecretKey] = stagingSecret.Data[constants.SSHPrivateKeySecretKey]
+		secret.Data[sshPublicKeySecretKey] = stagingSecret.Data[sshPublicKeySecretKey]
+		cdLog.Info("storing rotated SSH key in SSH key secret")
+		if err := r.Update(context.TODO(), secret); err != nil {
+			return errors.Wrap(err, "could not update rotated SSH key secret")
+		}
+	}
+
+	if cd.Spec.Provisioning.SSHPrivateKeySecretRef.Name != secret.Name {
+		cdLog.WithField("secret", secret.Name).Info("pointing cluster deployment at rotated SSH key secret")
+		cd.Spec.Provisioning.SSHPrivateKeySecretRef = &corev1.LocalObjectReference{Name: secret.Name}
+		if err := r.Update(context.TODO(), cd); err != nil {
+			return errors.Wrap(err, "could not point cluster deployment at rotated SSH key secret")
+		}
+	}
+
+	if err := r.Delete(context.TODO(), stagingSecret); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "could not delete SSH key rotation secret")
+	}
+
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey(stagingSecret.Data[sshPublicKeySecretKey])
+	if err != nil {
+		return errors.Wrap(err, "could not parse rotated SSH public key")
+	}
+	now := metav1.Now()
+	cd.Status.SSHKeyRotation = &hivev1.SSHKeyRotationStatus{
+		RotationID:           cd.Spec.SSHKeyRotation.RotationID,
+		PublicKeyFingerprint: ssh.FingerprintSHA256(publicKey),
+		LastRotationTime:     &now,
+	}
+	cd.Status.Conditions = controllerutils.SetClusterDeploymentCondition(
+		cd.Status.Conditions,
+		hivev1.SSHKeyRotationInProgressClusterDeploymentCondition,
+		corev1.ConditionFalse,
+		hivev1.SSHKeyRotationCompleteReason,
+		"The SSH key has been rotated",
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	cdLog.Info("SSH key rotation complete")
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not update SSH key rotation status")
+		return err
+	}
+	return nil
+}
+
+// setFailed reports the error in the SSHKeyRotationInProgress condition and returns it.
+func (r *ReconcileSSHKeyRotation) setFailed(cd *hivev1.ClusterDeployment, err error, cdLog log.FieldLogger) error {
+	cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error rotating SSH key")
+	if condErr := r.setCondition(cd, corev1.ConditionFalse, hivev1.SSHKeyRotationFailedReason, err.Error(), cdLog); condErr != nil {
+		return condErr
+	}
+	return err
+}
+
+func (r *ReconcileSSHKeyRotation) setCondition(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason, message string, cdLog log.FieldLogger) error {
+	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.SSHKeyRotationInProgressClusterDeploymentCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conditions
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not update SSHKeyRotationInProgress condition")
+		return err
+	}
+	return nil
+}
+
+// generateKeyPair generates an RSA key pair, returning the PEM-encoded private key and the public key in the
+// authorized_keys format, commented to mark it as managed by Hive.
+func generateKeyPair() ([]byte, []byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not generate SSH key")
+	}
+	publicKey, err := ssh.NewPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not generate SSH public key")
+	}
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	authorizedKey := fmt.Sprintf("%s %s\n", bytes.TrimSpace(ssh.MarshalAuthorizedKey(publicKey)), publicKeyComment)
+	return privateKey, []byte(authorizedKey), nil
+}
+
+func stagingSecretName(cd *hivev1.ClusterDeployment) string {
+	return cd.Name + "-ssh-key-rotation"
+}
+
+func rotatedSecretName(cd *hivev1.ClusterDeployment) string {
+	return cd.Name + "-rotated-ssh-key"
+}
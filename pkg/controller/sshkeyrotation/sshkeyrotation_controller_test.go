@@ -0,0 +1,366 @@
+package sshkeyrotation
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+)
+
+const (
+	testName          = "foo"
+	testNamespace     = "default"
+	sshKeySecretName  = "foo-ssh-key"
+	testRotationID    = "rotation-2"
+	renderedOldSuffix = "-old"
+	renderedNewSuffix = "-new"
+)
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestSSHKeyRotationReconcile(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	stagedPrivateKey, stagedPublicKey, err := generateKeyPair()
+	require.NoError(t, err, "unexpected error generating key pair")
+	stagedKey := strings.TrimSpace(string(stagedPublicKey))
+	// The key the cluster was installed with, and a key of the core user not managed by Hive.
+	oldPrivateKey, oldPublicKey := testKeyPair(t, "")
+	_, userKey := testKeyPair(t, "user@example.com")
+
+	withRotation := func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.SSHKeyRotation = &hivev1.SSHKeyRotation{RotationID: testRotationID}
+	}
+	withoutSSHKeySecret := func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.Provisioning = nil
+	}
+	rotated := func(cd *hivev1.ClusterDeployment) {
+		cd.Status.SSHKeyRotation = &hivev1.SSHKeyRotationStatus{RotationID: testRotationID}
+	}
+	stagingSecret := func(rotationID string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   testNamespace,
+				Name:        testName + "-ssh-key-rotation",
+				Annotations: map[string]string{rotationIDAnnotation: rotationID},
+			},
+			Data: map[string][]byte{
+				constants.SSHPrivateKeySecretKey: stagedPrivateKey,
+				sshPublicKeySecretKey:            stagedPublicKey,
+			},
+		}
+	}
+	// remoteObjects returns the SSH MachineConfigs with the key and the key of the user, and pools on rendered
+	// configurations with the key when rolledOut is set.
+	remoteObjects := func(key string, rolledOut bool) []runtime.Object {
+		var objs []runtime.Object
+		for _, pool := range machineConfigPools {
+			renderedName := "rendered-" + pool + renderedOldSuffix
+			if rolledOut {
+				renderedName = "rendered-" + pool + renderedNewSuffix
+			}
+			objs = append(objs,
+				testMachineConfig("99-"+pool+"-ssh", userKey, key),
+				testMachineConfig("rendered-"+pool+renderedOldSuffix, oldPublicKey),
+				testMachineConfig("rendered-"+pool+renderedNewSuffix, key),
+				testMachineConfigPool(pool, renderedName),
+			)
+		}
+		return objs
+	}
+
+	tests := []struct {
+		name                string
+		cd                  *hivev1.ClusterDeployment
+		existing            []runtime.Object
+		remote              []runtime.Object
+		noRemoteCall        bool
+		expectRequeue       bool
+		expectNewKey        bool
+		expectStagedKey     bool
+		expectComplete      bool
+		expectedReason      string
+		expectedMCPublicKey string
+	}{
+		{
+			name:         "not requested",
+			cd:           testClusterDeployment(),
+			noRemoteCall: true,
+		},
+		{
+			name:         "already rotated",
+			cd:           testClusterDeployment(withRotation, rotated),
+			noRemoteCall: true,
+		},
+		{
+			name:           "no SSH key secret",
+			cd:             testClusterDeployment(withRotation, withoutSSHKeySecret),
+			noRemoteCall:   true,
+			expectedReason: hivev1.SSHKeyRotationFailedReason,
+		},
+		{
+			name:           "start rotation",
+			cd:             testClusterDeployment(withRotation),
+			remote:         remoteObjects(oldPublicKey, false),
+			expectRequeue:  true,
+			expectNewKey:   true,
+			expectedReason: hivev1.SSHKeyRolloutInProgressReason,
+		},
+		{
+			name:                "rollout in progress",
+			cd:                  testClusterDeployment(withRotation),
+			existing:            []runtime.Object{stagingSecret(testRotationID)},
+			remote:              remoteObjects(stagedKey, false),
+			expectRequeue:       true,
+			expectStagedKey:     true,
+			expectedReason:      hivev1.SSHKeyRolloutInProgressReason,
+			expectedMCPublicKey: stagedKey,
+		},
+		{
+			name:            "superseded rotation",
+			cd:              testClusterDeployment(withRotation),
+			existing:        []runtime.Object{stagingSecret("rotation-1")},
+			remote:          remoteObjects(stagedKey, false),
+			expectRequeue:   true,
+			expectNewKey:    true,
+			expectedReason:  hivev1.SSHKeyRolloutInProgressReason,
+			expectStagedKey: false,
+		},
+		{
+			name:                "rotation complete",
+			cd:                  testClusterDeployment(withRotation),
+			existing:            []runtime.Object{stagingSecret(testRotationID)},
+			remote:              remoteObjects(stagedKey, true),
+			expectComplete:      true,
+			expectedReason:      hivev1.SSHKeyRotationCompleteReason,
+			expectedMCPublicKey: stagedKey,
+		},
+		{
+			name: "second rotation complete",
+			cd: testClusterDeployment(withRotation, func(cd *hivev1.ClusterDeployment) {
+				cd.Spec.Provisioning.SSHPrivateKeySecretRef.Name = rotatedSecretName(cd)
+			}),
+			existing: []runtime.Object{stagingSecret(testRotationID), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testName + "-rotated-ssh-key"},
+				Data:       map[string][]byte{constants.SSHPrivateKeySecretKey: oldPrivateKey},
+			}},
+			remote:              remoteObjects(stagedKey, true),
+			expectComplete:      true,
+			expectedReason:      hivev1.SSHKeyRotationCompleteReason,
+			expectedMCPublicKey: stagedKey,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			existing := append([]runtime.Object{test.cd, testSSHKeySecret(oldPrivateKey)}, test.existing...)
+			fakeClient := fake.NewFakeClient(existing...)
+			remoteClient := fake.NewFakeClient(test.remote...)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			if !test.noRemoteCall {
+				mockRemoteClientBuilder.EXPECT().Build().Return(remoteClient, nil)
+			}
+			r := &ReconcileSSHKeyRotation{
+				Client:                        fakeClient,
+				scheme:                        scheme.Scheme,
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+			}
+
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName},
+			})
+			require.NoError(t, err, "unexpected error from reconcile")
+			if test.expectRequeue {
+				assert.Equal(t, rolloutRequeueInterval, result.RequeueAfter, "expected requeue to wait for the rollout")
+			} else {
+				assert.Zero(t, result.RequeueAfter, "unexpected requeue")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, cd))
+			cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.SSHKeyRotationInProgressClusterDeploymentCondition)
+			if test.expectedReason == "" {
+				assert.Nil(t, cond, "unexpected SSHKeyRotationInProgress condition")
+			} else if assert.NotNil(t, cond, "missing SSHKeyRotationInProgress condition") {
+				assert.Equal(t, test.expectedReason, cond.Reason, "unexpected condition reason")
+				expectedStatus := corev1.ConditionFalse
+				if test.expectedReason == hivev1.SSHKeyRolloutInProgressReason {
+					expectedStatus = corev1.ConditionTrue
+				}
+				assert.Equal(t, expectedStatus, cond.Status, "unexpected condition status")
+			}
+
+			staged := &corev1.Secret{}
+			err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName + "-ssh-key-rotation"}, staged)
+			if test.expectRequeue {
+				require.NoError(t, err, "unexpected error getting staging secret")
+				assert.Equal(t, testRotationID, staged.Annotations[rotationIDAnnotation], "unexpected rotation ID of staging secret")
+				stagedSecretKey := strings.TrimSpace(string(staged.Data[sshPublicKeySecretKey]))
+				if test.expectNewKey {
+					assert.NotEqual(t, stagedKey, stagedSecretKey, "expected a new key pair")
+				}
+				if test.expectStagedKey {
+					assert.Equal(t, stagedKey, stagedSecretKey, "expected the staged key pair to be reused")
+				}
+				for _, pool := range machineConfigPools {
+					assert.Equal(t, []string{userKey, stagedSecretKey}, getAuthorizedKeys(t, remoteClient, "99-"+pool+"-ssh"), "unexpected authorized keys")
+				}
+			} else {
+				assert.True(t, apierrors.IsNotFound(err), "expected no staging secret")
+			}
+
+			// The SSH key secret the cluster was installed with may be shared, so it is never changed.
+			sshKeySecret := &corev1.Secret{}
+			require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: sshKeySecretName}, sshKeySecret))
+			assert.Equal(t, string(oldPrivateKey), string(sshKeySecret.Data[constants.SSHPrivateKeySecretKey]), "expected private key to be unchanged")
+
+			rotatedSecret := &corev1.Secret{}
+			err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName + "-rotated-ssh-key"}, rotatedSecret)
+			if test.expectComplete {
+				require.NoError(t, err, "unexpected error getting rotated SSH key secret")
+				assert.Equal(t, string(stagedPrivateKey), string(rotatedSecret.Data[constants.SSHPrivateKeySecretKey]), "unexpected private key")
+				assert.Equal(t, string(stagedPublicKey), string(rotatedSecret.Data[sshPublicKeySecretKey]), "unexpected public key")
+				assert.Equal(t, rotatedSecret.Name, cd.Spec.Provisioning.SSHPrivateKeySecretRef.Name, "expected cluster deployment to point at rotated SSH key secret")
+				if assert.NotNil(t, cd.Status.SSHKeyRotation, "missing SSH key rotation status") {
+					assert.Equal(t, testRotationID, cd.Status.SSHKeyRotation.RotationID, "unexpected rotation ID")
+					assert.True(t, strings.HasPrefix(cd.Status.SSHKeyRotation.PublicKeyFingerprint, "SHA256:"), "unexpected fingerprint")
+					assert.NotNil(t, cd.Status.SSHKeyRotation.LastRotationTime, "missing last rotation time")
+				}
+			} else {
+				assert.True(t, apierrors.IsNotFound(err), "expected no rotated SSH key secret")
+			}
+
+			if test.expectedMCPublicKey != "" {
+				for _, pool := range machineConfigPools {
+					assert.Equal(t, []string{userKey, test.expectedMCPublicKey}, getAuthorizedKeys(t, remoteClient, "99-"+pool+"-ssh"), "unexpected authorized keys")
+				}
+			}
+		})
+	}
+}
+
+func testClusterDeployment(opts ...func(*hivev1.ClusterDeployment)) *hivev1.ClusterDeployment {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+			UID:       types.UID("1234"),
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: testName,
+			Installed:   true,
+			ClusterMetadata: &hivev1.ClusterMetadata{
+				ClusterID: "cluster-id",
+				InfraID:   "infra-id",
+			},
+			Provisioning: &hivev1.Provisioning{
+				SSHPrivateKeySecretRef: &corev1.LocalObjectReference{Name: sshKeySecretName},
+			},
+		},
+		Status: hivev1.ClusterDeploymentStatus{
+			Conditions: []hivev1.ClusterDeploymentCondition{{
+				Type:               hivev1.UnreachableCondition,
+				Status:             corev1.ConditionFalse,
+				LastProbeTime:      metav1.NewTime(time.Now()),
+				LastTransitionTime: metav1.NewTime(time.Now()),
+			}},
+		},
+	}
+	for _, o := range opts {
+		o(cd)
+	}
+	return cd
+}
+
+func testSSHKeySecret(privateKey []byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: sshKeySecretName},
+		Data:       map[string][]byte{constants.SSHPrivateKeySecretKey: privateKey},
+	}
+}
+
+// testKeyPair returns a key pair that was not generated by Hive, with the public key in the authorized_keys format.
+func testKeyPair(t *testing.T, comment string) ([]byte, string) {
+	privateKey, publicKey, err := generateKeyPair()
+	require.NoError(t, err, "unexpected error generating key pair")
+	fields := strings.Fields(string(publicKey))
+	return privateKey, strings.TrimSpace(strings.Join([]string{fields[0], fields[1], comment}, " "))
+}
+
+func testMachineConfig(name string, keys ...string) *unstructured.Unstructured {
+	sshAuthorizedKeys := make([]interface{}, len(keys))
+	for i, key := range keys {
+		sshAuthorizedKeys[i] = key
+	}
+	mc := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"config": map[string]interface{}{
+				"ignition": map[string]interface{}{"version": "3.2.0"},
+				"passwd": map[string]interface{}{
+					"users": []interface{}{
+						map[string]interface{}{
+							"name":              coreUser,
+							"sshAuthorizedKeys": sshAuthorizedKeys,
+						},
+					},
+				},
+			},
+		},
+	}}
+	mc.SetGroupVersionKind(machineConfigGVK)
+	mc.SetName(name)
+	return mc
+}
+
+func testMachineConfigPool(name, renderedName string) *unstructured.Unstructured {
+	mcp := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"configuration":       map[string]interface{}{"name": renderedName},
+			"machineCount":        int64(3),
+			"updatedMachineCount": int64(3),
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Updated", "status": "True"},
+			},
+		},
+	}}
+	mcp.SetGroupVersionKind(machineConfigPoolGVK)
+	mcp.SetName(name)
+	return mcp
+}
+
+func getAuthorizedKeys(t *testing.T, c client.Client, name string) []string {
+	mc := &unstructured.Unstructured{}
+	mc.SetGroupVersionKind(machineConfigGVK)
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: name}, mc), "unexpected error getting MachineConfig")
+	users, _, err := unstructured.NestedSlice(mc.Object, "spec", "config", "passwd", "users")
+	require.NoError(t, err, "unexpected error getting users")
+	return authorizedKeys(users)
+}
@@ -0,0 +1,69 @@
+package syncidentityprovider
+
+import (
+	openshiftapiv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// identityProviderConfigNamespace is the namespace in the cluster where the OAuth server looks for the secrets
+	// and config maps referenced by the identity providers.
+	identityProviderConfigNamespace = "openshift-config"
+)
+
+// referencedResources holds the names of the secrets and config maps referenced by identity providers.
+type referencedResources struct {
+	secrets    sets.String
+	configMaps sets.String
+}
+
+func newReferencedResources() *referencedResources {
+	return &referencedResources{
+		secrets:    sets.NewString(),
+		configMaps: sets.NewString(),
+	}
+}
+
+// add records the secrets and config maps referenced by the identity provider.
+func (r *referencedResources) add(idp openshiftapiv1.IdentityProvider) {
+	addSecret := func(ref openshiftapiv1.SecretNameReference) {
+		if ref.Name != "" {
+			r.secrets.Insert(ref.Name)
+		}
+	}
+	addConfigMap := func(ref openshiftapiv1.ConfigMapNameReference) {
+		if ref.Name != "" {
+			r.configMaps.Insert(ref.Name)
+		}
+	}
+	addRemoteConnectionInfo := func(info openshiftapiv1.OAuthRemoteConnectionInfo) {
+		addConfigMap(info.CA)
+		addSecret(info.TLSClientCert)
+		addSecret(info.TLSClientKey)
+	}
+
+	switch {
+	case idp.BasicAuth != nil:
+		addRemoteConnectionInfo(idp.BasicAuth.OAuthRemoteConnectionInfo)
+	case idp.GitHub != nil:
+		addSecret(idp.GitHub.ClientSecret)
+		addConfigMap(idp.GitHub.CA)
+	case idp.GitLab != nil:
+		addSecret(idp.GitLab.ClientSecret)
+		addConfigMap(idp.GitLab.CA)
+	case idp.Google != nil:
+		addSecret(idp.Google.ClientSecret)
+	case idp.HTPasswd != nil:
+		addSecret(idp.HTPasswd.FileData)
+	case idp.Keystone != nil:
+		addRemoteConnectionInfo(idp.Keystone.OAuthRemoteConnectionInfo)
+	case idp.LDAP != nil:
+		addSecret(idp.LDAP.BindPassword)
+		addConfigMap(idp.LDAP.CA)
+	case idp.OpenID != nil:
+		addSecret(idp.OpenID.ClientSecret)
+		addConfigMap(idp.OpenID.CA)
+	case idp.RequestHeader != nil:
+		addConfigMap(idp.RequestHeader.ClientCA)
+	}
+}
@@ -0,0 +1,146 @@
+package syncidentityprovider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// clusterApplyResult determines the result of applying the identity provider SyncSet to the cluster from the
+// ClusterSync of the cluster.
+func (r *ReconcileSyncIdentityProviders) clusterApplyResult(cd *hivev1.ClusterDeployment, ss *hivev1.SyncSet) (hivev1.IdentityProviderApplyResult, string, error) {
+	clusterSync := &hiveintv1alpha1.ClusterSync{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, clusterSync); {
+	case errors.IsNotFound(err):
+		return hivev1.PendingIdentityProviderApplyResult, "", nil
+	case err != nil:
+		return "", "", err
+	}
+	for _, syncStatus := range clusterSync.Status.SyncSets {
+		if syncStatus.Name != ss.Name {
+			continue
+		}
+		if syncStatus.ObservedGeneration != ss.Generation {
+			return hivev1.PendingIdentityProviderApplyResult, "", nil
+		}
+		if syncStatus.Result == hiveintv1alpha1.FailureSyncSetResult {
+			return hivev1.FailureIdentityProviderApplyResult, syncStatus.FailureMessage, nil
+		}
+		return hivev1.SuccessIdentityProviderApplyResult, "", nil
+	}
+	return hivev1.PendingIdentityProviderApplyResult, "", nil
+}
+
+// setClusterStatus records the result of applying the identity providers to the cluster in the status of the
+// SyncIdentityProvider or SelectorSyncIdentityProvider. A nil clusterStatus removes the cluster from the status.
+func (r *ReconcileSyncIdentityProviders) setClusterStatus(
+	obj client.Object,
+	status *hivev1.IdentityProviderStatus,
+	cdKey types.NamespacedName,
+	clusterStatus *hivev1.IdentityProviderClusterStatus,
+	logger log.FieldLogger,
+) error {
+	original := status.DeepCopy()
+
+	index := -1
+	for i, s := range status.ClusterDeployments {
+		if s.Namespace == cdKey.Namespace && s.Name == cdKey.Name {
+			index = i
+			break
+		}
+	}
+	switch {
+	case clusterStatus == nil && index >= 0:
+		status.ClusterDeployments = append(status.ClusterDeployments[:index], status.ClusterDeployments[index+1:]...)
+	case clusterStatus == nil:
+	case index < 0:
+		clusterStatus.LastTransitionTime = metav1.Now()
+		status.ClusterDeployments = append(status.ClusterDeployments, *clusterStatus)
+		sort.Slice(status.ClusterDeployments, func(i, j int) bool {
+			a, b := status.ClusterDeployments[i], status.ClusterDeployments[j]
+			if a.Namespace != b.Namespace {
+				return a.Namespace < b.Namespace
+			}
+			return a.Name < b.Name
+		})
+	default:
+		existing := &status.ClusterDeployments[index]
+		if existing.Result != clusterStatus.Result || existing.FailureMessage != clusterStatus.FailureMessage {
+			existing.Result = clusterStatus.Result
+			existing.FailureMessage = clusterStatus.FailureMessage
+			existing.LastTransitionTime = metav1.Now()
+		}
+	}
+
+	var failedClusters []string
+	for _, s := range status.ClusterDeployments {
+		if s.Result == hivev1.FailureIdentityProviderApplyResult {
+			failedClusters = append(failedClusters, s.Namespace+"/"+s.Name)
+		}
+	}
+	conditionStatus := corev1.ConditionFalse
+	reason := "ApplySucceeded"
+	message := "identity providers have not failed to apply to any cluster"
+	if len(failedClusters) > 0 {
+		conditionStatus = corev1.ConditionTrue
+		reason = "ApplyFailed"
+		message = fmt.Sprintf("identity providers failed to apply to clusters: %s", strings.Join(failedClusters, ", "))
+	}
+	status.Conditions, _ = controllerutils.SetIdentityProviderConditionWithChangeCheck(
+		status.Conditions,
+		hivev1.ApplyFailedIdentityProviderCondition,
+		conditionStatus,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+
+	if reflect.DeepEqual(original, status) {
+		return nil
+	}
+	if err := r.Status().Update(context.TODO(), obj); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update identity provider status")
+		return err
+	}
+	return nil
+}
+
+// removeClusterStatus removes the cluster from the status of all the SyncIdentityProviders and
+// SelectorSyncIdentityProviders.
+func (r *ReconcileSyncIdentityProviders) removeClusterStatus(cdKey types.NamespacedName, logger log.FieldLogger) error {
+	ssidpList := &hivev1.SelectorSyncIdentityProviderList{}
+	if err := r.List(context.TODO(), ssidpList); err != nil {
+		return err
+	}
+	for i := range ssidpList.Items {
+		ssidp := &ssidpList.Items[i]
+		if err := r.setClusterStatus(ssidp, &ssidp.Status, cdKey, nil, logger); err != nil {
+			return err
+		}
+	}
+	sidpList := &hivev1.SyncIdentityProviderList{}
+	if err := r.List(context.TODO(), sidpList, client.InNamespace(cdKey.Namespace)); err != nil {
+		return err
+	}
+	for i := range sidpList.Items {
+		sidp := &sidpList.Items[i]
+		if err := r.setClusterStatus(sidp, &sidp.Status, cdKey, nil, logger); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
@@ -15,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 
@@ -30,6 +32,7 @@ import (
 
 	apihelpers "github.com/openshift/hive/apis/helpers"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
@@ -47,7 +50,7 @@ const (
 // Add creates a new IdentityProvider Controller and adds it to the Manager with default RBAC. The Manager will set fields on the
 // Controller and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
 	if err != nil {
 		logger.WithError(err).Error("could not get controller configurations")
@@ -61,14 +64,14 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 	return &ReconcileSyncIdentityProviders{
 		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
 		scheme: mgr.GetScheme(),
-		logger: log.WithField("controller", ControllerName),
+		logger: controllerutils.ControllerLogEntry(ControllerName),
 	}
 }
 
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
-	c, err := controller.New(ControllerName.String()+"-controller", mgr, controller.Options{
+	c, err := controllerutils.NewController(ControllerName.String()+"-controller", mgr, controller.Options{
 		Reconciler:              r,
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
@@ -95,6 +98,13 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 
 	// Watch for changes to ClusterDeployment (easy case)
 	err = c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterSync to report the result of applying the identity providers. The ClusterSync has
+	// the same name as the ClusterDeployment.
+	err = c.Watch(&source.Kind{Type: &hiveintv1alpha1.ClusterSync{}}, &handler.EnqueueRequestForObject{})
 	return err
 }
 
@@ -115,7 +125,7 @@ func (r *ReconcileSyncIdentityProviders) syncIdentityProviderWatchHandler(a clie
 		}})
 	}
 
-	return retval
+	return appendStatusClusterRequests(retval, syncIDP.Status)
 }
 
 func (r *ReconcileSyncIdentityProviders) selectorSyncIdentityProviderWatchHandler(a client.Object) []reconcile.Request {
@@ -148,7 +158,24 @@ func (r *ReconcileSyncIdentityProviders) selectorSyncIdentityProviderWatchHandle
 		}
 	}
 
-	return retval
+	return appendStatusClusterRequests(retval, ssidp.Status)
+}
+
+// appendStatusClusterRequests adds requests for the clusters in the status that are not already requested so that
+// clusters which no longer match have the identity providers removed.
+func appendStatusClusterRequests(requests []reconcile.Request, status hivev1.IdentityProviderStatus) []reconcile.Request {
+	requested := map[types.NamespacedName]bool{}
+	for _, request := range requests {
+		requested[request.NamespacedName] = true
+	}
+	for _, clusterStatus := range status.ClusterDeployments {
+		key := types.NamespacedName{Namespace: clusterStatus.Namespace, Name: clusterStatus.Name}
+		if !requested[key] {
+			requests = append(requests, reconcile.Request{NamespacedName: key})
+			requested[key] = true
+		}
+	}
+	return requests
 }
 
 var _ reconcile.Reconciler = &ReconcileSyncIdentityProviders{}
@@ -183,9 +210,9 @@ func (r *ReconcileSyncIdentityProviders) Reconcile(ctx context.Context, request
 	err := r.Get(context.TODO(), request.NamespacedName, cd)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			// Object not found, return
+			// Object not found, remove the cluster from the identity provider statuses
 			contextLogger.Info("cluster deployment not found")
-			return reconcile.Result{}, nil
+			return reconcile.Result{}, r.removeClusterStatus(request.NamespacedName, contextLogger)
 		}
 
 		// Error reading the object - requeue the request
@@ -200,15 +227,19 @@ func (r *ReconcileSyncIdentityProviders) Reconcile(ctx context.Context, request
 		return reconcile.Result{}, err
 	}
 
+	if controllerutils.IsClusterDeploymentPaused(cd, contextLogger) {
+		return reconcile.Result{}, nil
+	}
+
 	// If the clusterdeployment is deleted, do not reconcile.
 	if cd.DeletionTimestamp != nil {
-		return reconcile.Result{}, nil
+		return reconcile.Result{}, r.removeClusterStatus(request.NamespacedName, contextLogger)
 	}
 
 	return reconcile.Result{}, r.syncIdentityProviders(cd, contextLogger)
 }
 
-func (r *ReconcileSyncIdentityProviders) createSyncSetSpec(cd *hivev1.ClusterDeployment, idps []openshiftapiv1.IdentityProvider) (*hivev1.SyncSetSpec, error) {
+func (r *ReconcileSyncIdentityProviders) createSyncSetSpec(cd *hivev1.ClusterDeployment, idps []openshiftapiv1.IdentityProvider, secretNames []string, configMaps []*corev1.ConfigMap) (*hivev1.SyncSetSpec, error) {
 	idpPatch := identityProviderPatch{
 		Spec: identityProviderPatchSpec{
 			IdentityProviders: idps,
@@ -220,7 +251,7 @@ func (r *ReconcileSyncIdentityProviders) createSyncSetSpec(cd *hivev1.ClusterDep
 		return nil, fmt.Errorf("Failed marshaling identity provider list: %v", err)
 	}
 
-	return &hivev1.SyncSetSpec{
+	spec := &hivev1.SyncSetSpec{
 		ClusterDeploymentRefs: []corev1.LocalObjectReference{
 			{
 				Name: cd.Name,
@@ -237,7 +268,37 @@ func (r *ReconcileSyncIdentityProviders) createSyncSetSpec(cd *hivev1.ClusterDep
 				},
 			},
 		},
-	}, nil
+	}
+
+	for _, name := range secretNames {
+		spec.Secrets = append(spec.Secrets, hivev1.SecretMapping{
+			SourceRef: hivev1.SecretReference{Name: name, Namespace: cd.Namespace},
+			TargetRef: hivev1.SecretReference{Name: name, Namespace: identityProviderConfigNamespace},
+		})
+	}
+
+	for _, cm := range configMaps {
+		// Copy only the data of the config map so that the resource in the SyncSet is stable across reconciles.
+		targetConfigMap := &corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cm.Name,
+				Namespace: identityProviderConfigNamespace,
+			},
+			Data:       cm.Data,
+			BinaryData: cm.BinaryData,
+		}
+		raw, err := json.Marshal(targetConfigMap)
+		if err != nil {
+			return nil, fmt.Errorf("Failed marshaling config map %s: %v", cm.Name, err)
+		}
+		spec.Resources = append(spec.Resources, runtime.RawExtension{Raw: raw})
+	}
+
+	return spec, nil
 }
 
 // GenerateIdentityProviderSyncSetName generates the name of the SyncSet that holds the identity provider information to sync.
@@ -246,35 +307,146 @@ func GenerateIdentityProviderSyncSetName(clusterDeploymentName string) string {
 }
 
 func (r *ReconcileSyncIdentityProviders) syncIdentityProviders(cd *hivev1.ClusterDeployment, contextLogger *log.Entry) error {
-	idpsFromSSIDP, err := r.getRelatedSelectorSyncIdentityProviders(cd, contextLogger)
-	if err != nil {
+	ssidpList := &hivev1.SelectorSyncIdentityProviderList{}
+	if err := r.List(context.TODO(), ssidpList); err != nil {
+		contextLogger.WithError(err).Error("error listing selectorsyncidentityproviders")
 		return err
 	}
-
-	idpsFromSIDP, err := r.getRelatedSyncIdentityProviders(cd)
-	if err != nil {
+	sidpList := &hivev1.SyncIdentityProviderList{}
+	if err := r.List(context.TODO(), sidpList, client.InNamespace(cd.Namespace)); err != nil {
+		contextLogger.WithError(err).Error("error listing syncidentityproviders")
 		return err
 	}
 
-	allIdps := append([]openshiftapiv1.IdentityProvider{}, idpsFromSSIDP...)
-	allIdps = append(allIdps, idpsFromSIDP...)
+	relatedSSIDPs := getRelatedSelectorSyncIdentityProviders(cd, ssidpList.Items, contextLogger)
+	relatedSIDPs := getRelatedSyncIdentityProviders(cd, sidpList.Items)
+
+	var idpsFromSSIDP, idpsFromSIDP []openshiftapiv1.IdentityProvider
+	references := newReferencedResources()
+	// objectReferences holds the config maps referenced by each of the objects syncing referenced resources so that
+	// a missing config map can be reported on the objects that reference it.
+	objectReferences := map[client.Object]*referencedResources{}
+	for _, ssidp := range relatedSSIDPs {
+		idpsFromSSIDP = append(idpsFromSSIDP, ssidp.Spec.IdentityProviders...)
+		if ssidp.Spec.SyncReferencedResources {
+			objectReferences[ssidp] = newReferencedResources()
+			for _, idp := range ssidp.Spec.IdentityProviders {
+				references.add(idp)
+				objectReferences[ssidp].add(idp)
+			}
+		}
+	}
+	for _, sidp := range relatedSIDPs {
+		idpsFromSIDP = append(idpsFromSIDP, sidp.Spec.IdentityProviders...)
+		if sidp.Spec.SyncReferencedResources {
+			objectReferences[sidp] = newReferencedResources()
+			for _, idp := range sidp.Spec.IdentityProviders {
+				references.add(idp)
+				objectReferences[sidp].add(idp)
+			}
+		}
+	}
+
+	// Sort so that the patch is consistent
+	allIdps := append([]openshiftapiv1.IdentityProvider{}, sortIdentityProviders(idpsFromSSIDP)...)
+	allIdps = append(allIdps, sortIdentityProviders(idpsFromSIDP)...)
+
+	var configMaps []*corev1.ConfigMap
+	missingConfigMaps := sets.NewString()
+	for _, name := range references.configMaps.List() {
+		cm := &corev1.ConfigMap{}
+		switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: name}, cm); {
+		case errors.IsNotFound(err):
+			contextLogger.WithField("configMap", name).Warn("config map referenced by identity providers not found")
+			missingConfigMaps.Insert(name)
+		case err != nil:
+			contextLogger.WithError(err).WithField("configMap", name).Error("error getting config map referenced by identity providers")
+			return err
+		default:
+			configMaps = append(configMaps, cm)
+		}
+	}
 
 	// Create a SyncSetSpec that includes all IdentityProviders as a patch
-	newSyncSetSpec, err := r.createSyncSetSpec(cd, allIdps)
+	newSyncSetSpec, err := r.createSyncSetSpec(cd, allIdps, references.secrets.List(), configMaps)
+	if err != nil {
+		return err
+	}
+
+	ss, err := r.applySyncSet(cd, newSyncSetSpec, len(allIdps) == 0, contextLogger)
 	if err != nil {
 		return err
 	}
 
+	result := hivev1.SuccessIdentityProviderApplyResult
+	failureMessage := ""
+	if ss != nil {
+		if result, failureMessage, err = r.clusterApplyResult(cd, ss); err != nil {
+			contextLogger.WithError(err).Error("error getting the result of applying the identity providers")
+			return err
+		}
+	}
+	clusterStatusFor := func(obj client.Object) *hivev1.IdentityProviderClusterStatus {
+		clusterStatus := &hivev1.IdentityProviderClusterStatus{
+			Namespace:      cd.Namespace,
+			Name:           cd.Name,
+			Result:         result,
+			FailureMessage: failureMessage,
+		}
+		if refs, ok := objectReferences[obj]; ok {
+			if missing := refs.configMaps.Intersection(missingConfigMaps); missing.Len() > 0 {
+				clusterStatus.Result = hivev1.FailureIdentityProviderApplyResult
+				clusterStatus.FailureMessage = fmt.Sprintf("referenced config maps not found: %s", strings.Join(missing.List(), ", "))
+			}
+		}
+		return clusterStatus
+	}
+
+	cdKey := types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}
+	related := map[client.Object]bool{}
+	for _, obj := range relatedSSIDPs {
+		related[obj] = true
+	}
+	for _, obj := range relatedSIDPs {
+		related[obj] = true
+	}
+	for i := range ssidpList.Items {
+		ssidp := &ssidpList.Items[i]
+		var clusterStatus *hivev1.IdentityProviderClusterStatus
+		if related[ssidp] {
+			clusterStatus = clusterStatusFor(ssidp)
+		}
+		if err := r.setClusterStatus(ssidp, &ssidp.Status, cdKey, clusterStatus, contextLogger); err != nil {
+			return err
+		}
+	}
+	for i := range sidpList.Items {
+		sidp := &sidpList.Items[i]
+		var clusterStatus *hivev1.IdentityProviderClusterStatus
+		if related[sidp] {
+			clusterStatus = clusterStatusFor(sidp)
+		}
+		if err := r.setClusterStatus(sidp, &sidp.Status, cdKey, clusterStatus, contextLogger); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applySyncSet creates or updates the SyncSet holding the identity providers of the cluster. It returns the SyncSet,
+// or nil when there are no identity providers to sync and the identity providers were not previously managed.
+func (r *ReconcileSyncIdentityProviders) applySyncSet(cd *hivev1.ClusterDeployment, newSyncSetSpec *hivev1.SyncSetSpec, empty bool, contextLogger *log.Entry) (*hivev1.SyncSet, error) {
 	ssName := GenerateIdentityProviderSyncSetName(cd.Name)
 
 	ss := &hivev1.SyncSet{}
-	err = r.Get(context.TODO(), types.NamespacedName{Name: ssName, Namespace: cd.Namespace}, ss)
+	err := r.Get(context.TODO(), types.NamespacedName{Name: ssName, Namespace: cd.Namespace}, ss)
 	if errors.IsNotFound(err) {
-		if len(allIdps) == 0 {
+		if empty {
 			// The IDP list is empty -and- an existing syncset wasn't found, which means that IDPs on this cluster
 			// haven't been managed previously. Therefore, DO NOT write out a syncset.
 			contextLogger.Debug("IDP list empty and syncset not found. Not writing out syncset with empty IDP list.")
-			return nil
+			return nil, nil
 		}
 
 		ss = &hivev1.SyncSet{
@@ -291,21 +463,21 @@ func (r *ReconcileSyncIdentityProviders) syncIdentityProviders(cd *hivev1.Cluste
 		ss.Labels = k8slabels.AddLabel(ss.Labels, constants.SyncSetTypeLabel, constants.SyncSetTypeIdentityProvider)
 		if err := controllerutil.SetControllerReference(cd, ss, r.scheme); err != nil {
 			contextLogger.WithError(err).Error("error setting controller reference on syncset")
-			return err
+			return nil, err
 		}
 
 		if err := r.Create(context.TODO(), ss); err != nil {
 			contextLogger.WithError(err).Log(controllerutils.LogLevel(err), "error creating syncset")
-			return err
+			return nil, err
 		}
 
 		// we successfully created it.
-		return nil
+		return ss, nil
 	}
 
 	if err != nil {
 		contextLogger.WithError(err).Error("error checking for existing syncset")
-		return err
+		return nil, err
 	}
 
 	// update the syncset if there have been changes
@@ -314,23 +486,18 @@ func (r *ReconcileSyncIdentityProviders) syncIdentityProviders(cd *hivev1.Cluste
 		if err := r.Update(context.TODO(), ss); err != nil {
 			errDetails := fmt.Errorf("error updating existing syncset: %v", err)
 			contextLogger.Error(errDetails)
-			return errDetails
+			return nil, errDetails
 		}
 	}
 
-	return nil
+	return ss, nil
 }
 
-func (r *ReconcileSyncIdentityProviders) getRelatedSelectorSyncIdentityProviders(cd *hivev1.ClusterDeployment, contextLogger *log.Entry) ([]openshiftapiv1.IdentityProvider, error) {
-	list := &hivev1.SelectorSyncIdentityProviderList{}
-	err := r.Client.List(context.TODO(), list)
-	if err != nil {
-		return nil, err
-	}
-
+func getRelatedSelectorSyncIdentityProviders(cd *hivev1.ClusterDeployment, ssidps []hivev1.SelectorSyncIdentityProvider, contextLogger *log.Entry) []*hivev1.SelectorSyncIdentityProvider {
 	cdLabelSet := labels.Set(cd.Labels)
-	var idps []openshiftapiv1.IdentityProvider
-	for _, ssidp := range list.Items {
+	var related []*hivev1.SelectorSyncIdentityProvider
+	for i := range ssidps {
+		ssidp := &ssidps[i]
 		labelSelector, err := metav1.LabelSelectorAsSelector(&ssidp.Spec.ClusterDeploymentSelector)
 		if err != nil {
 			contextLogger.WithError(err).Error("error converting LabelSelector to Selector")
@@ -338,37 +505,24 @@ func (r *ReconcileSyncIdentityProviders) getRelatedSelectorSyncIdentityProviders
 		}
 
 		if labelSelector.Matches(cdLabelSet) {
-			idps = append(idps, ssidp.Spec.IdentityProviders...)
+			related = append(related, ssidp)
 		}
 	}
-
-	// Sort so that the patch is consistent
-	idps = sortIdentityProviders(idps)
-
-	return idps, err
+	return related
 }
 
-func (r *ReconcileSyncIdentityProviders) getRelatedSyncIdentityProviders(cd *hivev1.ClusterDeployment) ([]openshiftapiv1.IdentityProvider, error) {
-	list := &hivev1.SyncIdentityProviderList{}
-	err := r.Client.List(context.TODO(), list, client.InNamespace(cd.Namespace))
-	if err != nil {
-		return nil, err
-	}
-
-	var idps []openshiftapiv1.IdentityProvider
-	for _, sip := range list.Items {
-		for _, cdRef := range sip.Spec.ClusterDeploymentRefs {
+func getRelatedSyncIdentityProviders(cd *hivev1.ClusterDeployment, sidps []hivev1.SyncIdentityProvider) []*hivev1.SyncIdentityProvider {
+	var related []*hivev1.SyncIdentityProvider
+	for i := range sidps {
+		sidp := &sidps[i]
+		for _, cdRef := range sidp.Spec.ClusterDeploymentRefs {
 			if cdRef.Name == cd.Name {
-				idps = append(idps, sip.Spec.IdentityProviders...)
+				related = append(related, sidp)
 				break // This cluster deployment won't be listed twice in the ClusterDeploymentRefs
 			}
 		}
 	}
-
-	// Sort so that the patch is consistent
-	idps = sortIdentityProviders(idps)
-
-	return idps, err
+	return related
 }
 
 func addSelectorSyncIdentityProviderLoggerFields(logger log.FieldLogger, ssidp *hivev1.SelectorSyncIdentityProvider) *log.Entry {
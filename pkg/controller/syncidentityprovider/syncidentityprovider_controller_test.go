@@ -9,6 +9,7 @@ import (
 	openshiftapiv1 "github.com/openshift/api/config/v1"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -19,8 +20,10 @@ import (
 
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
 
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 const (
@@ -187,6 +190,48 @@ func TestSyncIdentityProviderWatchHandler(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Cluster in status no longer referenced",
+			syncIdentityProvider: &hivev1.SyncIdentityProvider{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "sync1",
+					Namespace: "default",
+				},
+				Spec: hivev1.SyncIdentityProviderSpec{
+					ClusterDeploymentRefs: []corev1.LocalObjectReference{
+						{
+							Name: "someclusterdeployment",
+						},
+					},
+				},
+				Status: hivev1.IdentityProviderStatus{
+					ClusterDeployments: []hivev1.IdentityProviderClusterStatus{
+						{
+							Namespace: "default",
+							Name:      "otherclusterdeployment",
+						},
+						{
+							Namespace: "default",
+							Name:      "someclusterdeployment",
+						},
+					},
+				},
+			},
+			expectedRequestList: []reconcile.Request{
+				{
+					NamespacedName: types.NamespacedName{
+						Name:      "someclusterdeployment",
+						Namespace: "default",
+					},
+				},
+				{
+					NamespacedName: types.NamespacedName{
+						Name:      "otherclusterdeployment",
+						Namespace: "default",
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -481,6 +526,346 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
+func TestReconcileReferencedResources(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	openIDIdentityProvider := openshiftapiv1.IdentityProvider{
+		Name:          "openid",
+		MappingMethod: "claim",
+		IdentityProviderConfig: openshiftapiv1.IdentityProviderConfig{
+			Type: openshiftapiv1.IdentityProviderTypeOpenID,
+			OpenID: &openshiftapiv1.OpenIDIdentityProvider{
+				ClientID:     "hive",
+				ClientSecret: openshiftapiv1.SecretNameReference{Name: "openid-client-secret"},
+				CA:           openshiftapiv1.ConfigMapNameReference{Name: "openid-ca"},
+				Issuer:       "https://sso.example.com",
+			},
+		},
+	}
+	ldapIdentityProvider := openshiftapiv1.IdentityProvider{
+		Name:          "ldap",
+		MappingMethod: "claim",
+		IdentityProviderConfig: openshiftapiv1.IdentityProviderConfig{
+			Type: openshiftapiv1.IdentityProviderTypeLDAP,
+			LDAP: &openshiftapiv1.LDAPIdentityProvider{
+				URL:          "ldaps://ldap.example.com/ou=users,dc=example,dc=com?uid",
+				BindDN:       "cn=hive,dc=example,dc=com",
+				BindPassword: openshiftapiv1.SecretNameReference{Name: "ldap-bind-password"},
+			},
+		},
+	}
+	requestHeaderIdentityProvider := openshiftapiv1.IdentityProvider{
+		Name:          "requestheader",
+		MappingMethod: "claim",
+		IdentityProviderConfig: openshiftapiv1.IdentityProviderConfig{
+			Type: openshiftapiv1.IdentityProviderTypeRequestHeader,
+			RequestHeader: &openshiftapiv1.RequestHeaderIdentityProvider{
+				ClientCA: openshiftapiv1.ConfigMapNameReference{Name: "request-header-ca"},
+				Headers:  []string{"X-Remote-User"},
+			},
+		},
+	}
+	configMap := func(name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Data: map[string]string{"ca.crt": name + "-data"},
+		}
+	}
+	targetConfigMap := func(name string) runtime.RawExtension {
+		raw, _ := json.Marshal(&corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "openshift-config",
+			},
+			Data: map[string]string{"ca.crt": name + "-data"},
+		})
+		return runtime.RawExtension{Raw: raw}
+	}
+	secretMapping := func(name string) hivev1.SecretMapping {
+		return hivev1.SecretMapping{
+			SourceRef: hivev1.SecretReference{Name: name, Namespace: "default"},
+			TargetRef: hivev1.SecretReference{Name: name, Namespace: "openshift-config"},
+		}
+	}
+	syncReferencedResources := func(sidp *hivev1.SyncIdentityProvider) *hivev1.SyncIdentityProvider {
+		sidp.Spec.SyncReferencedResources = true
+		return sidp
+	}
+
+	tests := []struct {
+		name                  string
+		existing              []runtime.Object
+		expectedSecrets       []hivev1.SecretMapping
+		expectedResources     []runtime.RawExtension
+		expectedResult        hivev1.IdentityProviderApplyResult
+		expectedFailedMessage string
+	}{
+		{
+			name: "referenced resources not synced",
+			existing: []runtime.Object{
+				emptyClusterDeployment(),
+				syncIdentityProvidersThatReferencesEmptyClusterDeployment(sidpName, openIDIdentityProvider),
+			},
+			expectedResult: hivev1.PendingIdentityProviderApplyResult,
+		},
+		{
+			name: "openid, ldap and request header",
+			existing: []runtime.Object{
+				emptyClusterDeployment(),
+				syncReferencedResources(syncIdentityProvidersThatReferencesEmptyClusterDeployment(
+					sidpName, openIDIdentityProvider, ldapIdentityProvider, requestHeaderIdentityProvider)),
+				configMap("openid-ca"),
+				configMap("request-header-ca"),
+			},
+			expectedSecrets: []hivev1.SecretMapping{
+				secretMapping("ldap-bind-password"),
+				secretMapping("openid-client-secret"),
+			},
+			expectedResources: []runtime.RawExtension{
+				targetConfigMap("openid-ca"),
+				targetConfigMap("request-header-ca"),
+			},
+			expectedResult: hivev1.PendingIdentityProviderApplyResult,
+		},
+		{
+			name: "shared references are synced once",
+			existing: []runtime.Object{
+				emptyClusterDeployment(),
+				syncReferencedResources(syncIdentityProvidersThatReferencesEmptyClusterDeployment(sidpName, githubIdentityProvider(sidpName))),
+				syncReferencedResources(syncIdentityProvidersThatReferencesEmptyClusterDeployment(sidpName2, githubIdentityProvider(sidpName2))),
+			},
+			expectedSecrets: []hivev1.SecretMapping{
+				secretMapping("foo-github-client-secret"),
+			},
+			expectedResult: hivev1.PendingIdentityProviderApplyResult,
+		},
+		{
+			name: "missing config map",
+			existing: []runtime.Object{
+				emptyClusterDeployment(),
+				syncReferencedResources(syncIdentityProvidersThatReferencesEmptyClusterDeployment(sidpName, openIDIdentityProvider)),
+			},
+			expectedSecrets: []hivev1.SecretMapping{
+				secretMapping("openid-client-secret"),
+			},
+			expectedResult:        hivev1.FailureIdentityProviderApplyResult,
+			expectedFailedMessage: "referenced config maps not found: openid-ca",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &ReconcileSyncIdentityProviders{
+				Client: fake.NewFakeClient(test.existing...),
+				scheme: scheme.Scheme,
+				logger: log.WithField("controller", "syncidentityprovider"),
+			}
+
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "someclusterdeployment", Namespace: "default"},
+			})
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			ss := &hivev1.SyncSet{}
+			err = r.Get(context.TODO(), types.NamespacedName{Name: "someclusterdeployment-idp", Namespace: "default"}, ss)
+			require.NoError(t, err, "unexpected error getting syncset")
+			assert.Equal(t, test.expectedSecrets, ss.Spec.Secrets, "unexpected secret mappings")
+			assert.Equal(t, test.expectedResources, ss.Spec.Resources, "unexpected resources")
+
+			sidp := &hivev1.SyncIdentityProvider{}
+			err = r.Get(context.TODO(), types.NamespacedName{Name: sidpName + "somesyncidentityprovider", Namespace: "default"}, sidp)
+			require.NoError(t, err, "unexpected error getting syncidentityprovider")
+			if assert.Len(t, sidp.Status.ClusterDeployments, 1, "unexpected cluster statuses") {
+				assert.Equal(t, test.expectedResult, sidp.Status.ClusterDeployments[0].Result, "unexpected result")
+				assert.Equal(t, test.expectedFailedMessage, sidp.Status.ClusterDeployments[0].FailureMessage, "unexpected failure message")
+			}
+		})
+	}
+}
+
+func TestReconcileStatus(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	clusterSync := func(result hiveintv1alpha1.SyncSetResult, message string, observedGeneration int64) *hiveintv1alpha1.ClusterSync {
+		return &hiveintv1alpha1.ClusterSync{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "someclusterdeployment",
+				Namespace: "default",
+			},
+			Status: hiveintv1alpha1.ClusterSyncStatus{
+				SyncSets: []hiveintv1alpha1.SyncStatus{{
+					Name:               "someclusterdeployment-idp",
+					ObservedGeneration: observedGeneration,
+					Result:             result,
+					FailureMessage:     message,
+				}},
+			},
+		}
+	}
+	withClusterStatus := func(ssidp *hivev1.SelectorSyncIdentityProvider, namespace, name string, result hivev1.IdentityProviderApplyResult) *hivev1.SelectorSyncIdentityProvider {
+		ssidp.Status.ClusterDeployments = append(ssidp.Status.ClusterDeployments, hivev1.IdentityProviderClusterStatus{
+			Namespace: namespace,
+			Name:      name,
+			Result:    result,
+		})
+		return ssidp
+	}
+	deletedClusterDeployment := func() *hivev1.ClusterDeployment {
+		cd := clusterDeploymentWithLabels(labelMap)
+		now := metav1.Now()
+		cd.DeletionTimestamp = &now
+		return cd
+	}
+
+	tests := []struct {
+		name              string
+		existing          []runtime.Object
+		expectedStatuses  []hivev1.IdentityProviderClusterStatus
+		expectedCondition corev1.ConditionStatus
+	}{
+		{
+			name: "no clustersync",
+			existing: []runtime.Object{
+				clusterDeploymentWithLabels(labelMap),
+				selectorSyncIdentityProviders(ssidpName, githubIdentityProvider(ssidpName)),
+			},
+			expectedStatuses: []hivev1.IdentityProviderClusterStatus{{
+				Namespace: "default",
+				Name:      "someclusterdeployment",
+				Result:    hivev1.PendingIdentityProviderApplyResult,
+			}},
+		},
+		{
+			name: "syncset applied",
+			existing: []runtime.Object{
+				clusterDeploymentWithLabels(labelMap),
+				selectorSyncIdentityProviders(ssidpName, githubIdentityProvider(ssidpName)),
+				syncSetAsPointer(syncSetWithIdentityProviders(githubIdentityProvider(ssidpName))),
+				clusterSync(hiveintv1alpha1.SuccessSyncSetResult, "", 0),
+			},
+			expectedStatuses: []hivev1.IdentityProviderClusterStatus{{
+				Namespace: "default",
+				Name:      "someclusterdeployment",
+				Result:    hivev1.SuccessIdentityProviderApplyResult,
+			}},
+		},
+		{
+			name: "syncset not yet observed",
+			existing: []runtime.Object{
+				clusterDeploymentWithLabels(labelMap),
+				selectorSyncIdentityProviders(ssidpName, githubIdentityProvider(ssidpName)),
+				syncSetAsPointer(syncSetWithIdentityProviders(githubIdentityProvider(ssidpName))),
+				clusterSync(hiveintv1alpha1.SuccessSyncSetResult, "", -1),
+			},
+			expectedStatuses: []hivev1.IdentityProviderClusterStatus{{
+				Namespace: "default",
+				Name:      "someclusterdeployment",
+				Result:    hivev1.PendingIdentityProviderApplyResult,
+			}},
+		},
+		{
+			name: "syncset failed",
+			existing: []runtime.Object{
+				clusterDeploymentWithLabels(labelMap),
+				selectorSyncIdentityProviders(ssidpName, githubIdentityProvider(ssidpName)),
+				syncSetAsPointer(syncSetWithIdentityProviders(githubIdentityProvider(ssidpName))),
+				clusterSync(hiveintv1alpha1.FailureSyncSetResult, "failed to apply patch 0", 0),
+			},
+			expectedStatuses: []hivev1.IdentityProviderClusterStatus{{
+				Namespace:      "default",
+				Name:           "someclusterdeployment",
+				Result:         hivev1.FailureIdentityProviderApplyResult,
+				FailureMessage: "failed to apply patch 0",
+			}},
+			expectedCondition: corev1.ConditionTrue,
+		},
+		{
+			name: "failure resolved",
+			existing: []runtime.Object{
+				clusterDeploymentWithLabels(labelMap),
+				func() *hivev1.SelectorSyncIdentityProvider {
+					ssidp := withClusterStatus(selectorSyncIdentityProviders(ssidpName, githubIdentityProvider(ssidpName)),
+						"default", "someclusterdeployment", hivev1.FailureIdentityProviderApplyResult)
+					ssidp.Status.Conditions = []hivev1.IdentityProviderCondition{{
+						Type:   hivev1.ApplyFailedIdentityProviderCondition,
+						Status: corev1.ConditionTrue,
+						Reason: "ApplyFailed",
+					}}
+					return ssidp
+				}(),
+				syncSetAsPointer(syncSetWithIdentityProviders(githubIdentityProvider(ssidpName))),
+				clusterSync(hiveintv1alpha1.SuccessSyncSetResult, "", 0),
+			},
+			expectedStatuses: []hivev1.IdentityProviderClusterStatus{{
+				Namespace: "default",
+				Name:      "someclusterdeployment",
+				Result:    hivev1.SuccessIdentityProviderApplyResult,
+			}},
+			expectedCondition: corev1.ConditionFalse,
+		},
+		{
+			name: "cluster no longer matches",
+			existing: []runtime.Object{
+				emptyClusterDeployment(),
+				withClusterStatus(
+					withClusterStatus(selectorSyncIdentityProviders(ssidpName, githubIdentityProvider(ssidpName)),
+						"default", "someclusterdeployment", hivev1.SuccessIdentityProviderApplyResult),
+					"other", "othercluster", hivev1.SuccessIdentityProviderApplyResult),
+			},
+			expectedStatuses: []hivev1.IdentityProviderClusterStatus{{
+				Namespace: "other",
+				Name:      "othercluster",
+				Result:    hivev1.SuccessIdentityProviderApplyResult,
+			}},
+		},
+		{
+			name: "cluster deleted",
+			existing: []runtime.Object{
+				deletedClusterDeployment(),
+				withClusterStatus(selectorSyncIdentityProviders(ssidpName, githubIdentityProvider(ssidpName)),
+					"default", "someclusterdeployment", hivev1.SuccessIdentityProviderApplyResult),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &ReconcileSyncIdentityProviders{
+				Client: fake.NewFakeClient(test.existing...),
+				scheme: scheme.Scheme,
+				logger: log.WithField("controller", "syncidentityprovider"),
+			}
+
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "someclusterdeployment", Namespace: "default"},
+			})
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			ssidp := &hivev1.SelectorSyncIdentityProvider{}
+			err = r.Get(context.TODO(), types.NamespacedName{Name: ssidpName + "someselectorsyncidentityprovider", Namespace: "default"}, ssidp)
+			require.NoError(t, err, "unexpected error getting selectorsyncidentityprovider")
+			for i := range ssidp.Status.ClusterDeployments {
+				ssidp.Status.ClusterDeployments[i].LastTransitionTime = metav1.Time{}
+			}
+			assert.Equal(t, test.expectedStatuses, ssidp.Status.ClusterDeployments, "unexpected cluster statuses")
+
+			cond := controllerutils.FindIdentityProviderCondition(ssidp.Status.Conditions, hivev1.ApplyFailedIdentityProviderCondition)
+			if test.expectedCondition == "" {
+				assert.Nil(t, cond, "unexpected ApplyFailed condition")
+			} else if assert.NotNil(t, cond, "missing ApplyFailed condition") {
+				assert.Equal(t, test.expectedCondition, cond.Status, "unexpected ApplyFailed condition status")
+			}
+		})
+	}
+}
+
 func assertSyncSetLabelsCorrect(t *testing.T, actual *hivev1.SyncSetList) {
 	for ix := range actual.Items {
 		labels := actual.Items[ix].Labels
@@ -0,0 +1,93 @@
+package unreachable
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	defaultProbeBackoffThreshold       = 24 * time.Hour
+	defaultProbeBackoffInitialInterval = time.Hour
+	defaultProbeBackoffMultiplier      = 2
+	defaultProbeBackoffMaxInterval     = 24 * time.Hour
+)
+
+// probeBackoff is the backoff of the probes of clusters that have been unreachable for a long time. The zero value
+// does not back off.
+type probeBackoff struct {
+	threshold       time.Duration
+	initialInterval time.Duration
+	multiplier      time.Duration
+	maxInterval     time.Duration
+}
+
+// readProbeBackoff returns the probe backoff configured in the environment, using the defaults for the settings that
+// are not configured or cannot be parsed.
+func readProbeBackoff(logger log.FieldLogger) probeBackoff {
+	config := &hivev1.UnreachableProbeBackoffConfig{}
+	if envBackoff := os.Getenv(constants.UnreachableProbeBackoffEnvVar); envBackoff != "" {
+		if err := json.Unmarshal([]byte(envBackoff), config); err != nil {
+			logger.WithError(err).WithField("backoff", envBackoff).Errorf("unable to parse %s, using defaults", constants.UnreachableProbeBackoffEnvVar)
+			config = &hivev1.UnreachableProbeBackoffConfig{}
+		}
+	}
+	parse := func(field, value string, def time.Duration) time.Duration {
+		if value == "" {
+			return def
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			logger.WithError(err).WithField(field, value).Errorf("unable to parse probe backoff %s, using default", field)
+			return def
+		}
+		return d
+	}
+	b := probeBackoff{
+		threshold:       parse("threshold", config.Threshold, defaultProbeBackoffThreshold),
+		initialInterval: parse("initialInterval", config.InitialInterval, defaultProbeBackoffInitialInterval),
+		multiplier:      defaultProbeBackoffMultiplier,
+		maxInterval:     parse("maxInterval", config.MaxInterval, defaultProbeBackoffMaxInterval),
+	}
+	if config.Multiplier != nil && *config.Multiplier >= 2 {
+		b.multiplier = time.Duration(*config.Multiplier)
+	}
+	return b
+}
+
+// interval returns the interval between the probes of a cluster that has been unreachable since the given time, or
+// zero if its probes are not backed off. The interval is multiplied each time the time the cluster has been
+// unreachable is multiplied, up to the maximum interval.
+func (b probeBackoff) interval(unreachableSince time.Time) time.Duration {
+	if b.threshold <= 0 || b.initialInterval <= 0 || unreachableSince.IsZero() {
+		return 0
+	}
+	unreachableFor := time.Since(unreachableSince)
+	if unreachableFor < b.threshold {
+		return 0
+	}
+	interval, step := b.initialInterval, b.threshold
+	for unreachableFor >= step*b.multiplier && interval < b.maxInterval {
+		interval *= b.multiplier
+		step *= b.multiplier
+	}
+	if b.maxInterval > 0 && interval > b.maxInterval {
+		interval = b.maxInterval
+	}
+	return interval
+}
+
+// unreachableSince returns when the cluster became unreachable, or the zero time if that is not known.
+func unreachableSince(cd *hivev1.ClusterDeployment) time.Time {
+	cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.UnreachableCondition)
+	if cond == nil {
+		return time.Time{}
+	}
+	return cond.LastTransitionTime.Time
+}
@@ -21,12 +21,14 @@ package unreachable
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/util/flowcontrol"
@@ -54,7 +56,7 @@ const (
 // Add creates a new Unreachable Controller and adds it to the Manager with default RBAC. The Manager will set fields on the
 // Controller and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
-	logger := log.WithField("controller", ControllerName)
+	logger := controllerutils.ControllerLogEntry(ControllerName)
 	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
 	if err != nil {
 		logger.WithError(err).Error("could not get controller configurations")
@@ -68,8 +70,9 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 	r := &ReconcileRemoteMachineSet{
 		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
 		scheme: mgr.GetScheme(),
-		logger: log.WithField("controller", ControllerName),
+		logger: controllerutils.ControllerLogEntry(ControllerName),
 	}
+	r.probeBackoff = readProbeBackoff(r.logger)
 	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
 		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
 	}
@@ -79,7 +82,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
-	c, err := controller.New("unreachable-controller", mgr, controller.Options{
+	c, err := controllerutils.NewController("unreachable-controller", mgr, controller.Options{
 		Reconciler:              r,
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
@@ -109,6 +112,9 @@ type ReconcileRemoteMachineSet struct {
 	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
 	// for the remote cluster's API server
 	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+
+	// probeBackoff is the backoff of the probes of clusters that have been unreachable for a long time
+	probeBackoff probeBackoff
 }
 
 // Reconcile checks if we can establish an API client connection to the remote cluster and maintains the unreachable condition as a result.
@@ -130,6 +136,10 @@ func (r *ReconcileRemoteMachineSet) Reconcile(ctx context.Context, request recon
 		return reconcile.Result{}, err
 	}
 
+	if controllerutils.IsClusterDeploymentPaused(cd, cdLog) {
+		return reconcile.Result{}, nil
+	}
+
 	// If the clusterdeployment is deleted, do not reconcile.
 	if cd.DeletionTimestamp != nil {
 		cdLog.Debug("cluster has deletion timestamp")
@@ -165,6 +175,29 @@ func (r *ReconcileRemoteMachineSet) Reconcile(ctx context.Context, request recon
 		return reconcile.Result{RequeueAfter: connectivityRecheckDelay}, nil
 	}
 
+	// Probe clusters that have been unreachable for a long time less often, as they are likely to have been
+	// decommissioned.
+	backoffInterval := time.Duration(0)
+	if wasUnreachable {
+		backoffInterval = r.probeBackoff.interval(unreachableSince(cd))
+	}
+	if backoffInterval > 0 {
+		if delay := backoffInterval - time.Since(lastCheck); delay > 0 {
+			cdLog.WithField("delay", delay).Debug("waiting to probe long unreachable cluster")
+			return reconcile.Result{RequeueAfter: delay}, nil
+		}
+	}
+
+	// While connectivity is made via the fallback API URL, probe the preferred API URL on the schedule of the
+	// failback policy, if any.
+	probeInterval, healthyProbes := failbackPolicy(cd)
+	if !connectivityRecheckNeeded && !wasPrimaryActive && probeInterval > 0 {
+		if delay := probeInterval - time.Since(lastOverrideProbe(cd)); delay > 0 {
+			cdLog.WithField("delay", delay).Debug("waiting to probe API URL override")
+			return reconcile.Result{RequeueAfter: delay}, nil
+		}
+	}
+
 	cdLog.Info("checking if cluster is reachable")
 	remoteClientBuilder := r.remoteClusterAPIClientBuilder(cd)
 	var unreachableError error
@@ -195,29 +228,61 @@ func (r *ReconcileRemoteMachineSet) Reconcile(ctx context.Context, request recon
 		}
 	}
 
+	// Count the consecutive successful probes of the preferred API URL while failing back to it. Connectivity keeps
+	// being made via the fallback API URL until the failback policy is satisfied.
+	healthyProbesChanged := false
+	failbackPending := false
+	if hasOverride(cd) {
+		probes := int32(0)
+		if primaryErr == nil && !wasPrimaryActive {
+			probes = cd.Status.APIURLOverrideHealthyProbes + 1
+			failbackPending = probes < healthyProbes
+		}
+		if !failbackPending {
+			probes = 0
+		}
+		healthyProbesChanged = cd.Status.APIURLOverrideHealthyProbes != probes
+		cd.Status.APIURLOverrideHealthyProbes = probes
+	}
+
 	// Update conditions to reflect the current state of connectivity to the remote cluster.
 	unreachableChanged := false
 	if updateUnreachable {
 		unreachableChanged = setUnreachableCond(cd, unreachableError)
+		// The probe time of the condition determines when a backed off cluster is probed next, so it is updated on
+		// every probe of the cluster even when the connection error has not changed.
+		if backoffInterval > 0 && unreachableError != nil && !unreachableChanged {
+			cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.UnreachableCondition)
+			cond.LastProbeTime = metav1.Now()
+			unreachableChanged = true
+		}
 	}
-	overrideChanged := setActiveAPIURLOverrideCond(cd, primaryErr)
+	overrideChanged := setActiveAPIURLOverrideCond(cd, primaryErr, failbackPending, healthyProbes, probeInterval > 0)
 
 	// Determine when to requeue the ClusterDeployment. If there is no connectivity to the remote cluster via the
-	// preferred API URL, then requeue the ClusterDeployment using the backoff. If there is connectivity via the
-	// preferred API URL, then requeue the ClusterDeployment to sync again in 2 hours for the next connectivity re-check.
-	result := reconcile.Result{Requeue: primaryErr != nil}
-	if !result.Requeue {
+	// preferred API URL, then requeue the ClusterDeployment using the backoff, at the probe interval of the failback
+	// policy, or at the interval of the probe backoff if the cluster has been unreachable for a long time. If there is
+	// connectivity via the preferred API URL, then requeue the ClusterDeployment to sync again in 2 hours for the next
+	// connectivity re-check.
+	result := reconcile.Result{Requeue: primaryErr != nil || failbackPending}
+	switch {
+	case unreachableError != nil && backoffInterval > 0:
+		cdLog.WithField("interval", backoffInterval).Info("cluster has been unreachable for a long time, backing off probes")
+		result = reconcile.Result{RequeueAfter: backoffInterval}
+	case result.Requeue && probeInterval > 0:
+		result = reconcile.Result{RequeueAfter: probeInterval}
+	case !result.Requeue:
 		result.RequeueAfter = maxUnreachableDuration
 	}
 
 	// If none of the conditions have changed, stop the reconciliation now without updating the ClusterDeployment.
-	if !unreachableChanged && !overrideChanged {
+	if !unreachableChanged && !overrideChanged && !healthyProbesChanged {
 		return result, nil
 	}
 
 	// Log an info entry when the remote cluster becomes reachable.
 	transitionedToReachable := wasUnreachable && unreachableError == nil
-	isPrimaryActive := primaryErr == nil
+	isPrimaryActive := primaryErr == nil && !failbackPending
 	transitionedToPrimaryActive := !wasPrimaryActive && isPrimaryActive
 	if transitionedToReachable || transitionedToPrimaryActive {
 		switch {
@@ -247,7 +312,10 @@ func setUnreachableCond(cd *hivev1.ClusterDeployment, connectionError error) (co
 	return remoteclient.SetUnreachableCondition(cd, connectionError)
 }
 
-func setActiveAPIURLOverrideCond(cd *hivev1.ClusterDeployment, connectionError error) (condsChanged bool) {
+// setActiveAPIURLOverrideCond sets the ActiveAPIURLOverride condition. The condition stays false while the failback to
+// the API URL override is pending. When probing on a schedule, the condition is updated on every probe so that its probe
+// time determines when to probe next.
+func setActiveAPIURLOverrideCond(cd *hivev1.ClusterDeployment, connectionError error, failbackPending bool, healthyProbes int32, scheduledProbes bool) (condsChanged bool) {
 	if !hasOverride(cd) {
 		return
 	}
@@ -266,6 +334,15 @@ func setActiveAPIURLOverrideCond(cd *hivev1.ClusterDeployment, connectionError e
 		reason = "ErrorConnectingToCluster"
 		message = connectionError.Error()
 		updateCheck = controllerutils.UpdateConditionIfReasonOrMessageChange
+		if scheduledProbes {
+			updateCheck = controllerutils.UpdateConditionAlways
+		}
+	}
+	if failbackPending {
+		status = corev1.ConditionFalse
+		reason = "FailbackPending"
+		message = fmt.Sprintf("API URL override healthy for %d of %d consecutive probes", cd.Status.APIURLOverrideHealthyProbes, healthyProbes)
+		updateCheck = controllerutils.UpdateConditionIfReasonOrMessageChange
 	}
 	cd.Status.Conditions, condsChanged = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
 		cd.Status.Conditions,
@@ -278,6 +355,32 @@ func setActiveAPIURLOverrideCond(cd *hivev1.ClusterDeployment, connectionError e
 	return
 }
 
+// failbackPolicy returns the interval between the probes of the API URL override, 0 for an exponential backoff, and the
+// number of consecutive successful probes after which Hive fails back to the API URL override.
+func failbackPolicy(cd *hivev1.ClusterDeployment) (probeInterval time.Duration, healthyProbes int32) {
+	healthyProbes = 1
+	policy := cd.Spec.ControlPlaneConfig.APIURLOverrideFailback
+	if policy == nil {
+		return
+	}
+	if policy.ProbeInterval != nil {
+		probeInterval = policy.ProbeInterval.Duration
+	}
+	if policy.HealthyProbes > 1 {
+		healthyProbes = policy.HealthyProbes
+	}
+	return
+}
+
+// lastOverrideProbe returns the time of the last probe of the API URL override.
+func lastOverrideProbe(cd *hivev1.ClusterDeployment) time.Time {
+	cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ActiveAPIURLOverrideCondition)
+	if cond == nil {
+		return time.Time{}
+	}
+	return cond.LastProbeTime.Time
+}
+
 func hasOverride(cd *hivev1.ClusterDeployment) bool {
 	return cd.Spec.ControlPlaneConfig.APIURLOverride != ""
 }
@@ -60,6 +60,8 @@ func TestReconcile(t *testing.T) {
 		expectedActiveOverrideStatus  corev1.ConditionStatus
 		expectRequeue                 bool
 		expectRequeueAfter            bool
+		expectedHealthyProbes         int32
+		expectProbed                  bool
 	}{
 		{
 			name:               "recent reachable condition",
@@ -194,6 +196,107 @@ func TestReconcile(t *testing.T) {
 			expectedActiveOverrideStatus:  corev1.ConditionFalse,
 			expectRequeue:                 true,
 		},
+		{
+			name: "reachable to primary with failback pending",
+			cd: buildClusterDeployment(
+				withAPIURLOverride(),
+				withAPIURLOverrideFailback(nil, 3),
+				withUnreachableCondition(corev1.ConditionFalse, time.Now()),
+				withActiveAPIURLOverrideCondition(corev1.ConditionFalse),
+			),
+			errorConnecting:               pointer.BoolPtr(false),
+			expectedStatus:                corev1.ConditionFalse,
+			expectActiveOverrideCondition: true,
+			expectedActiveOverrideStatus:  corev1.ConditionFalse,
+			expectRequeue:                 true,
+			expectedHealthyProbes:         1,
+		},
+		{
+			name: "reachable to primary with failback policy satisfied",
+			cd: buildClusterDeployment(
+				withAPIURLOverride(),
+				withAPIURLOverrideFailback(nil, 3),
+				withUnreachableCondition(corev1.ConditionFalse, time.Now()),
+				withActiveAPIURLOverrideCondition(corev1.ConditionFalse),
+				withAPIURLOverrideHealthyProbes(2),
+			),
+			errorConnecting:               pointer.BoolPtr(false),
+			expectedStatus:                corev1.ConditionFalse,
+			expectActiveOverrideCondition: true,
+			expectedActiveOverrideStatus:  corev1.ConditionTrue,
+			expectRequeueAfter:            true,
+		},
+		{
+			name: "unreachable to primary resets healthy probes",
+			cd: buildClusterDeployment(
+				withAPIURLOverride(),
+				withAPIURLOverrideFailback(nil, 3),
+				withUnreachableCondition(corev1.ConditionFalse, time.Now()),
+				withActiveAPIURLOverrideCondition(corev1.ConditionFalse),
+				withAPIURLOverrideHealthyProbes(2),
+			),
+			errorConnecting:               pointer.BoolPtr(true),
+			expectedStatus:                corev1.ConditionFalse,
+			expectActiveOverrideCondition: true,
+			expectedActiveOverrideStatus:  corev1.ConditionFalse,
+			expectRequeue:                 true,
+		},
+		{
+			name: "scheduled probe of primary not due",
+			cd: buildClusterDeployment(
+				withAPIURLOverride(),
+				withAPIURLOverrideFailback(&metav1.Duration{Duration: 10 * time.Minute}, 1),
+				withUnreachableCondition(corev1.ConditionFalse, time.Now()),
+				withActiveAPIURLOverrideConditionProbedAt(corev1.ConditionFalse, time.Now()),
+			),
+			expectedStatus:                corev1.ConditionFalse,
+			expectActiveOverrideCondition: true,
+			expectedActiveOverrideStatus:  corev1.ConditionFalse,
+			expectRequeueAfter:            true,
+		},
+		{
+			name: "scheduled probe of primary unreachable",
+			cd: buildClusterDeployment(
+				withAPIURLOverride(),
+				withAPIURLOverrideFailback(&metav1.Duration{Duration: 10 * time.Minute}, 1),
+				withUnreachableCondition(corev1.ConditionFalse, time.Now()),
+				withActiveAPIURLOverrideConditionProbedAt(corev1.ConditionFalse, time.Now().Add(-time.Hour)),
+			),
+			errorConnecting:               pointer.BoolPtr(true),
+			expectedStatus:                corev1.ConditionFalse,
+			expectActiveOverrideCondition: true,
+			expectedActiveOverrideStatus:  corev1.ConditionFalse,
+			expectRequeueAfter:            true,
+		},
+		{
+			name:               "long unreachable probe not due",
+			cd:                 buildClusterDeployment(withUnreachableConditionSince(time.Now().Add(-72*time.Hour), time.Now().Add(-30*time.Minute))),
+			expectedStatus:     corev1.ConditionTrue,
+			expectRequeueAfter: true,
+		},
+		{
+			name:               "long unreachable probe due",
+			cd:                 buildClusterDeployment(withUnreachableConditionSince(time.Now().Add(-72*time.Hour), time.Now().Add(-3*time.Hour))),
+			errorConnecting:    pointer.BoolPtr(true),
+			expectedStatus:     corev1.ConditionTrue,
+			expectRequeueAfter: true,
+			expectProbed:       true,
+		},
+		{
+			name:               "long unreachable becomes reachable",
+			cd:                 buildClusterDeployment(withUnreachableConditionSince(time.Now().Add(-72*time.Hour), time.Now().Add(-3*time.Hour))),
+			errorConnecting:    pointer.BoolPtr(false),
+			expectedStatus:     corev1.ConditionFalse,
+			expectRequeueAfter: true,
+			expectProbed:       true,
+		},
+		{
+			name:            "recently unreachable not backed off",
+			cd:              buildClusterDeployment(withUnreachableConditionSince(time.Now().Add(-time.Hour), time.Now())),
+			errorConnecting: pointer.BoolPtr(true),
+			expectedStatus:  corev1.ConditionTrue,
+			expectRequeue:   true,
+		},
 	}
 
 	for _, test := range tests {
@@ -225,6 +328,7 @@ func TestReconcile(t *testing.T) {
 				scheme:                        scheme,
 				logger:                        log.WithField("controller", "unreachable"),
 				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+				probeBackoff:                  readProbeBackoff(log.StandardLogger()),
 			}
 
 			namespacedName := types.NamespacedName{
@@ -240,6 +344,9 @@ func TestReconcile(t *testing.T) {
 				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.UnreachableCondition)
 				if assert.NotNil(t, cond, "missing unreachable condition") {
 					assert.Equal(t, string(test.expectedStatus), string(cond.Status), "unexpected status on unreachable condition")
+					if test.expectProbed {
+						assert.WithinDuration(t, time.Now(), cond.LastProbeTime.Time, time.Minute, "expected probe time to be updated")
+					}
 				}
 				cond = controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ActiveAPIURLOverrideCondition)
 				if !test.expectActiveOverrideCondition {
@@ -249,6 +356,7 @@ func TestReconcile(t *testing.T) {
 						assert.Equal(t, string(test.expectedActiveOverrideStatus), string(cond.Status), "unexpected status on active override condition")
 					}
 				}
+				assert.Equal(t, test.expectedHealthyProbes, cd.Status.APIURLOverrideHealthyProbes, "unexpected healthy probes of API URL override")
 			}
 
 			assert.Equal(t, test.expectRequeue, result.Requeue, "unexpected requeue")
@@ -286,6 +394,19 @@ func withUnreachableCondition(status corev1.ConditionStatus, probeTime time.Time
 	)
 }
 
+func withUnreachableConditionSince(transitionTime, probeTime time.Time) testcd.Option {
+	return testcd.WithCondition(
+		hivev1.ClusterDeploymentCondition{
+			Type:               hivev1.UnreachableCondition,
+			Status:             corev1.ConditionTrue,
+			Reason:             "ErrorConnectingToCluster",
+			Message:            "cluster not reachable",
+			LastTransitionTime: metav1.NewTime(transitionTime),
+			LastProbeTime:      metav1.NewTime(probeTime),
+		},
+	)
+}
+
 func withActiveAPIURLOverrideCondition(status corev1.ConditionStatus) testcd.Option {
 	return testcd.WithCondition(
 		hivev1.ClusterDeploymentCondition{
@@ -295,8 +416,61 @@ func withActiveAPIURLOverrideCondition(status corev1.ConditionStatus) testcd.Opt
 	)
 }
 
+func withActiveAPIURLOverrideConditionProbedAt(status corev1.ConditionStatus, probeTime time.Time) testcd.Option {
+	return testcd.WithCondition(
+		hivev1.ClusterDeploymentCondition{
+			Type:          hivev1.ActiveAPIURLOverrideCondition,
+			Status:        status,
+			LastProbeTime: metav1.NewTime(probeTime),
+		},
+	)
+}
+
+func withAPIURLOverrideFailback(probeInterval *metav1.Duration, healthyProbes int32) testcd.Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.ControlPlaneConfig.APIURLOverrideFailback = &hivev1.APIURLOverrideFailback{
+			ProbeInterval: probeInterval,
+			HealthyProbes: healthyProbes,
+		}
+	}
+}
+
+func withAPIURLOverrideHealthyProbes(probes int32) testcd.Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Status.APIURLOverrideHealthyProbes = probes
+	}
+}
+
 func withAPIURLOverride() testcd.Option {
 	return func(clusterDeployment *hivev1.ClusterDeployment) {
 		clusterDeployment.Spec.ControlPlaneConfig.APIURLOverride = "some-api-url"
 	}
 }
+
+func TestProbeBackoffInterval(t *testing.T) {
+	b := probeBackoff{
+		threshold:       24 * time.Hour,
+		initialInterval: time.Hour,
+		multiplier:      2,
+		maxInterval:     12 * time.Hour,
+	}
+	cases := []struct {
+		unreachableFor time.Duration
+		expected       time.Duration
+	}{
+		{unreachableFor: time.Hour},
+		{unreachableFor: 25 * time.Hour, expected: time.Hour},
+		{unreachableFor: 50 * time.Hour, expected: 2 * time.Hour},
+		{unreachableFor: 100 * time.Hour, expected: 4 * time.Hour},
+		{unreachableFor: 200 * time.Hour, expected: 8 * time.Hour},
+		{unreachableFor: 400 * time.Hour, expected: 12 * time.Hour},
+		{unreachableFor: 4000 * time.Hour, expected: 12 * time.Hour},
+	}
+	for _, tc := range cases {
+		t.Run(tc.unreachableFor.String(), func(t *testing.T) {
+			assert.Equal(t, tc.expected, b.interval(time.Now().Add(-tc.unreachableFor)), "unexpected interval")
+		})
+	}
+	assert.Zero(t, probeBackoff{}.interval(time.Now().Add(-4000*time.Hour)), "expected no backoff when disabled")
+	assert.Zero(t, b.interval(time.Time{}), "expected no backoff without transition time")
+}
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ArchitectureAMD64 is the amd64 (x86_64) architecture, the default architecture of the machines of an
+	// InstallConfig.
+	ArchitectureAMD64 = "amd64"
+	// ArchitectureARM64 is the arm64 (aarch64) architecture.
+	ArchitectureARM64 = "arm64"
+)
+
+var (
+	// awsARM64InstanceTypeRegex matches the AWS Graviton instance types, whose family has a "g" after the generation,
+	// such as m6g, c7gn or r6gd, as well as the first generation a1.
+	awsARM64InstanceTypeRegex = regexp.MustCompile(`^(a1|[a-z]+[0-9]+g[a-z]*)\.`)
+	// azureARM64InstanceTypeRegex matches the Azure Ampere Altra sizes, which have a "p" in their additive features,
+	// such as Standard_D4ps_v5 or Standard_E8pds_v5.
+	azureARM64InstanceTypeRegex = regexp.MustCompile(`^Standard_[A-Z]+[0-9]+[a-z]*p[a-z]*_v[0-9]+$`)
+)
+
+// InstallConfigMachinePool is the architecture and instance type of a machine pool of an InstallConfig.
+type InstallConfigMachinePool struct {
+	// Name is the name of the machine pool.
+	Name string
+	// Architecture is the architecture of the machine pool, defaulted to amd64.
+	Architecture string
+	// InstanceType is the instance type of the machine pool on AWS, Azure or GCP, if set.
+	InstanceType string
+}
+
+type installConfigMachinePool struct {
+	Name         string `json:"name"`
+	Architecture string `json:"architecture,omitempty"`
+	Platform     struct {
+		AWS *struct {
+			Type string `json:"type,omitempty"`
+		} `json:"aws,omitempty"`
+		Azure *struct {
+			Type string `json:"type,omitempty"`
+		} `json:"azure,omitempty"`
+		GCP *struct {
+			Type string `json:"type,omitempty"`
+		} `json:"gcp,omitempty"`
+	} `json:"platform"`
+}
+
+// InstallConfigMachinePools returns the control plane and compute machine pools of the InstallConfig, control plane
+// first.
+func InstallConfigMachinePools(icData []byte) ([]InstallConfigMachinePool, error) {
+	ic := struct {
+		ControlPlane *installConfigMachinePool  `json:"controlPlane,omitempty"`
+		Compute      []installConfigMachinePool `json:"compute,omitempty"`
+	}{}
+	if err := yaml.Unmarshal(icData, &ic); err != nil {
+		return nil, errors.Wrap(err, "could not parse install config")
+	}
+	machinePools := ic.Compute
+	if ic.ControlPlane != nil {
+		machinePools = append([]installConfigMachinePool{*ic.ControlPlane}, machinePools...)
+	}
+	result := make([]InstallConfigMachinePool, len(machinePools))
+	for i, mp := range machinePools {
+		result[i] = InstallConfigMachinePool{Name: mp.Name, Architecture: mp.Architecture}
+		if result[i].Architecture == "" {
+			result[i].Architecture = ArchitectureAMD64
+		}
+		switch {
+		case mp.Platform.AWS != nil:
+			result[i].InstanceType = mp.Platform.AWS.Type
+		case mp.Platform.Azure != nil:
+			result[i].InstanceType = mp.Platform.Azure.Type
+		case mp.Platform.GCP != nil:
+			result[i].InstanceType = mp.Platform.GCP.Type
+		}
+	}
+	return result, nil
+}
+
+// InstanceTypeArchitecture returns the architecture of an AWS, Azure or GCP instance type.
+func InstanceTypeArchitecture(instanceType string) string {
+	switch {
+	case awsARM64InstanceTypeRegex.MatchString(instanceType),
+		azureARM64InstanceTypeRegex.MatchString(instanceType),
+		strings.HasPrefix(instanceType, "t2a-"),
+		strings.HasPrefix(instanceType, "c4a-"):
+		return ArchitectureARM64
+	}
+	return ArchitectureAMD64
+}
@@ -0,0 +1,218 @@
+package utils
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// NewCanaryManager wraps the manager so that the watches of the controllers added to it only handle the events of
+// objects in their partition of the namespaces during a canary rollout. The partition of the canary controllers is
+// the namespaces matching the selector. The partition of the stable controllers is the other namespaces and the
+// cluster-scoped objects.
+func NewCanaryManager(mgr manager.Manager, selector labels.Selector, canary bool) (manager.Manager, error) {
+	c := &canaryCache{
+		Cache:    mgr.GetCache(),
+		selector: selector,
+		canary:   canary,
+		logger:   log.WithField("canary", canary),
+	}
+	// Create the namespace informer up front so that it is started and synced along with the cache, rather than on
+	// the first event.
+	if _, err := c.Cache.GetInformer(context.Background(), &corev1.Namespace{}); err != nil {
+		return nil, err
+	}
+	return &canaryManager{Manager: mgr, cache: c}, nil
+}
+
+// NewController creates a new controller with controller.New. When the manager is partitioned for a canary rollout,
+// the event handlers of the watches of the controller only queue the reconcile requests in the partition of the
+// controllers. Handlers mapping an object to other objects, such as a cluster-scoped object to ClusterDeployments,
+// would otherwise queue requests outside the partition for objects in it.
+func NewController(name string, mgr manager.Manager, options controller.Options) (controller.Controller, error) {
+	c, err := controller.New(name, mgr, options)
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := mgr.(*canaryManager); ok {
+		return &canaryController{Controller: c, cache: m.cache}, nil
+	}
+	return c, nil
+}
+
+type canaryManager struct {
+	manager.Manager
+	cache *canaryCache
+}
+
+// SetFields injects the partitioned cache before the dependencies of the wrapped manager. Watch sources only take
+// the first cache injected into them, so their informers are the ones of the partitioned cache.
+func (m *canaryManager) SetFields(i interface{}) error {
+	if _, err := inject.CacheInto(m.cache, i); err != nil {
+		return err
+	}
+	return m.Manager.SetFields(i)
+}
+
+// canaryCache is a cache whose informers only pass on the events of objects in the partition of the controllers.
+type canaryCache struct {
+	cache.Cache
+	selector labels.Selector
+	canary   bool
+	logger   log.FieldLogger
+}
+
+func (c *canaryCache) GetInformer(ctx context.Context, obj client.Object) (cache.Informer, error) {
+	i, err := c.Cache.GetInformer(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	return &canaryInformer{Informer: i, cache: c}, nil
+}
+
+func (c *canaryCache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind) (cache.Informer, error) {
+	i, err := c.Cache.GetInformerForKind(ctx, gvk)
+	if err != nil {
+		return nil, err
+	}
+	return &canaryInformer{Informer: i, cache: c}, nil
+}
+
+// inPartition returns whether the object is in the partition of the controllers.
+func (c *canaryCache) inPartition(obj interface{}) bool {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	switch o := obj.(type) {
+	case *corev1.Namespace:
+		return c.selector.Matches(labels.Set(o.Labels)) == c.canary
+	case metav1.Object:
+		if o.GetNamespace() == "" {
+			return !c.canary
+		}
+		return c.isCanaryNamespace(o.GetNamespace()) == c.canary
+	default:
+		return !c.canary
+	}
+}
+
+// requestInPartition returns whether the reconcile request is in the partition of the controllers. Requests for
+// cluster-scoped objects are left to the filtering of the events.
+func (c *canaryCache) requestInPartition(item interface{}) bool {
+	req, ok := item.(reconcile.Request)
+	if !ok || req.Namespace == "" {
+		return true
+	}
+	return c.isCanaryNamespace(req.Namespace) == c.canary
+}
+
+func (c *canaryCache) isCanaryNamespace(name string) bool {
+	ns := &corev1.Namespace{}
+	if err := c.Cache.Get(context.Background(), client.ObjectKey{Name: name}, ns); err != nil {
+		// The namespace is most likely being deleted. Leave its objects to the stable controllers.
+		c.logger.WithError(err).WithField("namespace", name).Debug("could not get namespace")
+		return false
+	}
+	return c.selector.Matches(labels.Set(ns.Labels))
+}
+
+type canaryInformer struct {
+	cache.Informer
+	cache *canaryCache
+}
+
+func (i *canaryInformer) AddEventHandler(handler toolscache.ResourceEventHandler) {
+	i.Informer.AddEventHandler(toolscache.FilteringResourceEventHandler{
+		FilterFunc: i.cache.inPartition,
+		Handler:    handler,
+	})
+}
+
+func (i *canaryInformer) AddEventHandlerWithResyncPeriod(handler toolscache.ResourceEventHandler, resyncPeriod time.Duration) {
+	i.Informer.AddEventHandlerWithResyncPeriod(toolscache.FilteringResourceEventHandler{
+		FilterFunc: i.cache.inPartition,
+		Handler:    handler,
+	}, resyncPeriod)
+}
+
+// canaryQueue is a queue that drops the reconcile requests outside the partition of the controllers.
+type canaryQueue struct {
+	workqueue.RateLimitingInterface
+	cache *canaryCache
+}
+
+func (q *canaryQueue) Add(item interface{}) {
+	if q.cache.requestInPartition(item) {
+		q.RateLimitingInterface.Add(item)
+	}
+}
+
+func (q *canaryQueue) AddAfter(item interface{}, duration time.Duration) {
+	if q.cache.requestInPartition(item) {
+		q.RateLimitingInterface.AddAfter(item, duration)
+	}
+}
+
+func (q *canaryQueue) AddRateLimited(item interface{}) {
+	if q.cache.requestInPartition(item) {
+		q.RateLimitingInterface.AddRateLimited(item)
+	}
+}
+
+// canaryController is a controller whose watches only queue the reconcile requests in the partition of the
+// controllers.
+type canaryController struct {
+	controller.Controller
+	cache *canaryCache
+}
+
+func (c *canaryController) Watch(src source.Source, eventHandler handler.EventHandler, predicates ...predicate.Predicate) error {
+	return c.Controller.Watch(src, &canaryEventHandler{EventHandler: eventHandler, cache: c.cache}, predicates...)
+}
+
+// canaryEventHandler is an event handler that passes a queue dropping the reconcile requests outside the partition of
+// the controllers to the wrapped event handler.
+type canaryEventHandler struct {
+	handler.EventHandler
+	cache *canaryCache
+}
+
+// InjectFunc injects the dependencies of the wrapped event handler, such as the scheme and REST mapper of the
+// handlers enqueuing requests for owners.
+func (h *canaryEventHandler) InjectFunc(f inject.Func) error {
+	return f(h.EventHandler)
+}
+
+func (h *canaryEventHandler) Create(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	h.EventHandler.Create(evt, &canaryQueue{RateLimitingInterface: q, cache: h.cache})
+}
+
+func (h *canaryEventHandler) Update(evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	h.EventHandler.Update(evt, &canaryQueue{RateLimitingInterface: q, cache: h.cache})
+}
+
+func (h *canaryEventHandler) Delete(evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	h.EventHandler.Delete(evt, &canaryQueue{RateLimitingInterface: q, cache: h.cache})
+}
+
+func (h *canaryEventHandler) Generic(evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+	h.EventHandler.Generic(evt, &canaryQueue{RateLimitingInterface: q, cache: h.cache})
+}
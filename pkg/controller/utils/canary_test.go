@@ -0,0 +1,162 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// clientCache is a cache that reads objects with a client.
+type clientCache struct {
+	cache.Cache
+	c client.Client
+}
+
+func (c *clientCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	return c.c.Get(ctx, key, obj)
+}
+
+// handlerInformer is an informer that keeps the event handler added to it.
+type handlerInformer struct {
+	cache.Informer
+	handler toolscache.ResourceEventHandler
+}
+
+func (i *handlerInformer) AddEventHandler(handler toolscache.ResourceEventHandler) {
+	i.handler = handler
+}
+
+func TestCanaryInformerMappedRequests(t *testing.T) {
+	hivev1.AddToScheme(scheme.Scheme)
+
+	canaryNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "canary", Labels: map[string]string{"canary": "true"}}}
+	stableNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "stable"}}
+	canaryRequest := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "canary", Name: "cd"}}
+	stableRequest := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "stable", Name: "cd"}}
+	// Map the object of the event to ClusterDeployments in both partitions.
+	mapToClusterDeployments := handler.EnqueueRequestsFromMapFunc(func(client.Object) []reconcile.Request {
+		return []reconcile.Request{canaryRequest, stableRequest}
+	})
+
+	cases := []struct {
+		name                   string
+		obj                    client.Object
+		expectedCanaryRequests []reconcile.Request
+		expectedStableRequests []reconcile.Request
+	}{
+		{
+			name:                   "cluster-scoped object",
+			obj:                    &hivev1.ClusterImageSet{ObjectMeta: metav1.ObjectMeta{Name: "imageset"}},
+			expectedStableRequests: []reconcile.Request{stableRequest},
+		},
+		{
+			name:                   "object in canary namespace",
+			obj:                    &hivev1.ClusterPool{ObjectMeta: metav1.ObjectMeta{Namespace: "canary", Name: "pool"}},
+			expectedCanaryRequests: []reconcile.Request{canaryRequest},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &clientCache{c: fake.NewFakeClientWithScheme(scheme.Scheme, canaryNamespace, stableNamespace)}
+			selector := labels.SelectorFromSet(labels.Set{"canary": "true"})
+			for _, canary := range []bool{true, false} {
+				informer := &handlerInformer{}
+				cc := &canaryCache{Cache: c, selector: selector, canary: canary, logger: log.StandardLogger()}
+				src := &source.Informer{Informer: &canaryInformer{Informer: informer, cache: cc}}
+				queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+				eventHandler := &canaryEventHandler{EventHandler: mapToClusterDeployments, cache: cc}
+				assert.NoError(t, src.Start(context.TODO(), eventHandler, queue), "unexpected error starting source")
+
+				informer.handler.OnAdd(tc.obj)
+				var requests []reconcile.Request
+				for queue.Len() > 0 {
+					item, _ := queue.Get()
+					requests = append(requests, item.(reconcile.Request))
+					queue.Done(item)
+				}
+				queue.ShutDown()
+				if canary {
+					assert.Equal(t, tc.expectedCanaryRequests, requests, "unexpected canary requests")
+				} else {
+					assert.Equal(t, tc.expectedStableRequests, requests, "unexpected stable requests")
+				}
+			}
+		})
+	}
+}
+
+func TestCanaryCacheInPartition(t *testing.T) {
+	hivev1.AddToScheme(scheme.Scheme)
+
+	canaryNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "canary", Labels: map[string]string{"canary": "true"}}}
+	stableNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "stable"}}
+	cdIn := func(namespace string) *hivev1.ClusterDeployment {
+		return &hivev1.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "cd"}}
+	}
+
+	cases := []struct {
+		name           string
+		obj            interface{}
+		expectedCanary bool
+	}{
+		{
+			name:           "object in canary namespace",
+			obj:            cdIn("canary"),
+			expectedCanary: true,
+		},
+		{
+			name: "object in stable namespace",
+			obj:  cdIn("stable"),
+		},
+		{
+			name: "object in missing namespace",
+			obj:  cdIn("missing"),
+		},
+		{
+			name: "cluster-scoped object",
+			obj:  &hivev1.ClusterImageSet{ObjectMeta: metav1.ObjectMeta{Name: "imageset"}},
+		},
+		{
+			name:           "canary namespace",
+			obj:            canaryNamespace,
+			expectedCanary: true,
+		},
+		{
+			name: "stable namespace",
+			obj:  stableNamespace,
+		},
+		{
+			name:           "deleted object in canary namespace",
+			obj:            toolscache.DeletedFinalStateUnknown{Key: "canary/cd", Obj: cdIn("canary")},
+			expectedCanary: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &clientCache{c: fake.NewFakeClientWithScheme(scheme.Scheme, canaryNamespace, stableNamespace)}
+			selector := labels.SelectorFromSet(labels.Set{"canary": "true"})
+			canary := &canaryCache{Cache: c, selector: selector, canary: true, logger: log.StandardLogger()}
+			stable := &canaryCache{Cache: c, selector: selector, canary: false, logger: log.StandardLogger()}
+			assert.Equal(t, tc.expectedCanary, canary.inPartition(tc.obj), "unexpected canary partition")
+			assert.Equal(t, !tc.expectedCanary, stable.inPartition(tc.obj), "unexpected stable partition")
+		})
+	}
+}
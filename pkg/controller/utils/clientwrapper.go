@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -9,6 +10,8 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/client-go/rest"
@@ -19,6 +22,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/tracing"
 )
 
 var (
@@ -136,8 +140,26 @@ func (cmt *ControllerMetricsTripper) RoundTrip(req *http.Request) (*http.Respons
 	}
 	path, pathErr := parsePath(req.URL.Path)
 
+	// Requests made as part of a traced reconcile are traced too.
+	var span trace.Span
+	if isTraced(req.Context()) {
+		spanName := req.Method + " " + path
+		if pathErr != nil {
+			spanName = req.Method + " " + req.URL.Path
+		}
+		var ctx context.Context
+		ctx, span = tracing.Tracer().Start(req.Context(), spanName,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attribute.Bool("remote", cmt.Remote)),
+		)
+		req = req.WithContext(ctx)
+	}
+
 	// Call the nested RoundTripper.
 	resp, err := cmt.RoundTripper.RoundTrip(req)
+	if span != nil {
+		endRequestSpan(span, resp, err)
+	}
 	applyTime := metav1.Now().Sub(startTime)
 	if err == nil && pathErr == nil {
 		metricKubeClientRequests.WithLabelValues(cmt.Controller.String(), req.Method, path, remoteStr, resp.Status).Inc()
@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	// CloudAWS, CloudAzure and CloudGCP are the values of the cloud label of the cloud API metrics.
+	CloudAWS   = "aws"
+	CloudAzure = "azure"
+	CloudGCP   = "gcp"
+
+	// unknownController is the value of the controller label of the calls of cloud clients not created by a
+	// controller, such as those of the install pods.
+	unknownController = "unknown"
+)
+
+var (
+	metricCloudAPIRequestSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "hive_cloud_api_request_seconds",
+		Help:    "Length of time for cloud API calls, including retries, by controller, cloud, service and operation.",
+		Buckets: []float64{0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 120},
+	},
+		[]string{"controller", "cloud", "service", "operation"},
+	)
+	metricCloudAPIRequestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_cloud_api_request_errors_total",
+		Help: "Counter incremented for each failed cloud API call, by controller, cloud, service, operation and error code.",
+	},
+		[]string{"controller", "cloud", "service", "operation", "code"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricCloudAPIRequestSeconds)
+	metrics.Registry.MustRegister(metricCloudAPIRequestErrors)
+}
+
+// ObserveCloudAPICall records the duration of a call to a cloud API made on behalf of the controller, and counts it
+// as failed with the error code if the code is not empty.
+func ObserveCloudAPICall(controller hivev1.ControllerName, cloud, service, operation string, duration time.Duration, code string) {
+	controllerLabel := controller.String()
+	if controllerLabel == "" {
+		controllerLabel = unknownController
+	}
+	metricCloudAPIRequestSeconds.WithLabelValues(controllerLabel, cloud, service, operation).Observe(duration.Seconds())
+	if code != "" {
+		metricCloudAPIRequestErrors.WithLabelValues(controllerLabel, cloud, service, operation, code).Inc()
+	}
+}
+
+// CloudAPIMetricsTripper is a RoundTripper implementation which tracks the metrics of the calls to the REST APIs of
+// Azure and GCP. The service and operation of a call are derived from its URL, with the names of resources left out
+// for cardinality reasons. Failed calls are counted by HTTP status code. Calls made with the context of a traced
+// reconcile are traced as part of it.
+type CloudAPIMetricsTripper struct {
+	http.RoundTripper
+	Controller hivev1.ControllerName
+	Cloud      string
+}
+
+// RoundTrip implements the http RoundTripper interface.
+func (t *CloudAPIMetricsTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	service, operation := parseCloudAPIRequest(t.Cloud, req)
+	ctx, span := StartCloudAPISpan(req.Context(), t.Cloud, service, operation)
+	startTime := time.Now()
+	resp, err := t.RoundTripper.RoundTrip(req.WithContext(ctx))
+	code := ""
+	switch {
+	case err != nil:
+		code = "error"
+	case resp.StatusCode >= http.StatusBadRequest:
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	ObserveCloudAPICall(t.Controller, t.Cloud, service, operation, time.Since(startTime), code)
+	EndCloudAPISpan(span, code)
+	return resp, err
+}
+
+// NewCloudAPIHTTPClient returns an HTTP client for the calls to the REST APIs of the cloud made on behalf of the
+// controller, which tracks their metrics and goes through the proxy, if any.
+func NewCloudAPIHTTPClient(proxy *CloudClientProxy, controller hivev1.ControllerName, cloud string) (*http.Client, error) {
+	httpClient, err := proxy.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport
+	if httpClient != nil {
+		transport = httpClient.Transport
+	}
+	return &http.Client{
+		Transport: &CloudAPIMetricsTripper{
+			RoundTripper: transport,
+			Controller:   controller,
+			Cloud:        cloud,
+		},
+	}, nil
+}
+
+// parseCloudAPIRequest returns the service and the operation of a call to the REST API of the cloud.
+//
+// The paths of the Azure and GCP APIs alternate between collections and resource names, such as
+// /compute/v1/projects/{project}/zones/{zone}/instances/{instance}/stop for GCP. The operation is the HTTP method with
+// the collections and the custom verb, if any, such as "POST projects.zones.instances.stop".
+func parseCloudAPIRequest(cloud string, req *http.Request) (string, string) {
+	tokens := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	service := ""
+	switch cloud {
+	case CloudGCP:
+		// The service is the subdomain of googleapis.com, and the path starts after the version of the API.
+		service = strings.SplitN(req.URL.Hostname(), ".", 2)[0]
+		for i, token := range tokens {
+			if len(token) > 1 && token[0] == 'v' && token[1] >= '0' && token[1] <= '9' {
+				tokens = tokens[i+1:]
+				break
+			}
+		}
+	case CloudAzure:
+		if strings.HasPrefix(req.URL.Hostname(), "login.") {
+			// Tokens are requested from the tenant, whose ID is left out.
+			return "login", req.Method + " oauth2.token"
+		}
+		// The service is the namespace of the resource provider.
+		service = "Microsoft.Resources"
+		for i := 0; i+1 < len(tokens); i += 2 {
+			if strings.EqualFold(tokens[i], "providers") {
+				service = tokens[i+1]
+			}
+		}
+	}
+
+	var collections []string
+	for i, token := range tokens {
+		if token == "" {
+			continue
+		}
+		if i%2 == 0 {
+			collections = append(collections, token)
+		} else if ix := strings.LastIndex(token, ":"); ix >= 0 && len(collections) > 0 {
+			// Custom verbs follow the name of the resource, such as projects/{project}:testIamPermissions.
+			collections[len(collections)-1] += token[ix:]
+		}
+	}
+	if cloud == CloudAzure {
+		collections = filterAzureScopes(collections)
+	}
+	if len(collections) == 0 {
+		return service, req.Method
+	}
+	return service, req.Method + " " + strings.Join(collections, ".")
+}
+
+// filterAzureScopes removes the subscription, resource group and provider of a resource from its collections, which
+// are common to all the operations of a service.
+func filterAzureScopes(collections []string) []string {
+	var filtered []string
+	for _, c := range collections {
+		switch strings.ToLower(c) {
+		case "subscriptions", "resourcegroups", "providers":
+			continue
+		}
+		filtered = append(filtered, c)
+	}
+	return filtered
+}
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestParseCloudAPIRequest(t *testing.T) {
+	cases := []struct {
+		name              string
+		cloud             string
+		method            string
+		url               string
+		expectedService   string
+		expectedOperation string
+	}{
+		{
+			name:              "GCP compute custom method",
+			cloud:             CloudGCP,
+			method:            http.MethodPost,
+			url:               "https://compute.googleapis.com/compute/v1/projects/my-project/zones/us-east1-b/instances/my-instance/stop",
+			expectedService:   "compute",
+			expectedOperation: "POST projects.zones.instances.stop",
+		},
+		{
+			name:              "GCP DNS list",
+			cloud:             CloudGCP,
+			method:            http.MethodGet,
+			url:               "https://dns.googleapis.com/dns/v1/projects/my-project/managedZones/my-zone/rrsets?maxResults=100",
+			expectedService:   "dns",
+			expectedOperation: "GET projects.managedZones.rrsets",
+		},
+		{
+			name:              "GCP custom verb",
+			cloud:             CloudGCP,
+			method:            http.MethodPost,
+			url:               "https://cloudresourcemanager.googleapis.com/v1/projects/my-project:testIamPermissions",
+			expectedService:   "cloudresourcemanager",
+			expectedOperation: "POST projects:testIamPermissions",
+		},
+		{
+			name:              "GCP token",
+			cloud:             CloudGCP,
+			method:            http.MethodPost,
+			url:               "https://oauth2.googleapis.com/token",
+			expectedService:   "oauth2",
+			expectedOperation: "POST token",
+		},
+		{
+			name:              "Azure record set",
+			cloud:             CloudAzure,
+			method:            http.MethodPut,
+			url:               "https://management.azure.com/subscriptions/sub/resourceGroups/my-rg/providers/Microsoft.Network/dnsZones/example.com/A/api?api-version=2018-05-01",
+			expectedService:   "Microsoft.Network",
+			expectedOperation: "PUT dnsZones.A",
+		},
+		{
+			name:              "Azure virtual machine action",
+			cloud:             CloudAzure,
+			method:            http.MethodPost,
+			url:               "https://management.azure.com/subscriptions/sub/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/vm-1/deallocate",
+			expectedService:   "Microsoft.Compute",
+			expectedOperation: "POST virtualMachines.deallocate",
+		},
+		{
+			name:              "Azure resource group",
+			cloud:             CloudAzure,
+			method:            http.MethodGet,
+			url:               "https://management.azure.com/subscriptions/sub/resourceGroups/my-rg",
+			expectedService:   "Microsoft.Resources",
+			expectedOperation: "GET",
+		},
+		{
+			name:              "Azure token",
+			cloud:             CloudAzure,
+			method:            http.MethodPost,
+			url:               "https://login.microsoftonline.com/tenant-id/oauth2/token",
+			expectedService:   "login",
+			expectedOperation: "POST oauth2.token",
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest(test.method, test.url, nil)
+			require.NoError(t, err)
+			service, operation := parseCloudAPIRequest(test.cloud, req)
+			assert.Equal(t, test.expectedService, service, "unexpected service")
+			assert.Equal(t, test.expectedOperation, operation, "unexpected operation")
+		})
+	}
+}
+
+func TestCloudAPIMetricsTripper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/dns/v1/projects/my-project/managedZones/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewCloudAPIHTTPClient(nil, hivev1.DNSZoneControllerName, CloudGCP)
+	require.NoError(t, err)
+	failed := testutil.ToFloat64(metricCloudAPIRequestErrors.WithLabelValues("dnszone", CloudGCP, "127", "GET projects.managedZones", "404"))
+
+	for _, zone := range []string{"my-zone", "missing"} {
+		resp, err := c.Get(server.URL + "/dns/v1/projects/my-project/managedZones/" + zone)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.Equal(t, failed+1, testutil.ToFloat64(metricCloudAPIRequestErrors.WithLabelValues("dnszone", CloudGCP, "127", "GET projects.managedZones", "404")), "expected the failed call to be counted")
+}
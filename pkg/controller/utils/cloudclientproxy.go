@@ -0,0 +1,168 @@
+package utils
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	cloudClientProxyHTTPProxyKey       = "httpProxy"
+	cloudClientProxyHTTPSProxyKey      = "httpsProxy"
+	cloudClientProxyNoProxyKey         = "noProxy"
+	cloudClientProxyTrustedCABundleKey = "ca.crt"
+)
+
+// CloudClientProxy is the proxy configuration, and the additional trusted certificate authorities, of the clients of
+// the cloud APIs.
+type CloudClientProxy struct {
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+
+	// TrustedCABundle is a PEM bundle of certificate authorities trusted in addition to those of the system.
+	TrustedCABundle []byte
+}
+
+// ReadCloudClientProxyFiles reads the proxy configuration and the trusted CA bundle of the clients of the cloud APIs
+// from the files pointed to by the HIVE_CLOUD_CLIENT_PROXY_FILE and HIVE_CLOUD_CLIENT_TRUSTED_CA_BUNDLE_FILE environment
+// variables. Nil is returned if neither is configured in HiveConfig.
+func ReadCloudClientProxyFiles() (*CloudClientProxy, error) {
+	data, err := readOptionalFile(os.Getenv(constants.CloudClientProxyFileEnvVar))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the cloud client proxy file")
+	}
+	trustedCABundle, err := readOptionalFile(os.Getenv(constants.CloudClientTrustedCABundleFileEnvVar))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the cloud client trusted CA bundle file")
+	}
+	if len(data) == 0 && len(trustedCABundle) == 0 {
+		return nil, nil
+	}
+	proxy := &CloudClientProxy{TrustedCABundle: trustedCABundle}
+	if len(data) > 0 {
+		config := &hivev1.JobProxyConfig{}
+		if err := json.Unmarshal(data, config); err != nil {
+			return nil, errors.Wrap(err, "failed to parse the cloud client proxy file")
+		}
+		proxy.HTTPProxy, proxy.HTTPSProxy, proxy.NoProxy = config.HTTPProxy, config.HTTPSProxy, config.NoProxy
+	}
+	return proxy, nil
+}
+
+// GetCloudClientProxy returns the proxy configuration of the clients of the cloud APIs read from the secret in the
+// namespace, or from HiveConfig when there is no secret.
+func GetCloudClientProxy(c client.Client, namespace string, secretRef *corev1.LocalObjectReference) (*CloudClientProxy, error) {
+	if secretRef == nil || secretRef.Name == "" {
+		return ReadCloudClientProxyFiles()
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: secretRef.Name}, secret); err != nil {
+		return nil, errors.Wrap(err, "failed to get the cloud client proxy secret")
+	}
+	return &CloudClientProxy{
+		HTTPProxy:       string(secret.Data[cloudClientProxyHTTPProxyKey]),
+		HTTPSProxy:      string(secret.Data[cloudClientProxyHTTPSProxyKey]),
+		NoProxy:         string(secret.Data[cloudClientProxyNoProxyKey]),
+		TrustedCABundle: secret.Data[cloudClientProxyTrustedCABundleKey],
+	}, nil
+}
+
+// HTTPClient returns an HTTP client sending requests through the proxy and trusting the additional certificate
+// authorities. Nil is returned for a nil proxy, so that the default client of the cloud SDKs is used.
+func (p *CloudClientProxy) HTTPClient() (*http.Client, error) {
+	if p == nil {
+		return nil, nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if p.HTTPProxy != "" || p.HTTPSProxy != "" {
+		proxyFunc, err := p.proxyFunc()
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = proxyFunc
+	}
+	if len(p.TrustedCABundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(p.TrustedCABundle) {
+			return nil, errors.New("no certificate found in the trusted CA bundle of the cloud client proxy")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return &http.Client{Transport: transport}, nil
+}
+
+// proxyFunc returns the proxy function of the transport, which sends the requests to the hosts not matching NoProxy to
+// the proxy of their scheme.
+func (p *CloudClientProxy) proxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	var httpProxy, httpsProxy *url.URL
+	for _, u := range []struct {
+		raw    string
+		parsed **url.URL
+	}{{p.HTTPProxy, &httpProxy}, {p.HTTPSProxy, &httpsProxy}} {
+		if u.raw == "" {
+			continue
+		}
+		parsed, err := url.Parse(u.raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid proxy URL %q", u.raw)
+		}
+		*u.parsed = parsed
+	}
+	return func(req *http.Request) (*url.URL, error) {
+		if noProxyMatches(p.NoProxy, req.URL.Hostname()) {
+			return nil, nil
+		}
+		if req.URL.Scheme == "https" {
+			return httpsProxy, nil
+		}
+		return httpProxy, nil
+	}, nil
+}
+
+// noProxyMatches returns whether the host matches an entry of the comma-separated NoProxy list: "*", a CIDR containing
+// the host, the host itself, or a domain of which the host is a subdomain.
+func noProxyMatches(noProxy, host string) bool {
+	ip := net.ParseIP(host)
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+			continue
+		case entry == "*":
+			return true
+		case ip != nil:
+			if _, cidr, err := net.ParseCIDR(entry); err == nil && cidr.Contains(ip) {
+				return true
+			}
+			if entry == host {
+				return true
+			}
+		default:
+			domain := strings.TrimPrefix(entry, ".")
+			host := strings.ToLower(host)
+			if host == domain || strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+		}
+	}
+	return false
+}
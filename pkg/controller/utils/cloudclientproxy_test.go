@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNoProxyMatches(t *testing.T) {
+	cases := []struct {
+		name     string
+		noProxy  string
+		host     string
+		expected bool
+	}{
+		{name: "empty", noProxy: "", host: "ec2.amazonaws.com"},
+		{name: "wildcard", noProxy: "*", host: "ec2.amazonaws.com", expected: true},
+		{name: "exact host", noProxy: "ec2.amazonaws.com", host: "ec2.amazonaws.com", expected: true},
+		{name: "domain suffix", noProxy: "foo.com, .amazonaws.com", host: "ec2.us-east-1.amazonaws.com", expected: true},
+		{name: "domain without dot", noProxy: "amazonaws.com", host: "ec2.amazonaws.com", expected: true},
+		{name: "partial label", noProxy: "azonaws.com", host: "ec2.amazonaws.com"},
+		{name: "case insensitive", noProxy: "AmazonAWS.com", host: "EC2.amazonaws.com", expected: true},
+		{name: "CIDR", noProxy: "10.0.0.0/16", host: "10.0.3.4", expected: true},
+		{name: "CIDR not containing", noProxy: "10.0.0.0/16", host: "10.1.3.4"},
+		{name: "IP", noProxy: "10.0.3.4", host: "10.0.3.4", expected: true},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, noProxyMatches(test.noProxy, test.host))
+		})
+	}
+}
+
+func TestCloudClientProxyHTTPClient(t *testing.T) {
+	client, err := (*CloudClientProxy)(nil).HTTPClient()
+	require.NoError(t, err)
+	assert.Nil(t, client, "expected no client for a nil proxy")
+
+	proxy := &CloudClientProxy{
+		HTTPProxy:  "http://http-proxy:3128",
+		HTTPSProxy: "http://https-proxy:3128",
+		NoProxy:    ".internal",
+	}
+	client, err = proxy.HTTPClient()
+	require.NoError(t, err)
+	transport := client.Transport.(*http.Transport)
+	for url, expected := range map[string]string{
+		"https://ec2.amazonaws.com":      "http://https-proxy:3128",
+		"http://metadata.google.com":     "http://http-proxy:3128",
+		"https://vault.corp.internal:80": "",
+	} {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		proxyURL, err := transport.Proxy(req)
+		require.NoError(t, err)
+		if expected == "" {
+			assert.Nil(t, proxyURL, "expected no proxy for %s", url)
+			continue
+		}
+		if assert.NotNil(t, proxyURL, "expected a proxy for %s", url) {
+			assert.Equal(t, expected, proxyURL.String(), "unexpected proxy for %s", url)
+		}
+	}
+
+	_, err = (&CloudClientProxy{TrustedCABundle: []byte("not a certificate")}).HTTPClient()
+	assert.Error(t, err, "expected error for a bundle without certificates")
+}
+
+func TestCloudClientProxyTrustedCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	bundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	client, err := (&CloudClientProxy{}).HTTPClient()
+	require.NoError(t, err)
+	_, err = client.Get(server.URL)
+	assert.Error(t, err, "expected the server certificate to be untrusted")
+
+	client, err = (&CloudClientProxy{TrustedCABundle: bundle}).HTTPClient()
+	require.NoError(t, err)
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+}
+
+func TestGetCloudClientProxy(t *testing.T) {
+	c := fake.NewFakeClientWithScheme(scheme.Scheme, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "proxy"},
+		Data: map[string][]byte{
+			"httpsProxy": []byte("http://proxy:3128"),
+			"noProxy":    []byte(".internal"),
+			"ca.crt":     []byte(testTrustedCABundle),
+		},
+	})
+
+	proxy, err := GetCloudClientProxy(c, "test-namespace", &corev1.LocalObjectReference{Name: "proxy"})
+	require.NoError(t, err)
+	assert.Equal(t, &CloudClientProxy{
+		HTTPSProxy:      "http://proxy:3128",
+		NoProxy:         ".internal",
+		TrustedCABundle: []byte(testTrustedCABundle),
+	}, proxy)
+
+	_, err = GetCloudClientProxy(c, "test-namespace", &corev1.LocalObjectReference{Name: "missing"})
+	assert.Error(t, err, "expected error for a missing secret")
+
+	proxy, err = GetCloudClientProxy(c, "test-namespace", nil)
+	require.NoError(t, err)
+	assert.Nil(t, proxy, "expected no proxy when neither the secret nor HiveConfig configure one")
+}
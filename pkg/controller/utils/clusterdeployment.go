@@ -23,8 +23,17 @@ func IsFakeCluster(cd *hivev1.ClusterDeployment) bool {
 	return fakeCluster && err == nil
 }
 
+// IsClusterAdopted returns true if the cluster was adopted by Hive rather than installed by a provision or a
+// ClusterInstall.
+func IsClusterAdopted(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Installed && cd.Status.ProvisionRef == nil && cd.Spec.ClusterInstallRef == nil
+}
+
 // IsClusterPausedOrRelocating checks if the syncing to the cluster is paused or if the cluster is relocating
 func IsClusterPausedOrRelocating(cd *hivev1.ClusterDeployment, logger log.FieldLogger) bool {
+	if IsClusterDeploymentPaused(cd, logger) {
+		return true
+	}
 	if paused, err := strconv.ParseBool(cd.Annotations[constants.SyncsetPauseAnnotation]); err == nil && paused {
 		logger.WithField("annotation", constants.SyncsetPauseAnnotation).Warn("syncing to cluster is disabled by annotation")
 		return true
@@ -37,6 +46,16 @@ func IsClusterPausedOrRelocating(cd *hivev1.ClusterDeployment, logger log.FieldL
 	return false
 }
 
+// IsClusterDeploymentPaused returns true when the reconciliation of the ClusterDeployment is paused by spec.paused.
+// Controllers acting on a ClusterDeployment or its cluster must not make any change while it is paused.
+func IsClusterDeploymentPaused(cd *hivev1.ClusterDeployment, logger log.FieldLogger) bool {
+	if cd.Spec.Paused {
+		logger.Info("reconciling cluster deployment is paused by spec.paused")
+		return true
+	}
+	return false
+}
+
 func IsRelocating(obj metav1.Object) (relocateName string, status hivev1.RelocateStatus, err error) {
 	relocateValue, ok := obj.GetAnnotations()[constants.RelocateAnnotation]
 	if !ok {
@@ -90,6 +109,10 @@ func CredentialsSecretName(cd *hivev1.ClusterDeployment) string {
 		return cd.Spec.Platform.OpenStack.CredentialsSecretRef.Name
 	case p.Ovirt != nil:
 		return cd.Spec.Platform.Ovirt.CredentialsSecretRef.Name
+	case p.Nutanix != nil:
+		return cd.Spec.Platform.Nutanix.CredentialsSecretRef.Name
+	case p.PowerVS != nil:
+		return cd.Spec.Platform.PowerVS.CredentialsSecretRef.Name
 	case p.BareMetal != nil:
 		return ""
 	case p.AgentBareMetal != nil:
@@ -89,6 +89,11 @@ func TestIsClusterPausedOrRelocating(t *testing.T) {
 			),
 			expected: false,
 		},
+		{
+			name:     "spec paused",
+			cd:       clusterdeployment.Build(clusterdeployment.Paused()),
+			expected: true,
+		},
 		{
 			name: "relocate annotation",
 			cd: clusterdeployment.Build(
@@ -421,6 +421,103 @@ func SetDNSZoneConditionWithChangeCheck(
 	return conditions, changed
 }
 
+// SetIdentityProviderConditionWithChangeCheck sets a condition on a SyncIdentityProvider or
+// SelectorSyncIdentityProvider resource's status.
+// It returns the conditions as well a boolean indicating whether there was a change made
+// to the conditions.
+func SetIdentityProviderConditionWithChangeCheck(
+	conditions []hivev1.IdentityProviderCondition,
+	conditionType hivev1.IdentityProviderConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+	updateConditionCheck UpdateConditionCheck,
+) ([]hivev1.IdentityProviderCondition, bool) {
+	changed := false
+	now := metav1.Now()
+	existingCondition := FindIdentityProviderCondition(conditions, conditionType)
+	if existingCondition == nil {
+		if status == corev1.ConditionTrue {
+			conditions = append(
+				conditions,
+				hivev1.IdentityProviderCondition{
+					Type:               conditionType,
+					Status:             status,
+					Reason:             reason,
+					Message:            message,
+					LastTransitionTime: now,
+					LastProbeTime:      now,
+				},
+			)
+			changed = true
+		}
+	} else {
+		if shouldUpdateCondition(
+			existingCondition.Status, existingCondition.Reason, existingCondition.Message,
+			status, reason, message,
+			updateConditionCheck,
+		) {
+			if existingCondition.Status != status {
+				existingCondition.LastTransitionTime = now
+			}
+			existingCondition.Status = status
+			existingCondition.Reason = reason
+			existingCondition.Message = message
+			existingCondition.LastProbeTime = now
+			changed = true
+		}
+	}
+	return conditions, changed
+}
+
+// SetClusterImageSetConditionWithChangeCheck sets a condition on a ClusterImageSet resource's status.
+// Unlike most conditions, a missing condition is added regardless of its status, since the conditions of a
+// ClusterImageSet report positive results such as the image being valid.
+// It returns the conditions as well a boolean indicating whether there was a change made
+// to the conditions.
+func SetClusterImageSetConditionWithChangeCheck(
+	conditions []hivev1.ClusterImageSetCondition,
+	conditionType hivev1.ClusterImageSetConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+	updateConditionCheck UpdateConditionCheck,
+) ([]hivev1.ClusterImageSetCondition, bool) {
+	changed := false
+	now := metav1.Now()
+	existingCondition := FindClusterImageSetCondition(conditions, conditionType)
+	if existingCondition == nil {
+		conditions = append(
+			conditions,
+			hivev1.ClusterImageSetCondition{
+				Type:               conditionType,
+				Status:             status,
+				Reason:             reason,
+				Message:            message,
+				LastTransitionTime: now,
+				LastProbeTime:      now,
+			},
+		)
+		changed = true
+	} else {
+		if shouldUpdateCondition(
+			existingCondition.Status, existingCondition.Reason, existingCondition.Message,
+			status, reason, message,
+			updateConditionCheck,
+		) {
+			if existingCondition.Status != status {
+				existingCondition.LastTransitionTime = now
+			}
+			existingCondition.Status = status
+			existingCondition.Reason = reason
+			existingCondition.Message = message
+			existingCondition.LastProbeTime = now
+			changed = true
+		}
+	}
+	return conditions, changed
+}
+
 // SetMachinePoolCondition sets a condition on a MachinePool resource's status
 func SetMachinePoolCondition(
 	conditions []hivev1.MachinePoolCondition,
@@ -671,6 +768,28 @@ func FindDNSZoneCondition(conditions []hivev1.DNSZoneCondition, conditionType hi
 	return nil
 }
 
+// FindIdentityProviderCondition finds in the condition that has the
+// specified condition type in the given list. If none exists, then returns nil.
+func FindIdentityProviderCondition(conditions []hivev1.IdentityProviderCondition, conditionType hivev1.IdentityProviderConditionType) *hivev1.IdentityProviderCondition {
+	for i, condition := range conditions {
+		if condition.Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// FindClusterImageSetCondition finds in the condition that has the
+// specified condition type in the given list. If none exists, then returns nil.
+func FindClusterImageSetCondition(conditions []hivev1.ClusterImageSetCondition, conditionType hivev1.ClusterImageSetConditionType) *hivev1.ClusterImageSetCondition {
+	for i, condition := range conditions {
+		if condition.Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
 // FindMachinePoolCondition finds in the condition that has the
 // specified condition type in the given list. If none exists, then returns nil.
 func FindMachinePoolCondition(conditions []hivev1.MachinePoolCondition, conditionType hivev1.MachinePoolConditionType) *hivev1.MachinePoolCondition {
@@ -20,7 +20,11 @@ import (
 	"github.com/vmware/govmomi/vim25/soap"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1nutanix "github.com/openshift/hive/apis/hive/v1/nutanix"
+	hivev1powervs "github.com/openshift/hive/apis/hive/v1/powervs"
 	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/ibmclient"
+	"github.com/openshift/hive/pkg/nutanixclient"
 )
 
 // ValidateCredentialsForClusterDeployment will attempt to verify that the platform/cloud credentials
@@ -66,6 +70,31 @@ func ValidateCredentialsForClusterDeployment(kubeClient client.Client, cd *hivev
 			string(secret.Data[constants.PasswordSecretKey]),
 			rootCAFiles,
 			logger)
+	case constants.PlatformNutanix:
+		secretKey := types.NamespacedName{Name: cd.Spec.Platform.Nutanix.CredentialsSecretRef.Name, Namespace: cd.Namespace}
+		if err := kubeClient.Get(context.TODO(), secretKey, secret); err != nil {
+			logger.WithError(err).Error("failed to read in ClusterDeployment's platform creds")
+			return false, err
+		}
+
+		var certificatesSecret *corev1.Secret
+		if ref := cd.Spec.Platform.Nutanix.CertificatesSecretRef; ref != nil && ref.Name != "" {
+			certificatesSecret = &corev1.Secret{}
+			certificatesKey := types.NamespacedName{Name: ref.Name, Namespace: cd.Namespace}
+			if err := kubeClient.Get(context.TODO(), certificatesKey, certificatesSecret); err != nil {
+				logger.WithError(err).Error("failed to read in Prism Central certificates")
+				return false, err
+			}
+		}
+
+		return validateNutanixCredentials(cd.Spec.Platform.Nutanix.PrismCentral, secret, certificatesSecret, logger)
+	case constants.PlatformPowerVS:
+		secretKey := types.NamespacedName{Name: cd.Spec.Platform.PowerVS.CredentialsSecretRef.Name, Namespace: cd.Namespace}
+		if err := kubeClient.Get(context.TODO(), secretKey, secret); err != nil {
+			logger.WithError(err).Error("failed to read in ClusterDeployment's platform creds")
+			return false, err
+		}
+		return validatePowerVSCredentials(cd.Spec.Platform.PowerVS, secret, logger)
 	default:
 		// If we have no platform-specific credentials verification
 		// assume the creds are valid.
@@ -135,6 +164,48 @@ func validateVSphereCredentials(vcenter, username, password string, rootCAFiles
 	return err == nil, nil
 }
 
+func validateNutanixCredentials(prismCentral hivev1nutanix.PrismEndpoint, credentialsSecret, certificatesSecret *corev1.Secret, logger log.FieldLogger) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	nutanixClient, err := nutanixclient.NewClientFromSecrets(prismCentral, credentialsSecret, certificatesSecret)
+	if err != nil {
+		logger.WithError(err).Error("failed to create Prism Central client")
+		return false, err
+	}
+	if _, err := nutanixClient.GetCurrentUser(ctx); err != nil {
+		if nutanixclient.IsUnauthorized(err) {
+			// The credentials are invalid, rather than Prism Central being unreachable.
+			logger.WithError(err).Warn("failed to authenticate into Prism Central")
+			return false, nil
+		}
+		logger.WithError(err).Error("failed to reach Prism Central")
+		return false, err
+	}
+	return true, nil
+}
+
+func validatePowerVSCredentials(platform *hivev1powervs.Platform, credentialsSecret *corev1.Secret, logger log.FieldLogger) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	powerVSClient, err := ibmclient.NewPowerVSClientFromSecret(credentialsSecret, platform.Region, platform.ServiceInstanceID)
+	if err != nil {
+		logger.WithError(err).Error("failed to create IBM Cloud client")
+		return false, err
+	}
+	// Looking up the workspace checks both that the API key is valid and that it has access to the workspace.
+	if _, err := powerVSClient.GetResourceInstance(ctx, platform.ServiceInstanceID); err != nil {
+		if ibmclient.IsUnauthorized(err) {
+			logger.WithError(err).Warn("failed to authenticate into IBM Cloud")
+			return false, nil
+		}
+		logger.WithError(err).Error("failed to look up the Power Virtual Server workspace")
+		return false, err
+	}
+	return true, nil
+}
+
 // getClusterPlatform returns the platform of a given ClusterDeployment
 func getClusterPlatform(cd *hivev1.ClusterDeployment) string {
 	switch {
@@ -148,6 +219,10 @@ func getClusterPlatform(cd *hivev1.ClusterDeployment) string {
 		return constants.PlatformOpenStack
 	case cd.Spec.Platform.VSphere != nil:
 		return constants.PlatformVSphere
+	case cd.Spec.Platform.Nutanix != nil:
+		return constants.PlatformNutanix
+	case cd.Spec.Platform.PowerVS != nil:
+		return constants.PlatformPowerVS
 	case cd.Spec.Platform.BareMetal != nil:
 		return constants.PlatformBaremetal
 	}
@@ -38,6 +38,45 @@ func EnqueueDNSZonesOwnedByClusterDeployment(c client.Client, logger log.FieldLo
 	})
 }
 
+// DNSZoneCredentialsSecretName returns the name of the secret holding the cloud credentials used to manage the
+// DNSZone. An empty string is returned if the DNSZone does not reference a credentials secret.
+func DNSZoneCredentialsSecretName(dnsZone *hivev1.DNSZone) string {
+	switch {
+	case dnsZone.Spec.AWS != nil:
+		return dnsZone.Spec.AWS.CredentialsSecretRef.Name
+	case dnsZone.Spec.GCP != nil:
+		return dnsZone.Spec.GCP.CredentialsSecretRef.Name
+	case dnsZone.Spec.Azure != nil:
+		return dnsZone.Spec.Azure.CredentialsSecretRef.Name
+	case dnsZone.Spec.IBMCloud != nil:
+		return dnsZone.Spec.IBMCloud.CredentialsSecretRef.Name
+	case dnsZone.Spec.Webhook != nil && dnsZone.Spec.Webhook.AuthSecretRef != nil:
+		return dnsZone.Spec.Webhook.AuthSecretRef.Name
+	default:
+		return ""
+	}
+}
+
+// EnqueueDNSZonesForCredentialsSecret enqueues the DNSZones that use a secret for their cloud credentials when the
+// secret changes, so that rotated credentials are picked up promptly.
+func EnqueueDNSZonesForCredentialsSecret(c client.Client, logger log.FieldLogger) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(mapObj client.Object) []reconcile.Request {
+		dnsZones := &hivev1.DNSZoneList{}
+		if err := c.List(context.TODO(), dnsZones, client.InNamespace(mapObj.GetNamespace())); err != nil {
+			logger.WithError(err).Log(LogLevel(err), "could not list DNS zones for credentials secret")
+			return nil
+		}
+		var requests []reconcile.Request
+		for i := range dnsZones.Items {
+			if DNSZoneCredentialsSecretName(&dnsZones.Items[i]) != mapObj.GetName() {
+				continue
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&dnsZones.Items[i])})
+		}
+		return requests
+	})
+}
+
 // ReconcileDNSZoneForRelocation performs reconciliation on a DNSZone that is in the midst of a relocation to a new
 // Hive instance.
 // If the DNSZone is undergoing relocation, then the source Hive instance should not act on the DNSZone.
@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/singleflight"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	metricExpiringCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_expiring_cache_requests_total",
+		Help: "Counter incremented for each lookup in an expiring cache, by whether the value was cached.",
+	},
+		[]string{"cache", "result"},
+	)
+	metricExpiringCacheEvictions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_expiring_cache_evictions_total",
+		Help: "Counter incremented for each entry removed from an expiring cache, by whether it expired or the cache was full.",
+	},
+		[]string{"cache", "reason"},
+	)
+	metricExpiringCacheEntries = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_expiring_cache_entries",
+		Help: "Number of entries in an expiring cache.",
+	},
+		[]string{"cache"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricExpiringCacheRequests)
+	metrics.Registry.MustRegister(metricExpiringCacheEvictions)
+	metrics.Registry.MustRegister(metricExpiringCacheEntries)
+}
+
+// ExpiringCache is a cache of values that expire a fixed time after they are added. The number of entries is bounded,
+// with the entries closest to expiring evicted first when the cache is full, and concurrent loads of the same key are
+// deduplicated. Lookups, evictions and the number of entries are reported in metrics labeled with the name of the
+// cache. A nil ExpiringCache caches nothing, so that callers built without a cache, such as in tests, always load.
+type ExpiringCache struct {
+	name    string
+	ttl     time.Duration
+	maxSize int
+
+	mu      sync.Mutex
+	entries map[string]expiringCacheEntry
+	loads   singleflight.Group
+
+	// now returns the current time, here for testing
+	now func() time.Time
+}
+
+type expiringCacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// NewExpiringCache creates an ExpiringCache whose entries expire after the ttl, holding at most maxSize entries. The
+// name must be unique to the cache, as it is the label of the metrics of the cache.
+func NewExpiringCache(name string, ttl time.Duration, maxSize int) *ExpiringCache {
+	return &ExpiringCache{
+		name:    name,
+		ttl:     ttl,
+		maxSize: maxSize,
+		entries: map[string]expiringCacheEntry{},
+		now:     time.Now,
+	}
+}
+
+// Get returns the value cached for the key, if it has not expired.
+func (c *ExpiringCache) Get(key string) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if ok && !c.now().Before(entry.expires) {
+		c.remove(key, "expired")
+		ok = false
+	}
+	if !ok {
+		metricExpiringCacheRequests.WithLabelValues(c.name, "miss").Inc()
+		return nil, false
+	}
+	metricExpiringCacheRequests.WithLabelValues(c.name, "hit").Inc()
+	return entry.value, true
+}
+
+// Set caches the value for the key, evicting the entries closest to expiring if the cache is full.
+func (c *ExpiringCache) Set(key string, value interface{}) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxSize {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				c.remove(k, "expired")
+			}
+		}
+		for len(c.entries) >= c.maxSize {
+			oldest := ""
+			for k, entry := range c.entries {
+				if oldest == "" || entry.expires.Before(c.entries[oldest].expires) {
+					oldest = k
+				}
+			}
+			c.remove(oldest, "size")
+		}
+	}
+	c.entries[key] = expiringCacheEntry{value: value, expires: now.Add(c.ttl)}
+	metricExpiringCacheEntries.WithLabelValues(c.name).Set(float64(len(c.entries)))
+}
+
+// Delete removes the value cached for the key, so that it is loaded again when next used.
+func (c *ExpiringCache) Delete(key string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; ok {
+		delete(c.entries, key)
+		metricExpiringCacheEntries.WithLabelValues(c.name).Set(float64(len(c.entries)))
+	}
+}
+
+// GetOrLoad returns the value cached for the key, or loads and caches it if it is not cached. Concurrent calls for the
+// same key share a single load. Errors returned by the load are not cached.
+func (c *ExpiringCache) GetOrLoad(key string, load func() (interface{}, error)) (interface{}, error) {
+	if c == nil {
+		return load()
+	}
+	if value, ok := c.Get(key); ok {
+		return value, nil
+	}
+	value, err, _ := c.loads.Do(key, func() (interface{}, error) {
+		value, err := load()
+		if err != nil {
+			return nil, err
+		}
+		c.Set(key, value)
+		return value, nil
+	})
+	return value, err
+}
+
+// remove removes the entry for the key. The cache must be locked.
+func (c *ExpiringCache) remove(key, reason string) {
+	delete(c.entries, key)
+	metricExpiringCacheEvictions.WithLabelValues(c.name, reason).Inc()
+	metricExpiringCacheEntries.WithLabelValues(c.name).Set(float64(len(c.entries)))
+}
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpiringCache(t *testing.T) {
+	now := time.Date(2024, 3, 12, 10, 0, 0, 0, time.UTC)
+	c := NewExpiringCache("test", time.Minute, 2)
+	c.now = func() time.Time { return now }
+
+	_, ok := c.Get("a")
+	assert.False(t, ok, "unexpected value for key not set")
+
+	c.Set("a", 1)
+	v, ok := c.Get("a")
+	assert.True(t, ok, "expected value for key set")
+	assert.Equal(t, 1, v, "unexpected value")
+
+	now = now.Add(30 * time.Second)
+	c.Set("b", 2)
+	now = now.Add(30 * time.Second)
+	_, ok = c.Get("a")
+	assert.False(t, ok, "expected value to have expired")
+	_, ok = c.Get("b")
+	assert.True(t, ok, "expected value not to have expired")
+
+	c.Set("c", 3)
+	c.Set("d", 4)
+	_, ok = c.Get("b")
+	assert.False(t, ok, "expected value closest to expiring to be evicted")
+	_, ok = c.Get("c")
+	assert.True(t, ok, "expected value to be cached")
+	_, ok = c.Get("d")
+	assert.True(t, ok, "expected value to be cached")
+	assert.Len(t, c.entries, 2, "unexpected number of entries")
+
+	c.Delete("c")
+	_, ok = c.Get("c")
+	assert.False(t, ok, "expected value to be deleted")
+}
+
+func TestExpiringCacheGetOrLoad(t *testing.T) {
+	c := NewExpiringCache("test-load", time.Minute, 10)
+
+	_, err := c.GetOrLoad("a", func() (interface{}, error) { return nil, errors.New("load failed") })
+	assert.Error(t, err, "expected load error")
+	_, ok := c.Get("a")
+	assert.False(t, ok, "unexpected error cached")
+
+	var loads int32
+	release := make(chan struct{})
+	load := func() (interface{}, error) {
+		atomic.AddInt32(&loads, 1)
+		<-release
+		return "value", nil
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.GetOrLoad("a", load)
+			assert.NoError(t, err, "unexpected error")
+			assert.Equal(t, "value", v, "unexpected value")
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&loads), "expected concurrent loads to be deduplicated")
+
+	v, err := c.GetOrLoad("a", func() (interface{}, error) { return "other", nil })
+	require.NoError(t, err, "unexpected error")
+	assert.Equal(t, "value", v, "expected cached value")
+}
+
+func TestNilExpiringCache(t *testing.T) {
+	var c *ExpiringCache
+	c.Set("a", 1)
+	_, ok := c.Get("a")
+	assert.False(t, ok, "unexpected value in nil cache")
+	v, err := c.GetOrLoad("a", func() (interface{}, error) { return 2, nil })
+	require.NoError(t, err, "unexpected error")
+	assert.Equal(t, 2, v, "unexpected loaded value")
+	c.Delete("a")
+}
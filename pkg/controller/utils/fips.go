@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// hostFIPSEnabledFile is the file of the kernel reporting whether the host is running in FIPS mode.
+const hostFIPSEnabledFile = "/proc/sys/crypto/fips_enabled"
+
+// HostFIPSEnabled returns whether the host is running in FIPS mode, which is required to run the installer for a
+// cluster installed in FIPS mode.
+func HostFIPSEnabled() (bool, error) {
+	data, err := ioutil.ReadFile(hostFIPSEnabledFile)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "could not read the FIPS mode of the host")
+	}
+	return string(bytes.TrimSpace(data)) == "1", nil
+}
+
+// InstallConfigRequestsFIPS returns whether the InstallConfig requests the cluster to be installed in FIPS mode.
+func InstallConfigRequestsFIPS(icData []byte) (bool, error) {
+	ic := struct {
+		FIPS bool `json:"fips,omitempty"`
+	}{}
+	if err := yaml.Unmarshal(icData, &ic); err != nil {
+		return false, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	return ic.FIPS, nil
+}
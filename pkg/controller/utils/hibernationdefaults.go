@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// ReadHibernationDefaults reads the hibernation defaults from the HIVE_HIBERNATION_DEFAULTS environment variable.
+// Nil is returned if the environment variable is not set.
+func ReadHibernationDefaults() (*hivev1.HibernationDefaultsConfig, error) {
+	value := os.Getenv(constants.HibernationDefaultsEnvVar)
+	if value == "" {
+		return nil, nil
+	}
+	defaults := &hivev1.HibernationDefaultsConfig{}
+	if err := json.Unmarshal([]byte(value), defaults); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", constants.HibernationDefaultsEnvVar)
+	}
+	return defaults, nil
+}
+
+// IsPowerStateAllowed returns whether the power state policies of the hibernation defaults allow the power state for
+// the ClusterDeployment. The Running power state is always allowed.
+func IsPowerStateAllowed(defaults *hivev1.HibernationDefaultsConfig, cd *hivev1.ClusterDeployment, powerState hivev1.ClusterPowerState) (bool, error) {
+	if defaults == nil || powerState == "" || powerState == hivev1.RunningClusterPowerState {
+		return true, nil
+	}
+	for _, policy := range defaults.PowerStatePolicies {
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Selector)
+		if err != nil {
+			return false, errors.Wrap(err, "invalid power state policy selector")
+		}
+		if !selector.Matches(labels.Set(cd.Labels)) {
+			continue
+		}
+		allowed := false
+		for _, s := range policy.AllowedPowerStates {
+			if s == powerState {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
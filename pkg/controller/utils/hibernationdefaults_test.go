@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestIsPowerStateAllowed(t *testing.T) {
+	defaults := &hivev1.HibernationDefaultsConfig{
+		PowerStatePolicies: []hivev1.PowerStatePolicy{
+			{
+				Selector:           metav1.LabelSelector{MatchLabels: map[string]string{"env": "production"}},
+				AllowedPowerStates: []hivev1.ClusterPowerState{hivev1.WorkersStoppedClusterPowerState},
+			},
+			{
+				Selector:           metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+				AllowedPowerStates: []hivev1.ClusterPowerState{hivev1.HibernatingClusterPowerState, hivev1.WorkersStoppedClusterPowerState},
+			},
+		},
+	}
+	cases := []struct {
+		name       string
+		defaults   *hivev1.HibernationDefaultsConfig
+		labels     map[string]string
+		powerState hivev1.ClusterPowerState
+		expected   bool
+	}{
+		{
+			name:       "no defaults",
+			powerState: hivev1.HibernatingClusterPowerState,
+			expected:   true,
+		},
+		{
+			name:       "no matching policy",
+			defaults:   defaults,
+			labels:     map[string]string{"env": "dev"},
+			powerState: hivev1.HibernatingClusterPowerState,
+			expected:   true,
+		},
+		{
+			name:       "running always allowed",
+			defaults:   defaults,
+			labels:     map[string]string{"env": "production"},
+			powerState: hivev1.RunningClusterPowerState,
+			expected:   true,
+		},
+		{
+			name:       "not allowed by matching policy",
+			defaults:   defaults,
+			labels:     map[string]string{"env": "production", "team": "a"},
+			powerState: hivev1.HibernatingClusterPowerState,
+			expected:   false,
+		},
+		{
+			name:       "allowed by all matching policies",
+			defaults:   defaults,
+			labels:     map[string]string{"env": "production", "team": "a"},
+			powerState: hivev1.WorkersStoppedClusterPowerState,
+			expected:   true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := &hivev1.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Labels: tc.labels}}
+			allowed, err := IsPowerStateAllowed(tc.defaults, cd, tc.powerState)
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expected, allowed, "unexpected result")
+		})
+	}
+}
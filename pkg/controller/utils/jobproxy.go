@@ -0,0 +1,156 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// JobTrustedCABundleConfigMapName is the name of the ConfigMap holding the trusted CA bundle of the pods created
+	// by Hive in the namespace of the pods.
+	JobTrustedCABundleConfigMapName = "hive-job-trusted-ca-bundle"
+
+	jobTrustedCABundleKey       = "ca-bundle.crt"
+	jobTrustedCABundleVolume    = "hive-job-trusted-ca-bundle"
+	jobTrustedCABundleMountPath = "/etc/hive-job-trusted-ca"
+)
+
+// ReadJobProxyFiles reads the proxy configuration and the trusted CA bundle of the pods created by Hive from the files
+// pointed to by the HIVE_JOB_PROXY_FILE and HIVE_JOB_TRUSTED_CA_BUNDLE_FILE environment variables. No configuration is
+// returned if the environment variables are not set or the files do not exist.
+func ReadJobProxyFiles() (*hivev1.JobProxyConfig, []byte, error) {
+	data, err := readOptionalFile(os.Getenv(constants.JobProxyFileEnvVar))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read the job proxy file")
+	}
+	var config *hivev1.JobProxyConfig
+	if len(data) > 0 {
+		config = &hivev1.JobProxyConfig{}
+		if err := json.Unmarshal(data, config); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to parse the job proxy file")
+		}
+	}
+	trustedCABundle, err := readOptionalFile(os.Getenv(constants.JobTrustedCABundleFileEnvVar))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read the job trusted CA bundle file")
+	}
+	return config, trustedCABundle, nil
+}
+
+func readOptionalFile(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// ApplyJobProxy sets the proxy environment variables of all the containers of the pod spec, overriding any already
+// set. If there is a trusted CA bundle, it is saved to a ConfigMap in the namespace of the pod and mounted into the
+// containers, where it is trusted in addition to the system certificate authorities.
+func ApplyJobProxy(c client.Client, namespace string, podSpec *corev1.PodSpec, config *hivev1.JobProxyConfig, trustedCABundle []byte, logger log.FieldLogger) error {
+	if config != nil {
+		SetProxyEnvVars(podSpec, config.HTTPProxy, config.HTTPSProxy, config.NoProxy)
+		initContainersSpec := &corev1.PodSpec{Containers: podSpec.InitContainers}
+		SetProxyEnvVars(initContainersSpec, config.HTTPProxy, config.HTTPSProxy, config.NoProxy)
+		podSpec.InitContainers = initContainersSpec.Containers
+	}
+	if len(trustedCABundle) == 0 {
+		return nil
+	}
+
+	if err := ensureJobTrustedCABundle(c, namespace, string(trustedCABundle), logger); err != nil {
+		return err
+	}
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: jobTrustedCABundleVolume,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: JobTrustedCABundleConfigMapName},
+			},
+		},
+	})
+	addTrustedCABundle := func(containers []corev1.Container) {
+		for i := range containers {
+			// The bundle is mounted with a subpath so that it is a regular file, as the symlinks of a ConfigMap
+			// volume are skipped when loading certificates from a directory.
+			containers[i].VolumeMounts = append(containers[i].VolumeMounts, corev1.VolumeMount{
+				Name:      jobTrustedCABundleVolume,
+				MountPath: jobTrustedCABundleMountPath + "/" + jobTrustedCABundleKey,
+				SubPath:   jobTrustedCABundleKey,
+				ReadOnly:  true,
+			})
+			containers[i].Env = append(containers[i].Env, corev1.EnvVar{
+				Name:  "SSL_CERT_DIR",
+				Value: jobTrustedCABundleMountPath,
+			})
+		}
+	}
+	addTrustedCABundle(podSpec.InitContainers)
+	addTrustedCABundle(podSpec.Containers)
+	return nil
+}
+
+// ApplyJobProxyFromFiles applies the proxy configuration and trusted CA bundle read with ReadJobProxyFiles to the pod
+// spec.
+func ApplyJobProxyFromFiles(c client.Client, namespace string, podSpec *corev1.PodSpec, logger log.FieldLogger) error {
+	config, trustedCABundle, err := ReadJobProxyFiles()
+	if err != nil {
+		return err
+	}
+	return ApplyJobProxy(c, namespace, podSpec, config, trustedCABundle, logger)
+}
+
+// ensureJobTrustedCABundle creates or updates the ConfigMap holding the trusted CA bundle in the namespace.
+func ensureJobTrustedCABundle(c client.Client, namespace, trustedCABundle string, logger log.FieldLogger) error {
+	cm := &corev1.ConfigMap{}
+	switch err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: JobTrustedCABundleConfigMapName}, cm); {
+	case apierrors.IsNotFound(err):
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      JobTrustedCABundleConfigMapName,
+			},
+			Data: map[string]string{jobTrustedCABundleKey: trustedCABundle},
+		}
+		if err := c.Create(context.TODO(), cm); err != nil {
+			logger.WithError(err).Log(LogLevel(err), "error creating job trusted CA bundle configmap")
+			return err
+		}
+		logger.Info("created job trusted CA bundle configmap")
+		return nil
+	case err != nil:
+		logger.WithError(err).Log(LogLevel(err), "error getting job trusted CA bundle configmap")
+		return err
+	}
+	if cm.Data[jobTrustedCABundleKey] == trustedCABundle {
+		return nil
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[jobTrustedCABundleKey] = trustedCABundle
+	if err := c.Update(context.TODO(), cm); err != nil {
+		logger.WithError(err).Log(LogLevel(err), "error updating job trusted CA bundle configmap")
+		return err
+	}
+	logger.Info("updated job trusted CA bundle configmap")
+	return nil
+}
@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const testTrustedCABundle = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+
+func TestApplyJobProxy(t *testing.T) {
+	cases := []struct {
+		name            string
+		existing        []runtime.Object
+		config          *hivev1.JobProxyConfig
+		trustedCABundle string
+		expectedEnv     []corev1.EnvVar
+		expectCABundle  bool
+	}{
+		{
+			name:        "no configuration",
+			expectedEnv: []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://operator-proxy:3128"}},
+		},
+		{
+			name: "proxy overrides existing env",
+			config: &hivev1.JobProxyConfig{
+				HTTPProxy: "http://proxy:3128",
+				NoProxy:   ".cluster.local",
+			},
+			expectedEnv: []corev1.EnvVar{
+				{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+				{Name: "NO_PROXY", Value: ".cluster.local"},
+			},
+		},
+		{
+			name:            "trusted CA bundle",
+			trustedCABundle: testTrustedCABundle,
+			expectedEnv: []corev1.EnvVar{
+				{Name: "HTTP_PROXY", Value: "http://operator-proxy:3128"},
+				{Name: "SSL_CERT_DIR", Value: jobTrustedCABundleMountPath},
+			},
+			expectCABundle: true,
+		},
+		{
+			name: "trusted CA bundle updated",
+			existing: []runtime.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: JobTrustedCABundleConfigMapName},
+				Data:       map[string]string{jobTrustedCABundleKey: "old"},
+			}},
+			trustedCABundle: testTrustedCABundle,
+			expectedEnv: []corev1.EnvVar{
+				{Name: "HTTP_PROXY", Value: "http://operator-proxy:3128"},
+				{Name: "SSL_CERT_DIR", Value: jobTrustedCABundleMountPath},
+			},
+			expectCABundle: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, tc.existing...)
+			container := func() corev1.Container {
+				return corev1.Container{Env: []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://operator-proxy:3128"}}}
+			}
+			podSpec := &corev1.PodSpec{
+				InitContainers: []corev1.Container{container()},
+				Containers:     []corev1.Container{container()},
+			}
+			var trustedCABundle []byte
+			if tc.trustedCABundle != "" {
+				trustedCABundle = []byte(tc.trustedCABundle)
+			}
+			err := ApplyJobProxy(c, "test-namespace", podSpec, tc.config, trustedCABundle, log.StandardLogger())
+			require.NoError(t, err, "unexpected error applying job proxy")
+
+			for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+				assert.Equal(t, tc.expectedEnv, containers[0].Env, "unexpected container env")
+				if tc.expectCABundle {
+					assert.Len(t, containers[0].VolumeMounts, 1, "expected trusted CA bundle to be mounted")
+				} else {
+					assert.Empty(t, containers[0].VolumeMounts, "unexpected volume mounts")
+				}
+			}
+
+			cm := &corev1.ConfigMap{}
+			err = c.Get(context.TODO(), client.ObjectKey{Namespace: "test-namespace", Name: JobTrustedCABundleConfigMapName}, cm)
+			if tc.expectCABundle {
+				require.NoError(t, err, "expected trusted CA bundle configmap")
+				assert.Equal(t, tc.trustedCABundle, cm.Data[jobTrustedCABundleKey], "unexpected trusted CA bundle")
+				assert.Len(t, podSpec.Volumes, 1, "expected trusted CA bundle volume")
+			} else {
+				assert.Error(t, err, "unexpected trusted CA bundle configmap")
+				assert.Empty(t, podSpec.Volumes, "unexpected volumes")
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// controllerLogLevelsCheckInterval is how often the controller log levels file is checked for changes. The
+	// kubelet takes up to a minute to update a mounted ConfigMap anyway.
+	controllerLogLevelsCheckInterval = 30 * time.Second
+)
+
+var (
+	controllerLoggersLock sync.Mutex
+	// controllerLoggers holds the logger of each controller.
+	controllerLoggers = map[hivev1.ControllerName]*log.Logger{}
+)
+
+// ControllerLogger returns the logger to use for the given controller. The logger shares everything but the level
+// with the standard logger, and logs at the log level set for the controller with SetControllerLogLevels, or at the
+// level of the standard logger. The same logger is returned for every call, so that loggers built once for a
+// controller pick up later changes to its log level.
+func ControllerLogger(controller hivev1.ControllerName) *log.Logger {
+	controllerLoggersLock.Lock()
+	defer controllerLoggersLock.Unlock()
+	return controllerLoggerLocked(controller)
+}
+
+// ControllerLogEntry returns a log entry of the logger of the given controller with the controller field set. It is
+// meant for the loggers that controllers build once rather than for every reconcile.
+func ControllerLogEntry(controller hivev1.ControllerName) *log.Entry {
+	return ControllerLogger(controller).WithField("controller", controller)
+}
+
+func controllerLoggerLocked(controller hivev1.ControllerName) *log.Logger {
+	if logger, ok := controllerLoggers[controller]; ok {
+		return logger
+	}
+	std := log.StandardLogger()
+	logger := &log.Logger{
+		Out:          std.Out,
+		Hooks:        std.Hooks,
+		Formatter:    std.Formatter,
+		ReportCaller: std.ReportCaller,
+		Level:        std.GetLevel(),
+		ExitFunc:     std.ExitFunc,
+	}
+	controllerLoggers[controller] = logger
+	return logger
+}
+
+// SetControllerLogLevels sets the log levels of specific controllers, overriding the log level of the standard
+// logger. Controllers that are not in levels go back to the log level of the standard logger.
+func SetControllerLogLevels(levels map[hivev1.ControllerName]log.Level) {
+	controllerLoggersLock.Lock()
+	defer controllerLoggersLock.Unlock()
+	std := log.StandardLogger()
+	for controller, logger := range controllerLoggers {
+		if _, ok := levels[controller]; !ok {
+			logger.SetLevel(std.GetLevel())
+		}
+	}
+	for controller, level := range levels {
+		controllerLoggerLocked(controller).SetLevel(level)
+	}
+}
+
+// ParseControllerLogLevels parses the JSON map of controller names to log levels written by the hive-operator to
+// the controller log levels ConfigMap.
+func ParseControllerLogLevels(data []byte) (map[hivev1.ControllerName]log.Level, error) {
+	raw := map[hivev1.ControllerName]string{}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+	}
+	levels := make(map[hivev1.ControllerName]log.Level, len(raw))
+	for controller, value := range raw {
+		level, err := log.ParseLevel(value)
+		if err != nil {
+			return nil, err
+		}
+		levels[controller] = level
+	}
+	return levels, nil
+}
+
+// WatchControllerLogLevels periodically reads the controller log levels file pointed to by the
+// HIVE_CONTROLLER_LOG_LEVELS_FILE environment variable, and applies the log levels in it with
+// SetControllerLogLevels until stop is closed. Nothing is watched if the environment variable is not set.
+func WatchControllerLogLevels(stop <-chan struct{}) {
+	path := os.Getenv(constants.ControllerLogLevelsFileEnvVar)
+	if path == "" {
+		return
+	}
+	logger := log.WithField("path", path)
+	var current map[hivev1.ControllerName]log.Level
+	go wait.Until(func() {
+		data, err := ioutil.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			logger.WithError(err).Error("failed to read controller log levels")
+			return
+		}
+		levels, err := ParseControllerLogLevels(data)
+		if err != nil {
+			logger.WithError(err).Error("failed to parse controller log levels")
+			return
+		}
+		if reflect.DeepEqual(levels, current) {
+			return
+		}
+		logger.WithField("levels", levels).Info("applying controller log levels")
+		SetControllerLogLevels(levels)
+		current = levels
+	}, controllerLogLevelsCheckInterval, stop)
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestParseControllerLogLevels(t *testing.T) {
+	cases := []struct {
+		name        string
+		data        string
+		expected    map[hivev1.ControllerName]log.Level
+		expectError bool
+	}{
+		{
+			name:     "empty",
+			expected: map[hivev1.ControllerName]log.Level{},
+		},
+		{
+			name: "levels",
+			data: `{"dnszone": "debug", "clustersync": "warn"}`,
+			expected: map[hivev1.ControllerName]log.Level{
+				hivev1.DNSZoneControllerName:     log.DebugLevel,
+				hivev1.ClustersyncControllerName: log.WarnLevel,
+			},
+		},
+		{
+			name:        "invalid level",
+			data:        `{"dnszone": "verbose"}`,
+			expectError: true,
+		},
+		{
+			name:        "invalid json",
+			data:        `dnszone: debug`,
+			expectError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			levels, err := ParseControllerLogLevels([]byte(tc.data))
+			if tc.expectError {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expected, levels, "unexpected levels")
+		})
+	}
+}
+
+func TestSetControllerLogLevels(t *testing.T) {
+	defer SetControllerLogLevels(nil)
+
+	dnsZoneLogger := ControllerLogger(hivev1.DNSZoneControllerName)
+	assert.NotSame(t, log.StandardLogger(), dnsZoneLogger, "expected a dedicated logger for the dnszone controller")
+	assert.Equal(t, log.StandardLogger().GetLevel(), dnsZoneLogger.GetLevel(), "expected the log level of the standard logger")
+
+	SetControllerLogLevels(map[hivev1.ControllerName]log.Level{hivev1.DNSZoneControllerName: log.TraceLevel})
+	assert.Same(t, dnsZoneLogger, ControllerLogger(hivev1.DNSZoneControllerName), "expected the dnszone controller logger to be reused")
+	assert.Equal(t, log.TraceLevel, dnsZoneLogger.GetLevel(), "unexpected dnszone controller log level")
+	assert.Equal(t, log.StandardLogger().GetLevel(), ControllerLogger(hivev1.ClustersyncControllerName).GetLevel(), "expected the log level of the standard logger for the clustersync controller")
+
+	// Loggers built before the log level changed pick up the new level.
+	entry := ControllerLogEntry(hivev1.DNSZoneControllerName)
+	SetControllerLogLevels(map[hivev1.ControllerName]log.Level{hivev1.DNSZoneControllerName: log.ErrorLevel})
+	assert.Equal(t, log.ErrorLevel, entry.Logger.GetLevel(), "unexpected dnszone controller log level")
+	assert.Equal(t, hivev1.DNSZoneControllerName, entry.Data["controller"], "unexpected controller field")
+
+	SetControllerLogLevels(nil)
+	assert.Equal(t, log.StandardLogger().GetLevel(), dnsZoneLogger.GetLevel(), "expected the log level of the standard logger for the dnszone controller")
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// ReadNamespaceQuotasFile reads the namespace quotas from the file pointed to by the HIVE_NAMESPACE_QUOTAS_FILE
+// environment variable. No quotas are returned if the environment variable is not set or the file does not exist.
+func ReadNamespaceQuotasFile() ([]hivev1.NamespaceQuota, error) {
+	path := os.Getenv(constants.NamespaceQuotasFileEnvVar)
+	if path == "" {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the namespace quotas file")
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var quotas []hivev1.NamespaceQuota
+	if err := json.Unmarshal(data, &quotas); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the namespace quotas file")
+	}
+	return quotas, nil
+}
+
+// FindNamespaceQuota returns the quota for the given namespace, or nil if there is none.
+func FindNamespaceQuota(quotas []hivev1.NamespaceQuota, namespace string) *hivev1.NamespaceQuota {
+	for i, quota := range quotas {
+		if quota.Namespace == namespace {
+			return &quotas[i]
+		}
+	}
+	return nil
+}
+
+// NamespaceClusterCount returns the number of clusters counted against the cluster quota of the namespace. These are
+// the ClusterDeployments in the namespace that are not being deleted, and the ClusterClaims in the namespace that
+// have been assigned a cluster.
+func NamespaceClusterCount(c client.Client, namespace string) (int, error) {
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := c.List(context.Background(), cdList, client.InNamespace(namespace)); err != nil {
+		return 0, errors.Wrap(err, "could not list ClusterDeployments")
+	}
+	count := 0
+	for _, cd := range cdList.Items {
+		if cd.DeletionTimestamp == nil {
+			count++
+		}
+	}
+	claimList := &hivev1.ClusterClaimList{}
+	if err := c.List(context.Background(), claimList, client.InNamespace(namespace)); err != nil {
+		return 0, errors.Wrap(err, "could not list ClusterClaims")
+	}
+	for _, claim := range claimList.Items {
+		if claim.Spec.Namespace != "" {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// NamespaceMachineCount returns the number of machines counted against the machine quota of the namespace, leaving
+// out the MachinePool with the given name.
+func NamespaceMachineCount(c client.Client, namespace, excludedMachinePool string) (int, error) {
+	poolList := &hivev1.MachinePoolList{}
+	if err := c.List(context.Background(), poolList, client.InNamespace(namespace)); err != nil {
+		return 0, errors.Wrap(err, "could not list MachinePools")
+	}
+	count := 0
+	for i, pool := range poolList.Items {
+		if pool.Name == excludedMachinePool || pool.DeletionTimestamp != nil {
+			continue
+		}
+		count += MachinePoolMachineCount(&poolList.Items[i])
+	}
+	return count, nil
+}
+
+// MachinePoolMachineCount returns the number of machines counted against the machine quota for a MachinePool. This
+// is the maximum number of replicas for a MachinePool with autoscaling.
+func MachinePoolMachineCount(pool *hivev1.MachinePool) int {
+	switch {
+	case pool.Spec.Autoscaling != nil:
+		return int(pool.Spec.Autoscaling.MaxReplicas)
+	case pool.Spec.Replicas != nil:
+		return int(*pool.Spec.Replicas)
+	default:
+		return 1
+	}
+}
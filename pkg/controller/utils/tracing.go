@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/hive/pkg/tracing"
+)
+
+// TraceClient returns a client whose requests are made as part of the span of the context when they are made with a
+// context without a span, such as context.TODO(). This traces the requests of the controllers to the hub without
+// passing the context of the reconcile down to every request. The client is returned as is when the context has no
+// sampled span.
+func TraceClient(ctx context.Context, c client.Client) client.Client {
+	if !isTraced(ctx) {
+		return c
+	}
+	return &tracedClient{Client: c, span: trace.SpanFromContext(ctx)}
+}
+
+type tracedClient struct {
+	client.Client
+	span trace.Span
+}
+
+// context returns the context of a request, with the span of the client if the context has no span.
+func (c *tracedClient) context(ctx context.Context) context.Context {
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	return trace.ContextWithSpan(ctx, c.span)
+}
+
+func (c *tracedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	return c.Client.Get(c.context(ctx), key, obj)
+}
+
+func (c *tracedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.Client.List(c.context(ctx), list, opts...)
+}
+
+func (c *tracedClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return c.Client.Create(c.context(ctx), obj, opts...)
+}
+
+func (c *tracedClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return c.Client.Delete(c.context(ctx), obj, opts...)
+}
+
+func (c *tracedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.Client.Update(c.context(ctx), obj, opts...)
+}
+
+func (c *tracedClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.Client.Patch(c.context(ctx), obj, patch, opts...)
+}
+
+func (c *tracedClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	return c.Client.DeleteAllOf(c.context(ctx), obj, opts...)
+}
+
+func (c *tracedClient) Status() client.StatusWriter {
+	return &tracedStatusWriter{StatusWriter: c.Client.Status(), client: c}
+}
+
+type tracedStatusWriter struct {
+	client.StatusWriter
+	client *tracedClient
+}
+
+func (w *tracedStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return w.StatusWriter.Update(w.client.context(ctx), obj, opts...)
+}
+
+func (w *tracedStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return w.StatusWriter.Patch(w.client.context(ctx), obj, patch, opts...)
+}
+
+// endRequestSpan ends the span of an HTTP request with the outcome of the request.
+func endRequestSpan(span trace.Span, resp *http.Response, err error) {
+	switch {
+	case err != nil:
+		span.SetStatus(codes.Error, err.Error())
+	case resp.StatusCode >= http.StatusBadRequest:
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(resp.StatusCode))
+		span.SetStatus(codes.Error, resp.Status)
+	default:
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(resp.StatusCode))
+	}
+	span.End()
+}
+
+// isTraced returns whether the context has a sampled span.
+func isTraced(ctx context.Context) bool {
+	return trace.SpanContextFromContext(ctx).IsSampled()
+}
+
+// StartCloudAPISpan starts the span of a call to a cloud API made with the context, or returns nil when the context
+// has no sampled span.
+func StartCloudAPISpan(ctx context.Context, cloud, service, operation string) (context.Context, trace.Span) {
+	if !isTraced(ctx) {
+		return ctx, nil
+	}
+	return tracing.Tracer().Start(ctx, cloud+" "+service+" "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("cloud", cloud),
+			attribute.String("service", service),
+			attribute.String("operation", operation),
+		),
+	)
+}
+
+// EndCloudAPISpan ends the span of a call to a cloud API, failed with the error code if the code is not empty. A nil
+// span is ignored.
+func EndCloudAPISpan(span trace.Span, code string) {
+	if span == nil {
+		return
+	}
+	if code != "" {
+		span.SetStatus(codes.Error, code)
+	}
+	span.End()
+}
@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	exporttrace "go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/sdk/export/trace/tracetest"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// setupTracing records the spans sampled with the sampler until the end of the test.
+func setupTracing(t *testing.T, sampler sdktrace.Sampler) (trace.Tracer, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSampler(sampler), sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(trace.NewNoopTracerProvider()) })
+	return provider.Tracer("test"), exporter
+}
+
+// attributeValue returns the value of the attribute of the span with the key.
+func attributeValue(sd *exporttrace.SpanSnapshot, key attribute.Key) interface{} {
+	for _, kv := range sd.Attributes {
+		if kv.Key == key {
+			return kv.Value.AsInterface()
+		}
+	}
+	return nil
+}
+
+// contextRecordingClient records the contexts of the requests made with it.
+type contextRecordingClient struct {
+	client.Client
+	contexts []context.Context
+}
+
+func (c *contextRecordingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	c.contexts = append(c.contexts, ctx)
+	return c.Client.Get(ctx, key, obj)
+}
+
+func TestTraceClient(t *testing.T) {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "secret"}}
+	key := client.ObjectKey{Namespace: "ns", Name: "secret"}
+
+	t.Run("not sampled", func(t *testing.T) {
+		tracer, _ := setupTracing(t, sdktrace.NeverSample())
+		c := &contextRecordingClient{Client: fake.NewFakeClient(secret)}
+		ctx, span := tracer.Start(context.Background(), "reconcile")
+		defer span.End()
+		assert.Equal(t, client.Client(c), TraceClient(ctx, c), "expected client not to be wrapped")
+	})
+
+	t.Run("sampled", func(t *testing.T) {
+		tracer, _ := setupTracing(t, sdktrace.AlwaysSample())
+		c := &contextRecordingClient{Client: fake.NewFakeClient(secret)}
+		ctx, span := tracer.Start(context.Background(), "reconcile")
+		defer span.End()
+		tc := TraceClient(ctx, c)
+
+		require.NoError(t, tc.Get(context.TODO(), key, &corev1.Secret{}))
+		otherCtx, otherSpan := tracer.Start(context.Background(), "other")
+		defer otherSpan.End()
+		require.NoError(t, tc.Get(otherCtx, key, &corev1.Secret{}))
+
+		require.Len(t, c.contexts, 2, "expected two requests")
+		assert.Equal(t, span, trace.SpanFromContext(c.contexts[0]), "expected request without span to use the span of the client")
+		assert.Equal(t, otherSpan, trace.SpanFromContext(c.contexts[1]), "expected request with span to keep its span")
+	})
+}
+
+func TestControllerMetricsTripperSpans(t *testing.T) {
+	tracer, exporter := setupTracing(t, sdktrace.AlwaysSample())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			w.WriteHeader(http.StatusConflict)
+		}
+	}))
+	defer server.Close()
+	tripper := &ControllerMetricsTripper{RoundTripper: http.DefaultTransport, Controller: "test", Remote: true}
+
+	do := func(ctx context.Context, method string) {
+		req, err := http.NewRequest(method, server.URL+"/apis/hive.openshift.io/v1/namespaces/ns/clusterdeployments/cd", nil)
+		require.NoError(t, err)
+		resp, err := tripper.RoundTrip(req.WithContext(ctx))
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	// Requests outside of a traced reconcile are not traced.
+	do(context.Background(), http.MethodGet)
+
+	ctx, span := tracer.Start(context.Background(), "reconcile")
+	do(ctx, http.MethodGet)
+	do(ctx, http.MethodPut)
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3, "expected spans for the traced requests and the reconcile")
+	get, put := spans[0], spans[1]
+	for _, sd := range []*exporttrace.SpanSnapshot{get, put} {
+		assert.Equal(t, span.SpanContext().TraceID(), sd.SpanContext.TraceID(), "expected request to be part of the trace of the reconcile")
+		assert.Equal(t, span.SpanContext().SpanID(), sd.ParentSpanID, "expected request to be a child of the reconcile")
+		assert.Equal(t, trace.SpanKindClient, sd.SpanKind, "unexpected span kind")
+		assert.Equal(t, true, attributeValue(sd, "remote"), "unexpected remote attribute")
+	}
+	assert.Equal(t, "GET hive.openshift.io/v1/clusterdeployments", get.Name, "unexpected span name")
+	assert.Equal(t, int64(http.StatusOK), attributeValue(get, "http.status_code"), "unexpected status code")
+	assert.Equal(t, codes.Unset, get.StatusCode, "unexpected span status")
+	assert.Equal(t, "PUT hive.openshift.io/v1/clusterdeployments", put.Name, "unexpected span name")
+	assert.Equal(t, int64(http.StatusConflict), attributeValue(put, "http.status_code"), "unexpected status code")
+	assert.Equal(t, codes.Error, put.StatusCode, "unexpected span status")
+	assert.Equal(t, "409 Conflict", put.StatusMessage, "unexpected span status message")
+}
+
+func TestCloudAPIMetricsTripperSpans(t *testing.T) {
+	tracer, exporter := setupTracing(t, sdktrace.AlwaysSample())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+	tripper := &CloudAPIMetricsTripper{RoundTripper: http.DefaultTransport, Controller: "test", Cloud: CloudGCP}
+
+	ctx, span := tracer.Start(context.Background(), "reconcile")
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/dns/v1/projects/project/managedZones/zone", nil)
+	require.NoError(t, err)
+	resp, err := tripper.RoundTrip(req.WithContext(ctx))
+	require.NoError(t, err)
+	resp.Body.Close()
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2, "expected spans for the cloud API call and the reconcile")
+	call := spans[0]
+	assert.Equal(t, span.SpanContext().SpanID(), call.ParentSpanID, "expected call to be a child of the reconcile")
+	assert.Equal(t, trace.SpanKindClient, call.SpanKind, "unexpected span kind")
+	assert.Equal(t, "gcp 127 GET projects.managedZones", call.Name, "unexpected span name")
+	assert.Equal(t, "GET projects.managedZones", attributeValue(call, "operation"), "unexpected operation attribute")
+	assert.Equal(t, "403", call.StatusMessage, "unexpected span status")
+}
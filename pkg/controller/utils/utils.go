@@ -46,6 +46,10 @@ const (
 	// that stores concurrent reconciles for a controller
 	ConcurrentReconcilesEnvVariableFormat = "%s-concurrent-reconciles"
 
+	// ConcurrentReconcilesPerCredentialsEnvVariableFormat is the format of the environment variable that stores the
+	// cap of concurrent reconciles of objects using the same credentials secret for a controller
+	ConcurrentReconcilesPerCredentialsEnvVariableFormat = "%s-concurrent-reconciles-per-credentials"
+
 	// ClientQPSEnvVariableFormat is the format of the environment variable that stores
 	// client QPS for a controller
 	ClientQPSEnvVariableFormat = "%s-client-qps"
@@ -101,6 +105,15 @@ func getConcurrentReconciles(controllerName hivev1.ControllerName) (int, error)
 	return defaultConcurrentReconciles, nil
 }
 
+// GetConcurrentReconcilesPerCredentials returns the cap of concurrent reconciles of objects using the same
+// credentials secret for the controller. Zero, the default, means no cap.
+func GetConcurrentReconcilesPerCredentials(controllerName hivev1.ControllerName) (int, error) {
+	if value, ok := getValueFromEnvVariable(controllerName, ConcurrentReconcilesPerCredentialsEnvVariableFormat); ok {
+		return strconv.Atoi(value)
+	}
+	return 0, nil
+}
+
 // getClientRateLimiter returns the client rate limiter for the controller
 func getClientRateLimiter(controllerName hivev1.ControllerName) (flowcontrol.RateLimiter, error) {
 	qps := rest.DefaultQPS
@@ -344,8 +357,7 @@ func CopySecret(c client.Client, src, dest types.NamespacedName, owner metav1.Ob
 
 // BuildControllerLogger returns a logger for controllers with consistent fields.
 func BuildControllerLogger(controller hivev1.ControllerName, resource string, nsName types.NamespacedName) *log.Entry {
-	return log.WithFields(log.Fields{
-		"controller":  controller,
+	return ControllerLogEntry(controller).WithFields(log.Fields{
 		resource:      nsName.String(),
 		"reconcileID": utilrand.String(constants.ReconcileIDLen),
 	})
@@ -188,6 +188,51 @@ func TestGetConcurrentReconciles(t *testing.T) {
 	}
 }
 
+func TestGetConcurrentReconcilesPerCredentials(t *testing.T) {
+	cases := []struct {
+		name                 string
+		environmentVariables map[string]string
+		expectedReconciles   int
+		expectedError        bool
+	}{
+		{
+			name: "controller cap is set",
+			environmentVariables: map[string]string{
+				fmt.Sprintf(ConcurrentReconcilesPerCredentialsEnvVariableFormat, testControllerName): "3",
+			},
+			expectedReconciles: 3,
+		},
+		{
+			name:                 "cap is not set",
+			environmentVariables: map[string]string{},
+			expectedReconciles:   0,
+		},
+		{
+			name: "cap is set incorrectly",
+			environmentVariables: map[string]string{
+				fmt.Sprintf(ConcurrentReconcilesPerCredentialsEnvVariableFormat, testControllerName): "not-a-int",
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.environmentVariables {
+				os.Setenv(k, v)
+				defer os.Unsetenv(k)
+			}
+
+			reconciles, err := GetConcurrentReconcilesPerCredentials(testControllerName)
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedReconciles, reconciles, "unexpected concurrent reconciles per credentials")
+			}
+		})
+	}
+}
+
 func TestGetClientRateLimiter(t *testing.T) {
 	cases := []struct {
 		name                 string
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// ReadWorkloadSchedulingFile reads the scheduling configuration of the pods created by Hive from the file pointed to
+// by the HIVE_WORKLOAD_SCHEDULING_FILE environment variable. No configuration is returned if the environment variable
+// is not set or the file does not exist.
+func ReadWorkloadSchedulingFile() (*hivev1.WorkloadScheduling, error) {
+	path := os.Getenv(constants.WorkloadSchedulingFileEnvVar)
+	if path == "" {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the workload scheduling file")
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	scheduling := &hivev1.WorkloadScheduling{}
+	if err := json.Unmarshal(data, scheduling); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the workload scheduling file")
+	}
+	return scheduling, nil
+}
+
+// ApplyWorkloadScheduling applies the scheduling configuration to the pod spec. Node selector keys, the affinity and
+// the priority class already set in the pod spec are kept, and tolerations are only added if not already present.
+func ApplyWorkloadScheduling(podSpec *corev1.PodSpec, scheduling *hivev1.WorkloadScheduling) {
+	if scheduling == nil {
+		return
+	}
+	for k, v := range scheduling.NodeSelector {
+		if podSpec.NodeSelector == nil {
+			podSpec.NodeSelector = map[string]string{}
+		}
+		if _, ok := podSpec.NodeSelector[k]; !ok {
+			podSpec.NodeSelector[k] = v
+		}
+	}
+	for _, toleration := range scheduling.Tolerations {
+		found := false
+		for _, existing := range podSpec.Tolerations {
+			if apiequality.Semantic.DeepEqual(existing, toleration) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			podSpec.Tolerations = append(podSpec.Tolerations, toleration)
+		}
+	}
+	if podSpec.Affinity == nil && scheduling.Affinity != nil {
+		podSpec.Affinity = scheduling.Affinity.DeepCopy()
+	}
+	if podSpec.PriorityClassName == "" {
+		podSpec.PriorityClassName = scheduling.PriorityClassName
+	}
+}
+
+// ApplyWorkloadSchedulingFromFile applies the scheduling configuration read with ReadWorkloadSchedulingFile to the
+// pod spec.
+func ApplyWorkloadSchedulingFromFile(podSpec *corev1.PodSpec) error {
+	scheduling, err := ReadWorkloadSchedulingFile()
+	if err != nil {
+		return err
+	}
+	ApplyWorkloadScheduling(podSpec, scheduling)
+	return nil
+}
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestApplyWorkloadScheduling(t *testing.T) {
+	infraToleration := corev1.Toleration{
+		Key:      "node-role.kubernetes.io/infra",
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+	otherToleration := corev1.Toleration{
+		Key:      "other",
+		Operator: corev1.TolerationOpExists,
+	}
+	affinity := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{
+						Key:      "zone",
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   []string{"a"},
+					}},
+				}},
+			},
+		},
+	}
+	podAffinity := &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{}}
+	scheduling := &hivev1.WorkloadScheduling{
+		NodeSelector:      map[string]string{"node-role.kubernetes.io/infra": "", "disk": "ssd"},
+		Tolerations:       []corev1.Toleration{infraToleration},
+		Affinity:          affinity,
+		PriorityClassName: "hive-workloads",
+	}
+	cases := []struct {
+		name       string
+		podSpec    corev1.PodSpec
+		scheduling *hivev1.WorkloadScheduling
+		expected   corev1.PodSpec
+	}{
+		{
+			name:     "no scheduling",
+			podSpec:  corev1.PodSpec{NodeSelector: map[string]string{"disk": "hdd"}},
+			expected: corev1.PodSpec{NodeSelector: map[string]string{"disk": "hdd"}},
+		},
+		{
+			name:       "empty pod spec",
+			scheduling: scheduling,
+			expected: corev1.PodSpec{
+				NodeSelector:      map[string]string{"node-role.kubernetes.io/infra": "", "disk": "ssd"},
+				Tolerations:       []corev1.Toleration{infraToleration},
+				Affinity:          affinity,
+				PriorityClassName: "hive-workloads",
+			},
+		},
+		{
+			name: "pod spec settings kept",
+			podSpec: corev1.PodSpec{
+				NodeSelector:      map[string]string{"disk": "hdd"},
+				Tolerations:       []corev1.Toleration{otherToleration, infraToleration},
+				Affinity:          podAffinity,
+				PriorityClassName: "pod-priority",
+			},
+			scheduling: scheduling,
+			expected: corev1.PodSpec{
+				NodeSelector:      map[string]string{"node-role.kubernetes.io/infra": "", "disk": "hdd"},
+				Tolerations:       []corev1.Toleration{otherToleration, infraToleration},
+				Affinity:          podAffinity,
+				PriorityClassName: "pod-priority",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			podSpec := tc.podSpec.DeepCopy()
+			ApplyWorkloadScheduling(podSpec, tc.scheduling)
+			assert.Equal(t, tc.expected, *podSpec, "unexpected pod spec")
+		})
+	}
+}
+
+func TestReadWorkloadSchedulingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "workloadscheduling")
+	require.NoError(t, err, "unexpected error creating temp dir")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "workload-scheduling")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"nodeSelector":{"node-role.kubernetes.io/infra":""},"priorityClassName":"hive-workloads"}`), 0600))
+
+	os.Setenv(constants.WorkloadSchedulingFileEnvVar, path)
+	defer os.Unsetenv(constants.WorkloadSchedulingFileEnvVar)
+	scheduling, err := ReadWorkloadSchedulingFile()
+	require.NoError(t, err, "unexpected error reading workload scheduling file")
+	if assert.NotNil(t, scheduling, "expected workload scheduling") {
+		assert.Equal(t, map[string]string{"node-role.kubernetes.io/infra": ""}, scheduling.NodeSelector, "unexpected node selector")
+		assert.Equal(t, "hive-workloads", scheduling.PriorityClassName, "unexpected priority class")
+	}
+
+	os.Setenv(constants.WorkloadSchedulingFileEnvVar, filepath.Join(dir, "missing"))
+	scheduling, err = ReadWorkloadSchedulingFile()
+	require.NoError(t, err, "unexpected error reading missing workload scheduling file")
+	assert.Nil(t, scheduling, "expected no workload scheduling")
+}
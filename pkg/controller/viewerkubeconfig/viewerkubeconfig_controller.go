@@ -0,0 +1,445 @@
+package viewerkubeconfig
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	clientcmdlatest "k8s.io/client-go/tools/clientcmd/api/latest"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sigs.k8s.io/yaml"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	ControllerName = hivev1.ViewerKubeconfigControllerName
+
+	// viewerNamespace is the namespace on the cluster holding the ServiceAccount of the viewer.
+	viewerNamespace = "hive-viewer"
+	// viewerName is the name of the ServiceAccount, ClusterRole and ClusterRoleBinding of the viewer on the cluster.
+	viewerName = "hive-viewer"
+	// viewerTokenSecretName is the name of the secret holding the token of the viewer ServiceAccount on the cluster.
+	viewerTokenSecretName = "hive-viewer-token"
+
+	defaultViewerClusterRoleName = "cluster-reader"
+
+	// tokenRequeueInterval is the interval at which to check whether the token of the viewer ServiceAccount has been
+	// populated.
+	tokenRequeueInterval = 10 * time.Second
+)
+
+// Add creates a new ViewerKubeconfig Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := controllerutils.ControllerLogEntry(ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) reconcile.Reconciler {
+	r := &ReconcileViewerKubeconfig{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme: mgr.GetScheme(),
+	}
+	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	// Create a new controller
+	c, err := controllerutils.NewController("viewerkubeconfig-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to the viewer kubeconfig secrets
+	if err := c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &hivev1.ClusterDeployment{},
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileViewerKubeconfig{}
+
+// ReconcileViewerKubeconfig reconciles the viewer kubeconfig of a ClusterDeployment
+type ReconcileViewerKubeconfig struct {
+	client.Client
+	scheme *runtime.Scheme
+
+	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server
+	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+}
+
+// Reconcile provisions a ServiceAccount with restricted permissions on the cluster of a ClusterDeployment and stores
+// a kubeconfig for the ServiceAccount in a secret in the namespace of the ClusterDeployment.
+func (r *ReconcileViewerKubeconfig) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	// Fetch the ClusterDeployment instance
+	cd := &hivev1.ClusterDeployment{}
+	err := r.Get(context.TODO(), request.NamespacedName, cd)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Object not found, return. The viewer kubeconfig secret is garbage collected.
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	if controllerutils.IsClusterDeploymentPaused(cd, cdLog) {
+		return reconcile.Result{}, nil
+	}
+
+	// If the clusterdeployment is deleted, do not reconcile.
+	if cd.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	// If the cluster is not installed, do not reconcile.
+	if !cd.Spec.Installed {
+		cdLog.Debug("cluster installation is not complete")
+		return reconcile.Result{}, nil
+	}
+
+	if cd.Spec.ClusterMetadata == nil {
+		cdLog.Error("installed cluster with no cluster metadata")
+		return reconcile.Result{}, nil
+	}
+
+	if cd.Spec.ViewerKubeconfig == nil && cd.Spec.ClusterMetadata.ViewerKubeconfigSecretRef == nil {
+		cdLog.Debug("viewer kubeconfig not configured")
+		return reconcile.Result{}, nil
+	}
+
+	if unreachable, _ := remoteclient.Unreachable(cd); unreachable {
+		cdLog.Debug("cluster is unreachable, viewer kubeconfig will be reconciled once it is reachable")
+		return reconcile.Result{}, nil
+	}
+
+	kubeClient, err := r.remoteClusterAPIClientBuilder(cd).BuildKubeClient()
+	if err != nil {
+		cdLog.WithError(err).Error("error building client for the cluster")
+		return reconcile.Result{}, err
+	}
+
+	if cd.Spec.ViewerKubeconfig == nil {
+		return reconcile.Result{}, r.removeViewer(cd, kubeClient, cdLog)
+	}
+
+	token, err := ensureRemoteViewer(kubeClient, cd.Spec.ViewerKubeconfig, cdLog)
+	if err != nil {
+		cdLog.WithError(err).Error("error provisioning the viewer on the cluster")
+		return reconcile.Result{}, err
+	}
+	if token == nil {
+		cdLog.Debug("waiting for the token of the viewer service account to be populated")
+		return reconcile.Result{RequeueAfter: tokenRequeueInterval}, nil
+	}
+
+	if err := r.ensureViewerKubeconfigSecret(cd, token, cdLog); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	cdLog.Debug("reconcile complete")
+	return reconcile.Result{}, nil
+}
+
+// ensureRemoteViewer creates or updates the ServiceAccount of the viewer on the cluster along with its RBAC. It
+// returns the token secret of the ServiceAccount, or nil when the token has not been populated yet.
+func ensureRemoteViewer(kubeClient kubeclient.Interface, config *hivev1.ViewerKubeconfig, cdLog log.FieldLogger) (*corev1.Secret, error) {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: viewerNamespace}}
+	if _, err := kubeClient.CoreV1().Namespaces().Create(context.TODO(), namespace, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, errors.Wrap(err, "could not create viewer namespace")
+	}
+
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: viewerNamespace, Name: viewerName}}
+	if _, err := kubeClient.CoreV1().ServiceAccounts(viewerNamespace).Create(context.TODO(), sa, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, errors.Wrap(err, "could not create viewer service account")
+	}
+
+	clusterRoleName := config.ClusterRoleName
+	if clusterRoleName == "" {
+		clusterRoleName = defaultViewerClusterRoleName
+	}
+	if len(config.Rules) > 0 {
+		clusterRoleName = viewerName
+		if err := ensureClusterRole(kubeClient, config.Rules, cdLog); err != nil {
+			return nil, err
+		}
+	} else {
+		// The rules are no longer managed by Hive, so remove the ClusterRole managed for the viewer if any.
+		if err := kubeClient.RbacV1().ClusterRoles().Delete(context.TODO(), viewerName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return nil, errors.Wrap(err, "could not delete viewer cluster role")
+		}
+	}
+	if err := ensureClusterRoleBinding(kubeClient, clusterRoleName, cdLog); err != nil {
+		return nil, err
+	}
+
+	tokenSecret, err := kubeClient.CoreV1().Secrets(viewerNamespace).Get(context.TODO(), viewerTokenSecretName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		cdLog.Info("creating viewer service account token secret")
+		tokenSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   viewerNamespace,
+				Name:        viewerTokenSecretName,
+				Annotations: map[string]string{corev1.ServiceAccountNameKey: viewerName},
+			},
+			Type: corev1.SecretTypeServiceAccountToken,
+		}
+		if _, err := kubeClient.CoreV1().Secrets(viewerNamespace).Create(context.TODO(), tokenSecret, metav1.CreateOptions{}); err != nil {
+			return nil, errors.Wrap(err, "could not create viewer token secret")
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get viewer token secret")
+	}
+	if len(tokenSecret.Data[corev1.ServiceAccountTokenKey]) == 0 {
+		return nil, nil
+	}
+	return tokenSecret, nil
+}
+
+func ensureClusterRole(kubeClient kubeclient.Interface, rules []rbacv1.PolicyRule, cdLog log.FieldLogger) error {
+	clusterRoles := kubeClient.RbacV1().ClusterRoles()
+	existing, err := clusterRoles.Get(context.TODO(), viewerName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		cdLog.Info("creating viewer cluster role")
+		clusterRole := &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: viewerName},
+			Rules:      rules,
+		}
+		if _, err := clusterRoles.Create(context.TODO(), clusterRole, metav1.CreateOptions{}); err != nil {
+			return errors.Wrap(err, "could not create viewer cluster role")
+		}
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not get viewer cluster role")
+	}
+	if reflect.DeepEqual(existing.Rules, rules) {
+		return nil
+	}
+	cdLog.Info("updating viewer cluster role")
+	existing.Rules = rules
+	if _, err := clusterRoles.Update(context.TODO(), existing, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrap(err, "could not update viewer cluster role")
+	}
+	return nil
+}
+
+func ensureClusterRoleBinding(kubeClient kubeclient.Interface, clusterRoleName string, cdLog log.FieldLogger) error {
+	clusterRoleBindings := kubeClient.RbacV1().ClusterRoleBindings()
+	binding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: viewerName},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Namespace: viewerNamespace,
+			Name:      viewerName,
+		}},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     clusterRoleName,
+		},
+	}
+	existing, err := clusterRoleBindings.Get(context.TODO(), viewerName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return errors.Wrap(err, "could not get viewer cluster role binding")
+	case reflect.DeepEqual(existing.Subjects, binding.Subjects) && existing.RoleRef == binding.RoleRef:
+		return nil
+	default:
+		// The role of a binding cannot be changed, so the binding is replaced.
+		cdLog.Info("replacing viewer cluster role binding")
+		if err := clusterRoleBindings.Delete(context.TODO(), viewerName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrap(err, "could not delete viewer cluster role binding")
+		}
+	}
+	if _, err := clusterRoleBindings.Create(context.TODO(), binding, metav1.CreateOptions{}); err != nil {
+		return errors.Wrap(err, "could not create viewer cluster role binding")
+	}
+	return nil
+}
+
+// ensureViewerKubeconfigSecret writes the viewer kubeconfig to a secret in the namespace of the ClusterDeployment and
+// references it from the ClusterDeployment.
+func (r *ReconcileViewerKubeconfig) ensureViewerKubeconfigSecret(cd *hivev1.ClusterDeployment, tokenSecret *corev1.Secret, cdLog log.FieldLogger) error {
+	adminKubeconfigSecret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name}, adminKubeconfigSecret); err != nil {
+		cdLog.WithError(err).Error("error getting admin kubeconfig secret")
+		return err
+	}
+	kubeconfig, err := viewerKubeconfig(adminKubeconfigSecret.Data[constants.KubeconfigSecretKey], tokenSecret.Data[corev1.ServiceAccountTokenKey])
+	if err != nil {
+		cdLog.WithError(err).Error("error generating viewer kubeconfig")
+		return err
+	}
+
+	secretName := constants.GetViewerKubeconfigSecretName(cd)
+	secret := &corev1.Secret{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: secretName}, secret); {
+	case apierrors.IsNotFound(err):
+		cdLog.Info("creating viewer kubeconfig secret")
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cd.Namespace,
+				Name:      secretName,
+				Labels: map[string]string{
+					constants.ClusterDeploymentNameLabel: cd.Name,
+					constants.SecretTypeLabel:            constants.SecretTypeViewerKubeConfig,
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{constants.KubeconfigSecretKey: kubeconfig},
+		}
+		if err := controllerutil.SetControllerReference(cd, secret, r.scheme); err != nil {
+			cdLog.WithError(err).Error("error setting controller reference on viewer kubeconfig secret")
+			return err
+		}
+		if err := r.Create(context.TODO(), secret); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error creating viewer kubeconfig secret")
+			return err
+		}
+	case err != nil:
+		cdLog.WithError(err).Error("error getting viewer kubeconfig secret")
+		return err
+	case string(secret.Data[constants.KubeconfigSecretKey]) != string(kubeconfig):
+		cdLog.Info("updating viewer kubeconfig secret")
+		secret.Data = map[string][]byte{constants.KubeconfigSecretKey: kubeconfig}
+		if err := r.Update(context.TODO(), secret); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating viewer kubeconfig secret")
+			return err
+		}
+	}
+
+	if ref := cd.Spec.ClusterMetadata.ViewerKubeconfigSecretRef; ref != nil && ref.Name == secretName {
+		return nil
+	}
+	cd.Spec.ClusterMetadata.ViewerKubeconfigSecretRef = &corev1.LocalObjectReference{Name: secretName}
+	if err := r.Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error setting viewer kubeconfig secret reference")
+		return err
+	}
+	return nil
+}
+
+// removeViewer removes the viewer from the cluster and deletes the viewer kubeconfig secret.
+func (r *ReconcileViewerKubeconfig) removeViewer(cd *hivev1.ClusterDeployment, kubeClient kubeclient.Interface, cdLog log.FieldLogger) error {
+	cdLog.Info("removing viewer from the cluster")
+	// Deleting the namespace deletes the service account along with its token.
+	if err := kubeClient.CoreV1().Namespaces().Delete(context.TODO(), viewerNamespace, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		cdLog.WithError(err).Error("error deleting viewer namespace")
+		return err
+	}
+	if err := kubeClient.RbacV1().ClusterRoleBindings().Delete(context.TODO(), viewerName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		cdLog.WithError(err).Error("error deleting viewer cluster role binding")
+		return err
+	}
+	if err := kubeClient.RbacV1().ClusterRoles().Delete(context.TODO(), viewerName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		cdLog.WithError(err).Error("error deleting viewer cluster role")
+		return err
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: cd.Namespace, Name: cd.Spec.ClusterMetadata.ViewerKubeconfigSecretRef.Name}}
+	if err := r.Delete(context.TODO(), secret); err != nil && !apierrors.IsNotFound(err) {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error deleting viewer kubeconfig secret")
+		return err
+	}
+
+	cd.Spec.ClusterMetadata.ViewerKubeconfigSecretRef = nil
+	if err := r.Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error clearing viewer kubeconfig secret reference")
+		return err
+	}
+	return nil
+}
+
+// viewerKubeconfig generates a kubeconfig that connects to the cluster of the admin kubeconfig using the token.
+func viewerKubeconfig(adminKubeconfig, token []byte) ([]byte, error) {
+	adminConfig, err := clientcmd.Load(adminKubeconfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load admin kubeconfig")
+	}
+	adminContext, ok := adminConfig.Contexts[adminConfig.CurrentContext]
+	if !ok {
+		return nil, errors.Errorf("admin kubeconfig does not have the current context %q", adminConfig.CurrentContext)
+	}
+	cluster, ok := adminConfig.Clusters[adminContext.Cluster]
+	if !ok {
+		return nil, errors.Errorf("admin kubeconfig does not have the cluster %q", adminContext.Cluster)
+	}
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters[adminContext.Cluster] = cluster
+	config.AuthInfos[viewerName] = &clientcmdapi.AuthInfo{Token: string(token)}
+	config.Contexts[viewerName] = &clientcmdapi.Context{
+		Cluster:   adminContext.Cluster,
+		AuthInfo:  viewerName,
+		Namespace: adminContext.Namespace,
+	}
+	config.CurrentContext = viewerName
+
+	versionedConfig := &clientcmdv1.Config{}
+	if err := clientcmdlatest.Scheme.Convert(config, versionedConfig, nil); err != nil {
+		return nil, errors.Wrap(err, "could not convert viewer kubeconfig")
+	}
+	versionedConfig.APIVersion = clientcmdlatest.Version
+	versionedConfig.Kind = "Config"
+	return yaml.Marshal(versionedConfig)
+}
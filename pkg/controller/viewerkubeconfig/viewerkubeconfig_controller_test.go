@@ -0,0 +1,308 @@
+package viewerkubeconfig
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakekubeclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+)
+
+const (
+	testName            = "foo"
+	testNamespace       = "default"
+	adminKubeconfigName = "foo-admin-kubeconfig"
+	viewerSecretName    = "foo-viewer-kubeconfig"
+	testToken           = "viewer-token"
+	testServer          = "https://api.foo.example.com:6443"
+)
+
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestViewerKubeconfigReconcile(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	viewerRules := []rbacv1.PolicyRule{{
+		APIGroups: []string{""},
+		Resources: []string{"pods"},
+		Verbs:     []string{"get", "list"},
+	}}
+	withViewer := func(config *hivev1.ViewerKubeconfig) func(*hivev1.ClusterDeployment) {
+		return func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.ViewerKubeconfig = config
+		}
+	}
+	withViewerSecretRef := func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.ClusterMetadata.ViewerKubeconfigSecretRef = &corev1.LocalObjectReference{Name: viewerSecretName}
+	}
+	notInstalled := func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.Installed = false
+	}
+	unreachable := func(cd *hivev1.ClusterDeployment) {
+		cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{{
+			Type:   hivev1.UnreachableCondition,
+			Status: corev1.ConditionTrue,
+		}}
+	}
+	tokenSecret := func(token string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: viewerNamespace, Name: viewerTokenSecretName},
+			Type:       corev1.SecretTypeServiceAccountToken,
+			Data:       map[string][]byte{corev1.ServiceAccountTokenKey: []byte(token)},
+		}
+	}
+	clusterRoleBinding := func(roleName string) *rbacv1.ClusterRoleBinding {
+		return &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: viewerName},
+			Subjects: []rbacv1.Subject{{
+				Kind:      rbacv1.ServiceAccountKind,
+				Namespace: viewerNamespace,
+				Name:      viewerName,
+			}},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     roleName,
+			},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		cd               *hivev1.ClusterDeployment
+		existing         []runtime.Object
+		remote           []runtime.Object
+		noRemoteCall     bool
+		expectRequeue    bool
+		expectedRoleName string
+		expectedRules    []rbacv1.PolicyRule
+		expectViewer     bool
+		expectRemoved    bool
+	}{
+		{
+			name:         "not installed",
+			cd:           testClusterDeployment(notInstalled, withViewer(&hivev1.ViewerKubeconfig{})),
+			noRemoteCall: true,
+		},
+		{
+			name:         "not configured",
+			cd:           testClusterDeployment(),
+			noRemoteCall: true,
+		},
+		{
+			name:         "unreachable",
+			cd:           testClusterDeployment(unreachable, withViewer(&hivev1.ViewerKubeconfig{})),
+			noRemoteCall: true,
+		},
+		{
+			name:             "waiting for token",
+			cd:               testClusterDeployment(withViewer(&hivev1.ViewerKubeconfig{})),
+			expectRequeue:    true,
+			expectedRoleName: defaultViewerClusterRoleName,
+		},
+		{
+			name:             "default cluster role",
+			cd:               testClusterDeployment(withViewer(&hivev1.ViewerKubeconfig{})),
+			remote:           []runtime.Object{tokenSecret(testToken)},
+			expectedRoleName: defaultViewerClusterRoleName,
+			expectViewer:     true,
+		},
+		{
+			name:             "configured cluster role",
+			cd:               testClusterDeployment(withViewer(&hivev1.ViewerKubeconfig{ClusterRoleName: "view"})),
+			remote:           []runtime.Object{tokenSecret(testToken), clusterRoleBinding(defaultViewerClusterRoleName)},
+			expectedRoleName: "view",
+			expectViewer:     true,
+		},
+		{
+			name:             "rules",
+			cd:               testClusterDeployment(withViewer(&hivev1.ViewerKubeconfig{Rules: viewerRules})),
+			remote:           []runtime.Object{tokenSecret(testToken)},
+			expectedRoleName: viewerName,
+			expectedRules:    viewerRules,
+			expectViewer:     true,
+		},
+		{
+			name: "update outdated kubeconfig",
+			cd:   testClusterDeployment(withViewer(&hivev1.ViewerKubeconfig{}), withViewerSecretRef),
+			existing: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: viewerSecretName},
+					Data:       map[string][]byte{constants.KubeconfigSecretKey: []byte("outdated")},
+				},
+			},
+			remote:           []runtime.Object{tokenSecret(testToken)},
+			expectedRoleName: defaultViewerClusterRoleName,
+			expectViewer:     true,
+		},
+		{
+			name: "remove viewer",
+			cd:   testClusterDeployment(withViewerSecretRef),
+			existing: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: viewerSecretName},
+					Data:       map[string][]byte{constants.KubeconfigSecretKey: []byte("viewer")},
+				},
+			},
+			remote: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: viewerNamespace}},
+				clusterRoleBinding(viewerName),
+				&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: viewerName}},
+			},
+			expectRemoved: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			existing := append([]runtime.Object{test.cd, testAdminKubeconfigSecret(t)}, test.existing...)
+			fakeClient := fake.NewFakeClient(existing...)
+			remoteKubeClient := fakekubeclient.NewSimpleClientset(test.remote...)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			if !test.noRemoteCall {
+				mockRemoteClientBuilder.EXPECT().BuildKubeClient().Return(remoteKubeClient, nil)
+			}
+			r := &ReconcileViewerKubeconfig{
+				Client:                        fakeClient,
+				scheme:                        scheme.Scheme,
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+			}
+
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName},
+			})
+			require.NoError(t, err, "unexpected error from reconcile")
+			if test.expectRequeue {
+				assert.Equal(t, tokenRequeueInterval, result.RequeueAfter, "expected requeue to wait for the token")
+			} else {
+				assert.Zero(t, result.RequeueAfter, "unexpected requeue")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, cd))
+
+			if test.expectedRoleName != "" {
+				binding, err := remoteKubeClient.RbacV1().ClusterRoleBindings().Get(context.TODO(), viewerName, metav1.GetOptions{})
+				require.NoError(t, err, "unexpected error getting viewer cluster role binding")
+				assert.Equal(t, test.expectedRoleName, binding.RoleRef.Name, "unexpected viewer role")
+				_, err = remoteKubeClient.CoreV1().ServiceAccounts(viewerNamespace).Get(context.TODO(), viewerName, metav1.GetOptions{})
+				assert.NoError(t, err, "unexpected error getting viewer service account")
+				_, err = remoteKubeClient.CoreV1().Secrets(viewerNamespace).Get(context.TODO(), viewerTokenSecretName, metav1.GetOptions{})
+				assert.NoError(t, err, "unexpected error getting viewer token secret")
+			}
+
+			clusterRole, err := remoteKubeClient.RbacV1().ClusterRoles().Get(context.TODO(), viewerName, metav1.GetOptions{})
+			if test.expectedRules != nil {
+				require.NoError(t, err, "unexpected error getting viewer cluster role")
+				assert.Equal(t, test.expectedRules, clusterRole.Rules, "unexpected viewer rules")
+			} else if !test.noRemoteCall {
+				assert.True(t, apierrors.IsNotFound(err), "expected no viewer cluster role")
+			}
+
+			secret := &corev1.Secret{}
+			err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: viewerSecretName}, secret)
+			if test.expectViewer {
+				require.NoError(t, err, "unexpected error getting viewer kubeconfig secret")
+				config, err := clientcmd.Load(secret.Data[constants.KubeconfigSecretKey])
+				require.NoError(t, err, "unexpected error loading viewer kubeconfig")
+				viewerContext := config.Contexts[config.CurrentContext]
+				require.NotNil(t, viewerContext, "missing current context in viewer kubeconfig")
+				assert.Equal(t, testToken, config.AuthInfos[viewerContext.AuthInfo].Token, "unexpected viewer token")
+				assert.Equal(t, testServer, config.Clusters[viewerContext.Cluster].Server, "unexpected viewer server")
+				if assert.NotNil(t, cd.Spec.ClusterMetadata.ViewerKubeconfigSecretRef, "missing viewer kubeconfig secret reference") {
+					assert.Equal(t, viewerSecretName, cd.Spec.ClusterMetadata.ViewerKubeconfigSecretRef.Name, "unexpected viewer kubeconfig secret reference")
+				}
+			} else if test.expectRemoved {
+				assert.True(t, apierrors.IsNotFound(err), "expected viewer kubeconfig secret to be deleted")
+				assert.Nil(t, cd.Spec.ClusterMetadata.ViewerKubeconfigSecretRef, "expected viewer kubeconfig secret reference to be cleared")
+				_, err = remoteKubeClient.CoreV1().Namespaces().Get(context.TODO(), viewerNamespace, metav1.GetOptions{})
+				assert.True(t, apierrors.IsNotFound(err), "expected viewer namespace to be deleted")
+				_, err = remoteKubeClient.RbacV1().ClusterRoleBindings().Get(context.TODO(), viewerName, metav1.GetOptions{})
+				assert.True(t, apierrors.IsNotFound(err), "expected viewer cluster role binding to be deleted")
+			} else {
+				assert.True(t, apierrors.IsNotFound(err), "expected no viewer kubeconfig secret")
+			}
+		})
+	}
+}
+
+func testClusterDeployment(opts ...func(*hivev1.ClusterDeployment)) *hivev1.ClusterDeployment {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+			UID:       types.UID("1234"),
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: testName,
+			Installed:   true,
+			ClusterMetadata: &hivev1.ClusterMetadata{
+				ClusterID:                "cluster-id",
+				InfraID:                  "infra-id",
+				AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: adminKubeconfigName},
+			},
+		},
+		Status: hivev1.ClusterDeploymentStatus{
+			Conditions: []hivev1.ClusterDeploymentCondition{{
+				Type:               hivev1.UnreachableCondition,
+				Status:             corev1.ConditionFalse,
+				LastProbeTime:      metav1.NewTime(time.Now()),
+				LastTransitionTime: metav1.NewTime(time.Now()),
+			}},
+		},
+	}
+	for _, o := range opts {
+		o(cd)
+	}
+	return cd
+}
+
+func testAdminKubeconfigSecret(t *testing.T) *corev1.Secret {
+	kubeconfig := []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: cluster
+  cluster:
+    server: ` + testServer + `
+users:
+- name: admin
+  user:
+    token: admin-token
+contexts:
+- name: admin
+  context:
+    cluster: cluster
+    user: admin
+current-context: admin
+`)
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: adminKubeconfigName},
+		Data:       map[string][]byte{constants.KubeconfigSecretKey: kubeconfig},
+	}
+}
@@ -0,0 +1,154 @@
+package conversionwebhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev2 "github.com/openshift/hive/apis/hive/v2"
+)
+
+// conversionKey identifies the conversion of a kind between two versions of the hive API.
+type conversionKey struct {
+	kind string
+	from string
+	to   string
+}
+
+// conversionFunc decodes an object from its JSON and converts it.
+type conversionFunc func(in []byte) (runtime.Object, error)
+
+var (
+	v1APIVersion = hivev1.SchemeGroupVersion.String()
+	v2APIVersion = hivev2.SchemeGroupVersion.String()
+)
+
+var conversions = map[conversionKey]conversionFunc{
+	{kind: "ClusterDeployment", from: v1APIVersion, to: v2APIVersion}: func(in []byte) (runtime.Object, error) {
+		src, dst := &hivev1.ClusterDeployment{}, &hivev2.ClusterDeployment{}
+		if err := json.Unmarshal(in, src); err != nil {
+			return nil, err
+		}
+		return dst, hivev2.Convert_v1_ClusterDeployment_To_v2_ClusterDeployment(src, dst)
+	},
+	{kind: "ClusterDeployment", from: v2APIVersion, to: v1APIVersion}: func(in []byte) (runtime.Object, error) {
+		src, dst := &hivev2.ClusterDeployment{}, &hivev1.ClusterDeployment{}
+		if err := json.Unmarshal(in, src); err != nil {
+			return nil, err
+		}
+		return dst, hivev2.Convert_v2_ClusterDeployment_To_v1_ClusterDeployment(src, dst)
+	},
+	{kind: "ClusterPool", from: v1APIVersion, to: v2APIVersion}: func(in []byte) (runtime.Object, error) {
+		src, dst := &hivev1.ClusterPool{}, &hivev2.ClusterPool{}
+		if err := json.Unmarshal(in, src); err != nil {
+			return nil, err
+		}
+		return dst, hivev2.Convert_v1_ClusterPool_To_v2_ClusterPool(src, dst)
+	},
+	{kind: "ClusterPool", from: v2APIVersion, to: v1APIVersion}: func(in []byte) (runtime.Object, error) {
+		src, dst := &hivev2.ClusterPool{}, &hivev1.ClusterPool{}
+		if err := json.Unmarshal(in, src); err != nil {
+			return nil, err
+		}
+		return dst, hivev2.Convert_v2_ClusterPool_To_v1_ClusterPool(src, dst)
+	},
+	{kind: "SyncSet", from: v1APIVersion, to: v2APIVersion}: func(in []byte) (runtime.Object, error) {
+		src, dst := &hivev1.SyncSet{}, &hivev2.SyncSet{}
+		if err := json.Unmarshal(in, src); err != nil {
+			return nil, err
+		}
+		return dst, hivev2.Convert_v1_SyncSet_To_v2_SyncSet(src, dst)
+	},
+	{kind: "SyncSet", from: v2APIVersion, to: v1APIVersion}: func(in []byte) (runtime.Object, error) {
+		src, dst := &hivev2.SyncSet{}, &hivev1.SyncSet{}
+		if err := json.Unmarshal(in, src); err != nil {
+			return nil, err
+		}
+		return dst, hivev2.Convert_v2_SyncSet_To_v1_SyncSet(src, dst)
+	},
+}
+
+// ConversionWebhook converts hive objects between the versions of the hive API for the CRD conversion requests of
+// the API server. It accepts apiextensions.k8s.io/v1 and v1beta1 ConversionReviews, which share the same schema.
+type ConversionWebhook struct {
+	logger log.FieldLogger
+}
+
+// NewConversionWebhook returns a new ConversionWebhook.
+func NewConversionWebhook() *ConversionWebhook {
+	return &ConversionWebhook{
+		logger: log.WithField("webhook", "conversion"),
+	}
+}
+
+// ServeHTTP handles a ConversionReview.
+func (w *ConversionWebhook) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		w.logger.WithError(err).Error("could not read conversion request")
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	review := &apiextv1.ConversionReview{}
+	if err := json.Unmarshal(body, review); err != nil || review.Request == nil {
+		w.logger.WithError(err).Error("could not decode conversion review")
+		http.Error(rw, "could not decode conversion review", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = w.convert(review.Request)
+	review.Request = nil
+	rw.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(rw).Encode(review); err != nil {
+		w.logger.WithError(err).Error("could not encode conversion response")
+	}
+}
+
+// convert converts the objects of the conversion request to the desired API version. The conversion fails as a
+// whole when any of the objects cannot be converted.
+func (w *ConversionWebhook) convert(req *apiextv1.ConversionRequest) *apiextv1.ConversionResponse {
+	resp := &apiextv1.ConversionResponse{UID: req.UID}
+	for _, obj := range req.Objects {
+		converted, err := convertObject(obj.Raw, req.DesiredAPIVersion)
+		if err != nil {
+			w.logger.WithError(err).WithField("desiredAPIVersion", req.DesiredAPIVersion).Error("could not convert object")
+			resp.ConvertedObjects = nil
+			resp.Result = metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: err.Error(),
+			}
+			return resp
+		}
+		resp.ConvertedObjects = append(resp.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	resp.Result = metav1.Status{Status: metav1.StatusSuccess}
+	return resp
+}
+
+// convertObject converts the JSON of a hive object to the desired API version.
+func convertObject(in []byte, desiredAPIVersion string) ([]byte, error) {
+	typeMeta := &metav1.TypeMeta{}
+	if err := json.Unmarshal(in, typeMeta); err != nil {
+		return nil, fmt.Errorf("could not decode object: %v", err)
+	}
+	if typeMeta.APIVersion == desiredAPIVersion {
+		return in, nil
+	}
+	conversion, ok := conversions[conversionKey{kind: typeMeta.Kind, from: typeMeta.APIVersion, to: desiredAPIVersion}]
+	if !ok {
+		return nil, fmt.Errorf("conversion of %s from %s to %s is not supported", typeMeta.Kind, typeMeta.APIVersion, desiredAPIVersion)
+	}
+	out, err := conversion(in)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert %s from %s to %s: %v", typeMeta.Kind, typeMeta.APIVersion, desiredAPIVersion, err)
+	}
+	return json.Marshal(out)
+}
@@ -0,0 +1,246 @@
+package conversionwebhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/apis/hive/v1/gcp"
+	hivev2 "github.com/openshift/hive/apis/hive/v2"
+)
+
+// fuzzer returns a fuzzer of v1 objects that can be converted to v2 and back without loss.
+func fuzzer() *fuzz.Fuzzer {
+	return fuzz.New().NilChance(0.2).Funcs(
+		func(p *hivev1.Platform, c fuzz.Continue) {
+			// Only one platform can be set.
+			*p = hivev1.Platform{}
+			switch c.Intn(9) {
+			case 0:
+				c.Fuzz(&p.AWS)
+			case 1:
+				c.Fuzz(&p.Azure)
+			case 2:
+				c.Fuzz(&p.BareMetal)
+			case 3:
+				c.Fuzz(&p.GCP)
+			case 4:
+				c.Fuzz(&p.OpenStack)
+			case 5:
+				c.Fuzz(&p.VSphere)
+			case 6:
+				c.Fuzz(&p.Ovirt)
+			case 7:
+				c.Fuzz(&p.AgentBareMetal)
+			}
+		},
+		func(status *hivev1.ClusterDeploymentStatus, c fuzz.Continue) {
+			c.FuzzNoCustom(status)
+			status.InstallRestarts = int(int32(status.InstallRestarts))
+			// Conditions are keyed by type.
+			for i := range status.Conditions {
+				status.Conditions[i].Type += hivev1.ClusterDeploymentConditionType(strconv.Itoa(i))
+			}
+		},
+		func(status *hivev1.ClusterPoolStatus, c fuzz.Continue) {
+			c.FuzzNoCustom(status)
+			for i := range status.Conditions {
+				status.Conditions[i].Type += hivev1.ClusterPoolConditionType(strconv.Itoa(i))
+			}
+		},
+		func(raw *runtime.RawExtension, c fuzz.Continue) {
+			raw.Raw = []byte(`{"apiVersion":"v1","kind":"ConfigMap"}`)
+		},
+	)
+}
+
+func TestRoundTrip(t *testing.T) {
+	f := fuzzer()
+	for i := 0; i < 200; i++ {
+		cd := &hivev1.ClusterDeployment{}
+		f.Fuzz(cd)
+		cdV2, cdRoundTripped := &hivev2.ClusterDeployment{}, &hivev1.ClusterDeployment{}
+		require.NoError(t, hivev2.Convert_v1_ClusterDeployment_To_v2_ClusterDeployment(cd, cdV2))
+		require.NoError(t, hivev2.Convert_v2_ClusterDeployment_To_v1_ClusterDeployment(cdV2, cdRoundTripped))
+		cd.TypeMeta = cdRoundTripped.TypeMeta
+		assert.Equal(t, cd, cdRoundTripped, "unexpected ClusterDeployment after round trip")
+
+		pool := &hivev1.ClusterPool{}
+		f.Fuzz(pool)
+		poolV2, poolRoundTripped := &hivev2.ClusterPool{}, &hivev1.ClusterPool{}
+		require.NoError(t, hivev2.Convert_v1_ClusterPool_To_v2_ClusterPool(pool, poolV2))
+		require.NoError(t, hivev2.Convert_v2_ClusterPool_To_v1_ClusterPool(poolV2, poolRoundTripped))
+		pool.TypeMeta = poolRoundTripped.TypeMeta
+		assert.Equal(t, pool, poolRoundTripped, "unexpected ClusterPool after round trip")
+
+		ss := &hivev1.SyncSet{}
+		f.Fuzz(ss)
+		ssV2, ssRoundTripped := &hivev2.SyncSet{}, &hivev1.SyncSet{}
+		require.NoError(t, hivev2.Convert_v1_SyncSet_To_v2_SyncSet(ss, ssV2))
+		require.NoError(t, hivev2.Convert_v2_SyncSet_To_v1_SyncSet(ssV2, ssRoundTripped))
+		ss.TypeMeta = ssRoundTripped.TypeMeta
+		assert.Equal(t, ss, ssRoundTripped, "unexpected SyncSet after round trip")
+	}
+}
+
+func TestConvertPlatform(t *testing.T) {
+	cd := &hivev1.ClusterDeployment{}
+	cd.Spec.Platform.AWS = &aws.Platform{Region: "us-east-1"}
+	cdV2 := &hivev2.ClusterDeployment{}
+	require.NoError(t, hivev2.Convert_v1_ClusterDeployment_To_v2_ClusterDeployment(cd, cdV2))
+	assert.Equal(t, hivev2.AWSPlatformType, cdV2.Spec.Platform.Type, "unexpected platform type")
+
+	cdV2.Spec.Platform.Type = hivev2.GCPPlatformType
+	cdV2.Spec.Platform.GCP = &gcp.Platform{Region: "us-east1"}
+	err := hivev2.Convert_v2_ClusterDeployment_To_v1_ClusterDeployment(cdV2, &hivev1.ClusterDeployment{})
+	assert.EqualError(t, err, "platform type is GCP but the AWS platform configuration is set")
+
+	cdV2.Spec.Platform.AWS = nil
+	cdV2.Spec.Platform.GCP = nil
+	err = hivev2.Convert_v2_ClusterDeployment_To_v1_ClusterDeployment(cdV2, &hivev1.ClusterDeployment{})
+	assert.EqualError(t, err, "platform type is GCP but the GCP platform configuration is not set")
+}
+
+func TestConversionWebhook(t *testing.T) {
+	cdV2 := &hivev2.ClusterDeployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "hive.openshift.io/v2", Kind: "ClusterDeployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test-namespace",
+			Name:      "test-cluster",
+		},
+		Spec: hivev2.ClusterDeploymentSpec{
+			ClusterName: "test-cluster",
+			Platform: hivev2.Platform{
+				Type: hivev2.AWSPlatformType,
+				AWS:  &aws.Platform{Region: "us-east-1"},
+			},
+		},
+		Status: hivev2.ClusterDeploymentStatus{
+			Conditions: []metav1.Condition{{
+				Type:   string(hivev1.UnreachableCondition),
+				Status: metav1.ConditionFalse,
+				Reason: "ClusterReachable",
+			}},
+			InstalledTime: &metav1.Time{Time: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+	invalidCDV2 := cdV2.DeepCopy()
+	invalidCDV2.Spec.Platform.Type = hivev2.AzurePlatformType
+
+	tests := []struct {
+		name                  string
+		reviewAPIVersion      string
+		desiredAPIVersion     string
+		objects               []runtime.Object
+		expectedStatus        string
+		expectedObjectVersion string
+	}{
+		{
+			name:                  "v2 to v1",
+			reviewAPIVersion:      "apiextensions.k8s.io/v1",
+			desiredAPIVersion:     "hive.openshift.io/v1",
+			objects:               []runtime.Object{cdV2},
+			expectedStatus:        metav1.StatusSuccess,
+			expectedObjectVersion: "hive.openshift.io/v1",
+		},
+		{
+			name:                  "v1beta1 review",
+			reviewAPIVersion:      "apiextensions.k8s.io/v1beta1",
+			desiredAPIVersion:     "hive.openshift.io/v1",
+			objects:               []runtime.Object{cdV2},
+			expectedStatus:        metav1.StatusSuccess,
+			expectedObjectVersion: "hive.openshift.io/v1",
+		},
+		{
+			name:                  "same version",
+			reviewAPIVersion:      "apiextensions.k8s.io/v1",
+			desiredAPIVersion:     "hive.openshift.io/v2",
+			objects:               []runtime.Object{cdV2},
+			expectedStatus:        metav1.StatusSuccess,
+			expectedObjectVersion: "hive.openshift.io/v2",
+		},
+		{
+			name:              "invalid platform",
+			reviewAPIVersion:  "apiextensions.k8s.io/v1",
+			desiredAPIVersion: "hive.openshift.io/v1",
+			objects:           []runtime.Object{cdV2, invalidCDV2},
+			expectedStatus:    metav1.StatusFailure,
+		},
+		{
+			name:              "unsupported version",
+			reviewAPIVersion:  "apiextensions.k8s.io/v1",
+			desiredAPIVersion: "hive.openshift.io/v3",
+			objects:           []runtime.Object{cdV2},
+			expectedStatus:    metav1.StatusFailure,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			review := &apiextv1.ConversionReview{
+				TypeMeta: metav1.TypeMeta{APIVersion: test.reviewAPIVersion, Kind: "ConversionReview"},
+				Request: &apiextv1.ConversionRequest{
+					UID:               types.UID("test-uid"),
+					DesiredAPIVersion: test.desiredAPIVersion,
+				},
+			}
+			for _, obj := range test.objects {
+				raw, err := json.Marshal(obj)
+				require.NoError(t, err)
+				review.Request.Objects = append(review.Request.Objects, runtime.RawExtension{Raw: raw})
+			}
+			body, err := json.Marshal(review)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			NewConversionWebhook().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/convert", bytes.NewReader(body)))
+			require.Equal(t, http.StatusOK, rec.Code, "unexpected response code")
+
+			response := &apiextv1.ConversionReview{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), response))
+			assert.Equal(t, test.reviewAPIVersion, response.APIVersion, "unexpected review API version")
+			require.NotNil(t, response.Response, "missing conversion response")
+			assert.Equal(t, types.UID("test-uid"), response.Response.UID, "unexpected response UID")
+			assert.Equal(t, test.expectedStatus, response.Response.Result.Status, "unexpected result status")
+			if test.expectedStatus != metav1.StatusSuccess {
+				assert.Empty(t, response.Response.ConvertedObjects, "expected no converted objects")
+				return
+			}
+			require.Len(t, response.Response.ConvertedObjects, len(test.objects), "unexpected number of converted objects")
+			for _, obj := range response.Response.ConvertedObjects {
+				typeMeta := &metav1.TypeMeta{}
+				require.NoError(t, json.Unmarshal(obj.Raw, typeMeta))
+				assert.Equal(t, test.expectedObjectVersion, typeMeta.APIVersion, "unexpected converted object version")
+			}
+			if test.expectedObjectVersion == "hive.openshift.io/v1" {
+				cd := &hivev1.ClusterDeployment{}
+				require.NoError(t, json.Unmarshal(response.Response.ConvertedObjects[0].Raw, cd))
+				assert.Equal(t, "us-east-1", cd.Spec.Platform.AWS.Region, "unexpected platform")
+				assert.NotNil(t, cd.Status.InstalledTimestamp, "expected installed timestamp")
+				if assert.Len(t, cd.Status.Conditions, 1, "unexpected conditions") {
+					assert.Equal(t, "ClusterReachable", cd.Status.Conditions[0].Reason, "unexpected condition reason")
+				}
+			}
+		})
+	}
+}
+
+func TestConversionWebhookBadRequest(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewConversionWebhook().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/convert", bytes.NewReader([]byte("not json"))))
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "unexpected response code")
+}
@@ -0,0 +1,291 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	apiPrefix = "/v1/namespaces/"
+
+	clustersResource = "clusters"
+	claimsResource   = "claims"
+)
+
+// ClientFactory builds a client for the hive API server that acts with the identity of the given bearer token.
+type ClientFactory func(token string) (client.Client, error)
+
+// NewClientFactory returns a ClientFactory that builds clients from the given config, replacing the credentials in
+// the config with the bearer token of the caller. Requests through the gateway are thus subject to the RBAC of the
+// caller rather than that of the gateway. The REST mappings are discovered with the credentials of the gateway and
+// shared by the clients of all the callers, so that building a client does not query the discovery API.
+func NewClientFactory(cfg *rest.Config, scheme *runtime.Scheme) (ClientFactory, error) {
+	mapper, err := apiutil.NewDynamicRESTMapper(cfg, apiutil.WithLazyDiscovery)
+	if err != nil {
+		return nil, err
+	}
+	return func(token string) (client.Client, error) {
+		callerCfg := rest.AnonymousClientConfig(cfg)
+		callerCfg.BearerToken = token
+		return client.New(callerCfg, client.Options{Scheme: scheme, Mapper: mapper})
+	}, nil
+}
+
+// Server is a REST front-end for creating, claiming, inspecting, and deleting clusters without a Kubernetes client.
+// Requests are translated to ClusterDeployments and ClusterClaims.
+type Server struct {
+	clientFor ClientFactory
+	logger    log.FieldLogger
+}
+
+// NewServer returns a new gateway Server.
+func NewServer(clientFor ClientFactory, logger log.FieldLogger) *Server {
+	return &Server{
+		clientFor: clientFor,
+		logger:    logger,
+	}
+}
+
+// ServeHTTP handles requests of the following forms:
+//
+//	GET, POST         /v1/namespaces/{namespace}/clusters
+//	GET, DELETE       /v1/namespaces/{namespace}/clusters/{name}
+//	POST              /v1/namespaces/{namespace}/claims
+//	GET, DELETE       /v1/namespaces/{namespace}/claims/{name}
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithField("method", r.Method).WithField("path", r.URL.Path)
+
+	if !strings.HasPrefix(r.URL.Path, apiPrefix) {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, apiPrefix), "/"), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	namespace, resource, name := parts[0], parts[1], ""
+	if len(parts) == 3 {
+		name = parts[2]
+	}
+
+	token := bearerToken(r)
+	if token == "" {
+		writeError(w, http.StatusUnauthorized, "missing bearer token")
+		return
+	}
+	c, err := s.clientFor(token)
+	if err != nil {
+		logger.WithError(err).Error("could not build client for caller")
+		writeError(w, http.StatusInternalServerError, "could not build client")
+		return
+	}
+
+	var status int
+	var body interface{}
+	switch {
+	case resource == clustersResource && name == "" && r.Method == http.MethodGet:
+		status, body, err = listClusters(r.Context(), c, namespace)
+	case resource == clustersResource && name == "" && r.Method == http.MethodPost:
+		status, body, err = createCluster(r, c, namespace)
+	case resource == clustersResource && name != "" && r.Method == http.MethodGet:
+		status, body, err = getCluster(r.Context(), c, namespace, name)
+	case resource == clustersResource && name != "" && r.Method == http.MethodDelete:
+		status, body, err = deleteObject(r.Context(), c, &hivev1.ClusterDeployment{}, namespace, name)
+	case resource == claimsResource && name == "" && r.Method == http.MethodPost:
+		status, body, err = createClaim(r, c, namespace)
+	case resource == claimsResource && name != "" && r.Method == http.MethodGet:
+		status, body, err = getClaim(r.Context(), c, namespace, name)
+	case resource == claimsResource && name != "" && r.Method == http.MethodDelete:
+		status, body, err = deleteObject(r.Context(), c, &hivev1.ClusterClaim{}, namespace, name)
+	case resource == clustersResource || resource == claimsResource:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s not allowed", r.Method))
+		return
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if err != nil {
+		status = errorStatus(err)
+		if status == http.StatusInternalServerError {
+			logger.WithError(err).Error("error handling request")
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	logger.WithField("status", status).Debug("handled request")
+	writeJSON(w, status, body)
+}
+
+func listClusters(ctx context.Context, c client.Client, namespace string) (int, interface{}, error) {
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := c.List(ctx, cdList, client.InNamespace(namespace)); err != nil {
+		return 0, nil, err
+	}
+	list := ClusterList{Items: []Cluster{}}
+	for i := range cdList.Items {
+		list.Items = append(list.Items, clusterFromClusterDeployment(&cdList.Items[i]))
+	}
+	return http.StatusOK, list, nil
+}
+
+func getCluster(ctx context.Context, c client.Client, namespace, name string) (int, interface{}, error) {
+	cd := &hivev1.ClusterDeployment{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, cd); err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, clusterFromClusterDeployment(cd), nil
+}
+
+func createCluster(r *http.Request, c client.Client, namespace string) (int, interface{}, error) {
+	req := &ClusterRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return 0, nil, badRequest(fmt.Sprintf("could not decode request: %v", err))
+	}
+	switch {
+	case req.Name == "":
+		return 0, nil, badRequest("name is required")
+	case req.BaseDomain == "":
+		return 0, nil, badRequest("baseDomain is required")
+	case req.ClusterImageSetName == "":
+		return 0, nil, badRequest("clusterImageSetName is required")
+	case req.InstallConfigSecretName == "":
+		return 0, nil, badRequest("installConfigSecretName is required")
+	}
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      req.Name,
+			Labels:    req.Labels,
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: req.Name,
+			BaseDomain:  req.BaseDomain,
+			Platform:    req.Platform,
+			Provisioning: &hivev1.Provisioning{
+				ImageSetRef:            &hivev1.ClusterImageSetReference{Name: req.ClusterImageSetName},
+				InstallConfigSecretRef: &corev1.LocalObjectReference{Name: req.InstallConfigSecretName},
+			},
+		},
+	}
+	if req.PullSecretName != "" {
+		cd.Spec.PullSecretRef = &corev1.LocalObjectReference{Name: req.PullSecretName}
+	}
+	if err := c.Create(r.Context(), cd); err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, clusterFromClusterDeployment(cd), nil
+}
+
+func getClaim(ctx context.Context, c client.Client, namespace, name string) (int, interface{}, error) {
+	claim := &hivev1.ClusterClaim{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, claim); err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, claimFromClusterClaim(claim), nil
+}
+
+func createClaim(r *http.Request, c client.Client, namespace string) (int, interface{}, error) {
+	req := &ClaimRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return 0, nil, badRequest(fmt.Sprintf("could not decode request: %v", err))
+	}
+	switch {
+	case req.Name == "":
+		return 0, nil, badRequest("name is required")
+	case req.ClusterPoolName == "":
+		return 0, nil, badRequest("clusterPoolName is required")
+	}
+	claim := &hivev1.ClusterClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      req.Name,
+		},
+		Spec: hivev1.ClusterClaimSpec{
+			ClusterPoolName: req.ClusterPoolName,
+		},
+	}
+	if req.Lifetime != "" {
+		lifetime, err := time.ParseDuration(req.Lifetime)
+		if err != nil {
+			return 0, nil, badRequest(fmt.Sprintf("invalid lifetime: %v", err))
+		}
+		claim.Spec.Lifetime = &metav1.Duration{Duration: lifetime}
+	}
+	if err := c.Create(r.Context(), claim); err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, claimFromClusterClaim(claim), nil
+}
+
+func deleteObject(ctx context.Context, c client.Client, obj client.Object, namespace, name string) (int, interface{}, error) {
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
+		return 0, nil, err
+	}
+	if err := c.Delete(ctx, obj); err != nil {
+		return 0, nil, err
+	}
+	return http.StatusAccepted, nil, nil
+}
+
+type badRequestError string
+
+func (e badRequestError) Error() string {
+	return string(e)
+}
+
+func badRequest(msg string) error {
+	return badRequestError(msg)
+}
+
+// errorStatus maps an error to the HTTP status of the response. Errors from the API server keep their status so
+// that, for example, RBAC denials surface as 403 to the caller.
+func errorStatus(err error) int {
+	if _, ok := err.(badRequestError); ok {
+		return http.StatusBadRequest
+	}
+	if status, ok := err.(apierrors.APIStatus); ok && status.Status().Code != 0 {
+		return int(status.Status().Code)
+	}
+	return http.StatusInternalServerError
+}
+
+func bearerToken(r *http.Request) string {
+	const prefix = "Bearer "
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, prefix) {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimPrefix(auth, prefix))
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, Error{Code: status, Message: msg})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
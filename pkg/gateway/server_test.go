@@ -0,0 +1,273 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/apis/hive/v1/aws"
+)
+
+const (
+	testNamespace = "test-namespace"
+	testToken     = "test-token"
+)
+
+func testClusterDeployment(name string) *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      name,
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: name,
+			Installed:   true,
+		},
+		Status: hivev1.ClusterDeploymentStatus{
+			APIURL: "https://api." + name + ".example.com:6443",
+			Conditions: []hivev1.ClusterDeploymentCondition{
+				{Type: hivev1.ProvisionFailedCondition, Status: corev1.ConditionFalse},
+				{Type: hivev1.UnreachableCondition, Status: corev1.ConditionUnknown},
+			},
+		},
+	}
+}
+
+func testClusterClaim(name string) *hivev1.ClusterClaim {
+	return &hivev1.ClusterClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      name,
+		},
+		Spec: hivev1.ClusterClaimSpec{
+			ClusterPoolName: "test-pool",
+			Namespace:       "test-pool-abcde",
+		},
+		Status: hivev1.ClusterClaimStatus{
+			Conditions: []hivev1.ClusterClaimCondition{
+				{Type: hivev1.ClusterRunningCondition, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+}
+
+func TestServer(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	cases := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		noToken        bool
+		existing       []runtime.Object
+		expectedStatus int
+		expectedBody   string
+		validate       func(t *testing.T, c client.Client)
+	}{
+		{
+			name:           "missing token",
+			method:         http.MethodGet,
+			path:           "/v1/namespaces/test-namespace/clusters",
+			noToken:        true,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "unknown resource",
+			method:         http.MethodGet,
+			path:           "/v1/namespaces/test-namespace/machines",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodPut,
+			path:           "/v1/namespaces/test-namespace/clusters/cluster1",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "list clusters",
+			method:         http.MethodGet,
+			path:           "/v1/namespaces/test-namespace/clusters",
+			existing:       []runtime.Object{testClusterDeployment("cluster1"), testClusterDeployment("cluster2")},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"name":"cluster2"`,
+		},
+		{
+			name:           "get cluster",
+			method:         http.MethodGet,
+			path:           "/v1/namespaces/test-namespace/clusters/cluster1",
+			existing:       []runtime.Object{testClusterDeployment("cluster1")},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"apiURL":"https://api.cluster1.example.com:6443"`,
+		},
+		{
+			name:           "get missing cluster",
+			method:         http.MethodGet,
+			path:           "/v1/namespaces/test-namespace/clusters/cluster1",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "create cluster",
+			method:         http.MethodPost,
+			path:           "/v1/namespaces/test-namespace/clusters",
+			body:           `{"name":"cluster1","baseDomain":"example.com","clusterImageSetName":"openshift-v4.7.0","installConfigSecretName":"install-config","pullSecretName":"pull-secret","platform":{"aws":{"region":"us-east-1","credentialsSecretRef":{"name":"aws-creds"}}},"labels":{"team":"a"}}`,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `"name":"cluster1"`,
+			validate: func(t *testing.T, c client.Client) {
+				cd := &hivev1.ClusterDeployment{}
+				require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: "cluster1"}, cd), "unexpected error getting cluster deployment")
+				assert.Equal(t, "cluster1", cd.Spec.ClusterName, "unexpected cluster name")
+				assert.Equal(t, "example.com", cd.Spec.BaseDomain, "unexpected base domain")
+				assert.Equal(t, "openshift-v4.7.0", cd.Spec.Provisioning.ImageSetRef.Name, "unexpected image set")
+				assert.Equal(t, "install-config", cd.Spec.Provisioning.InstallConfigSecretRef.Name, "unexpected install config secret")
+				assert.Equal(t, "pull-secret", cd.Spec.PullSecretRef.Name, "unexpected pull secret")
+				assert.Equal(t, &aws.Platform{Region: "us-east-1", CredentialsSecretRef: corev1.LocalObjectReference{Name: "aws-creds"}}, cd.Spec.Platform.AWS, "unexpected platform")
+				assert.Equal(t, map[string]string{"team": "a"}, cd.Labels, "unexpected labels")
+			},
+		},
+		{
+			name:           "create cluster missing image set",
+			method:         http.MethodPost,
+			path:           "/v1/namespaces/test-namespace/clusters",
+			body:           `{"name":"cluster1","baseDomain":"example.com","installConfigSecretName":"install-config"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "clusterImageSetName is required",
+		},
+		{
+			name:           "create existing cluster",
+			method:         http.MethodPost,
+			path:           "/v1/namespaces/test-namespace/clusters",
+			body:           `{"name":"cluster1","baseDomain":"example.com","clusterImageSetName":"openshift-v4.7.0","installConfigSecretName":"install-config"}`,
+			existing:       []runtime.Object{testClusterDeployment("cluster1")},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "delete cluster",
+			method:         http.MethodDelete,
+			path:           "/v1/namespaces/test-namespace/clusters/cluster1",
+			existing:       []runtime.Object{testClusterDeployment("cluster1")},
+			expectedStatus: http.StatusAccepted,
+			validate: func(t *testing.T, c client.Client) {
+				err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: "cluster1"}, &hivev1.ClusterDeployment{})
+				assert.Error(t, err, "expected cluster deployment to be deleted")
+			},
+		},
+		{
+			name:           "create claim",
+			method:         http.MethodPost,
+			path:           "/v1/namespaces/test-namespace/claims",
+			body:           `{"name":"claim1","clusterPoolName":"test-pool","lifetime":"8h"}`,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `"lifetime":"8h0m0s"`,
+			validate: func(t *testing.T, c client.Client) {
+				claim := &hivev1.ClusterClaim{}
+				require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: "claim1"}, claim), "unexpected error getting claim")
+				assert.Equal(t, "test-pool", claim.Spec.ClusterPoolName, "unexpected pool name")
+				if assert.NotNil(t, claim.Spec.Lifetime, "expected lifetime") {
+					assert.Equal(t, "8h0m0s", claim.Spec.Lifetime.Duration.String(), "unexpected lifetime")
+				}
+			},
+		},
+		{
+			name:           "create claim invalid lifetime",
+			method:         http.MethodPost,
+			path:           "/v1/namespaces/test-namespace/claims",
+			body:           `{"name":"claim1","clusterPoolName":"test-pool","lifetime":"forever"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "get claim",
+			method:         http.MethodGet,
+			path:           "/v1/namespaces/test-namespace/claims/claim1",
+			existing:       []runtime.Object{testClusterClaim("claim1")},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"clusterNamespace":"test-pool-abcde","running":true`,
+		},
+		{
+			name:           "delete claim",
+			method:         http.MethodDelete,
+			path:           "/v1/namespaces/test-namespace/claims/claim1",
+			existing:       []runtime.Object{testClusterClaim("claim1")},
+			expectedStatus: http.StatusAccepted,
+			validate: func(t *testing.T, c client.Client) {
+				err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: "claim1"}, &hivev1.ClusterClaim{})
+				assert.Error(t, err, "expected claim to be deleted")
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewFakeClientWithScheme(scheme.Scheme, tc.existing...)
+			server := NewServer(func(token string) (client.Client, error) {
+				assert.Equal(t, testToken, token, "unexpected token")
+				return fakeClient, nil
+			}, log.WithField("test", t.Name()))
+
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			if !tc.noToken {
+				req.Header.Set("Authorization", "Bearer "+testToken)
+			}
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code, "unexpected status: %s", rec.Body.String())
+			if tc.expectedBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectedBody, "unexpected body")
+			}
+			if rec.Code >= http.StatusBadRequest {
+				e := &Error{}
+				if assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), e), "expected error body") {
+					assert.Equal(t, rec.Code, e.Code, "unexpected error code")
+				}
+			}
+			if tc.validate != nil {
+				tc.validate(t, fakeClient)
+			}
+		})
+	}
+}
+
+func TestClusterFromClusterDeploymentOmitsUnknownConditions(t *testing.T) {
+	c := clusterFromClusterDeployment(testClusterDeployment("cluster1"))
+	assert.Equal(t, hivev1.RunningClusterPowerState, c.PowerState, "unexpected power state")
+	if assert.Len(t, c.Conditions, 1, "unexpected conditions") {
+		assert.Equal(t, hivev1.ProvisionFailedCondition, c.Conditions[0].Type, "unexpected condition")
+	}
+}
+
+func TestClientFactorySharesRESTMapper(t *testing.T) {
+	requests := 0
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer apiServer.Close()
+
+	s := runtime.NewScheme()
+	require.NoError(t, apis.AddToScheme(s), "unexpected error adding hive types to scheme")
+	clientFor, err := NewClientFactory(&rest.Config{Host: apiServer.URL}, s)
+	require.NoError(t, err, "unexpected error creating client factory")
+	for _, token := range []string{"token1", "token2"} {
+		_, err := clientFor(token)
+		require.NoError(t, err, "unexpected error building client")
+	}
+	assert.Zero(t, requests, "expected clients to be built without querying the API server")
+}
@@ -0,0 +1,114 @@
+package gateway
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// ClusterRequest is the body of a request to create a cluster.
+type ClusterRequest struct {
+	// Name is the name of the ClusterDeployment. It is also used as the name of the cluster.
+	Name string `json:"name"`
+	// BaseDomain is the base domain of the cluster.
+	BaseDomain string `json:"baseDomain"`
+	// ClusterImageSetName is the name of the ClusterImageSet to install.
+	ClusterImageSetName string `json:"clusterImageSetName"`
+	// InstallConfigSecretName is the name of the secret in the namespace holding the install-config.yaml.
+	InstallConfigSecretName string `json:"installConfigSecretName"`
+	// PullSecretName is the name of the secret in the namespace holding the pull secret.
+	PullSecretName string `json:"pullSecretName,omitempty"`
+	// Platform is the cloud platform configuration of the cluster.
+	Platform hivev1.Platform `json:"platform"`
+	// Labels are added to the ClusterDeployment.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Cluster is the status of a cluster returned by the gateway.
+type Cluster struct {
+	Namespace     string                              `json:"namespace"`
+	Name          string                              `json:"name"`
+	Installed     bool                                `json:"installed"`
+	PowerState    hivev1.ClusterPowerState            `json:"powerState,omitempty"`
+	APIURL        string                              `json:"apiURL,omitempty"`
+	WebConsoleURL string                              `json:"webConsoleURL,omitempty"`
+	ClusterPool   string                              `json:"clusterPool,omitempty"`
+	Deleting      bool                                `json:"deleting,omitempty"`
+	Conditions    []hivev1.ClusterDeploymentCondition `json:"conditions,omitempty"`
+}
+
+// ClusterList is a list of clusters returned by the gateway.
+type ClusterList struct {
+	Items []Cluster `json:"items"`
+}
+
+// ClaimRequest is the body of a request to claim a cluster from a cluster pool.
+type ClaimRequest struct {
+	// Name is the name of the ClusterClaim.
+	Name string `json:"name"`
+	// ClusterPoolName is the name of the cluster pool in the namespace from which to claim a cluster.
+	ClusterPoolName string `json:"clusterPoolName"`
+	// Lifetime is the maximum lifetime of the claim, for example "8h".
+	Lifetime string `json:"lifetime,omitempty"`
+}
+
+// Claim is the status of a cluster claim returned by the gateway.
+type Claim struct {
+	Namespace        string                         `json:"namespace"`
+	Name             string                         `json:"name"`
+	ClusterPoolName  string                         `json:"clusterPoolName"`
+	ClusterNamespace string                         `json:"clusterNamespace,omitempty"`
+	Running          bool                           `json:"running"`
+	Lifetime         string                         `json:"lifetime,omitempty"`
+	Conditions       []hivev1.ClusterClaimCondition `json:"conditions,omitempty"`
+}
+
+// Error is the body of an error response from the gateway.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func clusterFromClusterDeployment(cd *hivev1.ClusterDeployment) Cluster {
+	c := Cluster{
+		Namespace:     cd.Namespace,
+		Name:          cd.Name,
+		Installed:     cd.Spec.Installed,
+		PowerState:    cd.Spec.PowerState,
+		APIURL:        cd.Status.APIURL,
+		WebConsoleURL: cd.Status.WebConsoleURL,
+		Deleting:      cd.DeletionTimestamp != nil,
+	}
+	if c.PowerState == "" {
+		c.PowerState = hivev1.RunningClusterPowerState
+	}
+	if cd.Spec.ClusterPoolRef != nil {
+		c.ClusterPool = cd.Spec.ClusterPoolRef.PoolName
+	}
+	for _, cond := range cd.Status.Conditions {
+		if cond.Status == corev1.ConditionUnknown {
+			continue
+		}
+		c.Conditions = append(c.Conditions, cond)
+	}
+	return c
+}
+
+func claimFromClusterClaim(claim *hivev1.ClusterClaim) Claim {
+	c := Claim{
+		Namespace:        claim.Namespace,
+		Name:             claim.Name,
+		ClusterPoolName:  claim.Spec.ClusterPoolName,
+		ClusterNamespace: claim.Spec.Namespace,
+		Conditions:       claim.Status.Conditions,
+	}
+	if claim.Spec.Lifetime != nil {
+		c.Lifetime = claim.Spec.Lifetime.Duration.String()
+	}
+	for _, cond := range claim.Status.Conditions {
+		if cond.Type == hivev1.ClusterRunningCondition && cond.Status == corev1.ConditionTrue {
+			c.Running = true
+		}
+	}
+	return c
+}
@@ -1,14 +1,21 @@
 package gcpclient
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
 	"time"
 
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v1"
 	compute "google.golang.org/api/compute/v1"
@@ -23,33 +30,88 @@ import (
 
 // Client is a wrapper object for actual GCP libraries to allow for easier mocking/testing.
 type Client interface {
-	ListManagedZones(opts ListManagedZonesOptions) (*dns.ManagedZonesListResponse, error)
+	ListManagedZones(ctx context.Context, opts ListManagedZonesOptions) (*dns.ManagedZonesListResponse, error)
 
-	ListResourceRecordSets(managedZone string, opts ListResourceRecordSetsOptions) (*dns.ResourceRecordSetsListResponse, error)
+	ListResourceRecordSets(ctx context.Context, managedZone string, opts ListResourceRecordSetsOptions) (*dns.ResourceRecordSetsListResponse, error)
 
-	AddResourceRecordSet(managedZone string, recordSet *dns.ResourceRecordSet) error
+	AddResourceRecordSet(ctx context.Context, managedZone string, recordSet *dns.ResourceRecordSet) error
 
-	DeleteResourceRecordSet(managedZone string, recordSet *dns.ResourceRecordSet) error
+	DeleteResourceRecordSet(ctx context.Context, managedZone string, recordSet *dns.ResourceRecordSet) error
 
-	DeleteResourceRecordSets(managedZone string, recordSet []*dns.ResourceRecordSet) error
+	DeleteResourceRecordSets(ctx context.Context, managedZone string, recordSet []*dns.ResourceRecordSet) error
 
-	UpdateResourceRecordSet(managedZone string, addRecordSet, removeRecordSet *dns.ResourceRecordSet) error
+	UpdateResourceRecordSet(ctx context.Context, managedZone string, addRecordSet, removeRecordSet *dns.ResourceRecordSet) error
 
-	GetManagedZone(managedZone string) (*dns.ManagedZone, error)
+	GetManagedZone(ctx context.Context, managedZone string) (*dns.ManagedZone, error)
 
-	CreateManagedZone(managedZone *dns.ManagedZone) (*dns.ManagedZone, error)
+	CreateManagedZone(ctx context.Context, managedZone *dns.ManagedZone) (*dns.ManagedZone, error)
 
-	DeleteManagedZone(managedZone string) error
+	DeleteManagedZone(ctx context.Context, managedZone string) error
 
-	ListComputeZones(ListComputeZonesOptions) (*compute.ZoneList, error)
+	ListComputeZones(context.Context, ListComputeZonesOptions) (*compute.ZoneList, error)
 
-	ListComputeImages(ListComputeImagesOptions) (*compute.ImageList, error)
+	ListComputeImages(context.Context, ListComputeImagesOptions) (*compute.ImageList, error)
 
-	ListComputeInstances(ListComputeInstancesOptions, func(*compute.InstanceAggregatedList) error) error
+	ListComputeInstances(context.Context, ListComputeInstancesOptions, func(*compute.InstanceAggregatedList) error) error
 
-	StopInstance(*compute.Instance) error
+	StopInstance(context.Context, *compute.Instance) error
 
-	StartInstance(*compute.Instance) error
+	StartInstance(context.Context, *compute.Instance) error
+
+	UpdateManagedZone(ctx context.Context, managedZone string, patch *dns.ManagedZone) error
+
+	GetForwardingRule(ctx context.Context, region, name string) (*compute.ForwardingRule, error)
+
+	CreateForwardingRule(ctx context.Context, region string, rule *compute.ForwardingRule) error
+
+	DeleteForwardingRule(ctx context.Context, region, name string) error
+
+	GetAddress(ctx context.Context, region, name string) (*compute.Address, error)
+
+	CreateAddress(ctx context.Context, region string, address *compute.Address) error
+
+	DeleteAddress(ctx context.Context, region, name string) error
+
+	GetSubnetwork(ctx context.Context, region, name string) (*compute.Subnetwork, error)
+
+	CreateSubnetwork(ctx context.Context, region string, subnet *compute.Subnetwork) error
+
+	DeleteSubnetwork(ctx context.Context, region, name string) error
+
+	GetServiceAttachment(ctx context.Context, region, name string) (*ServiceAttachment, error)
+
+	CreateServiceAttachment(ctx context.Context, region string, attachment *ServiceAttachment) error
+
+	DeleteServiceAttachment(ctx context.Context, region, name string) error
+
+	TestIamPermissions(ctx context.Context, project string, permissions []string) ([]string, error)
+}
+
+// ServiceAttachment is a Private Service Connect Service Attachment. The compute library vendored
+// does not include the serviceAttachments resource, so the fields required by hive are modelled here.
+type ServiceAttachment struct {
+	Name                 string                                   `json:"name,omitempty"`
+	Description          string                                   `json:"description,omitempty"`
+	SelfLink             string                                   `json:"selfLink,omitempty"`
+	TargetService        string                                   `json:"targetService,omitempty"`
+	ConnectionPreference string                                   `json:"connectionPreference,omitempty"`
+	NatSubnets           []string                                 `json:"natSubnets,omitempty"`
+	ConsumerAcceptLists  []*ServiceAttachmentConsumerProjectLimit `json:"consumerAcceptLists,omitempty"`
+	ConnectedEndpoints   []*ServiceAttachmentConnectedEndpoint    `json:"connectedEndpoints,omitempty"`
+}
+
+// ServiceAttachmentConsumerProjectLimit is a consumer project that is allowed to connect to a
+// Service Attachment.
+type ServiceAttachmentConsumerProjectLimit struct {
+	ProjectIdOrNum  string `json:"projectIdOrNum,omitempty"`
+	ConnectionLimit int64  `json:"connectionLimit,omitempty"`
+}
+
+// ServiceAttachmentConnectedEndpoint is an endpoint connected to a Service Attachment.
+type ServiceAttachmentConnectedEndpoint struct {
+	Endpoint        string `json:"endpoint,omitempty"`
+	PscConnectionId uint64 `json:"pscConnectionId,omitempty,string"`
+	Status          string `json:"status,omitempty"`
 }
 
 // ListManagedZonesOptions are the options for listing managed zones.
@@ -79,25 +141,28 @@ type gcpClient struct {
 	computeClient              *compute.Service
 	serviceUsageClient         *serviceusage.Service
 	dnsClient                  *dns.Service
+	httpClient                 *http.Client
 }
 
 const (
 	defaultCallTimeout = 2 * time.Minute
+
+	userAgent = "openshift.io hive/v1"
 )
 
 func contextWithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(ctx, defaultCallTimeout)
 }
 
-func (c *gcpClient) GetManagedZone(managedZone string) (*dns.ManagedZone, error) {
-	ctx, cancel := contextWithTimeout(context.TODO())
+func (c *gcpClient) GetManagedZone(ctx context.Context, managedZone string) (*dns.ManagedZone, error) {
+	ctx, cancel := contextWithTimeout(ctx)
 	defer cancel()
 
 	return c.dnsClient.ManagedZones.Get(c.projectName, managedZone).Context(ctx).Do()
 }
 
-func (c *gcpClient) ListManagedZones(opts ListManagedZonesOptions) (*dns.ManagedZonesListResponse, error) {
-	ctx, cancel := contextWithTimeout(context.TODO())
+func (c *gcpClient) ListManagedZones(ctx context.Context, opts ListManagedZonesOptions) (*dns.ManagedZonesListResponse, error) {
+	ctx, cancel := contextWithTimeout(ctx)
 	defer cancel()
 	call := c.dnsClient.ManagedZones.List(c.projectName).Context(ctx)
 
@@ -113,20 +178,20 @@ func (c *gcpClient) ListManagedZones(opts ListManagedZonesOptions) (*dns.Managed
 	return call.Do()
 }
 
-func (c *gcpClient) CreateManagedZone(managedZone *dns.ManagedZone) (*dns.ManagedZone, error) {
-	ctx, cancel := contextWithTimeout(context.TODO())
+func (c *gcpClient) CreateManagedZone(ctx context.Context, managedZone *dns.ManagedZone) (*dns.ManagedZone, error) {
+	ctx, cancel := contextWithTimeout(ctx)
 	defer cancel()
 	return c.dnsClient.ManagedZones.Create(c.projectName, managedZone).Context(ctx).Do()
 }
 
-func (c *gcpClient) DeleteManagedZone(managedZone string) error {
-	ctx, cancel := contextWithTimeout(context.TODO())
+func (c *gcpClient) DeleteManagedZone(ctx context.Context, managedZone string) error {
+	ctx, cancel := contextWithTimeout(ctx)
 	defer cancel()
 	return c.dnsClient.ManagedZones.Delete(c.projectName, managedZone).Context(ctx).Do()
 }
 
-func (c *gcpClient) ListResourceRecordSets(managedZone string, opts ListResourceRecordSetsOptions) (*dns.ResourceRecordSetsListResponse, error) {
-	ctx, cancel := contextWithTimeout(context.TODO())
+func (c *gcpClient) ListResourceRecordSets(ctx context.Context, managedZone string, opts ListResourceRecordSetsOptions) (*dns.ResourceRecordSetsListResponse, error) {
+	ctx, cancel := contextWithTimeout(ctx)
 	defer cancel()
 	call := c.dnsClient.ResourceRecordSets.List(c.projectName, managedZone).Context(ctx)
 	if opts.MaxResults > 0 {
@@ -144,8 +209,9 @@ func (c *gcpClient) ListResourceRecordSets(managedZone string, opts ListResource
 	return call.Do()
 }
 
-func (c *gcpClient) AddResourceRecordSet(managedZone string, recordSet *dns.ResourceRecordSet) error {
+func (c *gcpClient) AddResourceRecordSet(ctx context.Context, managedZone string, recordSet *dns.ResourceRecordSet) error {
 	return c.changeResourceRecordSet(
+		ctx,
 		managedZone,
 		&dns.Change{
 			Additions: []*dns.ResourceRecordSet{recordSet},
@@ -153,7 +219,7 @@ func (c *gcpClient) AddResourceRecordSet(managedZone string, recordSet *dns.Reso
 	)
 }
 
-func (c *gcpClient) UpdateResourceRecordSet(managedZone string, addRecordSet, removeRecordSet *dns.ResourceRecordSet) error {
+func (c *gcpClient) UpdateResourceRecordSet(ctx context.Context, managedZone string, addRecordSet, removeRecordSet *dns.ResourceRecordSet) error {
 	change := &dns.Change{}
 	if addRecordSet != nil && len(addRecordSet.Rrdatas) > 0 {
 		change.Additions = []*dns.ResourceRecordSet{addRecordSet}
@@ -162,17 +228,19 @@ func (c *gcpClient) UpdateResourceRecordSet(managedZone string, addRecordSet, re
 		change.Deletions = []*dns.ResourceRecordSet{removeRecordSet}
 	}
 	return c.changeResourceRecordSet(
+		ctx,
 		managedZone,
 		change,
 	)
 }
 
-func (c *gcpClient) DeleteResourceRecordSet(managedZone string, recordSet *dns.ResourceRecordSet) error {
-	return c.DeleteResourceRecordSets(managedZone, []*dns.ResourceRecordSet{recordSet})
+func (c *gcpClient) DeleteResourceRecordSet(ctx context.Context, managedZone string, recordSet *dns.ResourceRecordSet) error {
+	return c.DeleteResourceRecordSets(ctx, managedZone, []*dns.ResourceRecordSet{recordSet})
 }
 
-func (c *gcpClient) DeleteResourceRecordSets(managedZone string, recordSets []*dns.ResourceRecordSet) error {
+func (c *gcpClient) DeleteResourceRecordSets(ctx context.Context, managedZone string, recordSets []*dns.ResourceRecordSet) error {
 	return c.changeResourceRecordSet(
+		ctx,
 		managedZone,
 		&dns.Change{
 			Deletions: recordSets,
@@ -180,8 +248,8 @@ func (c *gcpClient) DeleteResourceRecordSets(managedZone string, recordSets []*d
 	)
 }
 
-func (c *gcpClient) changeResourceRecordSet(managedZone string, change *dns.Change) error {
-	ctx, cancel := contextWithTimeout(context.TODO())
+func (c *gcpClient) changeResourceRecordSet(ctx context.Context, managedZone string, change *dns.Change) error {
+	ctx, cancel := contextWithTimeout(ctx)
 	defer cancel()
 	_, err := c.dnsClient.Changes.Create(c.projectName, managedZone, change).Context(ctx).Do()
 	return err
@@ -194,8 +262,8 @@ type ListComputeZonesOptions struct {
 	Filter     string
 }
 
-func (c *gcpClient) ListComputeZones(opts ListComputeZonesOptions) (*compute.ZoneList, error) {
-	ctx, cancel := contextWithTimeout(context.TODO())
+func (c *gcpClient) ListComputeZones(ctx context.Context, opts ListComputeZonesOptions) (*compute.ZoneList, error) {
+	ctx, cancel := contextWithTimeout(ctx)
 	defer cancel()
 
 	call := c.computeClient.Zones.List(c.projectName).Filter(opts.Filter).Context(ctx)
@@ -217,8 +285,8 @@ type ListComputeImagesOptions struct {
 	Filter     string
 }
 
-func (c *gcpClient) ListComputeImages(opts ListComputeImagesOptions) (*compute.ImageList, error) {
-	ctx, cancel := contextWithTimeout(context.TODO())
+func (c *gcpClient) ListComputeImages(ctx context.Context, opts ListComputeImagesOptions) (*compute.ImageList, error) {
+	ctx, cancel := contextWithTimeout(ctx)
 	defer cancel()
 
 	call := c.computeClient.Images.List(c.projectName).Filter(opts.Filter).Context(ctx)
@@ -232,7 +300,7 @@ func (c *gcpClient) ListComputeImages(opts ListComputeImagesOptions) (*compute.I
 	return call.Do()
 }
 
-func (c *gcpClient) ListComputeInstances(opts ListComputeInstancesOptions, pagesFn func(*compute.InstanceAggregatedList) error) error {
+func (c *gcpClient) ListComputeInstances(ctx context.Context, opts ListComputeInstancesOptions, pagesFn func(*compute.InstanceAggregatedList) error) error {
 	req := c.computeClient.Instances.AggregatedList(c.projectName)
 	if len(opts.Fields) > 0 {
 		req.Fields(googleapi.Field(opts.Fields))
@@ -240,14 +308,16 @@ func (c *gcpClient) ListComputeInstances(opts ListComputeInstancesOptions, pages
 	if len(opts.Filter) > 0 {
 		req = req.Filter(opts.Filter)
 	}
-	err := req.Pages(context.TODO(), pagesFn)
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	err := req.Pages(ctx, pagesFn)
 	if err != nil {
 		return errors.Wrapf(err, "failed to fetch compute instances")
 	}
 	return nil
 }
 
-func (c *gcpClient) StopInstance(instance *compute.Instance) error {
+func (c *gcpClient) StopInstance(ctx context.Context, instance *compute.Instance) error {
 	zone := instanceZone(instance)
 	_, err := c.computeClient.Instances.Stop(c.projectName, zone, instance.Name).Do()
 	if err != nil && !isNotModified(err) {
@@ -256,7 +326,7 @@ func (c *gcpClient) StopInstance(instance *compute.Instance) error {
 	return nil
 }
 
-func (c *gcpClient) StartInstance(instance *compute.Instance) error {
+func (c *gcpClient) StartInstance(ctx context.Context, instance *compute.Instance) error {
 	zone := instanceZone(instance)
 	_, err := c.computeClient.Instances.Start(c.projectName, zone, instance.Name).Do()
 	if err != nil && !isNotModified(err) {
@@ -265,21 +335,175 @@ func (c *gcpClient) StartInstance(instance *compute.Instance) error {
 	return nil
 }
 
+func (c *gcpClient) UpdateManagedZone(ctx context.Context, managedZone string, patch *dns.ManagedZone) error {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	_, err := c.dnsClient.ManagedZones.Patch(c.projectName, managedZone, patch).Context(ctx).Do()
+	return err
+}
+
+func (c *gcpClient) GetForwardingRule(ctx context.Context, region, name string) (*compute.ForwardingRule, error) {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.computeClient.ForwardingRules.Get(c.projectName, region, name).Context(ctx).Do()
+}
+
+func (c *gcpClient) CreateForwardingRule(ctx context.Context, region string, rule *compute.ForwardingRule) error {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	_, err := c.computeClient.ForwardingRules.Insert(c.projectName, region, rule).Context(ctx).Do()
+	return err
+}
+
+func (c *gcpClient) DeleteForwardingRule(ctx context.Context, region, name string) error {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	_, err := c.computeClient.ForwardingRules.Delete(c.projectName, region, name).Context(ctx).Do()
+	return err
+}
+
+func (c *gcpClient) GetAddress(ctx context.Context, region, name string) (*compute.Address, error) {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.computeClient.Addresses.Get(c.projectName, region, name).Context(ctx).Do()
+}
+
+func (c *gcpClient) CreateAddress(ctx context.Context, region string, address *compute.Address) error {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	_, err := c.computeClient.Addresses.Insert(c.projectName, region, address).Context(ctx).Do()
+	return err
+}
+
+func (c *gcpClient) DeleteAddress(ctx context.Context, region, name string) error {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	_, err := c.computeClient.Addresses.Delete(c.projectName, region, name).Context(ctx).Do()
+	return err
+}
+
+func (c *gcpClient) GetSubnetwork(ctx context.Context, region, name string) (*compute.Subnetwork, error) {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	return c.computeClient.Subnetworks.Get(c.projectName, region, name).Context(ctx).Do()
+}
+
+func (c *gcpClient) CreateSubnetwork(ctx context.Context, region string, subnet *compute.Subnetwork) error {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	_, err := c.computeClient.Subnetworks.Insert(c.projectName, region, subnet).Context(ctx).Do()
+	return err
+}
+
+func (c *gcpClient) DeleteSubnetwork(ctx context.Context, region, name string) error {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	_, err := c.computeClient.Subnetworks.Delete(c.projectName, region, name).Context(ctx).Do()
+	return err
+}
+
+func (c *gcpClient) GetServiceAttachment(ctx context.Context, region, name string) (*ServiceAttachment, error) {
+	attachment := &ServiceAttachment{}
+	if err := c.doComputeRequest(ctx, http.MethodGet, c.serviceAttachmentsURL(region)+"/"+name, nil, attachment); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func (c *gcpClient) CreateServiceAttachment(ctx context.Context, region string, attachment *ServiceAttachment) error {
+	return c.doComputeRequest(ctx, http.MethodPost, c.serviceAttachmentsURL(region), attachment, nil)
+}
+
+func (c *gcpClient) DeleteServiceAttachment(ctx context.Context, region, name string) error {
+	return c.doComputeRequest(ctx, http.MethodDelete, c.serviceAttachmentsURL(region)+"/"+name, nil, nil)
+}
+
+// TestIamPermissions returns the subset of the permissions that the credentials of the client are granted on the
+// project.
+func (c *gcpClient) TestIamPermissions(ctx context.Context, project string, permissions []string) ([]string, error) {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+	resp, err := c.cloudResourceManagerClient.Projects.TestIamPermissions(project, &cloudresourcemanager.TestIamPermissionsRequest{
+		Permissions: permissions,
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Permissions, nil
+}
+
+func (c *gcpClient) serviceAttachmentsURL(region string) string {
+	return fmt.Sprintf("%sprojects/%s/regions/%s/serviceAttachments", c.computeClient.BasePath, c.projectName, region)
+}
+
+// doComputeRequest sends a request for a compute resource that is not available in the vendored compute library.
+// Errors are returned as *googleapi.Error so that they can be handled like errors from the library.
+func (c *gcpClient) doComputeRequest(ctx context.Context, method, url string, in, out interface{}) error {
+	ctx, cancel := contextWithTimeout(ctx)
+	defer cancel()
+
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", "openshift.io hive/v1")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer googleapi.CloseBody(res)
+	if err := googleapi.CheckResponse(res); err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
 // NewClient creates our client wrapper object for interacting with GCP. The supplied byte slice contains the GCP creds.
 func NewClient(authJSON []byte) (Client, error) {
-	return newClient(authJSONPassthroughSource(authJSON))
+	return newClient(authJSONPassthroughSource(authJSON), Options{})
 }
 
 // NewClientFromSecret creates our client wrapper object for interacting with GCP. The GCP creds are read from the
 // specified secret.
 func NewClientFromSecret(secret *corev1.Secret) (Client, error) {
-	return newClient(authJSONFromSecretSource(secret))
+	return newClient(authJSONFromSecretSource(secret), Options{})
+}
+
+// Options are the options of the client created by NewClientFromSecretWithOptions.
+type Options struct {
+	// Proxy is the proxy through which the client calls GCP. When it is nil, the CloudClientProxy of HiveConfig is
+	// used, if any.
+	Proxy *controllerutils.CloudClientProxy
+
+	// Controller is the name of the controller on behalf of which the client calls GCP, which labels the metrics of
+	// the calls.
+	Controller hivev1.ControllerName
+}
+
+// NewClientFromSecretWithOptions creates our client wrapper object for interacting with GCP with the options. The GCP
+// creds are read from the specified secret.
+func NewClientFromSecretWithOptions(secret *corev1.Secret, options Options) (Client, error) {
+	return newClient(authJSONFromSecretSource(secret), options)
 }
 
 // NewClientFromFile creates our client wrapper object for interacting with GCP. The GCP creds are read from the
 // specified file.
 func NewClientFromFile(filename string) (Client, error) {
-	return newClient(authJSONFromFileSource(filename))
+	return newClient(authJSONFromFileSource(filename), Options{})
 }
 
 // ProjectID returns the GCP project ID specified in the GCP creds. The supplied byte slice contains the GCP creds.
@@ -311,42 +535,60 @@ func projectID(authJSONSource func() ([]byte, error)) (string, error) {
 	return creds.ProjectID, nil
 }
 
-func newClient(authJSONSource func() ([]byte, error)) (*gcpClient, error) {
+// newClient creates the client with the creds of the source. The calls go through the proxy of the options, or through
+// the CloudClientProxy of HiveConfig when the proxy is nil.
+func newClient(authJSONSource func() ([]byte, error), opts Options) (*gcpClient, error) {
 	ctx := context.TODO()
 
 	authJSON, err := authJSONSource()
 	if err != nil {
 		return nil, err
 	}
+	proxy := opts.Proxy
+	if proxy == nil {
+		if proxy, err = controllerutils.ReadCloudClientProxyFiles(); err != nil {
+			return nil, err
+		}
+	}
+	httpClient, err := controllerutils.NewCloudAPIHTTPClient(proxy, opts.Controller, controllerutils.CloudGCP)
+	if err != nil {
+		return nil, err
+	}
+	// The tokens are fetched with the HTTP client of the context.
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
 	// since we're using a single creds var, we should specify all the required scopes when initializing
 	creds, err := google.CredentialsFromJSON(ctx, authJSON, dns.CloudPlatformScope)
 	if err != nil {
 		return nil, err
 	}
 
+	// The user agent option only applies to the clients created by the services, so it is set on the services.
 	options := []option.ClientOption{
-		option.WithCredentials(creds),
-		option.WithUserAgent("openshift.io hive/v1"),
+		option.WithHTTPClient(oauth2.NewClient(ctx, creds.TokenSource)),
 	}
 	cloudResourceManagerClient, err := cloudresourcemanager.NewService(ctx, options...)
 	if err != nil {
 		return nil, err
 	}
+	cloudResourceManagerClient.UserAgent = userAgent
 
 	computeClient, err := compute.NewService(ctx, options...)
 	if err != nil {
 		return nil, err
 	}
+	computeClient.UserAgent = userAgent
 
 	serviceUsageClient, err := serviceusage.NewService(ctx, options...)
 	if err != nil {
 		return nil, err
 	}
+	serviceUsageClient.UserAgent = userAgent
 
 	dnsClient, err := dns.NewService(ctx, options...)
 	if err != nil {
 		return nil, err
 	}
+	dnsClient.UserAgent = userAgent
 
 	return &gcpClient{
 		projectName:                creds.ProjectID,
@@ -355,6 +597,7 @@ func newClient(authJSONSource func() ([]byte, error)) (*gcpClient, error) {
 		computeClient:              computeClient,
 		serviceUsageClient:         serviceUsageClient,
 		dnsClient:                  dnsClient,
+		httpClient:                 oauth2.NewClient(ctx, creds.TokenSource),
 	}, nil
 }
 
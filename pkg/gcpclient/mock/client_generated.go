@@ -5,6 +5,7 @@
 package mock
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	gcpclient "github.com/openshift/hive/pkg/gcpclient"
 	compute "google.golang.org/api/compute/v1"
@@ -36,203 +37,404 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 }
 
 // ListManagedZones mocks base method
-func (m *MockClient) ListManagedZones(opts gcpclient.ListManagedZonesOptions) (*dns.ManagedZonesListResponse, error) {
+func (m *MockClient) ListManagedZones(ctx context.Context, opts gcpclient.ListManagedZonesOptions) (*dns.ManagedZonesListResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListManagedZones", opts)
+	ret := m.ctrl.Call(m, "ListManagedZones", ctx, opts)
 	ret0, _ := ret[0].(*dns.ManagedZonesListResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListManagedZones indicates an expected call of ListManagedZones
-func (mr *MockClientMockRecorder) ListManagedZones(opts interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) ListManagedZones(ctx, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListManagedZones", reflect.TypeOf((*MockClient)(nil).ListManagedZones), opts)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListManagedZones", reflect.TypeOf((*MockClient)(nil).ListManagedZones), ctx, opts)
 }
 
 // ListResourceRecordSets mocks base method
-func (m *MockClient) ListResourceRecordSets(managedZone string, opts gcpclient.ListResourceRecordSetsOptions) (*dns.ResourceRecordSetsListResponse, error) {
+func (m *MockClient) ListResourceRecordSets(ctx context.Context, managedZone string, opts gcpclient.ListResourceRecordSetsOptions) (*dns.ResourceRecordSetsListResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListResourceRecordSets", managedZone, opts)
+	ret := m.ctrl.Call(m, "ListResourceRecordSets", ctx, managedZone, opts)
 	ret0, _ := ret[0].(*dns.ResourceRecordSetsListResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListResourceRecordSets indicates an expected call of ListResourceRecordSets
-func (mr *MockClientMockRecorder) ListResourceRecordSets(managedZone, opts interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) ListResourceRecordSets(ctx, managedZone, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourceRecordSets", reflect.TypeOf((*MockClient)(nil).ListResourceRecordSets), managedZone, opts)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourceRecordSets", reflect.TypeOf((*MockClient)(nil).ListResourceRecordSets), ctx, managedZone, opts)
 }
 
 // AddResourceRecordSet mocks base method
-func (m *MockClient) AddResourceRecordSet(managedZone string, recordSet *dns.ResourceRecordSet) error {
+func (m *MockClient) AddResourceRecordSet(ctx context.Context, managedZone string, recordSet *dns.ResourceRecordSet) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddResourceRecordSet", managedZone, recordSet)
+	ret := m.ctrl.Call(m, "AddResourceRecordSet", ctx, managedZone, recordSet)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddResourceRecordSet indicates an expected call of AddResourceRecordSet
-func (mr *MockClientMockRecorder) AddResourceRecordSet(managedZone, recordSet interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) AddResourceRecordSet(ctx, managedZone, recordSet interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddResourceRecordSet", reflect.TypeOf((*MockClient)(nil).AddResourceRecordSet), managedZone, recordSet)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddResourceRecordSet", reflect.TypeOf((*MockClient)(nil).AddResourceRecordSet), ctx, managedZone, recordSet)
 }
 
 // DeleteResourceRecordSet mocks base method
-func (m *MockClient) DeleteResourceRecordSet(managedZone string, recordSet *dns.ResourceRecordSet) error {
+func (m *MockClient) DeleteResourceRecordSet(ctx context.Context, managedZone string, recordSet *dns.ResourceRecordSet) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteResourceRecordSet", managedZone, recordSet)
+	ret := m.ctrl.Call(m, "DeleteResourceRecordSet", ctx, managedZone, recordSet)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteResourceRecordSet indicates an expected call of DeleteResourceRecordSet
-func (mr *MockClientMockRecorder) DeleteResourceRecordSet(managedZone, recordSet interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DeleteResourceRecordSet(ctx, managedZone, recordSet interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResourceRecordSet", reflect.TypeOf((*MockClient)(nil).DeleteResourceRecordSet), managedZone, recordSet)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResourceRecordSet", reflect.TypeOf((*MockClient)(nil).DeleteResourceRecordSet), ctx, managedZone, recordSet)
 }
 
 // DeleteResourceRecordSets mocks base method
-func (m *MockClient) DeleteResourceRecordSets(managedZone string, recordSet []*dns.ResourceRecordSet) error {
+func (m *MockClient) DeleteResourceRecordSets(ctx context.Context, managedZone string, recordSet []*dns.ResourceRecordSet) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteResourceRecordSets", managedZone, recordSet)
+	ret := m.ctrl.Call(m, "DeleteResourceRecordSets", ctx, managedZone, recordSet)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteResourceRecordSets indicates an expected call of DeleteResourceRecordSets
-func (mr *MockClientMockRecorder) DeleteResourceRecordSets(managedZone, recordSet interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DeleteResourceRecordSets(ctx, managedZone, recordSet interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResourceRecordSets", reflect.TypeOf((*MockClient)(nil).DeleteResourceRecordSets), managedZone, recordSet)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResourceRecordSets", reflect.TypeOf((*MockClient)(nil).DeleteResourceRecordSets), ctx, managedZone, recordSet)
 }
 
 // UpdateResourceRecordSet mocks base method
-func (m *MockClient) UpdateResourceRecordSet(managedZone string, addRecordSet, removeRecordSet *dns.ResourceRecordSet) error {
+func (m *MockClient) UpdateResourceRecordSet(ctx context.Context, managedZone string, addRecordSet, removeRecordSet *dns.ResourceRecordSet) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateResourceRecordSet", managedZone, addRecordSet, removeRecordSet)
+	ret := m.ctrl.Call(m, "UpdateResourceRecordSet", ctx, managedZone, addRecordSet, removeRecordSet)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateResourceRecordSet indicates an expected call of UpdateResourceRecordSet
-func (mr *MockClientMockRecorder) UpdateResourceRecordSet(managedZone, addRecordSet, removeRecordSet interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) UpdateResourceRecordSet(ctx, managedZone, addRecordSet, removeRecordSet interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateResourceRecordSet", reflect.TypeOf((*MockClient)(nil).UpdateResourceRecordSet), managedZone, addRecordSet, removeRecordSet)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateResourceRecordSet", reflect.TypeOf((*MockClient)(nil).UpdateResourceRecordSet), ctx, managedZone, addRecordSet, removeRecordSet)
 }
 
 // GetManagedZone mocks base method
-func (m *MockClient) GetManagedZone(managedZone string) (*dns.ManagedZone, error) {
+func (m *MockClient) GetManagedZone(ctx context.Context, managedZone string) (*dns.ManagedZone, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetManagedZone", managedZone)
+	ret := m.ctrl.Call(m, "GetManagedZone", ctx, managedZone)
 	ret0, _ := ret[0].(*dns.ManagedZone)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetManagedZone indicates an expected call of GetManagedZone
-func (mr *MockClientMockRecorder) GetManagedZone(managedZone interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) GetManagedZone(ctx, managedZone interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManagedZone", reflect.TypeOf((*MockClient)(nil).GetManagedZone), managedZone)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManagedZone", reflect.TypeOf((*MockClient)(nil).GetManagedZone), ctx, managedZone)
 }
 
 // CreateManagedZone mocks base method
-func (m *MockClient) CreateManagedZone(managedZone *dns.ManagedZone) (*dns.ManagedZone, error) {
+func (m *MockClient) CreateManagedZone(ctx context.Context, managedZone *dns.ManagedZone) (*dns.ManagedZone, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateManagedZone", managedZone)
+	ret := m.ctrl.Call(m, "CreateManagedZone", ctx, managedZone)
 	ret0, _ := ret[0].(*dns.ManagedZone)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateManagedZone indicates an expected call of CreateManagedZone
-func (mr *MockClientMockRecorder) CreateManagedZone(managedZone interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) CreateManagedZone(ctx, managedZone interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateManagedZone", reflect.TypeOf((*MockClient)(nil).CreateManagedZone), managedZone)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateManagedZone", reflect.TypeOf((*MockClient)(nil).CreateManagedZone), ctx, managedZone)
 }
 
 // DeleteManagedZone mocks base method
-func (m *MockClient) DeleteManagedZone(managedZone string) error {
+func (m *MockClient) DeleteManagedZone(ctx context.Context, managedZone string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteManagedZone", managedZone)
+	ret := m.ctrl.Call(m, "DeleteManagedZone", ctx, managedZone)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteManagedZone indicates an expected call of DeleteManagedZone
-func (mr *MockClientMockRecorder) DeleteManagedZone(managedZone interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DeleteManagedZone(ctx, managedZone interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteManagedZone", reflect.TypeOf((*MockClient)(nil).DeleteManagedZone), managedZone)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteManagedZone", reflect.TypeOf((*MockClient)(nil).DeleteManagedZone), ctx, managedZone)
 }
 
 // ListComputeZones mocks base method
-func (m *MockClient) ListComputeZones(arg0 gcpclient.ListComputeZonesOptions) (*compute.ZoneList, error) {
+func (m *MockClient) ListComputeZones(arg0 context.Context, arg1 gcpclient.ListComputeZonesOptions) (*compute.ZoneList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListComputeZones", arg0)
+	ret := m.ctrl.Call(m, "ListComputeZones", arg0, arg1)
 	ret0, _ := ret[0].(*compute.ZoneList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListComputeZones indicates an expected call of ListComputeZones
-func (mr *MockClientMockRecorder) ListComputeZones(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) ListComputeZones(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeZones", reflect.TypeOf((*MockClient)(nil).ListComputeZones), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeZones", reflect.TypeOf((*MockClient)(nil).ListComputeZones), arg0, arg1)
 }
 
 // ListComputeImages mocks base method
-func (m *MockClient) ListComputeImages(arg0 gcpclient.ListComputeImagesOptions) (*compute.ImageList, error) {
+func (m *MockClient) ListComputeImages(arg0 context.Context, arg1 gcpclient.ListComputeImagesOptions) (*compute.ImageList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListComputeImages", arg0)
+	ret := m.ctrl.Call(m, "ListComputeImages", arg0, arg1)
 	ret0, _ := ret[0].(*compute.ImageList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListComputeImages indicates an expected call of ListComputeImages
-func (mr *MockClientMockRecorder) ListComputeImages(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) ListComputeImages(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeImages", reflect.TypeOf((*MockClient)(nil).ListComputeImages), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeImages", reflect.TypeOf((*MockClient)(nil).ListComputeImages), arg0, arg1)
 }
 
 // ListComputeInstances mocks base method
-func (m *MockClient) ListComputeInstances(arg0 gcpclient.ListComputeInstancesOptions, arg1 func(*compute.InstanceAggregatedList) error) error {
+func (m *MockClient) ListComputeInstances(arg0 context.Context, arg1 gcpclient.ListComputeInstancesOptions, arg2 func(*compute.InstanceAggregatedList) error) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListComputeInstances", arg0, arg1)
+	ret := m.ctrl.Call(m, "ListComputeInstances", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ListComputeInstances indicates an expected call of ListComputeInstances
-func (mr *MockClientMockRecorder) ListComputeInstances(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) ListComputeInstances(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeInstances", reflect.TypeOf((*MockClient)(nil).ListComputeInstances), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeInstances", reflect.TypeOf((*MockClient)(nil).ListComputeInstances), arg0, arg1, arg2)
 }
 
 // StopInstance mocks base method
-func (m *MockClient) StopInstance(arg0 *compute.Instance) error {
+func (m *MockClient) StopInstance(arg0 context.Context, arg1 *compute.Instance) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StopInstance", arg0)
+	ret := m.ctrl.Call(m, "StopInstance", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // StopInstance indicates an expected call of StopInstance
-func (mr *MockClientMockRecorder) StopInstance(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) StopInstance(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopInstance", reflect.TypeOf((*MockClient)(nil).StopInstance), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopInstance", reflect.TypeOf((*MockClient)(nil).StopInstance), arg0, arg1)
 }
 
 // StartInstance mocks base method
-func (m *MockClient) StartInstance(arg0 *compute.Instance) error {
+func (m *MockClient) StartInstance(arg0 context.Context, arg1 *compute.Instance) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StartInstance", arg0)
+	ret := m.ctrl.Call(m, "StartInstance", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // StartInstance indicates an expected call of StartInstance
-func (mr *MockClientMockRecorder) StartInstance(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) StartInstance(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartInstance", reflect.TypeOf((*MockClient)(nil).StartInstance), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartInstance", reflect.TypeOf((*MockClient)(nil).StartInstance), arg0, arg1)
+}
+
+// UpdateManagedZone mocks base method
+func (m *MockClient) UpdateManagedZone(ctx context.Context, managedZone string, patch *dns.ManagedZone) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateManagedZone", ctx, managedZone, patch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateManagedZone indicates an expected call of UpdateManagedZone
+func (mr *MockClientMockRecorder) UpdateManagedZone(ctx, managedZone, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateManagedZone", reflect.TypeOf((*MockClient)(nil).UpdateManagedZone), ctx, managedZone, patch)
+}
+
+// GetForwardingRule mocks base method
+func (m *MockClient) GetForwardingRule(ctx context.Context, region, name string) (*compute.ForwardingRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForwardingRule", ctx, region, name)
+	ret0, _ := ret[0].(*compute.ForwardingRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForwardingRule indicates an expected call of GetForwardingRule
+func (mr *MockClientMockRecorder) GetForwardingRule(ctx, region, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForwardingRule", reflect.TypeOf((*MockClient)(nil).GetForwardingRule), ctx, region, name)
+}
+
+// CreateForwardingRule mocks base method
+func (m *MockClient) CreateForwardingRule(ctx context.Context, region string, rule *compute.ForwardingRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateForwardingRule", ctx, region, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateForwardingRule indicates an expected call of CreateForwardingRule
+func (mr *MockClientMockRecorder) CreateForwardingRule(ctx, region, rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateForwardingRule", reflect.TypeOf((*MockClient)(nil).CreateForwardingRule), ctx, region, rule)
+}
+
+// DeleteForwardingRule mocks base method
+func (m *MockClient) DeleteForwardingRule(ctx context.Context, region, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteForwardingRule", ctx, region, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteForwardingRule indicates an expected call of DeleteForwardingRule
+func (mr *MockClientMockRecorder) DeleteForwardingRule(ctx, region, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteForwardingRule", reflect.TypeOf((*MockClient)(nil).DeleteForwardingRule), ctx, region, name)
+}
+
+// GetAddress mocks base method
+func (m *MockClient) GetAddress(ctx context.Context, region, name string) (*compute.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddress", ctx, region, name)
+	ret0, _ := ret[0].(*compute.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddress indicates an expected call of GetAddress
+func (mr *MockClientMockRecorder) GetAddress(ctx, region, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddress", reflect.TypeOf((*MockClient)(nil).GetAddress), ctx, region, name)
+}
+
+// CreateAddress mocks base method
+func (m *MockClient) CreateAddress(ctx context.Context, region string, address *compute.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAddress", ctx, region, address)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAddress indicates an expected call of CreateAddress
+func (mr *MockClientMockRecorder) CreateAddress(ctx, region, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAddress", reflect.TypeOf((*MockClient)(nil).CreateAddress), ctx, region, address)
+}
+
+// DeleteAddress mocks base method
+func (m *MockClient) DeleteAddress(ctx context.Context, region, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAddress", ctx, region, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAddress indicates an expected call of DeleteAddress
+func (mr *MockClientMockRecorder) DeleteAddress(ctx, region, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAddress", reflect.TypeOf((*MockClient)(nil).DeleteAddress), ctx, region, name)
+}
+
+// GetSubnetwork mocks base method
+func (m *MockClient) GetSubnetwork(ctx context.Context, region, name string) (*compute.Subnetwork, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetwork", ctx, region, name)
+	ret0, _ := ret[0].(*compute.Subnetwork)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetwork indicates an expected call of GetSubnetwork
+func (mr *MockClientMockRecorder) GetSubnetwork(ctx, region, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetwork", reflect.TypeOf((*MockClient)(nil).GetSubnetwork), ctx, region, name)
+}
+
+// CreateSubnetwork mocks base method
+func (m *MockClient) CreateSubnetwork(ctx context.Context, region string, subnet *compute.Subnetwork) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubnetwork", ctx, region, subnet)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSubnetwork indicates an expected call of CreateSubnetwork
+func (mr *MockClientMockRecorder) CreateSubnetwork(ctx, region, subnet interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubnetwork", reflect.TypeOf((*MockClient)(nil).CreateSubnetwork), ctx, region, subnet)
+}
+
+// DeleteSubnetwork mocks base method
+func (m *MockClient) DeleteSubnetwork(ctx context.Context, region, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubnetwork", ctx, region, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubnetwork indicates an expected call of DeleteSubnetwork
+func (mr *MockClientMockRecorder) DeleteSubnetwork(ctx, region, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubnetwork", reflect.TypeOf((*MockClient)(nil).DeleteSubnetwork), ctx, region, name)
+}
+
+// GetServiceAttachment mocks base method
+func (m *MockClient) GetServiceAttachment(ctx context.Context, region, name string) (*gcpclient.ServiceAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceAttachment", ctx, region, name)
+	ret0, _ := ret[0].(*gcpclient.ServiceAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceAttachment indicates an expected call of GetServiceAttachment
+func (mr *MockClientMockRecorder) GetServiceAttachment(ctx, region, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceAttachment", reflect.TypeOf((*MockClient)(nil).GetServiceAttachment), ctx, region, name)
+}
+
+// CreateServiceAttachment mocks base method
+func (m *MockClient) CreateServiceAttachment(ctx context.Context, region string, attachment *gcpclient.ServiceAttachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateServiceAttachment", ctx, region, attachment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateServiceAttachment indicates an expected call of CreateServiceAttachment
+func (mr *MockClientMockRecorder) CreateServiceAttachment(ctx, region, attachment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServiceAttachment", reflect.TypeOf((*MockClient)(nil).CreateServiceAttachment), ctx, region, attachment)
+}
+
+// DeleteServiceAttachment mocks base method
+func (m *MockClient) DeleteServiceAttachment(ctx context.Context, region, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteServiceAttachment", ctx, region, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteServiceAttachment indicates an expected call of DeleteServiceAttachment
+func (mr *MockClientMockRecorder) DeleteServiceAttachment(ctx, region, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServiceAttachment", reflect.TypeOf((*MockClient)(nil).DeleteServiceAttachment), ctx, region, name)
+}
+
+// TestIamPermissions mocks base method
+func (m *MockClient) TestIamPermissions(ctx context.Context, project string, permissions []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TestIamPermissions", ctx, project, permissions)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TestIamPermissions indicates an expected call of TestIamPermissions
+func (mr *MockClientMockRecorder) TestIamPermissions(ctx, project, permissions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestIamPermissions", reflect.TypeOf((*MockClient)(nil).TestIamPermissions), ctx, project, permissions)
 }
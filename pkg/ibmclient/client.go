@@ -0,0 +1,332 @@
+package ibmclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+//go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
+
+// Client is a wrapper object for the IBM Cloud Internet Services (CIS) API to allow for easier mocking/testing.
+// The CIS instance of each call is identified by its CRN.
+type Client interface {
+	// Zones
+	GetZone(ctx context.Context, crn string, zoneID string) (*Zone, error)
+	ListZones(ctx context.Context, crn string) ([]Zone, error)
+	CreateZone(ctx context.Context, crn string, name string) (*Zone, error)
+	DeleteZone(ctx context.Context, crn string, zoneID string) error
+
+	// DNS Records
+	ListDNSRecords(ctx context.Context, crn string, zoneID string) ([]DNSRecord, error)
+	DeleteDNSRecord(ctx context.Context, crn string, zoneID string, recordID string) error
+}
+
+// Zone is a zone, or domain, in IBM Cloud Internet Services.
+type Zone struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+
+	// Status is the status of the zone. Zones are pending until they are delegated to their name servers, and are
+	// active after.
+	Status string `json:"status"`
+
+	NameServers []string `json:"name_servers"`
+}
+
+// DNSRecord is a DNS record of a zone in IBM Cloud Internet Services.
+type DNSRecord struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+}
+
+// Error is an error returned by the IBM Cloud Internet Services API.
+type Error struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Messages are the error messages in the response.
+	Messages []string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("IBM Cloud API returned status %d: %s", e.StatusCode, strings.Join(e.Messages, "; "))
+}
+
+// IsNotFound returns whether the error is an error from the API for a resource that does not exist.
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsUnauthorized returns whether the error is an error from the API, or from IAM when getting a token, for an API key
+// which is not valid or not allowed to make the call.
+func IsUnauthorized(err error) bool {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	var tokenErr *tokenError
+	if errors.As(err, &tokenErr) {
+		// IAM rejects API keys which are not valid with client errors, mostly 400 Bad Request.
+		return apiErr.StatusCode >= http.StatusBadRequest && apiErr.StatusCode < http.StatusInternalServerError
+	}
+	return apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden
+}
+
+// tokenError is an error getting an IAM token for the API key.
+type tokenError struct {
+	err error
+}
+
+func (e *tokenError) Error() string {
+	return fmt.Sprintf("failed to get IAM token: %v", e.err)
+}
+
+func (e *tokenError) Unwrap() error {
+	return e.err
+}
+
+const (
+	defaultCISEndpoint = "https://api.cis.cloud.ibm.com/v1"
+	defaultIAMEndpoint = "https://iam.cloud.ibm.com/identity/token"
+
+	// defaultCallTimeout is the timeout of each API call, so that a stuck call does not block the caller until its
+	// context is done.
+	defaultCallTimeout = 2 * time.Minute
+
+	// pageSize is the number of results requested per page when listing.
+	pageSize = 100
+)
+
+type ibmClient struct {
+	cisEndpoint string
+	httpClient  *http.Client
+	tokenSource oauth2.TokenSource
+}
+
+// NewClientFromSecret creates our client wrapper object for interacting with IBM Cloud. The API key is read from the
+// specified secret.
+func NewClientFromSecret(secret *corev1.Secret) (Client, error) {
+	apiKey, ok := secret.Data[constants.IBMCloudAPIKeySecretKey]
+	if !ok {
+		return nil, fmt.Errorf("secret does not contain %q data", constants.IBMCloudAPIKeySecretKey)
+	}
+	return NewClient(strings.TrimSpace(string(apiKey))), nil
+}
+
+// NewClient creates our client wrapper object for interacting with IBM Cloud using the API key provided.
+func NewClient(apiKey string) Client {
+	return newClient(apiKey, defaultCISEndpoint, defaultIAMEndpoint)
+}
+
+func newClient(apiKey, cisEndpoint, iamEndpoint string) *ibmClient {
+	httpClient := &http.Client{Timeout: defaultCallTimeout}
+	return &ibmClient{
+		cisEndpoint: cisEndpoint,
+		httpClient:  httpClient,
+		// The IAM token is reused until shortly before it expires.
+		tokenSource: oauth2.ReuseTokenSource(nil, &iamTokenSource{
+			apiKey:     apiKey,
+			endpoint:   iamEndpoint,
+			httpClient: httpClient,
+		}),
+	}
+}
+
+func (c *ibmClient) GetZone(ctx context.Context, crn string, zoneID string) (*Zone, error) {
+	zone := &Zone{}
+	if _, err := c.do(ctx, http.MethodGet, zonesPath(crn)+"/"+url.PathEscape(zoneID), nil, zone); err != nil {
+		return nil, err
+	}
+	return zone, nil
+}
+
+func (c *ibmClient) ListZones(ctx context.Context, crn string) ([]Zone, error) {
+	var zones []Zone
+	for page := 1; ; page++ {
+		var pageZones []Zone
+		info, err := c.do(ctx, http.MethodGet, pagedPath(zonesPath(crn), page), nil, &pageZones)
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, pageZones...)
+		if info == nil || page >= info.TotalPages {
+			return zones, nil
+		}
+	}
+}
+
+func (c *ibmClient) CreateZone(ctx context.Context, crn string, name string) (*Zone, error) {
+	zone := &Zone{}
+	if _, err := c.do(ctx, http.MethodPost, zonesPath(crn), map[string]string{"name": name}, zone); err != nil {
+		return nil, err
+	}
+	return zone, nil
+}
+
+func (c *ibmClient) DeleteZone(ctx context.Context, crn string, zoneID string) error {
+	_, err := c.do(ctx, http.MethodDelete, zonesPath(crn)+"/"+url.PathEscape(zoneID), nil, nil)
+	return err
+}
+
+func (c *ibmClient) ListDNSRecords(ctx context.Context, crn string, zoneID string) ([]DNSRecord, error) {
+	var records []DNSRecord
+	for page := 1; ; page++ {
+		var pageRecords []DNSRecord
+		info, err := c.do(ctx, http.MethodGet, pagedPath(dnsRecordsPath(crn, zoneID), page), nil, &pageRecords)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, pageRecords...)
+		if info == nil || page >= info.TotalPages {
+			return records, nil
+		}
+	}
+}
+
+func (c *ibmClient) DeleteDNSRecord(ctx context.Context, crn string, zoneID string, recordID string) error {
+	_, err := c.do(ctx, http.MethodDelete, dnsRecordsPath(crn, zoneID)+"/"+url.PathEscape(recordID), nil, nil)
+	return err
+}
+
+func zonesPath(crn string) string {
+	return "/" + url.PathEscape(crn) + "/zones"
+}
+
+func dnsRecordsPath(crn, zoneID string) string {
+	return zonesPath(crn) + "/" + url.PathEscape(zoneID) + "/dns_records"
+}
+
+func pagedPath(path string, page int) string {
+	return fmt.Sprintf("%s?page=%d&per_page=%d", path, page, pageSize)
+}
+
+// response is the envelope of all responses of the CIS API.
+type response struct {
+	Success bool `json:"success"`
+	Errors  []struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+	Result     json.RawMessage `json:"result"`
+	ResultInfo *resultInfo     `json:"result_info"`
+}
+
+// resultInfo is the pagination information of list responses.
+type resultInfo struct {
+	Page       int `json:"page"`
+	TotalPages int `json:"total_pages"`
+}
+
+// do sends a request to the CIS API, and decodes the result of the response into out. Unsuccessful responses are
+// returned as *Error.
+func (c *ibmClient) do(ctx context.Context, method, path string, in, out interface{}) (*resultInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultCallTimeout)
+	defer cancel()
+
+	token, err := c.tokenSource.Token()
+	if err != nil {
+		return nil, &tokenError{err: err}
+	}
+
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.cisEndpoint+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "openshift.io hive/v1")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Auth-User-Token", token.Type()+" "+token.AccessToken)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	resp := &response{}
+	if err := json.NewDecoder(res.Body).Decode(resp); err != nil && res.StatusCode < http.StatusMultipleChoices {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if res.StatusCode >= http.StatusMultipleChoices || !resp.Success {
+		apiErr := &Error{StatusCode: res.StatusCode}
+		for _, e := range resp.Errors {
+			apiErr.Messages = append(apiErr.Messages, fmt.Sprintf("%d: %s", e.Code, e.Message))
+		}
+		return nil, apiErr
+	}
+	if out != nil && len(resp.Result) > 0 {
+		if err := json.Unmarshal(resp.Result, out); err != nil {
+			return nil, fmt.Errorf("failed to decode result: %w", err)
+		}
+	}
+	return resp.ResultInfo, nil
+}
+
+// iamTokenSource gets IAM access tokens for an IBM Cloud API key.
+type iamTokenSource struct {
+	apiKey     string
+	endpoint   string
+	httpClient *http.Client
+}
+
+// Token implements oauth2.TokenSource.
+func (s *iamTokenSource) Token() (*oauth2.Token, error) {
+	form := url.Values{
+		"grant_type": {"urn:ibm:params:oauth:grant-type:apikey"},
+		"apikey":     {s.apiKey},
+	}
+	req, err := http.NewRequest(http.MethodPost, s.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var tokenResp struct {
+		AccessToken  string `json:"access_token"`
+		TokenType    string `json:"token_type"`
+		Expiration   int64  `json:"expiration"`
+		ErrorMessage string `json:"errorMessage"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&tokenResp); err != nil && res.StatusCode == http.StatusOK {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, &Error{StatusCode: res.StatusCode, Messages: []string{tokenResp.ErrorMessage}}
+	}
+	return &oauth2.Token{
+		AccessToken: tokenResp.AccessToken,
+		TokenType:   tokenResp.TokenType,
+		Expiry:      time.Unix(tokenResp.Expiration, 0),
+	}, nil
+}
@@ -0,0 +1,85 @@
+package ibmclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCRN = "crn:v1:bluemix:public:internet-svcs:global:a/account:instance::"
+
+func newTestServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/identity/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		if r.Form.Get("apikey") != "apikey" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"errorMessage":"invalid API key"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"access_token":"token","token_type":"Bearer","expiration":%d}`, time.Now().Add(time.Hour).Unix())
+	})
+	mux.HandleFunc("/v1/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Auth-User-Token") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"success":false,"errors":[{"code":10000,"message":"Authentication error"}]}`)
+			return
+		}
+		switch r.URL.EscapedPath() {
+		case "/v1/crn:v1:bluemix:public:internet-svcs:global:a%2Faccount:instance::/zones":
+			page := r.URL.Query().Get("page")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success":     true,
+				"result":      []Zone{{ID: "zone-" + page, Name: "zone" + page + ".example.com"}},
+				"result_info": map[string]int{"page": 1, "total_pages": 2},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"success":false,"errors":[{"code":1001,"message":"Invalid zone identifier"}]}`)
+		}
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestListZones(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	c := newClient("apikey", server.URL+"/v1", server.URL+"/identity/token")
+	zones, err := c.ListZones(context.Background(), testCRN)
+	require.NoError(t, err)
+	assert.Equal(t, []Zone{
+		{ID: "zone-1", Name: "zone1.example.com"},
+		{ID: "zone-2", Name: "zone2.example.com"},
+	}, zones, "expected the zones of all pages")
+}
+
+func TestGetZoneNotFound(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	c := newClient("apikey", server.URL+"/v1", server.URL+"/identity/token")
+	_, err := c.GetZone(context.Background(), testCRN, "missing")
+	require.Error(t, err)
+	assert.True(t, IsNotFound(err), "expected a not found error")
+	assert.Contains(t, err.Error(), "Invalid zone identifier")
+}
+
+func TestInvalidAPIKey(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	c := newClient("wrong", server.URL+"/v1", server.URL+"/identity/token")
+	_, err := c.ListZones(context.Background(), testCRN)
+	require.Error(t, err)
+	assert.False(t, IsNotFound(err))
+	assert.True(t, IsUnauthorized(err), "expected an unauthorized error")
+	assert.Contains(t, err.Error(), "invalid API key")
+}
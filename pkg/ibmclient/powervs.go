@@ -0,0 +1,281 @@
+package ibmclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// PowerVSClient is a wrapper object for the IBM Power Virtual Server (PowerVS) API of a workspace, and the IBM Cloud
+// resource controller API, to allow for easier mocking/testing.
+type PowerVSClient interface {
+	// Resource instances
+	GetResourceInstance(ctx context.Context, guid string) (*ResourceInstance, error)
+	ListResourceInstances(ctx context.Context, name string) ([]ResourceInstance, error)
+	DeleteResourceInstance(ctx context.Context, guid string) error
+
+	// Instances of the workspace
+	ListInstances(ctx context.Context) ([]PowerVSResource, error)
+	DeleteInstance(ctx context.Context, id string) error
+
+	// Images of the workspace
+	ListImages(ctx context.Context) ([]PowerVSResource, error)
+	DeleteImage(ctx context.Context, id string) error
+
+	// Networks of the workspace
+	ListNetworks(ctx context.Context) ([]PowerVSResource, error)
+	DeleteNetwork(ctx context.Context, id string) error
+}
+
+// ResourceInstance is an instance of an IBM Cloud service, such as a Power Virtual Server workspace or a Cloud Object
+// Storage instance.
+type ResourceInstance struct {
+	GUID  string `json:"guid"`
+	CRN   string `json:"crn"`
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+// PowerVSResource is an instance, image or network of a Power Virtual Server workspace.
+type PowerVSResource struct {
+	ID   string
+	Name string
+}
+
+const (
+	powerVSEndpointFormat             = "https://%s.power-iaas.cloud.ibm.com/pcloud/v1"
+	defaultResourceControllerEndpoint = "https://resource-controller.cloud.ibm.com/v2"
+)
+
+type powerVSClient struct {
+	powerVSEndpoint            string
+	resourceControllerEndpoint string
+	serviceInstanceID          string
+	httpClient                 *http.Client
+	tokenSource                oauth2.TokenSource
+
+	// crn is the CRN of the workspace, which the PowerVS API requires with each call. It is looked up on first use.
+	crnLock sync.Mutex
+	crn     string
+}
+
+// NewPowerVSClientFromSecret creates our client wrapper object for interacting with the Power Virtual Server
+// workspace with the GUID in the region. The API key is read from the specified secret.
+func NewPowerVSClientFromSecret(secret *corev1.Secret, region, serviceInstanceID string) (PowerVSClient, error) {
+	apiKey, ok := secret.Data[constants.IBMCloudAPIKeySecretKey]
+	if !ok {
+		return nil, fmt.Errorf("secret does not contain %q data", constants.IBMCloudAPIKeySecretKey)
+	}
+	return NewPowerVSClient(strings.TrimSpace(string(apiKey)), region, serviceInstanceID), nil
+}
+
+// NewPowerVSClient creates our client wrapper object for interacting with the Power Virtual Server workspace with the
+// GUID in the region using the API key provided.
+func NewPowerVSClient(apiKey, region, serviceInstanceID string) PowerVSClient {
+	return newPowerVSClient(apiKey, serviceInstanceID, fmt.Sprintf(powerVSEndpointFormat, region), defaultResourceControllerEndpoint, defaultIAMEndpoint)
+}
+
+func newPowerVSClient(apiKey, serviceInstanceID, powerVSEndpoint, resourceControllerEndpoint, iamEndpoint string) *powerVSClient {
+	httpClient := &http.Client{Timeout: defaultCallTimeout}
+	return &powerVSClient{
+		powerVSEndpoint:            powerVSEndpoint,
+		resourceControllerEndpoint: resourceControllerEndpoint,
+		serviceInstanceID:          serviceInstanceID,
+		httpClient:                 httpClient,
+		// The IAM token is reused until shortly before it expires.
+		tokenSource: oauth2.ReuseTokenSource(nil, &iamTokenSource{
+			apiKey:     apiKey,
+			endpoint:   iamEndpoint,
+			httpClient: httpClient,
+		}),
+	}
+}
+
+func (c *powerVSClient) GetResourceInstance(ctx context.Context, guid string) (*ResourceInstance, error) {
+	instance := &ResourceInstance{}
+	if err := c.do(ctx, http.MethodGet, c.resourceControllerEndpoint+"/resource_instances/"+url.PathEscape(guid), nil, instance); err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+func (c *powerVSClient) ListResourceInstances(ctx context.Context, name string) ([]ResourceInstance, error) {
+	var instances []ResourceInstance
+	next := c.resourceControllerEndpoint + "/resource_instances?" + url.Values{
+		"name":  {name},
+		"limit": {fmt.Sprint(pageSize)},
+	}.Encode()
+	for next != "" {
+		resp := &struct {
+			Resources []ResourceInstance `json:"resources"`
+			NextURL   string             `json:"next_url"`
+		}{}
+		if err := c.do(ctx, http.MethodGet, next, nil, resp); err != nil {
+			return nil, err
+		}
+		instances = append(instances, resp.Resources...)
+		next = ""
+		if resp.NextURL != "" {
+			// The next URL is relative to the host of the resource controller, and includes the version.
+			u, err := url.Parse(c.resourceControllerEndpoint)
+			if err != nil {
+				return nil, err
+			}
+			next = u.Scheme + "://" + u.Host + resp.NextURL
+		}
+	}
+	return instances, nil
+}
+
+func (c *powerVSClient) DeleteResourceInstance(ctx context.Context, guid string) error {
+	// Instances are deleted with their resources, such as the buckets of Cloud Object Storage instances.
+	return c.do(ctx, http.MethodDelete, c.resourceControllerEndpoint+"/resource_instances/"+url.PathEscape(guid)+"?recursive=true", nil, nil)
+}
+
+func (c *powerVSClient) ListInstances(ctx context.Context) ([]PowerVSResource, error) {
+	resp := &struct {
+		PVMInstances []struct {
+			PVMInstanceID string `json:"pvmInstanceID"`
+			ServerName    string `json:"serverName"`
+		} `json:"pvmInstances"`
+	}{}
+	if err := c.doPowerVS(ctx, http.MethodGet, "/pvm-instances", resp); err != nil {
+		return nil, err
+	}
+	instances := make([]PowerVSResource, 0, len(resp.PVMInstances))
+	for _, i := range resp.PVMInstances {
+		instances = append(instances, PowerVSResource{ID: i.PVMInstanceID, Name: i.ServerName})
+	}
+	return instances, nil
+}
+
+func (c *powerVSClient) DeleteInstance(ctx context.Context, id string) error {
+	return c.doPowerVS(ctx, http.MethodDelete, "/pvm-instances/"+url.PathEscape(id), nil)
+}
+
+func (c *powerVSClient) ListImages(ctx context.Context) ([]PowerVSResource, error) {
+	resp := &struct {
+		Images []struct {
+			ImageID string `json:"imageID"`
+			Name    string `json:"name"`
+		} `json:"images"`
+	}{}
+	if err := c.doPowerVS(ctx, http.MethodGet, "/images", resp); err != nil {
+		return nil, err
+	}
+	images := make([]PowerVSResource, 0, len(resp.Images))
+	for _, i := range resp.Images {
+		images = append(images, PowerVSResource{ID: i.ImageID, Name: i.Name})
+	}
+	return images, nil
+}
+
+func (c *powerVSClient) DeleteImage(ctx context.Context, id string) error {
+	return c.doPowerVS(ctx, http.MethodDelete, "/images/"+url.PathEscape(id), nil)
+}
+
+func (c *powerVSClient) ListNetworks(ctx context.Context) ([]PowerVSResource, error) {
+	resp := &struct {
+		Networks []struct {
+			NetworkID string `json:"networkID"`
+			Name      string `json:"name"`
+		} `json:"networks"`
+	}{}
+	if err := c.doPowerVS(ctx, http.MethodGet, "/networks", resp); err != nil {
+		return nil, err
+	}
+	networks := make([]PowerVSResource, 0, len(resp.Networks))
+	for _, n := range resp.Networks {
+		networks = append(networks, PowerVSResource{ID: n.NetworkID, Name: n.Name})
+	}
+	return networks, nil
+}
+
+func (c *powerVSClient) DeleteNetwork(ctx context.Context, id string) error {
+	return c.doPowerVS(ctx, http.MethodDelete, "/networks/"+url.PathEscape(id), nil)
+}
+
+// workspaceCRN returns the CRN of the workspace, looking it up with the resource controller on first use.
+func (c *powerVSClient) workspaceCRN(ctx context.Context) (string, error) {
+	c.crnLock.Lock()
+	defer c.crnLock.Unlock()
+	if c.crn == "" {
+		instance, err := c.GetResourceInstance(ctx, c.serviceInstanceID)
+		if err != nil {
+			return "", err
+		}
+		c.crn = instance.CRN
+	}
+	return c.crn, nil
+}
+
+// doPowerVS sends a request for a resource of the workspace to the PowerVS API.
+func (c *powerVSClient) doPowerVS(ctx context.Context, method, path string, out interface{}) error {
+	crn, err := c.workspaceCRN(ctx)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, method, c.powerVSEndpoint+"/cloud-instances/"+url.PathEscape(c.serviceInstanceID)+path, map[string]string{"CRN": crn}, out)
+}
+
+// powerVSErrorResponse is the response of the PowerVS and resource controller APIs for failed calls.
+type powerVSErrorResponse struct {
+	Description string `json:"description"`
+	Error       string `json:"error"`
+	Message     string `json:"message"`
+}
+
+// do sends a request to the URL, and decodes the response into out. Unsuccessful responses are returned as *Error.
+func (c *powerVSClient) do(ctx context.Context, method, rawURL string, headers map[string]string, out interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultCallTimeout)
+	defer cancel()
+
+	token, err := c.tokenSource.Token()
+	if err != nil {
+		return &tokenError{err: err}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", "openshift.io hive/v1")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", token.Type()+" "+token.AccessToken)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		apiErr := &Error{StatusCode: res.StatusCode}
+		errResp := &powerVSErrorResponse{}
+		if err := json.NewDecoder(res.Body).Decode(errResp); err == nil {
+			for _, m := range []string{errResp.Error, errResp.Description, errResp.Message} {
+				if m != "" {
+					apiErr.Messages = append(apiErr.Messages, m)
+				}
+			}
+		}
+		return apiErr
+	}
+	if out != nil {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
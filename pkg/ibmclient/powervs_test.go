@@ -0,0 +1,116 @@
+package ibmclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testWorkspaceGUID = "workspace-guid"
+	testWorkspaceCRN  = "crn:v1:bluemix:public:power-iaas:dal12:a/account:workspace-guid::"
+)
+
+func newPowerVSTestServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/identity/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		if r.Form.Get("apikey") != "apikey" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"errorMessage":"Provided API key could not be found."}`)
+			return
+		}
+		fmt.Fprintf(w, `{"access_token":"token","token_type":"Bearer","expiration":%d}`, time.Now().Add(time.Hour).Unix())
+	})
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message":"Unauthorized","status_code":401}`)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/resource_instances/" + testWorkspaceGUID:
+			fmt.Fprintf(w, `{"guid":%q,"crn":%q,"name":"workspace","state":"active"}`, testWorkspaceGUID, testWorkspaceCRN)
+		case "/v2/resource_instances":
+			assert.Equal(t, "foo-12345-cos", r.URL.Query().Get("name"), "unexpected name filter")
+			if r.URL.Query().Get("start") == "" {
+				fmt.Fprint(w, `{"resources":[{"guid":"cos-1","name":"foo-12345-cos"}],"next_url":"/v2/resource_instances?name=foo-12345-cos&start=next"}`)
+				return
+			}
+			fmt.Fprint(w, `{"resources":[{"guid":"cos-2","name":"foo-12345-cos"}],"next_url":null}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"Instance not found","status_code":404}`)
+		}
+	})
+	mux.HandleFunc("/pcloud/v1/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"), "unexpected authorization")
+		assert.Equal(t, testWorkspaceCRN, r.Header.Get("CRN"), "unexpected CRN")
+		switch r.URL.Path {
+		case "/pcloud/v1/cloud-instances/" + testWorkspaceGUID + "/pvm-instances":
+			fmt.Fprint(w, `{"pvmInstances":[{"pvmInstanceID":"instance-1","serverName":"foo-12345-master-0"}]}`)
+		case "/pcloud/v1/cloud-instances/" + testWorkspaceGUID + "/networks":
+			fmt.Fprint(w, `{"networks":[{"networkID":"network-1","name":"foo-12345-network"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code":404,"description":"image not found","error":"image not found"}`)
+		}
+	})
+	return httptest.NewServer(mux)
+}
+
+func newTestPowerVSClient(server *httptest.Server, apiKey string) *powerVSClient {
+	return newPowerVSClient(apiKey, testWorkspaceGUID, server.URL+"/pcloud/v1", server.URL+"/v2", server.URL+"/identity/token")
+}
+
+func TestPowerVSListInstances(t *testing.T) {
+	server := newPowerVSTestServer(t)
+	defer server.Close()
+
+	c := newTestPowerVSClient(server, "apikey")
+	instances, err := c.ListInstances(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []PowerVSResource{{ID: "instance-1", Name: "foo-12345-master-0"}}, instances)
+
+	networks, err := c.ListNetworks(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []PowerVSResource{{ID: "network-1", Name: "foo-12345-network"}}, networks)
+}
+
+func TestPowerVSDeleteImageNotFound(t *testing.T) {
+	server := newPowerVSTestServer(t)
+	defer server.Close()
+
+	err := newTestPowerVSClient(server, "apikey").DeleteImage(context.Background(), "missing")
+	require.Error(t, err)
+	assert.True(t, IsNotFound(err), "expected a not found error")
+	assert.Contains(t, err.Error(), "image not found")
+}
+
+func TestPowerVSListResourceInstances(t *testing.T) {
+	server := newPowerVSTestServer(t)
+	defer server.Close()
+
+	instances, err := newTestPowerVSClient(server, "apikey").ListResourceInstances(context.Background(), "foo-12345-cos")
+	require.NoError(t, err)
+	assert.Equal(t, []ResourceInstance{
+		{GUID: "cos-1", Name: "foo-12345-cos"},
+		{GUID: "cos-2", Name: "foo-12345-cos"},
+	}, instances, "expected the instances of all pages")
+}
+
+func TestPowerVSInvalidAPIKey(t *testing.T) {
+	server := newPowerVSTestServer(t)
+	defer server.Close()
+
+	_, err := newTestPowerVSClient(server, "wrong").GetResourceInstance(context.Background(), testWorkspaceGUID)
+	require.Error(t, err)
+	assert.True(t, IsUnauthorized(err), "expected an unauthorized error")
+	assert.Contains(t, err.Error(), "Provided API key could not be found.")
+}
@@ -175,11 +175,17 @@ func (o *UpdateInstallerImageOptions) Run() (returnErr error) {
 	if cd.Spec.Platform.BareMetal != nil {
 		installerTagName = "baremetal-installer"
 	}
-	installerImage, err := findImageSpec(is, installerTagName)
-	if err != nil {
-		return errors.Wrap(err, "could not get installer image")
+	var installerImage string
+	if cd.Spec.Provisioning != nil && cd.Spec.Provisioning.InstallerImageOverride != "" {
+		installerImage = cd.Spec.Provisioning.InstallerImageOverride
+		o.log.WithField("installerImage", installerImage).Info("installer image overridden")
+	} else {
+		installerImage, err = findImageSpec(is, installerTagName)
+		if err != nil {
+			return errors.Wrap(err, "could not get installer image")
+		}
+		o.log.WithField("installerImage", installerImage).Info("installer image found")
 	}
-	o.log.WithField("installerImage", installerImage).Info("installer image found")
 
 	cliImage, err := findImageSpec(is, "cli")
 	if err != nil {
@@ -94,6 +94,31 @@ func TestUpdateInstallerImageCommand(t *testing.T) {
 			version:                   testReleaseVersion,
 			validateClusterDeployment: validateSuccessfulExecution,
 		},
+		{
+			name: "successful execution with installer image override",
+			existingClusterDeployment: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				cd.Spec.Provisioning = &hivev1.Provisioning{InstallerImageOverride: testInstallerImage}
+				return cd
+			}(),
+			images: map[string]string{
+				"installer": "registry.io/other-installer-image:latest",
+				"cli":       testCLIImage,
+			},
+			validateClusterDeployment: validateSuccessfulExecution,
+		},
+		{
+			name: "successful execution with installer image override and no installer in release",
+			existingClusterDeployment: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				cd.Spec.Provisioning = &hivev1.Provisioning{InstallerImageOverride: testInstallerImage}
+				return cd
+			}(),
+			images: map[string]string{
+				"cli": testCLIImage,
+			},
+			validateClusterDeployment: validateSuccessfulExecution,
+		},
 	}
 
 	for _, test := range tests {
@@ -2,9 +2,11 @@ package install
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -24,6 +27,7 @@ import (
 	apihelpers "github.com/openshift/hive/apis/helpers"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/images"
 	"github.com/openshift/hive/pkg/controller/utils"
@@ -42,6 +46,10 @@ const (
 	vsphereCloudsDir      = "/vsphere"
 	ovirtCloudsDir        = "/.ovirt"
 	ovirtCADir            = "/.ovirt-ca"
+	nutanixCADir          = "/nutanix-ca"
+
+	// AdditionalTrustBundleDir is the directory where the generated Job will mount the additional trust bundle to
+	AdditionalTrustBundleDir = "/additionaltrustbundle"
 
 	// SSHPrivateKeyDir is the directory where the generated Job will mount the ssh secret to
 	SSHPrivateKeyDir = "/sshkeys"
@@ -49,6 +57,9 @@ const (
 	// LibvirtSSHPrivateKeyDir is the directory where the generated Job will mount the libvirt ssh secret to
 	LibvirtSSHPrivateKeyDir = "/libvirtsshkeys"
 
+	// CredentialsManifestsDir is the directory where the generated Job will mount the manual credentials manifests to
+	CredentialsManifestsDir = "/credentials-manifests"
+
 	// provisionJobDeadline is the maximum time that provision job will be allowed to run.
 	// when this deadline is reached, the provision attempt will be marked failed.
 	// since provision jobs can include cleanup before attempting installation, this should
@@ -57,6 +68,13 @@ const (
 	// deprovisionJobDeadline is the maximum time that deprovision job will be allowed to run.
 	// when this deadline is reached, the deprovision attempt will be marked failed.
 	deprovisionJobDeadline = 1 * time.Hour
+
+	// awsBrokerCredentialsSecretKey is the key of the secret of the install and uninstall pods with the credentials
+	// minted by the credentials broker, in the format of the output of a credential process.
+	awsBrokerCredentialsSecretKey = "broker_credentials"
+	// awsBrokerCredentialsRefreshWindow is how long before they expire the credentials minted by the credentials
+	// broker are refreshed, leaving time for the kubelet to update the secret mounted in the pods.
+	awsBrokerCredentialsRefreshWindow = 15 * time.Minute
 )
 
 var (
@@ -67,6 +85,9 @@ var (
 	LibvirtSSHPrivateKeyFilePath = fmt.Sprintf("%s/%s", LibvirtSSHPrivateKeyDir, constants.SSHPrivateKeySecretKey)
 )
 
+// mintBrokerCredentials mints the credentials of the credentials broker. It is replaced in tests.
+var mintBrokerCredentials = awsclient.MintBrokerCredentials
+
 func AWSAssumeRoleSecretName(secretPrefix string) string {
 	return secretPrefix + "-aws-assume-role-config"
 }
@@ -104,21 +125,86 @@ func CopyAWSServiceProviderSecret(client client.Client, destNamespace string, en
 // AWSAssumeRoleCLIConfig creates a secret that can assume the role using the hiveutil
 // credential_process helper.
 func AWSAssumeRoleCLIConfig(client client.Client, role *hivev1aws.AssumeRole, secretName, secretNamespace string, owner metav1.Object, scheme *runtime.Scheme) error {
-	cmd := "/usr/bin/hiveutil"
-	args := []string{"install-manager", "aws-credentials"}
-	args = append(args, []string{"--namespace", secretNamespace}...)
-	args = append(args, []string{"--role-arn", role.RoleARN}...)
+	args := []string{"--role-arn", role.RoleARN}
 	if role.ExternalID != "" {
 		args = append(args, []string{"--external-id", role.ExternalID}...)
 	}
+	return awsCredentialProcessCLIConfig(client, args, secretName, secretNamespace, owner, scheme)
+}
 
-	cmd = fmt.Sprintf("%s %s", cmd, strings.Join(args, " "))
+// AWSCredentialsSourceSecret creates the secret of the install and uninstall pods with the short-lived credentials
+// minted by the credentials broker for the credentials source, and an AWS CLI config loading them, or refreshes the
+// credentials when they expire within awsBrokerCredentialsRefreshWindow. The credentials are minted by the calling
+// controller, so the pods never authenticate to AWS STS or Vault themselves. The pods load the credentials again from
+// the mounted secret once they expire. It returns how long until the credentials must be refreshed.
+func AWSCredentialsSourceSecret(client client.Client, source *hivev1aws.CredentialsSource, region, secretName, secretNamespace string, owner metav1.Object, scheme *runtime.Scheme) (time.Duration, error) {
+	secret := &corev1.Secret{}
+	exists := true
+	switch err := client.Get(context.TODO(), types.NamespacedName{Namespace: secretNamespace, Name: secretName}, secret); {
+	case apierrors.IsNotFound(err):
+		exists = false
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: secretNamespace,
+				Name:      secretName,
+			},
+		}
+	case err != nil:
+		return 0, err
+	default:
+		resp := &awsCredentialProcessResponse{}
+		if err := json.Unmarshal(secret.Data[awsBrokerCredentialsSecretKey], resp); err == nil && resp.Expiration != nil {
+			if refreshAfter := time.Until(*resp.Expiration) - awsBrokerCredentialsRefreshWindow; refreshAfter > 0 {
+				return refreshAfter, nil
+			}
+		}
+	}
 
-	template := `[default]
-credential_process = %s
-`
-	data := fmt.Sprintf(template, cmd)
+	v, expiry, err := mintBrokerCredentials(secretNamespace, source, region)
+	if err != nil {
+		return 0, err
+	}
+	// The pods load the credentials again a minute before they expire, by which time the refreshed credentials are
+	// mounted.
+	expiration := expiry.Add(-1 * time.Minute)
+	creds, err := json.Marshal(&awsCredentialProcessResponse{
+		Version:         1,
+		AccessKeyID:     v.AccessKeyID,
+		SecretAccessKey: v.SecretAccessKey,
+		SessionToken:    v.SessionToken,
+		Expiration:      &expiration,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to marshal the minted credentials")
+	}
+	secret.Data = map[string][]byte{
+		constants.AWSConfigSecretKey: []byte(awsCredentialProcessConfig([]string{
+			"--credentials-file", filepath.Join(constants.AWSCredsMount, awsBrokerCredentialsSecretKey),
+		}, secretNamespace)),
+		awsBrokerCredentialsSecretKey: creds,
+	}
+	if err := controllerutil.SetOwnerReference(owner, secret, scheme); err != nil {
+		return 0, err
+	}
+	if exists {
+		err = client.Update(context.TODO(), secret)
+	} else {
+		err = client.Create(context.TODO(), secret)
+	}
+	if err != nil {
+		return 0, err
+	}
 
+	refreshAfter := time.Until(expiry) - awsBrokerCredentialsRefreshWindow
+	if refreshAfter < time.Minute {
+		refreshAfter = time.Minute
+	}
+	return refreshAfter, nil
+}
+
+// awsCredentialProcessCLIConfig creates a secret with an AWS CLI config loading the credentials with the aws-credentials
+// command of the install manager, run with the args.
+func awsCredentialProcessCLIConfig(client client.Client, credentialsArgs []string, secretName, secretNamespace string, owner metav1.Object, scheme *runtime.Scheme) error {
 	secret := &corev1.Secret{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: corev1.SchemeGroupVersion.String(),
@@ -129,7 +215,7 @@ credential_process = %s
 			Name:      secretName,
 		},
 		Data: map[string][]byte{
-			constants.AWSConfigSecretKey: []byte(data),
+			constants.AWSConfigSecretKey: []byte(awsCredentialProcessConfig(credentialsArgs, secretNamespace)),
 		},
 	}
 	if err := controllerutil.SetOwnerReference(owner, secret, scheme); err != nil {
@@ -139,6 +225,32 @@ credential_process = %s
 	return client.Create(context.TODO(), secret)
 }
 
+// awsCredentialProcessConfig returns an AWS CLI config loading the credentials with the aws-credentials command of the
+// install manager, run with the args.
+func awsCredentialProcessConfig(credentialsArgs []string, secretNamespace string) string {
+	cmd := "/usr/bin/hiveutil"
+	args := []string{"install-manager", "aws-credentials"}
+	args = append(args, []string{"--namespace", secretNamespace}...)
+	args = append(args, credentialsArgs...)
+
+	cmd = fmt.Sprintf("%s %s", cmd, strings.Join(args, " "))
+
+	template := `[default]
+credential_process = %s
+`
+	return fmt.Sprintf(template, cmd)
+}
+
+// awsCredentialProcessResponse is the output of an external credential process, as detailed in
+// https://docs.aws.amazon.com/cli/latest/userguide/cli-configure-sourcing-external.html
+type awsCredentialProcessResponse struct {
+	Version         int
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string
+	SessionToken    string
+	Expiration      *time.Time
+}
+
 // InstallerPodSpec generates a spec for an installer pod.
 func InstallerPodSpec(
 	cd *hivev1.ClusterDeployment,
@@ -375,6 +487,24 @@ func InstallerPodSpec(
 			},
 		)
 		env = append(env, oVirtCredsEnvVars(cd.Spec.Platform.Ovirt.CredentialsSecretRef.Name)...)
+	case cd.Spec.Platform.Nutanix != nil:
+		if cd.Spec.Platform.Nutanix.CertificatesSecretRef != nil && cd.Spec.Platform.Nutanix.CertificatesSecretRef.Name != "" {
+			volumes = append(volumes, corev1.Volume{
+				Name: "nutanix-certificates",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: cd.Spec.Platform.Nutanix.CertificatesSecretRef.Name,
+					},
+				},
+			})
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      "nutanix-certificates",
+				MountPath: nutanixCADir,
+			})
+		}
+		env = append(env, nutanixCredsEnvVars(cd.Spec.Platform.Nutanix.CredentialsSecretRef.Name)...)
+	case cd.Spec.Platform.PowerVS != nil:
+		env = append(env, powerVSCredsEnvVars(cd.Spec.Platform.PowerVS.CredentialsSecretRef.Name)...)
 	}
 
 	if releaseImage != "" {
@@ -410,6 +540,21 @@ func InstallerPodSpec(
 		)
 	}
 
+	if mc := cd.Spec.Provisioning.ManualCredentials; mc != nil && mc.ManifestsSecretRef != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: "credentials-manifests",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: mc.ManifestsSecretRef.Name,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "credentials-manifests",
+			MountPath: CredentialsManifestsDir,
+		})
+	}
+
 	// If this cluster is using a custom BoundServiceAccountSigningKey, mount volume for the bound service account signing key:
 	if cd.Spec.BoundServiceAccountSignkingKeySecretRef != nil {
 		volumes = append(volumes, corev1.Volume{
@@ -475,6 +620,27 @@ func InstallerPodSpec(
 		})
 	}
 
+	if cd.Spec.AdditionalTrustBundle != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: "additional-trust-bundle",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: cd.Spec.AdditionalTrustBundle.ConfigMapRef,
+					Items: []corev1.KeyToPath{
+						{
+							Key:  constants.AdditionalTrustBundleConfigMapKey,
+							Path: constants.AdditionalTrustBundleConfigMapKey,
+						},
+					},
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "additional-trust-bundle",
+			MountPath: AdditionalTrustBundleDir,
+		})
+	}
+
 	// Signal to fake an installation:
 	if utils.IsFakeCluster(cd) {
 		env = append(env, corev1.EnvVar{
@@ -506,6 +672,15 @@ func InstallerPodSpec(
 		// Add OpenStack certificates to CA trust.
 		hiveArg = fmt.Sprintf("cp -vr %s/. /etc/pki/ca-trust/source/anchors/ && update-ca-trust && %s", openStackCADir, hiveArg)
 	}
+	if cd.Spec.Platform.Nutanix != nil && cd.Spec.Platform.Nutanix.CertificatesSecretRef != nil && cd.Spec.Platform.Nutanix.CertificatesSecretRef.Name != "" {
+		// Add Prism Central certificates to CA trust.
+		hiveArg = fmt.Sprintf("cp -vr %s/. /etc/pki/ca-trust/source/anchors/ && update-ca-trust && %s", nutanixCADir, hiveArg)
+	}
+
+	if cd.Spec.AdditionalTrustBundle != nil {
+		// Add the additional trust bundle to CA trust.
+		hiveArg = fmt.Sprintf("cp -vr %s/. /etc/pki/ca-trust/source/anchors/ && update-ca-trust && %s", AdditionalTrustBundleDir, hiveArg)
+	}
 
 	// This is used when scheduling the installer pod. It ensures that installer pods don't overwhelm
 	// a given node's memory.
@@ -664,6 +839,10 @@ func GenerateUninstallerJobForDeprovision(
 		completeVSphereDeprovisionJob(req, job)
 	case req.Spec.Platform.Ovirt != nil:
 		completeOvirtDeprovisionJob(req, job)
+	case req.Spec.Platform.Nutanix != nil:
+		completeNutanixDeprovisionJob(req, job)
+	case req.Spec.Platform.PowerVS != nil:
+		completePowerVSDeprovisionJob(req, job)
 	default:
 		return nil, errors.New("deprovision requests currently not supported for platform")
 	}
@@ -768,6 +947,18 @@ func completeAzureDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Jo
 		Name:  "AZURE_AUTH_LOCATION",
 		Value: azureAuthFile,
 	})
+	args := []string{
+		"deprovision",
+		"azure",
+		"--loglevel",
+		"debug",
+		"--creds-dir",
+		azureAuthDir,
+	}
+	if rg := req.Spec.Platform.Azure.ResourceGroupName; rg != "" {
+		args = append(args, "--resource-group", rg)
+	}
+	args = append(args, req.Spec.InfraID)
 	containers := []corev1.Container{
 		{
 			Name:            "deprovision",
@@ -775,16 +966,8 @@ func completeAzureDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Jo
 			ImagePullPolicy: images.GetHiveImagePullPolicy(),
 			Env:             env,
 			Command:         []string{"/usr/bin/hiveutil"},
-			Args: []string{
-				"deprovision",
-				"azure",
-				"--loglevel",
-				"debug",
-				"--creds-dir",
-				azureAuthDir,
-				req.Spec.InfraID,
-			},
-			VolumeMounts: volumeMounts,
+			Args:            args,
+			VolumeMounts:    volumeMounts,
 		},
 	}
 	job.Spec.Template.Spec.Containers = containers
@@ -812,6 +995,20 @@ func completeGCPDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Job)
 		Name:  "GOOGLE_CREDENTIALS",
 		Value: gcpAuthFile,
 	})
+	args := []string{
+		"deprovision",
+		"gcp",
+		"--loglevel",
+		"debug",
+		"--creds-dir",
+		gcpAuthDir,
+		"--region",
+		req.Spec.Platform.GCP.Region,
+	}
+	if project := req.Spec.Platform.GCP.NetworkProjectID; project != "" {
+		args = append(args, "--network-project-id", project)
+	}
+	args = append(args, req.Spec.InfraID)
 	containers := []corev1.Container{
 		{
 			Name:            "deprovision",
@@ -819,18 +1016,8 @@ func completeGCPDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Job)
 			ImagePullPolicy: images.GetHiveImagePullPolicy(),
 			Env:             env,
 			Command:         []string{"/usr/bin/hiveutil"},
-			Args: []string{
-				"deprovision",
-				"gcp",
-				"--loglevel",
-				"debug",
-				"--creds-dir",
-				gcpAuthDir,
-				"--region",
-				req.Spec.Platform.GCP.Region,
-				req.Spec.InfraID,
-			},
-			VolumeMounts: volumeMounts,
+			Args:            args,
+			VolumeMounts:    volumeMounts,
 		},
 	}
 	job.Spec.Template.Spec.Containers = containers
@@ -1017,6 +1204,116 @@ func completeOvirtDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Jo
 	job.Spec.Template.Spec.Volumes = volumes
 }
 
+func completeNutanixDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Job) {
+	const nutanixCredsDir = "/nutanix-creds"
+	volumes := []corev1.Volume{
+		{
+			Name: "nutanix-creds",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: req.Spec.Platform.Nutanix.CredentialsSecretRef.Name,
+				},
+			},
+		},
+	}
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      "nutanix-creds",
+			MountPath: nutanixCredsDir,
+		},
+	}
+	env := nutanixCredsEnvVars(req.Spec.Platform.Nutanix.CredentialsSecretRef.Name)
+	cmd := []string{"/usr/bin/hiveutil"}
+	args := []string{
+		"deprovision",
+		"nutanix",
+		"--loglevel",
+		"debug",
+		"--creds-dir",
+		nutanixCredsDir,
+		"--prism-central",
+		req.Spec.Platform.Nutanix.PrismCentral.Address,
+	}
+	if port := req.Spec.Platform.Nutanix.PrismCentral.Port; port != 0 {
+		args = append(args, "--prism-central-port", strconv.Itoa(int(port)))
+	}
+	args = append(args, req.Spec.InfraID)
+	if req.Spec.Platform.Nutanix.CertificatesSecretRef != nil && req.Spec.Platform.Nutanix.CertificatesSecretRef.Name != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: "nutanix-certificates",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: req.Spec.Platform.Nutanix.CertificatesSecretRef.Name,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "nutanix-certificates",
+			MountPath: nutanixCADir,
+		})
+		args = []string{fmt.Sprintf("cp -vr %s/. /etc/pki/ca-trust/source/anchors/ && update-ca-trust && %s", nutanixCADir, strings.Join(append(cmd, args...), " "))}
+		cmd = []string{"/bin/sh", "-c"}
+	}
+	containers := []corev1.Container{
+		{
+			Name:            "deprovision",
+			Image:           images.GetHiveImage(),
+			ImagePullPolicy: images.GetHiveImagePullPolicy(),
+			Env:             env,
+			Command:         cmd,
+			Args:            args,
+			VolumeMounts:    volumeMounts,
+		},
+	}
+	job.Spec.Template.Spec.Containers = containers
+	job.Spec.Template.Spec.Volumes = volumes
+}
+
+func completePowerVSDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Job) {
+	const powerVSCredsDir = "/powervs-creds"
+	volumes := []corev1.Volume{
+		{
+			Name: "powervs-creds",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: req.Spec.Platform.PowerVS.CredentialsSecretRef.Name,
+				},
+			},
+		},
+	}
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      "powervs-creds",
+			MountPath: powerVSCredsDir,
+		},
+	}
+	containers := []corev1.Container{
+		{
+			Name:            "deprovision",
+			Image:           images.GetHiveImage(),
+			ImagePullPolicy: images.GetHiveImagePullPolicy(),
+			Env:             powerVSCredsEnvVars(req.Spec.Platform.PowerVS.CredentialsSecretRef.Name),
+			Command:         []string{"/usr/bin/hiveutil"},
+			Args: []string{
+				"deprovision",
+				"powervs",
+				"--loglevel",
+				"debug",
+				"--creds-dir",
+				powerVSCredsDir,
+				"--region",
+				req.Spec.Platform.PowerVS.Region,
+				"--service-instance-id",
+				req.Spec.Platform.PowerVS.ServiceInstanceID,
+				req.Spec.InfraID,
+			},
+			VolumeMounts: volumeMounts,
+		},
+	}
+	job.Spec.Template.Spec.Containers = containers
+	job.Spec.Template.Spec.Volumes = volumes
+}
+
 func vSphereCredsEnvVars(credentialsSecret string) []corev1.EnvVar {
 	env := []corev1.EnvVar{}
 	env = append(
@@ -1043,6 +1340,43 @@ func vSphereCredsEnvVars(credentialsSecret string) []corev1.EnvVar {
 	return env
 }
 
+func nutanixCredsEnvVars(credentialsSecret string) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			Name: constants.NutanixUsernameEnvVar,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecret},
+					Key:                  constants.UsernameSecretKey,
+				},
+			},
+		},
+		{
+			Name: constants.NutanixPasswordEnvVar,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecret},
+					Key:                  constants.PasswordSecretKey,
+				},
+			},
+		},
+	}
+}
+
+func powerVSCredsEnvVars(credentialsSecret string) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			Name: constants.PowerVSAPIKeyEnvVar,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecret},
+					Key:                  constants.IBMCloudAPIKeySecretKey,
+				},
+			},
+		},
+	}
+}
+
 func oVirtCredsEnvVars(credentialsSecret string) []corev1.EnvVar {
 	env := []corev1.EnvVar{}
 	env = append(
@@ -1,15 +1,28 @@
 package install
 
 import (
+	"context"
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1nutanix "github.com/openshift/hive/apis/hive/v1/nutanix"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/constants"
 	hiveassert "github.com/openshift/hive/pkg/test/assert"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 var (
@@ -37,6 +50,93 @@ func TestGenerateDeprovision(t *testing.T) {
 	hiveassert.AssertAllContainersHaveEnvVar(t, &job.Spec.Template.Spec, "NO_PROXY", testNoProxy)
 }
 
+func TestGenerateAzureDeprovisionExistingResourceGroup(t *testing.T) {
+	dr := testClusterDeprovision()
+	dr.Spec.Platform = hivev1.ClusterDeprovisionPlatform{
+		Azure: &hivev1.AzureClusterDeprovision{
+			CredentialsSecretRef: &corev1.LocalObjectReference{Name: "azure-creds"},
+			ResourceGroupName:    "existing-rg",
+		},
+	}
+	job, err := GenerateUninstallerJobForDeprovision(dr, "someseviceaccount", "", "", "", nil)
+	if assert.NoError(t, err) {
+		args := job.Spec.Template.Spec.Containers[0].Args
+		assert.Contains(t, strings.Join(args, " "), "--resource-group existing-rg")
+		assert.Equal(t, "test-infra-id", args[len(args)-1], "infra ID must be the last argument")
+	}
+}
+
+func TestGenerateGCPDeprovisionSharedVPC(t *testing.T) {
+	dr := testClusterDeprovision()
+	dr.Spec.Platform = hivev1.ClusterDeprovisionPlatform{
+		GCP: &hivev1.GCPClusterDeprovision{
+			Region:               "us-central1",
+			CredentialsSecretRef: &corev1.LocalObjectReference{Name: "gcp-creds"},
+			NetworkProjectID:     "host-project",
+		},
+	}
+	job, err := GenerateUninstallerJobForDeprovision(dr, "someseviceaccount", "", "", "", nil)
+	if assert.NoError(t, err) {
+		args := job.Spec.Template.Spec.Containers[0].Args
+		assert.Contains(t, strings.Join(args, " "), "--network-project-id host-project")
+		assert.Equal(t, "test-infra-id", args[len(args)-1], "infra ID must be the last argument")
+	}
+}
+
+func TestGenerateNutanixDeprovision(t *testing.T) {
+	dr := testClusterDeprovision()
+	dr.Spec.Platform = hivev1.ClusterDeprovisionPlatform{
+		Nutanix: &hivev1.NutanixClusterDeprovision{
+			PrismCentral:         hivev1nutanix.PrismEndpoint{Address: "prism.example.com", Port: 9441},
+			CredentialsSecretRef: corev1.LocalObjectReference{Name: "nutanix-creds"},
+		},
+	}
+	job, err := GenerateUninstallerJobForDeprovision(dr, "someseviceaccount", "", "", "", nil)
+	if assert.NoError(t, err) {
+		args := job.Spec.Template.Spec.Containers[0].Args
+		assert.Contains(t, strings.Join(args, " "), "--prism-central prism.example.com --prism-central-port 9441")
+		assert.Equal(t, "test-infra-id", args[len(args)-1], "infra ID must be the last argument")
+		for _, env := range job.Spec.Template.Spec.Containers[0].Env {
+			if env.Name == "NUTANIX_USERNAME" || env.Name == "NUTANIX_PASSWORD" {
+				assert.Equal(t, "nutanix-creds", env.ValueFrom.SecretKeyRef.Name, "unexpected secret for %s", env.Name)
+			}
+		}
+	}
+
+	dr.Spec.Platform.Nutanix.CertificatesSecretRef = &corev1.LocalObjectReference{Name: "nutanix-certs"}
+	job, err = GenerateUninstallerJobForDeprovision(dr, "someseviceaccount", "", "", "", nil)
+	if assert.NoError(t, err) {
+		container := job.Spec.Template.Spec.Containers[0]
+		assert.Equal(t, []string{"/bin/sh", "-c"}, container.Command)
+		if assert.Len(t, container.Args, 1) {
+			assert.True(t, strings.HasPrefix(container.Args[0], "cp -vr /nutanix-ca/. /etc/pki/ca-trust/source/anchors/ && update-ca-trust && /usr/bin/hiveutil deprovision nutanix"),
+				"expected the certificates to be trusted before deprovisioning")
+		}
+	}
+}
+
+func TestGeneratePowerVSDeprovision(t *testing.T) {
+	dr := testClusterDeprovision()
+	dr.Spec.Platform = hivev1.ClusterDeprovisionPlatform{
+		PowerVS: &hivev1.PowerVSClusterDeprovision{
+			Region:               "dal",
+			ServiceInstanceID:    "workspace-guid",
+			CredentialsSecretRef: corev1.LocalObjectReference{Name: "powervs-creds"},
+		},
+	}
+	job, err := GenerateUninstallerJobForDeprovision(dr, "someseviceaccount", "", "", "", nil)
+	if assert.NoError(t, err) {
+		container := job.Spec.Template.Spec.Containers[0]
+		assert.Equal(t, "deprovision powervs --loglevel debug --creds-dir /powervs-creds --region dal --service-instance-id workspace-guid test-infra-id",
+			strings.Join(container.Args, " "))
+		if assert.Len(t, container.Env, 1) {
+			assert.Equal(t, "IBMCLOUD_API_KEY", container.Env[0].Name)
+			assert.Equal(t, "powervs-creds", container.Env[0].ValueFrom.SecretKeyRef.Name)
+			assert.Equal(t, "ibmcloud_api_key", container.Env[0].ValueFrom.SecretKeyRef.Key)
+		}
+	}
+}
+
 func testClusterDeprovision() *hivev1.ClusterDeprovision {
 	return &hivev1.ClusterDeprovision{
 		ObjectMeta: metav1.ObjectMeta{
@@ -103,6 +203,71 @@ func TestInstallerPodSpec(t *testing.T) {
 				assert.NoError(t, actualError)
 			},
 		},
+		{
+			name: "Test Additional Trust Bundle",
+			clusterDeployment: &hivev1.ClusterDeployment{
+				Spec: hivev1.ClusterDeploymentSpec{
+					Provisioning: &hivev1.Provisioning{
+						InstallConfigSecretRef: &corev1.LocalObjectReference{Name: "foo"},
+					},
+					AdditionalTrustBundle: &hivev1.AdditionalTrustBundle{
+						ConfigMapRef: corev1.LocalObjectReference{Name: "trust-bundle"},
+					},
+				},
+				Status: hivev1.ClusterDeploymentStatus{
+					InstallerImage: &installerImage,
+					CLIImage:       &cliImage,
+				},
+			},
+			provisionName: "testprovision",
+			validate: func(t *testing.T, actualPodSpec *corev1.PodSpec, actualError error) {
+				assert.NoError(t, actualError)
+				var volume *corev1.Volume
+				for i := range actualPodSpec.Volumes {
+					if actualPodSpec.Volumes[i].Name == "additional-trust-bundle" {
+						volume = &actualPodSpec.Volumes[i]
+					}
+				}
+				if assert.NotNil(t, volume, "missing additional trust bundle volume") && assert.NotNil(t, volume.ConfigMap, "expected a config map volume") {
+					assert.Equal(t, "trust-bundle", volume.ConfigMap.Name, "unexpected config map")
+				}
+				hiveContainer := actualPodSpec.Containers[2]
+				assert.Contains(t, hiveContainer.VolumeMounts, corev1.VolumeMount{Name: "additional-trust-bundle", MountPath: AdditionalTrustBundleDir})
+				assert.Contains(t, hiveContainer.Args[0], "cp -vr "+AdditionalTrustBundleDir+"/. /etc/pki/ca-trust/source/anchors/ && update-ca-trust", "expected trust bundle to be added to CA trust")
+			},
+		},
+		{
+			name: "Test Manual Credentials Manifests",
+			clusterDeployment: &hivev1.ClusterDeployment{
+				Spec: hivev1.ClusterDeploymentSpec{
+					Provisioning: &hivev1.Provisioning{
+						InstallConfigSecretRef: &corev1.LocalObjectReference{Name: "foo"},
+						ManualCredentials: &hivev1.ManualCredentials{
+							ManifestsSecretRef: &corev1.LocalObjectReference{Name: "credentials-manifests"},
+						},
+					},
+				},
+				Status: hivev1.ClusterDeploymentStatus{
+					InstallerImage: &installerImage,
+					CLIImage:       &cliImage,
+				},
+			},
+			provisionName: "testprovision",
+			validate: func(t *testing.T, actualPodSpec *corev1.PodSpec, actualError error) {
+				assert.NoError(t, actualError)
+				var volume *corev1.Volume
+				for i := range actualPodSpec.Volumes {
+					if actualPodSpec.Volumes[i].Name == "credentials-manifests" {
+						volume = &actualPodSpec.Volumes[i]
+					}
+				}
+				if assert.NotNil(t, volume, "missing credentials manifests volume") && assert.NotNil(t, volume.Secret, "expected a secret volume") {
+					assert.Equal(t, "credentials-manifests", volume.Secret.SecretName, "unexpected secret")
+				}
+				hiveContainer := actualPodSpec.Containers[2]
+				assert.Contains(t, hiveContainer.VolumeMounts, corev1.VolumeMount{Name: "credentials-manifests", MountPath: CredentialsManifestsDir})
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -127,3 +292,88 @@ func TestInstallerPodSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestAWSCredentialsSourceSecret(t *testing.T) {
+	hivev1.AddToScheme(scheme.Scheme)
+	source := &hivev1aws.CredentialsSource{
+		WebIdentity: &hivev1aws.WebIdentityCredentialsSource{RoleARN: "arn:aws:iam::123456789012:role/installer"},
+	}
+	existingSecret := func(expiry time.Time) *corev1.Secret {
+		creds, _ := json.Marshal(&awsCredentialProcessResponse{
+			Version:         1,
+			AccessKeyID:     "old-id",
+			SecretAccessKey: "old-secret",
+			Expiration:      &expiry,
+		})
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-secret"},
+			Data:       map[string][]byte{awsBrokerCredentialsSecretKey: creds},
+		}
+	}
+	cases := []struct {
+		name           string
+		existing       *corev1.Secret
+		expectMinted   bool
+		expectAccessID string
+	}{
+		{
+			name:           "no secret",
+			expectMinted:   true,
+			expectAccessID: "new-id",
+		},
+		{
+			name:           "fresh credentials",
+			existing:       existingSecret(time.Now().Add(time.Hour)),
+			expectAccessID: "old-id",
+		},
+		{
+			name:           "expiring credentials",
+			existing:       existingSecret(time.Now().Add(5 * time.Minute)),
+			expectMinted:   true,
+			expectAccessID: "new-id",
+		},
+		{
+			name: "secret without minted credentials",
+			existing: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-secret"},
+				Data:       map[string][]byte{constants.AWSConfigSecretKey: []byte("[default]\n")},
+			},
+			expectMinted:   true,
+			expectAccessID: "new-id",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			minted := false
+			mintBrokerCredentials = func(namespace string, s *hivev1aws.CredentialsSource, region string) (credentials.Value, time.Time, error) {
+				minted = true
+				assert.Equal(t, "test-namespace", namespace, "unexpected namespace")
+				assert.Equal(t, source, s, "unexpected credentials source")
+				assert.Equal(t, "us-east-1", region, "unexpected region")
+				return credentials.Value{AccessKeyID: "new-id", SecretAccessKey: "new-secret", SessionToken: "new-token"}, time.Now().Add(time.Hour), nil
+			}
+			defer func() { mintBrokerCredentials = awsclient.MintBrokerCredentials }()
+
+			c := fake.NewFakeClientWithScheme(scheme.Scheme)
+			if tc.existing != nil {
+				c = fake.NewFakeClientWithScheme(scheme.Scheme, tc.existing)
+			}
+			owner := &hivev1.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-cd"}}
+
+			refreshAfter, err := AWSCredentialsSourceSecret(c, source, "us-east-1", "test-secret", "test-namespace", owner, scheme.Scheme)
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectMinted, minted, "unexpected minting of credentials")
+			assert.True(t, refreshAfter > 0 && refreshAfter <= time.Hour-awsBrokerCredentialsRefreshWindow, "unexpected refresh delay %v", refreshAfter)
+
+			secret := &corev1.Secret{}
+			require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "test-namespace", Name: "test-secret"}, secret))
+			resp := &awsCredentialProcessResponse{}
+			require.NoError(t, json.Unmarshal(secret.Data[awsBrokerCredentialsSecretKey], resp), "unexpected error parsing the credentials")
+			assert.Equal(t, tc.expectAccessID, resp.AccessKeyID, "unexpected access key")
+			if tc.expectMinted {
+				assert.Contains(t, string(secret.Data[constants.AWSConfigSecretKey]), "--credentials-file /etc/aws-creds/broker_credentials", "unexpected AWS CLI config")
+				assert.Equal(t, "new-token", resp.SessionToken, "unexpected session token")
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"time"
 
@@ -32,11 +33,13 @@ type AWSCredentials struct {
 
 	RoleARN    string
 	ExternalID string
+
+	CredentialsFile string
 }
 
 // NewInstallManagerAWSCredentials is the entrypoint to load credentials for AWS SDK
-// using the service provider credentials. It supports the external process credential
-// provider as mentioned in https://docs.aws.amazon.com/cli/latest/userguide/cli-configure-sourcing-external.html
+// using the service provider credentials, or from the credentials minted by the credentials broker. It supports the
+// external process credential provider as mentioned in https://docs.aws.amazon.com/cli/latest/userguide/cli-configure-sourcing-external.html
 func NewInstallManagerAWSCredentials() *cobra.Command {
 	options := &AWSCredentials{}
 	cmd := &cobra.Command{
@@ -56,12 +59,14 @@ func NewInstallManagerAWSCredentials() *cobra.Command {
 				return
 			}
 
-			var err error
-			options.kubeClient, err = contributils.GetClient()
-			if err != nil {
-				fmt.Fprint(os.Stderr, err.Error())
-				os.Exit(1)
-				return
+			if options.CredentialsFile == "" {
+				var err error
+				options.kubeClient, err = contributils.GetClient()
+				if err != nil {
+					fmt.Fprint(os.Stderr, err.Error())
+					os.Exit(1)
+					return
+				}
 			}
 
 			if err := options.Run(); err != nil {
@@ -75,14 +80,19 @@ func NewInstallManagerAWSCredentials() *cobra.Command {
 	flags.StringVar(&options.ServiceProviderSecretNamespace, "namespace", "", "The namespace where the service provider secret is stored")
 	cmd.MarkFlagRequired("namespace")
 	flags.StringVar(&options.RoleARN, "role-arn", "", "The IAM role that should be assumed")
-	cmd.MarkFlagRequired("role-arn")
 	flags.StringVar(&options.ExternalID, "external-id", "", "External identifier required to assume the role specified.")
+	flags.StringVar(&options.CredentialsFile, "credentials-file", "", "The file with the credentials minted by the credentials broker of the Hive controllers")
 
 	return cmd
 }
 
 // Validate the options
-func (options *AWSCredentials) Validate() error { return nil }
+func (options *AWSCredentials) Validate() error {
+	if (options.RoleARN == "") == (options.CredentialsFile == "") {
+		return errors.New("exactly one of --role-arn and --credentials-file must be set")
+	}
+	return nil
+}
 
 // Complete the options using the args
 func (options *AWSCredentials) Complete(args []string) error {
@@ -93,6 +103,10 @@ func (options *AWSCredentials) Complete(args []string) error {
 
 // Run runs the command using the options.
 func (options *AWSCredentials) Run() error {
+	if options.CredentialsFile != "" {
+		return options.runCredentialsFile()
+	}
+
 	var secret *corev1.Secret
 	if options.ServiceProviderSecretName != "" {
 		secret = &corev1.Secret{}
@@ -128,6 +142,24 @@ func (options *AWSCredentials) Run() error {
 	return err
 }
 
+// runCredentialsFile prints the credentials minted by the credentials broker of the Hive controllers, which the
+// controllers keep refreshed in the mounted secret of the pod.
+func (options *AWSCredentials) runCredentialsFile() error {
+	data, err := ioutil.ReadFile(options.CredentialsFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read the credentials file")
+	}
+	resp := &credentialProcessResponse{}
+	if err := json.Unmarshal(data, resp); err != nil {
+		return errors.Wrap(err, "failed to parse the credentials file")
+	}
+	if resp.AccessKeyID == "" || resp.SecretAccessKey == "" {
+		return errors.New("the credentials file has no access key")
+	}
+	_, err = fmt.Fprint(options.output, string(data))
+	return err
+}
+
 func newCredentialProcessResponse(v credentials.Value, expiry time.Time) (string, error) {
 	resp := &credentialProcessResponse{
 		Version:         1,
@@ -1,6 +1,10 @@
 package installmanager
 
 import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -36,3 +40,50 @@ func TestNewCredentialProcessResponse(t *testing.T) {
 		})
 	}
 }
+
+type nopWriteCloser struct {
+	*bytes.Buffer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+func TestRunCredentialsFile(t *testing.T) {
+	cases := []struct {
+		name        string
+		contents    string
+		expectError bool
+	}{{
+		name:     "minted credentials",
+		contents: `{"Version":1,"AccessKeyId":"ASX..ID...","SecretAccessKey":"ASX..SECRET...","SessionToken":"ASX..TOKEN...","Expiration":"2021-01-01T00:00:00Z"}`,
+	}, {
+		name:        "no access key",
+		contents:    `{"Version":1}`,
+		expectError: true,
+	}, {
+		name:        "invalid file",
+		contents:    "invalid",
+		expectError: true,
+	}}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "aws-credentials")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+			file := filepath.Join(dir, "broker_credentials")
+			require.NoError(t, ioutil.WriteFile(file, []byte(test.contents), 0600))
+
+			out := &bytes.Buffer{}
+			options := &AWSCredentials{
+				output:          nopWriteCloser{out},
+				CredentialsFile: file,
+			}
+			err = options.Run()
+			if test.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.contents, out.String())
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	azureutils "github.com/openshift/hive/contrib/pkg/utils/azure"
 	gcputils "github.com/openshift/hive/contrib/pkg/utils/gcp"
 	"github.com/openshift/hive/pkg/awsclient"
@@ -21,7 +22,7 @@ import (
 
 // cleanupDNSZone will handle any needed DNS cleanup for ClusterDeployments with
 // ManageDNS enabled (this helps to clean up any stray DNS records on install failures)
-func cleanupDNSZone(dynClient client.Client, cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+func cleanupDNSZone(ctx context.Context, dynClient client.Client, cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
 	if cd.Spec.ManageDNS == false {
 		return nil
 	}
@@ -34,11 +35,11 @@ func cleanupDNSZone(dynClient client.Client, cd *hivev1.ClusterDeployment, logge
 
 	switch {
 	case cd.Spec.Platform.AWS != nil:
-		return cleanupAWSDNSZone(dnsZone, cd.Spec.Platform.AWS.Region, logger)
+		return cleanupAWSDNSZone(ctx, dnsZone, cd.Spec.Platform.AWS.Region, logger)
 	case cd.Spec.Platform.Azure != nil:
-		return cleanupAzureDNSZone(dnsZone, logger)
+		return cleanupAzureDNSZone(ctx, dnsZone, logger)
 	case cd.Spec.Platform.GCP != nil:
-		return cleanupGCPDNSZone(dnsZone, logger)
+		return cleanupGCPDNSZone(ctx, dnsZone, logger)
 	default:
 		log.Debug("No DNS cleanup for platform type")
 		return nil
@@ -47,7 +48,7 @@ func cleanupDNSZone(dynClient client.Client, cd *hivev1.ClusterDeployment, logge
 
 // cleanupAWSDNSZone will return a DNS zone to the minimum set of DNS records
 // May no longer be necessary once https://jira.coreos.com/browse/CORS-1195 is fixed.
-func cleanupAWSDNSZone(dnsZone *hivev1.DNSZone, region string, logger log.FieldLogger) error {
+func cleanupAWSDNSZone(ctx context.Context, dnsZone *hivev1.DNSZone, region string, logger log.FieldLogger) error {
 	if dnsZone.Status.AWS == nil {
 		return fmt.Errorf("found non-AWS DNSZone for AWS ClusterDeployment")
 	}
@@ -59,13 +60,17 @@ func cleanupAWSDNSZone(dnsZone *hivev1.DNSZone, region string, logger log.FieldL
 	zoneLogger := logger.WithField("dnsZoneID", *dnsZone.Status.AWS.ZoneID)
 	zoneLogger.Info("cleaning up DNSZone")
 
-	awsClient, err := awsclient.NewClient(nil, "", "", region)
+	options := awsclient.Options{Region: region}
+	if dnsZone.Spec.AWS != nil {
+		options.AssumeRole = dnsZone.Spec.AWS.AssumeRole
+	}
+	awsClient, err := awsclient.New(nil, options)
 	if err != nil {
 		logger.WithError(err).Error("failed to create AWS client")
 		return err
 	}
 
-	if err := dns.DeleteAWSRecordSets(awsClient, dnsZone, zoneLogger); err != nil {
+	if err := dns.DeleteAWSRecordSets(ctx, awsClient, dnsZone, zoneLogger); err != nil {
 		logger.WithError(err).Error("failed to clean up DNS Zone")
 		return err
 	}
@@ -74,7 +79,7 @@ func cleanupAWSDNSZone(dnsZone *hivev1.DNSZone, region string, logger log.FieldL
 }
 
 // cleanupAzureDNSZone will return a DNS zone to the minimum set of DNS records
-func cleanupAzureDNSZone(dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
+func cleanupAzureDNSZone(ctx context.Context, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
 	logger = logger.WithField("dnsZoneID", dnsZone.Spec.Zone)
 	logger.Info("cleaning up DNSZone")
 
@@ -84,13 +89,17 @@ func cleanupAzureDNSZone(dnsZone *hivev1.DNSZone, logger log.FieldLogger) error
 		return err
 	}
 
-	azureClient, err := azureclient.NewClient(creds)
+	var cloudName hivev1azure.CloudEnvironment
+	if dnsZone.Spec.Azure != nil {
+		cloudName = dnsZone.Spec.Azure.CloudName
+	}
+	azureClient, err := azureclient.NewClient(creds, cloudName)
 	if err != nil {
 		logger.WithError(err).Error("failed to create Azure client")
 		return err
 	}
 
-	if err := dns.DeleteAzureRecordSets(azureClient, dnsZone, logger); err != nil {
+	if err := dns.DeleteAzureRecordSets(ctx, azureClient, dnsZone, logger); err != nil {
 		logger.WithError(err).Error("failed to clean up DNS Zone")
 		return err
 	}
@@ -98,7 +107,7 @@ func cleanupAzureDNSZone(dnsZone *hivev1.DNSZone, logger log.FieldLogger) error
 	return nil
 }
 
-func cleanupGCPDNSZone(dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
+func cleanupGCPDNSZone(ctx context.Context, dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
 	if dnsZone.Status.GCP == nil {
 		return fmt.Errorf("found non-GCP DNSZone for DNS ClusterDeployment")
 	}
@@ -122,7 +131,7 @@ func cleanupGCPDNSZone(dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
 		return err
 	}
 
-	if err := dns.DeleteGCPRecordSets(gcpClient, dnsZone, logger); err != nil {
+	if err := dns.DeleteGCPRecordSets(ctx, gcpClient, dnsZone, logger); err != nil {
 		logger.WithError(err).Error("failed to clean up DNS zone")
 		return err
 	}
@@ -0,0 +1,237 @@
+package installmanager
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	installerAssetsConfigMapStringTemplate = "%s-installer-assets"
+	installConfigAssetKey                  = "install-config.yaml"
+	omittedAssetsKey                       = "omitted-assets"
+	redactedValue                          = "REDACTED"
+
+	// maxInstallerAssetsSizeInBytes keeps the installer assets within the size limit of a ConfigMap.
+	maxInstallerAssetsSizeInBytes = 900 * 1024
+)
+
+var (
+	// installerAssetDirs are the directories of the work dir holding the manifests generated by the installer.
+	installerAssetDirs = []string{"manifests", "openshift"}
+
+	// sensitiveFieldRegex matches the names of the InstallConfig fields whose values are redacted.
+	sensitiveFieldRegex = regexp.MustCompile(`(?i)password|secret|token`)
+
+	// secretKinds are the kinds of the objects of the manifests that are never saved.
+	secretKinds = map[string]bool{"Secret": true, "SecretList": true}
+)
+
+// installerAssets are the sanitized installer assets saved for debugging, keyed by their ConfigMap key.
+type installerAssets struct {
+	data    map[string]string
+	size    int
+	omitted []string
+}
+
+func (a *installerAssets) add(key, value string) {
+	if a.data == nil {
+		a.data = map[string]string{}
+	}
+	if a.size+len(value) > maxInstallerAssetsSizeInBytes {
+		a.omit(key, "too large")
+		return
+	}
+	a.data[key] = value
+	a.size += len(value)
+}
+
+func (a *installerAssets) omit(key, reason string) {
+	a.omitted = append(a.omitted, fmt.Sprintf("%s: %s", key, reason))
+}
+
+// collectInstallConfigAsset saves the install-config.yaml of the work dir, with its credentials redacted, before the
+// installer consumes it.
+func (m *InstallManager) collectInstallConfigAsset() {
+	icData, err := ioutil.ReadFile(filepath.Join(m.WorkDir, "install-config.yaml"))
+	if err != nil {
+		m.log.WithError(err).Warn("could not read install-config.yaml to save it")
+		return
+	}
+	sanitized, err := sanitizeInstallConfig(icData)
+	if err != nil {
+		m.log.WithError(err).Warn("could not sanitize install-config.yaml, it will not be saved")
+		m.installerAssets.omit(installConfigAssetKey, "could not be sanitized")
+		return
+	}
+	m.installerAssets.add(installConfigAssetKey, string(sanitized))
+}
+
+// collectManifestAssets saves the manifests generated by the installer and provided by the user. Manifests containing
+// Secrets, or that cannot be parsed to tell, are omitted, and lines mentioning passwords are redacted from the others.
+func (m *InstallManager) collectManifestAssets() {
+	for _, dir := range installerAssetDirs {
+		files, err := ioutil.ReadDir(filepath.Join(m.WorkDir, dir))
+		if err != nil {
+			m.log.WithError(err).WithField("dir", dir).Warn("could not read manifests to save them")
+			continue
+		}
+		sort.Slice(files, func(i, j int) bool { return files[i].Name() < files[j].Name() })
+		for _, f := range files {
+			if f.IsDir() {
+				continue
+			}
+			key := installerAssetKey(dir, f.Name())
+			data, err := ioutil.ReadFile(filepath.Join(m.WorkDir, dir, f.Name()))
+			if err != nil {
+				m.log.WithError(err).WithField("file", f.Name()).Warn("could not read manifest to save it")
+				m.installerAssets.omit(key, "could not be read")
+				continue
+			}
+			hasSecret, err := containsSecret(data)
+			if err != nil {
+				m.log.WithError(err).WithField("file", f.Name()).Warn("could not parse manifest to save it")
+				m.installerAssets.omit(key, "could not be parsed")
+				continue
+			}
+			if hasSecret {
+				m.installerAssets.omit(key, "contains a Secret")
+				continue
+			}
+			m.installerAssets.add(key, multiLineRedactLinesWithPassword.ReplaceAllString(string(data), "REDACTED LINE"))
+		}
+	}
+}
+
+// installerAssetKey returns the ConfigMap key for the file in the directory of the work dir.
+func installerAssetKey(dir, file string) string {
+	return dir + "_" + file
+}
+
+// containsSecret returns whether any of the YAML or JSON documents of the manifest is a Secret, or a list holding a
+// Secret.
+func containsSecret(data []byte) (bool, error) {
+	decoder := yamlutil.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		obj := map[string]interface{}{}
+		if err := decoder.Decode(&obj); err != nil {
+			if err == io.EOF {
+				return false, nil
+			}
+			return false, errors.Wrap(err, "could not decode manifest")
+		}
+		if objectContainsSecret(obj) {
+			return true, nil
+		}
+	}
+}
+
+func objectContainsSecret(obj map[string]interface{}) bool {
+	if kind, _ := obj["kind"].(string); secretKinds[kind] {
+		return true
+	}
+	items, _ := obj["items"].([]interface{})
+	for _, item := range items {
+		if itemObj, ok := item.(map[string]interface{}); ok && objectContainsSecret(itemObj) {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeInstallConfig redacts the pull secret and any other credentials from the InstallConfig.
+func sanitizeInstallConfig(icData []byte) ([]byte, error) {
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	redactSensitiveFields(icRaw)
+	return yaml.Marshal(icRaw)
+}
+
+func redactSensitiveFields(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if sensitiveFieldRegex.MatchString(key) {
+				v[key] = redactedValue
+				continue
+			}
+			redactSensitiveFields(field)
+		}
+	case []interface{}:
+		for _, item := range v {
+			redactSensitiveFields(item)
+		}
+	}
+}
+
+// uploadInstallerAssets saves the collected installer assets to a ConfigMap owned by the provision. A nil ConfigMap is
+// returned if no assets were collected.
+func uploadInstallerAssets(provision *hivev1.ClusterProvision, m *InstallManager) (*corev1.ConfigMap, error) {
+	if len(m.installerAssets.data) == 0 && len(m.installerAssets.omitted) == 0 {
+		return nil, nil
+	}
+	m.log.Infoln("uploading installer assets")
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf(installerAssetsConfigMapStringTemplate, m.ClusterProvisionName),
+			Namespace: m.Namespace,
+		},
+		Data: map[string]string{},
+	}
+	for key, value := range m.installerAssets.data {
+		configMap.Data[key] = value
+	}
+	if len(m.installerAssets.omitted) > 0 {
+		configMap.Data[omittedAssetsKey] = strings.Join(m.installerAssets.omitted, "\n") + "\n"
+	}
+	configMap.Labels = k8slabels.AddLabel(configMap.Labels, constants.ClusterProvisionNameLabel, provision.Name)
+
+	provisionGVK, err := apiutil.GVKForObject(provision, scheme.Scheme)
+	if err != nil {
+		m.log.WithError(err).Errorf("error getting GVK for provision")
+		return nil, err
+	}
+	configMap.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion:         provisionGVK.GroupVersion().String(),
+		Kind:               provisionGVK.Kind,
+		Name:               provision.Name,
+		UID:                provision.UID,
+		BlockOwnerDeletion: pointer.BoolPtr(true),
+	}}
+
+	// Replace the assets of an earlier run of the install pod for the same provision.
+	name := types.NamespacedName{Namespace: configMap.Namespace, Name: configMap.Name}
+	if err := m.deleteAnyExistingObject(name, &corev1.ConfigMap{}); err != nil {
+		m.log.WithError(err).Error("failed to fetch/delete any pre-existing installer assets configmap")
+		return nil, err
+	}
+	if err := createWithRetries(configMap, m); err != nil {
+		return nil, err
+	}
+	m.log.WithField("size", m.installerAssets.size).WithField("omitted", len(m.installerAssets.omitted)).
+		Info("saved installer assets")
+	return configMap, nil
+}
@@ -0,0 +1,190 @@
+package installmanager
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/hive/apis"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const testSanitizedInstallConfig = `apiVersion: v1
+baseDomain: example.com
+platform:
+  vsphere:
+    password: vsphere-password
+    username: admin
+pullSecret: '{"auths":{}}'
+sshKey: ssh-rsa AAAA
+`
+
+const testConfigMapManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cloud-provider-config
+data:
+  config: |
+    user: admin
+    password: cloud-password
+`
+
+const testSecretManifest = `apiVersion: v1
+kind: Secret
+metadata:
+  name: vsphere-creds
+stringData:
+  key: value
+`
+
+func TestSanitizeInstallConfig(t *testing.T) {
+	sanitized, err := sanitizeInstallConfig([]byte(testSanitizedInstallConfig))
+	require.NoError(t, err, "unexpected error sanitizing install-config")
+	icRaw := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal(sanitized, &icRaw), "unexpected error unmarshalling InstallConfig")
+	assert.Equal(t, redactedValue, icRaw["pullSecret"], "expected pull secret to be redacted")
+	vsphere := icRaw["platform"].(map[string]interface{})["vsphere"].(map[string]interface{})
+	assert.Equal(t, redactedValue, vsphere["password"], "expected password to be redacted")
+	assert.Equal(t, "admin", vsphere["username"], "unexpected username")
+	assert.Equal(t, "example.com", icRaw["baseDomain"], "unexpected base domain")
+	assert.Equal(t, "ssh-rsa AAAA", icRaw["sshKey"], "unexpected ssh key")
+}
+
+func TestUploadInstallerAssets(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	dir, err := ioutil.TempDir("", "installerassets")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"install-config.yaml":                       testSanitizedInstallConfig,
+		"manifests/cloud-provider-config.yaml":      testConfigMapManifest,
+		"openshift/99_cloud-creds-secret.yaml":      testSecretManifest,
+		"openshift/99_invalid.yaml":                 "kind: [Secret\n",
+		"openshift/99_openshift-cluster-api_x.yaml": "kind: MachineSet\n",
+	}
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700), "unexpected error creating dir")
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600), "unexpected error writing file")
+	}
+
+	provision := testClusterProvision()
+	c := fake.NewFakeClientWithScheme(scheme.Scheme, provision)
+	m := &InstallManager{
+		log:                  log.WithField("test", t.Name()),
+		WorkDir:              dir,
+		ClusterProvisionName: provision.Name,
+		Namespace:            provision.Namespace,
+		DynamicClient:        c,
+	}
+	m.collectInstallConfigAsset()
+	m.collectManifestAssets()
+	configMap, err := uploadInstallerAssets(provision, m)
+	require.NoError(t, err, "unexpected error uploading installer assets")
+	require.NotNil(t, configMap, "expected installer assets configmap")
+
+	saved := &corev1.ConfigMap{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testProvisionName + "-installer-assets"}, saved),
+		"unexpected error getting installer assets configmap")
+	assert.Equal(t, provision.Name, saved.Labels[constants.ClusterProvisionNameLabel], "incorrect cluster provision name label")
+	if assert.Len(t, saved.OwnerReferences, 1, "expected owner reference") {
+		assert.Equal(t, provision.Name, saved.OwnerReferences[0].Name, "unexpected owner")
+	}
+
+	assert.Contains(t, saved.Data[installConfigAssetKey], "baseDomain: example.com", "expected install-config to be saved")
+	assert.NotContains(t, saved.Data[installConfigAssetKey], "auths", "expected pull secret to be redacted")
+	assert.NotContains(t, saved.Data[installConfigAssetKey], "vsphere-password", "expected password to be redacted")
+
+	manifest := saved.Data["manifests_cloud-provider-config.yaml"]
+	assert.Contains(t, manifest, "user: admin", "expected manifest to be saved")
+	assert.NotContains(t, manifest, "cloud-password", "expected password to be redacted from manifest")
+	assert.Equal(t, "kind: MachineSet\n", saved.Data["openshift_99_openshift-cluster-api_x.yaml"], "expected manifest to be saved")
+
+	_, ok := saved.Data["openshift_99_cloud-creds-secret.yaml"]
+	assert.False(t, ok, "expected secret manifest to be omitted")
+	_, ok = saved.Data["openshift_99_invalid.yaml"]
+	assert.False(t, ok, "expected invalid manifest to be omitted")
+	assert.Equal(t, "openshift_99_cloud-creds-secret.yaml: contains a Secret\nopenshift_99_invalid.yaml: could not be parsed\n",
+		saved.Data[omittedAssetsKey], "unexpected omitted assets")
+}
+
+func TestContainsSecret(t *testing.T) {
+	cases := []struct {
+		name           string
+		manifest       string
+		expectedSecret bool
+		expectErr      bool
+	}{
+		{
+			name:           "secret",
+			manifest:       testSecretManifest,
+			expectedSecret: true,
+		},
+		{
+			name:     "configmap",
+			manifest: testConfigMapManifest,
+		},
+		{
+			name:           "quoted kind",
+			manifest:       "apiVersion: v1\nkind: \"Secret\"\nmetadata:\n  name: vsphere-creds\n",
+			expectedSecret: true,
+		},
+		{
+			name:           "json",
+			manifest:       `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"vsphere-creds"},"stringData":{"key":"value"}}`,
+			expectedSecret: true,
+		},
+		{
+			name:           "list",
+			manifest:       "apiVersion: v1\nkind: List\nitems:\n- apiVersion: v1\n  kind: ConfigMap\n- apiVersion: v1\n  kind: Secret\n",
+			expectedSecret: true,
+		},
+		{
+			name:     "list without secret",
+			manifest: "apiVersion: v1\nkind: List\nitems:\n- apiVersion: v1\n  kind: ConfigMap\n",
+		},
+		{
+			name:           "secret list",
+			manifest:       `{"apiVersion":"v1","kind":"SecretList","items":[]}`,
+			expectedSecret: true,
+		},
+		{
+			name:           "multiple documents",
+			manifest:       testConfigMapManifest + "---\n" + testSecretManifest,
+			expectedSecret: true,
+		},
+		{
+			name:     "empty documents",
+			manifest: "---\n" + testConfigMapManifest + "---\n",
+		},
+		{
+			name:      "invalid",
+			manifest:  "kind: [Secret\n",
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasSecret, err := containsSecret([]byte(tc.manifest))
+			if tc.expectErr {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectedSecret, hasSecret, "unexpected result")
+		})
+	}
+}
@@ -54,9 +54,17 @@ import (
 	installertypesvsphere "github.com/openshift/installer/pkg/types/vsphere"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1powervs "github.com/openshift/hive/apis/hive/v1/powervs"
 	contributils "github.com/openshift/hive/contrib/pkg/utils"
+	azureutils "github.com/openshift/hive/contrib/pkg/utils/azure"
+	nutanixutils "github.com/openshift/hive/contrib/pkg/utils/nutanix"
+	powervsutils "github.com/openshift/hive/contrib/pkg/utils/powervs"
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/gcpclient"
+	"github.com/openshift/hive/pkg/ibmclient"
+	"github.com/openshift/hive/pkg/install"
+	"github.com/openshift/hive/pkg/nutanixclient"
 	"github.com/openshift/hive/pkg/resource"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
 )
@@ -74,10 +82,12 @@ const (
 	installerFullLogFile                = ".openshift_install.log"
 	installerConsoleLogFilePath         = "/tmp/openshift-install-console.log"
 	provisioningTransitionTimeout       = 5 * time.Minute
+	skipHostCryptValidationEnvVar       = "OPENSHIFT_INSTALL_SKIP_HOSTCRYPT_VALIDATION"
 	sshCopyTempFile                     = "/tmp/ssh-privatekey"
 	defaultInstallConfigMountPath       = "/installconfig/install-config.yaml"
 	defaultPullSecretMountPath          = "/pullsecret/" + corev1.DockerConfigJsonKey
 	defaultManifestsMountPath           = "/manifests"
+	defaultAdditionalTrustBundlePath    = install.AdditionalTrustBundleDir + "/" + constants.AdditionalTrustBundleConfigMapKey
 	defaultHomeDir                      = "/home/hive" // Used if no HOME env var set.
 )
 
@@ -100,17 +110,21 @@ type InstallManager struct {
 	InstallConfigMountPath           string
 	PullSecretMountPath              string
 	ManifestsMountPath               string
+	AdditionalTrustBundlePath        string
 	DynamicClient                    client.Client
-	cleanupFailedProvision           func(dynamicClient client.Client, cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) error
+	cleanupFailedProvision           func(ctx context.Context, dynamicClient client.Client, cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) error
 	updateClusterProvision           func(*hivev1.ClusterProvision, *InstallManager, provisionMutation) error
 	readClusterMetadata              func(*hivev1.ClusterProvision, *InstallManager) ([]byte, *installertypes.ClusterMetadata, error)
 	uploadAdminKubeconfig            func(*hivev1.ClusterProvision, *InstallManager) (*corev1.Secret, error)
 	uploadAdminPassword              func(*hivev1.ClusterProvision, *InstallManager) (*corev1.Secret, error)
 	loadAdminPassword                func(*InstallManager) (string, error)
 	provisionCluster                 func(*InstallManager) error
+	resumeProvisionCluster           func(*InstallManager) error
 	readInstallerLog                 func(*hivev1.ClusterProvision, *InstallManager, bool) (string, error)
 	waitForProvisioningStage         func(*hivev1.ClusterProvision, *InstallManager) error
+	uploadInstallerAssets            func(*hivev1.ClusterProvision, *InstallManager) (*corev1.ConfigMap, error)
 	waitForInstallCompleteExecutions int
+	installerAssets                  installerAssets
 	binaryDir                        string
 	actuator                         LogUploaderActuator
 }
@@ -139,6 +153,7 @@ func NewInstallManagerCommand() *cobra.Command {
 			im.InstallConfigMountPath = defaultInstallConfigMountPath
 			im.PullSecretMountPath = defaultPullSecretMountPath
 			im.ManifestsMountPath = defaultManifestsMountPath
+			im.AdditionalTrustBundlePath = defaultAdditionalTrustBundlePath
 			im.binaryDir = getHomeDir()
 
 			if err := im.Validate(); err != nil {
@@ -177,7 +192,9 @@ func (m *InstallManager) Complete(args []string) error {
 	m.readInstallerLog = readInstallerLog
 	m.cleanupFailedProvision = cleanupFailedProvision
 	m.provisionCluster = provisionCluster
+	m.resumeProvisionCluster = resumeProvisionCluster
 	m.waitForProvisioningStage = waitForProvisioningStage
+	m.uploadInstallerAssets = uploadInstallerAssets
 
 	// Set log level
 	level, err := log.ParseLevel(m.LogLevel)
@@ -314,6 +331,31 @@ func (m *InstallManager) Run() error {
 		m.log.WithError(err).Error("error adding pull secret to install-config.yaml")
 		return err
 	}
+	if cd.Spec.AdditionalTrustBundle != nil {
+		icData, err = pasteInAdditionalTrustBundle(icData, m.AdditionalTrustBundlePath)
+		if err != nil {
+			m.log.WithError(err).Error("error adding additional trust bundle to install-config.yaml")
+			return err
+		}
+	}
+	if cd.Spec.Platform.PowerVS != nil {
+		icData, err = pasteInPowerVSPlatform(icData, cd.Spec.Platform.PowerVS)
+		if err != nil {
+			m.log.WithError(err).Error("error adding PowerVS platform to install-config.yaml")
+			return err
+		}
+	}
+	if cd.Spec.Provisioning != nil && cd.Spec.Provisioning.ManualCredentials != nil {
+		icData, err = pasteInManualCredentialsMode(icData)
+		if err != nil {
+			m.log.WithError(err).Error("error setting manual credentials mode in install-config.yaml")
+			return err
+		}
+	}
+	if err := checkHostFIPS(icData); err != nil {
+		m.log.WithError(err).Error("cannot install cluster in FIPS mode")
+		return err
+	}
 	destInstallConfigPath := filepath.Join(m.WorkDir, "install-config.yaml")
 	if err := ioutil.WriteFile(destInstallConfigPath, icData, 0644); err != nil {
 		m.log.WithError(err).Error("error writing install-config.yaml")
@@ -328,36 +370,67 @@ func (m *InstallManager) Run() error {
 		}
 	}
 
-	// If the cluster provision has an infraID set, this implies we failed an install
-	// and are re-trying. Cleanup any resources that may have been provisioned.
-	m.log.Info("cleaning up from past install attempts")
-	if err := m.cleanupFailedInstall(cd, provision); err != nil {
-		m.log.WithError(err).Error("error while trying to preemptively clean up")
+	// If the previous install attempt was lost after the bootstrap of the cluster completed, resume it from the
+	// saved install state rather than destroying the cluster.
+	resumed, err := m.restoreInstallState(cd, provision)
+	if err != nil {
+		m.log.WithError(err).Error("error restoring install state")
 		return err
 	}
 
-	// Generate installer assets we need to modify or upload.
-	m.log.Info("generating assets")
-	if err := m.generateAssets(cd); err != nil {
-		m.log.Info("reading installer log")
-		installLog, readErr := m.readInstallerLog(provision, m, scrubInstallLog)
-		if readErr != nil {
-			m.log.WithError(readErr).Error("error reading asset generation log")
+	if resumed {
+		m.log.Info("resuming install of previous install attempt")
+		if err := m.cleanupAdminKubeconfigSecret(); err != nil {
+			return err
+		}
+		if err := m.cleanupAdminPasswordSecret(); err != nil {
+			return err
+		}
+	} else {
+		// If the cluster provision has an infraID set, this implies we failed an install
+		// and are re-trying. Cleanup any resources that may have been provisioned.
+		m.log.Info("cleaning up from past install attempts")
+		if err := m.cleanupFailedInstall(cd, provision); err != nil {
+			m.log.WithError(err).Error("error while trying to preemptively clean up")
 			return err
 		}
+	}
 
-		m.log.Info("updating clusterprovision")
-		if err := m.updateClusterProvision(
-			provision,
-			m,
-			func(provision *hivev1.ClusterProvision) {
-				provision.Spec.InstallLog = pointer.StringPtr(installLog)
-			},
-		); err != nil {
-			m.log.WithError(err).Error("error updating cluster provision with asset generation log")
+	// Generate installer assets we need to modify or upload, unless they were restored from the previous attempt.
+	var installerAssetsRef *corev1.LocalObjectReference
+	if !resumed {
+		m.log.Info("generating assets")
+		err := m.generateAssets(cd)
+
+		// Save the sanitized assets so that a failed install can be reproduced. This is not a fatal error.
+		if configMap, uploadErr := m.uploadInstallerAssets(provision, m); uploadErr != nil {
+			m.log.WithError(uploadErr).Warning("error uploading installer assets")
+		} else if configMap != nil {
+			installerAssetsRef = &corev1.LocalObjectReference{Name: configMap.Name}
+		}
+
+		if err != nil {
+			m.log.Info("reading installer log")
+			installLog, readErr := m.readInstallerLog(provision, m, scrubInstallLog)
+			if readErr != nil {
+				m.log.WithError(readErr).Error("error reading asset generation log")
+				return err
+			}
+
+			m.log.Info("updating clusterprovision")
+			if err := m.updateClusterProvision(
+				provision,
+				m,
+				func(provision *hivev1.ClusterProvision) {
+					provision.Spec.InstallLog = pointer.StringPtr(installLog)
+					provision.Spec.InstallerAssetsRef = installerAssetsRef
+				},
+			); err != nil {
+				m.log.WithError(err).Error("error updating cluster provision with asset generation log")
+				return err
+			}
 			return err
 		}
-		return err
 	}
 
 	// We should now have cluster metadata.json we can parse for the infra ID,
@@ -395,6 +468,9 @@ func (m *InstallManager) Run() error {
 			provision.Spec.AdminPasswordSecretRef = &corev1.LocalObjectReference{
 				Name: passwordSecret.Name,
 			}
+			if installerAssetsRef != nil {
+				provision.Spec.InstallerAssetsRef = installerAssetsRef
+			}
 		},
 	); err != nil {
 		m.log.WithError(err).Error("error updating cluster provision with cluster metadata")
@@ -417,7 +493,26 @@ func (m *InstallManager) Run() error {
 		}
 	}
 
-	installErr := m.provisionCluster(m)
+	var installErr error
+	if resumed {
+		installErr = m.resumeProvisionCluster(m)
+	} else {
+		stopSavingInstallState := func() {}
+		if installStateResumable(cd) {
+			ctx, cancel := context.WithCancel(context.Background())
+			saved := make(chan struct{})
+			go func() {
+				defer close(saved)
+				m.saveInstallStateOnBootstrapComplete(ctx, cd, metadata.InfraID)
+			}()
+			stopSavingInstallState = func() {
+				cancel()
+				<-saved
+			}
+		}
+		installErr = m.provisionCluster(m)
+		stopSavingInstallState()
+	}
 	if installErr != nil {
 		m.log.WithError(installErr).Error("error running openshift-install, running deprovision to clean up")
 
@@ -455,6 +550,13 @@ func (m *InstallManager) Run() error {
 		m.log.WithError(err).Error("error reading installer log")
 	}
 
+	if installStateResumable(cd) {
+		// The install completed or failed, so the next install attempt, if any, must start over.
+		if err := m.deleteInstallState(cd); err != nil {
+			m.log.WithError(err).Warning("error deleting install state")
+		}
+	}
+
 	if installErr != nil {
 		m.log.WithError(installErr).Error("failed due to install error")
 		return installErr
@@ -534,7 +636,7 @@ func (m *InstallManager) cleanupFailedInstall(cd *hivev1.ClusterDeployment, prov
 	}
 	if infraID != nil {
 		m.log.Info("InfraID set from failed install, running deprovison")
-		if err := m.cleanupFailedProvision(m.DynamicClient, cd, *infraID, m.log); err != nil {
+		if err := m.cleanupFailedProvision(context.Background(), m.DynamicClient, cd, *infraID, m.log); err != nil {
 			return err
 		}
 	} else {
@@ -552,7 +654,7 @@ func (m *InstallManager) cleanupFailedInstall(cd *hivev1.ClusterDeployment, prov
 	return nil
 }
 
-func cleanupFailedProvision(dynClient client.Client, cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) error {
+func cleanupFailedProvision(ctx context.Context, dynClient client.Client, cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) error {
 	var uninstaller providers.Destroyer
 	switch {
 	case cd.Spec.Platform.AWS != nil:
@@ -565,6 +667,14 @@ func cleanupFailedProvision(dynClient client.Client, cd *hivev1.ClusterDeploymen
 			Region:  cd.Spec.Platform.AWS.Region,
 			Logger:  logger,
 		}
+	case cd.Spec.Platform.Azure != nil && cd.Spec.Platform.Azure.ResourceGroupName != "":
+		// Only the resources created for the cluster may be deleted from an existing resource group.
+		uninstaller = &azureutils.ExistingResourceGroupUninstaller{
+			InfraID:           infraID,
+			ResourceGroupName: cd.Spec.Platform.Azure.ResourceGroupName,
+			CloudName:         installertypesazure.PublicCloud,
+			Logger:            logger,
+		}
 	case cd.Spec.Platform.Azure != nil:
 		metadata := &installertypes.ClusterMetadata{
 			InfraID: infraID,
@@ -653,6 +763,35 @@ func cleanupFailedProvision(dynClient client.Client, cd *hivev1.ClusterDeploymen
 		if err != nil {
 			return err
 		}
+	case cd.Spec.Platform.Nutanix != nil:
+		username := os.Getenv(constants.NutanixUsernameEnvVar)
+		if username == "" {
+			return fmt.Errorf("No %s env var set, cannot proceed", constants.NutanixUsernameEnvVar)
+		}
+		password := os.Getenv(constants.NutanixPasswordEnvVar)
+		if password == "" {
+			return fmt.Errorf("No %s env var set, cannot proceed", constants.NutanixPasswordEnvVar)
+		}
+		// The CA certificates of Prism Central, if any, are added to the system trust by the install pod.
+		client, err := nutanixclient.NewClient(cd.Spec.Platform.Nutanix.PrismCentral, username, password, nil)
+		if err != nil {
+			return err
+		}
+		uninstaller = &nutanixutils.ClusterUninstaller{
+			InfraID: infraID,
+			Client:  client,
+			Logger:  logger,
+		}
+	case cd.Spec.Platform.PowerVS != nil:
+		apiKey := os.Getenv(constants.PowerVSAPIKeyEnvVar)
+		if apiKey == "" {
+			return fmt.Errorf("No %s env var set, cannot proceed", constants.PowerVSAPIKeyEnvVar)
+		}
+		uninstaller = &powervsutils.ClusterUninstaller{
+			InfraID: infraID,
+			Client:  ibmclient.NewPowerVSClient(apiKey, cd.Spec.Platform.PowerVS.Region, cd.Spec.Platform.PowerVS.ServiceInstanceID),
+			Logger:  logger,
+		}
 	default:
 		logger.Warn("unknown platform for re-try cleanup")
 		return errors.New("unknown platform for re-try cleanup")
@@ -662,12 +801,14 @@ func cleanupFailedProvision(dynClient client.Client, cd *hivev1.ClusterDeploymen
 		return err
 	}
 
-	return cleanupDNSZone(dynClient, cd, logger)
+	return cleanupDNSZone(ctx, dynClient, cd, logger)
 }
 
 // generateAssets runs openshift-install commands to generate on-disk assets we need to
 // upload or modify prior to provisioning resources in the cloud.
 func (m *InstallManager) generateAssets(cd *hivev1.ClusterDeployment) error {
+	m.collectInstallConfigAsset()
+
 	m.log.Info("running openshift-install create manifests")
 	err := m.runOpenShiftInstallCommand("create", "manifests")
 	if err != nil {
@@ -704,6 +845,16 @@ func (m *InstallManager) generateAssets(cd *hivev1.ClusterDeployment) error {
 		m.log.Infof("copied %s to %s", src, dest)
 	}
 
+	if cd.Spec.Provisioning != nil && cd.Spec.Provisioning.ManualCredentials != nil {
+		if err := m.writeManualCredentials(cd); err != nil {
+			m.log.WithError(err).Error("error adding manual credentials manifests")
+			return err
+		}
+	}
+
+	// The manifests are consumed by the installer when creating the ignition configs.
+	m.collectManifestAssets()
+
 	m.log.Info("running openshift-install create ignition-configs")
 	if err := m.runOpenShiftInstallCommand("create", "ignition-configs"); err != nil {
 		m.log.WithError(err).Error("error generating installer assets")
@@ -737,6 +888,17 @@ func provisionCluster(m *InstallManager) error {
 	return nil
 }
 
+// resumeProvisionCluster invokes the openshift-install wait-for install-complete command to resume the provisioning
+// of a cluster whose bootstrap completed in a previous install attempt.
+func resumeProvisionCluster(m *InstallManager) error {
+	m.log.Info("running openshift-install wait-for install-complete")
+	if err := m.runOpenShiftInstallCommand("wait-for", "install-complete"); err != nil {
+		m.log.WithError(err).Error("error waiting for install to complete")
+		return err
+	}
+	return nil
+}
+
 func (m *InstallManager) runOpenShiftInstallCommand(args ...string) error {
 	m.log.WithField("args", args).Info("running openshift-install binary")
 	cmd := exec.Command(filepath.Join(m.binaryDir, "openshift-install"), args...)
@@ -916,7 +1078,7 @@ func (m *InstallManager) gatherLogs(provision *hivev1.ClusterProvision, cd *hive
 		filepaths = append(filepaths, filepath.Join(m.LogsDir, file.Name()))
 	}
 
-	uploadErr := m.actuator.UploadLogs(cd.Spec.ClusterName, provision, m.DynamicClient, m.log, filepaths...)
+	uploadErr := m.actuator.UploadLogs(context.Background(), cd.Spec.ClusterName, provision, m.DynamicClient, m.log, filepaths...)
 	if uploadErr != nil {
 		m.log.WithError(uploadErr).Error("error uploading logs")
 	}
@@ -1439,6 +1601,69 @@ func pasteInPullSecret(icData []byte, pullSecretFile string) ([]byte, error) {
 	return yaml.Marshal(icRaw)
 }
 
+// pasteInAdditionalTrustBundle appends the additional trust bundle in the file to the additionalTrustBundle of the
+// InstallConfig.
+func pasteInAdditionalTrustBundle(icData []byte, trustBundleFile string) ([]byte, error) {
+	trustBundleData, err := ioutil.ReadFile(trustBundleFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read the additional trust bundle file")
+	}
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	trustBundle, _ := icRaw["additionalTrustBundle"].(string)
+	if trustBundle != "" && !strings.HasSuffix(trustBundle, "\n") {
+		trustBundle += "\n"
+	}
+	icRaw["additionalTrustBundle"] = trustBundle + string(trustBundleData)
+	return yaml.Marshal(icRaw)
+}
+
+// pasteInPowerVSPlatform sets the region, zone and workspace of the PowerVS platform of the InstallConfig to those of
+// the ClusterDeployment, keeping any other settings of the platform. The vendored installer types have no PowerVS
+// platform, so the InstallConfig is edited raw.
+func pasteInPowerVSPlatform(icData []byte, platform *hivev1powervs.Platform) ([]byte, error) {
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	platformRaw, _ := icRaw["platform"].(map[string]interface{})
+	if platformRaw == nil {
+		platformRaw = map[string]interface{}{}
+	}
+	powerVSRaw, _ := platformRaw["powervs"].(map[string]interface{})
+	if powerVSRaw == nil {
+		powerVSRaw = map[string]interface{}{}
+	}
+	powerVSRaw["region"] = platform.Region
+	powerVSRaw["zone"] = platform.Zone
+	powerVSRaw["serviceInstanceID"] = platform.ServiceInstanceID
+	platformRaw["powervs"] = powerVSRaw
+	icRaw["platform"] = platformRaw
+	return yaml.Marshal(icRaw)
+}
+
+// checkHostFIPS returns an error if the InstallConfig requests FIPS mode and the node of the install pod is not
+// running in FIPS mode, which the installer would only report after the cleanup of any previous install attempt.
+func checkHostFIPS(icData []byte) error {
+	if os.Getenv(skipHostCryptValidationEnvVar) != "" {
+		return nil
+	}
+	fips, err := controllerutils.InstallConfigRequestsFIPS(icData)
+	if err != nil || !fips {
+		return err
+	}
+	hostFIPS, err := controllerutils.HostFIPSEnabled()
+	if err != nil {
+		return err
+	}
+	if !hostFIPS {
+		return errors.New("the InstallConfig requests FIPS mode but the node of the install pod is not running in FIPS mode")
+	}
+	return nil
+}
+
 func getHomeDir() string {
 	home := os.Getenv("HOME")
 	if home != "" {
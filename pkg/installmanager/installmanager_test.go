@@ -24,9 +24,11 @@ import (
 
 	installertypes "github.com/openshift/installer/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1powervs "github.com/openshift/hive/apis/hive/v1/powervs"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
 )
@@ -92,6 +94,7 @@ func TestInstallManager(t *testing.T) {
 		expectPasswordSecret          bool
 		expectProvisionMetadataUpdate bool
 		expectProvisionLogUpdate      bool
+		expectResumed                 bool
 		expectError                   bool
 	}{
 		{
@@ -137,6 +140,27 @@ func TestInstallManager(t *testing.T) {
 			expectKubeconfigSecret:  true,
 			expectError:             true,
 		},
+		{
+			name: "resumed install",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Spec.Provisioning.ResumableInstall = true
+					return cd
+				}(),
+				func() *hivev1.ClusterProvision {
+					provision := testClusterProvision()
+					provision.Spec.PrevInfraID = pointer.StringPtr(testInfraID)
+					return provision
+				}(),
+				testInstallStateSecret(t, testInfraID),
+			},
+			expectKubeconfigSecret:        true,
+			expectPasswordSecret:          true,
+			expectProvisionMetadataUpdate: true,
+			expectProvisionLogUpdate:      true,
+			expectResumed:                 true,
+		},
 		{
 			name:                          "failed saving of installer log", // non-fatal
 			existing:                      []runtime.Object{testClusterDeployment(), testClusterProvision()},
@@ -239,6 +263,19 @@ func TestInstallManager(t *testing.T) {
 
 			// We don't want to run the uninstaller, so stub it out
 			im.cleanupFailedProvision = alwaysSucceedCleanupFailedProvision
+			if test.expectResumed {
+				im.cleanupFailedProvision = func(context.Context, client.Client, *hivev1.ClusterDeployment, string, log.FieldLogger) error {
+					t.Error("unexpected cleanup of resumed install")
+					return nil
+				}
+				im.provisionCluster = func(*InstallManager) error {
+					t.Error("unexpected provision of resumed install")
+					return nil
+				}
+				im.resumeProvisionCluster = func(m *InstallManager) error {
+					return ioutil.WriteFile(installerConsoleLogFilePath, []byte("some fake installer log output\n"), 0644)
+				}
+			}
 
 			// Save the list of actuators so that it can be restored at the end of this test
 			im.actuator = &s3LogUploaderActuator{awsClientFn: func(c client.Client, secretName, namespace, region string, logger log.FieldLogger) (awsclient.Client, error) {
@@ -324,6 +361,14 @@ func TestInstallManager(t *testing.T) {
 				assert.Nil(t, provision.Spec.AdminPasswordSecretRef, "expected password secret reference to be empty")
 			}
 
+			if test.expectProvisionMetadataUpdate && !test.expectResumed {
+				if assert.NotNil(t, provision.Spec.InstallerAssetsRef, "expected installer assets reference to be set") {
+					assert.Equal(t, "test-provision-installer-assets", provision.Spec.InstallerAssetsRef.Name, "unexpected name for installer assets reference")
+				}
+			} else {
+				assert.Nil(t, provision.Spec.InstallerAssetsRef, "expected installer assets reference to be empty")
+			}
+
 			if test.expectProvisionLogUpdate {
 				if assert.NotNil(t, provision.Spec.InstallLog, "expected install log to be set") {
 					assert.Equal(t, "some fake installer log output\n", *provision.Spec.InstallLog, "did not find expected contents in saved installer log")
@@ -331,6 +376,13 @@ func TestInstallManager(t *testing.T) {
 			} else {
 				assert.Nil(t, provision.Spec.InstallLog, "expected install log to be empty")
 			}
+
+			if test.expectResumed {
+				err = mocks.fakeKubeClient.Get(context.Background(),
+					types.NamespacedName{Namespace: testNamespace, Name: testDeploymentName + "-install-state"},
+					&corev1.Secret{})
+				assert.True(t, apierrors.IsNotFound(err), "expected install state to be deleted after install")
+			}
 		})
 	}
 }
@@ -353,7 +405,7 @@ func testClusterDeployment() *hivev1.ClusterDeployment {
 	}
 }
 
-func alwaysSucceedCleanupFailedProvision(client.Client, *hivev1.ClusterDeployment, string, log.FieldLogger) error {
+func alwaysSucceedCleanupFailedProvision(context.Context, client.Client, *hivev1.ClusterDeployment, string, log.FieldLogger) error {
 	log.Debugf("running always successful uninstall")
 	return nil
 }
@@ -712,3 +764,60 @@ func Test_pasteInPullSecret(t *testing.T) {
 		})
 	}
 }
+
+func Test_pasteInAdditionalTrustBundle(t *testing.T) {
+	const testCA = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+	cases := []struct {
+		name                string
+		installConfig       string
+		expectedTrustBundle string
+	}{
+		{
+			name:                "no existing trust bundle",
+			installConfig:       "baseDomain: example.com\n",
+			expectedTrustBundle: testCA,
+		},
+		{
+			name:                "existing trust bundle",
+			installConfig:       "additionalTrustBundle: |-\n  existing\nbaseDomain: example.com\n",
+			expectedTrustBundle: "existing\n" + testCA,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "installmanagertest")
+			require.NoError(t, err, "unexpected error creating temp dir")
+			defer os.RemoveAll(dir)
+			trustBundleFile := filepath.Join(dir, "ca-bundle.crt")
+			require.NoError(t, ioutil.WriteFile(trustBundleFile, []byte(testCA), 0644), "unexpected error writing trust bundle")
+
+			actual, err := pasteInAdditionalTrustBundle([]byte(tc.installConfig), trustBundleFile)
+			require.NoError(t, err, "unexpected error pasting in additional trust bundle")
+			icRaw := map[string]interface{}{}
+			require.NoError(t, yaml.Unmarshal(actual, &icRaw), "unexpected error unmarshalling InstallConfig")
+			assert.Equal(t, tc.expectedTrustBundle, icRaw["additionalTrustBundle"], "unexpected additional trust bundle")
+			assert.Equal(t, "example.com", icRaw["baseDomain"], "unexpected base domain")
+		})
+	}
+}
+
+func Test_pasteInPowerVSPlatform(t *testing.T) {
+	icData := []byte("baseDomain: example.com\nplatform:\n  powervs:\n    userID: user\n    zone: old\n")
+	actual, err := pasteInPowerVSPlatform(icData, &hivev1powervs.Platform{
+		Region:            "dal",
+		Zone:              "dal12",
+		ServiceInstanceID: "workspace-guid",
+	})
+	require.NoError(t, err, "unexpected error pasting in PowerVS platform")
+	icRaw := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal(actual, &icRaw), "unexpected error unmarshalling InstallConfig")
+	assert.Equal(t, map[string]interface{}{
+		"powervs": map[string]interface{}{
+			"region":            "dal",
+			"zone":              "dal12",
+			"serviceInstanceID": "workspace-guid",
+			"userID":            "user",
+		},
+	}, icRaw["platform"], "unexpected platform")
+	assert.Equal(t, "example.com", icRaw["baseDomain"], "unexpected base domain")
+}
@@ -0,0 +1,251 @@
+package installmanager
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	installStateSecretStringTemplate = "%s-install-state"
+	installStateSecretKey            = "state.tar.gz"
+	installStateInfraIDSecretKey     = "infraID"
+
+	// maxInstallStateSizeInBytes keeps the checkpoint within the size limit of a Secret.
+	maxInstallStateSizeInBytes = 900 * 1024
+)
+
+var (
+	// installStateFiles are the files of the work dir needed to wait for an install to complete.
+	installStateFiles = []string{
+		metadataRelativePath,
+		adminKubeConfigRelativePath,
+		adminPasswordRelativePath,
+		".openshift_install_state.json",
+	}
+
+	// bootstrapCompleteLogRegex matches the installer log line written once the bootstrap resources have been
+	// destroyed and the installer waits for the cluster to initialize.
+	bootstrapCompleteLogRegex = regexp.MustCompile(`Waiting up to \S+ .*for the cluster at \S+ to initialize`)
+
+	// installStateCheckInterval is how often the installer log is checked for the completion of the bootstrap.
+	installStateCheckInterval = 30 * time.Second
+)
+
+// installStateResumable returns true if a lost install of the cluster may be resumed from saved install state.
+func installStateResumable(cd *hivev1.ClusterDeployment) bool {
+	if cd.Spec.Provisioning == nil || !cd.Spec.Provisioning.ResumableInstall {
+		return false
+	}
+	// The privatelink controllers clean up the endpoints of the previous infra ID of a provision, which would
+	// be the infra ID of the resumed cluster.
+	if aws := cd.Spec.Platform.AWS; aws != nil && aws.PrivateLink != nil && aws.PrivateLink.Enabled {
+		return false
+	}
+	if gcp := cd.Spec.Platform.GCP; gcp != nil && gcp.PrivateServiceConnect != nil && gcp.PrivateServiceConnect.Enabled {
+		return false
+	}
+	return true
+}
+
+func installStateSecretName(cd *hivev1.ClusterDeployment) types.NamespacedName {
+	return types.NamespacedName{Namespace: cd.Namespace, Name: fmt.Sprintf(installStateSecretStringTemplate, cd.Name)}
+}
+
+// restoreInstallState restores the work dir from the install state saved by the previous install attempt. It returns
+// true if the install state was restored and the install should be resumed. Install state that cannot be used to
+// resume the install is deleted.
+func (m *InstallManager) restoreInstallState(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision) (bool, error) {
+	secret := &corev1.Secret{}
+	switch err := m.DynamicClient.Get(context.Background(), installStateSecretName(cd), secret); {
+	case apierrors.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		m.log.WithError(err).Error("error getting install state secret")
+		return false, err
+	}
+
+	infraID := string(secret.Data[installStateInfraIDSecretKey])
+	switch {
+	case !installStateResumable(cd):
+		m.log.Info("install state found but the install cannot be resumed")
+	case provision.Spec.PrevInfraID == nil || *provision.Spec.PrevInfraID != infraID:
+		m.log.WithField("infraID", infraID).Info("install state found for a different infra ID")
+	default:
+		if err := extractInstallState(secret.Data[installStateSecretKey], m.WorkDir); err != nil {
+			// The install state may have been tampered with, so fall back to cleaning up the previous install.
+			m.log.WithError(err).Warn("could not restore install state")
+			break
+		}
+		m.log.WithField("infraID", infraID).Info("restored install state of previous install attempt")
+		return true, nil
+	}
+	return false, m.deleteInstallState(cd)
+}
+
+// saveInstallState saves a checkpoint of the work dir to a Secret so that the install can be resumed by the next
+// install attempt if this one is lost.
+func (m *InstallManager) saveInstallState(cd *hivev1.ClusterDeployment, infraID string) error {
+	state, err := archiveInstallState(m.WorkDir)
+	if err != nil {
+		return errors.Wrap(err, "could not archive install state")
+	}
+	if len(state) > maxInstallStateSizeInBytes {
+		m.log.WithField("size", len(state)).Warn("install state is too large to be saved, the install will not be resumable")
+		return nil
+	}
+
+	name := installStateSecretName(cd)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+		},
+		Data: map[string][]byte{
+			installStateSecretKey:        state,
+			installStateInfraIDSecretKey: []byte(infraID),
+		},
+	}
+	secret.Labels = k8slabels.AddLabel(secret.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+	secret.Labels = k8slabels.AddLabel(secret.Labels, constants.SecretTypeLabel, constants.SecretTypeInstallState)
+
+	cdGVK, err := apiutil.GVKForObject(cd, scheme.Scheme)
+	if err != nil {
+		m.log.WithError(err).Errorf("error getting GVK for clusterdeployment")
+		return err
+	}
+	// The install state outlives the provision so that the next install attempt can use it.
+	secret.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: cdGVK.GroupVersion().String(),
+		Kind:       cdGVK.Kind,
+		Name:       cd.Name,
+		UID:        cd.UID,
+	}}
+
+	if err := m.deleteInstallState(cd); err != nil {
+		return err
+	}
+	if err := createWithRetries(secret, m); err != nil {
+		return err
+	}
+	m.log.WithField("size", len(state)).Info("saved install state")
+	return nil
+}
+
+// deleteInstallState deletes any install state saved for the cluster.
+func (m *InstallManager) deleteInstallState(cd *hivev1.ClusterDeployment) error {
+	if err := m.deleteAnyExistingObject(installStateSecretName(cd), &corev1.Secret{}); err != nil {
+		m.log.WithError(err).Error("failed to fetch/delete any pre-existing install state secret")
+		return err
+	}
+	return nil
+}
+
+// saveInstallStateOnBootstrapComplete waits for the installer to log that the bootstrap of the cluster has completed
+// and then saves the install state. It returns when the install state is saved or the context is done.
+func (m *InstallManager) saveInstallStateOnBootstrapComplete(ctx context.Context, cd *hivev1.ClusterDeployment, infraID string) {
+	logPath := filepath.Join(m.WorkDir, installerFullLogFile)
+	ticker := time.NewTicker(installStateCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		installLog, err := ioutil.ReadFile(logPath)
+		if err != nil || !bootstrapCompleteLogRegex.Match(installLog) {
+			continue
+		}
+		m.log.Info("bootstrap complete, saving install state")
+		if err := m.saveInstallState(cd, infraID); err != nil {
+			// Not a fatal error, the install will just not be resumable.
+			m.log.WithError(err).Warn("could not save install state")
+		}
+		return
+	}
+}
+
+// archiveInstallState returns a gzipped tarball of the install state files in the work dir.
+func archiveInstallState(workDir string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	gzw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gzw)
+	for _, name := range installStateFiles {
+		data, err := ioutil.ReadFile(filepath.Join(workDir, name))
+		switch {
+		case os.IsNotExist(err):
+			continue
+		case err != nil:
+			return nil, err
+		}
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(data))}); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gzw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// extractInstallState extracts the install state files from a gzipped tarball into the work dir.
+func extractInstallState(state []byte, workDir string) error {
+	gzr, err := gzip.NewReader(bytes.NewReader(state))
+	if err != nil {
+		return err
+	}
+	defer gzr.Close()
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		dest := filepath.Join(workDir, filepath.Clean(hdr.Name))
+		if !strings.HasPrefix(dest, filepath.Clean(workDir)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid file %q in install state", hdr.Name)
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0700); err != nil {
+			return err
+		}
+		data, err := ioutil.ReadAll(io.LimitReader(tr, maxInstallStateSizeInBytes*10))
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(dest, data, 0600); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package installmanager
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/hive/apis"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const testInfraID = "test-cluster-fe9531"
+
+var testInstallStateFiles = map[string]string{
+	metadataRelativePath:            `{"clusterName":"test-cluster","infraID":"test-cluster-fe9531","clusterID":"fe953108-f64c-4166-bb8e-20da7665ba00"}`,
+	adminKubeConfigRelativePath:     "fakekubeconfig\n",
+	adminPasswordRelativePath:       "fakepassword\n",
+	".openshift_install_state.json": "{}",
+}
+
+func writeInstallStateFiles(t *testing.T, dir string) {
+	for name, contents := range testInstallStateFiles {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700), "unexpected error creating dir")
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600), "unexpected error writing file")
+	}
+}
+
+func testInstallStateSecret(t *testing.T, infraID string) *corev1.Secret {
+	dir, err := ioutil.TempDir("", "installstate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	writeInstallStateFiles(t, dir)
+	state, err := archiveInstallState(dir)
+	require.NoError(t, err, "unexpected error archiving install state")
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testDeploymentName + "-install-state",
+		},
+		Data: map[string][]byte{
+			installStateSecretKey:        state,
+			installStateInfraIDSecretKey: []byte(infraID),
+		},
+	}
+}
+
+func TestInstallStateArchive(t *testing.T) {
+	srcDir, err := ioutil.TempDir("", "installstate")
+	require.NoError(t, err)
+	defer os.RemoveAll(srcDir)
+	destDir, err := ioutil.TempDir("", "installstate")
+	require.NoError(t, err)
+	defer os.RemoveAll(destDir)
+
+	writeInstallStateFiles(t, srcDir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(srcDir, installerBinary), []byte("binary"), 0755))
+
+	state, err := archiveInstallState(srcDir)
+	require.NoError(t, err, "unexpected error archiving install state")
+	require.NoError(t, extractInstallState(state, destDir), "unexpected error extracting install state")
+
+	for name, contents := range testInstallStateFiles {
+		data, err := ioutil.ReadFile(filepath.Join(destDir, name))
+		if assert.NoError(t, err, "missing %s", name) {
+			assert.Equal(t, contents, string(data), "unexpected contents of %s", name)
+		}
+	}
+	_, err = os.Stat(filepath.Join(destDir, installerBinary))
+	assert.True(t, os.IsNotExist(err), "installer binary should not be archived")
+}
+
+func TestRestoreInstallState(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	cases := []struct {
+		name             string
+		resumable        bool
+		privateLink      bool
+		secret           *corev1.Secret
+		prevInfraID      *string
+		expectResumed    bool
+		expectSecretKept bool
+	}{
+		{
+			name:        "no install state",
+			resumable:   true,
+			prevInfraID: pointer.StringPtr(testInfraID),
+		},
+		{
+			name:             "resume",
+			resumable:        true,
+			secret:           testInstallStateSecret(t, testInfraID),
+			prevInfraID:      pointer.StringPtr(testInfraID),
+			expectResumed:    true,
+			expectSecretKept: true,
+		},
+		{
+			name:        "install state of another infra ID",
+			resumable:   true,
+			secret:      testInstallStateSecret(t, "other-infra-id"),
+			prevInfraID: pointer.StringPtr(testInfraID),
+		},
+		{
+			name:      "no previous infra ID",
+			resumable: true,
+			secret:    testInstallStateSecret(t, testInfraID),
+		},
+		{
+			name:        "not resumable",
+			secret:      testInstallStateSecret(t, testInfraID),
+			prevInfraID: pointer.StringPtr(testInfraID),
+		},
+		{
+			name:        "not resumable with privatelink",
+			resumable:   true,
+			privateLink: true,
+			secret:      testInstallStateSecret(t, testInfraID),
+			prevInfraID: pointer.StringPtr(testInfraID),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			workDir, err := ioutil.TempDir("", "installstate")
+			require.NoError(t, err)
+			defer os.RemoveAll(workDir)
+
+			cd := testClusterDeployment()
+			cd.Spec.Provisioning.ResumableInstall = tc.resumable
+			if tc.privateLink {
+				cd.Spec.Platform.AWS = &hivev1aws.Platform{PrivateLink: &hivev1aws.PrivateLinkAccess{Enabled: true}}
+			}
+			provision := testClusterProvision()
+			provision.Spec.PrevInfraID = tc.prevInfraID
+			existing := []runtime.Object{cd, provision}
+			if tc.secret != nil {
+				existing = append(existing, tc.secret)
+			}
+			mocks := setupDefaultMocks(t, existing...)
+			defer mocks.mockCtrl.Finish()
+			m := &InstallManager{
+				WorkDir:       workDir,
+				DynamicClient: mocks.fakeKubeClient,
+				log:           log.WithField("test", tc.name),
+			}
+
+			resumed, err := m.restoreInstallState(cd, provision)
+			require.NoError(t, err, "unexpected error restoring install state")
+			assert.Equal(t, tc.expectResumed, resumed, "unexpected resumed")
+
+			_, err = os.Stat(filepath.Join(workDir, metadataRelativePath))
+			assert.Equal(t, tc.expectResumed, err == nil, "unexpected presence of restored metadata")
+
+			err = mocks.fakeKubeClient.Get(context.TODO(), installStateSecretName(cd), &corev1.Secret{})
+			if tc.expectSecretKept {
+				assert.NoError(t, err, "expected install state secret to be kept")
+			} else {
+				assert.True(t, apierrors.IsNotFound(err), "expected install state secret to be deleted: %v", err)
+			}
+		})
+	}
+}
+
+func TestSaveInstallStateOnBootstrapComplete(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	defer func(interval time.Duration) { installStateCheckInterval = interval }(installStateCheckInterval)
+	installStateCheckInterval = 10 * time.Millisecond
+
+	workDir, err := ioutil.TempDir("", "installstate")
+	require.NoError(t, err)
+	defer os.RemoveAll(workDir)
+	writeInstallStateFiles(t, workDir)
+
+	cd := testClusterDeployment()
+	cd.Spec.Provisioning.ResumableInstall = true
+	mocks := setupDefaultMocks(t, cd)
+	defer mocks.mockCtrl.Finish()
+	m := &InstallManager{
+		WorkDir:       workDir,
+		DynamicClient: mocks.fakeKubeClient,
+		log:           log.WithField("test", "TestSaveInstallStateOnBootstrapComplete"),
+	}
+
+	logPath := filepath.Join(workDir, installerFullLogFile)
+	require.NoError(t, ioutil.WriteFile(logPath, []byte(`level=info msg="Waiting up to 20m0s for the Kubernetes API"`+"\n"), 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.saveInstallStateOnBootstrapComplete(ctx, cd, testInfraID)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	err = mocks.fakeKubeClient.Get(context.TODO(), installStateSecretName(cd), &corev1.Secret{})
+	assert.True(t, apierrors.IsNotFound(err), "install state should not be saved before bootstrap completes")
+
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`level=info msg="Waiting up to 40m0s (until 12:00PM) for the cluster at https://api.test-cluster.example.com:6443 to initialize..."` + "\n")
+	require.NoError(t, err)
+	f.Close()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("install state was not saved")
+	}
+
+	secret := &corev1.Secret{}
+	require.NoError(t, mocks.fakeKubeClient.Get(context.TODO(), installStateSecretName(cd), secret), "expected install state secret")
+	assert.Equal(t, testInfraID, string(secret.Data[installStateInfraIDSecretKey]), "unexpected infra ID")
+	assert.Equal(t, constants.SecretTypeInstallState, secret.Labels[constants.SecretTypeLabel], "unexpected secret type label")
+	if assert.Len(t, secret.OwnerReferences, 1, "expected owner reference") {
+		assert.Equal(t, testDeploymentName, secret.OwnerReferences[0].Name, "unexpected owner")
+	}
+
+	restoreDir, err := ioutil.TempDir("", "installstate")
+	require.NoError(t, err)
+	defer os.RemoveAll(restoreDir)
+	require.NoError(t, extractInstallState(secret.Data[installStateSecretKey], restoreDir), "unexpected error extracting install state")
+	_, err = os.Stat(filepath.Join(restoreDir, adminKubeConfigRelativePath))
+	assert.NoError(t, err, "expected kubeconfig in install state")
+}
@@ -1,6 +1,8 @@
 package installmanager
 
 import (
+	"context"
+
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	log "github.com/sirupsen/logrus"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -12,5 +14,5 @@ type LogUploaderActuator interface {
 	IsConfigured() bool
 
 	// UploadLogs uploads installer logs to the provider's storage mechanism.
-	UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error
+	UploadLogs(ctx context.Context, clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error
 }
@@ -0,0 +1,393 @@
+package installmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	credreqv1 "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/install"
+)
+
+const (
+	credentialsRequestsRelativePath = "credrequests"
+
+	// boundServiceAccountTokenFile is where the components of a cluster using short lived credentials find their
+	// bound service account token.
+	boundServiceAccountTokenFile = "/var/run/secrets/openshift/serviceaccount/token"
+	// authenticationConfigFile is the manifest of the Authentication config generated by the installer.
+	authenticationConfigFile = "cluster-authentication-02-config.yaml"
+	// maxAWSRoleNameLength is the maximum length of the name of an IAM role.
+	maxAWSRoleNameLength = 64
+)
+
+// pasteInManualCredentialsMode sets the credentialsMode of the InstallConfig to Manual.
+func pasteInManualCredentialsMode(icData []byte) ([]byte, error) {
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	icRaw["credentialsMode"] = string(installertypes.ManualCredentialsMode)
+	return yaml.Marshal(icRaw)
+}
+
+// writeManualCredentials adds the credentials Secrets for the CredentialsRequests of the release being installed to
+// the manifests generated by the installer, and fails if any CredentialsRequest is left without a Secret.
+func (m *InstallManager) writeManualCredentials(cd *hivev1.ClusterDeployment) error {
+	mc := cd.Spec.Provisioning.ManualCredentials
+	manifestsDir := filepath.Join(m.WorkDir, "manifests")
+
+	credReqs, err := m.extractCredentialsRequests(cd)
+	if err != nil {
+		return err
+	}
+	m.log.WithField("count", len(credReqs)).Info("extracted CredentialsRequests of the release")
+
+	if mc.ManifestsSecretRef != nil {
+		m.log.Info("copying user-provided credentials manifests")
+		if err := copyCredentialsManifests(install.CredentialsManifestsDir, manifestsDir); err != nil {
+			return err
+		}
+	}
+
+	var credentialsSecret func(*credreqv1.CredentialsRequest) *corev1.Secret
+	switch {
+	case mc.AWSSTS != nil:
+		credentialsSecret = func(credReq *credreqv1.CredentialsRequest) *corev1.Secret {
+			return awsSTSCredentialsSecret(credReq, mc.AWSSTS.RoleARNPrefix)
+		}
+	case mc.GCPWorkloadIdentity != nil:
+		credentialsSecret = func(credReq *credreqv1.CredentialsRequest) *corev1.Secret {
+			return gcpWorkloadIdentityCredentialsSecret(credReq, mc.GCPWorkloadIdentity)
+		}
+	case mc.AzureWorkloadIdentity != nil:
+		region := ""
+		if cd.Spec.Platform.Azure != nil {
+			region = cd.Spec.Platform.Azure.Region
+		}
+		credentialsSecret = func(credReq *credreqv1.CredentialsRequest) *corev1.Secret {
+			return azureWorkloadIdentityCredentialsSecret(credReq, mc.AzureWorkloadIdentity, region)
+		}
+	}
+	if credentialsSecret != nil {
+		m.log.Info("generating credentials manifests")
+		for i := range credReqs {
+			secret := credentialsSecret(&credReqs[i])
+			if secret == nil {
+				// Reported below as a CredentialsRequest without a Secret.
+				continue
+			}
+			data, err := yaml.Marshal(secret)
+			if err != nil {
+				return errors.Wrap(err, "could not marshal credentials Secret")
+			}
+			dest := filepath.Join(manifestsDir, fmt.Sprintf("%s-%s-credentials.yaml", secret.Namespace, secret.Name))
+			if err := ioutil.WriteFile(dest, data, 0644); err != nil {
+				return errors.Wrapf(err, "could not write %s", dest)
+			}
+			m.log.WithField("secret", secret.Namespace+"/"+secret.Name).Info("generated credentials Secret")
+		}
+	}
+
+	if mc.ServiceAccountIssuer != "" {
+		dest := filepath.Join(manifestsDir, authenticationConfigFile)
+		data, err := yaml.Marshal(authenticationConfig(mc.ServiceAccountIssuer))
+		if err != nil {
+			return errors.Wrap(err, "could not marshal Authentication config")
+		}
+		if err := ioutil.WriteFile(dest, data, 0644); err != nil {
+			return errors.Wrapf(err, "could not write %s", dest)
+		}
+		m.log.WithField("issuer", mc.ServiceAccountIssuer).Info("configured service account issuer")
+	}
+
+	missing, err := missingCredentialsSecrets(credReqs, manifestsDir)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return errors.Errorf("no credentials Secret was provided for CredentialsRequests %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// extractCredentialsRequests extracts the CredentialsRequests for the platform of the cluster from the release
+// being installed.
+func (m *InstallManager) extractCredentialsRequests(cd *hivev1.ClusterDeployment) ([]credreqv1.CredentialsRequest, error) {
+	releaseImage := os.Getenv("OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE")
+	if releaseImage == "" {
+		return nil, errors.New("release image is not known, cannot extract CredentialsRequests")
+	}
+	cloud := credentialsRequestsCloud(cd)
+	if cloud == "" {
+		return nil, errors.New("manual credentials are not supported for the platform of the cluster")
+	}
+	dir := filepath.Join(m.WorkDir, credentialsRequestsRelativePath)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrapf(err, "could not create %s", dir)
+	}
+	cmd := exec.Command(filepath.Join(m.binaryDir, "oc"), "adm", "release", "extract",
+		"--credentials-requests",
+		"--cloud", cloud,
+		"--to", dir,
+		"--registry-config", m.PullSecretMountPath,
+		releaseImage,
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		m.log.WithError(err).WithField("output", string(out)).Error("error extracting CredentialsRequests")
+		return nil, errors.Wrap(err, "could not extract CredentialsRequests from the release image")
+	}
+	return readCredentialsRequests(dir)
+}
+
+// credentialsRequestsCloud returns the name of the platform of the cluster as understood by oc adm release extract.
+func credentialsRequestsCloud(cd *hivev1.ClusterDeployment) string {
+	switch {
+	case cd.Spec.Platform.AWS != nil:
+		return "aws"
+	case cd.Spec.Platform.Azure != nil:
+		return "azure"
+	case cd.Spec.Platform.GCP != nil:
+		return "gcp"
+	case cd.Spec.Platform.OpenStack != nil:
+		return "openstack"
+	case cd.Spec.Platform.VSphere != nil:
+		return "vsphere"
+	case cd.Spec.Platform.Ovirt != nil:
+		return "ovirt"
+	case cd.Spec.Platform.Nutanix != nil:
+		return "nutanix"
+	case cd.Spec.Platform.PowerVS != nil:
+		return "powervs"
+	}
+	return ""
+}
+
+// readCredentialsRequests reads the CredentialsRequests from the manifests in a directory.
+func readCredentialsRequests(dir string) ([]credreqv1.CredentialsRequest, error) {
+	var credReqs []credreqv1.CredentialsRequest
+	err := forEachManifest(dir, func(typeMeta metav1.TypeMeta, raw []byte) error {
+		if typeMeta.Kind != "CredentialsRequest" {
+			return nil
+		}
+		credReq := credreqv1.CredentialsRequest{}
+		if err := yaml.Unmarshal(raw, &credReq); err != nil {
+			return errors.Wrap(err, "could not unmarshal CredentialsRequest")
+		}
+		credReqs = append(credReqs, credReq)
+		return nil
+	})
+	return credReqs, err
+}
+
+// missingCredentialsSecrets returns the CredentialsRequests for which no Secret is found in the manifests in a
+// directory.
+func missingCredentialsSecrets(credReqs []credreqv1.CredentialsRequest, dir string) ([]string, error) {
+	secrets := map[string]bool{}
+	err := forEachManifest(dir, func(typeMeta metav1.TypeMeta, raw []byte) error {
+		if typeMeta.Kind != "Secret" {
+			return nil
+		}
+		objMeta := struct {
+			Metadata metav1.ObjectMeta `json:"metadata"`
+		}{}
+		if err := yaml.Unmarshal(raw, &objMeta); err != nil {
+			return errors.Wrap(err, "could not unmarshal Secret")
+		}
+		secrets[objMeta.Metadata.Namespace+"/"+objMeta.Metadata.Name] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	for _, credReq := range credReqs {
+		ref := credReq.Spec.SecretRef
+		if !secrets[ref.Namespace+"/"+ref.Name] {
+			missing = append(missing, fmt.Sprintf("%s/%s", credReq.Namespace, credReq.Name))
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+// forEachManifest calls fn with every YAML or JSON document in the files of a directory.
+func forEachManifest(dir string, fn func(metav1.TypeMeta, []byte) error) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return errors.Wrapf(err, "could not read %s", dir)
+	}
+	for _, f := range files {
+		if f.IsDir() || strings.HasPrefix(f.Name(), ".") {
+			continue
+		}
+		file, err := os.Open(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return errors.Wrapf(err, "could not open %s", f.Name())
+		}
+		decoder := utilyaml.NewYAMLOrJSONDecoder(file, 4096)
+		for {
+			raw := map[string]interface{}{}
+			if err := decoder.Decode(&raw); err != nil {
+				file.Close()
+				if err == io.EOF {
+					break
+				}
+				return errors.Wrapf(err, "could not decode %s", f.Name())
+			}
+			if len(raw) == 0 {
+				continue
+			}
+			data, err := yaml.Marshal(raw)
+			if err != nil {
+				file.Close()
+				return err
+			}
+			typeMeta := metav1.TypeMeta{}
+			if err := yaml.Unmarshal(data, &typeMeta); err != nil {
+				file.Close()
+				return errors.Wrapf(err, "could not decode %s", f.Name())
+			}
+			if err := fn(typeMeta, data); err != nil {
+				file.Close()
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// copyCredentialsManifests copies the credentials manifests mounted from a Secret into the manifests directory.
+func copyCredentialsManifests(src, dest string) error {
+	files, err := ioutil.ReadDir(src)
+	if err != nil {
+		return errors.Wrapf(err, "could not read %s", src)
+	}
+	for _, f := range files {
+		// Secret volumes contain hidden entries pointing at the current version of the data.
+		if strings.HasPrefix(f.Name(), ".") {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(src, f.Name()))
+		if err != nil {
+			return errors.Wrapf(err, "could not read %s", f.Name())
+		}
+		if err := ioutil.WriteFile(filepath.Join(dest, f.Name()), data, 0644); err != nil {
+			return errors.Wrapf(err, "could not write %s", f.Name())
+		}
+	}
+	return nil
+}
+
+// awsSTSCredentialsSecret returns the Secret satisfying a CredentialsRequest with the IAM role created for it by
+// ccoctl aws create-iam-roles.
+func awsSTSCredentialsSecret(credReq *credreqv1.CredentialsRequest, roleARNPrefix string) *corev1.Secret {
+	ref := credReq.Spec.SecretRef
+	i := strings.LastIndex(roleARNPrefix, "/")
+	roleName := fmt.Sprintf("%s-%s-%s", roleARNPrefix[i+1:], ref.Namespace, ref.Name)
+	if len(roleName) > maxAWSRoleNameLength {
+		roleName = roleName[:maxAWSRoleNameLength]
+	}
+	return credentialsSecretFor(credReq, map[string]string{
+		"credentials": fmt.Sprintf("[default]\nrole_arn = %s%s\nweb_identity_token_file = %s\n",
+			roleARNPrefix[:i+1], roleName, boundServiceAccountTokenFile),
+	})
+}
+
+// gcpWorkloadIdentityCredentialsSecret returns the Secret satisfying a CredentialsRequest with an external account
+// impersonating the GCP service account mapped to it, or nil if no service account is mapped to it.
+func gcpWorkloadIdentityCredentialsSecret(credReq *credreqv1.CredentialsRequest, wi *hivev1.ManualCredentialsGCPWorkloadIdentity) *corev1.Secret {
+	identity := workloadIdentityFor(credReq, wi.ServiceAccounts)
+	if identity == "" {
+		return nil
+	}
+	externalAccount := map[string]interface{}{
+		"type": "external_account",
+		"audience": fmt.Sprintf("//iam.googleapis.com/projects/%s/locations/global/workloadIdentityPools/%s/providers/%s",
+			wi.ProjectNumber, wi.PoolID, wi.ProviderID),
+		"subject_token_type": "urn:ietf:params:oauth:token-type:jwt",
+		"token_url":          "https://sts.googleapis.com/v1/token",
+		"service_account_impersonation_url": fmt.Sprintf(
+			"https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/%s:generateAccessToken", identity),
+		"credential_source": map[string]interface{}{
+			"file":   boundServiceAccountTokenFile,
+			"format": map[string]string{"type": "text"},
+		},
+	}
+	// Marshalling a map of strings cannot fail.
+	data, _ := json.MarshalIndent(externalAccount, "", "  ")
+	return credentialsSecretFor(credReq, map[string]string{"service_account.json": string(data)})
+}
+
+// azureWorkloadIdentityCredentialsSecret returns the Secret satisfying a CredentialsRequest with the federated
+// credentials of the Azure managed identity mapped to it, or nil if no managed identity is mapped to it.
+func azureWorkloadIdentityCredentialsSecret(credReq *credreqv1.CredentialsRequest, wi *hivev1.ManualCredentialsAzureWorkloadIdentity, region string) *corev1.Secret {
+	identity := workloadIdentityFor(credReq, wi.ServiceAccounts)
+	if identity == "" {
+		return nil
+	}
+	return credentialsSecretFor(credReq, map[string]string{
+		"azure_client_id":            identity,
+		"azure_tenant_id":            wi.TenantID,
+		"azure_subscription_id":      wi.SubscriptionID,
+		"azure_region":               region,
+		"azure_federated_token_file": boundServiceAccountTokenFile,
+	})
+}
+
+// workloadIdentityFor returns the cloud identity mapped to the Secret requested by a CredentialsRequest.
+func workloadIdentityFor(credReq *credreqv1.CredentialsRequest, serviceAccounts []hivev1.WorkloadIdentityServiceAccount) string {
+	ref := credReq.Spec.SecretRef
+	for _, sa := range serviceAccounts {
+		if sa.SecretNamespace == ref.Namespace && sa.SecretName == ref.Name {
+			return sa.Identity
+		}
+	}
+	return ""
+}
+
+func credentialsSecretFor(credReq *credreqv1.CredentialsRequest, data map[string]string) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: credReq.Spec.SecretRef.Namespace,
+			Name:      credReq.Spec.SecretRef.Name,
+		},
+		Type:       corev1.SecretTypeOpaque,
+		StringData: data,
+	}
+}
+
+// authenticationConfig returns the Authentication config of a cluster whose bound service account tokens are issued
+// by the given issuer.
+func authenticationConfig(issuer string) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "config.openshift.io/v1",
+		"kind":       "Authentication",
+		"metadata": map[string]interface{}{
+			"name": "cluster",
+		},
+		"spec": map[string]interface{}{
+			"serviceAccountIssuer": issuer,
+		},
+	}
+}
@@ -0,0 +1,188 @@
+package installmanager
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	credreqv1 "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const testCredentialsRequests = `apiVersion: cloudcredential.openshift.io/v1
+kind: CredentialsRequest
+metadata:
+  name: openshift-image-registry
+  namespace: openshift-cloud-credential-operator
+spec:
+  secretRef:
+    name: installer-cloud-credentials
+    namespace: openshift-image-registry
+---
+apiVersion: cloudcredential.openshift.io/v1
+kind: CredentialsRequest
+metadata:
+  name: openshift-ingress
+  namespace: openshift-cloud-credential-operator
+spec:
+  secretRef:
+    name: cloud-credentials
+    namespace: openshift-ingress-operator
+`
+
+const testCredentialsSecret = `apiVersion: v1
+kind: Secret
+metadata:
+  name: cloud-credentials
+  namespace: openshift-ingress-operator
+stringData:
+  credentials: fake
+`
+
+func testCredentialsRequest(namespace, name string) credreqv1.CredentialsRequest {
+	return credreqv1.CredentialsRequest{
+		Spec: credreqv1.CredentialsRequestSpec{
+			SecretRef: corev1.ObjectReference{Namespace: namespace, Name: name},
+		},
+	}
+}
+
+func Test_pasteInManualCredentialsMode(t *testing.T) {
+	actual, err := pasteInManualCredentialsMode([]byte("baseDomain: example.com\ncredentialsMode: Mint\n"))
+	require.NoError(t, err, "unexpected error setting credentials mode")
+	icRaw := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal(actual, &icRaw), "unexpected error unmarshalling InstallConfig")
+	assert.Equal(t, "Manual", icRaw["credentialsMode"], "unexpected credentials mode")
+	assert.Equal(t, "example.com", icRaw["baseDomain"], "unexpected base domain")
+}
+
+func TestMissingCredentialsSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "installmanagertest")
+	require.NoError(t, err, "unexpected error creating temp dir")
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "credrequests.yaml"), []byte(testCredentialsRequests), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "ingress-credentials.yaml"), []byte(testCredentialsSecret), 0644))
+
+	credReqs, err := readCredentialsRequests(dir)
+	require.NoError(t, err, "unexpected error reading CredentialsRequests")
+	if assert.Len(t, credReqs, 2, "unexpected number of CredentialsRequests") {
+		assert.Equal(t, "installer-cloud-credentials", credReqs[0].Spec.SecretRef.Name, "unexpected secret ref")
+	}
+
+	missing, err := missingCredentialsSecrets(credReqs, dir)
+	require.NoError(t, err, "unexpected error finding missing Secrets")
+	assert.Equal(t, []string{"openshift-cloud-credential-operator/openshift-image-registry"}, missing, "unexpected missing Secrets")
+}
+
+func TestAWSSTSCredentialsSecret(t *testing.T) {
+	cases := []struct {
+		name             string
+		credReq          credreqv1.CredentialsRequest
+		roleARNPrefix    string
+		expectedRoleARN  string
+		expectedSecretNS string
+	}{
+		{
+			name:             "short role name",
+			credReq:          testCredentialsRequest("openshift-ingress-operator", "cloud-credentials"),
+			roleARNPrefix:    "arn:aws:iam::123456789012:role/mycluster",
+			expectedRoleARN:  "arn:aws:iam::123456789012:role/mycluster-openshift-ingress-operator-cloud-credentials",
+			expectedSecretNS: "openshift-ingress-operator",
+		},
+		{
+			name:             "truncated role name",
+			credReq:          testCredentialsRequest("openshift-cluster-csi-drivers", "ebs-cloud-credentials"),
+			roleARNPrefix:    "arn:aws:iam::123456789012:role/my-long-cluster-name",
+			expectedRoleARN:  "arn:aws:iam::123456789012:role/my-long-cluster-name-openshift-cluster-csi-drivers-ebs-cloud-cre",
+			expectedSecretNS: "openshift-cluster-csi-drivers",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			secret := awsSTSCredentialsSecret(&tc.credReq, tc.roleARNPrefix)
+			assert.Equal(t, tc.credReq.Spec.SecretRef.Name, secret.Name, "unexpected secret name")
+			assert.Equal(t, tc.expectedSecretNS, secret.Namespace, "unexpected secret namespace")
+			assert.Equal(t, metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}, secret.TypeMeta, "unexpected type")
+			assert.Equal(t,
+				"[default]\nrole_arn = "+tc.expectedRoleARN+"\nweb_identity_token_file = "+boundServiceAccountTokenFile+"\n",
+				secret.StringData["credentials"],
+				"unexpected credentials")
+		})
+	}
+}
+
+func TestGCPWorkloadIdentityCredentialsSecret(t *testing.T) {
+	wi := &hivev1.ManualCredentialsGCPWorkloadIdentity{
+		ProjectNumber: "123456789",
+		PoolID:        "mypool",
+		ProviderID:    "myprovider",
+		ServiceAccounts: []hivev1.WorkloadIdentityServiceAccount{{
+			SecretNamespace: "openshift-ingress-operator",
+			SecretName:      "cloud-credentials",
+			Identity:        "ingress@myproject.iam.gserviceaccount.com",
+		}},
+	}
+
+	credReq := testCredentialsRequest("openshift-ingress-operator", "cloud-credentials")
+	secret := gcpWorkloadIdentityCredentialsSecret(&credReq, wi)
+	if assert.NotNil(t, secret, "expected a secret") {
+		assert.Equal(t, "cloud-credentials", secret.Name, "unexpected secret name")
+		externalAccount := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(secret.StringData["service_account.json"]), &externalAccount), "unexpected error unmarshalling external account")
+		assert.Equal(t, "external_account", externalAccount["type"], "unexpected type")
+		assert.Equal(t, "//iam.googleapis.com/projects/123456789/locations/global/workloadIdentityPools/mypool/providers/myprovider", externalAccount["audience"], "unexpected audience")
+		assert.Equal(t, "https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/ingress@myproject.iam.gserviceaccount.com:generateAccessToken", externalAccount["service_account_impersonation_url"], "unexpected impersonation URL")
+	}
+
+	credReq = testCredentialsRequest("openshift-image-registry", "installer-cloud-credentials")
+	assert.Nil(t, gcpWorkloadIdentityCredentialsSecret(&credReq, wi), "expected no secret for unmapped CredentialsRequest")
+}
+
+func TestAzureWorkloadIdentityCredentialsSecret(t *testing.T) {
+	wi := &hivev1.ManualCredentialsAzureWorkloadIdentity{
+		SubscriptionID: "test-subscription",
+		TenantID:       "test-tenant",
+		ServiceAccounts: []hivev1.WorkloadIdentityServiceAccount{{
+			SecretNamespace: "openshift-ingress-operator",
+			SecretName:      "cloud-credentials",
+			Identity:        "test-client-id",
+		}},
+	}
+
+	credReq := testCredentialsRequest("openshift-ingress-operator", "cloud-credentials")
+	secret := azureWorkloadIdentityCredentialsSecret(&credReq, wi, "centralus")
+	if assert.NotNil(t, secret, "expected a secret") {
+		assert.Equal(t, map[string]string{
+			"azure_client_id":            "test-client-id",
+			"azure_tenant_id":            "test-tenant",
+			"azure_subscription_id":      "test-subscription",
+			"azure_region":               "centralus",
+			"azure_federated_token_file": boundServiceAccountTokenFile,
+		}, secret.StringData, "unexpected credentials")
+	}
+
+	credReq = testCredentialsRequest("openshift-image-registry", "installer-cloud-credentials")
+	assert.Nil(t, azureWorkloadIdentityCredentialsSecret(&credReq, wi, "centralus"), "expected no secret for unmapped CredentialsRequest")
+}
+
+func TestAuthenticationConfig(t *testing.T) {
+	data, err := yaml.Marshal(authenticationConfig("https://issuer.example.com"))
+	require.NoError(t, err, "unexpected error marshalling Authentication config")
+	assert.Equal(t, `apiVersion: config.openshift.io/v1
+kind: Authentication
+metadata:
+  name: cluster
+spec:
+  serviceAccountIssuer: https://issuer.example.com
+`, string(data), "unexpected Authentication config")
+}
@@ -1,6 +1,7 @@
 package installmanager
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -38,7 +39,7 @@ func (a *s3LogUploaderActuator) IsConfigured() bool {
 }
 
 // UploadLogs uploads installer logs to the provider's storage mechanism.
-func (a *s3LogUploaderActuator) UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error {
+func (a *s3LogUploaderActuator) UploadLogs(ctx context.Context, clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error {
 	secretName, foundSecretName := os.LookupEnv(constants.InstallLogsCredentialsSecretRefEnvVar)
 	if !foundSecretName {
 		return errors.New("couldn't find secret name in environment variable. Skipping upload")
@@ -81,7 +82,7 @@ func (a *s3LogUploaderActuator) UploadLogs(clusterName string, clusterprovision
 
 		logkey := fmt.Sprintf("%v/%v-%v", folder, clusterprovision.Name, stat.Name())
 
-		_, err = awsc.Upload(&s3manager.UploadInput{
+		_, err = awsc.Upload(ctx, &s3manager.UploadInput{
 			Bucket: aws.String(bucket),
 			Key:    aws.String(logkey),
 			Body:   file,
@@ -1,6 +1,8 @@
 package installmanager
 
 import (
+	"context"
+
 	"os"
 	"testing"
 
@@ -65,7 +67,7 @@ func TestUploadLogs(t *testing.T) {
 			}
 			if test.setupPutObjectMock {
 				mocks.mockAWSClient.EXPECT().
-					Upload(gomock.Any()).
+					Upload(gomock.Any(), gomock.Any()).
 					Return(nil, test.putObjectError)
 			}
 
@@ -75,7 +77,7 @@ func TestUploadLogs(t *testing.T) {
 			provision := testClusterProvision()
 
 			// Act
-			err := actuator.UploadLogs("notarealcluster", provision, mocks.fakeKubeClient, log.New(), "/etc/issue")
+			err := actuator.UploadLogs(context.Background(), "notarealcluster", provision, mocks.fakeKubeClient, log.New(), "/etc/issue")
 
 			// Assert
 			if test.expectedUploadLogsError {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"os"
+	"strings"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
@@ -29,3 +30,25 @@ func ReadManagedDomainsFile() ([]hivev1.ManageDNSConfig, error) {
 
 	return domains, nil
 }
+
+// RoutingHostedZoneAllowed returns whether clusters with the given base domain may maintain records with a routing
+// policy in the AWS hosted zone. The hosted zone must be listed in the AWS configuration of a managed domain that
+// the base domain is under.
+func RoutingHostedZoneAllowed(managedDomains []hivev1.ManageDNSConfig, baseDomain, hostedZoneID string) bool {
+	for _, md := range managedDomains {
+		if md.AWS == nil {
+			continue
+		}
+		for _, domain := range md.Domains {
+			if !strings.HasSuffix(baseDomain, "."+domain) {
+				continue
+			}
+			for _, id := range md.AWS.RoutingHostedZoneIDs {
+				if id == hostedZoneID {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
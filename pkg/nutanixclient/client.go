@@ -0,0 +1,298 @@
+package nutanixclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1nutanix "github.com/openshift/hive/apis/hive/v1/nutanix"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// Client is a wrapper object for the Prism Central v3 API to allow for easier mocking/testing.
+type Client interface {
+	// GetCurrentUser returns the user the client authenticates as. It fails if the credentials are not valid.
+	GetCurrentUser(ctx context.Context) (*User, error)
+
+	// Virtual machines
+	ListVMs(ctx context.Context) ([]Entity, error)
+	DeleteVM(ctx context.Context, uuid string) error
+
+	// Images
+	ListImages(ctx context.Context) ([]Entity, error)
+	DeleteImage(ctx context.Context, uuid string) error
+
+	// Categories
+	DeleteCategoryValue(ctx context.Context, name, value string) error
+	DeleteCategory(ctx context.Context, name string) error
+}
+
+// User is a Prism Central user.
+type User struct {
+	UUID string
+	Name string
+}
+
+// Entity is a virtual machine or image in Prism Central.
+type Entity struct {
+	UUID string
+	Name string
+
+	// Categories are the categories the entity is assigned to, by category name.
+	Categories map[string]string
+}
+
+// Error is an error returned by the Prism Central API.
+type Error struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Messages are the error messages in the response.
+	Messages []string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("Prism Central API returned status %d: %s", e.StatusCode, strings.Join(e.Messages, "; "))
+}
+
+// IsNotFound returns whether the error is an error from the API for a resource that does not exist.
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsUnauthorized returns whether the error is an error from the API for credentials which are not valid.
+func IsUnauthorized(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden)
+}
+
+const (
+	// DefaultPrismPort is the port of Prism Central when the endpoint does not specify one.
+	DefaultPrismPort = 9440
+
+	apiPath = "/api/nutanix/v3"
+
+	// defaultCallTimeout is the timeout of each API call, so that a stuck call does not block the caller until its
+	// context is done.
+	defaultCallTimeout = 2 * time.Minute
+
+	// pageSize is the number of entities requested per page when listing.
+	pageSize = 100
+)
+
+type nutanixClient struct {
+	endpoint   string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// NewClientFromSecrets creates our client wrapper object for interacting with Prism Central. The username and
+// password are read from the credentials secret, and the CA certificates to trust in addition to the system ones from
+// the certificates secret, if any.
+func NewClientFromSecrets(prismCentral hivev1nutanix.PrismEndpoint, credentialsSecret, certificatesSecret *corev1.Secret) (Client, error) {
+	username, ok := credentialsSecret.Data[constants.UsernameSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("secret does not contain %q data", constants.UsernameSecretKey)
+	}
+	password, ok := credentialsSecret.Data[constants.PasswordSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("secret does not contain %q data", constants.PasswordSecretKey)
+	}
+	var caBundle []byte
+	if certificatesSecret != nil {
+		for _, cert := range certificatesSecret.Data {
+			caBundle = append(caBundle, cert...)
+			caBundle = append(caBundle, '\n')
+		}
+	}
+	return NewClient(prismCentral, strings.TrimSpace(string(username)), strings.TrimSpace(string(password)), caBundle)
+}
+
+// NewClient creates our client wrapper object for interacting with Prism Central using the credentials provided. The
+// certificates of the CA bundle, if any, are trusted in addition to the system ones.
+func NewClient(prismCentral hivev1nutanix.PrismEndpoint, username, password string, caBundle []byte) (Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(caBundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, errors.New("no certificates found in the CA bundle")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return newClient(EndpointURL(prismCentral), username, password, &http.Client{
+		Timeout:   defaultCallTimeout,
+		Transport: transport,
+	}), nil
+}
+
+func newClient(endpoint, username, password string, httpClient *http.Client) *nutanixClient {
+	return &nutanixClient{
+		endpoint:   endpoint,
+		username:   username,
+		password:   password,
+		httpClient: httpClient,
+	}
+}
+
+// EndpointURL returns the URL of the Prism endpoint.
+func EndpointURL(endpoint hivev1nutanix.PrismEndpoint) string {
+	port := int(endpoint.Port)
+	if port == 0 {
+		port = DefaultPrismPort
+	}
+	return "https://" + net.JoinHostPort(endpoint.Address, strconv.Itoa(port))
+}
+
+func (c *nutanixClient) GetCurrentUser(ctx context.Context) (*User, error) {
+	user := &entity{}
+	if err := c.do(ctx, http.MethodGet, "/users/me", nil, user); err != nil {
+		return nil, err
+	}
+	return &User{UUID: user.Metadata.UUID, Name: user.Status.Name}, nil
+}
+
+func (c *nutanixClient) ListVMs(ctx context.Context) ([]Entity, error) {
+	return c.list(ctx, "vm", "/vms/list")
+}
+
+func (c *nutanixClient) DeleteVM(ctx context.Context, uuid string) error {
+	return c.do(ctx, http.MethodDelete, "/vms/"+url.PathEscape(uuid), nil, nil)
+}
+
+func (c *nutanixClient) ListImages(ctx context.Context) ([]Entity, error) {
+	return c.list(ctx, "image", "/images/list")
+}
+
+func (c *nutanixClient) DeleteImage(ctx context.Context, uuid string) error {
+	return c.do(ctx, http.MethodDelete, "/images/"+url.PathEscape(uuid), nil, nil)
+}
+
+func (c *nutanixClient) DeleteCategoryValue(ctx context.Context, name, value string) error {
+	return c.do(ctx, http.MethodDelete, "/categories/"+url.PathEscape(name)+"/"+url.PathEscape(value), nil, nil)
+}
+
+func (c *nutanixClient) DeleteCategory(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, "/categories/"+url.PathEscape(name), nil, nil)
+}
+
+// entity is a resource of the Prism Central v3 API, with the fields used by the client.
+type entity struct {
+	Metadata struct {
+		UUID       string            `json:"uuid"`
+		Categories map[string]string `json:"categories"`
+	} `json:"metadata"`
+	Spec struct {
+		Name string `json:"name"`
+	} `json:"spec"`
+	Status struct {
+		Name string `json:"name"`
+	} `json:"status"`
+}
+
+// listResponse is the response of the list calls of the Prism Central v3 API.
+type listResponse struct {
+	Entities []entity `json:"entities"`
+	Metadata struct {
+		TotalMatches int `json:"total_matches"`
+	} `json:"metadata"`
+}
+
+// list returns all the entities of the kind, requesting them a page at a time.
+func (c *nutanixClient) list(ctx context.Context, kind, path string) ([]Entity, error) {
+	var entities []Entity
+	for offset := 0; ; offset += pageSize {
+		resp := &listResponse{}
+		req := map[string]interface{}{
+			"kind":   kind,
+			"offset": offset,
+			"length": pageSize,
+		}
+		if err := c.do(ctx, http.MethodPost, path, req, resp); err != nil {
+			return nil, err
+		}
+		for _, e := range resp.Entities {
+			entities = append(entities, Entity{
+				UUID:       e.Metadata.UUID,
+				Name:       e.Spec.Name,
+				Categories: e.Metadata.Categories,
+			})
+		}
+		if len(resp.Entities) == 0 || offset+len(resp.Entities) >= resp.Metadata.TotalMatches {
+			return entities, nil
+		}
+	}
+}
+
+// errorResponse is the response of the Prism Central v3 API for failed calls.
+type errorResponse struct {
+	MessageList []struct {
+		Reason  string `json:"reason"`
+		Message string `json:"message"`
+	} `json:"message_list"`
+}
+
+// do sends a request to the Prism Central v3 API, and decodes the response into out. Unsuccessful responses are
+// returned as *Error.
+func (c *nutanixClient) do(ctx context.Context, method, path string, in, out interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultCallTimeout)
+	defer cancel()
+
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+apiPath+path, body)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.username, c.password)
+	req.Header.Set("User-Agent", "openshift.io hive/v1")
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		apiErr := &Error{StatusCode: res.StatusCode}
+		errResp := &errorResponse{}
+		if err := json.NewDecoder(res.Body).Decode(errResp); err == nil {
+			for _, m := range errResp.MessageList {
+				apiErr.Messages = append(apiErr.Messages, fmt.Sprintf("%s: %s", m.Reason, m.Message))
+			}
+		}
+		return apiErr
+	}
+	if out != nil {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
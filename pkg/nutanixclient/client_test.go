@@ -0,0 +1,84 @@
+package nutanixclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hivev1nutanix "github.com/openshift/hive/apis/hive/v1/nutanix"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/nutanix/v3/", func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "admin" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"state":"ERROR","code":401,"message_list":[{"reason":"AUTHENTICATION_REQUIRED","message":"Authentication required."}]}`)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/nutanix/v3/users/me":
+			fmt.Fprint(w, `{"metadata":{"uuid":"user-uuid"},"status":{"name":"admin"}}`)
+		case "/api/nutanix/v3/vms/list":
+			req := struct {
+				Offset int `json:"offset"`
+				Length int `json:"length"`
+			}{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, pageSize, req.Length, "unexpected page size")
+			fmt.Fprintf(w, `{"entities":[{"metadata":{"uuid":"vm-%d","categories":{"kubernetes-io-cluster-test":"owned"}},"spec":{"name":"test-%d"}}],"metadata":{"total_matches":%d}}`,
+				req.Offset, req.Offset, pageSize+1)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"state":"ERROR","code":404,"message_list":[{"reason":"ENTITY_NOT_FOUND","message":"Entity not found."}]}`)
+		}
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestListVMs(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	c := newClient(server.URL, "admin", "password", server.Client())
+	vms, err := c.ListVMs(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Entity{
+		{UUID: "vm-0", Name: "test-0", Categories: map[string]string{"kubernetes-io-cluster-test": "owned"}},
+		{UUID: "vm-100", Name: "test-100", Categories: map[string]string{"kubernetes-io-cluster-test": "owned"}},
+	}, vms)
+}
+
+func TestGetCurrentUser(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	user, err := newClient(server.URL, "admin", "password", server.Client()).GetCurrentUser(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &User{UUID: "user-uuid", Name: "admin"}, user)
+
+	_, err = newClient(server.URL, "admin", "wrong", server.Client()).GetCurrentUser(context.Background())
+	if assert.Error(t, err, "expected error for invalid credentials") {
+		assert.Equal(t, "Prism Central API returned status 401: AUTHENTICATION_REQUIRED: Authentication required.", err.Error())
+		assert.True(t, IsUnauthorized(err), "expected unauthorized error")
+	}
+}
+
+func TestDeleteNotFound(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	err := newClient(server.URL, "admin", "password", server.Client()).DeleteCategory(context.Background(), "missing")
+	assert.True(t, IsNotFound(err), "expected not found error, got %v", err)
+}
+
+func TestEndpointURL(t *testing.T) {
+	assert.Equal(t, "https://prism.example.com:9440", EndpointURL(hivev1nutanix.PrismEndpoint{Address: "prism.example.com"}))
+	assert.Equal(t, "https://[fd00::1]:443", EndpointURL(hivev1nutanix.PrismEndpoint{Address: "fd00::1", Port: 443}))
+}
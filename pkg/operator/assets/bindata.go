@@ -3,6 +3,9 @@
 // config/clustersync/service.yaml
 // config/clustersync/statefulset.yaml
 // config/hiveadmission/apiservice.yaml
+// config/hiveadmission/clusterclaim-mutating-webhook.yaml
+// config/hiveadmission/clusterclaim-webhook.yaml
+// config/hiveadmission/clusterdeployment-mutating-webhook.yaml
 // config/hiveadmission/clusterdeployment-webhook.yaml
 // config/hiveadmission/clusterimageset-webhook.yaml
 // config/hiveadmission/clusterprovision-webhook.yaml
@@ -252,6 +255,132 @@ func configHiveadmissionApiserviceYaml() (*asset, error) {
 	return a, nil
 }
 
+var _configHiveadmissionClusterclaimMutatingWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: MutatingWebhookConfiguration
+metadata:
+  name: clusterclaimmutators.admission.hive.openshift.io
+webhooks:
+- name: clusterclaimmutators.admission.hive.openshift.io
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/clusterclaimmutators
+  rules:
+  - operations:
+    - CREATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - clusterclaims
+  failurePolicy: Fail
+  sideEffects: None
+`)
+
+func configHiveadmissionClusterclaimMutatingWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionClusterclaimMutatingWebhookYaml, nil
+}
+
+func configHiveadmissionClusterclaimMutatingWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionClusterclaimMutatingWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/clusterclaim-mutating-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _configHiveadmissionClusterclaimWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: clusterclaimvalidators.admission.hive.openshift.io
+webhooks:
+- name: clusterclaimvalidators.admission.hive.openshift.io
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/clusterclaimvalidators
+  rules:
+  - operations:
+    - CREATE
+    - UPDATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - clusterclaims
+  failurePolicy: Fail
+  sideEffects: None
+`)
+
+func configHiveadmissionClusterclaimWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionClusterclaimWebhookYaml, nil
+}
+
+func configHiveadmissionClusterclaimWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionClusterclaimWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/clusterclaim-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _configHiveadmissionClusterdeploymentMutatingWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: MutatingWebhookConfiguration
+metadata:
+  name: clusterdeploymentmutators.admission.hive.openshift.io
+webhooks:
+- name: clusterdeploymentmutators.admission.hive.openshift.io
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/clusterdeploymentmutators
+  rules:
+  - operations:
+    - CREATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - clusterdeployments
+  # Requests for the v2 API are converted to v1 before they are sent to the webhook.
+  matchPolicy: Equivalent
+  failurePolicy: Fail
+  sideEffects: None
+`)
+
+func configHiveadmissionClusterdeploymentMutatingWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionClusterdeploymentMutatingWebhookYaml, nil
+}
+
+func configHiveadmissionClusterdeploymentMutatingWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionClusterdeploymentMutatingWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/clusterdeployment-mutating-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _configHiveadmissionClusterdeploymentWebhookYaml = []byte(`---
 apiVersion: admissionregistration.k8s.io/v1beta1
 kind: ValidatingWebhookConfiguration
@@ -276,6 +405,8 @@ webhooks:
     - v1
     resources:
     - clusterdeployments
+  # Requests for the v2 API are converted to v1 before they are sent to the webhook.
+  matchPolicy: Equivalent
   failurePolicy: Fail
   sideEffects: None
 `)
@@ -421,6 +552,8 @@ spec:
         ports:
         - containerPort: 9443
           protocol: TCP
+        - containerPort: 9444
+          protocol: TCP
         envFrom:
         - configMapRef:
             name: hive-feature-gates
@@ -536,6 +669,16 @@ rules:
   - get
   - list
   - watch
+- apiGroups:
+  - hive.openshift.io
+  resources:
+  - clusterdeployments
+  - clusterclaims
+  - machinepools
+  verbs:
+  - get
+  - list
+  - watch
 - apiGroups:
   - authorization.k8s.io
   resources:
@@ -708,9 +851,14 @@ spec:
   selector:
     app: hiveadmission
   ports:
-  - port: 443
+  - name: https
+    port: 443
     targetPort: 9443
     protocol: TCP
+  - name: conversion
+    port: 9444
+    targetPort: 9444
+    protocol: TCP
 `)
 
 func configHiveadmissionServiceYamlBytes() ([]byte, error) {
@@ -751,6 +899,8 @@ webhooks:
     - v1
     resources:
     - syncsets
+  # Requests for the v2 API are converted to v1 before they are sent to the webhook.
+  matchPolicy: Equivalent
   failurePolicy: Fail
   sideEffects: None
 `)
@@ -1080,6 +1230,7 @@ rules:
   - syncsets
   - syncsetinstances
   - clusterdeprovisions
+  - clusterdeploymentsummaries
   # TODO: remove once v1alpha1 compat removed
   - clusterdeprovisionrequests
   - clusterstates
@@ -1320,6 +1471,7 @@ rules:
   - selectorsyncsets
   - syncsets
   - clusterdeprovisions
+  - clusterdeploymentsummaries
   # TODO: remove once v1alpha1 compat removed
   - clusterdeprovisionrequests
   - clusterstates
@@ -1457,6 +1609,7 @@ rules:
   - syncsets
   - syncsetinstances
   - clusterdeprovisions
+  - clusterdeploymentsummaries
   # TODO: remove once v1alpha1 compat removed
   - clusterdeprovisionrequests
   - clusterstates
@@ -1757,35 +1910,38 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"config/clustersync/service.yaml":                           configClustersyncServiceYaml,
-	"config/clustersync/statefulset.yaml":                       configClustersyncStatefulsetYaml,
-	"config/hiveadmission/apiservice.yaml":                      configHiveadmissionApiserviceYaml,
-	"config/hiveadmission/clusterdeployment-webhook.yaml":       configHiveadmissionClusterdeploymentWebhookYaml,
-	"config/hiveadmission/clusterimageset-webhook.yaml":         configHiveadmissionClusterimagesetWebhookYaml,
-	"config/hiveadmission/clusterprovision-webhook.yaml":        configHiveadmissionClusterprovisionWebhookYaml,
-	"config/hiveadmission/deployment.yaml":                      configHiveadmissionDeploymentYaml,
-	"config/hiveadmission/dnszones-webhook.yaml":                configHiveadmissionDnszonesWebhookYaml,
-	"config/hiveadmission/hiveadmission_rbac_role.yaml":         configHiveadmissionHiveadmission_rbac_roleYaml,
-	"config/hiveadmission/hiveadmission_rbac_role_binding.yaml": configHiveadmissionHiveadmission_rbac_role_bindingYaml,
-	"config/hiveadmission/machinepool-webhook.yaml":             configHiveadmissionMachinepoolWebhookYaml,
-	"config/hiveadmission/selectorsyncset-webhook.yaml":         configHiveadmissionSelectorsyncsetWebhookYaml,
-	"config/hiveadmission/service-account.yaml":                 configHiveadmissionServiceAccountYaml,
-	"config/hiveadmission/service.yaml":                         configHiveadmissionServiceYaml,
-	"config/hiveadmission/syncset-webhook.yaml":                 configHiveadmissionSyncsetWebhookYaml,
-	"config/controllers/deployment.yaml":                        configControllersDeploymentYaml,
-	"config/controllers/hive_controllers_role.yaml":             configControllersHive_controllers_roleYaml,
-	"config/controllers/hive_controllers_role_binding.yaml":     configControllersHive_controllers_role_bindingYaml,
-	"config/controllers/hive_controllers_serviceaccount.yaml":   configControllersHive_controllers_serviceaccountYaml,
-	"config/controllers/service.yaml":                           configControllersServiceYaml,
-	"config/rbac/hive_admin_role.yaml":                          configRbacHive_admin_roleYaml,
-	"config/rbac/hive_admin_role_binding.yaml":                  configRbacHive_admin_role_bindingYaml,
-	"config/rbac/hive_clusterpool_admin.yaml":                   configRbacHive_clusterpool_adminYaml,
-	"config/rbac/hive_frontend_role.yaml":                       configRbacHive_frontend_roleYaml,
-	"config/rbac/hive_frontend_role_binding.yaml":               configRbacHive_frontend_role_bindingYaml,
-	"config/rbac/hive_frontend_serviceaccount.yaml":             configRbacHive_frontend_serviceaccountYaml,
-	"config/rbac/hive_reader_role.yaml":                         configRbacHive_reader_roleYaml,
-	"config/rbac/hive_reader_role_binding.yaml":                 configRbacHive_reader_role_bindingYaml,
-	"config/configmaps/install-log-regexes-configmap.yaml":      configConfigmapsInstallLogRegexesConfigmapYaml,
+	"config/clustersync/service.yaml":                              configClustersyncServiceYaml,
+	"config/clustersync/statefulset.yaml":                          configClustersyncStatefulsetYaml,
+	"config/hiveadmission/apiservice.yaml":                         configHiveadmissionApiserviceYaml,
+	"config/hiveadmission/clusterclaim-mutating-webhook.yaml":      configHiveadmissionClusterclaimMutatingWebhookYaml,
+	"config/hiveadmission/clusterclaim-webhook.yaml":               configHiveadmissionClusterclaimWebhookYaml,
+	"config/hiveadmission/clusterdeployment-mutating-webhook.yaml": configHiveadmissionClusterdeploymentMutatingWebhookYaml,
+	"config/hiveadmission/clusterdeployment-webhook.yaml":          configHiveadmissionClusterdeploymentWebhookYaml,
+	"config/hiveadmission/clusterimageset-webhook.yaml":            configHiveadmissionClusterimagesetWebhookYaml,
+	"config/hiveadmission/clusterprovision-webhook.yaml":           configHiveadmissionClusterprovisionWebhookYaml,
+	"config/hiveadmission/deployment.yaml":                         configHiveadmissionDeploymentYaml,
+	"config/hiveadmission/dnszones-webhook.yaml":                   configHiveadmissionDnszonesWebhookYaml,
+	"config/hiveadmission/hiveadmission_rbac_role.yaml":            configHiveadmissionHiveadmission_rbac_roleYaml,
+	"config/hiveadmission/hiveadmission_rbac_role_binding.yaml":    configHiveadmissionHiveadmission_rbac_role_bindingYaml,
+	"config/hiveadmission/machinepool-webhook.yaml":                configHiveadmissionMachinepoolWebhookYaml,
+	"config/hiveadmission/selectorsyncset-webhook.yaml":            configHiveadmissionSelectorsyncsetWebhookYaml,
+	"config/hiveadmission/service-account.yaml":                    configHiveadmissionServiceAccountYaml,
+	"config/hiveadmission/service.yaml":                            configHiveadmissionServiceYaml,
+	"config/hiveadmission/syncset-webhook.yaml":                    configHiveadmissionSyncsetWebhookYaml,
+	"config/controllers/deployment.yaml":                           configControllersDeploymentYaml,
+	"config/controllers/hive_controllers_role.yaml":                configControllersHive_controllers_roleYaml,
+	"config/controllers/hive_controllers_role_binding.yaml":        configControllersHive_controllers_role_bindingYaml,
+	"config/controllers/hive_controllers_serviceaccount.yaml":      configControllersHive_controllers_serviceaccountYaml,
+	"config/controllers/service.yaml":                              configControllersServiceYaml,
+	"config/rbac/hive_admin_role.yaml":                             configRbacHive_admin_roleYaml,
+	"config/rbac/hive_admin_role_binding.yaml":                     configRbacHive_admin_role_bindingYaml,
+	"config/rbac/hive_clusterpool_admin.yaml":                      configRbacHive_clusterpool_adminYaml,
+	"config/rbac/hive_frontend_role.yaml":                          configRbacHive_frontend_roleYaml,
+	"config/rbac/hive_frontend_role_binding.yaml":                  configRbacHive_frontend_role_bindingYaml,
+	"config/rbac/hive_frontend_serviceaccount.yaml":                configRbacHive_frontend_serviceaccountYaml,
+	"config/rbac/hive_reader_role.yaml":                            configRbacHive_reader_roleYaml,
+	"config/rbac/hive_reader_role_binding.yaml":                    configRbacHive_reader_role_bindingYaml,
+	"config/configmaps/install-log-regexes-configmap.yaml":         configConfigmapsInstallLogRegexesConfigmapYaml,
 }
 
 // AssetDir returns the file names below a certain
@@ -1845,19 +2001,22 @@ var _bintree = &bintree{nil, map[string]*bintree{
 			"service.yaml":                         {configControllersServiceYaml, map[string]*bintree{}},
 		}},
 		"hiveadmission": {nil, map[string]*bintree{
-			"apiservice.yaml":                      {configHiveadmissionApiserviceYaml, map[string]*bintree{}},
-			"clusterdeployment-webhook.yaml":       {configHiveadmissionClusterdeploymentWebhookYaml, map[string]*bintree{}},
-			"clusterimageset-webhook.yaml":         {configHiveadmissionClusterimagesetWebhookYaml, map[string]*bintree{}},
-			"clusterprovision-webhook.yaml":        {configHiveadmissionClusterprovisionWebhookYaml, map[string]*bintree{}},
-			"deployment.yaml":                      {configHiveadmissionDeploymentYaml, map[string]*bintree{}},
-			"dnszones-webhook.yaml":                {configHiveadmissionDnszonesWebhookYaml, map[string]*bintree{}},
-			"hiveadmission_rbac_role.yaml":         {configHiveadmissionHiveadmission_rbac_roleYaml, map[string]*bintree{}},
-			"hiveadmission_rbac_role_binding.yaml": {configHiveadmissionHiveadmission_rbac_role_bindingYaml, map[string]*bintree{}},
-			"machinepool-webhook.yaml":             {configHiveadmissionMachinepoolWebhookYaml, map[string]*bintree{}},
-			"selectorsyncset-webhook.yaml":         {configHiveadmissionSelectorsyncsetWebhookYaml, map[string]*bintree{}},
-			"service-account.yaml":                 {configHiveadmissionServiceAccountYaml, map[string]*bintree{}},
-			"service.yaml":                         {configHiveadmissionServiceYaml, map[string]*bintree{}},
-			"syncset-webhook.yaml":                 {configHiveadmissionSyncsetWebhookYaml, map[string]*bintree{}},
+			"apiservice.yaml":                         {configHiveadmissionApiserviceYaml, map[string]*bintree{}},
+			"clusterclaim-mutating-webhook.yaml":      {configHiveadmissionClusterclaimMutatingWebhookYaml, map[string]*bintree{}},
+			"clusterclaim-webhook.yaml":               {configHiveadmissionClusterclaimWebhookYaml, map[string]*bintree{}},
+			"clusterdeployment-mutating-webhook.yaml": {configHiveadmissionClusterdeploymentMutatingWebhookYaml, map[string]*bintree{}},
+			"clusterdeployment-webhook.yaml":          {configHiveadmissionClusterdeploymentWebhookYaml, map[string]*bintree{}},
+			"clusterimageset-webhook.yaml":            {configHiveadmissionClusterimagesetWebhookYaml, map[string]*bintree{}},
+			"clusterprovision-webhook.yaml":           {configHiveadmissionClusterprovisionWebhookYaml, map[string]*bintree{}},
+			"deployment.yaml":                         {configHiveadmissionDeploymentYaml, map[string]*bintree{}},
+			"dnszones-webhook.yaml":                   {configHiveadmissionDnszonesWebhookYaml, map[string]*bintree{}},
+			"hiveadmission_rbac_role.yaml":            {configHiveadmissionHiveadmission_rbac_roleYaml, map[string]*bintree{}},
+			"hiveadmission_rbac_role_binding.yaml":    {configHiveadmissionHiveadmission_rbac_role_bindingYaml, map[string]*bintree{}},
+			"machinepool-webhook.yaml":                {configHiveadmissionMachinepoolWebhookYaml, map[string]*bintree{}},
+			"selectorsyncset-webhook.yaml":            {configHiveadmissionSelectorsyncsetWebhookYaml, map[string]*bintree{}},
+			"service-account.yaml":                    {configHiveadmissionServiceAccountYaml, map[string]*bintree{}},
+			"service.yaml":                            {configHiveadmissionServiceYaml, map[string]*bintree{}},
+			"syncset-webhook.yaml":                    {configHiveadmissionSyncsetWebhookYaml, map[string]*bintree{}},
 		}},
 		"rbac": {nil, map[string]*bintree{
 			"hive_admin_role.yaml":              {configRbacHive_admin_roleYaml, map[string]*bintree{}},
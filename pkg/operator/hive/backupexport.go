@@ -0,0 +1,69 @@
+package hive
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/operator/util"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	backupExportConfigMapName      = "hive-backup-export"
+	backupExportConfigMapNameKey   = "backup-export"
+	backupExportConfigMapMountPath = "/data/backup-export-config"
+)
+
+func (r *ReconcileHiveConfig) deployBackupExportConfigMap(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig) (string, error) {
+	cm := &corev1.ConfigMap{}
+	cm.Name = backupExportConfigMapName
+	cm.Namespace = getHiveNamespace(instance)
+	cm.Data = make(map[string]string)
+
+	if instance.Spec.Backup.Export != nil {
+		data, err := json.Marshal(instance.Spec.Backup.Export)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal backup export config")
+		}
+		cm.Data[backupExportConfigMapNameKey] = string(data)
+	}
+
+	result, err := util.ApplyRuntimeObjectWithGC(h, cm, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error applying hive-backup-export configmap")
+		return "", err
+	}
+	hLog.WithField("result", result).Info("hive-backup-export configmap applied")
+
+	return computeConfigHash(cm), nil
+}
+
+func addBackupExportConfigVolume(podSpec *corev1.PodSpec) {
+	optional := true
+	volume := corev1.Volume{}
+	volume.Name = backupExportConfigMapName
+	volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: backupExportConfigMapName,
+		},
+		Optional: &optional,
+	}
+	volumeMount := corev1.VolumeMount{
+		Name:      backupExportConfigMapName,
+		MountPath: backupExportConfigMapMountPath,
+	}
+	envVar := corev1.EnvVar{
+		Name:  constants.BackupExportConfigFileEnvVar,
+		Value: fmt.Sprintf("%s/%s", backupExportConfigMapMountPath, backupExportConfigMapNameKey),
+	}
+	podSpec.Volumes = append(podSpec.Volumes, volume)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, volumeMount)
+	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, envVar)
+}
@@ -0,0 +1,311 @@
+package hive
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/images"
+	"github.com/openshift/hive/pkg/operator/util"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	hiveControllersCanaryDeploymentName = "hive-controllers-canary"
+	hiveControllersCanaryConfigMapName  = "hive-controllers-canary-config"
+	canaryControlPlaneLabel             = "controller-manager-canary"
+
+	defaultCanaryAnalysisDuration    = time.Hour
+	defaultCanaryMaxErrorRatePercent = 5
+
+	// canaryAnalysisInterval is how often the error rate of the canary controllers is analyzed while the canary is
+	// progressing.
+	canaryAnalysisInterval = time.Minute
+
+	// minCanaryReconciles is the number of reconciles of the canary controllers needed to analyze their error rate,
+	// so that a few errors right after the canary controllers start do not roll the canary back, and a canary that
+	// barely reconciled is not promoted.
+	minCanaryReconciles = 20
+
+	// maxCanaryRestarts is the number of restarts of the canary controllers after which the canary is rolled back.
+	// The metrics of the canary controllers start over when they restart, so the error rate alone would miss
+	// controllers that crash.
+	maxCanaryRestarts = 3
+
+	hiveControllersMetricsPort = "2112"
+	reconcileTotalMetric       = "controller_runtime_reconcile_total"
+)
+
+var (
+	// controllersNotCanaried are the controllers that act on the whole fleet on a schedule rather than on the
+	// watch events of objects, so they only run in the stable controllers.
+	controllersNotCanaried = []string{
+		hivev1.MetricsControllerName.String(),
+		hivev1.ClusterImageSetDiscoveryControllerName.String(),
+	}
+)
+
+// canaryInPhase returns whether the canary of the HiveConfig is in the given phase.
+func canaryInPhase(instance *hivev1.HiveConfig, phase hivev1.CanaryPhase) bool {
+	return instance.Spec.Canary != nil && instance.Status.Canary != nil && instance.Status.Canary.Phase == phase
+}
+
+// stableControllersConfig returns the configuration of the stable controllers, which is the configuration of the
+// canary once it has been promoted.
+func stableControllersConfig(instance *hivev1.HiveConfig) *hivev1.ControllersConfig {
+	if canaryInPhase(instance, hivev1.CanaryPhasePromoted) && instance.Spec.Canary.ControllersConfig != nil {
+		return instance.Spec.Canary.ControllersConfig
+	}
+	return instance.Spec.ControllersConfig
+}
+
+// stableHiveImage returns the image of the stable controllers, which is the image of the canary once it has been
+// promoted.
+func (r *ReconcileHiveConfig) stableHiveImage(instance *hivev1.HiveConfig) string {
+	if canaryInPhase(instance, hivev1.CanaryPhasePromoted) && instance.Spec.Canary.Image != "" {
+		return instance.Spec.Canary.Image
+	}
+	return r.hiveImage
+}
+
+// canaryNamespaceSelector returns the namespace selector of the canary in the string form passed to the controllers.
+// It is empty when the selector is invalid or selects every namespace.
+func canaryNamespaceSelector(canary *hivev1.CanaryConfig) string {
+	selector, err := metav1.LabelSelectorAsSelector(&canary.NamespaceSelector)
+	if err != nil {
+		return ""
+	}
+	return selector.String()
+}
+
+func canaryConfigHash(canary *hivev1.CanaryConfig) string {
+	data, _ := json.Marshal(canary)
+	sum := md5.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// reconcileCanary updates the status of the canary of the HiveConfig: it starts a rollout when the canary
+// configuration changes, and promotes or rolls back a progressing canary based on the reconciles of the canary
+// controllers. It returns when the canary should be analyzed next, or 0 when it is not progressing.
+func (r *ReconcileHiveConfig) reconcileCanary(hLog log.FieldLogger, instance *hivev1.HiveConfig) time.Duration {
+	canary := instance.Spec.Canary
+	if canary == nil {
+		instance.Status.Canary = nil
+		return 0
+	}
+
+	hash := canaryConfigHash(canary)
+	cLog := hLog.WithField("canaryConfigHash", hash)
+	status := instance.Status.Canary
+	if status == nil || status.ConfigHash != hash {
+		instance.Status.Canary = &hivev1.CanaryStatus{
+			ConfigHash: hash,
+			Phase:      hivev1.CanaryPhaseProgressing,
+			StartTime:  metav1.Now(),
+			Message:    "Canary controllers are starting",
+		}
+		if canaryNamespaceSelector(canary) == "" {
+			cLog.Warn("canary namespace selector is invalid or selects every namespace, not rolling out canary")
+			instance.Status.Canary.Phase = hivev1.CanaryPhaseRolledBack
+			instance.Status.Canary.Message = "The namespace selector must select a subset of the namespaces"
+			return 0
+		}
+		cLog.Info("starting canary rollout")
+		return canaryAnalysisInterval
+	}
+	if status.Phase != hivev1.CanaryPhaseProgressing {
+		return 0
+	}
+
+	analysisDuration := defaultCanaryAnalysisDuration
+	if canary.AnalysisDuration != "" {
+		d, err := time.ParseDuration(canary.AnalysisDuration)
+		if err != nil {
+			cLog.WithError(err).WithField("analysisDuration", canary.AnalysisDuration).Error("unable to parse canary analysis duration, using default")
+		} else {
+			analysisDuration = d
+		}
+	}
+	maxErrorRatePercent := int64(defaultCanaryMaxErrorRatePercent)
+	if canary.MaxErrorRatePercent != nil {
+		maxErrorRatePercent = int64(*canary.MaxErrorRatePercent)
+	}
+
+	reconciles, reconcileErrors, restarts, err := r.canaryReconciles(getHiveNamespace(instance))
+	switch {
+	case restarts > maxCanaryRestarts:
+		status.Phase = hivev1.CanaryPhaseRolledBack
+		status.Message = fmt.Sprintf("Canary controllers restarted %d times", restarts)
+	case err != nil:
+		cLog.WithError(err).Warn("could not get the reconciles of the canary controllers")
+		status.Message = fmt.Sprintf("Could not get the reconciles of the canary controllers: %v", err)
+		return canaryAnalysisInterval
+	default:
+		status.Reconciles = reconciles
+		status.ReconcileErrors = reconcileErrors
+		switch {
+		case reconciles >= minCanaryReconciles && reconcileErrors*100 > maxErrorRatePercent*reconciles:
+			status.Phase = hivev1.CanaryPhaseRolledBack
+			status.Message = fmt.Sprintf("%d of %d reconciles of the canary controllers failed, over the maximum error rate of %d%%", reconcileErrors, reconciles, maxErrorRatePercent)
+		case time.Since(status.StartTime.Time) >= analysisDuration && reconciles < minCanaryReconciles:
+			status.Phase = hivev1.CanaryPhaseRolledBack
+			status.Message = fmt.Sprintf("The canary controllers reconciled %d times during the analysis, fewer than the %d reconciles needed to analyze their error rate", reconciles, minCanaryReconciles)
+		case time.Since(status.StartTime.Time) >= analysisDuration:
+			status.Phase = hivev1.CanaryPhasePromoted
+			status.Message = fmt.Sprintf("%d of %d reconciles of the canary controllers failed during the analysis", reconcileErrors, reconciles)
+		default:
+			status.Message = fmt.Sprintf("%d of %d reconciles of the canary controllers failed", reconcileErrors, reconciles)
+			return canaryAnalysisInterval
+		}
+	}
+	cLog.WithField("phase", status.Phase).Info(status.Message)
+	return 0
+}
+
+// canaryReconciles returns the number of reconciles of the running canary controllers, how many of them failed, and
+// how many times the canary controllers restarted.
+func (r *ReconcileHiveConfig) canaryReconciles(namespace string) (reconciles, reconcileErrors int64, restarts int32, returnErr error) {
+	pods, err := r.kubeClient.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("control-plane=%s", canaryControlPlaneLabel),
+	})
+	if err != nil {
+		return 0, 0, 0, errors.Wrap(err, "could not list the canary controller pods")
+	}
+	running := 0
+	for _, pod := range pods.Items {
+		for _, cs := range pod.Status.ContainerStatuses {
+			restarts += cs.RestartCount
+		}
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		raw, err := r.kubeClient.CoreV1().Pods(namespace).ProxyGet("http", pod.Name, hiveControllersMetricsPort, "metrics", nil).DoRaw(context.Background())
+		if err != nil {
+			return 0, 0, restarts, errors.Wrapf(err, "could not get the metrics of pod %s", pod.Name)
+		}
+		total, failed := countReconciles(raw)
+		reconciles += total
+		reconcileErrors += failed
+		running++
+	}
+	if running == 0 {
+		return 0, 0, restarts, errors.New("no canary controllers are running")
+	}
+	return reconciles, reconcileErrors, restarts, nil
+}
+
+// countReconciles returns the total number of reconciles, and the number of failed reconciles, counted by the
+// controller reconcile counter in metrics in the Prometheus text format.
+func countReconciles(metrics []byte) (total, failed int64) {
+	scanner := bufio.NewScanner(bytes.NewReader(metrics))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, reconcileTotalMetric+"{") {
+			continue
+		}
+		end := strings.LastIndex(line, "}")
+		if end < 0 {
+			continue
+		}
+		// The value may be followed by a timestamp.
+		fields := strings.Fields(line[end+1:])
+		if len(fields) == 0 {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			continue
+		}
+		total += int64(value)
+		if strings.Contains(line[:end], `result="error"`) {
+			failed += int64(value)
+		}
+	}
+	return total, failed
+}
+
+// deployHiveCanary deploys the canary controllers alongside the stable hive-controllers deployment while the canary
+// is progressing, and removes them otherwise.
+func (r *ReconcileHiveConfig) deployHiveCanary(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig, hiveDeployment *appsv1.Deployment, hiveControllersConfigHash string) error {
+	hiveNSName := getHiveNamespace(instance)
+	if !canaryInPhase(instance, hivev1.CanaryPhaseProgressing) {
+		for _, obj := range []client.Object{
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: hiveNSName, Name: hiveControllersCanaryDeploymentName}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: hiveNSName, Name: hiveControllersCanaryConfigMapName}},
+		} {
+			if err := r.Delete(context.Background(), obj); err != nil && !apierrors.IsNotFound(err) {
+				hLog.WithError(err).WithField("name", obj.GetName()).Error("error deleting canary controllers object")
+				return err
+			}
+		}
+		return nil
+	}
+	canary := instance.Spec.Canary
+
+	controllersConfig := canary.ControllersConfig
+	if controllersConfig == nil {
+		controllersConfig = instance.Spec.ControllersConfig
+	}
+	canaryConfigMap, err := r.applyHiveControllersConfigMap(hLog, h, instance, hiveControllersCanaryConfigMapName, controllersConfig)
+	if err != nil {
+		return err
+	}
+
+	deployment := hiveDeployment.DeepCopy()
+	deployment.Name = hiveControllersCanaryDeploymentName
+	deployment.ResourceVersion = ""
+	for _, labels := range []map[string]string{deployment.Labels, deployment.Spec.Selector.MatchLabels, deployment.Spec.Template.Labels} {
+		labels["control-plane"] = canaryControlPlaneLabel
+	}
+	deployment.Spec.Template.Annotations[hiveConfigHashAnnotation] = computeHiveControllersConfigHash(canaryConfigMap, hiveControllersConfigHash)
+
+	container := &deployment.Spec.Template.Spec.Containers[0]
+	for i, envFrom := range container.EnvFrom {
+		if envFrom.ConfigMapRef != nil && envFrom.ConfigMapRef.Name == hiveControllersConfigMapName {
+			container.EnvFrom[i].ConfigMapRef.Name = hiveControllersCanaryConfigMapName
+		}
+	}
+	for i, arg := range container.Args {
+		if arg == "--disabled-controllers" && i+1 < len(container.Args) {
+			container.Args[i+1] = strings.Join(append([]string{container.Args[i+1]}, controllersNotCanaried...), ",")
+		}
+	}
+	if canary.Image != "" {
+		container.Image = canary.Image
+		for i := range container.Env {
+			if container.Env[i].Name == images.HiveImageEnvVar {
+				container.Env[i].Value = canary.Image
+			}
+		}
+	}
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  constants.CanaryEnvVar,
+		Value: "true",
+	})
+
+	result, err := util.ApplyRuntimeObjectWithGC(h, deployment, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error applying canary deployment")
+		return err
+	}
+	hLog.Infof("hive-controllers-canary deployment applied (%s)", result)
+	return nil
+}
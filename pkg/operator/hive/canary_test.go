@@ -0,0 +1,165 @@
+package hive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekubeclient "k8s.io/client-go/kubernetes/fake"
+	restclient "k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// metricsResponse is the response of the metrics endpoint of a canary controller pod.
+type metricsResponse string
+
+func (r metricsResponse) DoRaw(context.Context) ([]byte, error) {
+	return []byte(r), nil
+}
+
+func (r metricsResponse) Stream(context.Context) (io.ReadCloser, error) {
+	return ioutil.NopCloser(strings.NewReader(string(r))), nil
+}
+
+func canaryMetrics(succeeded, failed int) metricsResponse {
+	return metricsResponse(fmt.Sprintf(`# TYPE controller_runtime_reconcile_total counter
+controller_runtime_reconcile_total{controller="clusterdeployment",result="success"} %d
+controller_runtime_reconcile_total{controller="clusterdeployment",result="error"} %d
+`, succeeded, failed))
+}
+
+func TestReconcileCanary(t *testing.T) {
+	canary := &hivev1.CanaryConfig{
+		NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+		Image:             "registry.example.com/hive:canary",
+	}
+	canaryPod := func(restarts int32) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: constants.DefaultHiveNamespace,
+				Name:      "hive-controllers-canary-abc",
+				Labels:    map[string]string{"control-plane": canaryControlPlaneLabel},
+			},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{RestartCount: restarts}},
+			},
+		}
+	}
+
+	cases := []struct {
+		name               string
+		elapsed            time.Duration
+		restarts           int32
+		succeeded          int
+		failed             int
+		expectedPhase      hivev1.CanaryPhase
+		expectedReconciles int64
+		expectRequeue      bool
+	}{
+		{
+			name:               "promote",
+			elapsed:            2 * time.Hour,
+			succeeded:          99,
+			failed:             1,
+			expectedPhase:      hivev1.CanaryPhasePromoted,
+			expectedReconciles: 100,
+		},
+		{
+			name:               "promote at minimum reconciles",
+			elapsed:            2 * time.Hour,
+			succeeded:          minCanaryReconciles,
+			expectedPhase:      hivev1.CanaryPhasePromoted,
+			expectedReconciles: minCanaryReconciles,
+		},
+		{
+			name:               "progressing",
+			elapsed:            10 * time.Minute,
+			succeeded:          99,
+			failed:             1,
+			expectedPhase:      hivev1.CanaryPhaseProgressing,
+			expectedReconciles: 100,
+			expectRequeue:      true,
+		},
+		{
+			name:               "rollback for error rate",
+			elapsed:            10 * time.Minute,
+			succeeded:          90,
+			failed:             10,
+			expectedPhase:      hivev1.CanaryPhaseRolledBack,
+			expectedReconciles: 100,
+		},
+		{
+			name:          "rollback for restarts",
+			elapsed:       10 * time.Minute,
+			restarts:      maxCanaryRestarts + 1,
+			succeeded:     99,
+			failed:        1,
+			expectedPhase: hivev1.CanaryPhaseRolledBack,
+		},
+		{
+			name:               "insufficient data during analysis",
+			elapsed:            10 * time.Minute,
+			succeeded:          2,
+			failed:             3,
+			expectedPhase:      hivev1.CanaryPhaseProgressing,
+			expectedReconciles: 5,
+			expectRequeue:      true,
+		},
+		{
+			name:               "insufficient data after analysis",
+			elapsed:            2 * time.Hour,
+			succeeded:          5,
+			expectedPhase:      hivev1.CanaryPhaseRolledBack,
+			expectedReconciles: 5,
+		},
+		{
+			name:          "no reconciles after analysis",
+			elapsed:       2 * time.Hour,
+			expectedPhase: hivev1.CanaryPhaseRolledBack,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			kubeClient := fakekubeclient.NewSimpleClientset(canaryPod(tc.restarts))
+			metrics := canaryMetrics(tc.succeeded, tc.failed)
+			kubeClient.PrependProxyReactor("pods", func(clienttesting.Action) (bool, restclient.ResponseWrapper, error) {
+				return true, metrics, nil
+			})
+			r := &ReconcileHiveConfig{kubeClient: kubeClient}
+			instance := &hivev1.HiveConfig{
+				Spec: hivev1.HiveConfigSpec{Canary: canary},
+				Status: hivev1.HiveConfigStatus{
+					Canary: &hivev1.CanaryStatus{
+						ConfigHash: canaryConfigHash(canary),
+						Phase:      hivev1.CanaryPhaseProgressing,
+						StartTime:  metav1.NewTime(time.Now().Add(-tc.elapsed)),
+					},
+				},
+			}
+
+			requeueAfter := r.reconcileCanary(log.WithField("test", tc.name), instance)
+
+			status := instance.Status.Canary
+			assert.Equal(t, tc.expectedPhase, status.Phase, "unexpected canary phase: %s", status.Message)
+			assert.Equal(t, tc.expectedReconciles, status.Reconciles, "unexpected reconciles")
+			if tc.expectRequeue {
+				assert.Equal(t, canaryAnalysisInterval, requeueAfter, "expected canary to be analyzed again")
+			} else {
+				assert.Zero(t, requeueAfter, "expected canary analysis to be over")
+			}
+		})
+	}
+}
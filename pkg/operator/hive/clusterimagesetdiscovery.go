@@ -0,0 +1,74 @@
+package hive
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/operator/util"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	clusterImageSetDiscoveryConfigMapName      = "clusterimageset-discovery"
+	clusterImageSetDiscoveryConfigMapNameKey   = "clusterimageset-discovery"
+	clusterImageSetDiscoveryConfigMapMountPath = "/data/clusterimageset-discovery-config"
+)
+
+func (r *ReconcileHiveConfig) deployClusterImageSetDiscoveryConfigMap(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig) (string, error) {
+	cm := &corev1.ConfigMap{}
+	cm.Name = clusterImageSetDiscoveryConfigMapName
+	cm.Namespace = getHiveNamespace(instance)
+	cm.Data = make(map[string]string)
+
+	if instance.Spec.ClusterImageSetDiscovery != nil {
+		data, err := json.Marshal(instance.Spec.ClusterImageSetDiscovery)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal clusterimageset discovery controller config")
+		}
+		cm.Data[clusterImageSetDiscoveryConfigMapNameKey] = string(data)
+	}
+
+	result, err := util.ApplyRuntimeObjectWithGC(h, cm, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error applying clusterimageset-discovery configmap")
+		return "", err
+	}
+	hLog.WithField("result", result).Info("clusterimageset-discovery configmap applied")
+
+	hLog.Info("Hashing clusterimageset-discovery data onto a hive deployment annotation")
+	hasher := md5.New()
+	hasher.Write([]byte(fmt.Sprintf("%v", cm.Data)))
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+func addClusterImageSetDiscoveryConfigVolume(podSpec *corev1.PodSpec) {
+	optional := true
+	volume := corev1.Volume{}
+	volume.Name = clusterImageSetDiscoveryConfigMapName
+	volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: clusterImageSetDiscoveryConfigMapName,
+		},
+		Optional: &optional,
+	}
+	volumeMount := corev1.VolumeMount{
+		Name:      clusterImageSetDiscoveryConfigMapName,
+		MountPath: clusterImageSetDiscoveryConfigMapMountPath,
+	}
+	envVar := corev1.EnvVar{
+		Name:  constants.ClusterImageSetDiscoveryControllerConfigFileEnvVar,
+		Value: fmt.Sprintf("%s/%s", clusterImageSetDiscoveryConfigMapMountPath, clusterImageSetDiscoveryConfigMapNameKey),
+	}
+	podSpec.Volumes = append(podSpec.Volumes, volume)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, volumeMount)
+	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, envVar)
+}
@@ -29,12 +29,13 @@ func (r *ReconcileHiveConfig) deployClusterSync(hLog log.FieldLogger, h resource
 	newClusterSyncStatefulSet := controllerutils.ReadStatefulsetOrDie(asset)
 	hiveContainer := &newClusterSyncStatefulSet.Spec.Template.Spec.Containers[0]
 
-	hLog.Infof("hive image: %s", r.hiveImage)
-	if r.hiveImage != "" {
-		hiveContainer.Image = r.hiveImage
+	hiveImage := r.stableHiveImage(hiveconfig)
+	hLog.Infof("hive image: %s", hiveImage)
+	if hiveImage != "" {
+		hiveContainer.Image = hiveImage
 		hiveImageEnvVar := corev1.EnvVar{
 			Name:  images.HiveImageEnvVar,
-			Value: r.hiveImage,
+			Value: hiveImage,
 		}
 
 		hiveContainer.Env = append(hiveContainer.Env, hiveImageEnvVar)
@@ -56,6 +57,10 @@ func (r *ReconcileHiveConfig) deployClusterSync(hLog log.FieldLogger, h resource
 		hiveContainer.Args = append(hiveContainer.Args, "--log-level", level)
 	}
 
+	if format := hiveconfig.Spec.LogFormat; format != "" {
+		hiveContainer.Args = append(hiveContainer.Args, "--log-format", string(format))
+	}
+
 	if syncSetReapplyInterval := hiveconfig.Spec.SyncSetReapplyInterval; syncSetReapplyInterval != "" {
 		syncsetReapplyIntervalEnvVar := corev1.EnvVar{
 			Name:  "SYNCSET_REAPPLY_INTERVAL",
@@ -65,6 +70,13 @@ func (r *ReconcileHiveConfig) deployClusterSync(hLog log.FieldLogger, h resource
 		hiveContainer.Env = append(hiveContainer.Env, syncsetReapplyIntervalEnvVar)
 	}
 
+	if err := addTracingEnvVar(hiveContainer, hiveconfig); err != nil {
+		hLog.WithError(err).Error("error marshaling tracing")
+		return err
+	}
+
+	addControllerLogLevelsVolume(&newClusterSyncStatefulSet.Spec.Template.Spec)
+
 	hiveNSName := getHiveNamespace(hiveconfig)
 
 	if newClusterSyncStatefulSet.Spec.Template.Annotations == nil {
@@ -3,10 +3,14 @@ package hive
 import (
 	"crypto/md5"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strconv"
 
+	"github.com/pkg/errors"
+
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/operator/util"
 	"github.com/openshift/hive/pkg/resource"
@@ -18,19 +22,39 @@ const (
 	// hiveControllersConfigMapName is the name of the configmap to store the
 	// configurations like goroutines, qps, burst etc. for different hive controllers
 	hiveControllersConfigMapName = "hive-controllers-config"
+
+	// hiveControllersLogLevelsConfigMapName is the name of the configmap to store the log levels of specific hive
+	// controllers. It is mounted into the controller pods rather than hashed onto them, so that log level changes
+	// are picked up without restarting the pods.
+	hiveControllersLogLevelsConfigMapName      = "hive-controllers-log-levels"
+	hiveControllersLogLevelsConfigMapKey       = "log-levels"
+	hiveControllersLogLevelsConfigMapMountPath = "/data/hive-controllers-log-levels"
 )
 
 func (r *ReconcileHiveConfig) deployHiveControllersConfigMap(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig, additionalControllerConfigHashes ...string) (string, error) {
+	hiveControllersConfigMap, err := r.applyHiveControllersConfigMap(hLog, h, instance, hiveControllersConfigMapName, stableControllersConfig(instance))
+	if err != nil {
+		return "", err
+	}
+
+	hLog.Info("Hashing hive-controllers-config data onto a hive deployment annotation")
+	hiveControllersConfigHash := computeHiveControllersConfigHash(hiveControllersConfigMap, additionalControllerConfigHashes...)
+
+	return hiveControllersConfigHash, nil
+}
+
+// applyHiveControllersConfigMap applies the configmap with the given name holding the configuration of the controllers.
+func (r *ReconcileHiveConfig) applyHiveControllersConfigMap(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig, name string, controllersConfig *hivev1.ControllersConfig) (*corev1.ConfigMap, error) {
 	hiveControllersConfigMap := &corev1.ConfigMap{}
-	hiveControllersConfigMap.Name = hiveControllersConfigMapName
+	hiveControllersConfigMap.Name = name
 	hiveControllersConfigMap.Namespace = getHiveNamespace(instance)
 	hiveControllersConfigMap.Data = make(map[string]string)
 
-	if instance.Spec.ControllersConfig != nil {
-		if instance.Spec.ControllersConfig.Default != nil {
-			setHiveControllersConfig(instance.Spec.ControllersConfig.Default, hiveControllersConfigMap, "default")
+	if controllersConfig != nil {
+		if controllersConfig.Default != nil {
+			setHiveControllersConfig(controllersConfig.Default, hiveControllersConfigMap, "default")
 		}
-		for _, controller := range instance.Spec.ControllersConfig.Controllers {
+		for _, controller := range controllersConfig.Controllers {
 			replicasIsSet := controller.Config.Replicas != nil
 			replicasShouldBeSet := controllersUsingReplicas.Contains(controller.Name)
 			if !replicasShouldBeSet && replicasIsSet {
@@ -43,15 +67,11 @@ func (r *ReconcileHiveConfig) deployHiveControllersConfigMap(hLog log.FieldLogge
 
 	result, err := util.ApplyRuntimeObjectWithGC(h, hiveControllersConfigMap, instance)
 	if err != nil {
-		hLog.WithError(err).Error("error applying hive-controllers-config configmap")
-		return "", err
+		hLog.WithError(err).Errorf("error applying %s configmap", name)
+		return nil, err
 	}
-	hLog.WithField("result", result).Info("hive-controllers-config configmap applied")
-
-	hLog.Info("Hashing hive-controllers-config data onto a hive deployment annotation")
-	hiveControllersConfigHash := computeHiveControllersConfigHash(hiveControllersConfigMap, additionalControllerConfigHashes...)
-
-	return hiveControllersConfigHash, nil
+	hLog.WithField("result", result).Infof("%s configmap applied", name)
+	return hiveControllersConfigMap, nil
 }
 
 func getHiveControllerConfig(controllerName hivev1.ControllerName, controllerConfigs []hivev1.SpecificControllerConfig) (*hivev1.ControllerConfig, bool) {
@@ -68,6 +88,9 @@ func setHiveControllersConfig(config *hivev1.ControllerConfig, hiveControllersCo
 	if config.ConcurrentReconciles != nil {
 		hiveControllersConfigMap.Data[fmt.Sprintf(utils.ConcurrentReconcilesEnvVariableFormat, controllerName)] = strconv.Itoa(int(*config.ConcurrentReconciles))
 	}
+	if config.ConcurrentReconcilesPerCredentials != nil {
+		hiveControllersConfigMap.Data[fmt.Sprintf(utils.ConcurrentReconcilesPerCredentialsEnvVariableFormat, controllerName)] = strconv.Itoa(int(*config.ConcurrentReconcilesPerCredentials))
+	}
 	if config.ClientQPS != nil {
 		hiveControllersConfigMap.Data[fmt.Sprintf(utils.ClientQPSEnvVariableFormat, controllerName)] = strconv.Itoa(int(*config.ClientQPS))
 	}
@@ -90,3 +113,59 @@ func computeHiveControllersConfigHash(hiveControllersConfigMap *corev1.ConfigMap
 	}
 	return hex.EncodeToString(hasher.Sum(nil))
 }
+
+func (r *ReconcileHiveConfig) deployHiveControllersLogLevelsConfigMap(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig) error {
+	cm := &corev1.ConfigMap{}
+	cm.Name = hiveControllersLogLevelsConfigMapName
+	cm.Namespace = getHiveNamespace(instance)
+
+	levels := map[hivev1.ControllerName]string{}
+	if instance.Spec.ControllersConfig != nil {
+		for _, controller := range instance.Spec.ControllersConfig.Controllers {
+			if controller.Config.LogLevel == "" {
+				continue
+			}
+			if _, err := log.ParseLevel(controller.Config.LogLevel); err != nil {
+				hLog.WithField("controller", controller.Name).WithError(err).Warn("ignoring invalid log level")
+				continue
+			}
+			levels[controller.Name] = controller.Config.LogLevel
+		}
+	}
+	data, err := json.Marshal(levels)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal controller log levels")
+	}
+	cm.Data = map[string]string{hiveControllersLogLevelsConfigMapKey: string(data)}
+
+	result, err := util.ApplyRuntimeObjectWithGC(h, cm, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error applying hive-controllers-log-levels configmap")
+		return err
+	}
+	hLog.WithField("result", result).Info("hive-controllers-log-levels configmap applied")
+	return nil
+}
+
+func addControllerLogLevelsVolume(podSpec *corev1.PodSpec) {
+	optional := true
+	volume := corev1.Volume{}
+	volume.Name = hiveControllersLogLevelsConfigMapName
+	volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: hiveControllersLogLevelsConfigMapName,
+		},
+		Optional: &optional,
+	}
+	volumeMount := corev1.VolumeMount{
+		Name:      hiveControllersLogLevelsConfigMapName,
+		MountPath: hiveControllersLogLevelsConfigMapMountPath,
+	}
+	envVar := corev1.EnvVar{
+		Name:  constants.ControllerLogLevelsFileEnvVar,
+		Value: fmt.Sprintf("%s/%s", hiveControllersLogLevelsConfigMapMountPath, hiveControllersLogLevelsConfigMapKey),
+	}
+	podSpec.Volumes = append(podSpec.Volumes, volume)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, volumeMount)
+	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, envVar)
+}
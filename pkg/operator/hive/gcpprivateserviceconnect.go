@@ -0,0 +1,74 @@
+package hive
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/operator/util"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	gcpPrivateServiceConnectConfigMapName      = "gcp-private-service-connect"
+	gcpPrivateServiceConnectConfigMapNameKey   = "gcp-private-service-connect"
+	gcpPrivateServiceConnectConfigMapMountPath = "/data/gcp-private-service-connect-config"
+)
+
+func (r *ReconcileHiveConfig) deployGCPPrivateServiceConnectConfigMap(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig) (string, error) {
+	cm := &corev1.ConfigMap{}
+	cm.Name = gcpPrivateServiceConnectConfigMapName
+	cm.Namespace = getHiveNamespace(instance)
+	cm.Data = make(map[string]string)
+
+	if instance.Spec.GCPPrivateServiceConnect != nil {
+		data, err := json.Marshal(instance.Spec.GCPPrivateServiceConnect)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal gcp private service connect controller config")
+		}
+		cm.Data[gcpPrivateServiceConnectConfigMapNameKey] = string(data)
+	}
+
+	result, err := util.ApplyRuntimeObjectWithGC(h, cm, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error applying gcp-private-service-connect configmap")
+		return "", err
+	}
+	hLog.WithField("result", result).Info("gcp-private-service-connect configmap applied")
+
+	hLog.Info("Hashing gcp-private-service-connect data onto a hive deployment annotation")
+	hasher := md5.New()
+	hasher.Write([]byte(fmt.Sprintf("%v", cm.Data)))
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+func addGCPPrivateServiceConnectConfigVolume(podSpec *corev1.PodSpec) {
+	optional := true
+	volume := corev1.Volume{}
+	volume.Name = gcpPrivateServiceConnectConfigMapName
+	volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: gcpPrivateServiceConnectConfigMapName,
+		},
+		Optional: &optional,
+	}
+	volumeMount := corev1.VolumeMount{
+		Name:      gcpPrivateServiceConnectConfigMapName,
+		MountPath: gcpPrivateServiceConnectConfigMapMountPath,
+	}
+	envVar := corev1.EnvVar{
+		Name:  constants.GCPPrivateServiceConnectControllerConfigFileEnvVar,
+		Value: fmt.Sprintf("%s/%s", gcpPrivateServiceConnectConfigMapMountPath, gcpPrivateServiceConnectConfigMapNameKey),
+	}
+	podSpec.Volumes = append(podSpec.Volumes, volume)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, volumeMount)
+	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, envVar)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/md5"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
@@ -59,12 +60,13 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 	hiveDeployment := resourceread.ReadDeploymentV1OrDie(asset)
 	hiveContainer := &hiveDeployment.Spec.Template.Spec.Containers[0]
 
-	hLog.Infof("hive image: %s", r.hiveImage)
-	if r.hiveImage != "" {
-		hiveContainer.Image = r.hiveImage
+	hiveImage := r.stableHiveImage(instance)
+	hLog.Infof("hive image: %s", hiveImage)
+	if hiveImage != "" {
+		hiveContainer.Image = hiveImage
 		hiveImageEnvVar := corev1.EnvVar{
 			Name:  images.HiveImageEnvVar,
-			Value: r.hiveImage,
+			Value: hiveImage,
 		}
 
 		hiveContainer.Env = append(hiveContainer.Env, hiveImageEnvVar)
@@ -90,6 +92,10 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		hiveContainer.Args = append(hiveContainer.Args, "--log-level", level)
 	}
 
+	if format := instance.Spec.LogFormat; format != "" {
+		hiveContainer.Args = append(hiveContainer.Args, "--log-format", string(format))
+	}
+
 	if syncSetReapplyInterval := instance.Spec.SyncSetReapplyInterval; syncSetReapplyInterval != "" {
 		syncsetReapplyIntervalEnvVar := corev1.EnvVar{
 			Name:  "SYNCSET_REAPPLY_INTERVAL",
@@ -99,8 +105,115 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		hiveContainer.Env = append(hiveContainer.Env, syncsetReapplyIntervalEnvVar)
 	}
 
+	if warningPeriod := instance.Spec.CredentialsExpiryWarningPeriod; warningPeriod != "" {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.CredentialsExpiryWarningPeriodEnvVar,
+			Value: warningPeriod,
+		})
+	}
+
+	if checkInterval := instance.Spec.ParentLinkCheckInterval; checkInterval != "" {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.ParentLinkCheckIntervalEnvVar,
+			Value: checkInterval,
+		})
+	}
+
+	if resolvers := instance.Spec.ParentLinkResolvers; len(resolvers) > 0 {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.ParentLinkResolversEnvVar,
+			Value: strings.Join(resolvers, ","),
+		})
+	}
+
+	if backoff := instance.Spec.UnreachableProbeBackoff; backoff != nil {
+		backoffJSON, err := json.Marshal(backoff)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling unreachable probe backoff")
+			return err
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.UnreachableProbeBackoffEnvVar,
+			Value: string(backoffJSON),
+		})
+	}
+
+	if defaults := instance.Spec.HibernationDefaults; defaults != nil {
+		defaultsJSON, err := json.Marshal(defaults)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling hibernation defaults")
+			return err
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.HibernationDefaultsEnvVar,
+			Value: string(defaultsJSON),
+		})
+	}
+
+	if endpointHealth := instance.Spec.EndpointHealth; endpointHealth != nil {
+		endpointHealthJSON, err := json.Marshal(endpointHealth)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling endpoint health")
+			return err
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.EndpointHealthEnvVar,
+			Value: string(endpointHealthJSON),
+		})
+	}
+
+	if rateLimit := instance.Spec.AWSRoute53RateLimit; rateLimit != nil {
+		rateLimitJSON, err := json.Marshal(rateLimit)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling AWS Route53 rate limit")
+			return err
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.AWSRoute53RateLimitEnvVar,
+			Value: string(rateLimitJSON),
+		})
+	}
+
+	if cache := instance.Spec.AWSClientCache; cache != nil {
+		cacheJSON, err := json.Marshal(cache)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling AWS client cache")
+			return err
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.AWSClientCacheEnvVar,
+			Value: string(cacheJSON),
+		})
+	}
+
+	if broker := instance.Spec.CredentialsBroker; broker != nil {
+		brokerJSON, err := json.Marshal(broker)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling credentials broker")
+			return err
+		}
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.CredentialsBrokerEnvVar,
+			Value: string(brokerJSON),
+		})
+	}
+
+	if canaryInPhase(instance, hivev1.CanaryPhaseProgressing) {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.CanaryNamespaceSelectorEnvVar,
+			Value: canaryNamespaceSelector(instance.Spec.Canary),
+		})
+	}
+
 	addManagedDomainsVolume(&hiveDeployment.Spec.Template.Spec, mdConfigMap.Name)
 	addAWSPrivateLinkConfigVolume(&hiveDeployment.Spec.Template.Spec)
+	addGCPPrivateServiceConnectConfigVolume(&hiveDeployment.Spec.Template.Spec)
+	addClusterImageSetDiscoveryConfigVolume(&hiveDeployment.Spec.Template.Spec)
+	addControllerLogLevelsVolume(&hiveDeployment.Spec.Template.Spec)
+	addNamespaceQuotasConfigVolume(&hiveDeployment.Spec.Template.Spec)
+	addWorkloadSchedulingConfigVolume(&hiveDeployment.Spec.Template.Spec)
+	addBackupExportConfigVolume(&hiveDeployment.Spec.Template.Spec)
+	addJobProxyConfigVolume(&hiveDeployment.Spec.Template.Spec)
 
 	hiveNSName := getHiveNamespace(instance)
 
@@ -133,6 +246,20 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		hiveContainer.Env = append(hiveContainer.Env, awsLogsEnvVars...)
 	}
 
+	if ref := instance.Spec.FailedProvisionConfig.AdditionalInstallLogRegexesConfigMapRef; ref != nil && ref.Name != "" {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.AdditionalInstallLogRegexesConfigMapEnvVar,
+			Value: ref.Name,
+		})
+	}
+
+	if retryReasons := instance.Spec.FailedProvisionConfig.RetryReasons; retryReasons != nil {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.FailedProvisionRetryReasonsEnvVar,
+			Value: strings.Join(*retryReasons, ","),
+		})
+	}
+
 	if awssp := instance.Spec.ServiceProviderCredentialsConfig.AWS; awssp != nil && awssp.CredentialsSecretRef.Name != "" {
 		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
 			Name:  constants.HiveAWSServiceProviderCredentialsSecretRefEnvVar,
@@ -184,6 +311,28 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		hiveContainer.Env = append(hiveContainer.Env, tmpEnvVar)
 	}
 
+	if instance.Spec.ReleaseImageValidation != nil {
+		hLog.Info("release image validation enabled")
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.ReleaseImageValidationArchitecturesEnvVar,
+			Value: strings.Join(instance.Spec.ReleaseImageValidation.AllowedArchitectures, ","),
+		})
+	}
+
+	if instance.Spec.ReleaseImageVerification != nil {
+		hLog.Info("release image verification enabled")
+		hiveContainer.Env = append(hiveContainer.Env,
+			corev1.EnvVar{
+				Name:  hiveconstants.ReleaseImageVerificationSignatureStoresEnvVar,
+				Value: strings.Join(instance.Spec.ReleaseImageVerification.SignatureStores, ","),
+			},
+			corev1.EnvVar{
+				Name:  hiveconstants.ReleaseImageVerificationKeysSecretEnvVar,
+				Value: instance.Spec.ReleaseImageVerification.PublicKeysSecretRef.Name,
+			},
+		)
+	}
+
 	if instance.Spec.DeleteProtection == hivev1.DeleteProtectionEnabled {
 		hLog.Info("Delete Protection enabled")
 		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
@@ -192,6 +341,11 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		})
 	}
 
+	if err := addTracingEnvVar(hiveContainer, instance); err != nil {
+		hLog.WithError(err).Error("error marshaling tracing")
+		return err
+	}
+
 	if err := r.includeAdditionalCAs(hLog, h, instance, hiveDeployment); err != nil {
 		return err
 	}
@@ -289,6 +443,10 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 	}
 	hLog.Infof("hive-controllers deployment applied (%s)", result)
 
+	if err := r.deployHiveCanary(hLog, h, instance, hiveDeployment, hiveControllersConfigHash); err != nil {
+		return err
+	}
+
 	hLog.Info("all hive components successfully reconciled")
 	return nil
 }
@@ -379,6 +537,22 @@ func (r *ReconcileHiveConfig) includeGlobalPullSecret(hLog log.FieldLogger, h re
 	hiveDeployment.Spec.Template.Spec.Containers[0].Env = append(hiveDeployment.Spec.Template.Spec.Containers[0].Env, globalPullSecretEnvVar)
 }
 
+// addTracingEnvVar passes the tracing configuration of the HiveConfig, if any, to the controllers of the container.
+func addTracingEnvVar(container *corev1.Container, instance *hivev1.HiveConfig) error {
+	if instance.Spec.Tracing == nil {
+		return nil
+	}
+	tracingJSON, err := json.Marshal(instance.Spec.Tracing)
+	if err != nil {
+		return err
+	}
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  constants.TracingEnvVar,
+		Value: string(tracingJSON),
+	})
+	return nil
+}
+
 func (r *ReconcileHiveConfig) runningOnOpenShift(hLog log.FieldLogger) (bool, error) {
 	deploymentConfigGroupVersion := oappsv1.GroupVersion.String()
 	list, err := r.discoveryClient.ServerResourcesForGroupVersion(deploymentConfigGroupVersion)
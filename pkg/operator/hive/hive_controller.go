@@ -407,6 +407,22 @@ func (r *ReconcileHiveConfig) Reconcile(ctx context.Context, request reconcile.R
 		return reconcile.Result{}, err
 	}
 
+	pscConfigHash, err := r.deployGCPPrivateServiceConnectConfigMap(hLog, h, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error deploying gcp private service connect configmap")
+		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingGCPPrivateServiceConnectConfigmap", err.Error())
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
+	cisdConfigHash, err := r.deployClusterImageSetDiscoveryConfigMap(hLog, h, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error deploying clusterimageset discovery configmap")
+		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingClusterImageSetDiscoveryConfigmap", err.Error())
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
 	scConfigHash, err := r.deploySupportedContractsConfigMap(hLog, h, instance)
 	if err != nil {
 		hLog.WithError(err).Error("error deploying supported contracts configmap")
@@ -414,7 +430,41 @@ func (r *ReconcileHiveConfig) Reconcile(ctx context.Context, request reconcile.R
 		return reconcile.Result{}, err
 	}
 
-	confighash, err := r.deployHiveControllersConfigMap(hLog, h, instance, plConfigHash)
+	nqConfigHash, err := r.deployNamespaceQuotasConfigMap(hLog, h, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error deploying namespace quotas configmap")
+		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingNamespaceQuotasConfigmap", err.Error())
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
+	wsConfigHash, err := r.deployWorkloadSchedulingConfigMap(hLog, h, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error deploying workload scheduling configmap")
+		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingWorkloadSchedulingConfigmap", err.Error())
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
+	beConfigHash, err := r.deployBackupExportConfigMap(hLog, h, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error deploying backup export configmap")
+		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingBackupExportConfigmap", err.Error())
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
+	jpConfigHash, err := r.deployJobProxyConfigMap(hLog, h, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error deploying job proxy configmap")
+		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingJobProxyConfigmap", err.Error())
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
+	canaryRequeueAfter := r.reconcileCanary(hLog, instance)
+
+	confighash, err := r.deployHiveControllersConfigMap(hLog, h, instance, plConfigHash, pscConfigHash, cisdConfigHash, nqConfigHash, wsConfigHash, beConfigHash, jpConfigHash)
 	if err != nil {
 		hLog.WithError(err).Error("error deploying controllers configmap")
 		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingControllersConfigmap", err.Error())
@@ -422,6 +472,13 @@ func (r *ReconcileHiveConfig) Reconcile(ctx context.Context, request reconcile.R
 		return reconcile.Result{}, err
 	}
 
+	if err := r.deployHiveControllersLogLevelsConfigMap(hLog, h, instance); err != nil {
+		hLog.WithError(err).Error("error deploying controllers log levels configmap")
+		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingControllersLogLevelsConfigmap", err.Error())
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
 	fgConfigHash, err := r.deployFeatureGatesConfigMap(hLog, h, instance)
 	if err != nil {
 		hLog.WithError(err).Error("error deploying feature gates configmap")
@@ -451,7 +508,7 @@ func (r *ReconcileHiveConfig) Reconcile(ctx context.Context, request reconcile.R
 		return reconcile.Result{}, err
 	}
 
-	err = r.deployHiveAdmission(hLog, h, instance, recorder, managedDomainsConfigMap, fgConfigHash, plConfigHash, scConfigHash)
+	err = r.deployHiveAdmission(hLog, h, instance, recorder, managedDomainsConfigMap, fgConfigHash, plConfigHash, pscConfigHash, scConfigHash, nqConfigHash)
 	if err != nil {
 		hLog.WithError(err).Error("error deploying HiveAdmission")
 		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingHiveAdmission", err.Error())
@@ -471,7 +528,7 @@ func (r *ReconcileHiveConfig) Reconcile(ctx context.Context, request reconcile.R
 		return reconcile.Result{}, err
 	}
 
-	return reconcile.Result{}, nil
+	return reconcile.Result{RequeueAfter: canaryRequeueAfter}, nil
 }
 
 func (r *ReconcileHiveConfig) establishSecretWatch(hLog *log.Entry, hiveNSName string) error {
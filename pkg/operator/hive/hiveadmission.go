@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"reflect"
 	"sort"
 	"strings"
 
@@ -57,6 +58,7 @@ const (
 )
 
 var webhookAssets = []string{
+	"config/hiveadmission/clusterclaim-webhook.yaml",
 	"config/hiveadmission/clusterdeployment-webhook.yaml",
 	"config/hiveadmission/clusterimageset-webhook.yaml",
 	"config/hiveadmission/clusterprovision-webhook.yaml",
@@ -66,6 +68,22 @@ var webhookAssets = []string{
 	"config/hiveadmission/selectorsyncset-webhook.yaml",
 }
 
+// conversionWebhookCRDs are the CRDs whose versions are converted by the conversion webhook of hiveadmission.
+var conversionWebhookCRDs = []string{
+	"clusterdeployments.hive.openshift.io",
+	"clusterpools.hive.openshift.io",
+	"syncsets.hive.openshift.io",
+}
+
+// serviceCAInjectAnnotation asks the service CA operator of OpenShift 4 to inject the service CA into the conversion
+// webhook of a CRD.
+const serviceCAInjectAnnotation = "service.beta.openshift.io/inject-cabundle"
+
+var mutatingWebhookAssets = []string{
+	"config/hiveadmission/clusterclaim-mutating-webhook.yaml",
+	"config/hiveadmission/clusterdeployment-mutating-webhook.yaml",
+}
+
 func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig, recorder events.Recorder, mdConfigMap *corev1.ConfigMap, additionalHashes ...string) error {
 	hiveNSName := getHiveNamespace(instance)
 
@@ -133,7 +151,53 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 
 	addManagedDomainsVolume(&hiveAdmDeployment.Spec.Template.Spec, mdConfigMap.Name)
 	addAWSPrivateLinkConfigVolume(&hiveAdmDeployment.Spec.Template.Spec)
+	addGCPPrivateServiceConnectConfigVolume(&hiveAdmDeployment.Spec.Template.Spec)
 	addSupportedContractsConfigVolume(&hiveAdmDeployment.Spec.Template.Spec)
+	addNamespaceQuotasConfigVolume(&hiveAdmDeployment.Spec.Template.Spec)
+
+	if len(instance.Spec.AllowedInstallerEnv) > 0 {
+		hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env = append(hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+			Name:  constants.AllowedInstallerEnvEnvVar,
+			Value: strings.Join(instance.Spec.AllowedInstallerEnv, ","),
+		})
+	}
+
+	if defaults := instance.Spec.HibernationDefaults; defaults != nil {
+		defaultsJSON, err := json.Marshal(defaults)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling hibernation defaults")
+			return err
+		}
+		hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env = append(hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+			Name:  constants.HibernationDefaultsEnvVar,
+			Value: string(defaultsJSON),
+		})
+	}
+
+	if broker := instance.Spec.CredentialsBroker; broker != nil {
+		brokerJSON, err := json.Marshal(broker)
+		if err != nil {
+			hLog.WithError(err).Error("error marshaling credentials broker")
+			return err
+		}
+		hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env = append(hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+			Name:  constants.CredentialsBrokerEnvVar,
+			Value: string(brokerJSON),
+		})
+	}
+
+	var warnRules []string
+	for _, rule := range instance.Spec.AdmissionRules {
+		if rule.Mode == hivev1.WarnAdmissionRuleMode {
+			warnRules = append(warnRules, string(rule.Name))
+		}
+	}
+	if len(warnRules) > 0 {
+		hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env = append(hiveAdmDeployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+			Name:  constants.AdmissionWarnRulesEnvVar,
+			Value: strings.Join(warnRules, ","),
+		})
+	}
 
 	validatingWebhooks := make([]*admregv1.ValidatingWebhookConfiguration, len(webhookAssets))
 	for i, yaml := range webhookAssets {
@@ -142,6 +206,13 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 		validatingWebhooks[i] = wh
 	}
 
+	mutatingWebhooks := make([]*admregv1.MutatingWebhookConfiguration, len(mutatingWebhookAssets))
+	for i, yaml := range mutatingWebhookAssets {
+		asset = assets.MustAsset(yaml)
+		wh := util.ReadMutatingWebhookConfigurationV1Beta1OrDie(asset, scheme.Scheme)
+		mutatingWebhooks[i] = wh
+	}
+
 	hLog.Debug("reading apiservice")
 	asset = assets.MustAsset("config/hiveadmission/apiservice.yaml")
 	apiService := util.ReadAPIServiceV1Beta1OrDie(asset, scheme.Scheme)
@@ -164,7 +235,7 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 	}
 	if !isOpenShift || is311 {
 		hLog.Debug("non-OpenShift 4.x cluster detected, modifying hiveadmission webhooks for CA certs")
-		err = r.injectCerts(apiService, validatingWebhooks, nil, hiveNSName, hLog)
+		err = r.injectCerts(apiService, validatingWebhooks, mutatingWebhooks, hiveNSName, hLog)
 		if err != nil {
 			hLog.WithError(err).Error("error injecting certs")
 			return err
@@ -207,6 +278,20 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 		hLog.WithField("webhook", webhook.Name).Infof("validating webhook: %s", result)
 	}
 
+	for _, webhook := range mutatingWebhooks {
+		result, err = util.ApplyRuntimeObjectWithGC(h, webhook, instance)
+		if err != nil {
+			hLog.WithField("webhook", webhook.Name).WithError(err).Errorf("error applying mutating webhook")
+			return err
+		}
+		hLog.WithField("webhook", webhook.Name).Infof("mutating webhook: %s", result)
+	}
+
+	if err := r.configureConversionWebhooks(hLog, hiveNSName, !isOpenShift || is311); err != nil {
+		hLog.WithError(err).Error("error configuring CRD conversion webhooks")
+		return err
+	}
+
 	hLog.Info("hiveadmission components reconciled successfully")
 	return nil
 }
@@ -281,6 +366,57 @@ func (r *ReconcileHiveConfig) injectCerts(apiService *apiregistrationv1.APIServi
 	return nil
 }
 
+// configureConversionWebhooks points the conversion webhooks of the multi-version CRDs at the hiveadmission service
+// in the hive namespace. The service CA is injected by the service CA operator on OpenShift 4, and set directly
+// elsewhere. CRDs installed without a conversion webhook are left alone.
+func (r *ReconcileHiveConfig) configureConversionWebhooks(hLog log.FieldLogger, hiveNSName string, injectCA bool) error {
+	var serviceCA []byte
+	if injectCA {
+		var err error
+		serviceCA, _, err = r.getCACerts(hLog, hiveNSName)
+		if err != nil {
+			return err
+		}
+	}
+	for _, name := range conversionWebhookCRDs {
+		crdLog := hLog.WithField("crd", name)
+		crd := &apiextv1beta1.CustomResourceDefinition{}
+		if err := r.Client.Get(context.Background(), types.NamespacedName{Name: name}, crd); err != nil {
+			if errors.IsNotFound(err) {
+				crdLog.Debug("CRD not found, skipping conversion webhook")
+				continue
+			}
+			crdLog.WithError(err).Error("error getting CRD")
+			return err
+		}
+		conversion := crd.Spec.Conversion
+		if conversion == nil || conversion.Strategy != apiextv1beta1.WebhookConverter ||
+			conversion.WebhookClientConfig == nil || conversion.WebhookClientConfig.Service == nil {
+			crdLog.Debug("CRD has no conversion webhook, skipping")
+			continue
+		}
+		original := crd.DeepCopy()
+		conversion.WebhookClientConfig.Service.Namespace = hiveNSName
+		if injectCA {
+			conversion.WebhookClientConfig.CABundle = serviceCA
+		} else {
+			if crd.Annotations == nil {
+				crd.Annotations = map[string]string{}
+			}
+			crd.Annotations[serviceCAInjectAnnotation] = "true"
+		}
+		if reflect.DeepEqual(original, crd) {
+			continue
+		}
+		if err := r.Client.Update(context.Background(), crd); err != nil {
+			crdLog.WithError(err).Error("error updating CRD conversion webhook")
+			return err
+		}
+		crdLog.Info("updated CRD conversion webhook")
+	}
+	return nil
+}
+
 // is311 returns true if this is a 3.11 OpenShift cluster. We check by looking for a ClusterVersion CRD,
 // which should only exist on OpenShift 4.x. We do not expect Hive to ever be deployed on pre-3.11.
 func (r *ReconcileHiveConfig) is311(hLog log.FieldLogger) (bool, error) {
@@ -0,0 +1,123 @@
+package hive
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/operator/util"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	jobProxyConfigMapName               = "hive-job-proxy"
+	jobProxyConfigMapNameKey            = "job-proxy"
+	jobProxyConfigMapTrustedCABundleKey = "ca-bundle.crt"
+	jobProxyConfigMapMountPath          = "/data/job-proxy-config"
+
+	// The proxy configuration of the clients of the cloud APIs of the controllers is kept in the same ConfigMap.
+	cloudClientProxyConfigMapKey                = "cloud-client-proxy"
+	cloudClientProxyConfigMapTrustedCABundleKey = "cloud-client-ca-bundle.crt"
+)
+
+func (r *ReconcileHiveConfig) deployJobProxyConfigMap(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig) (string, error) {
+	cm := &corev1.ConfigMap{}
+	cm.Name = jobProxyConfigMapName
+	cm.Namespace = getHiveNamespace(instance)
+	cm.Data = make(map[string]string)
+
+	if err := r.addProxyConfig(hLog, instance, cm, instance.Spec.JobProxy, jobProxyConfigMapNameKey, jobProxyConfigMapTrustedCABundleKey); err != nil {
+		return "", err
+	}
+	if err := r.addProxyConfig(hLog, instance, cm, instance.Spec.CloudClientProxy, cloudClientProxyConfigMapKey, cloudClientProxyConfigMapTrustedCABundleKey); err != nil {
+		return "", err
+	}
+
+	result, err := util.ApplyRuntimeObjectWithGC(h, cm, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error applying hive-job-proxy configmap")
+		return "", err
+	}
+	hLog.WithField("result", result).Info("hive-job-proxy configmap applied")
+
+	return computeConfigHash(cm), nil
+}
+
+// addProxyConfig adds the proxy configuration, and the bundle of the certificate authorities of the trusted CA
+// secrets, to the ConfigMap under the keys.
+func (r *ReconcileHiveConfig) addProxyConfig(hLog log.FieldLogger, instance *hivev1.HiveConfig, cm *corev1.ConfigMap, proxy *hivev1.JobProxyConfig, configKey, trustedCABundleKey string) error {
+	if proxy == nil {
+		return nil
+	}
+	data, err := json.Marshal(&hivev1.JobProxyConfig{
+		HTTPProxy:  proxy.HTTPProxy,
+		HTTPSProxy: proxy.HTTPSProxy,
+		NoProxy:    proxy.NoProxy,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal proxy")
+	}
+	cm.Data[configKey] = string(data)
+
+	trustedCABundle := &bytes.Buffer{}
+	for _, ref := range proxy.TrustedCASecretRefs {
+		caSecret, err := r.hiveSecretLister.Secrets(getHiveNamespace(instance)).Get(ref.Name)
+		if err != nil {
+			hLog.WithError(err).WithField("secret", ref.Name).Error("Cannot read proxy CA secret")
+			continue
+		}
+		crt, ok := caSecret.Data["ca.crt"]
+		if !ok {
+			hLog.WithField("secret", ref.Name).Warning("Secret does not contain expected key (ca.crt)")
+			continue
+		}
+		fmt.Fprintf(trustedCABundle, "%s\n", crt)
+	}
+	if trustedCABundle.Len() > 0 {
+		cm.Data[trustedCABundleKey] = trustedCABundle.String()
+	}
+	return nil
+}
+
+func addJobProxyConfigVolume(podSpec *corev1.PodSpec) {
+	optional := true
+	volume := corev1.Volume{}
+	volume.Name = jobProxyConfigMapName
+	volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: jobProxyConfigMapName,
+		},
+		Optional: &optional,
+	}
+	volumeMount := corev1.VolumeMount{
+		Name:      jobProxyConfigMapName,
+		MountPath: jobProxyConfigMapMountPath,
+	}
+	podSpec.Volumes = append(podSpec.Volumes, volume)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, volumeMount)
+	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env,
+		corev1.EnvVar{
+			Name:  constants.JobProxyFileEnvVar,
+			Value: fmt.Sprintf("%s/%s", jobProxyConfigMapMountPath, jobProxyConfigMapNameKey),
+		},
+		corev1.EnvVar{
+			Name:  constants.JobTrustedCABundleFileEnvVar,
+			Value: fmt.Sprintf("%s/%s", jobProxyConfigMapMountPath, jobProxyConfigMapTrustedCABundleKey),
+		},
+		corev1.EnvVar{
+			Name:  constants.CloudClientProxyFileEnvVar,
+			Value: fmt.Sprintf("%s/%s", jobProxyConfigMapMountPath, cloudClientProxyConfigMapKey),
+		},
+		corev1.EnvVar{
+			Name:  constants.CloudClientTrustedCABundleFileEnvVar,
+			Value: fmt.Sprintf("%s/%s", jobProxyConfigMapMountPath, cloudClientProxyConfigMapTrustedCABundleKey),
+		},
+	)
+}
@@ -0,0 +1,69 @@
+package hive
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/operator/util"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	namespaceQuotasConfigMapName      = "hive-namespace-quotas"
+	namespaceQuotasConfigMapNameKey   = "namespace-quotas"
+	namespaceQuotasConfigMapMountPath = "/data/namespace-quotas-config"
+)
+
+func (r *ReconcileHiveConfig) deployNamespaceQuotasConfigMap(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig) (string, error) {
+	cm := &corev1.ConfigMap{}
+	cm.Name = namespaceQuotasConfigMapName
+	cm.Namespace = getHiveNamespace(instance)
+	cm.Data = make(map[string]string)
+
+	if len(instance.Spec.NamespaceQuotas) > 0 {
+		data, err := json.Marshal(instance.Spec.NamespaceQuotas)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal namespace quotas")
+		}
+		cm.Data[namespaceQuotasConfigMapNameKey] = string(data)
+	}
+
+	result, err := util.ApplyRuntimeObjectWithGC(h, cm, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error applying hive-namespace-quotas configmap")
+		return "", err
+	}
+	hLog.WithField("result", result).Info("hive-namespace-quotas configmap applied")
+
+	return computeConfigHash(cm), nil
+}
+
+func addNamespaceQuotasConfigVolume(podSpec *corev1.PodSpec) {
+	optional := true
+	volume := corev1.Volume{}
+	volume.Name = namespaceQuotasConfigMapName
+	volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: namespaceQuotasConfigMapName,
+		},
+		Optional: &optional,
+	}
+	volumeMount := corev1.VolumeMount{
+		Name:      namespaceQuotasConfigMapName,
+		MountPath: namespaceQuotasConfigMapMountPath,
+	}
+	envVar := corev1.EnvVar{
+		Name:  constants.NamespaceQuotasFileEnvVar,
+		Value: fmt.Sprintf("%s/%s", namespaceQuotasConfigMapMountPath, namespaceQuotasConfigMapNameKey),
+	}
+	podSpec.Volumes = append(podSpec.Volumes, volume)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, volumeMount)
+	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, envVar)
+}
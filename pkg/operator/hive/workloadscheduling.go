@@ -0,0 +1,69 @@
+package hive
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/operator/util"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	workloadSchedulingConfigMapName      = "hive-workload-scheduling"
+	workloadSchedulingConfigMapNameKey   = "workload-scheduling"
+	workloadSchedulingConfigMapMountPath = "/data/workload-scheduling-config"
+)
+
+func (r *ReconcileHiveConfig) deployWorkloadSchedulingConfigMap(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig) (string, error) {
+	cm := &corev1.ConfigMap{}
+	cm.Name = workloadSchedulingConfigMapName
+	cm.Namespace = getHiveNamespace(instance)
+	cm.Data = make(map[string]string)
+
+	if instance.Spec.WorkloadScheduling != nil {
+		data, err := json.Marshal(instance.Spec.WorkloadScheduling)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal workload scheduling")
+		}
+		cm.Data[workloadSchedulingConfigMapNameKey] = string(data)
+	}
+
+	result, err := util.ApplyRuntimeObjectWithGC(h, cm, instance)
+	if err != nil {
+		hLog.WithError(err).Error("error applying hive-workload-scheduling configmap")
+		return "", err
+	}
+	hLog.WithField("result", result).Info("hive-workload-scheduling configmap applied")
+
+	return computeConfigHash(cm), nil
+}
+
+func addWorkloadSchedulingConfigVolume(podSpec *corev1.PodSpec) {
+	optional := true
+	volume := corev1.Volume{}
+	volume.Name = workloadSchedulingConfigMapName
+	volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: workloadSchedulingConfigMapName,
+		},
+		Optional: &optional,
+	}
+	volumeMount := corev1.VolumeMount{
+		Name:      workloadSchedulingConfigMapName,
+		MountPath: workloadSchedulingConfigMapMountPath,
+	}
+	envVar := corev1.EnvVar{
+		Name:  constants.WorkloadSchedulingFileEnvVar,
+		Value: fmt.Sprintf("%s/%s", workloadSchedulingConfigMapMountPath, workloadSchedulingConfigMapNameKey),
+	}
+	podSpec.Volumes = append(podSpec.Volumes, volume)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, volumeMount)
+	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, envVar)
+}
@@ -0,0 +1,315 @@
+// Package preflight checks that the cloud credentials provided to Hive have the permissions needed for the
+// operations Hive will perform with them, so that missing permissions can be reported up front rather than as an
+// AccessDenied error from the middle of an install.
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/hive/pkg/awsclient"
+)
+
+// Operation is an operation that Hive performs with a set of cloud credentials.
+type Operation string
+
+const (
+	// OperationProvision is the installation of a cluster.
+	OperationProvision Operation = "provision"
+	// OperationDNS is the management of a hosted zone for a cluster.
+	OperationDNS Operation = "dns"
+	// OperationHibernation is stopping and starting the machines of a cluster.
+	OperationHibernation Operation = "hibernation"
+	// OperationSharedVPC is authorizing the association of the private hosted zone of a cluster with a VPC shared
+	// from another account.
+	OperationSharedVPC Operation = "shared-vpc"
+	// OperationSharedVPCNetwork is the use of a VPC network shared from another AWS account or GCP project, in the
+	// account or project owning the network. On AWS, it is tagging the shared subnets and associating the VPC with the
+	// private hosted zone of the cluster. On GCP, it is using the shared subnets and managing the firewall rules of
+	// the cluster in the host project.
+	OperationSharedVPCNetwork Operation = "shared-vpc-network"
+)
+
+// simulateBatchSize is the number of actions simulated per SimulatePrincipalPolicy request.
+const simulateBatchSize = 100
+
+// awsPermissions are the AWS actions needed for each operation.
+var awsPermissions = map[Operation][]string{
+	// The base set of permissions that the installer requires to create a cluster.
+	OperationProvision: {
+		// EC2 related perms
+		"ec2:AllocateAddress",
+		"ec2:AssociateAddress",
+		"ec2:AuthorizeSecurityGroupEgress",
+		"ec2:AuthorizeSecurityGroupIngress",
+		"ec2:CopyImage",
+		"ec2:CreateNetworkInterface",
+		"ec2:AttachNetworkInterface",
+		"ec2:CreateSecurityGroup",
+		"ec2:CreateTags",
+		"ec2:CreateVolume",
+		"ec2:DeleteSecurityGroup",
+		"ec2:DeleteSnapshot",
+		"ec2:DeregisterImage",
+		"ec2:DescribeAccountAttributes",
+		"ec2:DescribeAddresses",
+		"ec2:DescribeAvailabilityZones",
+		"ec2:DescribeDhcpOptions",
+		"ec2:DescribeImages",
+		"ec2:DescribeInstanceAttribute",
+		"ec2:DescribeInstanceCreditSpecifications",
+		"ec2:DescribeInstances",
+		"ec2:DescribeInternetGateways",
+		"ec2:DescribeKeyPairs",
+		"ec2:DescribeNatGateways",
+		"ec2:DescribeNetworkAcls",
+		"ec2:DescribeNetworkInterfaces",
+		"ec2:DescribePrefixLists",
+		"ec2:DescribeRegions",
+		"ec2:DescribeRouteTables",
+		"ec2:DescribeSecurityGroups",
+		"ec2:DescribeSubnets",
+		"ec2:DescribeTags",
+		"ec2:DescribeVolumes",
+		"ec2:DescribeVpcAttribute",
+		"ec2:DescribeVpcClassicLink",
+		"ec2:DescribeVpcClassicLinkDnsSupport",
+		"ec2:DescribeVpcEndpoints",
+		"ec2:DescribeVpcs",
+		"ec2:GetEbsDefaultKmsKeyId",
+		"ec2:ModifyInstanceAttribute",
+		"ec2:ModifyNetworkInterfaceAttribute",
+		"ec2:ReleaseAddress",
+		"ec2:RevokeSecurityGroupEgress",
+		"ec2:RevokeSecurityGroupIngress",
+		"ec2:RunInstances",
+		"ec2:TerminateInstances",
+
+		// ELB related perms
+		"elasticloadbalancing:AddTags",
+		"elasticloadbalancing:ApplySecurityGroupsToLoadBalancer",
+		"elasticloadbalancing:AttachLoadBalancerToSubnets",
+		"elasticloadbalancing:ConfigureHealthCheck",
+		"elasticloadbalancing:CreateListener",
+		"elasticloadbalancing:CreateLoadBalancer",
+		"elasticloadbalancing:CreateLoadBalancerListeners",
+		"elasticloadbalancing:CreateTargetGroup",
+		"elasticloadbalancing:DeleteLoadBalancer",
+		"elasticloadbalancing:DeregisterInstancesFromLoadBalancer",
+		"elasticloadbalancing:DeregisterTargets",
+		"elasticloadbalancing:DescribeInstanceHealth",
+		"elasticloadbalancing:DescribeListeners",
+		"elasticloadbalancing:DescribeLoadBalancerAttributes",
+		"elasticloadbalancing:DescribeLoadBalancers",
+		"elasticloadbalancing:DescribeTags",
+		"elasticloadbalancing:DescribeTargetGroupAttributes",
+		"elasticloadbalancing:DescribeTargetHealth",
+		"elasticloadbalancing:ModifyLoadBalancerAttributes",
+		"elasticloadbalancing:ModifyTargetGroup",
+		"elasticloadbalancing:ModifyTargetGroupAttributes",
+		"elasticloadbalancing:RegisterInstancesWithLoadBalancer",
+		"elasticloadbalancing:RegisterTargets",
+		"elasticloadbalancing:SetLoadBalancerPoliciesOfListener",
+
+		// IAM related perms
+		"iam:AddRoleToInstanceProfile",
+		"iam:CreateInstanceProfile",
+		"iam:CreateRole",
+		"iam:DeleteInstanceProfile",
+		"iam:DeleteRole",
+		"iam:DeleteRolePolicy",
+		"iam:GetInstanceProfile",
+		"iam:GetRole",
+		"iam:GetRolePolicy",
+		"iam:GetUser",
+		"iam:ListInstanceProfilesForRole",
+		"iam:ListRoles",
+		"iam:ListUsers",
+		"iam:PassRole",
+		"iam:PutRolePolicy",
+		"iam:RemoveRoleFromInstanceProfile",
+		"iam:SimulatePrincipalPolicy",
+		"iam:TagRole",
+
+		// Route53 related perms
+		"route53:ChangeResourceRecordSets",
+		"route53:ChangeTagsForResource",
+		"route53:CreateHostedZone",
+		"route53:DeleteHostedZone",
+		"route53:GetChange",
+		"route53:GetHostedZone",
+		"route53:ListHostedZones",
+		"route53:ListHostedZonesByName",
+		"route53:ListResourceRecordSets",
+		"route53:ListTagsForResource",
+		"route53:UpdateHostedZoneComment",
+
+		// S3 related perms
+		"s3:CreateBucket",
+		"s3:DeleteBucket",
+		"s3:GetAccelerateConfiguration",
+		"s3:GetBucketAcl",
+		"s3:GetBucketCors",
+		"s3:GetBucketLocation",
+		"s3:GetBucketLogging",
+		"s3:GetBucketObjectLockConfiguration",
+		"s3:GetBucketReplication",
+		"s3:GetBucketRequestPayment",
+		"s3:GetBucketTagging",
+		"s3:GetBucketVersioning",
+		"s3:GetBucketWebsite",
+		"s3:GetEncryptionConfiguration",
+		"s3:GetLifecycleConfiguration",
+		"s3:GetReplicationConfiguration",
+		"s3:ListBucket",
+		"s3:PutBucketAcl",
+		"s3:PutBucketTagging",
+		"s3:PutEncryptionConfiguration",
+
+		// More S3 (would be nice to limit 'Resource' to just the bucket we actually interact with...)
+		"s3:DeleteObject",
+		"s3:GetObject",
+		"s3:GetObjectAcl",
+		"s3:GetObjectTagging",
+		"s3:GetObjectVersion",
+		"s3:PutObject",
+		"s3:PutObjectAcl",
+		"s3:PutObjectTagging",
+	},
+	OperationDNS: {
+		"route53:ChangeResourceRecordSets",
+		"route53:ChangeTagsForResource",
+		"route53:CreateHostedZone",
+		"route53:DeleteHostedZone",
+		"route53:GetHostedZone",
+		"route53:ListResourceRecordSets",
+		"route53:ListTagsForResource",
+		"tag:GetResources",
+	},
+	OperationHibernation: {
+		"ec2:DescribeInstances",
+		"ec2:StartInstances",
+		"ec2:StopInstances",
+	},
+	OperationSharedVPC: {
+		"route53:CreateVPCAssociationAuthorization",
+		"route53:DeleteVPCAssociationAuthorization",
+		"route53:GetHostedZone",
+		"route53:ListHostedZonesByName",
+	},
+	OperationSharedVPCNetwork: {
+		"ec2:CreateTags",
+		"ec2:DeleteTags",
+		"ec2:DescribeSubnets",
+		"route53:AssociateVPCWithHostedZone",
+		"route53:DisassociateVPCFromHostedZone",
+	},
+}
+
+// AWSPermissions returns the sorted, de-duplicated list of AWS actions needed for the given operations.
+func AWSPermissions(operations ...Operation) []string {
+	return permissionsFor(awsPermissions, operations)
+}
+
+// permissionsFor returns the sorted, de-duplicated list of permissions in the map for the given operations.
+func permissionsFor(permissions map[Operation][]string, operations []Operation) []string {
+	set := map[string]bool{}
+	for _, op := range operations {
+		for _, permission := range permissions[op] {
+			set[permission] = true
+		}
+	}
+	list := make([]string, 0, len(set))
+	for permission := range set {
+		list = append(list, permission)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// MissingAWSPermissions simulates the AWS actions needed for the given operations against the policies of the
+// principal that the client is authenticated as, and returns the actions that are not allowed. The credentials
+// themselves need the iam:SimulatePrincipalPolicy permission. Nothing is reported missing for the root user of an
+// account.
+func MissingAWSPermissions(ctx context.Context, client awsclient.Client, operations ...Operation) ([]string, error) {
+	identity, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get the caller identity")
+	}
+	principal, isRoot, err := principalARN(aws.StringValue(identity.Arn))
+	if err != nil {
+		return nil, err
+	}
+	if isRoot {
+		return nil, nil
+	}
+
+	var missing []string
+	actions := AWSPermissions(operations...)
+	for start := 0; start < len(actions); start += simulateBatchSize {
+		end := start + simulateBatchSize
+		if end > len(actions) {
+			end = len(actions)
+		}
+		input := &iam.SimulatePrincipalPolicyInput{
+			PolicySourceArn: aws.String(principal),
+			ActionNames:     aws.StringSlice(actions[start:end]),
+		}
+		for {
+			resp, err := client.SimulatePrincipalPolicy(ctx, input)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not simulate the principal policy")
+			}
+			for _, result := range resp.EvaluationResults {
+				if aws.StringValue(result.EvalDecision) != iam.PolicyEvaluationDecisionTypeAllowed {
+					missing = append(missing, aws.StringValue(result.EvalActionName))
+				}
+			}
+			if !aws.BoolValue(resp.IsTruncated) {
+				break
+			}
+			input.Marker = resp.Marker
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+// principalARN returns the ARN of the IAM user or role to simulate policies for from the ARN of the caller
+// identity, along with whether the caller is the root user of the account. The caller identity of an assumed role is
+// a session of the role, which cannot be simulated directly.
+func principalARN(callerARN string) (string, bool, error) {
+	parsed, err := arn.Parse(callerARN)
+	if err != nil {
+		return "", false, errors.Wrapf(err, "could not parse caller ARN %q", callerARN)
+	}
+	switch {
+	case parsed.Resource == "root":
+		return callerARN, true, nil
+	case parsed.Service == "sts" && strings.HasPrefix(parsed.Resource, "assumed-role/"):
+		// assumed-role/<role name>/<session name>
+		parts := strings.Split(parsed.Resource, "/")
+		if len(parts) < 3 {
+			return "", false, fmt.Errorf("unexpected assumed role ARN %q", callerARN)
+		}
+		parsed.Service = "iam"
+		parsed.Region = ""
+		parsed.Resource = "role/" + strings.Join(parts[1:len(parts)-1], "/")
+		return parsed.String(), false, nil
+	default:
+		return callerARN, false, nil
+	}
+}
+
+// MissingPermissionsMessage formats a list of missing permissions for use in a condition message.
+func MissingPermissionsMessage(missing []string) string {
+	return fmt.Sprintf("credentials are missing %d required permission(s): %s", len(missing), strings.Join(missing, ", "))
+}
@@ -0,0 +1,131 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/hive/pkg/awsclient/mock"
+)
+
+func TestPrincipalARN(t *testing.T) {
+	cases := []struct {
+		name              string
+		callerARN         string
+		expectedPrincipal string
+		expectedRoot      bool
+		expectError       bool
+	}{
+		{
+			name:              "user",
+			callerARN:         "arn:aws:iam::123456789012:user/hive",
+			expectedPrincipal: "arn:aws:iam::123456789012:user/hive",
+		},
+		{
+			name:              "assumed role",
+			callerARN:         "arn:aws:sts::123456789012:assumed-role/hive-role/session",
+			expectedPrincipal: "arn:aws:iam::123456789012:role/hive-role",
+		},
+		{
+			name:              "root",
+			callerARN:         "arn:aws:iam::123456789012:root",
+			expectedPrincipal: "arn:aws:iam::123456789012:root",
+			expectedRoot:      true,
+		},
+		{
+			name:        "invalid",
+			callerARN:   "not-an-arn",
+			expectError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			principal, isRoot, err := principalARN(tc.callerARN)
+			if tc.expectError {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectedPrincipal, principal, "unexpected principal")
+			assert.Equal(t, tc.expectedRoot, isRoot, "unexpected root")
+		})
+	}
+}
+
+func TestMissingAWSPermissions(t *testing.T) {
+	cases := []struct {
+		name            string
+		callerARN       string
+		denied          []string
+		simulateErr     error
+		expectedMissing []string
+		expectError     bool
+	}{
+		{
+			name:      "all allowed",
+			callerARN: "arn:aws:iam::123456789012:user/hive",
+		},
+		{
+			name:            "some denied",
+			callerARN:       "arn:aws:iam::123456789012:user/hive",
+			denied:          []string{"ec2:StopInstances", "ec2:StartInstances"},
+			expectedMissing: []string{"ec2:StartInstances", "ec2:StopInstances"},
+		},
+		{
+			name:      "root",
+			callerARN: "arn:aws:iam::123456789012:root",
+		},
+		{
+			name:        "simulate error",
+			callerARN:   "arn:aws:iam::123456789012:user/hive",
+			simulateErr: errors.New("AccessDenied"),
+			expectError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			client := mock.NewMockClient(mockCtrl)
+			client.EXPECT().GetCallerIdentity(gomock.Any(), gomock.Any()).
+				Return(&sts.GetCallerIdentityOutput{Arn: aws.String(tc.callerARN)}, nil)
+			if tc.callerARN != "arn:aws:iam::123456789012:root" {
+				client.EXPECT().SimulatePrincipalPolicy(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, input *iam.SimulatePrincipalPolicyInput) (*iam.SimulatePolicyResponse, error) {
+						if tc.simulateErr != nil {
+							return nil, tc.simulateErr
+						}
+						assert.Equal(t, tc.callerARN, aws.StringValue(input.PolicySourceArn), "unexpected policy source")
+						resp := &iam.SimulatePolicyResponse{}
+						for _, action := range aws.StringValueSlice(input.ActionNames) {
+							decision := iam.PolicyEvaluationDecisionTypeAllowed
+							for _, d := range tc.denied {
+								if d == action {
+									decision = iam.PolicyEvaluationDecisionTypeImplicitDeny
+								}
+							}
+							resp.EvaluationResults = append(resp.EvaluationResults, &iam.EvaluationResult{
+								EvalActionName: aws.String(action),
+								EvalDecision:   aws.String(decision),
+							})
+						}
+						return resp, nil
+					})
+			}
+			missing, err := MissingAWSPermissions(context.Background(), client, OperationHibernation)
+			if tc.expectError {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectedMissing, missing, "unexpected missing permissions")
+		})
+	}
+}
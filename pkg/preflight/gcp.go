@@ -0,0 +1,65 @@
+package preflight
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+// testIamPermissionsBatchSize is the maximum number of permissions tested per TestIamPermissions request.
+const testIamPermissionsBatchSize = 100
+
+// gcpPermissions are the GCP permissions needed for each operation. Only the operations which act in a project other
+// than the project of the cluster are checked on GCP, as the permissions of the cluster project are granted through
+// predefined roles.
+var gcpPermissions = map[Operation][]string{
+	OperationSharedVPCNetwork: {
+		"compute.firewalls.create",
+		"compute.firewalls.delete",
+		"compute.firewalls.get",
+		"compute.firewalls.list",
+		"compute.networks.get",
+		"compute.networks.updatePolicy",
+		"compute.subnetworks.get",
+		"compute.subnetworks.use",
+		"compute.subnetworks.useExternalIp",
+		"dns.networks.bindPrivateDNSZone",
+	},
+}
+
+// GCPPermissions returns the sorted, de-duplicated list of GCP permissions needed for the given operations.
+func GCPPermissions(operations ...Operation) []string {
+	return permissionsFor(gcpPermissions, operations)
+}
+
+// MissingGCPPermissions tests the GCP permissions needed for the given operations on the project, and returns the
+// permissions that the credentials of the client are not granted.
+func MissingGCPPermissions(ctx context.Context, client gcpclient.Client, project string, operations ...Operation) ([]string, error) {
+	permissions := GCPPermissions(operations...)
+	granted := map[string]bool{}
+	for start := 0; start < len(permissions); start += testIamPermissionsBatchSize {
+		end := start + testIamPermissionsBatchSize
+		if end > len(permissions) {
+			end = len(permissions)
+		}
+		resp, err := client.TestIamPermissions(ctx, project, permissions[start:end])
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not test the permissions on project %s", project)
+		}
+		for _, permission := range resp {
+			granted[permission] = true
+		}
+	}
+
+	var missing []string
+	for _, permission := range permissions {
+		if !granted[permission] {
+			missing = append(missing, permission)
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
@@ -0,0 +1,68 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gcpmock "github.com/openshift/hive/pkg/gcpclient/mock"
+)
+
+func TestMissingGCPPermissions(t *testing.T) {
+	cases := []struct {
+		name            string
+		denied          []string
+		testErr         error
+		expectedMissing []string
+		expectError     bool
+	}{
+		{
+			name: "all granted",
+		},
+		{
+			name:            "some denied",
+			denied:          []string{"compute.subnetworks.use", "compute.firewalls.create"},
+			expectedMissing: []string{"compute.firewalls.create", "compute.subnetworks.use"},
+		},
+		{
+			name:        "test error",
+			testErr:     errors.New("permission denied"),
+			expectError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			client := gcpmock.NewMockClient(mockCtrl)
+			client.EXPECT().TestIamPermissions(gomock.Any(), "host-project", GCPPermissions(OperationSharedVPCNetwork)).DoAndReturn(
+				func(_ context.Context, project string, permissions []string) ([]string, error) {
+					if tc.testErr != nil {
+						return nil, tc.testErr
+					}
+					var granted []string
+					for _, permission := range permissions {
+						isDenied := false
+						for _, d := range tc.denied {
+							isDenied = isDenied || d == permission
+						}
+						if !isDenied {
+							granted = append(granted, permission)
+						}
+					}
+					return granted, nil
+				})
+			missing, err := MissingGCPPermissions(context.Background(), client, "host-project", OperationSharedVPCNetwork)
+			if tc.expectError {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectedMissing, missing, "unexpected missing permissions")
+		})
+	}
+}
@@ -0,0 +1,385 @@
+package registryclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+//go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
+
+const (
+	defaultRegistry     = "docker.io"
+	defaultRegistryHost = "registry-1.docker.io"
+
+	mediaTypeManifestList  = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeManifest      = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeOCIIndex      = "application/vnd.oci.image.index.v1+json"
+	mediaTypeOCIManifest   = "application/vnd.oci.image.manifest.v1+json"
+	defaultRequestTimeout  = 30 * time.Second
+	maxManifestSizeInBytes = 4 * 1024 * 1024
+)
+
+var (
+	// ErrImageNotFound is returned when the image does not exist in the registry.
+	ErrImageNotFound = errors.New("image not found")
+	// ErrUnauthorized is returned when the credentials in the pull secret do not allow pulling the image.
+	ErrUnauthorized = errors.New("not authorized to pull image")
+)
+
+// Client is a client for inspecting images in container registries.
+type Client interface {
+	// ImageArchitectures returns the architectures supported by the image. It returns ErrImageNotFound if the
+	// image does not exist, and ErrUnauthorized if the image cannot be pulled with the credentials of the client.
+	ImageArchitectures(ctx context.Context, image string) ([]string, error)
+
+	// ImageDigest returns the digest of the manifest, or manifest list, of the image. It returns ErrImageNotFound if
+	// the image does not exist, and ErrUnauthorized if the image cannot be pulled with the credentials of the client.
+	ImageDigest(ctx context.Context, image string) (string, error)
+}
+
+type dockerConfigJSON struct {
+	Auths map[string]dockerAuth `json:"auths"`
+}
+
+type dockerAuth struct {
+	Auth string `json:"auth"`
+}
+
+type registryClient struct {
+	httpClient *http.Client
+	// credentials are the user and password of each registry keyed by the registry from the pull secret.
+	credentials map[string][2]string
+}
+
+// NewClient returns a Client that authenticates to registries with the credentials of the given pull secret
+// (the contents of a .dockerconfigjson). The pull secret may be empty to only pull public images.
+func NewClient(pullSecret string) (Client, error) {
+	c := &registryClient{
+		httpClient:  &http.Client{Timeout: defaultRequestTimeout},
+		credentials: map[string][2]string{},
+	}
+	if pullSecret == "" {
+		return c, nil
+	}
+	config := &dockerConfigJSON{}
+	if err := json.Unmarshal([]byte(pullSecret), config); err != nil {
+		return nil, errors.Wrap(err, "could not parse pull secret")
+	}
+	for registry, auth := range config.Auths {
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode auth for registry %s", registry)
+		}
+		parts := strings.SplitN(string(decoded), ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid auth for registry %s", registry)
+		}
+		c.credentials[normalizeRegistry(registry)] = [2]string{parts[0], parts[1]}
+	}
+	return c, nil
+}
+
+// imageReference is a parsed image pull spec.
+type imageReference struct {
+	registry   string
+	repository string
+	// reference is the tag or digest of the image.
+	reference string
+}
+
+func parseImageReference(image string) (*imageReference, error) {
+	ref := &imageReference{registry: defaultRegistry}
+	rest := image
+	if i := strings.Index(rest, "/"); i >= 0 {
+		first := rest[:i]
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			ref.registry = first
+			rest = rest[i+1:]
+		}
+	}
+	switch {
+	case strings.Contains(rest, "@"):
+		i := strings.Index(rest, "@")
+		ref.repository, ref.reference = rest[:i], rest[i+1:]
+	case strings.LastIndex(rest, ":") > strings.LastIndex(rest, "/"):
+		i := strings.LastIndex(rest, ":")
+		ref.repository, ref.reference = rest[:i], rest[i+1:]
+	default:
+		ref.repository, ref.reference = rest, "latest"
+	}
+	if ref.repository == "" || ref.reference == "" {
+		return nil, fmt.Errorf("invalid image reference %q", image)
+	}
+	if ref.registry == defaultRegistry && !strings.Contains(ref.repository, "/") {
+		ref.repository = "library/" + ref.repository
+	}
+	return ref, nil
+}
+
+// ImageWithDigest returns the pull spec of the image pinned to the given digest, replacing the tag or digest of the
+// image.
+func ImageWithDigest(image, digest string) (string, error) {
+	if _, err := parseImageReference(image); err != nil {
+		return "", err
+	}
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	} else if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+	return name + "@" + digest, nil
+}
+
+func normalizeRegistry(registry string) string {
+	registry = strings.TrimPrefix(strings.TrimPrefix(registry, "https://"), "http://")
+	registry = strings.TrimSuffix(strings.SplitN(registry, "/", 2)[0], "/")
+	if registry == "index.docker.io" || registry == defaultRegistryHost {
+		return defaultRegistry
+	}
+	return registry
+}
+
+func (r *imageReference) host() string {
+	if r.registry == defaultRegistry {
+		return defaultRegistryHost
+	}
+	return r.registry
+}
+
+type manifest struct {
+	MediaType string `json:"mediaType"`
+	Config    struct {
+		Digest string `json:"digest"`
+	} `json:"config"`
+	Manifests []struct {
+		Platform struct {
+			Architecture string `json:"architecture"`
+		} `json:"platform"`
+	} `json:"manifests"`
+}
+
+type imageConfig struct {
+	Architecture string `json:"architecture"`
+}
+
+func (c *registryClient) ImageArchitectures(ctx context.Context, image string) ([]string, error) {
+	ref, err := parseImageReference(image)
+	if err != nil {
+		return nil, err
+	}
+	session := &registrySession{client: c, ref: ref}
+
+	body, contentType, err := session.get(ctx, "manifests/"+ref.reference,
+		strings.Join([]string{mediaTypeManifestList, mediaTypeOCIIndex, mediaTypeManifest, mediaTypeOCIManifest}, ", "))
+	if err != nil {
+		return nil, err
+	}
+	m := &manifest{}
+	if err := json.Unmarshal(body, m); err != nil {
+		return nil, errors.Wrap(err, "could not parse image manifest")
+	}
+	if m.MediaType == "" {
+		m.MediaType = contentType
+	}
+
+	switch m.MediaType {
+	case mediaTypeManifestList, mediaTypeOCIIndex:
+		archs := sets.NewString()
+		for _, child := range m.Manifests {
+			if child.Platform.Architecture != "" {
+				archs.Insert(child.Platform.Architecture)
+			}
+		}
+		return archs.List(), nil
+	default:
+		if m.Config.Digest == "" {
+			return nil, fmt.Errorf("unsupported manifest type %q", m.MediaType)
+		}
+		body, _, err := session.get(ctx, "blobs/"+m.Config.Digest, "")
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get image config")
+		}
+		config := &imageConfig{}
+		if err := json.Unmarshal(body, config); err != nil {
+			return nil, errors.Wrap(err, "could not parse image config")
+		}
+		if config.Architecture == "" {
+			return nil, nil
+		}
+		return []string{config.Architecture}, nil
+	}
+}
+
+func (c *registryClient) ImageDigest(ctx context.Context, image string) (string, error) {
+	ref, err := parseImageReference(image)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(ref.reference, "sha256:") {
+		return ref.reference, nil
+	}
+	session := &registrySession{client: c, ref: ref}
+	body, _, err := session.get(ctx, "manifests/"+ref.reference,
+		strings.Join([]string{mediaTypeManifestList, mediaTypeOCIIndex, mediaTypeManifest, mediaTypeOCIManifest}, ", "))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(body)), nil
+}
+
+// registrySession issues requests for a repository, authenticating with the registry as needed.
+type registrySession struct {
+	client *registryClient
+	ref    *imageReference
+	// authorization is the Authorization header of requests once authenticated.
+	authorization string
+}
+
+func (s *registrySession) get(ctx context.Context, path, accept string) ([]byte, string, error) {
+	u := fmt.Sprintf("https://%s/v2/%s/%s", s.ref.host(), s.ref.repository, path)
+	resp, err := s.do(ctx, u, accept)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && s.authorization == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if err := s.authenticate(ctx, challenge); err != nil {
+			return nil, "", err
+		}
+		if resp, err = s.do(ctx, u, accept); err != nil {
+			return nil, "", err
+		}
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, "", ErrImageNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, "", ErrUnauthorized
+	default:
+		return nil, "", fmt.Errorf("unexpected response from registry %s: %s", s.ref.registry, resp.Status)
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxManifestSizeInBytes+1))
+	if err != nil {
+		return nil, "", errors.Wrap(err, "could not read response from registry")
+	}
+	if len(body) > maxManifestSizeInBytes {
+		return nil, "", fmt.Errorf("response from registry %s is too large", s.ref.registry)
+	}
+	return body, resp.Header.Get("Content-Type"), nil
+}
+
+func (s *registrySession) do(ctx context.Context, u, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if s.authorization != "" {
+		req.Header.Set("Authorization", s.authorization)
+	}
+	resp, err := s.client.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not reach registry %s", s.ref.registry)
+	}
+	return resp, nil
+}
+
+// authenticate responds to an authentication challenge of the registry with the credentials for the registry.
+func (s *registrySession) authenticate(ctx context.Context, challenge string) error {
+	creds, hasCreds := s.client.credentials[normalizeRegistry(s.ref.registry)]
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if !hasCreds {
+			return ErrUnauthorized
+		}
+		s.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(creds[0]+":"+creds[1]))
+		return nil
+	case "bearer":
+	default:
+		return ErrUnauthorized
+	}
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return fmt.Errorf("invalid authentication realm from registry %s", s.ref.registry)
+	}
+	q := realm.Query()
+	if service := params["service"]; service != "" {
+		q.Set("service", service)
+	}
+	q.Set("scope", fmt.Sprintf("repository:%s:pull", s.ref.repository))
+	realm.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	if hasCreds {
+		req.SetBasicAuth(creds[0], creds[1])
+	}
+	resp, err := s.client.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "could not authenticate with registry %s", s.ref.registry)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrUnauthorized
+	default:
+		return fmt.Errorf("unexpected response authenticating with registry %s: %s", s.ref.registry, resp.Status)
+	}
+	token := &struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(token); err != nil {
+		return errors.Wrap(err, "could not parse registry token")
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return ErrUnauthorized
+	}
+	s.authorization = "Bearer " + token.Token
+	return nil
+}
+
+// parseChallenge parses a WWW-Authenticate header such as
+// Bearer realm="https://auth.example.com/token",service="registry.example.com"
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+	parts := strings.SplitN(strings.TrimSpace(challenge), " ", 2)
+	if len(parts) < 2 {
+		return parts[0], params
+	}
+	for _, param := range strings.Split(parts[1], ",") {
+		kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		params[strings.ToLower(kv[0])] = strings.Trim(kv[1], `"`)
+	}
+	return parts[0], params
+}
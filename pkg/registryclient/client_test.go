@@ -0,0 +1,212 @@
+package registryclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImageReference(t *testing.T) {
+	cases := []struct {
+		image    string
+		expected imageReference
+	}{
+		{
+			image:    "quay.io/openshift-release-dev/ocp-release:4.15.3-x86_64",
+			expected: imageReference{registry: "quay.io", repository: "openshift-release-dev/ocp-release", reference: "4.15.3-x86_64"},
+		},
+		{
+			image:    "quay.io/openshift-release-dev/ocp-release@sha256:abc",
+			expected: imageReference{registry: "quay.io", repository: "openshift-release-dev/ocp-release", reference: "sha256:abc"},
+		},
+		{
+			image:    "registry.example.com:5000/ocp/release",
+			expected: imageReference{registry: "registry.example.com:5000", repository: "ocp/release", reference: "latest"},
+		},
+		{
+			image:    "busybox:1.0",
+			expected: imageReference{registry: "docker.io", repository: "library/busybox", reference: "1.0"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.image, func(t *testing.T) {
+			ref, err := parseImageReference(tc.image)
+			require.NoError(t, err, "unexpected error parsing image reference")
+			assert.Equal(t, tc.expected, *ref, "unexpected image reference")
+		})
+	}
+}
+
+func TestImageWithDigest(t *testing.T) {
+	cases := []struct {
+		image    string
+		expected string
+	}{
+		{
+			image:    "quay.io/openshift-release-dev/ocp-release:4.15.3-x86_64",
+			expected: "quay.io/openshift-release-dev/ocp-release@sha256:def",
+		},
+		{
+			image:    "quay.io/openshift-release-dev/ocp-release@sha256:abc",
+			expected: "quay.io/openshift-release-dev/ocp-release@sha256:def",
+		},
+		{
+			image:    "registry.example.com:5000/ocp/release",
+			expected: "registry.example.com:5000/ocp/release@sha256:def",
+		},
+		{
+			image:    "busybox:1.0",
+			expected: "busybox@sha256:def",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.image, func(t *testing.T) {
+			image, err := ImageWithDigest(tc.image, "sha256:def")
+			require.NoError(t, err, "unexpected error pinning image")
+			assert.Equal(t, tc.expected, image, "unexpected image")
+		})
+	}
+}
+
+func TestImageArchitectures(t *testing.T) {
+	const token = "test-token"
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			user, pass, ok := r.BasicAuth()
+			if !ok || user != "user" || pass != "pass" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprintf(w, `{"token":%q}`, token)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/ocp/release/manifests/single":
+			fmt.Fprintf(w, `{"mediaType":%q,"config":{"digest":"sha256:config"}}`, mediaTypeManifest)
+		case "/v2/ocp/release/blobs/sha256:config":
+			fmt.Fprint(w, `{"architecture":"amd64"}`)
+		case "/v2/ocp/release/manifests/multi":
+			w.Header().Set("Content-Type", mediaTypeManifestList)
+			fmt.Fprint(w, `{"manifests":[{"platform":{"architecture":"arm64"}},{"platform":{"architecture":"amd64"}}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	cases := []struct {
+		name          string
+		image         string
+		user          string
+		expectedArchs []string
+		expectedErr   error
+	}{
+		{
+			name:          "single architecture",
+			image:         host + "/ocp/release:single",
+			user:          "user",
+			expectedArchs: []string{"amd64"},
+		},
+		{
+			name:          "manifest list",
+			image:         host + "/ocp/release:multi",
+			user:          "user",
+			expectedArchs: []string{"amd64", "arm64"},
+		},
+		{
+			name:        "not found",
+			image:       host + "/ocp/release:missing",
+			user:        "user",
+			expectedErr: ErrImageNotFound,
+		},
+		{
+			name:        "bad credentials",
+			image:       host + "/ocp/release:single",
+			user:        "other",
+			expectedErr: ErrUnauthorized,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			auth := base64.StdEncoding.EncodeToString([]byte(tc.user + ":pass"))
+			c, err := NewClient(fmt.Sprintf(`{"auths":{%q:{"auth":%q}}}`, host, auth))
+			require.NoError(t, err, "unexpected error creating client")
+			c.(*registryClient).httpClient = server.Client()
+
+			archs, err := c.ImageArchitectures(context.TODO(), tc.image)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, errors.Cause(err), "unexpected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error getting architectures")
+			assert.Equal(t, tc.expectedArchs, archs, "unexpected architectures")
+		})
+	}
+}
+
+func TestImageDigest(t *testing.T) {
+	const manifest = `{"mediaType":"application/vnd.docker.distribution.manifest.v2+json"}`
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/ocp/release/manifests/4.15.3" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, manifest)
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	cases := []struct {
+		name           string
+		image          string
+		expectedDigest string
+		expectedErr    error
+	}{
+		{
+			name:           "tag",
+			image:          host + "/ocp/release:4.15.3",
+			expectedDigest: fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(manifest))),
+		},
+		{
+			name:           "digest",
+			image:          host + "/ocp/release@sha256:abc",
+			expectedDigest: "sha256:abc",
+		},
+		{
+			name:        "not found",
+			image:       host + "/ocp/release:missing",
+			expectedErr: ErrImageNotFound,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := NewClient("")
+			require.NoError(t, err, "unexpected error creating client")
+			c.(*registryClient).httpClient = server.Client()
+
+			digest, err := c.ImageDigest(context.TODO(), tc.image)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, errors.Cause(err), "unexpected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error getting digest")
+			assert.Equal(t, tc.expectedDigest, digest, "unexpected digest")
+		})
+	}
+}
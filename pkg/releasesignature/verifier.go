@@ -0,0 +1,189 @@
+package releasesignature
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
+)
+
+//go:generate mockgen -source=./verifier.go -destination=./mock/verifier_generated.go -package=mock
+
+const (
+	// maxSignaturesPerStore is the number of signatures read from a store for a release image before giving up.
+	maxSignaturesPerStore   = 16
+	maxSignatureSizeInBytes = 64 * 1024
+	defaultRequestTimeout   = 30 * time.Second
+
+	// atomicContainerSignatureType is the type of the simple signing payload of a container image signature.
+	atomicContainerSignatureType = "atomic container signature"
+)
+
+var (
+	// ErrSignatureNotFound is returned when none of the signature stores has a signature for the release image.
+	ErrSignatureNotFound = errors.New("no signature found for release image")
+	// ErrSignatureNotVerified is returned when no signature of the release image is signed by a trusted key for the
+	// digest of the release image.
+	ErrSignatureNotVerified = errors.New("no signature of release image could be verified")
+)
+
+// Verifier verifies the signatures of release images.
+type Verifier interface {
+	// Verify checks that a signature of the release image with the given digest, found in one of the signature
+	// stores, is signed by one of the trusted keys. It returns ErrSignatureNotFound if no store has a signature for
+	// the digest, and ErrSignatureNotVerified if none of the signatures found can be verified.
+	Verify(ctx context.Context, digest string) error
+}
+
+type verifier struct {
+	httpClient *http.Client
+	stores     []string
+	keyring    openpgp.EntityList
+}
+
+// NewVerifier returns a Verifier that reads signatures from the given signature stores and trusts the given
+// ASCII-armored GPG public keys.
+func NewVerifier(stores []string, publicKeys [][]byte) (Verifier, error) {
+	if len(stores) == 0 {
+		return nil, errors.New("no signature stores configured")
+	}
+	if len(publicKeys) == 0 {
+		return nil, errors.New("no public keys configured")
+	}
+	var keyring openpgp.EntityList
+	for i, key := range publicKeys {
+		entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(key))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read public key %d", i)
+		}
+		keyring = append(keyring, entities...)
+	}
+	return &verifier{
+		httpClient: &http.Client{Timeout: defaultRequestTimeout},
+		stores:     stores,
+		keyring:    keyring,
+	}, nil
+}
+
+// simpleSigning is the payload of a container image signature in the simple signing format.
+type simpleSigning struct {
+	Critical struct {
+		Type  string `json:"type"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+func (v *verifier) Verify(ctx context.Context, digest string) error {
+	algorithm, hash := splitDigest(digest)
+	if algorithm == "" {
+		return fmt.Errorf("invalid digest %q", digest)
+	}
+
+	found := false
+	for _, store := range v.stores {
+		for i := 1; i <= maxSignaturesPerStore; i++ {
+			u := fmt.Sprintf("%s/%s=%s/signature-%d", strings.TrimSuffix(store, "/"), algorithm, hash, i)
+			signature, err := v.fetch(ctx, u)
+			if err != nil {
+				return err
+			}
+			if signature == nil {
+				break
+			}
+			found = true
+			payload, err := v.verifySignature(signature)
+			if err != nil {
+				continue
+			}
+			if verifyPayload(payload, digest) == nil {
+				return nil
+			}
+		}
+	}
+	if !found {
+		return ErrSignatureNotFound
+	}
+	return ErrSignatureNotVerified
+}
+
+// fetch returns the signature at the URL, or nil if the store does not have it.
+func (v *verifier) fetch(ctx context.Context, u string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not create request for %s", u)
+	}
+	resp, err := v.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not fetch signature %s", u)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusForbidden:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unexpected status %s fetching signature %s", resp.Status, u)
+	}
+	signature, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxSignatureSizeInBytes))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read signature %s", u)
+	}
+	return signature, nil
+}
+
+// verifySignature verifies the signature with the trusted public keys, returning the signed payload.
+func (v *verifier) verifySignature(signature []byte) ([]byte, error) {
+	md, err := openpgp.ReadMessage(bytes.NewReader(signature), v.keyring, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read signature")
+	}
+	if !md.IsSigned || md.SignedBy == nil {
+		return nil, errors.New("signature is not signed by a trusted key")
+	}
+	// The signature is only checked once the payload has been read to the end.
+	payload, err := ioutil.ReadAll(io.LimitReader(md.UnverifiedBody, maxSignatureSizeInBytes))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read signature payload")
+	}
+	if md.SignatureError != nil {
+		return nil, errors.Wrap(md.SignatureError, "signature is not valid")
+	}
+	if md.Signature == nil {
+		return nil, errors.New("signature is not valid")
+	}
+	return payload, nil
+}
+
+// verifyPayload checks that the simple signing payload is a container image signature for the digest.
+func verifyPayload(payload []byte, digest string) error {
+	signing := &simpleSigning{}
+	if err := json.Unmarshal(payload, signing); err != nil {
+		return errors.Wrap(err, "could not parse signature payload")
+	}
+	if signing.Critical.Type != atomicContainerSignatureType {
+		return fmt.Errorf("unexpected signature type %q", signing.Critical.Type)
+	}
+	if signing.Critical.Image.DockerManifestDigest != digest {
+		return fmt.Errorf("signature is for digest %s", signing.Critical.Image.DockerManifestDigest)
+	}
+	return nil
+}
+
+// splitDigest splits a digest such as sha256:<hex> into its algorithm and hash.
+func splitDigest(digest string) (string, string) {
+	parts := strings.SplitN(digest, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", ""
+	}
+	return parts[0], parts[1]
+}
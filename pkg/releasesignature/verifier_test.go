@@ -0,0 +1,120 @@
+package releasesignature
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/openpgp/packet"
+)
+
+const testDigest = "sha256:0123456789abcdef"
+
+var testKeyConfig = &packet.Config{DefaultHash: crypto.SHA256}
+
+// testKey is a GPG key generated for a test.
+type testKey struct {
+	entity    *openpgp.Entity
+	publicKey []byte
+}
+
+func newTestKey(t *testing.T, name string) *testKey {
+	entity, err := openpgp.NewEntity(name, "", name+"@example.com", testKeyConfig)
+	require.NoError(t, err, "unexpected error generating key")
+	publicKey := &bytes.Buffer{}
+	w, err := armor.Encode(publicKey, openpgp.PublicKeyType, nil)
+	require.NoError(t, err, "unexpected error armoring public key")
+	require.NoError(t, entity.Serialize(w), "unexpected error exporting public key")
+	require.NoError(t, w.Close(), "unexpected error armoring public key")
+	return &testKey{entity: entity, publicKey: publicKey.Bytes()}
+}
+
+func (k *testKey) sign(t *testing.T, digest string) []byte {
+	payload := fmt.Sprintf(`{"critical":{"type":%q,"image":{"docker-manifest-digest":%q},"identity":{"docker-reference":"quay.io/openshift-release-dev/ocp-release"}},"optional":null}`,
+		atomicContainerSignatureType, digest)
+	signature := &bytes.Buffer{}
+	w, err := openpgp.Sign(signature, k.entity, nil, testKeyConfig)
+	require.NoError(t, err, "unexpected error signing")
+	_, err = w.Write([]byte(payload))
+	require.NoError(t, err, "unexpected error signing")
+	require.NoError(t, w.Close(), "unexpected error signing")
+	return signature.Bytes()
+}
+
+func TestVerify(t *testing.T) {
+	trusted := newTestKey(t, "trusted")
+	untrusted := newTestKey(t, "untrusted")
+
+	cases := []struct {
+		name        string
+		signatures  [][]byte
+		expectedErr error
+	}{
+		{
+			name:       "signed by trusted key",
+			signatures: [][]byte{trusted.sign(t, testDigest)},
+		},
+		{
+			name:       "second signature signed by trusted key",
+			signatures: [][]byte{untrusted.sign(t, testDigest), trusted.sign(t, testDigest)},
+		},
+		{
+			name:        "no signatures",
+			expectedErr: ErrSignatureNotFound,
+		},
+		{
+			name:        "signed by untrusted key",
+			signatures:  [][]byte{untrusted.sign(t, testDigest)},
+			expectedErr: ErrSignatureNotVerified,
+		},
+		{
+			name:        "signed for other digest",
+			signatures:  [][]byte{trusted.sign(t, "sha256:fedcba9876543210")},
+			expectedErr: ErrSignatureNotVerified,
+		},
+		{
+			name:        "not a signature",
+			signatures:  [][]byte{[]byte("garbage")},
+			expectedErr: ErrSignatureNotVerified,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			for i, signature := range tc.signatures {
+				signature := signature
+				mux.HandleFunc(fmt.Sprintf("/sha256=0123456789abcdef/signature-%d", i+1), func(w http.ResponseWriter, r *http.Request) {
+					w.Write(signature)
+				})
+			}
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			v, err := NewVerifier([]string{server.URL + "/"}, [][]byte{trusted.publicKey})
+			require.NoError(t, err, "unexpected error creating verifier")
+			err = v.Verify(context.TODO(), testDigest)
+			assert.Equal(t, tc.expectedErr, err, "unexpected verification result")
+		})
+	}
+}
+
+func TestNewVerifierInvalidKey(t *testing.T) {
+	_, err := NewVerifier([]string{"https://example.com/"}, [][]byte{[]byte("not a key")})
+	assert.Error(t, err, "expected error for invalid public key")
+}
+
+func TestSplitDigest(t *testing.T) {
+	algorithm, hash := splitDigest("sha256:abc")
+	assert.Equal(t, "sha256", algorithm, "unexpected algorithm")
+	assert.Equal(t, "abc", hash, "unexpected hash")
+	algorithm, _ = splitDigest("abc")
+	assert.Empty(t, algorithm, "expected invalid digest")
+}
@@ -8,6 +8,8 @@ import (
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	openshiftapiv1 "github.com/openshift/api/config/v1"
+
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 )
 
@@ -32,6 +34,7 @@ func (b *kubeconfigBuilder) Build() (client.Client, error) {
 	scheme := runtime.NewScheme()
 	corev1.SchemeBuilder.AddToScheme(scheme)
 	hivev1.SchemeBuilder.AddToScheme(scheme)
+	openshiftapiv1.Install(scheme)
 
 	return client.New(cfg, client.Options{
 		Scheme: scheme,
@@ -4,6 +4,8 @@ package remoteclient
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 
 	"github.com/pkg/errors"
@@ -28,6 +30,10 @@ import (
 	"github.com/openshift/hive/pkg/controller/utils"
 )
 
+// restConfigs caches the REST configs parsed from kubeconfig secrets, keyed by the hash of the kubeconfig, so that
+// the kubeconfig of a cluster is not parsed again for every client built.
+var restConfigs = utils.NewExpiringCache("remote_rest_config", 10*time.Minute, 5000)
+
 // Builder is used to build API clients to the remote cluster
 type Builder interface {
 	// Build will return a static controller-runtime client for the remote cluster.
@@ -289,6 +295,14 @@ func (b *builder) RESTConfig() (*rest.Config, error) {
 		}
 	}
 
+	if b.cd.Spec.ControlPlaneConfig.SSHBastion != nil {
+		dial, err := sshBastionDialer(b.c, b.cd)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Dial = dial
+	}
+
 	return cfg, nil
 }
 
@@ -309,10 +323,18 @@ func restConfigFromSecret(kubeconfigSecret *corev1.Secret) (*rest.Config, error)
 	if !ok {
 		return nil, errors.Errorf("kubeconfig secret does not contain %q data", constants.KubeconfigSecretKey)
 	}
-	config, err := clientcmd.Load(kubeconfigData)
+	hash := sha256.Sum256(kubeconfigData)
+	cfg, err := restConfigs.GetOrLoad(hex.EncodeToString(hash[:]), func() (interface{}, error) {
+		config, err := clientcmd.Load(kubeconfigData)
+		if err != nil {
+			return nil, err
+		}
+		kubeConfig := clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{})
+		return kubeConfig.ClientConfig()
+	})
 	if err != nil {
 		return nil, err
 	}
-	kubeConfig := clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{})
-	return kubeConfig.ClientConfig()
+	// The callers modify the config, so each gets its own copy of the cached config.
+	return rest.CopyConfig(cfg.(*rest.Config)), nil
 }
@@ -0,0 +1,271 @@
+package remoteclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	defaultSSHPort = "22"
+
+	sshBastionDialTimeout = 30 * time.Second
+)
+
+// bastions holds the connections to the SSH bastion hosts shared by all the remote clients in the process.
+var bastions = &bastionConnections{clients: map[string]*bastionClient{}}
+
+// bastionConnections caches one SSH connection per ClusterDeployment so that every request to the remote
+// cluster does not have to go through the SSH handshake.
+type bastionConnections struct {
+	mu      sync.Mutex
+	clients map[string]*bastionClient
+}
+
+type bastionClient struct {
+	// fingerprint identifies the bastion configuration the client was created with, so that a change of the
+	// bastion or a rotation of its key secret results in a new connection.
+	fingerprint string
+	// connected is closed once the connection to the bastion has been attempted, after which client or err is set.
+	connected chan struct{}
+	client    *ssh.Client
+	err       error
+}
+
+// sshBastionDialer returns a dial function that tunnels the connections through the SSH bastion configured on the
+// ClusterDeployment.
+func sshBastionDialer(c client.Client, cd *hivev1.ClusterDeployment) (func(ctx context.Context, network, address string) (net.Conn, error), error) {
+	bastion := cd.Spec.ControlPlaneConfig.SSHBastion
+	keySecret := &corev1.Secret{}
+	if err := c.Get(
+		context.Background(),
+		client.ObjectKey{Namespace: cd.Namespace, Name: bastion.KeySecretRef.Name},
+		keySecret,
+	); err != nil {
+		return nil, errors.Wrap(err, "could not get SSH bastion key secret")
+	}
+	config, err := sshClientConfig(bastion, keySecret)
+	if err != nil {
+		return nil, err
+	}
+
+	key := client.ObjectKey{Namespace: cd.Namespace, Name: cd.Name}.String()
+	bastionAddress := sshBastionAddress(bastion.Host)
+	fingerprint := fmt.Sprintf("%s@%s/%s/%t", bastion.User, bastionAddress, keySecret.ResourceVersion, bastion.InsecureIgnoreHostKey)
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		return bastions.dial(ctx, key, fingerprint, bastionAddress, config, network, address)
+	}, nil
+}
+
+// sshClientConfig builds the configuration for connecting to the bastion host using the key secret.
+func sshClientConfig(bastion *hivev1.SSHBastion, keySecret *corev1.Secret) (*ssh.ClientConfig, error) {
+	privateKey, ok := keySecret.Data[constants.SSHPrivateKeySecretKey]
+	if !ok {
+		return nil, errors.Errorf("SSH bastion key secret does not contain %q data", constants.SSHPrivateKeySecretKey)
+	}
+	signer, err := ssh.ParsePrivateKey(privateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse SSH bastion private key")
+	}
+
+	var hostKeyCallback ssh.HostKeyCallback
+	if knownHosts, ok := keySecret.Data[constants.SSHKnownHostsSecretKey]; ok {
+		hostKeyCallback, err = knownHostsCallback(knownHosts)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if !bastion.InsecureIgnoreHostKey {
+			return nil, errors.Errorf("SSH bastion key secret does not contain %q data", constants.SSHKnownHostsSecretKey)
+		}
+		hostKeyCallback = ssh.InsecureIgnoreHostKey()
+	}
+
+	return &ssh.ClientConfig{
+		User:            bastion.User,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         sshBastionDialTimeout,
+	}, nil
+}
+
+// knownHostsCallback returns a host key callback that accepts any of the keys in the known_hosts data. The host
+// patterns of the entries are not checked since the data is specific to the bastion host.
+func knownHostsCallback(knownHosts []byte) (ssh.HostKeyCallback, error) {
+	var keys []ssh.PublicKey
+	for rest := knownHosts; len(rest) > 0; {
+		var key ssh.PublicKey
+		var err error
+		_, _, key, _, rest, err = ssh.ParseKnownHosts(rest)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "could not parse SSH bastion known hosts")
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("SSH bastion known hosts does not contain any key")
+	}
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		for _, k := range keys {
+			if bytes.Equal(k.Marshal(), key.Marshal()) {
+				return nil
+			}
+		}
+		return errors.Errorf("SSH bastion host key for %s is not in the known hosts", hostname)
+	}, nil
+}
+
+// sshBastionAddress returns the address of the bastion host, adding the default SSH port when the host does not
+// specify one.
+func sshBastionAddress(host string) string {
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	return net.JoinHostPort(host, defaultSSHPort)
+}
+
+// CloseSSHBastionConnection closes the connection to the SSH bastion of the ClusterDeployment, if any. Connections
+// are otherwise kept for the lifetime of the process, so this must be called once the ClusterDeployment is gone.
+func CloseSSHBastionConnection(namespace, name string) {
+	bastions.mu.Lock()
+	defer bastions.mu.Unlock()
+	bastions.remove(client.ObjectKey{Namespace: namespace, Name: name}.String())
+}
+
+func (b *bastionConnections) dial(ctx context.Context, key, fingerprint, bastionAddress string, config *ssh.ClientConfig, network, address string) (net.Conn, error) {
+	sshClient, err := b.client(ctx, key, fingerprint, bastionAddress, config)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not connect to SSH bastion")
+	}
+
+	// Opening a channel through the bastion cannot be cancelled, so give up waiting for it when the context is done.
+	type dialResult struct {
+		conn net.Conn
+		err  error
+	}
+	results := make(chan dialResult, 1)
+	go func() {
+		conn, err := sshClient.Dial(network, address)
+		results <- dialResult{conn: conn, err: err}
+	}()
+	select {
+	case r := <-results:
+		if r.err != nil {
+			// The connection to the bastion may have been dropped, so discard it in order to reconnect on the next dial.
+			b.discard(key, sshClient)
+			return nil, errors.Wrapf(r.err, "could not connect to %s through SSH bastion", address)
+		}
+		return r.conn, nil
+	case <-ctx.Done():
+		go func() {
+			if r := <-results; r.conn != nil {
+				r.conn.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
+// client returns the connection to the bastion for the key, connecting to the bastion when there is none for the
+// fingerprint. Concurrent callers for the same key share a single attempt to connect, which is made without holding
+// the lock so that the clients of other ClusterDeployments are not blocked by a slow or unreachable bastion.
+func (b *bastionConnections) client(ctx context.Context, key, fingerprint, bastionAddress string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	b.mu.Lock()
+	existing, ok := b.clients[key]
+	if !ok || existing.fingerprint != fingerprint {
+		b.remove(key)
+		existing = &bastionClient{fingerprint: fingerprint, connected: make(chan struct{})}
+		b.clients[key] = existing
+		go b.connect(key, existing, bastionAddress, config)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-existing.connected:
+		return existing.client, existing.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// connect connects to the bastion for the entry. The attempt is not bound to the context of any of the callers
+// waiting for it, but it is limited to sshBastionDialTimeout, including the SSH handshake.
+func (b *bastionConnections) connect(key string, entry *bastionClient, bastionAddress string, config *ssh.ClientConfig) {
+	sshClient, err := dialSSHBastion(bastionAddress, config)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch {
+	case err != nil:
+		entry.err = err
+		if b.clients[key] == entry {
+			delete(b.clients, key)
+		}
+	case b.clients[key] != entry:
+		// The entry was replaced or removed while connecting.
+		sshClient.Close()
+		entry.err = errors.New("SSH bastion connection was closed")
+	default:
+		entry.client = sshClient
+	}
+	close(entry.connected)
+}
+
+func dialSSHBastion(bastionAddress string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	conn, err := net.DialTimeout("tcp", bastionAddress, sshBastionDialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	// ssh.Dial only limits the time to establish the TCP connection, so bound the handshake with a deadline.
+	if err := conn.SetDeadline(time.Now().Add(sshBastionDialTimeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, bastionAddress, config)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		sshConn.Close()
+		return nil, err
+	}
+	return ssh.NewClient(sshConn, chans, reqs), nil
+}
+
+func (b *bastionConnections) discard(key string, sshClient *ssh.Client) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if existing, ok := b.clients[key]; ok && existing.client == sshClient {
+		b.remove(key)
+	}
+}
+
+// remove removes the entry of the key, closing its connection. A connection still being established is closed by
+// connect once it is. The lock must be held.
+func (b *bastionConnections) remove(key string) {
+	existing, ok := b.clients[key]
+	if !ok {
+		return
+	}
+	delete(b.clients, key)
+	if existing.client != nil {
+		existing.client.Close()
+	}
+}
@@ -0,0 +1,333 @@
+package remoteclient
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const testBastionKeySecretName = "test-bastion-key"
+
+func Test_sshClientConfig(t *testing.T) {
+	privateKey, _ := testSSHKey(t)
+	_, hostKey := testSSHKey(t)
+	cases := []struct {
+		name        string
+		data        map[string][]byte
+		insecure    bool
+		expectedErr string
+	}{
+		{
+			name: "private key and known hosts",
+			data: map[string][]byte{
+				constants.SSHPrivateKeySecretKey: privateKey,
+				constants.SSHKnownHostsSecretKey: knownHostsLine(hostKey),
+			},
+		},
+		{
+			name: "no private key",
+			data: map[string][]byte{
+				constants.SSHKnownHostsSecretKey: knownHostsLine(hostKey),
+			},
+			expectedErr: `SSH bastion key secret does not contain "ssh-privatekey" data`,
+		},
+		{
+			name: "invalid private key",
+			data: map[string][]byte{
+				constants.SSHPrivateKeySecretKey: []byte("not a key"),
+				constants.SSHKnownHostsSecretKey: knownHostsLine(hostKey),
+			},
+			expectedErr: "could not parse SSH bastion private key: ssh: no key found",
+		},
+		{
+			name: "no known hosts",
+			data: map[string][]byte{
+				constants.SSHPrivateKeySecretKey: privateKey,
+			},
+			expectedErr: `SSH bastion key secret does not contain "ssh-knownhosts" data`,
+		},
+		{
+			name: "no known hosts, insecure",
+			data: map[string][]byte{
+				constants.SSHPrivateKeySecretKey: privateKey,
+			},
+			insecure: true,
+		},
+		{
+			name: "empty known hosts",
+			data: map[string][]byte{
+				constants.SSHPrivateKeySecretKey: privateKey,
+				constants.SSHKnownHostsSecretKey: []byte("# no keys\n"),
+			},
+			expectedErr: "SSH bastion known hosts does not contain any key",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			bastion := &hivev1.SSHBastion{
+				Host:                  "bastion.example.com",
+				User:                  "core",
+				InsecureIgnoreHostKey: tc.insecure,
+			}
+			config, err := sshClientConfig(bastion, &corev1.Secret{Data: tc.data})
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err, "unexpected error building SSH client config")
+			assert.Equal(t, "core", config.User, "unexpected user")
+		})
+	}
+}
+
+func Test_knownHostsCallback(t *testing.T) {
+	_, hostKey := testSSHKey(t)
+	_, otherKey := testSSHKey(t)
+	callback, err := knownHostsCallback(knownHostsLine(hostKey))
+	require.NoError(t, err, "unexpected error parsing known hosts")
+	assert.NoError(t, callback("bastion:22", nil, hostKey), "expected known host key to be accepted")
+	assert.Error(t, callback("bastion:22", nil, otherKey), "expected unknown host key to be rejected")
+}
+
+func Test_sshBastionAddress(t *testing.T) {
+	assert.Equal(t, "bastion.example.com:22", sshBastionAddress("bastion.example.com"))
+	assert.Equal(t, "bastion.example.com:2222", sshBastionAddress("bastion.example.com:2222"))
+	assert.Equal(t, "[fd00::1]:22", sshBastionAddress("fd00::1"))
+}
+
+func Test_builder_RESTConfig_SSHBastion(t *testing.T) {
+	target := startEchoServer(t)
+	clientKey, clientPublicKey := testSSHKey(t)
+	bastionAddress, bastionHostKey := startSSHBastion(t, clientPublicKey)
+
+	cd := testClusterDeployment()
+	cd.Spec.ControlPlaneConfig.SSHBastion = &hivev1.SSHBastion{
+		Host:         bastionAddress,
+		User:         "core",
+		KeySecretRef: corev1.LocalObjectReference{Name: testBastionKeySecretName},
+	}
+	keySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testBastionKeySecretName,
+		},
+		Data: map[string][]byte{
+			constants.SSHPrivateKeySecretKey: clientKey,
+			constants.SSHKnownHostsSecretKey: knownHostsLine(bastionHostKey),
+		},
+	}
+	c := fakeClient(cd, testKubeconfigSecret(t), keySecret)
+
+	cfg, err := NewBuilder(c, cd, testControllerName).RESTConfig()
+	require.NoError(t, err, "unexpected error getting REST config")
+	require.NotNil(t, cfg.Dial, "expected dial function to be set")
+
+	conn, err := cfg.Dial(context.Background(), "tcp", target)
+	require.NoError(t, err, "unexpected error dialing through the bastion")
+	defer conn.Close()
+	_, err = conn.Write([]byte("ping"))
+	require.NoError(t, err, "unexpected error writing through the bastion")
+	reply := make([]byte, 4)
+	_, err = io.ReadFull(conn, reply)
+	require.NoError(t, err, "unexpected error reading through the bastion")
+	assert.Equal(t, "ping", string(reply), "unexpected reply through the bastion")
+
+	CloseSSHBastionConnection(cd.Namespace, cd.Name)
+	bastions.mu.Lock()
+	defer bastions.mu.Unlock()
+	assert.NotContains(t, bastions.clients, testNamespace+"/"+cd.Name, "expected the connection to be evicted")
+}
+
+func Test_bastionConnections_reuseAndRemove(t *testing.T) {
+	clientKey, clientPublicKey := testSSHKey(t)
+	bastionAddress, bastionHostKey := startSSHBastion(t, clientPublicKey)
+	config, err := sshClientConfig(&hivev1.SSHBastion{User: "core"}, &corev1.Secret{
+		Data: map[string][]byte{
+			constants.SSHPrivateKeySecretKey: clientKey,
+			constants.SSHKnownHostsSecretKey: knownHostsLine(bastionHostKey),
+		},
+	})
+	require.NoError(t, err, "unexpected error building client config")
+
+	b := &bastionConnections{clients: map[string]*bastionClient{}}
+	sshClient, err := b.client(context.Background(), "test-namespace/test-cd", "fingerprint", bastionAddress, config)
+	require.NoError(t, err, "unexpected error connecting to the bastion")
+	again, err := b.client(context.Background(), "test-namespace/test-cd", "fingerprint", bastionAddress, config)
+	require.NoError(t, err, "unexpected error connecting to the bastion")
+	assert.Same(t, sshClient, again, "expected the connection to be reused")
+
+	b.mu.Lock()
+	b.remove("test-namespace/test-cd")
+	b.mu.Unlock()
+	assert.Empty(t, b.clients, "expected the connection to be evicted")
+	assert.Error(t, sshClient.Wait(), "expected the connection to be closed")
+}
+
+func Test_bastionConnections_unresponsiveBastion(t *testing.T) {
+	// The bastion accepts connections but never completes the SSH handshake.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "unexpected error listening")
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { conn.Close() })
+		}
+	}()
+	clientKey, clientPublicKey := testSSHKey(t)
+	bastionAddress, bastionHostKey := startSSHBastion(t, clientPublicKey)
+	config, err := sshClientConfig(&hivev1.SSHBastion{User: "core"}, &corev1.Secret{
+		Data: map[string][]byte{
+			constants.SSHPrivateKeySecretKey: clientKey,
+			constants.SSHKnownHostsSecretKey: knownHostsLine(bastionHostKey),
+		},
+	})
+	require.NoError(t, err, "unexpected error building client config")
+
+	b := &bastionConnections{clients: map[string]*bastionClient{}}
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err = b.client(ctx, "test-namespace/unresponsive", "fingerprint", l.Addr().String(), config)
+	assert.Equal(t, context.DeadlineExceeded, err, "expected the wait for the connection to end with the context")
+
+	// The bastions of other clusters are not blocked by the pending connection.
+	_, err = b.client(context.Background(), "test-namespace/responsive", "fingerprint", bastionAddress, config)
+	assert.NoError(t, err, "unexpected error connecting to the bastion of another cluster")
+}
+
+func Test_builder_RESTConfig_SSHBastionMissingSecret(t *testing.T) {
+	cd := testClusterDeployment()
+	cd.Spec.ControlPlaneConfig.SSHBastion = &hivev1.SSHBastion{
+		Host:         "bastion.example.com",
+		User:         "core",
+		KeySecretRef: corev1.LocalObjectReference{Name: testBastionKeySecretName},
+	}
+	c := fakeClient(cd, testKubeconfigSecret(t))
+	_, err := NewBuilder(c, cd, testControllerName).RESTConfig()
+	assert.Error(t, err, "expected error when the bastion key secret is missing")
+}
+
+func testSSHKey(t *testing.T) ([]byte, ssh.PublicKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "unexpected error generating key")
+	publicKey, err := ssh.NewPublicKey(&key.PublicKey)
+	require.NoError(t, err, "unexpected error building public key")
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return privateKey, publicKey
+}
+
+func knownHostsLine(key ssh.PublicKey) []byte {
+	return append([]byte("bastion.example.com "), ssh.MarshalAuthorizedKey(key)...)
+}
+
+// startEchoServer starts a TCP server echoing what it receives and returns its address.
+func startEchoServer(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "unexpected error listening")
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+	return l.Addr().String()
+}
+
+// startSSHBastion starts a minimal SSH server that authorizes the given key and forwards direct-tcpip channels.
+// It returns the address and the host key of the server.
+func startSSHBastion(t *testing.T, authorizedKey ssh.PublicKey) (string, ssh.PublicKey) {
+	hostKey, hostPublicKey := testSSHKey(t)
+	hostSigner, err := ssh.ParsePrivateKey(hostKey)
+	require.NoError(t, err, "unexpected error parsing host key")
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(authorizedKey.Marshal()) {
+				return nil, io.EOF
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(hostSigner)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "unexpected error listening")
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serveSSHConn(conn, config)
+		}
+	}()
+	return l.Addr().String(), hostPublicKey
+}
+
+func serveSSHConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "direct-tcpip" {
+			newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+		var payload struct {
+			Host       string
+			Port       uint32
+			OriginHost string
+			OriginPort uint32
+		}
+		if err := ssh.Unmarshal(newChannel.ExtraData(), &payload); err != nil {
+			newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		target, err := net.Dial("tcp", net.JoinHostPort(payload.Host, fmt.Sprint(payload.Port)))
+		if err != nil {
+			newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		channel, channelReqs, err := newChannel.Accept()
+		if err != nil {
+			target.Close()
+			continue
+		}
+		go ssh.DiscardRequests(channelReqs)
+		go func() {
+			defer channel.Close()
+			defer target.Close()
+			go io.Copy(target, channel)
+			io.Copy(channel, target)
+		}()
+	}
+}
@@ -0,0 +1,122 @@
+package resource
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/hive/pkg/tracing"
+)
+
+// NewTracedHelper returns a helper whose operations are traced as part of the span of the context. The helper is
+// returned as is when the context has no sampled span.
+func NewTracedHelper(ctx context.Context, h Helper) Helper {
+	if !trace.SpanContextFromContext(ctx).IsSampled() {
+		return h
+	}
+	return &tracedHelper{Helper: h, ctx: ctx}
+}
+
+type tracedHelper struct {
+	Helper
+	ctx context.Context
+}
+
+// startSpan starts the span of an operation on the object with the given kind, namespace and name.
+func (h *tracedHelper) startSpan(operation, apiVersion, kind, namespace, name string) trace.Span {
+	_, span := tracing.Tracer().Start(h.ctx, "resource."+operation, trace.WithAttributes(
+		attribute.String("apiVersion", apiVersion),
+		attribute.String("kind", kind),
+		attribute.String("namespace", namespace),
+		attribute.String("name", name),
+	))
+	return span
+}
+
+// startObjectSpan starts the span of an operation on the serialized object.
+func (h *tracedHelper) startObjectSpan(operation string, obj []byte) trace.Span {
+	meta := &metav1.PartialObjectMetadata{}
+	// The object is still traced if its metadata cannot be read, the operation will fail on it anyway.
+	yaml.Unmarshal(obj, meta)
+	return h.startSpan(operation, meta.APIVersion, meta.Kind, meta.Namespace, meta.Name)
+}
+
+// startRuntimeObjectSpan starts the span of an operation on the object.
+func (h *tracedHelper) startRuntimeObjectSpan(operation string, obj runtime.Object) trace.Span {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	namespace, name := "", ""
+	if accessor, ok := obj.(metav1.Object); ok {
+		namespace, name = accessor.GetNamespace(), accessor.GetName()
+	}
+	return h.startSpan(operation, gvk.GroupVersion().String(), gvk.Kind, namespace, name)
+}
+
+// endSpan ends the span of an operation with its error, if any.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (h *tracedHelper) Apply(obj []byte) (ApplyResult, error) {
+	span := h.startObjectSpan("Apply", obj)
+	result, err := h.Helper.Apply(obj)
+	endSpan(span, err)
+	return result, err
+}
+
+func (h *tracedHelper) ApplyRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (ApplyResult, error) {
+	span := h.startRuntimeObjectSpan("Apply", obj)
+	result, err := h.Helper.ApplyRuntimeObject(obj, scheme)
+	endSpan(span, err)
+	return result, err
+}
+
+func (h *tracedHelper) CreateOrUpdate(obj []byte) (ApplyResult, error) {
+	span := h.startObjectSpan("CreateOrUpdate", obj)
+	result, err := h.Helper.CreateOrUpdate(obj)
+	endSpan(span, err)
+	return result, err
+}
+
+func (h *tracedHelper) CreateOrUpdateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (ApplyResult, error) {
+	span := h.startRuntimeObjectSpan("CreateOrUpdate", obj)
+	result, err := h.Helper.CreateOrUpdateRuntimeObject(obj, scheme)
+	endSpan(span, err)
+	return result, err
+}
+
+func (h *tracedHelper) Create(obj []byte) (ApplyResult, error) {
+	span := h.startObjectSpan("Create", obj)
+	result, err := h.Helper.Create(obj)
+	endSpan(span, err)
+	return result, err
+}
+
+func (h *tracedHelper) CreateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (ApplyResult, error) {
+	span := h.startRuntimeObjectSpan("Create", obj)
+	result, err := h.Helper.CreateRuntimeObject(obj, scheme)
+	endSpan(span, err)
+	return result, err
+}
+
+func (h *tracedHelper) Patch(name types.NamespacedName, kind, apiVersion string, patch []byte, patchType string) error {
+	span := h.startSpan("Patch", apiVersion, kind, name.Namespace, name.Name)
+	err := h.Helper.Patch(name, kind, apiVersion, patch, patchType)
+	endSpan(span, err)
+	return err
+}
+
+func (h *tracedHelper) Delete(apiVersion, kind, namespace, name string) error {
+	span := h.startSpan("Delete", apiVersion, kind, namespace, name)
+	err := h.Helper.Delete(apiVersion, kind, namespace, name)
+	endSpan(span, err)
+	return err
+}
@@ -111,3 +111,10 @@ func WithLifetime(lifetime time.Duration) Option {
 		clusterClaim.Spec.Lifetime = &metav1.Duration{Duration: lifetime}
 	}
 }
+
+// WithPowerState sets the power state requested by the ClusterClaim
+func WithPowerState(powerState hivev1.ClusterPowerState) Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		clusterClaim.Spec.PowerState = powerState
+	}
+}
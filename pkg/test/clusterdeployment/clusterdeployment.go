@@ -8,6 +8,7 @@ import (
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/test/generic"
 )
@@ -129,6 +130,12 @@ func Installed() Option {
 	}
 }
 
+func Paused() Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.Paused = true
+	}
+}
+
 func InstalledTimestamp(instTime time.Time) Option {
 	return func(clusterDeployment *hivev1.ClusterDeployment) {
 		clusterDeployment.Spec.Installed = true
@@ -164,3 +171,10 @@ func WithAWSPlatform(platform *hivev1aws.Platform) Option {
 		clusterDeployment.Spec.Platform.AWS = platform
 	}
 }
+
+// WithGCPPlatform sets the specified gcp platform on the supplied object.
+func WithGCPPlatform(platform *hivev1gcp.Platform) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.Platform.GCP = platform
+	}
+}
@@ -146,6 +146,13 @@ func WithMaximumClaimLifetime(d time.Duration) Option {
 	}
 }
 
+// WithStaleClusterPolicy sets the policy for stale unclaimed clusters of the ClusterPool
+func WithStaleClusterPolicy(policy hivev1.StaleClusterPolicy) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.StaleClusterPolicy = policy
+	}
+}
+
 // WithCondition adds the specified condition to the ClusterPool
 func WithCondition(cond hivev1.ClusterPoolCondition) Option {
 	return func(clusterPool *hivev1.ClusterPool) {
@@ -87,3 +87,23 @@ func WithClusterDeploymentSelector(key, value string) Option {
 		}
 	}
 }
+
+func WithNamespaceSelector(key, value string) Option {
+	return func(clusterRelocate *hivev1.ClusterRelocate) {
+		clusterRelocate.Spec.NamespaceSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{key: value},
+		}
+	}
+}
+
+func WithBatching(batching *hivev1.ClusterRelocateBatching) Option {
+	return func(clusterRelocate *hivev1.ClusterRelocate) {
+		clusterRelocate.Spec.Batching = batching
+	}
+}
+
+func WithStatus(status hivev1.ClusterRelocateStatus) Option {
+	return func(clusterRelocate *hivev1.ClusterRelocate) {
+		clusterRelocate.Status = status
+	}
+}
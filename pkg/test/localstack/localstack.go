@@ -0,0 +1,266 @@
+// Package localstack provides a harness to run integration tests of the AWS code of Hive against LocalStack, or
+// another AWS emulator like moto, exercising the real AWS SDK request and response handling for pagination,
+// throttling and error paths.
+//
+// The tests using the harness are built with the localstack build tag and are skipped unless the
+// LOCALSTACK_ENDPOINT environment variable is set. Run them with make test-localstack.
+package localstack
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/hive/pkg/awsclient"
+)
+
+const (
+	// EndpointEnvVar is the environment variable with the URL of the LocalStack endpoint, for example
+	// http://localhost:4566.
+	EndpointEnvVar = "LOCALSTACK_ENDPOINT"
+
+	// Region is the region used for the tests.
+	Region = "us-east-1"
+
+	// Namespace is the namespace of the credentials secret in the kube client of the harness.
+	Namespace = "localstack"
+	// CredentialsSecretName is the name of the credentials secret in the kube client of the harness.
+	CredentialsSecretName = "localstack-creds"
+
+	// LocalStack accepts any credentials.
+	accessKeyID     = "test"
+	secretAccessKey = "test"
+
+	// fallbackImageID is used to run instances when the emulator does not list any image.
+	fallbackImageID = "ami-12345678"
+)
+
+// Harness runs tests against a LocalStack endpoint.
+type Harness struct {
+	// Endpoint is the URL of the LocalStack endpoint.
+	Endpoint string
+
+	// KubeClient is a fake kube client containing the credentials secret for LocalStack.
+	KubeClient client.Client
+
+	// Session is an AWS session for LocalStack, used to set up the resources of the tests with the AWS SDK
+	// directly.
+	Session *session.Session
+}
+
+// New returns a harness for the LocalStack endpoint in LOCALSTACK_ENDPOINT, skipping the test when the variable is
+// not set.
+func New(t *testing.T) *Harness {
+	endpoint := os.Getenv(EndpointEnvVar)
+	if endpoint == "" {
+		t.Skipf("%s is not set, skipping LocalStack test", EndpointEnvVar)
+	}
+	sess, err := session.NewSession(&aws.Config{
+		Region:           aws.String(Region),
+		Endpoint:         aws.String(endpoint),
+		Credentials:      credentials.NewStaticCredentials(accessKeyID, secretAccessKey, ""),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	require.NoError(t, err, "unexpected error creating LocalStack session")
+	return &Harness{
+		Endpoint:   endpoint,
+		KubeClient: fake.NewFakeClient(CredentialsSecret(Namespace, CredentialsSecretName)),
+		Session:    sess,
+	}
+}
+
+// CredentialsSecret returns a secret with credentials accepted by LocalStack.
+func CredentialsSecret(namespace, name string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Data: map[string][]byte{
+			"aws_access_key_id":     []byte(accessKeyID),
+			"aws_secret_access_key": []byte(secretAccessKey),
+		},
+	}
+}
+
+// Options returns the options of an AWS client for LocalStack using the credentials secret of the harness.
+func (h *Harness) Options() awsclient.Options {
+	return awsclient.Options{
+		Region: Region,
+		CredentialsSource: awsclient.CredentialsSource{
+			Secret: &awsclient.SecretCredentialsSource{
+				Namespace: Namespace,
+				Ref:       &corev1.LocalObjectReference{Name: CredentialsSecretName},
+			},
+		},
+		Endpoint: h.Endpoint,
+	}
+}
+
+// Client returns an AWS client for LocalStack.
+func (h *Harness) Client(t *testing.T) awsclient.Client {
+	c, err := awsclient.New(h.KubeClient, h.Options())
+	require.NoError(t, err, "unexpected error creating AWS client")
+	return c
+}
+
+// ClientBuilder returns a function building AWS clients like awsclient.New, with the endpoint of the harness. The
+// credentials source of the options must refer to a secret with credentials accepted by LocalStack.
+func (h *Harness) ClientBuilder() func(client.Client, awsclient.Options) (awsclient.Client, error) {
+	return func(c client.Client, options awsclient.Options) (awsclient.Client, error) {
+		options.Endpoint = h.Endpoint
+		return awsclient.New(c, options)
+	}
+}
+
+// ThrottlingEndpoint starts a proxy in front of LocalStack that rejects the first failures requests with the
+// throttling error of the AWS service, and returns the URL of the proxy.
+func (h *Harness) ThrottlingEndpoint(t *testing.T, failures int32) string {
+	target, err := url.Parse(h.Endpoint)
+	require.NoError(t, err, "unexpected error parsing LocalStack endpoint")
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) > failures {
+			proxy.ServeHTTP(w, r)
+			return
+		}
+		writeThrottlingError(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// writeThrottlingError writes the throttling error of the protocol of the request. Route53 uses the REST-XML
+// protocol while EC2 uses the EC2 query protocol.
+func writeThrottlingError(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/xml")
+	if strings.HasPrefix(r.URL.Path, "/2013-04-01/") {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `<ErrorResponse><Error><Type>Sender</Type><Code>Throttling</Code><Message>Rate exceeded</Message></Error><RequestId>localstack-throttled</RequestId></ErrorResponse>`)
+		return
+	}
+	w.WriteHeader(http.StatusServiceUnavailable)
+	fmt.Fprint(w, `<Response><Errors><Error><Code>RequestLimitExceeded</Code><Message>Request limit exceeded.</Message></Error></Errors><RequestID>localstack-throttled</RequestID></Response>`)
+}
+
+// UniqueName returns a name with a random suffix, since the resources of LocalStack outlive the tests.
+func UniqueName(prefix string) string {
+	return fmt.Sprintf("%s-%s", prefix, utilrand.String(6))
+}
+
+// CreateHostedZone creates a public hosted zone that is deleted along with its record sets at the end of the test,
+// and returns its ID.
+func (h *Harness) CreateHostedZone(t *testing.T, zone string) string {
+	r53 := route53.New(h.Session)
+	out, err := r53.CreateHostedZone(&route53.CreateHostedZoneInput{
+		Name:            aws.String(zone),
+		CallerReference: aws.String(UniqueName("hive-localstack")),
+	})
+	require.NoError(t, err, "unexpected error creating hosted zone")
+	id := aws.StringValue(out.HostedZone.Id)
+	t.Cleanup(func() { h.deleteHostedZone(t, id) })
+	return id
+}
+
+func (h *Harness) deleteHostedZone(t *testing.T, id string) {
+	r53 := route53.New(h.Session)
+	var changes []*route53.Change
+	err := r53.ListResourceRecordSetsPages(&route53.ListResourceRecordSetsInput{HostedZoneId: aws.String(id)},
+		func(out *route53.ListResourceRecordSetsOutput, _ bool) bool {
+			for _, rs := range out.ResourceRecordSets {
+				if t := aws.StringValue(rs.Type); t == route53.RRTypeNs || t == route53.RRTypeSoa {
+					continue
+				}
+				changes = append(changes, &route53.Change{Action: aws.String(route53.ChangeActionDelete), ResourceRecordSet: rs})
+			}
+			return true
+		})
+	if err != nil {
+		// The zone was deleted by the test.
+		return
+	}
+	if len(changes) > 0 {
+		if _, err := r53.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+			HostedZoneId: aws.String(id),
+			ChangeBatch:  &route53.ChangeBatch{Changes: changes},
+		}); err != nil {
+			t.Logf("could not delete record sets of hosted zone %s: %v", id, err)
+		}
+	}
+	if _, err := r53.DeleteHostedZone(&route53.DeleteHostedZoneInput{Id: aws.String(id)}); err != nil {
+		t.Logf("could not delete hosted zone %s: %v", id, err)
+	}
+}
+
+// CreateRecordSets creates count A record sets in the hosted zone.
+func (h *Harness) CreateRecordSets(t *testing.T, zoneID, zone string, count int) {
+	r53 := route53.New(h.Session)
+	changes := make([]*route53.Change, 0, count)
+	for i := 0; i < count; i++ {
+		changes = append(changes, &route53.Change{
+			Action: aws.String(route53.ChangeActionCreate),
+			ResourceRecordSet: &route53.ResourceRecordSet{
+				Name:            aws.String(fmt.Sprintf("record-%d.%s", i, zone)),
+				Type:            aws.String(route53.RRTypeA),
+				TTL:             aws.Int64(60),
+				ResourceRecords: []*route53.ResourceRecord{{Value: aws.String("192.0.2.1")}},
+			},
+		})
+	}
+	_, err := r53.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+		ChangeBatch:  &route53.ChangeBatch{Changes: changes},
+	})
+	require.NoError(t, err, "unexpected error creating record sets")
+}
+
+// CreateClusterInstances runs count instances tagged as owned by the cluster with the infra ID. The instances are
+// terminated at the end of the test.
+func (h *Harness) CreateClusterInstances(t *testing.T, infraID string, count int) []string {
+	ec2Client := ec2.New(h.Session)
+	imageID := fallbackImageID
+	if images, err := ec2Client.DescribeImages(&ec2.DescribeImagesInput{}); err == nil && len(images.Images) > 0 {
+		imageID = aws.StringValue(images.Images[0].ImageId)
+	}
+	out, err := ec2Client.RunInstances(&ec2.RunInstancesInput{
+		ImageId:      aws.String(imageID),
+		InstanceType: aws.String(ec2.InstanceTypeM5Xlarge),
+		MinCount:     aws.Int64(int64(count)),
+		MaxCount:     aws.Int64(int64(count)),
+		TagSpecifications: []*ec2.TagSpecification{{
+			ResourceType: aws.String(ec2.ResourceTypeInstance),
+			Tags: []*ec2.Tag{{
+				Key:   aws.String(fmt.Sprintf("kubernetes.io/cluster/%s", infraID)),
+				Value: aws.String("owned"),
+			}},
+		}},
+	})
+	require.NoError(t, err, "unexpected error running instances")
+	var ids []string
+	for _, i := range out.Instances {
+		ids = append(ids, aws.StringValue(i.InstanceId))
+	}
+	t.Cleanup(func() {
+		if _, err := ec2Client.TerminateInstances(&ec2.TerminateInstancesInput{InstanceIds: aws.StringSlice(ids)}); err != nil {
+			t.Logf("could not terminate instances %v: %v", ids, err)
+		}
+	})
+	return ids
+}
@@ -107,6 +107,12 @@ func WithApplyBehavior(applyBehavior hivev1.SyncSetApplyBehavior) Option {
 	}
 }
 
+func WithApplyAfterClaim() Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.ApplyAfterClaim = true
+	}
+}
+
 func WithResources(objs ...hivev1.MetaRuntimeObject) Option {
 	return func(syncSet *hivev1.SyncSet) {
 		syncSet.Spec.Resources = make([]runtime.RawExtension, len(objs))
@@ -0,0 +1,146 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlphttp"
+	exporttrace "go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	defaultSamplingRatio = 0.1
+
+	// tracesPath is the path of the OTLP/HTTP receiver to which traces are sent.
+	tracesPath = "/v1/traces"
+
+	// instrumentationName is the name of the tracer of the spans of Hive.
+	instrumentationName = "github.com/openshift/hive"
+)
+
+var (
+	metricSpansDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "hive_tracing_spans_dropped_total",
+		Help: "Counter incremented for each span that could not be exported to the tracing collector.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricSpansDropped)
+}
+
+// Tracer returns the tracer with which Hive starts its spans. Its spans are recorded once Configure has enabled
+// tracing, and are no-ops otherwise.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Configure enables the tracing of reconciles configured by the HIVE_TRACING environment variable, exporting the
+// spans of the service to the collector with the OpenTelemetry SDK. The returned function exports the spans that
+// have not been exported yet and stops the exporter, and must be called before the process exits. Tracing is left
+// disabled when the environment variable is not set, so that the instrumentation costs next to nothing.
+func Configure(serviceName string, logger log.FieldLogger) (func(context.Context) error, error) {
+	shutdown := func(context.Context) error { return nil }
+	value := os.Getenv(constants.TracingEnvVar)
+	if value == "" {
+		return shutdown, nil
+	}
+	config := hivev1.TracingConfig{}
+	if err := json.Unmarshal([]byte(value), &config); err != nil {
+		return shutdown, errors.Wrapf(err, "unable to parse %s", constants.TracingEnvVar)
+	}
+	ratio, err := samplingRatio(config)
+	if err != nil {
+		return shutdown, err
+	}
+	driver, err := newDriver(config.Endpoint)
+	if err != nil {
+		return shutdown, err
+	}
+	exporter, err := otlp.NewExporter(context.Background(), driver)
+	if err != nil {
+		return shutdown, errors.Wrap(err, "unable to start the trace exporter")
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithBatcher(&droppedSpansExporter{SpanExporter: exporter}),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.ServiceNameKey.String(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetErrorHandler(&errorHandler{logger: logger})
+	logger.WithField("endpoint", config.Endpoint).WithField("samplingRatio", ratio).Info("tracing enabled")
+	return provider.Shutdown, nil
+}
+
+// newDriver returns the OTLP/HTTP driver sending the spans to the /v1/traces path of the endpoint in the JSON
+// encoding.
+func newDriver(endpoint string) (otlp.ProtocolDriver, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return nil, errors.Errorf("invalid tracing endpoint %q", endpoint)
+	}
+	opts := []otlphttp.Option{
+		otlphttp.WithEndpoint(u.Host),
+		otlphttp.WithTracesURLPath(path.Join("/", u.Path, tracesPath)),
+		otlphttp.WithMarshal(otlphttp.MarshalJSON),
+	}
+	switch u.Scheme {
+	case "http":
+		opts = append(opts, otlphttp.WithInsecure())
+	case "https":
+	default:
+		return nil, errors.Errorf("invalid tracing endpoint %q, the scheme must be http or https", endpoint)
+	}
+	return otlphttp.NewDriver(opts...), nil
+}
+
+func samplingRatio(config hivev1.TracingConfig) (float64, error) {
+	if config.SamplingRatio == "" {
+		return defaultSamplingRatio, nil
+	}
+	ratio, err := strconv.ParseFloat(config.SamplingRatio, 64)
+	if err != nil || ratio < 0 || ratio > 1 {
+		return 0, errors.Errorf("invalid sampling ratio %q, must be between 0 and 1", config.SamplingRatio)
+	}
+	return ratio, nil
+}
+
+// droppedSpansExporter counts the spans of the batches that could not be exported.
+type droppedSpansExporter struct {
+	exporttrace.SpanExporter
+}
+
+func (e *droppedSpansExporter) ExportSpans(ctx context.Context, spans []*exporttrace.SpanSnapshot) error {
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	if err != nil {
+		metricSpansDropped.Add(float64(len(spans)))
+	}
+	return err
+}
+
+// errorHandler logs the errors of the OpenTelemetry SDK, such as the failed exports of spans.
+type errorHandler struct {
+	logger log.FieldLogger
+}
+
+func (h *errorHandler) Handle(err error) {
+	h.logger.WithError(err).Warn("tracing error")
+}
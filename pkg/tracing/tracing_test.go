@@ -0,0 +1,143 @@
+package tracing
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestConfigure(t *testing.T) {
+	requests := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/otlp"+tracesPath, r.URL.Path, "unexpected path")
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"), "unexpected content type")
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err, "unexpected error reading request")
+		requests <- body
+	}))
+	defer server.Close()
+
+	os.Setenv(constants.TracingEnvVar, `{"endpoint":"`+server.URL+`/otlp","samplingRatio":"1"}`)
+	defer os.Unsetenv(constants.TracingEnvVar)
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	shutdown, err := Configure("hive-controllers", log.StandardLogger())
+	require.NoError(t, err, "unexpected error configuring tracing")
+	_, span := Tracer().Start(context.Background(), "clusterdeployment.Reconcile")
+	assert.True(t, span.IsRecording(), "expected span to be recorded")
+	span.End()
+
+	// The span is batched, shutting down must export it.
+	require.NoError(t, shutdown(context.Background()), "unexpected error shutting down tracing")
+	select {
+	case body := <-requests:
+		assert.Contains(t, string(body), `"name":"clusterdeployment.Reconcile"`, "expected span in export request")
+		assert.Contains(t, string(body), `"stringValue":"hive-controllers"`, "expected service name in export request")
+	default:
+		t.Fatal("expected spans to be exported on shutdown")
+	}
+}
+
+func TestConfigureDisabled(t *testing.T) {
+	os.Unsetenv(constants.TracingEnvVar)
+	shutdown, err := Configure("hive-controllers", log.StandardLogger())
+	require.NoError(t, err, "unexpected error configuring tracing")
+	_, span := Tracer().Start(context.Background(), "clusterdeployment.Reconcile")
+	assert.False(t, span.IsRecording(), "expected span not to be recorded")
+	span.End()
+	assert.NoError(t, shutdown(context.Background()), "unexpected error shutting down tracing")
+}
+
+func TestNewDriver(t *testing.T) {
+	cases := []struct {
+		name      string
+		endpoint  string
+		expectErr bool
+	}{
+		{
+			name:     "http",
+			endpoint: "http://otel-collector.observability.svc:4318",
+		},
+		{
+			name:     "https",
+			endpoint: "https://otel-collector.example.com",
+		},
+		{
+			name:      "no host",
+			endpoint:  "otel-collector:4318",
+			expectErr: true,
+		},
+		{
+			name:      "unsupported scheme",
+			endpoint:  "grpc://otel-collector:4317",
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newDriver(tc.endpoint)
+			if tc.expectErr {
+				assert.Error(t, err, "expected error")
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+		})
+	}
+}
+
+func TestSamplingRatio(t *testing.T) {
+	cases := []struct {
+		name          string
+		samplingRatio string
+		expectedRatio float64
+		expectErr     bool
+	}{
+		{
+			name:          "default",
+			expectedRatio: defaultSamplingRatio,
+		},
+		{
+			name:          "set",
+			samplingRatio: "0.25",
+			expectedRatio: 0.25,
+		},
+		{
+			name:          "all",
+			samplingRatio: "1",
+			expectedRatio: 1,
+		},
+		{
+			name:          "out of range",
+			samplingRatio: "1.5",
+			expectErr:     true,
+		},
+		{
+			name:          "not a number",
+			samplingRatio: "half",
+			expectErr:     true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ratio, err := samplingRatio(hivev1.TracingConfig{Endpoint: "http://collector:4318", SamplingRatio: tc.samplingRatio})
+			if tc.expectErr {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectedRatio, ratio, "unexpected sampling ratio")
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/base64"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+var templateFuncs = template.FuncMap{
+	"b64enc": func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	},
+	"b64dec": func(s string) (string, error) {
+		decoded, err := base64.StdEncoding.DecodeString(s)
+		return string(decoded), err
+	},
+}
+
+// ParseTemplate parses the template of a key of a secret mapping.
+func ParseTemplate(keyTemplate hivev1.SecretKeyTemplate) (*template.Template, error) {
+	return template.New(keyTemplate.Key).Funcs(templateFuncs).Option("missingkey=error").Parse(keyTemplate.Template)
+}
+
+// Transform applies the key mappings, templates and type of a secret mapping to the data of a secret. The secret is
+// left unchanged if the mapping has no transformations.
+func Transform(secret *corev1.Secret, mapping hivev1.SecretMapping) error {
+	if mapping.Type != "" {
+		secret.Type = mapping.Type
+	}
+	if len(mapping.Keys) == 0 && len(mapping.Templates) == 0 {
+		return nil
+	}
+
+	data := map[string][]byte{}
+	for _, key := range mapping.Keys {
+		value, ok := secret.Data[key.SourceKey]
+		if !ok {
+			return errors.Errorf("source secret has no key %q", key.SourceKey)
+		}
+		targetKey := key.TargetKey
+		if targetKey == "" {
+			targetKey = key.SourceKey
+		}
+		data[targetKey] = value
+	}
+
+	if len(mapping.Templates) > 0 {
+		values := make(map[string]string, len(secret.Data))
+		for k, v := range secret.Data {
+			values[k] = string(v)
+		}
+		for _, keyTemplate := range mapping.Templates {
+			tmpl, err := ParseTemplate(keyTemplate)
+			if err != nil {
+				return errors.Wrapf(err, "could not parse template for key %q", keyTemplate.Key)
+			}
+			buf := &bytes.Buffer{}
+			if err := tmpl.Execute(buf, values); err != nil {
+				return errors.Wrapf(err, "could not render template for key %q", keyTemplate.Key)
+			}
+			data[keyTemplate.Key] = buf.Bytes()
+		}
+	}
+
+	secret.Data = data
+	secret.StringData = nil
+	return nil
+}
@@ -0,0 +1,94 @@
+package secrets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestTransform(t *testing.T) {
+	cases := []struct {
+		name         string
+		mapping      hivev1.SecretMapping
+		expectedData map[string][]byte
+		expectedType corev1.SecretType
+		expectError  bool
+	}{
+		{
+			name: "no transformation",
+			expectedData: map[string][]byte{
+				"registry": []byte("quay.io"),
+				"username": []byte("user"),
+				"password": []byte("pass"),
+			},
+			expectedType: corev1.SecretTypeOpaque,
+		},
+		{
+			name: "filter and rename keys",
+			mapping: hivev1.SecretMapping{
+				Keys: []hivev1.SecretKeyMapping{
+					{SourceKey: "username"},
+					{SourceKey: "password", TargetKey: "token"},
+				},
+			},
+			expectedData: map[string][]byte{
+				"username": []byte("user"),
+				"token":    []byte("pass"),
+			},
+			expectedType: corev1.SecretTypeOpaque,
+		},
+		{
+			name: "missing source key",
+			mapping: hivev1.SecretMapping{
+				Keys: []hivev1.SecretKeyMapping{{SourceKey: "missing"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "dockerconfigjson template",
+			mapping: hivev1.SecretMapping{
+				Templates: []hivev1.SecretKeyTemplate{{
+					Key:      corev1.DockerConfigJsonKey,
+					Template: `{"auths":{"{{ .registry }}":{"auth":"{{ printf "%s:%s" .username .password | b64enc }}"}}}`,
+				}},
+				Type: corev1.SecretTypeDockerConfigJson,
+			},
+			expectedData: map[string][]byte{
+				corev1.DockerConfigJsonKey: []byte(`{"auths":{"quay.io":{"auth":"dXNlcjpwYXNz"}}}`),
+			},
+			expectedType: corev1.SecretTypeDockerConfigJson,
+		},
+		{
+			name: "template with missing key",
+			mapping: hivev1.SecretMapping{
+				Templates: []hivev1.SecretKeyTemplate{{Key: "config", Template: "{{ .missing }}"}},
+			},
+			expectError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				Type: corev1.SecretTypeOpaque,
+				Data: map[string][]byte{
+					"registry": []byte("quay.io"),
+					"username": []byte("user"),
+					"password": []byte("pass"),
+				},
+			}
+			err := Transform(secret, tc.mapping)
+			if tc.expectError {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectedData, secret.Data, "unexpected data")
+			assert.Equal(t, tc.expectedType, secret.Type, "unexpected type")
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// admissionRules are the modes of the admission rules configured in HiveConfig.
+type admissionRules struct {
+	warn sets.String
+}
+
+func newAdmissionRules() *admissionRules {
+	warn := sets.NewString()
+	for _, rule := range strings.Split(os.Getenv(constants.AdmissionWarnRulesEnvVar), ",") {
+		if rule = strings.TrimSpace(rule); rule != "" {
+			warn.Insert(rule)
+		}
+	}
+	return &admissionRules{warn: warn}
+}
+
+// warnOnly returns whether failures of the rule are returned as warnings instead of rejecting the request.
+func (r *admissionRules) warnOnly(rule hivev1.AdmissionRule) bool {
+	return r != nil && r.warn.Has(string(rule))
+}
+
+// errors returns the validation errors of the rule, or adds them to the warnings and returns no errors when the rule
+// is in Warn mode.
+func (r *admissionRules) errors(rule hivev1.AdmissionRule, errs field.ErrorList, warnings *[]string, logger log.FieldLogger) field.ErrorList {
+	if len(errs) == 0 || !r.warnOnly(rule) {
+		return errs
+	}
+	for _, err := range errs {
+		*warnings = append(*warnings, ruleWarning(rule, err.Error()))
+	}
+	logger.WithField("rule", rule).WithError(errs.ToAggregate()).Info("validation failure returned as warning")
+	return nil
+}
+
+// response returns the rejection of the request by the rule, or adds its message to the warnings and returns nil
+// when the rule is in Warn mode.
+func (r *admissionRules) response(rule hivev1.AdmissionRule, resp *admissionv1beta1.AdmissionResponse, warnings *[]string, logger log.FieldLogger) *admissionv1beta1.AdmissionResponse {
+	if resp == nil || resp.Allowed || !r.warnOnly(rule) {
+		return resp
+	}
+	message := ""
+	if resp.Result != nil {
+		message = resp.Result.Message
+	}
+	*warnings = append(*warnings, ruleWarning(rule, message))
+	logger.WithField("rule", rule).WithField("message", message).Info("validation failure returned as warning")
+	return nil
+}
+
+func ruleWarning(rule hivev1.AdmissionRule, message string) string {
+	return fmt.Sprintf("%s (admission rule %s is not enforced)", message, rule)
+}
@@ -0,0 +1,115 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestAdmissionRulesWarnMode(t *testing.T) {
+	existingCD := validAWSClusterDeployment()
+	existingCD.Name = "existing"
+	existingCD.Namespace = quotaTestNamespace
+	quotas := []hivev1.NamespaceQuota{{Namespace: quotaTestNamespace, MaxClusters: pointer.Int32Ptr(1)}}
+	withInstallerEnv := func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.Provisioning.InstallerEnv = []corev1.EnvVar{{Name: "NOT_ALLOWED", Value: "true"}}
+	}
+	withBadPauseAnnotation := func(cd *hivev1.ClusterDeployment) {
+		cd.Annotations = map[string]string{constants.SyncsetPauseAnnotation: "yes"}
+	}
+
+	cases := []struct {
+		name             string
+		warnRules        []hivev1.AdmissionRule
+		quotas           []hivev1.NamespaceQuota
+		modify           []func(*hivev1.ClusterDeployment)
+		expectedAllowed  bool
+		expectedWarnings int
+	}{
+		{
+			name:   "installer env enforced",
+			modify: []func(*hivev1.ClusterDeployment){withInstallerEnv},
+		},
+		{
+			name:             "installer env warned",
+			warnRules:        []hivev1.AdmissionRule{hivev1.InstallerEnvAdmissionRule},
+			modify:           []func(*hivev1.ClusterDeployment){withInstallerEnv},
+			expectedAllowed:  true,
+			expectedWarnings: 1,
+		},
+		{
+			name:             "other rule still enforced",
+			warnRules:        []hivev1.AdmissionRule{hivev1.InstallerEnvAdmissionRule},
+			modify:           []func(*hivev1.ClusterDeployment){withInstallerEnv, withBadPauseAnnotation},
+			expectedWarnings: 1,
+		},
+		{
+			name:             "multiple rules warned",
+			warnRules:        []hivev1.AdmissionRule{hivev1.InstallerEnvAdmissionRule, hivev1.SyncSetPauseAnnotationAdmissionRule},
+			modify:           []func(*hivev1.ClusterDeployment){withInstallerEnv, withBadPauseAnnotation},
+			expectedAllowed:  true,
+			expectedWarnings: 2,
+		},
+		{
+			name:   "quota enforced",
+			quotas: quotas,
+		},
+		{
+			name:             "quota warned",
+			warnRules:        []hivev1.AdmissionRule{hivev1.NamespaceQuotaAdmissionRule},
+			quotas:           quotas,
+			expectedAllowed:  true,
+			expectedWarnings: 1,
+		},
+		{
+			name:            "valid with rules warned",
+			warnRules:       []hivev1.AdmissionRule{hivev1.InstallerEnvAdmissionRule},
+			expectedAllowed: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			hivev1.AddToScheme(scheme)
+			rules := &admissionRules{warn: sets.NewString()}
+			for _, rule := range tc.warnRules {
+				rules.warn.Insert(string(rule))
+			}
+			hook := ClusterDeploymentValidatingAdmissionHook{
+				decoder:         createDecoder(t),
+				fs:              newFeatureSet(),
+				namespaceQuotas: tc.quotas,
+				client:          fake.NewFakeClientWithScheme(scheme, existingCD),
+				rules:           rules,
+			}
+			cd := validAWSClusterDeployment()
+			cd.Namespace = quotaTestNamespace
+			for _, modify := range tc.modify {
+				modify(cd)
+			}
+			raw, _ := json.Marshal(cd)
+			response := hook.Validate(&admissionv1beta1.AdmissionRequest{
+				Operation: admissionv1beta1.Create,
+				Namespace: quotaTestNamespace,
+				Resource:  metav1.GroupVersionResource{Group: "hive.openshift.io", Version: "v1", Resource: "clusterdeployments"},
+				Object:    runtime.RawExtension{Raw: raw},
+			})
+			if !assert.Equal(t, tc.expectedAllowed, response.Allowed, "unexpected response") {
+				t.Logf("Response result = %#v", response.Result)
+			}
+			assert.Len(t, response.Warnings, tc.expectedWarnings, "unexpected warnings")
+		})
+	}
+}
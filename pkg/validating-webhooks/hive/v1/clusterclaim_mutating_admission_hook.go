@@ -0,0 +1,131 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// ClusterClaimMutatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
+// It records the identity of the user or service account creating a ClusterClaim in annotations of the claim, so that the
+// usage of ClusterPools can be attributed.
+type ClusterClaimMutatingAdmissionHook struct {
+	decoder *admission.Decoder
+
+	// now returns the current time, here for testing
+	now func() time.Time
+}
+
+// NewClusterClaimMutatingAdmissionHook constructs a new ClusterClaimMutatingAdmissionHook
+func NewClusterClaimMutatingAdmissionHook(decoder *admission.Decoder) *ClusterClaimMutatingAdmissionHook {
+	return &ClusterClaimMutatingAdmissionHook{decoder: decoder, now: time.Now}
+}
+
+// MutatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
+//                  webhook is accessed by the kube apiserver.
+// For example, generic-admission-server uses the data below to register the webhook on the REST resource "/apis/admission.hive.openshift.io/v1/clusterclaimmutators".
+//              When the kube apiserver calls this registered REST resource, the generic-admission-server calls the Admit() method below.
+func (a *ClusterClaimMutatingAdmissionHook) MutatingResource() (plural schema.GroupVersionResource, singular string) {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "clusterclaimmutator",
+	}).Info("Registering mutation REST resource")
+	// NOTE: This GVR is meant to be different than the ClusterClaim CRD GVR which has group "hive.openshift.io".
+	return schema.GroupVersionResource{
+			Group:    "admission.hive.openshift.io",
+			Version:  "v1",
+			Resource: "clusterclaimmutators",
+		},
+		"clusterclaimmutator"
+}
+
+// Initialize is called by generic-admission-server on startup to setup any special initialization that your webhook needs.
+func (a *ClusterClaimMutatingAdmissionHook) Initialize(kubeClientConfig *rest.Config, stopCh <-chan struct{}) error {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "clusterclaimmutator",
+	}).Info("Initializing mutation REST resource")
+	return nil // No initialization needed right now.
+}
+
+// Admit is called by generic-admission-server when the registered REST resource above is called with an admission request.
+// On creation of a ClusterClaim, it responds with a patch setting the claimant annotations from the user info of the request.
+func (a *ClusterClaimMutatingAdmissionHook) Admit(admissionSpec *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	contextLogger := log.WithFields(log.Fields{
+		"operation": admissionSpec.Operation,
+		"group":     admissionSpec.Resource.Group,
+		"version":   admissionSpec.Resource.Version,
+		"resource":  admissionSpec.Resource.Resource,
+		"method":    "Admit",
+	})
+
+	if !isClusterClaimRequest(admissionSpec) || admissionSpec.Operation != admissionv1beta1.Create {
+		contextLogger.Info("Skipping mutation for request")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	newObject := &hivev1.ClusterClaim{}
+	if err := a.decoder.DecodeRaw(admissionSpec.Object, newObject); err != nil {
+		contextLogger.Errorf("Failed unmarshaling Object: %v", err.Error())
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	// Add the new data to the contextLogger
+	contextLogger.Data["object.Name"] = newObject.Name
+
+	annotations := map[string]string{}
+	for k, v := range newObject.Annotations {
+		annotations[k] = v
+	}
+	// Any claimant set by the requester is overwritten with the actual identity of the requester.
+	annotations[constants.ClusterClaimClaimantUsernameAnnotation] = admissionSpec.UserInfo.Username
+	annotations[constants.ClusterClaimClaimantGroupsAnnotation] = strings.Join(admissionSpec.UserInfo.Groups, ",")
+	annotations[constants.ClusterClaimClaimantTimestampAnnotation] = a.now().UTC().Format(time.RFC3339)
+
+	// An "add" operation replaces the annotations if they already exist.
+	patch, err := json.Marshal([]map[string]interface{}{{
+		"op":    "add",
+		"path":  "/metadata/annotations",
+		"value": annotations,
+	}})
+	if err != nil {
+		contextLogger.WithError(err).Error("Failed marshaling patch")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	contextLogger.WithField("claimant", admissionSpec.UserInfo.Username).Info("Recording claimant")
+	patchType := admissionv1beta1.PatchTypeJSONPatch
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patch,
+		PatchType: &patchType,
+	}
+}
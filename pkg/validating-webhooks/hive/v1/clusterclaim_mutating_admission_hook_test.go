@@ -0,0 +1,101 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestClusterClaimMutatingResource(t *testing.T) {
+	data := NewClusterClaimMutatingAdmissionHook(createDecoder(t))
+	plural, singular := data.MutatingResource()
+	assert.Equal(t, "admission.hive.openshift.io", plural.Group)
+	assert.Equal(t, "v1", plural.Version)
+	assert.Equal(t, "clusterclaimmutators", plural.Resource)
+	assert.Equal(t, "clusterclaimmutator", singular)
+}
+
+func TestClusterClaimAdmit(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name                string
+		operation           admissionv1beta1.Operation
+		annotations         map[string]string
+		expectPatch         bool
+		expectedAnnotations map[string]string
+	}{
+		{
+			name:        "create",
+			operation:   admissionv1beta1.Create,
+			expectPatch: true,
+			expectedAnnotations: map[string]string{
+				constants.ClusterClaimClaimantUsernameAnnotation:  "system:serviceaccount:ci:claimer",
+				constants.ClusterClaimClaimantGroupsAnnotation:    "system:serviceaccounts,system:serviceaccounts:ci",
+				constants.ClusterClaimClaimantTimestampAnnotation: "2026-10-15T12:00:00Z",
+			},
+		},
+		{
+			name:      "create with forged claimant",
+			operation: admissionv1beta1.Create,
+			annotations: map[string]string{
+				constants.ClusterClaimClaimantUsernameAnnotation: "someone-else",
+				"other": "value",
+			},
+			expectPatch: true,
+			expectedAnnotations: map[string]string{
+				constants.ClusterClaimClaimantUsernameAnnotation:  "system:serviceaccount:ci:claimer",
+				constants.ClusterClaimClaimantGroupsAnnotation:    "system:serviceaccounts,system:serviceaccounts:ci",
+				constants.ClusterClaimClaimantTimestampAnnotation: "2026-10-15T12:00:00Z",
+				"other": "value",
+			},
+		},
+		{
+			name:      "update",
+			operation: admissionv1beta1.Update,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := NewClusterClaimMutatingAdmissionHook(createDecoder(t))
+			data.now = func() time.Time { return now }
+			object := testClusterClaimRaw(t, tc.annotations)
+			request := &admissionv1beta1.AdmissionRequest{
+				Resource: metav1.GroupVersionResource{
+					Group:    "hive.openshift.io",
+					Version:  "v1",
+					Resource: "clusterclaims",
+				},
+				Operation: tc.operation,
+				UserInfo: authenticationv1.UserInfo{
+					Username: "system:serviceaccount:ci:claimer",
+					Groups:   []string{"system:serviceaccounts", "system:serviceaccounts:ci"},
+				},
+				Object: object,
+			}
+			response := data.Admit(request)
+			assert.True(t, response.Allowed, "unexpected response: %v", response.Result)
+			if !tc.expectPatch {
+				assert.Nil(t, response.Patch, "unexpected patch")
+				return
+			}
+			patch, err := jsonpatch.DecodePatch(response.Patch)
+			require.NoError(t, err, "could not decode patch")
+			patched, err := patch.Apply(object.Raw)
+			require.NoError(t, err, "could not apply patch")
+			claim := &hivev1.ClusterClaim{}
+			require.NoError(t, json.Unmarshal(patched, claim), "could not unmarshal patched ClusterClaim")
+			assert.Equal(t, tc.expectedAnnotations, claim.Annotations, "unexpected annotations")
+		})
+	}
+}
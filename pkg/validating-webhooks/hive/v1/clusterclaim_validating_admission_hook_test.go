@@ -0,0 +1,158 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestClusterClaimValidatingResource(t *testing.T) {
+	data := NewClusterClaimValidatingAdmissionHook(createDecoder(t))
+	plural, singular := data.ValidatingResource()
+	assert.Equal(t, "admission.hive.openshift.io", plural.Group)
+	assert.Equal(t, "v1", plural.Version)
+	assert.Equal(t, "clusterclaimvalidators", plural.Resource)
+	assert.Equal(t, "clusterclaimvalidator", singular)
+}
+
+func TestClusterClaimValidate(t *testing.T) {
+	claimant := map[string]string{
+		constants.ClusterClaimClaimantUsernameAnnotation:  "alice",
+		constants.ClusterClaimClaimantGroupsAnnotation:    "team-a,system:authenticated",
+		constants.ClusterClaimClaimantTimestampAnnotation: "2026-10-15T12:00:00Z",
+	}
+	withAnnotation := func(key, value string) map[string]string {
+		annotations := map[string]string{}
+		for k, v := range claimant {
+			annotations[k] = v
+		}
+		annotations[key] = value
+		return annotations
+	}
+	withoutAnnotation := func(key string) map[string]string {
+		annotations := withAnnotation("other", "value")
+		delete(annotations, key)
+		return annotations
+	}
+
+	cases := []struct {
+		name            string
+		operation       admissionv1beta1.Operation
+		username        string
+		oldAnnotations  map[string]string
+		newAnnotations  map[string]string
+		expectedAllowed bool
+	}{
+		{
+			name:            "create by claimant",
+			operation:       admissionv1beta1.Create,
+			username:        "alice",
+			newAnnotations:  claimant,
+			expectedAllowed: true,
+		},
+		{
+			name:            "create without claimant",
+			operation:       admissionv1beta1.Create,
+			username:        "alice",
+			expectedAllowed: true,
+		},
+		{
+			name:            "create with forged claimant",
+			operation:       admissionv1beta1.Create,
+			username:        "bob",
+			newAnnotations:  claimant,
+			expectedAllowed: false,
+		},
+		{
+			name:            "update other annotation",
+			operation:       admissionv1beta1.Update,
+			username:        "bob",
+			oldAnnotations:  claimant,
+			newAnnotations:  withAnnotation("other", "value"),
+			expectedAllowed: true,
+		},
+		{
+			name:            "update claimant",
+			operation:       admissionv1beta1.Update,
+			username:        "bob",
+			oldAnnotations:  claimant,
+			newAnnotations:  withAnnotation(constants.ClusterClaimClaimantUsernameAnnotation, "bob"),
+			expectedAllowed: false,
+		},
+		{
+			name:            "update claimant timestamp",
+			operation:       admissionv1beta1.Update,
+			username:        "alice",
+			oldAnnotations:  claimant,
+			newAnnotations:  withAnnotation(constants.ClusterClaimClaimantTimestampAnnotation, "2026-10-16T12:00:00Z"),
+			expectedAllowed: false,
+		},
+		{
+			name:            "remove claimant groups",
+			operation:       admissionv1beta1.Update,
+			username:        "alice",
+			oldAnnotations:  claimant,
+			newAnnotations:  withoutAnnotation(constants.ClusterClaimClaimantGroupsAnnotation),
+			expectedAllowed: false,
+		},
+		{
+			name:            "add claimant to existing claim",
+			operation:       admissionv1beta1.Update,
+			username:        "alice",
+			newAnnotations:  claimant,
+			expectedAllowed: false,
+		},
+		{
+			name:            "delete",
+			operation:       admissionv1beta1.Delete,
+			username:        "bob",
+			oldAnnotations:  claimant,
+			expectedAllowed: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := NewClusterClaimValidatingAdmissionHook(createDecoder(t))
+			request := &admissionv1beta1.AdmissionRequest{
+				Resource: metav1.GroupVersionResource{
+					Group:    "hive.openshift.io",
+					Version:  "v1",
+					Resource: "clusterclaims",
+				},
+				Operation: tc.operation,
+				UserInfo:  authenticationv1.UserInfo{Username: tc.username},
+				Object:    testClusterClaimRaw(t, tc.newAnnotations),
+				OldObject: testClusterClaimRaw(t, tc.oldAnnotations),
+			}
+			response := data.Validate(request)
+			assert.Equal(t, tc.expectedAllowed, response.Allowed, "unexpected response: %v", response.Result)
+		})
+	}
+}
+
+func testClusterClaimRaw(t *testing.T, annotations map[string]string) runtime.RawExtension {
+	claim := &hivev1.ClusterClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-claim",
+			Namespace:   "test-namespace",
+			Annotations: annotations,
+		},
+		Spec: hivev1.ClusterClaimSpec{
+			ClusterPoolName: "test-pool",
+		},
+	}
+	raw, err := json.Marshal(claim)
+	if err != nil {
+		t.Fatalf("could not marshal ClusterClaim: %v", err)
+	}
+	return runtime.RawExtension{Raw: raw}
+}
@@ -0,0 +1,159 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// ClusterDeploymentMutatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
+// It applies the hibernation defaults of the HiveConfig to new ClusterDeployments.
+type ClusterDeploymentMutatingAdmissionHook struct {
+	decoder *admission.Decoder
+
+	// hibernationDefaults are the hibernation defaults of the HiveConfig, or nil if there are none.
+	hibernationDefaults *hivev1.HibernationDefaultsConfig
+}
+
+// NewClusterDeploymentMutatingAdmissionHook constructs a new ClusterDeploymentMutatingAdmissionHook
+func NewClusterDeploymentMutatingAdmissionHook(decoder *admission.Decoder) *ClusterDeploymentMutatingAdmissionHook {
+	defaults, err := controllerutils.ReadHibernationDefaults()
+	if err != nil {
+		log.WithError(err).Error("unable to read the hibernation defaults, ignoring them")
+	}
+	return &ClusterDeploymentMutatingAdmissionHook{decoder: decoder, hibernationDefaults: defaults}
+}
+
+// MutatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
+//                  webhook is accessed by the kube apiserver.
+// For example, generic-admission-server uses the data below to register the webhook on the REST resource "/apis/admission.hive.openshift.io/v1/clusterdeploymentmutators".
+//              When the kube apiserver calls this registered REST resource, the generic-admission-server calls the Admit() method below.
+func (a *ClusterDeploymentMutatingAdmissionHook) MutatingResource() (plural schema.GroupVersionResource, singular string) {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "clusterdeploymentmutator",
+	}).Info("Registering mutation REST resource")
+	// NOTE: This GVR is meant to be different than the ClusterDeployment CRD GVR which has group "hive.openshift.io".
+	return schema.GroupVersionResource{
+			Group:    "admission.hive.openshift.io",
+			Version:  "v1",
+			Resource: "clusterdeploymentmutators",
+		},
+		"clusterdeploymentmutator"
+}
+
+// Initialize is called by generic-admission-server on startup to setup any special initialization that your webhook needs.
+func (a *ClusterDeploymentMutatingAdmissionHook) Initialize(kubeClientConfig *rest.Config, stopCh <-chan struct{}) error {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "clusterdeploymentmutator",
+	}).Info("Initializing mutation REST resource")
+	return nil // No initialization needed right now.
+}
+
+// Admit is called by generic-admission-server when the registered REST resource above is called with an admission request.
+// On creation of a ClusterDeployment that does not set hibernateAfter, is not part of a ClusterPool and is allowed to be
+// hibernated, it responds with a patch setting the default hibernateAfter of the HiveConfig.
+func (a *ClusterDeploymentMutatingAdmissionHook) Admit(admissionSpec *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	contextLogger := log.WithFields(log.Fields{
+		"operation": admissionSpec.Operation,
+		"group":     admissionSpec.Resource.Group,
+		"version":   admissionSpec.Resource.Version,
+		"resource":  admissionSpec.Resource.Resource,
+		"method":    "Admit",
+	})
+
+	if !a.shouldMutate(admissionSpec) {
+		contextLogger.Info("Skipping mutation for request")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	newObject := &hivev1.ClusterDeployment{}
+	if err := a.decoder.DecodeRaw(admissionSpec.Object, newObject); err != nil {
+		contextLogger.Errorf("Failed unmarshaling Object: %v", err.Error())
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	// Add the new data to the contextLogger
+	contextLogger.Data["object.Name"] = newObject.Name
+
+	if newObject.Spec.HibernateAfter != nil || newObject.Spec.ClusterPoolRef != nil {
+		contextLogger.Debug("Not defaulting hibernateAfter")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+	allowed, err := controllerutils.IsPowerStateAllowed(a.hibernationDefaults, newObject, hivev1.HibernatingClusterPowerState)
+	if err != nil {
+		contextLogger.WithError(err).Error("Failed evaluating power state policies")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+				Message: err.Error(),
+			},
+		}
+	}
+	if !allowed {
+		contextLogger.Debug("Not defaulting hibernateAfter, hibernation is not allowed by the power state policies")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	patch, err := json.Marshal([]map[string]interface{}{{
+		"op":    "add",
+		"path":  "/spec/hibernateAfter",
+		"value": a.hibernationDefaults.HibernateAfter,
+	}})
+	if err != nil {
+		contextLogger.WithError(err).Error("Failed marshaling patch")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	contextLogger.WithField("hibernateAfter", a.hibernationDefaults.HibernateAfter.Duration).Info("Defaulting hibernateAfter")
+	patchType := admissionv1beta1.PatchTypeJSONPatch
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patch,
+		PatchType: &patchType,
+	}
+}
+
+// shouldMutate returns whether the request is the creation of a ClusterDeployment while a default hibernateAfter is
+// configured.
+func (a *ClusterDeploymentMutatingAdmissionHook) shouldMutate(admissionSpec *admissionv1beta1.AdmissionRequest) bool {
+	if a.hibernationDefaults == nil || a.hibernationDefaults.HibernateAfter == nil {
+		return false
+	}
+	return admissionSpec.Operation == admissionv1beta1.Create &&
+		admissionSpec.Resource.Group == clusterDeploymentGroup &&
+		admissionSpec.Resource.Version == clusterDeploymentVersion &&
+		admissionSpec.Resource.Resource == clusterDeploymentResource
+}
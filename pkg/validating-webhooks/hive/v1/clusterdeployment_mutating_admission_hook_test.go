@@ -0,0 +1,124 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestClusterDeploymentMutatingResource(t *testing.T) {
+	data := NewClusterDeploymentMutatingAdmissionHook(createDecoder(t))
+	plural, singular := data.MutatingResource()
+	assert.Equal(t, "admission.hive.openshift.io", plural.Group)
+	assert.Equal(t, "v1", plural.Version)
+	assert.Equal(t, "clusterdeploymentmutators", plural.Resource)
+	assert.Equal(t, "clusterdeploymentmutator", singular)
+}
+
+func TestClusterDeploymentAdmit(t *testing.T) {
+	defaults := &hivev1.HibernationDefaultsConfig{
+		HibernateAfter: &metav1.Duration{Duration: 8 * time.Hour},
+		PowerStatePolicies: []hivev1.PowerStatePolicy{{
+			Selector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "production"}},
+		}},
+	}
+	cases := []struct {
+		name                   string
+		operation              admissionv1beta1.Operation
+		defaults               *hivev1.HibernationDefaultsConfig
+		labels                 map[string]string
+		hibernateAfter         *metav1.Duration
+		clusterPoolRef         *hivev1.ClusterPoolReference
+		expectedHibernateAfter *metav1.Duration
+	}{
+		{
+			name:                   "create",
+			operation:              admissionv1beta1.Create,
+			defaults:               defaults,
+			expectedHibernateAfter: &metav1.Duration{Duration: 8 * time.Hour},
+		},
+		{
+			name:      "create without defaults",
+			operation: admissionv1beta1.Create,
+		},
+		{
+			name:                   "create with hibernate after",
+			operation:              admissionv1beta1.Create,
+			defaults:               defaults,
+			hibernateAfter:         &metav1.Duration{Duration: time.Hour},
+			expectedHibernateAfter: &metav1.Duration{Duration: time.Hour},
+		},
+		{
+			name:      "create in cluster pool",
+			operation: admissionv1beta1.Create,
+			defaults:  defaults,
+			clusterPoolRef: &hivev1.ClusterPoolReference{
+				Namespace: "pool-namespace",
+				PoolName:  "pool",
+			},
+		},
+		{
+			name:      "create with hibernation not allowed",
+			operation: admissionv1beta1.Create,
+			defaults:  defaults,
+			labels:    map[string]string{"env": "production"},
+		},
+		{
+			name:      "update",
+			operation: admissionv1beta1.Update,
+			defaults:  defaults,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := NewClusterDeploymentMutatingAdmissionHook(createDecoder(t))
+			data.hibernationDefaults = tc.defaults
+			cd := &hivev1.ClusterDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "test-namespace",
+					Labels:    tc.labels,
+				},
+				Spec: hivev1.ClusterDeploymentSpec{
+					ClusterName:    "test-cluster",
+					BaseDomain:     "example.com",
+					HibernateAfter: tc.hibernateAfter,
+					ClusterPoolRef: tc.clusterPoolRef,
+					PullSecretRef:  &corev1.LocalObjectReference{Name: "pull-secret"},
+				},
+			}
+			raw, err := json.Marshal(cd)
+			require.NoError(t, err, "could not marshal ClusterDeployment")
+			request := &admissionv1beta1.AdmissionRequest{
+				Resource: metav1.GroupVersionResource{
+					Group:    "hive.openshift.io",
+					Version:  "v1",
+					Resource: "clusterdeployments",
+				},
+				Operation: tc.operation,
+			}
+			request.Object.Raw = raw
+			response := data.Admit(request)
+			assert.True(t, response.Allowed, "unexpected response: %v", response.Result)
+			if response.Patch != nil {
+				patch, err := jsonpatch.DecodePatch(response.Patch)
+				require.NoError(t, err, "could not decode patch")
+				raw, err = patch.Apply(raw)
+				require.NoError(t, err, "could not apply patch")
+			}
+			patched := &hivev1.ClusterDeployment{}
+			require.NoError(t, json.Unmarshal(raw, patched), "could not unmarshal patched ClusterDeployment")
+			assert.Equal(t, tc.expectedHibernateAfter, patched.Spec.HibernateAfter, "unexpected hibernateAfter")
+		})
+	}
+}
@@ -2,7 +2,10 @@ package v1
 
 import (
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -12,6 +15,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,14 +26,20 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
 	hivecontractsv1alpha1 "github.com/openshift/hive/apis/hivecontracts/v1alpha1"
 
+	"github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/awsprivatelink"
+	"github.com/openshift/hive/pkg/controller/gcpprivateserviceconnect"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/manageddns"
 	"github.com/openshift/hive/pkg/util/contracts"
 )
@@ -44,17 +54,44 @@ const (
 )
 
 var (
-	mutableFields = []string{"CertificateBundles", "ClusterMetadata", "ControlPlaneConfig", "Ingress", "Installed", "PreserveOnDelete", "ClusterPoolRef", "PowerState", "HibernateAfter", "InstallAttemptsLimit", "MachineManagement"}
+	mutableFields = []string{"CertificateBundles", "ClusterMetadata", "ControlPlaneConfig", "Ingress", "Installed", "PreserveOnDelete", "ClusterPoolRef", "PowerState", "HibernateAfter", "InstallAttemptsLimit", "MachineManagement", "DNSRouting", "Adoption", "Paused", "SSHKeyRotation", "ViewerKubeconfig", "SyncSetApplyWindows", "AdditionalTrustBundle", "CloudClientProxySecretRef"}
+
+	// defaultAllowedInstallerEnv are the installer environment variables allowed when HiveConfig does not
+	// specify any.
+	defaultAllowedInstallerEnv = []string{"OPENSHIFT_INSTALL_*"}
+
+	// reservedInstallerEnv are the installer environment variables set by Hive that cannot be overridden.
+	reservedInstallerEnv = sets.NewString(
+		"OPENSHIFT_INSTALL_INVOKER",
+		"OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE",
+		constants.SSHPrivKeyPathEnvVar,
+		constants.LibvirtSSHPrivKeyPathEnvVar,
+		constants.BoundServiceAccountSigningKeyEnvVar,
+		constants.FakeClusterInstallEnvVar,
+	)
 )
 
 // ClusterDeploymentValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type ClusterDeploymentValidatingAdmissionHook struct {
 	decoder *admission.Decoder
 
-	validManagedDomains  []string
-	fs                   *featureSet
-	awsPrivateLinkConfig *hivev1.AWSPrivateLinkConfig
-	supportedContracts   contracts.SupportedContractImplementationsList
+	validManagedDomains            []string
+	fs                             *featureSet
+	awsPrivateLinkConfig           *hivev1.AWSPrivateLinkConfig
+	gcpPrivateServiceConnectConfig *hivev1.GCPPrivateServiceConnectConfig
+	supportedContracts             contracts.SupportedContractImplementationsList
+	// allowedInstallerEnv are the names of the environment variables that may be passed through to the installer.
+	// A name ending in * allows all names with that prefix.
+	allowedInstallerEnv []string
+	namespaceQuotas     []hivev1.NamespaceQuota
+	// credentialsBroker binds the credentials sources of the credentials broker to the namespaces that may use them.
+	credentialsBroker *hivev1.CredentialsBrokerConfig
+	// managedDomains are the managed domains of HiveConfig, which list the shared hosted zones allowed for records
+	// with a routing policy.
+	managedDomains []hivev1.ManageDNSConfig
+	// client is used to count the clusters in namespaces with quotas. It is only set when there are quotas.
+	client client.Client
+	rules  *admissionRules
 }
 
 // NewClusterDeploymentValidatingAdmissionHook constructs a new ClusterDeploymentValidatingAdmissionHook
@@ -74,19 +111,47 @@ func NewClusterDeploymentValidatingAdmissionHook(decoder *admission.Decoder) *Cl
 		logger.WithError(err).Fatal("Unable to read AWS Private Link Config file")
 	}
 
+	pscConfig, err := gcpprivateserviceconnect.ReadGCPPrivateServiceConnectControllerConfigFile()
+	if err != nil {
+		logger.WithError(err).Fatal("Unable to read GCP Private Service Connect Config file")
+	}
+
 	supportContractsConfig, err := contracts.ReadSupportContractsFile()
 	if err != nil {
 		logger.WithError(err).Fatal("Unable to read Supported Contract Implementations file")
 
 	}
 
+	namespaceQuotas, err := controllerutils.ReadNamespaceQuotasFile()
+	if err != nil {
+		logger.WithError(err).Fatal("Unable to read namespace quotas file")
+	}
+
+	credentialsBroker, err := awsclient.ReadCredentialsBrokerConfig()
+	if err != nil {
+		logger.WithError(err).Fatal("Unable to read the credentials broker config")
+	}
+
+	var allowedInstallerEnv []string
+	for _, name := range strings.Split(os.Getenv(constants.AllowedInstallerEnvEnvVar), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			allowedInstallerEnv = append(allowedInstallerEnv, name)
+		}
+	}
+
 	logger.WithField("managedDomains", domains).Info("Read managed domains")
 	return &ClusterDeploymentValidatingAdmissionHook{
-		decoder:              decoder,
-		validManagedDomains:  domains,
-		fs:                   newFeatureSet(),
-		awsPrivateLinkConfig: aplConfig,
-		supportedContracts:   supportContractsConfig,
+		decoder:                        decoder,
+		validManagedDomains:            domains,
+		managedDomains:                 managedDomains,
+		fs:                             newFeatureSet(),
+		awsPrivateLinkConfig:           aplConfig,
+		gcpPrivateServiceConnectConfig: pscConfig,
+		supportedContracts:             supportContractsConfig,
+		allowedInstallerEnv:            allowedInstallerEnv,
+		namespaceQuotas:                namespaceQuotas,
+		credentialsBroker:              credentialsBroker,
+		rules:                          newAdmissionRules(),
 	}
 }
 
@@ -117,7 +182,15 @@ func (a *ClusterDeploymentValidatingAdmissionHook) Initialize(kubeClientConfig *
 		"version":  clusterDeploymentAdmissionVersion,
 		"resource": "clusterdeploymentvalidator",
 	}).Info("Initializing validation REST resource")
-	return nil // No initialization needed right now.
+	if len(a.namespaceQuotas) == 0 {
+		return nil
+	}
+	c, err := newQuotaClient(kubeClientConfig)
+	if err != nil {
+		return err
+	}
+	a.client = c
+	return nil
 }
 
 // Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
@@ -269,17 +342,23 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 
 	allErrs := field.ErrorList{}
 	specPath := field.NewPath("spec")
+	var warnings []string
+
+	allErrs = append(allErrs, a.rules.errors(hivev1.SyncSetPauseAnnotationAdmissionRule, validateSyncSetPauseAnnotation(cd), &warnings, contextLogger)...)
 
 	if !cd.Spec.Installed {
 		if cd.Spec.Provisioning != nil && cd.Spec.ClusterInstallRef != nil {
 			allErrs = append(allErrs, field.Forbidden(specPath.Child("provisioning"), "provisioning and clusterInstallRef cannot be set at the same time"))
 		}
 
-		if cd.Spec.Provisioning == nil && cd.Spec.ClusterInstallRef == nil {
+		if cd.Spec.Provisioning == nil && cd.Spec.ClusterInstallRef == nil && cd.Spec.Adoption == nil {
 			allErrs = append(allErrs, field.Required(specPath.Child("provisioning"), "provisioning is required if not installed"))
 		}
 	}
 
+	allErrs = append(allErrs, validateAdoption(specPath, cd.Spec)...)
+	allErrs = append(allErrs, validateSyncSetApplyWindows(specPath.Child("syncSetApplyWindows"), cd.Spec.SyncSetApplyWindows)...)
+
 	if !cd.Spec.Installed && cd.Spec.Provisioning != nil {
 		// InstallConfigSecretRef is not required for anyone using the new ClusterInstall interface:
 		if cd.Spec.Provisioning.InstallConfigSecretRef == nil || cd.Spec.Provisioning.InstallConfigSecretRef.Name == "" {
@@ -288,16 +367,37 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 	}
 
 	allErrs = append(allErrs, validateClusterPlatform(specPath.Child("platform"), cd.Spec.Platform)...)
+	if aws := cd.Spec.Platform.AWS; aws != nil && aws.CredentialsSource != nil {
+		allErrs = append(allErrs, validateAWSCredentialsSourceBinding(specPath.Child("platform", "aws", "credentialsSource"), a.credentialsBroker, admissionSpec.Namespace, aws.CredentialsSource)...)
+	}
 	allErrs = append(allErrs, validateCanManageDNSForClusterPlatform(specPath, cd.Spec)...)
+	allErrs = append(allErrs, validateDNSRouting(specPath, cd.Spec)...)
+	allErrs = append(allErrs, validateDNSRoutingHostedZone(specPath, cd.Spec, a.managedDomains)...)
 
 	if cd.Spec.Platform.AWS != nil {
 		allErrs = append(allErrs, validateAWSPrivateLink(specPath.Child("platform", "aws"), cd.Spec.Platform.AWS, a.awsPrivateLinkConfig)...)
 	}
 
+	if cd.Spec.Platform.GCP != nil {
+		allErrs = append(allErrs, validateGCPPrivateServiceConnect(specPath.Child("platform", "gcp"), cd.Spec.Platform.GCP, a.gcpPrivateServiceConnectConfig)...)
+	}
+
+	allErrs = append(allErrs, a.rules.errors(hivev1.SSHBastionAdmissionRule,
+		validateSSHBastion(specPath.Child("controlPlaneConfig", "sshBastion"), cd.Spec.ControlPlaneConfig.SSHBastion), &warnings, contextLogger)...)
+
 	if cd.Spec.Provisioning != nil {
 		if cd.Spec.Provisioning.SSHPrivateKeySecretRef != nil && cd.Spec.Provisioning.SSHPrivateKeySecretRef.Name == "" {
 			allErrs = append(allErrs, field.Required(specPath.Child("provisioning", "sshPrivateKeySecretRef", "name"), "must specify a name for the ssh private key secret if the ssh private key secret is specified"))
 		}
+		allErrs = append(allErrs, a.rules.errors(hivev1.InstallerEnvAdmissionRule,
+			a.validateInstallerEnv(specPath.Child("provisioning", "installerEnv"), cd.Spec.Provisioning.InstallerEnv), &warnings, contextLogger)...)
+		if image := cd.Spec.Provisioning.InstallerImageOverride; image != "" && !imageReferenceRegex.MatchString(image) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("provisioning", "installerImageOverride"), image, "must be a valid image reference, such as quay.io/myorg/installer:tag or quay.io/myorg/installer@sha256:<digest>"))
+		}
+		if mc := cd.Spec.Provisioning.ManualCredentials; mc != nil {
+			allErrs = append(allErrs, a.rules.errors(hivev1.ManualCredentialsAdmissionRule,
+				validateManualCredentials(specPath, &cd.Spec, mc), &warnings, contextLogger)...)
+		}
 	}
 
 	if cd.Spec.ClusterInstallRef != nil {
@@ -328,15 +428,23 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 	if len(allErrs) > 0 {
 		status := errors.NewInvalid(schemaGVK(admissionSpec.Kind).GroupKind(), admissionSpec.Name, allErrs).Status()
 		return &admissionv1beta1.AdmissionResponse{
-			Allowed: false,
-			Result:  &status,
+			Allowed:  false,
+			Result:   &status,
+			Warnings: warnings,
 		}
 	}
 
+	if resp := a.rules.response(hivev1.NamespaceQuotaAdmissionRule,
+		validateClusterQuota(a.client, a.namespaceQuotas, admissionSpec.Namespace, cd.Name, contextLogger), &warnings, contextLogger); resp != nil {
+		resp.Warnings = warnings
+		return resp
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	contextLogger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
-		Allowed: true,
+		Allowed:  true,
+		Warnings: warnings,
 	}
 }
 
@@ -348,14 +456,20 @@ func validateAWSPrivateLink(path *field.Path, platform *hivev1aws.Platform, conf
 		return allErrs
 	}
 
-	if config == nil || len(config.EndpointVPCInventory) == 0 {
+	if config == nil || (len(config.EndpointVPCInventory) == 0 && pl.VPCEndpointID == "") {
 		allErrs = append(allErrs, field.Forbidden(path.Child("privateLink", "enabled"), "AWS PrivateLink is not supported in the environment"))
 		return allErrs
 	}
 
+	// A pre-created VPC Endpoint does not need a VPC from the inventory.
+	if pl.VPCEndpointID != "" {
+		return allErrs
+	}
+
 	supportedRegions := sets.NewString()
 	for _, inv := range config.EndpointVPCInventory {
 		supportedRegions.Insert(inv.Region)
+		supportedRegions.Insert(inv.ServedRegions...)
 	}
 	if !supportedRegions.Has(platform.Region) {
 		allErrs = append(allErrs, field.Forbidden(path.Child("privateLink", "enabled"),
@@ -365,6 +479,229 @@ func validateAWSPrivateLink(path *field.Path, platform *hivev1aws.Platform, conf
 	return allErrs
 }
 
+func validateGCPPrivateServiceConnect(path *field.Path, platform *hivev1gcp.Platform, config *hivev1.GCPPrivateServiceConnectConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+	psc := platform.PrivateServiceConnect
+
+	if psc == nil || !psc.Enabled {
+		return allErrs
+	}
+
+	if config == nil || len(config.EndpointInventory) == 0 {
+		allErrs = append(allErrs, field.Forbidden(path.Child("privateServiceConnect", "enabled"), "GCP Private Service Connect is not supported in the environment"))
+		return allErrs
+	}
+
+	supportedRegions := sets.NewString()
+	for _, inv := range config.EndpointInventory {
+		supportedRegions.Insert(inv.Region)
+	}
+	if !supportedRegions.Has(platform.Region) {
+		allErrs = append(allErrs, field.Forbidden(path.Child("privateServiceConnect", "enabled"),
+			fmt.Sprintf("GCP Private Service Connect is not supported in %s region", platform.Region)))
+	}
+
+	if cidr := psc.ServiceAttachmentSubnetCIDR; cidr != "" {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("privateServiceConnect", "serviceAttachmentSubnetCIDR"), cidr, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+func validateSSHBastion(path *field.Path, bastion *hivev1.SSHBastion) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if bastion == nil {
+		return allErrs
+	}
+	if bastion.Host == "" {
+		allErrs = append(allErrs, field.Required(path.Child("host"), "must specify the address of the bastion host"))
+	}
+	if bastion.User == "" {
+		allErrs = append(allErrs, field.Required(path.Child("user"), "must specify the user for the bastion host"))
+	}
+	if bastion.KeySecretRef.Name == "" {
+		allErrs = append(allErrs, field.Required(path.Child("keySecretRef", "name"), "must specify the secret containing the key for the bastion host"))
+	}
+	return allErrs
+}
+
+// validateManualCredentials ensures that the credentials for the CredentialsRequests of a cluster installed with
+// manual credentials come from exactly one source.
+func validateManualCredentials(specPath *field.Path, spec *hivev1.ClusterDeploymentSpec, mc *hivev1.ManualCredentials) field.ErrorList {
+	allErrs := field.ErrorList{}
+	path := specPath.Child("provisioning", "manualCredentials")
+	sources := 0
+	for _, set := range []bool{mc.ManifestsSecretRef != nil, mc.AWSSTS != nil, mc.GCPWorkloadIdentity != nil, mc.AzureWorkloadIdentity != nil} {
+		if set {
+			sources++
+		}
+	}
+	switch {
+	case sources == 0:
+		allErrs = append(allErrs, field.Required(path, "must specify one of manifestsSecretRef, awsSTS, gcpWorkloadIdentity or azureWorkloadIdentity"))
+	case sources > 1:
+		allErrs = append(allErrs, field.Forbidden(path, "only one of manifestsSecretRef, awsSTS, gcpWorkloadIdentity or azureWorkloadIdentity may be set"))
+	}
+	if mc.ManifestsSecretRef != nil && mc.ManifestsSecretRef.Name == "" {
+		allErrs = append(allErrs, field.Required(path.Child("manifestsSecretRef", "name"), "must specify the secret containing the credentials manifests"))
+	}
+	if mc.ServiceAccountIssuer != "" {
+		if u, err := url.Parse(mc.ServiceAccountIssuer); err != nil || u.Scheme != "https" || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(path.Child("serviceAccountIssuer"), mc.ServiceAccountIssuer, "must be an https URL"))
+		}
+	}
+	requireSigningKey := func(mode string) {
+		if spec.BoundServiceAccountSignkingKeySecretRef == nil {
+			allErrs = append(allErrs, field.Required(specPath.Child("boundServiceAccountSigningKeySecretRef"),
+				fmt.Sprintf("must specify a bound service account signing key when using %s", mode)))
+		}
+	}
+	if sts := mc.AWSSTS; sts != nil {
+		stsPath := path.Child("awsSTS")
+		if spec.Platform.AWS == nil {
+			allErrs = append(allErrs, field.Forbidden(stsPath, "awsSTS is only supported for clusters on AWS"))
+		}
+		requireSigningKey("awsSTS")
+		if !strings.HasPrefix(sts.RoleARNPrefix, "arn:") || !strings.Contains(sts.RoleARNPrefix, ":role/") {
+			allErrs = append(allErrs, field.Invalid(stsPath.Child("roleARNPrefix"), sts.RoleARNPrefix, "must be the ARN prefix of IAM roles, such as arn:aws:iam::123456789012:role/mycluster"))
+		}
+	}
+	if wi := mc.GCPWorkloadIdentity; wi != nil {
+		wiPath := path.Child("gcpWorkloadIdentity")
+		if spec.Platform.GCP == nil {
+			allErrs = append(allErrs, field.Forbidden(wiPath, "gcpWorkloadIdentity is only supported for clusters on GCP"))
+		}
+		requireSigningKey("gcpWorkloadIdentity")
+		if wi.ProjectNumber == "" {
+			allErrs = append(allErrs, field.Required(wiPath.Child("projectNumber"), "must specify the number of the project of the workload identity pool"))
+		}
+		if wi.PoolID == "" {
+			allErrs = append(allErrs, field.Required(wiPath.Child("poolID"), "must specify the workload identity pool"))
+		}
+		if wi.ProviderID == "" {
+			allErrs = append(allErrs, field.Required(wiPath.Child("providerID"), "must specify the provider of the workload identity pool"))
+		}
+		allErrs = append(allErrs, validateWorkloadIdentityServiceAccounts(wiPath.Child("serviceAccounts"), wi.ServiceAccounts)...)
+	}
+	if wi := mc.AzureWorkloadIdentity; wi != nil {
+		wiPath := path.Child("azureWorkloadIdentity")
+		if spec.Platform.Azure == nil {
+			allErrs = append(allErrs, field.Forbidden(wiPath, "azureWorkloadIdentity is only supported for clusters on Azure"))
+		}
+		requireSigningKey("azureWorkloadIdentity")
+		if wi.SubscriptionID == "" {
+			allErrs = append(allErrs, field.Required(wiPath.Child("subscriptionID"), "must specify the subscription of the managed identities"))
+		}
+		if wi.TenantID == "" {
+			allErrs = append(allErrs, field.Required(wiPath.Child("tenantID"), "must specify the tenant of the managed identities"))
+		}
+		allErrs = append(allErrs, validateWorkloadIdentityServiceAccounts(wiPath.Child("serviceAccounts"), wi.ServiceAccounts)...)
+	}
+	return allErrs
+}
+
+func validateWorkloadIdentityServiceAccounts(path *field.Path, serviceAccounts []hivev1.WorkloadIdentityServiceAccount) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(serviceAccounts) == 0 {
+		allErrs = append(allErrs, field.Required(path, "must map the CredentialsRequests of the release to cloud identities"))
+	}
+	seen := sets.NewString()
+	for i, sa := range serviceAccounts {
+		saPath := path.Index(i)
+		if sa.SecretNamespace == "" {
+			allErrs = append(allErrs, field.Required(saPath.Child("secretNamespace"), "must specify the namespace of the secret"))
+		}
+		if sa.SecretName == "" {
+			allErrs = append(allErrs, field.Required(saPath.Child("secretName"), "must specify the name of the secret"))
+		}
+		if sa.Identity == "" {
+			allErrs = append(allErrs, field.Required(saPath.Child("identity"), "must specify the cloud identity"))
+		}
+		if key := sa.SecretNamespace + "/" + sa.SecretName; seen.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(saPath, key))
+		} else {
+			seen.Insert(key)
+		}
+	}
+	return allErrs
+}
+
+// validateSyncSetPauseAnnotation validates the deprecated syncset-pause annotation, which is ignored by the controllers
+// unless it is a boolean. spec.paused should be used instead to pause a ClusterDeployment.
+func validateSyncSetPauseAnnotation(cd *hivev1.ClusterDeployment) field.ErrorList {
+	value, present := cd.Annotations[constants.SyncsetPauseAnnotation]
+	if !present {
+		return nil
+	}
+	if _, err := strconv.ParseBool(value); err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("metadata", "annotations", constants.SyncsetPauseAnnotation), value,
+			"must be a boolean, use spec.paused to pause the reconciliation of the cluster deployment")}
+	}
+	return nil
+}
+
+// validateSyncSetApplyWindows ensures that the apply windows stay open for some time, as windows that never open would
+// hold back the changes to syncsets indefinitely.
+func validateSyncSetApplyWindows(path *field.Path, windows []hivev1.SyncSetApplyWindow) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, window := range windows {
+		if window.Duration.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Index(i).Child("duration"), window.Duration.Duration.String(), "must be greater than zero"))
+		}
+	}
+	return allErrs
+}
+
+// validateInstallerEnv ensures that only allowed environment variables are passed through to the installer.
+func (a *ClusterDeploymentValidatingAdmissionHook) validateInstallerEnv(path *field.Path, env []corev1.EnvVar) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allowed := a.allowedInstallerEnv
+	if len(allowed) == 0 {
+		allowed = defaultAllowedInstallerEnv
+	}
+	seen := sets.NewString()
+	for i, envVar := range env {
+		namePath := path.Index(i).Child("name")
+		switch {
+		case envVar.Name == "":
+			allErrs = append(allErrs, field.Required(namePath, "must specify the name of the environment variable"))
+		case seen.Has(envVar.Name):
+			allErrs = append(allErrs, field.Duplicate(namePath, envVar.Name))
+		case reservedInstallerEnv.Has(envVar.Name):
+			allErrs = append(allErrs, field.Forbidden(namePath, fmt.Sprintf("%s is set by Hive and cannot be overridden", envVar.Name)))
+		case !installerEnvAllowed(envVar.Name, allowed):
+			allErrs = append(allErrs, field.NotSupported(namePath, envVar.Name, allowed))
+		}
+		seen.Insert(envVar.Name)
+	}
+	return allErrs
+}
+
+func installerEnvAllowed(name string, allowed []string) bool {
+	for _, a := range allowed {
+		if prefix := strings.TrimSuffix(a, "*"); prefix != a {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if name == a {
+			return true
+		}
+	}
+	return false
+}
+
+// imageReferenceRegex matches the image references that can be pulled, such as registry.example.com:5000/org/image:tag
+// or org/image@sha256:<digest>, following the grammar of the docker distribution reference package.
+var imageReferenceRegex = regexp.MustCompile(`^` +
+	// An optional registry, with an optional port.
+	`(?:(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])(?:\.(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]))*(?::[0-9]+)?/)?` +
+	// The repository path.
+	`[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*` +
+	// An optional tag and an optional digest.
+	`(?::[\w][\w.-]{0,127})?(?:@[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,})?$`)
+
 /* TODO: move to explicit validation for AgentClusterInstall */
 /*
 func validateAgentInstallStrategy(specPath *field.Path, cd *hivev1.ClusterDeployment) field.ErrorList {
@@ -445,15 +782,25 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 	if aws := platform.AWS; aws != nil {
 		numberOfPlatforms++
 		awsPath := path.Child("aws")
-		if aws.CredentialsSecretRef.Name == "" && aws.CredentialsAssumeRole == nil {
+		if aws.CredentialsSecretRef.Name == "" && aws.CredentialsAssumeRole == nil && aws.CredentialsSource == nil {
 			allErrs = append(allErrs, field.Required(awsPath.Child("credentialsSecretRef", "name"), "must specify secrets for AWS access"))
 		}
 		if aws.CredentialsAssumeRole != nil && aws.CredentialsSecretRef.Name != "" {
 			allErrs = append(allErrs, field.Required(awsPath.Child("credentialsAssumeRole"), "cannot specify assume role when credentials secret is provided"))
 		}
+		if aws.CredentialsSource != nil {
+			if aws.CredentialsSecretRef.Name != "" || aws.CredentialsAssumeRole != nil {
+				allErrs = append(allErrs, field.Forbidden(awsPath.Child("credentialsSource"), "cannot specify credentials source when credentials secret or assume role is provided"))
+			}
+			allErrs = append(allErrs, validateAWSCredentialsSource(awsPath.Child("credentialsSource"), aws.CredentialsSource)...)
+		}
 		if aws.Region == "" {
 			allErrs = append(allErrs, field.Required(awsPath.Child("region"), "must specify AWS region"))
 		}
+		if aws.SharedVPC != nil {
+			allErrs = append(allErrs, validateAWSSharedVPC(awsPath.Child("sharedVPC"), aws.SharedVPC)...)
+		}
+		allErrs = append(allErrs, validateAWSServiceEndpoints(awsPath.Child("serviceEndpoints"), aws.ServiceEndpoints)...)
 	}
 	if azure := platform.Azure; azure != nil {
 		numberOfPlatforms++
@@ -467,6 +814,7 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 		if azure.BaseDomainResourceGroupName == "" {
 			allErrs = append(allErrs, field.Required(azurePath.Child("baseDomainResourceGroupName"), "must specify the Azure resource group for the base domain"))
 		}
+		allErrs = append(allErrs, validateAzureExistingResources(azurePath, azure)...)
 	}
 	if gcp := platform.GCP; gcp != nil {
 		numberOfPlatforms++
@@ -477,6 +825,7 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 		if gcp.Region == "" {
 			allErrs = append(allErrs, field.Required(gcpPath.Child("region"), "must specify GCP region"))
 		}
+		allErrs = append(allErrs, validateGCPExistingNetwork(gcpPath, gcp)...)
 	}
 	if openstack := platform.OpenStack; openstack != nil {
 		numberOfPlatforms++
@@ -526,6 +875,46 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 			allErrs = append(allErrs, field.Required(ovirtPath.Child("ovirt_storage_domain_id"), "must specify ovirt_storage_domain_id"))
 		}
 	}
+	if nutanix := platform.Nutanix; nutanix != nil {
+		numberOfPlatforms++
+		nutanixPath := path.Child("nutanix")
+		if nutanix.CredentialsSecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(nutanixPath.Child("credentialsSecretRef", "name"), "must specify secrets for Prism Central access"))
+		}
+		if nutanix.CertificatesSecretRef != nil && nutanix.CertificatesSecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(nutanixPath.Child("certificatesSecretRef", "name"), "must specify name of the secret for Prism Central access"))
+		}
+		if nutanix.PrismCentral.Address == "" {
+			allErrs = append(allErrs, field.Required(nutanixPath.Child("prismCentral", "address"), "must specify Prism Central address"))
+		}
+		if len(nutanix.PrismElements) == 0 {
+			allErrs = append(allErrs, field.Required(nutanixPath.Child("prismElements"), "must specify at least one Prism Element"))
+		}
+		for i, pe := range nutanix.PrismElements {
+			if pe.UUID == "" {
+				allErrs = append(allErrs, field.Required(nutanixPath.Child("prismElements").Index(i).Child("uuid"), "must specify Prism Element UUID"))
+			}
+		}
+		if len(nutanix.SubnetUUIDs) == 0 {
+			allErrs = append(allErrs, field.Required(nutanixPath.Child("subnetUUIDs"), "must specify at least one subnet"))
+		}
+	}
+	if powerVS := platform.PowerVS; powerVS != nil {
+		numberOfPlatforms++
+		powerVSPath := path.Child("powervs")
+		if powerVS.CredentialsSecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(powerVSPath.Child("credentialsSecretRef", "name"), "must specify secrets for IBM Cloud access"))
+		}
+		if powerVS.Region == "" {
+			allErrs = append(allErrs, field.Required(powerVSPath.Child("region"), "must specify IBM Cloud region"))
+		}
+		if powerVS.Zone == "" {
+			allErrs = append(allErrs, field.Required(powerVSPath.Child("zone"), "must specify IBM Cloud zone"))
+		}
+		if powerVS.ServiceInstanceID == "" {
+			allErrs = append(allErrs, field.Required(powerVSPath.Child("serviceInstanceID"), "must specify Power Virtual Server workspace"))
+		}
+	}
 	if baremetal := platform.BareMetal; baremetal != nil {
 		numberOfPlatforms++
 	}
@@ -541,6 +930,147 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 	return allErrs
 }
 
+// azureResourceGroupNameRegex matches valid Azure resource group names, which may not end in a period.
+var azureResourceGroupNameRegex = regexp.MustCompile(`^[-\w\.\(\)]{0,89}[-\w\(\)]$`)
+
+// validateAzureExistingResources validates the existing resource group and VNet into which a cluster is installed.
+func validateAzureExistingResources(path *field.Path, platform *hivev1azure.Platform) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, rg := range []struct {
+		name  string
+		value string
+	}{
+		{name: "resourceGroupName", value: platform.ResourceGroupName},
+		{name: "networkResourceGroupName", value: platform.NetworkResourceGroupName},
+	} {
+		if rg.value != "" && !azureResourceGroupNameRegex.MatchString(rg.value) {
+			allErrs = append(allErrs, field.Invalid(path.Child(rg.name), rg.value, "must be a valid Azure resource group name"))
+		}
+	}
+	// The installer requires the resource group to be empty, which the resource group of the base domain is not.
+	if platform.ResourceGroupName != "" && strings.EqualFold(platform.ResourceGroupName, platform.BaseDomainResourceGroupName) {
+		allErrs = append(allErrs, field.Invalid(path.Child("resourceGroupName"), platform.ResourceGroupName, "must not be the resource group of the base domain"))
+	}
+	networkFields := []struct {
+		name  string
+		value string
+	}{
+		{name: "networkResourceGroupName", value: platform.NetworkResourceGroupName},
+		{name: "virtualNetwork", value: platform.VirtualNetwork},
+		{name: "controlPlaneSubnet", value: platform.ControlPlaneSubnet},
+		{name: "computeSubnet", value: platform.ComputeSubnet},
+	}
+	anySet := false
+	for _, f := range networkFields {
+		anySet = anySet || f.value != ""
+	}
+	if anySet {
+		for _, f := range networkFields {
+			if f.value == "" {
+				allErrs = append(allErrs, field.Required(path.Child(f.name), "networkResourceGroupName, virtualNetwork, controlPlaneSubnet and computeSubnet must be set together"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateAWSSharedVPC validates the shared subnets into which a cluster is installed and the credentials for the
+// network account owning them.
+func validateAWSSharedVPC(path *field.Path, sharedVPC *hivev1aws.SharedVPC) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(sharedVPC.Subnets) == 0 {
+		allErrs = append(allErrs, field.Required(path.Child("subnets"), "must specify the shared subnets"))
+	}
+	for i, subnet := range sharedVPC.Subnets {
+		if !strings.HasPrefix(subnet, "subnet-") {
+			allErrs = append(allErrs, field.Invalid(path.Child("subnets").Index(i), subnet, "must be a subnet ID"))
+		}
+	}
+	hasSecret := sharedVPC.CredentialsSecretRef != nil && sharedVPC.CredentialsSecretRef.Name != ""
+	hasRole := sharedVPC.CredentialsAssumeRole != nil && sharedVPC.CredentialsAssumeRole.RoleARN != ""
+	switch {
+	case !hasSecret && !hasRole:
+		allErrs = append(allErrs, field.Required(path.Child("credentialsSecretRef", "name"), "must specify credentials for the network account"))
+	case hasSecret && hasRole:
+		allErrs = append(allErrs, field.Invalid(path.Child("credentialsAssumeRole"), sharedVPC.CredentialsAssumeRole.RoleARN, "cannot specify assume role when credentials secret is provided"))
+	}
+	return allErrs
+}
+
+// validateAWSCredentialsSource validates the source from which the credentials broker mints short-lived AWS
+// credentials.
+func validateAWSCredentialsSource(path *field.Path, source *hivev1aws.CredentialsSource) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch {
+	case source.WebIdentity == nil && source.Vault == nil:
+		allErrs = append(allErrs, field.Required(path, "must specify either webIdentity or vault"))
+	case source.WebIdentity != nil && source.Vault != nil:
+		allErrs = append(allErrs, field.Forbidden(path.Child("vault"), "cannot specify vault when webIdentity is provided"))
+	}
+	if webIdentity := source.WebIdentity; webIdentity != nil {
+		if !strings.HasPrefix(webIdentity.RoleARN, "arn:") || !strings.Contains(webIdentity.RoleARN, ":role/") {
+			allErrs = append(allErrs, field.Invalid(path.Child("webIdentity", "roleARN"), webIdentity.RoleARN, "must be the ARN of an IAM role"))
+		}
+	}
+	if vault := source.Vault; vault != nil && vault.Role == "" {
+		allErrs = append(allErrs, field.Required(path.Child("vault", "role"), "must specify the Vault role"))
+	}
+	return allErrs
+}
+
+// validateAWSCredentialsSourceBinding validates that the credentials broker allows the resources of the namespace to
+// use the credentials source, as Hive mints the credentials with its own identity.
+func validateAWSCredentialsSourceBinding(path *field.Path, config *hivev1.CredentialsBrokerConfig, namespace string, source *hivev1aws.CredentialsSource) field.ErrorList {
+	if err := awsclient.CheckCredentialsSourceBinding(config, namespace, source); err != nil {
+		return field.ErrorList{field.Forbidden(path, err.Error())}
+	}
+	return nil
+}
+
+// validateAWSServiceEndpoints validates the endpoints overriding those of AWS services.
+func validateAWSServiceEndpoints(path *field.Path, serviceEndpoints []hivev1aws.ServiceEndpoint) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := sets.NewString()
+	for i, e := range serviceEndpoints {
+		if e.Name == "" {
+			allErrs = append(allErrs, field.Required(path.Index(i).Child("name"), "must specify the name of the service"))
+		} else if names.Has(e.Name) {
+			allErrs = append(allErrs, field.Duplicate(path.Index(i).Child("name"), e.Name))
+		}
+		names.Insert(e.Name)
+		if u, err := url.Parse(e.URL); err != nil || u.Scheme != "https" || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(path.Index(i).Child("url"), e.URL, "must be an https URL"))
+		}
+	}
+	return allErrs
+}
+
+// validateGCPExistingNetwork validates the existing network, and the host project of a Shared VPC network, into which
+// a cluster is installed.
+func validateGCPExistingNetwork(path *field.Path, platform *hivev1gcp.Platform) field.ErrorList {
+	allErrs := field.ErrorList{}
+	networkFields := []struct {
+		name  string
+		value string
+	}{
+		{name: "network", value: platform.Network},
+		{name: "controlPlaneSubnet", value: platform.ControlPlaneSubnet},
+		{name: "computeSubnet", value: platform.ComputeSubnet},
+	}
+	anySet := platform.NetworkProjectID != ""
+	for _, f := range networkFields {
+		anySet = anySet || f.value != ""
+	}
+	if anySet {
+		for _, f := range networkFields {
+			if f.value == "" {
+				allErrs = append(allErrs, field.Required(path.Child(f.name), "network, controlPlaneSubnet and computeSubnet must be set together, and when networkProjectID is set"))
+			}
+		}
+	}
+	return allErrs
+}
+
 func validateCanManageDNSForClusterPlatform(specPath *field.Path, spec hivev1.ClusterDeploymentSpec) field.ErrorList {
 	allErrs := field.ErrorList{}
 	canManageDNS := false
@@ -559,6 +1089,117 @@ func validateCanManageDNSForClusterPlatform(specPath *field.Path, spec hivev1.Cl
 	return allErrs
 }
 
+// validateAdoption validates the adoption of an existing cluster. The adoption details may be corrected until the
+// cluster is adopted.
+func validateAdoption(specPath *field.Path, spec hivev1.ClusterDeploymentSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	adoption := spec.Adoption
+	if adoption == nil {
+		return allErrs
+	}
+	path := specPath.Child("adoption")
+	if spec.Provisioning != nil {
+		allErrs = append(allErrs, field.Forbidden(path, "adoption and provisioning cannot be set at the same time"))
+	}
+	if spec.ClusterInstallRef != nil {
+		allErrs = append(allErrs, field.Forbidden(path, "adoption and clusterInstallRef cannot be set at the same time"))
+	}
+	if adoption.InfraID == "" {
+		allErrs = append(allErrs, field.Required(path.Child("infraID"), "must specify the infra ID of the cluster"))
+	}
+	if adoption.AdminKubeconfigSecretRef.Name == "" {
+		allErrs = append(allErrs, field.Required(path.Child("adminKubeconfigSecretRef", "name"), "must specify the admin kubeconfig secret of the cluster"))
+	}
+	if adoption.AdminPasswordSecretRef != nil && adoption.AdminPasswordSecretRef.Name == "" {
+		allErrs = append(allErrs, field.Required(path.Child("adminPasswordSecretRef", "name"), "must specify the admin password secret of the cluster"))
+	}
+	return allErrs
+}
+
+// dnsRoutingHostedZoneID returns the shared hosted zone of the records with a routing policy of a cluster, or an
+// empty string if there is none.
+func dnsRoutingHostedZoneID(spec hivev1.ClusterDeploymentSpec) string {
+	if spec.DNSRouting == nil || spec.DNSRouting.AWS == nil {
+		return ""
+	}
+	return spec.DNSRouting.AWS.HostedZoneID
+}
+
+// validateDNSRoutingHostedZone validates that the shared hosted zone of the records with a routing policy of a
+// cluster is allowed for the managed domain of the cluster. The records are maintained with the credentials of the
+// managed domain, which may be able to write to the hosted zones of other clusters.
+func validateDNSRoutingHostedZone(specPath *field.Path, spec hivev1.ClusterDeploymentSpec, managedDomains []hivev1.ManageDNSConfig) field.ErrorList {
+	hostedZoneID := dnsRoutingHostedZoneID(spec)
+	if hostedZoneID == "" || manageddns.RoutingHostedZoneAllowed(managedDomains, spec.BaseDomain, hostedZoneID) {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(specPath.Child("dnsRouting", "aws", "hostedZoneID"),
+		fmt.Sprintf("hosted zone %s is not allowed for the managed domain of %s", hostedZoneID, spec.BaseDomain))}
+}
+
+// validateDNSRouting validates the records with a routing policy of an adopted cluster with managed DNS.
+func validateDNSRouting(specPath *field.Path, spec hivev1.ClusterDeploymentSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.DNSRouting == nil {
+		return allErrs
+	}
+	path := specPath.Child("dnsRouting")
+	if !spec.ManageDNS {
+		allErrs = append(allErrs, field.Invalid(path, spec.DNSRouting, "requires managed DNS"))
+	}
+	routing := spec.DNSRouting.AWS
+	if routing == nil {
+		return append(allErrs, field.Required(path.Child("aws"), "must specify the routing of the records"))
+	}
+	path = path.Child("aws")
+	if spec.Platform.AWS == nil {
+		allErrs = append(allErrs, field.Invalid(path, routing, "requires the AWS platform"))
+	}
+	if routing.HostedZoneID == "" {
+		allErrs = append(allErrs, field.Required(path.Child("hostedZoneID"), "must specify the shared hosted zone"))
+	}
+	if routing.Domain == "" {
+		allErrs = append(allErrs, field.Required(path.Child("domain"), "must specify the domain of the shared endpoints"))
+	}
+	switch routing.Policy {
+	case hivev1aws.WeightedDNSRoutingPolicy:
+		if routing.Weight == nil {
+			allErrs = append(allErrs, field.Required(path.Child("weight"), "must specify the weight with the Weighted policy"))
+		} else if *routing.Weight < 0 || *routing.Weight > 255 {
+			allErrs = append(allErrs, field.Invalid(path.Child("weight"), *routing.Weight, "must be between 0 and 255"))
+		}
+	case hivev1aws.FailoverDNSRoutingPolicy:
+		if routing.Failover != hivev1aws.PrimaryDNSFailoverRole && routing.Failover != hivev1aws.SecondaryDNSFailoverRole {
+			allErrs = append(allErrs, field.NotSupported(path.Child("failover"), routing.Failover,
+				[]string{string(hivev1aws.PrimaryDNSFailoverRole), string(hivev1aws.SecondaryDNSFailoverRole)}))
+		}
+	case hivev1aws.LatencyDNSRoutingPolicy:
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("policy"), routing.Policy, []string{
+			string(hivev1aws.WeightedDNSRoutingPolicy), string(hivev1aws.LatencyDNSRoutingPolicy), string(hivev1aws.FailoverDNSRoutingPolicy),
+		}))
+	}
+	if routing.Weight != nil && routing.Policy != hivev1aws.WeightedDNSRoutingPolicy {
+		allErrs = append(allErrs, field.Forbidden(path.Child("weight"), "only allowed with the Weighted policy"))
+	}
+	if routing.Failover != "" && routing.Policy != hivev1aws.FailoverDNSRoutingPolicy {
+		allErrs = append(allErrs, field.Forbidden(path.Child("failover"), "only allowed with the Failover policy"))
+	}
+	if hc := routing.HealthCheck; hc != nil {
+		hcPath := path.Child("healthCheck")
+		if hc.Port != nil && (*hc.Port < 1 || *hc.Port > 65535) {
+			allErrs = append(allErrs, field.Invalid(hcPath.Child("port"), *hc.Port, "must be a valid port"))
+		}
+		if hc.FailureThreshold != nil && (*hc.FailureThreshold < 1 || *hc.FailureThreshold > 10) {
+			allErrs = append(allErrs, field.Invalid(hcPath.Child("failureThreshold"), *hc.FailureThreshold, "must be between 1 and 10"))
+		}
+		if hc.Path != "" && hc.Type == hivev1aws.TCPDNSHealthCheckType {
+			allErrs = append(allErrs, field.Forbidden(hcPath.Child("path"), "not allowed with TCP health checks"))
+		}
+	}
+	return allErrs
+}
+
 // validateUpdate specifically validates update operations for ClusterDeployment objects.
 func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
 	contextLogger := log.WithFields(log.Fields{
@@ -640,11 +1281,18 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 
 	allErrs := field.ErrorList{}
 	specPath := field.NewPath("spec")
+	var warnings []string
+
+	// Only validate the annotation when it changes, so that clusters with an existing invalid annotation can still be
+	// updated, such as to remove their finalizers.
+	if cd.Annotations[constants.SyncsetPauseAnnotation] != oldObject.Annotations[constants.SyncsetPauseAnnotation] {
+		allErrs = append(allErrs, a.rules.errors(hivev1.SyncSetPauseAnnotationAdmissionRule, validateSyncSetPauseAnnotation(cd), &warnings, contextLogger)...)
+	}
 
 	if cd.Spec.Installed {
 		if cd.Spec.ClusterMetadata != nil {
 			if oldObject.Spec.Installed {
-				allErrs = append(allErrs, apivalidation.ValidateImmutableField(cd.Spec.ClusterMetadata, oldObject.Spec.ClusterMetadata, specPath.Child("clusterMetadata"))...)
+				allErrs = append(allErrs, validateClusterMetadataUpdate(cd.Spec.ClusterMetadata, oldObject.Spec.ClusterMetadata, specPath.Child("clusterMetadata"))...)
 			}
 		} else {
 			allErrs = append(allErrs, field.Required(specPath.Child("clusterMetadata"), "installed cluster must have cluster metadata"))
@@ -669,6 +1317,23 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 		allErrs = append(allErrs, field.Invalid(specPath.Child("clusterPoolRef"), newPoolRef, "cannot add clusterPoolRef"))
 	}
 
+	allErrs = append(allErrs, a.rules.errors(hivev1.SSHBastionAdmissionRule,
+		validateSSHBastion(specPath.Child("controlPlaneConfig", "sshBastion"), cd.Spec.ControlPlaneConfig.SSHBastion), &warnings, contextLogger)...)
+
+	allErrs = append(allErrs, validateDNSRouting(specPath, cd.Spec)...)
+	// The hosted zone is only checked when it changes, so that clusters keep being updated and deleted after their
+	// hosted zone is removed from HiveConfig.
+	if oldHostedZoneID, newHostedZoneID := dnsRoutingHostedZoneID(oldObject.Spec), dnsRoutingHostedZoneID(cd.Spec); newHostedZoneID != oldHostedZoneID {
+		allErrs = append(allErrs, validateDNSRoutingHostedZone(specPath, cd.Spec, a.managedDomains)...)
+	}
+
+	allErrs = append(allErrs, validateSyncSetApplyWindows(specPath.Child("syncSetApplyWindows"), cd.Spec.SyncSetApplyWindows)...)
+
+	allErrs = append(allErrs, validateAdoption(specPath, cd.Spec)...)
+	if oldObject.Spec.Installed {
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(cd.Spec.Adoption, oldObject.Spec.Adoption, specPath.Child("adoption"))...)
+	}
+
 	// Validate cd.Spec.MachineManagement.TargetNamespace
 	if cd.Spec.MachineManagement != nil {
 		switch oldTargetNamespace, newTargetNamespace := oldObject.Spec.MachineManagement.TargetNamespace, cd.Spec.MachineManagement.TargetNamespace; {
@@ -683,15 +1348,17 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 		contextLogger.WithError(allErrs.ToAggregate()).Info("failed validation")
 		status := errors.NewInvalid(schemaGVK(admissionSpec.Kind).GroupKind(), admissionSpec.Name, allErrs).Status()
 		return &admissionv1beta1.AdmissionResponse{
-			Allowed: false,
-			Result:  &status,
+			Allowed:  false,
+			Result:   &status,
+			Warnings: warnings,
 		}
 	}
 
 	// If we get here, then all checks passed, so the object is valid.
 	contextLogger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
-		Allowed: true,
+		Allowed:  true,
+		Warnings: warnings,
 	}
 }
 
@@ -756,6 +1423,17 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateDelete(request *admis
 	}
 }
 
+// validateClusterMetadataUpdate validates that the cluster metadata of an installed cluster has not changed. The viewer
+// kubeconfig secret reference is the exception, since it is set by the viewerkubeconfig controller after install.
+func validateClusterMetadataUpdate(newMetadata, oldMetadata *hivev1.ClusterMetadata, fldPath *field.Path) field.ErrorList {
+	if oldMetadata == nil {
+		return apivalidation.ValidateImmutableField(newMetadata, oldMetadata, fldPath)
+	}
+	newCopy, oldCopy := newMetadata.DeepCopy(), oldMetadata.DeepCopy()
+	newCopy.ViewerKubeconfigSecretRef, oldCopy.ViewerKubeconfigSecretRef = nil, nil
+	return apivalidation.ValidateImmutableField(newCopy, oldCopy, fldPath)
+}
+
 // hasChangedImmutableField determines if a ClusterDeployment.spec immutable field was changed.
 // it returns the diff string that shows the changes that are not supported
 func hasChangedImmutableField(oldObject, cd *hivev1.ClusterDeploymentSpec) (bool, string) {
@@ -765,6 +1443,10 @@ func hasChangedImmutableField(oldObject, cd *hivev1.ClusterDeploymentSpec) (bool
 		cmpopts.IgnoreFields(hivev1.ClusterDeploymentSpec{}, mutableFields...),
 		cmp.Reporter(r),
 	}
+	if cd.SSHKeyRotation != nil {
+		// The SSH key secret is repointed to the rotated SSH key secret of the cluster by SSH key rotation.
+		opts = append(opts, cmpopts.IgnoreFields(hivev1.Provisioning{}, "SSHPrivateKeySecretRef"))
+	}
 	return !cmp.Equal(oldObject, cd, opts), r.String()
 }
 
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -13,14 +14,17 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1agent "github.com/openshift/hive/apis/hive/v1/agent"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	hivev1nutanix "github.com/openshift/hive/apis/hive/v1/nutanix"
 	hivev1openstack "github.com/openshift/hive/apis/hive/v1/openstack"
 	hivev1ovirt "github.com/openshift/hive/apis/hive/v1/ovirt"
+	hivev1powervs "github.com/openshift/hive/apis/hive/v1/powervs"
 	hivev1vsphere "github.com/openshift/hive/apis/hive/v1/vsphere"
 	hivecontractsv1alpha1 "github.com/openshift/hive/apis/hivecontracts/v1alpha1"
 
@@ -35,6 +39,14 @@ var validTestManagedDomains = []string{
 	"ccc.com",
 }
 
+var testManagedDomains = []hivev1.ManageDNSConfig{{
+	Domains: []string{"foo.aaa.com"},
+	AWS: &hivev1.ManageDNSAWSConfig{
+		CredentialsSecretRef: corev1.LocalObjectReference{Name: "dns-creds"},
+		RoutingHostedZoneIDs: []string{"Z1234"},
+	},
+}}
+
 func clusterDeploymentTemplate() *hivev1.ClusterDeployment {
 	return &hivev1.ClusterDeployment{
 		Spec: hivev1.ClusterDeploymentSpec{
@@ -66,6 +78,35 @@ func clusterDeploymentWithManagedDomain(domain string) *hivev1.ClusterDeployment
 	return cd
 }
 
+func clusterDeploymentWithDNSRouting(policy hivev1aws.DNSRoutingPolicy) *hivev1.ClusterDeployment {
+	cd := clusterDeploymentWithManagedDomain("bar.foo.aaa.com")
+	cd.Spec.DNSRouting = &hivev1.DNSRouting{
+		AWS: &hivev1aws.DNSRouting{
+			HostedZoneID: "Z1234",
+			Domain:       "shared.aaa.com",
+			Policy:       policy,
+			HealthCheck:  &hivev1aws.DNSHealthCheck{Path: "/readyz"},
+		},
+	}
+	switch policy {
+	case hivev1aws.WeightedDNSRoutingPolicy:
+		cd.Spec.DNSRouting.AWS.Weight = pointer.Int64Ptr(100)
+	case hivev1aws.FailoverDNSRoutingPolicy:
+		cd.Spec.DNSRouting.AWS.Failover = hivev1aws.PrimaryDNSFailoverRole
+	}
+	return cd
+}
+
+func clusterDeploymentWithAdoption() *hivev1.ClusterDeployment {
+	cd := validAWSClusterDeployment()
+	cd.Spec.Provisioning = nil
+	cd.Spec.Adoption = &hivev1.ClusterAdoption{
+		InfraID:                  "sameclustername-lqmsh",
+		AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: "admin-kubeconfig"},
+	}
+	return cd
+}
+
 func validGCPClusterDeployment() *hivev1.ClusterDeployment {
 	cd := clusterDeploymentTemplate()
 	cd.Spec.Platform.GCP = &hivev1gcp.Platform{
@@ -84,6 +125,17 @@ func validAWSClusterDeployment() *hivev1.ClusterDeployment {
 	return cd
 }
 
+const testCredentialsBrokerNamespace = "test-namespace"
+
+// testCredentialsBroker binds the credentials sources of the tests to their namespace.
+var testCredentialsBroker = &hivev1.CredentialsBrokerConfig{
+	Bindings: []hivev1.CredentialsBrokerBinding{{
+		Namespace:  testCredentialsBrokerNamespace,
+		RoleARNs:   []string{"arn:aws:iam::123456789012:role/installer", "arn:aws:iam::123456789012:role/dns"},
+		VaultRoles: []string{"aws/installer"},
+	}},
+}
+
 func validAWSClusterDeploymentFromPool(poolNS, poolName, claimName string) *hivev1.ClusterDeployment {
 	cd := clusterDeploymentTemplate()
 	cd.Spec.Platform.AWS = &hivev1aws.Platform{
@@ -143,6 +195,28 @@ func validOvirtClusterDeployment() *hivev1.ClusterDeployment {
 	return cd
 }
 
+func validNutanixClusterDeployment() *hivev1.ClusterDeployment {
+	cd := clusterDeploymentTemplate()
+	cd.Spec.Platform.Nutanix = &hivev1nutanix.Platform{
+		PrismCentral:         hivev1nutanix.PrismEndpoint{Address: "prism.example.com"},
+		PrismElements:        []hivev1nutanix.PrismElement{{UUID: "fake-pe-uuid", Endpoint: hivev1nutanix.PrismEndpoint{Address: "pe.example.com"}}},
+		SubnetUUIDs:          []string{"fake-subnet-uuid"},
+		CredentialsSecretRef: corev1.LocalObjectReference{Name: "fake-creds-secret"},
+	}
+	return cd
+}
+
+func validPowerVSClusterDeployment() *hivev1.ClusterDeployment {
+	cd := clusterDeploymentTemplate()
+	cd.Spec.Platform.PowerVS = &hivev1powervs.Platform{
+		CredentialsSecretRef: corev1.LocalObjectReference{Name: "fake-creds-secret"},
+		Region:               "dal",
+		Zone:                 "dal12",
+		ServiceInstanceID:    "fake-workspace-guid",
+	}
+	return cd
+}
+
 func validAgentBareMetalClusterDeployment() *hivev1.ClusterDeployment {
 	cd := clusterDeploymentTemplate()
 	cd.Spec.Platform.AgentBareMetal = &hivev1agent.BareMetalPlatform{
@@ -215,7 +289,9 @@ func TestClusterDeploymentValidate(t *testing.T) {
 		gvr                 *metav1.GroupVersionResource
 		enabledFeatureGates []string
 		awsPrivateLink      *hivev1.AWSPrivateLinkConfig
+		gcpPSC              *hivev1.GCPPrivateServiceConnectConfig
 		supportedContracts  contracts.SupportedContractImplementationsList
+		allowedInstallerEnv []string
 	}{
 		{
 			name:            "Test valid create",
@@ -364,6 +440,133 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Update,
 			expectedAllowed: false,
 		},
+		{
+			name: "Test setting viewer kubeconfig secret reference after installed",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+					InfraID: "old-infra-id",
+				}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+					InfraID:                   "old-infra-id",
+					ViewerKubeconfigSecretRef: &corev1.LocalObjectReference{Name: "sameclustername-viewer-kubeconfig"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test Update ViewerKubeconfig",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ViewerKubeconfig = &hivev1.ViewerKubeconfig{ClusterRoleName: "view"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test Update SyncSetApplyWindows",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.SyncSetApplyWindows = []hivev1.SyncSetApplyWindow{{
+					Days:     []hivev1.SyncSetApplyWindowDay{"Saturday"},
+					Start:    "02:00",
+					Duration: metav1.Duration{Duration: 2 * time.Hour},
+				}}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test Update SyncSetApplyWindows with zero duration",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.SyncSetApplyWindows = []hivev1.SyncSetApplyWindow{{
+					Start:    "02:00",
+					Duration: metav1.Duration{Duration: 0},
+				}}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test Update SyncSetApplyWindows with negative duration",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.SyncSetApplyWindows = []hivev1.SyncSetApplyWindow{{
+					Start:    "02:00",
+					Duration: metav1.Duration{Duration: -time.Hour},
+				}}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with SyncSetApplyWindows",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.SyncSetApplyWindows = []hivev1.SyncSetApplyWindow{{
+					Start:    "02:00",
+					Duration: metav1.Duration{Duration: 2 * time.Hour},
+				}}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with SyncSetApplyWindows with zero duration",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.SyncSetApplyWindows = []hivev1.SyncSetApplyWindow{{
+					Start:    "02:00",
+					Duration: metav1.Duration{Duration: 0},
+				}}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test Update AdditionalTrustBundle",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.AdditionalTrustBundle = &hivev1.AdditionalTrustBundle{
+					ConfigMapRef:  corev1.LocalObjectReference{Name: "my-ca-bundle"},
+					SyncToCluster: true,
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test Update CloudClientProxySecretRef",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.CloudClientProxySecretRef = &corev1.LocalObjectReference{Name: "cloud-proxy"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
 		{
 			name:      "Test Update PreserveOnDelete",
 			oldObject: validAWSClusterDeployment(),
@@ -653,68 +856,63 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			expectedAllowed: false,
 		},
 		{
-			name:      "Azure update region",
-			oldObject: validAzureClusterDeployment(),
+			name: "AWS create with shared VPC",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAzureClusterDeployment()
-				cd.Spec.Platform.Azure.Region = ""
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.SharedVPC = &hivev1aws.SharedVPC{
+					Subnets:              []string{"subnet-1", "subnet-2"},
+					CredentialsSecretRef: &corev1.LocalObjectReference{Name: "network-creds"},
+				}
 				return cd
 			}(),
-			operation:       admissionv1beta1.Update,
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "AWS create with shared VPC without subnets",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.SharedVPC = &hivev1aws.SharedVPC{
+					CredentialsAssumeRole: &hivev1aws.AssumeRole{RoleARN: "arn:aws:iam::123456789012:role/network"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
-		// TODO: ensure Azure clusterDeployments have necessary info for
-		// machine sets
-		// {
-		// 	name: "not setting instance type on Azure machine pool",
-		// 	newObject: func() *hivev1.ClusterDeployment {
-		// 		cd := validAzureClusterDeployment()
-		// 		cd.Spec.Compute = []hivev1.MachinePool{
-		// 			{
-		// 				Name: "testmachinepool",
-		// 				Platform: hivev1.MachinePoolPlatform{
-		// 					Azure: &hivev1azure.MachinePool{
-		// 						InstanceType: "",
-		// 					},
-		// 				},
-		// 			},
-		// 		}
-		// 		return cd
-		// 	}(),
-		// 	operation:       admissionv1beta1.Create,
-		// 	expectedAllowed: false,
-		// },
 		{
-			name: "create with two cloud platforms",
+			name: "AWS create with shared VPC without network credentials",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAzureClusterDeployment()
-				awsCD := validAWSClusterDeployment()
-				cd.Spec.Platform.AWS = awsCD.Spec.Platform.AWS
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.SharedVPC = &hivev1aws.SharedVPC{
+					Subnets: []string{"subnet-1"},
+				}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "create with no cloud platforms",
+			name: "AWS create with shared VPC with both network credentials",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAzureClusterDeployment()
-				cd.Spec.Platform.Azure = nil
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.SharedVPC = &hivev1aws.SharedVPC{
+					Subnets:               []string{"subnet-1"},
+					CredentialsSecretRef:  &corev1.LocalObjectReference{Name: "network-creds"},
+					CredentialsAssumeRole: &hivev1aws.AssumeRole{RoleARN: "arn:aws:iam::123456789012:role/network"},
+				}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "ingress with serving certificate",
+			name: "AWS create with web identity credentials source",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validClusterDeploymentWithIngress()
-				cd.Spec.Ingress[0].ServingCertificate = "test-serving-cert"
-				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
-					{
-						Name:                 "test-serving-cert",
-						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
-					},
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.CredentialsSecretRef.Name = ""
+				cd.Spec.Platform.AWS.CredentialsSource = &hivev1aws.CredentialsSource{
+					WebIdentity: &hivev1aws.WebIdentityCredentialsSource{RoleARN: "arn:aws:iam::123456789012:role/installer"},
 				}
 				return cd
 			}(),
@@ -722,44 +920,38 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			expectedAllowed: true,
 		},
 		{
-			name: "ingress with missing serving certificate",
+			name: "AWS create with Vault credentials source",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validClusterDeploymentWithIngress()
-				cd.Spec.Ingress[0].ServingCertificate = "missing-serving-cert"
-				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
-					{
-						Name:                 "test-serving-cert",
-						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
-					},
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.CredentialsSecretRef.Name = ""
+				cd.Spec.Platform.AWS.CredentialsSource = &hivev1aws.CredentialsSource{
+					Vault: &hivev1aws.VaultCredentialsSource{Role: "installer"},
 				}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
-			expectedAllowed: false,
+			expectedAllowed: true,
 		},
 		{
-			name: "valid serving certificate",
+			name: "AWS create with web identity credentials source not bound to namespace",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
-					{
-						Name:                 "test-serving-cert",
-						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
-					},
+				cd.Spec.Platform.AWS.CredentialsSecretRef.Name = ""
+				cd.Spec.Platform.AWS.CredentialsSource = &hivev1aws.CredentialsSource{
+					WebIdentity: &hivev1aws.WebIdentityCredentialsSource{RoleARN: "arn:aws:iam::210987654321:role/installer"},
 				}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
-			expectedAllowed: true,
+			expectedAllowed: false,
 		},
 		{
-			name: "serving certificate without name",
+			name: "AWS create with Vault credentials source not bound to namespace",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
-					{
-						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
-					},
+				cd.Spec.Platform.AWS.CredentialsSecretRef.Name = ""
+				cd.Spec.Platform.AWS.CredentialsSource = &hivev1aws.CredentialsSource{
+					Vault: &hivev1aws.VaultCredentialsSource{Role: "installer", MountPath: "aws-other-tenant"},
 				}
 				return cd
 			}(),
@@ -767,13 +959,11 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			expectedAllowed: false,
 		},
 		{
-			name: "serving certificate without secret reference",
+			name: "AWS create with credentials source and credentials secret",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
-					{
-						Name: "test-serving-cert",
-					},
+				cd.Spec.Platform.AWS.CredentialsSource = &hivev1aws.CredentialsSource{
+					Vault: &hivev1aws.VaultCredentialsSource{Role: "installer"},
 				}
 				return cd
 			}(),
@@ -781,333 +971,1376 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			expectedAllowed: false,
 		},
 		{
-			name: "InstallConfig is missing",
+			name: "AWS create with both web identity and Vault credentials source",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.Provisioning.InstallConfigSecretRef = nil
+				cd.Spec.Platform.AWS.CredentialsSecretRef.Name = ""
+				cd.Spec.Platform.AWS.CredentialsSource = &hivev1aws.CredentialsSource{
+					WebIdentity: &hivev1aws.WebIdentityCredentialsSource{RoleARN: "arn:aws:iam::123456789012:role/installer"},
+					Vault:       &hivev1aws.VaultCredentialsSource{Role: "installer"},
+				}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "ClusterInstallRef is set with Provisioning",
+			name: "AWS create with web identity credentials source with invalid role ARN",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.ClusterInstallRef = &hivev1.ClusterInstallLocalReference{
-					Group:   "hive.openshift.io",
-					Version: "v1",
-					Kind:    "FakeClusterInstall",
-					Name:    "dummy",
+				cd.Spec.Platform.AWS.CredentialsSecretRef.Name = ""
+				cd.Spec.Platform.AWS.CredentialsSource = &hivev1aws.CredentialsSource{
+					WebIdentity: &hivev1aws.WebIdentityCredentialsSource{RoleARN: "installer"},
 				}
 				return cd
 			}(),
-			enabledFeatureGates: []string{hivev1.FeatureGateAgentInstallStrategy},
-			operation:           admissionv1beta1.Create,
-			expectedAllowed:     false,
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
 		},
 		{
-			name: "ClusterInstallRef is set when no supported",
+			name: "AWS create with service endpoints",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.Provisioning = nil
-				cd.Spec.ClusterInstallRef = &hivev1.ClusterInstallLocalReference{
-					Group:   "hive.openshift.io",
-					Version: "v1",
-					Kind:    "FakeClusterInstall",
-					Name:    "dummy",
+				cd.Spec.Platform.AWS.Region = "us-iso-east-1"
+				cd.Spec.Platform.AWS.ServiceEndpoints = []hivev1aws.ServiceEndpoint{
+					{Name: "ec2", URL: "https://ec2.us-iso-east-1.c2s.ic.gov"},
+					{Name: "route53", URL: "https://route53.c2s.ic.gov"},
 				}
 				return cd
 			}(),
-			enabledFeatureGates: []string{hivev1.FeatureGateAgentInstallStrategy},
-			operation:           admissionv1beta1.Create,
-			expectedAllowed:     false,
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
 		},
 		{
-			name: "ClusterInstallRef is set when not supported",
+			name: "AWS create with duplicate service endpoints",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.Provisioning = nil
-				cd.Spec.ClusterInstallRef = &hivev1.ClusterInstallLocalReference{
-					Group:   "hive.openshift.io",
-					Version: "v1",
-					Kind:    "FakeClusterInstall",
-					Name:    "dummy",
+				cd.Spec.Platform.AWS.ServiceEndpoints = []hivev1aws.ServiceEndpoint{
+					{Name: "ec2", URL: "https://ec2.us-iso-east-1.c2s.ic.gov"},
+					{Name: "ec2", URL: "https://ec2.us-iso-west-1.c2s.ic.gov"},
 				}
 				return cd
 			}(),
-			enabledFeatureGates: []string{hivev1.FeatureGateAgentInstallStrategy},
-			supportedContracts: contracts.SupportedContractImplementationsList{{
-				Name: hivecontractsv1alpha1.ClusterInstallContractName,
-				Supported: []contracts.ContractImplementation{{
-					Group:   "hive.openshift.io",
-					Version: "v1",
-					Kind:    "AgentClusterInstall",
-				}},
-			}},
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "ClusterInstallRef is set when supported",
+			name: "AWS create with http service endpoint",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.Provisioning = nil
-				cd.Spec.ClusterInstallRef = &hivev1.ClusterInstallLocalReference{
-					Group:   "hive.openshift.io",
-					Version: "v1",
-					Kind:    "FakeClusterInstall",
-					Name:    "dummy",
+				cd.Spec.Platform.AWS.ServiceEndpoints = []hivev1aws.ServiceEndpoint{
+					{Name: "ec2", URL: "http://ec2.us-iso-east-1.c2s.ic.gov"},
 				}
 				return cd
 			}(),
-			enabledFeatureGates: []string{hivev1.FeatureGateAgentInstallStrategy},
-			supportedContracts: contracts.SupportedContractImplementationsList{{
-				Name: hivecontractsv1alpha1.ClusterInstallContractName,
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "AWS create with shared VPC with invalid subnet",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.SharedVPC = &hivev1aws.SharedVPC{
+					Subnets:              []string{"vpc-1"},
+					CredentialsSecretRef: &corev1.LocalObjectReference{Name: "network-creds"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "AWS create with failover DNS routing",
+			newObject:       clusterDeploymentWithDNSRouting(hivev1aws.FailoverDNSRoutingPolicy),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "AWS create with weighted DNS routing without weight",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentWithDNSRouting(hivev1aws.WeightedDNSRoutingPolicy)
+				cd.Spec.DNSRouting.AWS.Weight = nil
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "AWS create with latency DNS routing and failover role",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentWithDNSRouting(hivev1aws.LatencyDNSRoutingPolicy)
+				cd.Spec.DNSRouting.AWS.Failover = hivev1aws.PrimaryDNSFailoverRole
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "AWS create with DNS routing without managed DNS",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentWithDNSRouting(hivev1aws.LatencyDNSRoutingPolicy)
+				cd.Spec.ManageDNS = false
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "AWS create with TCP health check with path",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentWithDNSRouting(hivev1aws.FailoverDNSRoutingPolicy)
+				cd.Spec.DNSRouting.AWS.HealthCheck.Type = hivev1aws.TCPDNSHealthCheckType
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "AWS create with DNS routing in a hosted zone that is not allowed",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentWithDNSRouting(hivev1aws.FailoverDNSRoutingPolicy)
+				cd.Spec.DNSRouting.AWS.HostedZoneID = "Z5678"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "AWS create with DNS routing in a hosted zone of another managed domain",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentWithDNSRouting(hivev1aws.FailoverDNSRoutingPolicy)
+				cd.Spec.BaseDomain = "bar.bbb.com"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "AWS update of DNS routing to a hosted zone that is not allowed",
+			oldObject: clusterDeploymentWithDNSRouting(hivev1aws.FailoverDNSRoutingPolicy),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentWithDNSRouting(hivev1aws.FailoverDNSRoutingPolicy)
+				cd.Spec.DNSRouting.AWS.HostedZoneID = "Z5678"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "AWS update of DNS routing in a hosted zone that is no longer allowed",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentWithDNSRouting(hivev1aws.FailoverDNSRoutingPolicy)
+				cd.Spec.DNSRouting.AWS.HostedZoneID = "Z5678"
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentWithDNSRouting(hivev1aws.FailoverDNSRoutingPolicy)
+				cd.Spec.DNSRouting.AWS.HostedZoneID = "Z5678"
+				cd.Spec.DNSRouting.AWS.Failover = hivev1aws.SecondaryDNSFailoverRole
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "AWS update of failover role",
+			oldObject: clusterDeploymentWithDNSRouting(hivev1aws.FailoverDNSRoutingPolicy),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentWithDNSRouting(hivev1aws.FailoverDNSRoutingPolicy)
+				cd.Spec.DNSRouting.AWS.Failover = hivev1aws.SecondaryDNSFailoverRole
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "create with adoption",
+			newObject:       clusterDeploymentWithAdoption(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create with adoption and provisioning",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentWithAdoption()
+				cd.Spec.Provisioning = clusterDeploymentTemplate().Spec.Provisioning
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with adoption without infra ID",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentWithAdoption()
+				cd.Spec.Adoption.InfraID = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with adoption without admin kubeconfig secret",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentWithAdoption()
+				cd.Spec.Adoption.AdminKubeconfigSecretRef.Name = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "update of adoption before the cluster is adopted",
+			oldObject: clusterDeploymentWithAdoption(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentWithAdoption()
+				cd.Spec.Adoption.InfraID = "sameclustername-abcde"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "update of adoption after the cluster is adopted",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentWithAdoption()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{InfraID: "sameclustername-lqmsh"}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := clusterDeploymentWithAdoption()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{InfraID: "sameclustername-lqmsh"}
+				cd.Spec.Adoption.InfraID = "sameclustername-abcde"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "GCP create in Shared VPC",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.NetworkProjectID = "host-project"
+				cd.Spec.Platform.GCP.Network = "shared-network"
+				cd.Spec.Platform.GCP.ControlPlaneSubnet = "control-plane-subnet"
+				cd.Spec.Platform.GCP.ComputeSubnet = "compute-subnet"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "GCP create in Shared VPC without subnets",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.NetworkProjectID = "host-project"
+				cd.Spec.Platform.GCP.Network = "shared-network"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "GCP create in existing network without subnets",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.Network = "existing-network"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Azure create in existing resource group and VNet",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.ResourceGroupName = "existing-rg"
+				cd.Spec.Platform.Azure.NetworkResourceGroupName = "network-rg"
+				cd.Spec.Platform.Azure.VirtualNetwork = "existing-vnet"
+				cd.Spec.Platform.Azure.ControlPlaneSubnet = "control-plane-subnet"
+				cd.Spec.Platform.Azure.ComputeSubnet = "compute-subnet"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Azure create invalid resource group name",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.ResourceGroupName = "existing-rg."
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Azure create in base domain resource group",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.ResourceGroupName = "os4-common"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Azure create VNet without subnets",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.NetworkResourceGroupName = "network-rg"
+				cd.Spec.Platform.Azure.VirtualNetwork = "existing-vnet"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Azure update region",
+			oldObject: validAzureClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.Region = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		// TODO: ensure Azure clusterDeployments have necessary info for
+		// machine sets
+		// {
+		// 	name: "not setting instance type on Azure machine pool",
+		// 	newObject: func() *hivev1.ClusterDeployment {
+		// 		cd := validAzureClusterDeployment()
+		// 		cd.Spec.Compute = []hivev1.MachinePool{
+		// 			{
+		// 				Name: "testmachinepool",
+		// 				Platform: hivev1.MachinePoolPlatform{
+		// 					Azure: &hivev1azure.MachinePool{
+		// 						InstanceType: "",
+		// 					},
+		// 				},
+		// 			},
+		// 		}
+		// 		return cd
+		// 	}(),
+		// 	operation:       admissionv1beta1.Create,
+		// 	expectedAllowed: false,
+		// },
+		{
+			name: "create with two cloud platforms",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				awsCD := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS = awsCD.Spec.Platform.AWS
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with no cloud platforms",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure = nil
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "ingress with serving certificate",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validClusterDeploymentWithIngress()
+				cd.Spec.Ingress[0].ServingCertificate = "test-serving-cert"
+				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
+					{
+						Name:                 "test-serving-cert",
+						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "ingress with missing serving certificate",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validClusterDeploymentWithIngress()
+				cd.Spec.Ingress[0].ServingCertificate = "missing-serving-cert"
+				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
+					{
+						Name:                 "test-serving-cert",
+						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "valid serving certificate",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
+					{
+						Name:                 "test-serving-cert",
+						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "serving certificate without name",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
+					{
+						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "serving certificate without secret reference",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
+					{
+						Name: "test-serving-cert",
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "InstallConfig is missing",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.InstallConfigSecretRef = nil
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "ClusterInstallRef is set with Provisioning",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ClusterInstallRef = &hivev1.ClusterInstallLocalReference{
+					Group:   "hive.openshift.io",
+					Version: "v1",
+					Kind:    "FakeClusterInstall",
+					Name:    "dummy",
+				}
+				return cd
+			}(),
+			enabledFeatureGates: []string{hivev1.FeatureGateAgentInstallStrategy},
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     false,
+		},
+		{
+			name: "ClusterInstallRef is set when no supported",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning = nil
+				cd.Spec.ClusterInstallRef = &hivev1.ClusterInstallLocalReference{
+					Group:   "hive.openshift.io",
+					Version: "v1",
+					Kind:    "FakeClusterInstall",
+					Name:    "dummy",
+				}
+				return cd
+			}(),
+			enabledFeatureGates: []string{hivev1.FeatureGateAgentInstallStrategy},
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     false,
+		},
+		{
+			name: "ClusterInstallRef is set when not supported",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning = nil
+				cd.Spec.ClusterInstallRef = &hivev1.ClusterInstallLocalReference{
+					Group:   "hive.openshift.io",
+					Version: "v1",
+					Kind:    "FakeClusterInstall",
+					Name:    "dummy",
+				}
+				return cd
+			}(),
+			enabledFeatureGates: []string{hivev1.FeatureGateAgentInstallStrategy},
+			supportedContracts: contracts.SupportedContractImplementationsList{{
+				Name: hivecontractsv1alpha1.ClusterInstallContractName,
+				Supported: []contracts.ContractImplementation{{
+					Group:   "hive.openshift.io",
+					Version: "v1",
+					Kind:    "AgentClusterInstall",
+				}},
+			}},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "ClusterInstallRef is set when supported",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning = nil
+				cd.Spec.ClusterInstallRef = &hivev1.ClusterInstallLocalReference{
+					Group:   "hive.openshift.io",
+					Version: "v1",
+					Kind:    "FakeClusterInstall",
+					Name:    "dummy",
+				}
+				return cd
+			}(),
+			enabledFeatureGates: []string{hivev1.FeatureGateAgentInstallStrategy},
+			supportedContracts: contracts.SupportedContractImplementationsList{{
+				Name: hivecontractsv1alpha1.ClusterInstallContractName,
 				Supported: []contracts.ContractImplementation{{
 					Group:   "hive.openshift.io",
 					Version: "v1",
 					Kind:    "FakeClusterInstall",
 				}},
-			}},
+			}},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "ClusterInstallRef and Provisioning both not set",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning = nil
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "valid GCP clusterdeployment",
+			newObject:       validGCPClusterDeployment(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Provisioning is missing",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning = nil
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "OpenStack create valid",
+			newObject:       validOpenStackClusterDeployment(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "OpenStack create without secret reference for certificates",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validOpenStackClusterDeployment()
+				cd.Spec.Platform.OpenStack.CertificatesSecretRef = &corev1.LocalObjectReference{Name: ""}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "OpenStack create with valid secret reference for certificates",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validOpenStackClusterDeployment()
+				cd.Spec.Platform.OpenStack.CertificatesSecretRef = &corev1.LocalObjectReference{Name: "openstack-certificates"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test valid delete",
+			oldObject:       validAWSClusterDeployment(),
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test protected delete",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				if cd.Annotations == nil {
+					cd.Annotations = make(map[string]string, 1)
+				}
+				cd.Annotations[constants.ProtectedDeleteAnnotation] = "true"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test protected delete annotation false",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				if cd.Annotations == nil {
+					cd.Annotations = make(map[string]string, 1)
+				}
+				cd.Annotations[constants.ProtectedDeleteAnnotation] = "false"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test delete on OpenShift 3.11",
+			oldObject:       nil,
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: true,
+		},
+		{
+			name:            "vSphere create valid",
+			newObject:       validVSphereClusterDeployment(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "oVirt create valid",
+			newObject:       validOvirtClusterDeployment(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Nutanix create valid",
+			newObject:       validNutanixClusterDeployment(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Nutanix create without Prism Elements",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validNutanixClusterDeployment()
+				cd.Spec.Platform.Nutanix.PrismElements = nil
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Nutanix create without credentials",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validNutanixClusterDeployment()
+				cd.Spec.Platform.Nutanix.CredentialsSecretRef.Name = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "PowerVS create valid",
+			newObject:       validPowerVSClusterDeployment(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "PowerVS create without workspace",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validPowerVSClusterDeployment()
+				cd.Spec.Platform.PowerVS.ServiceInstanceID = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Block create with targetNamespace set",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.MachineManagement = &hivev1.MachineManagement{
+					Central:         &hivev1.CentralMachineManagement{},
+					TargetNamespace: "nope",
+				}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     false,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+		},
+		{
+			name: "targetNamespace can be set if unset",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.MachineManagement = &hivev1.MachineManagement{
+					Central: &hivev1.CentralMachineManagement{},
+				}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.MachineManagement = &hivev1.MachineManagement{
+					Central:         &hivev1.CentralMachineManagement{},
+					TargetNamespace: "okay",
+				}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Update,
+			expectedAllowed:     true,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+		},
+		{
+			name: "targetNamespace is immutable once set",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.MachineManagement = &hivev1.MachineManagement{
+					Central:         &hivev1.CentralMachineManagement{},
+					TargetNamespace: "yes",
+				}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.MachineManagement = &hivev1.MachineManagement{
+					Central:         &hivev1.CentralMachineManagement{},
+					TargetNamespace: "nope",
+				}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Update,
+			expectedAllowed:     false,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+		},
+		{
+			name: "private link set, but disabled, no config",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     true,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+		},
+		{
+			name: "private link enabled, no config",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{Enabled: true}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     false,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+		},
+		{
+			name: "private link enabled, no inventory",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{Enabled: true}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     false,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			awsPrivateLink:      &hivev1.AWSPrivateLinkConfig{},
+		},
+		{
+			name: "private link enabled, no inventory in the given region",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{Enabled: true}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     false,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			awsPrivateLink: &hivev1.AWSPrivateLinkConfig{
+				EndpointVPCInventory: []hivev1.AWSPrivateLinkInventory{{
+					AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
+						Region: "some-region",
+						VPCID:  "vpc-id",
+					},
+				}},
+			},
+		},
+		{
+			name: "private link enabled, some inventory in given region",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{Enabled: true}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     true,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			awsPrivateLink: &hivev1.AWSPrivateLinkConfig{
+				EndpointVPCInventory: []hivev1.AWSPrivateLinkInventory{{
+					AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
+						Region: "some-region",
+						VPCID:  "vpc-id",
+					},
+				}, {
+					AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
+						Region: "test-region",
+						VPCID:  "vpc-id-2",
+					},
+				}},
+			},
+		},
+		{
+			name: "private link enabled, inventory serving the given region",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{Enabled: true}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     true,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			awsPrivateLink: &hivev1.AWSPrivateLinkConfig{
+				EndpointVPCInventory: []hivev1.AWSPrivateLinkInventory{{
+					AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
+						Region: "some-region",
+						VPCID:  "vpc-id",
+					},
+					ServedRegions: []string{"test-region"},
+				}},
+			},
+		},
+		{
+			name: "private link enabled, pre-created endpoint, no inventory",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{
+					Enabled:       true,
+					VPCEndpointID: "vpce-12345",
+				}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     true,
+			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			awsPrivateLink:      &hivev1.AWSPrivateLinkConfig{},
+		},
+		{
+			name: "paused",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Paused = true
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:      "paused on update",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Paused = true
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "syncset pause annotation",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Annotations = map[string]string{constants.SyncsetPauseAnnotation: "true"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "syncset pause annotation not a boolean",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Annotations = map[string]string{constants.SyncsetPauseAnnotation: "yes please"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:      "ssh key rotation on update",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.SSHKeyRotation = &hivev1.SSHKeyRotation{RotationID: "rotation-1"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "ssh private key secret repointed by ssh key rotation on update",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.SSHPrivateKeySecretRef = &corev1.LocalObjectReference{Name: "shared-ssh-key"}
+				cd.Spec.SSHKeyRotation = &hivev1.SSHKeyRotation{RotationID: "rotation-1"}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.SSHPrivateKeySecretRef = &corev1.LocalObjectReference{Name: "sameclustername-rotated-ssh-key"}
+				cd.Spec.SSHKeyRotation = &hivev1.SSHKeyRotation{RotationID: "rotation-1"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "ssh private key secret changed without ssh key rotation on update",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.SSHPrivateKeySecretRef = &corev1.LocalObjectReference{Name: "shared-ssh-key"}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.SSHPrivateKeySecretRef = &corev1.LocalObjectReference{Name: "other-ssh-key"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "update with existing syncset pause annotation not a boolean",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Annotations = map[string]string{constants.SyncsetPauseAnnotation: "yes please"}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Annotations = map[string]string{constants.SyncsetPauseAnnotation: "yes please"}
+				cd.Finalizers = []string{}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "syncset pause annotation not a boolean on create",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Annotations = map[string]string{constants.SyncsetPauseAnnotation: "yes please"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "ssh bastion",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ControlPlaneConfig.SSHBastion = &hivev1.SSHBastion{
+					Host:         "bastion.example.com",
+					User:         "hive",
+					KeySecretRef: corev1.LocalObjectReference{Name: "bastion-key"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "ssh bastion without key secret",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ControlPlaneConfig.SSHBastion = &hivev1.SSHBastion{
+					Host: "bastion.example.com",
+					User: "hive",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "ssh bastion added without host",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ControlPlaneConfig.SSHBastion = &hivev1.SSHBastion{
+					User:         "hive",
+					KeySecretRef: corev1.LocalObjectReference{Name: "bastion-key"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "private service connect enabled, no config",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.PrivateServiceConnect = &hivev1gcp.PrivateServiceConnectAccess{Enabled: true}
+				return cd
+			}(),
 			operation:       admissionv1beta1.Create,
-			expectedAllowed: true,
+			expectedAllowed: false,
 		},
 		{
-			name: "ClusterInstallRef and Provisioning both not set",
+			name: "private service connect enabled, no inventory in the given region",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAWSClusterDeployment()
-				cd.Spec.Provisioning = nil
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.PrivateServiceConnect = &hivev1gcp.PrivateServiceConnectAccess{Enabled: true}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
+			gcpPSC: &hivev1.GCPPrivateServiceConnectConfig{
+				EndpointInventory: []hivev1.GCPPrivateServiceConnectInventory{{
+					Region:  "some-region",
+					Network: "hub-network",
+					Subnet:  "hub-subnet",
+				}},
+			},
 		},
 		{
-			name:            "valid GCP clusterdeployment",
-			newObject:       validGCPClusterDeployment(),
+			name: "private service connect enabled, inventory in given region",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.PrivateServiceConnect = &hivev1gcp.PrivateServiceConnectAccess{Enabled: true}
+				return cd
+			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
+			gcpPSC: &hivev1.GCPPrivateServiceConnectConfig{
+				EndpointInventory: []hivev1.GCPPrivateServiceConnectInventory{{
+					Region:  "us-central1",
+					Network: "hub-network",
+					Subnet:  "hub-subnet",
+				}},
+			},
 		},
 		{
-			name: "Provisioning is missing",
+			name: "private service connect enabled, invalid subnet cidr",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validAWSClusterDeployment()
-				cd.Spec.Provisioning = nil
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.PrivateServiceConnect = &hivev1gcp.PrivateServiceConnectAccess{
+					Enabled:                     true,
+					ServiceAttachmentSubnetCIDR: "172.16.0.0",
+				}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
+			gcpPSC: &hivev1.GCPPrivateServiceConnectConfig{
+				EndpointInventory: []hivev1.GCPPrivateServiceConnectInventory{{
+					Region:  "us-central1",
+					Network: "hub-network",
+					Subnet:  "hub-subnet",
+				}},
+			},
 		},
 		{
-			name:            "OpenStack create valid",
-			newObject:       validOpenStackClusterDeployment(),
+			name: "installer env allowed by default",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.InstallerEnv = []corev1.EnvVar{{Name: "OPENSHIFT_INSTALL_EXPERIMENTAL_FLAG", Value: "true"}}
+				return cd
+			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
 		{
-			name: "OpenStack create without secret reference for certificates",
+			name: "installer env not allowed by default",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validOpenStackClusterDeployment()
-				cd.Spec.Platform.OpenStack.CertificatesSecretRef = &corev1.LocalObjectReference{Name: ""}
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.InstallerEnv = []corev1.EnvVar{{Name: "SOME_TOGGLE", Value: "true"}}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "OpenStack create with valid secret reference for certificates",
+			name: "installer env allowed by HiveConfig",
 			newObject: func() *hivev1.ClusterDeployment {
-				cd := validOpenStackClusterDeployment()
-				cd.Spec.Platform.OpenStack.CertificatesSecretRef = &corev1.LocalObjectReference{Name: "openstack-certificates"}
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.InstallerEnv = []corev1.EnvVar{{Name: "SOME_TOGGLE", Value: "true"}, {Name: "EXPERIMENT_ONE", Value: "true"}}
 				return cd
 			}(),
-			operation:       admissionv1beta1.Create,
-			expectedAllowed: true,
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     true,
+			allowedInstallerEnv: []string{"SOME_TOGGLE", "EXPERIMENT_*"},
 		},
 		{
-			name:            "Test valid delete",
-			oldObject:       validAWSClusterDeployment(),
-			operation:       admissionv1beta1.Delete,
-			expectedAllowed: true,
+			name: "installer env not allowed by HiveConfig",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.InstallerEnv = []corev1.EnvVar{{Name: "OPENSHIFT_INSTALL_EXPERIMENTAL_FLAG", Value: "true"}}
+				return cd
+			}(),
+			operation:           admissionv1beta1.Create,
+			expectedAllowed:     false,
+			allowedInstallerEnv: []string{"SOME_TOGGLE"},
 		},
 		{
-			name: "Test protected delete",
-			oldObject: func() *hivev1.ClusterDeployment {
+			name: "installer env reserved by hive",
+			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				if cd.Annotations == nil {
-					cd.Annotations = make(map[string]string, 1)
-				}
-				cd.Annotations[constants.ProtectedDeleteAnnotation] = "true"
+				cd.Spec.Provisioning.InstallerEnv = []corev1.EnvVar{{Name: "OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE", Value: "true"}}
 				return cd
 			}(),
-			operation:       admissionv1beta1.Delete,
+			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name: "Test protected delete annotation false",
-			oldObject: func() *hivev1.ClusterDeployment {
+			name: "installer env duplicated",
+			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				if cd.Annotations == nil {
-					cd.Annotations = make(map[string]string, 1)
-				}
-				cd.Annotations[constants.ProtectedDeleteAnnotation] = "false"
+				cd.Spec.Provisioning.InstallerEnv = []corev1.EnvVar{{Name: "OPENSHIFT_INSTALL_EXPERIMENTAL_FLAG", Value: "true"}, {Name: "OPENSHIFT_INSTALL_EXPERIMENTAL_FLAG", Value: "true"}}
 				return cd
 			}(),
-			operation:       admissionv1beta1.Delete,
-			expectedAllowed: true,
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
 		},
 		{
-			name:            "Test delete on OpenShift 3.11",
-			oldObject:       nil,
-			operation:       admissionv1beta1.Delete,
+			name: "installer image override with tag",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.InstallerImageOverride = "quay.io/myorg/installer:4.8-fix"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
 		{
-			name:            "vSphere create valid",
-			newObject:       validVSphereClusterDeployment(),
+			name: "installer image override with registry port and digest",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.InstallerImageOverride = "registry.example.com:5000/myorg/installer@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+				return cd
+			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
 		{
-			name:            "oVirt create valid",
-			newObject:       validOvirtClusterDeployment(),
+			name: "installer image override without registry",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.InstallerImageOverride = "myorg/installer"
+				return cd
+			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
 		{
-			name: "Block create with targetNamespace set",
+			name: "installer image override with invalid reference",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.MachineManagement = &hivev1.MachineManagement{
-					Central:         &hivev1.CentralMachineManagement{},
-					TargetNamespace: "nope",
-				}
+				cd.Spec.Provisioning.InstallerImageOverride = "quay.io/MyOrg/installer:4.8"
 				return cd
 			}(),
-			operation:           admissionv1beta1.Create,
-			expectedAllowed:     false,
-			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
 		},
 		{
-			name: "targetNamespace can be set if unset",
+			name: "installer image override with invalid tag",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.InstallerImageOverride = "quay.io/myorg/installer:4.8 fix"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "installer image override changed before installed",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.InstallerImageOverride = "quay.io/myorg/installer:4.8-fix"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "installer image override changed once installed",
 			oldObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.MachineManagement = &hivev1.MachineManagement{
-					Central: &hivev1.CentralMachineManagement{},
-				}
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				cd.Spec.Provisioning.InstallerImageOverride = "quay.io/myorg/installer:4.8-fix"
 				return cd
 			}(),
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.MachineManagement = &hivev1.MachineManagement{
-					Central:         &hivev1.CentralMachineManagement{},
-					TargetNamespace: "okay",
-				}
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				cd.Spec.Provisioning.InstallerImageOverride = "quay.io/myorg/installer:4.8-fix2"
 				return cd
 			}(),
-			operation:           admissionv1beta1.Update,
-			expectedAllowed:     true,
-			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
 		},
 		{
-			name: "targetNamespace is immutable once set",
+			name: "installer image override removed once installed",
 			oldObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.MachineManagement = &hivev1.MachineManagement{
-					Central:         &hivev1.CentralMachineManagement{},
-					TargetNamespace: "yes",
-				}
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				cd.Spec.Provisioning.InstallerImageOverride = "quay.io/myorg/installer:4.8-fix"
 				return cd
 			}(),
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.MachineManagement = &hivev1.MachineManagement{
-					Central:         &hivev1.CentralMachineManagement{},
-					TargetNamespace: "nope",
-				}
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
 				return cd
 			}(),
-			operation:           admissionv1beta1.Update,
-			expectedAllowed:     false,
-			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
 		},
 		{
-			name: "private link set, but disabled, no config",
+			name: "manual credentials from manifests secret",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{}
+				cd.Spec.Provisioning.ManualCredentials = &hivev1.ManualCredentials{
+					ManifestsSecretRef: &corev1.LocalObjectReference{Name: "credentials-manifests"},
+				}
 				return cd
 			}(),
-			operation:           admissionv1beta1.Create,
-			expectedAllowed:     true,
-			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
 		},
 		{
-			name: "private link enabled, no config",
+			name: "manual credentials without source",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{Enabled: true}
+				cd.Spec.Provisioning.ManualCredentials = &hivev1.ManualCredentials{}
 				return cd
 			}(),
-			operation:           admissionv1beta1.Create,
-			expectedAllowed:     false,
-			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
 		},
 		{
-			name: "private link enabled, no inventory",
+			name: "manual credentials with AWS STS",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{Enabled: true}
+				cd.Spec.BoundServiceAccountSignkingKeySecretRef = &corev1.LocalObjectReference{Name: "bound-sa-signing-key"}
+				cd.Spec.Provisioning.ManualCredentials = &hivev1.ManualCredentials{
+					AWSSTS: &hivev1.ManualCredentialsAWSSTS{RoleARNPrefix: "arn:aws:iam::123456789012:role/mycluster"},
+				}
 				return cd
 			}(),
-			operation:           admissionv1beta1.Create,
-			expectedAllowed:     false,
-			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
-			awsPrivateLink:      &hivev1.AWSPrivateLinkConfig{},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
 		},
 		{
-			name: "private link enabled, no inventory in the given region",
+			name: "manual credentials with AWS STS without bound service account signing key",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{Enabled: true}
+				cd.Spec.Provisioning.ManualCredentials = &hivev1.ManualCredentials{
+					AWSSTS: &hivev1.ManualCredentialsAWSSTS{RoleARNPrefix: "arn:aws:iam::123456789012:role/mycluster"},
+				}
 				return cd
 			}(),
-			operation:           admissionv1beta1.Create,
-			expectedAllowed:     false,
-			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
-			awsPrivateLink: &hivev1.AWSPrivateLinkConfig{
-				EndpointVPCInventory: []hivev1.AWSPrivateLinkInventory{{
-					AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
-						Region: "some-region",
-						VPCID:  "vpc-id",
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "manual credentials with GCP workload identity",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.BoundServiceAccountSignkingKeySecretRef = &corev1.LocalObjectReference{Name: "bound-sa-signing-key"}
+				cd.Spec.Provisioning.ManualCredentials = &hivev1.ManualCredentials{
+					GCPWorkloadIdentity: &hivev1.ManualCredentialsGCPWorkloadIdentity{
+						ProjectNumber: "123456789",
+						PoolID:        "mypool",
+						ProviderID:    "myprovider",
+						ServiceAccounts: []hivev1.WorkloadIdentityServiceAccount{{
+							SecretNamespace: "openshift-ingress-operator",
+							SecretName:      "cloud-credentials",
+							Identity:        "ingress@myproject.iam.gserviceaccount.com",
+						}},
 					},
-				}},
-			},
+					ServiceAccountIssuer: "https://storage.googleapis.com/mycluster-oidc",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
 		},
 		{
-			name: "private link enabled, some inventory in given region",
+			name: "manual credentials with GCP workload identity on AWS",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.Platform.AWS.PrivateLink = &hivev1aws.PrivateLinkAccess{Enabled: true}
+				cd.Spec.BoundServiceAccountSignkingKeySecretRef = &corev1.LocalObjectReference{Name: "bound-sa-signing-key"}
+				cd.Spec.Provisioning.ManualCredentials = &hivev1.ManualCredentials{
+					GCPWorkloadIdentity: &hivev1.ManualCredentialsGCPWorkloadIdentity{
+						ProjectNumber: "123456789",
+						PoolID:        "mypool",
+						ProviderID:    "myprovider",
+						ServiceAccounts: []hivev1.WorkloadIdentityServiceAccount{{
+							SecretNamespace: "openshift-ingress-operator",
+							SecretName:      "cloud-credentials",
+							Identity:        "ingress@myproject.iam.gserviceaccount.com",
+						}},
+					},
+				}
 				return cd
 			}(),
-			operation:           admissionv1beta1.Create,
-			expectedAllowed:     true,
-			enabledFeatureGates: []string{hivev1.FeatureGateMachineManagement},
-			awsPrivateLink: &hivev1.AWSPrivateLinkConfig{
-				EndpointVPCInventory: []hivev1.AWSPrivateLinkInventory{{
-					AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
-						Region: "some-region",
-						VPCID:  "vpc-id",
-					},
-				}, {
-					AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
-						Region: "test-region",
-						VPCID:  "vpc-id-2",
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "manual credentials with Azure workload identity without service accounts",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.BoundServiceAccountSignkingKeySecretRef = &corev1.LocalObjectReference{Name: "bound-sa-signing-key"}
+				cd.Spec.Provisioning.ManualCredentials = &hivev1.ManualCredentials{
+					AzureWorkloadIdentity: &hivev1.ManualCredentialsAzureWorkloadIdentity{
+						SubscriptionID: "test-subscription",
+						TenantID:       "test-tenant",
 					},
-				}},
-			},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "manual credentials with invalid service account issuer",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.ManualCredentials = &hivev1.ManualCredentials{
+					ManifestsSecretRef:   &corev1.LocalObjectReference{Name: "credentials-manifests"},
+					ServiceAccountIssuer: "http://issuer.example.com",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "manual credentials with AWS STS and invalid role ARN prefix",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.BoundServiceAccountSignkingKeySecretRef = &corev1.LocalObjectReference{Name: "bound-sa-signing-key"}
+				cd.Spec.Provisioning.ManualCredentials = &hivev1.ManualCredentials{
+					AWSSTS: &hivev1.ManualCredentialsAWSSTS{RoleARNPrefix: "mycluster"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
 		},
 	}
 
@@ -1122,8 +2355,12 @@ func TestClusterDeploymentValidate(t *testing.T) {
 						Enabled: tc.enabledFeatureGates,
 					},
 				},
-				awsPrivateLinkConfig: tc.awsPrivateLink,
-				supportedContracts:   tc.supportedContracts,
+				awsPrivateLinkConfig:           tc.awsPrivateLink,
+				gcpPrivateServiceConnectConfig: tc.gcpPSC,
+				supportedContracts:             tc.supportedContracts,
+				allowedInstallerEnv:            tc.allowedInstallerEnv,
+				credentialsBroker:              testCredentialsBroker,
+				managedDomains:                 testManagedDomains,
 			}
 
 			if tc.gvr == nil {
@@ -1145,6 +2382,7 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			request := &admissionv1beta1.AdmissionRequest{
 				Operation: tc.operation,
 				Resource:  *tc.gvr,
+				Namespace: testCredentialsBrokerNamespace,
 				Object: runtime.RawExtension{
 					Raw: tc.newObjectRaw,
 				},
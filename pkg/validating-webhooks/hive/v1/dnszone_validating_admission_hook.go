@@ -3,6 +3,9 @@ package v1
 import (
 	"fmt"
 	"net/http"
+	"net/url"
+	"path"
+	"reflect"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -11,10 +14,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	dnsvalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
 )
 
 const (
@@ -26,11 +31,20 @@ const (
 // DNSZoneValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type DNSZoneValidatingAdmissionHook struct {
 	decoder *admission.Decoder
+	// credentialsBroker binds the credentials sources of the credentials broker to the namespaces that may use them.
+	credentialsBroker *hivev1.CredentialsBrokerConfig
 }
 
 // NewDNSZoneValidatingAdmissionHook constructs a new DNSZoneValidatingAdmissionHook
 func NewDNSZoneValidatingAdmissionHook(decoder *admission.Decoder) *DNSZoneValidatingAdmissionHook {
-	return &DNSZoneValidatingAdmissionHook{decoder: decoder}
+	credentialsBroker, err := awsclient.ReadCredentialsBrokerConfig()
+	if err != nil {
+		log.WithField("validatingWebhook", "dnszone").WithError(err).Fatal("Unable to read the credentials broker config")
+	}
+	return &DNSZoneValidatingAdmissionHook{
+		decoder:           decoder,
+		credentialsBroker: credentialsBroker,
+	}
 }
 
 // ValidatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
@@ -156,6 +170,12 @@ func (a *DNSZoneValidatingAdmissionHook) validateCreate(admissionSpec *admission
 	contextLogger.Data["object.Name"] = newObject.Name
 
 	strErrs := dnsvalidation.IsDNS1123Subdomain(newObject.Spec.Zone)
+	strErrs = append(strErrs, validateAzureDNSZoneSpec(&newObject.Spec)...)
+	strErrs = append(strErrs, validateAWSDNSZoneSpec(&newObject.Spec)...)
+	strErrs = append(strErrs, validateGCPDNSZoneSpec(&newObject.Spec)...)
+	strErrs = append(strErrs, validateRecordCleanupSpec(&newObject.Spec)...)
+	strErrs = append(strErrs, validateWebhookDNSZoneSpec(&newObject.Spec)...)
+	strErrs = append(strErrs, a.validateAWSCredentialsSourceBinding(admissionSpec.Namespace, nil, &newObject.Spec)...)
 	if len(strErrs) != 0 {
 		message := fmt.Sprintf("Failed validation: %v", strings.Join(strErrs, ";"))
 		contextLogger.Infof(message)
@@ -228,9 +248,259 @@ func (a *DNSZoneValidatingAdmissionHook) validateUpdate(admissionSpec *admission
 		}
 	}
 
+	var strErrs []string
+	if azureDNSZoneType(&oldObject.Spec) != azureDNSZoneType(&newObject.Spec) {
+		strErrs = append(strErrs, "DNSZone.Spec.Azure.ZoneType is immutable")
+	}
+	if oldObject.Spec.Azure != nil && newObject.Spec.Azure != nil && oldObject.Spec.Azure.CloudName.Name() != newObject.Spec.Azure.CloudName.Name() {
+		strErrs = append(strErrs, "DNSZone.Spec.Azure.CloudName is immutable")
+	}
+	if awsDNSZoneType(&oldObject.Spec) != awsDNSZoneType(&newObject.Spec) {
+		strErrs = append(strErrs, "DNSZone.Spec.AWS.ZoneType is immutable")
+	}
+	if oldObject.Spec.AWS != nil && newObject.Spec.AWS != nil && oldObject.Spec.AWS.ZoneID != newObject.Spec.AWS.ZoneID {
+		strErrs = append(strErrs, "DNSZone.Spec.AWS.ZoneID is immutable")
+	}
+	if oldObject.Spec.GCP != nil && newObject.Spec.GCP != nil && oldObject.Spec.GCP.ZoneName != newObject.Spec.GCP.ZoneName {
+		strErrs = append(strErrs, "DNSZone.Spec.GCP.ZoneName is immutable")
+	}
+	if gcpDNSZoneType(&oldObject.Spec) != gcpDNSZoneType(&newObject.Spec) {
+		strErrs = append(strErrs, "DNSZone.Spec.GCP.ZoneType is immutable")
+	}
+	if oldObject.Spec.GCP != nil && newObject.Spec.GCP != nil && gcpPeeringTarget(&oldObject.Spec) != gcpPeeringTarget(&newObject.Spec) {
+		strErrs = append(strErrs, "DNSZone.Spec.GCP.Peering is immutable")
+	}
+	strErrs = append(strErrs, validateAzureDNSZoneSpec(&newObject.Spec)...)
+	strErrs = append(strErrs, validateAWSDNSZoneSpec(&newObject.Spec)...)
+	strErrs = append(strErrs, validateGCPDNSZoneSpec(&newObject.Spec)...)
+	strErrs = append(strErrs, validateRecordCleanupSpec(&newObject.Spec)...)
+	strErrs = append(strErrs, validateWebhookDNSZoneSpec(&newObject.Spec)...)
+	strErrs = append(strErrs, a.validateAWSCredentialsSourceBinding(admissionSpec.Namespace, &oldObject.Spec, &newObject.Spec)...)
+	if len(strErrs) != 0 {
+		message := fmt.Sprintf("Failed validation: %v", strings.Join(strErrs, ";"))
+		contextLogger.Infof(message)
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: message,
+			},
+		}
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	contextLogger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
 		Allowed: true,
 	}
 }
+
+// validateAWSCredentialsSourceBinding validates that the credentials broker allows the namespace of the DNSZone to use
+// its AWS credentials source, when the source is set or changed.
+func (a *DNSZoneValidatingAdmissionHook) validateAWSCredentialsSourceBinding(namespace string, oldSpec, spec *hivev1.DNSZoneSpec) []string {
+	if spec.AWS == nil || spec.AWS.CredentialsSource == nil {
+		return nil
+	}
+	if oldSpec != nil && oldSpec.AWS != nil && reflect.DeepEqual(oldSpec.AWS.CredentialsSource, spec.AWS.CredentialsSource) {
+		return nil
+	}
+	var errs []string
+	for _, err := range validateAWSCredentialsSourceBinding(field.NewPath("DNSZone", "Spec", "AWS", "CredentialsSource"), a.credentialsBroker, namespace, spec.AWS.CredentialsSource) {
+		errs = append(errs, err.Error())
+	}
+	return errs
+}
+
+// azureDNSZoneType returns the type of the Azure zone of the DNSZone, or the empty string if the zone is not on Azure.
+func azureDNSZoneType(spec *hivev1.DNSZoneSpec) hivev1.AzureDNSZoneType {
+	if spec.Azure == nil {
+		return ""
+	}
+	if spec.Azure.ZoneType == "" {
+		return hivev1.AzurePublicDNSZoneType
+	}
+	return spec.Azure.ZoneType
+}
+
+// validateAzureDNSZoneSpec validates the fields of the DNSZone that are specific to Azure Private DNS zones.
+func validateAzureDNSZoneSpec(spec *hivev1.DNSZoneSpec) []string {
+	var errs []string
+	switch azureDNSZoneType(spec) {
+	case hivev1.AzurePrivateDNSZoneType:
+		if spec.LinkToParentDomain {
+			errs = append(errs, "DNSZone.Spec.LinkToParentDomain is not supported for private zones")
+		}
+		names := map[string]bool{}
+		for _, link := range spec.Azure.VirtualNetworkLinks {
+			if link.Name == "" || link.VirtualNetworkID == "" {
+				errs = append(errs, "DNSZone.Spec.Azure.VirtualNetworkLinks must have a name and a virtual network ID")
+			}
+			if names[link.Name] {
+				errs = append(errs, fmt.Sprintf("DNSZone.Spec.Azure.VirtualNetworkLinks has duplicate link %q", link.Name))
+			}
+			names[link.Name] = true
+		}
+	case hivev1.AzurePublicDNSZoneType:
+		if len(spec.Azure.VirtualNetworkLinks) > 0 {
+			errs = append(errs, "DNSZone.Spec.Azure.VirtualNetworkLinks is only supported for private zones")
+		}
+	}
+	return errs
+}
+
+// awsDNSZoneType returns the type of the AWS zone of the DNSZone, or the empty string if the zone is not on AWS.
+func awsDNSZoneType(spec *hivev1.DNSZoneSpec) hivev1.AWSDNSZoneType {
+	if spec.AWS == nil {
+		return ""
+	}
+	if spec.AWS.ZoneType == "" {
+		return hivev1.AWSPublicDNSZoneType
+	}
+	return spec.AWS.ZoneType
+}
+
+// validateAWSDNSZoneSpec validates the fields of the DNSZone that are specific to Route53 private hosted zones and
+// DNSSEC.
+func validateAWSDNSZoneSpec(spec *hivev1.DNSZoneSpec) []string {
+	var errs []string
+	switch awsDNSZoneType(spec) {
+	case hivev1.AWSPrivateDNSZoneType:
+		if spec.LinkToParentDomain {
+			errs = append(errs, "DNSZone.Spec.LinkToParentDomain is not supported for private zones")
+		}
+		if len(spec.AWS.VPCs) == 0 {
+			errs = append(errs, "DNSZone.Spec.AWS.VPCs must have at least one VPC for private zones")
+		}
+		vpcs := map[hivev1.AWSDNSZoneVPC]bool{}
+		for _, vpc := range spec.AWS.VPCs {
+			if vpc.VPCID == "" || vpc.Region == "" {
+				errs = append(errs, "DNSZone.Spec.AWS.VPCs must have a VPC ID and a region")
+			}
+			if vpcs[vpc] {
+				errs = append(errs, fmt.Sprintf("DNSZone.Spec.AWS.VPCs has duplicate VPC %q in region %q", vpc.VPCID, vpc.Region))
+			}
+			vpcs[vpc] = true
+		}
+	case hivev1.AWSPublicDNSZoneType:
+		if len(spec.AWS.VPCs) > 0 {
+			errs = append(errs, "DNSZone.Spec.AWS.VPCs is only supported for private zones")
+		}
+	}
+	if spec.AWS != nil && spec.AWS.EnableDNSSEC {
+		if awsDNSZoneType(spec) == hivev1.AWSPrivateDNSZoneType {
+			errs = append(errs, "DNSZone.Spec.AWS.EnableDNSSEC is not supported for private zones")
+		}
+		if spec.AWS.DNSSECKMSKeyARN == "" {
+			errs = append(errs, "DNSZone.Spec.AWS.DNSSECKMSKeyARN is required when DNSSEC is enabled")
+		}
+	}
+	if spec.AWS != nil && spec.AWS.CredentialsSource != nil {
+		for _, err := range validateAWSCredentialsSource(field.NewPath("DNSZone", "Spec", "AWS", "CredentialsSource"), spec.AWS.CredentialsSource) {
+			errs = append(errs, err.Error())
+		}
+	}
+	if spec.AWS != nil {
+		for _, err := range validateAWSServiceEndpoints(field.NewPath("DNSZone", "Spec", "AWS", "ServiceEndpoints"), spec.AWS.ServiceEndpoints) {
+			errs = append(errs, err.Error())
+		}
+	}
+	if spec.AWS != nil && spec.AWS.QueryLogging != nil {
+		if awsDNSZoneType(spec) == hivev1.AWSPrivateDNSZoneType {
+			errs = append(errs, "DNSZone.Spec.AWS.QueryLogging is not supported for private zones")
+		}
+		if logGroupARN := spec.AWS.QueryLogging.CloudWatchLogsLogGroupARN; !strings.HasPrefix(logGroupARN, "arn:") || !strings.Contains(logGroupARN, ":logs:") || !strings.Contains(logGroupARN, ":log-group:") {
+			errs = append(errs, fmt.Sprintf("DNSZone.Spec.AWS.QueryLogging.CloudWatchLogsLogGroupARN %q must be the ARN of a CloudWatch Logs log group, such as arn:aws:logs:us-east-1:123456789012:log-group:/aws/route53/example.com", logGroupARN))
+		}
+	}
+	return errs
+}
+
+// gcpDNSZoneType returns the type of the GCP zone of the DNSZone, or the empty string if the zone is not on GCP.
+func gcpDNSZoneType(spec *hivev1.DNSZoneSpec) hivev1.GCPDNSZoneType {
+	if spec.GCP == nil {
+		return ""
+	}
+	if spec.GCP.ZoneType == "" {
+		return hivev1.GCPPublicDNSZoneType
+	}
+	return spec.GCP.ZoneType
+}
+
+// gcpPeeringTarget returns the URL of the target network of the DNS peering of the GCP zone of the DNSZone, or the
+// empty string if the zone is not a peering zone.
+func gcpPeeringTarget(spec *hivev1.DNSZoneSpec) string {
+	if spec.GCP == nil || spec.GCP.Peering == nil {
+		return ""
+	}
+	return spec.GCP.Peering.TargetNetworkURL
+}
+
+// validateGCPDNSZoneSpec validates the fields of the DNSZone that are specific to Cloud DNS private managed zones.
+func validateGCPDNSZoneSpec(spec *hivev1.DNSZoneSpec) []string {
+	var errs []string
+	switch gcpDNSZoneType(spec) {
+	case hivev1.GCPPrivateDNSZoneType:
+		if spec.LinkToParentDomain {
+			errs = append(errs, "DNSZone.Spec.LinkToParentDomain is not supported for private zones")
+		}
+		if len(spec.GCP.Networks) == 0 {
+			errs = append(errs, "DNSZone.Spec.GCP.Networks must have at least one network for private zones")
+		}
+		networks := map[string]bool{}
+		for _, network := range spec.GCP.Networks {
+			if network.NetworkURL == "" {
+				errs = append(errs, "DNSZone.Spec.GCP.Networks must have a network URL")
+			}
+			if networks[network.NetworkURL] {
+				errs = append(errs, fmt.Sprintf("DNSZone.Spec.GCP.Networks has duplicate network %q", network.NetworkURL))
+			}
+			networks[network.NetworkURL] = true
+		}
+		if spec.GCP.Peering != nil && spec.GCP.Peering.TargetNetworkURL == "" {
+			errs = append(errs, "DNSZone.Spec.GCP.Peering must have a target network URL")
+		}
+	case hivev1.GCPPublicDNSZoneType:
+		if len(spec.GCP.Networks) > 0 {
+			errs = append(errs, "DNSZone.Spec.GCP.Networks is only supported for private zones")
+		}
+		if spec.GCP.Peering != nil {
+			errs = append(errs, "DNSZone.Spec.GCP.Peering is only supported for private zones")
+		}
+	}
+	return errs
+}
+
+// validateRecordCleanupSpec validates the record cleanup policy and the preserved record names of the DNSZone.
+func validateRecordCleanupSpec(spec *hivev1.DNSZoneSpec) []string {
+	var errs []string
+	for _, pattern := range spec.PreservedRecordNames {
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = append(errs, fmt.Sprintf("DNSZone.Spec.PreservedRecordNames has invalid pattern %q", pattern))
+		}
+	}
+	if spec.Webhook != nil {
+		// Zones of external DNS webhooks are deleted by the webhook, which cannot be told which records to keep.
+		if spec.RecordCleanupPolicy == hivev1.FailDNSZoneRecordCleanupPolicy {
+			errs = append(errs, "DNSZone.Spec.RecordCleanupPolicy Fail is not supported for DNS webhook zones")
+		}
+		if len(spec.PreservedRecordNames) > 0 {
+			errs = append(errs, "DNSZone.Spec.PreservedRecordNames is not supported for DNS webhook zones")
+		}
+	}
+	return errs
+}
+
+// validateWebhookDNSZoneSpec validates the external DNS webhook of the DNSZone.
+func validateWebhookDNSZoneSpec(spec *hivev1.DNSZoneSpec) []string {
+	if spec.Webhook == nil {
+		return nil
+	}
+	u, err := url.Parse(spec.Webhook.URL)
+	if err != nil {
+		return []string{fmt.Sprintf("DNSZone.Spec.Webhook.URL is invalid: %v", err)}
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return []string{"DNSZone.Spec.Webhook.URL must be an https URL"}
+	}
+	return nil
+}
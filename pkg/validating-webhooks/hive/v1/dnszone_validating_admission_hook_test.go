@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	"github.com/stretchr/testify/assert"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -46,6 +48,16 @@ func TestDNSZoneValidate(t *testing.T) {
 		name            string
 		newZoneStr      string
 		oldZoneStr      string
+		newAzure        *hivev1.AzureDNSZoneSpec
+		oldAzure        *hivev1.AzureDNSZoneSpec
+		newAWS          *hivev1.AWSDNSZoneSpec
+		oldAWS          *hivev1.AWSDNSZoneSpec
+		newGCP          *hivev1.GCPDNSZoneSpec
+		oldGCP          *hivev1.GCPDNSZoneSpec
+		newWebhook      *hivev1.WebhookDNSZoneSpec
+		newCleanup      hivev1.DNSZoneRecordCleanupPolicy
+		newPreserved    []string
+		newLinkToParent bool
 		newObjectRaw    []byte
 		oldObjectRaw    []byte
 		operation       admissionv1beta1.Operation
@@ -104,6 +116,491 @@ func TestDNSZoneValidate(t *testing.T) {
 
 			expectedAllowed: true,
 		},
+		{
+			name:       "Test Azure private zone with virtual network links",
+			newZoneStr: "this.is.a.valid.zone",
+			newAzure: &hivev1.AzureDNSZoneSpec{
+				ZoneType: hivev1.AzurePrivateDNSZoneType,
+				VirtualNetworkLinks: []hivev1.AzureVirtualNetworkLink{
+					{Name: "vnet-link", VirtualNetworkID: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet"},
+				},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test Azure private zone linked to parent domain",
+			newZoneStr:      "this.is.a.valid.zone",
+			newAzure:        &hivev1.AzureDNSZoneSpec{ZoneType: hivev1.AzurePrivateDNSZoneType},
+			newLinkToParent: true,
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test Azure private zone with duplicate virtual network links",
+			newZoneStr: "this.is.a.valid.zone",
+			newAzure: &hivev1.AzureDNSZoneSpec{
+				ZoneType: hivev1.AzurePrivateDNSZoneType,
+				VirtualNetworkLinks: []hivev1.AzureVirtualNetworkLink{
+					{Name: "vnet-link", VirtualNetworkID: "vnet-1"},
+					{Name: "vnet-link", VirtualNetworkID: "vnet-2"},
+				},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test Azure public zone with virtual network links",
+			newZoneStr: "this.is.a.valid.zone",
+			newAzure: &hivev1.AzureDNSZoneSpec{
+				VirtualNetworkLinks: []hivev1.AzureVirtualNetworkLink{
+					{Name: "vnet-link", VirtualNetworkID: "vnet-1"},
+				},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test Azure zone type is immutable",
+			newZoneStr: "this.is.a.valid.zone",
+			oldZoneStr: "this.is.a.valid.zone",
+			newAzure:   &hivev1.AzureDNSZoneSpec{ZoneType: hivev1.AzurePrivateDNSZoneType},
+			oldAzure:   &hivev1.AzureDNSZoneSpec{},
+			operation:  admissionv1beta1.Update,
+
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test Azure cloud name is immutable",
+			newZoneStr: "this.is.a.valid.zone",
+			oldZoneStr: "this.is.a.valid.zone",
+			newAzure:   &hivev1.AzureDNSZoneSpec{CloudName: hivev1azure.USGovernmentCloud},
+			oldAzure:   &hivev1.AzureDNSZoneSpec{},
+			operation:  admissionv1beta1.Update,
+
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test Azure cloud name set to the default",
+			newZoneStr: "this.is.a.valid.zone",
+			oldZoneStr: "this.is.a.valid.zone",
+			newAzure:   &hivev1.AzureDNSZoneSpec{CloudName: hivev1azure.PublicCloud},
+			oldAzure:   &hivev1.AzureDNSZoneSpec{},
+			operation:  admissionv1beta1.Update,
+
+			expectedAllowed: true,
+		},
+		{
+			name:       "Test Azure private zone virtual network links can be updated",
+			newZoneStr: "this.is.a.valid.zone",
+			oldZoneStr: "this.is.a.valid.zone",
+			newAzure: &hivev1.AzureDNSZoneSpec{
+				ZoneType: hivev1.AzurePrivateDNSZoneType,
+				VirtualNetworkLinks: []hivev1.AzureVirtualNetworkLink{
+					{Name: "vnet-link", VirtualNetworkID: "vnet-1"},
+				},
+			},
+			oldAzure:  &hivev1.AzureDNSZoneSpec{ZoneType: hivev1.AzurePrivateDNSZoneType},
+			operation: admissionv1beta1.Update,
+
+			expectedAllowed: true,
+		},
+		{
+			name:       "Test AWS private zone with VPCs",
+			newZoneStr: "this.is.a.valid.zone",
+			newAWS: &hivev1.AWSDNSZoneSpec{
+				ZoneType: hivev1.AWSPrivateDNSZoneType,
+				VPCs:     []hivev1.AWSDNSZoneVPC{{VPCID: "vpc-1", Region: "us-east-1"}},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test AWS private zone without VPCs",
+			newZoneStr:      "this.is.a.valid.zone",
+			newAWS:          &hivev1.AWSDNSZoneSpec{ZoneType: hivev1.AWSPrivateDNSZoneType},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test AWS private zone linked to parent domain",
+			newZoneStr: "this.is.a.valid.zone",
+			newAWS: &hivev1.AWSDNSZoneSpec{
+				ZoneType: hivev1.AWSPrivateDNSZoneType,
+				VPCs:     []hivev1.AWSDNSZoneVPC{{VPCID: "vpc-1", Region: "us-east-1"}},
+			},
+			newLinkToParent: true,
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test AWS private zone with duplicate VPCs",
+			newZoneStr: "this.is.a.valid.zone",
+			newAWS: &hivev1.AWSDNSZoneSpec{
+				ZoneType: hivev1.AWSPrivateDNSZoneType,
+				VPCs: []hivev1.AWSDNSZoneVPC{
+					{VPCID: "vpc-1", Region: "us-east-1"},
+					{VPCID: "vpc-1", Region: "us-east-1"},
+				},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test AWS private zone VPC without region",
+			newZoneStr: "this.is.a.valid.zone",
+			newAWS: &hivev1.AWSDNSZoneSpec{
+				ZoneType: hivev1.AWSPrivateDNSZoneType,
+				VPCs:     []hivev1.AWSDNSZoneVPC{{VPCID: "vpc-1"}},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test AWS zone with DNSSEC",
+			newZoneStr: "this.is.a.valid.zone",
+			newAWS: &hivev1.AWSDNSZoneSpec{
+				EnableDNSSEC:    true,
+				DNSSECKMSKeyARN: "arn:aws:kms:us-east-1:123456789012:key/1234",
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test AWS zone with DNSSEC without KMS key",
+			newZoneStr:      "this.is.a.valid.zone",
+			newAWS:          &hivev1.AWSDNSZoneSpec{EnableDNSSEC: true},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test AWS private zone with DNSSEC",
+			newZoneStr: "this.is.a.valid.zone",
+			newAWS: &hivev1.AWSDNSZoneSpec{
+				ZoneType:        hivev1.AWSPrivateDNSZoneType,
+				VPCs:            []hivev1.AWSDNSZoneVPC{{VPCID: "vpc-1", Region: "us-east-1"}},
+				EnableDNSSEC:    true,
+				DNSSECKMSKeyARN: "arn:aws:kms:us-east-1:123456789012:key/1234",
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test AWS zone with query logging",
+			newZoneStr: "this.is.a.valid.zone",
+			newAWS: &hivev1.AWSDNSZoneSpec{
+				QueryLogging: &hivev1.AWSDNSZoneQueryLogging{
+					CloudWatchLogsLogGroupARN: "arn:aws:logs:us-east-1:123456789012:log-group:/aws/route53/this.is.a.valid.zone",
+				},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:       "Test AWS zone with query logging to invalid log group ARN",
+			newZoneStr: "this.is.a.valid.zone",
+			newAWS: &hivev1.AWSDNSZoneSpec{
+				QueryLogging: &hivev1.AWSDNSZoneQueryLogging{
+					CloudWatchLogsLogGroupARN: "arn:aws:kms:us-east-1:123456789012:key/1234",
+				},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test AWS private zone with query logging",
+			newZoneStr: "this.is.a.valid.zone",
+			newAWS: &hivev1.AWSDNSZoneSpec{
+				ZoneType: hivev1.AWSPrivateDNSZoneType,
+				VPCs:     []hivev1.AWSDNSZoneVPC{{VPCID: "vpc-1", Region: "us-east-1"}},
+				QueryLogging: &hivev1.AWSDNSZoneQueryLogging{
+					CloudWatchLogsLogGroupARN: "arn:aws:logs:us-east-1:123456789012:log-group:/aws/route53/this.is.a.valid.zone",
+				},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test AWS zone with credentials source",
+			newZoneStr: "this.is.a.valid.zone",
+			newAWS: &hivev1.AWSDNSZoneSpec{
+				CredentialsSource: &hivev1aws.CredentialsSource{
+					WebIdentity: &hivev1aws.WebIdentityCredentialsSource{RoleARN: "arn:aws:iam::123456789012:role/dns"},
+				},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:       "Test AWS zone with credentials source not bound to namespace",
+			newZoneStr: "this.is.a.valid.zone",
+			newAWS: &hivev1.AWSDNSZoneSpec{
+				CredentialsSource: &hivev1aws.CredentialsSource{
+					WebIdentity: &hivev1aws.WebIdentityCredentialsSource{RoleARN: "arn:aws:iam::210987654321:role/dns"},
+				},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test AWS zone update to credentials source not bound to namespace",
+			newZoneStr: "this.is.a.valid.zone",
+			oldZoneStr: "this.is.a.valid.zone",
+			oldAWS: &hivev1.AWSDNSZoneSpec{
+				CredentialsSource: &hivev1aws.CredentialsSource{
+					WebIdentity: &hivev1aws.WebIdentityCredentialsSource{RoleARN: "arn:aws:iam::123456789012:role/dns"},
+				},
+			},
+			newAWS: &hivev1.AWSDNSZoneSpec{
+				CredentialsSource: &hivev1aws.CredentialsSource{
+					WebIdentity: &hivev1aws.WebIdentityCredentialsSource{RoleARN: "arn:aws:iam::210987654321:role/dns"},
+				},
+			},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test AWS zone update keeping credentials source no longer bound to namespace",
+			newZoneStr: "this.is.a.valid.zone",
+			oldZoneStr: "this.is.a.valid.zone",
+			oldAWS: &hivev1.AWSDNSZoneSpec{
+				CredentialsSource: &hivev1aws.CredentialsSource{
+					WebIdentity: &hivev1aws.WebIdentityCredentialsSource{RoleARN: "arn:aws:iam::210987654321:role/dns"},
+				},
+			},
+			newAWS: &hivev1.AWSDNSZoneSpec{
+				CredentialsSource: &hivev1aws.CredentialsSource{
+					WebIdentity: &hivev1aws.WebIdentityCredentialsSource{RoleARN: "arn:aws:iam::210987654321:role/dns"},
+				},
+			},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:       "Test AWS zone with Vault credentials source without role",
+			newZoneStr: "this.is.a.valid.zone",
+			newAWS: &hivev1.AWSDNSZoneSpec{
+				CredentialsSource: &hivev1aws.CredentialsSource{
+					Vault: &hivev1aws.VaultCredentialsSource{},
+				},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test AWS zone with service endpoint without name",
+			newZoneStr: "this.is.a.valid.zone",
+			newAWS: &hivev1.AWSDNSZoneSpec{
+				Region:           "us-iso-east-1",
+				ServiceEndpoints: []hivev1aws.ServiceEndpoint{{URL: "https://route53.c2s.ic.gov"}},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test webhook zone",
+			newZoneStr:      "this.is.a.valid.zone",
+			newWebhook:      &hivev1.WebhookDNSZoneSpec{URL: "https://dns-webhook.example.com/v1"},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test webhook zone with http URL",
+			newZoneStr:      "this.is.a.valid.zone",
+			newWebhook:      &hivev1.WebhookDNSZoneSpec{URL: "http://dns-webhook.example.com/v1"},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test webhook zone with relative URL",
+			newZoneStr:      "this.is.a.valid.zone",
+			newWebhook:      &hivev1.WebhookDNSZoneSpec{URL: "/v1"},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test AWS public zone with VPCs",
+			newZoneStr: "this.is.a.valid.zone",
+			newAWS: &hivev1.AWSDNSZoneSpec{
+				VPCs: []hivev1.AWSDNSZoneVPC{{VPCID: "vpc-1", Region: "us-east-1"}},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test AWS zone type is immutable",
+			newZoneStr: "this.is.a.valid.zone",
+			oldZoneStr: "this.is.a.valid.zone",
+			newAWS: &hivev1.AWSDNSZoneSpec{
+				ZoneType: hivev1.AWSPrivateDNSZoneType,
+				VPCs:     []hivev1.AWSDNSZoneVPC{{VPCID: "vpc-1", Region: "us-east-1"}},
+			},
+			oldAWS:    &hivev1.AWSDNSZoneSpec{},
+			operation: admissionv1beta1.Update,
+
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test AWS zone to adopt",
+			newZoneStr: "this.is.a.valid.zone",
+			newAWS:     &hivev1.AWSDNSZoneSpec{ZoneID: "Z0123456789"},
+			operation:  admissionv1beta1.Create,
+
+			expectedAllowed: true,
+		},
+		{
+			name:       "Test AWS zone ID to adopt is immutable",
+			newZoneStr: "this.is.a.valid.zone",
+			oldZoneStr: "this.is.a.valid.zone",
+			newAWS:     &hivev1.AWSDNSZoneSpec{ZoneID: "Z0123456789"},
+			oldAWS:     &hivev1.AWSDNSZoneSpec{ZoneID: "Z9876543210"},
+			operation:  admissionv1beta1.Update,
+
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test AWS private zone VPCs can be updated",
+			newZoneStr: "this.is.a.valid.zone",
+			oldZoneStr: "this.is.a.valid.zone",
+			newAWS: &hivev1.AWSDNSZoneSpec{
+				ZoneType: hivev1.AWSPrivateDNSZoneType,
+				VPCs:     []hivev1.AWSDNSZoneVPC{{VPCID: "vpc-2", Region: "us-west-2"}},
+			},
+			oldAWS: &hivev1.AWSDNSZoneSpec{
+				ZoneType: hivev1.AWSPrivateDNSZoneType,
+				VPCs:     []hivev1.AWSDNSZoneVPC{{VPCID: "vpc-1", Region: "us-east-1"}},
+			},
+			operation: admissionv1beta1.Update,
+
+			expectedAllowed: true,
+		},
+		{
+			name:       "Test GCP private zone with networks",
+			newZoneStr: "this.is.a.valid.zone",
+			newGCP: &hivev1.GCPDNSZoneSpec{
+				ZoneType: hivev1.GCPPrivateDNSZoneType,
+				Networks: []hivev1.GCPDNSZoneNetwork{{NetworkURL: "https://www.googleapis.com/compute/v1/projects/p/global/networks/n1"}},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test GCP private zone without networks",
+			newZoneStr:      "this.is.a.valid.zone",
+			newGCP:          &hivev1.GCPDNSZoneSpec{ZoneType: hivev1.GCPPrivateDNSZoneType},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test GCP private zone linked to parent domain",
+			newZoneStr: "this.is.a.valid.zone",
+			newGCP: &hivev1.GCPDNSZoneSpec{
+				ZoneType: hivev1.GCPPrivateDNSZoneType,
+				Networks: []hivev1.GCPDNSZoneNetwork{{NetworkURL: "https://www.googleapis.com/compute/v1/projects/p/global/networks/n1"}},
+			},
+			newLinkToParent: true,
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test GCP private zone with duplicate networks",
+			newZoneStr: "this.is.a.valid.zone",
+			newGCP: &hivev1.GCPDNSZoneSpec{
+				ZoneType: hivev1.GCPPrivateDNSZoneType,
+				Networks: []hivev1.GCPDNSZoneNetwork{{NetworkURL: "https://www.googleapis.com/compute/v1/projects/p/global/networks/n1"}, {NetworkURL: "https://www.googleapis.com/compute/v1/projects/p/global/networks/n1"}},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test GCP peering zone",
+			newZoneStr: "this.is.a.valid.zone",
+			newGCP: &hivev1.GCPDNSZoneSpec{
+				ZoneType: hivev1.GCPPrivateDNSZoneType,
+				Networks: []hivev1.GCPDNSZoneNetwork{{NetworkURL: "https://www.googleapis.com/compute/v1/projects/p/global/networks/n1"}},
+				Peering:  &hivev1.GCPDNSZonePeering{TargetNetworkURL: "https://www.googleapis.com/compute/v1/projects/hub/global/networks/hub"},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:       "Test GCP public zone with peering",
+			newZoneStr: "this.is.a.valid.zone",
+			newGCP: &hivev1.GCPDNSZoneSpec{
+				Peering: &hivev1.GCPDNSZonePeering{TargetNetworkURL: "https://www.googleapis.com/compute/v1/projects/hub/global/networks/hub"},
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test GCP zone type is immutable",
+			newZoneStr: "this.is.a.valid.zone",
+			oldZoneStr: "this.is.a.valid.zone",
+			newGCP: &hivev1.GCPDNSZoneSpec{
+				ZoneType: hivev1.GCPPrivateDNSZoneType,
+				Networks: []hivev1.GCPDNSZoneNetwork{{NetworkURL: "https://www.googleapis.com/compute/v1/projects/p/global/networks/n1"}},
+			},
+			oldGCP:    &hivev1.GCPDNSZoneSpec{},
+			operation: admissionv1beta1.Update,
+
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test GCP peering is immutable",
+			newZoneStr: "this.is.a.valid.zone",
+			oldZoneStr: "this.is.a.valid.zone",
+			newGCP: &hivev1.GCPDNSZoneSpec{
+				ZoneType: hivev1.GCPPrivateDNSZoneType,
+				Networks: []hivev1.GCPDNSZoneNetwork{{NetworkURL: "https://www.googleapis.com/compute/v1/projects/p/global/networks/n1"}},
+				Peering:  &hivev1.GCPDNSZonePeering{TargetNetworkURL: "https://www.googleapis.com/compute/v1/projects/hub/global/networks/hub"},
+			},
+			oldGCP: &hivev1.GCPDNSZoneSpec{
+				ZoneType: hivev1.GCPPrivateDNSZoneType,
+				Networks: []hivev1.GCPDNSZoneNetwork{{NetworkURL: "https://www.googleapis.com/compute/v1/projects/p/global/networks/n1"}},
+			},
+			operation: admissionv1beta1.Update,
+
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test GCP private zone networks can be updated",
+			newZoneStr: "this.is.a.valid.zone",
+			oldZoneStr: "this.is.a.valid.zone",
+			newGCP: &hivev1.GCPDNSZoneSpec{
+				ZoneType: hivev1.GCPPrivateDNSZoneType,
+				Networks: []hivev1.GCPDNSZoneNetwork{{NetworkURL: "https://www.googleapis.com/compute/v1/projects/p/global/networks/n1"}, {NetworkURL: "https://www.googleapis.com/compute/v1/projects/p/global/networks/n2"}},
+			},
+			oldGCP: &hivev1.GCPDNSZoneSpec{
+				ZoneType: hivev1.GCPPrivateDNSZoneType,
+				Networks: []hivev1.GCPDNSZoneNetwork{{NetworkURL: "https://www.googleapis.com/compute/v1/projects/p/global/networks/n1"}},
+			},
+			operation: admissionv1beta1.Update,
+
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test Fail record cleanup policy with preserved records",
+			newZoneStr:      "this.is.a.valid.zone",
+			newCleanup:      hivev1.FailDNSZoneRecordCleanupPolicy,
+			newPreserved:    []string{"_acme-challenge.*", "*.apps.this.is.a.valid.zone"},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test invalid preserved record pattern",
+			newZoneStr:      "this.is.a.valid.zone",
+			newPreserved:    []string{"[api.this.is.a.valid.zone"},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test Fail record cleanup policy for webhook zone",
+			newZoneStr:      "this.is.a.valid.zone",
+			newWebhook:      &hivev1.WebhookDNSZoneSpec{URL: "https://dns.example.com/v1"},
+			newCleanup:      hivev1.FailDNSZoneRecordCleanupPolicy,
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test that we don't validate deletes",
 			operation:       admissionv1beta1.Delete,
@@ -142,14 +639,25 @@ func TestDNSZoneValidate(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Arrange
 			data := NewDNSZoneValidatingAdmissionHook(createDecoder(t))
+			data.credentialsBroker = testCredentialsBroker
 			newObject := &hivev1.DNSZone{
 				Spec: hivev1.DNSZoneSpec{
-					Zone: tc.newZoneStr,
+					Zone:                 tc.newZoneStr,
+					LinkToParentDomain:   tc.newLinkToParent,
+					Azure:                tc.newAzure,
+					AWS:                  tc.newAWS,
+					GCP:                  tc.newGCP,
+					Webhook:              tc.newWebhook,
+					RecordCleanupPolicy:  tc.newCleanup,
+					PreservedRecordNames: tc.newPreserved,
 				},
 			}
 			oldObject := &hivev1.DNSZone{
 				Spec: hivev1.DNSZoneSpec{
-					Zone: tc.oldZoneStr,
+					Zone:  tc.oldZoneStr,
+					Azure: tc.oldAzure,
+					AWS:   tc.oldAWS,
+					GCP:   tc.oldGCP,
 				},
 			}
 
@@ -172,6 +680,7 @@ func TestDNSZoneValidate(t *testing.T) {
 			request := &admissionv1beta1.AdmissionRequest{
 				Operation: tc.operation,
 				Resource:  *tc.gvr,
+				Namespace: testCredentialsBrokerNamespace,
 				Object: runtime.RawExtension{
 					Raw: tc.newObjectRaw,
 				},
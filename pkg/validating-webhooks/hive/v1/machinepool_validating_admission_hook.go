@@ -3,6 +3,8 @@ package v1
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
@@ -13,17 +15,22 @@ import (
 	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	hivev1nutanix "github.com/openshift/hive/apis/hive/v1/nutanix"
 	hivev1openstack "github.com/openshift/hive/apis/hive/v1/openstack"
 	hivev1ovirt "github.com/openshift/hive/apis/hive/v1/ovirt"
+	hivev1powervs "github.com/openshift/hive/apis/hive/v1/powervs"
 	hivev1vsphere "github.com/openshift/hive/apis/hive/v1/vsphere"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 const (
@@ -38,12 +45,24 @@ const (
 
 // MachinePoolValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type MachinePoolValidatingAdmissionHook struct {
-	decoder *admission.Decoder
+	decoder         *admission.Decoder
+	namespaceQuotas []hivev1.NamespaceQuota
+	// client is used to count the machines in namespaces with quotas. It is only set when there are quotas.
+	client client.Client
+	rules  *admissionRules
 }
 
 // NewMachinePoolValidatingAdmissionHook constructs a new MachinePoolValidatingAdmissionHook
 func NewMachinePoolValidatingAdmissionHook(decoder *admission.Decoder) *MachinePoolValidatingAdmissionHook {
-	return &MachinePoolValidatingAdmissionHook{decoder: decoder}
+	namespaceQuotas, err := controllerutils.ReadNamespaceQuotasFile()
+	if err != nil {
+		log.WithField("validatingWebhook", "machinepool").WithError(err).Fatal("Unable to read namespace quotas file")
+	}
+	return &MachinePoolValidatingAdmissionHook{
+		decoder:         decoder,
+		namespaceQuotas: namespaceQuotas,
+		rules:           newAdmissionRules(),
+	}
 }
 
 // ValidatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
@@ -73,7 +92,15 @@ func (a *MachinePoolValidatingAdmissionHook) Initialize(kubeClientConfig *rest.C
 		"resource": "machinepoolvalidator",
 	}).Info("Initializing validation REST resource")
 
-	return nil // No initialization needed right now.
+	if len(a.namespaceQuotas) == 0 {
+		return nil
+	}
+	c, err := newQuotaClient(kubeClientConfig)
+	if err != nil {
+		return err
+	}
+	a.client = c
+	return nil
 }
 
 // Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
@@ -158,10 +185,17 @@ func (a *MachinePoolValidatingAdmissionHook) validateCreateRequest(request *admi
 		}
 	}
 
+	var warnings []string
+	if resp := a.rules.response(hivev1.NamespaceQuotaAdmissionRule,
+		validateMachineQuota(a.client, a.namespaceQuotas, request.Namespace, nil, newObject, logger), &warnings, logger); resp != nil {
+		return resp
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	logger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
-		Allowed: true,
+		Allowed:  true,
+		Warnings: warnings,
 	}
 }
 
@@ -192,10 +226,17 @@ func (a *MachinePoolValidatingAdmissionHook) validateUpdateRequest(request *admi
 		}
 	}
 
+	var warnings []string
+	if resp := a.rules.response(hivev1.NamespaceQuotaAdmissionRule,
+		validateMachineQuota(a.client, a.namespaceQuotas, request.Namespace, oldObject, newObject, logger), &warnings, logger); resp != nil {
+		return resp
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	logger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
-		Allowed: true,
+		Allowed:  true,
+		Warnings: warnings,
 	}
 }
 
@@ -224,10 +265,79 @@ func validateMachinePoolUpdate(old, new *hivev1.MachinePool) field.ErrorList {
 	specPath := field.NewPath("spec")
 	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.ClusterDeploymentRef, old.Spec.ClusterDeploymentRef, specPath.Child("clusterDeploymentRef"))...)
 	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.Name, old.Spec.Name, specPath.Child("name"))...)
-	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.Platform, old.Spec.Platform, specPath.Child("platform"))...)
+	allErrs = append(allErrs, validateMachinePoolPlatformUpdate(&old.Spec.Platform, &new.Spec.Platform, specPath.Child("platform"))...)
+	return allErrs
+}
+
+// validateMachinePoolPlatformUpdate validates an update of the platform of a MachinePool. Zones, and their subnets, can
+// be added to a pool with an explicit list of zones to expand the pool to new zones. The rest of the platform is
+// immutable.
+func validateMachinePoolPlatformUpdate(old, new *hivev1.MachinePoolPlatform, fldPath *field.Path) field.ErrorList {
+	oldPlatform, newPlatform := old.DeepCopy(), new.DeepCopy()
+	oldZones, newZones := clearZoneFields(oldPlatform), clearZoneFields(newPlatform)
+	if allErrs := validation.ValidateImmutableField(newPlatform, oldPlatform, fldPath); len(allErrs) > 0 {
+		return allErrs
+	}
+	if newZones.platform == "" {
+		return nil
+	}
+	allErrs := field.ErrorList{}
+	platformPath := fldPath.Child(newZones.platform)
+	added := false
+	for _, f := range []struct {
+		name     string
+		old, new []string
+	}{
+		{name: "zones", old: oldZones.zones, new: newZones.zones},
+		{name: "zoneSubnets", old: oldZones.zoneSubnets, new: newZones.zoneSubnets},
+		{name: "subnets", old: oldZones.subnets, new: newZones.subnets},
+	} {
+		oldSet, newSet := sets.NewString(f.old...), sets.NewString(f.new...)
+		if !newSet.IsSuperset(oldSet) {
+			allErrs = append(allErrs, field.Forbidden(platformPath.Child(f.name), fmt.Sprintf("%s cannot be removed from a MachinePool", f.name)))
+		}
+		added = added || newSet.Len() > oldSet.Len()
+	}
+	if added && len(oldZones.zones) == 0 && len(oldZones.zoneSubnets) == 0 {
+		allErrs = append(allErrs, field.Forbidden(platformPath.Child("zones"), "zones cannot be added to a MachinePool using all of the zones of the region"))
+	}
 	return allErrs
 }
 
+// zoneFields are the fields of the platform of a MachinePool that can be expanded to new zones.
+type zoneFields struct {
+	platform    string
+	zones       []string
+	zoneSubnets []string
+	subnets     []string
+}
+
+// clearZoneFields clears the fields of the platform that can be expanded to new zones, and returns them.
+func clearZoneFields(platform *hivev1.MachinePoolPlatform) zoneFields {
+	var f zoneFields
+	switch {
+	case platform.AWS != nil:
+		f = zoneFields{platform: "aws", zones: platform.AWS.Zones, subnets: platform.AWS.Subnets}
+		for _, zs := range platform.AWS.ZoneSubnets {
+			f.zoneSubnets = append(f.zoneSubnets, fmt.Sprintf("%s/%s", zs.Zone, zs.Subnet))
+		}
+		platform.AWS.Zones, platform.AWS.Subnets, platform.AWS.ZoneSubnets = nil, nil, nil
+	case platform.GCP != nil:
+		f = zoneFields{platform: "gcp", zones: platform.GCP.Zones}
+		for _, zs := range platform.GCP.ZoneSubnets {
+			f.zoneSubnets = append(f.zoneSubnets, fmt.Sprintf("%s/%s", zs.Zone, zs.Subnet))
+		}
+		platform.GCP.Zones, platform.GCP.ZoneSubnets = nil, nil
+	case platform.Azure != nil:
+		f = zoneFields{platform: "azure", zones: platform.Azure.Zones}
+		for _, zs := range platform.Azure.ZoneSubnets {
+			f.zoneSubnets = append(f.zoneSubnets, fmt.Sprintf("%s/%s", zs.Zone, zs.Subnet))
+		}
+		platform.Azure.Zones, platform.Azure.ZoneSubnets = nil, nil
+	}
+	return f
+}
+
 func validateMachinePoolName(pool *hivev1.MachinePool) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if pool.Name != fmt.Sprintf("%s-%s", pool.Spec.ClusterDeploymentRef.Name, pool.Spec.Name) {
@@ -275,18 +385,27 @@ func validateMachinePoolSpecInvariants(spec *hivev1.MachinePoolSpec, fldPath *fi
 		platforms = append(platforms, "aws")
 		allErrs = append(allErrs, validateAWSMachinePoolPlatformInvariants(p, platformPath.Child("aws"))...)
 		numberOfMachineSets = len(p.Zones)
+		if numberOfMachineSets == 0 {
+			numberOfMachineSets = len(p.ZoneSubnets)
+		}
 		validZeroSizeAutoscalingMinReplicas = true
 	}
 	if p := spec.Platform.Azure; p != nil {
 		platforms = append(platforms, "azure")
 		allErrs = append(allErrs, validateAzureMachinePoolPlatformInvariants(p, platformPath.Child("azure"))...)
 		numberOfMachineSets = len(p.Zones)
+		if numberOfMachineSets == 0 {
+			numberOfMachineSets = len(p.ZoneSubnets)
+		}
 		validZeroSizeAutoscalingMinReplicas = true
 	}
 	if p := spec.Platform.GCP; p != nil {
 		platforms = append(platforms, "gcp")
 		allErrs = append(allErrs, validateGCPMachinePoolPlatformInvariants(p, platformPath.Child("gcp"))...)
 		numberOfMachineSets = len(p.Zones)
+		if numberOfMachineSets == 0 {
+			numberOfMachineSets = len(p.ZoneSubnets)
+		}
 		validZeroSizeAutoscalingMinReplicas = true
 	}
 	if p := spec.Platform.OpenStack; p != nil {
@@ -302,6 +421,14 @@ func validateMachinePoolSpecInvariants(spec *hivev1.MachinePoolSpec, fldPath *fi
 		platforms = append(platforms, "ovirt")
 		allErrs = append(allErrs, validateOvirtMachinePoolPlatformInvariants(p, platformPath.Child("ovirt"))...)
 	}
+	if p := spec.Platform.Nutanix; p != nil {
+		platforms = append(platforms, "nutanix")
+		allErrs = append(allErrs, validateNutanixMachinePoolPlatformInvariants(p, platformPath.Child("nutanix"))...)
+	}
+	if p := spec.Platform.PowerVS; p != nil {
+		platforms = append(platforms, "powervs")
+		allErrs = append(allErrs, validatePowerVSMachinePoolPlatformInvariants(p, platformPath.Child("powervs"))...)
+	}
 
 	switch len(platforms) {
 	case 0:
@@ -337,6 +464,14 @@ func validateAWSMachinePoolPlatformInvariants(platform *hivev1aws.MachinePoolPla
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("zones").Index(i), zone, "zone cannot be an empty string"))
 		}
 	}
+	zoneSubnets := make([]zoneSubnet, len(platform.ZoneSubnets))
+	for i, zs := range platform.ZoneSubnets {
+		zoneSubnets[i] = zoneSubnet{zone: zs.Zone, subnet: zs.Subnet}
+	}
+	allErrs = append(allErrs, validateZoneSubnets(platform.Zones, zoneSubnets, fldPath.Child("zoneSubnets"))...)
+	if len(platform.ZoneSubnets) > 0 && len(platform.Subnets) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("zoneSubnets"), "zoneSubnets cannot be used with subnets"))
+	}
 	if platform.InstanceType == "" {
 		allErrs = append(allErrs, field.Required(fldPath.Child("instanceType"), "instance type is required"))
 	}
@@ -351,6 +486,24 @@ func validateAWSMachinePoolPlatformInvariants(platform *hivev1aws.MachinePoolPla
 	if rootVolume.Type == "" {
 		allErrs = append(allErrs, field.Required(rootVolumePath.Child("type"), "volume type is required"))
 	}
+	if platform.OutpostARN != "" {
+		outpostPath := fldPath.Child("outpostARN")
+		if !strings.HasPrefix(platform.OutpostARN, "arn:") || !strings.Contains(platform.OutpostARN, ":outpost/") {
+			allErrs = append(allErrs, field.Invalid(outpostPath, platform.OutpostARN, "must be the ARN of an Outpost"))
+		}
+		if len(platform.Subnets) == 0 && len(platform.ZoneSubnets) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("subnets"), "the subnets of the Outpost are required"))
+		}
+		if rootVolume.Type != "" && rootVolume.Type != "gp2" {
+			allErrs = append(allErrs, field.NotSupported(rootVolumePath.Child("type"), rootVolume.Type, []string{"gp2"}))
+		}
+		if platform.SpotMarketOptions != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("spotMarketOptions"), "spot instances are not supported on Outposts"))
+		}
+	}
+	if platform.GPU != nil {
+		allErrs = append(allErrs, validateGPUCount(platform.GPU.Count, fldPath.Child("gpu", "count"))...)
+	}
 	return allErrs
 }
 
@@ -361,9 +514,29 @@ func validateGCPMachinePoolPlatformInvariants(platform *hivev1gcp.MachinePool, f
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("zones").Index(i), zone, "zone cannot be an empty string"))
 		}
 	}
+	zoneSubnets := make([]zoneSubnet, len(platform.ZoneSubnets))
+	for i, zs := range platform.ZoneSubnets {
+		zoneSubnets[i] = zoneSubnet{zone: zs.Zone, subnet: zs.Subnet}
+	}
+	allErrs = append(allErrs, validateZoneSubnets(platform.Zones, zoneSubnets, fldPath.Child("zoneSubnets"))...)
 	if platform.InstanceType == "" {
 		allErrs = append(allErrs, field.Required(fldPath.Child("instanceType"), "instance type is required"))
 	}
+	if (platform.CustomCPUs == 0) != (platform.CustomMemoryMB == 0) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("customCPUs"), platform.CustomCPUs, "customCPUs and customMemoryMB must be set together"))
+	}
+	if platform.LocalSSDs != nil && platform.OnHostMaintenance == "Migrate" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("onHostMaintenance"), platform.OnHostMaintenance, "instances with local SSDs cannot be migrated"))
+	}
+	if gpu := platform.GPU; gpu != nil {
+		if gpu.Type == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("gpu", "type"), "GPU type is required"))
+		}
+		allErrs = append(allErrs, validateGPUCount(gpu.Count, fldPath.Child("gpu", "count"))...)
+		if platform.OnHostMaintenance == "Migrate" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("onHostMaintenance"), platform.OnHostMaintenance, "instances with GPUs cannot be migrated"))
+		}
+	}
 	return allErrs
 }
 
@@ -374,6 +547,11 @@ func validateAzureMachinePoolPlatformInvariants(platform *hivev1azure.MachinePoo
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("zones").Index(i), zone, "zone cannot be an empty string"))
 		}
 	}
+	zoneSubnets := make([]zoneSubnet, len(platform.ZoneSubnets))
+	for i, zs := range platform.ZoneSubnets {
+		zoneSubnets[i] = zoneSubnet{zone: zs.Zone, subnet: zs.Subnet}
+	}
+	allErrs = append(allErrs, validateZoneSubnets(platform.Zones, zoneSubnets, fldPath.Child("zoneSubnets"))...)
 	if platform.InstanceType == "" {
 		allErrs = append(allErrs, field.Required(fldPath.Child("instanceType"), "instance type is required"))
 	}
@@ -382,6 +560,62 @@ func validateAzureMachinePoolPlatformInvariants(platform *hivev1azure.MachinePoo
 	if osDisk.DiskSizeGB <= 0 {
 		allErrs = append(allErrs, field.Invalid(osDiskPath.Child("iops"), osDisk.DiskSizeGB, "disk size must be positive"))
 	}
+	if des := osDisk.DiskEncryptionSet; des != nil {
+		desPath := osDiskPath.Child("diskEncryptionSet")
+		if des.SubscriptionID == "" {
+			allErrs = append(allErrs, field.Required(desPath.Child("subscriptionId"), "subscription ID is required"))
+		}
+		if des.ResourceGroup == "" {
+			allErrs = append(allErrs, field.Required(desPath.Child("resourceGroup"), "resource group is required"))
+		}
+		if des.Name == "" {
+			allErrs = append(allErrs, field.Required(desPath.Child("name"), "name is required"))
+		}
+	}
+	if platform.GPU != nil {
+		allErrs = append(allErrs, validateGPUCount(platform.GPU.Count, fldPath.Child("gpu", "count"))...)
+	}
+	return allErrs
+}
+
+// validateGPUCount validates the number of GPUs attached to each instance of a machine pool.
+func validateGPUCount(count int32, fldPath *field.Path) field.ErrorList {
+	if count < 1 {
+		return field.ErrorList{field.Invalid(fldPath, count, "GPU count must be positive")}
+	}
+	return nil
+}
+
+// zoneSubnet is a platform-agnostic entry of the zoneSubnets of a machine pool platform.
+type zoneSubnet struct {
+	zone   string
+	subnet string
+}
+
+// validateZoneSubnets validates an explicit mapping of zones to subnets. Each zone may only be mapped once, and each
+// of the zones of the machine pool must be mapped.
+func validateZoneSubnets(zones []string, zoneSubnets []zoneSubnet, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(zoneSubnets) == 0 {
+		return allErrs
+	}
+	mapped := sets.NewString()
+	for i, zs := range zoneSubnets {
+		if zs.zone == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("zone"), "zone is required"))
+		} else if mapped.Has(zs.zone) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("zone"), zs.zone))
+		}
+		if zs.subnet == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("subnet"), "subnet is required"))
+		}
+		mapped.Insert(zs.zone)
+	}
+	for _, zone := range zones {
+		if zone != "" && !mapped.Has(zone) {
+			allErrs = append(allErrs, field.Invalid(fldPath, zone, "no subnet for zone"))
+		}
+	}
 	return allErrs
 }
 
@@ -414,6 +648,44 @@ func validateVSphereMachinePoolPlatformInvariants(platform *hivev1vsphere.Machin
 	return allErrs
 }
 
+func validateNutanixMachinePoolPlatformInvariants(platform *hivev1nutanix.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if platform.NumCPUs <= 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("cpus"), "number of cpus must be positive"))
+	}
+
+	if platform.NumCoresPerSocket < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("coresPerSocket"), platform.NumCoresPerSocket, "number of cores per socket must not be negative"))
+	} else if platform.NumCoresPerSocket > 0 && platform.NumCPUs%platform.NumCoresPerSocket != 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cpus"), platform.NumCPUs, "number of cpus must be a multiple of the number of cores per socket"))
+	}
+
+	if platform.MemoryMiB <= 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("memoryMiB"), "memory must be positive"))
+	}
+
+	if platform.OSDisk.DiskSizeGiB <= 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("osDisk", "diskSizeGiB"), "disk size must be positive"))
+	}
+
+	return allErrs
+}
+
+func validatePowerVSMachinePoolPlatformInvariants(platform *hivev1powervs.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if platform.Processors != "" {
+		if processors, err := strconv.ParseFloat(platform.Processors, 64); err != nil || processors <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("processors"), platform.Processors, "processors must be a positive number"))
+		}
+	}
+
+	if platform.MemoryGiB < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("memoryGiB"), platform.MemoryGiB, "memory must not be negative"))
+	}
+
+	return allErrs
+}
+
 func validateOvirtMachinePoolPlatformInvariants(platform *hivev1ovirt.MachinePool, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	return allErrs
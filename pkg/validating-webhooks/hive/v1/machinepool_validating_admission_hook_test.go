@@ -17,6 +17,8 @@ import (
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	hivev1nutanix "github.com/openshift/hive/apis/hive/v1/nutanix"
+	hivev1powervs "github.com/openshift/hive/apis/hive/v1/powervs"
 	hivev1vsphere "github.com/openshift/hive/apis/hive/v1/vsphere"
 )
 
@@ -348,6 +350,106 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "AWS zone subnets",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.ZoneSubnets = []hivev1aws.ZoneSubnet{
+					{Zone: "test-zone-1", Subnet: "subnet-1"},
+					{Zone: "test-zone-2", Subnet: "subnet-2"},
+				}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "AWS zone without zone subnet",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.Zones = []string{"test-zone-1", "test-zone-2"}
+				pool.Spec.Platform.AWS.ZoneSubnets = []hivev1aws.ZoneSubnet{{Zone: "test-zone-1", Subnet: "subnet-1"}}
+				return pool
+			}(),
+		},
+		{
+			name: "duplicate AWS zone subnet",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.ZoneSubnets = []hivev1aws.ZoneSubnet{
+					{Zone: "test-zone-1", Subnet: "subnet-1"},
+					{Zone: "test-zone-1", Subnet: "subnet-2"},
+				}
+				return pool
+			}(),
+		},
+		{
+			name: "AWS zone subnet without subnet",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.ZoneSubnets = []hivev1aws.ZoneSubnet{{Zone: "test-zone-1"}}
+				return pool
+			}(),
+		},
+		{
+			name: "AWS zone subnets and subnets",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.Subnets = []string{"subnet-1"}
+				pool.Spec.Platform.AWS.ZoneSubnets = []hivev1aws.ZoneSubnet{{Zone: "test-zone-1", Subnet: "subnet-1"}}
+				return pool
+			}(),
+		},
+		{
+			name: "AWS outpost",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.OutpostARN = "arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"
+				pool.Spec.Platform.AWS.Subnets = []string{"subnet-1"}
+				pool.Spec.Platform.AWS.EC2RootVolume.Type = "gp2"
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "invalid AWS outpost ARN",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.OutpostARN = "op-0123456789abcdef0"
+				pool.Spec.Platform.AWS.Subnets = []string{"subnet-1"}
+				pool.Spec.Platform.AWS.EC2RootVolume.Type = "gp2"
+				return pool
+			}(),
+		},
+		{
+			name: "AWS outpost without subnets",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.OutpostARN = "arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"
+				pool.Spec.Platform.AWS.EC2RootVolume.Type = "gp2"
+				return pool
+			}(),
+		},
+		{
+			name: "unsupported AWS outpost volume type",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.OutpostARN = "arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"
+				pool.Spec.Platform.AWS.Subnets = []string{"subnet-1"}
+				pool.Spec.Platform.AWS.EC2RootVolume.Type = "gp3"
+				return pool
+			}(),
+		},
+		{
+			name: "AWS outpost with spot instances",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.OutpostARN = "arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"
+				pool.Spec.Platform.AWS.Subnets = []string{"subnet-1"}
+				pool.Spec.Platform.AWS.EC2RootVolume.Type = "gp2"
+				pool.Spec.Platform.AWS.SpotMarketOptions = &hivev1aws.SpotMarketOptions{}
+				return pool
+			}(),
+		},
 		{
 			name: "missing AWS instance type",
 			provision: func() *hivev1.MachinePool {
@@ -406,6 +508,87 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "GCP zone subnets",
+			provision: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				pool.Spec.Platform.GCP.Zones = []string{"test-zone-1"}
+				pool.Spec.Platform.GCP.ZoneSubnets = []hivev1gcp.ZoneSubnet{{Zone: "test-zone-1", Subnet: "subnet-1"}}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "GCP custom machine type",
+			provision: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				pool.Spec.Platform.GCP.InstanceType = "n2"
+				pool.Spec.Platform.GCP.CustomCPUs = 4
+				pool.Spec.Platform.GCP.CustomMemoryMB = 16384
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "GCP custom machine type without memory",
+			provision: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				pool.Spec.Platform.GCP.CustomCPUs = 4
+				return pool
+			}(),
+		},
+		{
+			name: "GCP local SSDs with migrate on host maintenance",
+			provision: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				pool.Spec.Platform.GCP.LocalSSDs = &hivev1gcp.LocalSSDs{Count: 1}
+				pool.Spec.Platform.GCP.OnHostMaintenance = "Migrate"
+				return pool
+			}(),
+		},
+		{
+			name: "GCP GPU",
+			provision: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				pool.Spec.Platform.GCP.GPU = &hivev1gcp.GPU{Type: "nvidia-tesla-t4", Count: 1}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "GCP GPU without type",
+			provision: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				pool.Spec.Platform.GCP.GPU = &hivev1gcp.GPU{Count: 1}
+				return pool
+			}(),
+		},
+		{
+			name: "GCP GPU with migrate on host maintenance",
+			provision: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				pool.Spec.Platform.GCP.GPU = &hivev1gcp.GPU{Type: "nvidia-tesla-t4", Count: 1}
+				pool.Spec.Platform.GCP.OnHostMaintenance = "Migrate"
+				return pool
+			}(),
+		},
+		{
+			name: "AWS GPU",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.GPU = &hivev1aws.GPU{Type: "nvidia-tesla-t4", Count: 1}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "Azure GPU without count",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.GPU = &hivev1azure.GPU{Type: "nvidia-tesla-v100"}
+				return pool
+			}(),
+		},
 		{
 			name: "missing GCP instance type",
 			provision: func() *hivev1.MachinePool {
@@ -431,6 +614,39 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "Azure zone without zone subnet",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.Zones = []string{"1", "2"}
+				pool.Spec.Platform.Azure.ZoneSubnets = []hivev1azure.ZoneSubnet{{Zone: "1", Subnet: "subnet-1"}}
+				return pool
+			}(),
+		},
+		{
+			name: "Azure disk encryption set",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.OSDisk.DiskEncryptionSet = &hivev1azure.DiskEncryptionSet{
+					SubscriptionID: "test-subscription",
+					ResourceGroup:  "test-resource-group",
+					Name:           "test-disk-encryption-set",
+				}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "Azure disk encryption set without name",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.OSDisk.DiskEncryptionSet = &hivev1azure.DiskEncryptionSet{
+					SubscriptionID: "test-subscription",
+					ResourceGroup:  "test-resource-group",
+				}
+				return pool
+			}(),
+		},
 		{
 			name: "missing Azure instance type",
 			provision: func() *hivev1.MachinePool {
@@ -447,6 +663,80 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "valid Nutanix",
+			provision: func() *hivev1.MachinePool {
+				return testNutanixMachinePool()
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "Nutanix without cores per socket",
+			provision: func() *hivev1.MachinePool {
+				pool := testNutanixMachinePool()
+				pool.Spec.Platform.Nutanix.NumCoresPerSocket = 0
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "Nutanix cpus not a multiple of cores per socket",
+			provision: func() *hivev1.MachinePool {
+				pool := testNutanixMachinePool()
+				pool.Spec.Platform.Nutanix.NumCPUs = 3
+				return pool
+			}(),
+		},
+		{
+			name: "missing Nutanix memory",
+			provision: func() *hivev1.MachinePool {
+				pool := testNutanixMachinePool()
+				pool.Spec.Platform.Nutanix.MemoryMiB = 0
+				return pool
+			}(),
+		},
+		{
+			name: "invalid Nutanix disk size",
+			provision: func() *hivev1.MachinePool {
+				pool := testNutanixMachinePool()
+				pool.Spec.Platform.Nutanix.OSDisk.DiskSizeGiB = 0
+				return pool
+			}(),
+		},
+		{
+			name: "valid PowerVS",
+			provision: func() *hivev1.MachinePool {
+				return testPowerVSMachinePool()
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "PowerVS without resources",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Platform = hivev1.MachinePoolPlatform{
+					PowerVS: &hivev1powervs.MachinePool{},
+				}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "invalid PowerVS processors",
+			provision: func() *hivev1.MachinePool {
+				pool := testPowerVSMachinePool()
+				pool.Spec.Platform.PowerVS.Processors = "0"
+				return pool
+			}(),
+		},
+		{
+			name: "invalid PowerVS memory",
+			provision: func() *hivev1.MachinePool {
+				pool := testPowerVSMachinePool()
+				pool.Spec.Platform.PowerVS.MemoryGiB = -1
+				return pool
+			}(),
+		},
 		{
 			name: "valid labels",
 			provision: func() *hivev1.MachinePool {
@@ -592,6 +882,114 @@ func Test_MachinePoolAdmission_Validate_Update(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "AWS zone added",
+			old: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Platform.AWS.Zones = []string{"zone1", "zone2"}
+				return pool
+			}(),
+			new: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Platform.AWS.Zones = []string{"zone1", "zone2", "zone3"}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "AWS zone subnet added",
+			old: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Platform.AWS.ZoneSubnets = []hivev1aws.ZoneSubnet{{Zone: "zone1", Subnet: "subnet-1"}}
+				return pool
+			}(),
+			new: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Platform.AWS.ZoneSubnets = []hivev1aws.ZoneSubnet{
+					{Zone: "zone1", Subnet: "subnet-1"},
+					{Zone: "zone2", Subnet: "subnet-2"},
+				}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "AWS zone removed",
+			old: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Platform.AWS.Zones = []string{"zone1", "zone2"}
+				return pool
+			}(),
+			new: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Platform.AWS.Zones = []string{"zone1"}
+				return pool
+			}(),
+		},
+		{
+			name: "AWS zone subnet changed",
+			old: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Platform.AWS.ZoneSubnets = []hivev1aws.ZoneSubnet{{Zone: "zone1", Subnet: "subnet-1"}}
+				return pool
+			}(),
+			new: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Platform.AWS.ZoneSubnets = []hivev1aws.ZoneSubnet{{Zone: "zone1", Subnet: "subnet-2"}}
+				return pool
+			}(),
+		},
+		{
+			name: "AWS zone added to pool using all zones",
+			old:  testMachinePool(),
+			new: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Platform.AWS.Zones = []string{"zone1"}
+				return pool
+			}(),
+		},
+		{
+			name: "AWS zone added and instance type changed",
+			old: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Platform.AWS.Zones = []string{"zone1"}
+				return pool
+			}(),
+			new: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Platform.AWS.Zones = []string{"zone1", "zone2"}
+				pool.Spec.Platform.AWS.InstanceType = "other-instance-type"
+				return pool
+			}(),
+		},
+		{
+			name: "GCP zone added",
+			old: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				pool.Spec.Platform.GCP.Zones = []string{"zone1"}
+				return pool
+			}(),
+			new: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				pool.Spec.Platform.GCP.Zones = []string{"zone1", "zone2"}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "Azure zone added",
+			old: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.Zones = []string{"1"}
+				return pool
+			}(),
+			new: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.Zones = []string{"1", "2"}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -671,6 +1069,34 @@ func testvSphereMachinePool() *hivev1.MachinePool {
 	return pool
 }
 
+func testNutanixMachinePool() *hivev1.MachinePool {
+	pool := testMachinePool()
+	pool.Spec.Platform = hivev1.MachinePoolPlatform{
+		Nutanix: &hivev1nutanix.MachinePool{
+			NumCPUs:           4,
+			NumCoresPerSocket: 2,
+			MemoryMiB:         16 * 1024,
+			OSDisk: hivev1nutanix.OSDisk{
+				DiskSizeGiB: 120,
+			},
+		},
+	}
+	return pool
+}
+
+func testPowerVSMachinePool() *hivev1.MachinePool {
+	pool := testMachinePool()
+	pool.Spec.Platform = hivev1.MachinePoolPlatform{
+		PowerVS: &hivev1powervs.MachinePool{
+			ProcType:   "Shared",
+			Processors: "0.5",
+			MemoryGiB:  32,
+			SysType:    "s922",
+		},
+	}
+	return pool
+}
+
 func validAWSMachinePoolPlatform() *hivev1aws.MachinePoolPlatform {
 	return &hivev1aws.MachinePoolPlatform{
 		InstanceType: "test-instance-type",
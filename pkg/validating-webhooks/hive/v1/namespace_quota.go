@@ -0,0 +1,86 @@
+package v1
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// newQuotaClient creates the client used to count the clusters and machines in namespaces with quotas.
+func newQuotaClient(kubeClientConfig *rest.Config) (client.Client, error) {
+	scheme := runtime.NewScheme()
+	if err := hivev1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	return client.New(kubeClientConfig, client.Options{Scheme: scheme})
+}
+
+// validateClusterQuota denies the creation of a ClusterDeployment in a namespace that is at its cluster quota.
+func validateClusterQuota(c client.Client, quotas []hivev1.NamespaceQuota, namespace, name string, logger log.FieldLogger) *admissionv1beta1.AdmissionResponse {
+	quota := controllerutils.FindNamespaceQuota(quotas, namespace)
+	if quota == nil || quota.MaxClusters == nil {
+		return nil
+	}
+	count, err := controllerutils.NamespaceClusterCount(c, namespace)
+	if err != nil {
+		logger.WithError(err).Error("could not count clusters for namespace quota")
+		return quotaErrorResponse(err)
+	}
+	if count+1 > int(*quota.MaxClusters) {
+		message := fmt.Sprintf("namespace %s is at its quota of %d clusters", namespace, *quota.MaxClusters)
+		logger.WithField("clusters", count).Info(message)
+		return quotaExceededResponse(hivev1.Resource("clusterdeployments"), name, message)
+	}
+	return nil
+}
+
+// validateMachineQuota denies the creation or update of a MachinePool that would take its namespace over its
+// machine quota. Updates that do not add machines are always allowed, so that a namespace over its quota can scale
+// down.
+func validateMachineQuota(c client.Client, quotas []hivev1.NamespaceQuota, namespace string, old, new *hivev1.MachinePool, logger log.FieldLogger) *admissionv1beta1.AdmissionResponse {
+	quota := controllerutils.FindNamespaceQuota(quotas, namespace)
+	if quota == nil || quota.MaxMachines == nil {
+		return nil
+	}
+	machines := controllerutils.MachinePoolMachineCount(new)
+	if old != nil && machines <= controllerutils.MachinePoolMachineCount(old) {
+		return nil
+	}
+	count, err := controllerutils.NamespaceMachineCount(c, namespace, new.Name)
+	if err != nil {
+		logger.WithError(err).Error("could not count machines for namespace quota")
+		return quotaErrorResponse(err)
+	}
+	if count+machines > int(*quota.MaxMachines) {
+		message := fmt.Sprintf("namespace %s would have %d machines, exceeding its quota of %d machines", namespace, count+machines, *quota.MaxMachines)
+		logger.WithField("machines", count).Info(message)
+		return quotaExceededResponse(hivev1.Resource("machinepools"), new.Name, message)
+	}
+	return nil
+}
+
+func quotaExceededResponse(resource schema.GroupResource, name, message string) *admissionv1beta1.AdmissionResponse {
+	status := errors.NewForbidden(resource, name, fmt.Errorf("%s", message)).Status()
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: false,
+		Result:  &status,
+	}
+}
+
+func quotaErrorResponse(err error) *admissionv1beta1.AdmissionResponse {
+	status := errors.NewInternalError(fmt.Errorf("could not check namespace quota: %w", err)).Status()
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: false,
+		Result:  &status,
+	}
+}
@@ -0,0 +1,191 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const quotaTestNamespace = "tenant"
+
+func TestClusterDeploymentNamespaceQuota(t *testing.T) {
+	existingCD := func(name string) *hivev1.ClusterDeployment {
+		cd := validAWSClusterDeployment()
+		cd.Name = name
+		cd.Namespace = quotaTestNamespace
+		return cd
+	}
+	assignedClaim := &hivev1.ClusterClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: quotaTestNamespace, Name: "claim"},
+		Spec:       hivev1.ClusterClaimSpec{ClusterPoolName: "pool", Namespace: "pool-cluster"},
+	}
+	cases := []struct {
+		name            string
+		quotas          []hivev1.NamespaceQuota
+		existing        []runtime.Object
+		expectedAllowed bool
+	}{
+		{
+			name:            "no quota",
+			existing:        []runtime.Object{existingCD("cd1"), existingCD("cd2")},
+			expectedAllowed: true,
+		},
+		{
+			name:            "quota for other namespace",
+			quotas:          []hivev1.NamespaceQuota{{Namespace: "other", MaxClusters: pointer.Int32Ptr(1)}},
+			existing:        []runtime.Object{existingCD("cd1"), existingCD("cd2")},
+			expectedAllowed: true,
+		},
+		{
+			name:            "under quota",
+			quotas:          []hivev1.NamespaceQuota{{Namespace: quotaTestNamespace, MaxClusters: pointer.Int32Ptr(3)}},
+			existing:        []runtime.Object{existingCD("cd1"), existingCD("cd2")},
+			expectedAllowed: true,
+		},
+		{
+			name:     "at quota",
+			quotas:   []hivev1.NamespaceQuota{{Namespace: quotaTestNamespace, MaxClusters: pointer.Int32Ptr(2)}},
+			existing: []runtime.Object{existingCD("cd1"), existingCD("cd2")},
+		},
+		{
+			name:     "assigned claims count against quota",
+			quotas:   []hivev1.NamespaceQuota{{Namespace: quotaTestNamespace, MaxClusters: pointer.Int32Ptr(2)}},
+			existing: []runtime.Object{existingCD("cd1"), assignedClaim},
+		},
+		{
+			name:   "deleting clusters do not count against quota",
+			quotas: []hivev1.NamespaceQuota{{Namespace: quotaTestNamespace, MaxClusters: pointer.Int32Ptr(2)}},
+			existing: []runtime.Object{existingCD("cd1"), func() runtime.Object {
+				cd := existingCD("cd2")
+				now := metav1.Now()
+				cd.DeletionTimestamp = &now
+				return cd
+			}()},
+			expectedAllowed: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			hivev1.AddToScheme(scheme)
+			hook := ClusterDeploymentValidatingAdmissionHook{
+				decoder:         createDecoder(t),
+				fs:              newFeatureSet(),
+				namespaceQuotas: tc.quotas,
+				client:          fake.NewFakeClientWithScheme(scheme, tc.existing...),
+			}
+			cd := validAWSClusterDeployment()
+			cd.Namespace = quotaTestNamespace
+			raw, _ := json.Marshal(cd)
+			response := hook.Validate(&admissionv1beta1.AdmissionRequest{
+				Operation: admissionv1beta1.Create,
+				Namespace: quotaTestNamespace,
+				Resource:  metav1.GroupVersionResource{Group: "hive.openshift.io", Version: "v1", Resource: "clusterdeployments"},
+				Object:    runtime.RawExtension{Raw: raw},
+			})
+			if !assert.Equal(t, tc.expectedAllowed, response.Allowed, "unexpected response") {
+				t.Logf("Response result = %#v", response.Result)
+			}
+			if !tc.expectedAllowed {
+				assert.Equal(t, int32(http.StatusForbidden), response.Result.Code, "unexpected response code")
+			}
+		})
+	}
+}
+
+func TestMachinePoolNamespaceQuota(t *testing.T) {
+	machinePool := func(name string, replicas int64) *hivev1.MachinePool {
+		pool := testMachinePool()
+		pool.Namespace = quotaTestNamespace
+		pool.Name = "test-deployment-" + name
+		pool.Spec.Name = name
+		pool.Spec.Replicas = pointer.Int64Ptr(replicas)
+		return pool
+	}
+	autoscalingMachinePool := func(name string, max int32) *hivev1.MachinePool {
+		pool := machinePool(name, 0)
+		pool.Spec.Replicas = nil
+		pool.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{MinReplicas: 1, MaxReplicas: max}
+		return pool
+	}
+	quotas := []hivev1.NamespaceQuota{{Namespace: quotaTestNamespace, MaxMachines: pointer.Int32Ptr(10)}}
+	cases := []struct {
+		name            string
+		existing        []runtime.Object
+		oldObject       *hivev1.MachinePool
+		newObject       *hivev1.MachinePool
+		expectedAllowed bool
+	}{
+		{
+			name:            "create under quota",
+			existing:        []runtime.Object{machinePool("a", 3)},
+			newObject:       machinePool("worker", 7),
+			expectedAllowed: true,
+		},
+		{
+			name:      "create over quota",
+			existing:  []runtime.Object{machinePool("a", 3)},
+			newObject: machinePool("worker", 8),
+		},
+		{
+			name:      "autoscaling counts max replicas",
+			existing:  []runtime.Object{autoscalingMachinePool("a", 6)},
+			newObject: machinePool("worker", 5),
+		},
+		{
+			name:            "scale up under quota",
+			existing:        []runtime.Object{machinePool("a", 3), machinePool("worker", 3)},
+			oldObject:       machinePool("worker", 3),
+			newObject:       machinePool("worker", 7),
+			expectedAllowed: true,
+		},
+		{
+			name:      "scale up over quota",
+			existing:  []runtime.Object{machinePool("a", 3), machinePool("worker", 3)},
+			oldObject: machinePool("worker", 3),
+			newObject: machinePool("worker", 8),
+		},
+		{
+			name:            "scale down over quota",
+			existing:        []runtime.Object{machinePool("a", 12), machinePool("worker", 3)},
+			oldObject:       machinePool("worker", 3),
+			newObject:       machinePool("worker", 2),
+			expectedAllowed: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			hivev1.AddToScheme(scheme)
+			hook := MachinePoolValidatingAdmissionHook{
+				decoder:         createDecoder(t),
+				namespaceQuotas: quotas,
+				client:          fake.NewFakeClientWithScheme(scheme, tc.existing...),
+			}
+			request := &admissionv1beta1.AdmissionRequest{
+				Operation: admissionv1beta1.Create,
+				Namespace: quotaTestNamespace,
+				Resource:  metav1.GroupVersionResource{Group: machinePoolGroup, Version: machinePoolVersion, Resource: machinePoolResource},
+			}
+			request.Object.Raw, _ = json.Marshal(tc.newObject)
+			if tc.oldObject != nil {
+				request.Operation = admissionv1beta1.Update
+				request.OldObject.Raw, _ = json.Marshal(tc.oldObject)
+			}
+			response := hook.Validate(request)
+			if !assert.Equal(t, tc.expectedAllowed, response.Allowed, "unexpected response") {
+				t.Logf("Response result = %#v", response.Result)
+			}
+		})
+	}
+}
@@ -12,11 +12,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	secretutils "github.com/openshift/hive/pkg/util/secrets"
 )
 
 const (
@@ -310,6 +312,37 @@ func validateSecrets(secrets []hivev1.SecretMapping, fldPath *field.Path) field.
 	for i, secret := range secrets {
 		allErrs = append(allErrs, validateSecretRef(secret.SourceRef, fldPath.Index(i).Child("sourceRef"))...)
 		allErrs = append(allErrs, validateSecretRef(secret.TargetRef, fldPath.Index(i).Child("targetRef"))...)
+		allErrs = append(allErrs, validateSecretTransform(secret, fldPath.Index(i))...)
+	}
+	return allErrs
+}
+
+func validateSecretTransform(secret hivev1.SecretMapping, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	targetKeys := sets.NewString()
+	for i, key := range secret.Keys {
+		keyPath := fldPath.Child("keys").Index(i)
+		if key.SourceKey == "" {
+			allErrs = append(allErrs, field.Required(keyPath.Child("sourceKey"), "source key is required"))
+			continue
+		}
+		targetKey := key.TargetKey
+		if targetKey == "" {
+			targetKey = key.SourceKey
+		}
+		if targetKeys.Has(targetKey) {
+			allErrs = append(allErrs, field.Duplicate(keyPath.Child("targetKey"), targetKey))
+		}
+		targetKeys.Insert(targetKey)
+	}
+	for i, keyTemplate := range secret.Templates {
+		templatePath := fldPath.Child("templates").Index(i)
+		if keyTemplate.Key == "" {
+			allErrs = append(allErrs, field.Required(templatePath.Child("key"), "key is required"))
+		}
+		if _, err := secretutils.ParseTemplate(keyTemplate); err != nil {
+			allErrs = append(allErrs, field.Invalid(templatePath.Child("template"), keyTemplate.Template, err.Error()))
+		}
 	}
 	return allErrs
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -162,6 +163,44 @@ func TestSyncSetValidate(t *testing.T) {
 			}(),
 			expectedAllowed: false,
 		},
+		{
+			name:      "Test valid SecretReference transform create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSecretReferenceSyncSet()
+				ss.Spec.Secrets[0].Keys = []hivev1.SecretKeyMapping{{SourceKey: "ca.crt", TargetKey: "ca-bundle.crt"}}
+				ss.Spec.Secrets[0].Templates = []hivev1.SecretKeyTemplate{{
+					Key:      ".dockerconfigjson",
+					Template: `{"auths":{"{{ .registry }}":{"auth":"{{ printf "%s:%s" .username .password | b64enc }}"}}}`,
+				}}
+				ss.Spec.Secrets[0].Type = corev1.SecretTypeDockerConfigJson
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid SecretReference duplicate target key create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSecretReferenceSyncSet()
+				ss.Spec.Secrets[0].Keys = []hivev1.SecretKeyMapping{
+					{SourceKey: "a", TargetKey: "b"},
+					{SourceKey: "b"},
+				}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid SecretReference template create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSecretReferenceSyncSet()
+				ss.Spec.Secrets[0].Templates = []hivev1.SecretKeyTemplate{{Key: "config", Template: "{{ .username "}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
 		{
 			name:      "Test valid empty string resourceApplyMode create",
 			operation: admissionv1beta1.Create,